@@ -0,0 +1,132 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// aliasStore maps alias names to the physical indexes they refer to.
+// Queries against an alias are executed against its current index, so an
+// alias can be switched to a freshly built index without clients changing
+// the name they query. The mapping is small, so it's kept in memory and
+// persisted as a JSON file under the holder's path.
+type aliasStore struct {
+	mu      sync.RWMutex
+	path    string
+	aliases map[string]string
+}
+
+func newAliasStore(path string) *aliasStore {
+	return &aliasStore{path: path, aliases: make(map[string]string)}
+}
+
+// load reads the persisted aliases, if any.
+func (s *aliasStore) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	buf, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		s.aliases = make(map[string]string)
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading aliases")
+	}
+	aliases := make(map[string]string)
+	if err := json.Unmarshal(buf, &aliases); err != nil {
+		return errors.Wrap(err, "decoding aliases")
+	}
+	s.aliases = aliases
+	return nil
+}
+
+// save persists the aliases. The file is replaced atomically so that a
+// crash can't leave a partially written mapping behind. The caller must
+// hold the write lock.
+func (s *aliasStore) save() error {
+	buf, err := json.Marshal(s.aliases)
+	if err != nil {
+		return errors.Wrap(err, "encoding aliases")
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0600); err != nil {
+		return errors.Wrap(err, "writing aliases")
+	}
+	return errors.Wrap(os.Rename(tmp, s.path), "renaming aliases")
+}
+
+// Set points alias at index. An empty index removes the alias.
+func (s *aliasStore) Set(alias, index string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.aliases[alias]
+	if index == "" {
+		delete(s.aliases, alias)
+	} else {
+		s.aliases[alias] = index
+	}
+	if err := s.save(); err != nil {
+		// Keep the in-memory mapping consistent with what's on disk.
+		if ok {
+			s.aliases[alias] = prev
+		} else {
+			delete(s.aliases, alias)
+		}
+		return err
+	}
+	return nil
+}
+
+// Get returns the index alias refers to.
+func (s *aliasStore) Get(alias string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	index, ok := s.aliases[alias]
+	return index, ok
+}
+
+// Resolve returns the index name refers to if name is an alias, and name
+// otherwise.
+func (s *aliasStore) Resolve(name string) string {
+	if index, ok := s.Get(name); ok {
+		return index
+	}
+	return name
+}
+
+// All returns a copy of the alias mapping.
+func (s *aliasStore) All() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	aliases := make(map[string]string, len(s.aliases))
+	for alias, index := range s.aliases {
+		aliases[alias] = index
+	}
+	return aliases
+}
+
+// Targeting returns the sorted aliases which refer to index.
+func (s *aliasStore) Targeting(index string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var aliases []string
+	for alias, target := range s.aliases {
+		if target == index {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// SetAliasMessage is an internal message pointing an alias at an index,
+// or, with an empty Index, removing the alias.
+type SetAliasMessage struct {
+	Alias string
+	Index string
+}
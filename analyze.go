@@ -0,0 +1,297 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// POST /index/{index}/analyze parses a query without executing it, and
+// checks it against the index's schema for calls which would fail, or which
+// may not do what was meant, so editors and CI can catch them before the
+// query is run. A query which doesn't parse is a bad request; anything else
+// found is returned as warnings, each naming the top-level call it was found
+// in, counting from zero.
+const (
+	// WarningUnknownIndex is a call against another index which doesn't
+	// exist.
+	WarningUnknownIndex = "unknown-index"
+
+	// WarningUnknownField is a call naming a field which doesn't exist.
+	WarningUnknownField = "unknown-field"
+
+	// WarningTypeMismatch is a value which doesn't suit the field it's
+	// given for, such as an integer row of a keyed field.
+	WarningTypeMismatch = "type-mismatch"
+
+	// WarningDeprecated is a call which is deprecated.
+	WarningDeprecated = "deprecated"
+
+	// WarningUnboundedExtract is an Extract without a limit, which returns
+	// every column matching its filter.
+	WarningUnboundedExtract = "unbounded-extract"
+
+	// WarningNeedsExistence is a call which needs existence tracking, in an
+	// index which doesn't track existence.
+	WarningNeedsExistence = "needs-existence"
+)
+
+// QueryWarning is something AnalyzeQuery found in a query.
+type QueryWarning struct {
+	Call    int    `json:"call"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// QueryAnalysis is what AnalyzeQuery found in a query.
+type QueryAnalysis struct {
+	Calls    int            `json:"calls"`
+	Warnings []QueryWarning `json:"warnings"`
+}
+
+// AnalyzeQuery parses a query against an index without executing it, and
+// returns warnings about calls which would fail or may not do what was
+// meant.
+func (api *API) AnalyzeQuery(ctx context.Context, indexName, query string) (*QueryAnalysis, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.AnalyzeQuery")
+	defer span.Finish()
+
+	if err := api.validate(apiAnalyzeQuery); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	idx := api.holder.Index(indexName)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	q, err := pql.NewParser(strings.NewReader(query)).Parse()
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "parsing"))
+	}
+
+	a := &queryAnalyzer{
+		holder:            api.holder,
+		existenceFallback: api.server.executor.existenceFallback,
+		warnings:          []QueryWarning{},
+	}
+	for i, c := range q.Calls {
+		a.call = i
+		if api.server.rewriteLegacyCalls {
+			one := &pql.Query{Calls: []*pql.Call{c}}
+			var names []string
+			for name := range one.RewriteLegacy() {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				a.warn(WarningDeprecated, "%s is deprecated, and is rewritten to %s", name, pql.LegacyReplacement(name))
+			}
+			c = one.Calls[0]
+		}
+		a.analyze(idx, c)
+	}
+	return &QueryAnalysis{Calls: len(q.Calls), Warnings: a.warnings}, nil
+}
+
+// queryAnalyzer collects the warnings about a query's calls.
+type queryAnalyzer struct {
+	holder            *Holder
+	existenceFallback string
+
+	call     int // the top-level call being analyzed
+	warnings []QueryWarning
+}
+
+func (a *queryAnalyzer) warn(code, format string, args ...interface{}) {
+	a.warnings = append(a.warnings, QueryWarning{Call: a.call, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// analyze checks c, and its children, against idx.
+func (a *queryAnalyzer) analyze(idx *Index, c *pql.Call) {
+	if name := c.CallIndex(); name != "" {
+		if idx = a.holder.Index(name); idx == nil {
+			a.warn(WarningUnknownIndex, "%s: index %q does not exist", c.Name, name)
+			return
+		}
+	}
+
+	if replacement := pql.LegacyReplacement(c.Name); replacement != "" {
+		a.warn(WarningDeprecated, "%s is deprecated; use %s", c.Name, replacement)
+	}
+	if c.Name == "Extract" {
+		a.analyzeExtract(c)
+	}
+	if idx.existenceField() == nil {
+		switch c.Name {
+		case "Not", "All":
+			a.warnExistence(idx, c.Name)
+		case "Row", "CardinalityOf":
+			if usesExistence([]*pql.Call{{Name: c.Name, Args: c.Args}}) {
+				a.warnExistence(idx, c.Name+" matching null")
+			}
+		}
+	}
+
+	switch c.Name {
+	case "Set", "Clear", "Row", "Range", "ClearRow", "Store":
+		for key, val := range c.Args {
+			if !pql.IsReservedArg(key) {
+				a.analyzeValue(idx, c.Name, key, val)
+			}
+		}
+		if col, ok := c.Args["_col"]; ok {
+			a.analyzeColumn(idx, c.Name, col)
+		}
+	default:
+		for _, key := range []string{"_field", "field"} {
+			if name, ok := c.Args[key].(string); ok {
+				a.field(idx, c.Name, name)
+			}
+		}
+	}
+
+	for _, child := range c.Children {
+		a.analyze(idx, child)
+	}
+	for _, arg := range c.Args {
+		if child, ok := arg.(*pql.Call); ok {
+			a.analyze(idx, child)
+		}
+	}
+}
+
+// field returns the field of idx a call names, warning if it doesn't exist.
+func (a *queryAnalyzer) field(idx *Index, call, name string) *Field {
+	f := idx.Field(name)
+	if f == nil {
+		a.warn(WarningUnknownField, "%s: field %q does not exist in index %q", call, name, idx.Name())
+	}
+	return f
+}
+
+// analyzeValue checks that val suits the field of idx named name.
+func (a *queryAnalyzer) analyzeValue(idx *Index, call, name string, val interface{}) {
+	f := a.field(idx, call, name)
+	if f == nil {
+		return
+	}
+	a.analyzeFieldValue(f, call, val)
+}
+
+// analyzeFieldValue checks that val suits f.
+func (a *queryAnalyzer) analyzeFieldValue(f *Field, call string, val interface{}) {
+	if cond, ok := val.(*pql.Condition); ok {
+		val = cond.Value
+	}
+	switch val := val.(type) {
+	case nil:
+		return
+	case []interface{}:
+		for _, v := range val {
+			a.analyzeFieldValue(f, call, v)
+		}
+		return
+	}
+
+	var want string
+	switch f.Type() {
+	case FieldTypeSet, FieldTypeMutex, FieldTypeTime:
+		switch val.(type) {
+		case string:
+			if !f.Keys() {
+				want = "an integer row, as it isn't keyed"
+			}
+		case int64, uint64:
+			if f.Keys() {
+				want = "a string row, as it's keyed"
+			}
+		default:
+			want = "a row"
+		}
+	case FieldTypeBool:
+		if _, ok := val.(bool); !ok {
+			want = "true or false"
+		}
+	case FieldTypeInt:
+		switch val.(type) {
+		case int64, uint64:
+		case string:
+			if fi := a.holder.Index(f.ForeignIndex()); fi == nil || !fi.Keys() {
+				want = "an integer"
+			}
+		default:
+			want = "an integer"
+		}
+	case FieldTypeDecimal:
+		switch val.(type) {
+		case int64, uint64, pql.Decimal, float64:
+		default:
+			want = "a number"
+		}
+	}
+	if want != "" {
+		a.warn(WarningTypeMismatch, "%s: %s field %q takes %s, not %s", call, f.Type(), f.Name(), want, describeValue(val))
+	}
+}
+
+// analyzeColumn checks that a column suits idx.
+func (a *queryAnalyzer) analyzeColumn(idx *Index, call string, col interface{}) {
+	switch col.(type) {
+	case string:
+		if !idx.Keys() {
+			a.warn(WarningTypeMismatch, "%s: index %q takes integer columns, as it isn't keyed, not %s", call, idx.Name(), describeValue(col))
+		}
+	case int64, uint64:
+		if idx.Keys() {
+			a.warn(WarningTypeMismatch, "%s: index %q takes string columns, as it's keyed, not %s", call, idx.Name(), describeValue(col))
+		}
+	}
+}
+
+// analyzeExtract warns if an Extract has no limit.
+func (a *queryAnalyzer) analyzeExtract(c *pql.Call) {
+	if _, ok := c.Args["limit"]; ok {
+		return
+	}
+	if len(c.Children) > 0 {
+		switch filter := c.Children[0]; filter.Name {
+		case "Limit", "Sort":
+			if _, ok := filter.Args["limit"]; ok {
+				return
+			}
+		}
+	}
+	a.warn(WarningUnboundedExtract, "Extract has no limit, so returns every column its filter matches")
+}
+
+// warnExistence warns that what needs existence tracking, which idx doesn't
+// do.
+func (a *queryAnalyzer) warnExistence(idx *Index, what string) {
+	if a.existenceFallback == ExistenceFallbackNone {
+		a.warn(WarningNeedsExistence, "%s needs existence tracking, which index %q doesn't do, so fails", what, idx.Name())
+		return
+	}
+	a.warn(WarningNeedsExistence, "%s needs existence tracking, which index %q doesn't do, so uses the %q existence fallback", what, idx.Name(), a.existenceFallback)
+}
+
+// describeValue describes the type of a value of a query.
+func describeValue(val interface{}) string {
+	switch val := val.(type) {
+	case string:
+		return fmt.Sprintf("string %q", val)
+	case int64, uint64:
+		return fmt.Sprintf("integer %d", val)
+	case bool:
+		return fmt.Sprintf("%t", val)
+	case pql.Decimal:
+		return "decimal " + val.String()
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
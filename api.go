@@ -10,11 +10,13 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
 	"net/url"
 	"os"
+	"reflect"
 	"runtime"
 	"sort"
 	"strconv"
@@ -51,6 +53,13 @@ type API struct {
 	importWorkerPoolSize int
 	importWork           chan importJob
 
+	reindexes      reindexes
+	importSessions importSessions
+	clientSessions clientSessions
+
+	// optimizing is held while storage is optimized.
+	optimizing sync.Mutex
+
 	Serializer Serializer
 }
 
@@ -175,7 +184,7 @@ func (api *API) Txf() *TxFactory {
 }
 
 // Query parses a PQL query out of the request and executes it.
-func (api *API) Query(ctx context.Context, req *QueryRequest) (QueryResponse, error) {
+func (api *API) Query(ctx context.Context, req *QueryRequest) (_ QueryResponse, err0 error) {
 	start := time.Now()
 	span, ctx := tracing.StartSpanFromContext(ctx, "API.Query")
 	defer span.Finish()
@@ -185,7 +194,30 @@ func (api *API) Query(ctx context.Context, req *QueryRequest) (QueryResponse, er
 	}
 
 	if !req.Remote {
+		if timeout := api.server.currentSettings().QueryTimeout; timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		// Remote requests were already resolved by the coordinating node,
+		// so every node executes against the same physical index even if
+		// the alias is swapped mid-query.
+		indexes, err := api.resolveQueryIndexes(req.Index)
+		if err != nil {
+			return QueryResponse{}, err
+		}
+		if err := api.holder.checkNamespaceQuery(indexes); err != nil {
+			return QueryResponse{}, err
+		}
+		if err := api.checkQueryPolicy(ctx, req.Query, indexes); err != nil {
+			return QueryResponse{}, err
+		}
 		defer api.tracker.Finish(api.tracker.Start(req.Query, req.SQLQuery, api.server.nodeID, req.Index, start))
+		defer func(index string) { api.captureQuery(index, req, start, err0) }(req.Index)
+		if len(indexes) > 1 {
+			return api.queryIndexes(ctx, req, indexes)
+		}
+		req.Index = indexes[0]
 	}
 
 	return api.query(ctx, req)
@@ -195,9 +227,33 @@ func (api *API) Query(ctx context.Context, req *QueryRequest) (QueryResponse, er
 func (api *API) query(ctx context.Context, req *QueryRequest) (QueryResponse, error) {
 	q, err := pql.NewParser(strings.NewReader(req.Query)).Parse()
 	if err != nil {
-		return QueryResponse{}, errors.Wrap(err, "parsing")
+		return QueryResponse{}, NewBadRequestError(errors.Wrap(err, "parsing"))
 	}
+	if !req.Remote {
+		api.rewriteLegacyCalls(req.Index, q)
+	}
+	return api.executeQuery(ctx, req, q)
+}
+
+// rewriteLegacyCalls rewrites the legacy calls in a query against index to
+// the calls which replaced them, unless the server is configured not to,
+// logging and counting each kind of call rewritten.
+func (api *API) rewriteLegacyCalls(index string, q *pql.Query) {
+	if !api.server.rewriteLegacyCalls {
+		return
+	}
+	counts := q.RewriteLegacy()
+	for name, n := range counts {
+		api.server.logger.Infof("DEPRECATED: rewrote %d %s() calls against index %s to %s()", n, name, index, pql.LegacyReplacement(name))
+		api.holder.Stats.WithTags("call:"+name).Count(MetricLegacyCallsRewritten, int64(n), 1.0)
+	}
+}
 
+// executeQuery executes an already parsed query.
+func (api *API) executeQuery(ctx context.Context, req *QueryRequest, q *pql.Query) (QueryResponse, error) {
+	if req.ChunkWrites && !req.Remote && q.WriteCallN() > 0 {
+		return api.executeChunkedWrites(ctx, req, q)
+	}
 	// TODO can we get rid of exec options and pass the QueryRequest directly to executor?
 	execOpts := &ExecOptions{
 		Remote:        req.Remote,
@@ -205,11 +261,24 @@ func (api *API) query(ctx context.Context, req *QueryRequest) (QueryResponse, er
 		PreTranslated: req.PreTranslated,
 		EmbeddedData:  req.EmbeddedData, // precomputed values that needed to be passed with the request
 		MaxMemory:     req.MaxMemory,
+
+		IgnoreResultLimits: req.IgnoreResultLimits,
+		IncludeRowMeta:     req.IncludeRowMeta,
+		ExistenceFallback:  req.ExistenceFallback,
+		Priority:           req.Priority,
+		AllowPartial:       req.AllowPartial,
+		ExecPath:           req.ExecPath,
+		Client:             req.Client,
+		RejectNewKeys:      req.RejectNewKeys,
+		Hints:              req.Hints,
 	}
 	resp, err := api.server.executor.Execute(ctx, req.Index, q, req.Shards, execOpts)
 	if err != nil {
 		return QueryResponse{}, errors.Wrap(err, "executing")
 	}
+	if !req.Remote && req.ExecPath == ExecPathCurrent && !req.Profile && resp.Err == nil && q.WriteCallN() == 0 {
+		api.server.canary.check(req, resp, api.query)
+	}
 
 	// Check for an error embedded in the response.
 	if resp.Err != nil {
@@ -228,6 +297,13 @@ func (api *API) CreateIndex(ctx context.Context, indexName string, options Index
 		return nil, errors.Wrap(err, "validating api method")
 	}
 
+	if _, ok := api.holder.aliases.Get(indexName); ok {
+		return nil, newConflictError(ErrAliasExists)
+	}
+	if ns := options.Namespace; ns != "" && !nameRegexp.MatchString(ns) {
+		return nil, NewBadRequestError(errors.Errorf("invalid namespace %q, must match [a-z][a-z0-9_-]* and contain at most 230 characters", ns))
+	}
+
 	// Populate the create index message.
 	cim := &CreateIndexMessage{
 		Index:     indexName,
@@ -242,6 +318,7 @@ func (api *API) CreateIndex(ctx context.Context, indexName string, options Index
 	}
 
 	api.holder.Stats.Count(MetricCreateIndex, 1, 1.0)
+	api.publishEvent(Event{Type: EventIndexCreated, Index: indexName})
 	return index, nil
 }
 
@@ -261,6 +338,90 @@ func (api *API) Index(ctx context.Context, indexName string) (*Index, error) {
 	return index, nil
 }
 
+// CreateAlias creates an alias which queries can use in place of the name
+// of index.
+func (api *API) CreateAlias(ctx context.Context, alias, index string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.CreateAlias")
+	defer span.Finish()
+
+	if err := api.validate(apiCreateAlias); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	if err := ValidateName(alias); err != nil {
+		return NewBadRequestError(err)
+	}
+	if _, ok := api.holder.aliases.Get(alias); ok {
+		return newConflictError(ErrAliasExists)
+	}
+	if api.holder.Index(alias) != nil {
+		return newConflictError(ErrIndexExists)
+	}
+	return api.setAlias(alias, index)
+}
+
+// SwapAlias atomically points an existing alias at a different index.
+// Queries started before the swap finish against the old index; queries
+// started after it run against the new one.
+func (api *API) SwapAlias(ctx context.Context, alias, index string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.SwapAlias")
+	defer span.Finish()
+
+	if err := api.validate(apiSwapAlias); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	if _, ok := api.holder.aliases.Get(alias); !ok {
+		return newNotFoundError(ErrAliasNotFound, alias)
+	}
+	return api.setAlias(alias, index)
+}
+
+// DeleteAlias removes an alias. The index it referred to is unaffected.
+func (api *API) DeleteAlias(ctx context.Context, alias string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.DeleteAlias")
+	defer span.Finish()
+
+	if err := api.validate(apiDeleteAlias); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	if _, ok := api.holder.aliases.Get(alias); !ok {
+		return newNotFoundError(ErrAliasNotFound, alias)
+	}
+	return api.setAlias(alias, "")
+}
+
+// Aliases returns a map of alias names to the indexes they refer to.
+func (api *API) Aliases(ctx context.Context) (map[string]string, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.Aliases")
+	defer span.Finish()
+
+	if err := api.validate(apiAliases); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.holder.aliases.All(), nil
+}
+
+// setAlias points alias at index, or removes it if index is empty, on
+// this node and then on the rest of the cluster.
+func (api *API) setAlias(alias, index string) error {
+	if index != "" && api.holder.Index(index) == nil {
+		return newNotFoundError(ErrIndexNotFound, index)
+	}
+	msg := &SetAliasMessage{
+		Alias: alias,
+		Index: index,
+	}
+	if err := api.holder.aliases.Set(msg.Alias, msg.Index); err != nil {
+		return errors.Wrap(err, "setting alias")
+	}
+	if err := api.server.SendSync(msg); err != nil {
+		return errors.Wrap(err, "sending SetAlias message")
+	}
+	return nil
+}
+
 // DeleteIndex removes the named index. If the index is not found it does
 // nothing and returns no error.
 func (api *API) DeleteIndex(ctx context.Context, indexName string) error {
@@ -271,6 +432,12 @@ func (api *API) DeleteIndex(ctx context.Context, indexName string) error {
 		return errors.Wrap(err, "validating api method")
 	}
 
+	// Queries against an alias would start failing, so the alias must be
+	// swapped or deleted first.
+	if aliases := api.holder.aliases.Targeting(indexName); len(aliases) > 0 {
+		return newConflictError(errors.Wrapf(ErrIndexAliased, "%s is referenced by %s", indexName, strings.Join(aliases, ", ")))
+	}
+
 	// Delete index from the holder.
 	err := api.holder.DeleteIndex(indexName)
 	if err != nil {
@@ -293,6 +460,7 @@ func (api *API) DeleteIndex(ctx context.Context, indexName string) error {
 		}
 	}
 	api.holder.Stats.Count(MetricDeleteIndex, 1, 1.0)
+	api.publishEvent(Event{Type: EventIndexDeleted, Index: indexName})
 	return nil
 }
 
@@ -318,6 +486,12 @@ func (api *API) CreateField(ctx context.Context, indexName string, fieldName str
 	if index == nil {
 		return nil, newNotFoundError(ErrIndexNotFound, indexName)
 	}
+	if err := index.validateDerive(fo); err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "deriving field"))
+	}
+	if err := index.validateCascade(fo); err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "cascading field"))
+	}
 
 	// Populate the create field message.
 	cfm := &CreateFieldMessage{
@@ -339,7 +513,8 @@ func (api *API) CreateField(ctx context.Context, indexName string, fieldName str
 		return nil, errors.Wrap(err, "sending CreateField message")
 	}
 
-	api.holder.Stats.CountWithCustomTags(MetricCreateField, 1, 1.0, []string{fmt.Sprintf("index:%s", indexName)})
+	api.holder.Stats.CountWithCustomTags(MetricCreateField, 1, 1.0, api.holder.indexTags(indexName))
+	api.publishEvent(Event{Type: EventFieldCreated, Index: indexName, Field: fieldName})
 	return field, nil
 }
 
@@ -391,6 +566,87 @@ func (api *API) Field(ctx context.Context, indexName, fieldName string) (*Field,
 	return field, nil
 }
 
+// rowMetaField returns the field to which row metadata for row may be
+// attached, validating row against the field's keys.
+func (api *API) rowMetaField(indexName, fieldName, row string) (*Field, error) {
+	field := api.holder.Field(indexName, fieldName)
+	if field == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
+	}
+	if !fieldHasRowMeta(field) {
+		return nil, NewBadRequestError(errors.Errorf("fields of type %s do not support row metadata", field.Type()))
+	}
+	if row == "" {
+		return nil, NewBadRequestError(errors.New("row required"))
+	}
+	if !field.Keys() {
+		if _, err := strconv.ParseUint(row, 10, 64); err != nil {
+			return nil, NewBadRequestError(errors.Errorf("invalid row ID %q for unkeyed field", row))
+		}
+	}
+	return field, nil
+}
+
+// SetRowMeta attaches a JSON metadata blob to a row of a field. The row is
+// given as its key for keyed fields and as its decimal ID otherwise. Empty
+// or null metadata removes any existing metadata for the row.
+func (api *API) SetRowMeta(ctx context.Context, indexName, fieldName, row string, meta json.RawMessage) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.SetRowMeta")
+	defer span.Finish()
+
+	if err := api.validate(apiSetRowMeta); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	if _, err := api.rowMetaField(indexName, fieldName, row); err != nil {
+		return err
+	}
+	if len(meta) == 0 || bytes.Equal(bytes.TrimSpace(meta), []byte("null")) {
+		meta = nil
+	} else if len(meta) > MaxRowMetaSize {
+		return NewBadRequestError(ErrRowMetaTooLarge)
+	} else if !json.Valid(meta) {
+		return NewBadRequestError(errors.New("row metadata must be valid JSON"))
+	}
+
+	msg := &SetRowMetaMessage{
+		Index: indexName,
+		Field: fieldName,
+		Row:   row,
+		Meta:  meta,
+	}
+	if err := api.holder.rowMeta.Set(msg.Index, msg.Field, msg.Row, msg.Meta); err != nil {
+		return errors.Wrap(err, "setting row metadata")
+	}
+
+	// Send the metadata to all nodes, since any of them may coordinate
+	// a query which includes it.
+	if err := api.server.SendSync(msg); err != nil {
+		return errors.Wrap(err, "sending SetRowMeta message")
+	}
+	return nil
+}
+
+// RowMeta returns the metadata attached to a row of a field, or nil if
+// there isn't any.
+func (api *API) RowMeta(ctx context.Context, indexName, fieldName, row string) (json.RawMessage, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.RowMeta")
+	defer span.Finish()
+
+	if err := api.validate(apiRowMeta); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	if _, err := api.rowMetaField(indexName, fieldName, row); err != nil {
+		return nil, err
+	}
+	metas, err := api.holder.rowMeta.Get(indexName, fieldName, []string{row})
+	if err != nil || metas == nil {
+		return nil, errors.Wrap(err, "getting row metadata")
+	}
+	return metas[0], nil
+}
+
 func setUpImportOptions(opts ...ImportOption) (*ImportOptions, error) {
 	options := &ImportOptions{}
 	for _, opt := range opts {
@@ -556,6 +812,9 @@ func (api *API) ImportRoaring(ctx context.Context, indexName, fieldName string,
 	if err = req.ValidateWithTimestamp(index.CreatedAt(), field.CreatedAt()); err != nil {
 		return newPreconditionFailedError(err)
 	}
+	if err := api.holder.checkWrite(index, shard, req.Clear); err != nil {
+		return err
+	}
 
 	qcx := api.Txf().NewQcx()
 	defer qcx.Abort()
@@ -639,7 +898,8 @@ func (api *API) DeleteField(ctx context.Context, indexName string, fieldName str
 		api.server.logger.Errorf("problem sending DeleteField message: %s", err)
 		return errors.Wrap(err, "sending DeleteField message")
 	}
-	api.holder.Stats.CountWithCustomTags(MetricDeleteField, 1, 1.0, []string{fmt.Sprintf("index:%s", indexName)})
+	api.holder.Stats.CountWithCustomTags(MetricDeleteField, 1, 1.0, api.holder.indexTags(indexName))
+	api.publishEvent(Event{Type: EventFieldDeleted, Index: indexName, Field: fieldName})
 	return nil
 }
 
@@ -671,7 +931,7 @@ func (api *API) DeleteAvailableShard(_ context.Context, indexName, fieldName str
 		api.server.logger.Errorf("problem sending DeleteAvailableShard message: %s", err)
 		return errors.Wrap(err, "sending DeleteAvailableShard message")
 	}
-	api.holder.Stats.CountWithCustomTags(MetricDeleteAvailableShard, 1, 1.0, []string{fmt.Sprintf("index:%s", indexName)})
+	api.holder.Stats.CountWithCustomTags(MetricDeleteAvailableShard, 1, 1.0, api.holder.indexTags(indexName))
 	return nil
 }
 
@@ -933,6 +1193,28 @@ func (api *API) TranslateData(ctx context.Context, indexName string, partition i
 	return store, nil
 }
 
+// LocalTranslateData returns the translation data this node has for the
+// specified partition, whether or not it owns the partition. It's used to
+// copy partitions from nodes which owned them before the cluster changed.
+func (api *API) LocalTranslateData(ctx context.Context, indexName string, partition int) (io.WriterTo, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.LocalTranslateData")
+	defer span.Finish()
+
+	if err := api.validate(apiTranslateData); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	idx := api.holder.Index(indexName)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	store := idx.TranslateStore(partition)
+	if store == nil {
+		return nil, ErrTranslateStoreNotFound
+	}
+	return store, nil
+}
+
 // FieldTranslateData returns all translation data in the specified field.
 func (api *API) FieldTranslateData(ctx context.Context, indexName, fieldName string) (io.WriterTo, error) {
 	span, _ := tracing.StartSpanFromContext(ctx, "API.FieldTranslateData")
@@ -1088,6 +1370,49 @@ func (api *API) IndexInfo(ctx context.Context, name string) (*IndexInfo, error)
 	return nil, ErrIndexNotFound
 }
 
+// SearchSchema returns the parts of the schema tagged with tag, which is
+// either "key", matching any value, or "key=value". An index whose own tags
+// match is returned with all of its fields; otherwise an index is returned
+// with only its matching fields, and omitted if none match.
+func (api *API) SearchSchema(ctx context.Context, tag string) ([]*IndexInfo, error) {
+	if err := api.validate(apiSearchSchema); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	span, _ := tracing.StartSpanFromContext(ctx, "API.SearchSchema")
+	defer span.Finish()
+
+	if tag == "" || strings.HasPrefix(tag, "=") {
+		return nil, NewBadRequestError(errors.New("tag key required"))
+	}
+
+	schema, err := api.holder.limitedSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*IndexInfo, 0)
+	for _, ii := range schema {
+		if ii.Options.MatchTag(tag) {
+			matches = append(matches, ii)
+			continue
+		}
+		var fields []*FieldInfo
+		for _, fi := range ii.Fields {
+			if fi.Options.MatchTag(tag) {
+				fields = append(fields, fi)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		match := *ii
+		match.Fields = fields
+		matches = append(matches, &match)
+	}
+	return matches, nil
+}
+
 // ApplySchema takes the given schema and applies it across the
 // cluster (if remote is false), or just to this node (if remote is
 // true). This is designed for the use case of replicating a schema
@@ -1173,7 +1498,7 @@ func (api *API) ApplyOneIngestSchema(ctx context.Context, schema *ingestSpec) (i
 		}
 		if index != nil {
 			existingOpts := index.Options()
-			if existingOpts != opts {
+			if !reflect.DeepEqual(existingOpts, opts) {
 				return nil, nil, fmt.Errorf("index %q options mismatch: schema %#v, existing %#v", indexName, opts, existingOpts)
 			}
 			break
@@ -1380,6 +1705,12 @@ type ImportOptions struct {
 	Presorted      bool
 	fullySorted    bool // format-aware sorting, internal use only please.
 
+	// CreateParents makes the records of the field's foreign index which
+	// the import references exist, setting ParentExistsField, a bool field
+	// of the foreign index, for them if it's set.
+	CreateParents     bool
+	ParentExistsField string
+
 	// test Tx atomicity if > 0
 	SimPowerLossAfter int
 }
@@ -1405,6 +1736,19 @@ func OptImportOptionsIgnoreKeyCheck(b bool) ImportOption {
 	}
 }
 
+// OptImportOptionsCreateParents is a functional option on ImportOption
+// used to specify whether an import into a field with a foreign index
+// makes the records it references in the foreign index exist. If
+// existsField isn't empty, it names a bool field of the foreign index which
+// is set for them.
+func OptImportOptionsCreateParents(b bool, existsField string) ImportOption {
+	return func(o *ImportOptions) error {
+		o.CreateParents = b
+		o.ParentExistsField = existsField
+		return nil
+	}
+}
+
 func OptImportOptionsPresorted(b bool) ImportOption {
 	return func(o *ImportOptions) error {
 		o.Presorted = b
@@ -1563,6 +1907,12 @@ func (api *API) ImportWithTx(ctx context.Context, qcx *Qcx, req *ImportRequest,
 		} else if len(req.ColumnKeys) != 0 {
 			return errors.New("record keys cannot be used because field uses integer IDs")
 		}
+
+		if options.CreateParents && !options.Clear {
+			if err := api.importParents(ctx, field, req.RowIDs, options); err != nil {
+				return errors.Wrap(err, "creating parents")
+			}
+		}
 	}
 
 	// if you specify a shard of ^0, we try to split this out. If we did any
@@ -1595,6 +1945,9 @@ func (api *API) ImportWithTx(ctx context.Context, qcx *Qcx, req *ImportRequest,
 	if err := api.validateShardOwnership(req.Index, req.Shard); err != nil {
 		return errors.Wrap(err, "validating shard ownership")
 	}
+	if err := api.holder.checkWrite(idx, req.Shard, options.Clear); err != nil {
+		return err
+	}
 
 	var timestamps []int64
 	for _, v := range req.Timestamps {
@@ -1615,13 +1968,19 @@ func (api *API) ImportWithTx(ctx context.Context, qcx *Qcx, req *ImportRequest,
 		}
 	}
 
+	// Import overwrites the column IDs, so keep a copy for derived fields.
+	var derivedCols []uint64
+	if idx.hasDerivedFields() {
+		derivedCols = append(derivedCols, req.ColumnIDs...)
+	}
+
 	// Import into fragment.
 	err = field.Import(qcx, req.RowIDs, req.ColumnIDs, timestamps, req.Shard, options)
 	if err != nil {
 		api.server.logger.Errorf("import error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
 		return errors.Wrap(err, "importing")
 	}
-	return errors.Wrap(err, "committing")
+	return errors.Wrap(api.importDerived(ctx, qcx, idx, field, req.Shard, derivedCols), "importing derived fields")
 }
 
 // ImportRoaringShard transactionally imports roaring-encoded data
@@ -1635,10 +1994,10 @@ func (api *API) ImportRoaringShard(ctx context.Context, indexName string, shard
 	}
 
 	// we really only need a Tx, but getting a Qcx so that there's only one path for getting a Tx
-	qcx := api.Txf().NewQcx()
-	qcx.write = true
+	qcx := api.Txf().NewWritableQcx()
 	tx, finisher, err := qcx.GetTx(Txo{Write: true, Index: index, Shard: shard})
 	if err != nil {
+		qcx.Abort()
 		return errors.Wrap(err, "getting Tx")
 	}
 	defer qcx.Finish()
@@ -1648,6 +2007,16 @@ func (api *API) ImportRoaringShard(ctx context.Context, indexName string, shard
 	if !req.Remote {
 		return errors.New("forwarding unimplemented on this endpoint")
 	}
+	clear := true
+	for _, viewUpdate := range req.Views {
+		if len(viewUpdate.Set) > 0 {
+			clear = false
+			break
+		}
+	}
+	if err1 = api.holder.checkWrite(index, shard, clear); err1 != nil {
+		return err1
+	}
 
 	for _, viewUpdate := range req.Views {
 		field := index.Field(viewUpdate.Field)
@@ -1758,7 +2127,7 @@ func (api *API) ImportValueWithTx(ctx context.Context, qcx *Qcx, req *ImportValu
 	}
 
 	numCols := len(req.ColumnIDs) + len(req.ColumnKeys)
-	numVals := len(req.Values) + len(req.FloatValues) + len(req.TimestampValues) + len(req.StringValues)
+	numVals := len(req.Values) + len(req.FloatValues) + len(req.TimestampValues) + len(req.StringValues) + len(req.DecimalValues)
 	if numCols != numVals {
 		return errors.New(fmt.Sprintf("number of columns (%v) and number of values (%v) do not match", numCols, numVals))
 	}
@@ -1782,6 +2151,16 @@ func (api *API) ImportValueWithTx(ctx context.Context, qcx *Qcx, req *ImportValu
 	span.LogKV(
 		"index", req.Index,
 		"field", req.Field)
+
+	// Scale decimal values before anything else, so that records are
+	// rejected before any are imported, and so they're forwarded to the
+	// nodes owning their shards as values.
+	if len(req.DecimalValues) > 0 {
+		if req.Values, err = field.decimalImportValues(req); err != nil {
+			return err
+		}
+		req.DecimalValues = nil
+	}
 	// Unless explicitly ignoring key validation (meaning keys have been
 	// translate to ids in a previous step at the primary node), then
 	// check to see if keys need translation.
@@ -1803,19 +2182,48 @@ func (api *API) ImportValueWithTx(ctx context.Context, qcx *Qcx, req *ImportValu
 		if field.Keys() {
 			// Perform translation.
 			span.LogKV("rowKeys", true)
-			uints, err := api.cluster.translateIndexKeys(ctx, field.ForeignIndex(), req.StringValues, true)
+			keys := req.StringValues
+			if len(req.Nulls) > 0 {
+				keys = make([]string, 0, len(req.StringValues))
+				for i, key := range req.StringValues {
+					if !req.Nulls[i] {
+						keys = append(keys, key)
+					}
+				}
+			}
+			uints, err := api.cluster.translateIndexKeys(ctx, field.ForeignIndex(), keys, true)
 			if err != nil {
 				return err
 			}
 
 			// Because the BSI field supports negative values, we have to
-			// convert the uint64 keys to a slice of int64.
-			ints := make([]int64, len(uints))
-			for i := range uints {
-				ints[i] = int64(uints[i])
+			// convert the uint64 keys to a slice of int64. Null values
+			// weren't translated, and are left as zero.
+			ints := make([]int64, len(req.StringValues))
+			for i := range ints {
+				if len(req.Nulls) > 0 && req.Nulls[i] {
+					continue
+				}
+				ints[i] = int64(uints[0])
+				uints = uints[1:]
 			}
 			req.Values = ints
 		}
+
+		if options.CreateParents && !options.Clear {
+			ids := make([]uint64, 0, len(req.Values))
+			for i, v := range req.Values {
+				if len(req.Nulls) > 0 && req.Nulls[i] {
+					continue
+				}
+				if v >= 0 {
+					ids = append(ids, uint64(v))
+				}
+			}
+			if err := api.importParents(ctx, field, ids, options); err != nil {
+				return errors.Wrap(err, "creating parents")
+			}
+		}
 	}
 
 	if !options.Presorted {
@@ -1842,6 +2250,9 @@ func (api *API) ImportValueWithTx(ctx context.Context, qcx *Qcx, req *ImportValu
 		if err := api.validateShardOwnership(req.Index, req.Shard); err != nil {
 			return errors.Wrap(err, "validating shard ownership")
 		}
+		if err := api.holder.checkWrite(idx, req.Shard, options.Clear); err != nil {
+			return err
+		}
 		// Import columnIDs into existence field.
 		if !options.Clear {
 			if err := importExistenceColumns(qcx, idx, req.ColumnIDs, shard); err != nil {
@@ -1850,6 +2261,21 @@ func (api *API) ImportValueWithTx(ctx context.Context, qcx *Qcx, req *ImportValu
 			}
 		}
 
+		// Clear the values of null columns in the same transaction as the
+		// others are set.
+		columns := req.ColumnIDs
+		if nulls := req.removeNulls(); len(nulls) > 0 {
+			tx, finisher, err := qcx.GetTx(Txo{Write: true, Index: idx, Shard: shard})
+			if err != nil {
+				return err
+			}
+			err = field.ClearBits(tx, shard, nulls...)
+			finisher(&err)
+			if err != nil {
+				return errors.Wrap(err, "clearing null values")
+			}
+		}
+
 		// Import into fragment.
 		if len(req.Values) > 0 {
 			err = field.importValue(qcx, req.ColumnIDs, req.Values, shard, options)
@@ -1867,7 +2293,10 @@ func (api *API) ImportValueWithTx(ctx context.Context, qcx *Qcx, req *ImportValu
 				api.server.logger.Errorf("import error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
 			}
 		}
-		return errors.Wrap(err, "importing value")
+		if err != nil {
+			return errors.Wrap(err, "importing value")
+		}
+		return errors.Wrap(api.importDerived(ctx, qcx, idx, field, shard, columns), "importing derived fields")
 
 	} // end if req.Shard != math.MaxUint64
 	options.IgnoreKeyCheck = true
@@ -1890,6 +2319,9 @@ func (api *API) ImportValueWithTx(ctx context.Context, qcx *Qcx, req *ImportValu
 			} else if req.TimestampValues != nil {
 				subreq.TimestampValues = req.TimestampValues[start:i]
 			}
+			if req.Nulls != nil {
+				subreq.Nulls = req.Nulls[start:i]
+			}
 			guard <- struct{}{} // would block if guard channel is already filled
 			eg.Go(func() error {
 				err := api.server.defaultClient.ImportValue(ctx, qcx, subreq, options)
@@ -1911,6 +2343,9 @@ func (api *API) ImportValueWithTx(ctx context.Context, qcx *Qcx, req *ImportValu
 	} else if req.FloatValues != nil {
 		subreq.FloatValues = req.FloatValues[start:]
 	}
+	if req.Nulls != nil {
+		subreq.Nulls = req.Nulls[start:]
+	}
 	eg.Go(func() error {
 		// TODO we should elevate the logic for figuring out which
 		// node(s) to send to into API instead of having those details
@@ -1952,6 +2387,9 @@ func (api *API) IngestNodeOperations(ctx context.Context, qcx *Qcx, indexName st
 		api.server.logger.Errorf("ingest: no such index %q", indexName)
 		return newNotFoundError(ErrIndexNotFound, indexName)
 	}
+	if err := api.holder.checkIndexWritable(index); err != nil {
+		return err
+	}
 	fields := index.Fields()
 	knownFields := map[string]*Field{}
 	for _, field := range fields {
@@ -1983,6 +2421,9 @@ func (api *API) IngestOperations(ctx context.Context, qcx *Qcx, indexName string
 		api.server.logger.Errorf("ingest: no such index %q", indexName)
 		return newNotFoundError(ErrIndexNotFound, indexName)
 	}
+	if err := api.holder.checkIndexWritable(index); err != nil {
+		return err
+	}
 	fields := index.Fields()
 	var indexKeys ingest.KeyTranslator
 	if index.Keys() {
@@ -2117,6 +2558,9 @@ func (api *API) applyOperations(ctx context.Context, qcx *Qcx, index *Index, sha
 							return fmt.Errorf("clearing field %q: %w", name, err)
 						}
 					}
+					if err = api.holder.columnAttrs.Delete(index.name, op.ClearRecordIDs); err != nil {
+						return fmt.Errorf("deleting column attributes: %w", err)
+					}
 					return nil
 				}
 				// clear things that we need to wipe out, whether it's because
@@ -2261,10 +2705,10 @@ func (api *API) StatsWithTags(tags []string) stats.StatsClient {
 	return api.holder.Stats.WithTags(tags...)
 }
 
-// LongQueryTime returns the configured threshold for logging/statting
+// LongQueryTime returns the current threshold for logging/statting
 // long running queries.
 func (api *API) LongQueryTime() time.Duration {
-	return api.server.longQueryTime
+	return api.server.currentSettings().LongQueryTime
 }
 
 func (api *API) validateShardOwnership(indexName string, shard uint64) error {
@@ -2339,6 +2783,9 @@ func (api *API) Info() serverInfo {
 		ReplicaN:         api.cluster.ReplicaN,
 		ShardHash:        api.cluster.Hasher.Name(),
 		KeyHash:          api.cluster.Hasher.Name(),
+
+		ProtocolVersion:    ProtocolVersion,
+		MinProtocolVersion: MinProtocolVersion,
 	}
 }
 
@@ -2778,46 +3225,7 @@ func (api *API) RestoreShard(ctx context.Context, indexName string, shard uint64
 	if err != nil {
 		return err
 	}
-	tx, err := db.NewTx(false, idx.name, Txo{})
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-	//arguments idx,shard do not matter for rbf they
-	//are ignored
-	flvs, err := tx.GetSortedFieldViewList(idx, shard)
-	if err != nil {
-		return nil
-	}
-
-	for _, flv := range flvs {
-		fld := idx.field(flv.Field)
-		view := fld.view(flv.View)
-		if view == nil {
-			view, err = fld.createViewIfNotExists(flv.View)
-			if err != nil {
-				return err
-			}
-		}
-		frag, err := view.CreateFragmentIfNotExists(shard)
-		if err != nil {
-			return err
-		}
-		err = frag.RebuildRankCache(ctx)
-		if err != nil {
-			return err
-		}
-		bd, err := view.bitDepth([]uint64{shard})
-		if err != nil {
-			return err
-		}
-		err = fld.cacheBitDepth(bd)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return idx.openShardFragments(ctx, db, shard)
 }
 
 func (api *API) mutexCheckThisNode(ctx context.Context, qcx *Qcx, indexName string, fieldName string, details bool, limit int) (map[uint64]map[uint64][]uint64, error) {
@@ -3229,6 +3637,11 @@ type serverInfo struct {
 	CPULogicalCores  int    `json:"cpuLogicalCores"`
 	CPUMHz           int    `json:"cpuMHz"`
 	StorageBackend   string `json:"storageBackend"`
+
+	// The newest and oldest versions of the protocol between nodes which
+	// this node speaks.
+	ProtocolVersion    int `json:"protocolVersion"`
+	MinProtocolVersion int `json:"minProtocolVersion"`
 }
 
 type apiMethod int
@@ -3278,11 +3691,47 @@ const (
 	apiIngestOperations
 	apiIngestNodeOperations
 	apiMutexCheck
+	apiSetRowMeta
+	apiRowMeta
+	apiSearchSchema
+	apiCreateAlias
+	apiSwapAlias
+	apiDeleteAlias
+	apiAliases
+	apiCloneIndex
+	apiFieldResidency
+	apiOpenState
+	apiHealth
+	apiUpdateIndex
+	apiMaintenance
+	apiFieldWrites
+	apiGenerateData
+	apiGenerateLoad
+	apiCanary
+	apiImportColumnAttrs
+	apiColumnAttrs
+	apiCheckConsistency
+	apiReindex
+	apiUsageReport
+	apiIndexStats
+	apiSettings
+	apiAnalyzeQuery
+	apiFieldKeyCollisions
+	apiMergeDuplicateKeys
+	apiImportSession
+	apiClientSessions
+	apiStorage
+	apiNodeDiagnostics
+	apiKeyPrefixShards
+	apiFieldCache
 )
 
 var methodsCommon = map[apiMethod]struct{}{
 	apiClusterMessage: {},
 	apiState:          {},
+	apiOpenState:      {},
+	apiHealth:         {},
+	apiMaintenance:    {},
 }
 
 // var methodsDegraded = map[apiMethod]struct{}{
@@ -3339,6 +3788,36 @@ var methodsNormal = map[apiMethod]struct{}{
 	apiIngestOperations:     {},
 	apiIngestNodeOperations: {},
 	apiMutexCheck:           {},
+	apiSetRowMeta:           {},
+	apiRowMeta:              {},
+	apiSearchSchema:         {},
+	apiCreateAlias:          {},
+	apiSwapAlias:            {},
+	apiDeleteAlias:          {},
+	apiAliases:              {},
+	apiCloneIndex:           {},
+	apiFieldResidency:       {},
+	apiUpdateIndex:          {},
+	apiFieldWrites:          {},
+	apiGenerateData:         {},
+	apiGenerateLoad:         {},
+	apiCanary:               {},
+	apiImportColumnAttrs:    {},
+	apiColumnAttrs:          {},
+	apiCheckConsistency:     {},
+	apiReindex:              {},
+	apiUsageReport:          {},
+	apiIndexStats:           {},
+	apiSettings:             {},
+	apiAnalyzeQuery:         {},
+	apiFieldKeyCollisions:   {},
+	apiMergeDuplicateKeys:   {},
+	apiImportSession:        {},
+	apiClientSessions:       {},
+	apiStorage:              {},
+	apiNodeDiagnostics:      {},
+	apiKeyPrefixShards:      {},
+	apiFieldCache:           {},
 }
 
 // SchemaAPI is a subset of the API methods which have to do with schema. This
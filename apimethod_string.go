@@ -45,11 +45,44 @@ func _() {
 	_ = x[apiIngestOperations-34]
 	_ = x[apiIngestNodeOperations-35]
 	_ = x[apiMutexCheck-36]
+	_ = x[apiSetRowMeta-37]
+	_ = x[apiRowMeta-38]
+	_ = x[apiSearchSchema-39]
+	_ = x[apiCreateAlias-40]
+	_ = x[apiSwapAlias-41]
+	_ = x[apiDeleteAlias-42]
+	_ = x[apiAliases-43]
+	_ = x[apiCloneIndex-44]
+	_ = x[apiFieldResidency-45]
+	_ = x[apiOpenState-46]
+	_ = x[apiHealth-47]
+	_ = x[apiUpdateIndex-48]
+	_ = x[apiMaintenance-49]
+	_ = x[apiFieldWrites-50]
+	_ = x[apiGenerateData-51]
+	_ = x[apiGenerateLoad-52]
+	_ = x[apiCanary-53]
+	_ = x[apiImportColumnAttrs-54]
+	_ = x[apiColumnAttrs-55]
+	_ = x[apiCheckConsistency-56]
+	_ = x[apiReindex-57]
+	_ = x[apiUsageReport-58]
+	_ = x[apiIndexStats-59]
+	_ = x[apiSettings-60]
+	_ = x[apiAnalyzeQuery-61]
+	_ = x[apiFieldKeyCollisions-62]
+	_ = x[apiMergeDuplicateKeys-63]
+	_ = x[apiImportSession-64]
+	_ = x[apiClientSessions-65]
+	_ = x[apiStorage-66]
+	_ = x[apiNodeDiagnostics-67]
+	_ = x[apiKeyPrefixShards-68]
+	_ = x[apiFieldCache-69]
 }
 
-const _apiMethod_name = "apiClusterMessageapiCreateFieldapiCreateIndexapiDeleteFieldapiDeleteAvailableShardapiDeleteIndexapiDeleteViewapiExportCSVapiFragmentBlockDataapiFragmentBlocksapiFragmentDataapiTranslateDataapiFieldTranslateDataapiFieldapiImportapiImportValueapiIndexapiQueryapiRecalculateCachesapiSchemaapiShardNodesapiStateapiViewsapiApplySchemaapiStartTransactionapiFinishTransactionapiTransactionsapiGetTransactionapiActiveQueriesapiPastQueriesapiIDReserveapiIDCommitapiIDResetapiPartitionNodesapiIngestOperationsapiIngestNodeOperationsapiMutexCheck"
+const _apiMethod_name = "apiClusterMessageapiCreateFieldapiCreateIndexapiDeleteFieldapiDeleteAvailableShardapiDeleteIndexapiDeleteViewapiExportCSVapiFragmentBlockDataapiFragmentBlocksapiFragmentDataapiTranslateDataapiFieldTranslateDataapiFieldapiImportapiImportValueapiIndexapiQueryapiRecalculateCachesapiSchemaapiShardNodesapiStateapiViewsapiApplySchemaapiStartTransactionapiFinishTransactionapiTransactionsapiGetTransactionapiActiveQueriesapiPastQueriesapiIDReserveapiIDCommitapiIDResetapiPartitionNodesapiIngestOperationsapiIngestNodeOperationsapiMutexCheckapiSetRowMetaapiRowMetaapiSearchSchemaapiCreateAliasapiSwapAliasapiDeleteAliasapiAliasesapiCloneIndexapiFieldResidencyapiOpenStateapiHealthapiUpdateIndexapiMaintenanceapiFieldWritesapiGenerateDataapiGenerateLoadapiCanaryapiImportColumnAttrsapiColumnAttrsapiCheckConsistencyapiReindexapiUsageReportapiIndexStatsapiSettingsapiAnalyzeQueryapiFieldKeyCollisionsapiMergeDuplicateKeysapiImportSessionapiClientSessionsapiStorageapiNodeDiagnosticsapiKeyPrefixShardsapiFieldCache"
 
-var _apiMethod_index = [...]uint16{0, 17, 31, 45, 59, 82, 96, 109, 121, 141, 158, 173, 189, 210, 218, 227, 241, 249, 257, 277, 286, 299, 307, 315, 329, 348, 368, 383, 400, 416, 430, 442, 453, 463, 480, 499, 522, 535}
+var _apiMethod_index = [...]uint16{0, 17, 31, 45, 59, 82, 96, 109, 121, 141, 158, 173, 189, 210, 218, 227, 241, 249, 257, 277, 286, 299, 307, 315, 329, 348, 368, 383, 400, 416, 430, 442, 453, 463, 480, 499, 522, 535, 548, 558, 573, 587, 599, 613, 623, 636, 653, 665, 674, 688, 702, 716, 731, 746, 755, 775, 789, 808, 818, 832, 845, 856, 871, 892, 913, 929, 946, 956, 974, 992, 1005}
 
 func (i apiMethod) String() string {
 	if i < 0 || i >= apiMethod(len(_apiMethod_index)-1) {
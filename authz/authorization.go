@@ -15,6 +15,11 @@ import (
 type GroupPermissions struct {
 	Permissions map[string]map[string]Permission `yaml:"user-groups"`
 	Admin       string                           `yaml:"admin"`
+
+	// Namespaces holds each group's permissions on namespaces, which apply
+	// to every index in the namespace the group has no permission on of
+	// its own.
+	Namespaces map[string]map[string]Permission `yaml:"namespaces"`
 }
 
 type Permission string
@@ -57,6 +62,13 @@ func (p *GroupPermissions) ReadPermissionsFile(permsFile io.Reader) (err error)
 }
 
 func (p *GroupPermissions) GetPermissions(user *authn.UserInfo, index string) (permission Permission, errors error) {
+	return p.GetNamespacedPermissions(user, index, "")
+}
+
+// GetNamespacedPermissions returns the user's permission on an index in a
+// namespace, which is the permission its groups have on the index, or else
+// on the namespace.
+func (p *GroupPermissions) GetNamespacedPermissions(user *authn.UserInfo, index, namespace string) (permission Permission, errors error) {
 	groups := user.Groups
 	if admin := p.IsAdmin(groups); admin {
 		return Admin, nil
@@ -73,15 +85,20 @@ func (p *GroupPermissions) GetPermissions(user *authn.UserInfo, index string) (p
 
 	var groupsDenied []string
 	for _, group := range groups {
-		if _, ok := p.Permissions[group.GroupID]; ok {
-			if perm, ok := p.Permissions[group.GroupID][index]; ok {
-				allPermissions[perm] = true
-			} else {
-				return None, fmt.Errorf("user %s does not have permission to index %s", user.UserID, index)
-			}
-		} else {
+		indexPerms, hasIndexPerms := p.Permissions[group.GroupID]
+		namespacePerms, hasNamespacePerms := p.Namespaces[group.GroupID]
+		if !hasIndexPerms && !hasNamespacePerms {
 			groupsDenied = append(groupsDenied, group.GroupID)
+			continue
 		}
+		perm, ok := indexPerms[index]
+		if !ok && namespace != "" {
+			perm, ok = namespacePerms[namespace]
+		}
+		if !ok {
+			return None, fmt.Errorf("user %s does not have permission to index %s", user.UserID, index)
+		}
+		allPermissions[perm] = true
 	}
 
 	if len(groupsDenied) == len(groups) {
@@ -128,3 +145,25 @@ func (p *GroupPermissions) GetAuthorizedIndexList(groups []authn.Group, desiredP
 	}
 	return indexList
 }
+
+// GetAuthorizedNamespaceList returns the namespaces the groups have at least
+// the desired permission on.
+func (p *GroupPermissions) GetAuthorizedNamespaceList(groups []authn.Group, desiredPermission Permission) (namespaceList []string) {
+	if p.IsAdmin(groups) {
+		for groupId := range p.Namespaces {
+			for namespace := range p.Namespaces[groupId] {
+				namespaceList = append(namespaceList, namespace)
+			}
+		}
+		return namespaceList
+	}
+
+	for _, group := range groups {
+		for namespace, permission := range p.Namespaces[group.GroupID] {
+			if permission.Satisfies(desiredPermission) {
+				namespaceList = append(namespaceList, namespace)
+			}
+		}
+	}
+	return namespaceList
+}
@@ -231,6 +231,61 @@ func TestAuth_IsAdmin(t *testing.T) {
 	}
 }
 
+func TestAuth_GetNamespacedPermissions(t *testing.T) {
+	permissions := `"user-groups":
+  "dca35310-ecda-4f23-86cd-876aee55906b":
+    "test": "read"
+namespaces:
+  "dca35310-ecda-4f23-86cd-876aee55906b":
+    "tenant": "write"
+  "dca35310-ecda-4f23-86cd-876aee559900":
+    "other": "read"
+admin: "ac97c9e2-346b-42a2-b6da-18bcb61a32fe"`
+
+	var p authz.GroupPermissions
+	if err := p.ReadPermissionsFile(strings.NewReader(permissions)); err != nil {
+		t.Fatal(err)
+	}
+	groups := []authn.Group{{GroupID: "dca35310-ecda-4f23-86cd-876aee55906b", GroupName: "name"}}
+	otherGroups := []authn.Group{{GroupID: "dca35310-ecda-4f23-86cd-876aee559900", GroupName: "name"}}
+
+	tests := []struct {
+		groups     []authn.Group
+		index      string
+		namespace  string
+		userAccess authz.Permission
+		err        string
+	}{
+		// The index's own permission comes before its namespace's.
+		{groups, "test", "tenant", authz.Read, ""},
+		{groups, "test2", "tenant", authz.Write, ""},
+		{groups, "test2", "", authz.None, "does not have permission to index"},
+		{groups, "test2", "other", authz.None, "does not have permission to index"},
+		{otherGroups, "test2", "other", authz.Read, ""},
+		{otherGroups, "test2", "tenant", authz.None, "does not have permission to index"},
+	}
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			perm, err := p.GetNamespacedPermissions(&authn.UserInfo{Groups: test.groups}, test.index, test.namespace)
+			if perm != test.userAccess {
+				t.Errorf("expected permission to be %s, but got %s", test.userAccess, perm)
+			}
+			if test.err == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if err != nil && !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error to contain %s, but got %s", test.err, err.Error())
+			}
+		})
+	}
+
+	if got := p.GetAuthorizedNamespaceList(groups, authz.Write); !reflect.DeepEqual(got, []string{"tenant"}) {
+		t.Errorf("expected [tenant], got %v", got)
+	}
+	if got := p.GetAuthorizedNamespaceList(otherGroups, authz.Write); got != nil {
+		t.Errorf("expected no namespaces, got %v", got)
+	}
+}
+
 func TestAuth_GetAuthorizedIndexList(t *testing.T) {
 
 	group1 := []authn.Group{
@@ -0,0 +1,298 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// Avg(field=f) returns the average of the values of an int or decimal
+// field, and WeightedAvg(value=f, weight=w) the average of f's values
+// weighted by w's, over the columns with values in both. Either can take a
+// row call to filter the columns, or be used as a GroupBy aggregate. Each
+// shard returns sums and counts, as for Sum, along with the sum of the
+// weights for WeightedAvg, and the node coordinating the query divides them
+// once they've all been added, so an average takes one pass over the data.
+// Averages are returned as decimals in ValCount.DecimalVal, or
+// GroupCount.DecimalAgg, with avgExtraScale more digits than the field
+// averaged.
+
+// avgExtraScale is the number of digits averages have beyond those of the
+// values averaged.
+const avgExtraScale = 4
+
+// avgMaxScale is the largest scale of an average.
+const avgMaxScale = 18
+
+// averageScale returns the scale of averages of values of the given scale.
+func averageScale(scale int64) int64 {
+	if scale+avgExtraScale > avgMaxScale {
+		if scale > avgMaxScale {
+			return scale
+		}
+		return avgMaxScale
+	}
+	return scale + avgExtraScale
+}
+
+// average returns sum/n, where sum is of values of the given scale, as a
+// decimal of the scale of their average, rounding halves away from zero.
+func average(sum, n *big.Int, scale int64) pql.Decimal {
+	avgScale := averageScale(scale)
+	num := new(big.Int).Exp(big.NewInt(10), big.NewInt(avgScale-scale), nil)
+	num.Mul(num, sum)
+	q, r := new(big.Int).QuoRem(num, n, new(big.Int))
+	if r.Sign() != 0 && new(big.Int).Lsh(new(big.Int).Abs(r), 1).CmpAbs(n) >= 0 {
+		if num.Sign() == n.Sign() {
+			q.Add(q, big.NewInt(1))
+		} else {
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+	dec := pql.Decimal{Scale: avgScale}
+	dec.SetBigIntValue(q)
+	return dec
+}
+
+// averageField returns the int or decimal field of an average.
+func (e *executor) averageField(index, call, name string) (*Field, error) {
+	field := e.Holder.Field(index, name)
+	if field == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, name)
+	}
+	switch field.Type() {
+	case FieldTypeInt, FieldTypeDecimal:
+		return field, nil
+	}
+	return nil, NewBadRequestError(errors.Errorf("%s(): field %s is of type %s, expected int or decimal", call, name, field.Type()))
+}
+
+// weightedAvgFields returns the names of the value and weight fields of a
+// WeightedAvg call.
+func weightedAvgFields(c *pql.Call) (value, weight string, err error) {
+	value, ok, err := c.StringArg("value")
+	if err != nil {
+		return "", "", errors.Wrap(err, "WeightedAvg(): value")
+	} else if !ok {
+		return "", "", errors.New("WeightedAvg(): value field required")
+	}
+	weight, ok, err = c.StringArg("weight")
+	if err != nil {
+		return "", "", errors.Wrap(err, "WeightedAvg(): weight")
+	} else if !ok {
+		return "", "", errors.New("WeightedAvg(): weight field required")
+	}
+	return value, weight, nil
+}
+
+// executeAvg executes an Avg() call.
+func (e *executor) executeAvg(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (ValCount, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeAvg")
+	defer span.Finish()
+
+	fieldName, err := c.FirstStringArg("field", "_field")
+	if err != nil {
+		return ValCount{}, errors.Wrap(err, "Avg(): field required")
+	}
+	if len(c.Children) > 1 {
+		return ValCount{}, errors.New("Avg() only accepts a single bitmap input")
+	}
+	field, err := e.averageField(index, "Avg", fieldName)
+	if err != nil {
+		return ValCount{}, err
+	}
+
+	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
+		return e.executeSumCountShard(ctx, qcx, index, c, nil, shard)
+	}
+	reduceFn := func(ctx context.Context, prev, v interface{}) interface{} {
+		other, _ := prev.(ValCount)
+		return other.add(v.(ValCount))
+	}
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return ValCount{}, err
+	}
+	other, _ := result.(ValCount)
+	if other.Count == 0 {
+		return ValCount{}, nil
+	} else if opt.Remote {
+		return other, nil
+	}
+
+	dec := average(other.sum(), big.NewInt(other.Count), field.Options().Scale)
+	return ValCount{DecimalVal: &dec, Count: other.Count}, nil
+}
+
+// executeWeightedAvg executes a WeightedAvg() call.
+func (e *executor) executeWeightedAvg(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (ValCount, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeWeightedAvg")
+	defer span.Finish()
+
+	valueName, weightName, err := weightedAvgFields(c)
+	if err != nil {
+		return ValCount{}, err
+	}
+	if len(c.Children) > 1 {
+		return ValCount{}, errors.New("WeightedAvg() only accepts a single bitmap input")
+	}
+	value, err := e.averageField(index, "WeightedAvg", valueName)
+	if err != nil {
+		return ValCount{}, err
+	}
+	if _, err := e.averageField(index, "WeightedAvg", weightName); err != nil {
+		return ValCount{}, err
+	}
+
+	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
+		return e.executeWeightedSumShard(ctx, qcx, index, c, nil, shard)
+	}
+	reduceFn := func(ctx context.Context, prev, v interface{}) interface{} {
+		other, _ := prev.(ValCount)
+		return other.add(v.(ValCount))
+	}
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return ValCount{}, err
+	}
+	other, _ := result.(ValCount)
+	if other.Count == 0 {
+		return ValCount{}, nil
+	} else if opt.Remote {
+		return other, nil
+	}
+	if other.WeightSum == nil || other.WeightSum.Sign() == 0 {
+		return ValCount{}, errors.Errorf("WeightedAvg(): weights of %d values sum to zero", other.Count)
+	}
+
+	// The products have the scales of both fields, and the weights the
+	// scale of the weight field, leaving the scale of the value field.
+	dec := average(other.sum(), other.WeightSum, value.Options().Scale)
+	return ValCount{DecimalVal: &dec, Count: other.Count}, nil
+}
+
+// executeWeightedSumShard returns the sum of the products of the values
+// and weights of a WeightedAvg, in Val or BigVal, the sum of the weights,
+// and the number of columns with both, on a shard.
+func (e *executor) executeWeightedSumShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, filter *Row, shard uint64) (_ ValCount, err0 error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeWeightedSumShard")
+	defer span.Finish()
+
+	if filter == nil && len(c.Children) == 1 {
+		row, err := e.executeBitmapCallShard(ctx, qcx, index, c.Children[0], shard)
+		if err != nil {
+			return ValCount{}, errors.Wrap(err, "executing bitmap call")
+		}
+		filter = row
+	}
+
+	valueName, weightName, err := weightedAvgFields(c)
+	if err != nil {
+		return ValCount{}, err
+	}
+	value, weight := e.Holder.Field(index, valueName), e.Holder.Field(index, weightName)
+	if value == nil || weight == nil {
+		return ValCount{}, ErrFieldNotFound
+	}
+	vbsig, wbsig := value.bsiGroup(valueName), weight.bsiGroup(weightName)
+	if vbsig == nil || wbsig == nil {
+		return ValCount{}, nil
+	}
+	vfrag := e.Holder.fragment(index, valueName, viewBSIGroupPrefix+valueName, shard)
+	wfrag := e.Holder.fragment(index, weightName, viewBSIGroupPrefix+weightName, shard)
+	if vfrag == nil || wfrag == nil {
+		return ValCount{}, nil
+	}
+
+	tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: e.Holder.Index(index), Fragment: vfrag, Shard: shard})
+	if err != nil {
+		return ValCount{}, err
+	}
+	defer finisher(&err0)
+
+	// Only columns with both a value and a weight count.
+	both, err := vfrag.row(tx, bsiExistsBit)
+	if err != nil {
+		return ValCount{}, errors.Wrap(err, "finding values")
+	}
+	wexists, err := wfrag.row(tx, bsiExistsBit)
+	if err != nil {
+		return ValCount{}, errors.Wrap(err, "finding weights")
+	}
+	both = both.Intersect(wexists)
+	if filter != nil {
+		both = both.Intersect(filter)
+	}
+
+	vsum, count, err := vfrag.sum(tx, both, vbsig.BitDepth)
+	if err != nil {
+		return ValCount{}, errors.Wrap(err, "summing values")
+	} else if count == 0 {
+		return ValCount{}, nil
+	}
+	wsum, _, err := wfrag.sum(tx, both, wbsig.BitDepth)
+	if err != nil {
+		return ValCount{}, errors.Wrap(err, "summing weights")
+	}
+	prod, err := vfrag.sumProduct(tx, wfrag, both, vbsig.BitDepth, wbsig.BitDepth)
+	if err != nil {
+		return ValCount{}, errors.Wrap(err, "summing products")
+	}
+
+	// The fragments store each value less its field's base, so
+	// sum((v+vb)*(w+wb)) = sum(v*w) + wb*sum(v) + vb*sum(w) + n*vb*wb.
+	n := new(big.Int).SetUint64(count)
+	vb, wb := big.NewInt(vbsig.Base), big.NewInt(wbsig.Base)
+	prod.Add(prod, new(big.Int).Mul(wb, vsum))
+	prod.Add(prod, new(big.Int).Mul(vb, wsum))
+	prod.Add(prod, new(big.Int).Mul(n, new(big.Int).Mul(vb, wb)))
+	wsum.Add(wsum, new(big.Int).Mul(n, wb))
+
+	out := ValCount{Count: int64(count), WeightSum: wsum}
+	out.setSum(prod)
+	return out, nil
+}
+
+// groupAverages sets the DecimalAgg of each of the results of a GroupBy to
+// its average, if aggregate is an Avg or WeightedAvg call. Groups whose
+// weights sum to zero have no average.
+func (e *executor) groupAverages(index string, aggregate *pql.Call, results []GroupCount) error {
+	var fieldName string
+	var err error
+	switch aggregate.Name {
+	case "Avg":
+		fieldName, err = aggregate.FirstStringArg("field", "_field")
+		if err != nil {
+			return errors.Wrap(err, "Avg(): field required")
+		}
+	case "WeightedAvg":
+		fieldName, _, err = weightedAvgFields(aggregate)
+		if err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	field, err := e.averageField(index, aggregate.Name, fieldName)
+	if err != nil {
+		return err
+	}
+	scale := field.Options().Scale
+	for i, gc := range results {
+		n := new(big.Int).SetUint64(gc.Count)
+		if aggregate.Name == "WeightedAvg" {
+			n.SetInt64(gc.AggWeight)
+		}
+		if n.Sign() == 0 {
+			continue
+		}
+		dec := average(big.NewInt(gc.Agg), n, scale)
+		results[i].DecimalAgg = &dec
+	}
+	return nil
+}
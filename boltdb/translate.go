@@ -362,6 +362,31 @@ func (s *TranslateStore) ForceSet(id uint64, key string) error {
 	return nil
 }
 
+// ForceSetEntries writes the id/key pairs of entries to the store in one
+// transaction, even if read only. Used by other stores to write themselves
+// in this store's format.
+func (s *TranslateStore) ForceSetEntries(entries []pilosa.TranslateEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		keys, ids := tx.Bucket(bucketKeys), tx.Bucket(bucketIDs)
+		for _, entry := range entries {
+			_, boltKey := findIDByKey(keys, entry.Key)
+			if err := keys.Put(boltKey, u64tob(entry.ID)); err != nil {
+				return err
+			} else if err := ids.Put(u64tob(entry.ID), boltKey); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.notifyWrite()
+	return nil
+}
+
 // EntryReader returns a reader that streams the underlying data file.
 func (s *TranslateStore) EntryReader(ctx context.Context, offset uint64) (pilosa.TranslateEntryReader, error) {
 	ctx, cancel := context.WithCancel(ctx)
@@ -541,6 +566,9 @@ func (s *TranslateStore) FreeIDs() (*roaring.Bitmap, error) {
 			return errors.Errorf(errFmtTranslateBucketNotFound, bucketKeys)
 		}
 		b := bkt.Get(freeKey)
+		if b == nil {
+			return nil
+		}
 		err := result.UnmarshalBinary(b)
 		if err != nil {
 			return err
@@ -570,6 +598,13 @@ func (s *TranslateStore) MergeFree(tx *bolt.Tx, newIDs *roaring.Bitmap) error {
 	return bkt.Put(freeKey, buf.Bytes())
 }
 
+// MergeFreeIDs adds ids to the store's free IDs.
+func (s *TranslateStore) MergeFreeIDs(ids *roaring.Bitmap) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return s.MergeFree(tx, ids)
+	})
+}
+
 // Delete removes the lookeup pairs in order to make avialble for reuse but doesn't commit the
 // transaction for that is tied to the associated rbf transaction being successful
 func (s *TranslateStore) Delete(records *roaring.Bitmap) (pilosa.Commitor, error) {
@@ -0,0 +1,199 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// A bool field holds one of three states for each column: true, false, or
+// null, for columns which exist but have never been set or were set to
+// null. Only true and false are stored, so null depends on the index
+// tracking existence. Set(col, b=null) clears a column's value, and
+// Row(b=null) returns the columns which exist without a value. Not(Row(b=true))
+// includes those columns, where Row(b=false) doesn't.
+//
+// CountTrue(field=b), CountFalse(field=b) and CountNull(field=b) count the
+// columns in each state, optionally among those of a bitmap call given as
+// their first argument. Without one, they count each shard's containers
+// without reading rows.
+
+// boolField returns the named bool field of an index.
+func (e *executor) boolField(index, fieldName string) (*Index, *Field, error) {
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return nil, nil, newNotFoundError(ErrIndexNotFound, index)
+	}
+	f := idx.Field(fieldName)
+	if f == nil {
+		return nil, nil, newNotFoundError(ErrFieldNotFound, fieldName)
+	} else if f.Type() != FieldTypeBool {
+		return nil, nil, errors.Errorf("field %q is of type %q, not bool", fieldName, f.Type())
+	}
+	return idx, f, nil
+}
+
+// executeCountBool executes a CountTrue(), CountFalse() or CountNull() call.
+func (e *executor) executeCountBool(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (uint64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeCountBool")
+	defer span.Finish()
+
+	fieldName, _ := c.Args["field"].(string)
+	if fieldName == "" {
+		return 0, errors.Errorf("%s() argument required: field", c.Name)
+	} else if len(c.Children) > 1 {
+		return 0, errors.Errorf("%s() only accepts a single bitmap input", c.Name)
+	}
+	idx, _, err := e.boolField(index, fieldName)
+	if err != nil {
+		return 0, err
+	}
+	var rowID uint64
+	countNull := false
+	switch c.Name {
+	case "CountTrue":
+		rowID = trueRowID
+	case "CountFalse":
+		rowID = falseRowID
+	default:
+		countNull = true
+	}
+	if countNull && idx.existenceField() == nil {
+		return 0, errors.Errorf("%s() requires an index which tracks existence", c.Name)
+	}
+
+	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
+		var filter *Row
+		if len(c.Children) == 1 {
+			if filter, err = e.executeBitmapCallShard(ctx, qcx, index, c.Children[0], shard); err != nil {
+				return 0, err
+			}
+		}
+		tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Shard: shard})
+		if err != nil {
+			return 0, err
+		}
+		defer finisher(&err)
+
+		if !countNull {
+			return e.countBoolRow(tx, idx, fieldName, rowID, shard, filter)
+		}
+		exists, err := e.countBoolRow(tx, idx, existenceFieldName, 0, shard, filter)
+		if err != nil {
+			return 0, err
+		}
+		for _, id := range []uint64{trueRowID, falseRowID} {
+			n, err := e.countBoolRow(tx, idx, fieldName, id, shard, filter)
+			if err != nil {
+				return 0, err
+			}
+			exists -= n
+		}
+		return exists, nil
+	}
+
+	reduceFn := func(ctx context.Context, prev, v interface{}) interface{} {
+		other, _ := prev.(uint64)
+		return other + v.(uint64)
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return 0, err
+	}
+	n, _ := result.(uint64)
+	return n, nil
+}
+
+// countBoolRow counts the columns of a row in a shard, among those of filter
+// if it isn't nil. Without a filter, it counts the row's containers.
+func (e *executor) countBoolRow(tx Tx, idx *Index, fieldName string, rowID, shard uint64, filter *Row) (uint64, error) {
+	if filter == nil {
+		return tx.CountRange(idx.Name(), fieldName, viewStandard, shard, rowID*ShardWidth, (rowID+1)*ShardWidth)
+	}
+	frag := e.Holder.fragment(idx.Name(), fieldName, viewStandard, shard)
+	if frag == nil {
+		return 0, nil
+	}
+	row, err := frag.row(tx, rowID)
+	if err != nil {
+		return 0, err
+	}
+	return row.intersectionCount(filter), nil
+}
+
+// executeBoolNullShard returns the columns in a shard which exist but have
+// no value for a bool field.
+func (e *executor) executeBoolNullShard(ctx context.Context, qcx *Qcx, idx *Index, f *Field, shard uint64) (_ *Row, err0 error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeBoolNullShard")
+	defer span.Finish()
+
+	if idx.existenceField() == nil {
+		return nil, errors.New("Row() with a null bool value requires an index which tracks existence")
+	}
+	tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Shard: shard})
+	if err != nil {
+		return nil, err
+	}
+	defer finisher(&err0)
+
+	row, err := e.existenceRowShard(ctx, tx, idx, shard)
+	if err != nil {
+		return nil, err
+	}
+	if frag := e.Holder.fragment(idx.Name(), f.Name(), viewStandard, shard); frag != nil {
+		for _, id := range []uint64{trueRowID, falseRowID} {
+			r, err := frag.row(tx, id)
+			if err != nil {
+				return nil, err
+			}
+			row = row.Difference(r)
+		}
+	}
+	if qcx.write {
+		row = row.Clone()
+	}
+	return row, nil
+}
+
+// executeSetBoolNull executes a Set() call setting a bool field to null, by
+// clearing the column's value.
+func (e *executor) executeSetBoolNull(ctx context.Context, qcx *Qcx, index string, c *pql.Call, f *Field, colID uint64, opt *ExecOptions) (bool, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeSetBoolNull")
+	defer span.Finish()
+
+	shard := colID / ShardWidth
+	snap := e.Cluster.NewSnapshot()
+
+	ret := false
+	for _, node := range snap.ShardNodes(index, shard) {
+		// Update locally if host matches.
+		if node.ID == e.Node.ID {
+			for _, id := range []uint64{trueRowID, falseRowID} {
+				val, err := f.ClearBit(qcx, id, colID)
+				if err != nil {
+					return false, err
+				} else if val {
+					ret = true
+				}
+			}
+			continue
+		}
+		// Do not forward call if this is already being forwarded.
+		if opt.Remote {
+			continue
+		}
+
+		// Forward call to remote node otherwise.
+		res, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil, nil, 0)
+		if err != nil {
+			return false, err
+		}
+		ret = res[0].(bool)
+	}
+	return ret, nil
+}
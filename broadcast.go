@@ -72,6 +72,12 @@ const (
 	messageTypeUNUSED2 // used to be ResizeNodeMessage
 	messageTypeUNUSED3 // used to be ResizeAbortMessage
 	messageTypeUpdateField
+	messageTypeSetRowMeta
+	messageTypeSetAlias
+	messageTypeCloneIndex
+	messageTypeUpdateIndex
+	messageTypeMaintenance
+	messageTypeSettings
 )
 
 // MarshalInternalMessage serializes the pilosa message and adds pilosa internal
@@ -117,6 +123,18 @@ func getMessage(typ byte) Message {
 		return &TransactionMessage{}
 	case messageTypeUpdateField:
 		return &UpdateFieldMessage{}
+	case messageTypeSetRowMeta:
+		return &SetRowMetaMessage{}
+	case messageTypeSetAlias:
+		return &SetAliasMessage{}
+	case messageTypeCloneIndex:
+		return &CloneIndexMessage{}
+	case messageTypeUpdateIndex:
+		return &UpdateIndexMessage{}
+	case messageTypeMaintenance:
+		return &MaintenanceMessage{}
+	case messageTypeSettings:
+		return &SettingsMessage{}
 	default:
 		panic(fmt.Sprintf("unknown message type %d", typ))
 	}
@@ -154,6 +172,18 @@ func getMessageType(m Message) byte {
 		return messageTypeTransaction
 	case *UpdateFieldMessage:
 		return messageTypeUpdateField
+	case *SetRowMetaMessage:
+		return messageTypeSetRowMeta
+	case *SetAliasMessage:
+		return messageTypeSetAlias
+	case *CloneIndexMessage:
+		return messageTypeCloneIndex
+	case *UpdateIndexMessage:
+		return messageTypeUpdateIndex
+	case *MaintenanceMessage:
+		return messageTypeMaintenance
+	case *SettingsMessage:
+		return messageTypeSettings
 	default:
 		panic(fmt.Sprintf("don't have type for message %#v", m))
 	}
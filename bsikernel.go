@@ -0,0 +1,540 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"math/big"
+	"math/bits"
+	"sort"
+
+	"github.com/featurebasedb/featurebase/v3/roaring"
+)
+
+// The BSI aggregates and range scans work through a fragment one container
+// (65,536 columns) at a time, treating the container of each bit slice as a
+// dense block of 1,024 words. The kernels below are simple passes over
+// those blocks, which the compiler keeps free of bounds checks and compiles
+// to hardware popcount instructions where available. Working a container at
+// a time keeps a handful of blocks in cache, rather than building a Row for
+// every bit slice of the whole shard.
+
+// bsiBlockWords is the number of 64-bit words in a container.
+const bsiBlockWords = 1024
+
+// containersPerShard is the number of containers in one row of a shard.
+const containersPerShard = ShardWidth >> 16
+
+// bsiBlock is a container's worth of bits.
+type bsiBlock = [bsiBlockWords]uint64
+
+// zeroBlock is an empty block. It must not be modified.
+var zeroBlock bsiBlock
+
+// blockCopy sets dst to src.
+func blockCopy(dst, src *bsiBlock) {
+	*dst = *src
+}
+
+// blockZero clears dst.
+func blockZero(dst *bsiBlock) {
+	*dst = zeroBlock
+}
+
+// blockAnd sets dst to dst AND src.
+func blockAnd(dst, src *bsiBlock) {
+	for i := range dst {
+		dst[i] &= src[i]
+	}
+}
+
+// blockAndNot sets dst to dst AND NOT src.
+func blockAndNot(dst, src *bsiBlock) {
+	for i := range dst {
+		dst[i] &^= src[i]
+	}
+}
+
+// blockOr sets dst to dst OR src.
+func blockOr(dst, src *bsiBlock) {
+	for i := range dst {
+		dst[i] |= src[i]
+	}
+}
+
+// blockXor sets dst to dst XOR src.
+func blockXor(dst, src *bsiBlock) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+// blockAndInto sets dst to a AND b, and reports whether any bits are set.
+func blockAndInto(dst, a, b *bsiBlock) bool {
+	var acc uint64
+	for i := range dst {
+		dst[i] = a[i] & b[i]
+		acc |= dst[i]
+	}
+	return acc != 0
+}
+
+// blockAndNotInto sets dst to a AND NOT b, and reports whether any bits are
+// set.
+func blockAndNotInto(dst, a, b *bsiBlock) bool {
+	var acc uint64
+	for i := range dst {
+		dst[i] = a[i] &^ b[i]
+		acc |= dst[i]
+	}
+	return acc != 0
+}
+
+// blockAny reports whether any bits are set in b.
+func blockAny(b *bsiBlock) bool {
+	var acc uint64
+	for i := 0; i < bsiBlockWords; i += 4 {
+		acc |= b[i] | b[i+1] | b[i+2] | b[i+3]
+	}
+	return acc != 0
+}
+
+// blockCount returns the number of bits set in b.
+func blockCount(b *bsiBlock) uint64 {
+	// Independent accumulators let the popcounts run in parallel.
+	var n0, n1, n2, n3 int
+	for i := 0; i < bsiBlockWords; i += 4 {
+		n0 += bits.OnesCount64(b[i])
+		n1 += bits.OnesCount64(b[i+1])
+		n2 += bits.OnesCount64(b[i+2])
+		n3 += bits.OnesCount64(b[i+3])
+	}
+	return uint64(n0 + n1 + n2 + n3)
+}
+
+// blockAndCount returns the number of bits set in both a and b.
+func blockAndCount(a, b *bsiBlock) uint64 {
+	var n0, n1, n2, n3 int
+	for i := 0; i < bsiBlockWords; i += 4 {
+		n0 += bits.OnesCount64(a[i] & b[i])
+		n1 += bits.OnesCount64(a[i+1] & b[i+1])
+		n2 += bits.OnesCount64(a[i+2] & b[i+2])
+		n3 += bits.OnesCount64(a[i+3] & b[i+3])
+	}
+	return uint64(n0 + n1 + n2 + n3)
+}
+
+// blockAndCount2 returns the number of bits set in both a and c, and in
+// both b and c, reading c once.
+func blockAndCount2(a, b, c *bsiBlock) (uint64, uint64) {
+	var na, nb int
+	for i := range c {
+		na += bits.OnesCount64(a[i] & c[i])
+		nb += bits.OnesCount64(b[i] & c[i])
+	}
+	return uint64(na), uint64(nb)
+}
+
+// blockAddCounts adds one to the count of each position whose bit is set
+// in b.
+func blockAddCounts(b *bsiBlock, counts *[1 << 16]uint32) {
+	for i, w := range b {
+		for w != 0 {
+			counts[i*64+bits.TrailingZeros64(w)]++
+			w &= w - 1
+		}
+	}
+}
+
+// blockPositions appends the positions of the bits set in b to pos.
+func blockPositions(b *bsiBlock, pos []uint16) []uint16 {
+	for i, w := range b {
+		for w != 0 {
+			pos = append(pos, uint16(i*64+bits.TrailingZeros64(w)))
+			w &= w - 1
+		}
+	}
+	return pos
+}
+
+// addShifted128 adds v<<shift, for shift up to 64, to the unsigned 128-bit
+// integer hi:lo.
+func addShifted128(hi, lo, v, shift uint64) (uint64, uint64) {
+	var carry uint64
+	lo, carry = bits.Add64(lo, v<<shift, 0)
+	hi, _ = bits.Add64(hi, v>>(64-shift), carry)
+	return hi, lo
+}
+
+// uint128ToBig returns the unsigned 128-bit integer hi:lo as a big.Int.
+func uint128ToBig(hi, lo uint64) *big.Int {
+	v := new(big.Int).SetUint64(hi)
+	return v.Lsh(v, 64).Or(v, new(big.Int).SetUint64(lo))
+}
+
+// containerBlock returns the bits of c as a block, using buf if c isn't
+// already a bitmap container. The result must not be modified, since it
+// may be c's own storage. A nil container gives nil.
+func containerBlock(c *roaring.Container, buf *bsiBlock) *bsiBlock {
+	if c == nil || c.N() == 0 {
+		return nil
+	}
+	return (*bsiBlock)(c.AsBitmap(buf[:]))
+}
+
+// sliceBlock returns container k of the given row of the fragment as a
+// block, or nil if the container is empty. The result must not be
+// modified.
+func (f *fragment) sliceBlock(tx Tx, rowID, k uint64, buf *bsiBlock) (*bsiBlock, error) {
+	c, err := tx.Container(f.index(), f.field(), f.view(), f.shard, rowID*containersPerShard+k)
+	if err != nil {
+		return nil, err
+	}
+	return containerBlock(c, buf), nil
+}
+
+// rowBlock copies container k of the fragment's shard of r into dst, and
+// reports whether it has any bits set.
+func (f *fragment) rowBlock(r *Row, k uint64, dst, buf *bsiBlock) bool {
+	seg := r.segment(f.shard)
+	if seg == nil {
+		return false
+	}
+	src := containerBlock(seg.data.Containers.Get(f.shard*containersPerShard+k), buf)
+	if src == nil {
+		return false
+	}
+	blockCopy(dst, src)
+	return true
+}
+
+// bsiScan builds a row by running kernel over each container of the
+// fragment's shard of filter, with rem holding a copy of the filter's
+// container. The kernel may modify rem and tmp, uses buf for loading bit
+// slices, and leaves the columns to include in out, which starts empty.
+func (f *fragment) bsiScan(filter *Row, kernel func(k uint64, rem, out, tmp, buf *bsiBlock) error) (*Row, error) {
+	var rem, out, tmp, buf bsiBlock
+	data := roaring.NewSliceBitmap()
+	for k := uint64(0); k < containersPerShard; k++ {
+		if !f.rowBlock(filter, k, &rem, &buf) {
+			continue
+		}
+		blockZero(&out)
+		if err := kernel(k, &rem, &out, &tmp, &buf); err != nil {
+			return nil, err
+		}
+		if n := blockCount(&out); n > 0 {
+			words := make([]uint64, bsiBlockWords)
+			copy(words, out[:])
+			data.Put(f.shard*containersPerShard+k, roaring.NewContainerBitmap(int(n), words))
+		}
+	}
+	data.Optimize()
+
+	row := &Row{
+		segments: []rowSegment{{
+			data:     data,
+			shard:    f.shard,
+			writable: true,
+		}},
+	}
+	row.invalidateCount()
+	return row, nil
+}
+
+// blockMaxUnsigned narrows cand, the columns of container k to consider,
+// to those with the highest value in the low bitDepth bits. It returns
+// that value, and the block now holding the narrowed columns, which is
+// either cand or tmp.
+func (f *fragment) blockMaxUnsigned(tx Tx, k uint64, cand, tmp, buf *bsiBlock, bitDepth uint64) (uint64, *bsiBlock, error) {
+	var max uint64
+	for i := int(bitDepth - 1); i >= 0; i-- {
+		row, err := f.sliceBlock(tx, uint64(bsiOffsetBit+i), k, buf)
+		if err != nil {
+			return 0, nil, err
+		} else if row == nil {
+			continue
+		}
+		if blockAndInto(tmp, cand, row) {
+			cand, tmp = tmp, cand
+			max |= 1 << uint(i)
+		}
+	}
+	return max, cand, nil
+}
+
+// blockMinUnsigned narrows cand, the columns of container k to consider,
+// to those with the lowest value in the low bitDepth bits. It returns that
+// value, and the block now holding the narrowed columns, which is either
+// cand or tmp.
+func (f *fragment) blockMinUnsigned(tx Tx, k uint64, cand, tmp, buf *bsiBlock, bitDepth uint64) (uint64, *bsiBlock, error) {
+	var min uint64
+	for i := int(bitDepth - 1); i >= 0; i-- {
+		row, err := f.sliceBlock(tx, uint64(bsiOffsetBit+i), k, buf)
+		if err != nil {
+			return 0, nil, err
+		} else if row == nil {
+			continue
+		}
+		if blockAndNotInto(tmp, cand, row) {
+			cand, tmp = tmp, cand
+		} else {
+			min |= 1 << uint(i)
+		}
+	}
+	return min, cand, nil
+}
+
+// bsiSparseLimit is the number of columns in a container below which
+// narrowing them down column by column is cheaper than working with
+// blocks.
+const bsiSparseLimit = 1024
+
+// sparseExtremeUnsigned narrows cand, a few sorted columns of container
+// k, to those with the highest (or lowest) value in the low bitDepth bits.
+// It returns that value and the narrowed columns, using tmp and buf for
+// scratch.
+func (f *fragment) sparseExtremeUnsigned(tx Tx, k uint64, cand, tmp []uint16, buf *bsiBlock, bitDepth uint64, highest bool) (uint64, []uint16, error) {
+	var v uint64
+	for i := int(bitDepth - 1); i >= 0; i-- {
+		c, err := tx.Container(f.index(), f.field(), f.view(), f.shard, uint64(bsiOffsetBit+i)*containersPerShard+k)
+		if err != nil {
+			return 0, nil, err
+		}
+		// Keep the columns with a one bit here for the highest value, or
+		// with a zero bit for the lowest. Sparse bit slices are merged
+		// with the columns, and dense ones probed.
+		tmp = tmp[:0]
+		if c != nil && c.N() >= roaring.ArrayMaxSize {
+			words := containerBlock(c, buf)
+			for _, pos := range cand {
+				if (words[pos/64]>>(pos%64))&1 == 1 == highest {
+					tmp = append(tmp, pos)
+				}
+			}
+		} else {
+			vals := c.Slice()
+			j := 0
+			for _, pos := range cand {
+				for j < len(vals) && vals[j] < pos {
+					j++
+				}
+				if (j < len(vals) && vals[j] == pos) == highest {
+					tmp = append(tmp, pos)
+				}
+			}
+		}
+		switch {
+		case len(tmp) > 0:
+			cand, tmp = tmp, cand
+			if highest {
+				v |= 1 << uint(i)
+			}
+		case !highest:
+			v |= 1 << uint(i)
+		}
+	}
+	return v, cand, nil
+}
+
+// extremeUnsigned finds the highest (or lowest) value in the low bitDepth
+// bits of the columns in filter, and the number of columns with it. Each
+// container is narrowed down separately, and the results combined.
+// Containers with few columns are narrowed column by column.
+func (f *fragment) extremeUnsigned(tx Tx, filter *Row, bitDepth uint64, highest bool) (value int64, count uint64, err error) {
+	var cand, tmp, buf bsiBlock
+	positions := make([]uint16, 0, bsiSparseLimit)
+	scratch := make([]uint16, 0, bsiSparseLimit)
+	var best uint64
+	for k := uint64(0); k < containersPerShard; k++ {
+		if !f.rowBlock(filter, k, &cand, &buf) {
+			continue
+		}
+		var v, n uint64
+		if n = blockCount(&cand); n < bsiSparseLimit {
+			var narrowed []uint16
+			v, narrowed, err = f.sparseExtremeUnsigned(tx, k, blockPositions(&cand, positions[:0]), scratch[:0], &buf, bitDepth, highest)
+			if err != nil {
+				return 0, 0, err
+			}
+			n = uint64(len(narrowed))
+		} else {
+			var narrowed *bsiBlock
+			if highest {
+				v, narrowed, err = f.blockMaxUnsigned(tx, k, &cand, &tmp, &buf, bitDepth)
+			} else {
+				v, narrowed, err = f.blockMinUnsigned(tx, k, &cand, &tmp, &buf, bitDepth)
+			}
+			if err != nil {
+				return 0, 0, err
+			}
+			n = blockCount(narrowed)
+		}
+		switch {
+		case n == 0:
+		case count == 0 || (highest && v > best) || (!highest && v < best):
+			best, count = v, n
+		case v == best:
+			count += n
+		}
+	}
+	return int64(best), count, nil
+}
+
+// blockLTUnsigned adds to out the columns of rem with values less than
+// predicate in the low bitDepth bits. It may modify rem and tmp.
+func (f *fragment) blockLTUnsigned(tx Tx, k uint64, rem, out, tmp, buf *bsiBlock, bitDepth, predicate uint64) error {
+	for i := int(bitDepth - 1); i >= 0 && predicate > 0; i-- {
+		row, err := f.sliceBlock(tx, uint64(bsiOffsetBit+i), k, buf)
+		if err != nil {
+			return err
+		}
+		bit := (predicate >> uint(i)) & 1
+		if row == nil {
+			if bit == 1 {
+				// Everything left has a zero bit here.
+				blockOr(out, rem)
+				return nil
+			}
+			continue
+		}
+		zeroes := blockAndNotInto(tmp, rem, row)
+		switch bit {
+		case 1:
+			// Match everything with a zero bit here, and carry on with
+			// the rest.
+			blockOr(out, tmp)
+			predicate &^= 1 << uint(i)
+			blockAnd(rem, row)
+		case 0:
+			// Discard everything with a one bit here.
+			if !zeroes {
+				return nil
+			}
+			rem, tmp = tmp, rem
+		}
+	}
+	return nil
+}
+
+// blockGTUnsigned adds to out the columns of rem with values greater than
+// predicate in the low bitDepth bits. It may modify rem and tmp.
+func (f *fragment) blockGTUnsigned(tx Tx, k uint64, rem, out, tmp, buf *bsiBlock, bitDepth, predicate uint64) error {
+	predicate |= (^uint64(0)) << bitDepth
+	for i := int(bitDepth - 1); i >= 0 && predicate < ^uint64(0); i-- {
+		row, err := f.sliceBlock(tx, uint64(bsiOffsetBit+i), k, buf)
+		if err != nil {
+			return err
+		}
+		bit := (predicate >> uint(i)) & 1
+		if row == nil {
+			if bit == 1 {
+				// Nothing left has a one bit here.
+				return nil
+			}
+			predicate |= 1 << uint(i)
+			continue
+		}
+		ones := blockAndInto(tmp, rem, row)
+		switch bit {
+		case 1:
+			// Discard everything with a zero bit here.
+			if !ones {
+				return nil
+			}
+			rem, tmp = tmp, rem
+		case 0:
+			// Match everything with a one bit here, and carry on with
+			// the rest.
+			blockOr(out, tmp)
+			predicate |= 1 << uint(i)
+			blockAndNot(rem, row)
+		}
+	}
+	return nil
+}
+
+// blockNarrowEQ narrows rem to the columns whose bits from lo up to
+// bitDepth match those of predicate, and reports whether any are left.
+func (f *fragment) blockNarrowEQ(tx Tx, k uint64, rem, buf *bsiBlock, bitDepth, lo, predicate uint64) (bool, error) {
+	for i := int(bitDepth - 1); i >= int(lo); i-- {
+		row, err := f.sliceBlock(tx, uint64(bsiOffsetBit+i), k, buf)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case row == nil:
+			if (predicate>>uint(i))&1 == 1 {
+				return false, nil
+			}
+		case (predicate>>uint(i))&1 == 1:
+			if !blockAndInto(rem, rem, row) {
+				return false, nil
+			}
+		default:
+			if !blockAndNotInto(rem, rem, row) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// blockNarrowIn adds to out the columns of cand whose low n bits match
+// those of any of predicates, given the container's bit slices, which may
+// be nil. The predicates must be sorted and share their bits from n up,
+// so they split into those with a zero and those with a one at bit n-1.
+// levels[i] holds the columns narrowed down to bit i.
+func blockNarrowIn(slices []*bsiBlock, levels []bsiBlock, cand, out *bsiBlock, n int, predicates []uint64) {
+	if n == 0 {
+		blockOr(out, cand)
+		return
+	}
+	i := n - 1
+	ones := sort.Search(len(predicates), func(j int) bool {
+		return (predicates[j]>>uint(i))&1 == 1
+	})
+	next := &levels[i]
+	if ones > 0 {
+		if slices[i] == nil {
+			blockNarrowIn(slices, levels, cand, out, i, predicates[:ones])
+		} else if blockAndNotInto(next, cand, slices[i]) {
+			blockNarrowIn(slices, levels, next, out, i, predicates[:ones])
+		}
+	}
+	if ones < len(predicates) && slices[i] != nil && blockAndInto(next, cand, slices[i]) {
+		blockNarrowIn(slices, levels, next, out, i, predicates[ones:])
+	}
+}
+
+// blockAnyZero adds to out the columns of rem with a zero in any of the low
+// bitDepth bits.
+func (f *fragment) blockAnyZero(tx Tx, k uint64, rem, out, tmp, buf *bsiBlock, bitDepth uint64) error {
+	for i := uint64(0); i < bitDepth; i++ {
+		row, err := f.sliceBlock(tx, bsiOffsetBit+i, k, buf)
+		if err != nil {
+			return err
+		} else if row == nil {
+			blockOr(out, rem)
+			return nil
+		}
+		blockAndNotInto(tmp, rem, row)
+		blockOr(out, tmp)
+	}
+	return nil
+}
+
+// blockAnyOne adds to out the columns of rem with a one in any of the low
+// bitDepth bits.
+func (f *fragment) blockAnyOne(tx Tx, k uint64, rem, out, tmp, buf *bsiBlock, bitDepth uint64) error {
+	for i := uint64(0); i < bitDepth; i++ {
+		row, err := f.sliceBlock(tx, bsiOffsetBit+i, k, buf)
+		if err != nil {
+			return err
+		} else if row == nil {
+			continue
+		}
+		blockAndInto(tmp, rem, row)
+		blockOr(out, tmp)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+// mustImportRandomValues imports n random values between -max and max into
+// f, spread across the shard so that they fill several containers, and
+// returns them by column.
+func mustImportRandomValues(tb testing.TB, f *fragment, tx Tx, rnd *rand.Rand, n int, bitDepth uint64, max int64) map[uint64]int64 {
+	values := make(map[uint64]int64, n)
+	for len(values) < n {
+		values[uint64(rnd.Int63n(ShardWidth))] = rnd.Int63n(2*max+1) - max
+	}
+	cols := make([]uint64, 0, n)
+	for col := range values {
+		cols = append(cols, col)
+	}
+	sort.Slice(cols, func(i, j int) bool { return cols[i] < cols[j] })
+	vals := make([]int64, len(cols))
+	for i, col := range cols {
+		vals[i] = values[col]
+	}
+	if err := f.importValue(tx, cols, vals, bitDepth, false); err != nil {
+		tb.Fatalf("importing values: %v", err)
+	}
+	return values
+}
+
+func TestFragment_BSIKernels(t *testing.T) {
+	const bitDepth = 10
+
+	f, _, tx := mustOpenFragment(t)
+	defer f.Clean(t)
+
+	rnd := rand.New(rand.NewSource(7))
+	values := mustImportRandomValues(t, f, tx, rnd, 5000, bitDepth, 1000)
+
+	// The filter includes half the columns with values, and some without.
+	var filterCols []uint64
+	for col := range values {
+		if rnd.Intn(2) == 0 {
+			filterCols = append(filterCols, col)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		filterCols = append(filterCols, uint64(rnd.Int63n(ShardWidth)))
+	}
+	filter := NewRow(filterCols...)
+
+	matching := func(filt *Row, fn func(v int64) bool) []uint64 {
+		cols := []uint64{}
+		for col, v := range values {
+			if (filt == nil || filt.Includes(col)) && fn(v) {
+				cols = append(cols, col)
+			}
+		}
+		sort.Slice(cols, func(i, j int) bool { return cols[i] < cols[j] })
+		return cols
+	}
+
+	t.Run("Sum", func(t *testing.T) {
+		for _, filt := range []*Row{nil, filter} {
+			var expSum int64
+			var expCount uint64
+			for col, v := range values {
+				if filt == nil || filt.Includes(col) {
+					expSum += v
+					expCount++
+				}
+			}
+			if sum, n, err := f.sum(tx, filt, bitDepth); err != nil {
+				t.Fatal(err)
+			} else if sum.Int64() != expSum || n != expCount {
+				t.Fatalf("expected sum %d count %d, got %d %d", expSum, expCount, sum, n)
+			}
+		}
+	})
+
+	t.Run("MinMax", func(t *testing.T) {
+		for _, filt := range []*Row{nil, filter} {
+			var min, max int64
+			var minCount, maxCount uint64
+			for col, v := range values {
+				if filt != nil && !filt.Includes(col) {
+					continue
+				}
+				if minCount == 0 || v < min {
+					min, minCount = v, 1
+				} else if v == min {
+					minCount++
+				}
+				if maxCount == 0 || v > max {
+					max, maxCount = v, 1
+				} else if v == max {
+					maxCount++
+				}
+			}
+			if v, n, err := f.min(tx, filt, bitDepth); err != nil {
+				t.Fatal(err)
+			} else if v != min || n != minCount {
+				t.Fatalf("expected min %d count %d, got %d %d", min, minCount, v, n)
+			}
+			if v, n, err := f.max(tx, filt, bitDepth); err != nil {
+				t.Fatal(err)
+			} else if v != max || n != maxCount {
+				t.Fatalf("expected max %d count %d, got %d %d", max, maxCount, v, n)
+			}
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		for _, filt := range []*Row{nil, filter} {
+			for _, predicate := range []int64{-1023, -1000, -513, -1, 0, 1, 2, 511, 512, 999, 1023} {
+				for op, fn := range map[pql.Token]func(v int64) bool{
+					pql.EQ:  func(v int64) bool { return v == predicate },
+					pql.NEQ: func(v int64) bool { return v != predicate },
+					pql.LT:  func(v int64) bool { return v < predicate },
+					pql.LTE: func(v int64) bool { return v <= predicate },
+					pql.GT:  func(v int64) bool { return v > predicate },
+					pql.GTE: func(v int64) bool { return v >= predicate },
+				} {
+					row, err := f.rangeOp(tx, filt, op, bitDepth, predicate)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if got, exp := row.Columns(), matching(filt, fn); !reflect.DeepEqual(got, exp) {
+						t.Fatalf("%s %d: expected %d columns, got %d", op, predicate, len(exp), len(got))
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("Between", func(t *testing.T) {
+		for _, filt := range []*Row{nil, filter} {
+			for _, bounds := range [][2]int64{{-1000, 1000}, {-500, -20}, {-3, 3}, {0, 17}, {100, 900}, {513, 514}, {7, 7}} {
+				row, err := f.rangeBetween(tx, filt, bitDepth, bounds[0], bounds[1])
+				if err != nil {
+					t.Fatal(err)
+				}
+				exp := matching(filt, func(v int64) bool { return v >= bounds[0] && v <= bounds[1] })
+				if got := row.Columns(); !reflect.DeepEqual(got, exp) {
+					t.Fatalf("between %v: expected %d columns, got %d", bounds, len(exp), len(got))
+				}
+			}
+		}
+	})
+
+	t.Run("In", func(t *testing.T) {
+		for _, filt := range []*Row{nil, filter} {
+			for _, predicates := range [][]int64{{}, {7}, {-1000, 1000}, {-3, 3, 0, -3}, {1, 2, 3, 4, 5, 6, 7, 8}, {-512, -513, 511, 512, 5000}} {
+				row, err := f.rangeIn(tx, filt, bitDepth, predicates)
+				if err != nil {
+					t.Fatal(err)
+				}
+				exp := matching(filt, func(v int64) bool {
+					for _, p := range predicates {
+						if v == p {
+							return true
+						}
+					}
+					return false
+				})
+				if got := row.Columns(); !reflect.DeepEqual(got, exp) {
+					t.Fatalf("in %v: expected %d columns, got %d", predicates, len(exp), len(got))
+				}
+			}
+		}
+	})
+}
+
+// Ensure sums of values near the limits of an int64 don't overflow.
+func TestFragment_SumWide(t *testing.T) {
+	const bitDepth = 63
+
+	f, _, tx := mustOpenFragment(t)
+	defer f.Clean(t)
+
+	rnd := rand.New(rand.NewSource(3))
+	values := mustImportRandomValues(t, f, tx, rnd, 3000, bitDepth, math.MaxInt64>>1)
+	exp := new(big.Int)
+	for _, v := range values {
+		exp.Add(exp, big.NewInt(v))
+	}
+	if sum, n, err := f.sum(tx, nil, bitDepth); err != nil {
+		t.Fatal(err)
+	} else if sum.Cmp(exp) != 0 || n != uint64(len(values)) {
+		t.Fatalf("expected sum %s count %d, got %s %d", exp, len(values), sum, n)
+	}
+}
+
+func BenchmarkFragment_BSI(b *testing.B) {
+	const bitDepth = 20
+
+	for _, n := range []int{10000, ShardWidth / 2} {
+		f, _, tx := mustOpenFragment(b)
+		rnd := rand.New(rand.NewSource(7))
+		mustImportRandomValues(b, f, tx, rnd, n, bitDepth, 1<<bitDepth-1)
+
+		b.Run(fmt.Sprintf("Sum_%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := f.sum(tx, nil, bitDepth); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Max_%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := f.max(tx, nil, bitDepth); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("GT_%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := f.rangeOp(tx, nil, pql.GT, bitDepth, 12345); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Between_%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := f.rangeBetween(tx, nil, bitDepth, -54321, 12345); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("In_%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := f.rangeIn(tx, nil, bitDepth, []int64{-54321, -5, 17, 12345, 12346, 99999}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		f.Clean(b)
+	}
+}
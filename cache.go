@@ -38,6 +38,18 @@ type cache interface {
 	// Rebuilds the cache.
 	Recalculate()
 
+	// SetStaleness sets how long changes may go unreflected in Top, when
+	// the cache is maintained in the background. If zero, the cache is
+	// brought up to date when Top is called.
+	SetStaleness(d time.Duration)
+
+	// Maintain rebuilds the cache if it has changed since it was last
+	// built.
+	Maintain()
+
+	// Stale reports whether there are changes not yet reflected in Top.
+	Stale() bool
+
 	// Returns an ordered list of the top ranked bitmaps.
 	Top() []bitmapPair
 
@@ -96,6 +108,15 @@ func (c *lruCache) Invalidate() {}
 // Recalculate is a no-op.
 func (c *lruCache) Recalculate() {}
 
+// SetStaleness is a no-op.
+func (c *lruCache) SetStaleness(time.Duration) {}
+
+// Maintain is a no-op.
+func (c *lruCache) Maintain() {}
+
+// Stale always returns false.
+func (c *lruCache) Stale() bool { return false }
+
 // IDs returns a list of all IDs in the cache.
 func (c *lruCache) IDs() []uint64 {
 	a := make([]uint64, 0, len(c.counts))
@@ -146,6 +167,13 @@ type rankCache struct {
 	rankingsRead bool
 	dirty        bool
 
+	// dirtyTime is when the cache first changed after it was last built.
+	dirtyTime time.Time
+
+	// staleness is how long changes may go unreflected in the rankings,
+	// when the cache is maintained in the background.
+	staleness time.Duration
+
 	updateN    int
 	updateTime time.Time
 
@@ -181,6 +209,7 @@ func (c *rankCache) Clear() {
 	c.rankings = c.rankings[:0]
 	c.rankingsRead = false
 	c.dirty = false
+	c.dirtyTime = time.Time{}
 
 	c.updateN = 0
 	c.updateTime = time.Time{}
@@ -194,7 +223,7 @@ func (c *rankCache) Add(id uint64, n uint64) {
 
 	// Flag the cache as dirty.
 	// This forces recalculation if top is called before the cache is recalculated.
-	c.dirty = true
+	c.markDirty()
 
 	// Ignore if the column count is below the threshold,
 	// unless the count is 0, which is effectively used
@@ -206,7 +235,10 @@ func (c *rankCache) Add(id uint64, n uint64) {
 
 	c.entries[id] = n
 
-	c.invalidate()
+	// A maintained cache is recalculated in the background instead.
+	if c.staleness == 0 {
+		c.invalidate()
+	}
 }
 
 // BulkAdd adds a count to the cache unsorted. You should Invalidate after completion.
@@ -216,7 +248,7 @@ func (c *rankCache) BulkAdd(id uint64, n uint64) {
 
 	// Flag the cache as dirty.
 	// This forces recalculation if top is called before the cache is recalculated.
-	c.dirty = true
+	c.markDirty()
 
 	if n < c.thresholdValue {
 		delete(c.entries, id)
@@ -263,10 +295,14 @@ func (c *rankCache) IDs() []uint64 {
 	return ids
 }
 
-// Invalidate recalculates the entries by rank.
+// Invalidate recalculates the entries by rank. It's a no-op if the cache
+// is maintained in the background.
 func (c *rankCache) Invalidate() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.staleness > 0 {
+		return
+	}
 	c.invalidate()
 }
 
@@ -278,6 +314,39 @@ func (c *rankCache) Recalculate() {
 	c.recalculate()
 }
 
+// SetStaleness sets how long changes may go unreflected in the rankings.
+func (c *rankCache) SetStaleness(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.staleness = d
+}
+
+// Maintain recalculates the rankings if the cache has changed.
+func (c *rankCache) Maintain() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return
+	}
+	c.stats.Count(MetricMaintainCache, 1, 1.0)
+	c.recalculate()
+}
+
+// Stale reports whether there are changes not yet reflected in the
+// rankings.
+func (c *rankCache) Stale() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.dirty
+}
+
+// markDirty flags the cache as changed since it was last built.
+func (c *rankCache) markDirty() {
+	if !c.dirty {
+		c.dirty, c.dirtyTime = true, time.Now()
+	}
+}
+
 func (c *rankCache) invalidate() {
 	// Don't invalidate more than once every X seconds.
 	// TODO: consider making this configurable.
@@ -288,7 +357,7 @@ func (c *rankCache) invalidate() {
 		// This may cause unexpected memory growth, so record it in metrics for debugging purposes.
 		c.stats.Count(MetricInvalidateCacheSkipped, 1, 1.0)
 		// Ensure that we're marked as dirty even if we weren't otherwise.
-		c.dirty = true
+		c.markDirty()
 		return
 	}
 	c.stats.Count(MetricInvalidateCache, 1, 1.0)
@@ -353,8 +422,10 @@ func (c *rankCache) Top() []bitmapPair {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.dirty {
-		// The cache is dirty, so we need to recalculate it to get a consistent view.
+	// The cache is dirty, so we need to recalculate it to get a consistent
+	// view, unless it's maintained in the background and the changes are
+	// within its staleness budget.
+	if c.dirty && (c.staleness == 0 || time.Since(c.dirtyTime) >= c.staleness) {
 		c.stats.Count(MetricReadDirtyCache, 1, 1.0)
 		c.recalculate()
 	}
@@ -363,6 +434,24 @@ func (c *rankCache) Top() []bitmapPair {
 	return c.rankings
 }
 
+// contents describes the cache as it stands, with up to n of its top
+// entries, without recalculating its rankings.
+func (c *rankCache) contents(n int) FragmentCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fc := FragmentCache{
+		Entries:   len(c.entries),
+		Stale:     c.dirty,
+		Threshold: c.thresholdValue,
+		Top:       topPairs(c.rankings, n),
+	}
+	if c.dirty {
+		since := c.dirtyTime
+		fc.StaleSince = &since
+	}
+	return fc
+}
+
 // WriteTo writes the cache to w.
 func (c *rankCache) WriteTo(w io.Writer) (n int64, err error) {
 	panic("FIXME: TODO")
@@ -526,14 +615,27 @@ func (p Pairs) String() string {
 type PairsField struct {
 	Pairs []Pair
 	Field string
+
+	// Meta optionally holds the row metadata for each pair.
+	Meta []json.RawMessage
+
+	// Stale is set if the pairs were ranked by a cache which is maintained
+	// in the background, and which had changes not yet reflected in its
+	// rankings; they may not be exactly the top rows.
+	Stale bool
 }
 
 func (p *PairsField) Clone() (r *PairsField) {
 	r = &PairsField{
 		Pairs: make([]Pair, len(p.Pairs)),
 		Field: p.Field,
+		Stale: p.Stale,
 	}
 	copy(r.Pairs, p.Pairs)
+	if p.Meta != nil {
+		r.Meta = make([]json.RawMessage, len(p.Meta))
+		copy(r.Meta, p.Meta)
+	}
 	return
 }
 
@@ -588,7 +690,18 @@ func (p *PairsField) ToRows(callback func(*pb.RowResponse) error) error {
 // MarshalJSON marshals PairsField into a JSON-encoded byte slice,
 // excluding `Field`.
 func (p PairsField) MarshalJSON() ([]byte, error) {
-	return json.Marshal(p.Pairs)
+	if p.Meta == nil {
+		return json.Marshal(p.Pairs)
+	}
+	type pairMeta struct {
+		Pair
+		Meta json.RawMessage `json:"meta,omitempty"`
+	}
+	pairs := make([]pairMeta, len(p.Pairs))
+	for i := range p.Pairs {
+		pairs[i] = pairMeta{Pair: p.Pairs[i], Meta: p.Meta[i]}
+	}
+	return json.Marshal(pairs)
 }
 
 // int64Slice represents a sortable slice of int64 numbers.
@@ -623,6 +736,9 @@ func (c nopCache) IDs() []uint64          { return []uint64{} }
 func (c nopCache) Invalidate()                {}
 func (c nopCache) Len() int                   { return 0 }
 func (c nopCache) Recalculate()               {}
+func (c nopCache) SetStaleness(time.Duration) {}
+func (c nopCache) Maintain()                  {}
+func (c nopCache) Stale() bool                { return false }
 func (c nopCache) SetStats(stats.StatsClient) {}
 func (c nopCache) Clear()                     {}
 
@@ -5,6 +5,7 @@ package pilosa_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	pilosa "github.com/featurebasedb/featurebase/v3"
 )
@@ -83,3 +84,27 @@ func TestCache_Rank_BulkAdd(t *testing.T) {
 		}
 	}
 }
+
+// Ensure a cache maintained in the background only reflects changes in Top
+// once it's maintained, or once they're older than its staleness budget.
+func TestCache_Rank_Staleness(t *testing.T) {
+	cache := pilosa.NewRankCache(5)
+	cache.SetStaleness(time.Hour)
+
+	cache.Add(1, 5)
+	if top := cache.Top(); len(top) != 0 || !cache.Stale() {
+		t.Fatalf("expected stale empty rankings, got %v", top)
+	}
+	cache.Maintain()
+	if top := cache.Top(); len(top) != 1 || top[0].ID != 1 || cache.Stale() {
+		t.Fatalf("expected fresh rankings, got %v", top)
+	}
+
+	// Changes older than the budget are reflected when Top is called.
+	cache.SetStaleness(time.Nanosecond)
+	cache.Add(2, 7)
+	time.Sleep(time.Millisecond)
+	if top := cache.Top(); len(top) != 2 || top[0].ID != 2 || cache.Stale() {
+		t.Fatalf("expected rankings to be recalculated, got %v", top)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Besides years, months, days and hours, a time quantum may include weeks
+// (W) and quarters (Q), as in "YQMWD". Week views are named for ISO weeks,
+// as in standard_2022w09, and start on Mondays. Quarter views are named for
+// the fiscal year they fall in, as in standard_2022q1; fiscal years start in
+// January unless a field sets FiscalYearStart, and are named for the
+// calendar year in which they end. Ranges of time covering whole weeks or
+// quarters are read from their views instead of those of the days or months
+// in them.
+
+// HasQuarter returns true if the quantum contains a 'Q' unit.
+func (q TimeQuantum) HasQuarter() bool { return strings.ContainsRune(string(q), 'Q') }
+
+// HasWeek returns true if the quantum contains a 'W' unit.
+func (q TimeQuantum) HasWeek() bool { return strings.ContainsRune(string(q), 'W') }
+
+// baseUnits returns the quantum without its week and quarter units.
+func (q TimeQuantum) baseUnits() TimeQuantum {
+	return TimeQuantum(strings.Map(func(r rune) rune {
+		if r == 'Q' || r == 'W' {
+			return -1
+		}
+		return r
+	}, string(q)))
+}
+
+// hasCalendarUnits returns true if the quantum contains a week or quarter
+// unit.
+func (q TimeQuantum) hasCalendarUnits() bool { return q.HasQuarter() || q.HasWeek() }
+
+// calendarUnits lists the time units from longest to shortest.
+const calendarUnits = "YQMWDH"
+
+// OptFieldFiscalYearStart is a functional option on FieldOptions used to set
+// the month in which a time field's fiscal years, and so its quarters,
+// start.
+func OptFieldFiscalYearStart(month time.Month) FieldOption {
+	return func(fo *FieldOptions) error {
+		if month < time.January || month > time.December {
+			return errors.Errorf("invalid fiscal year start month: %d", month)
+		}
+		fo.FiscalYearStart = month
+		return nil
+	}
+}
+
+// fiscalQuarter returns the fiscal year and quarter in which t falls, with
+// fiscal years starting in fy.
+func fiscalQuarter(t time.Time, fy time.Month) (year, quarter int) {
+	if fy == 0 {
+		fy = time.January
+	}
+	year = t.Year()
+	if fy != time.January && t.Month() >= fy {
+		year++
+	}
+	return year, (int(t.Month()-fy)+12)%12/3 + 1
+}
+
+// periodStart returns the start of the period of a time unit in which t
+// falls.
+func periodStart(t time.Time, unit rune, fy time.Month) time.Time {
+	y, m, d := t.Date()
+	switch unit {
+	case 'Y':
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, t.Location())
+	case 'Q':
+		if fy == 0 {
+			fy = time.January
+		}
+		m -= time.Month((int(m-fy) + 12) % 3)
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case 'M':
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case 'W':
+		return time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case 'D':
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location())
+	}
+}
+
+// periodEnd returns the end of the period of a time unit starting at start.
+func periodEnd(start time.Time, unit rune) time.Time {
+	switch unit {
+	case 'Y':
+		return start.AddDate(1, 0, 0)
+	case 'Q':
+		return start.AddDate(0, 3, 0)
+	case 'M':
+		return start.AddDate(0, 1, 0)
+	case 'W':
+		return start.AddDate(0, 0, 7)
+	case 'D':
+		return start.AddDate(0, 0, 1)
+	default:
+		return start.Add(time.Hour)
+	}
+}
+
+// viewByCalendarUnit returns the view name for time with a given quantum
+// unit, including weeks and quarters.
+func viewByCalendarUnit(name string, t time.Time, unit rune, fy time.Month) string {
+	switch unit {
+	case 'Q':
+		y, q := fiscalQuarter(t, fy)
+		return fmt.Sprintf("%s_%04dq%d", name, y, q)
+	case 'W':
+		y, w := t.ISOWeek()
+		return fmt.Sprintf("%s_%04dw%02d", name, y, w)
+	default:
+		return viewByTimeUnit(name, t, unit)
+	}
+}
+
+// calendarViewsByTime returns the week and quarter views, if any, for a
+// given timestamp. The rest come from viewsByTime.
+func calendarViewsByTime(name string, t time.Time, q TimeQuantum, fy time.Month) []string {
+	var a []string
+	for _, unit := range q {
+		if unit == 'Q' || unit == 'W' {
+			a = append(a, viewByCalendarUnit(name, t, unit, fy))
+		}
+	}
+	return a
+}
+
+// calendarViewsByTimeRange returns a list of views to traverse to query a
+// time range with a quantum including weeks or quarters. At each point it
+// takes the view of the longest period starting there which ends by end,
+// or else that of the shortest period including it, so a range is rounded
+// out to the periods of the quantum's shortest unit.
+func calendarViewsByTimeRange(name string, start, end time.Time, q TimeQuantum, fy time.Month) []string {
+	var units []rune
+	for _, unit := range calendarUnits {
+		if strings.ContainsRune(string(q), unit) {
+			units = append(units, unit)
+		}
+	}
+	if len(units) == 0 {
+		return nil
+	}
+	shortest := units[len(units)-1]
+
+	var results []string
+	for t := start; t.Before(end); {
+		unit, next := shortest, time.Time{}
+		for _, u := range units {
+			if s := periodStart(t, u, fy); s.Equal(t) {
+				if e := periodEnd(s, u); !e.After(end) {
+					unit, next = u, e
+					break
+				}
+			}
+		}
+		if next.IsZero() {
+			next = periodEnd(periodStart(t, unit, fy), unit)
+		}
+		results = append(results, viewByCalendarUnit(name, t, unit, fy))
+		t = next
+	}
+	return results
+}
+
+// timeOfCalendarView returns the start, or if adj is true the end, of the
+// period of a week or quarter view, with fiscal years starting in fy. ok is
+// false if the view isn't one.
+func timeOfCalendarView(v string, fy time.Month, adj bool) (t time.Time, ok bool, err error) {
+	part := v[strings.LastIndexByte(v, '_')+1:]
+	if len(part) < 6 || (part[4] != 'q' && part[4] != 'w') {
+		return t, false, nil
+	}
+	year, err := strconv.Atoi(part[:4])
+	if err != nil {
+		return t, false, nil
+	}
+	n, err := strconv.Atoi(part[5:])
+	if err != nil {
+		return t, false, nil
+	}
+
+	var unit rune
+	switch part[4] {
+	case 'q':
+		if n < 1 || n > 4 {
+			return t, true, errors.Errorf("invalid quarter on view: %s", v)
+		}
+		if fy == 0 {
+			fy = time.January
+		}
+		if fy != time.January {
+			year--
+		}
+		unit, t = 'Q', time.Date(year, fy+time.Month(3*(n-1)), 1, 0, 0, 0, 0, time.UTC)
+	case 'w':
+		if n < 1 || n > 53 {
+			return t, true, errors.Errorf("invalid week on view: %s", v)
+		}
+		// ISO week 1 is the one including January 4th.
+		unit, t = 'W', periodStart(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC), 'W', fy).AddDate(0, 0, 7*(n-1))
+	}
+	if adj {
+		t = periodEnd(t, unit)
+	}
+	return t, true, nil
+}
+
+// timeOfView returns the start, or if adj is true the end, of the period of
+// one of the field's time views, including week and quarter views.
+func (f *Field) timeOfView(v string, adj bool) (time.Time, error) {
+	if t, ok, err := timeOfCalendarView(v, f.options.FiscalYearStart, adj); ok {
+		return t, err
+	}
+	return timeOfView(v, adj)
+}
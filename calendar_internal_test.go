@@ -0,0 +1,107 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeQuantum_ValidCalendar(t *testing.T) {
+	for q, exp := range map[TimeQuantum]bool{
+		"YQMWDH": true,
+		"YMWD":   true,
+		"YQ":     true,
+		"WD":     true,
+		"QM":     true,
+		"W":      false,
+		"Q":      false,
+		"YWM":    false,
+		"QY":     false,
+		"YQQ":    false,
+		"YWH":    false,
+	} {
+		if q.Valid() != exp {
+			t.Errorf("%q: expected valid %v", q, exp)
+		}
+	}
+}
+
+func TestViewByCalendarUnit(t *testing.T) {
+	for i, tt := range []struct {
+		t    time.Time
+		unit rune
+		fy   time.Month
+		exp  string
+	}{
+		{mustParseTime("2022-03-02 10:00"), 'W', 0, "F_2022w09"},
+		{mustParseTime("2021-01-03 10:00"), 'W', 0, "F_2020w53"},
+		{mustParseTime("2024-12-30 00:00"), 'W', 0, "F_2025w01"},
+		{mustParseTime("2022-03-31 23:00"), 'Q', 0, "F_2022q1"},
+		{mustParseTime("2022-04-01 00:00"), 'Q', 0, "F_2022q2"},
+		{mustParseTime("2022-09-30 00:00"), 'Q', time.October, "F_2022q4"},
+		{mustParseTime("2022-10-01 00:00"), 'Q', time.October, "F_2023q1"},
+		{mustParseTime("2023-01-15 00:00"), 'Q', time.October, "F_2023q2"},
+		{mustParseTime("2022-01-15 00:00"), 'Q', time.February, "F_2022q4"},
+		{mustParseTime("2022-03-15 00:00"), 'D', 0, "F_20220315"},
+	} {
+		if v := viewByCalendarUnit("F", tt.t, tt.unit, tt.fy); v != tt.exp {
+			t.Errorf("%d. expected %s, got %s", i, tt.exp, v)
+		}
+	}
+}
+
+func TestCalendarViewsByTimeRange(t *testing.T) {
+	for i, tt := range []struct {
+		from, to string
+		q        TimeQuantum
+		fy       time.Month
+		exp      []string
+	}{
+		// Monday to Monday.
+		{"2022-02-28 00:00", "2022-03-07 00:00", "YMWD", 0, []string{"F_2022w09"}},
+		{"2022-02-26 00:00", "2022-03-15 00:00", "YMWD", 0, []string{
+			"F_20220226", "F_20220227", "F_2022w09", "F_2022w10", "F_20220314",
+		}},
+		// A whole month is read from its view rather than its weeks.
+		{"2022-08-01 00:00", "2022-09-06 00:00", "YMWD", 0, []string{"F_202208", "F_20220901", "F_20220902", "F_20220903", "F_20220904", "F_20220905"}},
+		{"2022-01-01 00:00", "2023-01-01 00:00", "YQM", 0, []string{"F_2022"}},
+		{"2022-01-01 00:00", "2022-08-01 00:00", "YQM", 0, []string{"F_2022q1", "F_2022q2", "F_202207"}},
+		// A fiscal year starting in October.
+		{"2022-10-01 00:00", "2023-10-01 00:00", "YQM", time.October, []string{"F_2023q1", "F_2023q2", "F_2023q3", "F_2023q4"}},
+		{"2022-11-01 00:00", "2023-04-01 00:00", "YQM", time.October, []string{"F_202211", "F_202212", "F_2023q2"}},
+		// Rounded out to whole weeks.
+		{"2022-03-02 00:00", "2022-03-10 00:00", "YW", 0, []string{"F_2022w09", "F_2022w10"}},
+	} {
+		if v := calendarViewsByTimeRange("F", mustParseTime(tt.from), mustParseTime(tt.to), tt.q, tt.fy); !reflect.DeepEqual(v, tt.exp) {
+			t.Errorf("%d. expected %v, got %v", i, tt.exp, v)
+		}
+	}
+}
+
+func TestTimeOfCalendarView(t *testing.T) {
+	for i, tt := range []struct {
+		view       string
+		fy         time.Month
+		start, end string
+	}{
+		{"F_2022w09", 0, "2022-02-28 00:00", "2022-03-07 00:00"},
+		{"F_2020w53", 0, "2020-12-28 00:00", "2021-01-04 00:00"},
+		{"F_2022q2", 0, "2022-04-01 00:00", "2022-07-01 00:00"},
+		{"F_2023q2", time.October, "2023-01-01 00:00", "2023-04-01 00:00"},
+		{"F_2023q1", time.October, "2022-10-01 00:00", "2023-01-01 00:00"},
+	} {
+		start, ok, err := timeOfCalendarView(tt.view, tt.fy, false)
+		if !ok || err != nil || !start.Equal(mustParseTime(tt.start)) {
+			t.Errorf("%d. expected start %s, got %v, %v, %v", i, tt.start, start, ok, err)
+		}
+		end, _, _ := timeOfCalendarView(tt.view, tt.fy, true)
+		if !end.Equal(mustParseTime(tt.end)) {
+			t.Errorf("%d. expected end %s, got %v", i, tt.end, end)
+		}
+	}
+	if _, ok, _ := timeOfCalendarView("F_2022030412", 0, false); ok {
+		t.Error("expected an hour view not to be a calendar view")
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/logger"
+	"github.com/pkg/errors"
+)
+
+// An execution path is a way of executing queries, selected by a query's
+// ExecPath. Paths other than the current one are experimental, or kept
+// for reference, so that changes to the executor can be checked against
+// them. Every node a query reaches executes its shards on the query's path.
+//
+// In canary mode, a node executes a sample of the read-only queries it
+// coordinates a second time, on the canary path, after responding, and
+// logs each query whose results differ, with the query, its shards and
+// both results, so it can be reproduced. Writes between the two executions
+// can cause mismatches too.
+
+// Execution paths.
+const (
+	// ExecPathCurrent is the path queries take by default.
+	ExecPathCurrent = ""
+
+	// ExecPathUnplanned computes the inputs of Intersect() in the order
+	// they're given, without the planner, and computes comparisons of BSI
+	// fields over whole shards.
+	ExecPathUnplanned = "unplanned"
+)
+
+// validateExecPath returns an error if path isn't an execution path.
+func validateExecPath(path string) error {
+	switch path {
+	case ExecPathCurrent, ExecPathUnplanned:
+		return nil
+	}
+	return NewBadRequestError(errors.Errorf("unknown execution path %q", path))
+}
+
+type contextKeyExecPathType struct{}
+
+var contextKeyExecPath = contextKeyExecPathType{}
+
+// withExecPath returns a context for a query executing on path.
+func withExecPath(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, contextKeyExecPath, path)
+}
+
+// execPathFromContext returns the execution path of the query running in
+// ctx.
+func execPathFromContext(ctx context.Context) string {
+	path, _ := ctx.Value(contextKeyExecPath).(string)
+	return path
+}
+
+// Limits on canary executions.
+const (
+	// maxCanaryInFlight is the number of canary executions which can run
+	// at once. Queries sampled while that many run are skipped.
+	maxCanaryInFlight = 4
+
+	// canaryTimeout bounds each canary execution.
+	canaryTimeout = time.Minute
+
+	// maxCanaryMismatches is the number of recent mismatches reported.
+	maxCanaryMismatches = 100
+
+	// maxCanaryLogResult is the length results are truncated to in logs.
+	maxCanaryLogResult = 1024
+)
+
+// CanaryMismatch is a query whose results differed on the canary path.
+type CanaryMismatch struct {
+	Time   time.Time `json:"time"`
+	Index  string    `json:"index"`
+	Query  string    `json:"query"`
+	Shards []uint64  `json:"shards,omitempty"`
+	Path   string    `json:"path"`
+
+	// Current and Canary are the results of the query, as JSON, on the
+	// current path and the canary path. Error is set instead of Canary if
+	// the query failed on the canary path.
+	Current json.RawMessage `json:"current"`
+	Canary  json.RawMessage `json:"canary,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// CanaryReport describes the queries this node has executed on a canary
+// path.
+type CanaryReport struct {
+	Path       string  `json:"path"`
+	SampleRate float64 `json:"sampleRate"`
+
+	// Queries is the number of queries executed on both paths, of which
+	// Mismatches had different results. Skipped is the number of sampled
+	// queries which weren't executed, as too many others were.
+	Queries    uint64 `json:"queries"`
+	Mismatches uint64 `json:"mismatches"`
+	Skipped    uint64 `json:"skipped"`
+
+	// Recent are the most recent mismatches, oldest first.
+	Recent []CanaryMismatch `json:"recent"`
+}
+
+// canary executes a sample of queries on a canary path, and compares their
+// results. A nil canary executes nothing.
+type canary struct {
+	path string
+	rate float64
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	inFlight chan struct{}
+	wg       sync.WaitGroup
+	report   CanaryReport
+
+	logger logger.Logger
+}
+
+// newCanary returns a canary sampling queries at rate to execute on path,
+// or nil if rate is zero.
+func newCanary(path string, rate float64, logger logger.Logger) (*canary, error) {
+	if rate == 0 {
+		return nil, nil
+	} else if rate < 0 || rate > 1 {
+		return nil, errors.Errorf("canary sample rate must be between 0 and 1, not %v", rate)
+	} else if path == ExecPathCurrent {
+		return nil, errors.New("canary requires an execution path other than the current one")
+	} else if err := validateExecPath(path); err != nil {
+		return nil, err
+	}
+	return &canary{
+		path:     path,
+		rate:     rate,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		inFlight: make(chan struct{}, maxCanaryInFlight),
+		report:   CanaryReport{Path: path, SampleRate: rate},
+		logger:   logger,
+	}, nil
+}
+
+// check executes a query on the canary path, in the background, if it's
+// sampled, and compares its results with resp, the results of the query
+// on the current path. exec executes a request.
+func (c *canary) check(req *QueryRequest, resp QueryResponse, exec func(context.Context, *QueryRequest) (QueryResponse, error)) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	sampled := c.rand.Float64() < c.rate
+	c.mu.Unlock()
+	if !sampled {
+		return
+	}
+	select {
+	case c.inFlight <- struct{}{}:
+	default:
+		c.mu.Lock()
+		c.report.Skipped++
+		c.mu.Unlock()
+		return
+	}
+	// The results are encoded now, before the response is written.
+	current, err := json.Marshal(resp.Results)
+	if err != nil {
+		<-c.inFlight
+		c.logger.Errorf("canary: encoding results: %v", err)
+		return
+	}
+	creq := &QueryRequest{
+		Index:              req.Index,
+		Query:              req.Query,
+		Shards:             req.Shards,
+		PreTranslated:      req.PreTranslated,
+		EmbeddedData:       req.EmbeddedData,
+		MaxMemory:          req.MaxMemory,
+		IgnoreResultLimits: req.IgnoreResultLimits,
+		IncludeRowMeta:     req.IncludeRowMeta,
+		ExistenceFallback:  req.ExistenceFallback,
+		Priority:           QueryPriorityBackground,
+		ExecPath:           c.path,
+	}
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer func() { <-c.inFlight }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), canaryTimeout)
+		defer cancel()
+		cresp, err := exec(ctx, creq)
+		if err == nil {
+			err = cresp.Err
+		}
+		c.compare(creq, current, cresp, err)
+	}()
+}
+
+// compare records the results of a query on the canary path, and reports
+// them if they differ from the current results.
+func (c *canary) compare(req *QueryRequest, current []byte, resp QueryResponse, err error) {
+	m := CanaryMismatch{
+		Time:    time.Now().UTC(),
+		Index:   req.Index,
+		Query:   req.Query,
+		Shards:  req.Shards,
+		Path:    c.path,
+		Current: current,
+	}
+	if err != nil {
+		m.Error = err.Error()
+	} else if m.Canary, err = json.Marshal(resp.Results); err != nil {
+		m.Error = "encoding results: " + err.Error()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.Queries++
+	if m.Error == "" && string(m.Canary) == string(m.Current) {
+		return
+	}
+	c.report.Mismatches++
+	if len(c.report.Recent) == maxCanaryMismatches {
+		c.report.Recent = append(c.report.Recent[:0], c.report.Recent[1:]...)
+	}
+	c.report.Recent = append(c.report.Recent, m)
+
+	canaryResult := truncateString(string(m.Canary), maxCanaryLogResult)
+	if m.Error != "" {
+		canaryResult = "error: " + m.Error
+	}
+	c.logger.Warnf("canary mismatch on path %q: index=%s query=%q shards=%v current=%s canary=%s",
+		c.path, m.Index, m.Query, m.Shards, truncateString(string(m.Current), maxCanaryLogResult), canaryResult)
+}
+
+// Report returns a report of the queries executed on the canary path.
+func (c *canary) Report() *CanaryReport {
+	if c == nil {
+		return &CanaryReport{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	report := c.report
+	report.Recent = append([]CanaryMismatch{}, c.report.Recent...)
+	return &report
+}
+
+// Close waits for canary executions in progress.
+func (c *canary) Close() {
+	if c == nil {
+		return
+	}
+	c.wg.Wait()
+}
+
+// truncateString returns s, cut to at most n bytes.
+func truncateString(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+// Canary returns a report of the queries this node has executed on its
+// canary path.
+func (api *API) Canary(ctx context.Context) (*CanaryReport, error) {
+	if err := api.validate(apiCanary); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.server.canary.Report(), nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/featurebasedb/featurebase/v3/logger"
+	"github.com/pkg/errors"
+)
+
+func TestCanary_Compare(t *testing.T) {
+	logs := logger.NewCaptureLogger()
+	c, err := newCanary(ExecPathUnplanned, 1, logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &QueryRequest{Index: "i", Query: "Count(All())", Shards: []uint64{1}}
+	current := QueryResponse{Results: []interface{}{uint64(3)}}
+
+	for _, canaryResult := range []struct {
+		results []interface{}
+		err     error
+	}{
+		{results: []interface{}{uint64(3)}},
+		{results: []interface{}{uint64(4)}},
+		{err: errors.New("boom")},
+	} {
+		c.check(req, current, func(ctx context.Context, creq *QueryRequest) (QueryResponse, error) {
+			if creq.ExecPath != ExecPathUnplanned || creq.Query != req.Query {
+				t.Errorf("unexpected canary request %+v", creq)
+			}
+			return QueryResponse{Results: canaryResult.results}, canaryResult.err
+		})
+		c.Close()
+	}
+
+	report := c.Report()
+	if report.Queries != 3 || report.Mismatches != 2 || len(report.Recent) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if m := report.Recent[0]; string(m.Current) != "[3]" || string(m.Canary) != "[4]" || m.Query != req.Query || m.Shards[0] != 1 {
+		t.Fatalf("unexpected mismatch %+v", m)
+	} else if m := report.Recent[1]; m.Error != "boom" {
+		t.Fatalf("expected canary error, got %+v", m)
+	}
+	if len(logs.Prints) != 2 || !strings.Contains(logs.Prints[0], `query="Count(All())"`) {
+		t.Fatalf("expected mismatches to be logged, got %v", logs.Prints)
+	}
+
+	if _, err := newCanary(ExecPathCurrent, 1, logs); err == nil {
+		t.Fatal("expected error for a canary on the current path")
+	} else if c, err := newCanary("", 0, logs); err != nil || c != nil {
+		t.Fatalf("expected no canary, got %v, %v", c, err)
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"strings"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/featurebasedb/featurebase/v3/roaring"
+	"github.com/pkg/errors"
+)
+
+// CardinalityOf counts the bits each column has set in a set, mutex or time
+// field, that is, the number of the field's rows it's in, so that clients
+// needn't maintain an int field alongside the field to hold the count. As a
+// row call with a condition, as in CardinalityOf(tags > 5), it returns the
+// columns whose counts satisfy the condition; conditions which zero
+// satisfies include the columns which exist but have no bits set. As a
+// child of Extract, as in Extract(All(), CardinalityOf(field=tags)), it
+// adds an int64 column of the counts. Counts are found a shard at a time by
+// adding up the bits of each row of the field, a container of columns at a
+// time.
+
+// cardinalityExtractPrefix prefixes the field names of CardinalityOf
+// columns of an Extract, which can't be the names of fields.
+const cardinalityExtractPrefix = "CardinalityOf("
+
+// cardinalityExtractName returns the name of the Extract column of the
+// counts of field.
+func cardinalityExtractName(field string) string {
+	return cardinalityExtractPrefix + field + ")"
+}
+
+// cardinalityExtractField returns the field whose counts the Extract
+// column of the given name holds, if it's a CardinalityOf column.
+func cardinalityExtractField(name string) (string, bool) {
+	if !strings.HasPrefix(name, cardinalityExtractPrefix) || !strings.HasSuffix(name, ")") {
+		return "", false
+	}
+	return name[len(cardinalityExtractPrefix) : len(name)-1], true
+}
+
+// cardinalityCondition returns the field and condition of a CardinalityOf
+// row call.
+func cardinalityCondition(c *pql.Call) (string, *pql.Condition, error) {
+	if len(c.Args) != 1 {
+		return "", nil, errors.New("CardinalityOf() requires a single condition, as in CardinalityOf(f > 1)")
+	}
+	for field, arg := range c.Args {
+		if cond, ok := arg.(*pql.Condition); ok {
+			return field, cond, nil
+		}
+	}
+	return "", nil, errors.New("CardinalityOf() requires a condition, as in CardinalityOf(f > 1)")
+}
+
+// cardinalityMatcher returns a function reporting whether a count
+// satisfies cond.
+func cardinalityMatcher(cond *pql.Condition) (func(uint64) bool, error) {
+	switch cond.Op {
+	case pql.EQ, pql.NEQ, pql.LT, pql.LTE, pql.GT, pql.GTE:
+		v, ok := cond.Int64Value()
+		if !ok {
+			return nil, errors.Errorf("CardinalityOf(): expected an integer, got %v", cond.Value)
+		}
+		switch cond.Op {
+		case pql.EQ:
+			return func(n uint64) bool { return int64(n) == v }, nil
+		case pql.NEQ:
+			return func(n uint64) bool { return int64(n) != v }, nil
+		case pql.LT:
+			return func(n uint64) bool { return int64(n) < v }, nil
+		case pql.LTE:
+			return func(n uint64) bool { return int64(n) <= v }, nil
+		case pql.GT:
+			return func(n uint64) bool { return int64(n) > v }, nil
+		default:
+			return func(n uint64) bool { return int64(n) >= v }, nil
+		}
+	case pql.BETWEEN, pql.BTWN_LT_LTE, pql.BTWN_LTE_LT, pql.BTWN_LT_LT:
+		v, ok := cond.Int64SliceValue()
+		if !ok || len(v) != 2 {
+			return nil, errors.Errorf("CardinalityOf(): expected two integers, got %v", cond.Value)
+		}
+		lo, hi := v[0], v[1]
+		if cond.Op == pql.BTWN_LT_LTE || cond.Op == pql.BTWN_LT_LT {
+			lo++
+		}
+		if cond.Op == pql.BTWN_LTE_LT || cond.Op == pql.BTWN_LT_LT {
+			hi--
+		}
+		return func(n uint64) bool { return int64(n) >= lo && int64(n) <= hi }, nil
+	case pql.IN:
+		v, ok := cond.Int64SliceValue()
+		if !ok {
+			return nil, errors.Errorf("CardinalityOf(): expected a list of integers, got %v", cond.Value)
+		}
+		set := make(map[int64]struct{}, len(v))
+		for _, n := range v {
+			set[n] = struct{}{}
+		}
+		return func(n uint64) bool {
+			_, ok := set[int64(n)]
+			return ok
+		}, nil
+	}
+	return nil, errors.Errorf("CardinalityOf(): unsupported operator %s", cond.Op)
+}
+
+// cardinalityMatchesZero reports whether a CardinalityOf row call
+// includes columns with no bits set, which needs existence tracking.
+func cardinalityMatchesZero(c *pql.Call) bool {
+	_, cond, err := cardinalityCondition(c)
+	if err != nil {
+		return false
+	}
+	match, err := cardinalityMatcher(cond)
+	return err == nil && match(0)
+}
+
+// cardinalityField returns the field CardinalityOf counts the bits of.
+func (e *executor) cardinalityField(index, name string) (*Field, error) {
+	field := e.Holder.Field(index, name)
+	if field == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, name)
+	}
+	switch field.Type() {
+	case FieldTypeSet, FieldTypeMutex, FieldTypeTime:
+		return field, nil
+	}
+	return nil, NewBadRequestError(errors.Errorf("CardinalityOf(): field %s is of type %s, expected set, mutex or time", name, field.Type()))
+}
+
+// cardinalityCounter counts the bits of each column of a container of a
+// fragment's rows.
+type cardinalityCounter struct {
+	f      *fragment
+	tx     Tx
+	rows   []uint64
+	counts [1 << 16]uint32
+	buf    bsiBlock
+}
+
+// newCardinalityCounter returns a counter of the bits of each column of the
+// fragment, which may be nil if the shard has no bits in the field.
+func newCardinalityCounter(ctx context.Context, tx Tx, f *fragment) (*cardinalityCounter, error) {
+	cc := &cardinalityCounter{f: f, tx: tx}
+	if f == nil {
+		return cc, nil
+	}
+	rows, err := f.rows(ctx, tx, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing rows")
+	}
+	cc.rows = rows
+	return cc, nil
+}
+
+// count sets cc.counts to the counts of the bits of each column of
+// container k.
+func (cc *cardinalityCounter) count(k uint64) error {
+	cc.counts = [1 << 16]uint32{}
+	for _, rowID := range cc.rows {
+		b, err := cc.f.sliceBlock(cc.tx, rowID, k, &cc.buf)
+		if err != nil {
+			return errors.Wrap(err, "reading row")
+		} else if b != nil {
+			blockAddCounts(b, &cc.counts)
+		}
+	}
+	return nil
+}
+
+// executeCardinalityOfShard executes a CardinalityOf row call on a shard.
+func (e *executor) executeCardinalityOfShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (_ *Row, err0 error) {
+	fieldName, cond, err := cardinalityCondition(c)
+	if err != nil {
+		return nil, err
+	}
+	match, err := cardinalityMatcher(cond)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := e.cardinalityField(index, fieldName); err != nil {
+		return nil, err
+	}
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, index)
+	}
+
+	tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Shard: shard})
+	if err != nil {
+		return nil, err
+	}
+	defer finisher(&err0)
+
+	// Columns with no bits can only be found among those which exist.
+	var exists *Row
+	if match(0) {
+		if exists, err = e.existenceRowShard(ctx, tx, idx, shard); err != nil {
+			return nil, err
+		}
+	}
+
+	cc, err := newCardinalityCounter(ctx, tx, e.Holder.fragment(index, fieldName, viewStandard, shard))
+	if err != nil {
+		return nil, err
+	}
+	if len(cc.rows) == 0 && exists == nil {
+		return NewRow(), nil
+	}
+
+	data := roaring.NewSliceBitmap()
+	var out, existsBuf bsiBlock
+	var existing *bsiBlock
+	for k := uint64(0); k < containersPerShard; k++ {
+		if exists != nil {
+			existing = nil
+			if seg := exists.segment(shard); seg != nil {
+				existing = containerBlock(seg.data.Containers.Get(shard*containersPerShard+k), &existsBuf)
+			}
+			if existing == nil && len(cc.rows) == 0 {
+				continue
+			}
+		}
+		if err := cc.count(k); err != nil {
+			return nil, err
+		}
+		blockZero(&out)
+		for i, n := range cc.counts {
+			if n == 0 && (existing == nil || existing[i/64]&(1<<(i%64)) == 0) {
+				continue
+			}
+			if match(uint64(n)) {
+				out[i/64] |= 1 << (i % 64)
+			}
+		}
+		if n := blockCount(&out); n > 0 {
+			words := make([]uint64, bsiBlockWords)
+			copy(words, out[:])
+			data.Put(shard*containersPerShard+k, roaring.NewContainerBitmap(int(n), words))
+		}
+	}
+	return NewRowFromBitmap(data), nil
+}
+
+// cardinalitiesShard sets the CardinalityOf column i of the matrix m of an
+// Extract to the counts of the bits of the field in each column, on a
+// shard.
+func (e *executor) cardinalitiesShard(ctx context.Context, tx Tx, index, fieldName string, shard uint64, m []ExtractedIDColumn, i int) error {
+	if _, err := e.cardinalityField(index, fieldName); err != nil {
+		return err
+	}
+	cc, err := newCardinalityCounter(ctx, tx, e.Holder.fragment(index, fieldName, viewStandard, shard))
+	if err != nil {
+		return err
+	}
+	// Unless sorted by a field, the columns are in order, so each
+	// container is counted once.
+	k := uint64(containersPerShard)
+	for j := range m {
+		n := uint64(0)
+		if len(cc.rows) > 0 {
+			col := m[j].ColumnID % ShardWidth
+			if col>>16 != k {
+				k = col >> 16
+				if err := cc.count(k); err != nil {
+					return err
+				}
+			}
+			n = uint64(cc.counts[col&0xffff])
+		}
+		m[j].Rows[i] = []uint64{n}
+	}
+	return nil
+}
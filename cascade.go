@@ -0,0 +1,205 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"strings"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// A field referencing a foreign index can cascade deletes from it: when
+// Delete() removes records from the foreign index, the field's references
+// to them are either cleared, with the action "clear", or flagged, with the
+// action "flag:<field>", which sets the referencing records' value of the
+// named bool field to true. Cascades run after the records are deleted,
+// and each child field is written with its own queries, so a failure can
+// leave some references in place.
+
+// CascadeClear is the cascade action which clears references to deleted
+// records.
+const CascadeClear = "clear"
+
+// cascadeFlagPrefix prefixes the bool field named by a flag cascade action.
+const cascadeFlagPrefix = "flag:"
+
+// cascadeBatchSize is the number of deleted records whose references are
+// found, and written, by each query.
+const cascadeBatchSize = 1000
+
+// OptFieldCascade is a functional option on FieldOptions used to set what
+// deleting records in the field's foreign index does to its references to
+// them.
+func OptFieldCascade(action string) FieldOption {
+	return func(fo *FieldOptions) error {
+		if _, err := parseCascade(action); err != nil {
+			return err
+		}
+		fo.Cascade = action
+		return nil
+	}
+}
+
+// parseCascade returns the bool field a cascade action flags references in,
+// or "" if it clears them.
+func parseCascade(action string) (string, error) {
+	if action == CascadeClear {
+		return "", nil
+	} else if strings.HasPrefix(action, cascadeFlagPrefix) {
+		if flag := strings.TrimPrefix(action, cascadeFlagPrefix); flag != "" {
+			return flag, nil
+		}
+	}
+	return "", errors.Errorf("invalid cascade action %q: must be %q or %q followed by a bool field", action, CascadeClear, cascadeFlagPrefix)
+}
+
+// validateCascade checks that a field with options fo can cascade deletes as
+// its cascade action says.
+func (i *Index) validateCascade(fo *FieldOptions) error {
+	if fo.Cascade == "" {
+		return nil
+	} else if fo.ForeignIndex == "" {
+		return errors.New("cascade requires a foreign index")
+	}
+	switch fo.Type {
+	case "", FieldTypeSet, FieldTypeMutex, FieldTypeInt:
+	default:
+		return errors.Errorf("fields of type %q can't cascade", fo.Type)
+	}
+	flag, err := parseCascade(fo.Cascade)
+	if err != nil || flag == "" {
+		return err
+	}
+	f := i.Field(flag)
+	if f == nil {
+		return errors.Wrapf(ErrFieldNotFound, "cascade flag %q", flag)
+	} else if f.Type() != FieldTypeBool {
+		return errors.Errorf("cascade flag %q is of type %q, not bool", flag, f.Type())
+	}
+	return nil
+}
+
+// cascadingFields returns the fields which cascade deletes from an index.
+func (h *Holder) cascadingFields(index string) []*Field {
+	var fields []*Field
+	for _, idx := range h.Indexes() {
+		for _, f := range idx.Fields() {
+			if f.options.Cascade != "" && f.options.ForeignIndex == index {
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
+
+// cascadeDelete cascades the deletion of records from an index to the
+// fields referencing them.
+func (e *executor) cascadeDelete(ctx context.Context, index string, cols []uint64) error {
+	fields := e.Holder.cascadingFields(index)
+	if len(fields) == 0 || len(cols) == 0 {
+		return nil
+	}
+	// The references hold the records' IDs, even when the index is keyed,
+	// and their keys are gone once they're deleted, so the cascade's calls
+	// are executed without translation.
+	refs := make([]interface{}, len(cols))
+	for i, col := range cols {
+		refs[i] = col
+	}
+
+	for _, f := range fields {
+		for start := 0; start < len(refs); start += cascadeBatchSize {
+			end := start + cascadeBatchSize
+			if end > len(refs) {
+				end = len(refs)
+			}
+			if err := e.cascadeField(ctx, f, refs[start:end]); err != nil {
+				return errors.Wrapf(err, "cascading to field %q in index %q", f.Name(), f.Index())
+			}
+		}
+	}
+	return nil
+}
+
+// cascadeField clears or flags a field's references to deleted records.
+func (e *executor) cascadeField(ctx context.Context, f *Field, refs []interface{}) error {
+	flag, err := parseCascade(f.options.Cascade)
+	if err != nil {
+		return err
+	}
+
+	var calls []*pql.Call
+	if flag == "" && f.Type() != FieldTypeInt {
+		// A reference in a set or mutex field is a row.
+		for _, ref := range refs {
+			calls = append(calls, &pql.Call{Name: "ClearRow", Args: map[string]interface{}{f.Name(): ref}})
+		}
+	} else {
+		results, err := e.cascadeExecute(ctx, f.Index(), false, foreignRefsCall(f, refs))
+		if err != nil {
+			return errors.Wrap(err, "finding references")
+		}
+		row, _ := results[0].(*Row)
+		if row == nil {
+			return nil
+		}
+		for _, col := range row.Columns() {
+			if flag == "" {
+				calls = append(calls, &pql.Call{Name: "Clear", Args: map[string]interface{}{"_" + columnLabel: col, f.Name(): int64(0)}})
+			} else {
+				calls = append(calls, &pql.Call{Name: "Set", Args: map[string]interface{}{"_" + columnLabel: col, flag: trueRowID}})
+			}
+		}
+	}
+	if len(calls) == 0 {
+		return nil
+	}
+	_, err = e.cascadeExecute(ctx, f.Index(), true, calls...)
+	return err
+}
+
+// foreignRefsCall returns a call for the records whose value of a field
+// with a foreign index refers to one of refs, the IDs of records in it.
+func foreignRefsCall(f *Field, refs []interface{}) *pql.Call {
+	if f.Type() == FieldTypeInt {
+		return &pql.Call{Name: "Row", Args: map[string]interface{}{f.Name(): &pql.Condition{Op: pql.IN, Value: refs}}}
+	}
+	c := &pql.Call{Name: "Union"}
+	for _, ref := range refs {
+		c.Children = append(c.Children, &pql.Call{Name: "Row", Args: map[string]interface{}{f.Name(): ref}})
+	}
+	return c
+}
+
+// cascadeExecute executes calls on an index, as a query of their own, with
+// their arguments already translated.
+func (e *executor) cascadeExecute(ctx context.Context, index string, write bool, calls ...*pql.Call) ([]interface{}, error) {
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, index)
+	}
+	var qcx *Qcx
+	if write {
+		qcx = idx.holder.txf.NewWritableQcx()
+	} else {
+		qcx = idx.holder.txf.NewQcx()
+	}
+	defer qcx.Abort()
+
+	results, err := e.execute(ctx, qcx, index, &pql.Query{Calls: calls}, nil, &ExecOptions{PreTranslated: true})
+	if err != nil {
+		return nil, err
+	}
+	// Copy rows out of the transaction before it ends.
+	for i, r := range results {
+		if row, ok := r.(*Row); ok {
+			results[i] = row.Clone()
+		}
+	}
+	if write {
+		return results, qcx.Finish()
+	}
+	return results, nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// When checksums are enabled, a checksum of each fragment's data is saved
+// next to it when the fragment is closed, and verified when it's next
+// opened, and optionally every so often while it's open, so data damaged on
+// disk is reported rather than quietly giving wrong query results. The
+// first write to a fragment removes its checksum, since it no longer
+// matches; a new one is saved when the fragment is closed. Verifying reads
+// all of a fragment's data, so opening takes longer with checksums enabled.
+
+// CorruptFragment describes a fragment whose data doesn't match the
+// checksum saved with it.
+type CorruptFragment struct {
+	Index    string    `json:"index"`
+	Field    string    `json:"field"`
+	View     string    `json:"view"`
+	Shard    uint64    `json:"shard"`
+	Expected string    `json:"expected"`
+	Actual   string    `json:"actual"`
+	Detected time.Time `json:"detected"`
+}
+
+func (c CorruptFragment) key() string {
+	return fmt.Sprintf("%s/%s/%s/%d", c.Index, c.Field, c.View, c.Shard)
+}
+
+// Health reports problems with a node's data.
+type Health struct {
+	// Healthy is false if any of the node's data is known to be corrupt,
+	// or couldn't be opened.
+	Healthy bool `json:"healthy"`
+
+	// ReadOnly is why the node is read-only, if it is, and Maintenance is
+	// why it's in maintenance mode.
+	ReadOnly    string `json:"readOnly,omitempty"`
+	Maintenance string `json:"maintenance,omitempty"`
+
+	CorruptFragments []CorruptFragment `json:"corruptFragments"`
+
+	// UnopenedFragments are fragments which couldn't be opened, and whose
+	// shards can't be queried.
+	UnopenedFragments []UnopenedFragment `json:"unopenedFragments,omitempty"`
+
+	// LastWrite is the time of the node's latest write since it started,
+	// if it has had one.
+	LastWrite *time.Time `json:"lastWrite,omitempty"`
+
+	// OpenTransactions counts the node's open transaction contexts by
+	// index, and StaleTransactions are those which have been open for
+	// longer than the stale transaction age, and may have leaked.
+	OpenTransactions  map[string]int     `json:"openTransactions,omitempty"`
+	StaleTransactions []StaleTransaction `json:"staleTransactions,omitempty"`
+}
+
+// checksumsEnabled reports whether the fragment's checksum is saved and
+// verified.
+func (f *fragment) checksumsEnabled() bool {
+	return f.holder != nil && f.holder.cfg != nil && f.holder.cfg.Checksums
+}
+
+// dataChanged removes the fragment's saved checksum, if it has one, before
+// its data is changed.
+func (f *fragment) dataChanged() {
+	if !atomic.CompareAndSwapInt32(&f.checksumSaved, 1, 0) {
+		return
+	}
+	if err := os.Remove(f.checksumPath()); err != nil && !os.IsNotExist(err) {
+		f.holder.Logger.Errorf("removing checksum: err=%s, path=%s", err, f.checksumPath())
+	}
+}
+
+// freshChecksum returns a checksum of the fragment's data, read from
+// storage rather than from cached block checksums. The caller must hold
+// f.mu for writing.
+func (f *fragment) freshChecksum() ([]byte, error) {
+	f.checksums = make(map[int][]byte)
+	blocks, err := f.blocks()
+	if err != nil {
+		return nil, err
+	}
+	return checksumBlocks(blocks), nil
+}
+
+// saveChecksum saves a checksum of the fragment's data, unless a current
+// one is already saved. The caller must hold f.mu for writing.
+func (f *fragment) saveChecksum() error {
+	if atomic.LoadInt32(&f.checksumSaved) == 1 {
+		return nil
+	}
+	blocks, err := f.blocks()
+	if err != nil {
+		return errors.Wrap(err, "getting blocks")
+	}
+	path := f.checksumPath()
+	if err := os.WriteFile(path+".tmp", checksumBlocks(blocks), 0600); err != nil {
+		return errors.Wrap(err, "writing")
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return errors.Wrap(err, "renaming")
+	}
+	atomic.StoreInt32(&f.checksumSaved, 1)
+	return nil
+}
+
+// verifyChecksum compares the fragment's data with its saved checksum, if
+// it has one, recording it with the holder as corrupt if they differ. The
+// caller must hold f.mu for writing.
+func (f *fragment) verifyChecksum() error {
+	expected, err := os.ReadFile(f.checksumPath())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading checksum")
+	}
+	atomic.StoreInt32(&f.checksumSaved, 1)
+
+	actual, err := f.freshChecksum()
+	if err != nil {
+		return errors.Wrap(err, "getting checksum")
+	}
+	// Writes don't all hold f.mu, so one could have started while the
+	// checksum was found. If so, the data was meant to change.
+	if bytes.Equal(expected, actual) || atomic.LoadInt32(&f.checksumSaved) == 0 {
+		return nil
+	}
+	f.holder.noteCorrupt(CorruptFragment{
+		Index:    f.index(),
+		Field:    f.field(),
+		View:     f.view(),
+		Shard:    f.shard,
+		Expected: hex.EncodeToString(expected),
+		Actual:   hex.EncodeToString(actual),
+		Detected: time.Now().UTC(),
+	})
+	return nil
+}
+
+// scrub verifies the fragment's data against its saved checksum, if it
+// has a current one.
+func (f *fragment) scrub() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.isOpen() || atomic.LoadInt32(&f.checksumSaved) == 0 {
+		return nil
+	}
+	return f.verifyChecksum()
+}
+
+// noteCorrupt records that a fragment's data doesn't match its checksum.
+func (h *Holder) noteCorrupt(c CorruptFragment) {
+	h.corruptMu.Lock()
+	defer h.corruptMu.Unlock()
+	if h.corrupt == nil {
+		h.corrupt = make(map[string]CorruptFragment)
+	}
+	if _, ok := h.corrupt[c.key()]; !ok {
+		h.Logger.Errorf("fragment %s is corrupt: expected checksum %s, got %s", c.key(), c.Expected, c.Actual)
+		h.corrupt[c.key()] = c
+	}
+	h.Stats.Gauge(MetricCorruptFragments, float64(len(h.corrupt)), 1.0)
+}
+
+// forgetCorrupt forgets that a fragment, which has been deleted, was
+// corrupt.
+func (h *Holder) forgetCorrupt(f *fragment) {
+	h.corruptMu.Lock()
+	defer h.corruptMu.Unlock()
+	delete(h.corrupt, CorruptFragment{Index: f.index(), Field: f.field(), View: f.view(), Shard: f.shard}.key())
+}
+
+// health returns the problems known with the holder's data.
+func (h *Holder) health() Health {
+	h.corruptMu.Lock()
+	corrupt := make([]CorruptFragment, 0, len(h.corrupt))
+	for _, c := range h.corrupt {
+		corrupt = append(corrupt, c)
+	}
+	h.corruptMu.Unlock()
+	sort.Slice(corrupt, func(i, j int) bool {
+		return corrupt[i].key() < corrupt[j].key()
+	})
+
+	h.readOnlyMu.RLock()
+	readOnly, maintenance := h.readOnly, h.maintenance
+	h.readOnlyMu.RUnlock()
+
+	unopened := h.unopenedFragments()
+	health := Health{
+		Healthy:           len(corrupt) == 0 && len(unopened) == 0,
+		ReadOnly:          readOnly,
+		Maintenance:       maintenance,
+		CorruptFragments:  corrupt,
+		UnopenedFragments: unopened,
+	}
+	if t := h.lastWrite(); !t.IsZero() {
+		health.LastWrite = &t
+	}
+	if txf := h.txf; txf != nil {
+		health.OpenTransactions, health.StaleTransactions = txf.qcxs.report(h.cfg.StaleTxAge)
+	}
+	return health
+}
+
+// scrubChecksums verifies every open fragment with a current checksum.
+// It stops early, returning false, if the server starts closing.
+func (s *Server) scrubChecksums() bool {
+	for _, idx := range s.holder.Indexes() {
+		for _, fld := range idx.Fields() {
+			for _, v := range fld.views() {
+				for _, frag := range v.openFragments() {
+					select {
+					case <-s.closing:
+						return false
+					default:
+					}
+					if err := frag.scrub(); err != nil {
+						s.logger.Errorf("scrubbing index/field/view/fragment %s/%s/%s/%d: %v", frag.index(), frag.field(), frag.view(), frag.shard, err)
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// monitorChecksums periodically verifies fragments against their saved
+// checksums, if checksums and scrubbing are enabled.
+func (s *Server) monitorChecksums() {
+	interval := s.holder.cfg.ScrubInterval
+	if !s.holder.cfg.Checksums || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+		}
+		if !s.scrubChecksums() {
+			return
+		}
+	}
+}
+
+// Health reports problems with this node's data.
+func (api *API) Health(ctx context.Context) (Health, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.Health")
+	defer span.Finish()
+
+	if err := api.validate(apiHealth); err != nil {
+		return Health{}, errors.Wrap(err, "validating api method")
+	}
+	return api.holder.health(), nil
+}
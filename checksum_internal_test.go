@@ -0,0 +1,122 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFragment_Checksums(t *testing.T) {
+	cfg := TestHolderConfig()
+	cfg.Checksums = true
+	h := NewHolder(t.TempDir(), cfg)
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	idx, err := h.CreateIndex("i", IndexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := idx.CreateField("f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setBit := func(row, col uint64) {
+		t.Helper()
+		qcx := h.Txf().NewWritableQcx()
+		if _, err := f.SetBit(qcx, row, col, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := qcx.Finish(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reopen := func() *fragment {
+		t.Helper()
+		if err := h.Close(); err != nil {
+			t.Fatal(err)
+		} else if err := h.Open(); err != nil {
+			t.Fatal(err)
+		}
+		idx = h.Index("i")
+		f = idx.Field("f")
+		return h.fragment("i", "f", viewStandard, 0)
+	}
+	setBit(1, 1)
+
+	// The checksum saved on close matches when the data is reopened.
+	frag := reopen()
+	if _, err := os.Stat(frag.checksumPath()); err != nil {
+		t.Fatalf("expected saved checksum: %v", err)
+	}
+	if health := h.health(); !health.Healthy || len(health.CorruptFragments) != 0 {
+		t.Fatalf("expected healthy, got %+v", health)
+	}
+
+	// Writing removes the checksum, which no longer matches.
+	setBit(2, 2)
+	if _, err := os.Stat(frag.checksumPath()); !os.IsNotExist(err) {
+		t.Fatalf("expected checksum removed, got %v", err)
+	}
+	if err := frag.scrub(); err != nil {
+		t.Fatal(err)
+	} else if health := h.health(); !health.Healthy {
+		t.Fatalf("expected healthy after write, got %+v", health)
+	}
+
+	// Data changed behind the fragment's back doesn't match its checksum,
+	// which the scrubber reports.
+	frag = reopen()
+	tx := h.Txf().NewTx(Txo{Write: writable, Index: idx, Shard: 0})
+	if _, err := tx.Add("i", "f", viewStandard, 0, 3*ShardWidth+3); err != nil {
+		t.Fatal(err)
+	} else if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := frag.scrub(); err != nil {
+		t.Fatal(err)
+	}
+	health := h.health()
+	if health.Healthy || len(health.CorruptFragments) != 1 {
+		t.Fatalf("expected 1 corrupt fragment, got %+v", health)
+	}
+	if c := health.CorruptFragments[0]; c.Index != "i" || c.Field != "f" || c.View != viewStandard || c.Shard != 0 || c.Expected == c.Actual {
+		t.Fatalf("unexpected corrupt fragment %+v", c)
+	}
+
+	// Once the data is rewritten, a new checksum is saved on close.
+	setBit(4, 4)
+	frag = reopen()
+	if health := h.health(); !health.Healthy {
+		t.Fatalf("expected healthy after rewrite, got %+v", health)
+	}
+
+	// A damaged checksum is reported too, when the fragment is opened.
+	if err := os.WriteFile(frag.checksumPath(), []byte("damaged"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Closing doesn't replace a checksum which was current when opened.
+	if buf, err := os.ReadFile(frag.checksumPath()); err != nil || string(buf) != "damaged" {
+		t.Fatalf("expected damaged checksum kept, got %q, %v", buf, err)
+	}
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if health := h.health(); health.Healthy || len(health.CorruptFragments) != 1 {
+		t.Fatalf("expected 1 corrupt fragment on open, got %+v", health)
+	}
+
+	// Deleting the fragment forgets it was corrupt.
+	if err := h.Index("i").Field("f").view(viewStandard).deleteFragment(0); err != nil {
+		t.Fatal(err)
+	}
+	if health := h.health(); !health.Healthy {
+		t.Fatalf("expected healthy after delete, got %+v", health)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// A query run with chunkWrites=true is split into runs of consecutive calls
+// with no more writes than MaxWritesPerRequest, each executed in its own
+// transaction, so ingesters needn't know the server's limit. If a chunk
+// fails, its calls are executed again one at a time, so that only the calls
+// which fail themselves aren't applied. The response holds a status for
+// every call, and a nil result for each which failed; the request as a
+// whole only fails if it can't be run at all.
+
+// StatementStatus reports whether a call of a query run with ChunkWrites
+// succeeded, and its error if it didn't.
+type StatementStatus struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// executeChunkedWrites executes the calls of q in chunks with as many
+// writes as a request may make.
+func (api *API) executeChunkedWrites(ctx context.Context, req *QueryRequest, q *pql.Query) (QueryResponse, error) {
+	if len(q.Bindings) > 0 {
+		return QueryResponse{}, NewBadRequestError(errors.New("queries with bindings can't be chunked"))
+	}
+	creq := *req
+	creq.ChunkWrites = false
+
+	resp := QueryResponse{
+		Results:    make([]interface{}, len(q.Calls)),
+		Statements: make([]StatementStatus, len(q.Calls)),
+	}
+	// exec executes the calls from start to end in one transaction, and
+	// records their results.
+	exec := func(start, end int) error {
+		calls := make([]*pql.Call, end-start)
+		for i := range calls {
+			calls[i] = q.Calls[start+i].Clone()
+		}
+		cresp, err := api.executeQuery(ctx, &creq, &pql.Query{Calls: calls})
+		if err != nil {
+			return err
+		}
+		copy(resp.Results[start:end], cresp.Results)
+		for i := start; i < end; i++ {
+			resp.Statements[i] = StatementStatus{OK: true}
+		}
+		if cresp.ExistenceFallback != "" {
+			resp.ExistenceFallback = cresp.ExistenceFallback
+		}
+		return nil
+	}
+
+	limit := api.server.executor.maxWritesPerRequest()
+	for start := 0; start < len(q.Calls); {
+		end := writeChunkEnd(q.Calls, start, limit)
+		if err := validateQueryContext(ctx); err != nil {
+			return QueryResponse{}, err
+		}
+		if err := exec(start, end); err != nil {
+			if end-start == 1 {
+				resp.Statements[start] = StatementStatus{Error: err.Error()}
+			} else {
+				for i := start; i < end; i++ {
+					if err := exec(i, i+1); err != nil {
+						resp.Statements[i] = StatementStatus{Error: err.Error()}
+					}
+				}
+			}
+		}
+		start = end
+	}
+	return resp, nil
+}
+
+// writeChunkEnd returns the end of the chunk of calls starting at start,
+// which has at most limit writes, or all the calls if limit isn't
+// positive. A chunk holds at least one call.
+func writeChunkEnd(calls []*pql.Call, start, limit int) int {
+	if limit <= 0 {
+		return len(calls)
+	}
+	end, writes := start, 0
+	for end < len(calls) {
+		if (&pql.Query{Calls: calls[end : end+1]}).WriteCallN() > 0 {
+			if writes == limit {
+				break
+			}
+			writes++
+		}
+		end++
+	}
+	return end
+}
@@ -287,6 +287,9 @@ func (c *Client) Query(query PQLQuery, options ...interface{}) (*QueryResponse,
 	path := fmt.Sprintf("/index/%s/query", query.Index().name)
 	_, respData, err := c.HTTPRequest("POST", path, reqData, c.augmentHeaders(defaultProtobufHeaders()))
 	if err != nil {
+		if qerr := decodeQueryError(respData); qerr != nil {
+			return nil, qerr
+		}
 		return nil, err
 	}
 	iqr := &pb.QueryResponse{}
@@ -301,6 +304,28 @@ func (c *Client) Query(query PQLQuery, options ...interface{}) (*QueryResponse,
 	return queryResponse, nil
 }
 
+// decodeQueryError returns the error in the body of a failed query's
+// response, if it has one.
+func decodeQueryError(data []byte) *QueryError {
+	if len(data) == 0 {
+		return nil
+	}
+	iqr := &pb.QueryResponse{}
+	if err := proto.Unmarshal(data, iqr); err != nil || iqr.Err == "" {
+		return nil
+	}
+	return &QueryError{Message: iqr.Err, Code: iqr.ErrCode, Retryable: iqr.ErrRetryable}
+}
+
+// queryErrorOf returns the query error in the body of a failed response to
+// a request for path, if path is that of a query.
+func queryErrorOf(path string, data []byte) *QueryError {
+	if !strings.HasPrefix(path, "/index/") || !strings.HasSuffix(path, "/query") {
+		return nil
+	}
+	return decodeQueryError(data)
+}
+
 // CreateIndex creates an index on the server using the given Index struct.
 func (c *Client) CreateIndex(index *Index) error {
 	span := c.tracer.StartSpan("Client.CreateIndex")
@@ -863,7 +888,8 @@ func (c *Client) httpRequest(method string, path string, data []byte, headers ma
 		// doRequest implements expotential backoff
 		status, body, err = c.doRequest(host, method, path, c.augmentHeaders(headers), data)
 		// conditions when primary should not be tried
-		if err == nil || usePrimary || path == "/status" {
+		var qerr *QueryError
+		if err == nil || usePrimary || path == "/status" || (errors.As(err, &qerr) && !qerr.Retryable) {
 			break
 		}
 
@@ -917,7 +943,10 @@ func (c *Client) host(usePrimary bool) (*pnet.URI, error) {
 	return host, nil
 }
 
-// doRequest creates and performs an http request.
+// doRequest creates and performs an http request. If the request fails,
+// the body of the last response is returned along with the error. Failed
+// queries are retried when the server says they're retryable, and only
+// then.
 func (c *Client) doRequest(host *pnet.URI, method, path string, headers map[string]string, data []byte) (int, []byte, error) {
 	var (
 		req       *http.Request
@@ -966,8 +995,16 @@ func (c *Client) doRequest(host *pnet.URI, method, path string, headers map[stri
 			}
 
 		case resp.StatusCode >= 400 && resp.StatusCode < 500:
-			// don't retry any 400 level errors
-			return resp.StatusCode, nil, errors.New(strings.TrimSpace(buf.String()))
+			// don't retry any 400 level errors, unless they're query errors
+			// the server says are worth retrying
+			qerr := queryErrorOf(path, buf.Bytes())
+			if qerr == nil {
+				return resp.StatusCode, buf.Bytes(), errors.New(strings.TrimSpace(buf.String()))
+			} else if !qerr.Retryable {
+				return resp.StatusCode, buf.Bytes(), qerr
+			}
+			err = qerr
+			sleepTime = time.Duration(1<<uint(retry))*time.Second + time.Duration(rand.Intn(1000))*time.Millisecond
 
 		case resp.StatusCode == 503:
 			// This indicates that Pilosa is not ready to service this request,
@@ -976,6 +1013,10 @@ func (c *Client) doRequest(host *pnet.URI, method, path string, headers map[stri
 			sleepTime = time.Duration(1<<uint(retry))*time.Second + time.Duration(rand.Intn(1000))*time.Millisecond
 
 		default:
+			// don't retry query errors the server says aren't worth retrying
+			if qerr := queryErrorOf(path, buf.Bytes()); qerr != nil && !qerr.Retryable {
+				return resp.StatusCode, buf.Bytes(), qerr
+			}
 			err = errors.Errorf("server error (%d) %s: %s", resp.StatusCode, resp.Status, strings.TrimSpace(buf.String()))
 			sleepTime = time.Duration(1<<uint(retry))*time.Second + time.Duration(rand.Intn(1000))*time.Millisecond
 		}
@@ -986,9 +1027,9 @@ func (c *Client) doRequest(host *pnet.URI, method, path string, headers map[stri
 			// we've hit the max retries limit. If an error exists, wrap it.
 			errMsg := fmt.Sprintf("max retries (%d) exceeded", c.maxRetries)
 			if err == nil {
-				return resp.StatusCode, nil, errors.New(errMsg)
+				return resp.StatusCode, buf.Bytes(), errors.New(errMsg)
 			}
-			return resp.StatusCode, nil, errors.Wrap(err, errMsg)
+			return resp.StatusCode, buf.Bytes(), errors.Wrap(err, errMsg)
 		}
 		// The client can continue retrying after it has reached the maxBackoff time.
 		if sleepTime > c.maxBackoff {
@@ -1317,6 +1358,8 @@ func newHTTPClient(options *ClientOptions) *http.Client {
 		TLSClientConfig:     options.TLSConfig,
 		MaxIdleConnsPerHost: options.PoolSizePerRoute,
 		MaxIdleConns:        options.TotalPoolSize,
+		MaxConnsPerHost:     options.MaxConnsPerHost,
+		IdleConnTimeout:     options.IdleConnTimeout,
 	}
 	return &http.Client{
 		Transport: transport,
@@ -1349,6 +1392,8 @@ type ClientOptions struct {
 	ConnectTimeout      time.Duration
 	PoolSizePerRoute    int
 	TotalPoolSize       int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
 	TLSConfig           *tls.Config
 	manualServerAddress bool
 	tracer              opentracing.Tracer
@@ -1402,6 +1447,25 @@ func OptClientTotalPoolSize(size int) ClientOption {
 	}
 }
 
+// OptClientMaxConnsPerHost limits the number of connections to a host,
+// whether idle or in use. Requests over the limit wait for a connection.
+// Zero means no limit.
+func OptClientMaxConnsPerHost(size int) ClientOption {
+	return func(options *ClientOptions) error {
+		options.MaxConnsPerHost = size
+		return nil
+	}
+}
+
+// OptClientIdleConnTimeout is how long a connection stays idle in the pool
+// before it is closed.
+func OptClientIdleConnTimeout(timeout time.Duration) ClientOption {
+	return func(options *ClientOptions) error {
+		options.IdleConnTimeout = timeout
+		return nil
+	}
+}
+
 // OptClientTLSConfig contains the TLS configuration.
 func OptClientTLSConfig(config *tls.Config) ClientOption {
 	return func(options *ClientOptions) error {
@@ -1427,7 +1491,8 @@ func OptClientTracer(tracer opentracing.Tracer) ClientOption {
 	}
 }
 
-// OptClientRetries sets the number of retries on HTTP request failures.
+// OptClientRetries sets the number of retries on HTTP request failures, and
+// on query errors the server says are worth retrying.
 func OptClientRetries(retries int) ClientOption {
 	return func(options *ClientOptions) error {
 		if retries < 0 {
@@ -1483,6 +1548,9 @@ func (co *ClientOptions) withDefaults() (updated *ClientOptions) {
 	if updated.TotalPoolSize <= 0 {
 		updated.TotalPoolSize = 500
 	}
+	if updated.IdleConnTimeout <= 0 {
+		updated.IdleConnTimeout = 90 * time.Second
+	}
 	if updated.TLSConfig == nil {
 		updated.TLSConfig = &tls.Config{}
 	}
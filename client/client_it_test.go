@@ -4,6 +4,7 @@ package client
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"testing"
@@ -537,6 +538,10 @@ func TestClientAgainstCluster(t *testing.T) {
 
 				_, err = cli.Query(testIndex.RawQuery("Invalid query"))
 				require.Error(t, err)
+				var qerr *QueryError
+				require.True(t, errors.As(err, &qerr), "expected a QueryError, got %T", err)
+				require.Equal(t, "BAD_REQUEST", qerr.Code)
+				require.False(t, qerr.Retryable)
 			})
 
 			t.Run("Sync", func(t *testing.T) {
@@ -8,10 +8,14 @@ package client
 import (
 	"crypto/tls"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
 	pnet "github.com/featurebasedb/featurebase/v3/net"
+	"github.com/featurebasedb/featurebase/v3/pb"
+	"github.com/golang/protobuf/proto" //nolint:staticcheck
 )
 
 func TestQueryWithError(t *testing.T) {
@@ -25,12 +29,63 @@ func TestQueryWithError(t *testing.T) {
 	}
 }
 
+func TestQueryRetries(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		status    int
+		retryable bool
+		requests  int
+	}{
+		{name: "Retryable", status: http.StatusBadRequest, retryable: true, requests: 2},
+		{name: "NotRetryable", status: http.StatusInternalServerError, retryable: false, requests: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				resp := &pb.QueryResponse{Err: "failed", ErrCode: "CODE", ErrRetryable: tt.retryable}
+				if requests > 1 {
+					resp = &pb.QueryResponse{Results: []*pb.QueryResult{{Type: QueryResultTypeBool, Changed: true}}}
+				}
+				data, err := proto.Marshal(resp)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if requests == 1 {
+					w.WriteHeader(tt.status)
+				}
+				_, _ = w.Write(data)
+			}))
+			defer srv.Close()
+
+			client, err := NewClient(srv.URL, OptClientManualServerAddress(true), OptClientRetries(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			_, err = client.Query(NewIndex("i").RawQuery("Set(1, f=1)"))
+			if requests != tt.requests {
+				t.Fatalf("expected %d requests, got %d", tt.requests, requests)
+			}
+			var qerr *QueryError
+			if tt.retryable && err != nil {
+				t.Fatalf("expected retried query to succeed, got %v", err)
+			} else if !tt.retryable && (!errors.As(err, &qerr) || qerr.Code != "CODE") {
+				t.Fatalf("expected query error, got %#v", err)
+			}
+		})
+	}
+}
+
 func TestClientOptions(t *testing.T) {
 	targets := []*ClientOptions{
 		{SocketTimeout: 10},
 		{ConnectTimeout: 5},
 		{PoolSizePerRoute: 7},
 		{TotalPoolSize: 17},
+		{MaxConnsPerHost: 3},
+		{IdleConnTimeout: 11},
 		{TLSConfig: &tls.Config{InsecureSkipVerify: true}},
 	}
 	optionsList := [][]ClientOption{
@@ -38,6 +93,8 @@ func TestClientOptions(t *testing.T) {
 		{OptClientConnectTimeout(5)},
 		{OptClientPoolSizePerRoute(7)},
 		{OptClientTotalPoolSize(17)},
+		{OptClientMaxConnsPerHost(3)},
+		{OptClientIdleConnTimeout(11)},
 		{OptClientTLSConfig(&tls.Config{InsecureSkipVerify: true})},
 	}
 
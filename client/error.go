@@ -24,3 +24,16 @@ var (
 	ErrSingleServerAddressRequired = errors.New("OptClientManualServerAddress requires a single URI or address")
 	ErrPreconditionFailed          = errors.New("Precondition failed")
 )
+
+// QueryError is the error the server returned for a query, with the code
+// classifying it and whether the query is worth retrying. Queries are
+// retried as many times as the client's retries allow when they are.
+type QueryError struct {
+	Message   string
+	Code      string
+	Retryable bool
+}
+
+func (e *QueryError) Error() string {
+	return e.Message
+}
@@ -1442,6 +1442,11 @@ func (q *PQLRowsQuery) Union() *PQLRowQuery {
 	return NewPQLRowQuery(fmt.Sprintf("UnionRows(%s)", q.serialize().String()), q.index, nil)
 }
 
+// Intersect returns the intersection of all matched rows.
+func (q *PQLRowsQuery) Intersect() *PQLRowQuery {
+	return NewPQLRowQuery(fmt.Sprintf("IntersectRows(%s)", q.serialize().String()), q.index, nil)
+}
+
 // Rows creates a Rows query with defaults
 func (f *Field) Rows() *PQLRowsQuery {
 	text := fmt.Sprintf("Rows(field='%s')", f.name)
@@ -626,6 +626,12 @@ func TestORM(t *testing.T) {
 			collabField.Rows().Union())
 	})
 
+	t.Run("IntersectRows", func(t *testing.T) {
+		comparePQL(t,
+			"IntersectRows(Rows(field='collaboration',like='a%'))",
+			collabField.Like("a%").Intersect())
+	})
+
 	t.Run("Like", func(t *testing.T) {
 		comparePQL(t,
 			"Rows(field='collaboration',like='_')",
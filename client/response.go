@@ -33,6 +33,8 @@ const (
 type QueryResponse struct {
 	ResultList   []QueryResult `json:"results,omitempty"`
 	ErrorMessage string        `json:"error-message,omitempty"`
+	ErrorCode    string        `json:"error-code,omitempty"`
+	Retryable    bool          `json:"retryable,omitempty"`
 	Success      bool          `json:"success,omitempty"`
 }
 
@@ -40,6 +42,8 @@ func newQueryResponseFromInternal(response *pb.QueryResponse) (*QueryResponse, e
 	if response.Err != "" {
 		return &QueryResponse{
 			ErrorMessage: response.Err,
+			ErrorCode:    response.ErrCode,
+			Retryable:    response.ErrRetryable,
 			Success:      false,
 		}, nil
 	}
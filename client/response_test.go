@@ -75,7 +75,9 @@ func TestNewQueryResponseFromInternal(t *testing.T) {
 
 func TestNewQueryResponseWithErrorFromInternal(t *testing.T) {
 	response := &pb.QueryResponse{
-		Err: "some error",
+		Err:          "some error",
+		ErrCode:      "SHARD_UNAVAILABLE",
+		ErrRetryable: true,
 	}
 	qr, err := newQueryResponseFromInternal(response)
 	if err != nil {
@@ -84,6 +86,9 @@ func TestNewQueryResponseWithErrorFromInternal(t *testing.T) {
 	if qr.ErrorMessage != "some error" {
 		t.Fatalf("The response should include the error message")
 	}
+	if qr.ErrorCode != "SHARD_UNAVAILABLE" || !qr.Retryable {
+		t.Fatalf("The response should include the error code and retryability")
+	}
 	if qr.Success {
 		t.Fatalf("IsSuccess should be false")
 	}
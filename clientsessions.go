@@ -0,0 +1,240 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// A node keeps track of the sessions clients hold with it: its HTTP
+// connections, and its gRPC streams, which can last as long as a client
+// keeps reading results. Each session records how many bytes have been
+// sent in it, the query it's running, if any, and when it was last active,
+// so sessions held by stuck clients can be found, and closed. Closing an
+// HTTP session closes its connection; closing a gRPC stream cancels it, so
+// the query behind it stops, and its next send fails.
+
+// Protocols of client sessions.
+const (
+	ClientSessionHTTP       = "http"
+	ClientSessionGRPCStream = "grpc-stream"
+)
+
+// ClientSessionStatus reports a client session.
+type ClientSessionStatus struct {
+	ID       string `json:"id"`
+	Protocol string `json:"protocol"`
+	Remote   string `json:"remote"`
+	// Method is the gRPC method of a stream.
+	Method    string        `json:"method,omitempty"`
+	Index     string        `json:"index,omitempty"`
+	Query     string        `json:"query,omitempty"`
+	Started   time.Time     `json:"started"`
+	Idle      time.Duration `json:"idle"`
+	BytesSent int64         `json:"bytesSent"`
+}
+
+// ClientSession is a session a client holds with a node. Its methods can be
+// called on a nil session, and do nothing.
+type ClientSession struct {
+	sessions *clientSessions
+	id       string
+	protocol string
+	remote   string
+	method   string
+	started  time.Time
+	close    func()
+
+	// sent and active, the time it was last active in Unix nanoseconds,
+	// are updated atomically.
+	sent   int64
+	active int64
+
+	mu    sync.Mutex
+	index string
+	query string
+}
+
+// clientSessions holds the client sessions open on a node.
+type clientSessions struct {
+	mu       sync.Mutex
+	next     uint64
+	sessions map[string]*ClientSession
+}
+
+// StartClientSession starts tracking a session of a client at remote, which
+// closeFn closes. It must be ended once it's done.
+func (api *API) StartClientSession(protocol, remote, method string, closeFn func()) *ClientSession {
+	now := time.Now()
+	cs := &ClientSession{
+		sessions: &api.clientSessions,
+		protocol: protocol,
+		remote:   remote,
+		method:   method,
+		started:  now,
+		close:    closeFn,
+		active:   now.UnixNano(),
+	}
+	s := &api.clientSessions
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	cs.id = strconv.FormatUint(s.next, 10)
+	if s.sessions == nil {
+		s.sessions = make(map[string]*ClientSession)
+	}
+	s.sessions[cs.id] = cs
+	return cs
+}
+
+// ClientSessions reports the client sessions open on this node, oldest
+// first.
+func (api *API) ClientSessions(ctx context.Context) ([]ClientSessionStatus, error) {
+	if err := api.validate(apiClientSessions); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	s := &api.clientSessions
+	s.mu.Lock()
+	sessions := make([]*ClientSession, 0, len(s.sessions))
+	for _, cs := range s.sessions {
+		sessions = append(sessions, cs)
+	}
+	s.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]ClientSessionStatus, len(sessions))
+	for i, cs := range sessions {
+		statuses[i] = cs.status(now)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Started.Before(statuses[j].Started)
+	})
+	return statuses, nil
+}
+
+// CloseClientSession closes a client session open on this node.
+func (api *API) CloseClientSession(ctx context.Context, id string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.CloseClientSession")
+	defer span.Finish()
+
+	if err := api.validate(apiClientSessions); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	s := &api.clientSessions
+	s.mu.Lock()
+	cs, ok := s.sessions[id]
+	s.mu.Unlock()
+	if !ok {
+		return newNotFoundError(errors.New("client session not found"), id)
+	}
+	cs.close()
+	cs.End()
+	return nil
+}
+
+// ID returns the ID of the session.
+func (cs *ClientSession) ID() string {
+	if cs == nil {
+		return ""
+	}
+	return cs.id
+}
+
+// Sent records that n bytes were sent in the session.
+func (cs *ClientSession) Sent(n int) {
+	if cs == nil {
+		return
+	}
+	atomic.AddInt64(&cs.sent, int64(n))
+	cs.Touch()
+}
+
+// Touch records that the session is active.
+func (cs *ClientSession) Touch() {
+	if cs == nil {
+		return
+	}
+	atomic.StoreInt64(&cs.active, time.Now().UnixNano())
+}
+
+// SetQuery records the query the session is running.
+func (cs *ClientSession) SetQuery(index, query string) {
+	if cs == nil {
+		return
+	}
+	cs.mu.Lock()
+	cs.index, cs.query = index, query
+	cs.mu.Unlock()
+	cs.Touch()
+}
+
+// End stops tracking the session.
+func (cs *ClientSession) End() {
+	if cs == nil {
+		return
+	}
+	cs.sessions.mu.Lock()
+	delete(cs.sessions.sessions, cs.id)
+	cs.sessions.mu.Unlock()
+}
+
+func (cs *ClientSession) status(now time.Time) ClientSessionStatus {
+	cs.mu.Lock()
+	index, query := cs.index, cs.query
+	cs.mu.Unlock()
+	return ClientSessionStatus{
+		ID:        cs.id,
+		Protocol:  cs.protocol,
+		Remote:    cs.remote,
+		Method:    cs.method,
+		Index:     index,
+		Query:     query,
+		Started:   cs.started,
+		Idle:      now.Sub(time.Unix(0, atomic.LoadInt64(&cs.active))),
+		BytesSent: atomic.LoadInt64(&cs.sent),
+	}
+}
+
+type contextKeyClientSessionType struct{}
+
+var contextKeyClientSession = contextKeyClientSessionType{}
+
+// WithClientSession returns a context for the requests of a client session.
+func WithClientSession(ctx context.Context, cs *ClientSession) context.Context {
+	return context.WithValue(ctx, contextKeyClientSession, cs)
+}
+
+// ClientSessionFromContext returns the client session of the request
+// whose context is ctx, or nil.
+func ClientSessionFromContext(ctx context.Context) *ClientSession {
+	cs, _ := ctx.Value(contextKeyClientSession).(*ClientSession)
+	return cs
+}
+
+// clientSessionWriter counts the bytes written to a response in a client
+// session.
+type clientSessionWriter struct {
+	http.ResponseWriter
+	session *ClientSession
+}
+
+func (w *clientSessionWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.session.Sent(n)
+	return n, err
+}
+
+func (w *clientSessionWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/featurebasedb/featurebase/v3/roaring"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// CloneIndexMessage is an internal message telling a node to copy the data
+// for the Source index into the (already created) Target index.
+type CloneIndexMessage struct {
+	Source string
+	Target string
+}
+
+// cloneFile copies the file at src to dst. Where the filesystem supports
+// it, dst shares src's blocks copy-on-write, so the copy takes no space
+// until one of the files is modified; otherwise the data is copied. The
+// copy is written to a temporary file and renamed into place.
+func cloneFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer out.Close()
+
+	if err := reflinkFile(out, in); err != nil {
+		if _, err := io.Copy(out, in); err != nil {
+			return errors.Wrap(err, "copying")
+		}
+	}
+	if err := out.Sync(); err != nil {
+		return err
+	} else if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+// writeShardFile writes the contents of r to the file at path, via a
+// temporary file which is renamed into place.
+func writeShardFile(path string, r io.Reader) error {
+	tmp := path + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer out.Close()
+
+	bw := bufio.NewWriter(out)
+	if _, err := io.Copy(bw, r); err != nil {
+		return err
+	} else if err := bw.Flush(); err != nil {
+		return err
+	} else if err := out.Sync(); err != nil {
+		return err
+	} else if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// cloneIndexData copies the data for the index src into the index dst,
+// which must already exist with the same fields, for the shards of dst
+// which this node owns. Since shards are assigned to nodes by index name as
+// well as shard number, a shard of dst may belong to a different node than
+// the same shard of src; those shards are fetched from a node owning them.
+// Each shard is copied as of a single point in time, but writes to src
+// during the clone may be included for some shards and not others.
+func (s *Server) cloneIndexData(ctx context.Context, src, dst string) error {
+	h := s.holder
+	srcIdx, dstIdx := h.Index(src), h.Index(dst)
+	if srcIdx == nil {
+		return newNotFoundError(ErrIndexNotFound, src)
+	} else if dstIdx == nil {
+		return newNotFoundError(ErrIndexNotFound, dst)
+	}
+
+	// Field keys are translated on every node, rather than partitioned by
+	// index, so each node can copy its own translation.
+	for _, srcFld := range srcIdx.Fields() {
+		dstFld := dstIdx.Field(srcFld.Name())
+		if dstFld == nil {
+			return newNotFoundError(ErrFieldNotFound, srcFld.Name())
+		}
+		if store := srcFld.TranslateStore(); store != nil {
+			if err := copyTranslateStore(dstFld.TranslateStore(), store); err != nil {
+				return errors.Wrapf(err, "copying translation for field %s", srcFld.Name())
+			}
+		}
+	}
+	if srcIdx.Keys() {
+		return s.remapIndexData(ctx, srcIdx, dstIdx)
+	}
+
+	snap := s.cluster.NewSnapshot()
+	for _, shard := range srcIdx.AvailableShards(false).Slice() {
+		if !snap.OwnsShard(s.nodeID, dst, shard) {
+			continue
+		}
+		var err error
+		if snap.OwnsShard(s.nodeID, src, shard) {
+			err = h.cloneShard(ctx, srcIdx, dstIdx, shard)
+		} else {
+			err = s.fetchShard(ctx, srcIdx, dstIdx, shard)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "cloning shard %d", shard)
+		}
+	}
+
+	// Shards held by other nodes are cloned by those nodes, but every
+	// node needs to know about them.
+	for _, srcFld := range srcIdx.Fields() {
+		if err := dstIdx.Field(srcFld.Name()).AddRemoteAvailableShards(srcFld.AvailableShards(false)); err != nil {
+			return errors.Wrapf(err, "adding available shards for field %s", srcFld.Name())
+		}
+	}
+	return nil
+}
+
+// remapIndexData copies the data for the keyed index src into dst, for the
+// shards of src whose primary is this node. Record keys are assigned to
+// partitions, and so to record IDs, by the name of the index, so src's
+// record translation can't be used for dst as is: each record's key is
+// created in dst instead, and the shard's bits are rewritten for the
+// record's new ID and imported into the shards of dst which hold it.
+func (s *Server) remapIndexData(ctx context.Context, src, dst *Index) error {
+	snap := s.cluster.NewSnapshot()
+	for _, shard := range src.AvailableShards(false).Slice() {
+		nodes := snap.ShardNodes(src.Name(), shard)
+		if len(nodes) == 0 || nodes[0].ID != s.nodeID {
+			continue
+		}
+		if err := s.remapShard(ctx, src, dst, shard); err != nil {
+			return errors.Wrapf(err, "cloning shard %d", shard)
+		}
+	}
+	return nil
+}
+
+// remapShard copies a local shard of the keyed index src into dst, giving
+// each record the ID of its key in dst.
+func (s *Server) remapShard(ctx context.Context, src, dst *Index, shard uint64) error {
+	type viewBits struct {
+		field, view string
+		bits        *roaring.Bitmap
+	}
+	var views []viewBits
+	ids := make(map[uint64]struct{})
+	tx := s.holder.txf.NewTx(Txo{Index: src, Shard: shard})
+	defer tx.Rollback()
+	for _, fld := range src.Fields() {
+		for _, v := range fld.views() {
+			if v.Fragment(shard) == nil {
+				continue
+			}
+			bits, err := tx.RoaringBitmap(src.Name(), fld.Name(), v.Name(), shard)
+			if err != nil {
+				return errors.Wrapf(err, "reading field %s view %s", fld.Name(), v.Name())
+			}
+			if err := bits.ForEach(func(pos uint64) error {
+				ids[shard*ShardWidth+pos%ShardWidth] = struct{}{}
+				return nil
+			}); err != nil {
+				return err
+			}
+			views = append(views, viewBits{field: fld.Name(), view: v.Name(), bits: bits})
+		}
+	}
+	tx.Rollback()
+	if len(ids) == 0 {
+		return nil
+	}
+
+	keys, err := s.cluster.translateIndexIDSet(ctx, src.Name(), ids)
+	if err != nil {
+		return errors.Wrap(err, "translating records")
+	}
+	keyList := make([]string, 0, len(keys))
+	for _, key := range keys {
+		keyList = append(keyList, key)
+	}
+	dstIDs, err := s.cluster.createIndexKeys(ctx, dst.Name(), keyList...)
+	if err != nil {
+		return errors.Wrap(err, "creating records")
+	}
+	remap := make(map[uint64]uint64, len(keys))
+	for id, key := range keys {
+		if dstID, ok := dstIDs[key]; ok {
+			remap[id] = dstID
+		}
+	}
+
+	// Group the rewritten bits by the field and shard of dst they go to,
+	// so each can be imported with one request.
+	type target struct {
+		field string
+		shard uint64
+	}
+	out := make(map[target]map[string]*roaring.Bitmap)
+	for _, vb := range views {
+		err := vb.bits.ForEach(func(pos uint64) error {
+			id, ok := remap[shard*ShardWidth+pos%ShardWidth]
+			if !ok {
+				return errors.Errorf("no key for record %d", shard*ShardWidth+pos%ShardWidth)
+			}
+			t := target{field: vb.field, shard: id / ShardWidth}
+			if out[t] == nil {
+				out[t] = make(map[string]*roaring.Bitmap)
+			}
+			bm := out[t][vb.view]
+			if bm == nil {
+				bm = roaring.NewBitmap()
+				out[t][vb.view] = bm
+			}
+			bm.DirectAdd(pos/ShardWidth*ShardWidth + id%ShardWidth)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for t, bms := range out {
+		if err := s.importClonedBits(ctx, dst.Field(t.field), t.shard, bms); err != nil {
+			return errors.Wrapf(err, "importing field %s shard %d", t.field, t.shard)
+		}
+	}
+	return nil
+}
+
+// importClonedBits sets the bits in the views of a shard of fld on every
+// node which owns the shard.
+func (s *Server) importClonedBits(ctx context.Context, fld *Field, shard uint64, views map[string]*roaring.Bitmap) error {
+	data := make(map[string][]byte, len(views))
+	for name, bm := range views {
+		var buf bytes.Buffer
+		if _, err := bm.WriteTo(&buf); err != nil {
+			return errors.Wrap(err, "encoding bits")
+		}
+		data[name] = buf.Bytes()
+	}
+
+	for _, node := range s.cluster.NewSnapshot().ShardNodes(fld.Index(), shard) {
+		if node.ID != s.nodeID {
+			// The import handler names the views of time fields by
+			// their quantum alone.
+			req := &ImportRoaringRequest{Views: make(map[string][]byte, len(data))}
+			for name, d := range data {
+				if fld.Type() == FieldTypeTime {
+					name = strings.TrimPrefix(strings.TrimPrefix(name, viewStandard), "_")
+				}
+				req.Views[name] = d
+			}
+			if err := s.defaultClient.ImportRoaring(ctx, &node.URI, fld.Index(), fld.Name(), shard, true, req); err != nil {
+				return errors.Wrapf(err, "importing on node %s", node.ID)
+			}
+			continue
+		}
+
+		qcx := s.holder.txf.NewQcx()
+		err := func() (err error) {
+			tx, finisher, err := qcx.GetTx(Txo{Write: true, Index: fld.idx, Shard: shard})
+			if err != nil {
+				return err
+			}
+			defer finisher(&err)
+			for name, d := range data {
+				if err := fld.importRoaring(ctx, tx, d, shard, name, false); err != nil {
+					return err
+				}
+			}
+			return nil
+		}()
+		if err != nil {
+			qcx.Abort()
+			return err
+		}
+		if err := qcx.Finish(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyTranslateStore replaces the contents of dst with those of src.
+func copyTranslateStore(dst, src TranslateStore) error {
+	if dst == nil {
+		return errors.New("no translate store for target")
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := src.WriteTo(pw)
+		pw.CloseWithError(err)
+	}()
+	_, err := dst.ReadFrom(pr)
+	pr.Close()
+	return err
+}
+
+// newShardPath creates the directory for the RBF database of a shard of
+// idx, which must not exist yet. The database must not be opened until its
+// files are in place, since an open database caches their contents.
+func (h *Holder) newShardPath(idx *Index, shard uint64) (string, error) {
+	path := h.txf.dbPerShard.prefixForType(idx, rbfTxn) + fmt.Sprintf("shard.%04v", shard)
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("target shard already exists: %s", path)
+	}
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return "", errors.Wrap(err, "creating target shard")
+	}
+	return path, nil
+}
+
+// cloneShard copies a local shard of src into dst by cloning its RBF
+// files. Bitmap names within a shard's database don't include the index,
+// so the copy can be used as is.
+func (h *Holder) cloneShard(ctx context.Context, src, dst *Index, shard uint64) error {
+	// Hold a write transaction on the source so that nothing can commit
+	// to it, or checkpoint its WAL, while its files are being copied.
+	tx := h.txf.NewTx(Txo{Write: true, Index: src, Shard: shard})
+	defer tx.Rollback()
+	rtx, ok := tx.(*RBFTx)
+	if !ok {
+		return fmt.Errorf("cloning not available for %q storage", tx.Type())
+	}
+	srcPath := rtx.Db.Path()
+
+	dstPath, err := h.newShardPath(dst, shard)
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{"data", "wal"} {
+		if _, err := os.Stat(filepath.Join(srcPath, name)); os.IsNotExist(err) {
+			continue
+		}
+		if err := cloneFile(filepath.Join(dstPath, name), filepath.Join(srcPath, name)); err != nil {
+			return errors.Wrapf(err, "cloning %s", name)
+		}
+	}
+	tx.Rollback()
+
+	return h.openClonedShard(ctx, dst, shard)
+}
+
+// fetchShard copies a shard of src held by another node into dst.
+func (s *Server) fetchShard(ctx context.Context, src, dst *Index, shard uint64) error {
+	nodes := s.cluster.NewSnapshot().ShardNodes(src.Name(), shard)
+	if len(nodes) == 0 {
+		return errors.New("no nodes own source shard")
+	}
+	rc, err := s.defaultClient.ShardReaderFromURI(ctx, src.Name(), shard, nodes[0].URI)
+	if err != nil {
+		return errors.Wrap(err, "fetching shard")
+	}
+	defer rc.Close()
+
+	dstPath, err := s.holder.newShardPath(dst, shard)
+	if err != nil {
+		return err
+	}
+	if err := writeShardFile(filepath.Join(dstPath, "data"), rc); err != nil {
+		return errors.Wrap(err, "writing shard")
+	}
+	return s.holder.openClonedShard(ctx, dst, shard)
+}
+
+// openClonedShard opens a shard of idx whose files have been copied into
+// place, creating its views and fragments.
+func (h *Holder) openClonedShard(ctx context.Context, idx *Index, shard uint64) error {
+	dbs, err := h.txf.dbPerShard.GetDBShard(idx.Name(), shard, idx)
+	if err != nil {
+		return errors.Wrap(err, "opening target shard")
+	}
+	return idx.openShardFragments(ctx, dbs.W, shard)
+}
+
+// CloneIndex creates the index dst as a copy of src, including its fields
+// and data, so that destructive experiments can be run against the copy
+// without affecting src. On filesystems with copy-on-write file cloning,
+// such as btrfs and XFS, shards which stay on the same node share storage
+// with src until either index is written to, so even large indexes can be
+// cloned quickly and cheaply. Writes to src while it's being cloned may or
+// may not be included in the clone.
+//
+// Since record keys are assigned to nodes and record IDs by the name of the
+// index, the records of an index with keys are given new IDs in dst, and
+// its shards are copied bit by bit rather than cloned. If cloning fails
+// after dst has been created, dst is deleted again.
+func (api *API) CloneIndex(ctx context.Context, src, dst string) (_ *Index, err error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.CloneIndex")
+	defer span.Finish()
+
+	if err := api.validate(apiCloneIndex); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	srcIdx := api.holder.Index(src)
+	if srcIdx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, src)
+	}
+	// Create the target with the same options and fields. Views are
+	// created as the shards holding them are cloned. A read-only target
+	// would refuse the imports which copy the data of a keyed index, so
+	// it's made read-only once the data is in place.
+	idxOpts := srcIdx.Options()
+	readOnly := idxOpts.ReadOnly
+	idxOpts.ReadOnly = false
+	if _, err := api.CreateIndex(ctx, dst, idxOpts); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if derr := api.DeleteIndex(ctx, dst); derr != nil {
+				api.server.logger.Errorf("deleting index %s after failed clone: %v", dst, derr)
+			}
+		}
+	}()
+	for _, fld := range srcIdx.Fields() {
+		if fld.Name() == existenceFieldName {
+			continue
+		}
+		opts := fld.Options()
+		if _, err := api.CreateField(ctx, dst, fld.Name(), func(fo *FieldOptions) error {
+			*fo = opts
+			return nil
+		}); err != nil {
+			return nil, errors.Wrapf(err, "creating field %s", fld.Name())
+		}
+	}
+
+	msg := &CloneIndexMessage{
+		Source: src,
+		Target: dst,
+	}
+	if err := api.server.cloneIndexData(ctx, msg.Source, msg.Target); err != nil {
+		return nil, errors.Wrap(err, "cloning index")
+	}
+	if err := api.server.SendSync(msg); err != nil {
+		return nil, errors.Wrap(err, "sending CloneIndex message")
+	}
+	if readOnly {
+		if err := api.UpdateIndex(ctx, dst, IndexUpdate{Option: "readOnly", Value: "true"}); err != nil {
+			return nil, errors.Wrap(err, "making clone read-only")
+		}
+	}
+	return api.holder.Index(dst), nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+//go:build linux
+// +build linux
+
+package pilosa
+
+import (
+	"os"
+	"syscall"
+)
+
+// ficlone is the FICLONE ioctl, which makes the destination file share the
+// source file's extents on filesystems which support it, such as btrfs
+// and XFS.
+const ficlone = 0x40049409
+
+// reflinkFile makes dst a copy-on-write clone of src.
+func reflinkFile(dst, src *os.File) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dst.Fd(), ficlone, src.Fd())
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+//go:build !linux
+// +build !linux
+
+package pilosa
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// reflinkFile makes dst a copy-on-write clone of src. It is only
+// supported on Linux.
+func reflinkFile(dst, src *os.File) error {
+	return errors.New("file cloning not supported")
+}
@@ -53,6 +53,46 @@ func TestCluster_Owners(t *testing.T) {
 	}
 }
 
+// Ensure the ring-hash assignment spreads partitions fairly, and moves few of
+// them when a node is added.
+func TestCluster_RingHashAssignment(t *testing.T) {
+	nodes := make([]*disco.Node, 0, 5)
+	for i := 0; i < 5; i++ {
+		nodes = append(nodes, &disco.Node{ID: fmt.Sprintf("node%d", i)})
+	}
+	before := disco.NewClusterSnapshot(disco.NewLocalNoder(nodes[:4]), &disco.Jmphasher{}, disco.PartitionAssignmentRingHash, 2)
+	after := disco.NewClusterSnapshot(disco.NewLocalNoder(nodes), &disco.Jmphasher{}, disco.PartitionAssignmentRingHash, 2)
+
+	primaries := make(map[string]int)
+	var moved int
+	for i := 0; i < after.PartitionN; i++ {
+		owners := after.PartitionNodes(i)
+		if len(owners) != 2 || owners[0].ID == owners[1].ID {
+			t.Fatalf("partition %d has owners %v, expected two distinct nodes", i, owners)
+		} else if after.PrimaryPartitionNode(i).ID != owners[0].ID {
+			t.Fatalf("partition %d has primary %s, expected %s", i, after.PrimaryPartitionNode(i).ID, owners[0].ID)
+		}
+		primaries[owners[0].ID]++
+
+		// Partitions only move to the new node.
+		if prev := before.PrimaryPartitionNode(i).ID; prev != owners[0].ID {
+			if owners[0].ID != "node4" {
+				t.Fatalf("partition %d moved from %s to %s, not the new node", i, prev, owners[0].ID)
+			}
+			moved++
+		}
+	}
+	expected := float64(after.PartitionN) / 5
+	for id, n := range primaries {
+		if ratio := float64(n) / expected; ratio < 0.6 || ratio > 1.4 {
+			t.Fatalf("node %s is primary of %d partitions, expected about %.1f", id, n, expected)
+		}
+	}
+	if ratio := float64(moved) / expected; ratio < 0.6 || ratio > 1.4 {
+		t.Fatalf("%d partitions moved, expected about %.1f", moved, expected)
+	}
+}
+
 // Ensure the partitioner can assign a fragment to a partition.
 func TestCluster_Partition(t *testing.T) {
 	if err := quick.Check(func(index string, shard uint64, partitionN int) bool {
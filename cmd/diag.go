@@ -0,0 +1,36 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/featurebasedb/featurebase/v3/ctl"
+	"github.com/spf13/cobra"
+)
+
+func newDiagCommand(stdin io.Reader, stdout io.Writer, stderr io.Writer) *cobra.Command {
+	cmd := ctl.NewDiagCommand(stdin, stdout, stderr)
+	ccmd := &cobra.Command{
+		Use:   "diag",
+		Short: "Gather the diagnostics of every node into one report",
+		Long: `
+			Asks every node of a FeatureBase cluster for its diagnostics at once, and merges
+			them into a report of the cluster's problems, the version each node runs, disk
+			usage, how evenly shards are spread, shard replicas which are missing, and the
+			slowest recent queries. Use --json for a report to attach to a support ticket.
+`,
+		RunE: func(c *cobra.Command, args []string) error {
+			return cmd.Run(context.Background())
+		},
+	}
+
+	flags := ccmd.Flags()
+	flags.StringVar(&cmd.Host, "host", "localhost:10101", "host:port of FeatureBase.")
+	flags.IntVar(&cmd.SlowQueries, "slow", cmd.SlowQueries, "Number of slowest recent queries to report.")
+	flags.BoolVar(&cmd.JSON, "json", false, "Write the report as JSON.")
+	flags.StringVar(&cmd.AuthToken, "auth-token", "", "Authentication token")
+	ctl.SetTLSConfig(flags, "", &cmd.TLS.CertificatePath, &cmd.TLS.CertificateKeyPath, &cmd.TLS.CACertPath, &cmd.TLS.SkipVerify, &cmd.TLS.EnableClientVerification)
+	return ccmd
+}
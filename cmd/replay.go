@@ -0,0 +1,46 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/featurebasedb/featurebase/v3/ctl"
+)
+
+var Replayer *ctl.ReplayCommand
+
+func newReplayCommand(stdin io.Reader, stdout, stderr io.Writer) *cobra.Command {
+	Replayer = ctl.NewReplayCommand(stdin, stdout, stderr)
+	replayCmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay captured queries against FeatureBase.",
+		Long: `
+Replays queries captured by a server with query-capture.path set against
+another server, for regression and capacity testing. If the INFILE is not
+specified then the queries are read from STDIN.
+
+Queries are sent at the pace they were captured at, scaled by the speed.
+Queries which fail but didn't when captured, and queries slower than when
+captured by the regression factor or more, are reported, followed by a
+summary.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Replayer.Run(context.Background())
+		},
+	}
+	flags := replayCmd.Flags()
+
+	flags.StringVarP(&Replayer.Host, "host", "", "localhost:10101", "host:port of FeatureBase.")
+	flags.StringVarP(&Replayer.Path, "input-file", "f", "", "Capture file to replay - default stdin")
+	flags.StringVarP(&Replayer.Index, "index", "i", "", "Only replay queries against this index")
+	flags.Float64VarP(&Replayer.Speed, "speed", "s", Replayer.Speed, "Speed relative to the captured pace - 0 for as fast as possible")
+	flags.IntVarP(&Replayer.Concurrency, "concurrency", "c", Replayer.Concurrency, "Maximum number of queries in flight")
+	flags.Float64VarP(&Replayer.Regression, "regression", "", Replayer.Regression, "Report queries this many times slower than when captured")
+	ctl.SetTLSConfig(flags, "", &Replayer.TLS.CertificatePath, &Replayer.TLS.CertificateKeyPath, &Replayer.TLS.CACertPath, &Replayer.TLS.SkipVerify, &Replayer.TLS.EnableClientVerification)
+
+	return replayCmd
+}
@@ -5,6 +5,7 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	pilosa "github.com/featurebasedb/featurebase/v3"
@@ -52,10 +53,13 @@ at https://docs.featurebase.com/.
 	rc.PersistentFlags().StringP("config", "c", "", "Configuration file to read from.")
 
 	rc.AddCommand(newChkSumCommand(stdin, stdout, stderr))
+	rc.AddCommand(newStorageCommand(stdin, stdout, stderr))
+	rc.AddCommand(newDiagCommand(stdin, stdout, stderr))
 	rc.AddCommand(newBackupCommand(stdin, stdout, stderr))
 	rc.AddCommand(newRestoreCommand(stdin, stdout, stderr))
 	rc.AddCommand(newBackupTarCommand(stdin, stdout, stderr))
 	rc.AddCommand(newRestoreTarCommand(stdin, stdout, stderr))
+	rc.AddCommand(newReplayCommand(stdin, stdout, stderr))
 	rc.AddCommand(newConfigCommand(stdin, stdout, stderr))
 	rc.AddCommand(newExportCommand(stdin, stdout, stderr))
 	rc.AddCommand(newGenerateConfigCommand(stdin, stdout, stderr))
@@ -66,6 +70,7 @@ at https://docs.featurebase.com/.
 	rc.AddCommand(newHolderCmd(stdin, stdout, stderr))
 	rc.AddCommand(newHolderCmd(stdin, stdout, stderr))
 	rc.AddCommand(newKeygenCommand(stdin, stdout, stderr))
+	rc.AddCommand(newTranslateMigrateCommand(stdin, stdout, stderr))
 	rc.AddCommand(newCLICommand(stdin, stdout, stderr))
 
 	rc.SetOutput(stderr)
@@ -137,6 +142,14 @@ func setAllConfig(v *viper.Viper, flags *pflag.FlagSet) error { // nolint: unpar
 			// from a flag or env var.
 			vss := v.GetStringSlice(f.Name)
 			value = strings.Join(vss, ",")
+		} else if t := f.Value.Type(); t == "stringToInt" || t == "stringToString" {
+			// Maps need special handling too: their defaults are formatted
+			// as "[key=value]", which can't be set back, and a config file
+			// gives a table rather than a string.
+			value = mapFlagValue(v.Get(f.Name))
+			if value == "" {
+				return
+			}
 		} else {
 			value = v.GetString(f.Name)
 		}
@@ -154,3 +167,21 @@ func setAllConfig(v *viper.Viper, flags *pflag.FlagSet) error { // nolint: unpar
 	})
 	return flagErr
 }
+
+// mapFlagValue formats a value of a map flag from viper as the
+// comma-separated key=value pairs the flag is set with.
+func mapFlagValue(v interface{}) string {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		pairs := make([]string, 0, len(v))
+		for key, val := range v {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", key, val))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	case string:
+		return strings.TrimSuffix(strings.TrimPrefix(v, "["), "]")
+	default:
+		return ""
+	}
+}
@@ -5,6 +5,7 @@ package cmd_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -35,6 +36,10 @@ func TestServerConfig(t *testing.T) {
 	failErr(t, err, "making data dir")
 	logFile, err := testhook.TempFile(t, "")
 	failErr(t, err, "making log file")
+	namespacesPath := filepath.Join(actualDataDir, "namespaces.json")
+	failErr(t, os.WriteFile(namespacesPath, []byte(`{"acme": {"maxShards": 100}}`), 0600), "writing namespace quotas")
+	queryRulesPath := filepath.Join(actualDataDir, "rules.json")
+	failErr(t, os.WriteFile(queryRulesPath, []byte(`[{"calls": ["Store"]}]`), 0600), "writing query rules")
 	tests := []commandTest{
 		// TEST 0
 		{
@@ -86,14 +91,17 @@ func TestServerConfig(t *testing.T) {
 				"--profile.mutex-fraction", "8290",
 			},
 			env: map[string]string{
-				"PILOSA_TRANSLATION_MAP_SIZE":   "100000",
-				"PILOSA_PROFILE_BLOCK_RATE":     "9123",
-				"PILOSA_PROFILE_MUTEX_FRACTION": "444",
+				"PILOSA_TRANSLATION_MAP_SIZE":          "100000",
+				"PILOSA_PROFILE_BLOCK_RATE":            "9123",
+				"PILOSA_PROFILE_MUTEX_FRACTION":        "444",
+				"PILOSA_QUERY_PRIORITY_CLIENT_WEIGHTS": "etl=2,ui=5",
 			},
 			cfgFileContent: `
 	bind = ` + nextPort() + `
 	bind-grpc = ` + nextPort() + `
 	data-dir = "` + actualDataDir + `"
+	namespaces = "` + namespacesPath + `"
+	query-rules = "` + queryRulesPath + `"
     [etcd]
         listen-client-address = "http://localhost:0"
         listen-peer-address = "http://localhost:0"
@@ -108,6 +116,9 @@ func TestServerConfig(t *testing.T) {
 				v.Check(cmd.Server.Config.Translation.MapSize, 100000)
 				v.Check(cmd.Server.Config.Profile.BlockRate, 4832)
 				v.Check(cmd.Server.Config.Profile.MutexFraction, 8290)
+				v.Check(cmd.Server.Config.QueryPriority.ClientWeights, map[string]int{"etl": 2, "ui": 5})
+				v.Check(cmd.Server.Config.Namespaces, namespacesPath)
+				v.Check(cmd.Server.Config.QueryRules, queryRulesPath)
 				return v.Error()
 			},
 		},
@@ -131,6 +142,8 @@ func TestServerConfig(t *testing.T) {
 	[profile]
 		block-rate = 5352
 		mutex-fraction = 91
+	[query-priority]
+		client-weights = { etl = 3 }
 
 	`,
 			validation: func() error {
@@ -141,6 +154,7 @@ func TestServerConfig(t *testing.T) {
 				v.Check(cmd.Server.Config.Metric.Host, "127.0.0.1:8125")
 				v.Check(cmd.Server.Config.Profile.BlockRate, 5352)
 				v.Check(cmd.Server.Config.Profile.MutexFraction, 91)
+				v.Check(cmd.Server.Config.QueryPriority.ClientWeights, map[string]int{"etl": 3})
 				if v.Error() != nil {
 					return v.Error()
 				}
@@ -0,0 +1,36 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/featurebasedb/featurebase/v3/ctl"
+	"github.com/spf13/cobra"
+)
+
+func newStorageCommand(stdin io.Reader, stdout io.Writer, stderr io.Writer) *cobra.Command {
+	cmd := ctl.NewStorageCommand(stdin, stdout, stderr)
+	ccmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Report and optimize how each node stores its data",
+		Long: `
+			Reports, for each node of a FeatureBase cluster, how many shard files each index
+			has, their sizes, free pages, and how their containers are stored. With --optimize,
+			rewrites containers in their smallest form and checkpoints each shard, a shard at a
+			time, reporting progress as it goes, and the storage afterwards.
+`,
+		RunE: func(c *cobra.Command, args []string) error {
+			return cmd.Run(context.Background())
+		},
+	}
+
+	flags := ccmd.Flags()
+	flags.StringVar(&cmd.Host, "host", "localhost:10101", "host:port of FeatureBase.")
+	flags.StringVarP(&cmd.Index, "index", "i", "", "Index to report on. Defaults to every index.")
+	flags.BoolVar(&cmd.Optimize, "optimize", false, "Optimize the storage of each node.")
+	flags.StringVar(&cmd.AuthToken, "auth-token", "", "Authentication token")
+	ctl.SetTLSConfig(flags, "", &cmd.TLS.CertificatePath, &cmd.TLS.CertificateKeyPath, &cmd.TLS.CACertPath, &cmd.TLS.SkipVerify, &cmd.TLS.EnableClientVerification)
+	return ccmd
+}
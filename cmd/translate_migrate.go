@@ -0,0 +1,38 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/featurebasedb/featurebase/v3/ctl"
+	"github.com/spf13/cobra"
+)
+
+func newTranslateMigrateCommand(stdin io.Reader, stdout io.Writer, stderr io.Writer) *cobra.Command {
+	cmd := ctl.NewTranslateMigrateCommand(stdin, stdout, stderr)
+	ccmd := &cobra.Command{
+		Use:   "translate-migrate",
+		Short: "Copy key translation stores between backends.",
+		Long: `
+Copies the key translation stores of a node's indexes and fields from one
+backend to another, boltdb or etcd, so the node can be switched to the other
+with the translation.backend option. The node must be stopped while its
+stores are copied.
+`,
+		RunE: func(c *cobra.Command, args []string) error {
+			return cmd.Run(context.Background())
+		},
+	}
+
+	flags := ccmd.Flags()
+	flags.StringVarP(&cmd.DataDir, "data-dir", "d", "", "Data directory of the node.")
+	flags.StringVar(&cmd.From, "from", cmd.From, "Backend to copy stores from: boltdb or etcd.")
+	flags.StringVar(&cmd.To, "to", cmd.To, "Backend to copy stores to: boltdb or etcd.")
+	flags.StringVarP(&cmd.Index, "index", "i", "", "Only copy the stores of this index.")
+	flags.StringVar(&cmd.EtcdHosts, "etcd-hosts", "", "Comma-separated etcd endpoints of the etcd backend.")
+	flags.StringVar(&cmd.EtcdPrefix, "etcd-prefix", cmd.EtcdPrefix, "Prefix of the etcd backend's keys.")
+	flags.IntVar(&cmd.PartitionN, "partition-n", cmd.PartitionN, "Number of partitions of the index stores.")
+	return ccmd
+}
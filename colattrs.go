@@ -0,0 +1,362 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/sync/errgroup"
+)
+
+// Column attributes are small named values, strings, numbers and bools,
+// attached to the columns of an index, for display-only data which doesn't
+// merit a field. They're imported with ImportColumnAttrs, stored by the
+// nodes owning each column's shard, and returned by
+// Extract(..., includeAttrs=true). Deleting a record deletes its
+// attributes. Attributes aren't moved when shards move between nodes.
+
+// MaxColumnAttrsSize is the largest encoding of a column's attributes.
+const MaxColumnAttrsSize = 4 * 1024
+
+// ErrColumnAttrsTooLarge is returned when a column's attributes exceed
+// MaxColumnAttrsSize.
+var ErrColumnAttrsTooLarge = errors.New("column attributes too large")
+
+// columnAttrStore holds the attributes of columns, as JSON objects, in a
+// bolt database under the holder's path, with one bucket per index keyed
+// by column ID. The database is opened lazily, like the row metadata
+// store.
+type columnAttrStore struct {
+	mu           sync.Mutex
+	path         string
+	fsyncEnabled bool
+	db           *bolt.DB
+}
+
+func newColumnAttrStore(path string, fsyncEnabled bool) *columnAttrStore {
+	return &columnAttrStore{path: path, fsyncEnabled: fsyncEnabled}
+}
+
+// open returns the underlying database, opening it if necessary. If create
+// is false and the database doesn't exist yet, it returns nil.
+func (s *columnAttrStore) open(create bool) (*bolt.DB, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db != nil {
+		return s.db, nil
+	}
+	if !create {
+		if _, err := os.Stat(s.path); os.IsNotExist(err) {
+			return nil, nil
+		}
+	}
+	db, err := bolt.Open(s.path, 0600, &bolt.Options{Timeout: 1 * time.Second, NoSync: !s.fsyncEnabled})
+	if err != nil {
+		return nil, errors.Wrap(err, "opening column attribute store")
+	}
+	s.db = db
+	return db, nil
+}
+
+// Close closes the underlying database, if it was opened.
+func (s *columnAttrStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db = nil
+	return err
+}
+
+func columnAttrKey(col uint64) []byte {
+	var key [8]byte
+	binary.BigEndian.PutUint64(key[:], col)
+	return key[:]
+}
+
+// Set merges attrs into the attributes of each column. A nil value removes
+// an attribute.
+func (s *columnAttrStore) Set(index string, attrs map[uint64]map[string]interface{}) error {
+	db, err := s.open(true)
+	if err != nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(index))
+		if err != nil {
+			return errors.Wrap(err, "creating index bucket")
+		}
+		for col, set := range attrs {
+			key := columnAttrKey(col)
+			m := make(map[string]interface{}, len(set))
+			if v := b.Get(key); v != nil {
+				if err := decodeColumnAttrs(v, &m); err != nil {
+					return errors.Wrapf(err, "decoding attributes of column %d", col)
+				}
+			}
+			for k, v := range set {
+				if v == nil {
+					delete(m, k)
+				} else {
+					m[k] = v
+				}
+			}
+			if len(m) == 0 {
+				if err := b.Delete(key); err != nil {
+					return err
+				}
+				continue
+			}
+			buf, err := json.Marshal(m)
+			if err != nil {
+				return errors.Wrapf(err, "encoding attributes of column %d", col)
+			} else if len(buf) > MaxColumnAttrsSize {
+				return NewBadRequestError(errors.Wrapf(ErrColumnAttrsTooLarge, "column %d", col))
+			}
+			if err := b.Put(key, buf); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// decodeColumnAttrs decodes attributes, keeping numbers as they were
+// given.
+func decodeColumnAttrs(buf []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// Get returns the attributes of those of cols which have any.
+func (s *columnAttrStore) Get(index string, cols []uint64) (map[uint64]map[string]interface{}, error) {
+	db, err := s.open(false)
+	if err != nil || db == nil {
+		return nil, err
+	}
+	attrs := make(map[uint64]map[string]interface{})
+	err = db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(index))
+		if b == nil {
+			return nil
+		}
+		for _, col := range cols {
+			v := b.Get(columnAttrKey(col))
+			if v == nil {
+				continue
+			}
+			var m map[string]interface{}
+			if err := decodeColumnAttrs(v, &m); err != nil {
+				return errors.Wrapf(err, "decoding attributes of column %d", col)
+			}
+			attrs[col] = m
+		}
+		return nil
+	})
+	return attrs, err
+}
+
+// Delete removes the attributes of cols.
+func (s *columnAttrStore) Delete(index string, cols []uint64) error {
+	db, err := s.open(false)
+	if err != nil || db == nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(index))
+		if b == nil {
+			return nil
+		}
+		for _, col := range cols {
+			if err := b.Delete(columnAttrKey(col)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteIndex removes the attributes of the columns of index.
+func (s *columnAttrStore) DeleteIndex(index string) error {
+	db, err := s.open(false)
+	if err != nil || db == nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket([]byte(index)) == nil {
+			return nil
+		}
+		return tx.DeleteBucket([]byte(index))
+	})
+}
+
+// ImportColumnAttrsRequest sets the attributes of columns, identified by
+// ID or, in keyed indexes, by key. Each column's attributes are merged
+// into those it has, and attributes set to null are removed.
+type ImportColumnAttrsRequest struct {
+	ColumnIDs  []uint64                 `json:"columnIDs,omitempty"`
+	ColumnKeys []string                 `json:"columnKeys,omitempty"`
+	Attrs      []map[string]interface{} `json:"attrs"`
+}
+
+// validateColumnAttrs checks that attribute values are strings, numbers,
+// bools or null, and that they fit in MaxColumnAttrsSize.
+func validateColumnAttrs(attrs map[string]interface{}) error {
+	for k, v := range attrs {
+		switch v.(type) {
+		case nil, string, json.Number, float64, bool, int64, uint64, int:
+		default:
+			return errors.Errorf("attribute %q must be a string, number, bool or null, not %T", k, v)
+		}
+	}
+	if buf, err := json.Marshal(attrs); err != nil {
+		return errors.Wrap(err, "encoding attributes")
+	} else if len(buf) > MaxColumnAttrsSize {
+		return ErrColumnAttrsTooLarge
+	}
+	return nil
+}
+
+// ImportColumnAttrs sets the attributes of columns of an index, on the
+// nodes owning their shards.
+func (api *API) ImportColumnAttrs(ctx context.Context, indexName string, req *ImportColumnAttrsRequest) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ImportColumnAttrs")
+	defer span.Finish()
+
+	if err := api.validate(apiImportColumnAttrs); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	idx, err := api.Index(ctx, indexName)
+	if err != nil {
+		return err
+	}
+	cols := req.ColumnIDs
+	if idx.Keys() {
+		if len(req.ColumnIDs) != 0 {
+			return NewBadRequestError(errors.New("column ids cannot be used because index uses string keys"))
+		}
+		if cols, err = api.cluster.translateIndexKeys(ctx, indexName, req.ColumnKeys, true); err != nil {
+			return errors.Wrap(err, "translating columns")
+		}
+	} else if len(req.ColumnKeys) != 0 {
+		return NewBadRequestError(errors.New("column keys cannot be used because index uses integer IDs"))
+	}
+	if len(cols) != len(req.Attrs) {
+		return NewBadRequestError(errors.Errorf("number of columns (%d) and attributes (%d) do not match", len(cols), len(req.Attrs)))
+	}
+
+	// Group the attributes by the nodes owning their shards.
+	snap := api.cluster.NewSnapshot()
+	byNode := make(map[string]map[uint64]map[string]interface{})
+	nodes := make(map[string]*disco.Node)
+	for i, col := range cols {
+		if err := validateColumnAttrs(req.Attrs[i]); err != nil {
+			return NewBadRequestError(errors.Wrapf(err, "column %d", col))
+		}
+		for _, node := range snap.ShardNodes(indexName, col/ShardWidth) {
+			if byNode[node.ID] == nil {
+				byNode[node.ID] = make(map[uint64]map[string]interface{})
+				nodes[node.ID] = node
+			}
+			byNode[node.ID][col] = req.Attrs[i]
+		}
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for id, attrs := range byNode {
+		id, attrs := id, attrs
+		eg.Go(func() error {
+			if id == api.NodeID() {
+				return api.holder.columnAttrs.Set(indexName, attrs)
+			}
+			return api.server.defaultClient.ImportColumnAttrsNode(ctx, &nodes[id].URI, indexName, attrs)
+		})
+	}
+	return eg.Wait()
+}
+
+// ImportColumnAttrsNode sets the attributes of columns of an index on this
+// node only.
+func (api *API) ImportColumnAttrsNode(ctx context.Context, indexName string, attrs map[uint64]map[string]interface{}) error {
+	if err := api.validate(apiImportColumnAttrs); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	if _, err := api.Index(ctx, indexName); err != nil {
+		return err
+	}
+	return api.holder.columnAttrs.Set(indexName, attrs)
+}
+
+// ColumnAttrsNode returns the attributes this node holds for columns of an
+// index.
+func (api *API) ColumnAttrsNode(ctx context.Context, indexName string, cols []uint64) (map[uint64]map[string]interface{}, error) {
+	if err := api.validate(apiColumnAttrs); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	if _, err := api.Index(ctx, indexName); err != nil {
+		return nil, err
+	}
+	return api.holder.columnAttrs.Get(indexName, cols)
+}
+
+// columnAttrs returns the attributes of columns of an index, from the
+// nodes owning their shards.
+func (e *executor) columnAttrs(ctx context.Context, index string, cols []uint64) (map[uint64]map[string]interface{}, error) {
+	snap := e.Cluster.NewSnapshot()
+	byNode := make(map[string][]uint64)
+	owners := make(map[string]*disco.Node)
+	for _, col := range cols {
+		nodes := snap.ShardNodes(index, col/ShardWidth)
+		if len(nodes) == 0 {
+			continue
+		}
+		// Prefer this node, if it owns the shard.
+		node := nodes[0]
+		for _, n := range nodes {
+			if n.ID == e.Node.ID {
+				node = n
+			}
+		}
+		owners[node.ID] = node
+		byNode[node.ID] = append(byNode[node.ID], col)
+	}
+
+	var mu sync.Mutex
+	attrs := make(map[uint64]map[string]interface{}, len(cols))
+	eg, ctx := errgroup.WithContext(ctx)
+	for id, cols := range byNode {
+		id, cols := id, cols
+		eg.Go(func() error {
+			var m map[uint64]map[string]interface{}
+			var err error
+			if id == e.Node.ID {
+				m, err = e.Holder.columnAttrs.Get(index, cols)
+			} else {
+				m, err = e.client.ColumnAttrsNode(ctx, &owners[id].URI, index, cols)
+			}
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for col, a := range m {
+				attrs[col] = a
+			}
+			return nil
+		})
+	}
+	return attrs, eg.Wait()
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/proto"
+)
+
+// The headers of the tables gRPC returns for Extract results describe each
+// column well enough for generic clients to render it without looking up
+// the schema: the index and field it came from, whether it can be null,
+// the scale of decimal fields, and the epoch and time unit of timestamp
+// fields. The _id column and CardinalityOf columns always have values;
+// field columns are null for records without a value in the field.
+
+// AnnotateColumns fills in the metadata of the headers of a table of an
+// Extract of the index, from the schema. Headers which aren't columns of
+// the index's fields are left as they are.
+func (i *Index) AnnotateColumns(headers []*proto.ColumnInfo) {
+	for _, h := range headers {
+		if h.Name == "_id" {
+			h.Index = i.name
+			continue
+		}
+		if name, ok := cardinalityExtractField(h.Name); ok {
+			h.Index, h.Field = i.name, name
+			continue
+		}
+		f := i.Field(h.Name)
+		if f == nil {
+			continue
+		}
+		h.Index, h.Field, h.Nullable = i.name, f.Name(), true
+		opts := f.Options()
+		switch opts.Type {
+		case FieldTypeDecimal:
+			h.Scale = opts.Scale
+		case FieldTypeTimestamp:
+			h.TimeUnit = opts.TimeUnit
+			if epoch, err := ValToTimestamp(opts.TimeUnit, opts.Base); err == nil {
+				h.Epoch = epoch.Format(time.RFC3339Nano)
+			}
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Clearing rows or records updates everything kept about the data it
+// clears in the same pass: ranked caches (see fragment.rowsCleared), the
+// distinct values of fields tracking them, and, when records are deleted,
+// existence and column attributes. CheckConsistency verifies that what's
+// kept agrees with the data.
+
+// Kinds of consistency problems.
+const (
+	// ConsistencyCache is a cached row count which isn't the row's count.
+	ConsistencyCache = "cache"
+	// ConsistencyExistence is columns with values which don't exist.
+	ConsistencyExistence = "existence"
+	// ConsistencyDistinct is tracked distinct values which aren't the
+	// values a shard holds.
+	ConsistencyDistinct = "distinct"
+)
+
+// ConsistencyProblem is a way in which something a node keeps about a
+// shard's data disagrees with the data.
+type ConsistencyProblem struct {
+	Node   string `json:"node,omitempty"`
+	Field  string `json:"field,omitempty"`
+	View   string `json:"view,omitempty"`
+	Shard  uint64 `json:"shard"`
+	Kind   string `json:"kind"`
+	Detail string `json:"detail"`
+}
+
+// CheckConsistency checks, on every node, that the caches, existence and
+// distinct values kept for an index's data agree with the data.
+func (api *API) CheckConsistency(ctx context.Context, indexName string) ([]ConsistencyProblem, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.CheckConsistency")
+	defer span.Finish()
+
+	if err := api.validate(apiCheckConsistency); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	if _, err := api.Index(ctx, indexName); err != nil {
+		return nil, err
+	}
+
+	snap := api.cluster.NewSnapshot()
+	results := make([][]ConsistencyProblem, len(snap.Nodes))
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, node := range snap.Nodes {
+		i, node := i, node
+		eg.Go(func() (err error) {
+			if node.ID == api.NodeID() {
+				results[i], err = api.checkConsistencyThisNode(ctx, indexName)
+			} else {
+				results[i], err = api.server.defaultClient.CheckConsistency(ctx, &node.URI, indexName)
+			}
+			return errors.Wrapf(err, "checking node %s", node.ID)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	problems := []ConsistencyProblem{}
+	for _, result := range results {
+		problems = append(problems, result...)
+	}
+	return problems, nil
+}
+
+// CheckConsistencyNode checks the consistency of an index's data on this
+// node only.
+func (api *API) CheckConsistencyNode(ctx context.Context, indexName string) ([]ConsistencyProblem, error) {
+	if err := api.validate(apiCheckConsistency); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	if _, err := api.Index(ctx, indexName); err != nil {
+		return nil, err
+	}
+	return api.checkConsistencyThisNode(ctx, indexName)
+}
+
+func (api *API) checkConsistencyThisNode(ctx context.Context, indexName string) ([]ConsistencyProblem, error) {
+	idx := api.holder.Index(indexName)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	qcx := api.Txf().NewQcx()
+	defer qcx.Abort()
+	problems, err := idx.checkConsistency(ctx, qcx)
+	for i := range problems {
+		problems[i].Node = api.NodeID()
+	}
+	return problems, err
+}
+
+// checkConsistency checks the index's fragments on this node, shard by
+// shard.
+func (i *Index) checkConsistency(ctx context.Context, qcx *Qcx) ([]ConsistencyProblem, error) {
+	fields := i.Fields()
+	byShard := make(map[uint64][]*fragment)
+	for _, f := range fields {
+		for _, v := range f.views() {
+			if isDistinctView(v.name) {
+				continue
+			}
+			for _, frag := range v.allFragments() {
+				byShard[frag.shard] = append(byShard[frag.shard], frag)
+			}
+		}
+	}
+	shards := make([]uint64, 0, len(byShard))
+	for shard := range byShard {
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(a, b int) bool { return shards[a] < shards[b] })
+
+	var problems []ConsistencyProblem
+	for _, shard := range shards {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		p, err := i.checkShardConsistency(ctx, qcx, fields, shard, byShard[shard])
+		if err != nil {
+			return nil, errors.Wrapf(err, "checking shard %d", shard)
+		}
+		problems = append(problems, p...)
+	}
+	return problems, nil
+}
+
+func (i *Index) checkShardConsistency(ctx context.Context, qcx *Qcx, fields []*Field, shard uint64, frags []*fragment) (problems []ConsistencyProblem, err0 error) {
+	tx, finisher, err := qcx.GetTx(Txo{Index: i, Shard: shard})
+	if err != nil {
+		return nil, err
+	}
+	defer finisher(&err0)
+
+	for _, frag := range frags {
+		p, err := frag.checkConsistency(tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "checking field %s view %s", frag.field(), frag.view())
+		}
+		problems = append(problems, p...)
+	}
+
+	// Every column with a value must exist.
+	if ef := i.existenceField(); ef != nil {
+		cols, err := fieldsColumnsShard(ctx, tx, fields, shard)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting columns with values")
+		}
+		exists := NewRow()
+		if frag := i.holder.fragment(i.name, existenceFieldName, viewStandard, shard); frag != nil {
+			if exists, err = frag.row(tx, 0); err != nil {
+				return nil, errors.Wrap(err, "getting existence")
+			}
+		}
+		if missing := cols.Difference(exists); missing.Any() {
+			problems = append(problems, ConsistencyProblem{
+				Shard:  shard,
+				Kind:   ConsistencyExistence,
+				Detail: fmt.Sprintf("%d columns have values but don't exist, starting with %d", missing.Count(), missing.Columns()[0]),
+			})
+		}
+	}
+	return problems, nil
+}
+
+// checkConsistency checks the fragment's cached row counts and, if it
+// tracks them, its field's distinct values.
+func (f *fragment) checkConsistency(tx Tx) ([]ConsistencyProblem, error) {
+	var problems []ConsistencyProblem
+	problem := func(kind, format string, args ...interface{}) {
+		problems = append(problems, ConsistencyProblem{
+			Field:  f.field(),
+			View:   f.view(),
+			Shard:  f.shard,
+			Kind:   kind,
+			Detail: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if f.CacheType != CacheTypeNone {
+		for _, rowID := range f.cache.IDs() {
+			n, err := tx.CountRange(f.index(), f.field(), f.view(), f.shard, rowID*ShardWidth, (rowID+1)*ShardWidth)
+			if err != nil {
+				return nil, errors.Wrap(err, "counting row")
+			}
+			if cached := f.cache.Get(rowID); cached != n {
+				problem(ConsistencyCache, "row %d has %d bits but a cached count of %d", rowID, n, cached)
+			}
+		}
+	}
+
+	if f.tracksDistinct() {
+		bsig := f.fld.bsiGroup(f.field())
+		if bsig == nil {
+			return problems, nil
+		}
+		want, err := f.storedValues(tx, bsig.BitDepth, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting values")
+		}
+		var extra []int64
+		got := make(map[int64]struct{})
+		err = tx.ForEach(f.index(), f.field(), viewDistinct, f.shard, func(pos uint64) error {
+			v := distinctValue(pos)
+			got[v] = struct{}{}
+			if _, ok := want[v]; !ok {
+				extra = append(extra, v)
+			}
+			return nil
+		})
+		if err != nil {
+			switch errors.Cause(err) {
+			case ViewNotFound, FragmentNotFound:
+			default:
+				return nil, errors.Wrap(err, "reading distinct values")
+			}
+		}
+		var missing []int64
+		for v := range want {
+			if _, ok := got[v]; !ok {
+				missing = append(missing, v)
+			}
+		}
+		if len(extra) > 0 || len(missing) > 0 {
+			problem(ConsistencyDistinct, "%d stored values aren't tracked and %d tracked ones aren't stored", len(missing), len(extra))
+		}
+	}
+	return problems, nil
+}
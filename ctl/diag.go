@@ -0,0 +1,354 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package ctl
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	pilosa "github.com/featurebasedb/featurebase/v3"
+	"github.com/featurebasedb/featurebase/v3/authn"
+	"github.com/featurebasedb/featurebase/v3/server"
+)
+
+// DiagCommand represents a command for gathering the diagnostics of every
+// node of a cluster into one report.
+type DiagCommand struct {
+	tlsConfig *tls.Config
+
+	// Host and port of a node of the cluster.
+	Host string `json:"host"`
+
+	// Number of slowest recent queries to report.
+	SlowQueries int `json:"slow-queries"`
+
+	// Write the report as JSON.
+	JSON bool `json:"json"`
+
+	// Reusable client.
+	client *pilosa.InternalClient
+
+	// Standard input/output
+	*pilosa.CmdIO
+
+	TLS server.TLSConfig
+
+	AuthToken string
+}
+
+// NewDiagCommand returns a new instance of DiagCommand.
+func NewDiagCommand(stdin io.Reader, stdout, stderr io.Writer) *DiagCommand {
+	return &DiagCommand{
+		SlowQueries: pilosa.DefaultSlowQueries,
+		CmdIO:       pilosa.NewCmdIO(stdin, stdout, stderr),
+	}
+}
+
+// DiagReport merges the diagnostics of the nodes of a cluster.
+type DiagReport struct {
+	Time time.Time `json:"time"`
+
+	// Problems summarizes what's wrong with the cluster.
+	Problems []string `json:"problems"`
+
+	// Versions lists the nodes running each version.
+	Versions map[string][]string `json:"versions"`
+
+	// Unreachable holds the error getting the diagnostics of each node
+	// which couldn't be reached.
+	Unreachable map[string]string `json:"unreachable,omitempty"`
+
+	Disk            []DiagDisk                `json:"disk"`
+	ShardBalance    []DiagShardBalance        `json:"shardBalance"`
+	ReplicationGaps []DiagReplicationGap      `json:"replicationGaps"`
+	SlowQueries     []pilosa.PastQueryStatus  `json:"slowQueries"`
+	Nodes           []*pilosa.NodeDiagnostics `json:"nodes"`
+}
+
+// DiagDisk is the storage used by a node.
+type DiagDisk struct {
+	Node      string `json:"node"`
+	Files     int    `json:"files"`
+	Bytes     int64  `json:"bytes"`
+	WALBytes  int64  `json:"walBytes"`
+	FreePages int    `json:"freePages"`
+}
+
+// DiagShardBalance is how many shards of an index each node holds.
+type DiagShardBalance struct {
+	Index string         `json:"index"`
+	Min   int            `json:"min"`
+	Max   int            `json:"max"`
+	Held  map[string]int `json:"held"`
+}
+
+// DiagReplicationGap is a shard a node should hold, but doesn't.
+type DiagReplicationGap struct {
+	Index string `json:"index"`
+	Shard uint64 `json:"shard"`
+	Node  string `json:"node"`
+}
+
+// Run gets the diagnostics of every node concurrently, and writes the
+// merged report.
+func (cmd *DiagCommand) Run(ctx context.Context) (err error) {
+	// Parse TLS configuration for node-specific clients.
+	tls := cmd.TLSConfiguration()
+	if cmd.tlsConfig, err = server.GetTLSConfig(&tls, cmd.Logger()); err != nil {
+		return fmt.Errorf("parsing tls config: %w", err)
+	}
+
+	client, err := commandClient(cmd)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+	cmd.client = client
+
+	if cmd.AuthToken != "" {
+		ctx = context.WithValue(ctx, authn.ContextValueAccessToken, "Bearer "+cmd.AuthToken)
+	}
+
+	nodes, err := cmd.client.Nodes(ctx)
+	if err != nil {
+		return fmt.Errorf("getting nodes: %w", err)
+	}
+
+	diags := make([]*pilosa.NodeDiagnostics, len(nodes))
+	errs := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		i, node := i, node
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			uri := node.URI
+			d, err := cmd.client.NodeDiagnostics(ctx, &uri, cmd.SlowQueries)
+			if err != nil {
+				mu.Lock()
+				errs[node.ID] = err.Error()
+				mu.Unlock()
+				return
+			}
+			diags[i] = d
+		}()
+	}
+	wg.Wait()
+
+	report := mergeDiagnostics(diags, errs, cmd.SlowQueries)
+	if cmd.JSON {
+		enc := json.NewEncoder(cmd.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	return cmd.writeReport(report)
+}
+
+// mergeDiagnostics merges the diagnostics of the nodes which could be
+// reached, and the errors of those which couldn't, into a report with up
+// to slowN of the slowest queries across the cluster.
+func mergeDiagnostics(diags []*pilosa.NodeDiagnostics, errs map[string]string, slowN int) *DiagReport {
+	report := &DiagReport{
+		Time:            time.Now().UTC(),
+		Problems:        []string{},
+		Versions:        make(map[string][]string),
+		Disk:            []DiagDisk{},
+		ShardBalance:    []DiagShardBalance{},
+		ReplicationGaps: []DiagReplicationGap{},
+		SlowQueries:     []pilosa.PastQueryStatus{},
+		Nodes:           []*pilosa.NodeDiagnostics{},
+	}
+	if len(errs) > 0 {
+		report.Unreachable = errs
+		ids := make([]string, 0, len(errs))
+		for id := range errs {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			report.Problems = append(report.Problems, fmt.Sprintf("node %s is unreachable: %s", id, errs[id]))
+		}
+	}
+
+	balance := make(map[string]*DiagShardBalance)
+	for _, d := range diags {
+		if d == nil {
+			continue
+		}
+		report.Nodes = append(report.Nodes, d)
+		report.Versions[d.Version] = append(report.Versions[d.Version], d.Node)
+
+		if d.State != "NORMAL" {
+			report.Problems = append(report.Problems, fmt.Sprintf("node %s sees the cluster as %s", d.Node, d.State))
+		}
+		if !d.Health.Healthy {
+			report.Problems = append(report.Problems, fmt.Sprintf("node %s has %d corrupt fragments", d.Node, len(d.Health.CorruptFragments)))
+		}
+		if d.Health.ReadOnly != "" {
+			report.Problems = append(report.Problems, fmt.Sprintf("node %s is read-only: %s", d.Node, d.Health.ReadOnly))
+		}
+		if d.Health.Maintenance != "" {
+			report.Problems = append(report.Problems, fmt.Sprintf("node %s is in maintenance: %s", d.Node, d.Health.Maintenance))
+		}
+		if n := len(d.Health.StaleTransactions); n > 0 {
+			report.Problems = append(report.Problems, fmt.Sprintf("node %s has %d stale transactions", d.Node, n))
+		}
+
+		disk := DiagDisk{Node: d.Node}
+		if d.Storage != nil {
+			disk.Files = d.Storage.Files
+			disk.Bytes = d.Storage.Bytes
+			disk.WALBytes = d.Storage.WALBytes
+			disk.FreePages = d.Storage.FreePages
+		}
+		report.Disk = append(report.Disk, disk)
+
+		for _, sh := range d.Shards {
+			b, ok := balance[sh.Index]
+			if !ok {
+				b = &DiagShardBalance{Index: sh.Index, Held: make(map[string]int)}
+				balance[sh.Index] = b
+			}
+			b.Held[d.Node] = sh.Held
+			for _, shard := range sh.Missing {
+				report.ReplicationGaps = append(report.ReplicationGaps, DiagReplicationGap{Index: sh.Index, Shard: shard, Node: d.Node})
+			}
+		}
+
+		report.SlowQueries = append(report.SlowQueries, d.SlowQueries...)
+	}
+
+	if len(report.Versions) > 1 {
+		versions := make([]string, 0, len(report.Versions))
+		for v := range report.Versions {
+			versions = append(versions, v)
+		}
+		sort.Strings(versions)
+		report.Problems = append(report.Problems, fmt.Sprintf("nodes run different versions: %s", strings.Join(versions, ", ")))
+	}
+
+	indexes := make([]string, 0, len(balance))
+	for index := range balance {
+		indexes = append(indexes, index)
+	}
+	sort.Strings(indexes)
+	for _, index := range indexes {
+		b := balance[index]
+		first := true
+		for _, n := range b.Held {
+			if first || n < b.Min {
+				b.Min = n
+			}
+			if first || n > b.Max {
+				b.Max = n
+			}
+			first = false
+		}
+		// A node holding a shard or two more than another is expected,
+		// since shards are placed by hashing.
+		if b.Max-b.Min > 1 && float64(b.Max) > 1.25*float64(b.Min) {
+			report.Problems = append(report.Problems, fmt.Sprintf("shards of %s are unbalanced: nodes hold between %d and %d", index, b.Min, b.Max))
+		}
+		report.ShardBalance = append(report.ShardBalance, *b)
+	}
+
+	sort.Slice(report.ReplicationGaps, func(i, j int) bool {
+		a, b := report.ReplicationGaps[i], report.ReplicationGaps[j]
+		if a.Index != b.Index {
+			return a.Index < b.Index
+		} else if a.Shard != b.Shard {
+			return a.Shard < b.Shard
+		}
+		return a.Node < b.Node
+	})
+	if n := len(report.ReplicationGaps); n > 0 {
+		report.Problems = append(report.Problems, fmt.Sprintf("%d shard replicas are missing", n))
+	}
+
+	sort.SliceStable(report.SlowQueries, func(i, j int) bool {
+		return report.SlowQueries[i].RuntimeNs > report.SlowQueries[j].RuntimeNs
+	})
+	if len(report.SlowQueries) > slowN {
+		report.SlowQueries = report.SlowQueries[:slowN]
+	}
+	return report
+}
+
+// writeReport writes the report as text.
+func (cmd *DiagCommand) writeReport(report *DiagReport) error {
+	w := cmd.Stdout
+	fmt.Fprintf(w, "Cluster diagnostics at %s\n\n", report.Time.Format(time.RFC3339))
+
+	fmt.Fprintln(w, "Problems:")
+	if len(report.Problems) == 0 {
+		fmt.Fprintln(w, "  none found")
+	}
+	for _, p := range report.Problems {
+		fmt.Fprintf(w, "  %s\n", p)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "\nNODE\tURI\tVERSION\tSTATE\tHEALTHY\tFILES\tBYTES\tWAL BYTES\tFREE PAGES")
+	for i, d := range report.Nodes {
+		disk := report.Disk[i]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%d\t%d\t%d\t%d\n", d.Node, d.URI, d.Version, d.State, d.Health.Healthy,
+			disk.Files, disk.Bytes, disk.WALBytes, disk.FreePages)
+	}
+	ids := make([]string, 0, len(report.Unreachable))
+	for id := range report.Unreachable {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		fmt.Fprintf(tw, "%s\t\t\tUNREACHABLE\t\t\t\t\t\n", id)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nShard balance:")
+	tw = tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "  INDEX\tMIN\tMAX\tHELD")
+	for _, b := range report.ShardBalance {
+		held := make([]string, 0, len(b.Held))
+		for node, n := range b.Held {
+			held = append(held, fmt.Sprintf("%s=%d", node, n))
+		}
+		sort.Strings(held)
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%s\n", b.Index, b.Min, b.Max, strings.Join(held, " "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(report.ReplicationGaps) > 0 {
+		fmt.Fprintln(w, "\nMissing shard replicas:")
+		for _, g := range report.ReplicationGaps {
+			fmt.Fprintf(w, "  %s shard %d on node %s\n", g.Index, g.Shard, g.Node)
+		}
+	}
+
+	fmt.Fprintln(w, "\nSlowest recent queries:")
+	tw = tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "  RUNTIME\tNODE\tINDEX\tSTARTED\tQUERY")
+	for _, q := range report.SlowQueries {
+		query := q.PQL
+		if q.SQL != "" {
+			query = q.SQL
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", q.RuntimeNs, q.Node, q.Index, q.Start.Format(time.RFC3339), query)
+	}
+	return tw.Flush()
+}
+
+func (cmd *DiagCommand) TLSHost() string { return cmd.Host }
+
+func (cmd *DiagCommand) TLSConfiguration() server.TLSConfig { return cmd.TLS }
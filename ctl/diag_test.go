@@ -0,0 +1,100 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pilosa "github.com/featurebasedb/featurebase/v3"
+	"github.com/featurebasedb/featurebase/v3/test"
+)
+
+func TestDiagCommand_Run(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1)
+	defer cluster.Close()
+	cmd := cluster.GetNode(0)
+
+	idx := cluster.Idx()
+	cluster.CreateField(t, idx, pilosa.IndexOptions{}, "f")
+	if _, err := cmd.API.Query(context.Background(), &pilosa.QueryRequest{Index: idx, Query: "Set(1, f=1)"}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Text", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		cm := NewDiagCommand(bytes.NewReader(nil), &stdout, &stderr)
+		cm.Host = cmd.API.Node().URI.HostPort()
+		if err := cm.Run(context.Background()); err != nil {
+			t.Fatalf("Diag Run doesn't work: %s", err)
+		}
+		out := stdout.String()
+		for _, want := range []string{"Problems:\n  none found", "NODE", cmd.API.NodeID(), "Shard balance:", idx, "Slowest recent queries:", "Set(1, f=1)"} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("expected %q in output:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		cm := NewDiagCommand(bytes.NewReader(nil), &stdout, &stderr)
+		cm.Host = cmd.API.Node().URI.HostPort()
+		cm.JSON = true
+		if err := cm.Run(context.Background()); err != nil {
+			t.Fatalf("Diag Run doesn't work: %s", err)
+		}
+		var report DiagReport
+		if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+			t.Fatalf("decoding report: %s\n%s", err, stdout.String())
+		}
+		if len(report.Nodes) != 1 || len(report.Disk) != 1 || report.Disk[0].Files != 1 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+}
+
+func TestMergeDiagnostics(t *testing.T) {
+	node := func(id, version string, held int, missing []uint64, runtimes ...time.Duration) *pilosa.NodeDiagnostics {
+		d := &pilosa.NodeDiagnostics{
+			Node:    id,
+			Version: version,
+			State:   "NORMAL",
+			Health:  pilosa.Health{Healthy: true},
+			Storage: &pilosa.StorageReport{StorageCounts: pilosa.StorageCounts{Files: held}},
+			Shards:  []pilosa.ShardHolding{{Index: "i", Held: held, Missing: missing}},
+		}
+		for _, r := range runtimes {
+			d.SlowQueries = append(d.SlowQueries, pilosa.PastQueryStatus{Node: id, RuntimeNs: r})
+		}
+		return d
+	}
+	report := mergeDiagnostics([]*pilosa.NodeDiagnostics{
+		node("a", "v3.1.0", 10, nil, 3*time.Second, time.Second),
+		node("b", "v3.2.0", 2, []uint64{7, 3}, 2*time.Second),
+		nil,
+	}, map[string]string{"c": "connection refused"}, 2)
+
+	if exp := []string{
+		"node c is unreachable: connection refused",
+		"nodes run different versions: v3.1.0, v3.2.0",
+		"shards of i are unbalanced: nodes hold between 2 and 10",
+		"2 shard replicas are missing",
+	}; !reflect.DeepEqual(report.Problems, exp) {
+		t.Fatalf("expected problems %q, got %q", exp, report.Problems)
+	}
+	if exp := []DiagReplicationGap{{Index: "i", Shard: 3, Node: "b"}, {Index: "i", Shard: 7, Node: "b"}}; !reflect.DeepEqual(report.ReplicationGaps, exp) {
+		t.Fatalf("expected gaps %v, got %v", exp, report.ReplicationGaps)
+	}
+	if len(report.SlowQueries) != 2 || report.SlowQueries[0].Node != "a" || report.SlowQueries[1].Node != "b" {
+		t.Fatalf("unexpected slow queries: %v", report.SlowQueries)
+	}
+	if len(report.Disk) != 2 || report.Disk[1].Files != 2 {
+		t.Fatalf("unexpected disk: %v", report.Disk)
+	}
+}
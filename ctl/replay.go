@@ -0,0 +1,187 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package ctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	pilosa "github.com/featurebasedb/featurebase/v3"
+	"github.com/featurebasedb/featurebase/v3/server"
+	"github.com/pkg/errors"
+)
+
+// ReplayCommand represents a command for replaying captured queries against
+// a server.
+type ReplayCommand struct {
+	// Remote host and port.
+	Host string
+
+	// Capture file to replay. Read from STDIN if empty. It's read as
+	// queries are replayed, so it mustn't be one the server is capturing to.
+	Path string
+
+	// Speed is the rate queries are sent at, relative to the rate they
+	// were captured at. Zero sends them as fast as possible.
+	Speed float64
+
+	// Concurrency is the maximum number of queries in flight.
+	Concurrency int
+
+	// If set, only queries against this index are replayed.
+	Index string
+
+	// Regression is how many times slower than when it was captured a
+	// query must be to be reported.
+	Regression float64
+
+	// Standard input/output
+	*pilosa.CmdIO
+
+	TLS server.TLSConfig
+}
+
+// NewReplayCommand returns a new instance of ReplayCommand.
+func NewReplayCommand(stdin io.Reader, stdout, stderr io.Writer) *ReplayCommand {
+	return &ReplayCommand{
+		CmdIO:       pilosa.NewCmdIO(stdin, stdout, stderr),
+		Speed:       1,
+		Concurrency: 8,
+		Regression:  2,
+	}
+}
+
+// ReplaySummary describes the results of a replay.
+type ReplaySummary struct {
+	Queries int
+	Errors  int
+
+	// NewErrors are the queries which failed in the replay, but not when
+	// they were captured.
+	NewErrors int
+
+	// Regressions are the queries which took at least Regression times as
+	// long as when they were captured.
+	Regressions int
+
+	// Captured and Replayed are the total durations of the queries, when
+	// captured and replayed.
+	Captured time.Duration
+	Replayed time.Duration
+}
+
+// Run executes the replay.
+func (cmd *ReplayCommand) Run(ctx context.Context) error {
+	_, err := cmd.Replay(ctx)
+	return err
+}
+
+// Replay replays the captured queries, reporting failures and regressions
+// as it goes, and returns a summary of the results.
+func (cmd *ReplayCommand) Replay(ctx context.Context) (*ReplaySummary, error) {
+	if cmd.Speed < 0 {
+		return nil, errors.New("speed must not be negative")
+	}
+	concurrency := cmd.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var r io.Reader = cmd.Stdin
+	if cmd.Path != "" {
+		f, err := os.Open(cmd.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening capture file")
+		}
+		defer f.Close()
+		r = f
+	}
+
+	client, err := commandClient(cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating client")
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		summary ReplaySummary
+		sem     = make(chan struct{}, concurrency)
+		first   time.Time
+		start   = time.Now()
+	)
+	replay := func(q pilosa.CapturedQuery) {
+		defer wg.Done()
+		defer func() { <-sem }()
+
+		t := time.Now()
+		_, err := client.Query(ctx, q.Index, &pilosa.QueryRequest{Index: q.Index, Query: q.Query, Shards: q.Shards})
+		dur := time.Since(t)
+
+		mu.Lock()
+		defer mu.Unlock()
+		summary.Queries++
+		summary.Captured += q.Duration
+		summary.Replayed += dur
+		if err != nil {
+			summary.Errors++
+			if q.Error == "" {
+				summary.NewErrors++
+				fmt.Fprintf(cmd.Stdout, "error: %s: %s: %v\n", q.Index, q.Query, err)
+			}
+			return
+		}
+		if cmd.Regression > 0 && q.Duration > 0 && float64(dur) >= cmd.Regression*float64(q.Duration) {
+			summary.Regressions++
+			fmt.Fprintf(cmd.Stdout, "slower: %.1fx (%v -> %v): %s: %s\n", float64(dur)/float64(q.Duration), q.Duration, dur, q.Index, q.Query)
+		}
+	}
+
+	err = pilosa.ReadCapturedQueries(r, func(q pilosa.CapturedQuery) error {
+		if cmd.Index != "" && q.Index != cmd.Index {
+			return nil
+		}
+		// Send each query at the same offset from the first as when it was
+		// captured, scaled by the speed.
+		if first.IsZero() {
+			first = q.Time
+		} else if cmd.Speed > 0 {
+			at := start.Add(time.Duration(float64(q.Time.Sub(first)) / cmd.Speed))
+			if wait := time.Until(at); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		wg.Add(1)
+		go replay(q)
+		return nil
+	})
+	wg.Wait()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading captured queries")
+	}
+
+	fmt.Fprintf(cmd.Stdout, "replayed %d queries in %v: %d errors (%d new), %d regressions; captured total %v, replayed total %v\n",
+		summary.Queries, time.Since(start).Round(time.Millisecond), summary.Errors, summary.NewErrors, summary.Regressions, summary.Captured, summary.Replayed)
+	return &summary, nil
+}
+
+func (cmd *ReplayCommand) TLSHost() string {
+	return cmd.Host
+}
+
+func (cmd *ReplayCommand) TLSConfiguration() server.TLSConfig {
+	return cmd.TLS
+}
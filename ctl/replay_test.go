@@ -0,0 +1,85 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pilosa "github.com/featurebasedb/featurebase/v3"
+	"github.com/featurebasedb/featurebase/v3/server"
+	"github.com/featurebasedb/featurebase/v3/test"
+)
+
+func TestReplayCommand_Run(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.ndjson")
+	cluster := test.MustRunCluster(t, 1, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerQueryCapture(path, 1)),
+	})
+	defer cluster.Close()
+
+	cluster.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	cluster.Query(t, "i", `Set(1, f=1)`)
+	cluster.Query(t, "i", `Count(Row(f=1))`)
+	if _, err := cluster.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Row(nope=1)`}); err == nil {
+		t.Fatal("expected error querying a missing field")
+	}
+
+	var captured []pilosa.CapturedQuery
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := pilosa.ReadCapturedQueries(f, func(q pilosa.CapturedQuery) error {
+		captured = append(captured, q)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(captured) != 3 {
+		t.Fatalf("expected 3 captured queries, got %+v", captured)
+	} else if captured[1].Query != `Count(Row(f=1))` || captured[1].Index != "i" {
+		t.Fatalf("unexpected captured query: %+v", captured[1])
+	} else if captured[2].Error == "" {
+		t.Fatalf("expected captured error: %+v", captured[2])
+	}
+
+	// Replay a copy, since the cluster captures the replayed queries too.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayPath := filepath.Join(t.TempDir(), "replay.ndjson")
+	if err := os.WriteFile(replayPath, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := bytes.Buffer{}
+	stdin, stdout, stderr := GetIO(buf)
+	cm := NewReplayCommand(stdin, stdout, stderr)
+	cm.Host = cluster.GetNode(0).API.Node().URI.HostPort()
+	cm.Path = replayPath
+	cm.Speed = 0
+	cm.Concurrency = 1
+	cm.Regression = 0
+	summary, err := cm.Replay(context.Background())
+	if err != nil {
+		t.Fatalf("replaying: %v", err)
+	}
+	if summary.Queries != 3 || summary.Errors != 1 || summary.NewErrors != 0 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+
+	cm.Path = ""
+	cm.Stdin = strings.NewReader(`{"index": "i", "query": "Row(missing=1)"}`)
+	if summary, err = cm.Replay(context.Background()); err != nil {
+		t.Fatalf("replaying: %v", err)
+	} else if summary.NewErrors != 1 {
+		t.Fatalf("expected a new error: %+v", summary)
+	}
+}
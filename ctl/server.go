@@ -27,22 +27,68 @@ func BuildServerFlags(cmd *cobra.Command, srv *server.Command) {
 	flags.DurationVar((*time.Duration)(&srv.Config.LongQueryTime), "long-query-time", time.Duration(srv.Config.LongQueryTime), "Duration that will trigger log and stat messages for slow queries. Zero to disable.")
 	flags.IntVar(&srv.Config.QueryHistoryLength, "query-history-length", srv.Config.QueryHistoryLength, "Number of queries to remember in history.")
 	flags.Int64Var(&srv.Config.MaxQueryMemory, "max-query-memory", srv.Config.MaxQueryMemory, "Maximum memory allowed per Extract() or SELECT query.")
+	flags.StringVar(&srv.Config.TempDir, "temp-dir", srv.Config.TempDir, "Directory which results too large to assemble in memory are spilled to. Defaults to the data directory's tmp.")
+	flags.Int64Var(&srv.Config.TempDirQuota, "temp-dir-quota", srv.Config.TempDirQuota, "Maximum bytes spilled to the temporary directory. Zero means no limit.")
+	flags.Int64Var(&srv.Config.SpillThreshold, "spill-threshold", srv.Config.SpillThreshold, "Bytes the columns of an Extract() can take before they're spilled to the temporary directory. Zero never spills them.")
+	flags.StringVar(&srv.Config.ExistenceFallback, "existence-fallback", srv.Config.ExistenceFallback, "Field whose columns Not(), All(), and == null treat as existing in indexes without existence tracking, or * for all fields.")
+	flags.BoolVar(&srv.Config.LazyOpen, "lazy-open", srv.Config.LazyOpen, "Open fragments when they're first used rather than all at startup.")
+	flags.BoolVar(&srv.Config.Checksums.Enabled, "checksums.enabled", srv.Config.Checksums.Enabled, "Save a checksum of each fragment's data, and verify it when the fragment is opened.")
+	flags.BoolVar(&srv.Config.RewriteLegacyCalls, "rewrite-legacy-calls", srv.Config.RewriteLegacyCalls, "Rewrite legacy PQL calls, such as SetBit and Range, to the calls which replaced them.")
+	flags.DurationVar((*time.Duration)(&srv.Config.StaleTransactionAge), "stale-transaction-age", time.Duration(srv.Config.StaleTransactionAge), "How long a transaction can be open before it's reported as stale. Zero disables reporting.")
+	flags.DurationVar((*time.Duration)(&srv.Config.ReadCoalesceWindow), "read-coalesce-window", time.Duration(srv.Config.ReadCoalesceWindow), "How long a row read from a fragment is shared with other queries reading the same data. Negative disables sharing.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Checksums.ScrubInterval), "checksums.scrub-interval", time.Duration(srv.Config.Checksums.ScrubInterval), "How often to verify open fragments against their checksums. Zero disables scrubbing.")
+
+	// QueryPriority
+	flags.IntVar(&srv.Config.QueryPriority.MaxBatch, "query-priority.max-batch", srv.Config.QueryPriority.MaxBatch, "Maximum number of batch priority queries coordinated at once. Zero for no limit.")
+	flags.IntVar(&srv.Config.QueryPriority.MaxBackground, "query-priority.max-background", srv.Config.QueryPriority.MaxBackground, "Maximum number of background priority queries coordinated at once. Zero for no limit.")
+	flags.StringToIntVar(&srv.Config.QueryPriority.ClientWeights, "query-priority.client-weights", srv.Config.QueryPriority.ClientWeights, "Shares of the shard workers given to clients, as client=weight pairs. Clients without a weight have a weight of one.")
+
+	// Disk
+	flags.Int64Var(&srv.Config.Disk.MaxStorage, "disk.max-storage-bytes", srv.Config.Disk.MaxStorage, "Bytes of disk the node's data can use before imports are refused. Zero means no limit.")
+	flags.Uint64Var(&srv.Config.Disk.MinFree, "disk.min-free-bytes", srv.Config.Disk.MinFree, "Bytes of disk which must be left free, below which the node is read-only.")
+	flags.Float64Var(&srv.Config.Disk.MinFreePercent, "disk.min-free-percent", srv.Config.Disk.MinFreePercent, "Percentage of disk which must be left free, below which the node is read-only.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Disk.CheckInterval), "disk.check-interval", time.Duration(srv.Config.Disk.CheckInterval), "How often to check the free space on disk.")
+
+	// Events
+	flags.StringSliceVar(&srv.Config.Events.Webhooks, "events.webhooks", srv.Config.Events.Webhooks, "Comma separated list of URLs to post events about schema and cluster changes to.")
+
+	// Query capture
+	flags.StringVar(&srv.Config.QueryCapture.Path, "query-capture.path", srv.Config.QueryCapture.Path, "File to capture queries to, for replay. Empty for no capture.")
+	flags.Float64Var(&srv.Config.QueryCapture.SampleRate, "query-capture.sample-rate", srv.Config.QueryCapture.SampleRate, "Fraction of queries to capture. Queries slower than long-query-time are always captured.")
+
+	// Canary
+	flags.StringVar(&srv.Config.Canary.Path, "canary.path", srv.Config.Canary.Path, "Execution path to execute sampled queries on a second time, comparing their results.")
+	flags.Float64Var(&srv.Config.Canary.SampleRate, "canary.sample-rate", srv.Config.Canary.SampleRate, "Fraction of read-only queries to execute on the canary path. Zero disables the canary.")
+
+	// Plugins
+	flags.StringVar(&srv.Config.PluginsDir, "plugins-dir", srv.Config.PluginsDir, "Directory user-defined functions, as WebAssembly modules, are loaded from.")
+	flags.StringVar(&srv.Config.Namespaces, "namespaces", srv.Config.Namespaces, "JSON file of the quotas of namespaces, by name.")
+	flags.StringVar(&srv.Config.QueryRules, "query-rules", srv.Config.QueryRules, "JSON file of rules which allow or deny calls in queries, checked in order.")
+	flags.StringVar(&srv.Config.OptionTemplates, "option-templates", srv.Config.OptionTemplates, "JSON file of default options for new indexes and fields, and of named templates of options their requests can use.")
 
 	// TLS
 	SetTLSConfig(flags, "", &srv.Config.TLS.CertificatePath, &srv.Config.TLS.CertificateKeyPath, &srv.Config.TLS.CACertPath, &srv.Config.TLS.SkipVerify, &srv.Config.TLS.EnableClientVerification)
 
 	// Handler
 	flags.StringSliceVar(&srv.Config.Handler.AllowedOrigins, "handler.allowed-origins", []string{}, "Comma separated list of allowed origin URIs (for CORS/Web UI).")
+	flags.StringSliceVar(&srv.Config.Handler.AllowedHeaders, "handler.allowed-headers", []string{}, "Comma separated list of headers, besides Content-Type, cross-origin requests can send.")
+	flags.StringSliceVar(&srv.Config.Handler.AllowedMethods, "handler.allowed-methods", []string{}, "Comma separated list of methods cross-origin requests can use (default GET, HEAD and POST).")
+	flags.StringSliceVar(&srv.Config.Handler.ExposedHeaders, "handler.exposed-headers", []string{}, "Comma separated list of response headers cross-origin requests can read.")
+	flags.BoolVar(&srv.Config.Handler.AllowCredentials, "handler.allow-credentials", false, "Allow cross-origin requests to send credentials.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Handler.CORSMaxAge), "handler.cors-max-age", 0, "How long browsers can cache responses to CORS preflight requests.")
 
 	// Cluster
 	flags.IntVar(&srv.Config.Cluster.ReplicaN, "cluster.replicas", 1, "Number of hosts each piece of data should be stored on.")
 	flags.DurationVar((*time.Duration)(&srv.Config.Cluster.LongQueryTime), "cluster.long-query-time", time.Duration(srv.Config.Cluster.LongQueryTime), "RENAMED TO 'long-query-time': Duration that will trigger log and stat messages for slow queries.") // negative duration indicates invalid value because 0 is meaningful
 	flags.StringVar(&srv.Config.Cluster.Name, "cluster.name", srv.Config.Cluster.Name, "Human-readable name for the cluster.")
-	flags.StringVar(&srv.Config.Cluster.PartitionToNodeAssignment, "cluster.partition-to-node-assignment", srv.Config.Cluster.PartitionToNodeAssignment, "How to assign partitions to nodes. jmp-hash or modulus")
+	flags.StringVar(&srv.Config.Cluster.PartitionToNodeAssignment, "cluster.partition-to-node-assignment", srv.Config.Cluster.PartitionToNodeAssignment, "How to assign partitions to nodes. jmp-hash, modulus or ring-hash")
 
 	// Translation
 	flags.StringVar(&srv.Config.Translation.PrimaryURL, "translation.primary-url", srv.Config.Translation.PrimaryURL, "DEPRECATED: URL for primary translation node for replication.")
 	flags.IntVar(&srv.Config.Translation.MapSize, "translation.map-size", srv.Config.Translation.MapSize, "Size in bytes of mmap to allocate for key translation.")
+	flags.StringVar(&srv.Config.Translation.Backend, "translation.backend", srv.Config.Translation.Backend, "Where key translation stores are kept: boltdb or etcd.")
+	flags.StringVar(&srv.Config.Translation.EtcdHosts, "translation.etcd-hosts", srv.Config.Translation.EtcdHosts, "Comma-separated etcd endpoints of the etcd translation backend. Defaults to etcd.etcd-hosts or the embedded etcd.")
+	flags.StringVar(&srv.Config.Translation.EtcdPrefix, "translation.etcd-prefix", srv.Config.Translation.EtcdPrefix, "Prefix of the etcd translation backend's keys.")
 
 	// Etcd
 	// Etcd.Name used Config.Name for its value.
@@ -65,7 +111,7 @@ func BuildServerFlags(cmd *cobra.Command, srv *server.Command) {
 	flags.StringVar(&srv.Config.LookupDBDSN, "lookup-db-dsn", "", "external (postgres) database DSN to use for ExternalLookup calls")
 
 	// AntiEntropy
-	flags.DurationVar((*time.Duration)(&srv.Config.AntiEntropy.Interval), "anti-entropy.interval", (time.Duration)(srv.Config.AntiEntropy.Interval), "Interval at which to run anti-entropy routine.")
+	flags.DurationVar((*time.Duration)(&srv.Config.AntiEntropy.Interval), "anti-entropy.interval", (time.Duration)(srv.Config.AntiEntropy.Interval), "Interval at which to run anti-entropy routine. Reads are only routed from degraded nodes to replicas when set.")
 
 	// Metric
 	flags.StringVar(&srv.Config.Metric.Service, "metric.service", srv.Config.Metric.Service, "Where to send stats: can be expvar (in-memory served at /debug/vars), prometheus, statsd or none.")
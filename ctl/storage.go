@@ -0,0 +1,124 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package ctl
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	pilosa "github.com/featurebasedb/featurebase/v3"
+	"github.com/featurebasedb/featurebase/v3/authn"
+	"github.com/featurebasedb/featurebase/v3/server"
+)
+
+// StorageCommand represents a command for reporting, and optimizing, how
+// each node of a cluster stores its data.
+type StorageCommand struct {
+	tlsConfig *tls.Config
+
+	// Host and port of a node of the cluster.
+	Host string `json:"host"`
+
+	// Index to report on, or every index if empty.
+	Index string `json:"index"`
+
+	// Optimize the storage of each node, after reporting it.
+	Optimize bool `json:"optimize"`
+
+	// Reusable client.
+	client *pilosa.InternalClient
+
+	// Standard input/output
+	*pilosa.CmdIO
+
+	TLS server.TLSConfig
+
+	AuthToken string
+}
+
+// NewStorageCommand returns a new instance of StorageCommand.
+func NewStorageCommand(stdin io.Reader, stdout, stderr io.Writer) *StorageCommand {
+	return &StorageCommand{
+		CmdIO: pilosa.NewCmdIO(stdin, stdout, stderr),
+	}
+}
+
+// Run reports the storage of each node, and optimizes it if requested,
+// reporting progress a shard at a time, and the storage afterwards.
+func (cmd *StorageCommand) Run(ctx context.Context) (err error) {
+	// Parse TLS configuration for node-specific clients.
+	tls := cmd.TLSConfiguration()
+	if cmd.tlsConfig, err = server.GetTLSConfig(&tls, cmd.Logger()); err != nil {
+		return fmt.Errorf("parsing tls config: %w", err)
+	}
+
+	client, err := commandClient(cmd)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+	cmd.client = client
+
+	if cmd.AuthToken != "" {
+		ctx = context.WithValue(ctx, authn.ContextValueAccessToken, "Bearer "+cmd.AuthToken)
+	}
+
+	nodes, err := cmd.client.Nodes(ctx)
+	if err != nil {
+		return fmt.Errorf("getting nodes: %w", err)
+	}
+
+	for _, node := range nodes {
+		uri := node.URI
+		report, err := cmd.client.StorageReport(ctx, &uri, cmd.Index)
+		if err != nil {
+			return fmt.Errorf("getting storage report of %s: %w", node.ID, err)
+		}
+		fmt.Fprintf(cmd.Stdout, "Node %s (%s):\n", node.ID, uri.HostPort())
+		if err := cmd.writeReport(report); err != nil {
+			return err
+		}
+		if !cmd.Optimize {
+			continue
+		}
+
+		fmt.Fprintf(cmd.Stdout, "Optimizing %s:\n", node.ID)
+		if err := cmd.client.OptimizeStorage(ctx, &uri, cmd.Index, func(p pilosa.StorageOptimizeProgress) error {
+			_, err := fmt.Fprintf(cmd.Stdout, "  [%d/%d] %s shard %d: %d containers rewritten, %d bytes (%d WAL) -> %d bytes (%d WAL)\n",
+				p.Done, p.Total, p.Index, p.Shard, p.Containers, p.BytesBefore, p.WALBytesBefore, p.Bytes, p.WALBytes)
+			return err
+		}); err != nil {
+			return fmt.Errorf("optimizing storage of %s: %w", node.ID, err)
+		}
+		if report, err = cmd.client.StorageReport(ctx, &uri, cmd.Index); err != nil {
+			return fmt.Errorf("getting storage report of %s: %w", node.ID, err)
+		}
+		fmt.Fprintf(cmd.Stdout, "Node %s (%s) after optimizing:\n", node.ID, uri.HostPort())
+		if err := cmd.writeReport(report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeReport writes a node's storage report as a table, with a row per
+// index and a total.
+func (cmd *StorageCommand) writeReport(report *pilosa.StorageReport) error {
+	tw := tabwriter.NewWriter(cmd.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "INDEX\tFILES\tBYTES\tWAL BYTES\tPAGES\tFREE PAGES\tARRAYS\tRUNS\tBITMAPS\t")
+	row := func(name string, c pilosa.StorageCounts) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t\n", name, c.Files, c.Bytes, c.WALBytes,
+			c.Pages, c.FreePages, c.ArrayContainers, c.RunContainers, c.BitmapContainers)
+	}
+	for _, is := range report.Indexes {
+		row(is.Index, is.StorageCounts)
+	}
+	row("(total)", report.StorageCounts)
+	return tw.Flush()
+}
+
+func (cmd *StorageCommand) TLSHost() string { return cmd.Host }
+
+func (cmd *StorageCommand) TLSConfiguration() server.TLSConfig { return cmd.TLS }
@@ -0,0 +1,43 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	pilosa "github.com/featurebasedb/featurebase/v3"
+	"github.com/featurebasedb/featurebase/v3/test"
+)
+
+func TestStorageCommand_Run(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1)
+	defer cluster.Close()
+	cmd := cluster.GetNode(0)
+
+	// Four columns in a row are stored as an array, but are smaller as a
+	// run.
+	idx := cluster.Idx()
+	cluster.CreateField(t, idx, pilosa.IndexOptions{}, "f")
+	if _, err := cmd.API.Query(context.Background(), &pilosa.QueryRequest{Index: idx, Query: "Set(1, f=1) Set(2, f=1) Set(3, f=1) Set(4, f=1)"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cm := NewStorageCommand(bytes.NewReader(nil), &stdout, &stderr)
+	cm.Host = cmd.API.Node().URI.HostPort()
+	cm.Index = idx
+	cm.Optimize = true
+	if err := cm.Run(context.Background()); err != nil {
+		t.Fatalf("Storage Run doesn't work: %s", err)
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"INDEX", idx, "(total)", "[1/1] " + idx + " shard 0: 1 containers rewritten", "after optimizing"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
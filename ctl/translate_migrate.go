@@ -0,0 +1,181 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package ctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	pilosa "github.com/featurebasedb/featurebase/v3"
+	"github.com/featurebasedb/featurebase/v3/boltdb"
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/etcdkeys"
+	"github.com/featurebasedb/featurebase/v3/server"
+	"github.com/pkg/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// translateStoreDir is the directory of an index's translation stores, as
+// the holder lays them out.
+const translateStoreDir = "_keys"
+
+// TranslateMigrateCommand copies the key translation stores of a node from
+// one backend to another. The node must not be running while its stores are
+// copied.
+type TranslateMigrateCommand struct {
+	// DataDir is the node's data directory, whose indexes and fields are
+	// those whose stores are copied.
+	DataDir string
+
+	// From and To are the backends copied from and to.
+	From string
+	To   string
+
+	// Only copy the stores of this index, if set.
+	Index string
+
+	// EtcdHosts and EtcdPrefix locate the stores of the etcd backend.
+	EtcdHosts  string
+	EtcdPrefix string
+
+	// PartitionN is the number of partitions of the index stores.
+	PartitionN int
+
+	cli *clientv3.Client
+
+	// Standard input/output
+	*pilosa.CmdIO
+}
+
+// NewTranslateMigrateCommand returns a new instance of
+// TranslateMigrateCommand.
+func NewTranslateMigrateCommand(stdin io.Reader, stdout, stderr io.Writer) *TranslateMigrateCommand {
+	return &TranslateMigrateCommand{
+		CmdIO:      pilosa.NewCmdIO(stdin, stdout, stderr),
+		From:       server.TranslationBackendBoltDB,
+		To:         server.TranslationBackendEtcd,
+		EtcdPrefix: etcdkeys.DefaultPrefix,
+		PartitionN: disco.DefaultPartitionN,
+	}
+}
+
+// Run copies the stores.
+func (cmd *TranslateMigrateCommand) Run(ctx context.Context) error {
+	if cmd.DataDir == "" {
+		return fmt.Errorf("data directory required")
+	} else if cmd.From == cmd.To {
+		return fmt.Errorf("stores must be copied between different backends")
+	}
+	for _, backend := range []string{cmd.From, cmd.To} {
+		switch backend {
+		case server.TranslationBackendBoltDB:
+		case server.TranslationBackendEtcd:
+			if cmd.EtcdHosts == "" {
+				return fmt.Errorf("etcd hosts required for the etcd backend")
+			}
+		default:
+			return fmt.Errorf("unknown translation backend %q", backend)
+		}
+	}
+	if cmd.EtcdHosts != "" {
+		cli, err := etcdkeys.NewClient(cmd.EtcdHosts)
+		if err != nil {
+			return err
+		}
+		defer cli.Close()
+		cmd.cli = cli
+	}
+
+	indexesPath := filepath.Join(cmd.DataDir, pilosa.IndexesDir)
+	indexes, err := os.ReadDir(indexesPath)
+	if err != nil {
+		return errors.Wrap(err, "reading indexes")
+	}
+	var n int
+	for _, index := range indexes {
+		if !index.IsDir() || (cmd.Index != "" && index.Name() != cmd.Index) {
+			continue
+		}
+		indexPath := filepath.Join(indexesPath, index.Name())
+		for partitionID := 0; partitionID < cmd.PartitionN; partitionID++ {
+			path := filepath.Join(indexPath, translateStoreDir, strconv.Itoa(partitionID))
+			copied, err := cmd.copyStore(path, index.Name(), "", partitionID)
+			if err != nil {
+				return errors.Wrapf(err, "copying keys of index %s partition %d", index.Name(), partitionID)
+			} else if copied {
+				n++
+			}
+		}
+
+		fields, err := os.ReadDir(filepath.Join(indexPath, pilosa.FieldsDir))
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "reading fields of index %s", index.Name())
+		}
+		for _, field := range fields {
+			if !field.IsDir() {
+				continue
+			}
+			path := filepath.Join(indexPath, pilosa.FieldsDir, field.Name(), "keys")
+			copied, err := cmd.copyStore(path, index.Name(), field.Name(), -1)
+			if err != nil {
+				return errors.Wrapf(err, "copying keys of field %s/%s", index.Name(), field.Name())
+			} else if copied {
+				n++
+			}
+		}
+	}
+	fmt.Fprintf(cmd.Stdout, "copied %d translation stores from %s to %s\n", n, cmd.From, cmd.To)
+	return nil
+}
+
+// copyStore copies a store, if there's anything in it. path is where the
+// store is kept by the boltdb backend.
+func (cmd *TranslateMigrateCommand) copyStore(path, index, field string, partitionID int) (bool, error) {
+	if cmd.From == server.TranslationBackendBoltDB {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return false, nil
+		}
+	}
+	src, err := cmd.openStore(cmd.From, path, index, field, partitionID)
+	if err != nil {
+		return false, errors.Wrap(err, "opening source")
+	}
+	defer src.Close()
+	if maxID, err := src.MaxID(); err != nil {
+		return false, errors.Wrap(err, "reading source")
+	} else if maxID == 0 {
+		return false, nil
+	}
+
+	dst, err := cmd.openStore(cmd.To, path, index, field, partitionID)
+	if err != nil {
+		return false, errors.Wrap(err, "opening destination")
+	}
+	defer dst.Close()
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := src.WriteTo(pw)
+		pw.CloseWithError(err)
+	}()
+	if _, err := dst.ReadFrom(pr); err != nil {
+		pr.CloseWithError(err)
+		return false, errors.Wrap(err, "writing destination")
+	}
+	return true, nil
+}
+
+func (cmd *TranslateMigrateCommand) openStore(backend, path, index, field string, partitionID int) (pilosa.TranslateStore, error) {
+	partitionN := cmd.PartitionN
+	if field != "" {
+		partitionN = -1
+	}
+	if backend == server.TranslationBackendEtcd {
+		return etcdkeys.NewTranslateStore(cmd.cli, cmd.EtcdPrefix, index, field, partitionID, partitionN), nil
+	}
+	return boltdb.OpenTranslateStore(path, index, field, partitionID, partitionN, true)
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/boltdb"
+	"github.com/featurebasedb/featurebase/v3/etcdkeys"
+	"github.com/featurebasedb/featurebase/v3/server"
+	"go.etcd.io/etcd/pkg/types"
+	"go.etcd.io/etcd/server/v3/embed"
+)
+
+func TestTranslateMigrateCommand_Run(t *testing.T) {
+	cfg := embed.NewConfig()
+	clientURL, peerURL := "unix://translate-migrate-client:0", "unix://translate-migrate-peer:0"
+	cfg.LPUrls = types.MustNewURLs([]string{peerURL})
+	cfg.APUrls = types.MustNewURLs([]string{peerURL})
+	cfg.LCUrls = types.MustNewURLs([]string{clientURL})
+	cfg.ACUrls = types.MustNewURLs([]string{clientURL})
+	cfg.InitialCluster = cfg.Name + "=" + peerURL
+	cfg.LogLevel = "error"
+	cfg.Dir = t.TempDir()
+	e, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		e.Close()
+		<-e.Server.StopNotify()
+		os.Remove("translate-migrate-client:0")
+		os.Remove("translate-migrate-peer:0")
+	}()
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(30 * time.Second):
+		t.Fatal("etcd took too long to start")
+	}
+
+	// Write keys of an index partition and a field to boltdb stores.
+	src := t.TempDir()
+	indexPath := filepath.Join(src, "indexes", "i")
+	colStore, err := boltdb.OpenTranslateStore(filepath.Join(indexPath, "_keys", "3"), "i", "", 3, 8, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols, err := colStore.CreateKeys("a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	colStore.Close()
+	rowStore, err := boltdb.OpenTranslateStore(filepath.Join(indexPath, "fields", "f", "keys"), "i", "f", -1, -1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := rowStore.CreateKeys("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowStore.Close()
+	if err := os.MkdirAll(filepath.Join(indexPath, "fields", "g"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(dataDir, from, to string) string {
+		var stdout, stderr bytes.Buffer
+		cmd := NewTranslateMigrateCommand(bytes.NewReader(nil), &stdout, &stderr)
+		cmd.DataDir, cmd.From, cmd.To = dataDir, from, to
+		cmd.EtcdHosts, cmd.PartitionN = clientURL, 8
+		if err := cmd.Run(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		return stdout.String()
+	}
+
+	if got, want := run(src, server.TranslationBackendBoltDB, server.TranslationBackendEtcd), "copied 2 translation stores from boltdb to etcd\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	// Copy the stores back, to the data directory of another node.
+	dst := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dst, "indexes", "i", "fields", "f"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := run(dst, server.TranslationBackendEtcd, server.TranslationBackendBoltDB), "copied 2 translation stores from etcd to boltdb\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	s, err := boltdb.OpenTranslateStore(filepath.Join(dst, "indexes", "i", "_keys", "3"), "i", "", 3, 8, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if found, err := s.FindKeys("a", "b"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(found, cols) {
+		t.Fatalf("found %v, want %v", found, cols)
+	}
+	s, err = boltdb.OpenTranslateStore(filepath.Join(dst, "indexes", "i", "fields", "f", "keys"), "i", "f", -1, -1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if found, err := s.FindKeys("x"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(found, rows) {
+		t.Fatalf("found %v, want %v", found, rows)
+	}
+
+	// The etcd stores are kept under the default prefix.
+	cli, err := etcdkeys.NewClient(clientURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	if ids, err := etcdkeys.NewTranslateStore(cli, etcdkeys.DefaultPrefix, "i", "f", -1, -1).FindKeys("x"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(ids, rows) {
+		t.Fatalf("etcd store found %v, want %v", ids, rows)
+	}
+}
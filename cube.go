@@ -0,0 +1,523 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// A cube is a GroupBy over a fixed list of fields, declared in an index's
+// options, whose groups are kept for each shard so that dashboards
+// repeating the query needn't read the fragments. GroupBy queries whose
+// Rows() children name the cube's fields in order, with no other
+// arguments, and which have the cube's aggregate and no filter, are
+// answered from the cube; limit, sort and having still apply as usual.
+//
+// A write to a shard discards the shard's groups before it commits, and
+// the groups are computed again in the background shortly after, so an
+// import only costs the cube the shards it touched. Groups are saved in
+// the index's directory, and are kept across restarts unless the shard
+// was written to meanwhile.
+
+// cubesDir is the directory, within an index's, holding the groups saved
+// for each cube, in a file per shard.
+const cubesDir = "_cubes"
+
+// cubeRefreshDelay is how long after a write to a shard its cubes are
+// computed again, so that the commits of an import don't each cause it.
+var cubeRefreshDelay = 500 * time.Millisecond
+
+// CubeOptions declares a cube: GroupBy(Rows(Fields[0]), Rows(Fields[1]),
+// ..., aggregate=Aggregate).
+type CubeOptions struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+
+	// Aggregate is a Sum(), Avg() or WeightedAvg() call to aggregate each
+	// group with, or empty to only count them.
+	Aggregate string `json:"aggregate,omitempty"`
+}
+
+// query returns the GroupBy call a cube stands for.
+func (o CubeOptions) query() string {
+	var b strings.Builder
+	b.WriteString("GroupBy(")
+	for i, field := range o.Fields {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "Rows(%s)", field)
+	}
+	if o.Aggregate != "" {
+		fmt.Fprintf(&b, ", aggregate=%s", o.Aggregate)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// validateCubes returns an error if the cubes aren't valid for idx.
+func validateCubes(idx *Index, cubes []CubeOptions) error {
+	names := make(map[string]struct{}, len(cubes))
+	for _, cube := range cubes {
+		if err := ValidateName(cube.Name); err != nil {
+			return errors.Wrap(err, "cube name")
+		} else if _, ok := names[cube.Name]; ok {
+			return errors.Errorf("cube '%s' is declared more than once", cube.Name)
+		}
+		names[cube.Name] = struct{}{}
+		if len(cube.Fields) == 0 {
+			return errors.Errorf("cube '%s' has no fields", cube.Name)
+		}
+		for _, name := range cube.Fields {
+			f := idx.Field(name)
+			if f == nil {
+				return errors.Wrapf(newNotFoundError(ErrFieldNotFound, name), "cube '%s'", cube.Name)
+			}
+			switch f.Type() {
+			case FieldTypeSet, FieldTypeMutex, FieldTypeBool, FieldTypeTime, FieldTypeInt, FieldTypeTimestamp:
+			default:
+				return errors.Errorf("cube '%s' can't group by %s field '%s'", cube.Name, f.Type(), name)
+			}
+		}
+		if cube.Aggregate != "" {
+			agg, err := cubeAggregate(cube.Aggregate)
+			if err != nil {
+				return errors.Wrapf(err, "cube '%s'", cube.Name)
+			}
+			switch agg.Name {
+			case "Sum", "Avg", "WeightedAvg":
+			default:
+				return errors.Errorf("cube '%s' can't aggregate with %s()", cube.Name, agg.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// cubeAggregate parses the aggregate of a cube, as an argument of GroupBy
+// so that it's parsed as it would be in a query.
+func cubeAggregate(s string) (*pql.Call, error) {
+	q, err := pql.ParseString(fmt.Sprintf("GroupBy(Rows(x), aggregate=%s)", s))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing aggregate")
+	} else if len(q.Calls) != 1 {
+		return nil, errors.New("aggregate must be a single call")
+	}
+	agg, _, err := q.Calls[0].CallArg("aggregate")
+	if err != nil || agg == nil {
+		return nil, errors.New("aggregate must be a single call")
+	}
+	return agg, nil
+}
+
+// cubeStore holds the cubes of an index and their groups for each shard.
+// Like shardStats, each shard has a generation, incremented by writes, and
+// groups computed during a write are only kept if it didn't change.
+type cubeStore struct {
+	mu   sync.Mutex
+	path string
+
+	defs    []CubeOptions
+	defined bool
+	// aggs holds the normalized aggregate of each cube, to match against
+	// queries.
+	aggs map[string]string
+
+	gens    map[uint64]uint64
+	groups  map[cubeKey]*cubeGroups
+	pending map[uint64]bool
+}
+
+type cubeKey struct {
+	cube  string
+	shard uint64
+}
+
+type cubeGroups struct {
+	gen    uint64
+	groups []GroupCount
+}
+
+func newCubeStore(path string) *cubeStore {
+	return &cubeStore{
+		path:    path,
+		aggs:    make(map[string]string),
+		gens:    make(map[uint64]uint64),
+		groups:  make(map[cubeKey]*cubeGroups),
+		pending: make(map[uint64]bool),
+	}
+}
+
+// define replaces the cubes, discarding the groups of any which were
+// removed or changed.
+func (s *cubeStore) define(defs []CubeOptions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keep := make(map[string]bool, len(defs))
+	aggs := make(map[string]string, len(defs))
+	for _, def := range defs {
+		// Groups saved before the index was opened are kept.
+		keep[def.Name] = !s.defined
+		for _, old := range s.defs {
+			if old.Name == def.Name && old.query() == def.query() {
+				keep[def.Name] = true
+			}
+		}
+		if def.Aggregate != "" {
+			if agg, err := cubeAggregate(def.Aggregate); err == nil {
+				aggs[def.Name] = agg.String()
+			}
+		}
+	}
+	for key := range s.groups {
+		if !keep[key.cube] {
+			delete(s.groups, key)
+		}
+	}
+	if entries, err := os.ReadDir(s.path); err == nil {
+		for _, entry := range entries {
+			if !keep[entry.Name()] {
+				os.RemoveAll(filepath.Join(s.path, entry.Name()))
+			}
+		}
+	}
+	s.defs = append([]CubeOptions(nil), defs...)
+	s.aggs = aggs
+	s.defined = true
+}
+
+// definitions returns the cubes.
+func (s *cubeStore) definitions() []CubeOptions {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.defs) == 0 {
+		return nil
+	}
+	return append([]CubeOptions(nil), s.defs...)
+}
+
+// beginWrite discards the groups of shard, saved and in memory, before a
+// write to it commits.
+func (s *cubeStore) beginWrite(shard uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gens[shard]++
+	for _, def := range s.defs {
+		delete(s.groups, cubeKey{cube: def.Name, shard: shard})
+		os.Remove(s.shardPath(def.Name, shard))
+	}
+}
+
+// endWrite schedules the groups of shard to be computed again, after a
+// write to it has committed.
+func (s *cubeStore) endWrite(idx *Index, shard uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Groups computed from before the commit mustn't be kept.
+	s.gens[shard]++
+	if len(s.defs) == 0 || s.pending[shard] {
+		return
+	}
+	s.pending[shard] = true
+	go s.refresh(idx, idx.closing, shard)
+}
+
+// refresh computes the groups of each cube for shard.
+func (s *cubeStore) refresh(idx *Index, closing <-chan struct{}, shard uint64) {
+	select {
+	case <-time.After(cubeRefreshDelay):
+	case <-closing:
+		return
+	}
+	s.mu.Lock()
+	delete(s.pending, shard)
+	defs := append([]CubeOptions(nil), s.defs...)
+	s.mu.Unlock()
+
+	e := idx.holder.executor
+	if e == nil {
+		return
+	}
+	for _, def := range defs {
+		if _, err := e.cubeShard(context.Background(), idx, def, shard); err != nil {
+			idx.holder.Logger.Infof("computing cube %s/%s for shard %d: %v", idx.name, def.Name, shard, err)
+		}
+	}
+}
+
+// deleteField discards the groups of the cubes grouping by a field which
+// has been deleted, since a field created in its place would have
+// different rows.
+func (s *cubeStore) deleteField(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, def := range s.defs {
+		for _, field := range def.Fields {
+			if field == name {
+				for key := range s.groups {
+					if key.cube == def.Name {
+						delete(s.groups, key)
+					}
+				}
+				os.RemoveAll(filepath.Join(s.path, def.Name))
+				break
+			}
+		}
+	}
+}
+
+// match returns the cube answering the GroupBy call c, if any.
+func (s *cubeStore) match(c *pql.Call, aggregate *pql.Call) (CubeOptions, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, def := range s.defs {
+		if len(def.Fields) != len(c.Children) {
+			continue
+		}
+		if aggregate == nil && def.Aggregate != "" || aggregate != nil && aggregate.String() != s.aggs[def.Name] {
+			continue
+		}
+		ok := true
+		for i, child := range c.Children {
+			for arg := range child.Args {
+				if arg != "_field" && arg != "field" {
+					ok = false
+				}
+			}
+			if child.Args["_field"] != def.Fields[i] {
+				ok = false
+			}
+		}
+		if ok {
+			return def, true
+		}
+	}
+	return CubeOptions{}, false
+}
+
+func (s *cubeStore) shardPath(cube string, shard uint64) string {
+	return filepath.Join(s.path, cube, strconv.FormatUint(shard, 10))
+}
+
+// get returns the groups of a cube for shard, if they're known, and the
+// shard's generation.
+func (s *cubeStore) get(cube string, shard uint64) ([]GroupCount, uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := cubeKey{cube: cube, shard: shard}
+	gen := s.gens[shard]
+	if g := s.groups[key]; g != nil && g.gen == gen {
+		return g.groups, gen, true
+	}
+
+	// Saved groups are removed by writes, so any found are current.
+	buf, err := os.ReadFile(s.shardPath(cube, shard))
+	if err != nil {
+		return nil, gen, false
+	}
+	var saved []savedGroup
+	if err := gob.NewDecoder(bytes.NewReader(buf)).Decode(&saved); err != nil {
+		return nil, gen, false
+	}
+	groups := make([]GroupCount, len(saved))
+	for i := range saved {
+		if groups[i], err = saved[i].groupCount(); err != nil {
+			return nil, gen, false
+		}
+	}
+	s.groups[key] = &cubeGroups{gen: gen, groups: groups}
+	return groups, gen, true
+}
+
+// put keeps the groups of a cube for shard computed as of generation gen,
+// unless the shard has been written to since, and saves them.
+func (s *cubeStore) put(cube string, shard, gen uint64, groups []GroupCount) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.gens[shard] != gen {
+		return nil
+	}
+	found := false
+	for _, def := range s.defs {
+		found = found || def.Name == cube
+	}
+	if !found {
+		return nil
+	}
+	s.groups[cubeKey{cube: cube, shard: shard}] = &cubeGroups{gen: gen, groups: groups}
+
+	saved := make([]savedGroup, len(groups))
+	for i := range groups {
+		saved[i] = newSavedGroup(groups[i])
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(saved); err != nil {
+		return errors.Wrap(err, "encoding groups")
+	}
+	path := s.shardPath(cube, shard)
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return errors.Wrap(err, "creating directory")
+	}
+	if err := os.WriteFile(path+".tmp", buf.Bytes(), 0600); err != nil {
+		return errors.Wrap(err, "writing groups")
+	}
+	return errors.Wrap(os.Rename(path+".tmp", path), "renaming groups")
+}
+
+// savedGroup is the form a GroupCount is saved in.
+type savedGroup struct {
+	Fields     []string
+	RowIDs     []uint64
+	Values     []int64
+	HasValues  []bool
+	Periods    []string
+	Count      uint64
+	Agg        int64
+	DecimalAgg string
+	AggWeight  int64
+}
+
+func newSavedGroup(gc GroupCount) savedGroup {
+	sg := savedGroup{Count: gc.Count, Agg: gc.Agg, AggWeight: gc.AggWeight}
+	for _, fr := range gc.Group {
+		sg.Fields = append(sg.Fields, fr.Field)
+		sg.RowIDs = append(sg.RowIDs, fr.RowID)
+		if fr.Value != nil {
+			sg.Values = append(sg.Values, *fr.Value)
+		} else {
+			sg.Values = append(sg.Values, 0)
+		}
+		sg.HasValues = append(sg.HasValues, fr.Value != nil)
+		sg.Periods = append(sg.Periods, fr.Period)
+	}
+	if gc.DecimalAgg != nil {
+		sg.DecimalAgg = gc.DecimalAgg.String()
+	}
+	return sg
+}
+
+func (sg savedGroup) groupCount() (GroupCount, error) {
+	gc := GroupCount{Count: sg.Count, Agg: sg.Agg, AggWeight: sg.AggWeight}
+	if sg.DecimalAgg != "" {
+		d, err := pql.ParseDecimal(sg.DecimalAgg)
+		if err != nil {
+			return gc, err
+		}
+		gc.DecimalAgg = &d
+	}
+	gc.Group = make([]FieldRow, len(sg.Fields))
+	for i := range sg.Fields {
+		gc.Group[i] = FieldRow{Field: sg.Fields[i], RowID: sg.RowIDs[i], Period: sg.Periods[i]}
+		if sg.HasValues[i] {
+			value := sg.Values[i]
+			gc.Group[i].Value = &value
+		}
+	}
+	return gc, nil
+}
+
+// copyGroupCount returns a copy of gc sharing no pointers with it, so that
+// merging results can't change a cube's groups.
+func copyGroupCount(gc GroupCount) GroupCount {
+	other := gc
+	other.Group = make([]FieldRow, len(gc.Group))
+	copy(other.Group, gc.Group)
+	for i := range other.Group {
+		if v := other.Group[i].Value; v != nil {
+			value := *v
+			other.Group[i].Value = &value
+		}
+	}
+	if gc.DecimalAgg != nil {
+		d := *gc.DecimalAgg
+		other.DecimalAgg = &d
+	}
+	return other
+}
+
+// cubeShard returns the groups of a cube for shard, computing them if
+// they aren't known.
+func (e *executor) cubeShard(ctx context.Context, idx *Index, cube CubeOptions, shard uint64) ([]GroupCount, error) {
+	groups, gen, ok := idx.cubes.get(cube.Name, shard)
+	if ok {
+		return groups, nil
+	}
+
+	q, err := pql.ParseString(cube.query())
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing cube")
+	}
+	c := q.Calls[0]
+	bases := make(map[int]int64)
+	for i, name := range cube.Fields {
+		f := idx.Field(name)
+		if f == nil {
+			return nil, newNotFoundError(ErrFieldNotFound, name)
+		}
+		switch f.Type() {
+		case FieldTypeInt, FieldTypeTimestamp:
+			bases[i] = f.bsiGroup(f.name).Base
+		}
+	}
+
+	// Use a Qcx of our own, begun after reading the generation, so that a
+	// write committing meanwhile is noticed.
+	qcx := idx.holder.txf.NewQcx()
+	defer qcx.Abort()
+	groups, err = e.groupByShard(ctx, qcx, idx.name, c, nil, shard, make([]RowIDs, len(c.Children)), bases, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	if err := idx.cubes.put(cube.Name, shard, gen, groups); err != nil {
+		return nil, errors.Wrap(err, "saving cube")
+	}
+	return groups, nil
+}
+
+// groupByCubeShard answers the GroupBy call c for shard from a cube, in
+// the way executeGroupByShard would.
+func (e *executor) groupByCubeShard(ctx context.Context, idx *Index, cube CubeOptions, c *pql.Call, shard uint64, limit int) ([]GroupCount, error) {
+	groups, err := e.cubeShard(ctx, idx, cube, shard)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting cube %s for shard %d", cube.Name, shard)
+	}
+
+	var subj string
+	threshold, hasThreshold, err := c.UintArg("shardthreshold")
+	if err != nil {
+		return nil, err
+	} else if hasThreshold {
+		having, _, err := c.CallArg("having")
+		if err != nil || having == nil {
+			return nil, errors.New("shardthreshold requires a having-condition")
+		}
+		for k := range having.Args {
+			subj = k
+		}
+	}
+
+	results := make([]GroupCount, 0, len(groups))
+	for _, gc := range groups {
+		if len(results) >= limit {
+			break
+		}
+		if hasThreshold && !gc.reachesThreshold(subj, threshold) {
+			continue
+		}
+		results = append(results, copyGroupCount(gc))
+	}
+	return results, nil
+}
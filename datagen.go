@@ -0,0 +1,596 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// The data generator synthesizes records into an existing index from a
+// declarative description of each field's values, and the load generator
+// runs a weighted mix of queries against an index for a while, measuring
+// their latency. Together they allow benchmarking a cluster's sizing
+// without real data.
+//
+// Records are generated in batches, which are imported like any other
+// data, so generated records can be queried as soon as their batch is
+// imported. Generation is deterministic for a given seed.
+
+// Distributions of generated values.
+const (
+	DistributionUniform    = "uniform"
+	DistributionZipf       = "zipf"
+	DistributionSequential = "sequential"
+)
+
+// Patterns of generated times.
+const (
+	TimePatternUniform = "uniform"
+	TimePatternDaily   = "daily"
+	TimePatternRecent  = "recent"
+)
+
+// Defaults and limits for data and load generation.
+const (
+	DefaultGenerateCardinality = 100
+	DefaultGenerateZipfSkew    = 1.5
+	DefaultLoadConcurrency     = 4
+	DefaultLoadDuration        = 10 * time.Second
+
+	generateBatchSize  = 1 << 16
+	maxGenerateRecords = 1 << 32
+)
+
+// GenerateRequest describes records to generate into an index.
+type GenerateRequest struct {
+	// Records is the number of records to generate.
+	Records uint64 `json:"records"`
+
+	// Start is the ID, or in a keyed index the number used as the key, of
+	// the first record.
+	Start uint64 `json:"start,omitempty"`
+
+	// Seed seeds the generator. The same request with the same seed
+	// generates the same records.
+	Seed int64 `json:"seed,omitempty"`
+
+	Fields []GenerateField `json:"fields"`
+}
+
+// GenerateField describes the values to generate for a field.
+type GenerateField struct {
+	Name string `json:"name"`
+
+	// Distribution is the distribution of values over the field's range:
+	// "uniform", the default, "zipf", where low values are the most common,
+	// or "sequential", where records take each value in turn.
+	Distribution string `json:"distribution,omitempty"`
+
+	// Skew is the exponent of a zipf distribution, greater than 1.
+	Skew float64 `json:"skew,omitempty"`
+
+	// Cardinality is the number of distinct rows of set, mutex and time
+	// fields.
+	Cardinality uint64 `json:"cardinality,omitempty"`
+
+	// Min and Max bound the values of int and decimal fields.
+	Min int64 `json:"min,omitempty"`
+	Max int64 `json:"max,omitempty"`
+
+	// Density is the fraction of records with a value. Zero means all of
+	// them.
+	Density float64 `json:"density,omitempty"`
+
+	// PerRecord is the number of values each record has in a set or time
+	// field, which may repeat. Zero means one.
+	PerRecord int `json:"perRecord,omitempty"`
+
+	// Time describes the times of time and timestamp fields.
+	Time *GenerateTime `json:"time,omitempty"`
+}
+
+// GenerateTime describes the times generated for a field.
+type GenerateTime struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// Pattern is how times are spread between start and end: "uniform",
+	// the default, "daily", following a day's activity peaking in the
+	// afternoon, or "recent", growing more frequent towards the end.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// GenerateResult describes the records generated.
+type GenerateResult struct {
+	Records uint64 `json:"records"`
+
+	// Values are the numbers of values generated for each field.
+	Values map[string]uint64 `json:"values"`
+}
+
+// GenerateData generates records into an index as the request describes.
+func (api *API) GenerateData(ctx context.Context, indexName string, req *GenerateRequest) (*GenerateResult, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.GenerateData")
+	defer span.Finish()
+
+	if err := api.validate(apiGenerateData); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	idx, err := api.Index(ctx, indexName)
+	if err != nil {
+		return nil, err
+	}
+	if req.Records == 0 || req.Records > maxGenerateRecords {
+		return nil, NewBadRequestError(errors.Errorf("records must be between 1 and %d", uint64(maxGenerateRecords)))
+	}
+	rng := rand.New(rand.NewSource(req.Seed))
+	gens := make([]*fieldGenerator, len(req.Fields))
+	for i := range req.Fields {
+		f := idx.Field(req.Fields[i].Name)
+		if f == nil {
+			return nil, newNotFoundError(ErrFieldNotFound, req.Fields[i].Name)
+		}
+		if gens[i], err = newFieldGenerator(f, &req.Fields[i], rng); err != nil {
+			return nil, NewBadRequestError(errors.Wrapf(err, "field %q", f.Name()))
+		}
+	}
+
+	result := &GenerateResult{Values: make(map[string]uint64, len(gens))}
+	for start := uint64(0); start < req.Records; start += generateBatchSize {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		n := req.Records - start
+		if n > generateBatchSize {
+			n = generateBatchSize
+		}
+		records := make([]uint64, n)
+		for i := range records {
+			records[i] = req.Start + start + uint64(i)
+		}
+		for _, g := range gens {
+			imported, err := api.generateBatch(ctx, idx, g, records)
+			if err != nil {
+				return result, errors.Wrapf(err, "generating field %q", g.field.Name())
+			}
+			result.Values[g.field.Name()] += imported
+		}
+		result.Records += n
+	}
+	return result, nil
+}
+
+// generateBatch generates and imports a field's values for records,
+// returning the number of values imported.
+func (api *API) generateBatch(ctx context.Context, idx *Index, g *fieldGenerator, records []uint64) (uint64, error) {
+	var cols []uint64
+	var rows []uint64
+	var timestamps []int64
+	var ints []int64
+	var floats []float64
+	var strs []string
+	for _, rec := range records {
+		if g.density < 1 && g.rng.Float64() >= g.density {
+			continue
+		}
+		switch g.field.Type() {
+		case FieldTypeInt:
+			cols, ints = append(cols, rec), append(ints, g.spec.Min+int64(g.value(rec)))
+			if g.field.Keys() {
+				strs = append(strs, g.key(uint64(ints[len(ints)-1])))
+			}
+		case FieldTypeDecimal:
+			v := float64(g.spec.Min) + float64(g.value(rec)) + g.rng.Float64()
+			cols, floats = append(cols, rec), append(floats, math.Min(v, float64(g.spec.Max)))
+		case FieldTypeTimestamp:
+			// Timestamps are imported as values, as they're forwarded to
+			// the nodes owning their shards.
+			cols, ints = append(cols, rec), append(ints, TimestampToVal(g.field.options.TimeUnit, g.time()))
+		case FieldTypeBool:
+			cols, rows = append(cols, rec), append(rows, g.value(rec))
+		default:
+			for i := 0; i < g.perRecord; i++ {
+				cols, rows = append(cols, rec), append(rows, g.value(rec))
+				if g.field.Type() == FieldTypeTime {
+					timestamps = append(timestamps, g.time().UnixNano())
+				}
+			}
+		}
+	}
+	if len(cols) == 0 {
+		return 0, nil
+	}
+
+	var colKeys []string
+	if idx.Keys() {
+		colKeys = make([]string, len(cols))
+		for i, col := range cols {
+			colKeys[i] = strconv.FormatUint(col, 10)
+		}
+		cols = nil
+	}
+	qcx := api.Txf().NewQcx()
+	defer qcx.Abort()
+
+	if rows == nil {
+		req := &ImportValueRequest{
+			Index:        idx.Name(),
+			Field:        g.field.Name(),
+			Shard:        ^uint64(0),
+			ColumnIDs:    cols,
+			ColumnKeys:   colKeys,
+			Values:       ints,
+			StringValues: strs,
+			FloatValues:  floats,
+		}
+		if strs != nil {
+			// The int field refers to a keyed index.
+			req.Values = nil
+		}
+		if err := api.ImportValue(ctx, qcx, req); err != nil {
+			return 0, err
+		}
+		return uint64(len(ints) + len(floats)), qcx.Finish()
+	}
+	n := uint64(len(rows))
+	req := &ImportRequest{
+		Index:      idx.Name(),
+		Field:      g.field.Name(),
+		Shard:      ^uint64(0),
+		ColumnIDs:  cols,
+		ColumnKeys: colKeys,
+		Timestamps: timestamps,
+	}
+	if g.field.Keys() {
+		req.RowKeys = make([]string, len(rows))
+		for i, row := range rows {
+			req.RowKeys[i] = g.key(row)
+		}
+	} else {
+		req.RowIDs = rows
+	}
+	if err := api.Import(ctx, qcx, req); err != nil {
+		return 0, err
+	}
+	return n, qcx.Finish()
+}
+
+// fieldGenerator generates the values of a field.
+type fieldGenerator struct {
+	field     *Field
+	spec      *GenerateField
+	rng       *rand.Rand
+	zipf      *rand.Zipf
+	n         uint64 // the number of distinct values
+	density   float64
+	perRecord int
+	timeSpan  time.Duration
+}
+
+func newFieldGenerator(f *Field, spec *GenerateField, rng *rand.Rand) (*fieldGenerator, error) {
+	g := &fieldGenerator{
+		field:     f,
+		spec:      spec,
+		rng:       rng,
+		n:         spec.Cardinality,
+		density:   spec.Density,
+		perRecord: spec.PerRecord,
+	}
+	if g.density < 0 || g.density > 1 {
+		return nil, errors.New("density must be between 0 and 1")
+	} else if g.density == 0 {
+		g.density = 1
+	}
+	if g.perRecord < 0 {
+		return nil, errors.New("perRecord must not be negative")
+	} else if g.perRecord == 0 || f.Type() == FieldTypeMutex {
+		g.perRecord = 1
+	}
+
+	switch f.Type() {
+	case FieldTypeSet, FieldTypeMutex, FieldTypeTime:
+		if g.n == 0 {
+			g.n = DefaultGenerateCardinality
+		}
+	case FieldTypeBool:
+		g.n = 2
+	case FieldTypeInt, FieldTypeDecimal:
+		if spec.Min == 0 && spec.Max == 0 {
+			spec.Min, spec.Max = f.options.Min.ToInt64(0), f.options.Max.ToInt64(0)
+			if f.Type() == FieldTypeDecimal {
+				spec.Min, spec.Max = int64(f.options.Min.Float64()), int64(f.options.Max.Float64())
+			}
+		}
+		if spec.Max < spec.Min {
+			return nil, errors.Errorf("max %d is less than min %d", spec.Max, spec.Min)
+		}
+		// Subtracting as unsigned values can't overflow.
+		g.n = uint64(spec.Max) - uint64(spec.Min)
+		if f.Type() == FieldTypeInt && g.n < math.MaxUint64 {
+			g.n++
+		}
+	case FieldTypeTimestamp:
+	default:
+		return nil, errors.Errorf("can't generate values for fields of type %q", f.Type())
+	}
+	if g.n == 0 {
+		g.n = 1
+	}
+
+	switch spec.Distribution {
+	case "", DistributionUniform, DistributionSequential:
+	case DistributionZipf:
+		skew := spec.Skew
+		if skew == 0 {
+			skew = DefaultGenerateZipfSkew
+		} else if skew <= 1 {
+			return nil, errors.New("zipf skew must be greater than 1")
+		}
+		g.zipf = rand.NewZipf(rng, skew, 1, g.n-1)
+	default:
+		return nil, errors.Errorf("unknown distribution %q", spec.Distribution)
+	}
+
+	if f.Type() == FieldTypeTime || f.Type() == FieldTypeTimestamp {
+		if spec.Time == nil {
+			return nil, errors.New("time fields require a time range")
+		} else if !spec.Time.End.After(spec.Time.Start) {
+			return nil, errors.New("time range must end after it starts")
+		}
+		switch spec.Time.Pattern {
+		case "", TimePatternUniform, TimePatternDaily, TimePatternRecent:
+		default:
+			return nil, errors.Errorf("unknown time pattern %q", spec.Time.Pattern)
+		}
+		g.timeSpan = spec.Time.End.Sub(spec.Time.Start)
+	}
+	return g, nil
+}
+
+// value returns a value, in [0, n), for a record.
+func (g *fieldGenerator) value(rec uint64) uint64 {
+	switch {
+	case g.zipf != nil:
+		return g.zipf.Uint64()
+	case g.spec.Distribution == DistributionSequential:
+		return rec % g.n
+	case g.n > math.MaxInt64:
+		return g.rng.Uint64() % g.n
+	default:
+		return uint64(g.rng.Int63n(int64(g.n)))
+	}
+}
+
+// key returns the key of a value of a keyed field. Values referring to a
+// foreign index use the keys of generated records, so that generated
+// indexes can be joined.
+func (g *fieldGenerator) key(v uint64) string {
+	if g.field.ForeignIndex() != "" {
+		return strconv.FormatUint(v, 10)
+	}
+	return g.field.Name() + "-" + strconv.FormatUint(v, 10)
+}
+
+// dailyActivity is the relative activity in each hour of a day.
+var dailyActivity = [24]float64{1, 1, 1, 1, 1, 2, 3, 5, 7, 8, 9, 10, 10, 10, 10, 10, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// time returns a time in the field's range, following its pattern.
+func (g *fieldGenerator) time() time.Time {
+	t := g.spec.Time
+	switch t.Pattern {
+	case TimePatternRecent:
+		// The square root of a uniform value has a linearly increasing
+		// density.
+		return t.Start.Add(time.Duration(math.Sqrt(g.rng.Float64()) * float64(g.timeSpan))).UTC()
+	case TimePatternDaily:
+		// Pick a day, then an hour weighted by its activity.
+		day := t.Start.Truncate(24 * time.Hour)
+		days := int64(t.End.Sub(day)/(24*time.Hour)) + 1
+		var total float64
+		for _, a := range dailyActivity {
+			total += a
+		}
+		for tries := 0; tries < 10; tries++ {
+			x := g.rng.Float64() * total
+			hour := 0
+			for ; hour < 23 && x >= dailyActivity[hour]; hour++ {
+				x -= dailyActivity[hour]
+			}
+			tm := day.Add(time.Duration(g.rng.Int63n(days))*24*time.Hour + time.Duration(hour)*time.Hour + time.Duration(g.rng.Int63n(int64(time.Hour))))
+			if !tm.Before(t.Start) && tm.Before(t.End) {
+				return tm.UTC()
+			}
+		}
+	}
+	return t.Start.Add(time.Duration(g.rng.Int63n(int64(g.timeSpan)))).UTC()
+}
+
+// LoadRequest describes query load to generate against an index.
+type LoadRequest struct {
+	// Queries are the queries to run, chosen at random by weight. A query
+	// may contain {rand:N}, which is replaced with a random number in
+	// [0, N) each time it runs.
+	Queries []LoadQuery `json:"queries"`
+
+	// Duration is how long to generate load for, such as "30s". Ten
+	// seconds if empty.
+	Duration string `json:"duration,omitempty"`
+
+	// Count, if set, stops the load after this many queries.
+	Count int `json:"count,omitempty"`
+
+	// Concurrency is the number of queries in flight.
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// Rate, if set, limits the queries run per second.
+	Rate float64 `json:"rate,omitempty"`
+
+	Seed int64 `json:"seed,omitempty"`
+}
+
+// LoadQuery is a query in a load, with its relative weight. A weight of
+// zero counts as one.
+type LoadQuery struct {
+	Query  string `json:"query"`
+	Weight int    `json:"weight,omitempty"`
+}
+
+// LoadResult describes the queries run by GenerateLoad and their latency.
+type LoadResult struct {
+	Queries int           `json:"queries"`
+	Errors  int           `json:"errors"`
+	Elapsed time.Duration `json:"elapsed"`
+	QPS     float64       `json:"qps"`
+
+	// Latencies of the queries, as durations.
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+
+	// FirstError is the first error a query returned, if any.
+	FirstError string `json:"firstError,omitempty"`
+}
+
+var loadRandPattern = regexp.MustCompile(`\{rand:(\d+)\}`)
+
+// GenerateLoad runs queries against an index as the request describes,
+// and reports their latency.
+func (api *API) GenerateLoad(ctx context.Context, indexName string, req *LoadRequest) (*LoadResult, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.GenerateLoad")
+	defer span.Finish()
+
+	if err := api.validate(apiGenerateLoad); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	if _, err := api.Index(ctx, indexName); err != nil {
+		return nil, err
+	}
+	if len(req.Queries) == 0 {
+		return nil, NewBadRequestError(errors.New("load requires queries"))
+	}
+	dur := DefaultLoadDuration
+	if req.Duration != "" {
+		var err error
+		if dur, err = time.ParseDuration(req.Duration); err != nil || dur <= 0 {
+			return nil, NewBadRequestError(errors.Errorf("invalid duration %q", req.Duration))
+		}
+	}
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultLoadConcurrency
+	}
+	if req.Rate < 0 || req.Count < 0 {
+		return nil, NewBadRequestError(errors.New("rate and count must not be negative"))
+	}
+	var total int
+	weights := make([]int, len(req.Queries))
+	for i, q := range req.Queries {
+		if q.Weight < 0 {
+			return nil, NewBadRequestError(errors.New("query weights must not be negative"))
+		} else if weights[i] = q.Weight; weights[i] == 0 {
+			weights[i] = 1
+		}
+		total += weights[i]
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dur)
+	defer cancel()
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		rng       = rand.New(rand.NewSource(req.Seed))
+		latencies []time.Duration
+		result    = &LoadResult{}
+		started   int
+		start     = time.Now()
+	)
+	// next returns the next query to run, or false when the load is over.
+	next := func() (string, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if ctx.Err() != nil || (req.Count > 0 && started >= req.Count) {
+			return "", false
+		}
+		if req.Rate > 0 {
+			at := start.Add(time.Duration(float64(started) / req.Rate * float64(time.Second)))
+			if wait := time.Until(at); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					return "", false
+				}
+			}
+		}
+		started++
+		x := rng.Intn(total)
+		i := 0
+		for ; x >= weights[i]; i++ {
+			x -= weights[i]
+		}
+		return loadRandPattern.ReplaceAllStringFunc(req.Queries[i].Query, func(s string) string {
+			n, _ := strconv.ParseInt(loadRandPattern.FindStringSubmatch(s)[1], 10, 64)
+			if n <= 0 {
+				return "0"
+			}
+			return strconv.FormatInt(rng.Int63n(n), 10)
+		}), true
+	}
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				query, ok := next()
+				if !ok {
+					return
+				}
+				t := time.Now()
+				_, err := api.Query(ctx, &QueryRequest{Index: indexName, Query: query})
+				lat := time.Since(t)
+				mu.Lock()
+				if err != nil && ctx.Err() != nil {
+					// The load ended during the query.
+					mu.Unlock()
+					return
+				}
+				result.Queries++
+				latencies = append(latencies, lat)
+				if err != nil {
+					if result.Errors++; result.FirstError == "" {
+						result.FirstError = fmt.Sprintf("%s: %v", query, err)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	result.Elapsed = time.Since(start)
+	result.QPS = float64(result.Queries) / result.Elapsed.Seconds()
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		var sum time.Duration
+		for _, l := range latencies {
+			sum += l
+		}
+		result.Mean = sum / time.Duration(len(latencies))
+		pct := func(p int) time.Duration { return latencies[(len(latencies)-1)*p/100] }
+		result.P50, result.P90, result.P99 = pct(50), pct(90), pct(99)
+		result.Max = latencies[len(latencies)-1]
+	}
+	return result, nil
+}
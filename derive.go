@@ -0,0 +1,313 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A derived field holds a value computed from another field of the same
+// column, and is kept up to date by imports: whenever Import or ImportValue
+// writes to a field, the fields derived from it are recomputed for the
+// columns written. Derived fields are int fields, holding the computed
+// value, or unkeyed mutex fields, holding it as a row ID. A column whose
+// source has no value has none in the derived field either.
+//
+// The expression is one of:
+//
+//   bucket(field, width[, lower])  (value - lower) / width, of an int field,
+//                                  for values of at least lower
+//   year(field)                    the year, month, day or hour of a
+//   month(field)                   timestamp field's value, in UTC
+//   day(field)
+//   hour(field)
+//   hash(_id, n)                   a hash of the column's key, or its ID in
+//                                  an unkeyed index, modulo n
+
+// deriveFuncs maps each derived field function to the number of its
+// integer arguments, after the source: required, and optional.
+var deriveFuncs = map[string][2]int{
+	"bucket": {1, 1},
+	"year":   {0, 0},
+	"month":  {0, 0},
+	"day":    {0, 0},
+	"hour":   {0, 0},
+	"hash":   {1, 0},
+}
+
+var deriveExprRegexp = regexp.MustCompile(`^\s*([a-z]+)\s*\((.*)\)\s*$`)
+
+// deriveExpr is a parsed derived field expression.
+type deriveExpr struct {
+	fn     string
+	source string
+	args   []int64
+}
+
+// OptFieldDerive is a functional option on FieldOptions used to derive the
+// field from another, with expr.
+func OptFieldDerive(expr string) FieldOption {
+	return func(fo *FieldOptions) error {
+		if _, err := parseDeriveExpr(expr); err != nil {
+			return err
+		}
+		fo.Derive = expr
+		return nil
+	}
+}
+
+func parseDeriveExpr(s string) (*deriveExpr, error) {
+	m := deriveExprRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return nil, errors.Errorf("invalid derived field expression %q", s)
+	}
+	nargs, ok := deriveFuncs[m[1]]
+	if !ok {
+		return nil, errors.Errorf("unknown derived field function %q", m[1])
+	}
+	parts := strings.Split(m[2], ",")
+	if n := len(parts) - 1; n < nargs[0] || n > nargs[0]+nargs[1] {
+		return nil, errors.Errorf("%s takes a field and %d arguments", m[1], nargs[0])
+	}
+	d := &deriveExpr{fn: m[1], source: strings.TrimSpace(parts[0])}
+	if d.source == "" {
+		return nil, errors.Errorf("%s: missing field", m[1])
+	}
+	for _, part := range parts[1:] {
+		v, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("%s: invalid argument %q", m[1], strings.TrimSpace(part))
+		}
+		d.args = append(d.args, v)
+	}
+	switch d.fn {
+	case "bucket":
+		if d.args[0] <= 0 {
+			return nil, errors.New("bucket: width must be positive")
+		}
+		if len(d.args) == 1 {
+			d.args = append(d.args, 0)
+		}
+	case "hash":
+		if d.source != "_id" {
+			return nil, errors.New("hash: only _id can be hashed")
+		} else if d.args[0] <= 0 {
+			return nil, errors.New("hash: n must be positive")
+		}
+	}
+	return d, nil
+}
+
+func (d *deriveExpr) String() string {
+	args := []string{d.source}
+	for _, a := range d.args {
+		args = append(args, strconv.FormatInt(a, 10))
+	}
+	return fmt.Sprintf("%s(%s)", d.fn, strings.Join(args, ", "))
+}
+
+// validateDerive checks that a field with options fo can be derived as its
+// expression says, from the fields of the index.
+func (i *Index) validateDerive(fo *FieldOptions) error {
+	if fo.Derive == "" {
+		return nil
+	}
+	d, err := parseDeriveExpr(fo.Derive)
+	if err != nil {
+		return err
+	}
+	switch fo.Type {
+	case FieldTypeInt:
+	case FieldTypeMutex:
+		if fo.Keys {
+			return errors.New("derived mutex fields can't use keys")
+		}
+	default:
+		return errors.Errorf("fields of type %q can't be derived", fo.Type)
+	}
+	if d.source == "_id" {
+		return nil
+	}
+	src := i.Field(d.source)
+	if src == nil {
+		return errors.Wrapf(ErrFieldNotFound, "source %q", d.source)
+	} else if src.options.Derive != "" {
+		return errors.Errorf("can't derive from field %q, which is itself derived", d.source)
+	}
+	want := FieldTypeInt
+	if d.fn != "bucket" {
+		want = FieldTypeTimestamp
+	}
+	if src.Type() != want {
+		return errors.Errorf("%s needs a field of type %q, not %q", d.fn, want, src.Type())
+	}
+	return nil
+}
+
+// deriveExpr returns the field's parsed expression, if it's derived.
+func (f *Field) deriveExpr() *deriveExpr {
+	if f.options.Derive == "" {
+		return nil
+	}
+	d, err := parseDeriveExpr(f.options.Derive)
+	if err != nil {
+		return nil
+	}
+	return d
+}
+
+// hasDerivedFields reports whether any of the index's fields are derived.
+func (i *Index) hasDerivedFields() bool {
+	for _, f := range i.Fields() {
+		if f.options.Derive != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// importDerived recomputes the fields derived from src for the columns just
+// imported into it, in shard.
+func (api *API) importDerived(ctx context.Context, qcx *Qcx, idx *Index, src *Field, shard uint64, columnIDs []uint64) error {
+	var derived []*Field
+	var exprs []*deriveExpr
+	for _, f := range idx.Fields() {
+		if f == src {
+			continue
+		}
+		if d := f.deriveExpr(); d != nil && (d.source == src.Name() || d.source == "_id") {
+			derived = append(derived, f)
+			exprs = append(exprs, d)
+		}
+	}
+	if len(derived) == 0 || len(columnIDs) == 0 {
+		return nil
+	}
+
+	cols := append([]uint64(nil), columnIDs...)
+	sort.Slice(cols, func(i, j int) bool { return cols[i] < cols[j] })
+	n := 1
+	for _, c := range cols[1:] {
+		if c != cols[n-1] {
+			cols[n] = c
+			n++
+		}
+	}
+	cols = cols[:n]
+
+	var keys []string
+	for i, f := range derived {
+		d := exprs[i]
+		vals, ok := make([]int64, len(cols)), make([]bool, len(cols))
+		switch d.fn {
+		case "hash":
+			if keys == nil {
+				if idx.Keys() {
+					var err error
+					if keys, err = api.cluster.translateIndexIDs(ctx, idx.Name(), cols); err != nil {
+						return errors.Wrap(err, "translating columns")
+					}
+				} else {
+					keys = make([]string, len(cols))
+					for j, c := range cols {
+						keys[j] = strconv.FormatUint(c, 10)
+					}
+				}
+			}
+			for j, key := range keys {
+				h := fnv.New64a()
+				_, _ = h.Write([]byte(key))
+				vals[j], ok[j] = int64(h.Sum64()%uint64(d.args[0])), true
+			}
+		default:
+			for j, c := range cols {
+				v, exists, err := src.Value(qcx, c)
+				if err != nil {
+					return errors.Wrapf(err, "reading %s", src.Name())
+				} else if exists {
+					vals[j], ok[j] = d.eval(src, v)
+				}
+			}
+		}
+		if err := f.importDerivedValues(qcx, shard, cols, vals, ok); err != nil {
+			return errors.Wrapf(err, "deriving field %q", f.Name())
+		}
+	}
+	return nil
+}
+
+// eval computes the derived value from a source value, reporting whether
+// there is one.
+func (d *deriveExpr) eval(src *Field, v int64) (int64, bool) {
+	switch d.fn {
+	case "bucket":
+		if v < d.args[1] {
+			return 0, false
+		}
+		return (v - d.args[1]) / d.args[0], true
+	}
+	t, err := ValToTimestamp(src.options.TimeUnit, v)
+	if err != nil {
+		return 0, false
+	}
+	switch d.fn {
+	case "year":
+		return int64(t.Year()), true
+	case "month":
+		return int64(t.Month()), true
+	case "day":
+		return int64(t.Day()), true
+	case "hour":
+		return int64(t.Hour()), true
+	}
+	return 0, false
+}
+
+// importDerivedValues writes derived values to the columns which have them,
+// and clears the others.
+func (f *Field) importDerivedValues(qcx *Qcx, shard uint64, cols []uint64, vals []int64, ok []bool) error {
+	var setCols, clearCols []uint64
+	var setVals []int64
+	for j, c := range cols {
+		if ok[j] {
+			setCols = append(setCols, c)
+			setVals = append(setVals, vals[j])
+		} else {
+			clearCols = append(clearCols, c)
+		}
+	}
+
+	if len(setCols) > 0 {
+		if f.Type() == FieldTypeInt {
+			if err := f.importValue(qcx, setCols, setVals, shard, &ImportOptions{}); err != nil {
+				return err
+			}
+		} else {
+			rows := make([]uint64, len(setVals))
+			for j, v := range setVals {
+				rows[j] = uint64(v)
+			}
+			if err := f.Import(qcx, rows, setCols, nil, shard, &ImportOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+	if len(clearCols) > 0 {
+		tx, finisher, err := qcx.GetTx(Txo{Write: true, Index: f.idx, Shard: shard})
+		if err != nil {
+			return err
+		}
+		err = f.ClearBits(tx, shard, clearCols...)
+		finisher(&err)
+		return err
+	}
+	return nil
+}
@@ -264,6 +264,7 @@ type SystemInfo interface {
 	CPUMHz() (int, error)
 	CPUArch() string
 	DiskCapacity(string) (uint64, error)
+	DiskFree(string) (uint64, error)
 }
 
 // newNopSystemInfo creates a no-op implementation of SystemInfo.
@@ -339,3 +340,8 @@ func (n *nopSystemInfo) CPUCores() (physical, logical int, err error) {
 func (n *nopSystemInfo) DiskCapacity(path string) (uint64, error) {
 	return 0, nil
 }
+
+// DiskFree returns the free space on the disk
+func (n *nopSystemInfo) DiskFree(path string) (uint64, error) {
+	return 0, nil
+}
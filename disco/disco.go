@@ -99,6 +99,7 @@ type Schemator interface {
 	Index(ctx context.Context, name string) ([]byte, error)
 
 	CreateIndex(ctx context.Context, name string, val []byte) error
+	UpdateIndex(ctx context.Context, name string, val []byte) error
 	DeleteIndex(ctx context.Context, name string) error
 	Field(ctx context.Context, index, field string) ([]byte, error)
 	CreateField(ctx context.Context, index, field string, val []byte) error
@@ -185,6 +186,9 @@ func (*nopSchemator) Index(ctx context.Context, name string) ([]byte, error) { r
 // CreateIndex is a no-op implementation of the Schemator CreateIndex method.
 func (*nopSchemator) CreateIndex(ctx context.Context, name string, val []byte) error { return nil }
 
+// UpdateIndex is a no-op implementation of the Schemator UpdateIndex method.
+func (*nopSchemator) UpdateIndex(ctx context.Context, name string, val []byte) error { return nil }
+
 // DeleteIndex is a no-op implementation of the Schemator DeleteIndex method.
 func (*nopSchemator) DeleteIndex(ctx context.Context, name string) error { return nil }
 
@@ -272,6 +276,18 @@ func (s *inMemSchemator) CreateIndex(ctx context.Context, name string, val []byt
 	return nil
 }
 
+// UpdateIndex is an in-memory implementation of the Schemator UpdateIndex method.
+func (s *inMemSchemator) UpdateIndex(ctx context.Context, name string, val []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx, ok := s.schema[name]
+	if !ok {
+		return ErrIndexDoesNotExist
+	}
+	idx.Data = val
+	return nil
+}
+
 // DeleteIndex is an in-memory implementation of the Schemator DeleteIndex method.
 func (s *inMemSchemator) DeleteIndex(ctx context.Context, name string) error {
 	s.mu.Lock()
@@ -0,0 +1,112 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package disco
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// Partition assignments, which assign partitions to nodes.
+//
+// The modulus and jmp-hash assignments pick a partition's primary by its
+// position in the list of nodes, which is sorted by ID, and its replicas
+// after it in the list, so adding or removing a node moves most partitions
+// whenever it changes the positions of other nodes.
+//
+// The ring-hash assignment places each node at a number of points on a hash
+// ring, by the hash of its ID, and assigns each partition to the nodes of the
+// first points after the hash of the partition. Adding a node moves only the
+// partitions whose points it takes, about 1/N of them, and removing one
+// moves only those it had.
+const (
+	PartitionAssignmentJmpHash  = "jmp-hash"
+	PartitionAssignmentModulus  = "modulus"
+	PartitionAssignmentRingHash = "ring-hash"
+)
+
+// ringPoints is how many points of the hash ring each node has. More points
+// spread partitions more evenly between nodes.
+const ringPoints = 128
+
+// hashRing is a consistent hash ring of the nodes of a cluster.
+type hashRing struct {
+	points []uint64 // sorted
+	nodes  []int    // the position of the node of each point in Nodes
+}
+
+// newHashRing returns the hash ring of nodes.
+func newHashRing(nodes []*Node) *hashRing {
+	r := &hashRing{
+		points: make([]uint64, 0, len(nodes)*ringPoints),
+		nodes:  make([]int, 0, len(nodes)*ringPoints),
+	}
+	for i, node := range nodes {
+		for j := 0; j < ringPoints; j++ {
+			r.points = append(r.points, ringHash([]byte(node.ID+"#"+strconv.Itoa(j))))
+			r.nodes = append(r.nodes, i)
+		}
+	}
+	sort.Sort(r)
+	return r
+}
+
+func (r *hashRing) Len() int { return len(r.points) }
+func (r *hashRing) Less(i, j int) bool {
+	// Break ties by node, so the ring doesn't depend on the order of the
+	// sort.
+	if r.points[i] == r.points[j] {
+		return r.nodes[i] < r.nodes[j]
+	}
+	return r.points[i] < r.points[j]
+}
+func (r *hashRing) Swap(i, j int) {
+	r.points[i], r.points[j] = r.points[j], r.points[i]
+	r.nodes[i], r.nodes[j] = r.nodes[j], r.nodes[i]
+}
+
+// partitionNodes returns the positions of the n distinct nodes at the first
+// points on the ring after partition's hash, the first of which is its
+// primary.
+func (r *hashRing) partitionNodes(partition, n int) []int {
+	if len(r.points) == 0 {
+		return nil
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(partition))
+	h := ringHash(buf[:])
+	start := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+
+	nodes := make([]int, 0, n)
+	for i := 0; i < len(r.points) && len(nodes) < n; i++ {
+		node := r.nodes[(start+i)%len(r.points)]
+		found := false
+		for _, other := range nodes {
+			if other == node {
+				found = true
+				break
+			}
+		}
+		if !found {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// ringHash hashes b to a point on the ring. FNV's output is mixed, as its
+// high bits vary little between similar short inputs, such as node IDs
+// differing in their last character.
+func ringHash(b []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(b)
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
@@ -34,6 +34,9 @@ type ClusterSnapshot struct {
 	ReplicaN int
 
 	PartitionAssignment string
+
+	// ring places nodes on a hash ring, for the ring-hash assignment.
+	ring *hashRing
 }
 
 // NewClusterSnapshot returns a new instance of ClusterSnapshot.
@@ -48,13 +51,17 @@ func NewClusterSnapshot(noder Noder, hasher Hasher, partitionAssignment string,
 		replicas = 1
 	}
 
-	return &ClusterSnapshot{
+	snap := &ClusterSnapshot{
 		Nodes:               nodes,
 		Hasher:              hasher,
 		PartitionN:          DefaultPartitionN,
 		ReplicaN:            replicas,
 		PartitionAssignment: partitionAssignment,
 	}
+	if partitionAssignment == PartitionAssignmentRingHash {
+		snap.ring = newHashRing(nodes)
+	}
+	return snap
 }
 
 //////////////////////////////////////////////////////////////////////////////
@@ -99,9 +106,14 @@ func (c *ClusterSnapshot) ShardNodes(index string, shard uint64) []*Node {
 
 // OwnsShard returns true if a host owns a fragment.
 func (c *ClusterSnapshot) OwnsShard(nodeID string, index string, shard uint64) (ret bool) {
-	idx := c.PrimaryNodeIndex(c.ShardToShardPartition(index, shard))
-	for i := 0; i < c.ReplicaN; i++ {
-		if c.Nodes[(idx+i)%len(c.Nodes)].ID == nodeID {
+	return c.OwnsPartition(nodeID, c.ShardToShardPartition(index, shard))
+}
+
+// OwnsPartition returns true if a host is the primary or a replica of a
+// partition.
+func (c *ClusterSnapshot) OwnsPartition(nodeID string, partitionID int) bool {
+	for _, i := range c.partitionNodeIndexes(partitionID) {
+		if c.Nodes[i].ID == nodeID {
 			return true
 		}
 	}
@@ -115,6 +127,25 @@ func (c *ClusterSnapshot) KeyNodes(index, key string) []*Node {
 
 // PartitionNodes returns a list of nodes that own the given partition.
 func (c *ClusterSnapshot) PartitionNodes(partitionID int) []*Node {
+	indexes := c.partitionNodeIndexes(partitionID)
+	if len(indexes) == 0 {
+		// no nodes anyway
+		return nil
+	}
+	nodes := make([]*Node, 0, len(indexes))
+	for _, i := range indexes {
+		nodes = append(nodes, c.Nodes[i])
+	}
+	return nodes
+}
+
+// partitionNodeIndexes returns the positions in Nodes of the nodes that own
+// the given partition, its primary first.
+func (c *ClusterSnapshot) partitionNodeIndexes(partitionID int) []int {
+	if c.ring != nil {
+		return c.ring.partitionNodes(partitionID, c.ReplicaN)
+	}
+
 	// Determine primary owner node.
 	nodeIndex := c.PrimaryNodeIndex(partitionID)
 	if nodeIndex < 0 {
@@ -122,12 +153,11 @@ func (c *ClusterSnapshot) PartitionNodes(partitionID int) []*Node {
 		return nil
 	}
 	// Collect nodes around the ring.
-	nodes := make([]*Node, 0, c.ReplicaN)
+	indexes := make([]int, 0, c.ReplicaN)
 	for i := 0; i < c.ReplicaN; i++ {
-		nodes = append(nodes, c.Nodes[(nodeIndex+i)%len(c.Nodes)])
+		indexes = append(indexes, (nodeIndex+i)%len(c.Nodes))
 	}
-
-	return nodes
+	return indexes
 }
 
 // PrimaryFieldTranslationNode is the primary node responsible for translating
@@ -164,7 +194,13 @@ func (c *ClusterSnapshot) IsPrimary(nodeID string, partition int) bool {
 // PrimaryNodeIndex returns the index (position in the cluster) of the primary
 // node for the given partition.
 func (c *ClusterSnapshot) PrimaryNodeIndex(partition int) int {
-	if c.PartitionAssignment == "modulus" {
+	if c.ring != nil {
+		if indexes := c.ring.partitionNodes(partition, 1); len(indexes) > 0 {
+			return indexes[0]
+		}
+		return -1
+	}
+	if c.PartitionAssignment == PartitionAssignmentModulus {
 		return partition % len(c.Nodes)
 	} else {
 		return c.Hasher.Hash(uint64(partition), len(c.Nodes))
@@ -174,15 +210,12 @@ func (c *ClusterSnapshot) PrimaryNodeIndex(partition int) int {
 // NonPrimaryReplicas returns the list of node IDs which are replicas for the
 // given partition.
 func (c *ClusterSnapshot) NonPrimaryReplicas(partition int) (nonPrimaryReplicas []string) {
-	primary := c.PrimaryNodeIndex(partition)
-	nodeN := len(c.Nodes)
-
-	// Collect nodes around the ring.
-	for i := 1; i < nodeN; i++ {
-		node := c.Nodes[(primary+i)%nodeN]
-		if i < c.ReplicaN {
-			nonPrimaryReplicas = append(nonPrimaryReplicas, node.ID)
-		}
+	indexes := c.partitionNodeIndexes(partition)
+	if len(indexes) == 0 {
+		return nil
+	}
+	for _, i := range indexes[1:] {
+		nonPrimaryReplicas = append(nonPrimaryReplicas, c.Nodes[i].ID)
 	}
 	return
 }
@@ -285,7 +318,7 @@ func NodePositionByID(nodes []*Node, nodeID string) int {
 // and a hasher. The order of the node IDs provided does not matter because this
 // function will re-order them in a deterministic way.
 func PrimaryNodeID(nodeIDs []string, hasher Hasher) string {
-	snap := NewClusterSnapshot(NewIDNoder(nodeIDs), hasher, "jmp-hash", 1)
+	snap := NewClusterSnapshot(NewIDNoder(nodeIDs), hasher, PartitionAssignmentJmpHash, 1)
 	primaryNode := snap.PrimaryFieldTranslationNode()
 	if primaryNode == nil {
 		return ""
@@ -0,0 +1,241 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"sort"
+
+	"github.com/featurebasedb/featurebase/v3/roaring"
+	"github.com/pkg/errors"
+)
+
+// An int, decimal or timestamp field with TrackDistinct maintains the
+// distinct values each of its shards holds in its distinct view, so that
+// Distinct (and Count(Distinct)) without a filter reads them rather than
+// scanning the field's BSI data. The view is written in the transactions
+// which write the values, so it's as consistent as they are. Each value v,
+// as stored (relative to the field's base), is the bit at distinctPos(v) of
+// the shard's fragment.
+//
+// Values written are added as they're written. Values overwritten or
+// cleared are checked for in the rest of the shard and removed if they're
+// gone, unless there are too many to check one by one, in which case the
+// shard's values are rebuilt from the BSI data, as they are after writes
+// which don't say which columns they touch.
+
+// viewDistinct is the view holding a field's distinct values.
+const viewDistinct = "distinct"
+
+// distinctRecheckLimit is the most values one write can remove from a shard
+// which are looked for individually before its values are rebuilt instead.
+const distinctRecheckLimit = 16
+
+// OptFieldTrackDistinct is a functional option on FieldOptions used to
+// maintain the distinct values of an int, decimal or timestamp field as it's
+// written.
+func OptFieldTrackDistinct() FieldOption {
+	return func(fo *FieldOptions) error {
+		fo.TrackDistinct = true
+		return nil
+	}
+}
+
+// isDistinctView reports whether a view holds a field's distinct values.
+func isDistinctView(name string) bool {
+	return name == viewDistinct
+}
+
+// distinctPos returns the position of a stored value in a distinct view
+// fragment, zig-zag encoded so that negative values have one too.
+func distinctPos(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+// distinctValue returns the stored value at a position in a distinct view
+// fragment.
+func distinctValue(pos uint64) int64 {
+	return int64(pos>>1) ^ -int64(pos&1)
+}
+
+// trackedDistinct returns the distinct values, plus offset, of a shard of a
+// field tracking them.
+func trackedDistinct(tx Tx, index, fieldName string, shard uint64, offset int64) (SignedRow, error) {
+	var posValues, negValues []uint64
+	err := tx.ForEach(index, fieldName, viewDistinct, shard, func(pos uint64) error {
+		if value := distinctValue(pos) + offset; value < 0 {
+			negValues = append(negValues, uint64(-value))
+		} else {
+			posValues = append(posValues, uint64(value))
+		}
+		return nil
+	})
+	if err != nil {
+		switch errors.Cause(err) {
+		case ViewNotFound, FragmentNotFound:
+			return SignedRow{}, nil
+		}
+		return SignedRow{}, errors.Wrap(err, "reading distinct values")
+	}
+	if len(posValues) == 0 && len(negValues) == 0 {
+		return SignedRow{}, nil
+	}
+	result := SignedRow{
+		Neg: NewRow(negValues...),
+		Pos: NewRow(posValues...),
+	}
+	result.Neg.Index, result.Pos.Index = index, index
+	result.Neg.Field, result.Pos.Field = fieldName, fieldName
+	return result, nil
+}
+
+// tracksDistinct reports whether f holds the BSI data of a field tracking
+// its distinct values.
+func (f *fragment) tracksDistinct() bool {
+	return f.fld != nil && f.fld.options.TrackDistinct && f.view() == viewBSIGroupPrefix+f.field()
+}
+
+// trackDistinct calls write, which writes values of the columns in cols in
+// tx, and then updates the shard's distinct values to match. A nil cols
+// means write may touch any column. It must be called without f.mu held.
+func (f *fragment) trackDistinct(tx Tx, cols []uint64, write func() error) error {
+	if !f.tracksDistinct() {
+		return write()
+	}
+	bsig := f.fld.bsiGroup(f.field())
+	if bsig == nil {
+		return write()
+	}
+	var filter *roaring.Bitmap
+	var before map[int64]struct{}
+	if cols != nil {
+		filter = roaring.NewBitmap()
+		for _, col := range cols {
+			filter.DirectAdd(f.shard*ShardWidth + col%ShardWidth)
+		}
+		var err error
+		if before, err = f.storedValues(tx, bsig.BitDepth, filter); err != nil {
+			return errors.Wrap(err, "getting previous values")
+		}
+	}
+	if err := write(); err != nil {
+		return err
+	}
+
+	// BSI writes may have deepened the field.
+	depth := f.fld.bsiGroup(f.field()).BitDepth
+	if cols == nil {
+		return f.rebuildDistinct(tx, depth)
+	}
+	after, err := f.storedValues(tx, depth, filter)
+	if err != nil {
+		return errors.Wrap(err, "getting new values")
+	}
+	var gone []int64
+	for v := range before {
+		if _, ok := after[v]; !ok {
+			gone = append(gone, v)
+		}
+	}
+	if len(gone) > distinctRecheckLimit {
+		return f.rebuildDistinct(tx, depth)
+	}
+
+	var add, remove []uint64
+	for v := range after {
+		add = append(add, distinctPos(v))
+	}
+	for _, v := range gone {
+		row, err := f.rangeEQ(tx, nil, depth, v)
+		if err != nil {
+			return errors.Wrapf(err, "looking for value %d", v)
+		} else if !row.Any() {
+			remove = append(remove, distinctPos(v))
+		}
+	}
+	return f.updateDistinct(tx, add, remove)
+}
+
+// storedValues returns the distinct stored values of the columns in filter,
+// or of all columns if it's nil.
+func (f *fragment) storedValues(tx Tx, depth uint64, filter *roaring.Bitmap) (map[int64]struct{}, error) {
+	r, err := bsiDistinct(tx, f.index(), f.field(), f.shard, depth, 0, filter)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[int64]struct{})
+	if r.Pos != nil {
+		for _, v := range r.Pos.Columns() {
+			values[int64(v)] = struct{}{}
+		}
+	}
+	if r.Neg != nil {
+		for _, v := range r.Neg.Columns() {
+			values[-int64(v)] = struct{}{}
+		}
+	}
+	return values, nil
+}
+
+// rebuildDistinct replaces the shard's distinct values with those its BSI
+// data holds.
+func (f *fragment) rebuildDistinct(tx Tx, depth uint64) error {
+	values, err := f.storedValues(tx, depth, nil)
+	if err != nil {
+		return errors.Wrap(err, "getting values")
+	}
+	add := make([]uint64, 0, len(values))
+	for v := range values {
+		add = append(add, distinctPos(v))
+	}
+	var remove []uint64
+	err = tx.ForEach(f.index(), f.field(), viewDistinct, f.shard, func(pos uint64) error {
+		if _, ok := values[distinctValue(pos)]; !ok {
+			remove = append(remove, pos)
+		}
+		return nil
+	})
+	if err != nil {
+		switch errors.Cause(err) {
+		case ViewNotFound, FragmentNotFound:
+		default:
+			return errors.Wrap(err, "reading distinct values")
+		}
+	}
+	return f.updateDistinct(tx, add, remove)
+}
+
+// updateDistinct adds and removes positions in the shard's distinct view
+// fragment, creating it if there's anything to add. Since they're written
+// through tx, the fragment's cached block checksums and saved checksum are
+// dropped here, as its own writes do.
+func (f *fragment) updateDistinct(tx Tx, add, remove []uint64) error {
+	if len(add) > 0 {
+		view, err := f.fld.createViewIfNotExists(viewDistinct)
+		if err != nil {
+			return errors.Wrap(err, "creating distinct view")
+		}
+		frag, err := view.CreateFragmentIfNotExists(f.shard)
+		if err != nil {
+			return errors.Wrap(err, "creating distinct fragment")
+		}
+		frag.dataChanged()
+		frag.InvalidateChecksums()
+		sort.Slice(add, func(i, j int) bool { return add[i] < add[j] })
+		if _, err := tx.Add(f.index(), f.field(), viewDistinct, f.shard, add...); err != nil {
+			return errors.Wrap(err, "adding distinct values")
+		}
+	}
+	if len(remove) > 0 {
+		if view := f.fld.view(viewDistinct); view != nil {
+			if frag := view.Fragment(f.shard); frag != nil {
+				frag.dataChanged()
+				frag.InvalidateChecksums()
+			}
+		}
+		sort.Slice(remove, func(i, j int) bool { return remove[i] < remove[j] })
+		if _, err := tx.Remove(f.index(), f.field(), viewDistinct, f.shard, remove...); err != nil {
+			return errors.Wrap(err, "removing distinct values")
+		}
+	}
+	return nil
+}
@@ -3,8 +3,11 @@
 package proto
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"sort"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -79,6 +82,54 @@ func (s Serializer) Unmarshal(buf []byte, m pilosa.Message) error {
 		}
 		s.decodeUpdateFieldMessage(msg, mt)
 		return nil
+	case *pilosa.SetRowMetaMessage:
+		msg := &pb.SetRowMetaMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling SetRowMetaMessage")
+		}
+		s.decodeSetRowMetaMessage(msg, mt)
+		return nil
+	case *pilosa.SetAliasMessage:
+		msg := &pb.SetAliasMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling SetAliasMessage")
+		}
+		s.decodeSetAliasMessage(msg, mt)
+		return nil
+	case *pilosa.CloneIndexMessage:
+		msg := &pb.CloneIndexMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling CloneIndexMessage")
+		}
+		s.decodeCloneIndexMessage(msg, mt)
+		return nil
+	case *pilosa.UpdateIndexMessage:
+		msg := &pb.UpdateIndexMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling UpdateIndexMessage")
+		}
+		s.decodeUpdateIndexMessage(msg, mt)
+		return nil
+	case *pilosa.MaintenanceMessage:
+		msg := &pb.MaintenanceMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling MaintenanceMessage")
+		}
+		s.decodeMaintenanceMessage(msg, mt)
+		return nil
+	case *pilosa.SettingsMessage:
+		msg := &pb.SettingsMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling SettingsMessage")
+		}
+		s.decodeSettingsMessage(msg, mt)
+		return nil
 	case *pilosa.DeleteFieldMessage:
 		msg := &pb.DeleteFieldMessage{}
 		err := proto.Unmarshal(buf, msg)
@@ -325,6 +376,18 @@ func (s Serializer) encodeToProto(m pilosa.Message) proto.Message {
 		return s.encodeCreateFieldMessage(mt)
 	case *pilosa.UpdateFieldMessage:
 		return s.encodeUpdateFieldMessage(mt)
+	case *pilosa.SetRowMetaMessage:
+		return s.encodeSetRowMetaMessage(mt)
+	case *pilosa.SetAliasMessage:
+		return s.encodeSetAliasMessage(mt)
+	case *pilosa.CloneIndexMessage:
+		return s.encodeCloneIndexMessage(mt)
+	case *pilosa.UpdateIndexMessage:
+		return s.encodeUpdateIndexMessage(mt)
+	case *pilosa.MaintenanceMessage:
+		return s.encodeMaintenanceMessage(mt)
+	case *pilosa.SettingsMessage:
+		return s.encodeSettingsMessage(mt)
 	case *pilosa.DeleteFieldMessage:
 		return s.encodeDeleteFieldMessage(mt)
 	case *pilosa.DeleteAvailableShardMessage:
@@ -433,6 +496,8 @@ func (s Serializer) encodeImportValueRequest(m *pilosa.ImportValueRequest) *pb.I
 		Values:         m.Values,
 		FloatValues:    m.FloatValues,
 		StringValues:   m.StringValues,
+		DecimalValues:  m.DecimalValues,
+		Nulls:          m.Nulls,
 		Clear:          m.Clear,
 	}
 }
@@ -487,6 +552,15 @@ func (s Serializer) encodeQueryRequest(m *pilosa.QueryRequest) *pb.QueryRequest
 		PreTranslated: m.PreTranslated,
 		EmbeddedData:  make([]*pb.Row, len(m.EmbeddedData)),
 		MaxMemory:     m.MaxMemory,
+
+		ExistenceFallback: m.ExistenceFallback,
+		Priority:          m.Priority,
+		AllowPartial:      m.AllowPartial,
+		ExecPath:          m.ExecPath,
+		Client:            m.Client,
+		Hints:             m.Hints,
+
+		IgnoreResultLimits: m.IgnoreResultLimits,
 	}
 	for i := range m.EmbeddedData {
 		r.EmbeddedData[i] = s.encodeRow(m.EmbeddedData[i])
@@ -551,6 +625,15 @@ func (s Serializer) encodeQueryResponse(m *pilosa.QueryResponse) *pb.QueryRespon
 		case pilosa.DistinctTimestamp:
 			resp.Results[i].Type = queryResultTypeDistinctTimestamp
 			resp.Results[i].DistinctTimestamp = s.encodeDistinctTimestamp(result)
+		case *pilosa.SortedRow:
+			resp.Results[i].Type = queryResultTypeSortedRow
+			resp.Results[i].SortedRow = s.encodeSortedRow(result)
+		case pilosa.ExtractedIDMatrixSorted:
+			resp.Results[i].Type = queryResultTypeExtractedIDMatrixSorted
+			resp.Results[i].ExtractedIDMatrixSorted = s.encodeExtractedIDMatrixSorted(result)
+		case *pilosa.Histogram:
+			resp.Results[i].Type = queryResultTypeHistogram
+			resp.Results[i].Histogram = s.encodeHistogram(result)
 		case nil:
 			resp.Results[i].Type = queryResultTypeNil
 		default:
@@ -560,11 +643,33 @@ func (s Serializer) encodeQueryResponse(m *pilosa.QueryResponse) *pb.QueryRespon
 
 	if m.Err != nil {
 		resp.Err = m.Err.Error()
+		code, retryable := pilosa.ErrorCodeOf(m.Err)
+		resp.ErrCode, resp.ErrRetryable = string(code), retryable
+	}
+	if m.Completeness != nil {
+		resp.Completeness = s.encodeCompleteness(m.Completeness)
 	}
 
 	return resp
 }
 
+func (s Serializer) encodeCompleteness(m *pilosa.Completeness) *pb.Completeness {
+	c := &pb.Completeness{
+		Complete: m.Complete,
+		Shards:   int64(m.Shards),
+		Coverage: m.Coverage,
+	}
+	indexes := make([]string, 0, len(m.MissingShards))
+	for index := range m.MissingShards {
+		indexes = append(indexes, index)
+	}
+	sort.Strings(indexes)
+	for _, index := range indexes {
+		c.MissingShards = append(c.MissingShards, &pb.IndexShards{Index: index, Shards: m.MissingShards[index]})
+	}
+	return c
+}
+
 func (s Serializer) encodeSchema(m *pilosa.Schema) *pb.Schema {
 	return &pb.Schema{
 		Indexes: s.encodeIndexInfos(m.Indexes),
@@ -615,20 +720,33 @@ func (s Serializer) encodeFieldOptions(o *pilosa.FieldOptions) *pb.FieldOptions
 		return nil
 	}
 	return &pb.FieldOptions{
-		Type:           o.Type,
-		CacheType:      o.CacheType,
-		CacheSize:      o.CacheSize,
-		Min:            s.encodeDecimal(&o.Min),
-		Max:            s.encodeDecimal(&o.Max),
-		Base:           o.Base,
-		Scale:          o.Scale,
-		BitDepth:       uint64(o.BitDepth),
-		TimeQuantum:    string(o.TimeQuantum),
-		TTL:            o.TTL.String(),
-		TimeUnit:       string(o.TimeUnit),
-		Keys:           o.Keys,
-		ForeignIndex:   o.ForeignIndex,
-		NoStandardView: o.NoStandardView,
+		Type:              o.Type,
+		CacheType:         o.CacheType,
+		CacheSize:         o.CacheSize,
+		Min:               s.encodeDecimal(&o.Min),
+		Max:               s.encodeDecimal(&o.Max),
+		Base:              o.Base,
+		Scale:             o.Scale,
+		BitDepth:          uint64(o.BitDepth),
+		TimeQuantum:       string(o.TimeQuantum),
+		TTL:               o.TTL.String(),
+		TimeUnit:          string(o.TimeUnit),
+		Keys:              o.Keys,
+		ForeignIndex:      o.ForeignIndex,
+		NoStandardView:    o.NoStandardView,
+		Description:       o.Description,
+		Owner:             o.Owner,
+		Tags:              o.Tags,
+		MemoryPolicy:      o.MemoryPolicy,
+		MemoryPolicyViews: o.MemoryPolicyViews,
+		CacheStaleness:    o.CacheStaleness.String(),
+		Derive:            o.Derive,
+		History:           string(o.History),
+		Cascade:           o.Cascade,
+		TrackDistinct:     o.TrackDistinct,
+		TimeZone:          o.TimeZone,
+		FiscalYearStart:   uint32(o.FiscalYearStart),
+		RejectNewKeys:     o.RejectNewKeys,
 	}
 }
 
@@ -689,9 +807,34 @@ func (s Serializer) encodeIndexMeta(m *pilosa.IndexOptions) *pb.IndexMeta {
 	return &pb.IndexMeta{
 		Keys:           m.Keys,
 		TrackExistence: m.TrackExistence,
+		MaxColumns:     m.MaxColumns,
+		MaxGroups:      m.MaxGroups,
+		MaxRows:        m.MaxRows,
+		Description:    m.Description,
+		Owner:          m.Owner,
+		Tags:           m.Tags,
+		Namespace:      m.Namespace,
+		MaxStorage:     m.MaxStorage,
+		ReadOnly:       m.ReadOnly,
+		Cubes:          s.encodeCubes(m.Cubes),
 	}
 }
 
+func (s Serializer) encodeCubes(a []pilosa.CubeOptions) []*pb.Cube {
+	if len(a) == 0 {
+		return nil
+	}
+	other := make([]*pb.Cube, len(a))
+	for i := range a {
+		other[i] = &pb.Cube{
+			Name:      a[i].Name,
+			Fields:    a[i].Fields,
+			Aggregate: a[i].Aggregate,
+		}
+	}
+	return other
+}
+
 func (s Serializer) encodeDeleteIndexMessage(m *pilosa.DeleteIndexMessage) *pb.DeleteIndexMessage {
 	return &pb.DeleteIndexMessage{
 		Index: m.Index,
@@ -721,6 +864,57 @@ func (s Serializer) encodeFieldUpdate(m *pilosa.FieldUpdate) *pb.FieldUpdate {
 	}
 }
 
+func (s Serializer) encodeSetRowMetaMessage(m *pilosa.SetRowMetaMessage) *pb.SetRowMetaMessage {
+	return &pb.SetRowMetaMessage{
+		Index: m.Index,
+		Field: m.Field,
+		Row:   m.Row,
+		Meta:  m.Meta,
+	}
+}
+
+func (s Serializer) encodeSetAliasMessage(m *pilosa.SetAliasMessage) *pb.SetAliasMessage {
+	return &pb.SetAliasMessage{
+		Alias: m.Alias,
+		Index: m.Index,
+	}
+}
+
+func (s Serializer) encodeCloneIndexMessage(m *pilosa.CloneIndexMessage) *pb.CloneIndexMessage {
+	return &pb.CloneIndexMessage{
+		Source: m.Source,
+		Target: m.Target,
+	}
+}
+
+func (s Serializer) encodeUpdateIndexMessage(m *pilosa.UpdateIndexMessage) *pb.UpdateIndexMessage {
+	return &pb.UpdateIndexMessage{
+		CreateIndexMessage: s.encodeCreateIndexMessage(&m.CreateIndexMessage),
+		Update: &pb.IndexUpdate{
+			Option: m.Update.Option,
+			Value:  m.Update.Value,
+		},
+	}
+}
+
+func (s Serializer) encodeMaintenanceMessage(m *pilosa.MaintenanceMessage) *pb.MaintenanceMessage {
+	return &pb.MaintenanceMessage{
+		Reason: m.Reason,
+	}
+}
+
+func (s Serializer) encodeSettingsMessage(m *pilosa.SettingsMessage) *pb.SettingsMessage {
+	return &pb.SettingsMessage{
+		QueryTimeout:         int64(m.Settings.QueryTimeout),
+		LongQueryTime:        int64(m.Settings.LongQueryTime),
+		WorkerPoolSize:       int64(m.Settings.WorkerPoolSize),
+		MaxWritesPerRequest:  int64(m.Settings.MaxWritesPerRequest),
+		MaxQueryMemory:       m.Settings.MaxQueryMemory,
+		MaxBatchQueries:      int64(m.Settings.MaxBatchQueries),
+		MaxBackgroundQueries: int64(m.Settings.MaxBackgroundQueries),
+	}
+}
+
 func (s Serializer) encodeDeleteFieldMessage(m *pilosa.DeleteFieldMessage) *pb.DeleteFieldMessage {
 	return &pb.DeleteFieldMessage{
 		Index: m.Index,
@@ -998,6 +1192,21 @@ func (s Serializer) decodeFieldOptions(options *pb.FieldOptions, m *pilosa.Field
 	m.Keys = options.Keys
 	m.ForeignIndex = options.ForeignIndex
 	m.NoStandardView = options.NoStandardView
+	m.Description = options.Description
+	m.Owner = options.Owner
+	m.Tags = options.Tags
+	m.MemoryPolicy = options.MemoryPolicy
+	m.MemoryPolicyViews = options.MemoryPolicyViews
+	if staleness, err := time.ParseDuration(options.CacheStaleness); err == nil {
+		m.CacheStaleness = staleness
+	}
+	m.Derive = options.Derive
+	m.History = pilosa.TimeQuantum(options.History)
+	m.Cascade = options.Cascade
+	m.TrackDistinct = options.TrackDistinct
+	m.TimeZone = options.TimeZone
+	m.FiscalYearStart = time.Month(options.FiscalYearStart)
+	m.RejectNewKeys = options.RejectNewKeys
 }
 
 func (s Serializer) decodeDecimal(d *pb.Decimal, m *pql.Decimal) {
@@ -1065,9 +1274,34 @@ func (s Serializer) decodeIndexMeta(pb *pb.IndexMeta, m *pilosa.IndexOptions) {
 	if pb != nil {
 		m.Keys = pb.Keys
 		m.TrackExistence = pb.TrackExistence
+		m.MaxColumns = pb.MaxColumns
+		m.MaxGroups = pb.MaxGroups
+		m.MaxRows = pb.MaxRows
+		m.Description = pb.Description
+		m.Owner = pb.Owner
+		m.Tags = pb.Tags
+		m.Namespace = pb.Namespace
+		m.MaxStorage = pb.MaxStorage
+		m.ReadOnly = pb.ReadOnly
+		m.Cubes = s.decodeCubes(pb.Cubes)
 	}
 }
 
+func (s Serializer) decodeCubes(a []*pb.Cube) []pilosa.CubeOptions {
+	if len(a) == 0 {
+		return nil
+	}
+	other := make([]pilosa.CubeOptions, len(a))
+	for i := range a {
+		other[i] = pilosa.CubeOptions{
+			Name:      a[i].Name,
+			Fields:    a[i].Fields,
+			Aggregate: a[i].Aggregate,
+		}
+	}
+	return other
+}
+
 func (s Serializer) decodeDeleteIndexMessage(pb *pb.DeleteIndexMessage, m *pilosa.DeleteIndexMessage) {
 	m.Index = pb.Index
 }
@@ -1090,6 +1324,47 @@ func (s Serializer) decodeFieldUpdate(pb *pb.FieldUpdate, m *pilosa.FieldUpdate)
 	m.Value = pb.Value
 }
 
+func (s Serializer) decodeSetRowMetaMessage(pb *pb.SetRowMetaMessage, m *pilosa.SetRowMetaMessage) {
+	m.Index = pb.Index
+	m.Field = pb.Field
+	m.Row = pb.Row
+	m.Meta = pb.Meta
+}
+
+func (s Serializer) decodeSetAliasMessage(pb *pb.SetAliasMessage, m *pilosa.SetAliasMessage) {
+	m.Alias = pb.Alias
+	m.Index = pb.Index
+}
+
+func (s Serializer) decodeCloneIndexMessage(pb *pb.CloneIndexMessage, m *pilosa.CloneIndexMessage) {
+	m.Source = pb.Source
+	m.Target = pb.Target
+}
+
+func (s Serializer) decodeUpdateIndexMessage(pb *pb.UpdateIndexMessage, m *pilosa.UpdateIndexMessage) {
+	s.decodeCreateIndexMessage(pb.CreateIndexMessage, &m.CreateIndexMessage)
+	if pb.Update != nil {
+		m.Update.Option = pb.Update.Option
+		m.Update.Value = pb.Update.Value
+	}
+}
+
+func (s Serializer) decodeMaintenanceMessage(pb *pb.MaintenanceMessage, m *pilosa.MaintenanceMessage) {
+	m.Reason = pb.Reason
+}
+
+func (s Serializer) decodeSettingsMessage(pb *pb.SettingsMessage, m *pilosa.SettingsMessage) {
+	m.Settings = pilosa.Settings{
+		QueryTimeout:         time.Duration(pb.QueryTimeout),
+		LongQueryTime:        time.Duration(pb.LongQueryTime),
+		WorkerPoolSize:       int(pb.WorkerPoolSize),
+		MaxWritesPerRequest:  int(pb.MaxWritesPerRequest),
+		MaxQueryMemory:       pb.MaxQueryMemory,
+		MaxBatchQueries:      int(pb.MaxBatchQueries),
+		MaxBackgroundQueries: int(pb.MaxBackgroundQueries),
+	}
+}
+
 func (s Serializer) decodeDeleteFieldMessage(pb *pb.DeleteFieldMessage, m *pilosa.DeleteFieldMessage) {
 	m.Index = pb.Index
 	m.Field = pb.Field
@@ -1174,6 +1449,13 @@ func (s Serializer) decodeQueryRequest(pb *pb.QueryRequest, m *pilosa.QueryReque
 	m.EmbeddedData = make([]*pilosa.Row, len(pb.EmbeddedData))
 	m.PreTranslated = pb.PreTranslated
 	m.MaxMemory = pb.MaxMemory
+	m.ExistenceFallback = pb.ExistenceFallback
+	m.Priority = pb.Priority
+	m.AllowPartial = pb.AllowPartial
+	m.ExecPath = pb.ExecPath
+	m.Client = pb.Client
+	m.Hints = pb.Hints
+	m.IgnoreResultLimits = pb.IgnoreResultLimits
 	for i := range pb.EmbeddedData {
 		m.EmbeddedData[i] = s.decodeRow(pb.EmbeddedData[i])
 	}
@@ -1202,6 +1484,8 @@ func (s Serializer) decodeImportValueRequest(pb *pb.ImportValueRequest, m *pilos
 	m.Values = pb.Values
 	m.FloatValues = pb.FloatValues
 	m.StringValues = pb.StringValues
+	m.DecimalValues = pb.DecimalValues
+	m.Nulls = pb.Nulls
 	m.IndexCreatedAt = pb.IndexCreatedAt
 	m.FieldCreatedAt = pb.FieldCreatedAt
 	m.Clear = pb.Clear
@@ -1258,11 +1542,31 @@ func (s Serializer) decodeBlockDataResponse(pb *pb.BlockDataResponse, m *pilosa.
 func (s Serializer) decodeQueryResponse(pb *pb.QueryResponse, m *pilosa.QueryResponse) {
 	if pb.Err == "" {
 		m.Err = nil
+	} else if pb.ErrCode != "" {
+		m.Err = pilosa.CodedError{Code: pilosa.ErrorCode(pb.ErrCode), Retryable: pb.ErrRetryable, Err: errors.New(pb.Err)}
 	} else {
 		m.Err = errors.New(pb.Err)
 	}
 	m.Results = make([]interface{}, len(pb.Results))
 	s.decodeQueryResults(pb.Results, m.Results)
+	if pb.Completeness != nil {
+		m.Completeness = s.decodeCompleteness(pb.Completeness)
+	}
+}
+
+func (s Serializer) decodeCompleteness(pb *pb.Completeness) *pilosa.Completeness {
+	m := &pilosa.Completeness{
+		Complete: pb.Complete,
+		Shards:   int(pb.Shards),
+		Coverage: pb.Coverage,
+	}
+	for _, missing := range pb.MissingShards {
+		if m.MissingShards == nil {
+			m.MissingShards = make(map[string][]uint64)
+		}
+		m.MissingShards[missing.Index] = missing.Shards
+	}
+	return m
 }
 
 func (s Serializer) decodeQueryResults(pb []*pb.QueryResult, m []interface{}) {
@@ -1362,6 +1666,9 @@ const (
 	queryResultTypeExtractedIDMatrix
 	queryResultTypeExtractedTable
 	queryResultTypeDistinctTimestamp
+	queryResultTypeSortedRow
+	queryResultTypeExtractedIDMatrixSorted
+	queryResultTypeHistogram
 )
 
 func (s Serializer) decodeQueryResult(pb *pb.QueryResult) interface{} {
@@ -1400,6 +1707,12 @@ func (s Serializer) decodeQueryResult(pb *pb.QueryResult) interface{} {
 		return s.decodeRowMatrix(pb.RowMatrix)
 	case queryResultTypeDistinctTimestamp:
 		return s.decodeDistinctTimestamp(pb.DistinctTimestamp)
+	case queryResultTypeSortedRow:
+		return s.decodeSortedRow(pb.SortedRow)
+	case queryResultTypeExtractedIDMatrixSorted:
+		return s.decodeExtractedIDMatrixSorted(pb.ExtractedIDMatrixSorted)
+	case queryResultTypeHistogram:
+		return s.decodeHistogram(pb.Histogram)
 	}
 	panic(fmt.Sprintf("unknown type: %d", pb.Type))
 }
@@ -1457,6 +1770,53 @@ func (s Serializer) decodeExtractedIDMatrix(m *pb.ExtractedIDMatrix) pilosa.Extr
 	}
 }
 
+func (s Serializer) decodeSortedRow(r *pb.SortedRow) *pilosa.SortedRow {
+	return &pilosa.SortedRow{
+		Row:    s.decodeRow(r.Row),
+		RowKVs: s.decodeSortedColumns(r.Columns),
+	}
+}
+
+func (s Serializer) decodeExtractedIDMatrixSorted(m *pb.ExtractedIDMatrixSorted) pilosa.ExtractedIDMatrixSorted {
+	matrix := s.decodeExtractedIDMatrix(m.ExtractedIDMatrix)
+	return pilosa.ExtractedIDMatrixSorted{
+		ExtractedIDMatrix: &matrix,
+		RowKVs:            s.decodeSortedColumns(m.Columns),
+	}
+}
+
+func (s Serializer) decodeSortedColumns(a []*pb.SortedColumn) []pilosa.RowKV {
+	rowKVs := make([]pilosa.RowKV, len(a))
+	for i, c := range a {
+		rowKVs[i] = pilosa.RowKV{
+			RowID: c.ID,
+			Value: s.decodeSortValue(c.Value),
+		}
+	}
+	return rowKVs
+}
+
+func (s Serializer) decodeSortValue(v *pb.SortValue) interface{} {
+	switch v := v.GetValue().(type) {
+	case *pb.SortValue_String_:
+		return v.String_
+	case *pb.SortValue_Bool:
+		return v.Bool
+	case *pb.SortValue_Int64:
+		return v.Int64
+	case *pb.SortValue_Uint64:
+		return v.Uint64
+	case *pb.SortValue_Values:
+		vals := make([]interface{}, len(v.Values.Values))
+		for i, val := range v.Values.Values {
+			vals[i] = s.decodeSortValue(val)
+		}
+		return vals
+	default:
+		return nil
+	}
+}
+
 func (s Serializer) decodeExtractedTable(t *pb.ExtractedTable) pilosa.ExtractedTable {
 	fields := make([]pilosa.ExtractedTableField, len(t.Fields))
 	for i, f := range t.Fields {
@@ -1506,6 +1866,11 @@ func (s Serializer) decodeExtractedTable(t *pb.ExtractedTable) pilosa.ExtractedT
 			Column: col,
 			Rows:   rows,
 		}
+		if len(c.Attrs) > 0 {
+			dec := json.NewDecoder(bytes.NewReader(c.Attrs))
+			dec.UseNumber()
+			_ = dec.Decode(&columns[i].Attrs)
+		}
 	}
 
 	return pilosa.ExtractedTable{
@@ -1533,19 +1898,42 @@ func (s Serializer) decodeGroupCounts(a *pb.GroupCounts, b []*pb.GroupCount) *pi
 			Count: gc.Count,
 			// note: not renaming the `pb. structure members now
 			// to avoid breaking protobuf interactions.
-			Agg: gc.Agg,
+			Agg:       gc.Agg,
+			AggWeight: gc.AggWeight,
+		}
+		if gc.TimestampAgg != "" {
+			other[i].TimestampAgg, _ = time.Parse(time.RFC3339Nano, gc.TimestampAgg)
+		}
+		if gc.Histogram != nil {
+			other[i].Histogram = s.decodeHistogram(gc.Histogram)
 		}
 	}
 	return pilosa.NewGroupCounts(a.Aggregate, other...)
 }
 
+func (s Serializer) decodeHistogram(h *pb.Histogram) *pilosa.Histogram {
+	if h == nil {
+		return nil
+	}
+	return &pilosa.Histogram{
+		Field:  h.Field,
+		Edges:  h.Edges,
+		Counts: h.Counts,
+	}
+}
+
 func (s Serializer) decodeFieldRows(a []*pb.FieldRow) []pilosa.FieldRow {
 	other := make([]pilosa.FieldRow, len(a))
 	for i := range a {
 		fr := a[i]
 		other[i].Field = fr.Field
+		other[i].Period = fr.Period
+		other[i].Index = fr.Index
 		if fr.Value != nil {
 			other[i].Value = &fr.Value.Value
+			if fr.ValueTo != nil {
+				other[i].ValueTo = &fr.ValueTo.Value
+			}
 		} else if fr.RowKey == "" {
 			other[i].RowID = fr.RowID
 		} else {
@@ -1571,6 +1959,7 @@ func (s Serializer) decodePairsField(a *pb.PairsField) *pilosa.PairsField {
 		other.Pairs[i] = s.decodePair(a.Pairs[i])
 	}
 	other.Field = a.Field
+	other.Stale = a.Stale
 	return other
 }
 
@@ -1599,12 +1988,22 @@ func (s Serializer) decodeValCount(pb *pb.ValCount) pilosa.ValCount {
 	if err != nil {
 		t = time.Time{}
 	}
+	var bigVal, weightSum *big.Int
+	if pb.BigVal != "" {
+		bigVal, _ = new(big.Int).SetString(pb.BigVal, 10)
+	}
+	if pb.WeightSum != "" {
+		weightSum, _ = new(big.Int).SetString(pb.WeightSum, 10)
+	}
 	return pilosa.ValCount{
 		Val:          pb.Val,
 		FloatVal:     pb.FloatVal,
 		DecimalVal:   s.decodeDecimalStruct(pb.DecimalVal),
 		TimestampVal: t,
 		Count:        pb.Count,
+		BigVal:       bigVal,
+		Precision:    pb.Precision,
+		WeightSum:    weightSum,
 	}
 }
 
@@ -1665,22 +2064,40 @@ func (s Serializer) encodeGroupCounts(counts *pilosa.GroupCounts) *pb.GroupCount
 	}
 	for i, gc := range groups {
 		result.Groups[i] = &pb.GroupCount{
-			Group: s.encodeFieldRows(gc.Group),
-			Count: gc.Count,
-			Agg:   gc.Agg,
+			Group:     s.encodeFieldRows(gc.Group),
+			Count:     gc.Count,
+			Agg:       gc.Agg,
+			AggWeight: gc.AggWeight,
+		}
+		if !gc.TimestampAgg.IsZero() {
+			result.Groups[i].TimestampAgg = gc.TimestampAgg.Format(time.RFC3339Nano)
+		}
+		if gc.Histogram != nil {
+			result.Groups[i].Histogram = s.encodeHistogram(gc.Histogram)
 		}
 	}
 	return result
 }
 
+func (s Serializer) encodeHistogram(h *pilosa.Histogram) *pb.Histogram {
+	return &pb.Histogram{
+		Field:  h.Field,
+		Edges:  h.Edges,
+		Counts: h.Counts,
+	}
+}
+
 func (s Serializer) encodeFieldRows(a []pilosa.FieldRow) []*pb.FieldRow {
 	other := make([]*pb.FieldRow, len(a))
 	for i := range a {
 		fr := a[i]
-		other[i] = &pb.FieldRow{Field: fr.Field}
+		other[i] = &pb.FieldRow{Field: fr.Field, Period: fr.Period, Index: fr.Index}
 
 		if fr.Value != nil {
 			other[i].Value = &pb.Int64{Value: *fr.Value}
+			if fr.ValueTo != nil {
+				other[i].ValueTo = &pb.Int64{Value: *fr.ValueTo}
+			}
 		} else if fr.RowKey == "" {
 			other[i].RowID = fr.RowID
 		} else {
@@ -1708,6 +2125,54 @@ func (s Serializer) endcodeExtractedIDMatrix(m pilosa.ExtractedIDMatrix) *pb.Ext
 	}
 }
 
+func (s Serializer) encodeSortedRow(r *pilosa.SortedRow) *pb.SortedRow {
+	return &pb.SortedRow{
+		Row:     s.encodeRow(r.Row),
+		Columns: s.encodeSortedColumns(r.RowKVs),
+	}
+}
+
+func (s Serializer) encodeExtractedIDMatrixSorted(m pilosa.ExtractedIDMatrixSorted) *pb.ExtractedIDMatrixSorted {
+	return &pb.ExtractedIDMatrixSorted{
+		ExtractedIDMatrix: s.endcodeExtractedIDMatrix(*m.ExtractedIDMatrix),
+		Columns:           s.encodeSortedColumns(m.RowKVs),
+	}
+}
+
+func (s Serializer) encodeSortedColumns(rowKVs []pilosa.RowKV) []*pb.SortedColumn {
+	a := make([]*pb.SortedColumn, len(rowKVs))
+	for i, kv := range rowKVs {
+		a[i] = &pb.SortedColumn{
+			ID:    kv.RowID,
+			Value: s.encodeSortValue(kv.Value),
+		}
+	}
+	return a
+}
+
+// encodeSortValue encodes the value a column is sorted by. Missing values
+// are encoded as an empty SortValue.
+func (s Serializer) encodeSortValue(v interface{}) *pb.SortValue {
+	switch v := v.(type) {
+	case string:
+		return &pb.SortValue{Value: &pb.SortValue_String_{String_: v}}
+	case bool:
+		return &pb.SortValue{Value: &pb.SortValue_Bool{Bool: v}}
+	case int64:
+		return &pb.SortValue{Value: &pb.SortValue_Int64{Int64: v}}
+	case uint64:
+		return &pb.SortValue{Value: &pb.SortValue_Uint64{Uint64: v}}
+	case []interface{}:
+		vals := make([]*pb.SortValue, len(v))
+		for i, val := range v {
+			vals[i] = s.encodeSortValue(val)
+		}
+		return &pb.SortValue{Value: &pb.SortValue_Values{Values: &pb.SortValues{Values: vals}}}
+	default:
+		return &pb.SortValue{}
+	}
+}
+
 func (s Serializer) encodeExtractedTable(t pilosa.ExtractedTable) *pb.ExtractedTable {
 	fields := make([]*pb.ExtractedTableField, len(t.Fields))
 	for i, f := range t.Fields {
@@ -1772,6 +2237,11 @@ func (s Serializer) encodeExtractedTable(t pilosa.ExtractedTable) *pb.ExtractedT
 			}
 		}
 		col.Values = rows
+		if len(c.Attrs) > 0 {
+			// Attributes hold only strings, numbers and bools, which always
+			// encode.
+			col.Attrs, _ = json.Marshal(c.Attrs)
+		}
 
 		cols[i] = &col
 	}
@@ -1798,6 +2268,7 @@ func (s Serializer) encodePairsField(a *pilosa.PairsField) *pb.PairsField {
 		other.Pairs[i] = s.encodePair(a.Pairs[i])
 	}
 	other.Field = a.Field
+	other.Stale = a.Stale
 	return other
 }
 
@@ -1817,12 +2288,22 @@ func (s Serializer) encodePairField(p pilosa.PairField) *pb.PairField {
 }
 
 func (s Serializer) encodeValCount(vc pilosa.ValCount) *pb.ValCount {
+	var bigVal, weightSum string
+	if vc.BigVal != nil {
+		bigVal = vc.BigVal.String()
+	}
+	if vc.WeightSum != nil {
+		weightSum = vc.WeightSum.String()
+	}
 	return &pb.ValCount{
 		Val:          vc.Val,
 		FloatVal:     vc.FloatVal,
 		DecimalVal:   s.encodeDecimal(vc.DecimalVal),
 		Count:        vc.Count,
 		TimestampVal: vc.TimestampVal.Format(time.RFC3339Nano),
+		BigVal:       bigVal,
+		Precision:    vc.Precision,
+		WeightSum:    weightSum,
 	}
 }
 
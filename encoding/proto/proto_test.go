@@ -3,6 +3,7 @@
 package proto
 
 import (
+	"encoding/json"
 	"errors"
 	"reflect"
 	"testing"
@@ -175,3 +176,88 @@ func TestDecodeQueryResult(t *testing.T) {
 		}
 	})
 }
+
+func TestEncodeDecodeSortedColumns(t *testing.T) {
+	s := Serializer{}
+	rowKVs := []pilosa.RowKV{
+		{RowID: 1, Value: "a"},
+		{RowID: 2, Value: true},
+		{RowID: 3, Value: int64(-4)},
+		{RowID: 4, Value: uint64(5)},
+		{RowID: 5, Value: []interface{}{"b", nil, int64(6)}},
+		{RowID: 6, Value: nil},
+	}
+	decoded := s.decodeSortedColumns(s.encodeSortedColumns(rowKVs))
+	if !reflect.DeepEqual(decoded, rowKVs) {
+		t.Errorf("failed to round trip sorted columns. expected %v got %v", rowKVs, decoded)
+	}
+}
+
+func TestEncodeDecodeCompleteness(t *testing.T) {
+	s := Serializer{}
+	c := &pilosa.Completeness{
+		Shards:        8,
+		MissingShards: map[string][]uint64{"a": {1, 5}, "b": {2}},
+		Coverage:      0.625,
+	}
+	decoded := s.decodeCompleteness(s.encodeCompleteness(c))
+	if !reflect.DeepEqual(decoded, c) {
+		t.Errorf("failed to round trip completeness. expected %+v got %+v", c, decoded)
+	}
+}
+
+func TestEncodeDecodeExtractedTableAttrs(t *testing.T) {
+	s := Serializer{}
+	table := pilosa.ExtractedTable{
+		Fields: []pilosa.ExtractedTableField{},
+		Columns: []pilosa.ExtractedTableColumn{
+			{
+				Column: pilosa.KeyOrID{Keyed: true, Key: "a"},
+				Rows:   []interface{}{},
+				Attrs:  map[string]interface{}{"name": "x", "n": json.Number("5"), "ok": true},
+			},
+			{
+				Column: pilosa.KeyOrID{Keyed: true, Key: "b"},
+				Rows:   []interface{}{},
+			},
+		},
+	}
+	decoded := s.decodeExtractedTable(s.encodeExtractedTable(table))
+	if !reflect.DeepEqual(decoded, table) {
+		t.Errorf("failed to round trip extracted table. expected %+v got %+v", table, decoded)
+	}
+}
+
+func TestEncodeDecodeQueryResponseErrorCode(t *testing.T) {
+	s := Serializer{}
+	resp := &pilosa.QueryResponse{Err: pilosa.MaintenanceError{Reason: "backup"}}
+	var decoded pilosa.QueryResponse
+	s.decodeQueryResponse(s.encodeQueryResponse(resp), &decoded)
+	if code, retryable := pilosa.ErrorCodeOf(decoded.Err); code != pilosa.ErrorCodeMaintenance || !retryable {
+		t.Errorf("expected retryable %s, got %s (retryable %t)", pilosa.ErrorCodeMaintenance, code, retryable)
+	} else if decoded.Err.Error() != resp.Err.Error() {
+		t.Errorf("expected error %q, got %q", resp.Err, decoded.Err)
+	}
+}
+
+func TestEncodeDecodeImportValueRequestNulls(t *testing.T) {
+	s := Serializer{}
+	req := &pilosa.ImportValueRequest{
+		Index:         "i",
+		Field:         "f",
+		ColumnIDs:     []uint64{1, 2, 3},
+		DecimalValues: []string{"1.25", "", "-3"},
+		Nulls:         []bool{false, true, false},
+	}
+	buf, err := s.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded pilosa.ImportValueRequest
+	if err := s.Unmarshal(buf, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, req) {
+		t.Errorf("failed to round trip import value request. expected %+v got %+v", req, &decoded)
+	}
+}
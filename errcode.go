@@ -0,0 +1,132 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Query errors carry a code, along with whether the query is worth
+// retrying, so that clients needn't match error messages to decide what
+// to do. They're returned in the "code" and "retryable" fields of JSON
+// query responses, next to "error", and in the ErrCode and ErrRetryable
+// fields of protobuf ones, which also carry them from node to node, so an
+// error from a remote node keeps its code on the coordinating node.
+
+// ErrorCode classifies an error.
+type ErrorCode string
+
+// Error codes.
+const (
+	// ErrorCodeUnknown is the code of errors which fit none of the others.
+	ErrorCodeUnknown ErrorCode = "UNKNOWN"
+
+	ErrorCodeBadRequest          ErrorCode = "BAD_REQUEST"
+	ErrorCodeNotFound            ErrorCode = "NOT_FOUND"
+	ErrorCodeShardUnavailable    ErrorCode = "SHARD_UNAVAILABLE"
+	ErrorCodeTranslationMiss     ErrorCode = "TRANSLATION_MISS"
+	ErrorCodeDeadlineExceeded    ErrorCode = "DEADLINE_EXCEEDED"
+	ErrorCodeCancelled           ErrorCode = "CANCELLED"
+	ErrorCodeTooManyWrites       ErrorCode = "TOO_MANY_WRITES"
+	ErrorCodeResultLimitExceeded ErrorCode = "RESULT_LIMIT_EXCEEDED"
+	ErrorCodeQuotaExceeded       ErrorCode = "QUOTA_EXCEEDED"
+	ErrorCodeNodeReadOnly        ErrorCode = "NODE_READ_ONLY"
+	ErrorCodeIndexReadOnly       ErrorCode = "INDEX_READ_ONLY"
+	ErrorCodeMaintenance         ErrorCode = "MAINTENANCE"
+	ErrorCodeQueryDenied         ErrorCode = "QUERY_DENIED"
+	ErrorCodeClusterUnavailable  ErrorCode = "CLUSTER_UNAVAILABLE"
+	ErrorCodeIncompatibleVersion ErrorCode = "INCOMPATIBLE_PROTOCOL"
+	ErrorCodeUnknownKeys         ErrorCode = "UNKNOWN_KEYS"
+	ErrorCodeSignedInput         ErrorCode = "SIGNED_INPUT"
+)
+
+// retryableErrorCodes are the codes of errors which may not recur if the
+// request is made again later.
+var retryableErrorCodes = map[ErrorCode]bool{
+	ErrorCodeShardUnavailable:   true,
+	ErrorCodeDeadlineExceeded:   true,
+	ErrorCodeIndexReadOnly:      true,
+	ErrorCodeMaintenance:        true,
+	ErrorCodeClusterUnavailable: true,
+}
+
+// Retryable reports whether errors with the code are worth retrying.
+func (c ErrorCode) Retryable() bool {
+	return retryableErrorCodes[c]
+}
+
+// CodedError is an error with a code given to it elsewhere, such as by the
+// node which returned it.
+type CodedError struct {
+	Code      ErrorCode
+	Retryable bool
+	Err       error
+}
+
+func (e CodedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error given the code.
+func (e CodedError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCodeOf returns the code of err, and whether it's worth retrying.
+func ErrorCodeOf(err error) (ErrorCode, bool) {
+	if err == nil {
+		return "", false
+	}
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded.Code, coded.Retryable
+	}
+	code := errorCode(err)
+	return code, code.Retryable()
+}
+
+// errorCode classifies an error which hasn't been given a code.
+func errorCode(err error) ErrorCode {
+	var badRequest BadRequestError
+	var notAllowed apiMethodNotAllowedError
+	switch {
+	case errors.Is(err, errShardUnavailable), strings.Contains(err.Error(), errConnectionRefused):
+		return ErrorCodeShardUnavailable
+	case errors.Is(err, ErrTranslatingKeyNotFound):
+		return ErrorCodeTranslationMiss
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, ErrQueryTimeout):
+		return ErrorCodeDeadlineExceeded
+	case errors.Is(err, context.Canceled), errors.Is(err, ErrQueryCancelled):
+		return ErrorCodeCancelled
+	case errors.Is(err, ErrTooManyWrites):
+		return ErrorCodeTooManyWrites
+	case errors.Is(err, ErrResultLimitExceeded):
+		return ErrorCodeResultLimitExceeded
+	case errors.Is(err, ErrNamespaceQuotaExceeded), errors.Is(err, ErrStorageQuotaExceeded):
+		return ErrorCodeQuotaExceeded
+	case errors.Is(err, ErrReadOnly):
+		return ErrorCodeNodeReadOnly
+	case errors.Is(err, ErrIndexReadOnly):
+		return ErrorCodeIndexReadOnly
+	case errors.Is(err, ErrMaintenance):
+		return ErrorCodeMaintenance
+	case errors.Is(err, ErrQueryDenied):
+		return ErrorCodeQueryDenied
+	case errors.Is(err, ErrIncompatibleProtocol):
+		return ErrorCodeIncompatibleVersion
+	case errors.Is(err, ErrUnknownKeys):
+		return ErrorCodeUnknownKeys
+	case errors.Is(err, ErrSignedInput):
+		return ErrorCodeSignedInput
+	case errors.As(err, &notAllowed):
+		return ErrorCodeClusterUnavailable
+	case errors.Is(err, ErrIndexNotFound), errors.Is(err, ErrFieldNotFound), errors.Is(err, ErrAliasNotFound):
+		return ErrorCodeNotFound
+	case errors.As(err, &badRequest), errors.Is(err, ErrQueryRequired), errors.Is(err, ErrIndexRequired):
+		return ErrorCodeBadRequest
+	}
+	return ErrorCodeUnknown
+}
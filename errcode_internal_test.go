@@ -0,0 +1,43 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	for _, tt := range []struct {
+		err       error
+		code      ErrorCode
+		retryable bool
+	}{
+		{errors.Wrap(errShardUnavailable, "mapping"), ErrorCodeShardUnavailable, true},
+		{errors.New("Post http://host1/index/i/query: dial tcp: connect: connection refused"), ErrorCodeShardUnavailable, true},
+		{errors.Wrap(ErrTranslatingKeyNotFound, "translating"), ErrorCodeTranslationMiss, false},
+		{errors.Wrap(context.DeadlineExceeded, "executing"), ErrorCodeDeadlineExceeded, true},
+		{ErrQueryCancelled, ErrorCodeCancelled, false},
+		{errors.Wrap(ErrTooManyWrites, "executing"), ErrorCodeTooManyWrites, false},
+		{newResultLimitError("i", "Extract", "columns", 10), ErrorCodeResultLimitExceeded, false},
+		{MaintenanceError{Reason: "backup"}, ErrorCodeMaintenance, true},
+		{IndexReadOnlyError{Index: "i"}, ErrorCodeIndexReadOnly, true},
+		{errors.Wrap(UnknownKeysError{Columns: map[string][]string{"i": {"a"}}}, "translating"), ErrorCodeUnknownKeys, false},
+		{errors.Wrap(SignedInputError{Call: "Distinct", Field: "v", Negatives: 2}, "executing"), ErrorCodeSignedInput, false},
+		{newAPIMethodNotAllowedError(errors.New("not allowed")), ErrorCodeClusterUnavailable, true},
+		{newNotFoundError(ErrFieldNotFound, "f"), ErrorCodeNotFound, false},
+		{NewBadRequestError(errors.New("bad")), ErrorCodeBadRequest, false},
+		{errors.New("something else"), ErrorCodeUnknown, false},
+		{errors.Wrap(CodedError{Code: ErrorCodeMaintenance, Retryable: true, Err: errors.New("remote")}, "mapping"), ErrorCodeMaintenance, true},
+	} {
+		code, retryable := ErrorCodeOf(tt.err)
+		if code != tt.code || retryable != tt.retryable {
+			t.Errorf("%v: expected %s (retryable %t), got %s (retryable %t)", tt.err, tt.code, tt.retryable, code, retryable)
+		}
+	}
+	if code, _ := ErrorCodeOf(nil); code != "" {
+		t.Errorf("expected no code for nil, got %s", code)
+	}
+}
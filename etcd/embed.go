@@ -843,6 +843,33 @@ func (e *Etcd) CreateIndex(ctx context.Context, name string, val []byte) error {
 	return nil
 }
 
+func (e *Etcd) UpdateIndex(ctx context.Context, name string, val []byte) error {
+	key := schemaPrefix + name
+
+	// Set up Op to write index value as bytes.
+	op := clientv3.OpPut(key, "")
+	op.WithValueBytes(val)
+
+	// Check for key existence, and execute Op within a transaction.
+	var resp *clientv3.TxnResponse
+	err := e.retryClient(func(cli *clientv3.Client) (err error) {
+		resp, err = cli.Txn(ctx).
+			If(clientv3util.KeyExists(key)).
+			Then(op).
+			Commit()
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "executing transaction")
+	}
+
+	if !resp.Succeeded {
+		return disco.ErrIndexDoesNotExist
+	}
+
+	return nil
+}
+
 func (e *Etcd) Index(ctx context.Context, name string) ([]byte, error) {
 	return e.getKeyBytes(ctx, schemaPrefix+name)
 }
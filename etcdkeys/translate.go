@@ -0,0 +1,695 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+
+// Package etcdkeys implements key translation stores kept in etcd, rather
+// than in files on each node, for deployments where translation data
+// outgrows local disk, or is too costly to replicate between nodes.
+//
+// Every node of a cluster shares the stores, so a replica finds keys created
+// on the primary as soon as they're written, and replicating them writes
+// what's already there. Each store is kept under its own prefix: index stores
+// under <prefix><index>/keys/<partition>/ and field stores under
+// <prefix><index>/fields/<field>/. Under that, k/<key> holds the ID of each
+// key, as 8 big-endian bytes, i/<ID>, with the ID in 16 hex digits, holds the
+// key of each ID, and free holds the IDs of deleted keys, which are reused.
+//
+// Stores are written to and read from in the format of the boltdb package's
+// stores, so translation data backed up from one backend can be restored to
+// the other, which is also how stores are migrated between backends.
+package etcdkeys
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pilosa "github.com/featurebasedb/featurebase/v3"
+	"github.com/featurebasedb/featurebase/v3/boltdb"
+	"github.com/featurebasedb/featurebase/v3/roaring"
+	"github.com/pkg/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// DefaultPrefix is the prefix under which stores are kept by default.
+const DefaultPrefix = "/featurebase/keys/"
+
+const (
+	// txnOps is the most operations in each part of a transaction, which is
+	// etcd's default limit.
+	txnOps = 128
+
+	// pageSize is how many entries are read from a store at once when
+	// scanning it.
+	pageSize = 1024
+
+	// requestTimeout bounds each request to etcd.
+	requestTimeout = 30 * time.Second
+
+	// createAttempts is how many times creating keys is tried when other
+	// writers to the store get in the way.
+	createAttempts = 8
+)
+
+// ErrConflict is returned when keys can't be created because other writers
+// keep changing the store.
+var ErrConflict = errors.New("etcdkeys: conflicting writes to translate store")
+
+// NewClient returns a client of the etcd cluster at the comma-separated
+// endpoints.
+func NewClient(endpoints string) (*clientv3.Client, error) {
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   strings.Split(endpoints, ","),
+		DialTimeout: 10 * time.Second,
+	})
+	return cli, errors.Wrap(err, "connecting to etcd")
+}
+
+// OpenTranslateStore returns a function which opens translation stores kept
+// in etcd, through cli, under prefix. Closing the stores doesn't close cli.
+func OpenTranslateStore(cli *clientv3.Client, prefix string) pilosa.OpenTranslateStoreFunc {
+	return func(path, index, field string, partitionID, partitionN int, fsyncEnabled bool) (pilosa.TranslateStore, error) {
+		return NewTranslateStore(cli, prefix, index, field, partitionID, partitionN), nil
+	}
+}
+
+// Ensure type implements interface.
+var _ pilosa.TranslateStore = &TranslateStore{}
+
+// TranslateStore is a translation store kept in etcd.
+type TranslateStore struct {
+	cli    *clientv3.Client
+	prefix string // of the store's etcd keys
+
+	index       string
+	field       string
+	partitionID int
+	partitionN  int
+
+	mu       sync.RWMutex
+	readOnly bool
+
+	// createMu serializes the creation of keys through the store. Writers
+	// elsewhere are caught by the transactions creating them.
+	createMu sync.Mutex
+}
+
+// NewTranslateStore returns the store of a partition of an index's keys, or
+// of a field's keys, kept in etcd, through cli, under prefix.
+func NewTranslateStore(cli *clientv3.Client, prefix, index, field string, partitionID, partitionN int) *TranslateStore {
+	return &TranslateStore{
+		cli:         cli,
+		prefix:      StorePrefix(prefix, index, field, partitionID),
+		index:       index,
+		field:       field,
+		partitionID: partitionID,
+		partitionN:  partitionN,
+	}
+}
+
+// StorePrefix returns the prefix under which the store of a partition of an
+// index's keys, or of a field's keys, is kept.
+func StorePrefix(prefix, index, field string, partitionID int) string {
+	if field == "" {
+		return fmt.Sprintf("%s%s/keys/%d/", prefix, index, partitionID)
+	}
+	return fmt.Sprintf("%s%s/fields/%s/", prefix, index, field)
+}
+
+func (s *TranslateStore) keyKey(key string) string { return s.prefix + "k/" + key }
+func (s *TranslateStore) idKey(id uint64) string   { return fmt.Sprintf("%si/%016x", s.prefix, id) }
+func (s *TranslateStore) idPrefix() string         { return s.prefix + "i/" }
+func (s *TranslateStore) freeKey() string          { return s.prefix + "free" }
+
+// parseIDKey returns the ID an i/ key is of.
+func (s *TranslateStore) parseIDKey(k []byte) (uint64, error) {
+	id, err := strconv.ParseUint(strings.TrimPrefix(string(k), s.idPrefix()), 16, 64)
+	return id, errors.Wrapf(err, "parsing id of %q", k)
+}
+
+// txn commits the ops in a transaction, if the cmps hold.
+func (s *TranslateStore) txn(cmps []clientv3.Cmp, ops []clientv3.Op) (*clientv3.TxnResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	resp, err := s.cli.Txn(ctx).If(cmps...).Then(ops...).Commit()
+	return resp, errors.Wrap(err, "etcd transaction")
+}
+
+// get gets key, or the keys opts ask for.
+func (s *TranslateStore) get(key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	resp, err := s.cli.Get(ctx, key, opts...)
+	return resp, errors.Wrap(err, "etcd get")
+}
+
+// Close closes the store. The client it uses is left open.
+func (s *TranslateStore) Close() error {
+	return nil
+}
+
+// Drop deletes everything in the store, when its index or field is deleted.
+func (s *TranslateStore) Drop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := s.cli.Delete(ctx, s.prefix, clientv3.WithPrefix())
+	return errors.Wrap(err, "deleting translate store")
+}
+
+// PartitionID returns the partition id the store was initialized with.
+func (s *TranslateStore) PartitionID() int {
+	return s.partitionID
+}
+
+// ReadOnly returns true if the store is in read-only mode.
+func (s *TranslateStore) ReadOnly() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.readOnly
+}
+
+// SetReadOnly toggles whether store is in read-only mode.
+func (s *TranslateStore) SetReadOnly(v bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readOnly = v
+}
+
+// MaxID returns the highest id in the store.
+func (s *TranslateStore) MaxID() (uint64, error) {
+	resp, err := s.get(s.idPrefix(), clientv3.WithPrefix(), clientv3.WithKeysOnly(),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend), clientv3.WithLimit(1))
+	if err != nil {
+		return 0, err
+	} else if len(resp.Kvs) == 0 {
+		return 0, nil
+	}
+	return s.parseIDKey(resp.Kvs[0].Key)
+}
+
+// FindKeys looks up the ID for each key.
+// Keys are not created if they do not exist.
+// Missing keys are not considered errors, so the length of the result may be less than that of the input.
+func (s *TranslateStore) FindKeys(keys ...string) (map[string]uint64, error) {
+	result := make(map[string]uint64, len(keys))
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > txnOps {
+			n = txnOps
+		}
+		ops := make([]clientv3.Op, n)
+		for i, key := range keys[:n] {
+			ops[i] = clientv3.OpGet(s.keyKey(key))
+		}
+		resp, err := s.txn(nil, ops)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding keys")
+		}
+		for i, r := range resp.Responses {
+			if kvs := r.GetResponseRange().Kvs; len(kvs) > 0 {
+				result[keys[i]] = btou64(kvs[0].Value)
+			}
+		}
+		keys = keys[n:]
+	}
+	return result, nil
+}
+
+// CreateKeys maps all keys to IDs, creating the IDs if they do not exist.
+// If the translator is read-only, this will return an error.
+func (s *TranslateStore) CreateKeys(keys ...string) (map[string]uint64, error) {
+	if s.ReadOnly() {
+		return nil, pilosa.ErrTranslateStoreReadOnly
+	}
+	s.createMu.Lock()
+	defer s.createMu.Unlock()
+
+	result, err := s.FindKeys(keys...)
+	if err != nil {
+		return nil, err
+	}
+	missing := make([]string, 0, len(keys)-len(result))
+	for _, key := range keys {
+		if _, ok := result[key]; !ok {
+			result[key] = 0 // so duplicates are only created once
+			missing = append(missing, key)
+		}
+	}
+
+	// Each key takes two comparisons and two puts, and the free IDs one of
+	// each.
+	const batchN = (txnOps - 1) / 2
+	for attempts := 0; len(missing) > 0; {
+		n := len(missing)
+		if n > batchN {
+			n = batchN
+		}
+		created, ok, err := s.createKeys(missing[:n])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			// Something else wrote to the store since it was read, so the
+			// keys may now exist, or the IDs may have been taken.
+			if attempts++; attempts == createAttempts {
+				return nil, ErrConflict
+			}
+			found, err := s.FindKeys(missing[:n]...)
+			if err != nil {
+				return nil, err
+			}
+			for key, id := range found {
+				result[key] = id
+			}
+			rest := missing[:0]
+			for _, key := range missing {
+				if _, ok := found[key]; !ok {
+					rest = append(rest, key)
+				}
+			}
+			missing = rest
+			continue
+		}
+		for key, id := range created {
+			result[key] = id
+		}
+		missing = missing[n:]
+	}
+	return result, nil
+}
+
+// createKeys creates keys, which didn't exist, in one transaction. It
+// returns false if the store was changed since it was read.
+func (s *TranslateStore) createKeys(keys []string) (map[string]uint64, bool, error) {
+	maxID, err := s.MaxID()
+	if err != nil {
+		return nil, false, errors.Wrap(err, "finding max id")
+	}
+	free, freeRev, err := s.freeIDs()
+	if err != nil {
+		return nil, false, err
+	}
+
+	created := make(map[string]uint64, len(keys))
+	cmps := []clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(s.freeKey()), "=", freeRev)}
+	ops := make([]clientv3.Op, 0, 2*len(keys)+1)
+	freeChanged := false
+	for _, key := range keys {
+		id, ok := free.Min()
+		if ok {
+			if _, err := free.RemoveN(id); err != nil {
+				return nil, false, errors.Wrap(err, "taking free id")
+			}
+			freeChanged = true
+		} else {
+			id = pilosa.GenerateNextPartitionedID(s.index, maxID, s.partitionID, s.partitionN)
+			maxID = id
+		}
+		cmps = append(cmps,
+			clientv3.Compare(clientv3.Version(s.keyKey(key)), "=", 0),
+			clientv3.Compare(clientv3.Version(s.idKey(id)), "=", 0),
+		)
+		ops = append(ops,
+			clientv3.OpPut(s.keyKey(key), string(u64tob(id))),
+			clientv3.OpPut(s.idKey(id), key),
+		)
+		created[key] = id
+	}
+	if freeChanged {
+		buf, err := free.MarshalBinary()
+		if err != nil {
+			return nil, false, errors.Wrap(err, "marshaling free ids")
+		}
+		ops = append(ops, clientv3.OpPut(s.freeKey(), string(buf)))
+	}
+
+	resp, err := s.txn(cmps, ops)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "creating keys")
+	}
+	return created, resp.Succeeded, nil
+}
+
+// freeIDs returns the IDs of deleted keys, and the revision in which they
+// were last changed.
+func (s *TranslateStore) freeIDs() (*roaring.Bitmap, int64, error) {
+	resp, err := s.get(s.freeKey())
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "getting free ids")
+	}
+	free := roaring.NewBitmap()
+	if len(resp.Kvs) == 0 {
+		return free, 0, nil
+	}
+	if err := free.UnmarshalBinary(resp.Kvs[0].Value); err != nil {
+		return nil, 0, errors.Wrap(err, "unmarshaling free ids")
+	}
+	return free, resp.Kvs[0].ModRevision, nil
+}
+
+// FreeIDs returns the IDs of deleted keys, which are reused.
+func (s *TranslateStore) FreeIDs() (*roaring.Bitmap, error) {
+	free, _, err := s.freeIDs()
+	return free, err
+}
+
+// scan calls fn with each ID and key in the store, in order of ID, from
+// offset on, as of one revision of the store.
+func (s *TranslateStore) scan(ctx context.Context, offset uint64, fn func(id uint64, key []byte) error) error {
+	from, end := s.idKey(offset), clientv3.GetPrefixRangeEnd(s.idPrefix())
+	var rev int64
+	for {
+		opts := []clientv3.OpOption{clientv3.WithRange(end), clientv3.WithLimit(pageSize)}
+		if rev != 0 {
+			opts = append(opts, clientv3.WithRev(rev))
+		}
+		resp, err := s.cli.Get(ctx, from, opts...)
+		if err != nil {
+			return errors.Wrap(err, "scanning translate store")
+		}
+		rev = resp.Header.Revision
+		for _, kv := range resp.Kvs {
+			id, err := s.parseIDKey(kv.Key)
+			if err != nil {
+				return err
+			}
+			if err := fn(id, kv.Value); err != nil {
+				return err
+			}
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return nil
+		}
+		from = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
+
+// Match finds the IDs of all keys matching a filter.
+func (s *TranslateStore) Match(filter func([]byte) bool) ([]uint64, error) {
+	var matches []uint64
+	err := s.scan(context.Background(), 0, func(id uint64, key []byte) error {
+		if filter(key) {
+			matches = append(matches, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// TranslateID converts an integer ID to a string key.
+// Returns a blank string if ID does not exist.
+func (s *TranslateStore) TranslateID(id uint64) (string, error) {
+	resp, err := s.get(s.idKey(id))
+	if err != nil {
+		return "", errors.Wrap(err, "translating id")
+	} else if len(resp.Kvs) == 0 {
+		return "", nil
+	}
+	return string(resp.Kvs[0].Value), nil
+}
+
+// TranslateIDs converts a list of integer IDs to a list of string keys.
+func (s *TranslateStore) TranslateIDs(ids []uint64) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(ids))
+	for len(ids) > 0 {
+		n := len(ids)
+		if n > txnOps {
+			n = txnOps
+		}
+		ops := make([]clientv3.Op, n)
+		for i, id := range ids[:n] {
+			ops[i] = clientv3.OpGet(s.idKey(id))
+		}
+		resp, err := s.txn(nil, ops)
+		if err != nil {
+			return nil, errors.Wrap(err, "translating ids")
+		}
+		for _, r := range resp.Responses {
+			var key string
+			if kvs := r.GetResponseRange().Kvs; len(kvs) > 0 {
+				key = string(kvs[0].Value)
+			}
+			keys = append(keys, key)
+		}
+		ids = ids[n:]
+	}
+	return keys, nil
+}
+
+// ForceSet writes the id/key pair to the store even if read only. Used by replication.
+func (s *TranslateStore) ForceSet(id uint64, key string) error {
+	_, err := s.txn(nil, []clientv3.Op{
+		clientv3.OpPut(s.keyKey(key), string(u64tob(id))),
+		clientv3.OpPut(s.idKey(id), key),
+	})
+	return errors.Wrap(err, "setting key")
+}
+
+// EntryReader returns a reader of the entries in the store from offset on,
+// which waits for more to be written when it reaches the end.
+func (s *TranslateStore) EntryReader(ctx context.Context, offset uint64) (pilosa.TranslateEntryReader, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &TranslateEntryReader{ctx: ctx, cancel: cancel, store: s, offset: offset}, nil
+}
+
+// WriteTo writes the contents of the store to w, as a boltdb store.
+func (s *TranslateStore) WriteTo(w io.Writer) (int64, error) {
+	dir, err := os.MkdirTemp("", "etcdkeys")
+	if err != nil {
+		return 0, errors.Wrap(err, "creating temp dir")
+	}
+	defer os.RemoveAll(dir)
+
+	bs := boltdb.NewTranslateStore(s.index, s.field, s.partitionID, s.partitionN, false)
+	bs.Path = filepath.Join(dir, "keys")
+	if err := bs.Open(); err != nil {
+		return 0, errors.Wrap(err, "opening boltdb store")
+	}
+	defer bs.Close()
+
+	entries := make([]pilosa.TranslateEntry, 0, pageSize)
+	flush := func() error {
+		err := bs.ForceSetEntries(entries)
+		entries = entries[:0]
+		return err
+	}
+	if err := s.scan(context.Background(), 0, func(id uint64, key []byte) error {
+		entries = append(entries, pilosa.TranslateEntry{ID: id, Key: string(key)})
+		if len(entries) == cap(entries) {
+			return flush()
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	if err := flush(); err != nil {
+		return 0, errors.Wrap(err, "writing boltdb store")
+	}
+	free, err := s.FreeIDs()
+	if err != nil {
+		return 0, err
+	}
+	if err := bs.MergeFreeIDs(free); err != nil {
+		return 0, errors.Wrap(err, "writing free ids")
+	}
+	return bs.WriteTo(w)
+}
+
+// ReadFrom replaces the contents of the store with those of the boltdb
+// store read from r. The store isn't replaced atomically, so it must not be
+// in use.
+func (s *TranslateStore) ReadFrom(r io.Reader) (n int64, err error) {
+	f, err := os.CreateTemp("", "etcdkeys")
+	if err != nil {
+		return 0, errors.Wrap(err, "creating temp file")
+	}
+	defer os.Remove(f.Name())
+	n, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return n, errors.Wrap(err, "reading boltdb store")
+	}
+
+	bs := boltdb.NewTranslateStore(s.index, s.field, s.partitionID, s.partitionN, false)
+	bs.Path = f.Name()
+	if err := bs.Open(); err != nil {
+		return n, errors.Wrap(err, "opening boltdb store")
+	}
+	defer bs.Close()
+	ids, err := bs.Match(func([]byte) bool { return true })
+	if err != nil {
+		return n, errors.Wrap(err, "reading boltdb store ids")
+	}
+	free, err := bs.FreeIDs()
+	if err != nil {
+		return n, errors.Wrap(err, "reading boltdb store free ids")
+	}
+
+	if err := s.Drop(); err != nil {
+		return n, err
+	}
+	const batchN = txnOps / 2
+	for len(ids) > 0 {
+		m := len(ids)
+		if m > batchN {
+			m = batchN
+		}
+		keys, err := bs.TranslateIDs(ids[:m])
+		if err != nil {
+			return n, errors.Wrap(err, "reading boltdb store keys")
+		}
+		ops := make([]clientv3.Op, 0, 2*m)
+		for i, id := range ids[:m] {
+			ops = append(ops,
+				clientv3.OpPut(s.keyKey(keys[i]), string(u64tob(id))),
+				clientv3.OpPut(s.idKey(id), keys[i]),
+			)
+		}
+		if _, err := s.txn(nil, ops); err != nil {
+			return n, errors.Wrap(err, "writing keys")
+		}
+		ids = ids[m:]
+	}
+	if free.Any() {
+		buf, err := free.MarshalBinary()
+		if err != nil {
+			return n, errors.Wrap(err, "marshaling free ids")
+		}
+		if _, err := s.txn(nil, []clientv3.Op{clientv3.OpPut(s.freeKey(), string(buf))}); err != nil {
+			return n, errors.Wrap(err, "writing free ids")
+		}
+	}
+	return n, nil
+}
+
+// Delete removes the keys of records from the store when the returned
+// Commitor is committed, making their IDs free for reuse.
+func (s *TranslateStore) Delete(records *roaring.Bitmap) (pilosa.Commitor, error) {
+	return &deleteCommitor{store: s, records: records}, nil
+}
+
+// deleteCommitor deletes keys from a store when it's committed.
+type deleteCommitor struct {
+	store   *TranslateStore
+	records *roaring.Bitmap
+}
+
+func (c *deleteCommitor) Rollback() {}
+
+func (c *deleteCommitor) Commit() error {
+	s := c.store
+	ids := c.records.Slice()
+	for len(ids) > 0 {
+		n := len(ids)
+		if n > txnOps/2 {
+			n = txnOps / 2
+		}
+		keys, err := s.TranslateIDs(ids[:n])
+		if err != nil {
+			return err
+		}
+		ops := make([]clientv3.Op, 0, 2*n)
+		for i, id := range ids[:n] {
+			ops = append(ops, clientv3.OpDelete(s.keyKey(keys[i])), clientv3.OpDelete(s.idKey(id)))
+		}
+		if _, err := s.txn(nil, ops); err != nil {
+			return errors.Wrap(err, "deleting keys")
+		}
+		ids = ids[n:]
+	}
+
+	for attempts := 0; attempts < createAttempts; attempts++ {
+		free, rev, err := s.freeIDs()
+		if err != nil {
+			return err
+		}
+		buf, err := free.Union(c.records).MarshalBinary()
+		if err != nil {
+			return errors.Wrap(err, "marshaling free ids")
+		}
+		resp, err := s.txn(
+			[]clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(s.freeKey()), "=", rev)},
+			[]clientv3.Op{clientv3.OpPut(s.freeKey(), string(buf))},
+		)
+		if err != nil {
+			return errors.Wrap(err, "freeing ids")
+		} else if resp.Succeeded {
+			return nil
+		}
+	}
+	return ErrConflict
+}
+
+// TranslateEntryReader reads the entries of a store, waiting for more to be
+// written when it reaches the end.
+type TranslateEntryReader struct {
+	ctx    context.Context
+	cancel func()
+	store  *TranslateStore
+	offset uint64
+}
+
+// Close closes the reader.
+func (r *TranslateEntryReader) Close() error {
+	r.cancel()
+	return nil
+}
+
+// ReadEntry reads the next entry from the store.
+func (r *TranslateEntryReader) ReadEntry(entry *pilosa.TranslateEntry) error {
+	s := r.store
+	for {
+		resp, err := s.cli.Get(r.ctx, s.idKey(r.offset), clientv3.WithRange(clientv3.GetPrefixRangeEnd(s.idPrefix())), clientv3.WithLimit(1))
+		if err != nil {
+			if r.ctx.Err() != nil {
+				return r.ctx.Err()
+			}
+			return errors.Wrap(err, "reading entry")
+		}
+		if len(resp.Kvs) > 0 {
+			id, err := s.parseIDKey(resp.Kvs[0].Key)
+			if err != nil {
+				return err
+			}
+			entry.Index, entry.Field = s.index, s.field
+			entry.ID, entry.Key = id, string(resp.Kvs[0].Value)
+			r.offset = id + 1
+			return nil
+		}
+
+		// Wait for anything written after the read.
+		ctx, cancel := context.WithCancel(r.ctx)
+		watch := s.cli.Watch(ctx, s.idPrefix(), clientv3.WithPrefix(), clientv3.WithRev(resp.Header.Revision+1))
+		select {
+		case <-r.ctx.Done():
+			cancel()
+			return r.ctx.Err()
+		case <-watch:
+			cancel()
+		}
+	}
+}
+
+// u64tob encodes v to big endian encoding.
+func u64tob(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+// btou64 decodes b from big endian encoding.
+func btou64(b []byte) uint64 { return binary.BigEndian.Uint64(b) }
@@ -0,0 +1,285 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package etcdkeys_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pilosa "github.com/featurebasedb/featurebase/v3"
+	"github.com/featurebasedb/featurebase/v3/boltdb"
+	"github.com/featurebasedb/featurebase/v3/etcdkeys"
+	"github.com/featurebasedb/featurebase/v3/roaring"
+	"github.com/featurebasedb/featurebase/v3/testhook"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/types"
+	"go.etcd.io/etcd/server/v3/embed"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3client"
+)
+
+func TestTranslateStore_CreateKeys(t *testing.T) {
+	cli := mustStartEtcd(t)
+	s := etcdkeys.NewTranslateStore(cli, etcdkeys.DefaultPrefix, "i", "", 0, 16)
+
+	ids, err := s.CreateKeys("foo", "bar", "foo", "")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(ids) != 3 {
+		t.Fatalf("expected three keys, got %v", ids)
+	} else if ids["foo"] == ids["bar"] || ids["foo"] == ids[""] {
+		t.Fatalf("keys map to the same ID: %v", ids)
+	}
+
+	// Ensure retranslation returns original IDs, and new keys new ones.
+	again, err := s.CreateKeys("bar", "baz", "foo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"foo", "bar", ""} {
+		if again[key] != ids[key] {
+			t.Fatalf("key %q mapped to %d, previously %d", key, again[key], ids[key])
+		}
+	}
+
+	// Ensure keys and IDs translate both ways.
+	if found, err := s.FindKeys("foo", "baz", "qux"); err != nil {
+		t.Fatal(err)
+	} else if exp := map[string]uint64{"foo": ids["foo"], "baz": again["baz"]}; !reflect.DeepEqual(found, exp) {
+		t.Fatalf("found %v, expected %v", found, exp)
+	}
+	if keys, err := s.TranslateIDs([]uint64{ids["bar"], ids[""], again["baz"], 1 << 40}); err != nil {
+		t.Fatal(err)
+	} else if exp := []string{"bar", "", "baz", ""}; !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("translated %q, expected %q", keys, exp)
+	}
+	if maxID, err := s.MaxID(); err != nil {
+		t.Fatal(err)
+	} else if maxID != again["baz"] {
+		t.Fatalf("max ID %d, expected %d", maxID, again["baz"])
+	}
+	if matches, err := s.Match(func(key []byte) bool { return bytes.HasPrefix(key, []byte("ba")) }); err != nil {
+		t.Fatal(err)
+	} else if exp := []uint64{ids["bar"], again["baz"]}; !reflect.DeepEqual(matches, exp) {
+		t.Fatalf("matched %v, expected %v", matches, exp)
+	}
+
+	// Ensure read-only stores don't create keys.
+	s.SetReadOnly(true)
+	if _, err := s.CreateKeys("qux"); err != pilosa.ErrTranslateStoreReadOnly {
+		t.Fatalf("expected read-only error, got %v", err)
+	}
+
+	// Ensure stores of other partitions are separate.
+	other := etcdkeys.NewTranslateStore(cli, etcdkeys.DefaultPrefix, "i", "", 1, 16)
+	if found, err := other.FindKeys("foo"); err != nil {
+		t.Fatal(err)
+	} else if len(found) != 0 {
+		t.Fatalf("found keys of another partition: %v", found)
+	}
+}
+
+func TestTranslateStore_CreateKeys_Many(t *testing.T) {
+	cli := mustStartEtcd(t)
+	s := etcdkeys.NewTranslateStore(cli, etcdkeys.DefaultPrefix, "i", "f", -1, -1)
+
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key%d", i)
+	}
+	ids, err := s.CreateKeys(keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[uint64]bool)
+	for _, key := range keys {
+		id, ok := ids[key]
+		if !ok || seen[id] {
+			t.Fatalf("key %q has missing or duplicate ID %d", key, id)
+		}
+		seen[id] = true
+	}
+	if maxID, err := s.MaxID(); err != nil {
+		t.Fatal(err)
+	} else if maxID != uint64(len(keys)) {
+		t.Fatalf("max ID %d, expected %d", maxID, len(keys))
+	}
+}
+
+func TestTranslateStore_Delete(t *testing.T) {
+	cli := mustStartEtcd(t)
+	s := etcdkeys.NewTranslateStore(cli, etcdkeys.DefaultPrefix, "i", "f", -1, -1)
+
+	ids, err := s.CreateKeys("a", "b", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := s.Delete(roaring.NewBitmap(ids["b"]))
+	if err != nil {
+		t.Fatal(err)
+	} else if err := commit.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := s.FindKeys("a", "b", "c"); err != nil {
+		t.Fatal(err)
+	} else if _, ok := found["b"]; ok || len(found) != 2 {
+		t.Fatalf("found %v after deleting b", found)
+	}
+
+	// Ensure the freed ID is reused.
+	if created, err := s.CreateKeys("d"); err != nil {
+		t.Fatal(err)
+	} else if created["d"] != ids["b"] {
+		t.Fatalf("created d with ID %d, expected freed ID %d", created["d"], ids["b"])
+	}
+
+	if err := s.Drop(); err != nil {
+		t.Fatal(err)
+	} else if maxID, err := s.MaxID(); err != nil {
+		t.Fatal(err)
+	} else if maxID != 0 {
+		t.Fatalf("max ID %d after drop", maxID)
+	}
+}
+
+// Ensure stores can be copied to boltdb stores and back.
+func TestTranslateStore_WriteToReadFrom(t *testing.T) {
+	cli := mustStartEtcd(t)
+	s := etcdkeys.NewTranslateStore(cli, etcdkeys.DefaultPrefix, "i", "f", -1, -1)
+
+	ids, err := s.CreateKeys("a", "b", "", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := s.Delete(roaring.NewBitmap(ids["c"]))
+	if err != nil {
+		t.Fatal(err)
+	} else if err := commit.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := s.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	bs, err := boltdb.OpenTranslateStore(filepath.Join(t.TempDir(), "keys"), "i", "f", -1, -1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bs.Close()
+	if _, err := bs.ReadFrom(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := bs.FindKeys("a", "b", ""); err != nil {
+		t.Fatal(err)
+	} else if exp := map[string]uint64{"a": ids["a"], "b": ids["b"], "": ids[""]}; !reflect.DeepEqual(found, exp) {
+		t.Fatalf("boltdb store found %v, expected %v", found, exp)
+	}
+
+	buf.Reset()
+	if _, err := bs.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	dst := etcdkeys.NewTranslateStore(cli, etcdkeys.DefaultPrefix, "j", "f", -1, -1)
+	if _, err := dst.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if keys, err := dst.TranslateIDs([]uint64{ids["a"], ids["b"], ids[""], ids["c"]}); err != nil {
+		t.Fatal(err)
+	} else if exp := []string{"a", "b", "", ""}; !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("translated %q, expected %q", keys, exp)
+	}
+	if free, err := dst.FreeIDs(); err != nil {
+		t.Fatal(err)
+	} else if !free.Contains(ids["c"]) {
+		t.Fatalf("free IDs %v don't include %d", free.Slice(), ids["c"])
+	}
+}
+
+func TestTranslateStore_EntryReader(t *testing.T) {
+	cli := mustStartEtcd(t)
+	s := etcdkeys.NewTranslateStore(cli, etcdkeys.DefaultPrefix, "i", "f", -1, -1)
+
+	ids, err := s.CreateKeys("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	r, err := s.EntryReader(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var entry pilosa.TranslateEntry
+	if err := r.ReadEntry(&entry); err != nil {
+		t.Fatal(err)
+	} else if entry.ID != ids["a"] || entry.Key != "a" || entry.Field != "f" {
+		t.Fatalf("unexpected entry %#v", entry)
+	}
+
+	// Ensure the reader waits for entries written later.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		if _, err := s.CreateKeys("b"); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err := r.ReadEntry(&entry); err != nil {
+		t.Fatal(err)
+	} else if entry.Key != "b" {
+		t.Fatalf("unexpected entry %#v", entry)
+	}
+}
+
+var socketN uint64
+
+// mustStartEtcd starts an embedded etcd server for the test, and returns a
+// client of it.
+func mustStartEtcd(tb testing.TB) *clientv3.Client {
+	tb.Helper()
+	cfg := embed.NewConfig()
+	n := atomic.AddUint64(&socketN, 1)
+	clientURL := fmt.Sprintf("unix://etcdkeys-client:%d", n)
+	peerURL := fmt.Sprintf("unix://etcdkeys-peer:%d", n)
+	cfg.LPUrls = types.MustNewURLs([]string{peerURL})
+	cfg.APUrls = types.MustNewURLs([]string{peerURL})
+	cfg.LCUrls = types.MustNewURLs([]string{clientURL})
+	cfg.ACUrls = types.MustNewURLs([]string{clientURL})
+	cfg.InitialCluster = cfg.Name + "=" + peerURL
+	cfg.LogLevel = "error"
+
+	dir, err := testhook.TempDir(tb, "etcdkeys-*")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	cfg.Dir = dir
+	e, err := embed.StartEtcd(cfg)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(30 * time.Second):
+		e.Close()
+		tb.Fatal("etcd took too long to start")
+	}
+	cli := v3client.New(e.Server)
+	tb.Cleanup(func() {
+		cli.Close()
+		e.Close()
+		<-e.Server.StopNotify()
+		os.RemoveAll(dir)
+		os.Remove(strings.TrimPrefix(clientURL, "unix://"))
+		os.Remove(strings.TrimPrefix(peerURL, "unix://"))
+	})
+	return cli
+}
@@ -0,0 +1,250 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/logger"
+	"github.com/pkg/errors"
+)
+
+// Types of events posted to event webhooks. Schema events are sent by the
+// node which handled the change, and cluster events by the primary node,
+// so each change is reported once.
+const (
+	EventIndexCreated        = "index-created"
+	EventIndexDeleted        = "index-deleted"
+	EventFieldCreated        = "field-created"
+	EventFieldDeleted        = "field-deleted"
+	EventNodeJoined          = "node-joined"
+	EventNodeLeft            = "node-left"
+	EventClusterStateChanged = "cluster-state-changed"
+)
+
+// Event is a change to the schema or cluster, as posted to event webhooks.
+type Event struct {
+	Type    string    `json:"type"`
+	Time    time.Time `json:"time"`
+	Cluster string    `json:"cluster,omitempty"`
+
+	// Index and Field are set by schema events.
+	Index string `json:"index,omitempty"`
+	Field string `json:"field,omitempty"`
+
+	// Node is set by node events, State and PreviousState by cluster state
+	// events.
+	Node          string `json:"node,omitempty"`
+	State         string `json:"state,omitempty"`
+	PreviousState string `json:"previous-state,omitempty"`
+}
+
+const (
+	// eventQueueSize is how many events can wait to be posted before new
+	// ones are dropped.
+	eventQueueSize = 1024
+
+	// eventRetries is how many more times posting an event to a webhook is
+	// tried after it fails, waiting eventRetryDelay, doubled each time.
+	eventRetries    = 3
+	eventRetryDelay = time.Second
+
+	// eventPollInterval is how often the cluster is checked for changes.
+	eventPollInterval = time.Second
+)
+
+// eventNotifier posts events to webhooks in the background, in the order
+// they were published. A nil eventNotifier publishes nothing.
+type eventNotifier struct {
+	webhooks   []string
+	cluster    string
+	queue      chan Event
+	client     *http.Client
+	retryDelay time.Duration
+
+	logger logger.Logger
+}
+
+// newEventNotifier returns an eventNotifier posting to webhooks, or nil if
+// there are none.
+func newEventNotifier(webhooks []string, cluster string, logger logger.Logger) *eventNotifier {
+	if len(webhooks) == 0 {
+		return nil
+	}
+	return &eventNotifier{
+		webhooks:   webhooks,
+		cluster:    cluster,
+		queue:      make(chan Event, eventQueueSize),
+		client:     &http.Client{Timeout: 10 * time.Second},
+		retryDelay: eventRetryDelay,
+		logger:     logger,
+	}
+}
+
+// publish queues an event to be posted, without waiting. The event is
+// dropped if the queue is full.
+func (n *eventNotifier) publish(ev Event) {
+	if n == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	ev.Cluster = n.cluster
+	select {
+	case n.queue <- ev:
+	default:
+		n.logger.Errorf("event queue full, dropping %s event", ev.Type)
+	}
+}
+
+// run posts queued events until closing is closed.
+func (n *eventNotifier) run(closing <-chan struct{}) {
+	for {
+		select {
+		case <-closing:
+			return
+		case ev := <-n.queue:
+			for _, url := range n.webhooks {
+				if err := n.post(url, ev, closing); err != nil {
+					n.logger.Errorf("posting %s event to %s: %v", ev.Type, url, err)
+				}
+			}
+		}
+	}
+}
+
+// post posts an event to a webhook, retrying while it fails.
+func (n *eventNotifier) post(url string, ev Event, closing <-chan struct{}) error {
+	buf, err := json.Marshal(ev)
+	if err != nil {
+		return errors.Wrap(err, "encoding event")
+	}
+	delay := n.retryDelay
+	for i := 0; ; i++ {
+		if err = n.postOnce(url, buf); err == nil || i == eventRetries {
+			return err
+		}
+		select {
+		case <-closing:
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (n *eventNotifier) postOnce(url string, buf []byte) error {
+	resp, err := n.client.Post(url, "application/json", bytes.NewReader(buf))
+	if err != nil {
+		return errors.Wrap(err, "posting")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return nil
+}
+
+// clusterWatcher finds the cluster events between successive observations
+// of the cluster state and its nodes. A node has joined the cluster once
+// it's started, and has left once it's no longer started or gone.
+type clusterWatcher struct {
+	observed bool
+	state    disco.ClusterState
+	started  map[string]bool
+}
+
+// observe records the cluster state and nodes, returning the events since
+// they were last observed. Nothing is returned the first time.
+func (w *clusterWatcher) observe(state disco.ClusterState, nodes []*disco.Node) []Event {
+	started := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		if node.State == disco.NodeStateStarted {
+			started[node.ID] = true
+		}
+	}
+	defer func() {
+		w.observed, w.state, w.started = true, state, started
+	}()
+	if !w.observed {
+		return nil
+	}
+
+	var events []Event
+	for _, node := range nodes {
+		if started[node.ID] && !w.started[node.ID] {
+			events = append(events, Event{Type: EventNodeJoined, Node: node.ID})
+		}
+	}
+	var left []string
+	for id := range w.started {
+		if !started[id] {
+			left = append(left, id)
+		}
+	}
+	sort.Strings(left)
+	for _, id := range left {
+		events = append(events, Event{Type: EventNodeLeft, Node: id})
+	}
+	if state != w.state {
+		events = append(events, Event{
+			Type:          EventClusterStateChanged,
+			State:         string(state),
+			PreviousState: string(w.state),
+		})
+	}
+	return events
+}
+
+// monitorEvents posts events to the event webhooks, and watches the cluster
+// for changes to report while this is the primary node.
+func (s *Server) monitorEvents() {
+	if s.events == nil {
+		return
+	}
+	if ok := s.addToWaitGroup(1); !ok {
+		return
+	}
+	go func() { defer s.wg.Done(); s.events.run(s.closing) }()
+
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+	var w clusterWatcher
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+		}
+
+		// Every node watches the cluster so whichever becomes primary
+		// knows what's changed since its last look.
+		state, err := s.noder.ClusterState(context.Background())
+		if err != nil {
+			s.logger.Printf("failed to check cluster state for events: %v", err)
+			continue
+		}
+		events := w.observe(state, s.noder.Nodes())
+		if !s.IsPrimary() {
+			continue
+		}
+		for _, ev := range events {
+			s.events.publish(ev)
+		}
+	}
+}
+
+// publishEvent publishes an event about a change made through api.
+func (api *API) publishEvent(ev Event) {
+	if api.server != nil {
+		api.server.events.publish(ev)
+	}
+}
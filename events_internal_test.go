@@ -0,0 +1,106 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/logger"
+)
+
+func TestClusterWatcher(t *testing.T) {
+	nodes := func(states ...disco.NodeState) []*disco.Node {
+		var nodes []*disco.Node
+		for i, state := range states {
+			nodes = append(nodes, &disco.Node{ID: string(rune('a' + i)), State: state})
+		}
+		return nodes
+	}
+
+	var w clusterWatcher
+	if events := w.observe(disco.ClusterStateStarting, nodes(disco.NodeStateStarted, disco.NodeStateStarting)); events != nil {
+		t.Fatalf("unexpected events on first observation: %+v", events)
+	}
+	if events := w.observe(disco.ClusterStateStarting, nodes(disco.NodeStateStarted, disco.NodeStateStarting)); events != nil {
+		t.Fatalf("unexpected events without changes: %+v", events)
+	}
+
+	events := w.observe(disco.ClusterStateNormal, nodes(disco.NodeStateStarted, disco.NodeStateStarted, disco.NodeStateStarted))
+	exp := []Event{
+		{Type: EventNodeJoined, Node: "b"},
+		{Type: EventNodeJoined, Node: "c"},
+		{Type: EventClusterStateChanged, State: "NORMAL", PreviousState: "STARTING"},
+	}
+	if !reflect.DeepEqual(events, exp) {
+		t.Fatalf("expected %+v, got %+v", exp, events)
+	}
+
+	events = w.observe(disco.ClusterStateDegraded, nodes(disco.NodeStateStarted, disco.NodeStateUnknown))
+	exp = []Event{
+		{Type: EventNodeLeft, Node: "b"},
+		{Type: EventNodeLeft, Node: "c"},
+		{Type: EventClusterStateChanged, State: "DEGRADED", PreviousState: "NORMAL"},
+	}
+	if !reflect.DeepEqual(events, exp) {
+		t.Fatalf("expected %+v, got %+v", exp, events)
+	}
+}
+
+func TestEventNotifier(t *testing.T) {
+	var mu sync.Mutex
+	var attempts int
+	received := make(chan Event, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		fail := attempts == 1
+		mu.Unlock()
+		// Fail the first attempt, so it has to be retried.
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var ev Event
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("decoding event: %v", err)
+		}
+		received <- ev
+	}))
+	defer srv.Close()
+
+	if n := newEventNotifier(nil, "c", logger.NopLogger); n != nil {
+		t.Fatalf("expected no notifier without webhooks, got %+v", n)
+	}
+	n := newEventNotifier([]string{srv.URL}, "c", logger.NopLogger)
+	n.retryDelay = time.Millisecond
+	closing := make(chan struct{})
+	defer close(closing)
+	go n.run(closing)
+
+	n.publish(Event{Type: EventIndexCreated, Index: "i"})
+	n.publish(Event{Type: EventFieldCreated, Index: "i", Field: "f"})
+	for _, exp := range []Event{
+		{Type: EventIndexCreated, Cluster: "c", Index: "i"},
+		{Type: EventFieldCreated, Cluster: "c", Index: "i", Field: "f"},
+	} {
+		select {
+		case ev := <-received:
+			if ev.Time.IsZero() {
+				t.Fatalf("expected event time")
+			}
+			ev.Time = time.Time{}
+			if ev != exp {
+				t.Fatalf("expected %+v, got %+v", exp, ev)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for %s event", exp.Type)
+		}
+	}
+}
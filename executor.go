@@ -8,8 +8,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"math/bits"
 	"reflect"
+	"runtime"
 	"sort"
 	"strings"
 	"sync"
@@ -17,7 +19,6 @@ import (
 	"time"
 	"unsafe"
 
-	"github.com/lib/pq"
 	"github.com/featurebasedb/featurebase/v3/disco"
 	"github.com/featurebasedb/featurebase/v3/pql"
 	"github.com/featurebasedb/featurebase/v3/proto"
@@ -26,6 +27,7 @@ import (
 	"github.com/featurebasedb/featurebase/v3/task"
 	"github.com/featurebasedb/featurebase/v3/testhook"
 	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
@@ -65,7 +67,21 @@ type executor struct {
 	shutdown       chan struct{}
 	workers        *task.Pool
 	workerPoolSize int
-	work           chan job
+	work           *jobQueue
+
+	// Weights of the clients sharing the workers, by name.
+	clientWeights map[string]int
+
+	// Limits on concurrent batch and background queries.
+	admission            *queryAdmission
+	maxBatchQueries      int
+	maxBackgroundQueries int
+
+	// Number of workers reducing the results of a single query.
+	reduceWorkers int
+
+	// Health of the nodes, for routing shards to replicas.
+	router *nodeRouter
 
 	// track active mapperLocal tasks so we can ensure we don't close
 	// e.work while they're still waiting to send
@@ -73,6 +89,20 @@ type executor struct {
 
 	// Maximum per-request memory usage (Extract() only)
 	maxMemory int64
+
+	// Protects the limits which can be changed with setLimits.
+	limitsMu sync.RWMutex
+
+	// Default fallback for indexes which don't track existence.
+	existenceFallback string
+
+	// User-defined functions.
+	plugins *pluginRegistry
+
+	// spill holds the results spilled to disk, once the columns of an
+	// Extract take more than spillThreshold bytes.
+	spill          *spillDir
+	spillThreshold int64
 }
 
 // executorOption is a functional option type for pilosa.executor
@@ -92,6 +122,29 @@ func optExecutorWorkerPoolSize(size int) executorOption {
 	}
 }
 
+func optExecutorClientWeights(weights map[string]int) executorOption {
+	return func(e *executor) error {
+		e.clientWeights = weights
+		return nil
+	}
+}
+
+func optExecutorQueryAdmission(maxBatch, maxBackground int) executorOption {
+	return func(e *executor) error {
+		e.maxBatchQueries = maxBatch
+		e.maxBackgroundQueries = maxBackground
+		return nil
+	}
+}
+
+func optExecutorSpill(dir *spillDir, threshold int64) executorOption {
+	return func(e *executor) error {
+		e.spill = dir
+		e.spillThreshold = threshold
+		return nil
+	}
+}
+
 func optExecutorMaxMemory(v int64) executorOption {
 	return func(e *executor) error {
 		e.maxMemory = v
@@ -99,6 +152,20 @@ func optExecutorMaxMemory(v int64) executorOption {
 	}
 }
 
+func optExecutorExistenceFallback(fallback string) executorOption {
+	return func(e *executor) error {
+		e.existenceFallback = fallback
+		return nil
+	}
+}
+
+func optExecutorPlugins(plugins *pluginRegistry) executorOption {
+	return func(e *executor) error {
+		e.plugins = plugins
+		return nil
+	}
+}
+
 func emptyResult(c *pql.Call) interface{} {
 	switch c.Name {
 	case "Clear", "ClearRow":
@@ -121,6 +188,8 @@ func emptyResult(c *pql.Call) interface{} {
 func newExecutor(opts ...executorOption) *executor {
 	e := &executor{
 		workerPoolSize: 2,
+		reduceWorkers:  runtime.GOMAXPROCS(0),
+		router:         newNodeRouter(),
 		shutdown:       make(chan struct{}),
 	}
 	for _, opt := range opts {
@@ -133,7 +202,8 @@ func newExecutor(opts ...executorOption) *executor {
 	// workerPoolSize... any larger doesn't seem to have an effect in
 	// the few tests we've done at scale with concurrent query
 	// workloads. Possible that it could be smaller.
-	e.work = make(chan job, e.workerPoolSize)
+	e.work = newJobQueue(e.workerPoolSize, defaultMaxPrioritySkips, e.clientWeights)
+	e.admission = newQueryAdmission(e.maxBatchQueries, e.maxBackgroundQueries)
 	_ = testhook.Opened(NewAuditor(), e, nil)
 	e.workers = task.NewPool(e.workerPoolSize, e.doOneJob, e)
 	return e
@@ -151,9 +221,9 @@ func (e *executor) Close() error {
 	for atomic.LoadUint64(&e.activeMappers) > 0 {
 		time.Sleep(1 * time.Millisecond)
 	}
-	close(e.work)
+	e.work.close()
 	e.workers.Close()
-	return nil
+	return e.plugins.close()
 }
 
 // PoolSize is exported to let the task pool update us
@@ -202,9 +272,14 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 	}
 
 	// Verify that the number of writes do not exceed the maximum.
-	if e.MaxWritesPerRequest > 0 && nw > e.MaxWritesPerRequest {
+	if max := e.maxWritesPerRequest(); max > 0 && nw > max {
 		return resp, ErrTooManyWrites
 	}
+	if nw > 0 {
+		if err := e.Holder.checkIndexWritable(idx); err != nil {
+			return resp, err
+		}
+	}
 
 	// Default options.
 	if opt == nil {
@@ -212,7 +287,56 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 	}
 	// Default maximum memory, if not passed in.
 	if opt.MaxMemory == 0 && q.HasCall("Extract") {
-		opt.MaxMemory = e.maxMemory
+		opt.MaxMemory = e.maxQueryMemory()
+	}
+	// Shards which can't be reached are left out of queries allowing
+	// partial results, and reported.
+	var partial *partialResults
+	if opt.AllowPartial && !opt.Remote {
+		if nw > 0 {
+			return resp, NewBadRequestError(errors.New("queries which write can't allow partial results"))
+		}
+		partial = newPartialResults()
+		ctx = withPartialResults(ctx, partial)
+	}
+	// Default existence fallback, if not passed in.
+	if opt.ExistenceFallback == "" {
+		opt.ExistenceFallback = e.existenceFallback
+	}
+	if opt.ExistenceFallback != "" && idx.existenceField() == nil {
+		ctx = withExistenceFallback(ctx, opt.ExistenceFallback)
+		if !opt.Remote && usesExistence(q.Calls) {
+			resp.ExistenceFallback = opt.ExistenceFallback
+		}
+	}
+
+	if err := validateExecPath(opt.ExecPath); err != nil {
+		return resp, err
+	} else if opt.ExecPath != ExecPathCurrent {
+		ctx = withExecPath(ctx, opt.ExecPath)
+	}
+	if err := validateQueryHints(opt.Hints); err != nil {
+		return resp, err
+	}
+	ctx = withQueryHints(ctx, opt.Hints)
+	if opt.IgnoreResultLimits {
+		ctx = withIgnoreResultLimits(ctx)
+	}
+
+	// Queries are admitted by the coordinating node, and their shards are
+	// worked on by every node in order of priority.
+	class, err := queryPriorityClass(opt.Priority)
+	if err != nil {
+		return resp, err
+	}
+	ctx = withQueryPriority(ctx, class)
+	ctx = withQueryClient(ctx, opt.Client)
+	if !opt.Remote {
+		release, err := e.admitQuery(ctx, class)
+		if err != nil {
+			return resp, err
+		}
+		defer release()
 	}
 
 	if opt.Profile {
@@ -236,6 +360,8 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 
 	}
 	defer qcx.Abort()
+	qcx.describe(index, q)
+	ctx = withTrackedQcx(ctx, qcx)
 
 	results, err := e.execute(ctx, qcx, index, q, shards, opt)
 	if err != nil {
@@ -244,6 +370,9 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 		return resp, err
 	}
 	resp.Results = results
+	if partial != nil {
+		resp.Completeness = partial.completeness(index)
+	}
 
 	// Translate response objects from ids to keys, if necessary.
 	// No need to translate a remote call.
@@ -262,6 +391,14 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 		} else if err := validateQueryContext(ctx); err != nil {
 			return resp, err
 		}
+
+		if opt.IncludeRowMeta {
+			for i := range resp.Results {
+				if resp.Results[i], err = e.attachRowMeta(idx, resp.Results[i]); err != nil {
+					return resp, errors.Wrap(err, "attaching row metadata")
+				}
+			}
+		}
 	}
 	// Must copy out of Tx data before Commiting, because it will become invalid afterwards.
 	respSafeNoTxData := safeCopy(resp)
@@ -281,6 +418,9 @@ func safeCopy(resp QueryResponse) (out QueryResponse) {
 	out = QueryResponse{
 		Err:     resp.Err,     //  error
 		Profile: resp.Profile, //  *tracing.Profile
+
+		ExistenceFallback: resp.ExistenceFallback,
+		Completeness:      resp.Completeness,
 	}
 	// Results can contain *roaring.Bitmap, so need to copy from Tx mmap-ed memory.
 	for _, v := range resp.Results {
@@ -336,6 +476,10 @@ func safeCopy(resp QueryResponse) (out QueryResponse) {
 			out.Results = append(out.Results, x)
 		case *SortedRow:
 			out.Results = append(out.Results, x)
+		case ExtractedIDMatrixSorted:
+			out.Results = append(out.Results, x)
+		case *Histogram:
+			out.Results = append(out.Results, x)
 		default:
 			panic(fmt.Sprintf("handle %T here", v))
 		}
@@ -400,6 +544,19 @@ func (e *executor) handlePreCalls(ctx context.Context, qcx *Qcx, index string, c
 
 	// We set c to look like a normal call, and actually execute it:
 	c.Type = pql.PrecallNone
+	// A pre-call the same as one already computed reuses its result.
+	key := precallKey(ctx, index, c)
+	if valueidx, ok := opt.precalls[key]; ok && key != "" {
+		row := opt.EmbeddedData[valueidx]
+		c.Children = []*pql.Call{}
+		c.Name = "Precomputed"
+		c.Args = map[string]interface{}{"valueidx": valueidx}
+		c.Precomputed = make(map[uint64]interface{}, len(row.segments))
+		for _, segment := range row.segments {
+			c.Precomputed[segment.shard] = &Row{segments: []rowSegment{segment}}
+		}
+		return nil
+	}
 	// possibly override call index.
 	v, err := e.executeCall(ctx, qcx, index, c, shards, opt)
 	if err != nil {
@@ -410,6 +567,12 @@ func (e *executor) handlePreCalls(ctx context.Context, qcx *Qcx, index string, c
 	case *Row:
 		row = r
 	case SignedRow:
+		// Columns can't be negative, so rather than quietly leaving the
+		// negative values out, which would give a wrong answer, the query
+		// fails.
+		if n := r.Neg.Count(); n > 0 {
+			return SignedInputError{Call: c.Name, Field: callArgString(c, "field"), Negatives: n}
+		}
 		row = r.Pos
 	default:
 		return fmt.Errorf("precomputed call %s returned unexpected non-Row data: %T", c.Name, v)
@@ -417,6 +580,12 @@ func (e *executor) handlePreCalls(ctx context.Context, qcx *Qcx, index string, c
 	if err := ctx.Err(); err != nil {
 		return err
 	}
+	if key != "" && row != nil {
+		if opt.precalls == nil {
+			opt.precalls = make(map[string]int64)
+		}
+		opt.precalls[key] = int64(len(opt.EmbeddedData))
+	}
 	c.Children = []*pql.Call{}
 	c.Name = "Precomputed"
 	c.Args = map[string]interface{}{"valueidx": len(opt.EmbeddedData)}
@@ -443,6 +612,76 @@ func (e *executor) dumpPrecomputedCalls(ctx context.Context, c *pql.Call) {
 	c.Precomputed = nil
 }
 
+// executeBinding computes the result of a call bound to a name, over all
+// shards, and adds it to the data embedded in queries, recording its
+// position in bound.
+func (e *executor) executeBinding(ctx context.Context, qcx *Qcx, index string, b *pql.Binding, shards []uint64, opt *ExecOptions, bound map[string]int64, colTranslations map[string]map[string]uint64, rowTranslations map[string]map[string]map[string]uint64) error {
+	call := b.Call
+	if err := resolveRefs(call, bound, opt); err != nil {
+		return err
+	}
+	if !opt.Remote && !opt.PreTranslated {
+		translated, err := e.translateCall(call, index, colTranslations, rowTranslations)
+		if err != nil {
+			return errors.Wrap(err, "translating call")
+		}
+		if translated == nil {
+			// Nothing matches a key which doesn't exist.
+			bound[b.Name] = int64(len(opt.EmbeddedData))
+			opt.EmbeddedData = append(opt.EmbeddedData, NewRow())
+			return nil
+		}
+		call = translated
+	}
+
+	// The call is executed like any other global pre-call, which leaves
+	// it as a Precomputed call, and its result at the end of the
+	// embedded data.
+	call.Type = pql.PrecallGlobal
+	if err := e.handlePreCalls(ctx, qcx, index, call, shards, opt); err != nil {
+		return err
+	}
+	bound[b.Name] = int64(len(opt.EmbeddedData) - 1)
+	e.dumpPrecomputedCalls(ctx, call)
+	return nil
+}
+
+// resolveRefs replaces the references to bound results in c's children
+// with Precomputed calls for those results.
+func resolveRefs(c *pql.Call, bound map[string]int64, opt *ExecOptions) error {
+	for i, child := range c.Children {
+		if child.Name != pql.RefCallName {
+			if err := resolveRefs(child, bound, opt); err != nil {
+				return err
+			}
+			continue
+		}
+		name, _ := child.Args["name"].(string)
+		idx, ok := bound[name]
+		if !ok {
+			return NewBadRequestError(errors.Errorf("%s is not bound", name))
+		}
+		row := opt.EmbeddedData[idx]
+		ref := &pql.Call{
+			Name:        "Precomputed",
+			Args:        map[string]interface{}{"valueidx": idx},
+			Precomputed: make(map[uint64]interface{}, len(row.segments)),
+		}
+		for _, segment := range row.segments {
+			ref.Precomputed[segment.shard] = &Row{segments: []rowSegment{segment}}
+		}
+		c.Children[i] = ref
+	}
+	for _, arg := range c.Args {
+		if call, ok := arg.(*pql.Call); ok {
+			if err := resolveRefs(call, bound, opt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // handlePreCallChildren handles any pre-calls in the children of a given call.
 func (e *executor) handlePreCallChildren(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) error {
 	for i := range c.Children {
@@ -479,7 +718,15 @@ func (e *executor) execute(ctx context.Context, qcx *Qcx, index string, q *pql.Q
 	var colTranslations map[string]map[string]uint64            // colID := colTranslations[index][key]
 	var rowTranslations map[string]map[string]map[string]uint64 // rowID := rowTranslations[index][field][key]
 	if !opt.Remote {
-		cols, rows, err := e.preTranslate(ctx, index, q.Calls...)
+		calls := q.Calls
+		if len(q.Bindings) > 0 {
+			calls = make([]*pql.Call, 0, len(q.Bindings)+len(q.Calls))
+			for _, b := range q.Bindings {
+				calls = append(calls, b.Call)
+			}
+			calls = append(calls, q.Calls...)
+		}
+		cols, rows, err := e.preTranslate(ctx, index, opt.RejectNewKeys, calls...)
 		if err != nil {
 			return nil, err
 		}
@@ -488,6 +735,7 @@ func (e *executor) execute(ctx context.Context, qcx *Qcx, index string, q *pql.Q
 
 	needShards := false
 	if len(shards) == 0 {
+		needShards = len(q.Bindings) > 0
 		for _, call := range q.Calls {
 			if needsShards(call) {
 				needShards = true
@@ -508,7 +756,13 @@ func (e *executor) execute(ctx context.Context, qcx *Qcx, index string, q *pql.Q
 		}
 	}
 
+	limits := e.resultLimits(index, opt)
+
 	lastWasWrite := false
+	// Bound results are computed once, after the calls before them, and
+	// embedded in the calls referring to them.
+	bindings := q.Bindings
+	bound := make(map[string]int64, len(bindings))
 	// Execute each call serially.
 	results := make([]interface{}, 0, len(q.Calls))
 	for i, call := range q.Calls {
@@ -525,11 +779,24 @@ func (e *executor) execute(ctx context.Context, qcx *Qcx, index string, q *pql.Q
 		}
 
 		lastWasWrite = call.IsWrite()
+		// Pre-calls are only reused within a call, so they see the
+		// writes of the calls before it.
+		opt.precalls = nil
 
 		if err := validateQueryContext(ctx); err != nil {
 			return nil, err
 		}
 
+		for len(bindings) > 0 && bindings[0].Pos <= i {
+			if err := e.executeBinding(ctx, qcx, index, bindings[0], shards, opt, bound, colTranslations, rowTranslations); err != nil {
+				return nil, errors.Wrapf(err, "computing %s", bindings[0].Name)
+			}
+			bindings = bindings[1:]
+		}
+		if err := resolveRefs(call, bound, opt); err != nil {
+			return nil, err
+		}
+
 		// Apply call translation.
 		if !opt.Remote && !opt.PreTranslated {
 			translated, err := e.translateCall(call, index, colTranslations, rowTranslations)
@@ -551,8 +818,15 @@ func (e *executor) execute(ctx context.Context, qcx *Qcx, index string, q *pql.Q
 		// are valid column IDs. So we don't actually eat top-level
 		// pre calls.
 		if call.Name == "Count" {
-			// Handle count specially, skipping the level directly underneath it.
+			// Handle count specially, skipping the level directly underneath it,
+			// except to fill in the results of bound calls.
 			for _, child := range call.Children {
+				if child.Name == "Precomputed" {
+					if err := e.handlePreCalls(ctx, qcx, index, child, shards, opt); err != nil {
+						return nil, err
+					}
+					continue
+				}
 				err := e.handlePreCallChildren(ctx, qcx, index, child, shards, opt)
 				if err != nil {
 					return nil, err
@@ -571,11 +845,12 @@ func (e *executor) execute(ctx context.Context, qcx *Qcx, index string, q *pql.Q
 		// still need to handle them. Since everything else was
 		// already precomputed by handlePreCallChildren, though,
 		// we don't need this logic in executeCall.
+		callCtx := withSubexprCache(ctx, call)
 		newIndex := call.CallIndex()
 		if newIndex != "" && newIndex != index {
-			v, err = e.executeCall(ctx, qcx, newIndex, call, nil, opt)
+			v, err = e.executeCall(callCtx, qcx, newIndex, call, nil, opt)
 		} else {
-			v, err = e.executeCall(ctx, qcx, index, call, shards, opt)
+			v, err = e.executeCall(callCtx, qcx, index, call, shards, opt)
 		}
 		if err != nil {
 			return nil, err
@@ -586,6 +861,12 @@ func (e *executor) execute(ctx context.Context, qcx *Qcx, index string, q *pql.Q
 			v = vc
 		}
 
+		// Remote nodes may be running part of a call, so the columns of
+		// a row are only limited where the whole of it is.
+		if row, ok := v.(*Row); ok && row != nil && !opt.Remote && limits.MaxColumns > 0 && row.Count() > limits.MaxColumns {
+			return nil, newResultLimitError(index, call.Name, "columns", limits.MaxColumns)
+		}
+
 		results = append(results, v)
 		// Some Calls can have significant data associated with them
 		// that gets generated during processing, such as Precomputed
@@ -628,6 +909,16 @@ func (e *executor) preprocessQuery(ctx context.Context, qcx *Qcx, index string,
 			return c, nil
 		}
 
+		// Columns ordered by key are limited by the coordinator once
+		// they're translated, which only happens for the results of the
+		// whole query.
+		order, err := parseColumnOrder(c)
+		if err != nil {
+			return nil, err
+		} else if order.byKey(e.Holder.Index(index)) {
+			return c, nil
+		}
+
 		// Rewrite the All() w/ limit to Limit(All()).
 		c.Children = []*pql.Call{
 			{
@@ -637,11 +928,28 @@ func (e *executor) preprocessQuery(ctx context.Context, qcx *Qcx, index string,
 		c.Name = "Limit"
 		return c, nil
 
+	case "Sort":
+		// Resolving where to resume only needs doing once, before the
+		// call is sent to other nodes.
+		if !opt.Remote {
+			if err := e.resolveSortAfter(ctx, qcx, index, c, opt); err != nil {
+				return nil, err
+			}
+		}
+		fallthrough
+
 	default:
 		// Recurse through child calls.
 		out := make([]*pql.Call, len(c.Children))
 		var changed bool
 		for i, child := range c.Children {
+			if child.Name == "All" {
+				if order, err := parseColumnOrder(child); err != nil {
+					return nil, err
+				} else if order.byKey(e.Holder.Index(index)) && (order.hasLimit || order.offset > 0) {
+					return nil, NewBadRequestError(errors.New("All() ordered by key can only be limited at the top level of a query"))
+				}
+			}
 			res, err := e.preprocessQuery(ctx, qcx, index, child, shards, opt)
 			if err != nil {
 				return nil, err
@@ -669,11 +977,11 @@ func (e *executor) executeCall(ctx context.Context, qcx *Qcx, index string, c *p
 	} else if err := e.validateCallArgs(c); err != nil {
 		return nil, errors.Wrap(err, "validating args")
 	}
-	indexTag := "index:" + index
+	indexTags := e.Holder.indexTags(index)
 	metricName := "query_" + strings.ToLower(c.Name) + "_total"
 	statFn := func() {
 		if !opt.Remote {
-			e.Holder.Stats.CountWithCustomTags(metricName, 1, 1.0, []string{indexTag})
+			e.Holder.Stats.CountWithCustomTags(metricName, 1, 1.0, indexTags)
 		}
 	}
 
@@ -709,6 +1017,18 @@ func (e *executor) executeCall(ctx context.Context, qcx *Qcx, index string, c *p
 		statFn()
 		res, err := e.executeSum(ctx, qcx, index, c, shards, opt)
 		return res, errors.Wrap(err, "executeSum")
+	case "Avg":
+		statFn()
+		res, err := e.executeAvg(ctx, qcx, index, c, shards, opt)
+		return res, errors.Wrap(err, "executeAvg")
+	case "WeightedAvg":
+		statFn()
+		res, err := e.executeWeightedAvg(ctx, qcx, index, c, shards, opt)
+		return res, errors.Wrap(err, "executeWeightedAvg")
+	case "Histogram":
+		statFn()
+		res, err := e.executeHistogram(ctx, qcx, index, c, shards, opt)
+		return res, errors.Wrap(err, "executeHistogram")
 	case "Min":
 		statFn()
 		res, err := e.executeMin(ctx, qcx, index, c, shards, opt)
@@ -790,6 +1110,9 @@ func (e *executor) executeCall(ctx context.Context, qcx *Qcx, index string, c *p
 	case "UnionRows":
 		res, err := e.executeUnionRows(ctx, qcx, index, c, shards, opt)
 		return res, errors.Wrap(err, "executeUnionRows")
+	case "IntersectRows":
+		res, err := e.executeIntersectRows(ctx, qcx, index, c, shards, opt)
+		return res, errors.Wrap(err, "executeIntersectRows")
 	case "ConstRow":
 		res, err := e.executeConstRow(ctx, index, c)
 		return res, errors.Wrap(err, "executeConstRow")
@@ -799,6 +1122,10 @@ func (e *executor) executeCall(ctx context.Context, qcx *Qcx, index string, c *p
 	case "Percentile":
 		res, err := e.executePercentile(ctx, qcx, index, c, shards, opt)
 		return res, errors.Wrap(err, "executePercentile")
+	case "CountTrue", "CountFalse", "CountNull":
+		statFn()
+		res, err := e.executeCountBool(ctx, qcx, index, c, shards, opt)
+		return res, errors.Wrap(err, "executeCountBool")
 	case "Delete":
 		statFn() // TODO(twg) need this?
 		res, err := e.executeDeleteRecords(ctx, qcx, index, c, shards, opt)
@@ -806,6 +1133,10 @@ func (e *executor) executeCall(ctx context.Context, qcx *Qcx, index string, c *p
 	case "Sort":
 		res, err := e.executeSort(ctx, qcx, index, c, shards, opt)
 		return res, errors.Wrap(err, "executeSort")
+	case "UDF":
+		statFn()
+		res, err := e.executeUDF(ctx, qcx, index, c, shards, opt)
+		return res, errors.Wrap(err, "executeUDF")
 	default: // e.g. "Row", "Union", "Intersect" or anything that returns a bitmap.
 		statFn()
 		res, err := e.executeBitmapCall(ctx, qcx, index, c, shards, opt)
@@ -844,7 +1175,7 @@ func (e *executor) validateTimeCallArgs(c *pql.Call, indexName string) error {
 	_, to := c.Args["to"]
 	field := e.Holder.Field(indexName, f)
 	tq := field.TimeQuantum()
-	if (from || to) && tq == "" {
+	if (from || to) && tq == "" && field.options.History == "" {
 		return fmt.Errorf("field %s is not a time-field, 'from' and 'to' are not valid options for this field type", f)
 
 	}
@@ -872,6 +1203,21 @@ func (e *executor) executeOptionsCall(ctx context.Context, qcx *Qcx, index strin
 			return nil, errors.New("Query(): shards must be a list of unsigned integers")
 		}
 	}
+	shards, err := e.targetShards(ctx, index, c, shards)
+	if err != nil {
+		return nil, err
+	}
+	// Timestamps are converted to timeUnit with the rest of the results.
+	if unit, _, err := c.StringArg("timeUnit"); err != nil {
+		return nil, errors.Wrap(err, "reading timeUnit")
+	} else if unit != "" && !IsValidTimeUnit(unit) {
+		return nil, NewBadRequestError(errors.Errorf("invalid time unit %q", unit))
+	}
+	hints, err := queryHintsArg(c)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withQueryHints(ctx, hints)
 	return e.executeCall(ctx, qcx, index, c.Children[0], shards, optCopy)
 }
 
@@ -1026,49 +1372,7 @@ func (e *executor) executeLimitCall(ctx context.Context, qcx *Qcx, index string,
 		return nil, errors.Errorf("expected Row but got %T", result)
 	}
 
-	if offset != 0 {
-		i := 0
-		var leadingBits []uint64
-		for i < len(result.segments) && offset > 0 {
-			seg := result.segments[i]
-			count := seg.Count()
-			if count > offset {
-				data := seg.Columns()
-				data = data[offset:]
-				leadingBits = data
-				i++
-				break
-			}
-
-			offset -= count
-			i++
-		}
-		row := NewRow(leadingBits...)
-		row.Merge(&Row{segments: result.segments[i:]})
-		result = row
-	}
-	if limit < result.Count() {
-		i := 0
-		var trailingBits []uint64
-		for i < len(result.segments) && limit > 0 {
-			seg := result.segments[i]
-			count := seg.Count()
-			if count > limit {
-				data := seg.Columns()
-				data = data[:limit]
-				trailingBits = data
-				break
-			}
-
-			limit -= count
-			i++
-		}
-		row := NewRow(trailingBits...)
-		row.Merge(&Row{segments: result.segments[:i]})
-		result = row
-	}
-
-	return result, nil
+	return result.sliceColumns(offset, limit, false), nil
 }
 
 // executeIncludesColumnCallShard
@@ -1101,6 +1405,11 @@ func (e *executor) executeSum(ctx context.Context, qcx *Qcx, index string, c *pq
 		return ValCount{}, errors.New("Sum() only accepts a single bitmap input")
 	}
 
+	precision, err := sumPrecision(c)
+	if err != nil {
+		return ValCount{}, err
+	}
+
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
 		return e.executeSumCountShard(ctx, qcx, index, c, nil, shard)
@@ -1129,11 +1438,16 @@ func (e *executor) executeSum(ctx context.Context, qcx *Qcx, index string, c *pq
 		if field == nil {
 			return ValCount{}, newNotFoundError(ErrFieldNotFound, fieldName)
 		}
+		if err := other.checkSumPrecision(fieldName, precision); err != nil {
+			return ValCount{}, err
+		}
 		if field.Type() == FieldTypeDecimal {
-			dec := pql.NewDecimal(other.Val, field.Options().Scale)
+			dec := pql.Decimal{Scale: field.Options().Scale}
+			dec.SetBigIntValue(other.sum())
 			other.DecimalVal = &dec
 			other.FloatVal = 0
 			other.Val = 0
+			other.BigVal = nil
 		}
 	}
 
@@ -1153,33 +1467,46 @@ func (e *executor) executeDistinct(ctx context.Context, qcx *Qcx, index string,
 		return SignedRow{}, fmt.Errorf("missing field option in Distinct query")
 	}
 
+	// Only the values up to offset+limit can make it into the result, so
+	// each shard's values, and the values merged so far, are cut down to
+	// those; the offset is only skipped once all the values are merged.
+	limit, hasLimit, err := c.UintArg("limit")
+	if err != nil {
+		return SignedRow{}, errors.Wrap(err, "reading limit option in Distinct query")
+	}
+	offset, hasOffset, err := c.UintArg("offset")
+	if err != nil {
+		return SignedRow{}, errors.Wrap(err, "reading offset option in Distinct query")
+	}
+	if !hasLimit {
+		limit = math.MaxUint64
+	}
+	keep := offset + limit
+	if keep < offset {
+		keep = math.MaxUint64
+	}
+	if hasLimit || hasOffset {
+		if f := e.Holder.Field(index, field); f != nil && f.Options().Type == FieldTypeTimestamp {
+			return SignedRow{}, NewBadRequestError(errors.New("Distinct(): limit and offset aren't supported on timestamp fields"))
+		}
+	}
+
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
-		return e.executeDistinctShard(ctx, qcx, index, field, c, shard)
+		result, err := e.executeDistinctShard(ctx, qcx, index, field, c, shard)
+		if err != nil || !hasLimit {
+			return result, err
+		}
+		return pageDistinct(result, 0, keep), nil
 	}
 
 	// Merge returned results at coordinating node.
 	reduceFn := func(ctx context.Context, prev, v interface{}) interface{} {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
-		switch other := prev.(type) {
-		case SignedRow:
-			return other.union(v.(SignedRow))
-		case *Row:
-			if other == nil {
-				return v
-			} else if v.(*Row) == nil {
-				return other
-			}
-			return other.Union(v.(*Row))
-		case nil:
-			return v
-		case DistinctTimestamp:
-			return other.Union(v.(DistinctTimestamp))
-		default:
-			return errors.Errorf("unexpected return type from executeDistinctShard: %+v %T", other, other)
+		result := reduceDistinct(ctx, prev, v)
+		if _, ok := result.(error); ok || !hasLimit {
+			return result
 		}
+		return pageDistinct(result, 0, keep)
 	}
 
 	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
@@ -1187,12 +1514,56 @@ func (e *executor) executeDistinct(ctx context.Context, qcx *Qcx, index string,
 		return nil, errors.Wrap(err, "mapReduce")
 	}
 
+	if (hasLimit || hasOffset) && !opt.Remote {
+		result = pageDistinct(result, offset, limit)
+	}
 	if other, ok := result.(SignedRow); ok {
 		other.field = field
 	}
 	return result, nil
 }
 
+// pageDistinct returns up to limit of the values of the result of a
+// Distinct() call, skipping the offset smallest.
+func pageDistinct(result interface{}, offset, limit uint64) interface{} {
+	switch r := result.(type) {
+	case SignedRow:
+		return r.page(offset, limit)
+	case *Row:
+		if r == nil {
+			return r
+		}
+		page := r.sliceColumns(offset, limit, false)
+		page.Index, page.Field = r.Index, r.Field
+		return page
+	}
+	return result
+}
+
+// reduceDistinct merges the results of a Distinct() call.
+func reduceDistinct(ctx context.Context, prev, v interface{}) interface{} {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	switch other := prev.(type) {
+	case SignedRow:
+		return other.union(v.(SignedRow))
+	case *Row:
+		if other == nil {
+			return v
+		} else if v.(*Row) == nil {
+			return other
+		}
+		return other.Union(v.(*Row))
+	case nil:
+		return v
+	case DistinctTimestamp:
+		return other.Union(v.(DistinctTimestamp))
+	default:
+		return errors.Errorf("unexpected return type from executeDistinctShard: %+v %T", other, other)
+	}
+}
+
 // executeMin executes a Min() call.
 func (e *executor) executeMin(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (_ ValCount, err error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeMin")
@@ -1487,13 +1858,13 @@ func (e *executor) executeBitmapCall(ctx context.Context, qcx *Qcx, index string
 	span.LogKV("pqlCallName", c.Name)
 	defer span.Finish()
 
-	indexTag := "index:" + index
+	indexTags := e.Holder.indexTags(index)
 	metricName := "query_" + strings.ToLower(c.Name) + "_total"
 	if c.Name == "Row" && c.HasConditionArg() {
 		metricName = "query_row_bsi_total"
 	}
 	if !opt.Remote {
-		e.Holder.Stats.CountWithCustomTags(metricName, 1, 1.0, []string{indexTag})
+		e.Holder.Stats.CountWithCustomTags(metricName, 1, 1.0, indexTags)
 	}
 
 	// Execute calls in bulk on each remote node and merge.
@@ -1534,6 +1905,27 @@ func (e *executor) executeBitmapCallShard(ctx context.Context, qcx *Qcx, index s
 	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeBitmapCallShard")
 	defer span.Finish()
 
+	// Subtrees repeated within the call being executed are computed once
+	// per shard.
+	return subexprCacheFromContext(ctx).row(c, shard, func() (*Row, error) {
+		return e.computeBitmapCallShard(ctx, qcx, index, c, shard)
+	})
+}
+
+// computeBitmapCallShard computes a bitmap call on a single shard.
+func (e *executor) computeBitmapCallShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (*Row, error) {
+	// Skip shards which can't match, according to their metadata. Writes
+	// made earlier in this query aren't reflected in the metadata yet.
+	if qcx != nil && !qcx.write && !hasQueryHint(ctx, HintNoShardPrune) {
+		if idx := e.Holder.Index(index); idx != nil {
+			if ok, err := e.shardMayMatch(ctx, idx, c, shard); err != nil {
+				return nil, errors.Wrap(err, "checking shard metadata")
+			} else if !ok {
+				return NewRow(), nil
+			}
+		}
+	}
+
 	switch c.Name {
 	case "Row", "Range":
 		return e.executeRowShard(ctx, qcx, index, c, shard)
@@ -1543,8 +1935,8 @@ func (e *executor) executeBitmapCallShard(ctx context.Context, qcx *Qcx, index s
 		return e.executeIntersectShard(ctx, qcx, index, c, shard)
 	case "Union":
 		return e.executeUnionShard(ctx, qcx, index, c, shard)
-	case "InnerUnionRows":
-		return e.executeInnerUnionRowsShard(ctx, qcx, index, c, shard)
+	case "InnerUnionRows", "InnerIntersectRows":
+		return e.executeInnerRowsShard(ctx, qcx, index, c, shard)
 	case "Xor":
 		return e.executeXorShard(ctx, qcx, index, c, shard)
 	case "Not":
@@ -1557,6 +1949,10 @@ func (e *executor) executeBitmapCallShard(ctx context.Context, qcx *Qcx, index s
 		return nil, errors.New("Distinct shouldn't be hit as a bitmap call")
 	case "Precomputed":
 		return e.executePrecomputedCallShard(ctx, qcx, index, c, shard)
+	case "CardinalityOf":
+		return e.executeCardinalityOfShard(ctx, qcx, index, c, shard)
+	case "UDF":
+		return e.executeUDFShard(ctx, qcx, index, c, shard)
 	default:
 		return nil, fmt.Errorf("unknown call: %s", c.Name)
 	}
@@ -1780,17 +2176,26 @@ func executeDistinctShardSet(ctx context.Context, qcx *Qcx, idx *Index, fieldNam
 }
 
 func executeDistinctShardBSI(ctx context.Context, qcx *Qcx, idx *Index, fieldName string, shard uint64, bsig *bsiGroup, filterBitmap *roaring.Bitmap) (result SignedRow, err0 error) {
-	view := viewBSIGroupPrefix + fieldName
-	index := idx.Name()
-	depth := uint64(bsig.BitDepth)
-	offset := bsig.Base
-
 	tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Shard: shard})
 	if err != nil {
 		return SignedRow{}, err
 	}
 	defer finisher(&err0)
 
+	// Without a filter, a field tracking its distinct values has them
+	// already.
+	if field := idx.Field(fieldName); field != nil && field.options.TrackDistinct && filterBitmap == nil {
+		return trackedDistinct(tx, idx.Name(), fieldName, shard, bsig.Base)
+	}
+	return bsiDistinct(tx, idx.Name(), fieldName, shard, uint64(bsig.BitDepth), bsig.Base, filterBitmap)
+}
+
+// bsiDistinct returns the distinct values, plus offset, which the columns
+// in filterBitmap (or all columns, if it's nil) have in a shard of a BSI
+// field.
+func bsiDistinct(tx Tx, index, fieldName string, shard uint64, depth uint64, offset int64, filterBitmap *roaring.Bitmap) (result SignedRow, err error) {
+	view := viewBSIGroupPrefix + fieldName
+
 	existsBitmap, err := tx.OffsetRange(index, fieldName, view, shard, ShardWidth*shard, ShardWidth*0, ShardWidth*1)
 	if err != nil {
 		switch errors.Cause(err) {
@@ -1894,7 +2299,7 @@ func executeDistinctShardBSI(ctx context.Context, qcx *Qcx, idx *Index, fieldNam
 		Neg: NewRowFromBitmap(negBitmap),
 		Pos: NewRowFromBitmap(posBitmap),
 	}
-	result.Neg.Index, result.Pos.Index = idx.Name(), idx.Name()
+	result.Neg.Index, result.Pos.Index = index, index
 	result.Neg.Field, result.Pos.Field = fieldName, fieldName
 	return result, nil
 }
@@ -1950,13 +2355,15 @@ func (e *executor) executeSumCountShard(ctx context.Context, qcx *Qcx, index str
 	if err != nil {
 		return ValCount{}, errors.Wrap(err, "computing sum")
 	}
+	vsum.Add(vsum, new(big.Int).Mul(new(big.Int).SetUint64(vcount), big.NewInt(bsig.Base)))
 	out := ValCount{
-		Val:   int64(vsum) + (int64(vcount) * bsig.Base),
 		Count: int64(vcount),
 	}
+	out.setSum(vsum)
 	if field.Type() == FieldTypeDecimal {
-		out.FloatVal = float64(int64(vsum)+(int64(vcount)*bsig.Base)) / math.Pow(10, float64(bsig.Scale))
-		dec := pql.NewDecimal((int64(vsum) + (int64(vcount) * bsig.Base)), bsig.Scale)
+		out.FloatVal, _ = new(big.Float).Quo(new(big.Float).SetInt(vsum), big.NewFloat(math.Pow(10, float64(bsig.Scale)))).Float64()
+		dec := pql.Decimal{Scale: bsig.Scale}
+		dec.SetBigIntValue(vsum)
 		out.DecimalVal = &dec
 	}
 	return out, nil
@@ -2014,6 +2421,34 @@ func (e *executor) executeMaxShard(ctx context.Context, qcx *Qcx, index string,
 	return field.MaxForShard(qcx, shard, filter)
 }
 
+// executeExtremeShardFiltered calculates the min or max of a Min or Max
+// aggregate of a GroupBy within a group on a shard.
+func (e *executor) executeExtremeShardFiltered(ctx context.Context, qcx *Qcx, index string, c *pql.Call, filter *Row, shard uint64) (ValCount, error) {
+	if len(c.Children) == 1 {
+		row, err := e.executeBitmapCallShard(ctx, qcx, index, c.Children[0], shard)
+		if err != nil {
+			return ValCount{}, err
+		}
+		filter = filter.Intersect(row)
+	}
+
+	fieldName, err := c.FirstStringArg("field", "_field")
+	if err != nil {
+		return ValCount{}, errors.Wrapf(err, "%s(): field required", c.Name)
+	}
+
+	field := e.Holder.Field(index, fieldName)
+	if field == nil {
+		return ValCount{}, ErrFieldNotFound
+	}
+	switch field.Type() {
+	case FieldTypeInt, FieldTypeTimestamp:
+	default:
+		return ValCount{}, NewBadRequestError(errors.Errorf("GroupBy() only supports %s aggregates on int and timestamp fields, not %s", c.Name, field.Type()))
+	}
+	return field.extremeForShard(qcx, shard, filter, c.Name == "Max")
+}
+
 // executeMinRowShard returns the minimum row ID for a shard.
 func (e *executor) executeMinRowShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (_ PairField, err0 error) {
 	var filter *Row
@@ -2181,11 +2616,15 @@ func (e *executor) executeTopKShard(ctx context.Context, qcx *Qcx, index string,
 	if f == nil {
 		return nil, ErrFieldNotFound
 	}
-
+	loc, err := callLocation(c, f)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse "from" time, if set.
 	var fromTime time.Time
 	if v, ok := c.Args["from"]; ok {
-		if fromTime, err = parseTime(v); err != nil {
+		if fromTime, err = parseTimeIn(v, loc); err != nil {
 			return nil, errors.Wrap(err, "parsing from time")
 		}
 	}
@@ -2193,7 +2632,7 @@ func (e *executor) executeTopKShard(ctx context.Context, qcx *Qcx, index string,
 	// Parse "to" time, if set.
 	var toTime time.Time
 	if v, ok := c.Args["to"]; ok {
-		if toTime, err = parseTime(v); err != nil {
+		if toTime, err = parseTimeIn(v, loc); err != nil {
 			return nil, errors.Wrap(err, "parsing to time")
 		}
 	}
@@ -2539,6 +2978,16 @@ func (e *executor) executeTopN(ctx context.Context, qcx *Qcx, index string, c *p
 		return nil, fmt.Errorf("executeTopN: %v", err)
 	}
 
+	if exact, _, err := c.BoolArg("exact"); err != nil {
+		return nil, fmt.Errorf("executeTopN: %v", err)
+	} else if exact {
+		return e.executeTopNExact(ctx, qcx, index, c, shards, opt)
+	} else if _, ok := c.Args["tanimotoThreshold"]; !ok && hasQueryHint(ctx, HintForceExactTopN) {
+		c = c.Clone()
+		c.Args["exact"] = true
+		return e.executeTopNExact(ctx, qcx, index, c, shards, opt)
+	}
+
 	// Execute original query.
 	pairs, err := e.executeTopNShards(ctx, qcx, index, c, shards, opt)
 	if err != nil {
@@ -2551,6 +3000,7 @@ func (e *executor) executeTopN(ctx context.Context, qcx *Qcx, index string, c *p
 		return &PairsField{
 			Pairs: pairs.Pairs,
 			Field: fieldName,
+			Stale: pairs.Stale,
 		}, nil
 	}
 	// Only the original caller should refetch the full counts.
@@ -2570,9 +3020,119 @@ func (e *executor) executeTopN(ctx context.Context, qcx *Qcx, index string, c *p
 		trimmedList.Pairs = trimmedList.Pairs[0:n]
 	}
 
+	// The full counts are exact, but the rows were chosen from the first
+	// pass's rankings.
 	return &PairsField{
 		Pairs: trimmedList.Pairs,
 		Field: fieldName,
+		Stale: pairs.Stale,
+	}, nil
+}
+
+// executeTopNExact executes a TopN() call with exact=true, which ranks rows
+// by their exact counts rather than by the ranked caches.
+//
+// Counting every row of every shard at the coordinator would be expensive,
+// so this uses a three phase threshold algorithm. First, every shard
+// reports its own top n rows, whose summed counts give a lower bound, tau,
+// on the count of the n'th row overall. Any row whose count reaches tau
+// must have at least tau/shards columns in some shard, so next every shard
+// reports every row with that many columns. Finally, the exact counts of
+// those candidates are fetched from every shard.
+func (e *executor) executeTopNExact(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (*PairsField, error) {
+	fieldName, _ := c.Args["_field"].(string)
+	n, _, err := c.UintArg("n")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNExact: %v", err)
+	}
+	idsArg, _, err := c.UintSliceArg("ids")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNExact: %v", err)
+	}
+	minThreshold, _, err := c.UintArg("threshold")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNExact: %v", err)
+	}
+	if _, ok := c.Args["tanimotoThreshold"]; ok {
+		return nil, errors.New("TopN() can't use tanimotoThreshold with exact")
+	}
+
+	// A remote node only executes the phase it was sent, as does a query for
+	// specific rows, whose counts are all exact.
+	if opt.Remote || len(idsArg) > 0 {
+		pairs, err := e.executeTopNShards(ctx, qcx, index, c, shards, opt)
+		if err != nil {
+			return nil, err
+		}
+		pairs.Field = fieldName
+		return pairs, nil
+	}
+
+	// phase runs a pass over every shard with per-shard options, and returns
+	// the summed counts. The threshold given to the query applies to the
+	// total count of each row, not to each shard.
+	phase := func(n, threshold uint64, ids []uint64) ([]Pair, error) {
+		other := c.Clone()
+		other.Args["n"] = n
+		other.Args["threshold"] = threshold
+		if ids != nil {
+			other.Args["ids"] = ids
+		}
+		pairs, err := e.executeTopNShards(ctx, qcx, index, other, shards, opt)
+		if err != nil {
+			return nil, err
+		}
+		return pairs.Pairs, nil
+	}
+
+	var pairs []Pair
+	if n == 0 || len(shards) < 2 {
+		// Every row has to be counted anyway.
+		if pairs, err = phase(n, 1, nil); err != nil {
+			return nil, errors.Wrap(err, "counting rows")
+		}
+	} else {
+		if pairs, err = phase(n, 1, nil); err != nil {
+			return nil, errors.Wrap(err, "finding top rows of each shard")
+		}
+		// If there are fewer than n rows, each shard reported all of its
+		// rows, so the counts are already exact.
+		if uint64(len(pairs)) >= n {
+			sortPairsExact(pairs)
+			tau := pairs[n-1].Count
+			perShard := (tau + uint64(len(shards)) - 1) / uint64(len(shards))
+			if pairs, err = phase(0, perShard, nil); err != nil {
+				return nil, errors.Wrap(err, "finding candidate rows")
+			}
+			// With a threshold of one, every shard reported all of its
+			// rows, so again the counts are already exact.
+			if perShard > 1 && len(pairs) > 0 {
+				ids := Pairs(pairs).Keys()
+				sort.Sort(uint64Slice(ids))
+				if pairs, err = phase(0, 1, ids); err != nil {
+					return nil, errors.Wrap(err, "counting candidate rows")
+				}
+			}
+		}
+	}
+
+	// Apply the threshold to the total counts, and keep the top n.
+	if minThreshold > 1 {
+		kept := pairs[:0]
+		for _, pair := range pairs {
+			if pair.Count >= minThreshold {
+				kept = append(kept, pair)
+			}
+		}
+		pairs = kept
+	}
+	sortPairsExact(pairs)
+	if n != 0 && uint64(len(pairs)) > n {
+		pairs = pairs[:n]
+	}
+	return &PairsField{
+		Pairs: pairs,
+		Field: fieldName,
 	}, nil
 }
 
@@ -2598,6 +3158,7 @@ func (e *executor) executeTopNShards(ctx context.Context, qcx *Qcx, index string
 			return err
 		}
 		other.Pairs = Pairs(other.Pairs).Add(vpf.Pairs)
+		other.Stale = other.Stale || vpf.Stale
 		return other
 	}
 
@@ -2638,6 +3199,10 @@ func (e *executor) executeTopNShard(ctx context.Context, qcx *Qcx, index string,
 	if err != nil {
 		return nil, fmt.Errorf("executeTopNShard: %v", err)
 	}
+	exact, _, err := c.BoolArg("exact")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNShard: %v", err)
+	}
 
 	// Retrieve bitmap used to intersect.
 	var src *Row
@@ -2659,7 +3224,7 @@ func (e *executor) executeTopNShard(ctx context.Context, qcx *Qcx, index string,
 	f := e.Holder.fragment(index, fieldName, viewStandard, shard)
 	if f == nil {
 		return &PairsField{}, nil
-	} else if f.CacheType == CacheTypeNone {
+	} else if f.CacheType == CacheTypeNone && !exact {
 		return nil, fmt.Errorf("cannot compute TopN(), field has no cache: %q", fieldName)
 	}
 
@@ -2678,6 +3243,19 @@ func (e *executor) executeTopNShard(ctx context.Context, qcx *Qcx, index string,
 	}
 	defer finisher(&err0)
 
+	if exact {
+		pairs, err := f.topExact(ctx, tx, topOptions{
+			N:            int(n),
+			Src:          src,
+			RowIDs:       rowIDs,
+			MinThreshold: minThreshold,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "getting exact top")
+		}
+		return &PairsField{Pairs: pairs}, nil
+	}
+
 	pairs, err := f.top(tx, topOptions{
 		N:                 int(n),
 		Src:               src,
@@ -2691,6 +3269,7 @@ func (e *executor) executeTopNShard(ctx context.Context, qcx *Qcx, index string,
 
 	return &PairsField{
 		Pairs: pairs,
+		Stale: len(rowIDs) == 0 && f.cacheStale(),
 	}, nil
 }
 
@@ -2725,8 +3304,9 @@ func (e *executor) executeDifferenceShard(ctx context.Context, qcx *Qcx, index s
 // Row query which returns `Columns` and `Keys`.
 // TODO: Rename this to something better. Anything.
 type RowIdentifiers struct {
-	Rows  []uint64 `json:"rows"`
-	Keys  []string `json:"keys,omitempty"`
+	Rows  []uint64          `json:"rows"`
+	Keys  []string          `json:"keys,omitempty"`
+	Meta  []json.RawMessage `json:"meta,omitempty"`
 	field string
 }
 
@@ -2742,6 +3322,10 @@ func (r *RowIdentifiers) Clone() (clone *RowIdentifiers) {
 		clone.Keys = make([]string, len(r.Keys))
 		copy(clone.Keys, r.Keys)
 	}
+	if r.Meta != nil {
+		clone.Meta = make([]json.RawMessage, len(r.Meta))
+		copy(clone.Meta, r.Meta)
+	}
 	return
 }
 
@@ -2866,7 +3450,13 @@ func (g *groupCountSorter) Less(i, j int) bool {
 				return fieldOrder == desc
 			}
 		case -2: // Aggregate
-			if gci.Agg < gcj.Agg {
+			if gci.DecimalAgg != nil && gcj.DecimalAgg != nil {
+				if gci.DecimalAgg.LessThan(*gcj.DecimalAgg) {
+					return fieldOrder == asc
+				} else if gci.DecimalAgg.GreaterThan(*gcj.DecimalAgg) {
+					return fieldOrder == desc
+				}
+			} else if gci.Agg < gcj.Agg {
 				return fieldOrder == asc
 			} else if gci.Agg > gcj.Agg {
 				return fieldOrder == desc
@@ -2933,16 +3523,38 @@ func (e *executor) executeGroupBy(ctx context.Context, qcx *Qcx, index string, c
 	if len(c.Children) == 0 {
 		return nil, errors.New("need at least one child call")
 	}
+	if via, err := groupByVia(c); err != nil {
+		return nil, err
+	} else if via >= 0 && !opt.Remote {
+		return e.executeGroupByVia(ctx, qcx, index, c, via, shards, opt)
+	}
 	limit := int(^uint(0) >> 1)
 	if lim, hasLimit, err := c.UintArg("limit"); err != nil {
 		return nil, err
 	} else if hasLimit {
 		limit = int(lim)
+		// The offset is applied after merging, so enough groups to skip
+		// it are needed from each shard.
+		if offset, _, err := c.UintArg("offset"); err != nil {
+			return nil, err
+		} else {
+			limit += int(offset)
+		}
 	}
 	filter, _, err := c.CallArg("filter")
 	if err != nil {
 		return nil, err
 	}
+	aggregate, _, err := c.CallArg("aggregate")
+	if err != nil {
+		return nil, err
+	}
+	aggType := groupByAggregateType(aggregate)
+	if aggType == "histogram" && !opt.Remote {
+		if err := e.resolveHistogramEdges(ctx, qcx, index, aggregate, shards, opt); err != nil {
+			return nil, err
+		}
+	}
 
 	var sorter *groupCountSorter
 	if sortSpec, found, err := c.StringArg("sort"); err != nil {
@@ -3012,14 +3624,36 @@ func (e *executor) executeGroupBy(ctx context.Context, qcx *Qcx, index string, c
 		case FieldTypeInt, FieldTypeTimestamp:
 			bases[i] = f.bsiGroup(f.name).Base
 		}
-
+		if period, hasPeriod, err := child.StringArg("period"); err != nil {
+			return nil, errors.Wrap(err, "getting period")
+		} else if hasPeriod {
+			if _, err := periodUnit(period); err != nil {
+				return nil, NewBadRequestError(err)
+			} else if f.Type() != FieldTypeTime {
+				return nil, NewBadRequestError(errors.Errorf("period requires a time field, but %s is a %s field", fieldName, f.Type()))
+			} else if _, ok := child.Args["previous"]; ok {
+				return nil, NewBadRequestError(errors.New("previous is not supported with period"))
+			}
+		}
+		if buckets, err := groupByBuckets(child); err != nil {
+			return nil, NewBadRequestError(err)
+		} else if buckets != nil {
+			if f.Type() != FieldTypeInt || f.ForeignIndex() != "" {
+				return nil, NewBadRequestError(errors.Errorf("buckets requires an int field, but %s is a %s field", fieldName, f.Type()))
+			} else if _, ok := child.Args["previous"]; ok || hasLimit || hasCol || hasLike || hasIn {
+				return nil, NewBadRequestError(errors.New("buckets can't be combined with limit, column, like, in or previous"))
+			}
+			// The groups' values are the buckets' bounds, which include
+			// the base already.
+			bases[i] = 0
+		}
+
+		if idx, ok := child.Args["valueidx"].(int64); ok {
+			// The rows query was already completed on the initiating node.
+			childRows[i] = opt.EmbeddedData[idx].Columns()
+			continue
+		}
 		if hasLimit || hasCol || hasLike || hasIn { // we need to perform this query cluster-wide ahead of executeGroupByShard
-			if idx, ok := child.Args["valueidx"].(int64); ok {
-				// The rows query was already completed on the initiating node.
-				childRows[i] = opt.EmbeddedData[idx].Columns()
-				continue
-			}
-
 			r, er := e.executeRows(ctx, qcx, index, child, shards, opt)
 			if er != nil {
 				return nil, errors.Wrap(er, "getting rows for ")
@@ -3040,10 +3674,15 @@ func (e *executor) executeGroupBy(ctx context.Context, qcx *Qcx, index string, c
 		}
 	}
 
-	ignoreLimit := sorter != nil
+	maxGroups := e.resultLimits(index, opt).MaxGroups
+	mergeType := NewGroupCounts(aggType).aggregateType
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
-		return e.executeGroupByShard(ctx, qcx, index, c, filter, shard, childRows, bases, ignoreLimit)
+		gc, err := e.executeGroupByShard(ctx, qcx, index, c, filter, shard, childRows, bases, limit)
+		if err == nil && maxGroups > 0 && uint64(len(findGroupCounts(gc))) > maxGroups {
+			return nil, newResultLimitError(index, c.Name, "groups", maxGroups)
+		}
+		return gc, err
 	}
 
 	// Merge returned results at coordinating node.
@@ -3052,7 +3691,10 @@ func (e *executor) executeGroupBy(ctx context.Context, qcx *Qcx, index string, c
 		if err := ctx.Err(); err != nil {
 			return err
 		}
-		x := mergeGroupCounts(other, findGroupCounts(v), limit)
+		x := mergeGroupCounts(other, findGroupCounts(v), limit, mergeType)
+		if maxGroups > 0 && uint64(len(x)) > maxGroups {
+			return newResultLimitError(index, c.Name, "groups", maxGroups)
+		}
 		for i := range x {
 			gc := &x[i]
 			for j := range gc.Group {
@@ -3070,13 +3712,54 @@ func (e *executor) executeGroupBy(ctx context.Context, qcx *Qcx, index string, c
 		}
 		return x
 	}
+
+	// When the having-condition puts a lower bound on the count or sum of a
+	// group, only groups reaching a share of that bound in some shard can
+	// satisfy it. Find those first, with each shard pruning the groups
+	// below its share, and then restrict the full query to their rows.
+	if hasHaving && !opt.Remote {
+		if threshold, ok := havingShardThreshold(idx, c, having, len(shards)); ok {
+			c.Args["shardthreshold"] = threshold
+			other, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+			delete(c.Args, "shardthreshold")
+			if err != nil {
+				return nil, errors.Wrap(resultLimitErr(err, index, c.Name, "groups", maxGroups), "mapReduce")
+			}
+			candidates, _ := other.([]GroupCount)
+			if len(candidates) == 0 {
+				return &GroupCounts{}, nil
+			}
+			for i, child := range c.Children {
+				if _, ok := bases[i]; ok {
+					continue
+				}
+				childRows[i] = candidateGroupRows(candidates, i, childRows[i])
+				if len(childRows[i]) == 0 {
+					return &GroupCounts{}, nil
+				}
+				rowsRow := NewRow(childRows[i]...)
+				rowsRow.NoSplit = true
+				child.Args["valueidx"] = int64(len(opt.EmbeddedData))
+				opt.EmbeddedData = append(opt.EmbeddedData, rowsRow)
+			}
+		}
+	}
+
 	// Get full result set.
 	other, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
 	if err != nil {
-		return nil, errors.Wrap(err, "mapReduce")
+		return nil, errors.Wrap(resultLimitErr(err, index, c.Name, "groups", maxGroups), "mapReduce")
 	}
 	results, _ := other.([]GroupCount)
 
+	// Find the averages of an Avg or WeightedAvg aggregate, now that the
+	// sums of every shard have been added.
+	if aggregate != nil && !opt.Remote {
+		if err := e.groupAverages(index, aggregate, results); err != nil {
+			return nil, err
+		}
+	}
+
 	// If there's no sorting, we want to apply limits before
 	// calculating the Distinct aggregate which is expensive on a
 	// per-result basis.
@@ -3087,22 +3770,36 @@ func (e *executor) executeGroupBy(ctx context.Context, qcx *Qcx, index string, c
 		}
 	}
 
-	// TODO as an optimization, we could apply some "having"
-	// conditions here long as they aren't on the Count(Distinct)
-	// aggregate
-
 	// Calculate Count(Distinct) aggregate if requested.
-	aggregate, _, err := c.CallArg("aggregate")
-	if err == nil && aggregate != nil && aggregate.Name == "Count" && len(aggregate.Children) > 0 && aggregate.Children[0].Name == "Distinct" && !opt.Remote {
+	if aggregate != nil && aggregate.Name == "Count" && len(aggregate.Children) > 0 && aggregate.Children[0].Name == "Distinct" && !opt.Remote {
+		// Groups which can't reach a lower bound of the having-condition
+		// needn't have their distinct values counted.
+		if hasHaving {
+			if bound, ok := havingShardThreshold(idx, c, having, 1); ok {
+				for subj := range having.Args {
+					results = applyThresholdToGroupCounts(results, subj, bound)
+				}
+			}
+		}
 		for n, gc := range results {
 			intersectRows := make([]*pql.Call, 0, len(gc.Group))
 			for _, fr := range gc.Group {
 				var value interface{} = fr.RowID
 				// use fr.Value instead of fr.RowID if set (from int fields)
-				if fr.Value != nil {
+				if fr.ValueTo != nil {
+					value = &pql.Condition{Op: pql.BTWN_LTE_LT, Value: []interface{}{*fr.Value, *fr.ValueTo}}
+				} else if fr.Value != nil {
 					value = &pql.Condition{Op: pql.EQ, Value: *fr.Value}
 				}
-				intersectRows = append(intersectRows, &pql.Call{Name: "Row", Args: map[string]interface{}{fr.Field: value}})
+				args := map[string]interface{}{fr.Field: value}
+				if fr.Period != "" {
+					from, to, err := periodRange(fr.Period)
+					if err != nil {
+						return nil, err
+					}
+					args["from"], args["to"], args["tz"] = from.Format(TimeFormat), to.Format(TimeFormat), "UTC"
+				}
+				intersectRows = append(intersectRows, &pql.Call{Name: "Row", Args: args})
 			}
 			// apply any filter, if present
 			if filter != nil {
@@ -3149,12 +3846,10 @@ func (e *executor) executeGroupBy(ctx context.Context, qcx *Qcx, index string, c
 			return nil, errors.New("Condition() must contain a single condition")
 		}
 		for subj, cond := range having.Args {
-			switch subj {
-			case "count", "sum":
-				results = applyConditionToGroupCounts(results, subj, cond.(*pql.Condition))
-			default:
-				return nil, errors.New("Condition() only supports count or sum")
+			if err := checkHavingSubject(subj, aggregate); err != nil {
+				return nil, err
 			}
+			results = applyConditionToGroupCounts(results, subj, cond.(*pql.Condition))
 		}
 	}
 
@@ -3173,15 +3868,6 @@ func (e *executor) executeGroupBy(ctx context.Context, qcx *Qcx, index string, c
 
 	}
 
-	aggType := ""
-	if aggregate != nil {
-		switch aggregate.Name {
-		case "Sum":
-			aggType = "sum"
-		case "Count":
-			aggType = "aggregate"
-		}
-	}
 	for _, res := range results {
 		if res.DecimalAgg != nil && aggType == "sum" {
 			aggType = "decimalSum"
@@ -3192,6 +3878,29 @@ func (e *executor) executeGroupBy(ctx context.Context, qcx *Qcx, index string, c
 	return NewGroupCounts(aggType, results...), nil
 }
 
+// groupByAggregateType returns the type of the results of a GroupBy's
+// aggregate, as given to NewGroupCounts.
+func groupByAggregateType(aggregate *pql.Call) string {
+	if aggregate == nil {
+		return ""
+	}
+	switch aggregate.Name {
+	case "Sum":
+		return "sum"
+	case "Count":
+		return "aggregate"
+	case "Avg", "WeightedAvg":
+		return "average"
+	case "Min":
+		return "min"
+	case "Max":
+		return "max"
+	case "Histogram":
+		return "histogram"
+	}
+	return ""
+}
+
 func applyLimitAndOffsetToGroupByResult(c *pql.Call, results []GroupCount) ([]GroupCount, error) {
 	// Apply offset.
 	if offset, hasOffset, err := c.UintArg("offset"); err != nil {
@@ -3214,11 +3923,24 @@ func applyLimitAndOffsetToGroupByResult(c *pql.Call, results []GroupCount) ([]Gr
 
 // FieldRow is used to distinguish rows in a group by result.
 type FieldRow struct {
-	Field        string        `json:"field"`
-	RowID        uint64        `json:"rowID"`
-	RowKey       string        `json:"rowKey,omitempty"`
-	Value        *int64        `json:"value,omitempty"`
-	FieldOptions *FieldOptions `json:"-"`
+	Field        string          `json:"field"`
+	RowID        uint64          `json:"rowID"`
+	RowKey       string          `json:"rowKey,omitempty"`
+	Value        *int64          `json:"value,omitempty"`
+	Meta         json.RawMessage `json:"meta,omitempty"`
+	FieldOptions *FieldOptions   `json:"-"`
+
+	// Period is the period of the row's time field the group is for, if
+	// the field is grouped by period.
+	Period string `json:"period,omitempty"`
+
+	// Index is the index of the field, if it isn't the one queried, for
+	// fields grouped by via a foreign index.
+	Index string `json:"index,omitempty"`
+
+	// ValueTo is the end, exclusive, of the range of values from Value
+	// which the group is for, if the int field is grouped by buckets.
+	ValueTo *int64 `json:"valueTo,omitempty"`
 }
 
 func (fr *FieldRow) Clone() (clone *FieldRow) {
@@ -3226,12 +3948,19 @@ func (fr *FieldRow) Clone() (clone *FieldRow) {
 		Field:  fr.Field,
 		RowID:  fr.RowID,
 		RowKey: fr.RowKey,
+		Meta:   fr.Meta,
+		Period: fr.Period,
+		Index:  fr.Index,
 	}
 	if fr.Value != nil {
 		// deep copy, for safety.
 		v := *fr.Value
 		clone.Value = &v
 	}
+	if fr.ValueTo != nil {
+		v := *fr.ValueTo
+		clone.ValueTo = &v
+	}
 	if fr.FieldOptions != nil {
 		// deep copy, for Extra Safety
 		v := *fr.FieldOptions
@@ -3258,39 +3987,59 @@ func (fr FieldRow) MarshalJSON() ([]byte, error) {
 			})
 		} else {
 			return json.Marshal(struct {
-				Field string `json:"field"`
-				Value int64  `json:"value"`
+				Field   string `json:"field"`
+				Value   int64  `json:"value"`
+				ValueTo *int64 `json:"valueTo,omitempty"`
 			}{
-				Field: fr.Field,
-				Value: *fr.Value,
+				Field:   fr.Field,
+				Value:   *fr.Value,
+				ValueTo: fr.ValueTo,
 			})
 		}
 	}
 
 	if fr.RowKey != "" {
 		return json.Marshal(struct {
-			Field  string `json:"field"`
-			RowKey string `json:"rowKey"`
+			Field  string          `json:"field"`
+			Index  string          `json:"index,omitempty"`
+			RowKey string          `json:"rowKey"`
+			Period string          `json:"period,omitempty"`
+			Meta   json.RawMessage `json:"meta,omitempty"`
 		}{
 			Field:  fr.Field,
+			Index:  fr.Index,
 			RowKey: fr.RowKey,
+			Period: fr.Period,
+			Meta:   fr.Meta,
 		})
 	}
 
 	return json.Marshal(struct {
-		Field string `json:"field"`
-		RowID uint64 `json:"rowID"`
+		Field  string          `json:"field"`
+		Index  string          `json:"index,omitempty"`
+		RowID  uint64          `json:"rowID"`
+		Period string          `json:"period,omitempty"`
+		Meta   json.RawMessage `json:"meta,omitempty"`
 	}{
-		Field: fr.Field,
-		RowID: fr.RowID,
+		Field:  fr.Field,
+		Index:  fr.Index,
+		RowID:  fr.RowID,
+		Period: fr.Period,
+		Meta:   fr.Meta,
 	})
 }
 
 // String is the FieldRow stringer.
 func (fr FieldRow) String() string {
+	if fr.ValueTo != nil {
+		return fmt.Sprintf("%s.%d.%d-%d.%s", fr.Field, fr.RowID, *fr.Value, *fr.ValueTo, fr.RowKey)
+	}
 	if fr.Value != nil {
 		return fmt.Sprintf("%s.%d.%d.%s", fr.Field, fr.RowID, *fr.Value, fr.RowKey)
 	}
+	if fr.Period != "" {
+		return fmt.Sprintf("%s.%d.%s.%s", fr.Field, fr.RowID, fr.RowKey, fr.Period)
+	}
 	return fmt.Sprintf("%s.%d.%s", fr.Field, fr.RowID, fr.RowKey)
 }
 
@@ -3301,6 +4050,10 @@ const (
 	sumAggregate        aggregateType = 1
 	distinctAggregate   aggregateType = 2
 	decimalSumAggregate aggregateType = 3
+	averageAggregate    aggregateType = 4
+	minAggregate        aggregateType = 5
+	maxAggregate        aggregateType = 6
+	histogramAggregate  aggregateType = 7
 )
 
 // GroupCounts is a list of GroupCount.
@@ -3320,6 +4073,14 @@ func (g *GroupCounts) AggregateColumn() string {
 		return "aggregate"
 	case decimalSumAggregate:
 		return "decimalSum"
+	case averageAggregate:
+		return "average"
+	case minAggregate:
+		return "min"
+	case maxAggregate:
+		return "max"
+	case histogramAggregate:
+		return "histogram"
 	default:
 		return ""
 	}
@@ -3346,6 +4107,14 @@ func NewGroupCounts(agg string, groups ...GroupCount) *GroupCounts {
 		aggType = distinctAggregate
 	case "decimalSum":
 		aggType = decimalSumAggregate
+	case "average":
+		aggType = averageAggregate
+	case "min":
+		aggType = minAggregate
+	case "max":
+		aggType = maxAggregate
+	case "histogram":
+		aggType = histogramAggregate
 	case "":
 		aggType = nilAggregate
 	default:
@@ -3373,9 +4142,21 @@ func (g *GroupCounts) ToRows(callback func(*proto.RowResponse) error) error {
 				} else {
 					ci = append(ci, &proto.ColumnInfo{Name: fieldRow.Field, Datatype: "uint64"})
 				}
+				if fieldRow.Period != "" {
+					ci = append(ci, &proto.ColumnInfo{Name: fieldRow.Field + "_period", Datatype: "string"})
+				}
+				if fieldRow.ValueTo != nil {
+					ci = append(ci, &proto.ColumnInfo{Name: fieldRow.Field + "_to", Datatype: "int64"})
+				}
 			}
 			ci = append(ci, &proto.ColumnInfo{Name: "count", Datatype: "uint64"})
-			if agg != "" {
+			if g.aggregateType == averageAggregate {
+				ci = append(ci, &proto.ColumnInfo{Name: agg, Datatype: "decimal"})
+			} else if g.aggregateType == histogramAggregate {
+				ci = append(ci, &proto.ColumnInfo{Name: agg, Datatype: "[]uint64"})
+			} else if !gc.TimestampAgg.IsZero() {
+				ci = append(ci, &proto.ColumnInfo{Name: agg, Datatype: "string"})
+			} else if agg != "" {
 				ci = append(ci, &proto.ColumnInfo{Name: agg, Datatype: "int64"})
 			}
 
@@ -3393,10 +4174,34 @@ func (g *GroupCounts) ToRows(callback func(*proto.RowResponse) error) error {
 			} else {
 				rowResp.Columns = append(rowResp.Columns, &proto.ColumnResponse{ColumnVal: &proto.ColumnResponse_Uint64Val{Uint64Val: fieldRow.RowID}})
 			}
+			if fieldRow.Period != "" {
+				rowResp.Columns = append(rowResp.Columns, &proto.ColumnResponse{ColumnVal: &proto.ColumnResponse_StringVal{StringVal: fieldRow.Period}})
+			}
+			if fieldRow.ValueTo != nil {
+				rowResp.Columns = append(rowResp.Columns, &proto.ColumnResponse{ColumnVal: &proto.ColumnResponse_Int64Val{Int64Val: *fieldRow.ValueTo}})
+			}
 		}
 		rowResp.Columns = append(rowResp.Columns,
 			&proto.ColumnResponse{ColumnVal: &proto.ColumnResponse_Uint64Val{Uint64Val: gc.Count}})
-		if agg != "" {
+		if g.aggregateType == averageAggregate {
+			var dec *proto.Decimal
+			if gc.DecimalAgg != nil {
+				value := gc.DecimalAgg.Value()
+				dec = &proto.Decimal{Value: value.Int64(), Scale: gc.DecimalAgg.Scale}
+			}
+			rowResp.Columns = append(rowResp.Columns,
+				&proto.ColumnResponse{ColumnVal: &proto.ColumnResponse_DecimalVal{DecimalVal: dec}})
+		} else if g.aggregateType == histogramAggregate {
+			var counts []uint64
+			if gc.Histogram != nil {
+				counts = gc.Histogram.Counts
+			}
+			rowResp.Columns = append(rowResp.Columns,
+				&proto.ColumnResponse{ColumnVal: &proto.ColumnResponse_Uint64ArrayVal{Uint64ArrayVal: &proto.Uint64Array{Vals: counts}}})
+		} else if !gc.TimestampAgg.IsZero() {
+			rowResp.Columns = append(rowResp.Columns,
+				&proto.ColumnResponse{ColumnVal: &proto.ColumnResponse_StringVal{StringVal: gc.TimestampAgg.Format(time.RFC3339Nano)}})
+		} else if agg != "" {
 			rowResp.Columns = append(rowResp.Columns,
 				&proto.ColumnResponse{ColumnVal: &proto.ColumnResponse_Int64Val{Int64Val: gc.Agg}})
 		}
@@ -3424,6 +4229,22 @@ func (g *GroupCounts) MarshalJSON() ([]byte, error) {
 		counts = *(*[]groupCountAggregate)(unsafe.Pointer(&groups))
 	case decimalSumAggregate:
 		counts = *(*[]groupCountDecimalSum)(unsafe.Pointer(&groups))
+	case averageAggregate:
+		counts = *(*[]groupCountAverage)(unsafe.Pointer(&groups))
+	case minAggregate:
+		if groups[0].TimestampAgg.IsZero() {
+			counts = *(*[]groupCountMin)(unsafe.Pointer(&groups))
+		} else {
+			counts = *(*[]groupCountTimestampMin)(unsafe.Pointer(&groups))
+		}
+	case maxAggregate:
+		if groups[0].TimestampAgg.IsZero() {
+			counts = *(*[]groupCountMax)(unsafe.Pointer(&groups))
+		} else {
+			counts = *(*[]groupCountTimestampMax)(unsafe.Pointer(&groups))
+		}
+	case histogramAggregate:
+		counts = *(*[]groupCountHistogram)(unsafe.Pointer(&groups))
 	}
 	return json.Marshal(counts)
 }
@@ -3434,33 +4255,119 @@ type GroupCount struct {
 	Count      uint64       `json:"count"`
 	Agg        int64        `json:"-"`
 	DecimalAgg *pql.Decimal `json:"-"`
+
+	// AggWeight is the sum of the weights of a WeightedAvg aggregate,
+	// whose sum of products is in Agg, until the average is found.
+	AggWeight int64 `json:"-"`
+
+	// TimestampAgg is the value of a Min or Max aggregate of a timestamp
+	// field, whose value as an integer is in Agg.
+	TimestampAgg time.Time `json:"-"`
+
+	// Histogram is the value of a Histogram aggregate.
+	Histogram *Histogram `json:"-"`
 }
 
 type groupCountSum struct {
-	Group      []FieldRow   `json:"group"`
-	Count      uint64       `json:"count"`
-	Agg        int64        `json:"sum"`
-	DecimalAgg *pql.Decimal `json:"-"`
+	Group        []FieldRow   `json:"group"`
+	Count        uint64       `json:"count"`
+	Agg          int64        `json:"sum"`
+	DecimalAgg   *pql.Decimal `json:"-"`
+	AggWeight    int64        `json:"-"`
+	TimestampAgg time.Time    `json:"-"`
+	Histogram    *Histogram   `json:"-"`
 }
 
 type groupCountAggregate struct {
-	Group      []FieldRow   `json:"group"`
-	Count      uint64       `json:"count"`
-	Agg        int64        `json:"aggregate"`
-	DecimalAgg *pql.Decimal `json:"-"`
+	Group        []FieldRow   `json:"group"`
+	Count        uint64       `json:"count"`
+	Agg          int64        `json:"aggregate"`
+	DecimalAgg   *pql.Decimal `json:"-"`
+	AggWeight    int64        `json:"-"`
+	TimestampAgg time.Time    `json:"-"`
+	Histogram    *Histogram   `json:"-"`
 }
 
 type groupCountDecimalSum struct {
-	Group      []FieldRow   `json:"group"`
-	Count      uint64       `json:"count"`
-	Agg        int64        `json:"-"`
-	DecimalAgg *pql.Decimal `json:"sum"`
+	Group        []FieldRow   `json:"group"`
+	Count        uint64       `json:"count"`
+	Agg          int64        `json:"-"`
+	DecimalAgg   *pql.Decimal `json:"sum"`
+	AggWeight    int64        `json:"-"`
+	TimestampAgg time.Time    `json:"-"`
+	Histogram    *Histogram   `json:"-"`
+}
+
+type groupCountAverage struct {
+	Group        []FieldRow   `json:"group"`
+	Count        uint64       `json:"count"`
+	Agg          int64        `json:"-"`
+	DecimalAgg   *pql.Decimal `json:"average"`
+	AggWeight    int64        `json:"-"`
+	TimestampAgg time.Time    `json:"-"`
+	Histogram    *Histogram   `json:"-"`
+}
+
+type groupCountMin struct {
+	Group        []FieldRow   `json:"group"`
+	Count        uint64       `json:"count"`
+	Agg          int64        `json:"min"`
+	DecimalAgg   *pql.Decimal `json:"-"`
+	AggWeight    int64        `json:"-"`
+	TimestampAgg time.Time    `json:"-"`
+	Histogram    *Histogram   `json:"-"`
+}
+
+type groupCountMax struct {
+	Group        []FieldRow   `json:"group"`
+	Count        uint64       `json:"count"`
+	Agg          int64        `json:"max"`
+	DecimalAgg   *pql.Decimal `json:"-"`
+	AggWeight    int64        `json:"-"`
+	TimestampAgg time.Time    `json:"-"`
+	Histogram    *Histogram   `json:"-"`
+}
+
+type groupCountTimestampMin struct {
+	Group        []FieldRow   `json:"group"`
+	Count        uint64       `json:"count"`
+	Agg          int64        `json:"-"`
+	DecimalAgg   *pql.Decimal `json:"-"`
+	AggWeight    int64        `json:"-"`
+	TimestampAgg time.Time    `json:"min"`
+	Histogram    *Histogram   `json:"-"`
+}
+
+type groupCountTimestampMax struct {
+	Group        []FieldRow   `json:"group"`
+	Count        uint64       `json:"count"`
+	Agg          int64        `json:"-"`
+	DecimalAgg   *pql.Decimal `json:"-"`
+	AggWeight    int64        `json:"-"`
+	TimestampAgg time.Time    `json:"max"`
+	Histogram    *Histogram   `json:"-"`
+}
+
+type groupCountHistogram struct {
+	Group        []FieldRow   `json:"group"`
+	Count        uint64       `json:"count"`
+	Agg          int64        `json:"-"`
+	DecimalAgg   *pql.Decimal `json:"-"`
+	AggWeight    int64        `json:"-"`
+	TimestampAgg time.Time    `json:"-"`
+	Histogram    *Histogram   `json:"histogram"`
 }
 
 var (
 	_ GroupCount = GroupCount(groupCountSum{})
 	_ GroupCount = GroupCount(groupCountAggregate{})
 	_ GroupCount = GroupCount(groupCountDecimalSum{})
+	_ GroupCount = GroupCount(groupCountAverage{})
+	_ GroupCount = GroupCount(groupCountMin{})
+	_ GroupCount = GroupCount(groupCountMax{})
+	_ GroupCount = GroupCount(groupCountTimestampMin{})
+	_ GroupCount = GroupCount(groupCountTimestampMax{})
+	_ GroupCount = GroupCount(groupCountHistogram{})
 )
 
 func (g *GroupCount) Clone() (r *GroupCount) {
@@ -3469,6 +4376,10 @@ func (g *GroupCount) Clone() (r *GroupCount) {
 		Count:      g.Count,
 		Agg:        g.Agg,
 		DecimalAgg: g.DecimalAgg,
+		AggWeight:  g.AggWeight,
+
+		TimestampAgg: g.TimestampAgg,
+		Histogram:    g.Histogram,
 	}
 	for i := range g.Group {
 		r.Group[i] = *(g.Group[i].Clone())
@@ -3476,10 +4387,36 @@ func (g *GroupCount) Clone() (r *GroupCount) {
 	return
 }
 
+// merge adds the count and aggregate of another GroupCount for the same
+// group to g, keeping the lower or higher of the values of Min and Max
+// aggregates, and adding the buckets of Histogram aggregates.
+func (g *GroupCount) merge(o GroupCount, agg aggregateType) {
+	g.Count += o.Count
+	switch agg {
+	case minAggregate:
+		if o.Agg < g.Agg {
+			g.Agg, g.TimestampAgg = o.Agg, o.TimestampAgg
+		}
+	case maxAggregate:
+		if o.Agg > g.Agg {
+			g.Agg, g.TimestampAgg = o.Agg, o.TimestampAgg
+		}
+	case histogramAggregate:
+		g.Histogram = g.Histogram.add(o.Histogram)
+	default:
+		g.Agg += o.Agg
+		g.AggWeight += o.AggWeight
+		if g.DecimalAgg != nil && o.DecimalAgg != nil {
+			sum := pql.AddDecimal(*g.DecimalAgg, *o.DecimalAgg)
+			g.DecimalAgg = &sum
+		}
+	}
+}
+
 // mergeGroupCounts merges two slices of GroupCounts throwing away any that go
 // beyond the limit. It assume that the two slices are sorted by the row ids in
 // the fields of the group counts. It may modify its arguments.
-func mergeGroupCounts(a, b []GroupCount, limit int) []GroupCount {
+func mergeGroupCounts(a, b []GroupCount, limit int, agg aggregateType) []GroupCount {
 	if limit > len(a)+len(b) {
 		limit = len(a) + len(b)
 	}
@@ -3491,12 +4428,7 @@ func mergeGroupCounts(a, b []GroupCount, limit int) []GroupCount {
 			ret = append(ret, a[i])
 			i++
 		case 0:
-			a[i].Count += b[j].Count
-			a[i].Agg += b[j].Agg
-			if a[i].DecimalAgg != nil && b[j].DecimalAgg != nil {
-				sum := pql.AddDecimal(*a[i].DecimalAgg, *b[j].DecimalAgg)
-				a[i].DecimalAgg = &sum
-			}
+			a[i].merge(b[j], agg)
 			ret = append(ret, a[i])
 			i++
 			j++
@@ -3534,6 +4466,12 @@ func (g GroupCount) Compare(o GroupCount) int {
 				return 1
 			}
 		}
+		if g1.Period != g2.Period {
+			if g1.Period < g2.Period {
+				return -1
+			}
+			return 1
+		}
 	}
 	return 0
 }
@@ -3595,7 +4533,7 @@ func (g GroupCount) satisfiesCondition(subj string, cond *pql.Condition) bool {
 				}
 			}
 		}
-	case "sum":
+	case "sum", "aggregate", "min", "max":
 		switch cond.Op {
 		case pql.EQ, pql.NEQ, pql.LT, pql.LTE, pql.GT, pql.GTE:
 			val, ok := cond.Int64Value()
@@ -3654,9 +4592,36 @@ func (g GroupCount) satisfiesCondition(subj string, cond *pql.Condition) bool {
 	return false
 }
 
+// checkHavingSubject returns an error unless subj, the subject of a
+// having-condition, is one a GroupBy with aggregate can be filtered by:
+// count, or the aggregate's column. sum is accepted for any aggregate, as
+// it always has been.
+func checkHavingSubject(subj string, aggregate *pql.Call) error {
+	switch subj {
+	case "count", "sum":
+		return nil
+	case "aggregate", "min", "max":
+		if groupByAggregateType(aggregate) == subj && !(subj == "aggregate" && countDistinctField(aggregate) == "") {
+			return nil
+		}
+		return errors.Errorf("Condition() on %s requires a GroupBy aggregate of that type", subj)
+	}
+	return errors.New("Condition() only supports count, sum, aggregate, min or max")
+}
+
+// countDistinctField returns the field of a Count(Distinct()) aggregate, or
+// "" if aggregate isn't one.
+func countDistinctField(aggregate *pql.Call) string {
+	if aggregate == nil || aggregate.Name != "Count" || len(aggregate.Children) == 0 || aggregate.Children[0].Name != "Distinct" {
+		return ""
+	}
+	field, _, _ := aggregate.Children[0].StringArg("field")
+	return field
+}
+
 // applyConditionToGroupCounts filters the contents of gcs according
-// to the condition. Currently, `count` and `sum` are the only
-// fields supported.
+// to the condition, on the count of each group or on its aggregate,
+// compared as an int64, for the other subjects.
 func applyConditionToGroupCounts(gcs []GroupCount, subj string, cond *pql.Condition) []GroupCount {
 	var i int
 	for _, gc := range gcs {
@@ -3669,10 +4634,160 @@ func applyConditionToGroupCounts(gcs []GroupCount, subj string, cond *pql.Condit
 	return gcs[:i]
 }
 
-func (e *executor) executeGroupByShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, filter *pql.Call, shard uint64, childRows []RowIDs, bases map[int]int64, ignoreLimit bool) (_ []GroupCount, err error) {
+// havingShardThreshold returns the count or sum which a group must reach
+// in at least one of n shards to satisfy the having-condition of the
+// GroupBy call c. It returns false unless the condition has a lower bound
+// on an aggregate which can only grow as shards are added, and the
+// threshold would prune anything. Counts always qualify, and sums do when
+// the field summed can't hold negative values. Counts of distinct values
+// qualify when the field has a single value per column, so a group has no
+// more of them than its count, which the threshold then applies to.
+func havingShardThreshold(idx *Index, c, having *pql.Call, n int) (uint64, bool) {
+	if having.Name != "Condition" || len(having.Args) != 1 || n == 0 {
+		return 0, false
+	}
+	for subj, arg := range having.Args {
+		cond, ok := arg.(*pql.Condition)
+		if !ok {
+			return 0, false
+		}
+		switch subj {
+		case "count":
+		case "sum":
+			aggregate, _, err := c.CallArg("aggregate")
+			if err != nil || aggregate == nil || aggregate.Name != "Sum" {
+				return 0, false
+			}
+			fieldName, err := aggregate.FieldArg()
+			if err != nil {
+				return 0, false
+			}
+			f := idx.Field(fieldName)
+			if f == nil || f.Type() != FieldTypeInt || f.bsiGroup(f.name).Min < 0 {
+				return 0, false
+			}
+		case "aggregate":
+			aggregate, _, err := c.CallArg("aggregate")
+			if err != nil {
+				return 0, false
+			}
+			f := idx.Field(countDistinctField(aggregate))
+			if f == nil || f.bsiGroup(f.name) == nil {
+				return 0, false
+			}
+		default:
+			return 0, false
+		}
+
+		var bound int64
+		switch cond.Op {
+		case pql.EQ, pql.GTE, pql.GT:
+			val, ok := cond.Int64Value()
+			if !ok {
+				return 0, false
+			}
+			bound = val
+			if cond.Op == pql.GT {
+				bound++
+			}
+		case pql.BETWEEN, pql.BTWN_LTE_LT, pql.BTWN_LT_LTE, pql.BTWN_LT_LT:
+			val, ok := cond.Int64SliceValue()
+			if !ok || len(val) != 2 {
+				return 0, false
+			}
+			bound = val[0]
+			if cond.Op == pql.BTWN_LT_LTE || cond.Op == pql.BTWN_LT_LT {
+				bound++
+			}
+		default:
+			return 0, false
+		}
+
+		// A group reaching bound across n shards must reach bound/n,
+		// rounded up, in at least one of them. Shards only return groups
+		// with a count of at least 1 anyway.
+		threshold := (bound + int64(n) - 1) / int64(n)
+		if threshold <= 1 && subj != "sum" || threshold <= 0 {
+			return 0, false
+		}
+		return uint64(threshold), true
+	}
+	return 0, false
+}
+
+// applyThresholdToGroupCounts filters out the groups of gcs which don't
+// reach threshold, as reachesThreshold has it.
+func applyThresholdToGroupCounts(gcs []GroupCount, subj string, threshold uint64) []GroupCount {
+	var i int
+	for _, gc := range gcs {
+		if !gc.reachesThreshold(subj, threshold) {
+			continue
+		}
+		gcs[i] = gc
+		i++
+	}
+	return gcs[:i]
+}
+
+// reachesThreshold reports whether the count or sum of g is at least
+// threshold. Thresholds on counts of distinct values apply to the count.
+func (g GroupCount) reachesThreshold(subj string, threshold uint64) bool {
+	switch subj {
+	case "count", "aggregate":
+		return g.Count >= threshold
+	case "sum":
+		return g.Agg >= 0 && uint64(g.Agg) >= threshold
+	}
+	return true
+}
+
+// candidateGroupRows returns the sorted row IDs appearing at position i in
+// the groups of candidates, limited to those in rows if it's not nil.
+func candidateGroupRows(candidates []GroupCount, i int, rows RowIDs) RowIDs {
+	seen := make(map[uint64]struct{})
+	for _, gc := range candidates {
+		seen[gc.Group[i].RowID] = struct{}{}
+	}
+	ids := make(RowIDs, 0, len(seen))
+	if rows != nil {
+		for _, id := range rows {
+			if _, ok := seen[id]; ok {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(x, y int) bool { return ids[x] < ids[y] })
+	return ids
+}
+
+func (e *executor) executeGroupByShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, filter *pql.Call, shard uint64, childRows []RowIDs, bases map[int]int64, limit int) (_ []GroupCount, err error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeGroupByShard")
 	defer span.Finish()
 
+	// Answer from a cube if there's one for the query. Writes made earlier
+	// in this query aren't reflected in the cube yet.
+	if idx := e.Holder.Index(index); idx != nil && filter == nil && (qcx == nil || !qcx.write) {
+		noRows := true
+		for _, rows := range childRows {
+			noRows = noRows && rows == nil
+		}
+		aggregate, _, err := c.CallArg("aggregate")
+		if err != nil {
+			return nil, err
+		}
+		if cube, ok := idx.cubes.match(c, aggregate); ok && noRows {
+			return e.groupByCubeShard(ctx, idx, cube, c, shard, limit)
+		}
+	}
+	return e.groupByShard(ctx, qcx, index, c, filter, shard, childRows, bases, limit)
+}
+
+// groupByShard computes the groups of a GroupBy call for a local shard.
+func (e *executor) groupByShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, filter *pql.Call, shard uint64, childRows []RowIDs, bases map[int]int64, limit int) (_ []GroupCount, err error) {
 	var filterRow *Row
 	if filter != nil {
 		if filterRow, err = e.executeBitmapCallShard(ctx, qcx, index, filter, shard); err != nil {
@@ -3696,11 +4811,22 @@ func (e *executor) executeGroupByShard(ctx context.Context, qcx *Qcx, index stri
 		return []GroupCount{}, nil
 	}
 
-	limit := int(^uint(0) >> 1)
-	if lim, hasLimit, err := c.UintArg("limit"); err != nil {
+	// A threshold set by the coordinating node prunes groups which can't
+	// satisfy the having-condition.
+	var subj string
+	threshold, hasThreshold, err := c.UintArg("shardthreshold")
+	if err != nil {
 		return nil, err
-	} else if !ignoreLimit && hasLimit {
-		limit = int(lim)
+	} else if hasThreshold {
+		having, _, err := c.CallArg("having")
+		if err != nil || having == nil {
+			return nil, errors.New("shardthreshold requires a having-condition")
+		}
+		// Condition() has a single argument, checked by the coordinating
+		// node.
+		for k := range having.Args {
+			subj = k
+		}
 	}
 
 	results := make([]GroupCount, 0)
@@ -3711,6 +4837,9 @@ func (e *executor) executeGroupByShard(ctx context.Context, qcx *Qcx, index stri
 			return nil, err
 		}
 
+		if hasThreshold && !gc.reachesThreshold(subj, threshold) {
+			continue
+		}
 		if gc.Count > 0 {
 			num++
 			results = append(results, gc)
@@ -3735,6 +4864,21 @@ func (e *executor) executeGroupByShard(ctx context.Context, qcx *Qcx, index stri
 		}
 	}
 
+	// The row IDs of bucketed groups are the indexes of their buckets,
+	// which end where the next begins.
+	for i, child := range c.Children {
+		buckets, err := groupByBuckets(child)
+		if err != nil {
+			return nil, err
+		} else if buckets == nil {
+			continue
+		}
+		for _, r := range results {
+			end := buckets[r.Group[i].RowID+1]
+			r.Group[i].ValueTo = &end
+		}
+	}
+
 	return results, nil
 }
 
@@ -3769,9 +4913,15 @@ func (e *executor) executeRows(ctx context.Context, qcx *Qcx, index string, c *p
 		return ids, nil
 	}
 
+	maxRows := e.resultLimits(index, opt).MaxRows
+
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
-		return e.executeRowsShard(ctx, qcx, index, fieldName, c, shard)
+		ids, err := e.executeRowsShard(ctx, qcx, index, fieldName, c, shard)
+		if err == nil && maxRows > 0 && uint64(len(ids)) > maxRows {
+			return nil, newResultLimitError(index, c.Name, "rows", maxRows)
+		}
+		return ids, err
 	}
 
 	// Determine limit so we can use it when reducing.
@@ -3788,12 +4938,16 @@ func (e *executor) executeRows(ctx context.Context, qcx *Qcx, index string, c *p
 		if err := ctx.Err(); err != nil {
 			return err
 		}
-		return other.merge(v.(RowIDs), limit)
+		merged := other.merge(v.(RowIDs), limit)
+		if maxRows > 0 && uint64(len(merged)) > maxRows {
+			return newResultLimitError(index, c.Name, "rows", maxRows)
+		}
+		return merged
 	}
 	// Get full result set.
 	other, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
 	if err != nil {
-		return nil, err
+		return nil, resultLimitErr(err, index, c.Name, "rows", maxRows)
 	}
 	results, _ := other.(RowIDs)
 
@@ -3854,15 +5008,23 @@ func (e *executor) executeRowsShard(ctx context.Context, qcx *Qcx, index string,
 	// in order to represent `Rows` for the field.
 	views := []string{viewStandard}
 
-	switch f.Type() {
+	fieldType := f.Type()
+	if fieldType == FieldTypeMutex && f.options.History != "" && (c.Args["from"] != nil || c.Args["to"] != nil) {
+		// A mutex field's history is queried by time.
+		fieldType = FieldTypeTime
+	}
+	switch fieldType {
 	case FieldTypeSet, FieldTypeMutex:
 	case FieldTypeTime:
-		var err error
+		loc, err := callLocation(c, f)
+		if err != nil {
+			return nil, err
+		}
 
 		// Parse "from" time, if set.
 		var fromTime time.Time
 		if v, ok := c.Args["from"]; ok {
-			if fromTime, err = parseTime(v); err != nil {
+			if fromTime, err = parseTimeIn(v, loc); err != nil {
 				return nil, errors.Wrap(err, "parsing from time")
 			}
 		}
@@ -3870,7 +5032,7 @@ func (e *executor) executeRowsShard(ctx context.Context, qcx *Qcx, index string,
 		// Parse "to" time, if set.
 		var toTime time.Time
 		if v, ok := c.Args["to"]; ok {
-			if toTime, err = parseTime(v); err != nil {
+			if toTime, err = parseTimeIn(v, loc); err != nil {
 				return nil, errors.Wrap(err, "parsing to time")
 			}
 		}
@@ -3954,6 +5116,9 @@ func (kid KeyOrID) MarshalJSON() ([]byte, error) {
 type ExtractedTableColumn struct {
 	Column KeyOrID       `json:"column"`
 	Rows   []interface{} `json:"rows"`
+
+	// Attrs are the column's attributes, if requested with includeAttrs.
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
 }
 
 type ExtractedTable struct {
@@ -4145,7 +5310,7 @@ func (e *executor) executeExternalLookup(ctx context.Context, qcx *Qcx, index st
 	}
 
 	qr := []interface{}{rawArg}
-	err = e.translateResults(ctx, index, idx, c.Children, qr, e.maxMemory)
+	err = e.translateResults(ctx, index, idx, c.Children, qr, e.maxQueryMemory())
 	if err != nil {
 		return ExtractedTable{}, errors.Wrap(err, "translating query result")
 	}
@@ -4356,27 +5521,62 @@ type TimeArgs struct {
 	To   time.Time
 }
 
-func (e *executor) executeExtract(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (ExtractedIDMatrix, error) {
+func (e *executor) executeExtract(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (interface{}, error) {
 	// Extract the column filter call.
 	if len(c.Children) < 1 {
 		return ExtractedIDMatrix{}, errors.New("missing column filter in Extract")
 	}
 	filter := c.Children[0]
-	var sort_desc bool
+	var sortDesc []bool
 	if filter.Name == "Sort" {
-		sd, _, err := filter.BoolArg("sort-desc")
+		keys, err := parseSortKeys(filter)
 		if err != nil {
 			return ExtractedIDMatrix{}, errors.Wrap(err, "sort field error")
 		}
-		sort_desc = sd
+		sortDesc = sortDirections(keys)
+	}
+
+	// Columns are ordered by ID here, and by key once translated, with the
+	// limit and offset applied after ordering. Each shard only needs to
+	// return enough columns to fill the limit: the lowest IDs when ordering
+	// by ID, and the lowest keys, translated by the trimmer, when ordering
+	// by key.
+	order, err := parseColumnOrder(c)
+	if err != nil {
+		return ExtractedIDMatrix{}, err
+	}
+	if filter.Name == "Sort" {
+		for _, arg := range []string{"order", "limit", "offset"} {
+			if _, ok := c.Args[arg]; ok {
+				return ExtractedIDMatrix{}, NewBadRequestError(errors.Errorf("Extract with Sort does not support %q", arg))
+			}
+		}
+	}
+	byKey := order.byKey(e.Holder.Index(index))
+	keep := -1
+	var trimmer *keyTrimmer
+	if !byKey {
+		keep = order.keep()
+	} else if order.hasLimit {
+		trimmer = newKeyTrimmer(order.keep(), func(ctx context.Context, ids map[uint64]struct{}) (map[uint64]string, error) {
+			return e.Cluster.translateIndexIDSet(ctx, index, ids)
+		})
 	}
 
 	// Extract fields from rows calls.
 	fields := make([]string, len(c.Children)-1)
 	timeArgs := make([]TimeArgs, len(c.Children)-1)
 	for i, rows := range c.Children[1:] {
+		if rows.Name == "CardinalityOf" {
+			fieldName, err := rows.FirstStringArg("field", "_field")
+			if err != nil {
+				return ExtractedIDMatrix{}, errors.Wrap(err, "CardinalityOf(): field required")
+			}
+			fields[i] = cardinalityExtractName(fieldName)
+			continue
+		}
 		if rows.Name != "Rows" {
-			return ExtractedIDMatrix{}, errors.Errorf("child call of Extract is %q but expected Rows", rows.Name)
+			return ExtractedIDMatrix{}, errors.Errorf("child call of Extract is %q but expected Rows or CardinalityOf", rows.Name)
 		}
 		var fieldName string
 		var ok bool
@@ -4386,18 +5586,7 @@ func (e *executor) executeExtract(ctx context.Context, qcx *Qcx, index string, c
 			case "field", "_field":
 				fieldName = v.(string)
 				ok = true
-			case "from":
-				fromTime, err := parseTime(v)
-				if err != nil {
-					return ExtractedIDMatrix{}, errors.Wrap(err, "parsing from time")
-				}
-				timeArg.From = fromTime
-			case "to":
-				toTime, err := parseTime(v)
-				if err != nil {
-					return ExtractedIDMatrix{}, errors.Wrap(err, "parsing from time")
-				}
-				timeArg.To = toTime
+			case "from", "to", "tz":
 			default:
 				return ExtractedIDMatrix{}, errors.Errorf("unsupported Rows argument for Extract: %q", k)
 			}
@@ -4405,13 +5594,75 @@ func (e *executor) executeExtract(ctx context.Context, qcx *Qcx, index string, c
 		if !ok {
 			return ExtractedIDMatrix{}, errors.New("missing field specification in Rows")
 		}
+		loc, err := callLocation(rows, e.Holder.Field(index, fieldName))
+		if err != nil {
+			return ExtractedIDMatrix{}, err
+		}
+		if v, ok := rows.Args["from"]; ok {
+			if timeArg.From, err = parseTimeIn(v, loc); err != nil {
+				return ExtractedIDMatrix{}, errors.Wrap(err, "parsing from time")
+			}
+		}
+		if v, ok := rows.Args["to"]; ok {
+			if timeArg.To, err = parseTimeIn(v, loc); err != nil {
+				return ExtractedIDMatrix{}, errors.Wrap(err, "parsing to time")
+			}
+		}
 		fields[i] = fieldName
 		timeArgs[i] = timeArg
 	}
 
+	where, err := e.extractWhere(ctx, index, c, fields, opt)
+	if err != nil {
+		return ExtractedIDMatrix{}, err
+	}
+
+	// Columns are spilled to disk once they take too much memory.
+	var spill *extractSpill
+	var spillMu sync.Mutex
+	var held int64
+	if e.spill != nil && e.spillThreshold > 0 && filter.Name != "Sort" {
+		spill = &extractSpill{dir: e.spill}
+		defer func() {
+			if err := spill.Close(); err != nil {
+				e.Holder.Logger.Errorf("removing spilled Extract columns: %v", err)
+			}
+			e.reportSpill()
+		}()
+	}
+	spilled := func() int {
+		if spill == nil {
+			return 0
+		}
+		spillMu.Lock()
+		defer spillMu.Unlock()
+		return spill.n
+	}
+
+	maxColumns := e.resultLimits(index, opt).MaxColumns
+	checkColumns := func(n int) error {
+		if maxColumns > 0 && uint64(n) > maxColumns {
+			return newResultLimitError(index, c.Name, "columns", maxColumns)
+		}
+		return nil
+	}
+
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
-		return e.executeExtractShard(ctx, qcx, index, fields, filter, shard, mopt, timeArgs)
+		result, err := e.executeExtractShard(ctx, qcx, index, fields, filter, where, shard, mopt, timeArgs, keep)
+		if err != nil {
+			return nil, err
+		}
+		switch r := result.(type) {
+		case ExtractedIDMatrix:
+			if r, err = trimmer.trim(ctx, r); err != nil {
+				return nil, err
+			}
+			result, err = r, checkColumns(len(r.Columns))
+		case ExtractedIDMatrixSorted:
+			err = checkColumns(len(r.ExtractedIDMatrix.Columns))
+		}
+		return result, err
 	}
 
 	// Merge returned results at coordinating node.
@@ -4421,24 +5672,47 @@ func (e *executor) executeExtract(ctx context.Context, qcx *Qcx, index string, c
 		}
 		switch other := v.(type) {
 		case ExtractedIDMatrixSorted:
-			if prev == nil {
-				return other
-			}
-			if _, ok := prev.(ExtractedIDMatrixSorted); !ok {
+			p, ok := prev.(ExtractedIDMatrixSorted)
+			if !ok {
+				if err := checkColumns(len(other.ExtractedIDMatrix.Columns)); err != nil {
+					return err
+				}
 				return other
 			}
-			if out, err := MergeExtractedIDMatrixSorted(other, prev.(ExtractedIDMatrixSorted), sort_desc); err != nil {
+			if out, err := MergeExtractedIDMatrixSorted(other, p, sortDesc); err != nil {
+				return err
+			} else if err := checkColumns(len(out.ExtractedIDMatrix.Columns)); err != nil {
 				return err
 			} else {
 				return out
 			}
 
 		case ExtractedIDMatrix:
+			size := calcResultMemory(other)
 			if prev != nil {
 				if p, ok := prev.(ExtractedIDMatrix); ok {
 					other.Append(p)
 				}
 			}
+			other, err := trimmer.trim(ctx, other)
+			if err != nil {
+				return err
+			}
+			if err := checkColumns(len(other.Columns) + spilled()); err != nil {
+				return err
+			}
+			if spill != nil {
+				spillMu.Lock()
+				defer spillMu.Unlock()
+				if held += size; held > e.spillThreshold {
+					if err := spill.add(other.Columns); err != nil {
+						return errors.Wrap(err, "spilling columns")
+					}
+					e.reportSpill()
+					other.Columns = nil
+					held = 0
+				}
+			}
 			return other
 		case nil:
 			return prev
@@ -4450,30 +5724,48 @@ func (e *executor) executeExtract(ctx context.Context, qcx *Qcx, index string, c
 	// Get full result set.
 	other, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
 	if err != nil {
-		return ExtractedIDMatrix{}, err
+		return ExtractedIDMatrix{}, resultLimitErr(err, index, c.Name, "columns", maxColumns)
 	}
 
 	switch results := other.(type) {
 	case ExtractedIDMatrix:
+		if spill != nil && spill.n > 0 {
+			return e.mergeSpilledExtract(spill, results, byKey, keep, order, opt)
+		}
 		sort.Slice(results.Columns, func(i, j int) bool {
 			return results.Columns[i].ColumnID < results.Columns[j].ColumnID
 		})
+		if byKey {
+			// Ordered by key, and limited, in translateResult.
+			return results, nil
+		}
+		if opt.Remote {
+			// The coordinator applies the offset, so keep enough
+			// columns for it.
+			if keep >= 0 && len(results.Columns) > keep {
+				results.Columns = results.Columns[:keep]
+			}
+			return results, nil
+		}
+		start, end := order.bounds(len(results.Columns))
+		results.Columns = results.Columns[start:end]
 		return results, nil
 	case ExtractedIDMatrixSorted:
-		offset, hasOffset, err := filter.UintArg("offset")
-		if err != nil {
-			return ExtractedIDMatrix{}, err
-		}
-		limit, hasLimit, err := filter.UintArg("limit")
+		sortOrder, err := parseColumnOrder(filter)
 		if err != nil {
 			return ExtractedIDMatrix{}, err
 		}
-		if hasOffset {
-			results.ExtractedIDMatrix.Columns = results.ExtractedIDMatrix.Columns[offset:]
-		}
-		if hasLimit && limit < uint64(len(results.RowKVs)) {
-			results.ExtractedIDMatrix.Columns = results.ExtractedIDMatrix.Columns[:limit]
+		if opt.Remote {
+			// The coordinator merges the sorted columns of each node
+			// and applies the offset, so keep enough columns for it.
+			if keep := sortOrder.keep(); keep >= 0 && len(results.RowKVs) > keep {
+				results.RowKVs = results.RowKVs[:keep]
+				results.ExtractedIDMatrix.Columns = results.ExtractedIDMatrix.Columns[:keep]
+			}
+			return results, nil
 		}
+		start, end := sortOrder.bounds(len(results.ExtractedIDMatrix.Columns))
+		results.ExtractedIDMatrix.Columns = results.ExtractedIDMatrix.Columns[start:end]
 		return *results.ExtractedIDMatrix, nil
 	default:
 		return ExtractedIDMatrix{}, errors.New("Extract, unexpected result type found")
@@ -4481,6 +5773,44 @@ func (e *executor) executeExtract(ctx context.Context, qcx *Qcx, index string, c
 
 }
 
+// mergeSpilledExtract merges the columns of an Extract which have been
+// spilled with those still in results, keeping those which are returned.
+func (e *executor) mergeSpilledExtract(spill *extractSpill, results ExtractedIDMatrix, byKey bool, keep int, order columnOrder, opt *ExecOptions) (ExtractedIDMatrix, error) {
+	if err := spill.add(results.Columns); err != nil {
+		return ExtractedIDMatrix{}, errors.Wrap(err, "spilling columns")
+	}
+	e.reportSpill()
+	start, end := 0, spill.n
+	switch {
+	case byKey:
+		// Ordered by key, and limited, in translateResult.
+	case opt.Remote:
+		// The coordinator applies the offset, so keep enough columns
+		// for it.
+		if keep >= 0 && end > keep {
+			end = keep
+		}
+	default:
+		start, end = order.bounds(spill.n)
+	}
+	cols, err := spill.merge(start, end)
+	if err != nil {
+		return ExtractedIDMatrix{}, errors.Wrap(err, "merging spilled columns")
+	}
+	results.Columns = cols
+	return results, nil
+}
+
+// reportSpill reports the files and bytes spilled to the temporary
+// directory.
+func (e *executor) reportSpill() {
+	if e.spill == nil {
+		return
+	}
+	e.Holder.Stats.Gauge(MetricSpillFiles, float64(e.spill.Files()), 1.0)
+	e.Holder.Stats.Gauge(MetricSpillBytes, float64(e.spill.Used()), 1.0)
+}
+
 func mergeBits(bits *Row, mask uint64, out map[uint64]uint64) {
 	for _, v := range bits.Columns() {
 		out[v] |= mask
@@ -4492,7 +5822,10 @@ var (
 	falseRowFakeID = []uint64{0}
 )
 
-func (e *executor) executeExtractShard(ctx context.Context, qcx *Qcx, index string, fields []string, filter *pql.Call, shard uint64, mopt *mapOptions, timeArgs []TimeArgs) (_ interface{}, err0 error) {
+// executeExtractShard extracts the fields of the columns matching filter,
+// and where, if it isn't nil, in a shard. If keep isn't negative, only the
+// lowest keep column IDs are extracted.
+func (e *executor) executeExtractShard(ctx context.Context, qcx *Qcx, index string, fields []string, filter *pql.Call, where *extractWhere, shard uint64, mopt *mapOptions, timeArgs []TimeArgs, keep int) (_ interface{}, err0 error) {
 	var colsBitmap *Row
 	var cols []uint64
 	var sortedResult *SortedRow
@@ -4502,7 +5835,7 @@ func (e *executor) executeExtractShard(ctx context.Context, qcx *Qcx, index stri
 			return ExtractedIDMatrix{}, errors.Wrap(err, "failed to get extraction sort column filter")
 		}
 		cols = res.Columns()
-		colsBitmap = res.Row
+		colsBitmap = NewRow(cols...)
 		sortedResult = res
 	} else {
 		// Execute filter.
@@ -4513,6 +5846,11 @@ func (e *executor) executeExtractShard(ctx context.Context, qcx *Qcx, index stri
 		// Decompress columns bitmap.
 		colsBitmap = res
 		cols = colsBitmap.Columns()
+		// Which columns to keep isn't known until where is evaluated.
+		if keep >= 0 && len(cols) > keep && where == nil {
+			cols = cols[:keep]
+			colsBitmap = NewRow(cols...)
+		}
 	}
 
 	// Fetch index.
@@ -4539,6 +5877,14 @@ func (e *executor) executeExtractShard(ctx context.Context, qcx *Qcx, index stri
 		}
 	}
 	if len(m) == 0 {
+		if sortedResult != nil {
+			return ExtractedIDMatrixSorted{
+				ExtractedIDMatrix: &ExtractedIDMatrix{
+					Fields:  fields,
+					Columns: m,
+				},
+			}, nil
+		}
 		return ExtractedIDMatrix{
 			Fields:  fields,
 			Columns: m,
@@ -4551,6 +5897,13 @@ func (e *executor) executeExtractShard(ctx context.Context, qcx *Qcx, index stri
 
 	// Process fields.
 	for i, name := range fields {
+		if fieldName, ok := cardinalityExtractField(name); ok {
+			if err := e.cardinalitiesShard(ctx, tx, index, fieldName, shard, m, i); err != nil {
+				return ExtractedIDMatrix{}, errors.Wrap(err, "counting bits")
+			}
+			continue
+		}
+
 		// Look up the field.
 		field := idx.Field(name)
 		if field == nil {
@@ -4742,6 +6095,20 @@ func (e *executor) executeExtractShard(ctx context.Context, qcx *Qcx, index stri
 		}
 	}
 
+	if where != nil {
+		var kvs []RowKV
+		if sortedResult != nil {
+			kvs = sortedResult.RowKVs
+		}
+		m, kvs = where.filter(m, kvs)
+		if keep >= 0 && len(m) > keep {
+			m = m[:keep]
+		}
+		if sortedResult != nil {
+			sortedResult.RowKVs = kvs
+		}
+	}
+
 	// Emit the final matrix.
 	// Like RowIDs, this is an internal type and will need to be converted.
 	matrix := ExtractedIDMatrix{
@@ -4767,7 +6134,7 @@ func (e *executor) executeRowShard(ctx context.Context, qcx *Qcx, index string,
 
 	// Handle bsiGroup ranges differently.
 	if c.HasConditionArg() {
-		return e.executeRowBSIGroupShard(ctx, qcx, index, c, shard)
+		return e.executeRowBSIGroupShard(ctx, qcx, index, c, shard, nil)
 	}
 
 	// Fetch index.
@@ -4790,11 +6157,15 @@ func (e *executor) executeRowShard(ctx context.Context, qcx *Qcx, index string,
 	if err != nil {
 		return nil, err
 	}
+	loc, err := callLocation(c, f)
+	if err != nil {
+		return nil, err
+	}
 
 	// Parse "from" time, if set.
 	var fromTime time.Time
 	if v, ok := c.Args["from"]; ok {
-		if fromTime, err = parseTime(v); err != nil {
+		if fromTime, err = parseTimeIn(v, loc); err != nil {
 			return nil, errors.Wrap(err, "parsing from time")
 		}
 	}
@@ -4802,11 +6173,15 @@ func (e *executor) executeRowShard(ctx context.Context, qcx *Qcx, index string,
 	// Parse "to" time, if set.
 	var toTime time.Time
 	if v, ok := c.Args["to"]; ok {
-		if toTime, err = parseTime(v); err != nil {
+		if toTime, err = parseTimeIn(v, loc); err != nil {
 			return nil, errors.Wrap(err, "parsing to time")
 		}
 	}
 
+	if f.Type() == FieldTypeBool && c.Args[fieldName] == nil {
+		return e.executeBoolNullShard(ctx, qcx, idx, f, shard)
+	}
+
 	rowID, rowOK, rowErr := c.UintArg(fieldName)
 	if rowErr != nil {
 		return nil, fmt.Errorf("Row() error with arg for row: %v", rowErr)
@@ -4814,6 +6189,14 @@ func (e *executor) executeRowShard(ctx context.Context, qcx *Qcx, index string,
 		return nil, fmt.Errorf("Row() must specify %v", rowLabel)
 	}
 
+	// Return a mutex field's row as of a time from its history.
+	if _, ok := c.Args["at"]; ok {
+		if !fromTime.IsZero() || !toTime.IsZero() {
+			return nil, errors.New("Row() can't specify both at and from or to")
+		}
+		return e.executeRowAtShard(ctx, qcx, idx, f, c, rowID, shard)
+	}
+
 	// Return row if times are not set and standard view exists.
 	timeNotSet := fromTime.IsZero() && toTime.IsZero()
 	if c.Name == "Row" && timeNotSet && !f.options.NoStandardView {
@@ -4874,7 +6257,9 @@ func (e *executor) executeRowShard(ctx context.Context, qcx *Qcx, index string,
 }
 
 // executeRowBSIGroupShard executes a range(bsiGroup) call for a local shard.
-func (e *executor) executeRowBSIGroupShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (cloneable *Row, err0 error) {
+// If filter isn't nil, the result is limited to its columns, and only they
+// are compared.
+func (e *executor) executeRowBSIGroupShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64, filter *Row) (cloneable *Row, err0 error) {
 	span, _ := tracing.StartSpanFromContext(ctx, "executor.executeRowBSIGroupShard")
 	defer span.Finish()
 
@@ -4918,6 +6303,7 @@ func (e *executor) executeRowBSIGroupShard(ctx context.Context, qcx *Qcx, index
 	// BETWEEN a,b(out)  BETWEEN/frag.NotNull()
 	// EQ <int>          frag.RangeOp
 	// NEQ <int>         frag.RangeOp
+	// IN [<int>, ...]   frag.rangeIn()
 
 	// Handle `!= null` and `== null`.
 	if cond.Op == pql.NEQ && cond.Value == nil {
@@ -4926,25 +6312,19 @@ func (e *executor) executeRowBSIGroupShard(ctx context.Context, qcx *Qcx, index
 		if frag == nil {
 			return NewRow(), nil
 		}
-		return frag.notNull(tx)
+		return frag.bsiExists(tx, filter)
 
 	} else if cond.Op == pql.EQ && cond.Value == nil {
-		// Make sure the index supports existence tracking.
 		idx := e.Holder.Index(index)
 		if idx == nil {
 			return nil, newNotFoundError(ErrIndexNotFound, index)
-		} else if idx.existenceField() == nil {
-			return nil, errors.Errorf("index does not support existence tracking: %s", index)
 		}
-
 		var existenceRow *Row
-		existenceFrag := e.Holder.fragment(index, existenceFieldName, viewStandard, shard)
-		if existenceFrag == nil {
-			existenceRow = NewRow()
-		} else {
-			if existenceRow, err0 = existenceFrag.row(tx, 0); err0 != nil {
-				return nil, err0
-			}
+		if existenceRow, err0 = e.existenceRowShard(ctx, tx, idx, shard); err0 != nil {
+			return nil, err0
+		}
+		if filter != nil {
+			existenceRow = existenceRow.Intersect(filter)
 		}
 
 		var notNull *Row
@@ -4997,10 +6377,42 @@ func (e *executor) executeRowBSIGroupShard(ctx context.Context, qcx *Qcx, index
 		// If the query is asking for the entire valid range, just return
 		// the not-null bitmap for the bsiGroup.
 		if predicates[0] <= bsig.Min && predicates[1] >= bsig.Max {
-			return frag.notNull(tx)
+			return frag.bsiExists(tx, filter)
 		}
 
-		return frag.rangeBetween(tx, bsig.BitDepth, baseValueMin, baseValueMax)
+		return frag.rangeBetween(tx, filter, bsig.BitDepth, baseValueMin, baseValueMax)
+
+	} else if cond.Op == pql.IN {
+		values, ok := cond.Value.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("Row(): in condition requires a list of values, got %v", cond.Value)
+		}
+
+		// Find bsiGroup.
+		bsig := f.bsiGroup(fieldName)
+		if bsig == nil {
+			return nil, ErrBSIGroupNotFound
+		}
+
+		// Values out of the bsiGroup's range can't match anything.
+		predicates := make([]int64, 0, len(values))
+		for _, v := range values {
+			value, err := getScaledInt(f, v)
+			if err != nil {
+				return nil, errors.Wrap(err, "getting scaled integer")
+			}
+			if baseValue, outOfRange := bsig.baseValue(pql.EQ, value); !outOfRange {
+				predicates = append(predicates, baseValue)
+			}
+		}
+
+		// Retrieve fragment.
+		frag := e.Holder.fragment(index, fieldName, viewBSIGroupPrefix+fieldName, shard)
+		if frag == nil {
+			return NewRow(), nil
+		}
+
+		return frag.rangeIn(tx, filter, bsig.BitDepth, predicates)
 
 	} else {
 		value, err := getScaledInt(f, cond.Value)
@@ -5028,15 +6440,15 @@ func (e *executor) executeRowBSIGroupShard(ctx context.Context, qcx *Qcx, index
 		// LT[E] and GT[E] should return all not-null if selected range fully encompasses valid bsiGroup range.
 		if (cond.Op == pql.LT && value > bsig.Max) || (cond.Op == pql.LTE && value >= bsig.Max) ||
 			(cond.Op == pql.GT && value < bsig.Min) || (cond.Op == pql.GTE && value <= bsig.Min) {
-			return frag.notNull(tx)
+			return frag.bsiExists(tx, filter)
 		}
 
 		// outOfRange for NEQ should return all not-null.
 		if outOfRange && cond.Op == pql.NEQ {
-			return frag.notNull(tx)
+			return frag.bsiExists(tx, filter)
 		}
 
-		return frag.rangeOp(tx, cond.Op, bsig.BitDepth, baseValue)
+		return frag.rangeOp(tx, filter, cond.Op, bsig.BitDepth, baseValue)
 	}
 }
 
@@ -5049,22 +6461,66 @@ func (e *executor) executeIntersectShard(ctx context.Context, qcx *Qcx, index st
 	if len(c.Children) == 0 {
 		return nil, fmt.Errorf("empty Intersect query is currently not supported")
 	}
+
+	// Compute the inputs from the smallest estimated size up.
+	steps := make([]*intersectStep, len(c.Children))
 	for i, input := range c.Children {
-		row, err := e.executeBitmapCallShard(ctx, qcx, index, input, shard)
+		steps[i] = &intersectStep{call: input}
+	}
+	idx := e.Holder.Index(index)
+	unplanned := execPathFromContext(ctx) == ExecPathUnplanned
+	if idx != nil && len(c.Children) > 1 && !unplanned {
+		if steps, err = e.planIntersect(ctx, idx, c.Children, shard); err != nil {
+			return nil, errors.Wrap(err, "planning intersect")
+		}
+	}
+	_, profiled := span.(tracing.ProfiledSpan)
+
+	// Comparisons of BSI fields read every bit slice of each container
+	// they scan, so they're computed last, and only for the columns the
+	// other inputs have left, skipping containers those have emptied.
+	var ranges []*intersectStep
+	for _, step := range steps {
+		if isBSIRangeCall(step.call) && !unplanned {
+			ranges = append(ranges, step)
+			continue
+		}
+		row, err := e.executeBitmapCallShard(ctx, qcx, index, step.call, shard)
 		if err != nil {
 			return nil, err
 		}
+		if profiled {
+			step.Actual = row.Count()
+		}
 
-		if i == 0 {
+		if other == nil {
 			other = row
 		} else {
 			other = other.Intersect(row)
 		}
 	}
+	for _, step := range ranges {
+		row, err := e.executeRowBSIGroupShard(ctx, qcx, index, step.call, shard, other)
+		if err != nil {
+			return nil, err
+		}
+		other = row
+		if profiled {
+			step.Actual = row.Count()
+		}
+	}
+	if profiled {
+		span.LogKV("plan", steps)
+	}
 	other.invalidateCount()
 	return other, nil
 }
 
+// isBSIRangeCall reports whether c compares the values of a BSI field.
+func isBSIRangeCall(c *pql.Call) bool {
+	return (c.Name == "Row" || c.Name == "Range") && c.HasConditionArg()
+}
+
 // executeUnionShard executes a union() call for a local shard.
 func (e *executor) executeUnionShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (out *Row, err error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeUnionShard")
@@ -5087,13 +6543,17 @@ func (e *executor) executeUnionShard(ctx context.Context, qcx *Qcx, index string
 	return rows[0].Union(rows[1:]...), nil
 }
 
-// executeInnerUnionRowsShard executes a special magical call which is actually
+// executeInnerRowsShard executes a special magical call which is actually
 // more like Row() than Union(), and takes a call plus a []uint64 of rows, and
-// generates the union of the rows in the []uint64.
-func (e *executor) executeInnerUnionRowsShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (out *Row, err0 error) {
-	span, _ := tracing.StartSpanFromContext(ctx, "Executor.executeInnerUnionRowsShard")
+// generates the union (for InnerUnionRows) or intersection (for
+// InnerIntersectRows) of the rows in the []uint64. Without a list of rows,
+// InnerUnionRows unions every row of the field present in the shard.
+func (e *executor) executeInnerRowsShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (out *Row, err0 error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "Executor.executeInnerRowsShard")
 	defer span.Finish()
 
+	intersect := c.Name == "InnerIntersectRows"
+
 	// Fetch index.
 	idx := e.Holder.Index(index)
 	if idx == nil {
@@ -5105,18 +6565,22 @@ func (e *executor) executeInnerUnionRowsShard(ctx context.Context, qcx *Qcx, ind
 		return nil, errors.Wrap(err, "finding field")
 	}
 	if !ok {
-		return nil, errors.New("InnerUnionRows requires _field")
+		return nil, errors.Errorf("%s requires _field", c.Name)
 	}
 
 	f := idx.Field(fieldName)
 	if f == nil {
 		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
 	}
+	loc, err := callLocation(c, f)
+	if err != nil {
+		return nil, err
+	}
 
 	// Parse "from" time, if set.
 	var fromTime time.Time
 	if v, ok := c.Args["from"]; ok {
-		if fromTime, err = parseTime(v); err != nil {
+		if fromTime, err = parseTimeIn(v, loc); err != nil {
 			return nil, errors.Wrap(err, "parsing from time")
 		}
 	}
@@ -5124,7 +6588,7 @@ func (e *executor) executeInnerUnionRowsShard(ctx context.Context, qcx *Qcx, ind
 	// Parse "to" time, if set.
 	var toTime time.Time
 	if v, ok := c.Args["to"]; ok {
-		if toTime, err = parseTime(v); err != nil {
+		if toTime, err = parseTimeIn(v, loc); err != nil {
 			return nil, errors.Wrap(err, "parsing to time")
 		}
 	}
@@ -5134,66 +6598,117 @@ func (e *executor) executeInnerUnionRowsShard(ctx context.Context, qcx *Qcx, ind
 		return nil, fmt.Errorf("extracting rows argument: %v", err)
 	}
 	if !rowOK {
-		return nil, fmt.Errorf("InnerUnionRows() must specify rows")
-	}
-
-	// Simply return row if times are not set.
-	timeNotSet := fromTime.IsZero() && toTime.IsZero()
-	if timeNotSet {
-		frag := e.Holder.fragment(index, fieldName, viewStandard, shard)
-		if frag == nil {
-			return NewRow(), nil
+		if intersect {
+			return nil, fmt.Errorf("%s() must specify rows", c.Name)
+		}
+		switch f.Type() {
+		case FieldTypeSet, FieldTypeMutex, FieldTypeTime:
+		default:
+			return nil, errors.Errorf("%s fields not supported by Rows() query", f.Type())
 		}
+	}
 
-		tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Fragment: frag, Index: idx, Shard: shard})
-		if err != nil {
+	// Only use the standard view if times are not set, unless it's a time
+	// field without one, whose rows are all in its time views.
+	views := []string{viewStandard}
+	if !fromTime.IsZero() || !toTime.IsZero() || f.Type() == FieldTypeTime {
+		if views, err = f.viewsByTimeRange(fromTime, toTime); err != nil {
 			return nil, err
 		}
-		defer finisher(&err0)
-		row, err := frag.unionRows(ctx, tx, rowIDs)
-		if qcx.write && err == nil {
-			row = row.Clone()
+	}
+
+	var frags []*fragment
+	for _, view := range views {
+		if frag := e.Holder.fragment(index, fieldName, view, shard); frag != nil {
+			frags = append(frags, frag)
 		}
-		return row, err
+	}
+	if len(frags) == 0 {
+		return NewRow(), nil
 	}
 
-	views, err := f.viewsByTimeRange(fromTime, toTime)
+	txo := Txo{Write: !writable, Index: idx, Shard: shard}
+	if len(frags) == 1 {
+		txo.Fragment = frags[0]
+	}
+	tx, finisher, err := qcx.GetTx(txo)
 	if err != nil {
 		return nil, err
 	}
-
-	// Union bitmaps across all time-based views.
-	rows := make([]*Row, 0, len(views))
-	tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Shard: shard})
 	defer finisher(&err0)
-	for _, view := range views {
-		f := e.Holder.fragment(index, fieldName, view, shard)
-		if f == nil {
-			continue
-		}
-		if err != nil {
+
+	var row *Row
+	if intersect {
+		row, err = intersectFragmentRows(tx, frags, rowIDs)
+	} else {
+		row, err = unionFragmentRows(ctx, tx, frags, rowIDs, rowOK)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if qcx.write {
+		row = row.Clone()
+	}
+	return row, nil
+}
+
+// unionFragmentRows returns the union of the rows with the given IDs in
+// each fragment, or of every row in each fragment if !rowOK.
+func unionFragmentRows(ctx context.Context, tx Tx, frags []*fragment, rowIDs []uint64, rowOK bool) (*Row, error) {
+	rows := make([]*Row, 0, len(frags))
+	for _, frag := range frags {
+		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
-
-		row, err := f.unionRows(ctx, tx, rowIDs)
+		ids := rowIDs
+		if !rowOK {
+			var err error
+			if ids, err = frag.rows(ctx, tx, 0); err != nil {
+				return nil, err
+			}
+		}
+		row, err := frag.unionRows(ctx, tx, ids)
 		if err != nil {
 			return nil, err
 		}
 		rows = append(rows, row)
 	}
-	if len(rows) == 0 {
-		return &Row{}, nil
-	} else if len(rows) == 1 {
-		if qcx.write {
-			return rows[0].Clone(), nil
-		}
+	if len(rows) == 1 {
 		return rows[0], nil
 	}
-	row := rows[0].Union(rows[1:]...)
-	if qcx.write {
-		row = row.Clone()
+	return rows[0].Union(rows[1:]...), nil
+}
+
+// intersectFragmentRows returns the intersection of the rows with the given
+// IDs, each of which is the union of that row in every fragment.
+func intersectFragmentRows(tx Tx, frags []*fragment, rowIDs []uint64) (*Row, error) {
+	var out *Row
+	for _, id := range rowIDs {
+		rows := make([]*Row, 0, len(frags))
+		for _, frag := range frags {
+			row, err := frag.row(tx, id)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+		row := rows[0]
+		if len(rows) > 1 {
+			row = row.Union(rows[1:]...)
+		}
+		if out == nil {
+			out = row
+		} else {
+			out = out.Intersect(row)
+		}
+		if !out.Any() {
+			break
+		}
 	}
-	return row, nil
+	if out == nil {
+		return NewRow(), nil
+	}
+	return out, nil
 }
 
 // executeXorShard executes a xor() call for a local shard.
@@ -5248,12 +6763,9 @@ func (e *executor) executeNotShard(ctx context.Context, qcx *Qcx, index string,
 		return nil, errors.New("Not() only accepts a single row input")
 	}
 
-	// Make sure the index supports existence tracking.
 	idx := e.Holder.Index(index)
 	if idx == nil {
 		return nil, newNotFoundError(ErrIndexNotFound, index)
-	} else if idx.existenceField() == nil {
-		return nil, errors.Errorf("index does not support existence tracking: %s", index)
 	}
 
 	tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Shard: shard})
@@ -5262,17 +6774,12 @@ func (e *executor) executeNotShard(ctx context.Context, qcx *Qcx, index string,
 	}
 	defer finisher(nil)
 
-	var existenceRow *Row
-	existenceFrag := e.Holder.fragment(index, existenceFieldName, viewStandard, shard)
-	if existenceFrag == nil {
-		existenceRow = NewRow()
-	} else {
-		if existenceRow, err = existenceFrag.row(tx, 0); err != nil {
-			return nil, err
-		}
-		if qcx.write {
-			existenceRow = existenceRow.Clone()
-		}
+	existenceRow, err := e.existenceRowShard(ctx, tx, idx, shard)
+	if err != nil {
+		return nil, err
+	}
+	if qcx.write {
+		existenceRow = existenceRow.Clone()
 	}
 	// the finishers returned by a write tx, which we might be in if there's
 	// a higher-level write in this call OR ANY OTHER CALL, are safe to
@@ -5300,6 +6807,47 @@ func (e *executor) executeConstRow(ctx context.Context, index string, c *pql.Cal
 
 func (e *executor) executeUnionRows(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (*Row, error) {
 	// Turn UnionRows(Rows(...)) into Union(Row(...), ...).
+	rows, err := e.rowsCalls(ctx, qcx, index, c, shards, opt, "InnerUnionRows")
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a Union call over the rows.
+	c = &pql.Call{
+		Name:     "Union",
+		Children: rows,
+	}
+
+	// Execute the generated Union() call.
+	return e.executeBitmapCall(ctx, qcx, index, c, shards, opt)
+}
+
+func (e *executor) executeIntersectRows(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (*Row, error) {
+	// Turn IntersectRows(Rows(...)) into Intersect(Row(...), ...).
+	rows, err := e.rowsCalls(ctx, qcx, index, c, shards, opt, "InnerIntersectRows")
+	if err != nil {
+		return nil, err
+	} else if len(rows) == 0 {
+		// The intersection of no rows is empty, rather than everything.
+		return NewRow(), nil
+	}
+
+	// Generate an Intersect call over the rows.
+	c = &pql.Call{
+		Name:     "Intersect",
+		Children: rows,
+	}
+
+	// Execute the generated Intersect() call.
+	return e.executeBitmapCall(ctx, qcx, index, c, shards, opt)
+}
+
+// rowsCalls turns the Rows() and TopN() children of c into calls returning
+// the rows they select, combining the rows selected by each Rows() call
+// with an inner call (InnerUnionRows or InnerIntersectRows). Calls which
+// select no rows are left out, except that for an intersection, no calls
+// are returned at all.
+func (e *executor) rowsCalls(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions, inner string) ([]*pql.Call, error) {
 	var rows []*pql.Call
 	for _, child := range c.Children {
 		// Check that we can use the call.
@@ -5310,6 +6858,24 @@ func (e *executor) executeUnionRows(ctx context.Context, qcx *Qcx, index string,
 			return nil, errors.Errorf("cannot use %v as a rows query", child)
 		}
 
+		// Combine the rows within each shard if we can, rather than
+		// gathering the list of rows first.
+		if child.Name == "Rows" {
+			rowCall, ok, err := e.shardLocalRowsCall(ctx, index, child, inner)
+			if err != nil {
+				return nil, err
+			} else if ok {
+				if ids, ok := rowCall.Args["rows"].([]uint64); ok && len(ids) == 0 {
+					if inner == "InnerIntersectRows" {
+						return nil, nil
+					}
+					continue
+				}
+				rows = append(rows, rowCall)
+				continue
+			}
+		}
+
 		// Execute the call.
 		rowsResult, err := e.executeCall(ctx, qcx, index, child, shards, opt)
 		if err != nil {
@@ -5339,9 +6905,12 @@ func (e *executor) executeUnionRows(ctx context.Context, qcx *Qcx, index string,
 				})
 			}
 		case RowIDs:
-			// Make a single InnerUnionRows from this
+			if len(rowsResult) == 0 {
+				break
+			}
+			// Make a single inner call from this
 			resultRows = append(resultRows, &pql.Call{
-				Name: "InnerUnionRows",
+				Name: inner,
 				Args: map[string]interface{}{
 					"_field": child.Args["_field"],
 					"rows":   []uint64(rowsResult),
@@ -5351,6 +6920,11 @@ func (e *executor) executeUnionRows(ctx context.Context, qcx *Qcx, index string,
 			return nil, errors.Errorf("unexpected Rows type %T", rowsResult)
 		}
 
+		// A call selecting no rows empties an intersection.
+		if len(resultRows) == 0 && inner == "InnerIntersectRows" {
+			return nil, nil
+		}
+
 		// Propogate any special properties of the call.
 		switch child.Name {
 		case "Rows":
@@ -5367,19 +6941,67 @@ func (e *executor) executeUnionRows(ctx context.Context, qcx *Qcx, index string,
 					rowCall.Args["to"] = v
 				}
 			}
+
+			// Propogate "tz", if set.
+			if v, ok := child.Args["tz"]; ok {
+				for _, rowCall := range resultRows {
+					rowCall.Args["tz"] = v
+				}
+			}
 		}
 
 		rows = append(rows, resultRows...)
 	}
+	return rows, nil
+}
 
-	// Generate a Union call over the rows.
-	c = &pql.Call{
-		Name:     "Union",
-		Children: rows,
+// shardLocalRowsCall returns an inner call which combines the rows selected
+// by a Rows() call within each shard, without first gathering the list of
+// rows across the cluster. This isn't possible for Rows() calls which limit
+// or page through the rows, since which rows are selected depends on the
+// other shards. Nor is it possible to intersect every row of a field, since
+// a row missing from one shard must still empty that shard's intersection.
+func (e *executor) shardLocalRowsCall(ctx context.Context, index string, c *pql.Call, inner string) (*pql.Call, bool, error) {
+	for _, arg := range []string{"limit", "previous", "column"} {
+		if _, ok := c.Args[arg]; ok {
+			return nil, false, nil
+		}
+	}
+	fieldName, err := c.FirstStringArg("_field", "field")
+	if err != nil || fieldName == "" {
+		return nil, false, errors.New("Rows() field required")
 	}
 
-	// Execute the generated Union() call.
-	return e.executeBitmapCall(ctx, qcx, index, c, shards, opt)
+	args := map[string]interface{}{"_field": fieldName}
+	for _, arg := range []string{"from", "to", "tz"} {
+		if v, ok := c.Args[arg]; ok {
+			args[arg] = v
+		}
+	}
+
+	if ids, found, err := c.UintSliceArg("in"); err != nil {
+		return nil, false, errors.Wrapf(err, "'in' argument of Rows must be a slice")
+	} else if found {
+		for arg := range c.Args {
+			if arg != "field" && arg != "_field" && arg != "in" {
+				return nil, false, errors.Errorf("Rows call with 'in' does not support other arguments, but found '%s'", arg)
+			}
+		}
+		args["rows"] = ids
+	} else if like, hasLike, err := c.StringArg("like"); err != nil {
+		return nil, false, errors.Wrap(err, "getting like pattern")
+	} else if hasLike {
+		// Only the primary can match keys, so the matching row IDs are
+		// found up front, but not which of them have any columns set.
+		matches, err := e.Cluster.matchField(ctx, e.Holder.Field(index, fieldName), like)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "matching like pattern")
+		}
+		args["rows"] = matches
+	} else if inner == "InnerIntersectRows" {
+		return nil, false, nil
+	}
+	return &pql.Call{Name: inner, Args: args}, true, nil
 }
 
 // executeAllCallShard executes an All() call for a local shard.
@@ -5391,32 +7013,23 @@ func (e *executor) executeAllCallShard(ctx context.Context, qcx *Qcx, index stri
 		return nil, errors.New("All() does not accept an input row")
 	}
 
-	// Make sure the index supports existence tracking.
 	idx := e.Holder.Index(index)
 	if idx == nil {
 		return nil, newNotFoundError(ErrIndexNotFound, index)
-	} else if idx.existenceField() == nil {
-		return nil, errors.Errorf("index does not support existence tracking: %s", index)
 	}
 
-	var existenceRow *Row
+	// Without existence tracking, All() may need every field's fragments.
 	existenceFrag := e.Holder.fragment(index, existenceFieldName, viewStandard, shard)
-	if existenceFrag == nil {
-		existenceRow = NewRow()
-	} else {
-		tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Fragment: existenceFrag, Shard: shard})
-		if err != nil {
-			return nil, err
-		}
-
-		defer finisher(&err0)
-
-		if existenceRow, err = existenceFrag.row(tx, 0); err != nil {
-			return nil, err
-		}
+	if existenceFrag == nil && idx.existenceField() != nil {
+		return NewRow(), nil
+	}
+	tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Fragment: existenceFrag, Shard: shard})
+	if err != nil {
+		return nil, err
 	}
+	defer finisher(&err0)
 
-	return existenceRow, nil
+	return e.existenceRowShard(ctx, tx, idx, shard)
 }
 
 // executeShiftShard executes a shift() call for a local shard.
@@ -5599,6 +7212,9 @@ func (e *executor) executeClearRow(ctx context.Context, qcx *Qcx, index string,
 	default:
 		return false, fmt.Errorf("ClearRow() is not supported on %s field types", field.Type())
 	}
+	if _, _, _, err := rowTimeRange(c, field); err != nil {
+		return false, err
+	}
 
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
@@ -5653,6 +7269,11 @@ func (e *executor) executeClearRowShard(ctx context.Context, qcx *Qcx, index str
 		return false, newNotFoundError(ErrFieldNotFound, fieldName)
 	}
 
+	from, to, hasRange, err := rowTimeRange(c, field)
+	if err != nil {
+		return false, err
+	}
+
 	idx := e.Holder.Index(index)
 	tx, finisher, err := qcx.GetTx(Txo{Write: writable, Index: idx, Shard: shard})
 	if err != nil {
@@ -5660,6 +7281,10 @@ func (e *executor) executeClearRowShard(ctx context.Context, qcx *Qcx, index str
 	}
 	defer finisher(&err0)
 
+	if hasRange {
+		return e.clearRowTimeRange(tx, field, shard, rowID, from, to)
+	}
+
 	// Remove the row from all views.
 	changed := false
 	for _, view := range field.views() {
@@ -5690,8 +7315,11 @@ func (e *executor) executeSetRow(ctx context.Context, qcx *Qcx, indexName string
 	if field == nil {
 		return false, newNotFoundError(ErrFieldNotFound, fieldName)
 	}
-	// Ensure the field type supports Store().
-	if field.Type() != FieldTypeSet {
+	// Ensure the field type supports Store(). Time fields do with a time
+	// range.
+	if _, _, hasRange, err := rowTimeRange(c, field); err != nil {
+		return false, err
+	} else if field.Type() != FieldTypeSet && !hasRange {
 		return false, fmt.Errorf("can't Store() on a %s field", field.Type())
 	}
 
@@ -5763,6 +7391,25 @@ func (e *executor) executeSetRowShard(ctx context.Context, qcx *Qcx, index strin
 		return false, errors.New("Store() requires a source row")
 	}
 
+	from, to, hasRange, err := rowTimeRange(c, field)
+	if err != nil {
+		return false, err
+	} else if hasRange {
+		idx := e.Holder.Index(index)
+		tx, finisher, err := qcx.GetTx(Txo{Write: writable, Index: idx, Shard: shard})
+		if err != nil {
+			return false, err
+		}
+		defer finisher(&err0)
+
+		cleared, err := e.clearRowTimeRange(tx, field, shard, rowID, from, to)
+		if err != nil {
+			return false, err
+		}
+		set, err := e.setRowAtTime(tx, field, shard, rowID, src, from)
+		return cleared || set, err
+	}
+
 	// Set the row on the standard view.
 	changed := false
 	fragment := e.Holder.fragment(index, fieldName, viewStandard, shard)
@@ -5812,6 +7459,9 @@ func (e *executor) executeSet(ctx context.Context, qcx *Qcx, index string, c *pq
 	if idx == nil {
 		return false, ErrIndexNotFound
 	}
+	if err := e.Holder.checkWrite(idx, colID/ShardWidth, false); err != nil {
+		return false, err
+	}
 
 	// Read field name.
 	fieldName, err := c.FieldArg()
@@ -5856,6 +7506,11 @@ func (e *executor) executeSet(ctx context.Context, qcx *Qcx, index string, c *pq
 		}
 		return e.executeSetValueField(ctx, qcx, index, c, f, colID, rowVal, opt)
 
+	case FieldTypeBool:
+		if c.Args[fieldName] == nil {
+			return e.executeSetBoolNull(ctx, qcx, index, c, f, colID, opt)
+		}
+		fallthrough
 	default:
 		// Read row ID.
 		rowID, ok, err := c.UintArg(fieldName)
@@ -6005,12 +7660,23 @@ func (e *executor) remoteExec(ctx context.Context, node *disco.Node, index strin
 		Remote:       true,
 		EmbeddedData: embed,
 		MaxMemory:    maxMemory,
+
+		ExistenceFallback: existenceFallbackFromContext(ctx),
+		Priority:          queryPriorities[queryPriorityFromContext(ctx)],
+		ExecPath:          execPathFromContext(ctx),
+		Client:            queryClientFromContext(ctx),
+		Hints:             queryHintsFromContext(ctx),
+
+		IgnoreResultLimits: ignoreResultLimitsFromContext(ctx),
 	}
 
 	resp, err := e.client.QueryNode(ctx, &node.URI, index, pbreq)
 	if err != nil {
 		return nil, err
 	}
+	if resp.load != nil {
+		e.router.report(node.ID, *resp.load)
+	}
 
 	return resp.Results, resp.Err
 }
@@ -6018,7 +7684,19 @@ func (e *executor) remoteExec(ctx context.Context, node *disco.Node, index strin
 // shardsByNode returns a mapping of nodes to shards.
 // Returns errShardUnavailable if a shard cannot be allocated to a node.
 func (e *executor) shardsByNode(nodes []*disco.Node, index string, shards []uint64) (map[*disco.Node][]uint64, error) {
-	m := make(map[*disco.Node][]uint64)
+	m, missing := e.assignShards(nodes, index, shards, false)
+	if len(missing) > 0 {
+		return nil, errors.Wrapf(errShardUnavailable, "%s:%d:%v", index, missing[0], nodes)
+	}
+	return m, nil
+}
+
+// assignShards returns a mapping of nodes to shards, and the shards which
+// can't be allocated to any of nodes. Each shard goes to its first available
+// owner, or, if preferReplicas is set, to the first of them which isn't
+// overloaded or degraded.
+func (e *executor) assignShards(nodes []*disco.Node, index string, shards []uint64, preferReplicas bool) (m map[*disco.Node][]uint64, missing []uint64) {
+	m = make(map[*disco.Node][]uint64)
 
 	// Create a snapshot of the cluster to use for node/partition calculations.
 	// We use e.Cluster.Nodes() here instead of e.Cluster.noder because we need
@@ -6026,20 +7704,32 @@ func (e *executor) shardsByNode(nodes []*disco.Node, index string, shards []uint
 	// node in the map of nodes to which we distribute the query.
 	snap := disco.NewClusterSnapshot(disco.NewLocalNoder(e.Cluster.Nodes()), e.Cluster.Hasher, e.Cluster.partitionAssigner, e.Cluster.ReplicaN)
 
-loop:
 	for _, shard := range shards {
+		var fallback *disco.Node
 		for _, node := range snap.ShardNodes(index, shard) {
 			// If the node being considered is in any state other than STARTED,
 			// then exclude it from the map. This way, one of that node's
 			// healthy replicas will be included instead.
-			if disco.Nodes(nodes).ContainsID(node.ID) && (node.State == disco.NodeStateStarted || node.State == disco.NodeStateUnknown) {
-				m[node] = append(m[node], shard)
-				continue loop
+			if !disco.Nodes(nodes).ContainsID(node.ID) || (node.State != disco.NodeStateStarted && node.State != disco.NodeStateUnknown) {
+				continue
+			}
+			// Prefer replicas which aren't overloaded or degraded, but use
+			// the first available node if they all are.
+			if fallback == nil {
+				fallback = node
+			}
+			if !preferReplicas || !e.router.avoid(node.ID) {
+				fallback = node
+				break
 			}
 		}
-		return nil, errors.Wrapf(errShardUnavailable, "%s:%d:%v", index, shard, nodes)
+		if fallback == nil {
+			missing = append(missing, shard)
+			continue
+		}
+		m[fallback] = append(m[fallback], shard)
 	}
-	return m, nil
+	return m, missing
 }
 
 // mapReduce maps and reduces data across the cluster.
@@ -6083,8 +7773,20 @@ func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64,
 		nodes = []*disco.Node{e.Cluster.nodeByID(e.Node.ID)}
 	}
 
+	// Reduce the results from each node as they arrive. The deferred call
+	// stops any workers on early returns.
+	red := e.newReducer(ctx, c, reduceFn, len(nodes))
+	defer red.finish() //nolint:errcheck
+
+	// Queries allowing partial results never give up on the remaining
+	// nodes when one fails.
+	partial := partialResultsFromContext(ctx)
+	if partial != nil && !opt.Remote {
+		partial.query(shards)
+	}
+
 	// Start mapping across all primary owners.
-	if err = e.mapper(ctx, eg, ch, nodes, index, shards, c, opt, e.Cluster.ReplicaN == 1, mapFn, reduceFn); err != nil {
+	if err = e.mapper(ctx, eg, ch, nodes, index, shards, c, opt, e.Cluster.ReplicaN == 1 && partial == nil, mapFn, reduceFn); err != nil {
 		return nil, errors.Wrap(err, "starting mapper")
 	}
 
@@ -6096,6 +7798,10 @@ func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64,
 		case <-done:
 			return nil, ctx.Err()
 		case resp := <-ch:
+			if resp.missing {
+				expected -= len(resp.shards)
+				continue
+			}
 			// On error retry against remaining nodes. If an error returns then
 			// the context will cancel and cause all open goroutines to return.
 
@@ -6109,7 +7815,7 @@ func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64,
 				nodes = disco.Nodes(nodes).FilterID(resp.node.ID)
 
 				// Begin mapper against secondary nodes.
-				if err := e.mapper(ctx, eg, ch, nodes, index, resp.shards, c, opt, true, mapFn, reduceFn); errors.Cause(err) == errShardUnavailable {
+				if err := e.mapper(ctx, eg, ch, nodes, index, resp.shards, c, opt, partial == nil, mapFn, reduceFn); errors.Cause(err) == errShardUnavailable {
 					return nil, resp.err
 				} else if err != nil {
 					return nil, errors.Wrap(err, "mapping on secondary node")
@@ -6123,17 +7829,15 @@ func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64,
 			expected -= len(resp.shards)
 
 			// Reduce value.
-			result = reduceFn(ctx, result, resp.result)
-			var ok bool
 			// note *not* shadowed.
-			if err, ok = result.(error); ok {
+			if err = red.add(resp.result); err != nil {
 				cancel()
 				return nil, err
 			}
 		}
 	}
 	// note the deferred Wait above which might override this nil.
-	return result, nil
+	return red.finish()
 }
 
 // makeEmbeddedDataForShards produces new rows containing the rowSegments
@@ -6185,11 +7889,23 @@ func (e *executor) mapper(ctx context.Context, eg *errgroup.Group, ch chan mapRe
 	defer span.Finish()
 
 	// Group shards together by nodes.
-	m, err := e.shardsByNode(nodes, index, shards)
-	if err != nil {
-		return errors.Wrapf(err, "shards by node")
-	}
+	m, missing := e.assignShards(nodes, index, shards, e.router.preferReplicas(index, c))
 	done := ctx.Done()
+	if len(missing) > 0 {
+		partial := partialResultsFromContext(ctx)
+		if partial == nil || opt.Remote {
+			return errors.Wrapf(errors.Wrapf(errShardUnavailable, "%s:%d:%v", index, missing[0], nodes), "shards by node")
+		}
+		partial.miss(missing)
+		eg.Go(func() error {
+			select {
+			case <-done:
+				return ctx.Err()
+			case ch <- mapResponse{shards: missing, missing: true}:
+			}
+			return nil
+		})
+	}
 
 	// Execute each node in a separate goroutine.
 	var memoryUsed int64
@@ -6213,9 +7929,13 @@ func (e *executor) mapper(ctx context.Context, eg *errgroup.Group, ch chan mapRe
 				memoryAvailable = math.MaxInt64
 			}
 
-			// Send local shards to mapper, otherwise remote exec.
+			// Send local shards to mapper, otherwise remote exec. The time
+			// each node takes is used to route later queries.
+			start := time.Now()
+			e.router.begin(n.ID, len(nodeShards))
 			if n.ID == e.Node.ID {
-				resp.result, resp.err = e.mapperLocal(ctx, nodeShards, mapFn, reduceFn, memoryAvailable)
+				resp.result, resp.err = e.mapperLocal(ctx, index, c, nodeShards, mapFn, reduceFn, memoryAvailable)
+				e.router.report(n.ID, e.localLoad())
 			} else if !opt.Remote {
 				var embeddedRowsForNode []*Row
 				if opt.EmbeddedData != nil {
@@ -6227,6 +7947,7 @@ func (e *executor) mapper(ctx context.Context, eg *errgroup.Group, ch chan mapRe
 				}
 				resp.err = err
 			}
+			e.router.end(n.ID, len(nodeShards), time.Since(start), resp.err == nil)
 
 			// Track total memory used in response.
 			if v := atomic.AddInt64(&memoryUsed, calcResultMemory(resp.result)); opt.MaxMemory > 0 && v > opt.MaxMemory {
@@ -6319,13 +8040,14 @@ type job struct {
 	shard           uint64
 	mapFn           mapFunc
 	ctx             context.Context
+	client          string
 	memoryAvailable *int64 // shared, atomic value
 	resultChan      chan mapResponse
 }
 
 // doOneJob had one job. *disappointed sigh*
 func (e *executor) doOneJob() {
-	j, ok := <-e.work
+	j, ok := e.work.next()
 	if !ok {
 		return
 	}
@@ -6333,17 +8055,21 @@ func (e *executor) doOneJob() {
 	// an ack so mapperLocal can be sure we aren't about to
 	// work on something it sent us.
 	if err := j.ctx.Err(); err != nil {
+		e.work.finished(j)
 		j.resultChan <- mapResponse{result: nil, err: err}
 		return
 	}
+	done := startJob(j.ctx)
 	result, err := j.mapFn(j.ctx, j.shard, &mapOptions{memoryAvailable: j.memoryAvailable})
+	done()
+	e.work.finished(j)
 	j.resultChan <- mapResponse{result: result, err: err}
 }
 
 var errShutdown = errors.New("executor has shut down")
 
 // mapperLocal performs map & reduce entirely on the local node.
-func (e *executor) mapperLocal(ctx context.Context, shards []uint64, mapFn mapFunc, reduceFn reduceFunc, memoryAvailable int64) (_ interface{}, err error) {
+func (e *executor) mapperLocal(ctx context.Context, index string, c *pql.Call, shards []uint64, mapFn mapFunc, reduceFn reduceFunc, memoryAvailable int64) (_ interface{}, err error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "executor.mapperLocal")
 	defer span.Finish()
 	ctx, cancel := context.WithCancel(ctx)
@@ -6362,8 +8088,25 @@ func (e *executor) mapperLocal(ctx context.Context, shards []uint64, mapFn mapFu
 	default:
 	}
 
+	// A fragment which couldn't be opened is left out of its shard's
+	// results, so the shard fails rather than giving incomplete ones.
+	if e.Holder != nil {
+		shardFn := mapFn
+		mapFn = func(ctx context.Context, shard uint64, mopt *mapOptions) (interface{}, error) {
+			result, err := shardFn(ctx, shard, mopt)
+			if err == nil {
+				err = e.Holder.unopenedShardErr(index, shard)
+			}
+			return result, err
+		}
+	}
+
 	ch := make(chan mapResponse, len(shards))
 
+	class := queryPriorityFromContext(ctx)
+	room := e.work.reserve(class)
+	client := queryClientFromContext(ctx)
+	start := time.Now()
 	expected := 0
 shardLoop:
 	for _, shard := range shards {
@@ -6371,6 +8114,7 @@ shardLoop:
 			shard:           shard,
 			mapFn:           mapFn,
 			ctx:             ctx,
+			client:          client,
 			resultChan:      ch,
 			memoryAvailable: &memoryAvailable,
 		}
@@ -6379,10 +8123,14 @@ shardLoop:
 			break shardLoop
 		case <-e.shutdown: // whole executor shutting down
 			break shardLoop
-		case e.work <- j:
+		case room <- struct{}{}:
+			e.work.push(class, j)
 			expected++
 		}
 	}
+	if e.Holder != nil {
+		e.Holder.Stats.WithTags("priority:"+queryPriorities[class]).Timing(MetricQueryQueueWaitSeconds, time.Since(start), 1.0)
+	}
 	// we *absolutely must* get responses for everything we successfully
 	// transmitted to the work queue, or there could be ongoing access to
 	// the parent Qcx's stuff.
@@ -6392,7 +8140,7 @@ shardLoop:
 	// going to send them and block waiting for us to receive them.
 
 	// Reduce results
-	var result interface{}
+	red := e.newReducer(ctx, c, reduceFn, expected)
 	for expected > 0 {
 		resp := <-ch
 		expected--
@@ -6403,17 +8151,20 @@ shardLoop:
 			// Only useful to do a possibly-expensive
 			// reduce if we don't already know we don't
 			// need it.
-			result = reduceFn(ctx, result, resp.result)
-			if resultErr, ok := result.(error); ok {
+			if resultErr := red.add(resp.result); resultErr != nil {
 				cancel()
 				err = resultErr
 			}
 		}
 	}
+	result, resultErr := red.finish()
+	if err == nil {
+		err = resultErr
+	}
 	return result, err
 }
 
-func (e *executor) preTranslate(ctx context.Context, index string, calls ...*pql.Call) (cols map[string]map[string]uint64, rows map[string]map[string]map[string]uint64, err error) {
+func (e *executor) preTranslate(ctx context.Context, index string, rejectNewKeys bool, calls ...*pql.Call) (cols map[string]map[string]uint64, rows map[string]map[string]map[string]uint64, err error) {
 	// Collect all of the required keys.
 	collector := keyCollector{
 		createCols: make(map[string][]string),
@@ -6427,6 +8178,9 @@ func (e *executor) preTranslate(ctx context.Context, index string, calls ...*pql
 			return nil, nil, err
 		}
 	}
+	if err := e.rejectNewKeys(ctx, &collector, rejectNewKeys); err != nil {
+		return nil, nil, err
+	}
 
 	// Create keys.
 	// Both rows and columns need to be created first because of foreign index keys.
@@ -6560,6 +8314,10 @@ func (e *executor) collectCallKeys(dst *keyCollector, c *pql.Call, index string)
 
 	case "Clear", "Row", "Range", "ClearRow":
 		if field, err := c.FieldArg(); err == nil {
+			if f := e.Holder.Field(index, field); f != nil && f.Type() == FieldTypeTimestamp {
+				// Strings compared with timestamps are times, not keys.
+				break
+			}
 			switch arg := c.Args[field].(type) {
 			case string:
 				dst.FindRows(index, field, arg)
@@ -6573,6 +8331,14 @@ func (e *executor) collectCallKeys(dst *keyCollector, c *pql.Call, index string)
 						return errors.Errorf("operator %v not defined on strings", arg.Op)
 					}
 				}
+				// Likewise for the keys in an `in` list.
+				if values, ok := arg.Value.([]interface{}); ok && arg.Op == pql.IN {
+					for _, v := range values {
+						if key, ok := v.(string); ok {
+							dst.FindRows(index, field, key)
+						}
+					}
+				}
 			}
 		}
 	}
@@ -6628,6 +8394,12 @@ func (e *executor) collectCallKeys(dst *keyCollector, c *pql.Call, index string)
 			dst.FindColumns(index, keys...)
 		}
 
+	case "Sort":
+		// Find the column to resume after.
+		if after, ok := c.Args["after"].(string); ok {
+			dst.FindColumns(index, after)
+		}
+
 	case "Rows":
 		// Find the field.
 		var field string
@@ -6862,6 +8634,13 @@ func (e *executor) translateCall(c *pql.Call, index string, columnKeys map[strin
 				return nil, errors.Wrapf(ErrFieldNotFound, "validating value for field %q", field)
 			}
 			arg := c.Args[field]
+			if c.Name == "Row" && f.Type() == FieldTypeTimestamp {
+				if arg, err = timestampCondition(f, c, arg); err != nil {
+					return nil, errors.Wrap(err, "converting timestamp")
+				}
+				c.Args[field] = arg
+				delete(c.Args, "timeUnit")
+			}
 			if err := fieldValidateValue(f, arg); err != nil {
 				return nil, errors.Wrap(err, "validating field parameter value")
 			}
@@ -6907,6 +8686,24 @@ func (e *executor) translateCall(c *pql.Call, index string, columnKeys map[strin
 						return nil, errors.Errorf("operator %v not defined on strings", arg.Op)
 					}
 				}
+				// Likewise for the keys in an `in` list. Keys which aren't
+				// found can't match, so they're dropped.
+				if values, ok := arg.Value.([]interface{}); ok && arg.Op == pql.IN {
+					translated := make([]interface{}, 0, len(values))
+					for _, v := range values {
+						key, ok := v.(string)
+						if !ok {
+							translated = append(translated, v)
+						} else if translation, ok := indexRows[field][key]; ok {
+							translated = append(translated, translation)
+						}
+					}
+					if len(translated) == 0 {
+						// Rewrite the call into a zero value call.
+						return e.callZero(c), nil
+					}
+					arg.Value = translated
+				}
 			}
 		}
 	}
@@ -6997,6 +8794,16 @@ func (e *executor) translateCall(c *pql.Call, index string, columnKeys map[strin
 			c.Args["columns"] = out
 		}
 
+	case "Sort":
+		// Translate the column to resume after.
+		if after, ok := c.Args["after"].(string); ok {
+			id, ok := indexCols[after]
+			if !ok {
+				return nil, NewBadRequestError(errors.Errorf("column to sort after not found: %q", after))
+			}
+			c.Args["after"] = id
+		}
+
 	case "Rows":
 		// Find the field.
 		var field string
@@ -7140,6 +8947,11 @@ func (e *executor) translateResults(ctx context.Context, index string, idx *Inde
 		if err != nil {
 			return err
 		}
+		if calls[i].Name == "Options" {
+			if unit, _, _ := calls[i].StringArg("timeUnit"); unit != "" {
+				results[i] = timestampsToUnit(results[i], unit)
+			}
+		}
 	}
 	return nil
 }
@@ -7273,6 +9085,13 @@ func (e *executor) translateResult(ctx context.Context, index string, idx *Index
 					other.Keys = append(other.Keys, idSet[col])
 				}
 			}
+			if call.Name == "All" {
+				if order, err := parseColumnOrder(call); err != nil {
+					return nil, err
+				} else if order.byKey(idx) {
+					other.Keys = orderKeys(other.Keys, order)
+				}
+			}
 			return other, nil
 		case byRowField:
 			keys, err := e.Cluster.translateFieldListIDs(ctx, rowField, result.Columns())
@@ -7321,6 +9140,9 @@ func (e *executor) translateResult(ctx context.Context, index string, idx *Index
 			}
 
 			if field.Keys() {
+				if n := result.Neg.Count(); n > 0 {
+					return nil, SignedInputError{Call: call.Name, Field: fieldName, Negatives: n}
+				}
 				rslt := result.Pos
 				if rslt == nil {
 					return &SignedRow{Pos: &Row{}}, nil
@@ -7399,6 +9221,9 @@ func (e *executor) translateResult(ctx context.Context, index string, idx *Index
 		for _, gl := range groups {
 			for _, g := range gl.Group {
 				field := idx.Field(g.Field)
+				if g.Index != "" {
+					field = e.Holder.Field(g.Index, g.Field)
+				}
 				if field == nil {
 					return nil, newNotFoundError(ErrFieldNotFound, g.Field)
 				}
@@ -7433,7 +9258,7 @@ func (e *executor) translateResult(ctx context.Context, index string, idx *Index
 			if err != nil {
 				return nil, errors.Wrapf(err, "translating IDs in field %q", field.Name())
 			}
-			fieldTranslations[field.Name()] = trans
+			fieldTranslations[field.Index()+"/"+field.Name()] = trans
 		}
 
 		foreignTranslations := make(map[string]map[uint64]string)
@@ -7442,7 +9267,7 @@ func (e *executor) translateResult(ctx context.Context, index string, idx *Index
 			if err != nil {
 				return nil, errors.Wrapf(err, "translating foreign IDs from index %q", field.ForeignIndex())
 			}
-			foreignTranslations[field.Name()] = trans
+			foreignTranslations[field.Index()+"/"+field.Name()] = trans
 		}
 
 		// We are reluctant to smash result, and I'm not sure we need
@@ -7453,9 +9278,14 @@ func (e *executor) translateResult(ctx context.Context, index string, idx *Index
 
 			group := make([]FieldRow, len(gl.Group))
 			for i, g := range gl.Group {
-				if ft, ok := fieldTranslations[g.Field]; ok {
+				// Fields grouped by via a foreign index are in that index.
+				key := idx.Name() + "/" + g.Field
+				if g.Index != "" {
+					key = g.Index + "/" + g.Field
+				}
+				if ft, ok := fieldTranslations[key]; ok {
 					g.RowKey = ft[g.RowID]
-				} else if ft, ok := foreignTranslations[g.Field]; ok && g.Value != nil {
+				} else if ft, ok := foreignTranslations[key]; ok && g.Value != nil {
 					g.RowKey = ft[uint64(*g.Value)]
 					g.Value = nil
 				}
@@ -7496,11 +9326,29 @@ func (e *executor) translateResult(ctx context.Context, index string, idx *Index
 		return other, nil
 
 	case ExtractedIDMatrix:
+		if call.Name == "Extract" {
+			if order, err := parseColumnOrder(call); err != nil {
+				return nil, err
+			} else if order.byKey(idx) {
+				result = orderMatrixByKey(result, order, idSet)
+			}
+		}
+
 		type fieldMapper = func([]uint64) (_ interface{}, err error)
 
 		fields := make([]ExtractedTableField, len(result.Fields))
 		mappers := make([]fieldMapper, len(result.Fields))
 		for i, v := range result.Fields {
+			if _, ok := cardinalityExtractField(v); ok {
+				mappers[i] = func(ids []uint64) (interface{}, error) {
+					if len(ids) == 0 {
+						return int64(0), nil
+					}
+					return int64(ids[0]), nil
+				}
+				fields[i] = ExtractedTableField{Name: v, Type: "int64"}
+				continue
+			}
 			field := idx.Field(v)
 			if field == nil {
 				return nil, newNotFoundError(ErrFieldNotFound, v)
@@ -7682,6 +9530,23 @@ func (e *executor) translateResult(ctx context.Context, index string, idx *Index
 			}
 		}
 
+		var colAttrs map[uint64]map[string]interface{}
+		if call.Name == "Extract" {
+			includeAttrs, _, err := call.BoolArg("includeAttrs")
+			if err != nil {
+				return nil, err
+			}
+			if includeAttrs {
+				ids := make([]uint64, len(result.Columns))
+				for i, col := range result.Columns {
+					ids[i] = col.ColumnID
+				}
+				if colAttrs, err = e.columnAttrs(ctx, index, ids); err != nil {
+					return nil, errors.Wrap(err, "fetching column attributes")
+				}
+			}
+		}
+
 		cols := make([]ExtractedTableColumn, len(result.Columns))
 		colData := make([]interface{}, len(cols)*len(result.Fields))
 		for i, col := range result.Columns {
@@ -7702,6 +9567,7 @@ func (e *executor) translateResult(ctx context.Context, index string, idx *Index
 			cols[i] = ExtractedTableColumn{
 				Column: colTrans,
 				Rows:   data,
+				Attrs:  colAttrs[col.ColumnID],
 			}
 			if *memoryAvailable -= calcResultMemory(cols[i]); *memoryAvailable < 0 {
 				return nil, fmt.Errorf("table exceeds available memory")
@@ -7796,6 +9662,10 @@ type mapResponse struct {
 	node   *disco.Node
 	shards []uint64
 
+	// missing is set if the shards couldn't be reached, and have been left
+	// out of a query allowing partial results.
+	missing bool
+
 	result interface{}
 	err    error
 }
@@ -7807,6 +9677,85 @@ type ExecOptions struct {
 	PreTranslated bool
 	EmbeddedData  []*Row
 	MaxMemory     int64
+
+	// IgnoreResultLimits disables the index's result limits. It is meant
+	// for trusted callers only.
+	IgnoreResultLimits bool
+
+	// IncludeRowMeta attaches row metadata to TopN, Rows, and GroupBy
+	// results.
+	IncludeRowMeta bool
+
+	// ExistenceFallback is used in place of existence tracking for indexes
+	// which don't track it.
+	ExistenceFallback string
+
+	// Priority is the priority class of the query.
+	Priority string
+
+	// AllowPartial leaves shards which can't be reached out of the query,
+	// instead of failing it.
+	AllowPartial bool
+
+	// ExecPath is the execution path the query takes.
+	ExecPath string
+
+	// Client is who made the query.
+	Client string
+
+	// RejectNewKeys fails writes which would create new row or column
+	// keys.
+	RejectNewKeys bool
+
+	// Hints turn off, or force, optimizations of the executor.
+	Hints []string
+
+	// precalls holds the index in EmbeddedData of the result of each
+	// global pre-call of the call being executed, by its precallKey.
+	precalls map[string]int64
+}
+
+// resultLimits returns the result limits which apply to queries against
+// index, or a zero value when opt ignores limits. Extract, GroupBy and
+// Rows check them on every node against the results of its own shards, so
+// that a query exceeding them fails without building its whole result
+// first.
+func (e *executor) resultLimits(index string, opt *ExecOptions) IndexOptions {
+	if opt.IgnoreResultLimits {
+		return IndexOptions{}
+	}
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return IndexOptions{}
+	}
+	return idx.Options()
+}
+
+// resultLimitErr returns err as a ResultLimitError for call if it's one
+// another node returned, which carries only the error's code.
+func resultLimitErr(err error, index, call, kind string, limit uint64) error {
+	var rle ResultLimitError
+	if code, _ := ErrorCodeOf(err); limit > 0 && code == ErrorCodeResultLimitExceeded && !errors.As(err, &rle) {
+		return newResultLimitError(index, call, kind, limit)
+	}
+	return err
+}
+
+type contextKeyIgnoreResultLimitsType struct{}
+
+var contextKeyIgnoreResultLimits = contextKeyIgnoreResultLimitsType{}
+
+// withIgnoreResultLimits returns a context for a query ignoring the result
+// limits of its index, on every node.
+func withIgnoreResultLimits(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyIgnoreResultLimits, true)
+}
+
+// ignoreResultLimitsFromContext reports whether the query running in ctx
+// ignores result limits.
+func ignoreResultLimitsFromContext(ctx context.Context) bool {
+	ignore, _ := ctx.Value(contextKeyIgnoreResultLimits).(bool)
+	return ignore
 }
 
 func needsShards(call *pql.Call) bool {
@@ -7924,6 +9873,21 @@ func toInt64(n uint64) (int64, error) {
 	return int64(n), nil
 }
 
+// page returns up to limit of the values of s, skipping the offset
+// smallest. The negative values, whose magnitudes are in Neg, are the
+// smallest, from the largest magnitude down, followed by the values in Pos.
+func (s SignedRow) page(offset, limit uint64) SignedRow {
+	out := SignedRow{field: s.field}
+	out.Neg = s.Neg.sliceColumns(offset, limit, true)
+	if n := s.Neg.Count(); offset > n {
+		offset -= n
+	} else {
+		offset = 0
+	}
+	out.Pos = s.Pos.sliceColumns(offset, limit-out.Neg.Count(), false)
+	return out
+}
+
 func (sr *SignedRow) union(other SignedRow) SignedRow {
 	ret := SignedRow{&Row{}, &Row{}, ""}
 
@@ -7955,6 +9919,16 @@ type ValCount struct {
 	DecimalVal   *pql.Decimal `json:"decimalValue"`
 	TimestampVal time.Time    `json:"timestampValue"`
 	Count        int64        `json:"count"`
+
+	// BigVal holds sums which don't fit in Val, and Precision is the
+	// precision in bits sums are returned to.
+	BigVal    *big.Int `json:"bigValue,omitempty"`
+	Precision int64    `json:"precision,omitempty"`
+
+	// WeightSum is the sum of the weights of a WeightedAvg, whose sum of
+	// products is in Val or BigVal, on its way from the shards to the
+	// coordinating node.
+	WeightSum *big.Int `json:"-"`
 }
 
 func (v *ValCount) Clone() (r *ValCount) {
@@ -7963,10 +9937,17 @@ func (v *ValCount) Clone() (r *ValCount) {
 		FloatVal:     v.FloatVal,
 		TimestampVal: v.TimestampVal,
 		Count:        v.Count,
+		Precision:    v.Precision,
 	}
 	if v.DecimalVal != nil {
 		r.DecimalVal = v.DecimalVal.Clone()
 	}
+	if v.BigVal != nil {
+		r.BigVal = new(big.Int).Set(v.BigVal)
+	}
+	if v.WeightSum != nil {
+		r.WeightSum = new(big.Int).Set(v.WeightSum)
+	}
 	return
 }
 
@@ -7979,8 +9960,32 @@ func (v ValCount) ToTable() (*proto.TableResponse, error) {
 func (v ValCount) ToRows(callback func(*proto.RowResponse) error) error {
 	var ci []*proto.ColumnInfo
 	// ValCount can have a decimal, float, or integer value, but
-	// not more than one (as of this writing).
+	// not more than one (as of this writing). Sums too large for an
+	// int64 are returned as strings.
+	wide := v.BigVal != nil
 	if v.DecimalVal != nil {
+		value := v.DecimalVal.Value()
+		wide = !value.IsInt64()
+	}
+	if wide {
+		ci = []*proto.ColumnInfo{
+			{Name: "value", Datatype: "string"},
+			{Name: "count", Datatype: "int64"},
+		}
+		value := v.BigVal.String()
+		if v.DecimalVal != nil {
+			value = v.DecimalVal.String()
+		}
+		if err := callback(&proto.RowResponse{
+			Headers: ci,
+			Columns: []*proto.ColumnResponse{
+				{ColumnVal: &proto.ColumnResponse_StringVal{StringVal: value}},
+				{ColumnVal: &proto.ColumnResponse_Int64Val{Int64Val: v.Count}},
+			},
+		}); err != nil {
+			return errors.Wrap(err, "calling callback")
+		}
+	} else if v.DecimalVal != nil {
 		ci = []*proto.ColumnInfo{
 			{Name: "value", Datatype: "decimal"},
 			{Name: "count", Datatype: "int64"},
@@ -8043,10 +10048,25 @@ func (v ValCount) ToRows(callback func(*proto.RowResponse) error) error {
 }
 
 func (vc *ValCount) add(other ValCount) ValCount {
-	return ValCount{
+	sum := ValCount{
 		Val:   vc.Val + other.Val,
 		Count: vc.Count + other.Count,
 	}
+	// Values of the same sign can overflow, in which case the sum has the
+	// other sign.
+	if vc.BigVal != nil || other.BigVal != nil || (vc.Val < 0) == (other.Val < 0) && (sum.Val < 0) != (vc.Val < 0) {
+		s := vc.sum()
+		sum.setSum(s.Add(s, other.sum()))
+	}
+	if vc.WeightSum != nil || other.WeightSum != nil {
+		sum.WeightSum = new(big.Int)
+		for _, w := range []*big.Int{vc.WeightSum, other.WeightSum} {
+			if w != nil {
+				sum.WeightSum.Add(sum.WeightSum, w)
+			}
+		}
+	}
+	return sum
 }
 
 // smaller returns the smaller of the two ValCounts.
@@ -8236,9 +10256,10 @@ type groupByIterator struct {
 	// field to the right require only a single intersect with the row of the
 	// previous field to determine the count of the new group.
 	rows []struct {
-		row   *Row
-		id    uint64
-		value *int64
+		row    *Row
+		id     uint64
+		value  *int64
+		period string
 	}
 
 	// fields helps with the construction of GroupCount results by holding all
@@ -8263,9 +10284,10 @@ func newGroupByIterator(executor *executor, qcx *Qcx, rowIDs []RowIDs, children
 		shard:    shard,
 		rowIters: make([]rowIterator, len(children)),
 		rows: make([]struct {
-			row   *Row
-			id    uint64
-			value *int64
+			row    *Row
+			id     uint64
+			value  *int64
+			period string
 		}, len(children)),
 		filter:    filter,
 		aggregate: aggregate,
@@ -8281,7 +10303,8 @@ func newGroupByIterator(executor *executor, qcx *Qcx, rowIDs []RowIDs, children
 	)
 	ignorePrev := false
 	for i, call := range children {
-		var isTimeField bool
+		var isTimeField, isPeriod bool
+		var buckets []int64
 		if fieldName, ok = call.Args["_field"].(string); !ok {
 			return nil, errors.Errorf("%s call must have field with valid (string) field name. Got %v of type %[2]T", call.Name, call.Args["_field"])
 		}
@@ -8301,6 +10324,10 @@ func newGroupByIterator(executor *executor, qcx *Qcx, rowIDs []RowIDs, children
 				err error
 				v   interface{}
 			)
+			loc, err := callLocation(call, field)
+			if err != nil {
+				return nil, err
+			}
 
 			// Parse "from" time, if set.
 			var (
@@ -8308,7 +10335,7 @@ func newGroupByIterator(executor *executor, qcx *Qcx, rowIDs []RowIDs, children
 				fromTime time.Time
 			)
 			if v, hasFrom = call.Args["from"]; hasFrom {
-				if fromTime, err = parseTime(v); err != nil {
+				if fromTime, err = parseTimeIn(v, loc); err != nil {
 					return nil, errors.Wrap(err, "parsing from time")
 				}
 			}
@@ -8319,12 +10346,23 @@ func newGroupByIterator(executor *executor, qcx *Qcx, rowIDs []RowIDs, children
 				toTime time.Time
 			)
 			if v, hasTo = call.Args["to"]; hasTo {
-				if toTime, err = parseTime(v); err != nil {
+				if toTime, err = parseTimeIn(v, loc); err != nil {
 					return nil, errors.Wrap(err, "parsing to time")
 				}
 			}
 
-			if hasTo || hasFrom {
+			if period, hasPeriod := call.Args["period"].(string); hasPeriod {
+				// Group by each period's view separately.
+				unit, err := periodUnit(period)
+				if err != nil {
+					return nil, err
+				}
+				views, err = field.periodViews(unit, fromTime, toTime)
+				if err != nil {
+					return nil, err
+				}
+				isTimeField, isPeriod = true, true
+			} else if hasTo || hasFrom {
 				// Determine the views based on the specified time range.
 				var err error
 				views, err = field.viewsByTimeRange(fromTime, toTime)
@@ -8337,6 +10375,10 @@ func newGroupByIterator(executor *executor, qcx *Qcx, rowIDs []RowIDs, children
 			}
 		case FieldTypeInt, FieldTypeTimestamp:
 			viewName = viewBSIGroupPrefix + fieldName
+			var err error
+			if buckets, err = groupByBuckets(call); err != nil {
+				return nil, err
+			}
 
 		default: // FieldTypeDecimal
 			return nil, errors.Errorf("%s call must have field of one of types: %s",
@@ -8368,7 +10410,11 @@ func newGroupByIterator(executor *executor, qcx *Qcx, rowIDs []RowIDs, children
 				return nil, nil
 			}
 
-			gbi.rowIters[i], err = timeFragmentsRowIterator(fragments, tx, i != 0, filters...)
+			if isPeriod {
+				gbi.rowIters[i], err = newPeriodRowIterator(fragments, tx, i != 0, filters...)
+			} else {
+				gbi.rowIters[i], err = timeFragmentsRowIterator(fragments, tx, i != 0, filters...)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -8378,7 +10424,11 @@ func newGroupByIterator(executor *executor, qcx *Qcx, rowIDs []RowIDs, children
 				return nil, nil
 			}
 
-			gbi.rowIters[i], err = frag.rowIterator(tx, i != 0, filters...)
+			if buckets != nil {
+				gbi.rowIters[i], err = newBucketRowIterator(frag, tx, field.bsiGroup(fieldName), buckets, i != 0)
+			} else {
+				gbi.rowIters[i], err = frag.rowIterator(tx, i != 0, filters...)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -8404,6 +10454,7 @@ func newGroupByIterator(executor *executor, qcx *Qcx, rowIDs []RowIDs, children
 		gbi.rows[i].row = nextRow
 		gbi.rows[i].id = rowID
 		gbi.rows[i].value = value
+		gbi.rows[i].period = gbi.period(i)
 		if hasPrev && rowID != prev {
 			// ignorePrev signals that we didn't find a previous row, so all
 			// Rows queries "deeper" than it need to ignore the previous
@@ -8425,6 +10476,7 @@ func newGroupByIterator(executor *executor, qcx *Qcx, rowIDs []RowIDs, children
 				gbi.rows[j].row = nextRow
 				gbi.rows[j].id = rowID
 				gbi.rows[j].value = value
+				gbi.rows[j].period = gbi.period(j)
 				if !wrapped {
 					break
 				}
@@ -8474,6 +10526,7 @@ func (gbi *groupByIterator) nextAtIdx(ctx context.Context, i int) (err error) {
 		}
 		gbi.rows[i].id = rowID
 		gbi.rows[i].value = value
+		gbi.rows[i].period = gbi.period(i)
 
 		if !gbi.rows[i].row.IsEmpty() {
 			break
@@ -8482,6 +10535,15 @@ func (gbi *groupByIterator) nextAtIdx(ctx context.Context, i int) (err error) {
 	return nil
 }
 
+// period returns the period of the current row of the field at index i, if
+// it's grouped by period.
+func (gbi *groupByIterator) period(i int) string {
+	if it, ok := gbi.rowIters[i].(*periodRowIterator); ok {
+		return it.period()
+	}
+	return ""
+}
+
 // Next returns a GroupCount representing the next group by record. When there
 // are no more records it will return an empty GroupCount and done==true.
 func (gbi *groupByIterator) Next(ctx context.Context) (ret GroupCount, done bool, err error) {
@@ -8512,9 +10574,53 @@ func (gbi *groupByIterator) Next(ctx context.Context) (ret GroupCount, done bool
 				if err != nil {
 					return ret, false, err
 				}
+				if result.BigVal != nil {
+					return ret, false, errors.Wrapf(ErrSumOverflow, "%s doesn't fit in %d bits", gbi.aggregate, sumPrecisionDefault)
+				}
 				ret.Count = uint64(result.Count)
 				ret.Agg = result.Val
 				ret.DecimalAgg = result.DecimalVal
+			case "Avg":
+				result, err := gbi.executor.executeSumCountShard(ctx, gbi.qcx, gbi.index, gbi.aggregate, filter, gbi.shard)
+				if err != nil {
+					return ret, false, err
+				}
+				if result.BigVal != nil {
+					return ret, false, errors.Wrapf(ErrSumOverflow, "%s doesn't fit in %d bits", gbi.aggregate, sumPrecisionDefault)
+				}
+				ret.Count = uint64(result.Count)
+				ret.Agg = result.Val
+			case "WeightedAvg":
+				result, err := gbi.executor.executeWeightedSumShard(ctx, gbi.qcx, gbi.index, gbi.aggregate, filter, gbi.shard)
+				if err != nil {
+					return ret, false, err
+				}
+				if result.BigVal != nil || (result.WeightSum != nil && !result.WeightSum.IsInt64()) {
+					return ret, false, errors.Wrapf(ErrSumOverflow, "%s doesn't fit in %d bits", gbi.aggregate, sumPrecisionDefault)
+				}
+				ret.Count = uint64(result.Count)
+				ret.Agg = result.Val
+				if result.WeightSum != nil {
+					ret.AggWeight = result.WeightSum.Int64()
+				}
+			case "Min", "Max":
+				result, err := gbi.executor.executeExtremeShardFiltered(ctx, gbi.qcx, gbi.index, gbi.aggregate, filter, gbi.shard)
+				if err != nil {
+					return ret, false, err
+				}
+				ret.Count = uint64(result.Count)
+				ret.Agg = result.Val
+				ret.TimestampAgg = result.TimestampVal
+			case "Histogram":
+				result, err := gbi.executor.executeHistogramShard(ctx, gbi.qcx, gbi.index, gbi.aggregate, filter, gbi.shard)
+				if err != nil {
+					return ret, false, err
+				}
+				ret.Count = 0
+				for _, n := range result.Counts {
+					ret.Count += n
+				}
+				ret.Histogram = result
 			}
 		}
 		if ret.Count == 0 {
@@ -8532,6 +10638,7 @@ func (gbi *groupByIterator) Next(ctx context.Context) (ret GroupCount, done bool
 	for i, r := range gbi.rows {
 		ret.Group[i].RowID = r.id
 		ret.Group[i].Value = r.value
+		ret.Group[i].Period = r.period
 	}
 
 	// set up for next call
@@ -8663,6 +10770,17 @@ func (e *executor) executeDeleteRecords(ctx context.Context, qcx *Qcx, index str
 	} else if len(c.Children) > 1 {
 		return false, errors.New("Delete() only accepts a single bitmap input")
 	}
+
+	// Find the records being deleted, if there are references to cascade
+	// the delete to.
+	var deleted []uint64
+	if !opt.Remote && len(e.Holder.cascadingFields(index)) > 0 {
+		row, err := e.executeBitmapCall(ctx, qcx, index, c.Children[0], shards, opt)
+		if err != nil {
+			return false, errors.Wrap(err, "finding records to delete")
+		}
+		deleted = row.Columns()
+	}
 	qcx.Abort()
 	qcx.Reset() // release the qcx to allow for rbf checkpoint
 
@@ -8682,6 +10800,11 @@ func (e *executor) executeDeleteRecords(ctx context.Context, qcx *Qcx, index str
 		return false, err
 	}
 	n, _ := result.(bool)
+	if n && len(deleted) > 0 {
+		if err := e.cascadeDelete(ctx, index, deleted); err != nil {
+			return n, errors.Wrap(err, "cascading delete")
+		}
+	}
 
 	return n, nil
 }
@@ -8903,7 +11026,11 @@ func DeleteRowsWithFlow(ctx context.Context, src *Row, idx *Index, shard uint64,
 			return change, err
 		}
 	}
-	return change, err
+	// Deleted records take their column attributes with them.
+	if err := idx.holder.columnAttrs.Delete(idx.name, bits); err != nil {
+		return change, errors.Wrap(err, "deleting column attributes")
+	}
+	return change, nil
 }
 
 type Commitor interface {
@@ -8930,10 +11057,11 @@ func (e *executor) executeSort(ctx context.Context, qcx *Qcx, index string, c *p
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeSort")
 	defer span.Finish()
 
-	sort_desc, _, err := c.BoolArg("sort-desc")
+	keys, err := parseSortKeys(c)
 	if err != nil {
-		return nil, errors.Wrap(err, " getting sort-desc")
+		return nil, err
 	}
+	desc := sortDirections(keys)
 
 	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
 		return e.executeSortShard(ctx, qcx, index, c, shard)
@@ -8946,7 +11074,7 @@ func (e *executor) executeSort(ctx context.Context, qcx *Qcx, index string, c *p
 		//var result *SortedRow
 		switch other := prev.(type) {
 		case *SortedRow:
-			if err := other.Merge(v.(*SortedRow), sort_desc); err != nil {
+			if err := other.Merge(v.(*SortedRow), desc); err != nil {
 				return err
 			} else {
 				return other
@@ -8972,27 +11100,30 @@ func (e *executor) executeSort(ctx context.Context, qcx *Qcx, index string, c *p
 		return nil, errors.Wrap(err, "mapReduce")
 	}
 
-	offset, hasOffset, err := c.UintArg("offset")
+	order, err := parseColumnOrder(c)
 	if err != nil {
 		return nil, err
 	}
-	limit, hasLimit, err := c.UintArg("limit")
-	if err != nil {
-		return nil, err
-	}
-	result := res.(*SortedRow)
-	if hasOffset {
-		result.RowKVs = result.RowKVs[offset:]
-		result.Row = NewRow(result.Columns()...)
+	result, ok := res.(*SortedRow)
+	if !ok {
+		return &SortedRow{Row: NewRow()}, nil
 	}
-	if hasLimit && limit < result.Row.Count() {
-		result.RowKVs = result.RowKVs[:limit]
-		result.Row = NewRow(result.Columns()...)
+	if opt.Remote {
+		// The coordinator merges the sorted columns of each node and
+		// applies the offset, so keep enough columns for it.
+		if keep := order.keep(); keep >= 0 && len(result.RowKVs) > keep {
+			result.RowKVs = result.RowKVs[:keep]
+			result.Row = NewRow(result.Columns()...)
+		}
+		return result, nil
 	}
+	start, end := order.bounds(len(result.RowKVs))
+	result.RowKVs = result.RowKVs[start:end]
+	result.Row = NewRow(result.Columns()...)
 	return result, nil
 }
 
-func (e *executor) executeSortShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (*SortedRow, error) {
+func (e *executor) executeSortShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (_ *SortedRow, err0 error) {
 	var filter *Row
 	if len(c.Children) == 1 {
 		row, err := e.executeBitmapCallShard(ctx, qcx, index, c.Children[0], shard)
@@ -9007,31 +11138,49 @@ func (e *executor) executeSortShard(ctx context.Context, qcx *Qcx, index string,
 		return nil, newNotFoundError(ErrIndexNotFound, index)
 	}
 
-	fieldName, err := c.FirstStringArg("field", "_field")
+	keys, err := parseSortKeys(c)
 	if err != nil {
-		return nil, errors.Wrap(err, "getting field")
-	}
-	f := idx.Field(fieldName)
-	if f == nil {
-		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
+		return nil, err
 	}
 
 	tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Shard: shard})
 	if err != nil {
 		return nil, ErrQcxDone
 	}
-	defer finisher(&err)
+	defer finisher(&err0)
+
+	after, err := parseSortAfter(c, idx, keys)
+	if err != nil {
+		return nil, err
+	}
+	order, err := parseColumnOrder(c)
+	if err != nil {
+		return nil, err
+	}
 
-	sort_desc, _, err := c.BoolArg("sort-desc")
+	var res *SortedRow
+	if len(keys) > 1 {
+		res, err = e.executeSortKeysShard(ctx, tx, idx, keys, filter, shard)
+	} else if f := idx.Field(keys[0].field); f == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, keys[0].field)
+	} else {
+		res, err = e.sortShardField(ctx, tx, idx, f, filter, shard, keys[0].desc)
+	}
 	if err != nil {
-		return nil, errors.Wrap(err, " getting sort-desc")
+		return nil, err
 	}
+	return seekSortedRow(res, after, sortDirections(keys), order.keep())
+}
 
+// sortShardField orders the columns of filter in a shard by their value in
+// f. Columns without a value in f are left out.
+func (e *executor) sortShardField(ctx context.Context, tx Tx, idx *Index, f *Field, filter *Row, shard uint64, sort_desc bool) (*SortedRow, error) {
+	index := idx.Name()
 	switch f.Type() {
 	case FieldTypeBool:
 		fragment := e.Holder.fragment(index, f.name, viewStandard, shard)
 		if fragment == nil {
-			return nil, errors.New("bool fragment not found")
+			return &SortedRow{Row: NewRow()}, nil
 		}
 		falses, err := fragment.row(tx, falseRowID)
 		if err != nil {
@@ -9043,38 +11192,22 @@ func (e *executor) executeSortShard(ctx context.Context, qcx *Qcx, index string,
 		}
 		falses = filter.Intersect(falses)
 		trues = filter.Intersect(trues)
-		rowKVs := make([]RowKV, len(filter.Columns()))
-		var i int
+		rowKVs := make([]RowKV, 0, falses.Count()+trues.Count())
+		first, second := falses, trues
 		if sort_desc {
-			for _, col := range trues.Columns() {
-				rowKVs[i] = RowKV{
-					RowID: col,
-					Value: true,
-				}
-				i++
-			}
-			for _, col := range falses.Columns() {
-				rowKVs[i] = RowKV{
-					RowID: col,
-					Value: false,
-				}
-				i++
-			}
-		} else {
-			for _, col := range falses.Columns() {
-				rowKVs[i] = RowKV{
-					RowID: col,
-					Value: false,
-				}
-				i++
-			}
-			for _, col := range trues.Columns() {
-				rowKVs[i] = RowKV{
-					RowID: col,
-					Value: true,
-				}
-				i++
-			}
+			first, second = trues, falses
+		}
+		for _, col := range first.Columns() {
+			rowKVs = append(rowKVs, RowKV{
+				RowID: col,
+				Value: sort_desc,
+			})
+		}
+		for _, col := range second.Columns() {
+			rowKVs = append(rowKVs, RowKV{
+				RowID: col,
+				Value: !sort_desc,
+			})
 		}
 		return &SortedRow{
 			Row:    filter,
@@ -9085,57 +11218,49 @@ func (e *executor) executeSortShard(ctx context.Context, qcx *Qcx, index string,
 	case FieldTypeMutex:
 		fragment := e.Holder.fragment(index, f.name, viewStandard, shard)
 		if fragment == nil {
-			return nil, errors.Errorf("fragment not found for field %s", f.name)
+			return &SortedRow{Row: NewRow()}, nil
 		}
 		rows, err := fragment.rows(ctx, tx, 0)
 		if err != nil {
 			return nil, errors.Wrap(err, " ggettign rows error")
 		}
-		rowKVs := make([]RowKV, filter.Count())
-		i := 0
-		for _, rowID := range rows {
+		// Only the primary translation node is sure to have every key.
+		var keys []string
+		if f.Keys() {
+			if keys, err = e.Cluster.translateFieldListIDs(ctx, f, rows); err != nil {
+				return nil, errors.Wrap(err, "error getting translateIDs")
+			}
+		}
+		rowKVs := make([]RowKV, 0, filter.Count())
+		for i, rowID := range rows {
 			row, err := fragment.row(tx, rowID)
 			if err != nil {
 				return nil, errors.Wrap(err, "couldn't load row from fragment")
 			}
 			row = row.Intersect(filter)
 			if row.Count() > 0 {
+				var value interface{} = rowID
 				if f.Keys() {
-					if rowStr, err := f.translateStore.TranslateID(rowID); err != nil {
-						return nil, errors.Wrap(err, "error getting translateIDs")
-					} else {
-						for _, v := range row.Columns() {
-							rowKVs[i] = RowKV{
-								RowID: v,
-								Value: rowStr,
-							}
-							i++
-						}
-					}
-				} else {
-					for _, v := range row.Columns() {
-						rowKVs[i] = RowKV{
-							RowID: v,
-							Value: rowID,
-						}
-						i++
-					}
+					value = keys[i]
+				}
+				for _, v := range row.Columns() {
+					rowKVs = append(rowKVs, RowKV{
+						RowID: v,
+						Value: value,
+					})
 				}
-
 			}
 		}
-		//this is to make sure compare function worked.
-		ok := true
+		var sortErr error
 		sort.SliceStable(rowKVs, func(i, j int) bool {
-			if c, k := rowKVs[i].Compare(rowKVs[j], sort_desc); k {
-				return c
-			} else {
-				ok = false
-				return !k
+			less, err := lessRowKV(rowKVs[i], rowKVs[j], []bool{sort_desc})
+			if err != nil {
+				sortErr = err
 			}
+			return less
 		})
-		if !ok {
-			return nil, errors.New("could not compare values for sort")
+		if sortErr != nil {
+			return nil, errors.Wrap(sortErr, "could not compare values for sort")
 		}
 		return &SortedRow{
 			Row:    filter,
@@ -9180,23 +11305,25 @@ func (s *SortedRow) ToRows(callback func(*proto.RowResponse) error) error {
 	return nil
 }
 
-func (s *SortedRow) Merge(o *SortedRow, sort_desc bool) error {
+// Merge merges the columns of o into s, given the direction of each of
+// the keys both are sorted by.
+func (s *SortedRow) Merge(o *SortedRow, desc []bool) error {
 	rowKVs := make([]RowKV, len(s.RowKVs)+len(o.RowKVs))
 
 	i, j, k := 0, 0, 0
 	for i < len(s.RowKVs) && j < len(o.RowKVs) {
-		if c, ok := s.RowKVs[i].Compare(o.RowKVs[j], sort_desc); ok {
-			if c {
-				rowKVs[k] = s.RowKVs[i]
-				i++
-			} else {
-				rowKVs[k] = o.RowKVs[j]
-				j++
-			}
-			k++
+		less, err := lessRowKV(s.RowKVs[i], o.RowKVs[j], desc)
+		if err != nil {
+			return err
+		}
+		if less {
+			rowKVs[k] = s.RowKVs[i]
+			i++
 		} else {
-			return errors.Errorf("Coultn't compare %v and %v", s.RowKVs[i], o.RowKVs[j])
+			rowKVs[k] = o.RowKVs[j]
+			j++
 		}
+		k++
 	}
 
 	for i < len(s.RowKVs) {
@@ -9221,25 +11348,27 @@ type ExtractedIDMatrixSorted struct {
 	RowKVs            []RowKV
 }
 
-func MergeExtractedIDMatrixSorted(a, b ExtractedIDMatrixSorted, sort_desc bool) (ExtractedIDMatrixSorted, error) {
+// MergeExtractedIDMatrixSorted merges two sorted matrices, given the
+// direction of each of the keys both are sorted by.
+func MergeExtractedIDMatrixSorted(a, b ExtractedIDMatrixSorted, desc []bool) (ExtractedIDMatrixSorted, error) {
 	columns := make([]ExtractedIDColumn, len(a.ExtractedIDMatrix.Columns)+len(b.ExtractedIDMatrix.Columns))
 	rowKVs := make([]RowKV, len(a.RowKVs)+len(b.RowKVs))
 	i, j, k := 0, 0, 0
 	for i < len(a.ExtractedIDMatrix.Columns) && j < len(b.ExtractedIDMatrix.Columns) {
-		if c, ok := a.RowKVs[i].Compare(b.RowKVs[j], sort_desc); ok {
-			if c {
-				columns[k] = a.ExtractedIDMatrix.Columns[i]
-				rowKVs[k] = a.RowKVs[i]
-				i++
-			} else {
-				columns[k] = b.ExtractedIDMatrix.Columns[j]
-				rowKVs[k] = b.RowKVs[j]
-				j++
-			}
-			k++
+		less, err := lessRowKV(a.RowKVs[i], b.RowKVs[j], desc)
+		if err != nil {
+			return ExtractedIDMatrixSorted{}, err
+		}
+		if less {
+			columns[k] = a.ExtractedIDMatrix.Columns[i]
+			rowKVs[k] = a.RowKVs[i]
+			i++
 		} else {
-			return ExtractedIDMatrixSorted{}, errors.Errorf("error comparing %v and %v", a.RowKVs[i], b.RowKVs[j])
+			columns[k] = b.ExtractedIDMatrix.Columns[j]
+			rowKVs[k] = b.RowKVs[j]
+			j++
 		}
+		k++
 	}
 	for i < len(a.ExtractedIDMatrix.Columns) {
 		columns[k] = a.ExtractedIDMatrix.Columns[i]
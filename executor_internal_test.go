@@ -84,7 +84,7 @@ func TestExecutor_TranslateRowsOnBool(t *testing.T) {
 			}
 
 			c := query.Calls[0]
-			colTranslations, rowTranslations, err := e.preTranslate(context.Background(), "i", c)
+			colTranslations, rowTranslations, err := e.preTranslate(context.Background(), "i", false, c)
 			if err != nil {
 				t.Fatalf("pre-translating call: %v", err)
 			}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"math/rand"
 	_ "net/http/pprof"
 	"os"
@@ -825,6 +826,26 @@ func TestExecutor(t *testing.T) {
 				t.Fatalf("unexpected columns: %+v", bits)
 			}
 		})
+
+		t.Run("columnRange", func(t *testing.T) {
+			writeQuery := fmt.Sprintf(`
+				Set(100, f=10)
+				Set(%d, f=10)
+				Set(%d, f=10)
+				Set(%d, f=10)`, ShardWidth+1, ShardWidth*2+2, ShardWidth*3+3)
+			readQueries := []string{
+				fmt.Sprintf(`Options(Row(f=10), columnRange=[%d, %d])`, ShardWidth+500, ShardWidth*2),
+				fmt.Sprintf(`Options(Row(f=10), columnRange=[%d, %d], shards=[0, 2])`, ShardWidth+500, ShardWidth*3),
+			}
+			responses := runCallTest(c, t, writeQuery, readQueries, nil)
+			// Shards are the unit of targeting, so ShardWidth+1 is included.
+			if bits := responses[0].Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(bits, []uint64{ShardWidth + 1, ShardWidth*2 + 2}) {
+				t.Fatalf("unexpected columns: %+v", bits)
+			}
+			if bits := responses[1].Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(bits, []uint64{ShardWidth*2 + 2}) {
+				t.Fatalf("unexpected columns: %+v", bits)
+			}
+		})
 	})
 
 	t.Run("Not", func(t *testing.T) {
@@ -1263,6 +1284,44 @@ func TestExecutor_Execute_Intersect(t *testing.T) {
 			t.Fatalf("unexpected columns: %+v", columns)
 		}
 	})
+
+	t.Run("MutexAndBSI", func(t *testing.T) {
+		c := test.MustRunCluster(t, 1)
+		defer c.Close()
+		c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "m", pilosa.OptFieldTypeMutex(pilosa.CacheTypeNone, 0))
+		c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "n", pilosa.OptFieldTypeInt(-100, 100))
+		c.Query(t, c.Idx(), fmt.Sprintf(`
+			Set(1, m=1) Set(1, n=20)
+			Set(2, m=1) Set(2, n=5)
+			Set(3, m=2) Set(3, n=30)
+			Set(4, m=1)
+			Set(%d, m=1) Set(%d, n=50)
+			Set(%d, n=60)`, ShardWidth, ShardWidth, ShardWidth+1))
+
+		for _, tt := range []struct {
+			query string
+			exp   []uint64
+		}{
+			{query: `Intersect(Row(m=1), Row(n > 10))`, exp: []uint64{1, ShardWidth}},
+			{query: `Intersect(Row(n > 10), Row(m=1))`, exp: []uint64{1, ShardWidth}},
+			{query: `Intersect(Row(n > 10), Row(n < 40), Row(m=1))`, exp: []uint64{1}},
+			{query: `Intersect(Row(m=1), Row(n != 20))`, exp: []uint64{2, ShardWidth}},
+			{query: `Intersect(Row(m=1), Row(n == null))`, exp: []uint64{4}},
+			{query: `Intersect(Row(m=1), Row(n != null))`, exp: []uint64{1, 2, ShardWidth}},
+			{query: `Intersect(Row(m=1), Row(-100 <= n <= 100))`, exp: []uint64{1, 2, ShardWidth}},
+			{query: `Intersect(Row(m=3), Row(n > 10))`, exp: []uint64{}},
+			{query: `Intersect(Row(n > 10), Row(n < 55))`, exp: []uint64{1, 3, ShardWidth}},
+		} {
+			if res, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: tt.query}); err != nil {
+				t.Fatal(err)
+			} else if columns := res.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(columns, tt.exp) {
+				t.Fatalf("%s: expected %v, got %v", tt.query, tt.exp, columns)
+			}
+		}
+		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Intersect(Row(m=3), Row(nosuchfield > 10))`}); err == nil {
+			t.Fatal("expected error for a missing field")
+		}
+	})
 }
 
 // Ensure an empty intersect query behaves properly.
@@ -1310,6 +1369,64 @@ func TestExecutor_Execute_Empty_Union(t *testing.T) {
 	}
 }
 
+// Ensure subtrees repeated within a call give the same results as
+// computing each of them.
+func TestExecutor_Execute_RepeatedSubexpressions(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "general")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "v", pilosa.OptFieldTypeInt(0, 1000))
+	c.Query(t, c.Idx(), `
+		Set(1, general=10)
+		Set(2, general=10)
+		Set(`+strconv.Itoa(ShardWidth+1)+`, general=10)
+		Set(2, general=11)
+		Set(3, general=11)
+		Set(1, v=2)
+		Set(2, v=3)
+		Set(3, v=5)
+		Set(`+strconv.Itoa(ShardWidth+1)+`, v=3)
+	`)
+
+	for _, tt := range []struct {
+		query string
+		exp   []uint64
+	}{
+		{
+			query: `Difference(All(), Not(Union(Row(general=10), Row(general=11))), Union(Row(general=10), Row(general=11)))`,
+			exp:   []uint64{},
+		},
+		{
+			query: `Intersect(Union(Row(general=10), Row(general=11)), Not(Xor(Row(general=10), Row(general=11))))`,
+			exp:   []uint64{2},
+		},
+		{
+			query: `Union(Distinct(Row(general=10), field=v), Intersect(Distinct(Row(general=10), field=v), Row(general=11)))`,
+			exp:   []uint64{2, 3},
+		},
+		{
+			query: `Difference(Distinct(Row(general=11), field=v), Intersect(Distinct(Row(general=10), field=v), Distinct(Row(general=11), field=v)))`,
+			exp:   []uint64{5},
+		},
+	} {
+		resp := c.Query(t, c.Idx(), tt.query)
+		if columns := resp.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(columns, tt.exp) {
+			t.Errorf("%s: expected %v, got %v", tt.query, tt.exp, columns)
+		}
+	}
+
+	// Results aren't reused across the calls of a query, so later calls
+	// see the writes of earlier ones.
+	resp := c.Query(t, c.Idx(), `
+		Count(Intersect(Row(general=10), Union(Row(general=10), Row(general=11))))
+		Set(3, general=10)
+		Count(Intersect(Row(general=10), Union(Row(general=10), Row(general=11))))
+	`)
+	if resp.Results[0] != uint64(3) || resp.Results[2] != uint64(4) {
+		t.Fatalf("expected counts 3 and 4, got %v and %v", resp.Results[0], resp.Results[2])
+	}
+}
+
 // Ensure a xor query can be executed.
 func TestExecutor_Execute_Xor(t *testing.T) {
 	t.Run("RowIDColumnID", func(t *testing.T) {
@@ -1617,8 +1734,12 @@ func TestExecutor_Execute_OldPQL(t *testing.T) {
 	// set a bit so the view gets created.
 	hldr.SetBit(c.Idx(), "f", 1, 0)
 
-	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `SetBit(frame=f, row=11, col=1)`}); err == nil || errors.Cause(err).Error() != "unknown call: SetBit" {
-		t.Fatalf("Expected error: 'unknown call: SetBit', got: %v. Full: %v", errors.Cause(err), err)
+	// Legacy calls are rewritten to the calls which replaced them.
+	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `SetBit(frame=f, row=11, col=1)`}); err != nil {
+		t.Fatal(err)
+	}
+	if cols := c.Query(t, c.Idx(), `Bitmap(frame=f, row=11)`).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, []uint64{1}) {
+		t.Fatalf("expected column 1, got %v", cols)
 	}
 }
 
@@ -2138,6 +2259,160 @@ func TestExecutor_Execute_TopN_fill_small(t *testing.T) {
 	}
 }
 
+// Ensure TopN() reads the rankings of caches maintained in the background,
+// and says whether they're stale.
+func TestExecutor_Execute_TopN_CacheStaleness(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "f", pilosa.OptFieldCacheStaleness("1h"))
+	c.Query(t, c.Idx(), fmt.Sprintf(`Set(0, f=10) Set(1, f=10) Set(%d, f=20)`, ShardWidth))
+
+	api := c.GetNode(0).API
+	topN := func() *pilosa.QueryResponse {
+		t.Helper()
+		resp, err := api.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `TopN(f)`})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &resp
+	}
+
+	// Bring the caches up to date, once the maintainer has had a chance to
+	// pick up the field's options.
+	time.Sleep(100 * time.Millisecond)
+	if err := api.RecalculateCaches(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	exp := []interface{}{&pilosa.PairsField{
+		Pairs: []pilosa.Pair{{ID: 10, Count: 2}, {ID: 20, Count: 1}},
+		Field: "f",
+	}}
+	if resp := topN(); !reflect.DeepEqual(resp.Results, exp) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(resp.Results))
+	}
+
+	// New rows aren't ranked until the caches are maintained, but the counts
+	// of ranked rows are exact.
+	c.Query(t, c.Idx(), `Set(2, f=20) Set(3, f=20) Set(3, f=30) Set(4, f=30) Set(5, f=30) Set(6, f=30)`)
+	resp := topN()
+	exp[0].(*pilosa.PairsField).Pairs = []pilosa.Pair{{ID: 20, Count: 3}, {ID: 10, Count: 2}}
+	exp[0].(*pilosa.PairsField).Stale = true
+	if !reflect.DeepEqual(resp.Results, exp) {
+		t.Fatalf("unexpected stale result: %s", spew.Sdump(resp.Results))
+	}
+	if buf, err := json.Marshal(resp); err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(string(buf), `"cacheStale":true`) {
+		t.Fatalf("expected cacheStale in response, got %s", buf)
+	}
+
+	// With a short budget, the caches are maintained in the background.
+	if err := api.UpdateField(context.Background(), c.Idx(), "f", pilosa.FieldUpdate{Option: "cacheStaleness", Value: "20ms"}); err != nil {
+		t.Fatal(err)
+	}
+	exp[0].(*pilosa.PairsField).Pairs = []pilosa.Pair{{ID: 30, Count: 4}, {ID: 20, Count: 3}, {ID: 10, Count: 2}}
+	exp[0].(*pilosa.PairsField).Stale = false
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		resp := topN()
+		if reflect.DeepEqual(resp.Results, exp) {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("caches not maintained: %s", spew.Sdump(resp.Results))
+		}
+	}
+
+	// The option only applies to fields with ranked caches.
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "i", pilosa.OptFieldTypeInt(0, 100))
+	if err := api.UpdateField(context.Background(), c.Idx(), "i", pilosa.FieldUpdate{Option: "cacheStaleness", Value: "1m"}); err == nil {
+		t.Fatal("expected error updating cacheStaleness of int field")
+	}
+}
+
+// Ensure an exact TopN() query ranks rows by their exact counts, without
+// needing a cache.
+func TestExecutor_Execute_TopN_Exact(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "f", pilosa.OptFieldTypeSet(pilosa.CacheTypeNone, 0))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "g")
+
+	// Rows have skewed counts in each shard, so that the top rows of each
+	// shard differ from the top rows overall. Row 1 of g holds the even
+	// columns.
+	const shardN, rowN = 4, 60
+	rnd := rand.New(rand.NewSource(3))
+	counts := make(map[uint64]uint64)
+	evenCounts := make(map[uint64]uint64)
+	var bits, gbits [][2]uint64
+	for shard := uint64(0); shard < shardN; shard++ {
+		for j := uint64(0); j < 100; j += 2 {
+			gbits = append(gbits, [2]uint64{1, shard*ShardWidth + j})
+		}
+		for row := uint64(0); row < rowN; row++ {
+			n := rnd.Intn(10)
+			if (row+shard)%7 == 0 {
+				n += 40
+			}
+			for _, j := range rnd.Perm(100)[:n] {
+				bits = append(bits, [2]uint64{row, shard*ShardWidth + uint64(j)})
+				counts[row]++
+				if j%2 == 0 {
+					evenCounts[row]++
+				}
+			}
+		}
+	}
+	c.ImportBits(t, c.Idx(), "f", bits)
+	c.ImportBits(t, c.Idx(), "g", gbits)
+
+	expected := func(counts map[uint64]uint64, n int, threshold uint64) []pilosa.Pair {
+		pairs := []pilosa.Pair{}
+		for row, count := range counts {
+			if count >= threshold && count > 0 {
+				pairs = append(pairs, pilosa.Pair{ID: row, Count: count})
+			}
+		}
+		sort.Slice(pairs, func(i, j int) bool {
+			if pairs[i].Count != pairs[j].Count {
+				return pairs[i].Count > pairs[j].Count
+			}
+			return pairs[i].ID < pairs[j].ID
+		})
+		if n > 0 && len(pairs) > n {
+			pairs = pairs[:n]
+		}
+		return pairs
+	}
+
+	for _, tt := range []struct {
+		query string
+		exp   []pilosa.Pair
+	}{
+		{`TopN(f, n=5, exact=true)`, expected(counts, 5, 0)},
+		{`TopN(f, n=1, exact=true)`, expected(counts, 1, 0)},
+		{`TopN(f, n=12, exact=true)`, expected(counts, 12, 0)},
+		{`TopN(f, Row(g=1), n=7, exact=true)`, expected(evenCounts, 7, 0)},
+		{`TopN(f, exact=true, threshold=100)`, expected(counts, 0, 100)},
+		{`TopN(f, n=100, exact=true)`, expected(counts, 0, 0)},
+		{`TopN(f, exact=true, ids=[1, 2, 3])`, expected(map[uint64]uint64{1: counts[1], 2: counts[2], 3: counts[3]}, 0, 0)},
+	} {
+		for i := range c.Nodes {
+			resp, err := c.GetNode(i).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: tt.query})
+			if err != nil {
+				t.Fatalf("%s: %v", tt.query, err)
+			}
+			pf := resp.Results[0].(*pilosa.PairsField)
+			if !reflect.DeepEqual(pf.Pairs, tt.exp) {
+				t.Fatalf("%s on node %d: expected %v, got %v", tt.query, i, tt.exp, pf.Pairs)
+			}
+		}
+	}
+
+	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `TopN(f, Row(g=1), exact=true, tanimotoThreshold=50)`}); err == nil {
+		t.Fatal("expected error using tanimotoThreshold with exact")
+	}
+}
+
 // Ensure a TopN() query with a source row can be executed.
 func TestExecutor_Execute_TopN_Src(t *testing.T) {
 	c := test.MustRunCluster(t, 1)
@@ -2824,7 +3099,7 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 			t.Run("NoFilter", func(t *testing.T) {
 				if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(field=foo)`}); err != nil {
 					t.Fatal(err)
-				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 200, Count: 5}) {
+				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 200, Count: 5, Precision: 64}) {
 					t.Fatalf("unexpected result: %s", spew.Sdump(result))
 				}
 			})
@@ -2832,7 +3107,7 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 			t.Run("NoFilter", func(t *testing.T) {
 				if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(field="foo")`}); err != nil {
 					t.Fatal(err)
-				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 200, Count: 5}) {
+				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 200, Count: 5, Precision: 64}) {
 					t.Fatalf("unexpected result: %s", spew.Sdump(result))
 				}
 			})
@@ -2840,7 +3115,7 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 			t.Run("NoFilter", func(t *testing.T) {
 				if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(foo)`}); err != nil {
 					t.Fatal(err)
-				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 200, Count: 5}) {
+				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 200, Count: 5, Precision: 64}) {
 					t.Fatalf("unexpected result: %s", spew.Sdump(result))
 				}
 			})
@@ -2848,7 +3123,7 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 			t.Run("WithFilter", func(t *testing.T) {
 				if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(Row(x=0), field=foo)`}); err != nil {
 					t.Fatal(err)
-				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 80, Count: 2}) {
+				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 80, Count: 2, Precision: 64}) {
 					t.Fatalf("unexpected result: %s", spew.Sdump(result))
 				}
 			})
@@ -2856,7 +3131,7 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 			t.Run("WithFilter", func(t *testing.T) {
 				if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(foo, Row(x=0))`}); err != nil {
 					t.Fatal(err)
-				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 80, Count: 2}) {
+				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 80, Count: 2, Precision: 64}) {
 					t.Fatalf("unexpected result: %s", spew.Sdump(result))
 				}
 			})
@@ -2873,7 +3148,7 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 			t.Run("NoFilter", func(t *testing.T) {
 				if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(field=dec)`}); err != nil {
 					t.Fatal(err)
-				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{DecimalVal: pql.NewDecimal(700007, 3).Clone(), Count: 3}) {
+				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{DecimalVal: pql.NewDecimal(700007, 3).Clone(), Count: 3, Precision: 64}) {
 					t.Fatalf("unexpected result: %s", spew.Sdump(result))
 				}
 			})
@@ -2881,7 +3156,7 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 			t.Run("WithFilter", func(t *testing.T) {
 				if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(Row(x=0), field=dec)`}); err != nil {
 					t.Fatal(err)
-				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{DecimalVal: pql.NewDecimal(500005, 3).Clone(), Count: 2}) {
+				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{DecimalVal: pql.NewDecimal(500005, 3).Clone(), Count: 2, Precision: 64}) {
 					t.Fatalf("unexpected result: %s", spew.Sdump(result))
 				}
 			})
@@ -2889,7 +3164,7 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 			t.Run("NoFilter", func(t *testing.T) {
 				if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(dec)`}); err != nil {
 					t.Fatal(err)
-				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{DecimalVal: pql.NewDecimal(700007, 3).Clone(), Count: 3}) {
+				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{DecimalVal: pql.NewDecimal(700007, 3).Clone(), Count: 3, Precision: 64}) {
 					t.Fatalf("unexpected result: %s", spew.Sdump(result))
 				}
 			})
@@ -2897,7 +3172,7 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 			t.Run("WithFilter", func(t *testing.T) {
 				if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(dec, Row(x=0))`}); err != nil {
 					t.Fatal(err)
-				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{DecimalVal: pql.NewDecimal(500005, 3).Clone(), Count: 2}) {
+				} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{DecimalVal: pql.NewDecimal(500005, 3).Clone(), Count: 2, Precision: 64}) {
 					t.Fatalf("unexpected result: %s", spew.Sdump(result))
 				}
 			})
@@ -2949,7 +3224,7 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 		t.Run("NoFilter", func(t *testing.T) {
 			if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(field=foo)`}); err != nil {
 				t.Fatal(err)
-			} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 200, Count: 5}) {
+			} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 200, Count: 5, Precision: 64}) {
 				t.Fatalf("unexpected result: %s", spew.Sdump(result))
 			}
 		})
@@ -2957,10 +3232,156 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 		t.Run("WithFilter", func(t *testing.T) {
 			if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(Row(x=0), field=foo)`}); err != nil {
 				t.Fatal(err)
-			} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 80, Count: 2}) {
+			} else if !reflect.DeepEqual(result.Results[0], pilosa.ValCount{Val: 80, Count: 2, Precision: 64}) {
+				t.Fatalf("unexpected result: %s", spew.Sdump(result))
+			}
+		})
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		c := test.MustRunCluster(t, 1)
+		defer c.Close()
+
+		c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "big", pilosa.OptFieldTypeInt(math.MinInt64, math.MaxInt64))
+		c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "dec", pilosa.OptFieldTypeDecimal(2))
+		// Values in different shards overflow when the shards are added,
+		// and values in the same shard overflow within the shard.
+		c.Query(t, c.Idx(), fmt.Sprintf(`
+			Set(1, big=%[1]d)
+			Set(2, big=%[1]d)
+			Set(%[2]d, big=%[1]d)
+			Set(1, dec=90000000000000000.00)
+			Set(%[2]d, dec=90000000000000000.00)
+		`, int64(math.MaxInt64), ShardWidth))
+
+		for _, q := range []string{`Sum(field=big)`, `Sum(field=dec)`, `Sum(field=big, precision=64)`} {
+			if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: q}); !errors.Is(err, pilosa.ErrSumOverflow) {
+				t.Fatalf("%s: expected overflow error, got %v", q, err)
+			}
+		}
+		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sum(field=big, precision=32)`}); err == nil {
+			t.Fatal("expected error for invalid precision")
+		}
+
+		exp := new(big.Int).Mul(big.NewInt(math.MaxInt64), big.NewInt(3))
+		result := c.Query(t, c.Idx(), `Sum(field=big, precision=128)`).Results[0].(pilosa.ValCount)
+		if result.BigVal == nil || result.BigVal.Cmp(exp) != 0 || result.Count != 3 || result.Precision != 128 {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+		result = c.Query(t, c.Idx(), `Sum(field=dec, precision=128)`).Results[0].(pilosa.ValCount)
+		if result.DecimalVal == nil || result.DecimalVal.String() != "180000000000000000.00" || result.Precision != 128 {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+
+		// Sums which fit are returned as before.
+		result = c.Query(t, c.Idx(), `Sum(ConstRow(columns=[1]), field=big, precision=128)`).Results[0].(pilosa.ValCount)
+		if !reflect.DeepEqual(result, pilosa.ValCount{Val: math.MaxInt64, Count: 1, Precision: 128}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+}
+
+func TestExecutor_Execute_Avg(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "x")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "foo", pilosa.OptFieldTypeInt(-990, 1000))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "w", pilosa.OptFieldTypeInt(5, 100))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "dec", pilosa.OptFieldTypeDecimal(3))
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(0, x=0)
+		Set(%[2]d, x=0)
+		Set(%[1]d, x=1)
+		Set(0, foo=20)
+		Set(%[1]d, foo=30)
+		Set(%[2]d, foo=-60)
+		Set(%[3]d, foo=40)
+		Set(%[4]d, foo=50)
+		Set(0, w=5)
+		Set(%[1]d, w=6)
+		Set(%[2]d, w=7)
+		Set(%[4]d, w=8)
+		Set(0, dec=100.001)
+		Set(%[1]d, dec=200.002)
+		Set(%[2]d, dec=400.004)
+	`, ShardWidth, ShardWidth+1, ShardWidth+2, 5*ShardWidth+100))
+
+	for _, tt := range []struct {
+		query string
+		exp   string
+		count int64
+	}{
+		{query: `Avg(field=foo)`, exp: "16.0000", count: 5},
+		{query: `Avg(Row(x=0), field=foo)`, exp: "-20.0000", count: 2},
+		{query: `Avg(field=dec)`, exp: "233.3356667", count: 3},
+		{query: `WeightedAvg(value=foo, weight=w)`, exp: "10.0000", count: 4},
+		{query: `WeightedAvg(Row(x=0), value=foo, weight=w)`, exp: "-26.6667", count: 2},
+		{query: `WeightedAvg(value=dec, weight=w)`, exp: "250.0025000", count: 3},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			result := c.Query(t, c.Idx(), tt.query).Results[0].(pilosa.ValCount)
+			if result.DecimalVal == nil || result.DecimalVal.String() != tt.exp || result.Count != tt.count {
 				t.Fatalf("unexpected result: %s", spew.Sdump(result))
 			}
 		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		result := c.Query(t, c.Idx(), `Avg(Row(x=2), field=foo)`).Results[0].(pilosa.ValCount)
+		if !reflect.DeepEqual(result, pilosa.ValCount{}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("SetField", func(t *testing.T) {
+		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Avg(field=x)`}); err == nil {
+			t.Fatal("expected error averaging a set field")
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "rv", pilosa.OptFieldTypeInt(-1<<30, 1<<30))
+		c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "rw", pilosa.OptFieldTypeInt(-1<<20, 1<<20))
+		rnd := rand.New(rand.NewSource(5))
+		var sb strings.Builder
+		values := map[int64][2]int64{}
+		for i := 0; i < 1000; i++ {
+			col := rnd.Int63n(3 * ShardWidth)
+			v, w := rnd.Int63n(1<<31)-1<<30, rnd.Int63n(1<<20)
+			fmt.Fprintf(&sb, "Set(%d, rv=%d)\nSet(%d, rw=%d)\n", col, v, col, w)
+			values[col] = [2]int64{v, w}
+		}
+		c.Query(t, c.Idx(), sb.String())
+		var prod, weights float64
+		for _, vw := range values {
+			prod += float64(vw[0]) * float64(vw[1])
+			weights += float64(vw[1])
+		}
+		result := c.Query(t, c.Idx(), `WeightedAvg(value=rv, weight=rw)`).Results[0].(pilosa.ValCount)
+		if exp := prod / weights; result.DecimalVal == nil || math.Abs(result.DecimalVal.Float64()-exp) > 1e-3 || result.Count != int64(len(values)) {
+			t.Fatalf("expected %f from %d values, got %s", exp, len(values), spew.Sdump(result))
+		}
+	})
+
+	t.Run("GroupBy", func(t *testing.T) {
+		for _, tt := range []struct {
+			query string
+			exp   []string
+		}{
+			{query: `GroupBy(Rows(x), aggregate=Avg(field=foo))`, exp: []string{"-20.0000", "30.0000"}},
+			{query: `GroupBy(Rows(x), aggregate=WeightedAvg(value=foo, weight=w))`, exp: []string{"-26.6667", "30.0000"}},
+		} {
+			groups := c.Query(t, c.Idx(), tt.query).Results[0].(*pilosa.GroupCounts)
+			if groups.AggregateColumn() != "average" || len(groups.Groups()) != len(tt.exp) {
+				t.Fatalf("%s: unexpected result: %s", tt.query, spew.Sdump(groups))
+			}
+			for i, gc := range groups.Groups() {
+				if gc.DecimalAgg == nil || gc.DecimalAgg.String() != tt.exp[i] {
+					t.Fatalf("%s: unexpected group %d: %s", tt.query, i, spew.Sdump(gc))
+				}
+			}
+		}
 	})
 }
 
@@ -3160,6 +3581,43 @@ func TestExecutor_Execute_Row_BSIGroup(t *testing.T) {
 
 	})
 
+	t.Run("Between", func(t *testing.T) {
+		if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Between(foo, 20, 30)`}); err != nil {
+			t.Fatal(err)
+		} else if got, exp := result.Results[0].(*pilosa.Row).Columns(), []uint64{50, ShardWidth, (5 * ShardWidth) + 100}; !reflect.DeepEqual(exp, got) {
+			t.Fatalf("Query().Row.Columns=%#v, expected %#v", got, exp)
+		}
+		if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Count(Between(edge, -100, 0))`}); err != nil {
+			t.Fatal(err)
+		} else if result.Results[0] != uint64(1) {
+			t.Fatalf("unexpected count: %v", result.Results[0])
+		}
+	})
+
+	t.Run("IN", func(t *testing.T) {
+		tests := []struct {
+			q   string
+			exp []uint64
+		}{
+			{q: `Row(foo in [10, 60])`, exp: []uint64{ShardWidth + 1, ShardWidth + 2}},
+			{q: `Row(foo in [20, 30, 5000, 20])`, exp: []uint64{50, ShardWidth, (5 * ShardWidth) + 100}},
+			{q: `Row(edge in [-100, 7, 100])`, exp: []uint64{0, 1}},
+			{q: `Row(foo in [5000])`, exp: []uint64{}},
+		}
+		for i, test := range tests {
+			t.Run(fmt.Sprintf("#%d_%s", i, test.q), func(t *testing.T) {
+				if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: test.q}); err != nil {
+					t.Fatal(err)
+				} else if got := result.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(test.exp, got) {
+					t.Fatalf("unexpected result for query: %s (%#v)", test.q, got)
+				}
+			})
+		}
+		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Row(foo in 20)`}); err == nil {
+			t.Fatal("expected error for a value which isn't a list")
+		}
+	})
+
 	// Ensure that the NotNull code path gets run.
 	t.Run("NotNull", func(t *testing.T) {
 		if result, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Row(0 <= other <= 1000)`}); err != nil {
@@ -3809,6 +4267,42 @@ func TestExecutor_Execute_ErrMaxWritesPerRequest(t *testing.T) {
 	}
 }
 
+// Ensure writes beyond the limit of a request can be split over several
+// transactions, with only the calls which fail left out.
+func TestExecutor_Execute_ChunkWrites(t *testing.T) {
+	c := test.MustUnsharedCluster(t, 1)
+	defer c.Close()
+	c.GetIdleNode(0).Config.MaxWritesPerRequest = 3
+	if err := c.Start(); err != nil {
+		t.Fatal(err)
+	}
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "f")
+
+	api := c.GetNode(0).API
+	resp, err := api.Query(context.Background(), &pilosa.QueryRequest{
+		Index:       c.Idx(),
+		Query:       `Set(1, f=1) Set(2, f=1) Set(3, f=1) Set(4, g=1) Count(Row(f=1)) Set(5, f=1) Set(6, f=1)`,
+		ChunkWrites: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, st := range resp.Statements {
+		if st.OK != (i != 3) {
+			t.Fatalf("unexpected status of statement %d: %+v", i, st)
+		}
+	}
+	if !strings.Contains(resp.Statements[3].Error, "field not found") {
+		t.Fatalf("unexpected error %q", resp.Statements[3].Error)
+	}
+	if exp := []interface{}{true, true, true, nil, uint64(3), true, true}; !reflect.DeepEqual(resp.Results, exp) {
+		t.Fatalf("expected results %v, got %v", exp, resp.Results)
+	}
+	if n := c.Query(t, c.Idx(), `Count(Row(f=1))`).Results[0]; n != uint64(5) {
+		t.Fatalf("expected 5 columns set, got %v", n)
+	}
+}
+
 func TestExecutor_Time_Clear_Quantums(t *testing.T) {
 	c := test.MustRunCluster(t, 1)
 	defer c.Close()
@@ -4328,6 +4822,145 @@ func TestExecutor_Sort(t *testing.T) {
 	})
 }
 
+func TestExecutor_Sort_Keys(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "region", pilosa.OptFieldKeys(), pilosa.OptFieldTypeMutex(pilosa.CacheTypeRanked, 5000))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "revenue", pilosa.OptFieldTypeInt(math.MinInt64, math.MaxInt64))
+	sw := uint64(pilosa.ShardWidth)
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(1, region="east") Set(1, revenue=10)
+		Set(%d, region="west") Set(%[1]d, revenue=30)
+		Set(%d, region="east") Set(%[2]d, revenue=50)
+		Set(%d, region="west") Set(%[3]d, revenue=30)
+		Set(5, region="east") Set(5, revenue=50)
+		Set(%d, region="north")
+		`, sw+2, 2*sw+3, 3*sw+4, sw+6))
+
+	sortColumns := func(t *testing.T, pql string) []uint64 {
+		t.Helper()
+		resp, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: pql})
+		if err != nil {
+			t.Fatalf("querying %s: %v", pql, err)
+		}
+		return resp.Results[0].(*pilosa.SortedRow).Columns()
+	}
+
+	// Columns without revenue sort last among those with the same region,
+	// and columns with equal values are ordered by ID.
+	if got, exp := sortColumns(t, `Sort(All(), by="region asc, revenue desc")`), []uint64{5, 2*sw + 3, 1, sw + 6, sw + 2, 3*sw + 4}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if got, exp := sortColumns(t, `Sort(All(), by="region desc, revenue", offset=1, limit=3)`), []uint64{3*sw + 4, sw + 6, 1}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	resp := c.Query(t, c.Idx(), `Extract(Sort(All(), by="region, revenue desc", offset=1, limit=2), Rows(revenue))`)
+	table := resp.Results[0].(pilosa.ExtractedTable)
+	var got []uint64
+	for _, col := range table.Columns {
+		got = append(got, col.Column.ID)
+	}
+	if exp := []uint64{2*sw + 3, 1}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sort(All(), by="region sideways")`}); err == nil {
+		t.Error("expected error for invalid sort direction")
+	}
+}
+
+// Ensure that columns whose BSI value is zero are sorted along with the
+// others, although none of their value bits are set.
+func TestExecutor_Sort_Zero(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "score", pilosa.OptFieldTypeInt(-10, 10))
+	c.Query(t, c.Idx(), `Set(1, score=2) Set(2, score=0) Set(3, score=-1) Set(4, score=1)`)
+
+	for pql, exp := range map[string][]uint64{
+		`Sort(All(), field=score)`:                 {3, 2, 4, 1},
+		`Sort(All(), field=score, sort-desc=true)`: {1, 4, 2, 3},
+	} {
+		if got := c.Query(t, c.Idx(), pql).Results[0].(*pilosa.SortedRow).Columns(); !reflect.DeepEqual(got, exp) {
+			t.Errorf("%s: expected %v, got %v", pql, exp, got)
+		}
+	}
+}
+
+func TestExecutor_Sort_After(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "score", pilosa.OptFieldTypeInt(0, 100))
+	var sets strings.Builder
+	for i := uint64(0); i < 20; i++ {
+		fmt.Fprintf(&sets, "Set(%d, score=%d)\n", (i%4)*pilosa.ShardWidth+i, i%5)
+	}
+	c.Query(t, c.Idx(), sets.String())
+
+	sortColumns := func(t *testing.T, pql string) []uint64 {
+		t.Helper()
+		resp, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: pql})
+		if err != nil {
+			t.Fatalf("querying %s: %v", pql, err)
+		}
+		return resp.Results[0].(*pilosa.SortedRow).Columns()
+	}
+
+	// Paging through columns after the last one seen returns every column
+	// once, in order, despite ties.
+	exp := sortColumns(t, `Sort(All(), by="score desc")`)
+	if len(exp) != 20 {
+		t.Fatalf("expected 20 columns, got %v", exp)
+	}
+	got := sortColumns(t, `Sort(All(), by="score desc", limit=3)`)
+	for page := got; len(page) > 0; got = append(got, page...) {
+		page = sortColumns(t, fmt.Sprintf(`Sort(All(), by="score desc", limit=3, after=%d)`, got[len(got)-1]))
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+
+	resp := c.Query(t, c.Idx(), fmt.Sprintf(`Extract(Sort(All(), field=score, limit=2, after=%d), Rows(score))`, exp[5]))
+	var cols []uint64
+	for _, col := range resp.Results[0].(pilosa.ExtractedTable).Columns {
+		cols = append(cols, col.Column.ID)
+	}
+	asc := sortColumns(t, `Sort(All(), field=score)`)
+	for i, col := range asc {
+		if col == exp[5] {
+			if want := asc[i+1 : i+3]; !reflect.DeepEqual(cols, want) {
+				t.Errorf("expected %v, got %v", want, cols)
+			}
+		}
+	}
+
+	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Sort(All(), field=score, after=7)`}); err == nil {
+		t.Error("expected error sorting after a column without a value")
+	}
+
+	t.Run("Keyed", func(t *testing.T) {
+		c.CreateField(t, "keyedsort", pilosa.IndexOptions{Keys: true, TrackExistence: true}, "score", pilosa.OptFieldTypeInt(0, 100))
+		c.Query(t, "keyedsort", `
+			Set("a", score=3)
+			Set("b", score=1)
+			Set("c", score=2)
+			Set("d", score=1)
+		`)
+		resp := c.Query(t, "keyedsort", `Extract(Sort(All(), field=score, after="c"), Rows(score))`)
+		var keys []string
+		for _, col := range resp.Results[0].(pilosa.ExtractedTable).Columns {
+			keys = append(keys, col.Column.Key)
+		}
+		if exp := []string{"a"}; !reflect.DeepEqual(keys, exp) {
+			t.Errorf("expected %v, got %v", exp, keys)
+		}
+	})
+}
+
 // Ensure an all query can be executed.
 func TestExecutor_Execute_All(t *testing.T) {
 	t.Run("ColumnID", func(t *testing.T) {
@@ -4513,22 +5146,621 @@ Set(5002, f=5)
 	})
 }
 
-// Ensure a row can be cleared.
-func TestExecutor_Execute_ClearRow(t *testing.T) {
-	t.Run("Int", func(t *testing.T) {
-		c := test.MustRunCluster(t, 1)
-		defer c.Close()
-		hldr := c.GetHolder(0)
-		index := hldr.MustCreateIndexIfNotExists(c.Idx(), pilosa.IndexOptions{TrackExistence: true})
-		_, err := index.CreateField("f", pilosa.OptFieldTypeInt(math.MinInt64, math.MaxInt64))
-		if err != nil {
-			t.Fatal(err)
-		}
+// Ensure Not(), All(), and == null can use an existence fallback against an
+// index which doesn't track existence.
+func TestExecutor_Execute_ExistenceFallback(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
 
-		// Ensure that clearing a row raises an error.
-		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `ClearRow(f=1)`}); err == nil {
-			t.Fatal("expected clear row to return an error")
-		}
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: false}, "f")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: false}, "v", pilosa.OptFieldTypeInt(-100, 100))
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(1, f=1)
+		Set(2, f=1)
+		Set(%d, f=1)
+		Set(3, f=2)
+		Set(2, v=10)
+		Set(4, v=20)
+		Set(%d, v=30)
+	`, ShardWidth+1, 2*ShardWidth+5))
+
+	query := func(t *testing.T, node int, pql, fallback string) (pilosa.QueryResponse, error) {
+		t.Helper()
+		return c.GetNode(node).API.Query(context.Background(), &pilosa.QueryRequest{
+			Index:             c.Idx(),
+			Query:             pql,
+			ExistenceFallback: fallback,
+		})
+	}
+
+	t.Run("NoFallback", func(t *testing.T) {
+		for _, pql := range []string{`Not(Row(f=1))`, `All()`, `Row(v == null)`} {
+			if _, err := query(t, 0, pql, ""); err == nil || !strings.Contains(err.Error(), "does not support existence tracking") {
+				t.Fatalf("%s: expected existence tracking error, got %v", pql, err)
+			}
+		}
+	})
+
+	for _, tt := range []struct {
+		fallback string
+		pql      string
+		exp      []uint64
+	}{
+		{pilosa.ExistenceFallbackAllFields, `All()`, []uint64{1, 2, 3, 4, ShardWidth + 1, 2*ShardWidth + 5}},
+		{pilosa.ExistenceFallbackAllFields, `Not(Row(f=1))`, []uint64{3, 4, 2*ShardWidth + 5}},
+		{pilosa.ExistenceFallbackAllFields, `Row(v == null)`, []uint64{1, 3, ShardWidth + 1}},
+		{"f", `All()`, []uint64{1, 2, 3, ShardWidth + 1}},
+		{"f", `Not(Row(f=1))`, []uint64{3}},
+		{"v", `Not(Row(f=1))`, []uint64{4, 2*ShardWidth + 5}},
+	} {
+		for node := 0; node < 3; node++ {
+			resp, err := query(t, node, tt.pql, tt.fallback)
+			if err != nil {
+				t.Fatalf("%s with fallback %q: %v", tt.pql, tt.fallback, err)
+			}
+			if cols := resp.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, tt.exp) {
+				t.Fatalf("%s with fallback %q on node %d: expected %v, got %v", tt.pql, tt.fallback, node, tt.exp, cols)
+			}
+			if resp.ExistenceFallback != tt.fallback {
+				t.Fatalf("%s: expected response to flag fallback %q, got %q", tt.pql, tt.fallback, resp.ExistenceFallback)
+			}
+		}
+	}
+
+	t.Run("Count", func(t *testing.T) {
+		resp, err := query(t, 1, `Count(All())`, "f")
+		if err != nil {
+			t.Fatal(err)
+		} else if n := resp.Results[0].(uint64); n != 4 {
+			t.Fatalf("expected count 4, got %d", n)
+		}
+		buf, err := json.Marshal(&resp)
+		if err != nil {
+			t.Fatal(err)
+		} else if !strings.Contains(string(buf), `"existenceFallback":"f"`) {
+			t.Fatalf("expected fallback in JSON response, got %s", buf)
+		}
+	})
+
+	t.Run("Unused", func(t *testing.T) {
+		resp, err := query(t, 0, `Row(f=1)`, pilosa.ExistenceFallbackAllFields)
+		if err != nil {
+			t.Fatal(err)
+		} else if resp.ExistenceFallback != "" {
+			t.Fatalf("expected no fallback flagged, got %q", resp.ExistenceFallback)
+		}
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		if _, err := query(t, 0, `All()`, "nope"); err == nil || !strings.Contains(err.Error(), "field not found") {
+			t.Fatalf("expected field not found error, got %v", err)
+		}
+	})
+}
+
+func TestExecutor_Execute_Priority(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "f")
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(1, f=1)
+		Set(%d, f=1)
+		Set(%d, f=1)
+	`, ShardWidth+1, 2*ShardWidth+1))
+
+	for _, priority := range []string{"", pilosa.QueryPriorityInteractive, pilosa.QueryPriorityBatch, pilosa.QueryPriorityBackground} {
+		resp, err := c.GetNode(1).API.Query(context.Background(), &pilosa.QueryRequest{
+			Index:    c.Idx(),
+			Query:    `Count(Row(f=1))`,
+			Priority: priority,
+		})
+		if err != nil {
+			t.Fatalf("priority %q: %v", priority, err)
+		} else if n := resp.Results[0].(uint64); n != 3 {
+			t.Fatalf("priority %q: expected 3, got %d", priority, n)
+		}
+	}
+
+	_, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{
+		Index:    c.Idx(),
+		Query:    `Count(Row(f=1))`,
+		Priority: "urgent",
+	})
+	var bre pilosa.BadRequestError
+	if !errors.As(err, &bre) {
+		t.Fatalf("expected bad request error, got %v", err)
+	}
+}
+
+func TestExecutor_Execute_Bindings(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "f")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "g")
+	var sets strings.Builder
+	for i := uint64(0); i < 30; i++ {
+		col := (i % 3 * ShardWidth) + i
+		fmt.Fprintf(&sets, "Set(%d, f=%d)\nSet(%d, g=%d)\n", col, i%2, col, i%3)
+	}
+	c.Query(t, c.Idx(), sets.String())
+
+	// Each query gives the same results as the query without bindings.
+	for q, exp := range map[string]string{
+		`x = Row(f=1); y = Row(g=2); Count(Intersect(x, y)); Count(x); Union(x, y)`:     `Count(Intersect(Row(f=1), Row(g=2))) Count(Row(f=1)) Union(Row(f=1), Row(g=2))`,
+		`x = Row(f=1) y = Difference(x, Row(g=2)) Count(y) Intersect(y, Not(Row(g=0)))`: `Count(Difference(Row(f=1), Row(g=2))) Intersect(Difference(Row(f=1), Row(g=2)), Not(Row(g=0)))`,
+		`Count(Row(g=1)); x = Row(g=1); Count(Union(x, Row(f=0))); Extract(x, Rows(f))`: `Count(Row(g=1)) Count(Union(Row(g=1), Row(f=0))) Extract(Row(g=1), Rows(f))`,
+	} {
+		got, want := c.Query(t, c.Idx(), q).Results, c.Query(t, c.Idx(), exp).Results
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: expected %v, got %v", q, want, got)
+		}
+	}
+
+	// Bound results are computed after the calls before them.
+	resp := c.Query(t, c.Idx(), `x = Row(f=8); Set(8, f=8); Set(9, f=9); y = Row(f=9); Count(x); Count(y)`)
+	if got := resp.Results[2:]; !reflect.DeepEqual(got, []interface{}{uint64(0), uint64(1)}) {
+		t.Fatalf("expected counts [0 1], got %v", got)
+	}
+
+	// Results must be rows.
+	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `x = Count(Row(f=1)); Count(x)`}); err == nil {
+		t.Fatal("expected error binding a count")
+	}
+
+	t.Run("Keys", func(t *testing.T) {
+		idx := c.Idx("k")
+		c.CreateField(t, idx, pilosa.IndexOptions{Keys: true, TrackExistence: true}, "kf", pilosa.OptFieldKeys())
+		c.Query(t, idx, `Set("a", kf="x") Set("b", kf="x") Set("b", kf="y") Set("c", kf="y")`)
+
+		resp := c.Query(t, idx, `x = Row(kf="x"); y = Row(kf="y"); z = Row(kf="nope"); Count(Intersect(x, y)); Union(x, z); Count(z)`)
+		if n := resp.Results[0].(uint64); n != 1 {
+			t.Fatalf("expected count 1, got %d", n)
+		}
+		if keys := resp.Results[1].(*pilosa.Row).Keys; !reflect.DeepEqual(keys, []string{"a", "b"}) {
+			t.Fatalf("expected keys [a b], got %v", keys)
+		}
+		if n := resp.Results[2].(uint64); n != 0 {
+			t.Fatalf("expected count 0, got %d", n)
+		}
+	})
+}
+
+func TestExecutor_Execute_TrackDistinct(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+
+	// tv tracks its distinct values and v doesn't; they're written alike.
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "v", pilosa.OptFieldTypeInt(-1000, 1000))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "tv", pilosa.OptFieldTypeInt(-1000, 1000), pilosa.OptFieldTrackDistinct())
+	write := func(format string, args ...interface{}) {
+		t.Helper()
+		for _, f := range []string{"v", "tv"} {
+			c.Query(t, c.Idx(), strings.ReplaceAll(fmt.Sprintf(format, args...), "FIELD", f))
+		}
+	}
+	values := func(r pilosa.SignedRow) string {
+		var pos, neg []uint64
+		if r.Pos != nil {
+			pos = r.Pos.Columns()
+		}
+		if r.Neg != nil {
+			neg = r.Neg.Columns()
+		}
+		return fmt.Sprint(pos, neg)
+	}
+	check := func(step string) {
+		t.Helper()
+		want := c.Query(t, c.Idx(), `Distinct(field=v) Count(Distinct(field=v))`).Results
+		got := c.Query(t, c.Idx(), `Distinct(field=tv) Count(Distinct(field=tv))`).Results
+		if values(got[0].(pilosa.SignedRow)) != values(want[0].(pilosa.SignedRow)) || got[1] != want[1] {
+			t.Fatalf("%s: expected %s (%v), got %s (%v)", step, values(want[0].(pilosa.SignedRow)), want[1], values(got[0].(pilosa.SignedRow)), got[1])
+		}
+	}
+
+	var sets strings.Builder
+	for i := 0; i < 40; i++ {
+		fmt.Fprintf(&sets, "Set(%d, FIELD=%d)\n", uint64(i%2)*ShardWidth+uint64(i), i%7-3)
+	}
+	write(sets.String())
+	check("set")
+
+	// Overwriting and clearing every column with a value removes it.
+	write("Set(0, FIELD=500) Set(14, FIELD=500) Set(28, FIELD=500)")
+	write("Clear(%d, FIELD=-3) Clear(%d, FIELD=-3)", ShardWidth+7, ShardWidth+21)
+	check("overwrite")
+	write("Clear(%d, FIELD=-3)", ShardWidth+35)
+	check("clear")
+
+	// Imports removing more values than are rechecked rebuild the shard's.
+	for _, f := range []string{"v", "tv"} {
+		cols, vals := make([]uint64, 0, 40), make([]int64, 0, 40)
+		for i := 0; i < 40; i += 2 {
+			cols, vals = append(cols, uint64(i)), append(vals, int64(100+i))
+		}
+		qcx := c.GetNode(0).API.Txf().NewQcx()
+		defer qcx.Abort()
+		if err := c.GetNode(0).API.ImportValue(context.Background(), qcx, &pilosa.ImportValueRequest{Index: c.Idx(), Field: f, Shard: 0, ColumnIDs: cols, Values: vals}); err != nil {
+			t.Fatal(err)
+		}
+		if err := qcx.Finish(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	check("import")
+	write("Set(2, FIELD=-999) Set(4, FIELD=-999)")
+	check("negative")
+
+	write("Delete(Row(FIELD < 0))")
+	check("delete")
+	if n := c.Query(t, c.Idx(), `Count(Distinct(field=tv))`).Results[0]; n != uint64(22) {
+		t.Fatalf("expected 22 distinct values, got %v", n)
+	}
+}
+
+func TestExecutor_Execute_TimeZone(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+
+	// ct reads times in Chicago by default; t reads them in UTC.
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "t", pilosa.OptFieldTypeTime("YMDH", "0"))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "ct", pilosa.OptFieldTypeTime("YMDH", "0"), pilosa.OptFieldTimeZone("America/Chicago"))
+
+	// March 13th, 2022 in Chicago, when DST started, ran from 06:00 UTC
+	// that day to 05:00 UTC the next.
+	for _, f := range []string{"t", "ct"} {
+		c.Query(t, c.Idx(), fmt.Sprintf(`
+			Set(1, %[1]s=1, 2022-03-13T05:30)
+			Set(2, %[1]s=1, 2022-03-13T06:00)
+			Set(3, %[1]s=1, 2022-03-14T04:59)
+			Set(4, %[1]s=1, 2022-03-14T05:00)`, f))
+	}
+
+	for i, tt := range []struct {
+		query string
+		exp   []uint64
+	}{
+		{`Row(t=1, from='2022-03-13T00:00', to='2022-03-14T00:00')`, []uint64{1, 2}},
+		{`Row(t=1, from='2022-03-13T00:00', to='2022-03-14T00:00', tz="America/Chicago")`, []uint64{2, 3}},
+		{`Row(t=1, from='2022-03-13', to='2022-03-14', tz="America/Chicago")`, []uint64{2, 3}},
+		{`Row(ct=1, from='2022-03-13T00:00', to='2022-03-14T00:00')`, []uint64{2, 3}},
+		{`Row(ct=1, from='2022-03-13T00:00', to='2022-03-14T00:00', tz="UTC")`, []uint64{1, 2}},
+		{`Row(ct=1, from='2022-03-13T00:00', to='2022-03-14T00:00', tz="Asia/Tokyo")`, []uint64{1, 2}},
+		{`Intersect(Row(ct=1, from='2022-03-13T12:00'), Row(t=1, to='2022-03-14T05:00'))`, []uint64{3}},
+	} {
+		if cols := c.Query(t, c.Idx(), tt.query).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, tt.exp) {
+			t.Errorf("%d. %s: expected %v, got %v", i, tt.query, tt.exp, cols)
+		}
+	}
+
+	rows := c.Query(t, c.Idx(), `Rows(ct, to='2022-03-13T01:00') Rows(ct, to='2022-03-13T01:00', tz="UTC")`).Results
+	if ids := rows[0].(pilosa.RowIdentifiers).Rows; !reflect.DeepEqual(ids, []uint64{1}) {
+		t.Errorf("Rows in Chicago: expected [1], got %v", ids)
+	}
+	if ids := rows[1].(pilosa.RowIdentifiers).Rows; len(ids) != 0 {
+		t.Errorf("Rows in UTC: expected none, got %v", ids)
+	}
+
+	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Row(t=1, from='2022-03-13T00:00', tz="Mars/Olympus")`}); err == nil || !strings.Contains(err.Error(), "invalid time zone") {
+		t.Fatalf("expected invalid time zone error, got %v", err)
+	}
+	if _, err := c.GetNode(0).API.CreateField(context.Background(), c.Idx(), "bad", pilosa.OptFieldTypeTime("YMDH", "0"), pilosa.OptFieldTimeZone("Mars/Olympus")); err == nil {
+		t.Fatal("expected error creating field with invalid time zone")
+	}
+}
+
+func TestExecutor_Execute_CalendarQuantum(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "w", pilosa.OptFieldTypeTime("YMWD", "0"))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "q", pilosa.OptFieldTypeTime("YQM", "0"), pilosa.OptFieldFiscalYearStart(time.October))
+
+	// Mondays, February 28th and March 7th, 2022, start weeks 9 and 10.
+	c.Query(t, c.Idx(), `
+		Set(1, w=1, 2022-02-27T12:00)
+		Set(2, w=1, 2022-02-28T00:00)
+		Set(3, w=1, 2022-03-06T23:00)
+		Set(1, q=1, 2022-09-30T00:00)
+		Set(2, q=1, 2022-10-01T00:00)
+		Set(3, q=1, 2023-01-01T00:00)`)
+	qcx := c.GetNode(0).API.Txf().NewQcx()
+	if err := c.GetNode(0).API.Import(context.Background(), qcx, &pilosa.ImportRequest{
+		Index:      c.Idx(),
+		Field:      "w",
+		RowIDs:     []uint64{1},
+		ColumnIDs:  []uint64{4},
+		Timestamps: []int64{time.Date(2022, time.March, 7, 1, 0, 0, 0, time.UTC).UnixNano()},
+		Shard:      0,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := qcx.Finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	views, err := c.GetNode(0).API.Views(context.Background(), c.Idx(), "w")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var weeks []string
+	for _, v := range views {
+		if strings.Contains(v.Name(), "w") {
+			weeks = append(weeks, v.Name())
+		}
+	}
+	sort.Strings(weeks)
+	if exp := []string{"standard_2022w08", "standard_2022w09", "standard_2022w10"}; !reflect.DeepEqual(weeks, exp) {
+		t.Fatalf("expected week views %v, got %v", exp, weeks)
+	}
+
+	for i, tt := range []struct {
+		query string
+		exp   []uint64
+	}{
+		{`Row(w=1, from='2022-02-28', to='2022-03-07')`, []uint64{2, 3}},
+		{`Row(w=1, from='2022-02-27', to='2022-03-14')`, []uint64{1, 2, 3, 4}},
+		{`Row(q=1, from='2022-10-01', to='2023-01-01')`, []uint64{2}},
+		{`Row(q=1, from='2022-10-01', to='2023-10-01')`, []uint64{2, 3}},
+		{`Row(q=1, from='2022-01-01', to='2022-10-01')`, []uint64{1}},
+	} {
+		if cols := c.Query(t, c.Idx(), tt.query).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, tt.exp) {
+			t.Errorf("%d. %s: expected %v, got %v", i, tt.query, tt.exp, cols)
+		}
+	}
+
+	if _, err := c.GetNode(0).API.CreateField(context.Background(), c.Idx(), "bad", pilosa.OptFieldTypeTime("YWM", "0")); err == nil {
+		t.Fatal("expected error creating field with weeks before months")
+	}
+}
+
+func TestExecutor_Execute_TimestampUnits(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "ts", pilosa.OptFieldTypeTimestamp(pilosa.DefaultEpoch, pilosa.TimeUnitSeconds))
+	c.Query(t, c.Idx(), `
+		Set(1, ts='2022-01-01T00:00:00Z')
+		Set(2, ts='2022-01-01T00:00:01Z')
+		Set(3, ts='2022-01-02T00:00:00Z')`)
+
+	// 2022-01-01T00:00:00Z is 1640995200 seconds after the epoch.
+	for i, tt := range []struct {
+		query string
+		exp   []uint64
+	}{
+		{`Row(ts == 1640995200)`, []uint64{1}},
+		{`Row(ts == 1640995200000, timeUnit="ms")`, []uint64{1}},
+		{`Row(ts > 1640995200000000000, timeUnit="ns")`, []uint64{2, 3}},
+		{`Row(ts == "2022-01-01T00:00:01Z")`, []uint64{2}},
+		{`Row(ts >= "2022-01-02")`, []uint64{3}},
+		{`Row(1640995200000 < ts < 1641081600000, timeUnit="ms")`, []uint64{2}},
+		{`Row(ts in [1640995200000, "2022-01-02T00:00"], timeUnit="ms")`, []uint64{1, 3}},
+	} {
+		if cols := c.Query(t, c.Idx(), tt.query).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, tt.exp) {
+			t.Errorf("%d. %s: expected %v, got %v", i, tt.query, tt.exp, cols)
+		}
+	}
+
+	res := c.Query(t, c.Idx(), `Options(Max(field=ts), timeUnit="ms") Max(field=ts) Options(Extract(ConstRow(columns=[1]), Rows(ts)), timeUnit="us")`).Results
+	if vc := res[0].(pilosa.ValCount); vc.Val != 1641081600000 || !vc.TimestampVal.IsZero() {
+		t.Errorf("expected max of 1641081600000ms, got %+v", vc)
+	}
+	if vc := res[1].(pilosa.ValCount); !vc.TimestampVal.Equal(time.Date(2022, time.January, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected max as a timestamp, got %+v", vc)
+	}
+	if v := res[2].(pilosa.ExtractedTable).Columns[0].Rows[0]; v != int64(1640995200000000) {
+		t.Errorf("expected extracted value of 1640995200000000us, got %v", v)
+	}
+
+	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Row(ts == 1, timeUnit="days")`}); err == nil || !strings.Contains(err.Error(), "invalid time unit") {
+		t.Fatalf("expected invalid time unit error, got %v", err)
+	}
+}
+
+func TestExecutor_Execute_GroupByMinMax(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "f")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "v", pilosa.OptFieldTypeInt(-100, 100))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "ts", pilosa.OptFieldTypeTimestamp(pilosa.DefaultEpoch, pilosa.TimeUnitSeconds))
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(1, f=1) Set(1, v=5) Set(1, ts='2022-01-03T00:00:00Z')
+		Set(%[1]d, f=1) Set(%[1]d, v=-3) Set(%[1]d, ts='2022-01-01T00:00:00Z')
+		Set(%[2]d, f=1) Set(%[2]d, v=9) Set(%[2]d, ts='2022-01-02T00:00:00Z')
+		Set(%[3]d, f=1)
+		Set(4, f=2) Set(4, v=7)`, ShardWidth+1, 2*ShardWidth+1, 2*ShardWidth+2))
+
+	day := func(d int) time.Time { return time.Date(2022, time.January, d, 0, 0, 0, 0, time.UTC) }
+	for i, tt := range []struct {
+		query string
+		exp   []pilosa.GroupCount
+	}{
+		{`GroupBy(Rows(f), aggregate=Min(field=v))`, []pilosa.GroupCount{
+			{Group: []pilosa.FieldRow{{Field: "f", RowID: 1}}, Count: 3, Agg: -3},
+			{Group: []pilosa.FieldRow{{Field: "f", RowID: 2}}, Count: 1, Agg: 7},
+		}},
+		{`GroupBy(Rows(f), aggregate=Max(field=v))`, []pilosa.GroupCount{
+			{Group: []pilosa.FieldRow{{Field: "f", RowID: 1}}, Count: 3, Agg: 9},
+			{Group: []pilosa.FieldRow{{Field: "f", RowID: 2}}, Count: 1, Agg: 7},
+		}},
+		{`GroupBy(Rows(f), aggregate=Max(Row(v < 9), field=v))`, []pilosa.GroupCount{
+			{Group: []pilosa.FieldRow{{Field: "f", RowID: 1}}, Count: 2, Agg: 5},
+			{Group: []pilosa.FieldRow{{Field: "f", RowID: 2}}, Count: 1, Agg: 7},
+		}},
+		{`GroupBy(Rows(f), aggregate=Min(field=ts))`, []pilosa.GroupCount{
+			{Group: []pilosa.FieldRow{{Field: "f", RowID: 1}}, Count: 3, Agg: day(1).Unix(), TimestampAgg: day(1)},
+		}},
+		{`GroupBy(Rows(f), aggregate=Max(field=ts))`, []pilosa.GroupCount{
+			{Group: []pilosa.FieldRow{{Field: "f", RowID: 1}}, Count: 3, Agg: day(3).Unix(), TimestampAgg: day(3)},
+		}},
+		{`Options(GroupBy(Rows(f), aggregate=Max(field=ts)), timeUnit="ms")`, []pilosa.GroupCount{
+			{Group: []pilosa.FieldRow{{Field: "f", RowID: 1}}, Count: 3, Agg: day(3).Unix() * 1000},
+		}},
+	} {
+		res := c.Query(t, c.Idx(), tt.query).Results[0].(*pilosa.GroupCounts)
+		test.CheckGroupBy(t, tt.exp, res.Groups())
+		for j, g := range res.Groups() {
+			if j < len(tt.exp) && !g.TimestampAgg.Equal(tt.exp[j].TimestampAgg) {
+				t.Errorf("%d. %s: expected timestamp %v, got %v", i, tt.query, tt.exp[j].TimestampAgg, g.TimestampAgg)
+			}
+		}
+	}
+
+	res := c.Query(t, c.Idx(), `GroupBy(Rows(f), aggregate=Max(field=ts))`).Results[0].(*pilosa.GroupCounts)
+	if data, err := json.Marshal(res); err != nil {
+		t.Fatal(err)
+	} else if exp := `[{"group":[{"field":"f","rowID":1}],"count":3,"max":"2022-01-03T00:00:00Z"}]`; string(data) != exp {
+		t.Errorf("expected JSON %s, got %s", exp, data)
+	}
+	table, err := res.ToTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := table.Headers[len(table.Headers)-1]; h.Name != "max" || h.Datatype != "string" {
+		t.Errorf("unexpected header %+v", h)
+	} else if v := table.Rows[0].Columns[len(table.Headers)-1].GetStringVal(); v != "2022-01-03T00:00:00Z" {
+		t.Errorf("expected max of 2022-01-03T00:00:00Z, got %q", v)
+	}
+
+	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `GroupBy(Rows(f), aggregate=Min(field=f))`}); err == nil || !strings.Contains(err.Error(), "only supports Min aggregates on int and timestamp fields") {
+		t.Fatalf("expected unsupported field error, got %v", err)
+	}
+}
+
+func TestExecutor_Execute_Histogram(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "f")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "v", pilosa.OptFieldTypeInt(-100, 100))
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(1, f=1) Set(1, v=5)
+		Set(%[1]d, f=1) Set(%[1]d, v=-3)
+		Set(%[2]d, f=1) Set(%[2]d, v=9)
+		Set(%[3]d, f=1)
+		Set(4, f=2) Set(4, v=7)`, ShardWidth+1, 2*ShardWidth+1, 2*ShardWidth+2))
+
+	for _, tt := range []struct {
+		query string
+		exp   []pilosa.HistogramBucket
+	}{
+		{`Histogram(field=v, bucketWidth=5)`, []pilosa.HistogramBucket{{From: -5, To: 0, Count: 1}, {From: 0, To: 5, Count: 0}, {From: 5, To: 10, Count: 3}}},
+		{`Histogram(field=v, bucketWidth=100)`, []pilosa.HistogramBucket{{From: -100, To: 0, Count: 1}, {From: 0, To: 100, Count: 3}}},
+		{`Histogram(Row(f=1), field=v, bucketWidth=5)`, []pilosa.HistogramBucket{{From: -5, To: 0, Count: 1}, {From: 0, To: 5, Count: 0}, {From: 5, To: 10, Count: 2}}},
+		{`Histogram(field=v, edges=[-200, 6, 9, 200])`, []pilosa.HistogramBucket{{From: -200, To: 6, Count: 2}, {From: 6, To: 9, Count: 1}, {From: 9, To: 200, Count: 1}}},
+		{`Histogram(field=v, edges=[6, 8])`, []pilosa.HistogramBucket{{From: 6, To: 8, Count: 1}}},
+	} {
+		res := c.Query(t, c.Idx(), tt.query).Results[0].(*pilosa.Histogram)
+		if got := res.Buckets(); !reflect.DeepEqual(got, tt.exp) {
+			t.Errorf("%s: expected %v, got %v", tt.query, tt.exp, got)
+		}
+	}
+
+	res := c.Query(t, c.Idx(), `GroupBy(Rows(f), aggregate=Histogram(field=v, bucketWidth=5))`).Results[0].(*pilosa.GroupCounts)
+	groups := res.Groups()
+	test.CheckGroupBy(t, []pilosa.GroupCount{
+		{Group: []pilosa.FieldRow{{Field: "f", RowID: 1}}, Count: 3},
+		{Group: []pilosa.FieldRow{{Field: "f", RowID: 2}}, Count: 1},
+	}, groups)
+	if exp := []uint64{1, 0, 2}; !reflect.DeepEqual(groups[0].Histogram.Counts, exp) {
+		t.Errorf("expected counts %v for f=1, got %v", exp, groups[0].Histogram.Counts)
+	}
+	if exp := []uint64{0, 0, 1}; !reflect.DeepEqual(groups[1].Histogram.Counts, exp) {
+		t.Errorf("expected counts %v for f=2, got %v", exp, groups[1].Histogram.Counts)
+	}
+	if data, err := json.Marshal(res); err != nil {
+		t.Fatal(err)
+	} else if exp := `[{"group":[{"field":"f","rowID":1}],"count":3,"histogram":{"field":"v","buckets":[{"from":-5,"to":0,"count":1},{"from":0,"to":5,"count":0},{"from":5,"to":10,"count":2}]}},` +
+		`{"group":[{"field":"f","rowID":2}],"count":1,"histogram":{"field":"v","buckets":[{"from":-5,"to":0,"count":0},{"from":0,"to":5,"count":0},{"from":5,"to":10,"count":1}]}}]`; string(data) != exp {
+		t.Errorf("expected JSON %s, got %s", exp, data)
+	}
+
+	for query, msg := range map[string]string{
+		`Histogram(field=v)`:                              "bucketWidth or edges required",
+		`Histogram(field=v, bucketWidth=5, edges=[1, 2])`: "can't both be given",
+		`Histogram(field=v, edges=[2, 1])`:                "strictly increasing",
+		`Histogram(field=v, bucketWidth=0)`:               "must be positive",
+		`Histogram(field=f, bucketWidth=5)`:               "expected int",
+	} {
+		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: query}); err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("%s: expected error containing %q, got %v", query, msg, err)
+		}
+	}
+}
+
+func TestExecutor_Execute_Canary(t *testing.T) {
+	c := test.MustRunCluster(t, 3, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerCanary(pilosa.ExecPathUnplanned, 1)),
+	})
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "f")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "v", pilosa.OptFieldTypeInt(0, 100))
+	var sets strings.Builder
+	for i := uint64(0); i < 30; i++ {
+		col := (i % 3 * ShardWidth) + i
+		fmt.Fprintf(&sets, "Set(%d, f=%d)\nSet(%d, v=%d)\n", col, i%2, col, i)
+	}
+	c.Query(t, c.Idx(), sets.String())
+
+	api := c.GetNode(1).API
+	queries := map[string]uint64{
+		`Count(Intersect(Row(f=1), Row(v < 10), Row(v > 2)))`: 4,
+		`Count(Intersect(Row(v >= 20), Not(Row(f=1))))`:       5,
+	}
+	for q, exp := range queries {
+		for _, path := range []string{pilosa.ExecPathCurrent, pilosa.ExecPathUnplanned} {
+			resp, err := api.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: q, ExecPath: path})
+			if err != nil {
+				t.Fatalf("%s on path %q: %v", q, path, err)
+			} else if n := resp.Results[0].(uint64); n != exp {
+				t.Fatalf("%s on path %q: expected %d, got %d", q, path, exp, n)
+			}
+		}
+	}
+	// Writes aren't executed again.
+	c.Query(t, c.Idx(), `Set(100, f=3)`)
+
+	// Only the queries on the current path are checked by the canary.
+	var report *pilosa.CanaryReport
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		var err error
+		if report, err = api.Canary(context.Background()); err != nil {
+			t.Fatal(err)
+		} else if report.Queries >= uint64(len(queries)) {
+			break
+		}
+	}
+	if report.Path != pilosa.ExecPathUnplanned || report.Queries != uint64(len(queries)) || report.Mismatches != 0 {
+		t.Fatalf("unexpected canary report %+v", report)
+	}
+
+	_, err := api.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Count(Row(f=1))`, ExecPath: "new"})
+	var bre pilosa.BadRequestError
+	if !errors.As(err, &bre) {
+		t.Fatalf("expected bad request error for unknown path, got %v", err)
+	}
+}
+
+// Ensure a row can be cleared.
+func TestExecutor_Execute_ClearRow(t *testing.T) {
+	t.Run("Int", func(t *testing.T) {
+		c := test.MustRunCluster(t, 1)
+		defer c.Close()
+		hldr := c.GetHolder(0)
+		index := hldr.MustCreateIndexIfNotExists(c.Idx(), pilosa.IndexOptions{TrackExistence: true})
+		_, err := index.CreateField("f", pilosa.OptFieldTypeInt(math.MinInt64, math.MaxInt64))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Ensure that clearing a row raises an error.
+		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `ClearRow(f=1)`}); err == nil {
+			t.Fatal("expected clear row to return an error")
+		}
 	})
 
 	t.Run("TopN", func(t *testing.T) {
@@ -4609,6 +5841,71 @@ func TestExecutor_Execute_ClearRow(t *testing.T) {
 	})
 }
 
+// Ensure ClearRow and Store can be limited to a time range of a time field.
+func TestExecutor_Execute_ClearRow_TimeRange(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "general")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "tq", pilosa.OptFieldTypeTime("YMD", "0"))
+	reset := func(t *testing.T) {
+		t.Helper()
+		c.Query(t, c.Idx(), fmt.Sprintf(`
+			ClearRow(tq=1)
+			Set(1, tq=1, 2022-01-05T10:00)
+			Set(2, tq=1, 2022-01-05T11:00)
+			Set(2, tq=1, 2022-01-20T00:00)
+			Set(3, tq=1, 2022-02-01T00:00)
+			Set(%d, tq=1, 2022-01-05T00:00)
+			Set(7, general=5)
+			Set(8, general=5)
+		`, ShardWidth+1))
+	}
+	check := func(t *testing.T, query string, expected []uint64) {
+		t.Helper()
+		got := c.Query(t, c.Idx(), query).Results[0].(*pilosa.Row).Columns()
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("%s: expected %v, got %v", query, expected, got)
+		}
+	}
+
+	t.Run("ClearRow", func(t *testing.T) {
+		reset(t)
+		query := `ClearRow(tq=1, from="2022-01-05T00:00", to="2022-01-06T00:00")`
+		if res := c.Query(t, c.Idx(), query).Results[0].(bool); !res {
+			t.Fatalf("expected %s to clear bits", query)
+		}
+		check(t, `Row(tq=1, from="2022-01-05T00:00", to="2022-01-06T00:00")`, []uint64{})
+		check(t, `Row(tq=1, from="2022-01-01T00:00", to="2022-02-01T00:00")`, []uint64{2})
+		check(t, `Row(tq=1, from="2022-01-01T00:00", to="2023-01-01T00:00")`, []uint64{2, 3})
+		check(t, `Row(tq=1)`, []uint64{2, 3})
+		if res := c.Query(t, c.Idx(), query).Results[0].(bool); res {
+			t.Fatalf("expected %s not to clear bits again", query)
+		}
+	})
+	t.Run("Store", func(t *testing.T) {
+		reset(t)
+		c.Query(t, c.Idx(), `Store(Row(general=5), tq=1, from="2022-01-05T00:00", to="2022-01-06T00:00")`)
+		check(t, `Row(tq=1, from="2022-01-05T00:00", to="2022-01-06T00:00")`, []uint64{7, 8})
+		check(t, `Row(tq=1, from="2022-01-01T00:00", to="2022-02-01T00:00")`, []uint64{2, 7, 8})
+		check(t, `Row(tq=1)`, []uint64{2, 3, 7, 8})
+	})
+	t.Run("Errors", func(t *testing.T) {
+		for query, msg := range map[string]string{
+			`ClearRow(tq=1, from="2022-01-05T10:00", to="2022-01-06T00:00")`:                   "boundary",
+			`ClearRow(tq=1, from="2022-01-06T00:00", to="2022-01-05T00:00")`:                   "range is empty",
+			`ClearRow(tq=1, from="2022-01-05T00:00")`:                                          "needs both",
+			`ClearRow(general=1, from="2022-01-05T00:00", to="2022-01-06T00:00")`:              "requires a time field",
+			`Store(Row(general=5), tq=1)`:                                                      "can't Store() on a time field",
+			`Store(Row(general=5), general=6, from="2022-01-05T00:00", to="2022-01-06T00:00")`: "requires a time field",
+		} {
+			_, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: query})
+			if err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("%s: expected error containing %q, got %v", query, msg, err)
+			}
+		}
+	})
+}
+
 // Ensure a row can be set.
 func TestExecutor_Execute_SetRow(t *testing.T) {
 	t.Run("Set_NewRow", func(t *testing.T) {
@@ -5164,6 +6461,328 @@ func TestExecutor_Execute_Extract_Keyed(t *testing.T) {
 	}
 }
 
+func TestExecutor_Execute_CardinalityOf(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "tags")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "n", pilosa.OptFieldTypeInt(0, 100))
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(0, tags=1)
+		Set(0, tags=2)
+		Set(0, tags=3)
+		Set(1, tags=1)
+		Set(2, n=1)
+		Set(%[1]d, tags=1)
+		Set(%[1]d, tags=2)
+		Set(%[1]d, tags=3)
+		Set(%[1]d, tags=4)
+		Set(%[1]d, tags=5)
+		Set(%[1]d, tags=6)
+	`, ShardWidth+5))
+
+	for _, tt := range []struct {
+		query string
+		exp   []uint64
+	}{
+		{query: `CardinalityOf(tags > 2)`, exp: []uint64{0, ShardWidth + 5}},
+		{query: `CardinalityOf(tags == 1)`, exp: []uint64{1}},
+		{query: `CardinalityOf(tags < 1)`, exp: []uint64{2}},
+		{query: `CardinalityOf(tags >< [1, 3])`, exp: []uint64{0, 1}},
+		{query: `CardinalityOf(tags in [1, 6])`, exp: []uint64{1, ShardWidth + 5}},
+		{query: `Intersect(CardinalityOf(tags >= 1), Row(tags=2))`, exp: []uint64{0, ShardWidth + 5}},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if cols := c.Query(t, c.Idx(), tt.query).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, tt.exp) {
+				t.Fatalf("expected %v, got %v", tt.exp, cols)
+			}
+		})
+	}
+
+	t.Run("Extract", func(t *testing.T) {
+		table := c.Query(t, c.Idx(), `Extract(All(), CardinalityOf(field=tags), Rows(n))`).Results[0].(pilosa.ExtractedTable)
+		if table.Fields[0] != (pilosa.ExtractedTableField{Name: "CardinalityOf(tags)", Type: "int64"}) {
+			t.Fatalf("unexpected fields: %v", table.Fields)
+		}
+		exp := map[uint64]int64{0: 3, 1: 1, 2: 0, ShardWidth + 5: 6}
+		if len(table.Columns) != len(exp) {
+			t.Fatalf("unexpected columns: %v", table.Columns)
+		}
+		for _, col := range table.Columns {
+			if n := col.Rows[0].(int64); n != exp[col.Column.ID] {
+				t.Fatalf("expected %d bits in column %d, got %d", exp[col.Column.ID], col.Column.ID, n)
+			}
+		}
+	})
+
+	t.Run("IntField", func(t *testing.T) {
+		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `CardinalityOf(n > 1)`}); err == nil {
+			t.Fatal("expected error counting bits of an int field")
+		}
+	})
+}
+
+func TestExecutor_Execute_Extract_Order(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx("k"), pilosa.IndexOptions{TrackExistence: true, Keys: true}, "set")
+	var keys []string
+	var sets strings.Builder
+	for i := 29; i >= 0; i-- {
+		key := fmt.Sprintf("k%02d", i)
+		keys = append([]string{key}, keys...)
+		fmt.Fprintf(&sets, "Set(%q, set=%d)\n", key, i%3)
+	}
+	c.Query(t, c.Idx("k"), sets.String())
+	// An index whose column limit is less than its columns.
+	c.CreateField(t, c.Idx("m"), pilosa.IndexOptions{TrackExistence: true, Keys: true, MaxColumns: 10}, "set")
+	c.Query(t, c.Idx("m"), sets.String())
+
+	c.CreateField(t, c.Idx("i"), pilosa.IndexOptions{TrackExistence: true}, "set")
+	c.ImportBits(t, c.Idx("i"), "set", [][2]uint64{
+		{1, 5},
+		{1, 1},
+		{1, ShardWidth + 3},
+		{1, 2 * ShardWidth},
+	})
+
+	extractedKeys := func(t *testing.T, res interface{}) []string {
+		t.Helper()
+		tbl, ok := res.(pilosa.ExtractedTable)
+		if !ok {
+			t.Fatalf("expected ExtractedTable, got %T", res)
+		}
+		got := []string{}
+		for _, col := range tbl.Columns {
+			got = append(got, col.Column.Key)
+		}
+		return got
+	}
+
+	for i := 0; i < 3; i++ {
+		api := c.GetNode(i).API
+		query := func(t *testing.T, index, pql string) interface{} {
+			t.Helper()
+			resp, err := api.Query(context.Background(), &pilosa.QueryRequest{Index: index, Query: pql})
+			if err != nil {
+				t.Fatalf("%s: %v", pql, err)
+			}
+			return resp.Results[0]
+		}
+
+		if got := extractedKeys(t, query(t, c.Idx("k"), `Extract(All(), Rows(set), order=key)`)); !reflect.DeepEqual(got, keys) {
+			t.Fatalf("expected %v, got %v", keys, got)
+		}
+		if got := extractedKeys(t, query(t, c.Idx("k"), `Extract(All(), Rows(set), order="key", limit=5, offset=3)`)); !reflect.DeepEqual(got, keys[3:8]) {
+			t.Fatalf("expected %v, got %v", keys[3:8], got)
+		}
+		if got := extractedKeys(t, query(t, c.Idx("k"), `Extract(Row(set=1), Rows(set), order=key, offset=8)`)); !reflect.DeepEqual(got, []string{"k25", "k28"}) {
+			t.Fatalf("expected [k25 k28], got %v", got)
+		}
+		if got := query(t, c.Idx("k"), `All(order=key)`).(*pilosa.Row).Keys; !reflect.DeepEqual(got, keys) {
+			t.Fatalf("expected %v, got %v", keys, got)
+		}
+		if got := query(t, c.Idx("k"), `All(order=key, limit=4, offset=2)`).(*pilosa.Row).Keys; !reflect.DeepEqual(got, keys[2:6]) {
+			t.Fatalf("expected %v, got %v", keys[2:6], got)
+		}
+		if n := query(t, c.Idx("k"), `Count(All(order=key))`).(uint64); n != 30 {
+			t.Fatalf("expected count 30, got %d", n)
+		}
+
+		// Limited columns ordered by key are trimmed as they're merged,
+		// so they stay within the column limit.
+		if got := extractedKeys(t, query(t, c.Idx("m"), `Extract(All(), Rows(set), order=key, limit=4, offset=3)`)); !reflect.DeepEqual(got, keys[3:7]) {
+			t.Fatalf("expected %v, got %v", keys[3:7], got)
+		}
+		if got := extractedKeys(t, query(t, c.Idx("m"), `Extract(Row(set=2), Rows(set), order=key, limit=3)`)); !reflect.DeepEqual(got, []string{"k02", "k05", "k08"}) {
+			t.Fatalf("expected [k02 k05 k08], got %v", got)
+		}
+		if _, err := api.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx("m"), Query: `Extract(All(), Rows(set), order=key)`}); err == nil {
+			t.Fatal("expected unlimited Extract to exceed the column limit")
+		}
+
+		// Without keys, columns are ordered by ID either way.
+		for _, order := range []string{"id", "key"} {
+			tbl := query(t, c.Idx("i"), `Extract(All(), Rows(set), order=`+order+`, limit=2, offset=1)`).(pilosa.ExtractedTable)
+			var got []uint64
+			for _, col := range tbl.Columns {
+				got = append(got, col.Column.ID)
+			}
+			if exp := []uint64{5, ShardWidth + 3}; !reflect.DeepEqual(got, exp) {
+				t.Fatalf("order=%s: expected %v, got %v", order, exp, got)
+			}
+		}
+		if got := query(t, c.Idx("i"), `Extract(All(), Rows(set), limit=10, offset=5)`).(pilosa.ExtractedTable).Columns; len(got) != 0 {
+			t.Fatalf("expected no columns past the end, got %v", got)
+		}
+	}
+
+	for _, pql := range []string{
+		`Extract(All(), Rows(set), order=value)`,
+		`Count(All(order=key, limit=2))`,
+		`Extract(Sort(set, limit=2), Rows(set), order=key)`,
+	} {
+		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx("k"), Query: pql}); err == nil {
+			t.Fatalf("%s: expected error", pql)
+		}
+	}
+}
+
+// Ensure Extract gives the same results when its columns are spilled to
+// disk.
+func TestExecutor_Execute_Extract_Spill(t *testing.T) {
+	c := test.MustRunCluster(t, 3, []server.CommandOption{server.OptCommandServerOptions(pilosa.OptServerSpillThreshold(1))})
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "set")
+	c.ImportBits(t, c.Idx(), "set", [][2]uint64{
+		{1, 5},
+		{1, 1},
+		{2, 1},
+		{1, ShardWidth + 3},
+		{2, 2 * ShardWidth},
+		{1, 3*ShardWidth + 7},
+	})
+	c.CreateField(t, c.Idx("k"), pilosa.IndexOptions{TrackExistence: true, Keys: true}, "set")
+	c.Query(t, c.Idx("k"), `
+		Set("c", set=1)
+		Set("a", set=2)
+		Set("b", set=1)
+	`)
+
+	columns := func(t *testing.T, res interface{}) []string {
+		t.Helper()
+		var got []string
+		for _, col := range res.(pilosa.ExtractedTable).Columns {
+			id := col.Column.Key
+			if id == "" {
+				id = strconv.FormatUint(col.Column.ID, 10)
+			}
+			got = append(got, fmt.Sprintf("%s:%v", id, col.Rows[0]))
+		}
+		return got
+	}
+	for _, tt := range []struct {
+		index string
+		query string
+		exp   []string
+	}{
+		{
+			index: c.Idx(),
+			query: `Extract(All(), Rows(set))`,
+			exp:   []string{"1:[1 2]", "5:[1]", fmt.Sprintf("%d:[1]", ShardWidth+3), fmt.Sprintf("%d:[2]", 2*ShardWidth), fmt.Sprintf("%d:[1]", 3*ShardWidth+7)},
+		},
+		{
+			index: c.Idx(),
+			query: `Extract(All(), Rows(set), limit=2, offset=1)`,
+			exp:   []string{"5:[1]", fmt.Sprintf("%d:[1]", ShardWidth+3)},
+		},
+		{
+			index: c.Idx("k"),
+			query: `Extract(All(), Rows(set), order=key)`,
+			exp:   []string{"a:[2]", "b:[1]", "c:[1]"},
+		},
+	} {
+		for i := 0; i < 3; i++ {
+			resp, err := c.GetNode(i).API.Query(context.Background(), &pilosa.QueryRequest{Index: tt.index, Query: tt.query})
+			if err != nil {
+				t.Fatalf("%s: %v", tt.query, err)
+			}
+			if got := columns(t, resp.Results[0]); !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("%s on node %d: expected %v, got %v", tt.query, i, tt.exp, got)
+			}
+		}
+	}
+}
+
+func TestExecutor_Execute_Extract_Where(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	opts := pilosa.IndexOptions{TrackExistence: true, Keys: true}
+	c.CreateField(t, c.Idx(), opts, "bsint", pilosa.OptFieldTypeInt(-100, 100))
+	c.CreateField(t, c.Idx(), opts, "keymutex", pilosa.OptFieldTypeMutex(pilosa.CacheTypeNone, 0), pilosa.OptFieldKeys())
+	c.CreateField(t, c.Idx(), opts, "dec", pilosa.OptFieldTypeDecimal(2))
+	c.CreateField(t, c.Idx(), opts, "b", pilosa.OptFieldTypeBool())
+	c.Query(t, c.Idx(), `
+		Set("a", bsint=1)
+		Set("a", keymutex="h")
+		Set("a", dec=1.5)
+		Set("a", b=true)
+		Set("b", bsint=3)
+		Set("b", keymutex="h")
+		Set("b", dec=2.25)
+		Set("c", bsint=5)
+		Set("c", keymutex="g")
+		Set("c", b=false)
+		Set("d", keymutex="h")
+	`)
+
+	for _, tt := range []struct {
+		where string
+		exp   []string
+	}{
+		{where: `bsint > 2 && keymutex == 'h'`, exp: []string{"b"}},
+		{where: `bsint > 2 || keymutex == "h"`, exp: []string{"a", "b", "c", "d"}},
+		{where: `!(bsint >= 3)`, exp: []string{"a", "d"}},
+		{where: `bsint == null`, exp: []string{"d"}},
+		{where: `bsint != null && keymutex != 'h'`, exp: []string{"c"}},
+		{where: `keymutex == 'missing'`, exp: []string{}},
+		{where: `keymutex != 'missing' && bsint <= -1`, exp: []string{}},
+		{where: `dec < 2`, exp: []string{"a"}},
+		{where: `dec >= 2.25`, exp: []string{"b"}},
+		{where: `b == true || b == false`, exp: []string{"a", "c"}},
+	} {
+		t.Run(tt.where, func(t *testing.T) {
+			for i := 0; i < 3; i++ {
+				resp, err := c.GetNode(i).API.Query(context.Background(), &pilosa.QueryRequest{
+					Index: c.Idx(),
+					Query: fmt.Sprintf(`Extract(All(), Rows(bsint), Rows(keymutex), Rows(dec), Rows(b), where=%q)`, tt.where),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := []string{}
+				for _, col := range resp.Results[0].(pilosa.ExtractedTable).Columns {
+					got = append(got, col.Column.Key)
+				}
+				sort.Strings(got)
+				if !reflect.DeepEqual(got, tt.exp) {
+					t.Fatalf("node %d: expected %v, got %v", i, tt.exp, got)
+				}
+			}
+		})
+	}
+
+	t.Run("Limit", func(t *testing.T) {
+		resp := c.Query(t, c.Idx(), `Extract(All(), Rows(keymutex), where="keymutex == 'h'", limit=2)`)
+		if n := len(resp.Results[0].(pilosa.ExtractedTable).Columns); n != 2 {
+			t.Fatalf("expected 2 columns, got %d", n)
+		}
+	})
+
+	for _, where := range []string{
+		`bsint >`,
+		`bsint > 2 &&`,
+		`(bsint > 2`,
+		`nope == 1`,
+		`keymutex > 'h'`,
+		`bsint == 'h'`,
+		`b == 1`,
+	} {
+		t.Run("Invalid/"+where, func(t *testing.T) {
+			_, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{
+				Index: c.Idx(),
+				Query: fmt.Sprintf(`Extract(All(), Rows(bsint), Rows(keymutex), Rows(b), where=%q)`, where),
+			})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
 func TestExecutor_Execute_MaxMemory(t *testing.T) {
 	c := test.MustRunCluster(t, 3)
 	defer c.Close()
@@ -5275,6 +6894,24 @@ func TestExecutor_Execute_RowsTimeEmpty(t *testing.T) {
 	rows.AssertEqual(t, &pilosa.RowIdentifiers{})
 }
 
+// Ensure that rows of a time field without a standard view are found in
+// its time views when no times are given.
+func TestExecutor_Execute_UnionRowsNoStandardView(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "x", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD"), "0", true))
+	c.Query(t, c.Idx(), fmt.Sprintf(`Set(1, x=1, 2000-01-01T00:00) Set(2, x=2, 2001-02-03T00:00) Set(%d, x=3, 2002-03-04T00:00)`, ShardWidth+3))
+
+	cols := c.Query(t, c.Idx(), `UnionRows(Rows(x))`).Results[0].(*pilosa.Row).Columns()
+	if exp := []uint64{1, 2, ShardWidth + 3}; !reflect.DeepEqual(cols, exp) {
+		t.Fatalf("expected %v, got %v", exp, cols)
+	}
+	cols = c.Query(t, c.Idx(), `UnionRows(Rows(x, in=[1, 3]))`).Results[0].(*pilosa.Row).Columns()
+	if exp := []uint64{1, ShardWidth + 3}; !reflect.DeepEqual(cols, exp) {
+		t.Fatalf("expected %v, got %v", exp, cols)
+	}
+}
+
 func TestExecutor_Execute_Query_Error(t *testing.T) {
 	c := test.MustRunCluster(t, 1)
 	defer c.Close()
@@ -5896,6 +7533,15 @@ func TestExecutor_ForeignIndex(t *testing.T) {
 		t.Fatalf("unexpected columns: %v", neq.Columns())
 	}
 
+	in := c.Query(t, child, `Row(parent_id in ["one", "twenty-one", "missing"])`).Results[0].(*pilosa.Row)
+	if !reflect.DeepEqual(in.Columns(), []uint64{1, 4, ShardWidth}) {
+		t.Fatalf("unexpected columns: %v", in.Columns())
+	}
+	in = c.Query(t, child, `Row(parent_id in ["missing"])`).Results[0].(*pilosa.Row)
+	if len(in.Columns()) != 0 {
+		t.Fatalf("unexpected columns: %v", in.Columns())
+	}
+
 	join := c.Query(t, parent, fmt.Sprintf(`Intersect(Row(general=%d), Distinct(Row(color="blue"), index=%c, field="parent_id"))`, ShardWidth, c)).Results[0].(*pilosa.Row)
 	if !reflect.DeepEqual(join.Keys, []string{"one"}) {
 		t.Fatalf("unexpected keys: %v", join.Keys)
@@ -5927,34 +7573,341 @@ func sameStringSlice(x, y []string) bool {
 		if diff[_y] == 0 {
 			delete(diff, _y)
 		}
-	}
-	return len(diff) == 0
+	}
+	return len(diff) == 0
+}
+
+func TestExecutor_Execute_DistinctFailure(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "general")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "v", pilosa.OptFieldTypeInt(0, 1000))
+	c.ImportBits(t, c.Idx(), "general", [][2]uint64{
+		{10, 0},
+		{10, 1},
+		{10, ShardWidth + 1},
+		{11, 2},
+		{11, ShardWidth + 2},
+		{12, 2},
+		{12, ShardWidth + 2},
+	})
+
+	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Set(0, v=10)`}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Set(1, v=100)`}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("BasicDistinct", func(t *testing.T) {
+		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Distinct(field="v")`}); err != nil {
+			t.Fatalf("unexpected error: \"%v\"", err)
+		}
+	})
+}
+
+func TestExecutor_Execute_DistinctLimit(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "v", pilosa.OptFieldTypeInt(-1000, 1000))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "f")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "ts", pilosa.OptFieldTypeTimestamp(pilosa.DefaultEpoch, pilosa.TimeUnitSeconds))
+
+	// Each shard has some of the values, and some values are in several
+	// shards.
+	values := []int64{-900, -500, -20, -3, 0, 4, 17, 600}
+	rows := []uint64{1, 5, 9, 12, 40}
+	var sets strings.Builder
+	for shard := uint64(0); shard < 4; shard++ {
+		for i := uint64(0); i < 6; i++ {
+			col := shard*ShardWidth + i
+			fmt.Fprintf(&sets, "Set(%d, v=%d)\nSet(%d, f=%d)\n", col, values[(shard*3+i)%uint64(len(values))], col, rows[(shard+i)%uint64(len(rows))])
+		}
+	}
+	c.Query(t, c.Idx(), sets.String())
+
+	signedValues := func(s pilosa.SignedRow) []int64 {
+		vals := []int64{}
+		negs := s.Neg.Columns()
+		for i := len(negs) - 1; i >= 0; i-- {
+			vals = append(vals, -int64(negs[i]))
+		}
+		for _, col := range s.Pos.Columns() {
+			vals = append(vals, int64(col))
+		}
+		return vals
+	}
+	page := func(offset, limit int) (int, int) {
+		if offset > len(values) {
+			offset = len(values)
+		}
+		if limit < 0 || offset+limit > len(values) {
+			return offset, len(values)
+		}
+		return offset, offset + limit
+	}
+
+	for _, tc := range []struct{ offset, limit int }{
+		{0, 3}, {0, 4}, {2, 3}, {3, 4}, {5, 10}, {8, 2}, {2, -1}, {0, 0},
+	} {
+		args := fmt.Sprintf("offset=%d", tc.offset)
+		if tc.limit >= 0 {
+			args += fmt.Sprintf(", limit=%d", tc.limit)
+		}
+		for i := 0; i < 3; i++ {
+			from, to := page(tc.offset, tc.limit)
+			resp, err := c.GetNode(i).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: fmt.Sprintf(`Distinct(field=v, %s)`, args)})
+			if err != nil {
+				t.Fatal(err)
+			} else if got := signedValues(resp.Results[0].(pilosa.SignedRow)); !reflect.DeepEqual(got, values[from:to]) {
+				t.Fatalf("node %d, %s: expected %v, got %v", i, args, values[from:to], got)
+			}
+
+			from, to = tc.offset, tc.offset+tc.limit
+			if from > len(rows) {
+				from = len(rows)
+			}
+			if tc.limit < 0 || to > len(rows) {
+				to = len(rows)
+			}
+			resp, err = c.GetNode(i).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: fmt.Sprintf(`Distinct(field=f, %s)`, args)})
+			if err != nil {
+				t.Fatal(err)
+			} else if got := resp.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(got, rows[from:to]) {
+				t.Fatalf("node %d, %s: expected rows %v, got %v", i, args, rows[from:to], got)
+			}
+		}
+	}
+
+	if n := c.Query(t, c.Idx(), `Count(Distinct(field=v, limit=5))`).Results[0]; n != uint64(5) {
+		t.Fatalf("expected count of 5, got %v", n)
+	}
+
+	_, err := c.GetPrimary().API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Distinct(field=ts, limit=2)`})
+	var bre pilosa.BadRequestError
+	if !errors.As(err, &bre) {
+		t.Fatalf("expected bad request error for a timestamp field, got %v", err)
+	}
+}
+
+func TestExecutor_Execute_DistinctSignedInput(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "v", pilosa.OptFieldTypeInt(-1000, 1000))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "f")
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(1, v=-5) Set(2, v=3) Set(%d, v=7) Set(%d, v=-9)
+		Set(3, f=1) Set(7, f=1) Set(%d, f=2)`, ShardWidth, ShardWidth+1, ShardWidth))
+
+	// Used as columns, negative values would be left out, so queries using
+	// them fail.
+	for _, q := range []string{
+		`Intersect(Row(f=1), Distinct(field=v))`,
+		`Union(Distinct(field=v), Row(f=2))`,
+		`GroupBy(Rows(f), filter=Distinct(field=v))`,
+		`x = Distinct(field=v) Count(Union(x))`,
+	} {
+		_, err := c.GetNode(1).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: q})
+		if !errors.Is(err, pilosa.ErrSignedInput) {
+			t.Fatalf("%s: expected signed input error, got %v", q, err)
+		} else if code, _ := pilosa.ErrorCodeOf(err); code != pilosa.ErrorCodeSignedInput {
+			t.Fatalf("%s: expected code %s, got %s", q, pilosa.ErrorCodeSignedInput, code)
+		}
+	}
+
+	// Without negative values, they work as before.
+	row := c.Query(t, c.Idx(), `Intersect(Row(f=1), Distinct(Row(v >= 0), field=v))`).Results[0].(*pilosa.Row)
+	if cols := row.Columns(); !reflect.DeepEqual(cols, []uint64{3, 7}) {
+		t.Fatalf("unexpected columns: %v", cols)
+	}
+	// Counting, or returning, the values themselves is fine.
+	if n := c.Query(t, c.Idx(), `Count(Distinct(field=v))`).Results[0]; n != uint64(4) {
+		t.Fatalf("expected 4 values, got %v", n)
+	}
+}
+
+func TestExecutor_Execute_GroupBy_Period(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "general")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "tq", pilosa.OptFieldTypeTime("YMD", "0"))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "v", pilosa.OptFieldTypeInt(0, 1000))
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(1, tq=1, 2022-01-01T10:00)
+		Set(2, tq=1, 2022-01-01T11:00)
+		Set(%d, tq=1, 2022-01-02T00:00)
+		Set(3, tq=2, 2022-01-02T00:00)
+		Set(4, tq=2, 2022-02-10T00:00)
+		Set(1, general=10)
+		Set(%[1]d, general=10)
+		Set(3, general=11)
+		Set(1, v=5)
+		Set(2, v=7)
+	`, ShardWidth+1))
+
+	type group struct {
+		row    uint64
+		period string
+		count  uint64
+	}
+	check := func(t *testing.T, query string, field int, expected []group) {
+		t.Helper()
+		groups := c.Query(t, c.Idx(), query).Results[0].(*pilosa.GroupCounts).Groups()
+		got := make([]group, len(groups))
+		for i, gc := range groups {
+			got[i] = group{row: gc.Group[field].RowID, period: gc.Group[field].Period, count: gc.Count}
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("%s: expected %v, got %v", query, expected, got)
+		}
+	}
+
+	t.Run("Day", func(t *testing.T) {
+		check(t, `GroupBy(Rows(tq, period="D"))`, 0, []group{
+			{1, "2022-01-01", 2},
+			{1, "2022-01-02", 1},
+			{2, "2022-01-02", 1},
+			{2, "2022-02-10", 1},
+		})
+	})
+	t.Run("Month", func(t *testing.T) {
+		check(t, `GroupBy(Rows(tq, period="M"))`, 0, []group{
+			{1, "2022-01", 3},
+			{2, "2022-01", 1},
+			{2, "2022-02", 1},
+		})
+	})
+	t.Run("Range", func(t *testing.T) {
+		check(t, `GroupBy(Rows(tq, period="D", from="2022-01-02T00:00", to="2022-02-01T00:00"))`, 0, []group{
+			{1, "2022-01-02", 1},
+			{2, "2022-01-02", 1},
+		})
+	})
+	t.Run("WithRows", func(t *testing.T) {
+		check(t, `GroupBy(Rows(general), Rows(tq, period="D"))`, 1, []group{
+			{1, "2022-01-01", 1},
+			{1, "2022-01-02", 1},
+			{2, "2022-01-02", 1},
+		})
+	})
+	t.Run("Aggregate", func(t *testing.T) {
+		groups := c.Query(t, c.Idx(), `GroupBy(Rows(tq, period="D"), aggregate=Sum(field=v), limit=1)`).Results[0].(*pilosa.GroupCounts).Groups()
+		if len(groups) != 1 || groups[0].Agg != 12 || groups[0].Group[0].Period != "2022-01-01" {
+			t.Fatalf("unexpected groups %+v", groups)
+		}
+		groups = c.Query(t, c.Idx(), `GroupBy(Rows(tq, period="D"), aggregate=Count(Distinct(field=v)))`).Results[0].(*pilosa.GroupCounts).Groups()
+		if len(groups) != 4 || groups[0].Agg != 2 || groups[1].Agg != 0 {
+			t.Fatalf("unexpected groups %+v", groups)
+		}
+	})
+	t.Run("Errors", func(t *testing.T) {
+		for query, msg := range map[string]string{
+			`GroupBy(Rows(tq, period="W"))`:             "invalid period",
+			`GroupBy(Rows(tq, period="H"))`:             "no views for period",
+			`GroupBy(Rows(general, period="D"))`:        "period requires a time field",
+			`GroupBy(Rows(tq, period="D", previous=1))`: "previous is not supported",
+		} {
+			_, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: query})
+			if err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("%s: expected error containing %q, got %v", query, msg, err)
+			}
+		}
+	})
 }
 
-func TestExecutor_Execute_DistinctFailure(t *testing.T) {
-	c := test.MustRunCluster(t, 1)
+func TestExecutor_Execute_GroupBy_Buckets(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
 	defer c.Close()
 	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "general")
-	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "v", pilosa.OptFieldTypeInt(0, 1000))
-	c.ImportBits(t, c.Idx(), "general", [][2]uint64{
-		{10, 0},
-		{10, 1},
-		{10, ShardWidth + 1},
-		{11, 2},
-		{11, ShardWidth + 2},
-		{12, 2},
-		{12, ShardWidth + 2},
-	})
-
-	if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Set(0, v=10)`}); err != nil {
-		t.Fatal(err)
-	} else if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Set(1, v=100)`}); err != nil {
-		t.Fatal(err)
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "v", pilosa.OptFieldTypeInt(-100, 10000))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "w", pilosa.OptFieldTypeInt(0, 1000))
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(1, v=5)
+		Set(2, v=7)
+		Set(%d, v=50)
+		Set(3, v=-5)
+		Set(4, v=2000)
+		Set(5, v=100)
+		Set(6, v=999)
+		Set(1, general=10)
+		Set(%[1]d, general=10)
+		Set(3, general=11)
+		Set(5, general=11)
+		Set(1, w=1)
+		Set(2, w=2)
+		Set(5, w=3)
+		Set(6, w=3)
+	`, ShardWidth+1))
+
+	type group struct {
+		from, to int64
+		count    uint64
+		agg      int64
+	}
+	check := func(t *testing.T, query string, field int, expected []group) {
+		t.Helper()
+		for _, node := range c.Nodes {
+			resp, err := node.API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: query})
+			if err != nil {
+				t.Fatal(err)
+			}
+			groups := resp.Results[0].(*pilosa.GroupCounts).Groups()
+			got := make([]group, len(groups))
+			for i, gc := range groups {
+				fr := gc.Group[field]
+				if fr.Value == nil || fr.ValueTo == nil {
+					t.Fatalf("%s: expected a bucketed group, got %v", query, fr)
+				}
+				got[i] = group{from: *fr.Value, to: *fr.ValueTo, count: gc.Count, agg: gc.Agg}
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Fatalf("%s: expected %v, got %v", query, expected, got)
+			}
+		}
 	}
 
-	t.Run("BasicDistinct", func(t *testing.T) {
-		if _, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `Distinct(field="v")`}); err != nil {
-			t.Fatalf("unexpected error: \"%v\"", err)
+	t.Run("Buckets", func(t *testing.T) {
+		check(t, `GroupBy(Rows(v, buckets=[0, 10, 100, 1000]))`, 0, []group{
+			{0, 10, 2, 0},
+			{10, 100, 1, 0},
+			{100, 1000, 2, 0},
+		})
+	})
+	t.Run("Negative", func(t *testing.T) {
+		check(t, `GroupBy(Rows(v, buckets=[-10, 0, 6]))`, 0, []group{
+			{-10, 0, 1, 0},
+			{0, 6, 1, 0},
+		})
+	})
+	t.Run("WithRows", func(t *testing.T) {
+		check(t, `GroupBy(Rows(general), Rows(v, buckets=[0, 10, 100, 1000]))`, 1, []group{
+			{0, 10, 1, 0},
+			{10, 100, 1, 0},
+			{100, 1000, 1, 0},
+		})
+	})
+	t.Run("Aggregate", func(t *testing.T) {
+		check(t, `GroupBy(Rows(v, buckets=[0, 10, 100, 1000]), aggregate=Sum(field=w))`, 0, []group{
+			{0, 10, 2, 3},
+			{100, 1000, 2, 6},
+		})
+		check(t, `GroupBy(Rows(v, buckets=[0, 10, 100, 1000]), aggregate=Count(Distinct(field=w)))`, 0, []group{
+			{0, 10, 2, 2},
+			{10, 100, 1, 0},
+			{100, 1000, 2, 1},
+		})
+	})
+	t.Run("Errors", func(t *testing.T) {
+		for query, msg := range map[string]string{
+			`GroupBy(Rows(v, buckets=[10]))`:               "at least two bounds",
+			`GroupBy(Rows(v, buckets=[10, 5]))`:            "increasing order",
+			`GroupBy(Rows(general, buckets=[0, 1]))`:       "buckets requires an int field",
+			`GroupBy(Rows(v, buckets=[0, 1], previous=1))`: "can't be combined",
+			`GroupBy(Rows(v, buckets=[0, 1], in=[1, 2]))`:  "can't be combined",
+		} {
+			_, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: query})
+			if err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("%s: expected error containing %q, got %v", query, msg, err)
+			}
 		}
 	})
 }
@@ -6110,6 +8063,82 @@ func TestExecutor_Execute_GroupBy(t *testing.T) {
 
 		})
 
+		t.Run("LimitOffset", func(t *testing.T) {
+			expected := []pilosa.GroupCount{
+				{Group: []pilosa.FieldRow{{Field: "general", RowID: 10}, {Field: "sub", RowID: 110}}, Count: 1},
+				{Group: []pilosa.FieldRow{{Field: "general", RowID: 11}, {Field: "sub", RowID: 110}}, Count: 1},
+			}
+
+			results := c.Query(t, c.Idx(), `GroupBy(Rows(general), Rows(sub), limit=2, offset=1)`).Results[0].(*pilosa.GroupCounts).Groups()
+			test.CheckGroupBy(t, expected, results)
+		})
+
+		// These having-conditions are pushed down to the shards, which
+		// only return groups reaching half the bound. Groups below that in
+		// one shard must still be counted in full.
+		t.Run("HavingCountPushdown", func(t *testing.T) {
+			expected := map[string][]pilosa.GroupCount{
+				"GroupBy(Rows(general), having=Condition(count>2))": {
+					{Group: []pilosa.FieldRow{{Field: "general", RowID: 10}}, Count: 3},
+				},
+				"GroupBy(Rows(sub), having=Condition(count>=4))": {
+					{Group: []pilosa.FieldRow{{Field: "sub", RowID: 100}}, Count: 4},
+				},
+				"GroupBy(Rows(general), Rows(sub), having=Condition(3<=count<10))": {
+					{Group: []pilosa.FieldRow{{Field: "general", RowID: 10}, {Field: "sub", RowID: 100}}, Count: 3},
+				},
+				"GroupBy(Rows(general), having=Condition(count>2), limit=1)": {
+					{Group: []pilosa.FieldRow{{Field: "general", RowID: 10}}, Count: 3},
+				},
+				"GroupBy(Rows(general), having=Condition(count>10))": {},
+			}
+
+			for query, want := range expected {
+				results := c.Query(t, c.Idx(), query).Results[0].(*pilosa.GroupCounts).Groups()
+				test.CheckGroupBy(t, want, results)
+			}
+		})
+
+		t.Run("HavingSumPushdown", func(t *testing.T) {
+			expected := []pilosa.GroupCount{
+				{Group: []pilosa.FieldRow{{Field: "general", RowID: 10}}, Count: 2, Agg: 110},
+			}
+
+			results := c.Query(t, c.Idx(), `GroupBy(Rows(general), aggregate=Sum(field=v), having=Condition(sum>100))`).Results[0].(*pilosa.GroupCounts).Groups()
+			test.CheckGroupBy(t, expected, results)
+		})
+
+		// Groups can't have more distinct values of an int field than
+		// they count, so lower bounds on the distinct count are pushed
+		// down as bounds on the count.
+		t.Run("HavingCountDistinct", func(t *testing.T) {
+			expected := map[string][]pilosa.GroupCount{
+				"GroupBy(Rows(general), aggregate=Count(Distinct(field=v)), having=Condition(aggregate>=2))": {
+					{Group: []pilosa.FieldRow{{Field: "general", RowID: 10}}, Count: 3, Agg: 2},
+				},
+				"GroupBy(Rows(general), aggregate=Count(Distinct(field=v)), having=Condition(aggregate<1))": {
+					{Group: []pilosa.FieldRow{{Field: "general", RowID: 11}}, Count: 2, Agg: 0},
+					{Group: []pilosa.FieldRow{{Field: "general", RowID: 12}}, Count: 2, Agg: 0},
+				},
+				"GroupBy(Rows(general), aggregate=Count(Distinct(field=v)), having=Condition(aggregate>2))": {},
+			}
+			for query, want := range expected {
+				results := c.Query(t, c.Idx(), query).Results[0].(*pilosa.GroupCounts).Groups()
+				test.CheckGroupBy(t, want, results)
+			}
+
+			for query, msg := range map[string]string{
+				"GroupBy(Rows(general), having=Condition(aggregate>1))":                       "requires a GroupBy aggregate",
+				"GroupBy(Rows(general), aggregate=Sum(field=v), having=Condition(min>1))":     "requires a GroupBy aggregate",
+				"GroupBy(Rows(general), aggregate=Sum(field=v), having=Condition(average>1))": "only supports",
+			} {
+				_, err := c.GetNode(0).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: query})
+				if err == nil || !strings.Contains(err.Error(), msg) {
+					t.Errorf("%s: expected error containing %q, got %v", query, msg, err)
+				}
+			}
+		})
+
 		c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "a")
 		c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "b")
 		c.ImportBits(t, c.Idx(), "a", [][2]uint64{
@@ -7204,6 +9233,73 @@ func Test_Executor_Execute_UnionRows(t *testing.T) {
 	}
 }
 
+func Test_Executor_Execute_IntersectRows(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "s")
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "t",
+		pilosa.OptFieldTypeTime("YMD", "0"),
+	)
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "k",
+		pilosa.OptFieldKeys(),
+	)
+
+	// Spread the data over several shards, so rows are missing from some.
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+			Set(1, s=1)
+			Set(1, s=2)
+			Set(2, s=1)
+			Set(%[1]d, s=1)
+			Set(%[1]d, s=2)
+			Set(%[2]d, s=3)
+			Set(1, t=1, 2020-01-01T00:00)
+			Set(1, t=2, 2021-01-01T00:00)
+			Set(2, t=1, 2021-01-01T00:00)
+			Set(2, t=2, 2021-06-01T00:00)
+			Set(1, k="us-east")
+			Set(%[1]d, k="us-east")
+			Set(%[1]d, k="us-west")
+			Set(%[2]d, k="eu-west")
+		`, ShardWidth+1, 2*ShardWidth+1))
+
+	for _, tt := range []struct {
+		pql string
+		exp []uint64
+	}{
+		{`UnionRows(Rows(s))`, []uint64{1, 2, ShardWidth + 1, 2*ShardWidth + 1}},
+		{`UnionRows(Rows(s, in=[2, 3]))`, []uint64{1, ShardWidth + 1, 2*ShardWidth + 1}},
+		{`UnionRows(Rows(s, limit=1))`, []uint64{1, 2, ShardWidth + 1}},
+		{`UnionRows(Rows(k, like="us-%"))`, []uint64{1, ShardWidth + 1}},
+		{`UnionRows(Rows(k, like="%-west"))`, []uint64{ShardWidth + 1, 2*ShardWidth + 1}},
+		{`UnionRows(Rows(k, like="nope%"))`, nil},
+		{`UnionRows(Rows(t, from=2021-01-01T00:00, to=2022-01-01T00:00))`, []uint64{1, 2}},
+		{`IntersectRows(Rows(s, in=[1, 2]))`, []uint64{1, ShardWidth + 1}},
+		{`IntersectRows(Rows(s, in=[1, 2, 4]))`, nil},
+		{`IntersectRows(Rows(s))`, nil},
+		{`IntersectRows(Rows(s, limit=2))`, []uint64{1, ShardWidth + 1}},
+		{`IntersectRows(Rows(k, like="us-%"))`, []uint64{ShardWidth + 1}},
+		{`IntersectRows(Rows(k, like="nope%"))`, nil},
+		{`IntersectRows(Rows(t))`, []uint64{1, 2}},
+		{`IntersectRows(Rows(t, from=2021-01-01T00:00, to=2022-01-01T00:00))`, []uint64{2}},
+		{`IntersectRows(Rows(s, in=[1]), Rows(t, in=[2]))`, []uint64{1, 2}},
+	} {
+		for i := 0; i < 3; i++ {
+			resp, err := c.GetNode(i).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: tt.pql})
+			if err != nil {
+				t.Fatalf("%s: %v", tt.pql, err)
+			}
+			if cols := resp.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, tt.exp) && (len(cols) != 0 || len(tt.exp) != 0) {
+				t.Fatalf("%s on node %d: expected %v, got %v", tt.pql, i, tt.exp, cols)
+			}
+		}
+	}
+
+	if res := c.Query(t, c.Idx(), `Count(IntersectRows(Rows(s, in=[1, 2])))`); res.Results[0] != uint64(2) {
+		t.Errorf("expected 2 columns, got %v", res.Results[0])
+	}
+}
+
 func TestTimelessClearRegression(t *testing.T) {
 	data, err := os.ReadFile("testdata/timeRegressionSchema.json")
 	if err != nil {
@@ -8773,6 +10869,14 @@ icecream,6,3
 			query:       "GroupBy(Rows(field=likes), aggregate=Count(Distinct(field=zip_code)), having=Condition(sum>2))",
 			csvVerifier: "icecream,6,3\n",
 		},
+		{
+			query:       "GroupBy(Rows(field=likes), aggregate=Count(Distinct(field=zip_code)), having=Condition(aggregate>=3))",
+			csvVerifier: "icecream,6,3\n",
+		},
+		{
+			query:       "GroupBy(Rows(field=likes), aggregate=Count(Distinct(field=zip_code)), having=Condition(aggregate<1))",
+			csvVerifier: "dog,1,0\n",
+		},
 		{
 			query: "GroupBy(Rows(field=likes), filter=Row(affinity>-11), aggregate=Count(Distinct(field=zip_code)))",
 			csvVerifier: `molecula,1,1
@@ -9782,3 +11886,452 @@ func TestExecutorTimeRange(t *testing.T) {
 	})
 
 }
+
+// Ensure the result limits configured on an index are enforced, and can be
+// bypassed with IgnoreResultLimits.
+func TestExecutor_Execute_ResultLimits(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	iopts := pilosa.IndexOptions{TrackExistence: true, MaxColumns: 3, MaxGroups: 2, MaxRows: 2}
+	c.CreateField(t, c.Idx(), iopts, "f")
+	c.CreateField(t, c.Idx(), iopts, "g")
+	c.ImportBits(t, c.Idx(), "f", [][2]uint64{
+		{0, 1},
+		{1, ShardWidth + 1},
+		{2, 2 * ShardWidth},
+		{3, 3 * ShardWidth},
+	})
+	// Every shard has more groups than allowed, so that nodes other than
+	// the coordinator exceed the limit on their own shards.
+	var bits [][2]uint64
+	for shard := uint64(0); shard < 4; shard++ {
+		for row := uint64(10); row < 13; row++ {
+			bits = append(bits, [2]uint64{row, shard*ShardWidth + 5})
+		}
+	}
+	c.ImportBits(t, c.Idx(), "g", bits)
+
+	api := c.GetPrimary().API
+	for q, exp := range map[string]pilosa.ResultLimitError{
+		`All()`: {Kind: "columns", Limit: 3},
+		`Union(Row(f=0), Row(f=1), Row(f=2), Row(f=3))`: {Kind: "columns", Limit: 3},
+		`Extract(All(), Rows(f))`:                       {Kind: "columns", Limit: 3},
+		`GroupBy(Rows(f))`:                              {Kind: "groups", Limit: 2},
+		`GroupBy(Rows(g))`:                              {Kind: "groups", Limit: 2},
+		`Rows(f)`:                                       {Kind: "rows", Limit: 2},
+	} {
+		t.Run(q, func(t *testing.T) {
+			_, err := api.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: q})
+			if !errors.Is(err, pilosa.ErrResultLimitExceeded) {
+				t.Fatalf("expected result limit error, got %v", err)
+			}
+			var rle pilosa.ResultLimitError
+			if !errors.As(err, &rle) || rle.Index != c.Idx() || rle.Kind != exp.Kind || rle.Limit != exp.Limit {
+				t.Fatalf("expected ResultLimitError for index %q with %d %s, got %#v", c.Idx(), exp.Limit, exp.Kind, err)
+			}
+
+			if _, err := api.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: q, IgnoreResultLimits: true}); err != nil {
+				t.Fatalf("ignoring result limits: %v", err)
+			}
+		})
+	}
+
+	// Results within the limits, and counts over large results, are unaffected.
+	for _, q := range []string{
+		`Count(All())`,
+		`Row(f=0)`,
+		`Extract(Limit(All(), limit=3), Rows(f))`,
+		`GroupBy(Rows(f), limit=2)`,
+		`Rows(f, limit=2)`,
+	} {
+		if _, err := api.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: q}); err != nil {
+			t.Fatalf("query %s: %v", q, err)
+		}
+	}
+}
+
+func TestExecutor_Execute_MutexHistory(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "f", pilosa.OptFieldTypeMutex(pilosa.CacheTypeNone, 0), pilosa.OptFieldHistory("YMD"))
+
+	// Column 1 is 10 until February, then 20. Column 2 is 10 until March,
+	// then 30. Column 3 is always 10.
+	c.Query(t, c.Idx(), `
+		Set(1, f=10, 2020-01-01T00:00)
+		Set(1, f=20, 2020-02-01T00:00)
+		Set(2, f=10, 2020-01-15T00:00)
+		Set(2, f=30, 2020-03-01T00:00)
+		Set(3, f=10, 2020-01-01T00:00)
+		Set(3, f=10, 2020-04-01T00:00)
+	`)
+
+	for q, exp := range map[string][]uint64{
+		`Row(f=10)`: {3},
+		`Row(f=10, from=2020-01-20T00:00, to=2020-02-15T00:00)`: {1},
+		`Row(f=10, from=2020-01-01T00:00, to=2021-01-01T00:00)`: {1, 2},
+		`Row(f=20, from=2020-01-01T00:00, to=2021-01-01T00:00)`: nil,
+		`Row(f=10, at=2020-01-20T00:00)`:                        {1, 2, 3},
+		`Row(f=10, at=2020-02-15T00:00)`:                        {2, 3},
+		`Row(f=20, at=2020-02-15T00:00)`:                        {1},
+		`Row(f=30, at=2020-02-15T00:00)`:                        nil,
+		`Row(f=30, at=2020-03-15T00:00)`:                        {2},
+	} {
+		res := c.Query(t, c.Idx(), q)
+		if cols := res.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, exp) && !(len(cols) == 0 && len(exp) == 0) {
+			t.Fatalf("%s: expected %v, got %v", q, exp, cols)
+		}
+	}
+
+	res := c.Query(t, c.Idx(), `Rows(f, from=2020-01-01T00:00, to=2021-01-01T00:00)`)
+	if rows := res.Results[0].(pilosa.RowIdentifiers).Rows; !reflect.DeepEqual(rows, []uint64{10}) {
+		t.Fatalf("expected values 10 to have changed, got %v", rows)
+	}
+
+	// Clearing a value records it as of now.
+	c.Query(t, c.Idx(), `Clear(1, f=20)`)
+	now := time.Now().UTC()
+	q := fmt.Sprintf(`Row(f=20, from=%s, to=%s)`, now.Add(-24*time.Hour).Format("2006-01-02T15:04"), now.Add(24*time.Hour).Format("2006-01-02T15:04"))
+	if cols := c.Query(t, c.Idx(), q).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, []uint64{1}) {
+		t.Fatalf("expected column 1 to have been cleared, got %v", cols)
+	}
+
+	if _, err := c.GetPrimary().API.CreateField(context.Background(), c.Idx(), "s", pilosa.OptFieldTypeDefault(), pilosa.OptFieldHistory("YMD")); err == nil {
+		t.Fatal("expected error for history on a set field")
+	}
+}
+
+func TestExecutor_Execute_BoolNull(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "b", pilosa.OptFieldTypeBool())
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true}, "f")
+
+	// Column 4 exists without a value for b, and column 5 is set to null.
+	c.Query(t, c.Idx(), fmt.Sprintf(`
+		Set(1, b=true)
+		Set(2, b=true)
+		Set(%d, b=false)
+		Set(4, f=1)
+		Set(5, b=true)
+		Set(5, b=null)
+		Set(1, f=1)
+		Set(%d, f=1)
+	`, ShardWidth+3, ShardWidth+3))
+
+	for q, exp := range map[string]uint64{
+		`CountTrue(field=b)`:           2,
+		`CountFalse(field=b)`:          1,
+		`CountNull(field=b)`:           2,
+		`CountTrue(Row(f=1), field=b)`: 1,
+		`CountNull(Row(f=1), field=b)`: 1,
+		`Count(Row(b=null))`:           2,
+		`Count(Not(Row(b=true)))`:      3,
+	} {
+		if n := c.Query(t, c.Idx(), q).Results[0].(uint64); n != exp {
+			t.Fatalf("%s: expected %d, got %d", q, exp, n)
+		}
+	}
+	if cols := c.Query(t, c.Idx(), `Row(b=null)`).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, []uint64{4, 5}) {
+		t.Fatalf("expected null columns [4 5], got %v", cols)
+	}
+
+	if _, err := c.GetPrimary().API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: `CountTrue(field=f)`}); err == nil {
+		t.Fatal("expected error counting a set field")
+	}
+	c.CreateField(t, c.Idx("noexist"), pilosa.IndexOptions{}, "b", pilosa.OptFieldTypeBool())
+	if _, err := c.GetPrimary().API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx("noexist"), Query: `CountNull(field=b)`}); err == nil {
+		t.Fatal("expected error counting nulls without existence tracking")
+	}
+}
+
+func TestExecutor_Execute_CascadeDelete(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	parent, child := c.Idx("p"), c.Idx("c")
+
+	c.CreateField(t, parent, pilosa.IndexOptions{Keys: true, TrackExistence: true}, "general")
+	c.CreateField(t, child, pilosa.IndexOptions{TrackExistence: true}, "orphan", pilosa.OptFieldTypeBool())
+	c.CreateField(t, child, pilosa.IndexOptions{TrackExistence: true}, "ps",
+		pilosa.OptFieldForeignIndex(parent),
+		pilosa.OptFieldCascade(pilosa.CascadeClear),
+	)
+	c.CreateField(t, child, pilosa.IndexOptions{TrackExistence: true}, "pi",
+		pilosa.OptFieldTypeInt(0, math.MaxInt64),
+		pilosa.OptFieldForeignIndex(parent),
+		pilosa.OptFieldCascade(pilosa.CascadeClear),
+	)
+	c.CreateField(t, child, pilosa.IndexOptions{TrackExistence: true}, "pm",
+		pilosa.OptFieldTypeMutex(pilosa.CacheTypeNone, 0),
+		pilosa.OptFieldForeignIndex(parent),
+		pilosa.OptFieldCascade("flag:orphan"),
+	)
+
+	c.Query(t, parent, `
+		Set("one", general=1)
+		Set("two", general=1)
+		Set("three", general=2)
+	`)
+	c.Query(t, child, fmt.Sprintf(`
+		Set(1, ps="one")
+		Set(2, ps="two")
+		Set(%[1]d, ps="one")
+		Set(4, ps="three")
+		Set(1, pi="one")
+		Set(2, pi="two")
+		Set(%[1]d, pi="one")
+		Set(4, pi="three")
+		Set(1, pm="one")
+		Set(2, pm="two")
+		Set(%[1]d, pm="one")
+		Set(4, pm="three")
+	`, ShardWidth))
+
+	c.Query(t, parent, `Delete(Row(general=1))`)
+
+	for q, exp := range map[string][]uint64{
+		`Row(ps="three")`:   {4},
+		`Row(pi!=null)`:     {4},
+		`Row(pi="three")`:   {4},
+		`Row(orphan=true)`:  {1, 2, ShardWidth},
+		`Row(orphan=false)`: nil,
+		`Row(pm="three")`:   {4},
+	} {
+		if cols := c.Query(t, child, q).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, exp) && len(cols)+len(exp) > 0 {
+			t.Fatalf("%s: expected %v, got %v", q, exp, cols)
+		}
+	}
+	if row := c.Query(t, child, `Distinct(field=ps)`).Results[0].(*pilosa.Row); !reflect.DeepEqual(row.Keys, []string{"three"}) {
+		t.Fatalf("expected references [three], got %v", row.Keys)
+	}
+
+	// Flagging needs a bool field in the child index.
+	if _, err := c.GetPrimary().API.CreateField(context.Background(), child, "bad",
+		pilosa.OptFieldForeignIndex(parent), pilosa.OptFieldCascade("flag:ps")); err == nil {
+		t.Fatal("expected error flagging a set field")
+	}
+	if _, err := c.GetPrimary().API.CreateField(context.Background(), child, "bad",
+		pilosa.OptFieldCascade(pilosa.CascadeClear)); err == nil {
+		t.Fatal("expected error cascading without a foreign index")
+	}
+}
+
+func TestExecutor_Execute_GroupByVia(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	parent, child := c.Idx("p"), c.Idx("c")
+
+	c.CreateField(t, parent, pilosa.IndexOptions{Keys: true}, "region", pilosa.OptFieldKeys())
+	c.CreateField(t, child, pilosa.IndexOptions{}, "customer",
+		pilosa.OptFieldTypeInt(0, math.MaxInt64),
+		pilosa.OptFieldForeignIndex(parent),
+	)
+	c.CreateField(t, child, pilosa.IndexOptions{}, "customers",
+		pilosa.OptFieldForeignIndex(parent),
+	)
+	c.CreateField(t, child, pilosa.IndexOptions{}, "kind", pilosa.OptFieldKeys())
+	c.CreateField(t, child, pilosa.IndexOptions{}, "region", pilosa.OptFieldKeys())
+	c.CreateField(t, child, pilosa.IndexOptions{}, "amount", pilosa.OptFieldTypeInt(0, 1000))
+
+	c.Query(t, parent, `
+		Set("alice", region="east")
+		Set("bob", region="west")
+		Set("carol", region="east")
+	`)
+	c.Query(t, child, fmt.Sprintf(`
+		Set(1, customer="alice")
+		Set(2, customer="bob")
+		Set(%[1]d, customer="carol")
+		Set(4, customer="bob")
+		Set(1, customers="alice")
+		Set(1, customers="bob")
+		Set(%[1]d, customers="carol")
+		Set(1, kind="buy")
+		Set(2, kind="buy")
+		Set(%[1]d, kind="sell")
+		Set(4, kind="sell")
+		Set(1, region="north")
+		Set(1, amount=10)
+		Set(2, amount=20)
+		Set(%[1]d, amount=30)
+		Set(4, amount=40)
+	`, ShardWidth))
+
+	// groupsString renders groups as "field=key,...:count[:agg]" strings.
+	groupsString := func(gcs *pilosa.GroupCounts) []string {
+		var out []string
+		for _, gc := range gcs.Groups() {
+			var s string
+			for _, fr := range gc.Group {
+				s += fmt.Sprintf("%s=%s,", fr.Field, fr.RowKey)
+			}
+			s += fmt.Sprintf(":%d", gc.Count)
+			if gc.Agg != 0 {
+				s += fmt.Sprintf(":%d", gc.Agg)
+			}
+			out = append(out, s)
+		}
+		return out
+	}
+
+	for q, exp := range map[string][]string{
+		`GroupBy(Rows(region, via=customer))`:                                                        {"region=east,:2", "region=west,:2"},
+		`GroupBy(Rows(region, via=customers))`:                                                       {"region=east,:2", "region=west,:1"},
+		`GroupBy(Rows(kind), Rows(region, via=customer))`:                                            {"kind=buy,region=east,:1", "kind=buy,region=west,:1", "kind=sell,region=east,:1", "kind=sell,region=west,:1"},
+		`GroupBy(Rows(region, via=customer), Rows(region))`:                                          {"region=east,region=north,:1"},
+		`GroupBy(Rows(region, via=customer), filter=Row(kind="sell"))`:                               {"region=east,:1", "region=west,:1"},
+		`GroupBy(Rows(region, via=customer), aggregate=Sum(field=amount))`:                           {"region=east,:2:40", "region=west,:2:60"},
+		`GroupBy(Rows(region, via=customer), sort="count desc", limit=1)`:                            {"region=east,:2"},
+		`GroupBy(Rows(region, via=customer), having=Condition(sum>50), aggregate=Sum(field=amount))`: {"region=west,:2:60"},
+	} {
+		gcs := c.Query(t, child, q).Results[0].(*pilosa.GroupCounts)
+		if got := groupsString(gcs); !reflect.DeepEqual(got, exp) {
+			t.Fatalf("%s: expected %v, got %v", q, exp, got)
+		}
+	}
+
+	for _, q := range []string{
+		`GroupBy(Rows(region, via=kind))`,
+		`GroupBy(Rows(region, via=customer), Rows(kind, via=customer))`,
+		`GroupBy(Rows(region, via=customer, like="e%"))`,
+	} {
+		if _, err := c.GetPrimary().API.Query(context.Background(), &pilosa.QueryRequest{Index: child, Query: q}); err == nil {
+			t.Fatalf("%s: expected error", q)
+		}
+	}
+}
+
+func TestExecutor_Execute_OptionsKeyPrefix(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	keyed, unkeyed := c.Idx("k"), c.Idx("u")
+	c.CreateField(t, keyed, pilosa.IndexOptions{Keys: true}, "f")
+	c.CreateField(t, unkeyed, pilosa.IndexOptions{}, "f")
+
+	var sets strings.Builder
+	var keys []string
+	for i := 0; i < 60; i++ {
+		prefix := "other"
+		if i%20 == 0 {
+			prefix = "match"
+		}
+		key := fmt.Sprintf("%s-%d", prefix, i)
+		keys = append(keys, key)
+		fmt.Fprintf(&sets, "Set(%q, f=1)\n", key)
+	}
+	c.Query(t, keyed, sets.String())
+
+	ids, err := c.GetPrimary().API.FindIndexKeys(context.Background(), keyed, keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matchShards := make(map[uint64]bool)
+	for key, id := range ids {
+		if strings.HasPrefix(key, "match") {
+			matchShards[id/ShardWidth] = true
+		}
+	}
+	var exp []string
+	for _, key := range keys {
+		if matchShards[ids[key]/ShardWidth] {
+			exp = append(exp, key)
+		}
+	}
+	sort.Strings(exp)
+
+	// Every node finds the same shards, whichever partitions it holds.
+	for i := 0; i < 3; i++ {
+		resp, err := c.GetNode(i).API.Query(context.Background(), &pilosa.QueryRequest{Index: keyed, Query: `Options(Row(f=1), keyPrefix="match")`})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := resp.Results[0].(*pilosa.Row).Keys
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, exp) {
+			t.Fatalf("node %d: expected %v, got %v", i, exp, got)
+		}
+	}
+
+	if resp := c.Query(t, keyed, `Options(Count(Row(f=1)), keyPrefix="none")`); resp.Results[0].(uint64) != 0 {
+		t.Fatalf("expected no columns for an unmatched prefix, got %v", resp.Results[0])
+	}
+
+	for idx, q := range map[string]string{
+		unkeyed: `Options(Row(f=1), keyPrefix="match")`,
+		keyed:   `Options(Row(f=1), columnRange=[10, 1])`,
+	} {
+		_, err := c.GetPrimary().API.Query(context.Background(), &pilosa.QueryRequest{Index: idx, Query: q})
+		var bre pilosa.BadRequestError
+		if !errors.As(err, &bre) {
+			t.Fatalf("%s: expected bad request error, got %v", q, err)
+		}
+	}
+}
+
+func TestExecutor_Execute_OptionsHints(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "f", pilosa.OptFieldTypeSet(pilosa.CacheTypeNone, 0))
+	c.CreateField(t, c.Idx(), pilosa.IndexOptions{}, "g")
+
+	var sets strings.Builder
+	for shard := uint64(0); shard < 4; shard++ {
+		for i := uint64(0); i < 10; i++ {
+			fmt.Fprintf(&sets, "Set(%d, f=%d)\nSet(%d, g=%d)\n", shard*ShardWidth+i, i%(shard+2), shard*ShardWidth+i, shard%2)
+		}
+	}
+	c.Query(t, c.Idx(), sets.String())
+
+	// Rows are compared by their columns, which is all a hint could change.
+	query := func(q string) ([]interface{}, error) {
+		resp, err := c.GetNode(1).API.Query(context.Background(), &pilosa.QueryRequest{Index: c.Idx(), Query: q})
+		for i, r := range resp.Results {
+			if row, ok := r.(*pilosa.Row); ok {
+				resp.Results[i] = row.Columns()
+			}
+		}
+		return resp.Results, err
+	}
+
+	// Without a cache, TopN() only works with exact counts.
+	if _, err := query(`TopN(f, n=2)`); err == nil {
+		t.Fatal("expected error for TopN() on a field without a cache")
+	}
+	exp, err := query(`TopN(f, n=2, exact=true)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := query(`Options(TopN(f, n=2), hint="force_exact_topn")`); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("force_exact_topn: expected %v, got %v", exp, got)
+	}
+
+	for _, q := range []string{`Count(Row(g=1))`, `Row(g=0)`, `Count(Union(Row(f=0), Row(g=1)))`} {
+		exp, err := query(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, hint := range []string{`"no_shard_prune"`, `"serial_reduce"`, `["no_shard_prune", "serial_reduce"]`} {
+			got, err := query(fmt.Sprintf(`Options(%s, hint=%s)`, q, hint))
+			if err != nil {
+				t.Fatal(err)
+			} else if !reflect.DeepEqual(got, exp) {
+				t.Fatalf("%s with hint %s: expected %v, got %v", q, hint, exp, got)
+			}
+		}
+	}
+
+	for _, q := range []string{
+		`Options(Count(Row(g=1)), hint="no_such_hint")`,
+		`Options(Count(Row(g=1)), hint=["serial_reduce", 1])`,
+	} {
+		_, err := query(q)
+		var bre pilosa.BadRequestError
+		if !errors.As(err, &bre) {
+			t.Fatalf("%s: expected bad request error, got %v", q, err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"strings"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// Not(), All(), and "== null" need to know which columns exist. Indexes
+// created without TrackExistence don't record that, so these queries fail
+// against them unless an existence fallback is given, in which case the
+// columns which exist are taken to be those with a value in one or more
+// fields. Columns which only ever had values in other fields, or whose
+// values have all been cleared, are then treated as not existing.
+const (
+	// ExistenceFallbackNone fails queries which need existence tracking
+	// against indexes which don't track it.
+	ExistenceFallbackNone = ""
+
+	// ExistenceFallbackAllFields treats every column with a value in any of
+	// the index's fields as existing. Any other fallback is the name of the
+	// field whose columns are treated as existing.
+	ExistenceFallbackAllFields = "*"
+)
+
+type contextKeyExistenceFallbackType struct{}
+
+var contextKeyExistenceFallback = contextKeyExistenceFallbackType{}
+
+// withExistenceFallback returns a context carrying an existence fallback,
+// for the shards of a query to use.
+func withExistenceFallback(ctx context.Context, fallback string) context.Context {
+	return context.WithValue(ctx, contextKeyExistenceFallback, fallback)
+}
+
+// existenceFallbackFromContext returns the existence fallback of the query
+// running in ctx, if any.
+func existenceFallbackFromContext(ctx context.Context) string {
+	fallback, _ := ctx.Value(contextKeyExistenceFallback).(string)
+	return fallback
+}
+
+// usesExistence reports whether any of calls needs existence tracking.
+func usesExistence(calls []*pql.Call) bool {
+	for _, c := range calls {
+		switch c.Name {
+		case "Not", "All":
+			return true
+		case "CardinalityOf":
+			if cardinalityMatchesZero(c) {
+				return true
+			}
+		case "Row":
+			for _, arg := range c.Args {
+				if cond, ok := arg.(*pql.Condition); ok && cond.Op == pql.EQ && cond.Value == nil {
+					return true
+				}
+			}
+		}
+		if usesExistence(c.Children) {
+			return true
+		}
+	}
+	return false
+}
+
+// existenceRowShard returns the columns which exist in a shard of idx. If
+// idx doesn't track existence, the existence fallback of the query running
+// in ctx is used.
+func (e *executor) existenceRowShard(ctx context.Context, tx Tx, idx *Index, shard uint64) (*Row, error) {
+	if idx.existenceField() != nil {
+		frag := e.Holder.fragment(idx.Name(), existenceFieldName, viewStandard, shard)
+		if frag == nil {
+			return NewRow(), nil
+		}
+		return frag.row(tx, 0)
+	}
+
+	var fields []*Field
+	switch fallback := existenceFallbackFromContext(ctx); fallback {
+	case ExistenceFallbackNone:
+		return nil, errors.Errorf("index does not support existence tracking: %s", idx.Name())
+	case ExistenceFallbackAllFields:
+		fields = idx.Fields()
+	default:
+		f := idx.Field(fallback)
+		if f == nil {
+			return nil, newNotFoundError(ErrFieldNotFound, fallback)
+		}
+		fields = []*Field{f}
+	}
+
+	return fieldsColumnsShard(ctx, tx, fields, shard)
+}
+
+// fieldsColumnsShard returns the columns which have values in any of fields
+// in a shard.
+func fieldsColumnsShard(ctx context.Context, tx Tx, fields []*Field, shard uint64) (*Row, error) {
+	row := NewRow()
+	for _, f := range fields {
+		for _, v := range f.views() {
+			if isDistinctView(v.name) {
+				continue
+			}
+			frag := v.Fragment(shard)
+			if frag == nil {
+				continue
+			}
+			var r *Row
+			var err error
+			if strings.HasPrefix(v.name, viewBSIGroupPrefix) {
+				r, err = frag.notNull(tx)
+			} else {
+				var rows []uint64
+				if rows, err = frag.rows(ctx, tx, 0); err != nil {
+					return nil, errors.Wrapf(err, "getting rows of field %s", f.Name())
+				}
+				r, err = frag.unionRows(ctx, tx, rows)
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, "getting columns of field %s", f.Name())
+			}
+			row = row.Union(r)
+		}
+	}
+	return row, nil
+}
+
+// ImportExistenceRequest lists columns, by ID or, in keyed indexes, by key,
+// to make exist.
+type ImportExistenceRequest struct {
+	ColumnIDs  []uint64 `json:"columnIDs,omitempty"`
+	ColumnKeys []string `json:"columnKeys,omitempty"`
+}
+
+// ImportExistence makes the columns of req exist in an index without
+// setting a value in any field, so that Not() and All() include records
+// known to exist which haven't been enriched yet. The index must track
+// existence.
+func (api *API) ImportExistence(ctx context.Context, indexName string, req *ImportExistenceRequest) error {
+	idx := api.holder.Index(indexName)
+	if idx == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	} else if idx.existenceField() == nil {
+		return NewBadRequestError(errors.Errorf("index %s doesn't track existence", indexName))
+	}
+	if idx.Keys() && len(req.ColumnIDs) != 0 {
+		return NewBadRequestError(errors.New("column ids cannot be used because index uses string keys"))
+	} else if !idx.Keys() && len(req.ColumnKeys) != 0 {
+		return NewBadRequestError(errors.New("column keys cannot be used because index uses integer IDs"))
+	}
+	n := len(req.ColumnIDs) + len(req.ColumnKeys)
+	if n == 0 {
+		return nil
+	}
+
+	// Existence is stored as row 0 of the existence field, which is
+	// imported into like any other field.
+	qcx := api.Txf().NewQcx()
+	defer qcx.Abort()
+	if err := api.Import(ctx, qcx, &ImportRequest{
+		Index:      indexName,
+		Field:      existenceFieldName,
+		Shard:      ^uint64(0),
+		RowIDs:     make([]uint64, n),
+		ColumnIDs:  req.ColumnIDs,
+		ColumnKeys: req.ColumnKeys,
+	}); err != nil {
+		return errors.Wrapf(err, "importing existence into index %s", indexName)
+	}
+	return qcx.Finish()
+}
@@ -0,0 +1,423 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/pql"
+	pb "github.com/featurebasedb/featurebase/v3/proto"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// ExportQuery writes the results of a query straight to object storage, or
+// a directory, rather than returning them. An Extract without a limit or
+// offset is split by node: each node runs it against the shards it holds,
+// and writes its results as a part of its own. Any other query is run, and
+// written as a single part, by the node which received the request. Once
+// all parts are written, a manifest listing them is written alongside.
+//
+// Destinations are s3://bucket/prefix, gs://bucket/prefix, or a local
+// directory, as a path or a file:// URL, which then needs to be shared by
+// all nodes. S3 credentials and region are found as by the AWS SDK, from
+// the environment or shared configuration. Google Cloud Storage is written
+// through its S3-compatible API, with HMAC keys passed as AWS credentials.
+
+// Formats results can be exported in.
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// exportManifestName is the name of the manifest of an export.
+const exportManifestName = "manifest.json"
+
+// ExportQueryRequest is a request to export the results of a query.
+type ExportQueryRequest struct {
+	Index       string `json:"index"`
+	Query       string `json:"query"`
+	Destination string `json:"destination"`
+	Format      string `json:"format"`
+}
+
+// ExportManifest describes the parts an export was written as.
+type ExportManifest struct {
+	Index       string       `json:"index"`
+	Query       string       `json:"query"`
+	Destination string       `json:"destination"`
+	Format      string       `json:"format"`
+	Rows        uint64       `json:"rows"`
+	Parts       []ExportPart `json:"parts"`
+}
+
+// ExportPart is a part of an export, written by a single node.
+type ExportPart struct {
+	Name   string   `json:"name"`
+	Node   string   `json:"node"`
+	Shards []uint64 `json:"shards,omitempty"`
+	Rows   uint64   `json:"rows"`
+}
+
+// ExportPartRequest is a request to a node to export a part of a query's
+// results, from shards.
+type ExportPartRequest struct {
+	ExportQueryRequest
+	Name   string   `json:"name"`
+	Shards []uint64 `json:"shards"`
+}
+
+// ExportQuery exports the results of a query, returning the manifest
+// written with them.
+func (api *API) ExportQuery(ctx context.Context, req *ExportQueryRequest) (*ExportManifest, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ExportQuery")
+	defer span.Finish()
+
+	if err := api.validate(apiQuery); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	store, err := newExportStore(req.Destination)
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+	ext, err := exportExt(req.Format)
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+	q, err := pql.NewParser(strings.NewReader(req.Query)).Parse()
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "parsing"))
+	} else if len(q.Calls) != 1 {
+		return nil, NewBadRequestError(errors.New("export requires a single query"))
+	}
+	api.rewriteLegacyCalls(req.Index, q)
+	idx, err := api.Index(ctx, req.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &ExportManifest{
+		Index:       req.Index,
+		Query:       req.Query,
+		Destination: req.Destination,
+		Format:      req.Format,
+	}
+	if c := q.Calls[0]; c.Name != "Extract" || c.Args["limit"] != nil || c.Args["offset"] != nil {
+		part, err := api.exportPart(ctx, store, &ExportPartRequest{ExportQueryRequest: *req, Name: "part-00000" + ext})
+		if err != nil {
+			return nil, err
+		}
+		manifest.Parts = []ExportPart{part}
+	} else {
+		if manifest.Parts, err = api.exportParts(ctx, idx, req, ext); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, part := range manifest.Parts {
+		manifest.Rows += part.Rows
+	}
+	buf, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding manifest")
+	}
+	if err := store.put(ctx, exportManifestName, bytes.NewReader(buf)); err != nil {
+		return nil, errors.Wrap(err, "writing manifest")
+	}
+	return manifest, nil
+}
+
+// exportParts has each node export the results of a query from the shards
+// it's assigned.
+func (api *API) exportParts(ctx context.Context, idx *Index, req *ExportQueryRequest, ext string) ([]ExportPart, error) {
+	shards := idx.AvailableShards(includeRemote).Slice()
+	byNode, err := api.server.executor.shardsByNode(api.cluster.Nodes(), req.Index, shards)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*disco.Node, 0, len(byNode))
+	for node := range byNode {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+
+	parts := make([]ExportPart, len(nodes))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, node := range nodes {
+		i, node := i, node
+		preq := &ExportPartRequest{
+			ExportQueryRequest: *req,
+			Name:               fmt.Sprintf("part-%05d%s", i, ext),
+			Shards:             byNode[node],
+		}
+		g.Go(func() (err error) {
+			if node.ID == api.NodeID() {
+				parts[i], err = api.ExportQueryPart(gctx, preq)
+			} else {
+				parts[i], err = api.server.defaultClient.ExportQueryPart(gctx, &node.URI, preq)
+			}
+			return errors.Wrapf(err, "exporting on node %s", node.ID)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// ExportQueryPart exports a part of the results of a query, run against
+// the shards in the request.
+func (api *API) ExportQueryPart(ctx context.Context, req *ExportPartRequest) (ExportPart, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ExportQueryPart")
+	defer span.Finish()
+
+	if err := api.validate(apiQuery); err != nil {
+		return ExportPart{}, errors.Wrap(err, "validating api method")
+	}
+	store, err := newExportStore(req.Destination)
+	if err != nil {
+		return ExportPart{}, NewBadRequestError(err)
+	}
+	return api.exportPart(ctx, store, req)
+}
+
+// exportPart runs the query in req, and writes its results to store.
+func (api *API) exportPart(ctx context.Context, store exportStore, req *ExportPartRequest) (ExportPart, error) {
+	part := ExportPart{Name: req.Name, Node: api.NodeID(), Shards: req.Shards}
+	resp, err := api.Query(ctx, &QueryRequest{Index: req.Index, Query: req.Query, Shards: req.Shards})
+	if err != nil {
+		return ExportPart{}, err
+	}
+	if len(resp.Results) != 1 {
+		return ExportPart{}, errors.Errorf("expected one result, got %d", len(resp.Results))
+	}
+	rows, ok := resp.Results[0].(pb.ToRowser)
+	if !ok {
+		return ExportPart{}, NewBadRequestError(errors.Errorf("results of type %T can't be exported", resp.Results[0]))
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		n, err := writeExportRows(pw, req.Format, rows)
+		part.Rows = n
+		pw.CloseWithError(err)
+	}()
+	err = store.put(ctx, req.Name, pr)
+	// Stop the writer, if put stopped reading early.
+	pr.CloseWithError(errors.New("export stopped"))
+	if err != nil {
+		return ExportPart{}, errors.Wrapf(err, "writing %s", req.Name)
+	}
+	return part, nil
+}
+
+// exportExt returns the file extension of parts in format.
+func exportExt(format string) (string, error) {
+	switch format {
+	case ExportFormatCSV:
+		return ".csv", nil
+	case ExportFormatNDJSON:
+		return ".ndjson", nil
+	}
+	return "", errors.Errorf("unsupported export format %q, expected %q or %q", format, ExportFormatCSV, ExportFormatNDJSON)
+}
+
+// writeExportRows writes rows to w in format, returning how many were
+// written.
+func writeExportRows(w io.Writer, format string, rows pb.ToRowser) (n uint64, err error) {
+	var headers []*pb.ColumnInfo
+	switch format {
+	case ExportFormatCSV:
+		cw := csv.NewWriter(w)
+		record := []string{}
+		err = rows.ToRows(func(row *pb.RowResponse) error {
+			if headers == nil {
+				headers = row.Headers
+				record = record[:0]
+				for _, h := range headers {
+					record = append(record, h.Name)
+				}
+				if err := cw.Write(record); err != nil {
+					return err
+				}
+			}
+			record = record[:0]
+			for _, col := range row.Columns {
+				record = append(record, exportCSVValue(col))
+			}
+			n++
+			return cw.Write(record)
+		})
+		if err == nil {
+			cw.Flush()
+			err = cw.Error()
+		}
+	case ExportFormatNDJSON:
+		enc := json.NewEncoder(w)
+		err = rows.ToRows(func(row *pb.RowResponse) error {
+			if headers == nil {
+				headers = row.Headers
+			}
+			obj := make(map[string]interface{}, len(row.Columns))
+			for i, col := range row.Columns {
+				if i < len(headers) {
+					obj[headers[i].Name] = exportValue(col)
+				}
+			}
+			n++
+			return enc.Encode(obj)
+		})
+	default:
+		_, err = exportExt(format)
+	}
+	return n, err
+}
+
+// exportValue returns the value of a column of a row of results, as it's
+// encoded in JSON.
+func exportValue(col *pb.ColumnResponse) interface{} {
+	switch v := col.GetColumnVal().(type) {
+	case *pb.ColumnResponse_StringVal:
+		return v.StringVal
+	case *pb.ColumnResponse_Uint64Val:
+		return v.Uint64Val
+	case *pb.ColumnResponse_Int64Val:
+		return v.Int64Val
+	case *pb.ColumnResponse_BoolVal:
+		return v.BoolVal
+	case *pb.ColumnResponse_BlobVal:
+		return v.BlobVal
+	case *pb.ColumnResponse_Uint64ArrayVal:
+		return v.Uint64ArrayVal.GetVals()
+	case *pb.ColumnResponse_StringArrayVal:
+		return v.StringArrayVal.GetVals()
+	case *pb.ColumnResponse_Float64Val:
+		return v.Float64Val
+	case *pb.ColumnResponse_DecimalVal:
+		return pql.NewDecimal(v.DecimalVal.GetValue(), v.DecimalVal.GetScale())
+	case *pb.ColumnResponse_TimestampVal:
+		return v.TimestampVal
+	}
+	return nil
+}
+
+// exportCSVValue returns the value of a column of a row of results, as
+// it's written in CSV. Lists are written as JSON arrays, and nulls as
+// empty strings.
+func exportCSVValue(col *pb.ColumnResponse) string {
+	switch v := exportValue(col).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case pql.Decimal:
+		return v.String()
+	case []uint64, []string:
+		buf, _ := json.Marshal(v)
+		return string(buf)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// exportStore is where the parts of an export are written.
+type exportStore interface {
+	// put writes the contents of r to the object name.
+	put(ctx context.Context, name string, r io.Reader) error
+}
+
+// newExportStore returns the store for a destination.
+func newExportStore(dest string) (exportStore, error) {
+	if dest == "" {
+		return nil, errors.New("export destination required")
+	}
+	u, err := url.Parse(dest)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing export destination")
+	}
+	switch u.Scheme {
+	case "s3", "gs":
+		if u.Host == "" {
+			return nil, errors.Errorf("export destination %q has no bucket", dest)
+		}
+		cfg := aws.NewConfig()
+		if u.Scheme == "gs" {
+			cfg = cfg.WithEndpoint("https://storage.googleapis.com").WithS3ForcePathStyle(true)
+			if os.Getenv("AWS_REGION") == "" {
+				cfg = cfg.WithRegion("auto")
+			}
+		}
+		sess, err := session.NewSessionWithOptions(session.Options{
+			Config:            *cfg,
+			SharedConfigState: session.SharedConfigEnable,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "creating session")
+		}
+		return &s3ExportStore{
+			uploader: s3manager.NewUploader(sess),
+			bucket:   u.Host,
+			prefix:   strings.Trim(u.Path, "/"),
+		}, nil
+	case "file":
+		return dirExportStore(u.Path), nil
+	case "":
+		return dirExportStore(dest), nil
+	}
+	return nil, errors.Errorf("unsupported export destination %q", dest)
+}
+
+// s3ExportStore writes to a prefix of an S3 bucket.
+type s3ExportStore struct {
+	uploader *s3manager.Uploader
+	bucket   string
+	prefix   string
+}
+
+func (s *s3ExportStore) put(ctx context.Context, name string, r io.Reader) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path.Join(s.prefix, name)),
+		Body:   r,
+	})
+	return err
+}
+
+// dirExportStore writes to a local directory.
+type dirExportStore string
+
+func (s dirExportStore) put(ctx context.Context, name string, r io.Reader) error {
+	if err := os.MkdirAll(string(s), 0750); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(string(s), name))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
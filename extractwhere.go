@@ -0,0 +1,515 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// An Extract's where argument filters the columns it returns by the values
+// extracted for them, on each shard, before they're sent anywhere:
+//
+//	Extract(All(), Rows(bsint), Rows(keymutex), where="bsint > 2 && keymutex == 'h'")
+//
+// An expression compares extracted fields to literals, combining
+// comparisons with &&, || and !, and parentheses. Literals are integers,
+// decimals, strings in single or double quotes, true, false and null.
+//
+// Int, decimal and timestamp fields can be compared with all of ==, !=, <,
+// <=, > and >=; timestamps are compared with RFC3339 strings, or integers
+// in the field's time unit. A column without a value doesn't satisfy any
+// comparison but "!= null". Set, mutex and time fields can only be
+// compared with == and !=, which test whether a column has a row, given by
+// its key for keyed fields or its ID otherwise, or, compared with null,
+// whether it has any row. Bool fields are compared with true, false and
+// null.
+//
+// The coordinating node translates the keys in the expression to IDs
+// before passing it on to other nodes.
+
+// extractWhere is a parsed where argument of Extract.
+type extractWhere struct {
+	expr whereExpr
+}
+
+// whereExpr is a node of a where expression.
+type whereExpr interface {
+	// match reports whether the column satisfies the expression.
+	match(col ExtractedIDColumn) bool
+	String() string
+}
+
+type whereAnd struct{ left, right whereExpr }
+
+func (w *whereAnd) match(col ExtractedIDColumn) bool { return w.left.match(col) && w.right.match(col) }
+func (w *whereAnd) String() string                   { return "(" + w.left.String() + " && " + w.right.String() + ")" }
+
+type whereOr struct{ left, right whereExpr }
+
+func (w *whereOr) match(col ExtractedIDColumn) bool { return w.left.match(col) || w.right.match(col) }
+func (w *whereOr) String() string                   { return "(" + w.left.String() + " || " + w.right.String() + ")" }
+
+type whereNot struct{ expr whereExpr }
+
+func (w *whereNot) match(col ExtractedIDColumn) bool { return !w.expr.match(col) }
+func (w *whereNot) String() string                   { return "!" + w.expr.String() }
+
+// Kinds of where literals.
+const (
+	whereLitNull = iota
+	whereLitBool
+	whereLitNumber
+	whereLitString
+)
+
+// whereLiteral is a literal as written.
+type whereLiteral struct {
+	kind int
+	text string
+}
+
+func (l whereLiteral) String() string {
+	switch l.kind {
+	case whereLitNull:
+		return "null"
+	case whereLitString:
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(l.text) + "'"
+	}
+	return l.text
+}
+
+// Kinds of values compared by where comparisons, by field type.
+const (
+	whereCmpInt = iota
+	whereCmpRows
+	whereCmpBool
+)
+
+// whereCmp compares the value of a field with a literal.
+type whereCmp struct {
+	field string
+	op    pql.Token
+	lit   whereLiteral
+
+	// Set when bound to the extracted fields.
+	i     int
+	kind  int
+	value int64
+}
+
+func (w *whereCmp) String() string {
+	return w.field + " " + w.op.String() + " " + w.lit.String()
+}
+
+func (w *whereCmp) match(col ExtractedIDColumn) bool {
+	rows := col.Rows[w.i]
+	if w.lit.kind == whereLitNull {
+		return (len(rows) == 0) == (w.op == pql.EQ)
+	}
+	switch w.kind {
+	case whereCmpRows:
+		var found bool
+		for _, row := range rows {
+			if int64(row) == w.value {
+				found = true
+				break
+			}
+		}
+		return found == (w.op == pql.EQ)
+	case whereCmpBool:
+		if len(rows) == 0 {
+			return false
+		}
+		return (int64(rows[0]) == w.value) == (w.op == pql.EQ)
+	}
+	if len(rows) == 0 {
+		return false
+	}
+	v := int64(rows[0])
+	switch w.op {
+	case pql.EQ:
+		return v == w.value
+	case pql.NEQ:
+		return v != w.value
+	case pql.LT:
+		return v < w.value
+	case pql.LTE:
+		return v <= w.value
+	case pql.GT:
+		return v > w.value
+	case pql.GTE:
+		return v >= w.value
+	}
+	return false
+}
+
+// String returns the expression in a form which parses to the same
+// expression.
+func (w *extractWhere) String() string { return w.expr.String() }
+
+// match reports whether the column satisfies the expression.
+func (w *extractWhere) match(col ExtractedIDColumn) bool { return w.expr.match(col) }
+
+// filter removes the columns which don't satisfy the expression from m, and
+// the corresponding entries of kvs, if it isn't nil.
+func (w *extractWhere) filter(m []ExtractedIDColumn, kvs []RowKV) ([]ExtractedIDColumn, []RowKV) {
+	n := 0
+	for i, col := range m {
+		if !w.match(col) {
+			continue
+		}
+		m[n] = col
+		if kvs != nil {
+			kvs[n] = kvs[i]
+		}
+		n++
+	}
+	if kvs != nil {
+		kvs = kvs[:n]
+	}
+	return m[:n], kvs
+}
+
+// extractWhere parses the where argument of an Extract of fields, binding
+// it to the fields. On the coordinating node, keys in the expression are
+// translated, and the argument is replaced with the translated expression
+// for the other nodes.
+func (e *executor) extractWhere(ctx context.Context, index string, c *pql.Call, fields []string, opt *ExecOptions) (*extractWhere, error) {
+	s, ok, err := c.StringArg("where")
+	if err != nil {
+		return nil, errors.Wrap(err, "Extract(): where")
+	} else if !ok {
+		return nil, nil
+	}
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, index)
+	}
+	where, err := parseExtractWhere(s)
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "parsing where"))
+	}
+
+	var bind func(expr whereExpr) error
+	bind = func(expr whereExpr) error {
+		switch expr := expr.(type) {
+		case *whereAnd:
+			if err := bind(expr.left); err != nil {
+				return err
+			}
+			return bind(expr.right)
+		case *whereOr:
+			if err := bind(expr.left); err != nil {
+				return err
+			}
+			return bind(expr.right)
+		case *whereNot:
+			return bind(expr.expr)
+		case *whereCmp:
+			return e.bindWhereCmp(ctx, idx, expr, fields, opt)
+		}
+		return nil
+	}
+	if err := bind(where.expr); err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "where"))
+	}
+	c.Args["where"] = where.String()
+	return where, nil
+}
+
+// bindWhereCmp binds a comparison to the extracted field it compares,
+// converting its literal to the field's representation.
+func (e *executor) bindWhereCmp(ctx context.Context, idx *Index, w *whereCmp, fields []string, opt *ExecOptions) error {
+	w.i = -1
+	for i, name := range fields {
+		if name == w.field {
+			w.i = i
+		}
+	}
+	if w.i < 0 {
+		return errors.Errorf("field %q isn't extracted", w.field)
+	}
+	f := idx.Field(w.field)
+	if f == nil {
+		return newNotFoundError(ErrFieldNotFound, w.field)
+	}
+	isEquality := w.op == pql.EQ || w.op == pql.NEQ
+
+	switch f.Type() {
+	case FieldTypeInt, FieldTypeDecimal, FieldTypeTimestamp:
+		w.kind = whereCmpInt
+	case FieldTypeSet, FieldTypeMutex, FieldTypeTime:
+		w.kind = whereCmpRows
+	case FieldTypeBool:
+		w.kind = whereCmpBool
+	default:
+		return errors.Errorf("field %q of type %s can't be compared", w.field, f.Type())
+	}
+	if w.kind != whereCmpInt && !isEquality {
+		return errors.Errorf("%s field %q can only be compared with == and !=", f.Type(), w.field)
+	}
+
+	switch w.lit.kind {
+	case whereLitNull:
+		if !isEquality {
+			return errors.Errorf("null can only be compared with == and !=")
+		}
+		return nil
+	case whereLitBool:
+		if w.kind != whereCmpBool {
+			return errors.Errorf("%s field %q can't be compared with %s", f.Type(), w.field, w.lit)
+		}
+		w.value = 0
+		if w.lit.text == "true" {
+			w.value = 1
+		}
+		return nil
+	}
+
+	switch w.kind {
+	case whereCmpBool:
+		return errors.Errorf("bool field %q can't be compared with %s", w.field, w.lit)
+	case whereCmpRows:
+		if w.lit.kind == whereLitNumber {
+			v, err := strconv.ParseInt(w.lit.text, 10, 64)
+			if err != nil {
+				return errors.Errorf("%s isn't a row ID", w.lit)
+			}
+			w.value = v
+			return nil
+		}
+		if !f.Keys() && f.ForeignIndex() == "" {
+			return errors.Errorf("field %q isn't keyed, so can't be compared with %s", w.field, w.lit)
+		} else if opt.Remote {
+			return errors.Errorf("key %s wasn't translated", w.lit)
+		}
+		ids, err := e.Cluster.findFieldKeys(ctx, f, w.lit.text)
+		if err != nil {
+			return errors.Wrap(err, "translating key")
+		}
+		// A key which doesn't exist can't match any row.
+		w.value = -1
+		if id, ok := ids[w.lit.text]; ok {
+			w.value = int64(id)
+		}
+		w.lit = whereLiteral{kind: whereLitNumber, text: strconv.FormatInt(w.value, 10)}
+		return nil
+	}
+
+	var v interface{}
+	switch {
+	case w.lit.kind == whereLitString && f.Type() == FieldTypeTimestamp:
+		t, err := time.Parse(time.RFC3339Nano, w.lit.text)
+		if err != nil {
+			return errors.Wrapf(err, "parsing timestamp %s", w.lit)
+		}
+		v = t
+	case w.lit.kind == whereLitString:
+		return errors.Errorf("%s field %q can't be compared with %s", f.Type(), w.field, w.lit)
+	case f.Type() == FieldTypeDecimal:
+		dec, err := pql.ParseDecimal(w.lit.text)
+		if err != nil {
+			return errors.Wrapf(err, "parsing decimal %s", w.lit)
+		}
+		v = dec
+	default:
+		i, err := strconv.ParseInt(w.lit.text, 10, 64)
+		if err != nil {
+			return errors.Errorf("%s field %q can't be compared with %s", f.Type(), w.field, w.lit)
+		}
+		v = i
+	}
+	value, err := getScaledInt(f, v)
+	if err != nil {
+		return err
+	}
+	w.value = value
+	return nil
+}
+
+// whereParser parses where expressions, by recursive descent.
+type whereParser struct {
+	s   string
+	pos int
+}
+
+// parseExtractWhere parses a where expression, without binding it to
+// fields.
+func parseExtractWhere(s string) (*extractWhere, error) {
+	p := &whereParser{s: s}
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	p.space()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return &extractWhere{expr: expr}, nil
+}
+
+func (p *whereParser) errorf(format string, args ...interface{}) error {
+	return errors.Errorf("at %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *whereParser) space() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// accept consumes tok if it's next.
+func (p *whereParser) accept(tok string) bool {
+	p.space()
+	if strings.HasPrefix(p.s[p.pos:], tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *whereParser) or() (whereExpr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &whereOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *whereParser) and() (whereExpr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &whereAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *whereParser) unary() (whereExpr, error) {
+	if p.accept("!") {
+		if p.accept("=") {
+			return nil, p.errorf("expected expression")
+		}
+		expr, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &whereNot{expr: expr}, nil
+	}
+	if p.accept("(") {
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, p.errorf("expected )")
+		}
+		return expr, nil
+	}
+	return p.comparison()
+}
+
+func (p *whereParser) comparison() (whereExpr, error) {
+	p.space()
+	start := p.pos
+	for p.pos < len(p.s) && isWhereIdentByte(p.s[p.pos], p.pos > start) {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, p.errorf("expected field name")
+	}
+	w := &whereCmp{field: p.s[start:p.pos]}
+
+	// Longer operators first.
+	for _, op := range []struct {
+		text string
+		tok  pql.Token
+	}{{"==", pql.EQ}, {"!=", pql.NEQ}, {"<=", pql.LTE}, {">=", pql.GTE}, {"<", pql.LT}, {">", pql.GT}} {
+		if p.accept(op.text) {
+			w.op = op.tok
+			break
+		}
+	}
+	if w.op == pql.ILLEGAL {
+		return nil, p.errorf("expected comparison operator")
+	}
+
+	lit, err := p.literal()
+	if err != nil {
+		return nil, err
+	}
+	w.lit = lit
+	return w, nil
+}
+
+func (p *whereParser) literal() (whereLiteral, error) {
+	p.space()
+	if p.pos == len(p.s) {
+		return whereLiteral{}, p.errorf("expected value")
+	}
+	if q := p.s[p.pos]; q == '\'' || q == '"' {
+		var b strings.Builder
+		for p.pos++; p.pos < len(p.s); p.pos++ {
+			switch ch := p.s[p.pos]; {
+			case ch == q:
+				p.pos++
+				return whereLiteral{kind: whereLitString, text: b.String()}, nil
+			case ch == '\\' && p.pos+1 < len(p.s):
+				p.pos++
+				b.WriteByte(p.s[p.pos])
+			default:
+				b.WriteByte(ch)
+			}
+		}
+		return whereLiteral{}, p.errorf("unterminated string")
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && isWhereIdentByte(p.s[p.pos], true) || p.pos < len(p.s) && p.s[p.pos] == '.' {
+		p.pos++
+	}
+	text := p.s[start:p.pos]
+	switch text {
+	case "null":
+		return whereLiteral{kind: whereLitNull}, nil
+	case "true", "false":
+		return whereLiteral{kind: whereLitBool, text: text}, nil
+	}
+	if _, err := strconv.ParseFloat(text, 64); err != nil || text == "" {
+		p.pos = start
+		return whereLiteral{}, p.errorf("expected value")
+	}
+	return whereLiteral{kind: whereLitNumber, text: text}, nil
+}
+
+// isWhereIdentByte reports whether ch can be part of a field name, or a
+// number; '-' can't start a field name, but starts negative numbers.
+func isWhereIdentByte(ch byte, inside bool) bool {
+	switch {
+	case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch == '_':
+		return true
+	case ch >= '0' && ch <= '9', ch == '-':
+		return inside
+	}
+	return false
+}
@@ -12,8 +12,10 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/featurebasedb/featurebase/v3/pql"
@@ -116,6 +118,21 @@ type Field struct {
 
 	// track whether we're shutting down
 	closing chan struct{}
+
+	// The goroutine maintaining the field's ranked caches is signalled
+	// through cacheStalenessChanged when the staleness budget changes, and
+	// stopped by closing cacheMaintainerStop.
+	cacheStalenessChanged chan struct{}
+	cacheMaintainerStop   chan struct{}
+	cacheMaintainerWG     sync.WaitGroup
+
+	// staleness is a copy of options.CacheStaleness, for fragments to read
+	// atomically as they're opened, when the field may be locked.
+	staleness int64
+
+	// cacheHits and cacheMisses count the rows looked up by ID in the
+	// field's caches which were, and weren't, found there.
+	cacheHits, cacheMisses uint64
 }
 
 // FieldOption is a functional option type for pilosa.fieldOptions.
@@ -141,6 +158,63 @@ func OptFieldForeignIndex(index string) FieldOption {
 	}
 }
 
+// OptFieldDescription is a functional option on FieldOptions
+// used to set a human-readable description of the field.
+func OptFieldDescription(description string) FieldOption {
+	return func(fo *FieldOptions) error {
+		fo.Description = description
+		return nil
+	}
+}
+
+// OptFieldOwner is a functional option on FieldOptions
+// used to record who is responsible for the field.
+func OptFieldOwner(owner string) FieldOption {
+	return func(fo *FieldOptions) error {
+		fo.Owner = owner
+		return nil
+	}
+}
+
+// OptFieldTags is a functional option on FieldOptions
+// used to attach arbitrary key/value tags to the field.
+func OptFieldTags(tags map[string]string) FieldOption {
+	return func(fo *FieldOptions) error {
+		fo.Tags = tags
+		return nil
+	}
+}
+
+// OptFieldMemoryPolicy is a functional option on FieldOptions used to
+// control how the field's data is kept in memory. The policy applies to the
+// given views, or to all of the field's views if none are given.
+func OptFieldMemoryPolicy(policy string, views ...string) FieldOption {
+	return func(fo *FieldOptions) error {
+		fo.FieldMemoryPolicy = FieldMemoryPolicy{
+			MemoryPolicy:      policy,
+			MemoryPolicyViews: views,
+		}
+		return fo.FieldMemoryPolicy.validate()
+	}
+}
+
+// OptFieldCacheStaleness is a functional option on FieldOptions used to
+// maintain the field's ranked caches in the background, letting TopN read
+// rankings which are up to staleness behind rather than recalculating them.
+func OptFieldCacheStaleness(staleness string) FieldOption {
+	return func(fo *FieldOptions) error {
+		d, err := time.ParseDuration(staleness)
+		if err != nil {
+			return errors.Errorf("cannot parse cache staleness: %s", staleness)
+		}
+		if d < 0 {
+			return errors.Errorf("cache staleness can't be negative: %s", staleness)
+		}
+		fo.CacheStaleness = d
+		return nil
+	}
+}
+
 // OptFieldTypeDefault is a functional option on FieldOptions
 // used to set the field type and cache setting to the default values.
 func OptFieldTypeDefault() FieldOption {
@@ -405,6 +479,8 @@ func newField(holder *Holder, path, index, name string, opts FieldOption) (*Fiel
 		holder: holder,
 
 		OpenTranslateStore: OpenInMemTranslateStore,
+
+		cacheStalenessChanged: make(chan struct{}, 1),
 	}
 
 	return f, nil
@@ -609,6 +685,10 @@ func (f *Field) Open() error {
 	}
 	f.closing = make(chan struct{})
 
+	f.cacheMaintainerStop = make(chan struct{})
+	f.cacheMaintainerWG.Add(1)
+	go f.maintainCaches(f.cacheMaintainerStop)
+
 	_ = testhook.Opened(f.holder.Auditor, f, nil)
 	f.holder.Logger.Debugf("successfully opened field index/field: %s/%s", f.index, f.name)
 	return nil
@@ -714,6 +794,12 @@ func (f *Field) ForeignIndex() string {
 	return f.options.ForeignIndex
 }
 
+// RejectNewKeys returns whether writes by query which would create new row
+// keys in the field fail.
+func (f *Field) RejectNewKeys() bool {
+	return f.options.RejectNewKeys
+}
+
 // TTL returns the ttl of the field.
 func (f *Field) TTL() time.Duration {
 	return f.options.TTL
@@ -788,6 +874,8 @@ func (f *Field) setOptions(opts *FieldOptions) {
 
 // applyOptions configures the field based on opt.
 func (f *Field) applyOptions(opt FieldOptions) error {
+	f.options.SchemaMetadata = opt.SchemaMetadata
+	f.options.FieldMemoryPolicy = opt.FieldMemoryPolicy
 	switch opt.Type {
 	case FieldTypeSet, FieldTypeMutex, "":
 		fldType := opt.Type
@@ -809,8 +897,15 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.BitDepth = 0
 		f.options.TimeQuantum = ""
 		f.options.TTL = 0
+		f.options.CacheStaleness = opt.CacheStaleness
 		f.options.Keys = opt.Keys
 		f.options.ForeignIndex = opt.ForeignIndex
+		f.options.Derive = opt.Derive
+		f.options.History = opt.History
+		f.options.Cascade = opt.Cascade
+		f.options.TimeZone = opt.TimeZone
+		f.options.FiscalYearStart = opt.FiscalYearStart
+		f.options.RejectNewKeys = opt.RejectNewKeys
 	case FieldTypeInt, FieldTypeDecimal, FieldTypeTimestamp:
 		f.options.Type = opt.Type
 		f.options.CacheType = CacheTypeNone
@@ -825,6 +920,9 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.TTL = 0
 		f.options.Keys = opt.Keys
 		f.options.ForeignIndex = opt.ForeignIndex
+		f.options.Derive = opt.Derive
+		f.options.Cascade = opt.Cascade
+		f.options.TrackDistinct = opt.TrackDistinct
 
 		// Create new bsiGroup.
 		bsig := &bsiGroup{
@@ -858,6 +956,9 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.TimeQuantum = opt.TimeQuantum
 		f.options.TTL = opt.TTL
 		f.options.ForeignIndex = opt.ForeignIndex
+		f.options.TimeZone = opt.TimeZone
+		f.options.FiscalYearStart = opt.FiscalYearStart
+		f.options.RejectNewKeys = opt.RejectNewKeys
 	case FieldTypeBool:
 		f.options.Type = FieldTypeBool
 		f.options.CacheType = CacheTypeNone
@@ -874,11 +975,25 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		return errors.New("invalid field type")
 	}
 
+	// Only set and mutex fields have ranked caches to maintain.
+	if f.options.Type != FieldTypeSet && f.options.Type != FieldTypeMutex {
+		f.options.CacheStaleness = 0
+	}
+	atomic.StoreInt64(&f.staleness, int64(f.options.CacheStaleness))
+	select {
+	case f.cacheStalenessChanged <- struct{}{}:
+	default:
+	}
+
 	return nil
 }
 
 // Close closes the field and its views.
 func (f *Field) Close() error {
+	// The cache maintainer needs the lock to find the field's fragments, so
+	// it has to be stopped first.
+	f.stopMaintainingCaches()
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	return f.unprotectedClose()
@@ -941,6 +1056,62 @@ func (f *Field) flushCaches() {
 	}
 }
 
+// maintainCaches keeps the field's ranked caches within its cache staleness
+// budget, recalculating them in the background so that TopN doesn't have
+// to. This is run in a goroutine while the field is open.
+func (f *Field) maintainCaches(stop <-chan struct{}) {
+	defer f.cacheMaintainerWG.Done()
+	for {
+		staleness := f.cacheStaleness()
+		for _, v := range f.views() {
+			for _, frag := range v.openFragments() {
+				frag.maintainCache(staleness)
+			}
+		}
+
+		// Recalculate often enough that reads rarely find a cache over
+		// budget.
+		var timer *time.Timer
+		var tick <-chan time.Time
+		if staleness > 0 {
+			timer = time.NewTimer(staleness / 2)
+			tick = timer.C
+		}
+		select {
+		case <-stop:
+		case <-tick:
+		case <-f.cacheStalenessChanged:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+}
+
+// cacheStaleness returns how far behind the field's ranked caches may let
+// their rankings fall.
+func (f *Field) cacheStaleness() time.Duration {
+	return time.Duration(atomic.LoadInt64(&f.staleness))
+}
+
+// stopMaintainingCaches stops the goroutine maintaining the field's ranked
+// caches, and waits for it to exit.
+func (f *Field) stopMaintainingCaches() {
+	f.mu.Lock()
+	stop := f.cacheMaintainerStop
+	f.cacheMaintainerStop = nil
+	f.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		f.cacheMaintainerWG.Wait()
+	}
+}
+
 // Keys returns true if the field uses string keys.
 func (f *Field) Keys() bool {
 	f.mu.RLock()
@@ -1010,21 +1181,27 @@ func (f *Field) viewsByTimeRange(from, to time.Time) (views []string, err error)
 	// yield union of all views
 	// yield "standard" if from and to were both not set and there is a
 	// standard view.
-	q := f.TimeQuantum()
+	q, prefix := f.TimeQuantum(), viewStandard
+	if q == "" && f.options.History != "" {
+		// A mutex field's history is queried by time.
+		q, prefix = f.options.History, viewHistory
+	}
 	if q == "" {
 		return nil, fmt.Errorf("field %s is not a time-field, 'from' and 'to' are not valid options for this field type", f.name)
 	}
 
-	if from.IsZero() && to.IsZero() && !f.options.NoStandardView {
+	if from.IsZero() && to.IsZero() && !f.options.NoStandardView && prefix == viewStandard {
 		return []string{viewStandard}, nil
 	}
 
 	// Get min/max based on existing views.
 	var vs []string
 	for _, v := range f.views() {
-		vs = append(vs, v.name)
+		if isHistoryView(v.name) == (prefix == viewHistory) {
+			vs = append(vs, v.name)
+		}
 	}
-	min, max := minMaxViews(vs, q)
+	min, max := minMaxViews(vs, q.baseUnits())
 
 	// If min/max are empty, there were no time views.
 	if min == "" || max == "" {
@@ -1047,7 +1224,10 @@ func (f *Field) viewsByTimeRange(from, to time.Time) (views []string, err error)
 	if to.IsZero() || to.After(maxTime) {
 		to = maxTime
 	}
-	return viewsByTimeRange(viewStandard, from, to, q), nil
+	if q.hasCalendarUnits() {
+		return calendarViewsByTimeRange(prefix, from, to, q, f.options.FiscalYearStart), nil
+	}
+	return viewsByTimeRange(prefix, from, to, q), nil
 }
 
 // RowTime gets the row at the particular time with the granularity specified by
@@ -1242,6 +1422,17 @@ func (f *Field) MutexCheck(ctx context.Context, qcx *Qcx, details bool, limit in
 // SetBit sets a bit on a view within the field.
 func (f *Field) SetBit(qcx *Qcx, rowID, colID uint64, t *time.Time) (changed bool, err error) {
 	viewName := viewStandard
+	if f.options.History != "" {
+		// A mutex field with history records the change at the time given,
+		// if any.
+		changedAt := time.Now().UTC()
+		if t != nil {
+			changedAt, t = *t, nil
+		}
+		if err := f.recordHistoryBit(qcx, rowID, colID, false, changedAt); err != nil {
+			return changed, errors.Wrap(err, "recording history")
+		}
+	}
 	if !f.options.NoStandardView {
 		// Retrieve view. Exit if it doesn't exist.
 		view, err := f.createViewIfNotExists(viewName)
@@ -1263,7 +1454,8 @@ func (f *Field) SetBit(qcx *Qcx, rowID, colID uint64, t *time.Time) (changed boo
 	}
 
 	// If a timestamp is specified then set bits across all views for the quantum.
-	for _, subname := range viewsByTime(viewName, *t, f.TimeQuantum()) {
+	q := f.TimeQuantum()
+	for _, subname := range append(viewsByTime(viewName, *t, q), calendarViewsByTime(viewName, *t, q, f.options.FiscalYearStart)...) {
 		view, err := f.createViewIfNotExists(subname)
 		if err != nil {
 			return changed, errors.Wrapf(err, "creating view %s", subname)
@@ -1282,6 +1474,9 @@ func (f *Field) SetBit(qcx *Qcx, rowID, colID uint64, t *time.Time) (changed boo
 // ClearBit clears a bit within the field.
 func (f *Field) ClearBit(qcx *Qcx, rowID, colID uint64) (changed bool, err error) {
 	viewName := viewStandard
+	if err := f.recordHistoryBit(qcx, rowID, colID, true, time.Now().UTC()); err != nil {
+		return false, errors.Wrap(err, "recording history")
+	}
 
 	// Retrieve view. Exit if it doesn't exist.
 	view, present := f.viewMap[viewName]
@@ -1372,6 +1567,9 @@ func (f *Field) allTimeViewsSortedByQuantum() (me []*view) {
 		}
 	}
 	me = me[:i]
+	if len(me) == 0 {
+		return me
+	}
 	year := strings.Index(me[0].name, "_") + 4
 	month := year + 2
 	day := month + 2
@@ -1557,6 +1755,99 @@ func (f *Field) MinForShard(qcx *Qcx, shard uint64, filter *Row) (ValCount, erro
 	return v, err
 }
 
+// extremeForShard returns the minimum value, or the maximum if max is
+// true, of the columns in filter on a shard. Unlike MinForShard and
+// MaxForShard, it returns the number of columns in filter with values,
+// as the groups of GroupBy count them.
+func (f *Field) extremeForShard(qcx *Qcx, shard uint64, filter *Row, max bool) (_ ValCount, err error) {
+	tx, finisher, err := qcx.GetTx(Txo{Write: true, Index: f.idx, Shard: shard})
+	if err != nil {
+		return ValCount{}, err
+	}
+	defer finisher(&err)
+	bsig := f.bsiGroup(f.name)
+	if bsig == nil {
+		return ValCount{}, ErrBSIGroupNotFound
+	}
+
+	view := f.view(viewBSIGroupPrefix + f.name)
+	if view == nil {
+		return ValCount{}, nil
+	}
+
+	fragment := view.Fragment(shard)
+	if fragment == nil {
+		return ValCount{}, nil
+	}
+
+	consider, err := fragment.notNull(tx)
+	if err != nil {
+		return ValCount{}, errors.Wrap(err, "getting existence row")
+	}
+	if filter != nil {
+		consider = consider.Intersect(filter)
+	}
+	n := consider.Count()
+	if n == 0 {
+		return ValCount{}, nil
+	}
+
+	var val int64
+	if max {
+		val, _, err = fragment.max(tx, consider, bsig.BitDepth)
+	} else {
+		val, _, err = fragment.min(tx, consider, bsig.BitDepth)
+	}
+	if err != nil {
+		return ValCount{}, errors.Wrap(err, "finding extreme value")
+	}
+	return f.valCountize(val, n, bsig)
+}
+
+// histogramForShard counts the values of the field on a shard in the
+// buckets between edges, among the columns of filter if it isn't nil.
+func (f *Field) histogramForShard(qcx *Qcx, shard uint64, filter *Row, edges []int64) (_ *Histogram, err error) {
+	h := newHistogram(f.name, edges)
+	tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: f.idx, Shard: shard})
+	if err != nil {
+		return nil, err
+	}
+	defer finisher(&err)
+	bsig := f.bsiGroup(f.name)
+	if bsig == nil {
+		return nil, ErrBSIGroupNotFound
+	}
+
+	view := f.view(viewBSIGroupPrefix + f.name)
+	if view == nil {
+		return h, nil
+	}
+
+	fragment := view.Fragment(shard)
+	if fragment == nil {
+		return h, nil
+	}
+
+	// Each bucket holds the values less than its upper edge, less those
+	// less than its lower edge.
+	var below uint64
+	for i, edge := range edges {
+		var n uint64
+		if predicate, outOfRange := bsig.baseValue(pql.LT, edge); !outOfRange {
+			row, err := fragment.rangeLT(tx, filter, bsig.BitDepth, predicate, false)
+			if err != nil {
+				return nil, errors.Wrap(err, "counting values below edge")
+			}
+			n = row.Count()
+		}
+		if i > 0 {
+			h.Counts[i-1] = n - below
+		}
+		below = n
+	}
+	return h, nil
+}
+
 // valCountize takes the "raw" value and count we get from the
 // fragment and calculates the cooked values for this field
 // (timestamping, decimaling, or just adding in the base). It always
@@ -1651,6 +1942,9 @@ func (f *Field) Import(qcx *Qcx, rowIDs, columnIDs []uint64, timestamps []int64,
 		if err != nil {
 			return errors.Wrap(err, "creating fragment")
 		}
+		if err1 = f.recordHistory(tx, shard, rowIDs, columnIDs, options.Clear, time.Now().UTC()); err1 != nil {
+			return errors.Wrap(err1, "recording history")
+		}
 
 		err1 = frag.bulkImport(tx, rowIDs, columnIDs, options)
 		return err1
@@ -1711,10 +2005,16 @@ func (f *Field) Import(qcx *Qcx, rowIDs, columnIDs []uint64, timestamps []int64,
 			// attach bit to all the views for this timestamp. note that the
 			// `timeViews` slice gets resliced and reused by this process, so
 			// we don't have to allocate millions of tiny slices of slice headers.
-			timeViews = viewsByTimeInto(timeStringBuf, timeViews, time.Unix(0, timestamps[i]).UTC(), q)
+			ts := time.Unix(0, timestamps[i]).UTC()
+			timeViews = viewsByTimeInto(timeStringBuf, timeViews, ts, q)
 			for _, v := range timeViews {
 				see(v, columnID, rowID)
 			}
+			if q.hasCalendarUnits() {
+				for _, v := range calendarViewsByTime(viewStandard, ts, q, f.options.FiscalYearStart) {
+					see([]byte(v), columnID, rowID)
+				}
+			}
 		}
 	}
 	tx, finisher, err := qcx.GetTx(Txo{Write: true, Index: f.idx, Shard: shard})
@@ -1760,6 +2060,48 @@ func (f *Field) importFloatValue(qcx *Qcx, columnIDs []uint64, values []float64,
 	return f.importValue(qcx, columnIDs, ivalues, shard, options)
 }
 
+// decimalImportValues converts the decimal strings of req to values scaled
+// to the field's scale. Every record whose value can't be parsed, has more
+// decimal places than the field's scale, or is outside the field's range is
+// reported in an *ImportValueErrors.
+func (f *Field) decimalImportValues(req *ImportValueRequest) ([]int64, error) {
+	opts := f.Options()
+	if opts.Type != FieldTypeDecimal {
+		return nil, NewBadRequestError(errors.Errorf("decimal values can't be imported into field %s of type %s", f.name, opts.Type))
+	}
+	values := make([]int64, len(req.DecimalValues))
+	var errs ImportValueErrors
+	for i, s := range req.DecimalValues {
+		if len(req.Nulls) > 0 && req.Nulls[i] {
+			continue
+		}
+		var reason string
+		dec, err := pql.ParseDecimal(s)
+		switch {
+		case err != nil:
+			reason = err.Error()
+		case dec.Scale > opts.Scale:
+			reason = fmt.Sprintf("has %d decimal places, but the field's scale is %d", dec.Scale, opts.Scale)
+		case dec.LessThan(opts.Min) || dec.GreaterThan(opts.Max):
+			reason = fmt.Sprintf("out of the field's range [%s, %s]", opts.Min, opts.Max)
+		default:
+			values[i] = dec.ToInt64(opts.Scale)
+			continue
+		}
+		ve := ImportValueError{Record: i, Value: s, Err: reason}
+		if i < len(req.ColumnKeys) {
+			ve.Column = req.ColumnKeys[i]
+		} else if i < len(req.ColumnIDs) {
+			ve.Column = strconv.FormatUint(req.ColumnIDs[i], 10)
+		}
+		errs.Errors = append(errs.Errors, ve)
+	}
+	if len(errs.Errors) > 0 {
+		return nil, &errs
+	}
+	return values, nil
+}
+
 // importTimestampValue imports timestamp values. In current usage, this
 // should only ever be called with data for a single shard; the API calls
 // around this are splitting it up per shard.
@@ -1999,6 +2341,40 @@ type FieldOptions struct {
 	TimeQuantum    TimeQuantum   `json:"timeQuantum,omitempty"`
 	ForeignIndex   string        `json:"foreignIndex"`
 	TTL            time.Duration `json:"ttl,omitempty"`
+	CacheStaleness time.Duration `json:"cacheStaleness,omitempty"`
+
+	// Derive is the expression the field's values are derived from
+	// another field's with, on import. See OptFieldDerive.
+	Derive string `json:"derive,omitempty"`
+
+	// History is the time quantum of the views in which a mutex field
+	// keeps its previous values. See OptFieldHistory.
+	History TimeQuantum `json:"history,omitempty"`
+
+	// Cascade is what deleting records in the field's foreign index does
+	// to its references to them. See OptFieldCascade.
+	Cascade string `json:"cascade,omitempty"`
+
+	// TrackDistinct maintains the distinct values of an int, decimal or
+	// timestamp field as it's written. See OptFieldTrackDistinct.
+	TrackDistinct bool `json:"trackDistinct,omitempty"`
+
+	// TimeZone is the zone in which queries of a time field, or of a mutex
+	// field's history, read times which don't give one. See
+	// OptFieldTimeZone.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// FiscalYearStart is the month in which the fiscal years, and so the
+	// quarters, of a time field or a mutex field's history start. See
+	// OptFieldFiscalYearStart.
+	FiscalYearStart time.Month `json:"fiscalYearStart,omitempty"`
+
+	// RejectNewKeys fails writes by query which would create new row keys
+	// in a keyed field. See OptFieldRejectNewKeys.
+	RejectNewKeys bool `json:"rejectNewKeys,omitempty"`
+
+	SchemaMetadata
+	FieldMemoryPolicy
 }
 
 // newFieldOptions returns a new instance of FieldOptions
@@ -2024,6 +2400,12 @@ func newFieldOptions(opts ...FieldOption) (*FieldOptions, error) {
 			return nil, ErrTimestampFieldWithKeys
 		}
 	}
+	if fo.History != "" && fo.Type != FieldTypeMutex {
+		return nil, errors.Errorf("history does not apply to field type %s", fo.Type)
+	}
+	if fo.RejectNewKeys && !fo.Keys {
+		return nil, errors.New("rejectNewKeys only applies to keyed fields")
+	}
 
 	return &fo, nil
 }
@@ -2049,25 +2431,38 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 	switch o.Type {
 	case FieldTypeSet, "":
 		return json.Marshal(struct {
-			Type      string `json:"type"`
-			CacheType string `json:"cacheType"`
-			CacheSize uint32 `json:"cacheSize"`
-			Keys      bool   `json:"keys"`
+			Type           string        `json:"type"`
+			CacheType      string        `json:"cacheType"`
+			CacheSize      uint32        `json:"cacheSize"`
+			Keys           bool          `json:"keys"`
+			CacheStaleness time.Duration `json:"cacheStaleness,omitempty"`
+			RejectNewKeys  bool          `json:"rejectNewKeys,omitempty"`
+			SchemaMetadata
+			FieldMemoryPolicy
 		}{
 			o.Type,
 			o.CacheType,
 			o.CacheSize,
 			o.Keys,
+			o.CacheStaleness,
+			o.RejectNewKeys,
+			o.SchemaMetadata,
+			o.FieldMemoryPolicy,
 		})
 	case FieldTypeInt:
 		return json.Marshal(struct {
-			Type         string      `json:"type"`
-			Base         int64       `json:"base"`
-			BitDepth     uint64      `json:"bitDepth"`
-			Min          pql.Decimal `json:"min"`
-			Max          pql.Decimal `json:"max"`
-			Keys         bool        `json:"keys"`
-			ForeignIndex string      `json:"foreignIndex"`
+			Type          string      `json:"type"`
+			Base          int64       `json:"base"`
+			BitDepth      uint64      `json:"bitDepth"`
+			Min           pql.Decimal `json:"min"`
+			Max           pql.Decimal `json:"max"`
+			Keys          bool        `json:"keys"`
+			ForeignIndex  string      `json:"foreignIndex"`
+			Derive        string      `json:"derive,omitempty"`
+			Cascade       string      `json:"cascade,omitempty"`
+			TrackDistinct bool        `json:"trackDistinct,omitempty"`
+			SchemaMetadata
+			FieldMemoryPolicy
 		}{
 			o.Type,
 			o.Base,
@@ -2076,16 +2471,24 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 			o.Max,
 			o.Keys,
 			o.ForeignIndex,
+			o.Derive,
+			o.Cascade,
+			o.TrackDistinct,
+			o.SchemaMetadata,
+			o.FieldMemoryPolicy,
 		})
 	case FieldTypeDecimal:
 		return json.Marshal(struct {
-			Type     string      `json:"type"`
-			Base     int64       `json:"base"`
-			Scale    int64       `json:"scale"`
-			BitDepth uint64      `json:"bitDepth"`
-			Min      pql.Decimal `json:"min"`
-			Max      pql.Decimal `json:"max"`
-			Keys     bool        `json:"keys"`
+			Type          string      `json:"type"`
+			Base          int64       `json:"base"`
+			Scale         int64       `json:"scale"`
+			BitDepth      uint64      `json:"bitDepth"`
+			Min           pql.Decimal `json:"min"`
+			Max           pql.Decimal `json:"max"`
+			Keys          bool        `json:"keys"`
+			TrackDistinct bool        `json:"trackDistinct,omitempty"`
+			SchemaMetadata
+			FieldMemoryPolicy
 		}{
 			o.Type,
 			o.Base,
@@ -2094,6 +2497,9 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 			o.Min,
 			o.Max,
 			o.Keys,
+			o.TrackDistinct,
+			o.SchemaMetadata,
+			o.FieldMemoryPolicy,
 		})
 	case FieldTypeTimestamp:
 		epoch, err := ValToTimestamp(o.TimeUnit, o.Base)
@@ -2102,12 +2508,15 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 		}
 
 		return json.Marshal(struct {
-			Type     string      `json:"type"`
-			Epoch    time.Time   `json:"epoch"`
-			BitDepth uint64      `json:"bitDepth"`
-			Min      pql.Decimal `json:"min"`
-			Max      pql.Decimal `json:"max"`
-			TimeUnit string      `json:"timeUnit"`
+			Type          string      `json:"type"`
+			Epoch         time.Time   `json:"epoch"`
+			BitDepth      uint64      `json:"bitDepth"`
+			Min           pql.Decimal `json:"min"`
+			Max           pql.Decimal `json:"max"`
+			TimeUnit      string      `json:"timeUnit"`
+			TrackDistinct bool        `json:"trackDistinct,omitempty"`
+			SchemaMetadata
+			FieldMemoryPolicy
 		}{
 			o.Type,
 			epoch,
@@ -2115,38 +2524,73 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 			o.Min,
 			o.Max,
 			o.TimeUnit,
+			o.TrackDistinct,
+			o.SchemaMetadata,
+			o.FieldMemoryPolicy,
 		})
 	case FieldTypeTime:
 		return json.Marshal(struct {
-			Type           string        `json:"type"`
-			TimeQuantum    TimeQuantum   `json:"timeQuantum"`
-			Keys           bool          `json:"keys"`
-			NoStandardView bool          `json:"noStandardView"`
-			TTL            time.Duration `json:"ttl"`
+			Type            string        `json:"type"`
+			TimeQuantum     TimeQuantum   `json:"timeQuantum"`
+			Keys            bool          `json:"keys"`
+			NoStandardView  bool          `json:"noStandardView"`
+			TTL             time.Duration `json:"ttl"`
+			TimeZone        string        `json:"timeZone,omitempty"`
+			FiscalYearStart time.Month    `json:"fiscalYearStart,omitempty"`
+			RejectNewKeys   bool          `json:"rejectNewKeys,omitempty"`
+			SchemaMetadata
+			FieldMemoryPolicy
 		}{
 			o.Type,
 			o.TimeQuantum,
 			o.Keys,
 			o.NoStandardView,
 			o.TTL,
+			o.TimeZone,
+			o.FiscalYearStart,
+			o.RejectNewKeys,
+			o.SchemaMetadata,
+			o.FieldMemoryPolicy,
 		})
 	case FieldTypeMutex:
 		return json.Marshal(struct {
-			Type      string `json:"type"`
-			CacheType string `json:"cacheType"`
-			CacheSize uint32 `json:"cacheSize"`
-			Keys      bool   `json:"keys"`
+			Type            string        `json:"type"`
+			CacheType       string        `json:"cacheType"`
+			CacheSize       uint32        `json:"cacheSize"`
+			Keys            bool          `json:"keys"`
+			CacheStaleness  time.Duration `json:"cacheStaleness,omitempty"`
+			Derive          string        `json:"derive,omitempty"`
+			History         TimeQuantum   `json:"history,omitempty"`
+			Cascade         string        `json:"cascade,omitempty"`
+			TimeZone        string        `json:"timeZone,omitempty"`
+			FiscalYearStart time.Month    `json:"fiscalYearStart,omitempty"`
+			RejectNewKeys   bool          `json:"rejectNewKeys,omitempty"`
+			SchemaMetadata
+			FieldMemoryPolicy
 		}{
 			o.Type,
 			o.CacheType,
 			o.CacheSize,
 			o.Keys,
+			o.CacheStaleness,
+			o.Derive,
+			o.History,
+			o.Cascade,
+			o.TimeZone,
+			o.FiscalYearStart,
+			o.RejectNewKeys,
+			o.SchemaMetadata,
+			o.FieldMemoryPolicy,
 		})
 	case FieldTypeBool:
 		return json.Marshal(struct {
 			Type string `json:"type"`
+			SchemaMetadata
+			FieldMemoryPolicy
 		}{
 			o.Type,
+			o.SchemaMetadata,
+			o.FieldMemoryPolicy,
 		})
 	}
 	return nil, errors.Errorf("invalid field type: '%s'", o.Type)
@@ -2373,14 +2817,15 @@ func (f *Field) SortShardRow(tx Tx, shard uint64, filter *Row, sort_desc bool) (
 		return nil, errors.New("bsig is nil")
 	}
 
+	// A shard without values has nothing to sort.
 	view := f.view(viewBSIGroupPrefix + f.name)
 	if view == nil {
-		return nil, errors.New("view is nil")
+		return &SortedRow{Row: NewRow()}, nil
 	}
 
 	fragment := view.Fragment(shard)
 	if fragment == nil {
-		return nil, errors.New("fragment is nil")
+		return &SortedRow{Row: NewRow()}, nil
 	}
 
 	return fragment.sortBsiData(tx, filter, bsig.BitDepth, sort_desc)
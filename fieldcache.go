@@ -0,0 +1,151 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// GET /index/{index}/field/{field}/cache reports what a field's caches hold
+// on a node: how many entries each fragment's cache has, whether its
+// rankings are behind its changes, the lowest count it keeps, and its top
+// entries. It also reports how often rows TopN() looked up by ID were found
+// in the caches, rather than read from storage, as happens when the rows
+// ranked highest in one shard are counted in the others. A low hit ratio
+// suggests a field's cache size is too small for its queries. The same
+// counts are exported as the cache_hits_total and cache_misses_total
+// metrics, tagged with the field.
+
+// DefaultFieldCacheTop is the number of top entries of each cache reported
+// by default.
+const DefaultFieldCacheTop = 10
+
+// FieldCache describes the caches of a field's fragments on a node. Hits and
+// Misses count the rows looked up in them since the field was opened.
+type FieldCache struct {
+	Index     string          `json:"index"`
+	Field     string          `json:"field"`
+	CacheType string          `json:"cacheType"`
+	CacheSize uint32          `json:"cacheSize"`
+	Staleness time.Duration   `json:"staleness,omitempty"`
+	Hits      uint64          `json:"hits"`
+	Misses    uint64          `json:"misses"`
+	HitRatio  float64         `json:"hitRatio"`
+	Fragments []FragmentCache `json:"fragments"`
+}
+
+// FragmentCache describes the cache of a fragment. A stale cache has had
+// changes, since StaleSince, which its rankings don't reflect yet. Threshold
+// is the lowest count a ranked cache keeps, and Top its highest ranked
+// entries.
+type FragmentCache struct {
+	View       string     `json:"view"`
+	Shard      uint64     `json:"shard"`
+	Entries    int        `json:"entries"`
+	Stale      bool       `json:"stale"`
+	StaleSince *time.Time `json:"staleSince,omitempty"`
+	Threshold  uint64     `json:"threshold,omitempty"`
+	Top        []Pair     `json:"top"`
+}
+
+// topPairs returns up to n of pairs, in a new slice.
+func topPairs(pairs []bitmapPair, n int) []Pair {
+	if n > len(pairs) {
+		n = len(pairs)
+	}
+	top := make([]Pair, 0, n)
+	for _, p := range pairs[:n] {
+		top = append(top, Pair{ID: p.ID, Count: p.Count})
+	}
+	return top
+}
+
+// cacheContents describes the fragment's cache, with up to n of its top
+// entries.
+func (f *fragment) cacheContents(n int) FragmentCache {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var fc FragmentCache
+	switch c := f.cache.(type) {
+	case *rankCache:
+		fc = c.contents(n)
+	case *lruCache:
+		fc = FragmentCache{Entries: c.Len(), Top: topPairs(c.Top(), n)}
+	default:
+		fc = FragmentCache{Top: []Pair{}}
+	}
+	fc.View, fc.Shard = f.view(), f.shard
+	return fc
+}
+
+// recordCacheLookups counts rows looked up in the field's caches which were,
+// and weren't, found there.
+func (f *Field) recordCacheLookups(hits, misses uint64) {
+	if hits+misses == 0 {
+		return
+	}
+	atomic.AddUint64(&f.cacheHits, hits)
+	atomic.AddUint64(&f.cacheMisses, misses)
+	stats := f.Stats.WithTags("field:" + f.name)
+	stats.Count(MetricCacheHits, int64(hits), 1.0)
+	stats.Count(MetricCacheMisses, int64(misses), 1.0)
+}
+
+// FieldCache describes the caches of a field's fragments on this node, with
+// up to n of the top entries of each.
+func (api *API) FieldCache(ctx context.Context, indexName, fieldName string, n int) (*FieldCache, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.FieldCache")
+	defer span.Finish()
+
+	if err := api.validate(apiFieldCache); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	if n < 0 {
+		return nil, NewBadRequestError(errors.New("n must not be negative"))
+	}
+
+	idx := api.holder.Index(indexName)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	f := idx.Field(fieldName)
+	if f == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
+	}
+
+	opts := f.Options()
+	fc := &FieldCache{
+		Index:     indexName,
+		Field:     fieldName,
+		CacheType: opts.CacheType,
+		CacheSize: opts.CacheSize,
+		Staleness: f.cacheStaleness(),
+		Hits:      atomic.LoadUint64(&f.cacheHits),
+		Misses:    atomic.LoadUint64(&f.cacheMisses),
+		Fragments: []FragmentCache{},
+	}
+	if lookups := fc.Hits + fc.Misses; lookups > 0 {
+		fc.HitRatio = float64(fc.Hits) / float64(lookups)
+	}
+	if opts.CacheType == CacheTypeNone {
+		return fc, nil
+	}
+	for _, v := range f.views() {
+		for _, frag := range v.fragmentList() {
+			fc.Fragments = append(fc.Fragments, frag.cacheContents(n))
+		}
+	}
+	sort.Slice(fc.Fragments, func(i, j int) bool {
+		if fc.Fragments[i].View != fc.Fragments[j].View {
+			return fc.Fragments[i].View < fc.Fragments[j].View
+		}
+		return fc.Fragments[i].Shard < fc.Fragments[j].Shard
+	})
+	return fc, nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Each index records when each of its fields was last written in each
+// shard, so that incremental exports and caches can ask which shards
+// changed since they last synced, with GET
+// /index/{index}/field/{field}/writes?since=<RFC3339 time>, rather than
+// reading everything again. A write is recorded when the transaction making
+// it commits. Times are only held in memory: a shard which hasn't been
+// written since its index was loaded reports the time it was loaded, which
+// is no earlier than its last write, so changes are never missed, though
+// every shard appears changed once after a restart. Across a cluster, each
+// shard reports the latest time of any of its replicas. /health reports the
+// time of the node's latest write.
+
+// ShardWrite is the time a field was last written in a shard.
+type ShardWrite struct {
+	Shard     uint64    `json:"shard"`
+	LastWrite time.Time `json:"lastWrite"`
+}
+
+// writeTimes holds the times each field of an index was last written in
+// each shard.
+type writeTimes struct {
+	mu     sync.Mutex
+	loaded time.Time
+	latest time.Time
+	fields map[string]map[uint64]time.Time
+
+	// shards holds the times shards were replaced wholesale, such as by a
+	// restore, which changes all of their fields.
+	shards map[uint64]time.Time
+}
+
+func newWriteTimes() *writeTimes {
+	return &writeTimes{
+		loaded: time.Now(),
+		fields: make(map[string]map[uint64]time.Time),
+		shards: make(map[uint64]time.Time),
+	}
+}
+
+// record records a write to field in shard at t.
+func (w *writeTimes) record(field string, shard uint64, t time.Time) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	shards := w.fields[field]
+	if shards == nil {
+		shards = make(map[uint64]time.Time)
+		w.fields[field] = shards
+	}
+	if t.After(shards[shard]) {
+		shards[shard] = t
+	}
+	if t.After(w.latest) {
+		w.latest = t
+	}
+}
+
+// recordShard records a write to every field in shard at t.
+func (w *writeTimes) recordShard(shard uint64, t time.Time) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t.After(w.shards[shard]) {
+		w.shards[shard] = t
+	}
+	if t.After(w.latest) {
+		w.latest = t
+	}
+}
+
+// deleteField discards the times of a field which has been deleted.
+func (w *writeTimes) deleteField(field string) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.fields, field)
+}
+
+// lastWrite returns the time of the latest write recorded, which is zero
+// if there hasn't been one.
+func (w *writeTimes) lastWrite() time.Time {
+	if w == nil {
+		return time.Time{}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.latest
+}
+
+// lastShardWrite returns the time field was last written in shard, which
+// is no earlier than when the index was loaded.
+func (w *writeTimes) lastShardWrite(field string, shard uint64) time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	t := w.loaded
+	if ft := w.fields[field][shard]; ft.After(t) {
+		t = ft
+	}
+	if st := w.shards[shard]; st.After(t) {
+		t = st
+	}
+	return t
+}
+
+// shardWrites returns the times field was last written in shards, and in
+// any other shards it has been written in, for those after since, in shard
+// order.
+func (w *writeTimes) shardWrites(field string, shards []uint64, since time.Time) []ShardWrite {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	times := make(map[uint64]time.Time, len(shards))
+	for _, shard := range shards {
+		times[shard] = w.loaded
+	}
+	// A shard written and then deleted has still changed.
+	for shard := range w.fields[field] {
+		times[shard] = w.loaded
+	}
+	out := []ShardWrite{}
+	for shard, t := range times {
+		if ft := w.fields[field][shard]; ft.After(t) {
+			t = ft
+		}
+		if st := w.shards[shard]; st.After(t) {
+			t = st
+		}
+		if t.After(since) {
+			out = append(out, ShardWrite{Shard: shard, LastWrite: t})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Shard < out[j].Shard })
+	return out
+}
+
+// writeKey identifies the data a transaction has written.
+type writeKey struct {
+	index string
+	field string
+	shard uint64
+}
+
+// noteWrite records that the transaction has written to field in shard,
+// to be recorded as a write to it when it commits.
+func (tx *RBFTx) noteWrite(index, field string, shard uint64) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.written == nil {
+		tx.written = make(map[writeKey]struct{})
+	}
+	tx.written[writeKey{index: index, field: field, shard: shard}] = struct{}{}
+}
+
+// recordWrites records the writes of a transaction which has committed.
+func (tx *RBFTx) recordWrites() {
+	tx.mu.Lock()
+	written := tx.written
+	tx.written = nil
+	tx.mu.Unlock()
+	if len(written) == 0 || tx.o.Index == nil {
+		return
+	}
+	now := time.Now()
+	for k := range written {
+		idx := tx.o.Index
+		if k.index != idx.name {
+			if idx = idx.holder.Index(k.index); idx == nil {
+				continue
+			}
+		}
+		idx.writeTimes.record(k.field, k.shard, now)
+	}
+}
+
+// lastWrite returns the time of the latest write to any index on this
+// node since it started, which is zero if there hasn't been one.
+func (h *Holder) lastWrite() time.Time {
+	var latest time.Time
+	for _, idx := range h.Indexes() {
+		if t := idx.writeTimes.lastWrite(); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// FieldWrites returns the shards of a field which have been written after
+// since, on any node, with the times they were last written.
+func (api *API) FieldWrites(ctx context.Context, indexName, fieldName string, since time.Time) ([]ShardWrite, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.FieldWrites")
+	defer span.Finish()
+
+	if err := api.validate(apiFieldWrites); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	if _, err := api.Field(ctx, indexName, fieldName); err != nil {
+		return nil, err
+	}
+
+	snap := api.cluster.NewSnapshot()
+	eg, _ := errgroup.WithContext(ctx)
+	myID := api.NodeID()
+	results := make([][]ShardWrite, len(snap.Nodes))
+	for i, node := range snap.Nodes {
+		i, node := i, node
+		if node.ID != myID {
+			eg.Go(func() (err error) {
+				results[i], err = api.server.defaultClient.FieldWrites(ctx, &node.URI, indexName, fieldName, since)
+				return err
+			})
+		} else {
+			eg.Go(func() (err error) {
+				results[i], err = api.fieldWritesThisNode(indexName, fieldName, since)
+				return err
+			})
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Each shard reports the latest write to any of its replicas.
+	latest := make(map[uint64]time.Time)
+	for _, writes := range results {
+		for _, w := range writes {
+			if w.LastWrite.After(latest[w.Shard]) {
+				latest[w.Shard] = w.LastWrite
+			}
+		}
+	}
+	out := make([]ShardWrite, 0, len(latest))
+	for shard, t := range latest {
+		out = append(out, ShardWrite{Shard: shard, LastWrite: t})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Shard < out[j].Shard })
+	return out, nil
+}
+
+// FieldWritesNode returns the shards of a field which have been written
+// after since on this node, with the times they were last written.
+func (api *API) FieldWritesNode(ctx context.Context, indexName, fieldName string, since time.Time) ([]ShardWrite, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.FieldWritesNode")
+	defer span.Finish()
+
+	if err := api.validate(apiFieldWrites); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.fieldWritesThisNode(indexName, fieldName, since)
+}
+
+func (api *API) fieldWritesThisNode(indexName, fieldName string, since time.Time) ([]ShardWrite, error) {
+	idx := api.holder.Index(indexName)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	f := idx.Field(fieldName)
+	if f == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
+	}
+	return idx.writeTimes.shardWrites(fieldName, f.AvailableShards(true).Slice(), since), nil
+}
@@ -12,6 +12,7 @@ import (
 	"hash"
 	"io"
 	"math"
+	"math/big"
 	"math/bits"
 	"os"
 	"path/filepath"
@@ -19,6 +20,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cespare/xxhash"
@@ -58,6 +60,9 @@ const (
 	// cacheExt is the file extension for persisted cache ids.
 	cacheExt = ".cache"
 
+	// checksumExt is the file extension for persisted fragment checksums.
+	checksumExt = ".checksum"
+
 	// HashBlockSize is the number of rows in a merkle hash block.
 	HashBlockSize = 100
 
@@ -119,6 +124,17 @@ type fragment struct {
 	// Cached checksums for each block.
 	checksums map[int][]byte
 
+	// Fragments of a lazily opened holder are opened on first use, by
+	// ensureOpen. opened is set atomically once the fragment is open.
+	openOnce sync.Once
+	openErr  error
+	opened   int32
+
+	// checksumSaved is set atomically while a checksum of the fragment's
+	// data is persisted. The first write after removes it, since it no
+	// longer matches.
+	checksumSaved int32
+
 	// Logger used for out-of-band log entries.
 	Logger logger.Logger
 
@@ -126,6 +142,9 @@ type fragment struct {
 	// existing value (to clear) prior to setting a new value.
 	mutexVector vector
 
+	// reads coalesces the reads of rows by concurrent queries.
+	reads readCoalescer
+
 	stats stats.StatsClient
 }
 
@@ -156,6 +175,9 @@ func newFragment(holder *Holder, idx *Index, fld *Field, vw *view, shard uint64)
 // cachePath returns the path to the fragment's cache data.
 func (f *fragment) cachePath() string { return f.path() + cacheExt }
 
+// checksumPath returns the path to the fragment's persisted checksum.
+func (f *fragment) checksumPath() string { return f.path() + checksumExt }
+
 func (f *fragment) bitDepth() (uint64, error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
@@ -187,6 +209,20 @@ func (f *fragment) Index() *Index {
 
 // Open opens the underlying storage.
 func (f *fragment) Open() error {
+	if err := f.open(); err != nil {
+		return err
+	}
+	if f.checksumsEnabled() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if err := f.verifyChecksum(); err != nil {
+			f.holder.Logger.Errorf("verifying checksum of index/field/view/fragment %s/%s/%s/%d: %v", f.index(), f.field(), f.view(), f.shard, err)
+		}
+	}
+	return nil
+}
+
+func (f *fragment) open() error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -205,11 +241,29 @@ func (f *fragment) Open() error {
 		return err
 	}
 
+	atomic.StoreInt32(&f.opened, 1)
 	_ = testhook.Opened(f.holder.Auditor, f, nil)
 	f.holder.Logger.Debugf("successfully opened index/field/view/fragment: %s/%s/%s/%d", f.index(), f.field(), f.view(), f.shard)
 	return nil
 }
 
+// isOpen reports whether the fragment has been opened.
+func (f *fragment) isOpen() bool {
+	return atomic.LoadInt32(&f.opened) == 1
+}
+
+// ensureOpen opens the fragment if it hasn't been yet, as happens on first
+// use when the holder is opened lazily.
+func (f *fragment) ensureOpen() error {
+	if f.isOpen() {
+		return nil
+	}
+	f.openOnce.Do(func() {
+		f.openErr = f.Open()
+	})
+	return f.openErr
+}
+
 // openCache initializes the cache from row ids persisted to disk.
 func (f *fragment) openCache() error {
 	// Determine cache type from field name.
@@ -225,6 +279,12 @@ func (f *fragment) openCache() error {
 		return ErrInvalidCacheType
 	}
 
+	// If the field's caches are maintained in the background, so is this
+	// one, once it's loaded.
+	if f.fld != nil {
+		defer f.cache.SetStaleness(f.fld.cacheStaleness())
+	}
+
 	// Read cache data from disk.
 	path := f.cachePath()
 	buf, err := os.ReadFile(path)
@@ -269,10 +329,21 @@ func (f *fragment) Close() error {
 }
 
 func (f *fragment) close() error {
-	// Flush cache if closing gracefully.
-	if err := f.flushCache(); err != nil {
-		f.holder.Logger.Errorf("fragment: error flushing cache on close: err=%s, path=%s", err, f.path())
-		return errors.Wrap(err, "flushing cache")
+	// Flush cache if closing gracefully. A fragment which couldn't be
+	// opened has a partly loaded cache, which mustn't replace the one on
+	// disk.
+	if f.isOpen() {
+		if err := f.flushCache(); err != nil {
+			f.holder.Logger.Errorf("fragment: error flushing cache on close: err=%s, path=%s", err, f.path())
+			return errors.Wrap(err, "flushing cache")
+		}
+	}
+
+	// Persist a checksum of the data, to be verified when it's next opened.
+	if f.checksumsEnabled() && f.isOpen() {
+		if err := f.saveChecksum(); err != nil {
+			f.holder.Logger.Errorf("fragment: error saving checksum on close: err=%s, path=%s", err, f.path())
+		}
 	}
 
 	// Remove checksums.
@@ -311,7 +382,7 @@ func (f *fragment) mustRow(tx Tx, rowID uint64) *Row {
 // unprotectedRow returns a row from the row cache if available or from storage
 // (updating the cache).
 func (f *fragment) unprotectedRow(tx Tx, rowID uint64) (*Row, error) {
-	row, err := f.rowFromStorage(tx, rowID)
+	row, err := f.coalescedRow(tx, rowID)
 	if err != nil {
 		return nil, err
 	}
@@ -380,6 +451,7 @@ func (f *fragment) handleMutex(tx Tx, rowID, columnID uint64) error {
 
 // unprotectedSetBit TODO should be replaced by an invocation of importPositions with a single bit to set.
 func (f *fragment) unprotectedSetBit(tx Tx, rowID, columnID uint64) (changed bool, err error) {
+	f.dataChanged()
 
 	// Determine the position of the bit in the storage.
 	pos, err := f.pos(rowID, columnID)
@@ -429,6 +501,7 @@ func (f *fragment) clearBit(tx Tx, rowID, columnID uint64) (changed bool, err er
 // unprotectedClearBit TODO should be replaced by an invocation of
 // importPositions with a single bit to clear.
 func (f *fragment) unprotectedClearBit(tx Tx, rowID, columnID uint64) (changed bool, err error) {
+	f.dataChanged()
 	changed = false
 	// Determine the position of the bit in the storage.
 	pos, err := f.pos(rowID, columnID)
@@ -476,6 +549,8 @@ func (f *fragment) setRow(tx Tx, row *Row, rowID uint64) (changed bool, err erro
 }
 
 func (f *fragment) unprotectedSetRow(tx Tx, row *Row, rowID uint64) (changed bool, err error) {
+	f.dataChanged()
+
 	// TODO: In order to return `changed`, we need to first compare
 	// the existing row with the given row. Determine if the overhead
 	// of this is worth having `changed`.
@@ -532,6 +607,7 @@ func (f *fragment) clearRow(tx Tx, rowID uint64) (changed bool, err error) {
 }
 
 func (f *fragment) unprotectedClearRow(tx Tx, rowID uint64) (changed bool, err error) {
+	f.dataChanged()
 	changed = false
 
 	// First container of the row in storage.
@@ -553,10 +629,10 @@ func (f *fragment) unprotectedClearRow(tx Tx, rowID uint64) (changed bool, err e
 		}
 	}
 
-	// Clear the row in cache.
-	f.cache.Add(rowID, 0)
-
-	return changed, nil
+	if !changed {
+		return false, nil
+	}
+	return true, f.rowsCleared(tx, map[uint64]struct{}{rowID: {}})
 }
 
 // clearBlock clears all rows for a given block.
@@ -622,12 +698,20 @@ func (f *fragment) value(tx Tx, columnID uint64, bitDepth uint64) (value int64,
 
 // clearValue uses a column of bits to clear a multi-bit value.
 func (f *fragment) clearValue(tx Tx, columnID uint64, bitDepth uint64, value int64) (changed bool, err error) {
-	return f.setValueBase(tx, columnID, bitDepth, value, true)
+	err = f.trackDistinct(tx, []uint64{columnID}, func() (err error) {
+		changed, err = f.setValueBase(tx, columnID, bitDepth, value, true)
+		return err
+	})
+	return changed, err
 }
 
 // setValue uses a column of bits to set a multi-bit value.
 func (f *fragment) setValue(tx Tx, columnID uint64, bitDepth uint64, value int64) (changed bool, err error) {
-	return f.setValueBase(tx, columnID, bitDepth, value, false)
+	err = f.trackDistinct(tx, []uint64{columnID}, func() (err error) {
+		changed, err = f.setValueBase(tx, columnID, bitDepth, value, false)
+		return err
+	})
+	return changed, err
 }
 
 func (f *fragment) positionsForValue(columnID uint64, bitDepth uint64, value int64, clear bool, toSet, toClear []uint64) ([]uint64, []uint64, error) {
@@ -734,33 +818,167 @@ func (f *fragment) setValueBase(tx Tx, columnID uint64, bitDepth uint64, value i
 }
 
 // sum returns the sum of a given bsiGroup as well as the number of columns involved.
-// A bitmap can be passed in to optionally filter the computed columns.
-func (f *fragment) sum(tx Tx, filter *Row, bitDepth uint64) (sum int64, count uint64, err error) {
+// A bitmap can be passed in to optionally filter the computed columns. The sum is
+// accumulated in 128 bits, so it can't overflow.
+func (f *fragment) sum(tx Tx, filter *Row, bitDepth uint64) (sum *big.Int, count uint64, err error) {
 	// If there's a provided filter, but it has no contents for this particular
 	// shard, we're done and can return early. If there's no provided filter,
 	// though, we want to run with no-filter, as opposed to an empty filter.
-	var filterData *roaring.Bitmap
-	if filter != nil {
-		for _, seg := range filter.segments {
-			if seg.shard == f.shard {
-				filterData = seg.data
-				break
+	if filter != nil && filter.segment(f.shard) == nil {
+		return new(big.Int), 0, nil
+	}
+
+	// Include any bits beyond bitDepth, in case it's out of date.
+	if maxRow, err := f.maxRowID(tx); err != nil {
+		return nil, 0, errors.Wrap(err, "getting bit depth")
+	} else if maxRow >= bsiOffsetBit && maxRow-bsiOffsetBit+1 > bitDepth {
+		bitDepth = maxRow - bsiOffsetBit + 1
+	}
+
+	// Sum each container separately, splitting the columns to count into
+	// positive and negative values, and adding the count of each in each
+	// bit slice, weighted by the bit.
+	var pos, neg, filt, buf bsiBlock
+	var phi, plo, nhi, nlo uint64
+	for k := uint64(0); k < containersPerShard; k++ {
+		exists, err := f.sliceBlock(tx, bsiExistsBit, k, &buf)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "finding existing positions")
+		} else if exists == nil {
+			continue
+		}
+		blockCopy(&pos, exists)
+		if filter != nil {
+			if !f.rowBlock(filter, k, &filt, &buf) {
+				continue
 			}
+			blockAnd(&pos, &filt)
+		}
+		n := blockCount(&pos)
+		if n == 0 {
+			continue
+		}
+		count += n
+
+		sign, err := f.sliceBlock(tx, bsiSignBit, k, &buf)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "finding negative values")
 		}
-		// if filter is empty, we're done
-		if filterData == nil {
-			return 0, 0, nil
+		hasNeg := sign != nil && blockAndInto(&neg, &pos, sign)
+		if hasNeg {
+			blockAndNot(&pos, sign)
+		}
+		for i := uint64(0); i < bitDepth; i++ {
+			row, err := f.sliceBlock(tx, bsiOffsetBit+i, k, &buf)
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "reading bit slice")
+			} else if row == nil {
+				continue
+			}
+			if hasNeg {
+				p, n := blockAndCount2(&pos, &neg, row)
+				phi, plo = addShifted128(phi, plo, p, i)
+				nhi, nlo = addShifted128(nhi, nlo, n, i)
+			} else {
+				phi, plo = addShifted128(phi, plo, blockAndCount(&pos, row), i)
+			}
 		}
 	}
-	bsiFilt := roaring.NewBitmapBSICountFilter(filterData)
-	err = tx.ApplyFilter(f.index(), f.field(), f.view(), f.shard, 0, bsiFilt)
-	if err != nil && err != io.EOF {
-		return sum, count, errors.Wrap(err, "finding existing positions")
+	sum = uint128ToBig(phi, plo)
+	return sum.Sub(sum, uint128ToBig(nhi, nlo)), count, nil
+}
+
+// sumProduct returns the sum, over the columns in filter, of the product of
+// the stored values of f and other, which must be a BSI fragment of the
+// same shard, ignoring their bases. Columns in filter must have values in
+// both fragments.
+func (f *fragment) sumProduct(tx Tx, other *fragment, filter *Row, bitDepth, otherBitDepth uint64) (*big.Int, error) {
+	if filter.segment(f.shard) == nil {
+		return new(big.Int), nil
 	}
 
-	c32, sum := bsiFilt.Total()
+	// Include any bits beyond the bit depths, in case they're out of date.
+	if maxRow, err := f.maxRowID(tx); err != nil {
+		return nil, errors.Wrap(err, "getting bit depth")
+	} else if maxRow >= bsiOffsetBit && maxRow-bsiOffsetBit+1 > bitDepth {
+		bitDepth = maxRow - bsiOffsetBit + 1
+	}
+	if maxRow, err := other.maxRowID(tx); err != nil {
+		return nil, errors.Wrap(err, "getting bit depth")
+	} else if maxRow >= bsiOffsetBit && maxRow-bsiOffsetBit+1 > otherBitDepth {
+		otherBitDepth = maxRow - bsiOffsetBit + 1
+	}
 
-	return sum, uint64(c32), nil
+	// The product of bits i and j of two values is worth 2^(i+j), so count
+	// the columns with each pair of bits set, splitting them by the sign of
+	// their product, and add the counts for each power of two at the end.
+	// The bit slices of other are loaded once per container, and each bit
+	// slice of f is matched against all of them.
+	posCounts := make([]uint64, bitDepth+otherBitDepth)
+	negCounts := make([]uint64, bitDepth+otherBitDepth)
+	slices := make([]bsiBlock, otherBitDepth)
+	present := make([]bool, otherBitDepth)
+	var pos, neg, signs, tpos, tneg, buf bsiBlock
+	for k := uint64(0); k < containersPerShard; k++ {
+		if !f.rowBlock(filter, k, &pos, &buf) {
+			continue
+		}
+		blockZero(&signs)
+		for _, frag := range []*fragment{f, other} {
+			sign, err := frag.sliceBlock(tx, bsiSignBit, k, &buf)
+			if err != nil {
+				return nil, errors.Wrap(err, "finding negative values")
+			} else if sign != nil {
+				blockXor(&signs, sign)
+			}
+		}
+		hasNeg := blockAndInto(&neg, &pos, &signs)
+		if hasNeg {
+			blockAndNot(&pos, &signs)
+		}
+		for j := uint64(0); j < otherBitDepth; j++ {
+			row, err := other.sliceBlock(tx, bsiOffsetBit+j, k, &buf)
+			if err != nil {
+				return nil, errors.Wrap(err, "reading bit slice")
+			}
+			present[j] = row != nil
+			if row != nil {
+				blockCopy(&slices[j], row)
+			}
+		}
+		for i := uint64(0); i < bitDepth; i++ {
+			row, err := f.sliceBlock(tx, bsiOffsetBit+i, k, &buf)
+			if err != nil {
+				return nil, errors.Wrap(err, "reading bit slice")
+			} else if row == nil {
+				continue
+			}
+			anyPos := blockAndInto(&tpos, &pos, row)
+			anyNeg := hasNeg && blockAndInto(&tneg, &neg, row)
+			if !anyPos && !anyNeg {
+				continue
+			}
+			for j := uint64(0); j < otherBitDepth; j++ {
+				if !present[j] {
+					continue
+				}
+				if anyNeg {
+					p, n := blockAndCount2(&tpos, &tneg, &slices[j])
+					posCounts[i+j] += p
+					negCounts[i+j] += n
+				} else {
+					posCounts[i+j] += blockAndCount(&tpos, &slices[j])
+				}
+			}
+		}
+	}
+
+	prod, v := new(big.Int), new(big.Int)
+	for s := range posCounts {
+		prod.Add(prod, v.Lsh(v.SetUint64(posCounts[s]), uint(s)))
+		prod.Sub(prod, v.Lsh(v.SetUint64(negCounts[s]), uint(s)))
+	}
+	return prod, nil
 }
 
 // min returns the min of a given bsiGroup as well as the number of columns involved.
@@ -795,24 +1013,7 @@ func (f *fragment) min(tx Tx, filter *Row, bitDepth uint64) (min int64, count ui
 
 // minUnsigned the lowest value without considering the sign bit. Filter is required.
 func (f *fragment) minUnsigned(tx Tx, filter *Row, bitDepth uint64) (min int64, count uint64, err error) {
-	count = filter.Count()
-	for i := int(bitDepth - 1); i >= 0; i-- {
-		row, err := f.row(tx, uint64(bsiOffsetBit+i))
-		if err != nil {
-			return min, count, err
-		}
-		row = filter.Difference(row)
-		count = row.Count()
-		if count > 0 {
-			filter = row
-		} else {
-			min += (1 << uint(i))
-			if i == 0 {
-				count = filter.Count()
-			}
-		}
-	}
-	return min, count, nil
+	return f.extremeUnsigned(tx, filter, bitDepth, false)
 }
 
 // max returns the max of a given bsiGroup as well as the number of columns involved.
@@ -847,23 +1048,7 @@ func (f *fragment) max(tx Tx, filter *Row, bitDepth uint64) (max int64, count ui
 
 // maxUnsigned the highest value without considering the sign bit. Filter is required.
 func (f *fragment) maxUnsigned(tx Tx, filter *Row, bitDepth uint64) (max int64, count uint64, err error) {
-	count = filter.Count()
-	for i := int(bitDepth - 1); i >= 0; i-- {
-		row, err := f.row(tx, uint64(bsiOffsetBit+i))
-		if err != nil {
-			return max, count, err
-		}
-		row = row.Intersect(filter)
-
-		count = row.Count()
-		if count > 0 {
-			max += (1 << uint(i))
-			filter = row
-		} else if i == 0 {
-			count = filter.Count()
-		}
-	}
-	return max, count, nil
+	return f.extremeUnsigned(tx, filter, bitDepth, true)
 }
 
 // minRow returns minRowID of the rows in the filter and its count.
@@ -948,21 +1133,33 @@ func (f *fragment) maxRowID(tx Tx) (_ uint64, err error) {
 }
 
 // rangeOp returns bitmaps with a bsiGroup value encoding matching the predicate.
-func (f *fragment) rangeOp(tx Tx, op pql.Token, bitDepth uint64, predicate int64) (*Row, error) {
+// If filter isn't nil, only its columns are compared, and the bit slices of
+// containers with none of them aren't read.
+func (f *fragment) rangeOp(tx Tx, filter *Row, op pql.Token, bitDepth uint64, predicate int64) (*Row, error) {
 	switch op {
 	case pql.EQ:
-		return f.rangeEQ(tx, bitDepth, predicate)
+		return f.rangeEQ(tx, filter, bitDepth, predicate)
 	case pql.NEQ:
-		return f.rangeNEQ(tx, bitDepth, predicate)
+		return f.rangeNEQ(tx, filter, bitDepth, predicate)
 	case pql.LT, pql.LTE:
-		return f.rangeLT(tx, bitDepth, predicate, op == pql.LTE)
+		return f.rangeLT(tx, filter, bitDepth, predicate, op == pql.LTE)
 	case pql.GT, pql.GTE:
-		return f.rangeGT(tx, bitDepth, predicate, op == pql.GTE)
+		return f.rangeGT(tx, filter, bitDepth, predicate, op == pql.GTE)
 	default:
 		return nil, ErrInvalidRangeOperation
 	}
 }
 
+// bsiExists returns the columns with values set, limited to those in filter
+// unless it's nil.
+func (f *fragment) bsiExists(tx Tx, filter *Row) (*Row, error) {
+	b, err := f.row(tx, bsiExistsBit)
+	if err != nil || filter == nil {
+		return b, err
+	}
+	return b.Intersect(filter), nil
+}
+
 func absInt64(v int64) uint64 {
 	switch {
 	case v > 0:
@@ -974,9 +1171,9 @@ func absInt64(v int64) uint64 {
 	}
 }
 
-func (f *fragment) rangeEQ(tx Tx, bitDepth uint64, predicate int64) (*Row, error) {
+func (f *fragment) rangeEQ(tx Tx, filter *Row, bitDepth uint64, predicate int64) (*Row, error) {
 	// Start with set of columns with values set.
-	b, err := f.row(tx, bsiExistsBit)
+	b, err := f.bsiExists(tx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -999,32 +1196,24 @@ func (f *fragment) rangeEQ(tx Tx, bitDepth uint64, predicate int64) (*Row, error
 	}
 
 	// Filter any bits that don't match the current bit value.
-	for i := int(bitDepth - 1); i >= 0; i-- {
-		row, err := f.row(tx, uint64(bsiOffsetBit+i))
-		if err != nil {
-			return nil, err
-		}
-		bit := (upredicate >> uint(i)) & 1
-
-		if bit == 1 {
-			b = b.Intersect(row)
-		} else {
-			b = b.Difference(row)
+	return f.bsiScan(b, func(k uint64, rem, out, tmp, buf *bsiBlock) error {
+		if ok, err := f.blockNarrowEQ(tx, k, rem, buf, bitDepth, 0, upredicate); err != nil || !ok {
+			return err
 		}
-	}
-
-	return b, nil
+		blockOr(out, rem)
+		return nil
+	})
 }
 
-func (f *fragment) rangeNEQ(tx Tx, bitDepth uint64, predicate int64) (*Row, error) {
+func (f *fragment) rangeNEQ(tx Tx, filter *Row, bitDepth uint64, predicate int64) (*Row, error) {
 	// Start with set of columns with values set.
-	b, err := f.row(tx, bsiExistsBit)
+	b, err := f.bsiExists(tx, filter)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get the equal bitmap.
-	eq, err := f.rangeEQ(tx, bitDepth, predicate)
+	eq, err := f.rangeEQ(tx, filter, bitDepth, predicate)
 	if err != nil {
 		return nil, err
 	}
@@ -1035,13 +1224,69 @@ func (f *fragment) rangeNEQ(tx Tx, bitDepth uint64, predicate int64) (*Row, erro
 	return b, nil
 }
 
-func (f *fragment) rangeLT(tx Tx, bitDepth uint64, predicate int64, allowEquality bool) (*Row, error) {
+// rangeIn returns the columns whose values are any of predicates. Rather
+// than a scan per value, each container's bit slices are read once, and
+// values sharing their high bits share the work of narrowing by them.
+func (f *fragment) rangeIn(tx Tx, filter *Row, bitDepth uint64, predicates []int64) (*Row, error) {
+	// Split the predicates by sign, dropping those out of range.
+	var neg, pos []uint64
+	for _, predicate := range predicates {
+		upredicate := absInt64(predicate)
+		if uint64(bits.Len64(upredicate)) > bitDepth {
+			continue
+		}
+		if predicate < 0 {
+			neg = append(neg, upredicate)
+		} else {
+			pos = append(pos, upredicate)
+		}
+	}
+	if len(neg) == 0 && len(pos) == 0 {
+		return NewRow(), nil
+	}
+	sort.Slice(neg, func(i, j int) bool { return neg[i] < neg[j] })
+	sort.Slice(pos, func(i, j int) bool { return pos[i] < pos[j] })
+
+	// Start with set of columns with values set.
+	b, err := f.bsiExists(tx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var signBuf bsiBlock
+	slices := make([]*bsiBlock, bitDepth)
+	bufs := make([]bsiBlock, bitDepth)
+	levels := make([]bsiBlock, bitDepth)
+	return f.bsiScan(b, func(k uint64, rem, out, tmp, buf *bsiBlock) error {
+		for i := range slices {
+			if slices[i], err = f.sliceBlock(tx, uint64(bsiOffsetBit+i), k, &bufs[i]); err != nil {
+				return err
+			}
+		}
+		sign, err := f.sliceBlock(tx, bsiSignBit, k, &signBuf)
+		if err != nil {
+			return err
+		}
+		if sign == nil {
+			sign = &zeroBlock
+		}
+		if len(pos) > 0 && blockAndNotInto(tmp, rem, sign) {
+			blockNarrowIn(slices, levels, tmp, out, int(bitDepth), pos)
+		}
+		if len(neg) > 0 && blockAndInto(tmp, rem, sign) {
+			blockNarrowIn(slices, levels, tmp, out, int(bitDepth), neg)
+		}
+		return nil
+	})
+}
+
+func (f *fragment) rangeLT(tx Tx, filter *Row, bitDepth uint64, predicate int64, allowEquality bool) (*Row, error) {
 	if predicate == 1 && !allowEquality {
 		predicate, allowEquality = 0, true
 	}
 
 	// Start with set of columns with values set.
-	b, err := f.row(tx, bsiExistsBit)
+	b, err := f.bsiExists(tx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -1061,7 +1306,7 @@ func (f *fragment) rangeLT(tx Tx, bitDepth uint64, predicate int64, allowEqualit
 		return b.Intersect(sign), nil
 	case predicate == 0 && allowEquality:
 		// Match all integers that are either negative or 0.
-		zeroes, err := f.rangeEQ(tx, bitDepth, 0)
+		zeroes, err := f.rangeEQ(tx, filter, bitDepth, 0)
 		if err != nil {
 			return nil, err
 		}
@@ -1090,48 +1335,25 @@ func (f *fragment) rangeLTUnsigned(tx Tx, filter *Row, bitDepth uint64, predicat
 		return filter, nil
 	case predicate == (1<<bitDepth)-1 && !allowEquality:
 		// This query matches everything that is not (1<<bitDepth)-1.
-		matches := NewRow()
-		for i := uint64(0); i < bitDepth; i++ {
-			row, err := f.row(tx, uint64(bsiOffsetBit+i))
-			if err != nil {
-				return nil, err
-			}
-			matches = matches.Union(filter.Difference(row))
-		}
-		return matches, nil
+		return f.bsiScan(filter, func(k uint64, rem, out, tmp, buf *bsiBlock) error {
+			return f.blockAnyZero(tx, k, rem, out, tmp, buf, bitDepth)
+		})
 	case allowEquality:
 		predicate++
 	}
 
 	// Compare intermediate bits.
-	matched := NewRow()
-	remaining := filter
-	for i := int(bitDepth - 1); i >= 0 && predicate > 0 && remaining.Any(); i-- {
-		row, err := f.row(tx, uint64(bsiOffsetBit+i))
-		if err != nil {
-			return nil, err
-		}
-		zeroes := remaining.Difference(row)
-		switch (predicate >> uint(i)) & 1 {
-		case 1:
-			// Match everything with a zero bit here.
-			matched = matched.Union(zeroes)
-			predicate &^= 1 << uint(i)
-		case 0:
-			// Discard everything with a one bit here.
-			remaining = zeroes
-		}
-	}
-
-	return matched, nil
+	return f.bsiScan(filter, func(k uint64, rem, out, tmp, buf *bsiBlock) error {
+		return f.blockLTUnsigned(tx, k, rem, out, tmp, buf, bitDepth, predicate)
+	})
 }
 
-func (f *fragment) rangeGT(tx Tx, bitDepth uint64, predicate int64, allowEquality bool) (*Row, error) {
+func (f *fragment) rangeGT(tx Tx, filter *Row, bitDepth uint64, predicate int64, allowEquality bool) (*Row, error) {
 	if predicate == -1 && !allowEquality {
 		predicate, allowEquality = 0, true
 	}
 
-	b, err := f.row(tx, bsiExistsBit)
+	b, err := f.bsiExists(tx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -1145,7 +1367,7 @@ func (f *fragment) rangeGT(tx Tx, bitDepth uint64, predicate int64, allowEqualit
 	switch {
 	case predicate == 0 && !allowEquality:
 		// Match all positive numbers except zero.
-		nonzero, err := f.rangeNEQ(tx, bitDepth, 0)
+		nonzero, err := f.rangeNEQ(tx, filter, bitDepth, 0)
 		if err != nil {
 			return nil, err
 		}
@@ -1176,15 +1398,9 @@ prep:
 		return filter, nil
 	case predicate == 0 && !allowEquality:
 		// This query matches everything that is not 0.
-		matches := NewRow()
-		for i := uint64(0); i < bitDepth; i++ {
-			row, err := f.row(tx, uint64(bsiOffsetBit+i))
-			if err != nil {
-				return nil, err
-			}
-			matches = matches.Union(filter.Intersect(row))
-		}
-		return matches, nil
+		return f.bsiScan(filter, func(k uint64, rem, out, tmp, buf *bsiBlock) error {
+			return f.blockAnyOne(tx, k, rem, out, tmp, buf, bitDepth)
+		})
 	case !allowEquality && uint64(bits.Len64(predicate)) > bitDepth:
 		// The predicate is bigger than the BSI width, so nothing can be bigger.
 		return NewRow(), nil
@@ -1195,27 +1411,9 @@ prep:
 	}
 
 	// Compare intermediate bits.
-	matched := NewRow()
-	remaining := filter
-	predicate |= (^uint64(0)) << bitDepth
-	for i := int(bitDepth - 1); i >= 0 && predicate < ^uint64(0) && remaining.Any(); i-- {
-		row, err := f.row(tx, uint64(bsiOffsetBit+i))
-		if err != nil {
-			return nil, err
-		}
-		ones := remaining.Intersect(row)
-		switch (predicate >> uint(i)) & 1 {
-		case 1:
-			// Discard everything with a zero bit here.
-			remaining = ones
-		case 0:
-			// Match everything with a one bit here.
-			matched = matched.Union(ones)
-			predicate |= 1 << uint(i)
-		}
-	}
-
-	return matched, nil
+	return f.bsiScan(filter, func(k uint64, rem, out, tmp, buf *bsiBlock) error {
+		return f.blockGTUnsigned(tx, k, rem, out, tmp, buf, bitDepth, predicate)
+	})
 }
 
 // notNull returns the exists row.
@@ -1224,8 +1422,8 @@ func (f *fragment) notNull(tx Tx) (*Row, error) {
 }
 
 // rangeBetween returns bitmaps with a bsiGroup value encoding matching any value between predicateMin and predicateMax.
-func (f *fragment) rangeBetween(tx Tx, bitDepth uint64, predicateMin, predicateMax int64) (*Row, error) {
-	b, err := f.row(tx, bsiExistsBit)
+func (f *fragment) rangeBetween(tx Tx, filter *Row, bitDepth uint64, predicateMin, predicateMax int64) (*Row, error) {
+	b, err := f.bsiExists(tx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -1235,7 +1433,7 @@ func (f *fragment) rangeBetween(tx Tx, bitDepth uint64, predicateMin, predicateM
 
 	switch {
 	case predicateMin == predicateMax:
-		return f.rangeEQ(tx, bitDepth, predicateMin)
+		return f.rangeEQ(tx, filter, bitDepth, predicateMin)
 	case predicateMin >= 0:
 		// Handle positive-only values.
 		r, err := f.row(tx, bsiSignBit)
@@ -1283,37 +1481,33 @@ func (f *fragment) rangeBetweenUnsigned(tx Tx, filter *Row, bitDepth uint64, pre
 		return f.rangeLTUnsigned(tx, filter, bitDepth, predicateMax, true)
 	}
 
-	// Compare any upper bits which are equal.
-	diffLen := bits.Len64(predicateMax ^ predicateMin)
-	remaining := filter
-	for i := int(bitDepth - 1); i >= diffLen; i-- {
-		row, err := f.row(tx, uint64(bsiOffsetBit+i))
-		if err != nil {
-			return nil, err
+	// Compare any upper bits which are equal, and then the remaining low
+	// bits against each bound.
+	diffLen := uint64(bits.Len64(predicateMax ^ predicateMin))
+	lowMask := uint64(1)<<diffLen - 1
+	upper := predicateMin &^ lowMask
+	predicateMin &= lowMask
+	predicateMax &= lowMask
+
+	var mid bsiBlock
+	return f.bsiScan(filter, func(k uint64, rem, out, tmp, buf *bsiBlock) error {
+		if ok, err := f.blockNarrowEQ(tx, k, rem, buf, bitDepth, diffLen, upper); err != nil || !ok {
+			return err
 		}
-		switch (predicateMin >> uint(i)) & 1 {
-		case 1:
-			remaining = remaining.Intersect(row)
-		case 0:
-			remaining = remaining.Difference(row)
+		src := rem
+		if predicateMin > 0 {
+			blockZero(&mid)
+			if err := f.blockGTUnsigned(tx, k, rem, &mid, tmp, buf, diffLen, predicateMin-1); err != nil {
+				return err
+			}
+			src = &mid
 		}
-	}
-
-	// Clear the bits we just compared.
-	equalMask := (^uint64(0)) << diffLen
-	predicateMin &^= equalMask
-	predicateMax &^= equalMask
-
-	var err error
-	remaining, err = f.rangeGTUnsigned(tx, remaining, uint64(diffLen), predicateMin, true)
-	if err != nil {
-		return nil, err
-	}
-	remaining, err = f.rangeLTUnsigned(tx, remaining, uint64(diffLen), predicateMax, true)
-	if err != nil {
-		return nil, err
-	}
-	return remaining, nil
+		if predicateMax == lowMask {
+			blockOr(out, src)
+			return nil
+		}
+		return f.blockLTUnsigned(tx, k, src, out, tmp, buf, diffLen, predicateMax+1)
+	})
 }
 
 // pos translates the row ID and column ID into a position in the storage bitmap.
@@ -1460,6 +1654,79 @@ func (f *fragment) top(tx Tx, opt topOptions) ([]Pair, error) {
 	return r, nil
 }
 
+// topExact returns the top rows from the fragment by their exact counts,
+// read from storage rather than the cache. If opt.RowIDs is specified, all
+// of those rows with columns are returned; otherwise, if opt.N is zero,
+// every row with at least opt.MinThreshold columns is. Rows whose counts
+// can't reach the results are skipped without counting their intersection
+// with opt.Src. Pairs are ordered by count, then by row ID.
+func (f *fragment) topExact(ctx context.Context, tx Tx, opt topOptions) ([]Pair, error) {
+	rowIDs := opt.RowIDs
+	if len(rowIDs) > 0 {
+		opt.N = 0
+	} else {
+		ids, err := f.rows(ctx, tx, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting rows")
+		}
+		rowIDs = ids
+	}
+	if opt.MinThreshold == 0 {
+		opt.MinThreshold = 1
+	}
+
+	results := &pairHeap{}
+	for _, rowID := range rowIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// A row's count bounds its count within the source, so rows
+		// which can't beat the lowest result can be pruned before
+		// intersecting them.
+		floor := opt.MinThreshold
+		if opt.N > 0 && results.Len() == opt.N && results.Pairs[0].Count >= floor {
+			floor = results.Pairs[0].Count + 1
+		}
+		count, err := tx.CountRange(f.index(), f.field(), f.view(), f.shard, rowID*ShardWidth, (rowID+1)*ShardWidth)
+		if err != nil {
+			return nil, errors.Wrap(err, "counting row")
+		}
+		if count < floor {
+			continue
+		}
+		if opt.Src != nil {
+			r, err := f.row(tx, rowID)
+			if err != nil {
+				return nil, err
+			}
+			if count = opt.Src.intersectionCount(r); count < floor {
+				continue
+			}
+		}
+
+		heap.Push(results, Pair{ID: rowID, Count: count})
+		if opt.N > 0 && results.Len() > opt.N {
+			heap.Pop(results)
+		}
+	}
+
+	pairs := results.Pairs
+	sortPairsExact(pairs)
+	return pairs, nil
+}
+
+// sortPairsExact sorts pairs by count, highest first, breaking ties by row
+// ID so that exact results are deterministic.
+func sortPairsExact(pairs []Pair) {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Count != pairs[j].Count {
+			return pairs[i].Count > pairs[j].Count
+		}
+		return pairs[i].ID < pairs[j].ID
+	})
+}
+
 func (f *fragment) topBitmapPairs(tx Tx, rowIDs []uint64) ([]bitmapPair, error) {
 	// Don't retrieve from storage if CacheTypeNone.
 	if f.CacheType == CacheTypeNone {
@@ -1475,15 +1742,18 @@ func (f *fragment) topBitmapPairs(tx Tx, rowIDs []uint64) ([]bitmapPair, error)
 
 	// Otherwise retrieve specific rows.
 	pairs := make([]bitmapPair, 0, len(rowIDs))
+	var hits, misses uint64
 	for _, rowID := range rowIDs {
 		// Look up cache first, if available.
 		if n := f.cache.Get(rowID); n > 0 {
+			hits++
 			pairs = append(pairs, bitmapPair{
 				ID:    rowID,
 				Count: n,
 			})
 			continue
 		}
+		misses++
 
 		row, err := f.row(tx, rowID)
 		if err != nil {
@@ -1497,6 +1767,9 @@ func (f *fragment) topBitmapPairs(tx Tx, rowIDs []uint64) ([]bitmapPair, error)
 			})
 		}
 	}
+	if f.fld != nil {
+		f.fld.recordCacheLookups(hits, misses)
+	}
 	sortPairs := bitmapPairs(pairs)
 	sort.Sort(&sortPairs)
 	return pairs, nil
@@ -1520,17 +1793,20 @@ type topOptions struct {
 // Checksum returns a checksum for the entire fragment.
 // If two fragments have the same checksum then they have the same data.
 func (f *fragment) Checksum() ([]byte, error) {
-	h := xxhash.New()
-
 	blocks, err := f.Blocks()
 	if err != nil {
 		return nil, err
 	}
+	return checksumBlocks(blocks), nil
+}
 
+// checksumBlocks returns a checksum of a fragment's blocks.
+func checksumBlocks(blocks []FragmentBlock) []byte {
+	h := xxhash.New()
 	for _, block := range blocks {
 		_, _ = h.Write(block.Checksum)
 	}
-	return h.Sum(nil), nil
+	return h.Sum(nil)
 }
 
 // InvalidateChecksums clears all cached block checksums.
@@ -1544,7 +1820,12 @@ func (f *fragment) InvalidateChecksums() {
 func (f *fragment) Blocks() ([]FragmentBlock, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	return f.blocks()
+}
 
+// blocks returns info for all blocks containing data. The caller must hold
+// f.mu for writing, since block checksums are cached.
+func (f *fragment) blocks() ([]FragmentBlock, error) {
 	var a []FragmentBlock
 
 	idx := f.holder.Index(f.index())
@@ -1994,6 +2275,7 @@ func (p parallelSlices) Swap(i, j int) {
 // snapshot of the fragment or just do in-memory updates while appending
 // operations to the op log.
 func (f *fragment) importPositions(tx Tx, set, clear []uint64, rowSet map[uint64]struct{}) error {
+	f.dataChanged()
 	if len(set) > 0 {
 		f.stats.Count(MetricImportingN, int64(len(set)), 1)
 
@@ -2045,6 +2327,21 @@ func (f *fragment) updateCaching(tx Tx, rowSet map[uint64]struct{}) error {
 	return nil
 }
 
+// rowsCleared brings what's kept about rows up to date after bits are
+// cleared from them by clearing rows or records. Unlike other writes, which
+// leave the cache's rankings to be recalculated when they're next
+// maintained or read, clears recalculate them now, so that TopN never
+// returns rows which have been cleared.
+func (f *fragment) rowsCleared(tx Tx, rowSet map[uint64]struct{}) error {
+	if err := f.updateCaching(tx, rowSet); err != nil {
+		return err
+	}
+	if f.CacheType != CacheTypeNone && len(rowSet) > 0 {
+		f.cache.Recalculate()
+	}
+	return nil
+}
+
 // sliceDifference removes everything from original that's found in remove,
 // updating the slice in place, and returns the compacted slice. The input
 // sets should be sorted.
@@ -2081,6 +2378,7 @@ func sliceDifference(original, remove []uint64) []uint64 {
 func (f *fragment) bulkImportMutex(tx Tx, rowIDs, columnIDs []uint64, options *ImportOptions) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	f.dataChanged()
 
 	// if ingest promises that this is "fully sorted", then we have been
 	// promised that (1) there's no duplicate entries that need to be
@@ -2212,14 +2510,28 @@ func (f *fragment) ClearRecords(tx Tx, recordIDs []uint64) (bool, error) {
 }
 
 func (f *fragment) clearRecordsByBitmap(tx Tx, columns *roaring.Bitmap) (changed bool, err error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	return f.unprotectedClearRecordsByBitmap(tx, columns)
+	// A distinct view's bits are values, not columns; clearing records
+	// updates it through the field's BSI view.
+	if isDistinctView(f.view()) {
+		return false, nil
+	}
+	var cols []uint64
+	if f.tracksDistinct() {
+		cols = columns.Slice()
+	}
+	err = f.trackDistinct(tx, cols, func() (err error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		changed, err = f.unprotectedClearRecordsByBitmap(tx, columns)
+		return err
+	})
+	return changed, err
 }
 
 // clearRecordsByBitmap clears bits in a fragment that correspond to those
 // positions within the bitmap.
 func (f *fragment) unprotectedClearRecordsByBitmap(tx Tx, columns *roaring.Bitmap) (changed bool, err error) {
+	f.dataChanged()
 	rowSet := make(map[uint64]struct{})
 	rewriteExisting := roaring.NewBitmapBitmapTrimmer(columns, func(key roaring.FilterKey, data *roaring.Container, filter *roaring.Container, writeback roaring.ContainerWriteback) error {
 		if filter.N() == 0 {
@@ -2244,11 +2556,18 @@ func (f *fragment) unprotectedClearRecordsByBitmap(tx Tx, columns *roaring.Bitma
 	if err != nil {
 		return false, err
 	}
-	return changed, f.updateCaching(tx, rowSet)
+	return changed, f.rowsCleared(tx, rowSet)
 }
 
 // importValue bulk imports a set of range-encoded values.
 func (f *fragment) importValue(tx Tx, columnIDs []uint64, values []int64, bitDepth uint64, clear bool) error {
+	return f.trackDistinct(tx, columnIDs, func() error {
+		return f.doImportValue(tx, columnIDs, values, bitDepth, clear)
+	})
+}
+
+// doImportValue does the work of importValue.
+func (f *fragment) doImportValue(tx Tx, columnIDs []uint64, values []int64, bitDepth uint64, clear bool) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -2343,7 +2662,12 @@ func (f *fragment) importRoaring(ctx context.Context, tx Tx, data []byte, clear
 	span, ctx := tracing.StartSpanFromContext(ctx, "fragment.importRoaring")
 	defer span.Finish()
 
-	rowSet, updateCache, err := f.doImportRoaring(ctx, tx, data, clear)
+	var rowSet map[uint64]int
+	var updateCache bool
+	err := f.trackDistinct(tx, nil, func() (err error) {
+		rowSet, updateCache, err = f.doImportRoaring(ctx, tx, data, clear)
+		return err
+	})
 	if err != nil {
 		return errors.Wrap(err, "doImportRoaring")
 	}
@@ -2355,6 +2679,7 @@ func (f *fragment) importRoaring(ctx context.Context, tx Tx, data []byte, clear
 
 // ImportRoaringClearAndSet simply clears the bits in clear and sets the bits in set.
 func (f *fragment) ImportRoaringClearAndSet(ctx context.Context, tx Tx, clear, set []byte) error {
+	f.dataChanged()
 	clearIter, err := roaring.NewContainerIterator(clear)
 	if err != nil {
 		return errors.Wrap(err, "getting clear iterator")
@@ -2369,7 +2694,9 @@ func (f *fragment) ImportRoaringClearAndSet(ctx context.Context, tx Tx, clear, s
 		return errors.Wrap(err, "getting rewriter")
 	}
 
-	err = tx.ApplyRewriter(f.index(), f.field(), f.view(), f.shard, 0, rewriter)
+	err = f.trackDistinct(tx, nil, func() error {
+		return tx.ApplyRewriter(f.index(), f.field(), f.view(), f.shard, 0, rewriter)
+	})
 	if err != nil {
 		errors.Wrap(err, "applying rewriter")
 	}
@@ -2389,6 +2716,7 @@ func (f *fragment) ImportRoaringClearAndSet(ctx context.Context, tx Tx, clear, s
 // records to be cleared, and "set" as specifying the values to be set
 // which implies clearing any other values in those columns.
 func (f *fragment) ImportRoaringBSI(ctx context.Context, tx Tx, clear, set []byte) error {
+	f.dataChanged()
 	// In this first block, we take the first row of clear as records
 	// we want to unconditionally clear, and the first row of set as
 	// records we also want to clear because they're going to get set
@@ -2423,6 +2751,7 @@ func (f *fragment) ImportRoaringBSI(ctx context.Context, tx Tx, clear, set []byt
 // "set" as the existence row to also be cleared. Essentially it's for
 // FieldTypeMutex.
 func (f *fragment) ImportRoaringSingleValued(ctx context.Context, tx Tx, clear, set []byte) error {
+	f.dataChanged()
 	clearIter, err := roaring.NewRepeatedRowIteratorFromBytes(clear)
 	if err != nil {
 		return errors.Wrap(err, "getting cleariterator")
@@ -2444,6 +2773,7 @@ func (f *fragment) ImportRoaringSingleValued(ctx context.Context, tx Tx, clear,
 func (f *fragment) doImportRoaring(ctx context.Context, tx Tx, data []byte, clear bool) (map[uint64]int, bool, error) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
+	f.dataChanged()
 	rowSize := uint64(1 << shardVsContainerExponent)
 	span, _ := tracing.StartSpanFromContext(ctx, "importRoaring.ImportRoaringBits")
 	defer span.Finish()
@@ -2514,6 +2844,29 @@ func (f *fragment) RecalculateCache() {
 	f.mu.Unlock()
 }
 
+// maintainCache sets how far behind the fragment's cache may let its
+// rankings fall, and brings them up to date if it's maintained in the
+// background.
+func (f *fragment) maintainCache(staleness time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cache == nil {
+		return
+	}
+	f.cache.SetStaleness(staleness)
+	if staleness > 0 {
+		f.cache.Maintain()
+	}
+}
+
+// cacheStale reports whether the fragment's cache has changes not yet
+// reflected in its rankings.
+func (f *fragment) cacheStale() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.cache != nil && f.cache.Stale()
+}
+
 // FlushCache writes the cache data to disk.
 func (f *fragment) FlushCache() error {
 	f.mu.Lock()
@@ -2707,6 +3060,7 @@ func (f *fragment) ReadFrom(r io.Reader) (n int64, err error) {
 // below for RoaringTx, but also work on any Tx because it uses
 // tx.ImportRoaringBits().
 func (f *fragment) fillFragmentFromArchive(tx Tx, r io.Reader) error {
+	f.dataChanged()
 
 	// this is reading from inside a tarball, so definitely no need
 	// to close it here.
@@ -3599,6 +3953,11 @@ func (r *RowKV) Compare(o RowKV, desc bool) (bool, bool) {
 			return desc != (val < oVal), true
 		}
 		return desc, false
+	case uint64:
+		if oVal, ok := o.Value.(uint64); ok {
+			return desc != (val < oVal), true
+		}
+		return desc, false
 	default:
 		return desc, false
 	}
@@ -3622,18 +3981,17 @@ func (f *fragment) sortBsiData(tx Tx, filter *Row, bitDepth uint64, sort_desc bo
 
 	var sortedRowIds []RowKV
 	f.flattenRowValues(tx, &sortedRowIds, neg, bitDepth, -1)
-	ok := true
 	f.flattenRowValues(tx, &sortedRowIds, pos, bitDepth, 1)
+	var sortErr error
 	sort.SliceStable(sortedRowIds, func(i, j int) bool {
-		if c, k := sortedRowIds[i].Compare(sortedRowIds[j], sort_desc); k {
-			return c
-		} else {
-			ok = false
-			return !k
+		less, err := lessRowKV(sortedRowIds[i], sortedRowIds[j], []bool{sort_desc})
+		if err != nil {
+			sortErr = err
 		}
+		return less
 	})
-	if !ok {
-		return nil, errors.New("Couldn't compare field type for sorting")
+	if sortErr != nil {
+		return nil, errors.Wrap(sortErr, "Couldn't compare field type for sorting")
 	}
 
 	return &SortedRow{
@@ -3643,7 +4001,11 @@ func (f *fragment) sortBsiData(tx Tx, filter *Row, bitDepth uint64, sort_desc bo
 }
 
 func (f *fragment) flattenRowValues(tx Tx, sortedRowIds *[]RowKV, filter *Row, bitDepth uint64, sign int64) error {
+	// Columns with no bits set have a value of zero.
 	m := make(map[uint64]int64)
+	for _, v := range filter.Columns() {
+		m[v] = 0
+	}
 	for i := int(bitDepth - 1); i >= 0; i-- {
 		row, err := f.row(tx, uint64(bsiOffsetBit+i))
 		if err != nil {
@@ -3652,11 +4014,7 @@ func (f *fragment) flattenRowValues(tx Tx, sortedRowIds *[]RowKV, filter *Row, b
 		row = row.Intersect(filter)
 
 		for _, v := range row.Columns() {
-			if val, ok := m[v]; ok {
-				m[v] = val | (1 << i)
-			} else {
-				m[v] = (1 << i)
-			}
+			m[v] |= 1 << i
 		}
 
 	}
@@ -17,6 +17,7 @@ import (
 	"sync"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/featurebasedb/featurebase/v3/pql"
@@ -74,6 +75,49 @@ func TestFragment_SetBit(t *testing.T) {
 	}
 }
 
+// Ensure reads of a row seeing the same data share it, without sharing
+// changes to it, and that reads after a write see the write.
+func TestFragment_CoalescedRow(t *testing.T) {
+	f, idx, tx := mustOpenFragment(t)
+	defer f.Clean(t)
+	f.holder.cfg.ReadCoalesceWindow = time.Minute
+
+	if _, err := f.setBit(tx, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	PanicOn(tx.Commit())
+
+	read := func() *Row {
+		rtx := f.holder.txf.NewTx(Txo{Write: !writable, Index: idx, Fragment: f, Shard: 0})
+		defer rtx.Rollback()
+		return f.mustRow(rtx, 1)
+	}
+	first := read()
+	first.SetBit(3)
+	if cols := read().Columns(); !reflect.DeepEqual(cols, []uint64{1}) {
+		t.Fatalf("expected shared row to be unchanged, got %v", cols)
+	} else if n := len(f.reads.reads); n != 1 {
+		t.Fatalf("expected one shared read, got %d", n)
+	}
+
+	wtx := f.holder.txf.NewTx(Txo{Write: writable, Index: idx, Fragment: f, Shard: 0})
+	defer wtx.Rollback()
+	if _, err := f.setBit(wtx, 1, 2); err != nil {
+		t.Fatal(err)
+	} else if cols := f.mustRow(wtx, 1).Columns(); !reflect.DeepEqual(cols, []uint64{1, 2}) {
+		t.Fatalf("expected writable tx to see its write, got %v", cols)
+	}
+	PanicOn(wtx.Commit())
+	if cols := read().Columns(); !reflect.DeepEqual(cols, []uint64{1, 2}) {
+		t.Fatalf("expected read after commit to see the write, got %v", cols)
+	} else if n := len(f.reads.reads); n != 2 {
+		t.Fatalf("expected two shared reads, got %d", n)
+	}
+}
+
+// newTestFragment makes the default /i/f/v/0 fragment, and returns the
+// things. the test holder will be deleted automatically in test cleanup.
+
 // Ensure a fragment can clear a set bit.
 func TestFragment_ClearBit(t *testing.T) {
 	f, idx, tx := mustOpenFragment(t)
@@ -481,7 +525,7 @@ func TestFragment_Sum(t *testing.T) {
 			t.Fatal(err)
 		} else if n != 5 {
 			t.Fatalf("unexpected count: %d", n)
-		} else if got, exp := sum, int64(382+300-600+2818+300); got != exp {
+		} else if got, exp := sum.Int64(), int64(382+300-600+2818+300); got != exp {
 			t.Fatalf("unexpected sum: got: %d, exp: %d", sum, exp)
 		}
 	})
@@ -491,7 +535,7 @@ func TestFragment_Sum(t *testing.T) {
 			t.Fatal(err)
 		} else if n != 2 {
 			t.Fatalf("unexpected count: %d", n)
-		} else if got, exp := sum, int64(300+300); got != exp {
+		} else if got, exp := sum.Int64(), int64(300+300); got != exp {
 			t.Fatalf("unexpected sum: got: %d, exp: %d", sum, exp)
 		}
 	})
@@ -514,7 +558,7 @@ func TestFragment_Sum(t *testing.T) {
 			t.Fatal(err)
 		} else if n != 4 {
 			t.Fatalf("unexpected count: %d", n)
-		} else if got, exp := sum, int64(3800-382-600); got != exp {
+		} else if got, exp := sum.Int64(), int64(3800-382-600); got != exp {
 			t.Fatalf("unexpected sum: got: %d, exp: %d", sum, exp)
 		}
 	})
@@ -623,7 +667,7 @@ func TestFragment_Range(t *testing.T) {
 		}
 
 		// Query for equality.
-		if b, err := f.rangeOp(tx, pql.EQ, bitDepth, 300); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.EQ, bitDepth, 300); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{2000, 4000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
@@ -643,12 +687,12 @@ func TestFragment_Range(t *testing.T) {
 		}
 
 		// Query for equality.
-		if b, err := f.rangeOp(tx, pql.EQ, 1, 3); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.EQ, 1, 3); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
 		}
-		if b, err := f.rangeOp(tx, pql.EQ, 1, 4); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.EQ, 1, 4); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
@@ -672,7 +716,7 @@ func TestFragment_Range(t *testing.T) {
 		}
 
 		// Query for inequality.
-		if b, err := f.rangeOp(tx, pql.NEQ, bitDepth, 300); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.NEQ, bitDepth, 300); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{1000, 3000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
@@ -700,28 +744,28 @@ func TestFragment_Range(t *testing.T) {
 		}
 
 		// Query for values less than (ending with set column).
-		if b, err := f.rangeOp(tx, pql.LT, bitDepth, 301); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.LT, bitDepth, 301); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{2000, 5000, 6000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
 		}
 
 		// Query for values less than (ending with unset column).
-		if b, err := f.rangeOp(tx, pql.LT, bitDepth, 300); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.LT, bitDepth, 300); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{5000, 6000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
 		}
 
 		// Query for values less than or equal to (ending with set column).
-		if b, err := f.rangeOp(tx, pql.LTE, bitDepth, 301); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.LTE, bitDepth, 301); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{2000, 4000, 5000, 6000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
 		}
 
 		// Query for values less than or equal to (ending with unset column).
-		if b, err := f.rangeOp(tx, pql.LTE, bitDepth, 300); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.LTE, bitDepth, 300); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{2000, 5000, 6000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
@@ -737,7 +781,7 @@ func TestFragment_Range(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if b, err := f.rangeOp(tx, pql.LT, 1, 2); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.LT, 1, 2); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{1}) {
 			t.Fatalf("unepxected coulmns: %+v", b.Columns())
@@ -783,28 +827,28 @@ func TestFragment_Range(t *testing.T) {
 		}
 
 		// Query for values greater than (ending with unset bit).
-		if b, err := f.rangeOp(tx, pql.GT, bitDepth, 300); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.GT, bitDepth, 300); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{1000, 3000, 4000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
 		}
 
 		// Query for values greater than (ending with set bit).
-		if b, err := f.rangeOp(tx, pql.GT, bitDepth, 301); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.GT, bitDepth, 301); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{1000, 3000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
 		}
 
 		// Query for values greater than or equal to (ending with unset bit).
-		if b, err := f.rangeOp(tx, pql.GTE, bitDepth, 300); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.GTE, bitDepth, 300); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{1000, 2000, 3000, 4000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
 		}
 
 		// Query for values greater than or equal to (ending with set bit).
-		if b, err := f.rangeOp(tx, pql.GTE, bitDepth, 301); err != nil {
+		if b, err := f.rangeOp(tx, nil, pql.GTE, bitDepth, 301); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{1000, 3000, 4000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
@@ -868,28 +912,28 @@ func TestFragment_Range(t *testing.T) {
 		}
 
 		// Query for values greater than (ending with unset column).
-		if b, err := f.rangeBetween(tx, bitDepth, 300, 2817); err != nil {
+		if b, err := f.rangeBetween(tx, nil, bitDepth, 300, 2817); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{1000, 2000, 3000, 4000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
 		}
 
 		// Query for values greater than (ending with set column).
-		if b, err := f.rangeBetween(tx, bitDepth, 301, 2817); err != nil {
+		if b, err := f.rangeBetween(tx, nil, bitDepth, 301, 2817); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{1000, 3000, 4000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
 		}
 
 		// Query for values greater than or equal to (ending with unset column).
-		if b, err := f.rangeBetween(tx, bitDepth, 301, 2816); err != nil {
+		if b, err := f.rangeBetween(tx, nil, bitDepth, 301, 2816); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{1000, 4000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
 		}
 
 		// Query for values greater than or equal to (ending with set column).
-		if b, err := f.rangeBetween(tx, bitDepth, 300, 2816); err != nil {
+		if b, err := f.rangeBetween(tx, nil, bitDepth, 300, 2816); err != nil {
 			t.Fatal(err)
 		} else if !reflect.DeepEqual(b.Columns(), []uint64{1000, 2000, 4000}) {
 			t.Fatalf("unexpected columns: %+v", b.Columns())
@@ -3108,8 +3152,6 @@ func newTestView(tb testing.TB, fieldOpts ...FieldOption) (*Holder, *Index, *Fie
 	return h, idx, fld, v
 }
 
-// newTestFragment makes the default /i/f/v/0 fragment, and returns the
-// things. the test holder will be deleted automatically in test cleanup.
 func newTestFragment(tb testing.TB, fieldOpts ...FieldOption) (*Holder, *Index, *Field, *view, *fragment) {
 	h, idx, fld, v := newTestView(tb, fieldOpts...)
 	f := v.newFragment(0)
@@ -3903,7 +3945,7 @@ func TestIntLTRegression(t *testing.T) {
 		t.Fatalf("setting value: %v", err)
 	}
 
-	row, err := f.rangeOp(tx, pql.LT, 6, 33)
+	row, err := f.rangeOp(tx, nil, pql.LT, 6, 33)
 	if err != nil {
 		t.Fatalf("doing range of: %v", err)
 	}
@@ -3958,7 +4000,7 @@ func TestFragmentBSIUnsigned(t *testing.T) {
 		defer tx.Rollback()
 
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeLT(tx, k, int64(i), false)
+			row, err := f.rangeLT(tx, nil, k, int64(i), false)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -3982,7 +4024,7 @@ func TestFragmentBSIUnsigned(t *testing.T) {
 		defer tx.Rollback()
 
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeLT(tx, k, int64(i), true)
+			row, err := f.rangeLT(tx, nil, k, int64(i), true)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4006,7 +4048,7 @@ func TestFragmentBSIUnsigned(t *testing.T) {
 		defer tx.Rollback()
 
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeGT(tx, k, int64(i), false)
+			row, err := f.rangeGT(tx, nil, k, int64(i), false)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4029,7 +4071,7 @@ func TestFragmentBSIUnsigned(t *testing.T) {
 		defer tx.Rollback()
 
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeGT(tx, k, int64(i), true)
+			row, err := f.rangeGT(tx, nil, k, int64(i), true)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4054,7 +4096,7 @@ func TestFragmentBSIUnsigned(t *testing.T) {
 
 		for i := minCheck; i < maxCheck; i++ {
 			for j := i; j < maxCheck; j++ {
-				row, err := f.rangeBetween(tx, k, int64(i), int64(j))
+				row, err := f.rangeBetween(tx, nil, k, int64(i), int64(j))
 				if err != nil {
 					t.Fatalf("failed to query fragment: %v", err)
 				}
@@ -4089,7 +4131,7 @@ func TestFragmentBSIUnsigned(t *testing.T) {
 		defer tx.Rollback()
 
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeEQ(tx, k, int64(i))
+			row, err := f.rangeEQ(tx, nil, k, int64(i))
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4140,7 +4182,7 @@ func TestFragmentBSIUnsigned_WithTxCommit(t *testing.T) {
 
 	t.Run("<", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeLT(tx, k, int64(i), false)
+			row, err := f.rangeLT(tx, nil, k, int64(i), false)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4160,7 +4202,7 @@ func TestFragmentBSIUnsigned_WithTxCommit(t *testing.T) {
 	})
 	t.Run("<=", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeLT(tx, k, int64(i), true)
+			row, err := f.rangeLT(tx, nil, k, int64(i), true)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4180,7 +4222,7 @@ func TestFragmentBSIUnsigned_WithTxCommit(t *testing.T) {
 	})
 	t.Run(">", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeGT(tx, k, int64(i), false)
+			row, err := f.rangeGT(tx, nil, k, int64(i), false)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4200,7 +4242,7 @@ func TestFragmentBSIUnsigned_WithTxCommit(t *testing.T) {
 	})
 	t.Run(">=", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeGT(tx, k, int64(i), true)
+			row, err := f.rangeGT(tx, nil, k, int64(i), true)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4221,7 +4263,7 @@ func TestFragmentBSIUnsigned_WithTxCommit(t *testing.T) {
 	t.Run("Range", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
 			for j := i; j < maxCheck; j++ {
-				row, err := f.rangeBetween(tx, k, int64(i), int64(j))
+				row, err := f.rangeBetween(tx, nil, k, int64(i), int64(j))
 				if err != nil {
 					t.Fatalf("failed to query fragment: %v", err)
 				}
@@ -4252,7 +4294,7 @@ func TestFragmentBSIUnsigned_WithTxCommit(t *testing.T) {
 	})
 	t.Run("==", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeEQ(tx, k, int64(i))
+			row, err := f.rangeEQ(tx, nil, k, int64(i))
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4303,7 +4345,7 @@ func TestFragmentBSISigned(t *testing.T) {
 
 	t.Run("<", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeLT(tx, k, int64(i), false)
+			row, err := f.rangeLT(tx, nil, k, int64(i), false)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4323,7 +4365,7 @@ func TestFragmentBSISigned(t *testing.T) {
 	})
 	t.Run("<=", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeLT(tx, k, int64(i), true)
+			row, err := f.rangeLT(tx, nil, k, int64(i), true)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4343,7 +4385,7 @@ func TestFragmentBSISigned(t *testing.T) {
 	})
 	t.Run(">", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeGT(tx, k, int64(i), false)
+			row, err := f.rangeGT(tx, nil, k, int64(i), false)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4363,7 +4405,7 @@ func TestFragmentBSISigned(t *testing.T) {
 	})
 	t.Run(">=", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeGT(tx, k, int64(i), true)
+			row, err := f.rangeGT(tx, nil, k, int64(i), true)
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4384,7 +4426,7 @@ func TestFragmentBSISigned(t *testing.T) {
 	t.Run("Range", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
 			for j := i; j < maxCheck; j++ {
-				row, err := f.rangeBetween(tx, k, int64(i), int64(j))
+				row, err := f.rangeBetween(tx, nil, k, int64(i), int64(j))
 				if err != nil {
 					t.Fatalf("failed to query fragment: %v", err)
 				}
@@ -4415,7 +4457,7 @@ func TestFragmentBSISigned(t *testing.T) {
 	})
 	t.Run("==", func(t *testing.T) {
 		for i := minCheck; i < maxCheck; i++ {
-			row, err := f.rangeEQ(tx, k, int64(i))
+			row, err := f.rangeEQ(tx, nil, k, int64(i))
 			if err != nil {
 				t.Fatalf("failed to query fragment: %v", err)
 			}
@@ -4539,7 +4581,7 @@ func TestImportValueRowCache(t *testing.T) {
 				t.Fatalf("importing values: %v", err)
 			}
 
-			if r, err := f.rangeOp(tx, pql.GT, test.tc1.depth, 0); err != nil {
+			if r, err := f.rangeOp(tx, nil, pql.GT, test.tc1.depth, 0); err != nil {
 				t.Error("getting range of values")
 			} else if !reflect.DeepEqual(r.Columns(), test.tc1.checkCols) {
 				t.Errorf("wrong column values. expected: %v, but got: %v", test.tc1.checkCols, r.Columns())
@@ -4550,7 +4592,7 @@ func TestImportValueRowCache(t *testing.T) {
 				t.Fatalf("importing values: %v", err)
 			}
 
-			if r, err := f.rangeOp(tx, pql.GT, test.tc2.depth, 0); err != nil {
+			if r, err := f.rangeOp(tx, nil, pql.GT, test.tc2.depth, 0); err != nil {
 				t.Error("getting range of values")
 			} else if !reflect.DeepEqual(r.Columns(), test.tc2.checkCols) {
 				t.Errorf("wrong column values. expected: %v, but got: %v", test.tc2.checkCols, r.Columns())
@@ -0,0 +1,136 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"sync"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/roaring"
+)
+
+// Queries running at once, such as those of a dashboard being refreshed,
+// often read the same rows of the same fragments. A fragment coalesces
+// their reads: a read of a row which another query is already reading, in a
+// read-only transaction seeing the same data, waits for that read and shares
+// its containers, which are frozen so each reader copies them before
+// changing them. With the holder's ReadCoalesceWindow set, rows are also
+// shared with reads starting that long after they're read, as long as no
+// writes have been committed to the shard in between. Writable transactions
+// always read for themselves, since they see their own writes.
+
+// snapshotTx is implemented by transactions which can tell which committed
+// data they see.
+type snapshotTx interface {
+	snapshot() (int64, bool)
+}
+
+// readCoalescer holds the rows of a fragment being read, or read within the
+// holder's ReadCoalesceWindow.
+type readCoalescer struct {
+	mu    sync.Mutex
+	reads map[coalescedReadKey]*coalescedRead
+
+	// swept is when reads past their window were last removed.
+	swept time.Time
+}
+
+type coalescedReadKey struct {
+	rowID    uint64
+	snapshot int64
+}
+
+// coalescedRead is a row read from a fragment, which is ready once the
+// reader which started it is done.
+type coalescedRead struct {
+	ready chan struct{}
+	data  *roaring.Bitmap
+	err   error
+
+	// expires is when the read stops being shared, once it's ready.
+	expires time.Time
+}
+
+// coalescedRow returns a row of f as tx sees it, sharing the read of the
+// same row by other transactions seeing the same data, if tx can tell
+// which data it sees.
+func (f *fragment) coalescedRow(tx Tx, rowID uint64) (*Row, error) {
+	stx, ok := tx.(snapshotTx)
+	if !ok {
+		return f.rowFromStorage(tx, rowID)
+	}
+	snapshot, ok := stx.snapshot()
+	if !ok {
+		return f.rowFromStorage(tx, rowID)
+	}
+	var window time.Duration
+	if f.holder != nil && f.holder.cfg != nil {
+		window = f.holder.cfg.ReadCoalesceWindow
+	}
+	if window < 0 {
+		return f.rowFromStorage(tx, rowID)
+	}
+
+	c := &f.reads
+	key := coalescedReadKey{rowID: rowID, snapshot: snapshot}
+	now := time.Now()
+	c.mu.Lock()
+	read, ok := c.reads[key]
+	if ok && (read.expires.IsZero() || now.Before(read.expires)) {
+		c.mu.Unlock()
+		f.stats.Count(MetricCoalescedRead, 1, 1.0)
+		<-read.ready
+		return read.row(f.shard)
+	}
+	if c.reads == nil {
+		c.reads = make(map[coalescedReadKey]*coalescedRead)
+	}
+	if window > 0 && now.Sub(c.swept) > window {
+		c.sweep(now)
+	}
+	read = &coalescedRead{ready: make(chan struct{})}
+	c.reads[key] = read
+	c.mu.Unlock()
+
+	row, err := f.rowFromStorage(tx, rowID)
+	if err == nil {
+		read.data = row.segments[0].data
+	}
+	read.err = err
+
+	c.mu.Lock()
+	if window == 0 || err != nil {
+		delete(c.reads, key)
+	} else {
+		read.expires = time.Now().Add(window)
+	}
+	c.mu.Unlock()
+	close(read.ready)
+	return read.row(f.shard)
+}
+
+// sweep removes the reads whose window has passed. c.mu must be held.
+func (c *readCoalescer) sweep(now time.Time) {
+	for key, read := range c.reads {
+		if !read.expires.IsZero() && !now.Before(read.expires) {
+			delete(c.reads, key)
+		}
+	}
+	c.swept = now
+}
+
+// row returns a row of shard holding a frozen copy of the data read.
+func (read *coalescedRead) row(shard uint64) (*Row, error) {
+	if read.err != nil {
+		return nil, read.err
+	}
+	row := &Row{
+		segments: []rowSegment{{
+			data:     read.data.Freeze(),
+			shard:    shard,
+			writable: true,
+		}},
+	}
+	row.invalidateCount()
+	return row, nil
+}
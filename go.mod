@@ -81,6 +81,7 @@ require (
 	github.com/google/uuid v1.3.0
 	github.com/jaffee/commandeer v0.5.0
 	github.com/linkedin/goavro/v2 v2.11.1
+	github.com/tetratelabs/wazero v1.0.0
 	google.golang.org/grpc v1.46.0
 )
 
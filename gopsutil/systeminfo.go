@@ -242,6 +242,15 @@ func (s *systemInfo) DiskCapacity(path string) (uint64, error) {
 	return diskInfo.Total, nil
 }
 
+// DiskFree returns the free space on the disk.
+func (s *systemInfo) DiskFree(path string) (uint64, error) {
+	diskInfo, err := disk.Usage(path)
+	if err != nil {
+		return 0, err
+	}
+	return diskInfo.Free, nil
+}
+
 // NewSystemInfo is a constructor for the gopsutil implementation of SystemInfo.
 func NewSystemInfo() *systemInfo {
 	return &systemInfo{}
@@ -0,0 +1,95 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"sort"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// GroupBy can group an int field by ranges of values rather than by each
+// distinct value: a Rows call on an int field with buckets, as in
+// GroupBy(Rows(f, buckets=[0, 10, 100, 1000])), groups by the ranges
+// between consecutive bounds, here 0 to 10, 10 to 100 and 100 to 1000,
+// each including its start but not its end. Values outside all the ranges
+// aren't in any group. A group's FieldRow has the start of its range as its
+// Value and the end as its ValueTo. The ranges' rows are found from the
+// field's BSI range masks, a shard at a time.
+
+// groupByBuckets returns the bounds of the buckets a GroupBy's Rows call
+// groups its int field by, or nil if it doesn't have any.
+func groupByBuckets(call *pql.Call) ([]int64, error) {
+	buckets, ok, err := call.IntSliceArg("buckets")
+	if err != nil {
+		return nil, errors.Wrap(err, "getting buckets")
+	} else if !ok {
+		return nil, nil
+	}
+	if len(buckets) < 2 {
+		return nil, errors.Errorf("buckets needs at least two bounds, got %d", len(buckets))
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			return nil, errors.Errorf("buckets must be in increasing order, but %d follows %d", buckets[i], buckets[i-1])
+		}
+	}
+	return buckets, nil
+}
+
+// bucketRowIterator iterates over the rows of the buckets of an int field's
+// fragment which have values in them, returning the index of each bucket as
+// its row ID and the start of its range as its value.
+type bucketRowIterator struct {
+	rows   []*Row
+	ids    []uint64 // in increasing order
+	values []int64
+	cur    int
+	wrap   bool
+}
+
+// newBucketRowIterator returns an iterator over the rows of the ranges of
+// values between buckets' bounds in frag, a fragment of bsig's view.
+func newBucketRowIterator(frag *fragment, tx Tx, bsig *bsiGroup, buckets []int64, wrap bool) (*bucketRowIterator, error) {
+	it := &bucketRowIterator{wrap: wrap}
+	for i := 0; i < len(buckets)-1; i++ {
+		lo, hi, outOfRange := bsig.baseValueBetween(buckets[i], buckets[i+1]-1)
+		if outOfRange {
+			continue
+		}
+		row, err := frag.rangeBetween(tx, nil, bsig.BitDepth, lo, hi)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting bucket %d", i)
+		}
+		if !row.Any() {
+			continue
+		}
+		it.rows = append(it.rows, row)
+		it.ids = append(it.ids, uint64(i))
+		it.values = append(it.values, buckets[i])
+	}
+	return it, nil
+}
+
+// Seek moves the iterator to the first bucket at or after the one with
+// index rowID.
+func (it *bucketRowIterator) Seek(rowID uint64) {
+	it.cur = sort.Search(len(it.ids), func(i int) bool {
+		return it.ids[i] >= rowID
+	})
+}
+
+func (it *bucketRowIterator) Next() (r *Row, rowID uint64, value *int64, wrapped bool, err error) {
+	if it.cur >= len(it.rows) {
+		if !it.wrap || len(it.rows) == 0 {
+			return nil, 0, nil, true, nil
+		}
+		it.Seek(0)
+		wrapped = true
+	}
+	v := it.values[it.cur]
+	r, rowID = it.rows[it.cur], it.ids[it.cur]
+	it.cur++
+	return r, rowID, &v, wrapped, nil
+}
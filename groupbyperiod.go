@@ -0,0 +1,180 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/roaring"
+	"github.com/pkg/errors"
+)
+
+// GroupBy can group by time as well as by row: a Rows call on a time field
+// with a period, as in GroupBy(Rows(f, period="D")), groups by each pair of
+// a row and a day in which it has bits, instead of by row. The field's time
+// quantum must include the period's unit, Y, M, D or H. The periods are read
+// from the field's views of that unit, a shard at a time, so a client
+// needn't issue a query per period. from and to limit the periods to those
+// starting in that range.
+
+// Layouts of the periods of each time unit, as returned in FieldRow.Period.
+var periodLayouts = map[rune]string{
+	'Y': "2006",
+	'M': "2006-01",
+	'D': "2006-01-02",
+	'H': "2006-01-02T15:00",
+}
+
+// periodUnit returns the time unit of a period argument.
+func periodUnit(period string) (rune, error) {
+	if len(period) == 1 {
+		if _, ok := periodLayouts[rune(period[0])]; ok {
+			return rune(period[0]), nil
+		}
+	}
+	return 0, errors.Errorf("invalid period %q, expected Y, M, D or H", period)
+}
+
+// periodViews returns the names of the field's views of a time unit for
+// periods starting from from until to, either of which may be zero, in
+// order.
+func (f *Field) periodViews(unit rune, from, to time.Time) ([]string, error) {
+	if !strings.ContainsRune(string(f.TimeQuantum()), unit) {
+		return nil, errors.Errorf("field %s has no views for period %c, its time quantum is %q", f.name, unit, f.TimeQuantum())
+	}
+	var names []string
+	for _, v := range f.views() {
+		if !strings.HasPrefix(v.name, viewStandard+"_") || len(viewTimePart(v.name)) != lengthsByQuantum[unit] {
+			continue
+		}
+		t, err := timeOfView(v.name, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting time of view %s", v.name)
+		}
+		if (!from.IsZero() && t.Before(from)) || (!to.IsZero() && !t.Before(to)) {
+			continue
+		}
+		names = append(names, v.name)
+	}
+	// For a single unit, views sort in time order.
+	sort.Strings(names)
+	return names, nil
+}
+
+// viewPeriod returns the period of a time view, formatted for its unit.
+func viewPeriod(view string) string {
+	t, err := timeOfView(view, false)
+	if err != nil {
+		return ""
+	}
+	for _, unit := range "YMDH" {
+		if lengthsByQuantum[unit] == len(viewTimePart(view)) {
+			return t.Format(periodLayouts[unit])
+		}
+	}
+	return ""
+}
+
+// periodRange returns the start and end of a period as returned in
+// FieldRow.Period.
+func periodRange(period string) (from, to time.Time, err error) {
+	for unit, layout := range periodLayouts {
+		if len(layout) != len(period) {
+			continue
+		}
+		if from, err = time.Parse(layout, period); err != nil {
+			return from, to, errors.Wrapf(err, "parsing period %q", period)
+		}
+		switch unit {
+		case 'Y':
+			to = from.AddDate(1, 0, 0)
+		case 'M':
+			to = addMonth(from)
+		case 'D':
+			to = from.AddDate(0, 0, 1)
+		case 'H':
+			to = from.Add(time.Hour)
+		}
+		return from, to, nil
+	}
+	return from, to, errors.Errorf("invalid period %q", period)
+}
+
+// periodRowIterator iterates over the rows of the fragments of a time
+// field's views of one unit, returning a row for each period each row ID
+// has bits in, ordered by row ID and then by period.
+type periodRowIterator struct {
+	tx        Tx
+	fragments []*fragment // in period order
+	rows      []periodRow // in row ID and then period order
+	cur       int
+	last      int // the position of the row last returned
+	wrap      bool
+}
+
+// periodRow identifies a row of one of the fragments of a
+// periodRowIterator.
+type periodRow struct {
+	rowID    uint64
+	fragment int
+}
+
+// newPeriodRowIterator returns an iterator over the rows of fragments,
+// which are of views of one time unit, in period order.
+func newPeriodRowIterator(fragments []*fragment, tx Tx, wrap bool, filters ...roaring.BitmapFilter) (*periodRowIterator, error) {
+	it := &periodRowIterator{
+		tx:        tx,
+		fragments: fragments,
+		wrap:      wrap,
+	}
+	for i, f := range fragments {
+		rowIDs, err := f.rows(context.Background(), tx, 0, filters...)
+		if err != nil {
+			return nil, err
+		}
+		for _, rowID := range rowIDs {
+			it.rows = append(it.rows, periodRow{rowID: rowID, fragment: i})
+		}
+	}
+	sort.Slice(it.rows, func(i, j int) bool {
+		if it.rows[i].rowID != it.rows[j].rowID {
+			return it.rows[i].rowID < it.rows[j].rowID
+		}
+		return it.rows[i].fragment < it.rows[j].fragment
+	})
+	return it, nil
+}
+
+// Seek moves the iterator to the first period of the first row ID at or
+// after rowID.
+func (it *periodRowIterator) Seek(rowID uint64) {
+	it.cur = sort.Search(len(it.rows), func(i int) bool {
+		return it.rows[i].rowID >= rowID
+	})
+}
+
+func (it *periodRowIterator) Next() (r *Row, rowID uint64, _ *int64, wrapped bool, err error) {
+	if it.cur >= len(it.rows) {
+		if !it.wrap || len(it.rows) == 0 {
+			return nil, 0, nil, true, nil
+		}
+		it.Seek(0)
+		wrapped = true
+	}
+	pr := it.rows[it.cur]
+	r, err = it.fragments[pr.fragment].row(it.tx, pr.rowID)
+	if err != nil {
+		return nil, pr.rowID, nil, wrapped, err
+	}
+	it.last = it.cur
+	it.cur++
+	return r, pr.rowID, nil, wrapped, nil
+}
+
+// period returns the period of the row last returned by Next.
+func (it *periodRowIterator) period() string {
+	return viewPeriod(it.fragments[it.rows[it.last].fragment].view())
+}
@@ -0,0 +1,246 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// A GroupBy child Rows(field, via=fk) groups records by the rows of a field
+// of another index, that of their foreign-index field fk: each record falls
+// in the groups of the record its fk value refers to. For example,
+// GroupBy(Rows(region, via=customer)) groups events by the region of their
+// customer, without copying regions into the events index.
+//
+// The coordinating node performs the join. For each row of the field, it
+// finds the records referring to a record in that row, and groups them by
+// the GroupBy's other children, so a field with many rows is expensive to
+// group by. Only one child can use via, and it only accepts a limit.
+
+// groupByVia returns the index of the GroupBy child using via, or -1.
+func groupByVia(c *pql.Call) (int, error) {
+	n := -1
+	for i, child := range c.Children {
+		if _, ok := child.Args["via"]; !ok {
+			continue
+		} else if n >= 0 {
+			return -1, errors.New("GroupBy() only supports one Rows() with via")
+		}
+		for k := range child.Args {
+			switch k {
+			case "_field", "field", "via", "limit":
+			default:
+				return -1, errors.Errorf("Rows() with via doesn't support %s", k)
+			}
+		}
+		n = i
+	}
+	return n, nil
+}
+
+// executeGroupByVia executes a GroupBy call with a child grouping by the
+// rows of a field in a foreign index.
+func (e *executor) executeGroupByVia(ctx context.Context, qcx *Qcx, index string, c *pql.Call, via int, shards []uint64, opt *ExecOptions) (*GroupCounts, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeGroupByVia")
+	defer span.Finish()
+
+	child := c.Children[via]
+	fieldName, err := child.FirstStringArg("_field", "field")
+	if err != nil || fieldName == "" {
+		return nil, errors.New("Rows() with via requires a field")
+	}
+	fkName, ok := child.Args["via"].(string)
+	if !ok {
+		return nil, errors.Errorf("via must be a field name, not %v", child.Args["via"])
+	}
+	fk := e.Holder.Field(index, fkName)
+	if fk == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, fkName)
+	} else if fk.ForeignIndex() == "" {
+		return nil, errors.Errorf("via field %q has no foreign index", fkName)
+	}
+	switch fk.Type() {
+	case FieldTypeSet, FieldTypeMutex, FieldTypeInt:
+	default:
+		return nil, errors.Errorf("via field %q is of type %q", fkName, fk.Type())
+	}
+	parent := e.Holder.Index(fk.ForeignIndex())
+	if parent == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, fk.ForeignIndex())
+	}
+	f := parent.Field(fieldName)
+	if f == nil {
+		return nil, errors.Wrapf(ErrFieldNotFound, "%q in index %q", fieldName, parent.Name())
+	}
+	switch f.Type() {
+	case FieldTypeSet, FieldTypeMutex, FieldTypeBool:
+	default:
+		return nil, errors.Errorf("can't group by field %q of type %q via a foreign index", fieldName, f.Type())
+	}
+
+	filter, _, err := c.CallArg("filter")
+	if err != nil {
+		return nil, err
+	}
+	aggregate, _, err := c.CallArg("aggregate")
+	if err != nil {
+		return nil, err
+	}
+	others := append(append([]*pql.Call{}, c.Children[:via]...), c.Children[via+1:]...)
+	if len(others) == 0 && aggregate != nil && aggregate.Name != "Sum" {
+		return nil, errors.Errorf("GroupBy() only supports a Sum aggregate when grouping by a foreign field alone")
+	}
+
+	// Find the rows of the field in the parent index.
+	parentShards := parent.AvailableShards(includeRemote).Slice()
+	rowsCall := &pql.Call{Name: "Rows", Args: map[string]interface{}{"_field": fieldName}}
+	if limit, ok := child.Args["limit"]; ok {
+		rowsCall.Args["limit"] = limit
+	}
+	rowIDs, err := e.executeRows(ctx, qcx, parent.Name(), rowsCall, parentShards, opt)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting rows of %q", fieldName)
+	}
+	options := f.Options()
+
+	var results []GroupCount
+	embedded := len(opt.EmbeddedData)
+	for _, rowID := range rowIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Find the records referring to a record in the row.
+		refRow, err := e.executeBitmapCall(ctx, qcx, parent.Name(), &pql.Call{Name: "Row", Args: map[string]interface{}{fieldName: rowID}}, parentShards, opt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting row %d of %q", rowID, fieldName)
+		}
+		cols := refRow.Columns()
+		if len(cols) == 0 {
+			continue
+		}
+		refs := make([]interface{}, len(cols))
+		for i, col := range cols {
+			refs[i] = col
+		}
+		row, err := e.executeBitmapCall(ctx, qcx, index, foreignRefsCall(fk, refs), shards, opt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding records referring to row %d of %q", rowID, fieldName)
+		}
+		if !row.Any() {
+			continue
+		}
+
+		// Group them by the other children, with the row as a filter.
+		// The row is embedded like a precomputed call, for other nodes.
+		rowFilter := &pql.Call{
+			Name:        "Precomputed",
+			Args:        map[string]interface{}{"valueidx": int64(len(opt.EmbeddedData))},
+			Precomputed: make(map[uint64]interface{}, len(row.segments)),
+		}
+		for _, segment := range row.segments {
+			rowFilter.Precomputed[segment.shard] = &Row{segments: []rowSegment{segment}}
+		}
+		opt.EmbeddedData = append(opt.EmbeddedData, row)
+		if filter != nil {
+			rowFilter = &pql.Call{Name: "Intersect", Children: []*pql.Call{filter, rowFilter}}
+		}
+		groups, err := e.groupByViaRow(ctx, qcx, index, c, others, rowFilter, aggregate, shards, opt)
+		opt.EmbeddedData = opt.EmbeddedData[:embedded]
+		if err != nil {
+			return nil, err
+		}
+
+		fr := FieldRow{Field: fieldName, Index: parent.Name(), RowID: rowID, FieldOptions: &options}
+		for _, gc := range groups {
+			group := make([]FieldRow, 0, len(gc.Group)+1)
+			group = append(append(append(group, gc.Group[:via]...), fr), gc.Group[via:]...)
+			gc.Group = group
+			results = append(results, gc)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Compare(results[j]) < 0 })
+
+	if maxGroups := e.resultLimits(index, opt).MaxGroups; maxGroups > 0 && uint64(len(results)) > maxGroups {
+		return nil, newResultLimitError(index, c.Name, "groups", maxGroups)
+	}
+
+	// Apply having, sorting, and the limit and offset, as GroupBy does.
+	if having, hasHaving, err := c.CallArg("having"); err != nil {
+		return nil, errors.Wrap(err, "getting 'having' argument")
+	} else if hasHaving {
+		if having.Name != "Condition" || len(having.Args) != 1 {
+			return nil, errors.New("the only supported having call is Condition() with a single condition")
+		}
+		for subj, cond := range having.Args {
+			if err := checkHavingSubject(subj, aggregate); err != nil {
+				return nil, err
+			}
+			results = applyConditionToGroupCounts(results, subj, cond.(*pql.Condition))
+		}
+	}
+	if sortSpec, found, err := c.StringArg("sort"); err != nil {
+		return nil, errors.Wrap(err, "getting sort arg")
+	} else if found {
+		sorter, err := getSorter(sortSpec)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing sort spec")
+		}
+		sorter.data = results
+		sort.Stable(sorter)
+	}
+	results, err = applyLimitAndOffsetToGroupByResult(c, results)
+	if err != nil {
+		return nil, errors.Wrap(err, "applying limit/offset")
+	}
+
+	aggType := groupByAggregateType(aggregate)
+	for _, res := range results {
+		if res.DecimalAgg != nil && aggType == "sum" {
+			aggType = "decimalSum"
+			break
+		}
+	}
+	return NewGroupCounts(aggType, results...), nil
+}
+
+// groupByViaRow returns the groups of the records of filter, by the GroupBy
+// call's children other than the one using via.
+func (e *executor) groupByViaRow(ctx context.Context, qcx *Qcx, index string, c *pql.Call, others []*pql.Call, filter, aggregate *pql.Call, shards []uint64, opt *ExecOptions) ([]GroupCount, error) {
+	if len(others) == 0 {
+		if aggregate == nil {
+			n, err := e.executeCount(ctx, qcx, index, &pql.Call{Name: "Count", Children: []*pql.Call{filter}}, shards, opt)
+			if err != nil || n == 0 {
+				return nil, err
+			}
+			return []GroupCount{{Count: n}}, nil
+		}
+		sum := aggregate.Clone()
+		sum.Children = []*pql.Call{filter}
+		vc, err := e.executeSum(ctx, qcx, index, sum, shards, opt)
+		if err != nil || vc.Count == 0 {
+			return nil, err
+		}
+		return []GroupCount{{Count: uint64(vc.Count), Agg: vc.Val, DecimalAgg: vc.DecimalVal}}, nil
+	}
+
+	sub := &pql.Call{Name: c.Name, Args: make(map[string]interface{}, len(c.Args)), Children: others}
+	for k, v := range c.Args {
+		switch k {
+		case "limit", "offset", "sort", "having":
+		default:
+			sub.Args[k] = v
+		}
+	}
+	sub.Args["filter"] = filter
+	gcs, err := e.executeGroupBy(ctx, qcx, index, sub, shards, opt)
+	if err != nil {
+		return nil, err
+	}
+	return gcs.Groups(), nil
+}
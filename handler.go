@@ -4,6 +4,8 @@ package pilosa
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/featurebasedb/featurebase/v3/ingest"
@@ -13,7 +15,10 @@ import (
 
 // QueryRequest represent a request to process a query.
 type QueryRequest struct {
-	// Index to execute query against.
+	// Index to execute query against. On the coordinating node, this may
+	// also be an alias, or a comma-separated list of indexes, aliases, and
+	// glob patterns such as "events_2024_*", in which case the query is
+	// run against each index and the results are merged.
 	Index string
 
 	// The query string to parse and execute.
@@ -43,6 +48,53 @@ type QueryRequest struct {
 
 	// Limit on memory used by request (Extract() only)
 	MaxMemory int64
+
+	// If true, the index's result limits are not enforced. Only
+	// trusted callers should set this.
+	IgnoreResultLimits bool
+
+	// If true, metadata attached to rows is included in TopN, Rows, and
+	// GroupBy results.
+	IncludeRowMeta bool
+
+	// ExistenceFallback is how Not(), All(), and "== null" are computed
+	// against indexes which don't track existence; see the
+	// ExistenceFallback constants. If empty, the server's default is used.
+	ExistenceFallback string
+
+	// Priority is the priority class of the query; see the QueryPriority
+	// constants. If empty, the query is interactive.
+	Priority string
+
+	// AllowPartial leaves shards which can't be reached, because the
+	// nodes holding them are down, out of the query instead of failing
+	// it. The response's Completeness reports the shards left out.
+	AllowPartial bool
+
+	// ExecPath is the execution path the query takes; see the ExecPath
+	// constants. If empty, the query takes the current path.
+	ExecPath string
+
+	// ChunkWrites runs a query with more writes than a request may make
+	// as several transactions, each making as many as it may, rather than
+	// rejecting it. The response's Statements report which calls failed.
+	ChunkWrites bool
+
+	// Client identifies who made the query, so the executor's workers can
+	// be shared fairly between clients. It's the authenticated user if
+	// there is one, or else the client argument of the request, or its
+	// remote host.
+	Client string
+
+	// RejectNewKeys fails writes which would create new row or column
+	// keys, returning the keys which don't exist, rather than creating
+	// them.
+	RejectNewKeys bool
+
+	// Hints turn off, or force, optimizations of the executor; see the
+	// Hint constants. They're set by the coordinating node, passing on
+	// those given to Options() calls to the nodes it asks to execute them.
+	Hints []string
 }
 
 // QueryResponse represent a response from a processed query.
@@ -58,25 +110,76 @@ type QueryResponse struct {
 
 	// Profiling data, if any
 	Profile *tracing.Profile
+
+	// ExistenceFallback is set if the query used a fallback in place of
+	// existence tracking, in which case the columns considered to exist
+	// are only those with a value in the fallback's fields.
+	ExistenceFallback string
+
+	// Completeness reports the shards reached by a query allowing partial
+	// results.
+	Completeness *Completeness
+
+	// Statements reports whether each call of a query which writes, run
+	// with ChunkWrites, succeeded.
+	Statements []StatementStatus
+
+	// Load reported by the node which executed a remote query, if any.
+	load *nodeLoad
+}
+
+// queryResponseJSON is the JSON encoding of a successful QueryResponse.
+type queryResponseJSON struct {
+	Results    []interface{}    `json:"results"`
+	Profile    *tracing.Profile `json:"profile,omitempty"`
+	CacheStale bool             `json:"cacheStale,omitempty"`
+
+	ExistenceFallback string            `json:"existenceFallback,omitempty"`
+	Completeness      *Completeness     `json:"completeness,omitempty"`
+	Statements        []StatementStatus `json:"statements,omitempty"`
+}
+
+// queryErrorJSON is the JSON encoding of a failed QueryResponse.
+type queryErrorJSON struct {
+	Err         string            `json:"error"`
+	Code        ErrorCode         `json:"code"`
+	Retryable   bool              `json:"retryable"`
+	UnknownKeys *UnknownKeysError `json:"unknownKeys,omitempty"`
 }
 
 // MarshalJSON marshals QueryResponse into a JSON-encoded byte slice
 func (resp *QueryResponse) MarshalJSON() ([]byte, error) {
 	if resp.Err != nil {
-		return json.Marshal(struct {
-			Err string `json:"error"`
-		}{Err: resp.Err.Error()})
-	}
-
-	return json.Marshal(struct {
-		Results []interface{}    `json:"results"`
-		Profile *tracing.Profile `json:"profile,omitempty"`
-	}{
-		Results: resp.Results,
-		Profile: resp.Profile,
+		code, retryable := ErrorCodeOf(resp.Err)
+		var unknownKeys *UnknownKeysError
+		if uk := (UnknownKeysError{}); errors.As(resp.Err, &uk) {
+			unknownKeys = &uk
+		}
+		return json.Marshal(queryErrorJSON{Err: resp.Err.Error(), Code: code, Retryable: retryable, UnknownKeys: unknownKeys})
+	}
+
+	return json.Marshal(queryResponseJSON{
+		Results:    resp.Results,
+		Profile:    resp.Profile,
+		CacheStale: resp.cacheStale(),
+
+		ExistenceFallback: resp.ExistenceFallback,
+		Completeness:      resp.Completeness,
+		Statements:        resp.Statements,
 	})
 }
 
+// cacheStale reports whether any of the results were ranked by a cache
+// with changes not yet reflected in its rankings.
+func (resp *QueryResponse) cacheStale() bool {
+	for _, result := range resp.Results {
+		if pf, ok := result.(*PairsField); ok && pf.Stale {
+			return true
+		}
+	}
+	return false
+}
+
 // HandlerI is the interface for the data handler, a wrapper around
 // Pilosa's data store.
 type HandlerI interface {
@@ -114,8 +217,16 @@ type ImportValueRequest struct {
 	FloatValues     []float64
 	TimestampValues []time.Time
 	StringValues    []string
-	Clear           bool
-	scratch         []int // scratch space to allow us to get a stable sort in reasonable time
+	// DecimalValues are decimal strings, such as "-12.34", for a decimal
+	// field. They're scaled to the field's scale on import, and rejected
+	// if they have more decimal places than it, rather than rounded.
+	DecimalValues []string
+	// Nulls, if set, is parallel to the columns, and clears the value of
+	// each column for which it's true. Those columns still need a value,
+	// which is ignored. Values are set and cleared in the same transaction.
+	Nulls   []bool
+	Clear   bool
+	scratch []int // scratch space to allow us to get a stable sort in reasonable time
 }
 
 func (ivr *ImportValueRequest) Clone() *ImportValueRequest {
@@ -150,6 +261,14 @@ func (ivr *ImportValueRequest) Clone() *ImportValueRequest {
 		newIVR.StringValues = make([]string, len(ivr.StringValues))
 		copy(newIVR.StringValues, ivr.StringValues)
 	}
+	if len(ivr.DecimalValues) > 0 {
+		newIVR.DecimalValues = make([]string, len(ivr.DecimalValues))
+		copy(newIVR.DecimalValues, ivr.DecimalValues)
+	}
+	if len(ivr.Nulls) > 0 {
+		newIVR.Nulls = make([]bool, len(ivr.Nulls))
+		copy(newIVR.Nulls, ivr.Nulls)
+	}
 	return newIVR
 }
 
@@ -203,12 +322,63 @@ func (ivr *ImportValueRequest) Swap(i, j int) {
 		ivr.TimestampValues[i], ivr.TimestampValues[j] = ivr.TimestampValues[j], ivr.TimestampValues[i]
 	} else if len(ivr.StringValues) > 0 {
 		ivr.StringValues[i], ivr.StringValues[j] = ivr.StringValues[j], ivr.StringValues[i]
+	} else if len(ivr.DecimalValues) > 0 {
+		ivr.DecimalValues[i], ivr.DecimalValues[j] = ivr.DecimalValues[j], ivr.DecimalValues[i]
+	}
+	if len(ivr.Nulls) > 0 {
+		ivr.Nulls[i], ivr.Nulls[j] = ivr.Nulls[j], ivr.Nulls[i]
 	}
 	if len(ivr.scratch) > 0 {
 		ivr.scratch[i], ivr.scratch[j] = ivr.scratch[j], ivr.scratch[i]
 	}
 }
 
+// removeNulls removes the records whose values are null from ivr, and
+// returns their columns. As with values, a record is superseded by a later
+// one for the same column, so ivr must be sorted.
+func (ivr *ImportValueRequest) removeNulls() []uint64 {
+	if len(ivr.Nulls) == 0 {
+		return nil
+	}
+	var nulls []uint64
+	keep := make([]int, 0, len(ivr.ColumnIDs))
+	for i, col := range ivr.ColumnIDs {
+		if i+1 < len(ivr.ColumnIDs) && ivr.ColumnIDs[i+1] == col {
+			continue
+		}
+		if ivr.Nulls[i] {
+			nulls = append(nulls, col)
+		} else {
+			keep = append(keep, i)
+		}
+	}
+
+	kept := &ImportValueRequest{ColumnIDs: make([]uint64, len(keep))}
+	for j, i := range keep {
+		kept.ColumnIDs[j] = ivr.ColumnIDs[i]
+		switch {
+		case len(ivr.Values) > 0:
+			kept.Values = append(kept.Values, ivr.Values[i])
+		case len(ivr.FloatValues) > 0:
+			kept.FloatValues = append(kept.FloatValues, ivr.FloatValues[i])
+		case len(ivr.TimestampValues) > 0:
+			kept.TimestampValues = append(kept.TimestampValues, ivr.TimestampValues[i])
+		case len(ivr.StringValues) > 0:
+			kept.StringValues = append(kept.StringValues, ivr.StringValues[i])
+		case len(ivr.DecimalValues) > 0:
+			kept.DecimalValues = append(kept.DecimalValues, ivr.DecimalValues[i])
+		}
+	}
+	ivr.ColumnIDs = kept.ColumnIDs
+	ivr.Values = kept.Values
+	ivr.FloatValues = kept.FloatValues
+	ivr.TimestampValues = kept.TimestampValues
+	ivr.StringValues = kept.StringValues
+	ivr.DecimalValues = kept.DecimalValues
+	ivr.Nulls = nil
+	return nulls
+}
+
 // Validate ensures that the payload of the request is valid.
 func (ivr *ImportValueRequest) Validate() error {
 	return ivr.ValidateWithTimestamp(ivr.IndexCreatedAt, ivr.FieldCreatedAt)
@@ -235,8 +405,14 @@ func (ivr *ImportValueRequest) ValidateWithTimestamp(indexCreatedAt, fieldCreate
 	if len(ivr.StringValues) != 0 {
 		valueSetCount++
 	}
+	if len(ivr.DecimalValues) != 0 {
+		valueSetCount++
+	}
 	if valueSetCount > 1 {
-		return errors.Errorf("must pass ints, floats, or strings but not multiple")
+		return errors.Errorf("must pass ints, floats, strings, or decimals but not multiple")
+	}
+	if n := len(ivr.ColumnIDs) + len(ivr.ColumnKeys); len(ivr.Nulls) != 0 && len(ivr.Nulls) != n {
+		return errors.Errorf("number of columns (%d) and number of nulls (%d) do not match", n, len(ivr.Nulls))
 	}
 
 	if (ivr.IndexCreatedAt != 0 && ivr.IndexCreatedAt != indexCreatedAt) ||
@@ -246,6 +422,39 @@ func (ivr *ImportValueRequest) ValidateWithTimestamp(indexCreatedAt, fieldCreate
 	return nil
 }
 
+// ImportValueError describes why a record of an ImportValueRequest was
+// rejected. Record is its position in the request, and Column its column ID
+// or key.
+type ImportValueError struct {
+	Record int    `json:"record"`
+	Column string `json:"column"`
+	Value  string `json:"value"`
+	Err    string `json:"error"`
+}
+
+// ImportValueErrors lists every record of an ImportValueRequest which was
+// rejected. None of the request's records are imported if any are.
+type ImportValueErrors struct {
+	Errors []ImportValueError `json:"errors"`
+}
+
+// maxImportValueErrors is the most errors an ImportValueErrors lists in its
+// message.
+const maxImportValueErrors = 10
+
+func (e *ImportValueErrors) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d invalid values", len(e.Errors))
+	for i, ve := range e.Errors {
+		if i == maxImportValueErrors {
+			fmt.Fprintf(&b, "; and %d more", len(e.Errors)-i)
+			break
+		}
+		fmt.Fprintf(&b, "; record %d (column %s) %q: %s", ve.Record, ve.Column, ve.Value, ve.Err)
+	}
+	return b.String()
+}
+
 // ImportRequest describes the import request structure
 // for an import.  BSIs use the ImportValueRequest instead.
 type ImportRequest struct {
@@ -0,0 +1,119 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// Options(hint="...") turns off, or forces, one of the executor's
+// optimizations for the call it wraps, so that a query hitting a bug in
+// one can be worked around, and the optimization's effect measured,
+// without a redeploy. Several hints can be given as a list, as in
+// Options(Count(Row(f=1)), hint=["no_shard_prune", "serial_reduce"]).
+// Hints apply to everything under the Options() call, on every node it
+// reaches.
+
+// Query hints.
+const (
+	// HintNoShardPrune reads every shard, rather than skipping those whose
+	// metadata shows they can't match.
+	HintNoShardPrune = "no_shard_prune"
+
+	// HintForceExactTopN computes TopN() calls as if they had exact=true,
+	// ranking rows by their exact counts rather than their ranked caches.
+	// Calls with a tanimotoThreshold, which exact counts don't support,
+	// are left as they are.
+	HintForceExactTopN = "force_exact_topn"
+
+	// HintSerialReduce folds the results of shards together one at a
+	// time, as they arrive, rather than spreading the work over several
+	// goroutines.
+	HintSerialReduce = "serial_reduce"
+)
+
+// validQueryHints are the hints a query can give.
+var validQueryHints = map[string]struct{}{
+	HintNoShardPrune:   {},
+	HintForceExactTopN: {},
+	HintSerialReduce:   {},
+}
+
+// validateQueryHints returns an error if any of hints isn't a query hint.
+func validateQueryHints(hints []string) error {
+	for _, hint := range hints {
+		if _, ok := validQueryHints[hint]; !ok {
+			return NewBadRequestError(errors.Errorf("unknown query hint %q", hint))
+		}
+	}
+	return nil
+}
+
+// queryHintsArg returns the hints given by the hint argument of an Options
+// call, which is either a string or a list of strings.
+func queryHintsArg(c *pql.Call) ([]string, error) {
+	var hints []string
+	switch arg := c.Args["hint"].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		hints = []string{arg}
+	case []interface{}:
+		for _, v := range arg {
+			hint, ok := v.(string)
+			if !ok {
+				return nil, NewBadRequestError(errors.New("Options(): hint must be a string or a list of strings"))
+			}
+			hints = append(hints, hint)
+		}
+	default:
+		return nil, NewBadRequestError(errors.New("Options(): hint must be a string or a list of strings"))
+	}
+	return hints, validateQueryHints(hints)
+}
+
+type contextKeyQueryHintsType struct{}
+
+var contextKeyQueryHints = contextKeyQueryHintsType{}
+
+// withQueryHints returns a context for a query taking hints, in addition to
+// any it already takes.
+func withQueryHints(ctx context.Context, hints []string) context.Context {
+	if len(hints) == 0 {
+		return ctx
+	}
+	set := make(map[string]struct{})
+	for _, hint := range queryHintsFromContext(ctx) {
+		set[hint] = struct{}{}
+	}
+	for _, hint := range hints {
+		set[hint] = struct{}{}
+	}
+	all := make([]string, 0, len(set))
+	for hint := range set {
+		all = append(all, hint)
+	}
+	sort.Strings(all)
+	return context.WithValue(ctx, contextKeyQueryHints, all)
+}
+
+// queryHintsFromContext returns the hints of the query running in ctx, in
+// order.
+func queryHintsFromContext(ctx context.Context) []string {
+	hints, _ := ctx.Value(contextKeyQueryHints).([]string)
+	return hints
+}
+
+// hasQueryHint reports whether the query running in ctx takes hint.
+func hasQueryHint(ctx context.Context, hint string) bool {
+	for _, h := range queryHintsFromContext(ctx) {
+		if h == hint {
+			return true
+		}
+	}
+	return false
+}
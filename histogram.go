@@ -0,0 +1,300 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/featurebasedb/featurebase/v3/proto"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// Histogram(field=f, bucketWidth=w) counts the values of an int field in
+// buckets w wide, aligned on multiples of w, and Histogram(field=f,
+// edges=[e0, e1, ..., en]) in the buckets [e0, e1), [e1, e2), and so on up
+// to en, leaving out values outside them. Either can take a row call to
+// filter the columns, or be used as a GroupBy aggregate. Each shard counts
+// the values less than every edge with a range mask over the field's bit
+// slices, and the differences of those counts are the buckets' counts, so a
+// histogram takes one pass over the data however many buckets it has. The
+// node coordinating the query finds the edges for a bucketWidth from the
+// field's lowest and highest values before the shards are counted, and
+// passes them on as edges.
+
+// maxHistogramBuckets is the largest number of buckets a histogram can
+// have.
+const maxHistogramBuckets = 10000
+
+// Histogram is the result of a Histogram call: the counts of the values of
+// a field in the buckets between consecutive edges.
+type Histogram struct {
+	Field  string
+	Edges  []int64
+	Counts []uint64
+}
+
+// HistogramBucket is one bucket of a Histogram, holding Count values from
+// From, inclusive, to To, exclusive.
+type HistogramBucket struct {
+	From  int64  `json:"from"`
+	To    int64  `json:"to"`
+	Count uint64 `json:"count"`
+}
+
+// newHistogram returns a Histogram of field with no values in the buckets
+// between edges.
+func newHistogram(field string, edges []int64) *Histogram {
+	return &Histogram{Field: field, Edges: edges, Counts: make([]uint64, len(edges)-1)}
+}
+
+// Buckets returns the buckets of h.
+func (h *Histogram) Buckets() []HistogramBucket {
+	if h == nil {
+		return nil
+	}
+	buckets := make([]HistogramBucket, len(h.Counts))
+	for i := range h.Counts {
+		buckets[i] = HistogramBucket{From: h.Edges[i], To: h.Edges[i+1], Count: h.Counts[i]}
+	}
+	return buckets
+}
+
+// MarshalJSON lists the buckets of a Histogram.
+func (h *Histogram) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Field   string            `json:"field"`
+		Buckets []HistogramBucket `json:"buckets"`
+	}{Field: h.Field, Buckets: h.Buckets()})
+}
+
+// ToTable implements the ToTabler interface.
+func (h *Histogram) ToTable() (*proto.TableResponse, error) {
+	return proto.RowsToTable(h, len(h.Counts))
+}
+
+// ToRows implements the ToRowser interface.
+func (h *Histogram) ToRows(callback func(*proto.RowResponse) error) error {
+	for i, b := range h.Buckets() {
+		var ci []*proto.ColumnInfo
+		if i == 0 {
+			ci = []*proto.ColumnInfo{
+				{Name: "from", Datatype: "int64"},
+				{Name: "to", Datatype: "int64"},
+				{Name: "count", Datatype: "uint64"},
+			}
+		}
+		if err := callback(&proto.RowResponse{
+			Headers: ci,
+			Columns: []*proto.ColumnResponse{
+				{ColumnVal: &proto.ColumnResponse_Int64Val{Int64Val: b.From}},
+				{ColumnVal: &proto.ColumnResponse_Int64Val{Int64Val: b.To}},
+				{ColumnVal: &proto.ColumnResponse_Uint64Val{Uint64Val: b.Count}},
+			},
+		}); err != nil {
+			return errors.Wrap(err, "calling callback")
+		}
+	}
+	return nil
+}
+
+// add returns a new Histogram with the counts of h and o added. Either can
+// be nil, but if neither is, they must have the same edges.
+func (h *Histogram) add(o *Histogram) *Histogram {
+	if h == nil {
+		return o
+	} else if o == nil {
+		return h
+	}
+	sum := &Histogram{Field: h.Field, Edges: h.Edges, Counts: make([]uint64, len(h.Counts))}
+	for i := range sum.Counts {
+		sum.Counts[i] = h.Counts[i] + o.Counts[i]
+	}
+	return sum
+}
+
+// histogramEdges returns the edges of a Histogram call, which must be
+// strictly increasing. The bool returned is false if the call gives a
+// bucketWidth rather than edges.
+func histogramEdges(c *pql.Call) ([]int64, bool, error) {
+	var edges []int64
+	switch v := c.Args["edges"].(type) {
+	case nil:
+		return nil, false, nil
+	case []int64:
+		edges = v
+	case []interface{}:
+		edges = make([]int64, len(v))
+		for i := range v {
+			switch e := v[i].(type) {
+			case int64:
+				edges[i] = e
+			case uint64:
+				edges[i] = int64(e)
+			default:
+				return nil, true, NewBadRequestError(errors.Errorf("Histogram(): edge %v is %[1]T, not an integer", v[i]))
+			}
+		}
+	default:
+		return nil, true, NewBadRequestError(errors.Errorf("Histogram(): edges must be a list of integers, not %T", v))
+	}
+	if len(edges) < 2 {
+		return nil, true, NewBadRequestError(errors.New("Histogram(): at least two edges are needed"))
+	} else if len(edges) > maxHistogramBuckets+1 {
+		return nil, true, NewBadRequestError(errors.Errorf("Histogram(): more than %d buckets", maxHistogramBuckets))
+	}
+	for i := 1; i < len(edges); i++ {
+		if edges[i] <= edges[i-1] {
+			return nil, true, NewBadRequestError(errors.New("Histogram(): edges must be strictly increasing"))
+		}
+	}
+	return edges, true, nil
+}
+
+// resolveHistogramEdges replaces the bucketWidth of a Histogram call with
+// the edges of buckets that width, starting at or below the lowest value of
+// the field among the columns the call counts, and ending above the
+// highest.
+func (e *executor) resolveHistogramEdges(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) error {
+	width, hasWidth, err := c.IntArg("bucketWidth")
+	if err != nil {
+		return errors.Wrap(err, "Histogram(): bucketWidth")
+	}
+	if _, hasEdges, err := histogramEdges(c); err != nil {
+		return err
+	} else if hasEdges {
+		if hasWidth {
+			return NewBadRequestError(errors.New("Histogram(): bucketWidth and edges can't both be given"))
+		}
+		return nil
+	} else if !hasWidth {
+		return NewBadRequestError(errors.New("Histogram(): bucketWidth or edges required"))
+	} else if width <= 0 {
+		return NewBadRequestError(errors.New("Histogram(): bucketWidth must be positive"))
+	}
+
+	args := map[string]interface{}{"field": c.Args["field"]}
+	if _, ok := c.Args["_field"]; ok {
+		args = map[string]interface{}{"_field": c.Args["_field"]}
+	}
+	lo, err := e.executeMin(ctx, qcx, index, &pql.Call{Name: "Min", Args: args, Children: c.Children}, shards, opt)
+	if err != nil {
+		return errors.Wrap(err, "finding lowest value")
+	}
+	hi, err := e.executeMax(ctx, qcx, index, &pql.Call{Name: "Max", Args: args, Children: c.Children}, shards, opt)
+	if err != nil {
+		return errors.Wrap(err, "finding highest value")
+	}
+
+	first, last := floorDiv(lo.Val, width), floorDiv(hi.Val, width)
+	if uint64(last-first) >= maxHistogramBuckets {
+		return NewBadRequestError(errors.Errorf("Histogram(): buckets %d wide give more than %d buckets", width, maxHistogramBuckets))
+	}
+	edges := make([]int64, 0, last-first+2)
+	for b := first; b <= last+1; b++ {
+		edges = append(edges, b*width)
+	}
+	delete(c.Args, "bucketWidth")
+	c.Args["edges"] = edges
+	return nil
+}
+
+// floorDiv returns a/b rounded down.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// executeHistogram executes a Histogram() call.
+func (e *executor) executeHistogram(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (*Histogram, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeHistogram")
+	defer span.Finish()
+
+	fieldName, err := c.FirstStringArg("field", "_field")
+	if err != nil {
+		return nil, errors.Wrap(err, "Histogram(): field required")
+	}
+	if len(c.Children) > 1 {
+		return nil, errors.New("Histogram() only accepts a single bitmap input")
+	}
+	if _, err := e.histogramField(index, fieldName); err != nil {
+		return nil, err
+	}
+	if !opt.Remote {
+		if err := e.resolveHistogramEdges(ctx, qcx, index, c, shards, opt); err != nil {
+			return nil, err
+		}
+	}
+	edges, _, err := histogramEdges(c)
+	if err != nil {
+		return nil, err
+	}
+
+	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
+		return e.executeHistogramShard(ctx, qcx, index, c, nil, shard)
+	}
+	reduceFn := func(ctx context.Context, prev, v interface{}) interface{} {
+		other, _ := prev.(*Histogram)
+		return other.add(v.(*Histogram))
+	}
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	if h, _ := result.(*Histogram); h != nil {
+		return h, nil
+	}
+	return newHistogram(fieldName, edges), nil
+}
+
+// executeHistogramShard counts the values of a Histogram call's field in
+// its buckets on a shard, among the columns of filter, if it isn't nil, and
+// of the call's child.
+func (e *executor) executeHistogramShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, filter *Row, shard uint64) (*Histogram, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeHistogramShard")
+	defer span.Finish()
+
+	if len(c.Children) == 1 {
+		row, err := e.executeBitmapCallShard(ctx, qcx, index, c.Children[0], shard)
+		if err != nil {
+			return nil, errors.Wrap(err, "executing bitmap call")
+		}
+		if filter != nil {
+			row = row.Intersect(filter)
+		}
+		filter = row
+	}
+
+	fieldName, err := c.FirstStringArg("field", "_field")
+	if err != nil {
+		return nil, errors.Wrap(err, "Histogram(): field required")
+	}
+	field, err := e.histogramField(index, fieldName)
+	if err != nil {
+		return nil, err
+	}
+	edges, ok, err := histogramEdges(c)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New("Histogram(): edges required")
+	}
+	return field.histogramForShard(qcx, shard, filter, edges)
+}
+
+// histogramField returns the int field of a histogram.
+func (e *executor) histogramField(index, name string) (*Field, error) {
+	field := e.Holder.Field(index, name)
+	if field == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, name)
+	}
+	if field.Type() != FieldTypeInt {
+		return nil, NewBadRequestError(errors.Errorf("Histogram(): field %s is of type %s, expected int", name, field.Type()))
+	}
+	return field, nil
+}
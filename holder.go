@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/featurebasedb/featurebase/v3/disco"
@@ -76,6 +77,10 @@ type Holder struct {
 	wg      sync.WaitGroup
 	closing chan struct{}
 
+	// memoryPolicyChanged is signalled when a field's memory policy
+	// changes, so that it's applied without waiting.
+	memoryPolicyChanged chan struct{}
+
 	// Stats
 	Stats stats.StatsClient
 
@@ -104,6 +109,41 @@ type Holder struct {
 
 	ida *idAllocator
 
+	// Metadata attached to field rows.
+	rowMeta *rowMetaStore
+
+	// Attributes attached to columns.
+	columnAttrs *columnAttrStore
+
+	// Alias names for indexes.
+	aliases *aliasStore
+
+	// Quotas of the namespaces indexes can belong to.
+	namespaces *namespaceQuotas
+
+	// Limits on the disk used by the node's data, and the disk used by
+	// indexes, namespaces and the node, as last found.
+	diskLimits DiskLimits
+	diskUsage  *diskUsageCache
+
+	// Why the node is read-only, or in maintenance mode, if it is.
+	readOnlyMu  sync.RWMutex
+	readOnly    string
+	maintenance string
+
+	// How long opening the indexes took.
+	openDuration time.Duration
+
+	// Fragments found not to match their saved checksums, by key.
+	corruptMu sync.Mutex
+	corrupt   map[string]CorruptFragment
+
+	// Fragments which couldn't be opened, by key, and how many there are,
+	// read atomically by queries.
+	unopenedMu sync.Mutex
+	unopened   map[string]UnopenedFragment
+	unopenedN  int32
+
 	// Queue of fields (having a foreign index) which have
 	// opened before their foreign index has opened.
 	foreignIndexFields   []*Field
@@ -207,6 +247,40 @@ type HolderConfig struct {
 	AntiEntropyInterval time.Duration
 
 	LookupDBDSN string
+
+	// Quotas of namespaces, by name.
+	NamespaceQuotas map[string]NamespaceQuota
+
+	// Limits on the disk used by the node's data.
+	DiskLimits DiskLimits
+
+	// LazyOpen defers opening fragments until they're first used, rather
+	// than opening them all when the holder is opened.
+	LazyOpen bool
+
+	// Checksums saves a checksum of each fragment's data when it's closed,
+	// and verifies it when it's next opened. If ScrubInterval is set, open
+	// fragments are also verified that often.
+	Checksums     bool
+	ScrubInterval time.Duration
+
+	// StaleTxAge is how long a transaction context can be open before
+	// it's reported as stale.
+	StaleTxAge time.Duration
+
+	// ReadCoalesceWindow is how long a row read from a fragment is shared
+	// with other queries reading the same data. Zero only shares reads
+	// with queries reading at the same time, and a negative window
+	// doesn't share them at all.
+	ReadCoalesceWindow time.Duration
+
+	// QueryRules allow or deny calls in queries.
+	QueryRules []QueryRule
+
+	// OptionTemplates are the options given to indexes and fields created
+	// through the server by default, and the templates their requests can
+	// name. Nil means there are none.
+	OptionTemplates *OptionTemplates
 }
 
 // DefaultHolderConfig provides a holder config with reasonable
@@ -262,6 +336,8 @@ func NewHolder(path string, cfg *HolderConfig) *Holder {
 		cfg:     cfg,
 		closing: make(chan struct{}),
 
+		memoryPolicyChanged: make(chan struct{}, 1),
+
 		opened: lockedChan{ch: make(chan struct{})},
 
 		broadcaster: NopBroadcaster,
@@ -285,6 +361,19 @@ func NewHolder(path string, cfg *HolderConfig) *Holder {
 		path: path,
 
 		indexes: make(map[string]*Index),
+
+		// The row metadata store is opened on first use.
+		rowMeta: newRowMetaStore(filepath.Join(path, "rowmeta.db"), cfg.StorageConfig.FsyncEnabled),
+
+		// As is the column attribute store.
+		columnAttrs: newColumnAttrStore(filepath.Join(path, "colattrs.db"), cfg.StorageConfig.FsyncEnabled),
+
+		aliases: newAliasStore(filepath.Join(path, "aliases.json")),
+
+		namespaces: newNamespaceQuotas(cfg.NamespaceQuotas),
+
+		diskLimits: cfg.DiskLimits,
+		diskUsage:  newDiskUsageCache(),
 	}
 
 	txf, err := NewTxFactory(cfg.StorageConfig.Backend, h.IndexesPath(), h)
@@ -394,6 +483,15 @@ func (h *Holder) Open() error {
 	// Reset closing in case Holder is being reopened.
 	h.closing = make(chan struct{})
 
+	// Fragments are verified again as they're opened.
+	h.corruptMu.Lock()
+	h.corrupt = nil
+	h.corruptMu.Unlock()
+	h.unopenedMu.Lock()
+	h.unopened = nil
+	atomic.StoreInt32(&h.unopenedN, 0)
+	h.unopenedMu.Unlock()
+
 	h.Logger.Printf("open holder path: %s", h.path)
 	if err := os.MkdirAll(h.IndexesPath(), 0750); err != nil {
 		return errors.Wrap(err, "creating directory")
@@ -412,50 +510,68 @@ func (h *Holder) Open() error {
 		return errors.Wrap(err, "opening ID allocator")
 	}
 
+	if err := h.aliases.load(); err != nil {
+		return errors.Wrap(err, "loading aliases")
+	}
+
 	// Load schema from etcd.
 	schema, err := h.Schemator.Schema(context.Background())
 	if err != nil {
 		return errors.Wrap(err, "getting schema")
 	}
 
+	// Indexes are opened in parallel, since opening the fields and views
+	// of each can take a while when there are many of them.
+	start := time.Now()
+	var g errgroup.Group
+	g.SetLimit(runtime.NumCPU())
 	for idxKey, idx := range schema {
-		// decode the CreateIndexMessage from the schema data in order to
-		// get its metadata, such as CreateAt.
-		cim, err := decodeCreateIndexMessage(h.serializer, idx.Data)
-		if err != nil {
-			return errors.Wrap(err, "decoding create index message")
-		}
+		idxKey, idx := idxKey, idx
+		g.Go(func() error {
+			// decode the CreateIndexMessage from the schema data in order to
+			// get its metadata, such as CreateAt.
+			cim, err := decodeCreateIndexMessage(h.serializer, idx.Data)
+			if err != nil {
+				return errors.Wrap(err, "decoding create index message")
+			}
 
-		h.Logger.Printf("opening index: %s", idxKey)
+			h.Logger.Printf("opening index: %s", idxKey)
 
-		index, err := h.newIndex(h.IndexPath(idxKey), idxKey)
-		if errors.Cause(err) == ErrName {
-			h.Logger.Errorf("opening index: %s, err=%s", idxKey, err)
-			continue
-		} else if err != nil {
-			return errors.Wrap(err, "opening index")
-		}
+			index, err := h.newIndex(h.IndexPath(idxKey), idxKey)
+			if errors.Cause(err) == ErrName {
+				h.Logger.Errorf("opening index: %s, err=%s", idxKey, err)
+				return nil
+			} else if err != nil {
+				return errors.Wrap(err, "opening index")
+			}
 
-		// Since we don't have createdAt stored on disk within the data
-		// directory, we need to populate it from the etcd schema data.
-		// TODO: we may no longer need the createdAt value stored in memory on
-		// the index struct; it may only be needed in the schema return value
-		// from the API, which already comes from etcd. In that case, this logic
-		// could be removed, and the createdAt on the index struct could be
-		// removed.
-		index.createdAt = cim.CreatedAt
+			// Since we don't have createdAt stored on disk within the data
+			// directory, we need to populate it from the etcd schema data.
+			// TODO: we may no longer need the createdAt value stored in memory on
+			// the index struct; it may only be needed in the schema return value
+			// from the API, which already comes from etcd. In that case, this logic
+			// could be removed, and the createdAt on the index struct could be
+			// removed.
+			index.createdAt = cim.CreatedAt
 
-		err = index.OpenWithSchema(idx)
-		if err != nil {
-			_ = h.txf.Close()
-			if err == ErrName {
-				h.Logger.Errorf("opening index: %s, err=%s", index.Name(), err)
-				continue
+			err = index.OpenWithSchema(idx)
+			if err != nil {
+				if err == ErrName {
+					h.Logger.Errorf("opening index: %s, err=%s", index.Name(), err)
+					return nil
+				}
+				return fmt.Errorf("open index: name=%s, err=%s", index.Name(), err)
 			}
-			return fmt.Errorf("open index: name=%s, err=%s", index.Name(), err)
-		}
-		h.addIndex(index)
+			h.addIndex(index)
+			return nil
+		})
 	}
+	if err := g.Wait(); err != nil {
+		_ = h.txf.Close()
+		return err
+	}
+	h.openDuration = time.Since(start)
+	h.Logger.Printf("opened %d indexes in %s", len(schema), h.openDuration)
 
 	// If any fields were opened before their foreign index
 	// was opened, it's safe to process those now since all index
@@ -529,6 +645,10 @@ func (h *Holder) Activate() {
 	// Periodically flush cache.
 	h.wg.Add(1)
 	go func() { defer h.wg.Done(); h.monitorCacheFlush() }()
+
+	// Keep pinned fields in memory, and cold ones out of it.
+	h.wg.Add(1)
+	go func() { defer h.wg.Done(); h.monitorMemoryPolicies() }()
 }
 
 // checkForeignIndex is a check before applying a foreign
@@ -586,6 +706,12 @@ func (h *Holder) Close() error {
 	if err := h.ida.Close(); err != nil {
 		return errors.Wrap(err, "closing ID allocator")
 	}
+	if err := h.rowMeta.Close(); err != nil {
+		return errors.Wrap(err, "closing row metadata store")
+	}
+	if err := h.columnAttrs.Close(); err != nil {
+		return errors.Wrap(err, "closing column attribute store")
+	}
 
 	// Reset opened in case Holder needs to be reopened.
 	h.txf = nil
@@ -930,6 +1056,8 @@ func (h *Holder) createIndex(cim *CreateIndexMessage, broadcast bool) (*Index, e
 
 	index.keys = cim.Meta.Keys
 	index.trackExistence = cim.Meta.TrackExistence
+	index.setNamespace(cim.Meta.Namespace)
+	index.setOptions(cim.Meta)
 	index.createdAt = cim.CreatedAt
 
 	if err = index.Open(); err != nil {
@@ -1071,16 +1199,41 @@ func (h *Holder) DeleteIndex(name string) error {
 		return errors.Wrapf(err, "deleting index from etcd: %s", name)
 	}
 
+	// Collect the index's translation stores, which are forgotten when it's
+	// closed.
+	var stores []TranslateStore
+	index.mu.RLock()
+	for _, store := range index.translateStores {
+		stores = append(stores, store)
+	}
+	for _, f := range index.fields {
+		if f.translateStore != nil {
+			stores = append(stores, f.translateStore)
+		}
+	}
+	index.mu.RUnlock()
+
 	// Close index.
 	if err := index.Close(); err != nil {
 		return errors.Wrap(err, "closing")
 	}
 
+	if err := dropTranslateStores(stores...); err != nil {
+		return errors.Wrapf(err, "index %s", name)
+	}
+
 	// remove any backing store.
 	if err := h.txf.DeleteIndex(name); err != nil {
 		return errors.Wrap(err, "h.Txf.DeleteIndex")
 	}
 
+	if err := h.rowMeta.DeleteIndex(name); err != nil {
+		return errors.Wrap(err, "deleting row metadata")
+	}
+	if err := h.columnAttrs.DeleteIndex(name); err != nil {
+		return errors.Wrap(err, "deleting column attributes")
+	}
+
 	// Delete index directory.
 	if err := os.RemoveAll(h.IndexPath(name)); err != nil {
 		// There is a rare edge case here: If a cache flush was happening, RemoveAll
@@ -1216,11 +1369,18 @@ type holderSyncer struct {
 
 	syncers errgroup.Group
 
+	// lastSnap is the snapshot of the cluster translation sync was last
+	// reset with.
+	lastSnap *disco.ClusterSnapshot
+
 	// Stats
 	Stats stats.StatsClient
 
 	// Signals that the sync should stop.
 	Closing <-chan struct{}
+
+	// Called with the time a sync began, once it has synced every fragment.
+	synced func(start time.Time)
 }
 
 // IsClosing returns true if the syncer has been asked to close.
@@ -1241,6 +1401,7 @@ func (s *holderSyncer) SyncHolder() error {
 	s.mu.Lock() // only allow one instance of SyncHolder to be running at a time
 	defer s.mu.Unlock()
 	ti := time.Now()
+	start := ti
 
 	// Create a snapshot of the cluster to use for node/partition calculations.
 	snap := s.Cluster.NewSnapshot()
@@ -1296,6 +1457,9 @@ func (s *holderSyncer) SyncHolder() error {
 		ti = time.Now() // reset ti
 	}
 
+	if s.synced != nil {
+		s.synced(start)
+	}
 	return nil
 }
 
@@ -1361,6 +1525,12 @@ func (s *holderSyncer) resetTranslationSync() error {
 	// Create a snapshot of the cluster to use for node/partition calculations.
 	snap := s.Cluster.NewSnapshot()
 
+	// Copy the key partitions this node has come to own.
+	if snap.PartitionAssignment == disco.PartitionAssignmentRingHash {
+		s.migrateKeyPartitions(s.lastSnap, snap)
+	}
+	s.lastSnap = snap
+
 	// Set read-only flag for all translation stores.
 	s.setTranslateReadOnlyFlags(snap)
 
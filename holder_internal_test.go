@@ -3,6 +3,8 @@
 package pilosa
 
 import (
+	"errors"
+	"os"
 	"testing"
 )
 
@@ -96,3 +98,77 @@ func TestHolder_ProcessDeleteInflight(t *testing.T) {
 
 	}
 }
+
+func TestHolder_LazyOpen(t *testing.T) {
+	cfg := TestHolderConfig()
+	cfg.LazyOpen = true
+	h := NewHolder(t.TempDir(), cfg)
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	idx, err := h.CreateIndex("i", IndexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := idx.CreateField("f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	qcx := h.Txf().NewWritableQcx()
+	for shard := uint64(0); shard < 3; shard++ {
+		if _, err := f.SetBit(qcx, 10, shard*ShardWidth+1, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := qcx.Finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	state := h.openState()
+	if !state.Lazy || state.Fragments != 3 || state.OpenFragments != 0 {
+		t.Fatalf("expected 3 lazy fragments, none open, got %+v", state)
+	}
+
+	// The first use of a fragment opens it, loading its cache.
+	frag := h.fragment("i", "f", viewStandard, 1)
+	if frag == nil || !frag.isOpen() {
+		t.Fatal("expected open fragment")
+	} else if n := frag.cache.Get(10); n != 1 {
+		t.Fatalf("expected cached count 1, got %d", n)
+	}
+	state = h.openState()
+	if state.OpenFragments != 1 || len(state.Indexes) != 1 || state.Indexes[0].OpenFragments != 1 {
+		t.Fatalf("expected 1 open fragment, got %+v", state)
+	}
+
+	// A fragment which can't be opened makes its shard fail, and the
+	// holder unhealthy, rather than being left out.
+	var unreadable *fragment
+	for _, frag := range h.view("i", "f", viewStandard).fragmentList() {
+		if frag.shard == 2 {
+			unreadable = frag
+		}
+	}
+	if err := os.RemoveAll(unreadable.cachePath()); err != nil {
+		t.Fatal(err)
+	} else if err := os.Mkdir(unreadable.cachePath(), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if frag := h.fragment("i", "f", viewStandard, 2); frag != nil {
+		t.Fatal("expected no fragment")
+	} else if err := h.unopenedShardErr("i", 2); !errors.Is(err, ErrFragmentUnopened) {
+		t.Fatalf("expected unopened fragment, got %v", err)
+	} else if err := h.unopenedShardErr("i", 1); err != nil {
+		t.Fatalf("expected shard 1 to be fine, got %v", err)
+	}
+	if health := h.health(); health.Healthy || len(health.UnopenedFragments) != 1 || health.UnopenedFragments[0].Shard != 2 {
+		t.Fatalf("expected unhealthy holder with an unopened fragment, got %+v", health)
+	}
+}
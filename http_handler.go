@@ -75,6 +75,11 @@ type Handler struct {
 
 	server *http.Server
 
+	// router routes the handler's requests, without its middleware.
+	router *mux.Router
+
+	sessions *connSessions
+
 	middleware []func(http.Handler) http.Handler
 
 	pprofCPUProfileBuffer *bytes.Buffer
@@ -133,11 +138,41 @@ func OptHandlerMiddleware(middleware func(http.Handler) http.Handler) handlerOpt
 }
 
 func OptHandlerAllowedOrigins(origins []string) handlerOption {
+	return OptHandlerCORS(CORSOptions{AllowedOrigins: origins})
+}
+
+// CORSOptions configures the responses to cross-origin requests.
+type CORSOptions struct {
+	AllowedOrigins []string
+	// AllowedHeaders are allowed in addition to Content-Type.
+	AllowedHeaders []string
+	// AllowedMethods default to GET, HEAD and POST.
+	AllowedMethods   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is how long, in seconds, preflight responses can be cached.
+	MaxAge int
+}
+
+func OptHandlerCORS(opts CORSOptions) handlerOption {
 	return func(h *Handler) error {
-		h.middleware = append(h.middleware, handlers.CORS(
-			handlers.AllowedOrigins(origins),
-			handlers.AllowedHeaders([]string{"Content-Type"}),
-		))
+		cors := []handlers.CORSOption{
+			handlers.AllowedOrigins(opts.AllowedOrigins),
+			handlers.AllowedHeaders(append([]string{"Content-Type"}, opts.AllowedHeaders...)),
+		}
+		if len(opts.AllowedMethods) > 0 {
+			cors = append(cors, handlers.AllowedMethods(opts.AllowedMethods))
+		}
+		if len(opts.ExposedHeaders) > 0 {
+			cors = append(cors, handlers.ExposedHeaders(opts.ExposedHeaders))
+		}
+		if opts.AllowCredentials {
+			cors = append(cors, handlers.AllowCredentials())
+		}
+		if opts.MaxAge > 0 {
+			cors = append(cors, handlers.MaxAge(opts.MaxAge))
+		}
+		h.middleware = append(h.middleware, handlers.CORS(cors...))
 		return nil
 	}
 }
@@ -266,11 +301,48 @@ func NewHandler(opts ...handlerOption) (*Handler, error) {
 		return nil, errors.New("must pass OptHandlerListener")
 	}
 
-	handler.server = &http.Server{Handler: handler}
+	handler.sessions = &connSessions{m: make(map[net.Conn]*ClientSession)}
+	handler.server = &http.Server{
+		Handler:     handler,
+		ConnContext: handler.connContext,
+		ConnState:   handler.connState,
+	}
 
 	return handler, nil
 }
 
+// connSessions holds the client session of each connection.
+type connSessions struct {
+	mu sync.Mutex
+	m  map[net.Conn]*ClientSession
+}
+
+// connContext starts the client session of a connection, and returns the
+// context for its requests.
+func (h *Handler) connContext(ctx context.Context, c net.Conn) context.Context {
+	cs := h.api.StartClientSession(ClientSessionHTTP, c.RemoteAddr().String(), "", func() { c.Close() })
+	h.sessions.mu.Lock()
+	h.sessions.m[c] = cs
+	h.sessions.mu.Unlock()
+	return WithClientSession(ctx, cs)
+}
+
+// connState ends the client session of a connection once it's closed.
+func (h *Handler) connState(c net.Conn, state http.ConnState) {
+	h.sessions.mu.Lock()
+	cs := h.sessions.m[c]
+	if state == http.StateClosed || state == http.StateHijacked {
+		delete(h.sessions.m, c)
+	}
+	h.sessions.mu.Unlock()
+	switch state {
+	case http.StateClosed, http.StateHijacked:
+		cs.End()
+	default:
+		cs.Touch()
+	}
+}
+
 func (h *Handler) Serve() error {
 	err := h.server.Serve(h.ln)
 	if err != nil && err.Error() != "http: Server closed" {
@@ -295,21 +367,38 @@ func (h *Handler) Close() error {
 func (h *Handler) populateValidators() {
 	h.validators = map[string]*queryValidationSpec{}
 	h.validators["GetExport"] = queryValidationSpecRequired("index", "field", "shard")
-	h.validators["GetIndexes"] = queryValidationSpecRequired()
+	h.validators["GetIndexes"] = queryValidationSpecRequired().Optional("namespace")
 	h.validators["GetIndex"] = queryValidationSpecRequired()
 	h.validators["PostIndex"] = queryValidationSpecRequired()
 	h.validators["DeleteIndex"] = queryValidationSpecRequired()
-	h.validators["GetTranslateData"] = queryValidationSpecRequired("index").Optional("partition", "field")
+	h.validators["GetAliases"] = queryValidationSpecRequired()
+	h.validators["PostAlias"] = queryValidationSpecRequired()
+	h.validators["PostSwapAlias"] = queryValidationSpecRequired()
+	h.validators["DeleteAlias"] = queryValidationSpecRequired()
+	h.validators["PostCloneIndex"] = queryValidationSpecRequired()
+	h.validators["GetTranslateData"] = queryValidationSpecRequired("index").Optional("partition", "field", "local")
 	h.validators["PostTranslateKeys"] = queryValidationSpecRequired()
 	h.validators["PostField"] = queryValidationSpecRequired()
 	h.validators["DeleteField"] = queryValidationSpecRequired()
-	h.validators["PostImport"] = queryValidationSpecRequired().Optional("clear", "ignoreKeyCheck")
+	h.validators["PostImport"] = queryValidationSpecRequired().Optional("clear", "ignoreKeyCheck", "createParents", "parentExistsField")
 	h.validators["PostImportAtomicRecord"] = queryValidationSpecRequired().Optional("simPowerLossAfter")
 	h.validators["PostImportRoaring"] = queryValidationSpecRequired().Optional("remote", "clear")
-	h.validators["PostQuery"] = queryValidationSpecRequired().Optional("shards", "excludeColumns", "profile")
+	h.validators["GetRowMeta"] = queryValidationSpecRequired("row")
+	h.validators["PostRowMeta"] = queryValidationSpecRequired()
+	h.validators["PostQuery"] = queryValidationSpecRequired().Optional("shards", "excludeColumns", "profile", "ignoreResultLimits", "includeMeta", "existenceFallback", "priority", "allowPartial", "chunkWrites", "client", "format", "rejectNewKeys")
 	h.validators["GetInfo"] = queryValidationSpecRequired()
 	h.validators["RecalculateCaches"] = queryValidationSpecRequired()
-	h.validators["GetSchema"] = queryValidationSpecRequired().Optional("views")
+	h.validators["GetSchema"] = queryValidationSpecRequired().Optional("views", "namespace")
+	h.validators["SearchSchema"] = queryValidationSpecRequired("tag")
+	h.validators["GetFieldResidency"] = queryValidationSpecRequired()
+	h.validators["GetFieldWrites"] = queryValidationSpecRequired().Optional("since")
+	h.validators["GetOpenState"] = queryValidationSpecRequired()
+	h.validators["GetHealth"] = queryValidationSpecRequired()
+	h.validators["GetMaintenance"] = queryValidationSpecRequired()
+	h.validators["PostMaintenance"] = queryValidationSpecRequired().Optional("cluster")
+	h.validators["GetSettings"] = queryValidationSpecRequired()
+	h.validators["PostSettings"] = queryValidationSpecRequired()
+	h.validators["PostAnalyzeQuery"] = queryValidationSpecRequired()
 	h.validators["PostSchema"] = queryValidationSpecRequired().Optional("remote")
 	h.validators["GetStatus"] = queryValidationSpecRequired()
 	h.validators["GetVersion"] = queryValidationSpecRequired()
@@ -326,6 +415,11 @@ func (h *Handler) populateValidators() {
 	h.validators["GetTransaction"] = queryValidationSpecRequired()
 	h.validators["PostTransaction"] = queryValidationSpecRequired()
 	h.validators["PostFinishTransaction"] = queryValidationSpecRequired()
+	h.validators["GetClientSessions"] = queryValidationSpecRequired().Optional("idle")
+	h.validators["GetStorage"] = queryValidationSpecRequired().Optional("index")
+	h.validators["PostStorageOptimize"] = queryValidationSpecRequired().Optional("index")
+	h.validators["GetNodeDiagnostics"] = queryValidationSpecRequired().Optional("slow")
+	h.validators["DeleteClientSession"] = queryValidationSpecRequired()
 
 }
 
@@ -335,6 +429,7 @@ const (
 	contextKeyQueryRequest contextKeyQuery = iota
 	contextKeyQueryError
 	contextKeyGroupMembership
+	contextKeyQueryBatch
 )
 
 // addQueryContext puts the results of handler.readQueryRequest into the Context for use by
@@ -344,9 +439,17 @@ func (h *Handler) addQueryContext(next http.Handler) http.Handler {
 		pathParts := strings.Split(r.URL.Path, "/")
 		if len(pathParts) > 3 && pathParts[3] == "query" {
 			req, err := h.readQueryRequest(r)
+			if req != nil {
+				ClientSessionFromContext(r.Context()).SetQuery(pathParts[2], req.Query)
+			}
 			ctx := context.WithValue(r.Context(), contextKeyQueryRequest, req)
 			ctx = context.WithValue(ctx, contextKeyQueryError, err)
 			next.ServeHTTP(w, r.WithContext(ctx))
+		} else if r.URL.Path == "/query-batch" {
+			reqs, err := h.readQueryBatchRequest(r)
+			ctx := context.WithValue(r.Context(), contextKeyQueryBatch, reqs)
+			ctx = context.WithValue(ctx, contextKeyQueryError, err)
+			next.ServeHTTP(w, r.WithContext(ctx))
 		} else {
 			next.ServeHTTP(w, r)
 		}
@@ -492,6 +595,7 @@ var latticeRoutes = []string{"/tables", "/query", "/querybuilder", "/signin"} //
 // newRouter creates a new mux http router.
 func newRouter(handler *Handler) http.Handler {
 	router := mux.NewRouter()
+	handler.router = router
 
 	// TODO: figure out how to protect these if needed
 	router.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux).Methods("GET")
@@ -501,6 +605,10 @@ func newRouter(handler *Handler) http.Handler {
 
 	router.HandleFunc("/metrics.json", handler.chkAuthZ(handler.handleGetMetricsJSON, authz.Admin)).Methods("GET").Name("GetMetricsJSON")
 	router.HandleFunc("/export", handler.chkAuthZ(handler.handleGetExport, authz.Read)).Methods("GET").Name("GetExport")
+	router.HandleFunc("/alias", handler.chkAuthZ(handler.handleGetAliases, authz.Read)).Methods("GET").Name("GetAliases")
+	router.HandleFunc("/alias/{alias}", handler.chkAuthZ(handler.handlePostAlias, authz.Admin)).Methods("POST").Name("PostAlias")
+	router.HandleFunc("/alias/{alias}", handler.chkAuthZ(handler.handleDeleteAlias, authz.Admin)).Methods("DELETE").Name("DeleteAlias")
+	router.HandleFunc("/alias/{alias}/swap", handler.chkAuthZ(handler.handlePostSwapAlias, authz.Admin)).Methods("POST").Name("PostSwapAlias")
 	router.HandleFunc("/import-atomic-record", handler.chkAuthZ(handler.handlePostImportAtomicRecord, authz.Admin)).Methods("POST").Name("PostImportAtomicRecord")
 	router.HandleFunc("/index", handler.chkAuthZ(handler.handleGetIndexes, authz.Read)).Methods("GET").Name("GetIndexes")
 	router.HandleFunc("/index", handler.chkAuthZ(handler.handlePostIndex, authz.Admin)).Methods("POST").Name("PostIndex")
@@ -508,6 +616,8 @@ func newRouter(handler *Handler) http.Handler {
 	router.HandleFunc("/index/{index}", handler.chkAuthZ(handler.handleGetIndex, authz.Read)).Methods("GET").Name("GetIndex")
 	router.HandleFunc("/index/{index}", handler.chkAuthZ(handler.handlePostIndex, authz.Admin)).Methods("POST").Name("PostIndex")
 	router.HandleFunc("/index/{index}", handler.chkAuthZ(handler.handleDeleteIndex, authz.Admin)).Methods("DELETE").Name("DeleteIndex")
+	router.HandleFunc("/index/{index}", handler.chkAuthZ(handler.handlePatchIndex, authz.Admin)).Methods("PATCH").Name("PatchIndex")
+	router.HandleFunc("/index/{index}/clone", handler.chkAuthZ(handler.handlePostCloneIndex, authz.Admin)).Methods("POST").Name("PostCloneIndex")
 	//router.HandleFunc("/index/{index}/field", handler.chkAuthZ(handler.handleGetFields, authz.Read)).Methods("GET") // Not implemented.
 	router.HandleFunc("/index/{index}/field", handler.chkAuthZ(handler.handlePostField, authz.Write)).Methods("POST").Name("PostField")
 	router.HandleFunc("/index/{index}/field/", handler.chkAuthZ(handler.handlePostField, authz.Write)).Methods("POST").Name("PostField")
@@ -517,14 +627,48 @@ func newRouter(handler *Handler) http.Handler {
 	router.HandleFunc("/index/{index}/field/{field}", handler.chkAuthZ(handler.handlePatchField, authz.Write)).Methods("PATCH").Name("PatchField")
 	router.HandleFunc("/index/{index}/field/{field}", handler.chkAuthZ(handler.handleDeleteField, authz.Write)).Methods("DELETE").Name("DeleteField")
 	router.HandleFunc("/index/{index}/field/{field}/import", handler.chkAuthZ(handler.handlePostImport, authz.Write)).Methods("POST").Name("PostImport")
+	router.HandleFunc("/index/{index}/field/{field}/row-meta", handler.chkAuthZ(handler.handleGetRowMeta, authz.Read)).Methods("GET").Name("GetRowMeta")
+	router.HandleFunc("/index/{index}/field/{field}/row-meta", handler.chkAuthZ(handler.handlePostRowMeta, authz.Write)).Methods("POST").Name("PostRowMeta")
+	router.HandleFunc("/index/{index}/import-column-attrs", handler.chkAuthZ(handler.handlePostImportColumnAttrs, authz.Write)).Methods("POST").Name("PostImportColumnAttrs")
+	router.HandleFunc("/index/{index}/import-existence", handler.chkAuthZ(handler.handlePostImportExistence, authz.Write)).Methods("POST").Name("PostImportExistence")
 	router.HandleFunc("/index/{index}/field/{field}/mutex-check", handler.chkAuthZ(handler.handleGetMutexCheck, authz.Read)).Methods("GET").Name("GetMutexCheck")
+	router.HandleFunc("/index/{index}/field/{field}/residency", handler.chkAuthZ(handler.handleGetFieldResidency, authz.Admin)).Methods("GET").Name("GetFieldResidency")
+	router.HandleFunc("/index/{index}/field/{field}/writes", handler.chkAuthZ(handler.handleGetFieldWrites, authz.Read)).Methods("GET").Name("GetFieldWrites")
+	router.HandleFunc("/index/{index}/field/{field}/cache", handler.chkAuthZ(handler.handleGetFieldCache, authz.Read)).Methods("GET").Name("GetFieldCache")
+	router.HandleFunc("/index/{index}/stats", handler.chkAuthZ(handler.handleGetIndexStats, authz.Read)).Methods("GET").Name("GetIndexStats")
+	router.HandleFunc("/index/{index}/consistency", handler.chkAuthZ(handler.handleGetConsistency, authz.Read)).Methods("GET").Name("GetConsistency")
+	router.HandleFunc("/index/{index}/field/{field}/key-collisions", handler.chkAuthZ(handler.handleGetKeyCollisions, authz.Read)).Methods("GET").Name("GetKeyCollisions")
+	router.HandleFunc("/index/{index}/field/{field}/merge-keys", handler.chkAuthZ(handler.handlePostMergeKeys, authz.Admin)).Methods("POST").Name("PostMergeKeys")
 	router.HandleFunc("/index/{index}/field/{field}/import-roaring/{shard}", handler.chkAuthZ(handler.handlePostImportRoaring, authz.Write)).Methods("POST").Name("PostImportRoaring")
 	router.HandleFunc("/index/{index}/shard/{shard}/import-roaring", handler.chkAuthZ(handler.handlePostShardImportRoaring, authz.Write)).Methods("POST").Name("PostImportRoaring")
 	router.HandleFunc("/index/{index}/query", handler.chkAuthZ(handler.handlePostQuery, authz.Read)).Methods("POST").Name("PostQuery")
+	router.HandleFunc("/index/{index}/analyze", handler.chkAuthZ(handler.handlePostAnalyzeQuery, authz.Read)).Methods("POST").Name("PostAnalyzeQuery")
+	router.HandleFunc("/index/{index}/import-session", handler.chkAuthZ(handler.handlePostImportSession, authz.Write)).Methods("POST").Name("PostImportSession")
+	router.HandleFunc("/index/{index}/import-session/{id}", handler.chkAuthZ(handler.handleGetImportSession, authz.Read)).Methods("GET").Name("GetImportSession")
+	router.HandleFunc("/index/{index}/import-session/{id}", handler.chkAuthZ(handler.handleDeleteImportSession, authz.Write)).Methods("DELETE").Name("DeleteImportSession")
+	router.HandleFunc("/index/{index}/import-session/{id}/part/{part}", handler.chkAuthZ(handler.handlePutImportSessionPart, authz.Write)).Methods("PUT").Name("PutImportSessionPart")
+	router.HandleFunc("/index/{index}/import-session/{id}/commit", handler.chkAuthZ(handler.handlePostImportSessionCommit, authz.Write)).Methods("POST").Name("PostImportSessionCommit")
+	router.HandleFunc("/index/{index}/generate", handler.chkAuthZ(handler.handlePostGenerateData, authz.Admin)).Methods("POST").Name("PostGenerateData")
+	router.HandleFunc("/index/{index}/generate-load", handler.chkAuthZ(handler.handlePostGenerateLoad, authz.Admin)).Methods("POST").Name("PostGenerateLoad")
+	router.HandleFunc("/index/{index}/archive", handler.chkAuthZ(handler.handleGetIndexArchive, authz.Admin)).Methods("GET").Name("GetIndexArchive")
+	router.HandleFunc("/archive", handler.chkAuthZ(handler.handlePostArchive, authz.Admin)).Methods("POST").Name("PostArchive")
+	router.HandleFunc("/query-batch", handler.chkAuthZ(handler.handlePostQueryBatch, authz.Read)).Methods("POST").Name("PostQueryBatch")
+	router.HandleFunc("/export", handler.chkAuthZ(handler.handlePostExport, authz.Read)).Methods("POST").Name("PostExport")
+	router.HandleFunc("/reindex", handler.chkAuthZ(handler.handlePostReindex, authz.Admin)).Methods("POST").Name("PostReindex")
+	router.HandleFunc("/reindex/{id}", handler.chkAuthZ(handler.handleGetReindex, authz.Admin)).Methods("GET").Name("GetReindex")
+	router.HandleFunc("/usage", handler.chkAuthZ(handler.handleGetUsage, authz.Read)).Methods("GET").Name("GetUsageReport")
+	router.HandleFunc("/usage", handler.chkAuthZ(handler.handlePostUsage, authz.Read)).Methods("POST").Name("PostUsageReport")
+	router.HandleFunc("/health", handler.chkAuthZ(handler.handleGetHealth, authz.Read)).Methods("GET").Name("GetHealth")
 	router.HandleFunc("/info", handler.chkAuthZ(handler.handleGetInfo, authz.Admin)).Methods("GET").Name("GetInfo")
+	router.HandleFunc("/maintenance", handler.chkAuthZ(handler.handleGetMaintenance, authz.Admin)).Methods("GET").Name("GetMaintenance")
+	router.HandleFunc("/maintenance", handler.chkAuthZ(handler.handlePostMaintenance, authz.Admin)).Methods("POST").Name("PostMaintenance")
+	router.HandleFunc("/settings", handler.chkAuthZ(handler.handleGetSettings, authz.Admin)).Methods("GET").Name("GetSettings")
+	router.HandleFunc("/settings", handler.chkAuthZ(handler.handlePostSettings, authz.Admin)).Methods("POST").Name("PostSettings")
+	router.HandleFunc("/open-state", handler.chkAuthZ(handler.handleGetOpenState, authz.Admin)).Methods("GET").Name("GetOpenState")
 	router.HandleFunc("/recalculate-caches", handler.chkAuthZ(handler.handleRecalculateCaches, authz.Admin)).Methods("POST").Name("RecalculateCaches")
 	router.HandleFunc("/schema", handler.chkAuthZ(handler.handleGetSchema, authz.Read)).Methods("GET").Name("GetSchema")
 	router.HandleFunc("/schema/details", handler.chkAuthZ(handler.handleGetSchemaDetails, authz.Read)).Methods("GET").Name("GetSchemaDetails")
+	router.HandleFunc("/schema/search", handler.chkAuthZ(handler.handleGetSchemaSearch, authz.Read)).Methods("GET").Name("SearchSchema")
 	router.HandleFunc("/schema", handler.chkAuthZ(handler.handlePostSchema, authz.Admin)).Methods("POST").Name("PostSchema")
 	router.HandleFunc("/status", handler.chkAuthZ(handler.handleGetStatus, authz.Read)).Methods("GET").Name("GetStatus")
 	router.HandleFunc("/transaction", handler.chkAuthZ(handler.handlePostTransaction, authz.Read)).Methods("POST").Name("PostTransaction")
@@ -533,7 +677,13 @@ func newRouter(handler *Handler) http.Handler {
 	router.HandleFunc("/transaction/{id}", handler.chkAuthZ(handler.handlePostTransaction, authz.Read)).Methods("POST").Name("PostTransaction")
 	router.HandleFunc("/transaction/{id}/finish", handler.chkAuthZ(handler.handlePostFinishTransaction, authz.Read)).Methods("POST").Name("PostFinishTransaction")
 	router.HandleFunc("/transactions", handler.chkAuthZ(handler.handleGetTransactions, authz.Read)).Methods("GET").Name("GetTransactions")
+	router.HandleFunc("/canary", handler.chkAuthZ(handler.handleGetCanary, authz.Admin)).Methods("GET").Name("GetCanary")
 	router.HandleFunc("/queries", handler.chkAuthZ(handler.handleGetActiveQueries, authz.Admin)).Methods("GET").Name("GetActiveQueries")
+	router.HandleFunc("/client-sessions", handler.chkAuthZ(handler.handleGetClientSessions, authz.Admin)).Methods("GET").Name("GetClientSessions")
+	router.HandleFunc("/client-sessions/{id}", handler.chkAuthZ(handler.handleDeleteClientSession, authz.Admin)).Methods("DELETE").Name("DeleteClientSession")
+	router.HandleFunc("/diagnostics", handler.chkAuthZ(handler.handleGetNodeDiagnostics, authz.Admin)).Methods("GET").Name("GetNodeDiagnostics")
+	router.HandleFunc("/storage", handler.chkAuthZ(handler.handleGetStorage, authz.Admin)).Methods("GET").Name("GetStorage")
+	router.HandleFunc("/storage/optimize", handler.chkAuthZ(handler.handlePostStorageOptimize, authz.Admin)).Methods("POST").Name("PostStorageOptimize")
 
 	// enable this endpoint based on config
 	if handler.sqlEnabled {
@@ -542,6 +692,7 @@ func newRouter(handler *Handler) http.Handler {
 
 	router.HandleFunc("/query-history", handler.chkAuthZ(handler.handleGetPastQueries, authz.Admin)).Methods("GET").Name("GetPastQueries")
 	router.HandleFunc("/version", handler.handleGetVersion).Methods("GET").Name("GetVersion")
+	router.HandleFunc("/openapi.json", handler.chkAuthZ(handler.handleGetOpenAPI, authz.Read)).Methods("GET").Name("GetOpenAPI")
 
 	// /ui endpoints are for UI use; they may change at any time.
 	router.HandleFunc("/ui/transaction", handler.chkAuthZ(handler.handleGetTransactionList, authz.Read)).Methods("GET").Name("GetTransactionList")
@@ -568,6 +719,15 @@ func newRouter(handler *Handler) http.Handler {
 	router.HandleFunc("/internal/translate/keys", handler.chkAuthN(handler.handlePostTranslateKeys)).Methods("POST").Name("PostTranslateKeys")
 	router.HandleFunc("/internal/translate/ids", handler.chkAuthN(handler.handlePostTranslateIDs)).Methods("POST").Name("PostTranslateIDs")
 	router.HandleFunc("/internal/index/{index}/field/{field}/mutex-check", handler.chkAuthZ(handler.handleInternalGetMutexCheck, authz.Read)).Methods("GET").Name("InternalGetMutexCheck")
+	router.HandleFunc("/internal/export-part", handler.chkAuthZ(handler.handlePostExportPart, authz.Read)).Methods("POST").Name("PostExportPart")
+	router.HandleFunc("/internal/reindex-shard", handler.chkAuthZ(handler.handlePostReindexShard, authz.Admin)).Methods("POST").Name("PostReindexShard")
+	router.HandleFunc("/internal/usage", handler.chkAuthZ(handler.handleInternalGetUsage, authz.Read)).Methods("GET").Name("InternalGetUsageReport")
+	router.HandleFunc("/internal/index/{index}/column-attrs", handler.chkAuthZ(handler.handleInternalPostColumnAttrs, authz.Write)).Methods("POST").Name("InternalPostColumnAttrs")
+	router.HandleFunc("/internal/index/{index}/column-attrs/get", handler.chkAuthZ(handler.handleInternalPostColumnAttrsGet, authz.Read)).Methods("POST").Name("InternalPostColumnAttrsGet")
+	router.HandleFunc("/internal/index/{index}/field/{field}/writes", handler.chkAuthZ(handler.handleInternalGetFieldWrites, authz.Read)).Methods("GET").Name("InternalGetFieldWrites")
+	router.HandleFunc("/internal/index/{index}/stats", handler.chkAuthZ(handler.handlePostInternalIndexStats, authz.Read)).Methods("POST").Name("PostInternalIndexStats")
+	router.HandleFunc("/internal/index/{index}/consistency", handler.chkAuthZ(handler.handleInternalGetConsistency, authz.Read)).Methods("GET").Name("InternalGetConsistency")
+	router.HandleFunc("/internal/index/{index}/field/{field}/merge-keys", handler.chkAuthZ(handler.handleInternalPostMergeKeys, authz.Admin)).Methods("POST").Name("InternalPostMergeKeys")
 	router.HandleFunc("/internal/index/{index}/field/{field}/remote-available-shards/{shardID}", handler.chkAuthZ(handler.handleDeleteRemoteAvailableShard, authz.Admin)).Methods("DELETE")
 	router.HandleFunc("/internal/index/{index}/shard/{shard}/snapshot", handler.chkAuthZ(handler.handleGetIndexShardSnapshot, authz.Read)).Methods("GET").Name("GetIndexShardSnapshot")
 	router.HandleFunc("/internal/index/{index}/shards", handler.chkAuthZ(handler.handleGetIndexAvailableShards, authz.Read)).Methods("GET").Name("GetIndexAvailableShards")
@@ -579,6 +739,7 @@ func newRouter(handler *Handler) http.Handler {
 	router.HandleFunc("/internal/schema", handler.chkAuthZ(handler.handleIngestSchema, authz.Admin)).Methods("POST").Name("PostIngestSchema")
 	router.HandleFunc("/internal/translate/index/{index}/keys/find", handler.chkAuthZ(handler.handleFindIndexKeys, authz.Admin)).Methods("POST").Name("FindIndexKeys")
 	router.HandleFunc("/internal/translate/index/{index}/keys/create", handler.chkAuthZ(handler.handleCreateIndexKeys, authz.Admin)).Methods("POST").Name("CreateIndexKeys")
+	router.HandleFunc("/internal/translate/index/{index}/keys/prefix-shards", handler.chkAuthZ(handler.handleKeyPrefixShards, authz.Read)).Methods("POST").Name("KeyPrefixShards")
 	router.HandleFunc("/internal/translate/index/{index}/{partition}", handler.chkAuthZ(handler.handlePostTranslateIndexDB, authz.Admin)).Methods("POST").Name("PostTranslateIndexDB")
 	router.HandleFunc("/internal/translate/field/{index}/{field}", handler.chkAuthZ(handler.handlePostTranslateFieldDB, authz.Admin)).Methods("POST").Name("PostTranslateFieldDB")
 	router.HandleFunc("/internal/translate/field/{index}/{field}/keys/find", handler.chkAuthZ(handler.handleFindFieldKeys, authz.Admin)).Methods("POST").Name("FindFieldKeys")
@@ -621,6 +782,7 @@ func newRouter(handler *Handler) http.Handler {
 		router.Path(route).Handler(latticeHandler)
 	}
 
+	router.Use(handler.checkProtocol)
 	router.Use(handler.queryArgValidator)
 	router.Use(handler.addQueryContext)
 	router.Use(handler.extractTracing)
@@ -652,6 +814,9 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
+	if cs := ClientSessionFromContext(r.Context()); cs != nil {
+		w = &clientSessionWriter{ResponseWriter: w, session: cs}
+	}
 	h.Handler.ServeHTTP(w, r)
 }
 
@@ -761,19 +926,33 @@ func (h *Handler) chkAuthZ(handler http.HandlerFunc, perm authz.Permission) http
 		queryString := ""
 		queryRequest := ctx.Value(contextKeyQueryRequest)
 		if req, ok := queryRequest.(*QueryRequest); ok {
+			if !req.Remote {
+				req.Client = uinfo.UserName
+			}
 			queryString = req.Query
-
-			q, err := pql.ParseString(queryString)
-			if err != nil {
+			if lperm, err = queryPermission(lperm, req); err != nil {
 				http.Error(w, errors.Wrap(err, "parsing query string").Error(), http.StatusBadRequest)
 				return
 			}
+		}
 
-			// if there are write calls, and the needed perms don't already
-			// satisfy write permissions, then make them write permissions
-			if q.WriteCallN() > 0 && !lperm.Satisfies(authz.Write) {
-				lperm = authz.Write
+		// each query of a batch is checked against its own index below
+		batch, _ := ctx.Value(contextKeyQueryBatch).([]*QueryRequest)
+		batchPerms := make([]authz.Permission, len(batch))
+		batchQueries := make([]string, len(batch))
+		for i, req := range batch {
+			req.Client = uinfo.UserName
+			if batchPerms[i], err = queryPermission(perm, req); err != nil {
+				http.Error(w, errors.Wrapf(err, "parsing query string %d", i).Error(), http.StatusBadRequest)
+				return
 			}
+			if batchPerms[i] == authz.Admin {
+				lperm = authz.Admin
+			}
+			batchQueries[i] = req.Query
+		}
+		if len(batch) > 0 {
+			queryString = strings.Join(batchQueries, "; ")
 		}
 		// make the query string pretty
 		queryString = strings.Replace(queryString, "\n", "", -1)
@@ -810,7 +989,7 @@ func (h *Handler) chkAuthZ(handler http.HandlerFunc, perm authz.Permission) http
 		// if we have an index name, then we check the user permissions
 		// against that index
 		if indexName != "" {
-			p, err := h.permissions.GetPermissions(uinfo, indexName)
+			p, err := h.api.IndexPermission(h.permissions, uinfo, indexName)
 			if err != nil {
 				w.Header().Add("Content-Type", "text/plain")
 				http.Error(w, errors.Wrap(err, "Insufficient Permissions").Error(), http.StatusForbidden)
@@ -824,11 +1003,45 @@ func (h *Handler) chkAuthZ(handler http.HandlerFunc, perm authz.Permission) http
 				return
 			}
 		}
+		for i, req := range batch {
+			p, err := h.api.IndexPermission(h.permissions, uinfo, req.Index)
+			if err != nil {
+				w.Header().Add("Content-Type", "text/plain")
+				http.Error(w, errors.Wrap(err, "Insufficient Permissions").Error(), http.StatusForbidden)
+				return
+			}
+			if !p.Satisfies(batchPerms[i]) {
+				w.Header().Add("Content-Type", "text/plain")
+				http.Error(w, fmt.Sprintf("Insufficient permissions for query %d", i), http.StatusForbidden)
+				return
+			}
+		}
 		handler.ServeHTTP(w, r.WithContext(ctx))
 
 	}
 }
 
+// queryPermission returns the permission needed to run req, given the
+// permission needed by its route.
+func queryPermission(perm authz.Permission, req *QueryRequest) (authz.Permission, error) {
+	q, err := pql.ParseString(req.Query)
+	if err != nil {
+		return perm, err
+	}
+
+	// if there are write calls, and the needed perms don't already
+	// satisfy write permissions, then make them write permissions
+	if q.WriteCallN() > 0 && !perm.Satisfies(authz.Write) {
+		perm = authz.Write
+	}
+
+	// only admins may bypass the index's result limits
+	if req.IgnoreResultLimits {
+		perm = authz.Admin
+	}
+	return perm, nil
+}
+
 func GetIP(r *http.Request) string {
 	// check if original IP was set in the request
 	og := r.Header.Get(OriginalIPHeader)
@@ -946,6 +1159,10 @@ func (r *successResponse) check(err error) (statusCode int) {
 	default:
 		statusCode = http.StatusInternalServerError
 	}
+	// Writes refused while writes are paused can be retried later.
+	if cause == ErrIndexReadOnly || cause == ErrMaintenance {
+		statusCode = http.StatusServiceUnavailable
+	}
 
 	r.Success = false
 	r.Error = &HTTPError{Message: err.Error()}
@@ -1037,7 +1254,7 @@ func headerAcceptRoaringRow(header http.Header) bool {
 func (h *Handler) filterSchema(schema []*IndexInfo, g []authn.Group) []*IndexInfo {
 	if !h.permissions.IsAdmin(g) {
 		var filtered []*IndexInfo
-		allowed := h.permissions.GetAuthorizedIndexList(g, authz.Read)
+		allowed := h.api.AuthorizedIndexes(h.permissions, g, authz.Read)
 		for _, s := range schema {
 			for _, index := range allowed {
 				if s.Name == index {
@@ -1083,6 +1300,43 @@ func (h *Handler) handleGetSchema(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.logger.Printf("getting schema error: %s", err)
 	}
+	if namespace := q.Get("namespace"); namespace != "" {
+		schema = namespaceSchema(schema, namespace)
+	}
+
+	// if auth is turned on, filter response to only include authorized indexes
+	if h.auth != nil {
+		g := h.getGroupMembership(r)
+		if g == nil {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		schema = h.filterSchema(schema, g)
+	}
+
+	if err := json.NewEncoder(w).Encode(Schema{Indexes: schema}); err != nil {
+		h.logger.Errorf("write schema response error: %s", err)
+	}
+}
+
+// handleGetSchemaSearch handles GET /schema/search requests, returning the
+// indexes and fields with a tag matching the "tag" query parameter.
+func (h *Handler) handleGetSchemaSearch(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	schema, err := h.api.SearchSchema(r.Context(), r.URL.Query().Get("tag"))
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
 
 	// if auth is turned on, filter response to only include authorized indexes
 	if h.auth != nil {
@@ -1094,6 +1348,7 @@ func (h *Handler) handleGetSchema(w http.ResponseWriter, r *http.Request) {
 		schema = h.filterSchema(schema, g)
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(Schema{Indexes: schema}); err != nil {
 		h.logger.Errorf("write schema response error: %s", err)
 	}
@@ -1297,6 +1552,9 @@ func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	var err error
 	err, _ = qerr.(error)
 	if err != nil || !ok {
+		if err != nil {
+			err = NewBadRequestError(err)
+		}
 		w.WriteHeader(http.StatusBadRequest)
 		e := h.writeQueryResponse(w, r, &QueryResponse{Err: err})
 		if e != nil {
@@ -1304,14 +1562,32 @@ func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	if _, err := queryFormat(r); err != nil {
+		h.writeBadRequest(w, r, err)
+		return
+	}
 	// TODO: Remove
 	req.Index = mux.Vars(r)["index"]
 
 	resp, err := h.api.Query(r.Context(), req)
+	if req.Remote {
+		// Let the coordinating node know how busy this node is.
+		if load, ok := h.api.nodeLoad(); ok {
+			w.Header().Set(headerNodeLoad, load.String())
+		}
+	}
 	if err != nil {
 		switch errors.Cause(err) {
-		case ErrTooManyWrites:
+		case ErrTooManyWrites, ErrResultLimitExceeded:
 			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		case ErrNamespaceQuotaExceeded:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case ErrStorageQuotaExceeded, ErrReadOnly:
+			w.WriteHeader(http.StatusInsufficientStorage)
+		case ErrIndexReadOnly, ErrMaintenance:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case ErrQueryDenied:
+			w.WriteHeader(http.StatusForbidden)
 		case ErrTranslateStoreReadOnly:
 			u := h.api.PrimaryReplicaNodeURL()
 			u.Path, u.RawQuery = r.URL.Path, r.URL.RawQuery
@@ -1345,6 +1621,83 @@ func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// queryBatchRequest is the body of a /query-batch request.
+type queryBatchRequest struct {
+	Queries []struct {
+		Index             string   `json:"index"`
+		Query             string   `json:"query"`
+		Shards            []uint64 `json:"shards,omitempty"`
+		Profile           bool     `json:"profile,omitempty"`
+		IncludeMeta       bool     `json:"includeMeta,omitempty"`
+		ExistenceFallback string   `json:"existenceFallback,omitempty"`
+		Priority          string   `json:"priority,omitempty"`
+		AllowPartial      bool     `json:"allowPartial,omitempty"`
+		RejectNewKeys     bool     `json:"rejectNewKeys,omitempty"`
+	} `json:"queries"`
+}
+
+// readQueryBatchRequest parses the queries of a /query-batch request.
+func (h *Handler) readQueryBatchRequest(r *http.Request) ([]*QueryRequest, error) {
+	var body queryBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, errors.Wrap(err, "decoding request")
+	}
+	reqs := make([]*QueryRequest, len(body.Queries))
+	for i, q := range body.Queries {
+		if q.Index == "" {
+			return nil, errors.Wrapf(ErrIndexRequired, "query %d", i)
+		}
+		reqs[i] = &QueryRequest{
+			Index:   q.Index,
+			Query:   q.Query,
+			Shards:  q.Shards,
+			Profile: q.Profile,
+
+			IncludeRowMeta:    q.IncludeMeta,
+			ExistenceFallback: q.ExistenceFallback,
+			Priority:          q.Priority,
+			AllowPartial:      q.AllowPartial,
+			RejectNewKeys:     q.RejectNewKeys,
+			Client:            requestClient(r),
+		}
+	}
+	return reqs, nil
+}
+
+// handlePostQueryBatch handles /query-batch requests, running each of the
+// queries in the body and returning their responses, in order, under
+// "responses". The status is 200 even if some of the queries fail; their
+// responses hold their errors.
+func (h *Handler) handlePostQueryBatch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	reqs, _ := r.Context().Value(contextKeyQueryBatch).([]*QueryRequest)
+	if err, _ := r.Context().Value(contextKeyQueryError).(error); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		if e := h.writeJSONQueryResponse(w, &QueryResponse{Err: NewBadRequestError(err)}); e != nil {
+			h.logger.Errorf("write query batch response error: %v (while trying to write another error: %v)", e, err)
+		}
+		return
+	}
+
+	resps, err := h.api.QueryBatch(r.Context(), reqs)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		if e := h.writeJSONQueryResponse(w, &QueryResponse{Err: err}); e != nil {
+			h.logger.Errorf("write query batch response error: %v (while trying to write another error: %v)", e, err)
+		}
+		return
+	}
+	body := struct {
+		Responses []*QueryResponse `json:"responses"`
+	}{Responses: make([]*QueryResponse, len(resps))}
+	for i := range resps {
+		body.Responses[i] = &resps[i]
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.logger.Errorf("write query batch response error: %s", err)
+	}
+}
+
 func (h *Handler) writeBadRequest(w http.ResponseWriter, r *http.Request, err error) {
 	w.WriteHeader(http.StatusBadRequest)
 	e := h.writeQueryResponse(w, r, &QueryResponse{Err: err})
@@ -1363,6 +1716,7 @@ func (h *Handler) handlePostSQL(w http.ResponseWriter, r *http.Request) {
 		h.writeBadRequest(w, r, err)
 		return
 	}
+	ClientSessionFromContext(r.Context()).SetQuery("", string(b))
 
 	start := time.Now()
 
@@ -1717,6 +2071,10 @@ func getValidOptions(option interface{}) []string {
 	validOptions := []string{}
 	val := reflect.ValueOf(option)
 	for i := 0; i < val.Type().NumField(); i++ {
+		if val.Type().Field(i).Anonymous {
+			validOptions = append(validOptions, getValidOptions(val.Field(i).Interface())...)
+			continue
+		}
 		jsonTag := val.Type().Field(i).Tag.Get("json")
 		s := strings.Split(jsonTag, ",")
 		validOptions = append(validOptions, s[0])
@@ -1782,14 +2140,24 @@ func (h *Handler) handlePostIndex(w http.ResponseWriter, r *http.Request) {
 
 	resp := successResponse{h: h, Name: indexName}
 
-	// Decode request.
+	// Decode request, with the options of the server's defaults and of
+	// any template it names.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		resp.write(w, err)
+		return
+	}
+	if body, err = h.api.holder.cfg.OptionTemplates.apply(body, indexOptionTemplate); err != nil {
+		resp.write(w, err)
+		return
+	}
 	req := postIndexRequest{
 		Options: IndexOptions{
 			Keys:           false,
 			TrackExistence: true,
 		},
 	}
-	err := json.NewDecoder(r.Body).Decode(&req)
+	err = json.NewDecoder(bytes.NewReader(body)).Decode(&req)
 	if err != nil && err != io.EOF {
 		resp.write(w, err)
 		return
@@ -1960,6 +2328,42 @@ func fieldOptionsToFunctionalOpts(opt fieldOptions) []FieldOption {
 	if opt.ForeignIndex != nil {
 		fos = append(fos, OptFieldForeignIndex(*opt.ForeignIndex))
 	}
+	if opt.Description != "" {
+		fos = append(fos, OptFieldDescription(opt.Description))
+	}
+	if opt.Owner != "" {
+		fos = append(fos, OptFieldOwner(opt.Owner))
+	}
+	if len(opt.Tags) > 0 {
+		fos = append(fos, OptFieldTags(opt.Tags))
+	}
+	if opt.MemoryPolicy != "" || len(opt.MemoryPolicyViews) > 0 {
+		fos = append(fos, OptFieldMemoryPolicy(opt.MemoryPolicy, opt.MemoryPolicyViews...))
+	}
+	if opt.CacheStaleness != nil {
+		fos = append(fos, OptFieldCacheStaleness(*opt.CacheStaleness))
+	}
+	if opt.Derive != nil {
+		fos = append(fos, OptFieldDerive(*opt.Derive))
+	}
+	if opt.History != nil {
+		fos = append(fos, OptFieldHistory(*opt.History))
+	}
+	if opt.Cascade != nil {
+		fos = append(fos, OptFieldCascade(*opt.Cascade))
+	}
+	if opt.TrackDistinct != nil && *opt.TrackDistinct {
+		fos = append(fos, OptFieldTrackDistinct())
+	}
+	if opt.TimeZone != nil {
+		fos = append(fos, OptFieldTimeZone(*opt.TimeZone))
+	}
+	if opt.FiscalYearStart != nil {
+		fos = append(fos, OptFieldFiscalYearStart(*opt.FiscalYearStart))
+	}
+	if opt.RejectNewKeys != nil && *opt.RejectNewKeys {
+		fos = append(fos, OptFieldRejectNewKeys())
+	}
 	return fos
 }
 
@@ -1984,11 +2388,21 @@ func (h *Handler) handlePostField(w http.ResponseWriter, r *http.Request) {
 
 	resp := successResponse{h: h, Name: fieldName}
 
-	// Decode request.
+	// Decode request, with the options of the server's defaults and of
+	// any template it names.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		resp.write(w, err)
+		return
+	}
+	if body, err = h.api.holder.cfg.OptionTemplates.apply(body, fieldOptionTemplate); err != nil {
+		resp.write(w, err)
+		return
+	}
 	var req postFieldRequest
-	dec := json.NewDecoder(r.Body)
+	dec := json.NewDecoder(bytes.NewReader(body))
 	dec.DisallowUnknownFields()
-	err := dec.Decode(&req)
+	err = dec.Decode(&req)
 	if err != nil && err != io.EOF {
 		resp.write(w, err)
 		return
@@ -2051,9 +2465,9 @@ func (h *Handler) handlePatchField(w http.ResponseWriter, r *http.Request) {
 	resp.write(w, err)
 }
 
-// handlePostIngestData handles JSON ingest data that may need key
-// translation, for the entire cluster.
-func (h *Handler) handlePostIngestData(w http.ResponseWriter, r *http.Request) {
+// handlePatchIndex handles PATCH /index/{index} requests, which change an
+// option of the index.
+func (h *Handler) handlePatchIndex(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
 		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
@@ -2065,105 +2479,404 @@ func (h *Handler) handlePostIngestData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	qcx := h.api.Txf().NewQcx()
-	err := h.api.IngestOperations(r.Context(), qcx, indexName, r.Body)
-	if err != nil {
-		qcx.Abort()
-		switch e := err.(type) {
-		case RedirectError:
-			http.Redirect(w, r, e.HostPort+r.URL.Path, http.StatusPermanentRedirect)
-			return
-		}
-	}
-	err = qcx.Finish()
-	if err != nil {
-		http.Error(w, fmt.Sprintf("ingesting: %v", err), http.StatusInternalServerError)
+	resp := successResponse{h: h, Name: indexName}
+
+	// Decode request.
+	var req IndexUpdate
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&req)
+	if err != nil && err != io.EOF {
+		resp.write(w, NewBadRequestError(err))
 		return
 	}
 
-	resp := successResponse{h: h, Name: indexName}
+	err = h.api.UpdateIndex(r.Context(), indexName, req)
 	resp.write(w, err)
 }
 
-type ingestSpec struct {
-	IndexName      string      `json:"index-name"`
-	IndexAction    string      `json:"index-action"`
-	FieldAction    string      `json:"field-action"`
-	PrimaryKeyType string      `json:"primary-key-type"`
-	Fields         []fieldSpec `json:"fields"`
+// rowMetaResponse is the body of a GET /index/{index}/field/{field}/row-meta
+// response, and of a POST request to the same path.
+type rowMetaResponse struct {
+	Row  interface{}     `json:"row"`
+	Meta json.RawMessage `json:"meta"`
 }
 
-type fieldSpec struct {
-	FieldName    string          `json:"field-name"`
-	FieldType    string          `json:"field-type"`
-	FieldOptions fieldOptionSpec `json:"field-options"`
-}
+// handleGetRowMeta handles GET /index/{index}/field/{field}/row-meta requests.
+func (h *Handler) handleGetRowMeta(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
 
-type fieldOptionSpec struct {
-	EnforceMutualExclusion bool       `json:"enforce-mutual-exclusion"`
-	CacheType              *string    `json:"cache-type"`
-	CacheSize              *uint32    `json:"cache-size"`
-	Scale                  *int64     `json:"scale"`
-	Epoch                  *time.Time `json:"epoch"`
-	Unit                   *string    `json:"unit"`
-	TimeQuantum            *string    `json:"time-quantum"`
-	TTL                    *string    `json:"ttl"`
+	indexName, fieldName := mux.Vars(r)["index"], mux.Vars(r)["field"]
+	row := r.URL.Query().Get("row")
+
+	meta, err := h.api.RowMeta(r.Context(), indexName, fieldName, row)
+	if err != nil {
+		resp := successResponse{h: h}
+		resp.write(w, err)
+		return
+	}
+	if meta == nil {
+		meta = json.RawMessage("null")
+	}
+	if err := json.NewEncoder(w).Encode(rowMetaResponse{Row: row, Meta: meta}); err != nil {
+		h.logger.Errorf("writing row-meta response: %v", err)
+	}
 }
 
-func fieldSpecToFieldOption(fSpec fieldSpec) fieldOptions {
-	opt := fieldOptions{}
-	// map fSpec type name to pilosa type name
+// handlePostRowMeta handles POST /index/{index}/field/{field}/row-meta
+// requests. The row may be given as a string key or a numeric ID.
+func (h *Handler) handlePostRowMeta(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
 
-	// a string field could be a string set, mutex or time quantum
-	if fSpec.FieldOptions.TimeQuantum != nil {
-		opt.Type = "time"
-	} else if fSpec.FieldOptions.EnforceMutualExclusion {
-		opt.Type = "mutex"
-	} else {
-		opt.Type = "set"
+	indexName, fieldName := mux.Vars(r)["index"], mux.Vars(r)["field"]
+	resp := successResponse{h: h}
+
+	var req rowMetaResponse
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+	if err := dec.Decode(&req); err != nil {
+		resp.write(w, NewBadRequestError(errors.Wrap(err, "decoding request")))
+		return
 	}
 
-	// for other field types, there's a one-to-one mapping
-	if fSpec.FieldType != "string" && fSpec.FieldType != "id" {
-		opt.Type = fSpec.FieldType
+	var row string
+	switch v := req.Row.(type) {
+	case string:
+		row = v
+	case json.Number:
+		row = v.String()
+	default:
+		resp.write(w, NewBadRequestError(errors.New("row must be a string key or an integer ID")))
+		return
 	}
 
-	if fSpec.FieldType == "string" {
-		var keys bool = true
-		opt.Keys = &keys
+	err := h.api.SetRowMeta(r.Context(), indexName, fieldName, row, req.Meta)
+	resp.write(w, err)
+}
+
+// handlePostImportColumnAttrs handles POST /index/{index}/import-column-attrs
+// requests, setting the attributes of columns.
+func (h *Handler) handlePostImportColumnAttrs(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
 	}
-	opt.CacheType = fSpec.FieldOptions.CacheType
-	opt.CacheSize = fSpec.FieldOptions.CacheSize
-	opt.Scale = fSpec.FieldOptions.Scale
-	opt.Epoch = fSpec.FieldOptions.Epoch
-	opt.TimeUnit = fSpec.FieldOptions.Unit
-	if fSpec.FieldOptions.TimeQuantum != nil {
-		timeQuantumVal := TimeQuantum(*fSpec.FieldOptions.TimeQuantum)
-		opt.TimeQuantum = &timeQuantumVal
+	resp := successResponse{h: h}
+
+	var req ImportColumnAttrsRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+	if err := dec.Decode(&req); err != nil {
+		resp.write(w, NewBadRequestError(errors.Wrap(err, "decoding request")))
+		return
 	}
-	opt.TTL = fSpec.FieldOptions.TTL
 
-	return opt
+	err := h.api.ImportColumnAttrs(r.Context(), mux.Vars(r)["index"], &req)
+	resp.write(w, err)
 }
 
-func (h *Handler) handleIngestSchema(w http.ResponseWriter, r *http.Request) {
+// handlePostImportExistence handles POST /index/{index}/import-existence
+// requests, making columns exist without setting any field's values.
+func (h *Handler) handlePostImportExistence(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
 		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
-
 	resp := successResponse{h: h}
 
+	var req ImportExistenceRequest
 	dec := json.NewDecoder(r.Body)
 	dec.DisallowUnknownFields()
-	schema := ingestSpec{}
-	// if a key in cleanupIndexes points to a 0-length slice, the
-	// entire index should be cleaned; otherwise, only the named
-	// fields within that index should be cleaned.
-	cleanupIndexes := map[string][]string{}
-	var schemaErr error
-	defer func() {
-		// we set schemaErr in any case where we need to do cleanup
+	if err := dec.Decode(&req); err != nil {
+		resp.write(w, NewBadRequestError(errors.Wrap(err, "decoding request")))
+		return
+	}
+
+	err := h.api.ImportExistence(r.Context(), mux.Vars(r)["index"], &req)
+	resp.write(w, err)
+}
+
+// handleInternalPostColumnAttrs handles internal /column-attrs requests,
+// setting the attributes of columns on this node only.
+func (h *Handler) handleInternalPostColumnAttrs(w http.ResponseWriter, r *http.Request) {
+	resp := successResponse{h: h}
+
+	var attrs map[uint64]map[string]interface{}
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&attrs); err != nil {
+		resp.write(w, NewBadRequestError(errors.Wrap(err, "decoding request")))
+		return
+	}
+
+	err := h.api.ImportColumnAttrsNode(r.Context(), mux.Vars(r)["index"], attrs)
+	resp.write(w, err)
+}
+
+// handleInternalPostColumnAttrsGet handles internal /column-attrs/get
+// requests, returning the attributes this node holds for the columns in
+// the request body.
+func (h *Handler) handleInternalPostColumnAttrsGet(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	var cols []uint64
+	if err := json.NewDecoder(r.Body).Decode(&cols); err != nil {
+		http.Error(w, "decoding request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	attrs, err := h.api.ColumnAttrsNode(r.Context(), mux.Vars(r)["index"], cols)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(attrs); err != nil {
+		h.logger.Errorf("writing column attrs response: %v", err)
+	}
+}
+
+// aliasRequest is the body of a POST /alias/{alias} or
+// /alias/{alias}/swap request.
+type aliasRequest struct {
+	Index string `json:"index"`
+}
+
+// handleGetAliases handles GET /alias requests, returning a map of alias
+// names to the indexes they refer to.
+func (h *Handler) handleGetAliases(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	aliases, err := h.api.Aliases(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(aliases); err != nil {
+		h.logger.Errorf("writing aliases response: %v", err)
+	}
+}
+
+// readAliasRequest decodes the body of an alias request.
+func readAliasRequest(r *http.Request) (aliasRequest, error) {
+	var req aliasRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return req, NewBadRequestError(errors.Wrap(err, "decoding request"))
+	}
+	if req.Index == "" {
+		return req, NewBadRequestError(ErrIndexRequired)
+	}
+	return req, nil
+}
+
+// handlePostAlias handles POST /alias/{alias} requests, creating an alias
+// for an index.
+func (h *Handler) handlePostAlias(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h}
+	req, err := readAliasRequest(r)
+	if err != nil {
+		resp.write(w, err)
+		return
+	}
+	resp.write(w, h.api.CreateAlias(r.Context(), mux.Vars(r)["alias"], req.Index))
+}
+
+// handlePostSwapAlias handles POST /alias/{alias}/swap requests, pointing
+// an existing alias at a different index.
+func (h *Handler) handlePostSwapAlias(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h}
+	req, err := readAliasRequest(r)
+	if err != nil {
+		resp.write(w, err)
+		return
+	}
+	resp.write(w, h.api.SwapAlias(r.Context(), mux.Vars(r)["alias"], req.Index))
+}
+
+// handleDeleteAlias handles DELETE /alias/{alias} requests.
+func (h *Handler) handleDeleteAlias(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h}
+	resp.write(w, h.api.DeleteAlias(r.Context(), mux.Vars(r)["alias"]))
+}
+
+// cloneIndexRequest is the body of a POST /index/{index}/clone request.
+type cloneIndexRequest struct {
+	Target string `json:"target"`
+}
+
+// handlePostCloneIndex handles POST /index/{index}/clone requests, creating
+// the target index as a copy of the index.
+func (h *Handler) handlePostCloneIndex(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h}
+	var req cloneIndexRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		resp.write(w, NewBadRequestError(errors.Wrap(err, "decoding request")))
+		return
+	} else if req.Target == "" {
+		resp.write(w, NewBadRequestError(ErrIndexRequired))
+		return
+	}
+	_, err := h.api.CloneIndex(r.Context(), mux.Vars(r)["index"], req.Target)
+	resp.write(w, err)
+}
+
+// handlePostIngestData handles JSON ingest data that may need key
+// translation, for the entire cluster.
+func (h *Handler) handlePostIngestData(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	indexName, ok := mux.Vars(r)["index"]
+	if !ok {
+		http.Error(w, "index name is required", http.StatusBadRequest)
+		return
+	}
+
+	qcx := h.api.Txf().NewQcx()
+	resp := successResponse{h: h, Name: indexName}
+	err := h.api.IngestOperations(r.Context(), qcx, indexName, r.Body)
+	if err != nil {
+		qcx.Abort()
+		switch e := err.(type) {
+		case RedirectError:
+			http.Redirect(w, r, e.HostPort+r.URL.Path, http.StatusPermanentRedirect)
+			return
+		}
+		resp.write(w, err)
+		return
+	}
+	err = qcx.Finish()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("ingesting: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	resp.write(w, err)
+}
+
+type ingestSpec struct {
+	IndexName      string      `json:"index-name"`
+	IndexAction    string      `json:"index-action"`
+	FieldAction    string      `json:"field-action"`
+	PrimaryKeyType string      `json:"primary-key-type"`
+	Fields         []fieldSpec `json:"fields"`
+}
+
+type fieldSpec struct {
+	FieldName    string          `json:"field-name"`
+	FieldType    string          `json:"field-type"`
+	FieldOptions fieldOptionSpec `json:"field-options"`
+}
+
+type fieldOptionSpec struct {
+	EnforceMutualExclusion bool       `json:"enforce-mutual-exclusion"`
+	CacheType              *string    `json:"cache-type"`
+	CacheSize              *uint32    `json:"cache-size"`
+	Scale                  *int64     `json:"scale"`
+	Epoch                  *time.Time `json:"epoch"`
+	Unit                   *string    `json:"unit"`
+	TimeQuantum            *string    `json:"time-quantum"`
+	TTL                    *string    `json:"ttl"`
+}
+
+func fieldSpecToFieldOption(fSpec fieldSpec) fieldOptions {
+	opt := fieldOptions{}
+	// map fSpec type name to pilosa type name
+
+	// a string field could be a string set, mutex or time quantum
+	if fSpec.FieldOptions.TimeQuantum != nil {
+		opt.Type = "time"
+	} else if fSpec.FieldOptions.EnforceMutualExclusion {
+		opt.Type = "mutex"
+	} else {
+		opt.Type = "set"
+	}
+
+	// for other field types, there's a one-to-one mapping
+	if fSpec.FieldType != "string" && fSpec.FieldType != "id" {
+		opt.Type = fSpec.FieldType
+	}
+
+	if fSpec.FieldType == "string" {
+		var keys bool = true
+		opt.Keys = &keys
+	}
+	opt.CacheType = fSpec.FieldOptions.CacheType
+	opt.CacheSize = fSpec.FieldOptions.CacheSize
+	opt.Scale = fSpec.FieldOptions.Scale
+	opt.Epoch = fSpec.FieldOptions.Epoch
+	opt.TimeUnit = fSpec.FieldOptions.Unit
+	if fSpec.FieldOptions.TimeQuantum != nil {
+		timeQuantumVal := TimeQuantum(*fSpec.FieldOptions.TimeQuantum)
+		opt.TimeQuantum = &timeQuantumVal
+	}
+	opt.TTL = fSpec.FieldOptions.TTL
+
+	return opt
+}
+
+func (h *Handler) handleIngestSchema(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	schema := ingestSpec{}
+	// if a key in cleanupIndexes points to a 0-length slice, the
+	// entire index should be cleaned; otherwise, only the named
+	// fields within that index should be cleaned.
+	cleanupIndexes := map[string][]string{}
+	var schemaErr error
+	defer func() {
+		// we set schemaErr in any case where we need to do cleanup
 		if schemaErr != nil {
 			for index, fields := range cleanupIndexes {
 				if len(fields) == 0 {
@@ -2226,20 +2939,35 @@ type postFieldRequest struct {
 // fieldOptions tracks FieldOptions. It is made up of pointers to values,
 // and used for input validation.
 type fieldOptions struct {
-	Type           string       `json:"type,omitempty"`
-	CacheType      *string      `json:"cacheType,omitempty"`
-	CacheSize      *uint32      `json:"cacheSize,omitempty"`
-	Min            *pql.Decimal `json:"min,omitempty"`
-	Max            *pql.Decimal `json:"max,omitempty"`
-	Scale          *int64       `json:"scale,omitempty"`
-	Epoch          *time.Time   `json:"epoch,omitempty"`
-	TimeUnit       *string      `json:"timeUnit,omitempty"`
-	TimeQuantum    *TimeQuantum `json:"timeQuantum,omitempty"`
-	Keys           *bool        `json:"keys,omitempty"`
-	NoStandardView bool         `json:"noStandardView,omitempty"`
-	ForeignIndex   *string      `json:"foreignIndex,omitempty"`
-	TTL            *string      `json:"ttl,omitempty"`
-	Base           *int64       `json:"base,omitempty"`
+	Type            string       `json:"type,omitempty"`
+	CacheType       *string      `json:"cacheType,omitempty"`
+	CacheSize       *uint32      `json:"cacheSize,omitempty"`
+	Min             *pql.Decimal `json:"min,omitempty"`
+	Max             *pql.Decimal `json:"max,omitempty"`
+	Scale           *int64       `json:"scale,omitempty"`
+	Epoch           *time.Time   `json:"epoch,omitempty"`
+	TimeUnit        *string      `json:"timeUnit,omitempty"`
+	TimeQuantum     *TimeQuantum `json:"timeQuantum,omitempty"`
+	Keys            *bool        `json:"keys,omitempty"`
+	NoStandardView  bool         `json:"noStandardView,omitempty"`
+	ForeignIndex    *string      `json:"foreignIndex,omitempty"`
+	TTL             *string      `json:"ttl,omitempty"`
+	Base            *int64       `json:"base,omitempty"`
+	CacheStaleness  *string      `json:"cacheStaleness,omitempty"`
+	Derive          *string      `json:"derive,omitempty"`
+	History         *TimeQuantum `json:"history,omitempty"`
+	Cascade         *string      `json:"cascade,omitempty"`
+	TrackDistinct   *bool        `json:"trackDistinct,omitempty"`
+	TimeZone        *string      `json:"timeZone,omitempty"`
+	FiscalYearStart *time.Month  `json:"fiscalYearStart,omitempty"`
+	RejectNewKeys   *bool        `json:"rejectNewKeys,omitempty"`
+
+	Description string            `json:"description,omitempty"`
+	Owner       string            `json:"owner,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+
+	MemoryPolicy      string   `json:"memoryPolicy,omitempty"`
+	MemoryPolicyViews []string `json:"memoryPolicyViews,omitempty"`
 }
 
 func (o *fieldOptions) validate() error {
@@ -2359,6 +3087,30 @@ func (o *fieldOptions) validate() error {
 	default:
 		return errors.Errorf("invalid field type: %s", o.Type)
 	}
+	if o.CacheStaleness != nil && o.Type != FieldTypeSet && o.Type != FieldTypeMutex {
+		return NewBadRequestError(errors.Errorf("cacheStaleness does not apply to field type %s", o.Type))
+	}
+	if o.Derive != nil && o.Type != FieldTypeInt && o.Type != FieldTypeMutex {
+		return NewBadRequestError(errors.Errorf("derive does not apply to field type %s", o.Type))
+	}
+	if o.History != nil && o.Type != FieldTypeMutex {
+		return NewBadRequestError(errors.Errorf("history does not apply to field type %s", o.Type))
+	}
+	if o.Cascade != nil && o.Type != FieldTypeSet && o.Type != FieldTypeMutex && o.Type != FieldTypeInt {
+		return NewBadRequestError(errors.Errorf("cascade does not apply to field type %s", o.Type))
+	}
+	if o.TrackDistinct != nil && o.Type != FieldTypeInt && o.Type != FieldTypeDecimal && o.Type != FieldTypeTimestamp {
+		return NewBadRequestError(errors.Errorf("trackDistinct does not apply to field type %s", o.Type))
+	}
+	if o.TimeZone != nil && o.Type != FieldTypeTime && o.Type != FieldTypeMutex {
+		return NewBadRequestError(errors.Errorf("timeZone does not apply to field type %s", o.Type))
+	}
+	if o.FiscalYearStart != nil && o.Type != FieldTypeTime && o.Type != FieldTypeMutex {
+		return NewBadRequestError(errors.Errorf("fiscalYearStart does not apply to field type %s", o.Type))
+	}
+	if o.RejectNewKeys != nil && *o.RejectNewKeys && (o.Keys == nil || !*o.Keys) {
+		return NewBadRequestError(errors.New("rejectNewKeys only applies to keyed fields"))
+	}
 	return nil
 }
 
@@ -2561,13 +3313,30 @@ func (h *Handler) handleGetIndexShardSnapshot(w http.ResponseWriter, r *http.Req
 }
 
 // readQueryRequest parses an query parameters from r.
-func (h *Handler) readQueryRequest(r *http.Request) (*QueryRequest, error) {
+func (h *Handler) readQueryRequest(r *http.Request) (req *QueryRequest, err error) {
 	switch r.Header.Get("Content-Type") {
 	case "application/x-protobuf":
-		return h.readProtobufQueryRequest(r)
+		req, err = h.readProtobufQueryRequest(r)
 	default:
-		return h.readURLQueryRequest(r)
+		req, err = h.readURLQueryRequest(r)
+	}
+	if req != nil && !req.Remote && req.Client == "" {
+		req.Client = requestClient(r)
+	}
+	return req, err
+}
+
+// requestClient returns the client argument of r, or its remote host if
+// it hasn't got one.
+func requestClient(r *http.Request) string {
+	if client := r.URL.Query().Get("client"); client != "" {
+		return client
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
 	}
+	return host
 }
 
 // passthroughWriter is used to remove non-Writer interfaces from an io.Writer.
@@ -2623,15 +3392,77 @@ func (h *Handler) readURLQueryRequest(r *http.Request) (*QueryRequest, error) {
 		}
 	}
 
+	// Optional inclusion of row metadata in results.
+	includeMeta := false
+	if s := q.Get("includeMeta"); s != "" {
+		includeMeta, err = strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid includeMeta argument: '%s' (should be true/false)", s)
+		}
+	}
+
+	// Optional override of the index's result limits.
+	ignoreResultLimits := false
+	if s := q.Get("ignoreResultLimits"); s != "" {
+		ignoreResultLimits, err = strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignoreResultLimits argument: '%s' (should be true/false)", s)
+		}
+	}
+
+	// Optionally leave unreachable shards out instead of failing.
+	allowPartial := false
+	if s := q.Get("allowPartial"); s != "" {
+		allowPartial, err = strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowPartial argument: '%s' (should be true/false)", s)
+		}
+	}
+
+	// Optionally split writes over several transactions instead of
+	// rejecting too many of them.
+	chunkWrites := false
+	if s := q.Get("chunkWrites"); s != "" {
+		chunkWrites, err = strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunkWrites argument: '%s' (should be true/false)", s)
+		}
+	}
+
+	// Optionally fail writes which would create new keys.
+	rejectNewKeys := false
+	if s := q.Get("rejectNewKeys"); s != "" {
+		rejectNewKeys, err = strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rejectNewKeys argument: '%s' (should be true/false)", s)
+		}
+	}
+
 	return &QueryRequest{
 		Query:   query,
 		Shards:  shards,
 		Profile: profile,
+
+		IgnoreResultLimits: ignoreResultLimits,
+		IncludeRowMeta:     includeMeta,
+		ExistenceFallback:  q.Get("existenceFallback"),
+		Priority:           q.Get("priority"),
+		AllowPartial:       allowPartial,
+		ChunkWrites:        chunkWrites,
+		RejectNewKeys:      rejectNewKeys,
 	}, nil
 }
 
 // writeQueryResponse writes the response from the executor to w.
 func (h *Handler) writeQueryResponse(w http.ResponseWriter, r *http.Request, resp *QueryResponse) error {
+	switch format, _ := queryFormat(r); format {
+	case QueryFormatNDJSON:
+		w.Header().Set("Content-Type", contentTypeNDJSON)
+		return writeNDJSONQueryResponse(w, resp)
+	case QueryFormatColumnar:
+		w.Header().Set("Content-Type", "application/json")
+		return h.writeJSONQueryResponse(w, columnarResponse(resp))
+	}
 	if !validHeaderAcceptJSON(r.Header) {
 		w.Header().Set("Content-Type", "application/protobuf")
 		return h.writeProtobufQueryResponse(w, resp, headerAcceptRoaringRow(r.Header))
@@ -2941,9 +3772,15 @@ func (h *Handler) handleGetTranslateData(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Retrieve partition data from holder.
-	p, err := h.api.TranslateData(r.Context(), q.Get("index"), int(partition))
-	if redir, ok := err.(RedirectError); ok {
+	// Retrieve partition data from holder. Data held locally is returned
+	// whether or not this node owns the partition if local is set.
+	var p io.WriterTo
+	if q.Get("local") == "true" {
+		p, err = h.api.LocalTranslateData(r.Context(), q.Get("index"), int(partition))
+	} else {
+		p, err = h.api.TranslateData(r.Context(), q.Get("index"), int(partition))
+	}
+	if redir, ok := err.(RedirectError); ok {
 		newURL := *r.URL
 		newURL.Host = redir.HostPort
 		http.Redirect(w, r, newURL.String(), http.StatusSeeOther)
@@ -2959,6 +3796,11 @@ func (h *Handler) handleGetTranslateData(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// getVersionResponse is the response to GET /version.
+type getVersionResponse struct {
+	Version string `json:"version"`
+}
+
 // handleGetVersion handles /version requests.
 func (h *Handler) handleGetVersion(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -2966,9 +3808,7 @@ func (h *Handler) handleGetVersion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(struct {
-		Version string `json:"version"`
-	}{
+	err := json.NewEncoder(w).Encode(getVersionResponse{
 		Version: h.api.Version(),
 	})
 	if err != nil {
@@ -3163,214 +4003,1121 @@ func GetHTTPClient(t *tls.Config, opts ...ClientOption) *http.Client {
 	return client
 }
 
-// handlePostImportAtomicRecord handles /import-atomic-record requests
-func (h *Handler) handlePostImportAtomicRecord(w http.ResponseWriter, r *http.Request) {
+// handlePostImportAtomicRecord handles /import-atomic-record requests
+func (h *Handler) handlePostImportAtomicRecord(w http.ResponseWriter, r *http.Request) {
+
+	// Verify that request is only communicating over protobufs.
+	if error, code := validateProtobufHeader(r); error != "" {
+		http.Error(w, error, code)
+		return
+	}
+
+	// Read entire body.
+	body, err := readBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Unmarshal request based on field type.
+
+	q := r.URL.Query()
+	sLoss := q.Get("simPowerLossAfter")
+	loss := 0
+	if sLoss != "" {
+		l, err := strconv.ParseInt(sLoss, 10, 64)
+		loss = int(l)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	opt := func(o *ImportOptions) error {
+		o.SimPowerLossAfter = loss
+		return nil
+	}
+
+	req := &AtomicRecord{}
+	if err := h.serializer.Unmarshal(body, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	qcx := h.api.Txf().NewQcx()
+	err = h.api.ImportAtomicRecord(r.Context(), qcx, req, opt)
+	if err == nil {
+		err = qcx.Finish()
+	} else {
+		qcx.Abort()
+	}
+	if err != nil {
+		switch errors.Cause(err) {
+		case ErrClusterDoesNotOwnShard, ErrPreconditionFailed:
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		case ErrNamespaceQuotaExceeded:
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+		case ErrStorageQuotaExceeded, ErrReadOnly:
+			http.Error(w, err.Error(), http.StatusInsufficientStorage)
+		case ErrIndexReadOnly, ErrMaintenance:
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		case ErrBSIGroupValueTooLow, ErrBSIGroupValueTooHigh, ErrDecimalOutOfRange:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Write response.
+	_, err = w.Write(importOk)
+	if err != nil {
+		h.logger.Errorf("writing import response: %v", err)
+	}
+}
+
+// handlePostImport handles /import requests.
+func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
+	// Verify that request is only communicating over protobufs.
+	if err, code := validateProtobufHeader(r); err != "" {
+		http.Error(w, err, code)
+		return
+	}
+
+	// Get index and field type to determine how to handle the
+	// import data.
+	indexName := mux.Vars(r)["index"]
+	index, err := h.api.Index(r.Context(), indexName)
+	if err != nil {
+		if errors.Cause(err) == ErrIndexNotFound {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	fieldName := mux.Vars(r)["field"]
+	field := index.Field(fieldName)
+	if field == nil {
+		http.Error(w, ErrFieldNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	// If the clear flag is true, treat the import as clear bits.
+	q := r.URL.Query()
+	doClear := q.Get("clear") == "true"
+	doIgnoreKeyCheck := q.Get("ignoreKeyCheck") == "true"
+
+	parentExistsField := q.Get("parentExistsField")
+	doCreateParents := q.Get("createParents") == "true" || parentExistsField != ""
+
+	opts := []ImportOption{
+		OptImportOptionsClear(doClear),
+		OptImportOptionsIgnoreKeyCheck(doIgnoreKeyCheck),
+		OptImportOptionsCreateParents(doCreateParents, parentExistsField),
+	}
+
+	// Read entire body.
+	body, err := readBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Unmarshal request based on field type.
+	if field.Type() == FieldTypeInt || field.Type() == FieldTypeDecimal || field.Type() == FieldTypeTimestamp {
+		// Field type: Int
+		// Marshal into request object.
+		req := &ImportValueRequest{}
+		if err := h.serializer.Unmarshal(body, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		qcx := h.api.Txf().NewQcx()
+		defer qcx.Abort()
+
+		if err := h.api.ImportValue(r.Context(), qcx, req, opts...); err != nil {
+			switch errors.Cause(err).(type) {
+			case *ImportValueErrors, BadRequestError:
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			switch errors.Cause(err) {
+			case ErrClusterDoesNotOwnShard, ErrPreconditionFailed:
+				http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			case ErrNamespaceQuotaExceeded:
+				http.Error(w, err.Error(), http.StatusTooManyRequests)
+			case ErrStorageQuotaExceeded, ErrReadOnly:
+				http.Error(w, err.Error(), http.StatusInsufficientStorage)
+			case ErrIndexReadOnly, ErrMaintenance:
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			case ErrBSIGroupValueTooLow, ErrBSIGroupValueTooHigh, ErrDecimalOutOfRange:
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			default:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+
+			return
+		}
+		err := qcx.Finish()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error in qcx.Finish(): '%v'", err.Error()), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		// Field type: set, time, mutex
+		// Marshal into request object.
+		req := &ImportRequest{}
+		if err := h.serializer.Unmarshal(body, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		qcx := h.api.Txf().NewQcx()
+		defer qcx.Abort()
+
+		if err := h.api.Import(r.Context(), qcx, req, opts...); err != nil {
+			switch errors.Cause(err) {
+			case ErrClusterDoesNotOwnShard, ErrPreconditionFailed:
+				http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			case ErrNamespaceQuotaExceeded:
+				http.Error(w, err.Error(), http.StatusTooManyRequests)
+			case ErrStorageQuotaExceeded, ErrReadOnly:
+				http.Error(w, err.Error(), http.StatusInsufficientStorage)
+			case ErrIndexReadOnly, ErrMaintenance:
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			case ErrBSIGroupValueTooLow, ErrBSIGroupValueTooHigh, ErrDecimalOutOfRange:
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			default:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+		err := qcx.Finish()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error in qcx.Finish() on set,time,mutex: '%v'", err.Error()), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Write response.
+	_, err = w.Write(importOk)
+	if err != nil {
+		h.logger.Errorf("writing import response: %v", err)
+	}
+}
+
+// handleGetMutexCheck handles /mutex-check requests.
+func (h *Handler) handleGetMutexCheck(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	// Get index and field type to determine how to handle the
+	// import data.
+	indexName, fieldName := mux.Vars(r)["index"], mux.Vars(r)["field"]
+	q := r.URL.Query()
+	limit := 0
+	details := q.Get("details") == "true"
+	limitStr := q.Get("limit")
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			http.Error(w, "limit must be numeric", http.StatusBadRequest)
+		}
+	}
+	qcx := h.api.Txf().NewQcx()
+	defer qcx.Abort()
+	out, err := h.api.MutexCheck(r.Context(), qcx, indexName, fieldName, details, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	outBytes, err := json.Marshal(out)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("marshalling response: %v", err), http.StatusInternalServerError)
+	}
+	_, err = w.Write(outBytes)
+	if err != nil {
+		h.logger.Errorf("writing mutex-check response: %v", err)
+	}
+}
+
+// handleGetFieldResidency handles GET /index/{index}/field/{field}/residency
+// requests, reporting how much of each of the field's fragments on this
+// node is in memory.
+func (h *Handler) handleGetFieldResidency(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	indexName, fieldName := mux.Vars(r)["index"], mux.Vars(r)["field"]
+	out, err := h.api.FieldResidency(r.Context(), indexName, fieldName)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing residency response: %v", err)
+	}
+}
+
+// handleGetFieldCache handles GET /index/{index}/field/{field}/cache
+// requests, describing the field's caches on this node, with the number of
+// top entries of each given by n.
+func (h *Handler) handleGetFieldCache(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	indexName, fieldName := mux.Vars(r)["index"], mux.Vars(r)["field"]
+	n := DefaultFieldCacheTop
+	if s := r.URL.Query().Get("n"); s != "" {
+		var err error
+		if n, err = strconv.Atoi(s); err != nil {
+			http.Error(w, "n must be an integer", http.StatusBadRequest)
+			return
+		}
+	}
+	out, err := h.api.FieldCache(r.Context(), indexName, fieldName, n)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing field cache response: %v", err)
+	}
+}
+
+// handleGetFieldWrites handles GET /index/{index}/field/{field}/writes
+// requests, reporting the shards of the field written after the time given
+// by since, or all of them, with the times they were last written.
+func (h *Handler) handleGetFieldWrites(w http.ResponseWriter, r *http.Request) {
+	h.serveFieldWrites(w, r, h.api.FieldWrites)
+}
+
+// handleInternalGetFieldWrites handles internal (non-forwarding) /writes
+// requests, for this node's shards only.
+func (h *Handler) handleInternalGetFieldWrites(w http.ResponseWriter, r *http.Request) {
+	h.serveFieldWrites(w, r, h.api.FieldWritesNode)
+}
+
+func (h *Handler) serveFieldWrites(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, indexName, fieldName string, since time.Time) ([]ShardWrite, error)) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	indexName, fieldName := mux.Vars(r)["index"], mux.Vars(r)["field"]
+	var since time.Time
+	if s := r.URL.Query().Get("since"); s != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339Nano, s); err != nil {
+			http.Error(w, "since must be an RFC3339 time", http.StatusBadRequest)
+			return
+		}
+	}
+	out, err := fn(r.Context(), indexName, fieldName, since)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing field writes response: %v", err)
+	}
+}
+
+// handleGetIndexStats handles GET /index/{index}/stats requests.
+func (h *Handler) handleGetIndexStats(w http.ResponseWriter, r *http.Request) {
+	h.serveIndexStats(w, r, nil, func(ctx context.Context, indexName string) (interface{}, error) {
+		return h.api.IndexStats(ctx, indexName)
+	})
+}
+
+// handlePostInternalIndexStats handles POST /internal/index/{index}/stats
+// requests, for the statistics this node counts.
+func (h *Handler) handlePostInternalIndexStats(w http.ResponseWriter, r *http.Request) {
+	var req IndexStatsNodeRequest
+	h.serveIndexStats(w, r, &req, func(ctx context.Context, indexName string) (interface{}, error) {
+		return h.api.IndexStatsNode(ctx, indexName, &req)
+	})
+}
+
+func (h *Handler) serveIndexStats(w http.ResponseWriter, r *http.Request, req *IndexStatsNodeRequest, fn func(ctx context.Context, indexName string) (interface{}, error)) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	if req != nil {
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	out, err := fn(r.Context(), mux.Vars(r)["index"])
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing index stats response: %v", err)
+	}
+}
+
+// handleGetConsistency handles /consistency requests, checking that the
+// caches, existence and distinct values kept for an index's data agree with
+// it on every node.
+func (h *Handler) handleGetConsistency(w http.ResponseWriter, r *http.Request) {
+	h.serveConsistency(w, r, h.api.CheckConsistency)
+}
+
+// handleInternalGetConsistency handles internal (non-forwarding)
+// /consistency requests, for this node only.
+func (h *Handler) handleInternalGetConsistency(w http.ResponseWriter, r *http.Request) {
+	h.serveConsistency(w, r, h.api.CheckConsistencyNode)
+}
+
+func (h *Handler) serveConsistency(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, indexName string) ([]ConsistencyProblem, error)) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	out, err := fn(r.Context(), mux.Vars(r)["index"])
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing consistency response: %v", err)
+	}
+}
+
+// handleGetKeyCollisions handles /key-collisions requests, reporting the
+// keys of a field which more than one ID translates to.
+func (h *Handler) handleGetKeyCollisions(w http.ResponseWriter, r *http.Request) {
+	h.serveKeyCollisions(w, r, h.api.FieldKeyCollisions)
+}
+
+// handlePostMergeKeys handles /merge-keys requests, merging the rows of
+// each key's duplicate IDs into its canonical ID's row on every node.
+func (h *Handler) handlePostMergeKeys(w http.ResponseWriter, r *http.Request) {
+	h.serveKeyCollisions(w, r, h.api.MergeDuplicateKeys)
+}
+
+func (h *Handler) serveKeyCollisions(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, indexName, fieldName string) ([]KeyCollision, error)) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	vars := mux.Vars(r)
+	out, err := fn(r.Context(), vars["index"], vars["field"])
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing key collisions response: %v", err)
+	}
+}
+
+// handleInternalPostMergeKeys handles internal (non-forwarding) /merge-keys
+// requests, merging the collisions in the request body on this node only.
+func (h *Handler) handleInternalPostMergeKeys(w http.ResponseWriter, r *http.Request) {
+	resp := successResponse{h: h}
+
+	var collisions []KeyCollision
+	if err := json.NewDecoder(r.Body).Decode(&collisions); err != nil {
+		resp.write(w, NewBadRequestError(errors.Wrap(err, "decoding request")))
+		return
+	}
+	vars := mux.Vars(r)
+	err := h.api.MergeDuplicateKeysNode(r.Context(), vars["index"], vars["field"], collisions)
+	resp.write(w, err)
+}
+
+// handleGetCanary handles GET /canary requests, reporting the queries
+// executed on the canary path and those whose results differed.
+func (h *Handler) handleGetCanary(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	report, err := h.api.Canary(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.logger.Errorf("writing canary response: %v", err)
+	}
+}
+
+// handlePostGenerateData handles POST /generate requests, generating
+// records into an index from a description of their fields.
+func (h *Handler) handlePostGenerateData(w http.ResponseWriter, r *http.Request) {
+	var req GenerateRequest
+	h.serveGenerate(w, r, &req, func(ctx context.Context, indexName string) (interface{}, error) {
+		return h.api.GenerateData(ctx, indexName, &req)
+	})
+}
+
+// handlePostGenerateLoad handles POST /generate-load requests, running a
+// mix of queries against an index and reporting their latency.
+func (h *Handler) handlePostGenerateLoad(w http.ResponseWriter, r *http.Request) {
+	var req LoadRequest
+	h.serveGenerate(w, r, &req, func(ctx context.Context, indexName string) (interface{}, error) {
+		return h.api.GenerateLoad(ctx, indexName, &req)
+	})
+}
+
+func (h *Handler) serveGenerate(w http.ResponseWriter, r *http.Request, req interface{}, fn func(ctx context.Context, indexName string) (interface{}, error)) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, "decoding request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	out, err := fn(r.Context(), mux.Vars(r)["index"])
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing generate response: %v", err)
+	}
+}
+
+// handlePostExport handles POST /export requests, exporting the results of
+// a query to the destination in the request, and responding with the
+// manifest of the export.
+func (h *Handler) handlePostExport(w http.ResponseWriter, r *http.Request) {
+	var req ExportQueryRequest
+	h.serveExport(w, r, &req, func(ctx context.Context) (interface{}, error) {
+		return h.api.ExportQuery(ctx, &req)
+	})
+}
+
+// handleGetIndexArchive handles GET /index/{index}/archive requests,
+// streaming an archive of the index which can be restored elsewhere.
+func (h *Handler) handleGetIndexArchive(w http.ResponseWriter, r *http.Request) {
+	indexName := mux.Vars(r)["index"]
+	if h.api.holder.Index(indexName) == nil {
+		http.Error(w, newNotFoundError(ErrIndexNotFound, indexName).Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", indexName+".tar"))
+	if _, err := h.api.ExportIndex(r.Context(), indexName, w); err != nil {
+		// The archive may be partly written by now, so appending the
+		// error at least leaves it unreadable.
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handlePostArchive handles POST /archive requests, restoring the index
+// in the archive in the request body.
+func (h *Handler) handlePostArchive(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	manifest, err := h.api.ImportIndex(r.Context(), r.Body)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case ConflictError:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(manifest); err != nil {
+		h.logger.Errorf("writing archive manifest: %s", err)
+	}
+}
+
+// handlePostExportPart handles POST /internal/export-part requests,
+// exporting the part of a query's results from the shards in the request.
+func (h *Handler) handlePostExportPart(w http.ResponseWriter, r *http.Request) {
+	var req ExportPartRequest
+	h.serveExport(w, r, &req, func(ctx context.Context) (interface{}, error) {
+		return h.api.ExportQueryPart(ctx, &req)
+	})
+}
+
+func (h *Handler) serveExport(w http.ResponseWriter, r *http.Request, req interface{}, fn func(ctx context.Context) (interface{}, error)) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	out, err := fn(r.Context())
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing export response: %v", err)
+	}
+}
+
+// handlePostReindex handles POST /reindex requests, copying data from one
+// index into another, and responding with the status of the reindex once
+// it's finished.
+func (h *Handler) handlePostReindex(w http.ResponseWriter, r *http.Request) {
+	var req ReindexRequest
+	h.serveReindex(w, r, &req, func(ctx context.Context) (interface{}, error) {
+		return h.api.Reindex(ctx, &req)
+	})
+}
+
+// handleGetReindex handles GET /reindex/{id} requests, reporting the
+// progress of a reindex coordinated by this node.
+func (h *Handler) handleGetReindex(w http.ResponseWriter, r *http.Request) {
+	h.serveReindex(w, r, nil, func(ctx context.Context) (interface{}, error) {
+		return h.api.ReindexStatus(ctx, mux.Vars(r)["id"])
+	})
+}
+
+// handlePostReindexShard handles POST /internal/reindex-shard requests,
+// copying a shard of the source of a reindex.
+func (h *Handler) handlePostReindexShard(w http.ResponseWriter, r *http.Request) {
+	var req ReindexShardRequest
+	h.serveReindex(w, r, &req, func(ctx context.Context) (interface{}, error) {
+		return h.api.ReindexShard(ctx, &req)
+	})
+}
+
+func (h *Handler) serveReindex(w http.ResponseWriter, r *http.Request, req interface{}, fn func(ctx context.Context) (interface{}, error)) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	if req != nil {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	out, err := fn(r.Context())
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case ConflictError:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing reindex response: %v", err)
+	}
+}
+
+// handleGetOpenAPI handles GET /openapi.json requests, describing the HTTP
+// API.
+func (h *Handler) handleGetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	doc, err := h.openAPIDocument(h.router)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(doc); err != nil {
+		h.logger.Errorf("writing openapi response: %v", err)
+	}
+}
+
+// handleGetClientSessions handles GET /client-sessions requests, listing
+// the client sessions open on this node. With the idle argument, only the
+// sessions idle for at least that long are listed.
+func (h *Handler) handleGetClientSessions(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	var idle time.Duration
+	if s := r.URL.Query().Get("idle"); s != "" {
+		var err error
+		if idle, err = time.ParseDuration(s); err != nil {
+			http.Error(w, "idle must be a duration", http.StatusBadRequest)
+			return
+		}
+	}
+	sessions, err := h.api.ClientSessions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	out := sessions[:0]
+	for _, cs := range sessions {
+		if cs.Idle >= idle {
+			out = append(out, cs)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing client sessions response: %v", err)
+	}
+}
+
+// handleDeleteClientSession handles DELETE /client-sessions/{id} requests,
+// closing a client session.
+func (h *Handler) handleDeleteClientSession(w http.ResponseWriter, r *http.Request) {
+	resp := successResponse{h: h}
+	err := h.api.CloseClientSession(r.Context(), mux.Vars(r)["id"])
+	resp.write(w, err)
+}
+
+// handleGetStorage handles GET /storage requests, reporting how this node
+// stores the data of an index, or of every index.
+func (h *Handler) handleGetStorage(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	report, err := h.api.StorageReport(r.Context(), r.URL.Query().Get("index"))
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.logger.Errorf("writing storage response: %v", err)
+	}
+}
+
+// handlePostStorageOptimize handles POST /storage/optimize requests,
+// optimizing the storage of this node's shards of an index, or of every
+// index. Progress is streamed as a line of JSON per shard; if optimizing
+// fails after the first shard, the last line has only an error.
+func (h *Handler) handlePostStorageOptimize(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	enc := json.NewEncoder(w)
+	started := false
+	err := h.api.OptimizeStorage(r.Context(), r.URL.Query().Get("index"), func(p StorageOptimizeProgress) error {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		if err := enc.Encode(p); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	})
+	switch {
+	case err == nil:
+	case !started:
+		writeStorageError(w, err)
+	default:
+		if err := enc.Encode(StorageOptimizeProgress{Error: err.Error()}); err != nil {
+			h.logger.Errorf("writing storage optimize error: %v", err)
+		}
+	}
+}
+
+// writeStorageError writes the status of a failed storage request.
+func writeStorageError(w http.ResponseWriter, err error) {
+	switch errors.Cause(err).(type) {
+	case NotFoundError:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case ConflictError:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleGetNodeDiagnostics handles GET /diagnostics requests,
+// describing this node for troubleshooting. The slow argument is how many
+// of its slowest recent queries to include.
+func (h *Handler) handleGetNodeDiagnostics(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	slow := DefaultSlowQueries
+	if s := r.URL.Query().Get("slow"); s != "" {
+		var err error
+		if slow, err = strconv.Atoi(s); err != nil || slow < 0 {
+			http.Error(w, "slow must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+	}
+	out, err := h.api.NodeDiagnostics(r.Context(), slow)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing diagnostics response: %v", err)
+	}
+}
+
+// handlePostImportSession handles POST /import-session requests, starting
+// an import session.
+func (h *Handler) handlePostImportSession(w http.ResponseWriter, r *http.Request) {
+	var req ImportSessionRequest
+	h.serveImportSession(w, r, &req, func(ctx context.Context) (interface{}, error) {
+		return h.api.StartImportSession(ctx, mux.Vars(r)["index"], &req)
+	})
+}
+
+// handleGetImportSession handles GET /import-session/{id} requests,
+// reporting the progress of an import session.
+func (h *Handler) handleGetImportSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	h.serveImportSession(w, r, nil, func(ctx context.Context) (interface{}, error) {
+		return h.api.ImportSession(ctx, vars["index"], vars["id"])
+	})
+}
+
+// handleDeleteImportSession handles DELETE /import-session/{id} requests,
+// aborting an open import session or forgetting a committed one.
+func (h *Handler) handleDeleteImportSession(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	h.serveImportSession(w, r, nil, func(ctx context.Context) (interface{}, error) {
+		return successResponse{Success: true}, h.api.DeleteImportSession(ctx, vars["index"], vars["id"])
+	})
+}
+
+// handlePutImportSessionPart handles PUT /import-session/{id}/part/{part}
+// requests, staging a part of an import session.
+func (h *Handler) handlePutImportSessionPart(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	part, err := strconv.Atoi(vars["part"])
+	if err != nil {
+		http.Error(w, "part must be a number", http.StatusBadRequest)
+		return
+	}
+	var req ImportSessionPart
+	h.serveImportSession(w, r, &req, func(ctx context.Context) (interface{}, error) {
+		return h.api.PushImportSessionPart(ctx, vars["index"], vars["id"], part, &req)
+	})
+}
+
+// handlePostImportSessionCommit handles POST /import-session/{id}/commit
+// requests, committing an import session a shard at a time, and responding
+// with its status once it's committed.
+func (h *Handler) handlePostImportSessionCommit(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	h.serveImportSession(w, r, nil, func(ctx context.Context) (interface{}, error) {
+		return h.api.CommitImportSession(ctx, vars["index"], vars["id"])
+	})
+}
+
+func (h *Handler) serveImportSession(w http.ResponseWriter, r *http.Request, req interface{}, fn func(ctx context.Context) (interface{}, error)) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	if req != nil {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(req); err != nil && err != io.EOF {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	out, err := fn(r.Context())
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case ConflictError:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing import session response: %v", err)
+	}
+}
+
+// handleGetUsage handles GET /usage requests, reporting the storage used
+// by every index, field, and view across the cluster.
+func (h *Handler) handleGetUsage(w http.ResponseWriter, r *http.Request) {
+	h.serveUsage(w, r, nil, func(ctx context.Context) (*UsageReport, error) {
+		return h.api.UsageReport(ctx, nil)
+	})
+}
+
+// handlePostUsage handles POST /usage requests, which pass a report
+// returned earlier, and get a new report with the changes since then.
+func (h *Handler) handlePostUsage(w http.ResponseWriter, r *http.Request) {
+	var prior UsageReport
+	h.serveUsage(w, r, &prior, func(ctx context.Context) (*UsageReport, error) {
+		return h.api.UsageReport(ctx, &prior)
+	})
+}
 
-	// Verify that request is only communicating over protobufs.
-	if error, code := validateProtobufHeader(r); error != "" {
-		http.Error(w, error, code)
-		return
-	}
+// handleInternalGetUsage handles GET /internal/usage requests, reporting
+// the storage used on this node only.
+func (h *Handler) handleInternalGetUsage(w http.ResponseWriter, r *http.Request) {
+	h.serveUsage(w, r, nil, func(ctx context.Context) (*UsageReport, error) {
+		return h.api.UsageReportNode(ctx)
+	})
+}
 
-	// Read entire body.
-	body, err := readBody(r)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+func (h *Handler) serveUsage(w http.ResponseWriter, r *http.Request, prior *UsageReport, fn func(ctx context.Context) (*UsageReport, error)) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
-	// Unmarshal request based on field type.
-
-	q := r.URL.Query()
-	sLoss := q.Get("simPowerLossAfter")
-	loss := 0
-	if sLoss != "" {
-		l, err := strconv.ParseInt(sLoss, 10, 64)
-		loss = int(l)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+	if prior != nil {
+		if err := json.NewDecoder(r.Body).Decode(prior); err != nil {
+			http.Error(w, "decoding prior report: "+err.Error(), http.StatusBadRequest)
+			return
 		}
 	}
-	opt := func(o *ImportOptions) error {
-		o.SimPowerLossAfter = loss
-		return nil
+	out, err := fn(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing usage report: %v", err)
+	}
+}
 
-	req := &AtomicRecord{}
-	if err := h.serializer.Unmarshal(body, req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+// handleGetOpenState handles GET /open-state requests, reporting how much
+// of this node's data has been opened.
+func (h *Handler) handleGetOpenState(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	out, err := h.api.OpenState(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing open state response: %v", err)
+	}
+}
 
-	qcx := h.api.Txf().NewQcx()
-	err = h.api.ImportAtomicRecord(r.Context(), qcx, req, opt)
-	if err == nil {
-		err = qcx.Finish()
-	} else {
-		qcx.Abort()
+// handleGetHealth handles GET /health requests.
+func (h *Handler) handleGetHealth(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
 	}
+	out, err := h.api.Health(r.Context())
 	if err != nil {
-		switch errors.Cause(err) {
-		case ErrClusterDoesNotOwnShard, ErrPreconditionFailed:
-			http.Error(w, err.Error(), http.StatusPreconditionFailed)
-		case ErrBSIGroupValueTooLow, ErrBSIGroupValueTooHigh, ErrDecimalOutOfRange:
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		default:
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing health response: %v", err)
+	}
+}
 
-	// Write response.
-	_, err = w.Write(importOk)
+// handleGetMaintenance handles GET /maintenance requests.
+func (h *Handler) handleGetMaintenance(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	out, err := h.api.Maintenance(r.Context())
 	if err != nil {
-		h.logger.Errorf("writing import response: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing maintenance response: %v", err)
 	}
 }
 
-// handlePostImport handles /import requests.
-func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
-	// Verify that request is only communicating over protobufs.
-	if err, code := validateProtobufHeader(r); err != "" {
-		http.Error(w, err, code)
+// handlePostMaintenance handles POST /maintenance requests, which put this
+// node, or with cluster=true every node, in maintenance mode, or take them
+// out of it.
+func (h *Handler) handlePostMaintenance(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	var req Maintenance
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, "decoding request: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	if req.Enabled && req.Reason == "" {
+		req.Reason = "maintenance"
+	} else if !req.Enabled {
+		req.Reason = ""
+	}
+	cluster := r.URL.Query().Get("cluster") == "true"
+	resp := successResponse{h: h}
+	resp.write(w, h.api.SetMaintenance(r.Context(), req.Reason, cluster))
+}
 
-	// Get index and field type to determine how to handle the
-	// import data.
-	indexName := mux.Vars(r)["index"]
-	index, err := h.api.Index(r.Context(), indexName)
-	if err != nil {
-		if errors.Cause(err) == ErrIndexNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
-		} else {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
+// handleGetSettings handles GET /settings requests.
+func (h *Handler) handleGetSettings(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
-	fieldName := mux.Vars(r)["field"]
-	field := index.Field(fieldName)
-	if field == nil {
-		http.Error(w, ErrFieldNotFound.Error(), http.StatusNotFound)
+	out, err := h.api.Settings(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-
-	// If the clear flag is true, treat the import as clear bits.
-	q := r.URL.Query()
-	doClear := q.Get("clear") == "true"
-	doIgnoreKeyCheck := q.Get("ignoreKeyCheck") == "true"
-
-	opts := []ImportOption{
-		OptImportOptionsClear(doClear),
-		OptImportOptionsIgnoreKeyCheck(doIgnoreKeyCheck),
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing settings response: %v", err)
 	}
+}
 
-	// Read entire body.
-	body, err := readBody(r)
+// handlePostSettings handles POST /settings requests, which change the
+// settings of every node. Only the settings in the request are changed.
+func (h *Handler) handlePostSettings(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	st, err := h.api.Settings(r.Context())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	// Unmarshal request based on field type.
-	if field.Type() == FieldTypeInt || field.Type() == FieldTypeDecimal || field.Type() == FieldTypeTimestamp {
-		// Field type: Int
-		// Marshal into request object.
-		req := &ImportValueRequest{}
-		if err := h.serializer.Unmarshal(body, req); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		qcx := h.api.Txf().NewQcx()
-		defer qcx.Abort()
-
-		if err := h.api.ImportValue(r.Context(), qcx, req, opts...); err != nil {
-			switch errors.Cause(err) {
-			case ErrClusterDoesNotOwnShard, ErrPreconditionFailed:
-				http.Error(w, err.Error(), http.StatusPreconditionFailed)
-			case ErrBSIGroupValueTooLow, ErrBSIGroupValueTooHigh, ErrDecimalOutOfRange:
-				http.Error(w, err.Error(), http.StatusBadRequest)
-			default:
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-
-			return
-		}
-		err := qcx.Finish()
-		if err != nil {
-			http.Error(w, fmt.Sprintf("error in qcx.Finish(): '%v'", err.Error()), http.StatusInternalServerError)
-			return
-		}
-	} else {
-		// Field type: set, time, mutex
-		// Marshal into request object.
-		req := &ImportRequest{}
-		if err := h.serializer.Unmarshal(body, req); err != nil {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&st); err != nil && err != io.EOF {
+		http.Error(w, "decoding request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.api.UpdateSettings(r.Context(), st); err != nil {
+		switch errors.Cause(err).(type) {
+		case BadRequestError:
 			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		qcx := h.api.Txf().NewQcx()
-		defer qcx.Abort()
-
-		if err := h.api.Import(r.Context(), qcx, req, opts...); err != nil {
-			switch errors.Cause(err) {
-			case ErrClusterDoesNotOwnShard, ErrPreconditionFailed:
-				http.Error(w, err.Error(), http.StatusPreconditionFailed)
-			case ErrBSIGroupValueTooLow, ErrBSIGroupValueTooHigh, ErrDecimalOutOfRange:
-				http.Error(w, err.Error(), http.StatusBadRequest)
-			default:
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		}
-		err := qcx.Finish()
-		if err != nil {
-			http.Error(w, fmt.Sprintf("error in qcx.Finish() on set,time,mutex: '%v'", err.Error()), http.StatusInternalServerError)
-			return
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
+		return
 	}
-
-	// Write response.
-	_, err = w.Write(importOk)
-	if err != nil {
-		h.logger.Errorf("writing import response: %v", err)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(st); err != nil {
+		h.logger.Errorf("writing settings response: %v", err)
 	}
 }
 
-// handleGetMutexCheck handles /mutex-check requests.
-func (h *Handler) handleGetMutexCheck(w http.ResponseWriter, r *http.Request) {
+// handlePostAnalyzeQuery handles POST /index/{index}/analyze requests,
+// which check the PQL query in the body without executing it.
+func (h *Handler) handlePostAnalyzeQuery(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
 		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
-	// Get index and field type to determine how to handle the
-	// import data.
-	indexName, fieldName := mux.Vars(r)["index"], mux.Vars(r)["field"]
-	q := r.URL.Query()
-	limit := 0
-	details := q.Get("details") == "true"
-	limitStr := q.Get("limit")
-	if limitStr != "" {
-		var err error
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil {
-			http.Error(w, "limit must be numeric", http.StatusBadRequest)
-		}
-	}
-	qcx := h.api.Txf().NewQcx()
-	defer qcx.Abort()
-	out, err := h.api.MutexCheck(r.Context(), qcx, indexName, fieldName, details, limit)
+	body, err := readBody(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, "reading request: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	outBytes, err := json.Marshal(out)
+	out, err := h.api.AnalyzeQuery(r.Context(), mux.Vars(r)["index"], string(body))
 	if err != nil {
-		http.Error(w, fmt.Sprintf("marshalling response: %v", err), http.StatusInternalServerError)
+		switch errors.Cause(err).(type) {
+		case BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
 	}
-	_, err = w.Write(outBytes)
-	if err != nil {
-		h.logger.Errorf("writing mutex-check response: %v", err)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		h.logger.Errorf("writing query analysis response: %v", err)
 	}
 }
 
@@ -3465,6 +5212,12 @@ func (h *Handler) handlePostImportRoaring(w http.ResponseWriter, r *http.Request
 		resp.Err = err.Error()
 		if _, ok := err.(BadRequestError); ok {
 			w.WriteHeader(http.StatusBadRequest)
+		} else if errors.Is(err, ErrNamespaceQuotaExceeded) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		} else if errors.Is(err, ErrStorageQuotaExceeded) || errors.Is(err, ErrReadOnly) {
+			w.WriteHeader(http.StatusInsufficientStorage)
+		} else if errors.Is(err, ErrIndexReadOnly) || errors.Is(err, ErrMaintenance) {
+			w.WriteHeader(http.StatusServiceUnavailable)
 		} else if _, ok := err.(NotFoundError); ok {
 			w.WriteHeader(http.StatusNotFound)
 		} else if _, ok := err.(PreconditionFailedError); ok {
@@ -3540,6 +5293,12 @@ func (h *Handler) handlePostShardImportRoaring(w http.ResponseWriter, r *http.Re
 			w.WriteHeader(http.StatusNotFound)
 		} else if errors.As(err, &BadRequestError{}) {
 			w.WriteHeader(http.StatusBadRequest)
+		} else if errors.Is(err, ErrNamespaceQuotaExceeded) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		} else if errors.Is(err, ErrStorageQuotaExceeded) || errors.Is(err, ErrReadOnly) {
+			w.WriteHeader(http.StatusInsufficientStorage)
+		} else if errors.Is(err, ErrIndexReadOnly) || errors.Is(err, ErrMaintenance) {
+			w.WriteHeader(http.StatusServiceUnavailable)
 		} else if _, ok := errors.Cause(err).(NotFoundError); ok {
 			w.WriteHeader(http.StatusNotFound)
 		} else if errors.As(err, &PreconditionFailedError{}) {
@@ -3851,6 +5610,41 @@ func (h *Handler) handleMatchField(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleKeyPrefixShards handles /internal/translate/index/{index}/keys/prefix-shards
+// requests, returning the shards holding a column whose key, in one of the
+// requested partitions, starts with the requested prefix.
+func (h *Handler) handleKeyPrefixShards(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	var req KeyPrefixShardsRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		http.Error(w, "decoding request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	shards, err := h.api.KeyPrefixShards(r.Context(), mux.Vars(r)["index"], req.Prefix, req.Partitions)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(shards); err != nil {
+		h.logger.Errorf("writing key prefix shards response: %v", err)
+	}
+}
+
 func (h *Handler) handleReserveIDs(w http.ResponseWriter, r *http.Request) {
 	// Verify input and output types
 	if r.Header.Get("Content-Type") != "application/json" {
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"reflect"
@@ -21,6 +22,7 @@ import (
 	"github.com/featurebasedb/featurebase/v3/encoding/proto"
 	"github.com/featurebasedb/featurebase/v3/server"
 	"github.com/featurebasedb/featurebase/v3/test"
+	"github.com/featurebasedb/featurebase/v3/toml"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -947,3 +949,150 @@ admin: "ac97c9e2-346b-42a2-b6da-18bcb61a32fe"`
 		}
 	}
 }
+
+func TestQueryFormats(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	m := c.GetPrimary()
+
+	m.MustCreateIndex(t, c.Idx(), pilosa.IndexOptions{TrackExistence: true})
+	m.MustCreateField(t, c.Idx(), "f")
+	m.MustCreateField(t, c.Idx(), "n", pilosa.OptFieldTypeInt(0, 100))
+	if _, err := m.Query(t, c.Idx(), "", `Set(1, f=1) Set(2, f=1) Set(2, f=2) Set(1, n=3) Set(2, n=4)`); err != nil {
+		t.Fatal(err)
+	}
+	query := `Extract(All(), Rows(f), Rows(n)) GroupBy(Rows(f), aggregate=Sum(field=n)) Count(All())`
+
+	t.Run("NDJSON", func(t *testing.T) {
+		m.QueryExpect(t, c.Idx(), "format=ndjson", query, strings.Join([]string{
+			`{"result":0,"fields":[{"name":"f","type":"[]uint64"},{"name":"n","type":"int64"}]}`,
+			`{"result":0,"column":1,"rows":[[1],3]}`,
+			`{"result":0,"column":2,"rows":[[1,2],4]}`,
+			`{"result":1,"group":[{"field":"f","rowID":1}],"count":2,"aggregate":"sum","value":7}`,
+			`{"result":1,"group":[{"field":"f","rowID":2}],"count":1,"aggregate":"sum","value":4}`,
+			`{"result":2,"value":2}`,
+			`{"done":true}`,
+		}, "\n"))
+	})
+
+	t.Run("Accept", func(t *testing.T) {
+		req, err := http.NewRequest("POST", fmt.Sprintf("%s/index/%s/query", m.URL(), c.Idx()), strings.NewReader(`Count(All())`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept", "application/x-ndjson")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := resp.Header.Get("Content-Type"); got != "application/x-ndjson" {
+			t.Fatalf("expected NDJSON content type, got %q", got)
+		}
+		if exp := "{\"result\":0,\"value\":2}\n{\"done\":true}\n"; string(body) != exp {
+			t.Fatalf("expected %q, got %q", exp, body)
+		}
+	})
+
+	t.Run("Columnar", func(t *testing.T) {
+		m.QueryExpect(t, c.Idx(), "format=columnar", query, `{"results":[`+
+			`{"fields":[{"name":"f","type":"[]uint64"},{"name":"n","type":"int64"}],"columns":[1,2],"values":[[[1],[1,2]],[3,4]]},`+
+			`{"fields":["f"],"groups":[[1,2]],"counts":[2,1],"aggregate":"sum","aggregates":[7,4]},`+
+			`2]}`)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		resp := test.Do(t, "POST", fmt.Sprintf("%s/index/%s/query?format=xml", m.URL(), c.Idx()), query)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected bad request, got %d: %s", resp.StatusCode, resp.Body)
+		}
+	})
+}
+
+func TestOpenAPI(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	m := c.GetNode(0)
+
+	resp := test.Do(t, "GET", m.URL()+"/openapi.json", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", resp.StatusCode, resp.Body)
+	}
+	var doc pilosa.OpenAPIDocument
+	if err := json.Unmarshal([]byte(resp.Body), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != "3.0.3" || doc.Info.Title != "FeatureBase" || doc.Info.Version == "" {
+		t.Fatalf("unexpected document header: %+v %+v", doc.OpenAPI, doc.Info)
+	}
+	for path := range doc.Paths {
+		if strings.HasPrefix(path, "/internal/") {
+			t.Fatalf("didn't expect internal path %s", path)
+		}
+	}
+
+	op := doc.Paths["/index/{index}/query"]["post"]
+	if op == nil || op.OperationID != "PostQuery" {
+		t.Fatalf("expected the query operation, got %+v", op)
+	}
+	params := make(map[string]string)
+	for _, p := range op.Parameters {
+		params[p.Name] = p.In
+	}
+	if params["index"] != "path" || params["shards"] != "query" {
+		t.Fatalf("unexpected parameters %+v", op.Parameters)
+	}
+	if op.RequestBody == nil || op.RequestBody.Content["text/plain"] == nil {
+		t.Fatalf("expected a text request body, got %+v", op.RequestBody)
+	}
+	if ref := op.Responses["200"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/QueryResponse" {
+		t.Fatalf("unexpected response schema %q", ref)
+	}
+	if results := doc.Components.Schemas["QueryResponse"].Properties["results"]; results == nil || results.Type != "array" {
+		t.Fatalf("unexpected results schema %+v", results)
+	}
+	if errors := doc.Components.Schemas["QueryError"].Properties; errors["error"] == nil || errors["code"] == nil {
+		t.Fatalf("unexpected error schema %+v", errors)
+	}
+
+	if op := doc.Paths["/client-sessions/{id}"]["delete"]; op == nil || op.OperationID != "DeleteClientSession" {
+		t.Fatalf("expected the client session operation, got %+v", op)
+	}
+}
+
+func TestCORSOptions(t *testing.T) {
+	c := test.MustRunCluster(t, 1, []server.CommandOption{
+		func(m *server.Command) error {
+			m.Config.Handler.AllowedOrigins = []string{"http://test/"}
+			m.Config.Handler.AllowedMethods = []string{"GET", "DELETE"}
+			m.Config.Handler.AllowCredentials = true
+			m.Config.Handler.CORSMaxAge = toml.Duration(10 * time.Minute)
+			return nil
+		},
+	})
+	defer c.Close()
+
+	req := test.MustNewHTTPRequest("OPTIONS", "/client-sessions/1", nil)
+	req.Header.Add("Origin", "http://test/")
+	req.Header.Add("Access-Control-Request-Method", "DELETE")
+	w := httptest.NewRecorder()
+	c.GetNode(0).Handler.(*pilosa.Handler).Handler.ServeHTTP(w, req)
+	result := w.Result()
+	if result.StatusCode != http.StatusOK {
+		t.Fatalf("CORS preflight status should be 200, but is %v", result.StatusCode)
+	}
+	for header, exp := range map[string]string{
+		"Access-Control-Allow-Origin":      "http://test/",
+		"Access-Control-Allow-Methods":     "DELETE",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Max-Age":           "600",
+	} {
+		if got := result.Header.Get(header); got != exp {
+			t.Fatalf("expected %s to be %q, got %q", header, exp, got)
+		}
+	}
+}
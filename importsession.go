@@ -0,0 +1,638 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// An import session imports data into an index in parts, none of which is
+// visible to queries until the session is committed. Each part pushed to a
+// session has its keys translated, and is staged, shard by shard, in the
+// data directory of the node coordinating the session, the one it was
+// started on. Pushing a part again replaces what it staged before, so a
+// client which isn't sure a part arrived can push it again. Committing a
+// session imports what its parts staged, a shard at a time, in the order of
+// the parts, and records each shard it's finished; a commit which fails
+// part way can be retried, and resumes with the shards not yet imported.
+// Sessions are kept until they're deleted, so they survive restarts of the
+// coordinating node.
+//
+// Commits are resumable, but not atomic: queries run while a session is
+// being committed, or after a commit has failed part way, see the shards
+// it has finished and not the others. Keys are created as parts are
+// pushed, so those of a session which is deleted without being committed
+// stay behind, with no data.
+
+// importSessionDir is the directory, within the data directory, in which
+// import sessions are kept.
+const importSessionDir = "import-sessions"
+
+// States of import sessions.
+const (
+	ImportSessionOpen       = "open"
+	ImportSessionCommitting = "committing"
+	ImportSessionCommitted  = "committed"
+)
+
+// ImportSessionRequest is a request to start an import session.
+type ImportSessionRequest struct {
+	// ID names the session. A name is generated if it's empty.
+	ID string `json:"id,omitempty"`
+}
+
+// ImportSessionPart is a part of an import session.
+type ImportSessionPart struct {
+	Imports []ImportSessionImport `json:"imports"`
+}
+
+// ImportSessionImport imports bits, or values, into a field of the index of
+// an import session. Columns are given by key if the index has keys, and by
+// ID otherwise. Int, decimal and timestamp fields take a value for each
+// column, as value imports do; other fields take a row for each column, by
+// key if the field has keys, and by ID otherwise, and optionally a
+// timestamp, in nanoseconds since the Unix epoch.
+type ImportSessionImport struct {
+	Field      string   `json:"field"`
+	ColumnIDs  []uint64 `json:"columnIDs,omitempty"`
+	ColumnKeys []string `json:"columnKeys,omitempty"`
+	RowIDs     []uint64 `json:"rowIDs,omitempty"`
+	RowKeys    []string `json:"rowKeys,omitempty"`
+	Values     []int64  `json:"values,omitempty"`
+	Timestamps []int64  `json:"timestamps,omitempty"`
+}
+
+// ImportSessionStatus reports the progress of an import session.
+type ImportSessionStatus struct {
+	ID     string               `json:"id"`
+	Index  string               `json:"index"`
+	State  string               `json:"state"`
+	Parts  int                  `json:"parts"`
+	Shards []ImportSessionShard `json:"shards"`
+	// Error is why the last commit failed.
+	Error string `json:"error,omitempty"`
+}
+
+// ImportSessionShard reports the progress of an import session in a shard.
+type ImportSessionShard struct {
+	Shard uint64 `json:"shard"`
+	// Staged is how many bits and values the session's parts hold for the
+	// shard, and Applied how many of them have been imported.
+	Staged  uint64 `json:"staged"`
+	Applied uint64 `json:"applied"`
+}
+
+// importSession is what's kept of an import session, besides what its parts
+// staged.
+type importSession struct {
+	ID    string `json:"id"`
+	Index string `json:"index"`
+	State string `json:"state"`
+	// Parts holds the number of bits and values each part staged in each
+	// shard.
+	Parts map[int]map[uint64]uint64 `json:"parts"`
+	// Applied holds the number imported in each shard a commit has
+	// finished.
+	Applied map[uint64]uint64 `json:"applied"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// importSessionChunk is what a part of an import session stages for a
+// field in a shard, with its keys translated.
+type importSessionChunk struct {
+	Field      string   `json:"field"`
+	ColumnIDs  []uint64 `json:"columnIDs"`
+	RowIDs     []uint64 `json:"rowIDs,omitempty"`
+	Values     []int64  `json:"values,omitempty"`
+	Timestamps []int64  `json:"timestamps,omitempty"`
+}
+
+// importSessions holds the import sessions coordinated by a node which have
+// been used since it started, and serializes changes to them.
+type importSessions struct {
+	mu         sync.Mutex
+	sessions   map[string]*importSession
+	pushing    map[string]int  // parts being pushed to each session
+	committing map[string]bool // sessions being committed
+}
+
+// StartImportSession starts an import session into an index.
+func (api *API) StartImportSession(ctx context.Context, indexName string, req *ImportSessionRequest) (*ImportSessionStatus, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.StartImportSession")
+	defer span.Finish()
+
+	if err := api.validate(apiImportSession); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	if _, err := api.Index(ctx, indexName); err != nil {
+		return nil, err
+	}
+	id := req.ID
+	if id == "" {
+		var b [8]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return nil, errors.Wrap(err, "generating id")
+		}
+		id = "import-" + hex.EncodeToString(b[:])
+	}
+	if err := ValidateName(id); err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "import session id"))
+	}
+
+	s := &api.importSessions
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, err := api.loadImportSession(id); err != nil {
+		return nil, err
+	} else if old != nil {
+		return nil, newConflictError(errors.Errorf("import session %s already exists", id))
+	}
+	sess := &importSession{
+		ID:      id,
+		Index:   indexName,
+		State:   ImportSessionOpen,
+		Parts:   make(map[int]map[uint64]uint64),
+		Applied: make(map[uint64]uint64),
+	}
+	if err := api.saveImportSession(sess); err != nil {
+		return nil, errors.Wrap(err, "saving session")
+	}
+	s.sessions[id] = sess
+	return sess.status(), nil
+}
+
+// ImportSession returns the status of an import session coordinated by
+// this node.
+func (api *API) ImportSession(ctx context.Context, indexName, id string) (*ImportSessionStatus, error) {
+	if err := api.validate(apiImportSession); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	s := &api.importSessions
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, err := api.importSession(indexName, id)
+	if err != nil {
+		return nil, err
+	}
+	return sess.status(), nil
+}
+
+// PushImportSessionPart stages a part of an open import session, replacing
+// what the part staged before. The part's new keys are created straight
+// away.
+func (api *API) PushImportSessionPart(ctx context.Context, indexName, id string, part int, p *ImportSessionPart) (*ImportSessionStatus, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.PushImportSessionPart")
+	defer span.Finish()
+
+	if err := api.validate(apiImportSession); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	if part < 0 {
+		return nil, NewBadRequestError(errors.Errorf("invalid part %d", part))
+	}
+
+	s := &api.importSessions
+	s.mu.Lock()
+	sess, err := api.importSession(indexName, id)
+	if err == nil && sess.State != ImportSessionOpen {
+		err = newConflictError(errors.Errorf("import session %s is %s", id, sess.State))
+	}
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	// Sessions aren't committed or deleted while parts are being pushed.
+	s.pushing[id]++
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.pushing[id]--
+		s.mu.Unlock()
+	}()
+
+	chunks, err := api.importSessionChunks(ctx, indexName, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stage the part beside the parts directory, and move it into place
+	// once it's complete.
+	dir := api.importSessionPath(id)
+	tmp, err := os.MkdirTemp(dir, fmt.Sprintf(".part-%d-", part))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating part")
+	}
+	defer os.RemoveAll(tmp)
+	counts := make(map[uint64]uint64, len(chunks))
+	for shard, cs := range chunks {
+		for _, c := range cs {
+			counts[shard] += uint64(len(c.ColumnIDs))
+		}
+		if err := writeJSONFile(filepath.Join(tmp, strconv.FormatUint(shard, 10)), cs); err != nil {
+			return nil, errors.Wrapf(err, "staging shard %d", shard)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	partPath := filepath.Join(dir, "parts", strconv.Itoa(part))
+	if err := os.MkdirAll(filepath.Dir(partPath), 0750); err != nil {
+		return nil, errors.Wrap(err, "creating parts")
+	}
+	if err := os.RemoveAll(partPath); err != nil {
+		return nil, errors.Wrap(err, "replacing part")
+	}
+	if err := os.Rename(tmp, partPath); err != nil {
+		return nil, errors.Wrap(err, "moving part")
+	}
+	sess.Parts[part] = counts
+	if err := api.saveImportSession(sess); err != nil {
+		return nil, errors.Wrap(err, "saving session")
+	}
+	return sess.status(), nil
+}
+
+// CommitImportSession imports what the parts of an import session staged,
+// making it visible to queries a shard at a time, and returns the session's
+// status once it's finished. Committing a session whose last commit failed
+// resumes it; until then, the shards it finished stay visible.
+func (api *API) CommitImportSession(ctx context.Context, indexName, id string) (*ImportSessionStatus, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.CommitImportSession")
+	defer span.Finish()
+
+	if err := api.validate(apiImportSession); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	s := &api.importSessions
+	s.mu.Lock()
+	sess, err := api.importSession(indexName, id)
+	if err == nil {
+		switch {
+		case sess.State == ImportSessionCommitted:
+			out := sess.status()
+			s.mu.Unlock()
+			return out, nil
+		case s.committing[id]:
+			err = newConflictError(errors.Errorf("import session %s is being committed", id))
+		case s.pushing[id] > 0:
+			err = newConflictError(errors.Errorf("import session %s has parts being pushed", id))
+		}
+	}
+	if err == nil {
+		sess.State = ImportSessionCommitting
+		err = errors.Wrap(api.saveImportSession(sess), "saving session")
+	}
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.committing[id] = true
+	parts := make([]int, 0, len(sess.Parts))
+	shardSet := make(map[uint64]struct{})
+	for part, counts := range sess.Parts {
+		parts = append(parts, part)
+		for shard := range counts {
+			if _, ok := sess.Applied[shard]; !ok {
+				shardSet[shard] = struct{}{}
+			}
+		}
+	}
+	s.mu.Unlock()
+	sort.Ints(parts)
+	shards := make([]uint64, 0, len(shardSet))
+	for shard := range shardSet {
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+
+	for _, shard := range shards {
+		var n uint64
+		if n, err = api.applyImportSessionShard(ctx, sess, parts, shard); err != nil {
+			err = errors.Wrapf(err, "importing shard %d", shard)
+			break
+		}
+		s.mu.Lock()
+		sess.Applied[shard] = n
+		err = errors.Wrap(api.saveImportSession(sess), "saving session")
+		s.mu.Unlock()
+		if err != nil {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.committing, id)
+	if err != nil {
+		sess.Error = err.Error()
+		if serr := api.saveImportSession(sess); serr != nil {
+			api.server.logger.Errorf("saving import session %s: %v", id, serr)
+		}
+		return sess.status(), err
+	}
+	sess.State, sess.Error = ImportSessionCommitted, ""
+	if err := api.saveImportSession(sess); err != nil {
+		return nil, errors.Wrap(err, "saving session")
+	}
+	if err := os.RemoveAll(filepath.Join(api.importSessionPath(id), "parts")); err != nil {
+		api.server.logger.Errorf("removing parts of import session %s: %v", id, err)
+	}
+	return sess.status(), nil
+}
+
+// DeleteImportSession deletes an import session: it aborts an open
+// session, discarding its parts, and forgets a committed one. Keys created
+// for the parts of an aborted session are kept. A session which has started
+// committing can only be deleted once it's committed.
+func (api *API) DeleteImportSession(ctx context.Context, indexName, id string) error {
+	if err := api.validate(apiImportSession); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	s := &api.importSessions
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, err := api.importSession(indexName, id)
+	if err != nil {
+		return err
+	}
+	if sess.State == ImportSessionCommitting {
+		return newConflictError(errors.Errorf("import session %s has started committing", id))
+	} else if s.pushing[id] > 0 {
+		return newConflictError(errors.Errorf("import session %s has parts being pushed", id))
+	}
+	if err := os.RemoveAll(api.importSessionPath(id)); err != nil {
+		return errors.Wrap(err, "removing session")
+	}
+	delete(s.sessions, id)
+	return nil
+}
+
+// applyImportSessionShard imports what parts staged in shard, returning the
+// number of bits and values imported.
+func (api *API) applyImportSessionShard(ctx context.Context, sess *importSession, parts []int, shard uint64) (uint64, error) {
+	qcx := api.Txf().NewQcx()
+	defer qcx.Abort()
+
+	var n uint64
+	options := &ImportOptions{IgnoreKeyCheck: true}
+	for _, part := range parts {
+		var chunks []importSessionChunk
+		path := filepath.Join(api.importSessionPath(sess.ID), "parts", strconv.Itoa(part), strconv.FormatUint(shard, 10))
+		if err := readJSONFile(path, &chunks); os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return 0, errors.Wrapf(err, "reading part %d", part)
+		}
+		for _, c := range chunks {
+			var err error
+			if c.Values != nil {
+				err = api.server.defaultClient.ImportValue(ctx, qcx, &ImportValueRequest{
+					Index:     sess.Index,
+					Field:     c.Field,
+					Shard:     shard,
+					ColumnIDs: c.ColumnIDs,
+					Values:    c.Values,
+				}, options)
+			} else {
+				err = api.server.defaultClient.Import(ctx, qcx, &ImportRequest{
+					Index:      sess.Index,
+					Field:      c.Field,
+					Shard:      shard,
+					RowIDs:     c.RowIDs,
+					ColumnIDs:  c.ColumnIDs,
+					Timestamps: c.Timestamps,
+				}, options)
+			}
+			if err != nil {
+				return 0, errors.Wrapf(err, "importing part %d into field %s", part, c.Field)
+			}
+			n += uint64(len(c.ColumnIDs))
+		}
+	}
+	return n, qcx.Finish()
+}
+
+// importSessionChunks checks the imports of a part against the fields they
+// import into, translates their keys, and splits them by shard.
+func (api *API) importSessionChunks(ctx context.Context, indexName string, p *ImportSessionPart) (map[uint64][]importSessionChunk, error) {
+	idx, err := api.Index(ctx, indexName)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make(map[uint64][]importSessionChunk)
+	for i, imp := range p.Imports {
+		field := idx.Field(imp.Field)
+		if field == nil {
+			return nil, newNotFoundError(ErrFieldNotFound, imp.Field)
+		}
+		bad := func(format string, args ...interface{}) error {
+			return NewBadRequestError(errors.Errorf("import %d into field %s: "+format, append([]interface{}{i, imp.Field}, args...)...))
+		}
+
+		n := len(imp.ColumnIDs)
+		if idx.Keys() {
+			if n != 0 {
+				return nil, bad("column ids cannot be used because index uses string keys")
+			}
+			n = len(imp.ColumnKeys)
+		} else if len(imp.ColumnKeys) != 0 {
+			return nil, bad("column keys cannot be used because index uses integer IDs")
+		}
+		if len(imp.Timestamps) != 0 && len(imp.Timestamps) != n {
+			return nil, bad("%d columns but %d timestamps", n, len(imp.Timestamps))
+		}
+		switch field.Type() {
+		case FieldTypeInt, FieldTypeDecimal, FieldTypeTimestamp:
+			if field.Keys() {
+				return nil, bad("values of fields with keys aren't supported")
+			} else if len(imp.RowIDs)+len(imp.RowKeys) != 0 || len(imp.Timestamps) != 0 {
+				return nil, bad("field takes values, not rows")
+			} else if len(imp.Values) != n {
+				return nil, bad("%d columns but %d values", n, len(imp.Values))
+			}
+		default:
+			if len(imp.Values) != 0 {
+				return nil, bad("field takes rows, not values")
+			}
+			if field.Keys() {
+				if len(imp.RowIDs) != 0 {
+					return nil, bad("row ids cannot be used because field uses string keys")
+				} else if len(imp.RowKeys) != n {
+					return nil, bad("%d columns but %d rows", n, len(imp.RowKeys))
+				}
+			} else if len(imp.RowKeys) != 0 {
+				return nil, bad("row keys cannot be used because field uses integer IDs")
+			} else if len(imp.RowIDs) != n {
+				return nil, bad("%d columns but %d rows", n, len(imp.RowIDs))
+			}
+		}
+		if n == 0 {
+			continue
+		}
+
+		cols, rows := imp.ColumnIDs, imp.RowIDs
+		if idx.Keys() {
+			if cols, err = api.cluster.translateIndexKeys(ctx, indexName, imp.ColumnKeys, true); err != nil {
+				return nil, errors.Wrap(err, "translating columns")
+			}
+		}
+		if field.Keys() && len(imp.RowKeys) != 0 {
+			if rows, err = api.cluster.translateFieldKeys(ctx, field, imp.RowKeys, true); err != nil {
+				return nil, errors.Wrap(err, "translating rows")
+			}
+		}
+
+		byShard := make(map[uint64]*importSessionChunk)
+		for j, col := range cols {
+			shard := col / ShardWidth
+			c := byShard[shard]
+			if c == nil {
+				c = &importSessionChunk{Field: imp.Field}
+				byShard[shard] = c
+			}
+			c.ColumnIDs = append(c.ColumnIDs, col)
+			if rows != nil {
+				c.RowIDs = append(c.RowIDs, rows[j])
+			}
+			if imp.Values != nil {
+				c.Values = append(c.Values, imp.Values[j])
+			}
+			if imp.Timestamps != nil {
+				c.Timestamps = append(c.Timestamps, imp.Timestamps[j])
+			}
+		}
+		for shard, c := range byShard {
+			chunks[shard] = append(chunks[shard], *c)
+		}
+	}
+	return chunks, nil
+}
+
+// importSession returns the import session id of an index. The caller must
+// hold api.importSessions.mu.
+func (api *API) importSession(indexName, id string) (*importSession, error) {
+	if err := ValidateName(id); err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "import session id"))
+	}
+	sess, err := api.loadImportSession(id)
+	if err != nil {
+		return nil, err
+	} else if sess == nil || sess.Index != indexName {
+		return nil, newNotFoundError(errors.New("import session not found"), id)
+	}
+	return sess, nil
+}
+
+// loadImportSession returns the import session id, reading it from the data
+// directory if it hasn't been used since the node started, or nil if there
+// isn't one. The caller must hold api.importSessions.mu.
+func (api *API) loadImportSession(id string) (*importSession, error) {
+	s := &api.importSessions
+	if s.sessions == nil {
+		s.sessions = make(map[string]*importSession)
+		s.pushing = make(map[string]int)
+		s.committing = make(map[string]bool)
+	}
+	if sess := s.sessions[id]; sess != nil {
+		return sess, nil
+	}
+	var sess importSession
+	if err := readJSONFile(filepath.Join(api.importSessionPath(id), "session"), &sess); os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "reading import session %s", id)
+	}
+	if sess.Parts == nil {
+		sess.Parts = make(map[int]map[uint64]uint64)
+	}
+	if sess.Applied == nil {
+		sess.Applied = make(map[uint64]uint64)
+	}
+	s.sessions[id] = &sess
+	return &sess, nil
+}
+
+// saveImportSession records sess in the data directory. The caller must
+// hold api.importSessions.mu.
+func (api *API) saveImportSession(sess *importSession) error {
+	dir := api.importSessionPath(sess.ID)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	return writeJSONFile(filepath.Join(dir, "session"), sess)
+}
+
+func (api *API) importSessionPath(id string) string {
+	return filepath.Join(api.holder.path, importSessionDir, id)
+}
+
+// status returns the status of the session.
+func (sess *importSession) status() *ImportSessionStatus {
+	staged := make(map[uint64]uint64)
+	for _, counts := range sess.Parts {
+		for shard, n := range counts {
+			staged[shard] += n
+		}
+	}
+	out := &ImportSessionStatus{
+		ID:     sess.ID,
+		Index:  sess.Index,
+		State:  sess.State,
+		Parts:  len(sess.Parts),
+		Shards: make([]ImportSessionShard, 0, len(staged)),
+		Error:  sess.Error,
+	}
+	for shard, n := range staged {
+		out.Shards = append(out.Shards, ImportSessionShard{Shard: shard, Staged: n, Applied: sess.Applied[shard]})
+	}
+	sort.Slice(out.Shards, func(i, j int) bool { return out.Shards[i].Shard < out.Shards[j].Shard })
+	return out
+}
+
+// writeJSONFile writes v to the file at path as JSON, replacing the file
+// only once it's written.
+func writeJSONFile(path string, v interface{}) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// readJSONFile reads the JSON file at path into v.
+func readJSONFile(path string, v interface{}) error {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, v)
+}
@@ -4,12 +4,14 @@ package pilosa
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,11 +32,27 @@ type Index struct {
 	name          string
 	qualifiedName string
 	keys          bool // use string keys
+	namespace     string
 
 	// Existence tracking.
 	trackExistence bool
 	existenceFld   *Field
 
+	// Result limits; zero means unlimited.
+	maxColumns uint64
+	maxGroups  uint64
+	maxRows    uint64
+
+	// Storage quota in bytes; zero means unlimited.
+	maxStorage int64
+
+	// readOnly refuses writes to the index. Unlike the other options, it
+	// can be changed once the index exists.
+	readOnly bool
+
+	// Descriptive metadata from the index options.
+	metadata SchemaMetadata
+
 	// Fields by name.
 	fields map[string]*Field
 
@@ -56,6 +74,18 @@ type Index struct {
 	// track the subset of shards available to our views
 	fieldView2shard *FieldView2Shards
 
+	// Per-shard metadata used to skip shards when querying.
+	shardStats *shardStats
+
+	// The times each field was last written in each shard.
+	writeTimes *writeTimes
+
+	// The statistics of each field in each shard, kept until it's written.
+	fieldStats *fieldStatsCache
+
+	// Cubes declared for the index, and their results for each shard.
+	cubes *cubeStore
+
 	// indicate that we're closing and should wrap up and not allow new actions
 	closing chan struct{}
 }
@@ -90,6 +120,11 @@ func NewIndex(holder *Holder, path, name string) (*Index, error) {
 		translationSyncer: NopTranslationSyncer,
 
 		OpenTranslateStore: OpenInMemTranslateStore,
+
+		shardStats: newShardStats(),
+		writeTimes: newWriteTimes(),
+		fieldStats: newFieldStatsCache(),
+		cubes:      newCubeStore(filepath.Join(path, cubesDir)),
 	}
 	return idx, nil
 }
@@ -139,6 +174,44 @@ func (i *Index) TranslateStore(partitionID int) TranslateStore {
 // Keys returns true if the index uses string keys.
 func (i *Index) Keys() bool { return i.keys }
 
+// Namespace returns the namespace the index belongs to, if any.
+func (i *Index) Namespace() string { return i.namespace }
+
+// MaxStorage returns the bytes of disk the index can use on each node, or
+// zero if it's unlimited.
+func (i *Index) MaxStorage() int64 { return i.maxStorage }
+
+// ReadOnly reports whether writes to the index are refused.
+func (i *Index) ReadOnly() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.readOnly
+}
+
+// setNamespace puts the index in a namespace, tagging its stats with it.
+func (i *Index) setNamespace(namespace string) {
+	i.namespace = namespace
+	if namespace != "" {
+		i.Stats = i.Stats.WithTags("namespace:" + namespace)
+	}
+}
+
+// setOptions copies the result limits, storage quota, read-only flag,
+// metadata and cube definitions from opts onto the index. Keys, existence
+// tracking and the namespace are fixed when the index is created, and are
+// handled separately.
+func (i *Index) setOptions(opts IndexOptions) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.maxColumns = opts.MaxColumns
+	i.maxGroups = opts.MaxGroups
+	i.maxRows = opts.MaxRows
+	i.maxStorage = opts.MaxStorage
+	i.readOnly = opts.ReadOnly
+	i.metadata = opts.SchemaMetadata
+	i.cubes.define(opts.Cubes)
+}
+
 // Options returns all options for this index.
 func (i *Index) Options() IndexOptions {
 	i.mu.RLock()
@@ -150,6 +223,14 @@ func (i *Index) options() IndexOptions {
 	return IndexOptions{
 		Keys:           i.keys,
 		TrackExistence: i.trackExistence,
+		Namespace:      i.namespace,
+		MaxColumns:     i.maxColumns,
+		MaxGroups:      i.maxGroups,
+		MaxRows:        i.maxRows,
+		MaxStorage:     i.maxStorage,
+		ReadOnly:       i.readOnly,
+		Cubes:          i.cubes.definitions(),
+		SchemaMetadata: i.metadata,
 	}
 }
 
@@ -174,6 +255,8 @@ func (i *Index) OpenWithSchema(idx *disco.Index) error {
 	i.createdAt = cim.CreatedAt
 	i.trackExistence = cim.Meta.TrackExistence
 	i.keys = cim.Meta.Keys
+	i.setNamespace(cim.Meta.Namespace)
+	i.setOptions(cim.Meta)
 
 	return i.open(idx)
 }
@@ -774,6 +857,47 @@ func (i *Index) UpdateField(ctx context.Context, name string, update FieldUpdate
 			return nil, NewBadRequestError(errors.Errorf("invalid value for noStandardView: '%s'", update.Value))
 		}
 		cfm.Meta.NoStandardView = boolValue
+	case "description":
+		cfm.Meta.Description = update.Value
+	case "owner":
+		cfm.Meta.Owner = update.Value
+	case "tags":
+		var tags map[string]string
+		if update.Value != "" {
+			if err := json.Unmarshal([]byte(update.Value), &tags); err != nil {
+				return nil, NewBadRequestError(errors.Wrap(err, "tags must be a JSON object of strings"))
+			}
+		}
+		cfm.Meta.Tags = tags
+	case "cacheStaleness":
+		switch cfm.Meta.Type {
+		case FieldTypeSet, FieldTypeMutex, "":
+		default:
+			return nil, NewBadRequestError(errors.Errorf("can only update 'cacheStaleness' on a 'set' or 'mutex' type field, not '%s'", cfm.Meta.Type))
+		}
+		dur, err := time.ParseDuration(update.Value)
+		if err != nil {
+			return nil, NewBadRequestError(errors.Wrap(err, "parsing duration"))
+		}
+		if dur < 0 {
+			return nil, NewBadRequestError(errors.Errorf("cache staleness can't be negative: '%s'", update.Value))
+		}
+		cfm.Meta.CacheStaleness = dur
+	case "rejectNewKeys":
+		if !cfm.Meta.Keys {
+			return nil, NewBadRequestError(errors.New("can only update 'rejectNewKeys' on a keyed field"))
+		}
+		boolValue, err := strconv.ParseBool(update.Value)
+		if err != nil {
+			return nil, NewBadRequestError(errors.Errorf("invalid value for rejectNewKeys: '%s'", update.Value))
+		}
+		cfm.Meta.RejectNewKeys = boolValue
+	case "memoryPolicy":
+		p, err := parseFieldMemoryPolicy(update.Value)
+		if err != nil {
+			return nil, err
+		}
+		cfm.Meta.FieldMemoryPolicy = p
 	default:
 		return nil, NewBadRequestError(errors.Errorf("updates for option '%s' are not supported", update.Option))
 	}
@@ -800,6 +924,9 @@ func (i *Index) UpdateFieldLocal(cfm *CreateFieldMessage, update FieldUpdate) er
 	if err := field.applyOptions(*cfm.Meta); err != nil {
 		return errors.Wrap(err, "updating local field options")
 	}
+	if update.Option == "memoryPolicy" {
+		i.holder.notifyMemoryPolicyChanged()
+	}
 
 	return nil
 
@@ -908,10 +1035,25 @@ func (i *Index) DeleteField(name string) error {
 		return errors.Wrap(err, "closing")
 	}
 
+	if f.translateStore != nil {
+		if err := dropTranslateStores(f.translateStore); err != nil {
+			return errors.Wrapf(err, "field %s", name)
+		}
+	}
+
 	if err := i.holder.txf.DeleteFieldFromStore(i.name, name, i.fieldPath(name)); err != nil {
 		return errors.Wrap(err, "Txf.DeleteFieldFromStore")
 	}
 
+	i.shardStats.deleteField(f)
+	i.writeTimes.deleteField(name)
+	i.fieldStats.deleteField(name)
+	i.cubes.deleteField(name)
+
+	if err := i.holder.rowMeta.DeleteField(i.name, name); err != nil {
+		return errors.Wrap(err, "deleting row metadata")
+	}
+
 	// Remove reference.
 	delete(i.fields, name)
 
@@ -957,6 +1099,56 @@ type IndexOptions struct {
 	Keys           bool `json:"keys"`
 	TrackExistence bool `json:"trackExistence"`
 	PartitionN     int  `json:"partitionN"`
+
+	// Namespace is the namespace the index belongs to, which decides the
+	// quotas it's subject to and the credentials which can access it. It
+	// can't be changed once the index is created.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Result limits enforced by the executor. Zero means unlimited.
+	// MaxColumns applies to the columns returned by Extract and bitmap
+	// calls such as All, MaxGroups to the groups produced by GroupBy, and
+	// MaxRows to the rows returned by Rows.
+	MaxColumns uint64 `json:"maxColumns,omitempty"`
+	MaxGroups  uint64 `json:"maxGroups,omitempty"`
+	MaxRows    uint64 `json:"maxRows,omitempty"`
+
+	// MaxStorage limits the bytes of disk the index uses on each node.
+	// Imports and Set() are refused once it's reached. Zero means
+	// unlimited.
+	MaxStorage int64 `json:"maxStorageBytes,omitempty"`
+
+	// ReadOnly refuses writes to the index, including clears, while still
+	// allowing reads. It can be changed with API.UpdateIndex.
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// Cubes are GroupBy queries whose results are kept for each shard
+	// and updated as the shards are written to. They can be changed with
+	// API.UpdateIndex.
+	Cubes []CubeOptions `json:"cubes,omitempty"`
+
+	SchemaMetadata
+}
+
+// SchemaMetadata is descriptive information attached to an index or field.
+// It has no effect on how data is stored or queried, but is returned with
+// the schema and can be searched with API.SearchSchema.
+type SchemaMetadata struct {
+	Description string            `json:"description,omitempty"`
+	Owner       string            `json:"owner,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+// MatchTag reports whether the metadata has a tag matching the given
+// filter. The filter is either "key", which matches any value, or
+// "key=value".
+func (m SchemaMetadata) MatchTag(filter string) bool {
+	key, value, hasValue := strings.Cut(filter, "=")
+	v, ok := m.Tags[key]
+	if !ok {
+		return false
+	}
+	return !hasValue || v == value
 }
 
 type importData struct {
@@ -968,3 +1160,55 @@ type importData struct {
 func FormatQualifiedIndexName(index string) string {
 	return fmt.Sprintf("%s\x00", index)
 }
+
+// openShardFragments opens the fragments for each field view found in the
+// data of a shard which was written directly to its database, such as by a
+// restore or a clone, creating views as needed.
+func (i *Index) openShardFragments(ctx context.Context, db DBWrapper, shard uint64) error {
+	// The shard's data has been replaced wholesale.
+	i.shardStats.invalidate(shard)
+	i.cubes.beginWrite(shard)
+	i.cubes.endWrite(i, shard)
+	i.writeTimes.recordShard(shard, time.Now())
+
+	tx, err := db.NewTx(false, i.name, Txo{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	//arguments idx,shard do not matter for rbf they
+	//are ignored
+	flvs, err := tx.GetSortedFieldViewList(i, shard)
+	if err != nil {
+		return nil
+	}
+
+	for _, flv := range flvs {
+		fld := i.field(flv.Field)
+		view := fld.view(flv.View)
+		if view == nil {
+			view, err = fld.createViewIfNotExists(flv.View)
+			if err != nil {
+				return err
+			}
+		}
+		frag, err := view.CreateFragmentIfNotExists(shard)
+		if err != nil {
+			return err
+		}
+		err = frag.RebuildRankCache(ctx)
+		if err != nil {
+			return err
+		}
+		bd, err := view.bitDepth([]uint64{shard})
+		if err != nil {
+			return err
+		}
+		err = fld.cacheBitDepth(bd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
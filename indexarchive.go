@@ -0,0 +1,586 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// An index archive is a tar file holding everything needed to recreate an
+// index in another cluster: its schema, an RBF snapshot of each shard, the
+// index's column key partitions, and the key stores of its keyed fields.
+// A manifest, written last, lists every other file with its checksum, as
+// well as the shard width and partition count the archive was taken with.
+//
+//   schema.json
+//   shards/0000
+//   translate/0000
+//   fields/<field>/translate
+//   manifest.json
+//
+// Shards are restored to whichever nodes own them in the target cluster,
+// so it needn't be the same size as the source. If the target uses a
+// different number of partitions, column keys are re-partitioned to match.
+
+const (
+	indexArchiveSchema   = "schema.json"
+	indexArchiveManifest = "manifest.json"
+)
+
+// IndexArchiveManifest describes the contents of an index archive.
+type IndexArchiveManifest struct {
+	Index      string            `json:"index"`
+	Version    string            `json:"version"`
+	ShardWidth uint64            `json:"shardWidth"`
+	PartitionN int               `json:"partitionN"`
+	Shards     []uint64          `json:"shards"`
+	Files      map[string]string `json:"files"`
+}
+
+func indexArchiveShard(shard uint64) string {
+	return path.Join("shards", fmt.Sprintf("%04d", shard))
+}
+
+func indexArchivePartition(partition int) string {
+	return path.Join("translate", fmt.Sprintf("%04d", partition))
+}
+
+func indexArchiveField(field string) string {
+	return path.Join("fields", field, "translate")
+}
+
+// ExportIndex writes an archive of an index to w, returning its manifest.
+func (api *API) ExportIndex(ctx context.Context, indexName string, w io.Writer) (*IndexArchiveManifest, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ExportIndex")
+	defer span.Finish()
+
+	if err := api.validate(apiSchema); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	idx, err := api.Index(ctx, indexName)
+	if err != nil {
+		return nil, err
+	}
+	info, err := api.IndexInfo(ctx, indexName)
+	if err != nil {
+		return nil, err
+	}
+	snap := api.cluster.NewSnapshot()
+
+	manifest := &IndexArchiveManifest{
+		Index:      indexName,
+		Version:    Version,
+		ShardWidth: ShardWidth,
+		PartitionN: snap.PartitionN,
+		Shards:     idx.AvailableShards(includeRemote).Slice(),
+		Files:      make(map[string]string),
+	}
+	tw := tar.NewWriter(w)
+	add := func(name string, fn func(io.Writer) error) error {
+		sum, err := writeArchiveEntry(tw, name, fn)
+		if err != nil {
+			return errors.Wrapf(err, "archiving %s", name)
+		}
+		manifest.Files[name] = sum
+		return nil
+	}
+
+	if err := add(indexArchiveSchema, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(info)
+	}); err != nil {
+		return nil, err
+	}
+	for _, shard := range manifest.Shards {
+		shard := shard
+		if err := add(indexArchiveShard(shard), func(w io.Writer) error {
+			return api.archiveShard(ctx, snap, indexName, shard, w)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if idx.Keys() {
+		for partition := 0; partition < snap.PartitionN; partition++ {
+			partition := partition
+			if err := add(indexArchivePartition(partition), func(w io.Writer) error {
+				return api.archivePartition(ctx, snap, idx, partition, w)
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, field := range idx.Fields() {
+		if !field.Keys() || field.ForeignIndex() != "" {
+			continue
+		}
+		field := field
+		if err := add(indexArchiveField(field.Name()), func(w io.Writer) error {
+			return api.archiveField(ctx, field, w)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	buf, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding manifest")
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     indexArchiveManifest,
+		Mode:     0600,
+		Size:     int64(len(buf)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return nil, err
+	} else if _, err := tw.Write(buf); err != nil {
+		return nil, err
+	}
+	return manifest, tw.Close()
+}
+
+// writeArchiveEntry spools what fn writes to a temporary file, since a tar
+// header needs its size, then copies it to tw, returning its checksum.
+func writeArchiveEntry(tw *tar.Writer, name string, fn func(io.Writer) error) (string, error) {
+	f, err := os.CreateTemp("", "featurebase-archive-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	h := sha256.New()
+	if err := fn(io.MultiWriter(f, h)); err != nil {
+		return "", err
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	} else if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0600,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return "", err
+	} else if _, err := io.Copy(tw, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// archiveShard writes a snapshot of a shard, from the first of its owners
+// able to provide one.
+func (api *API) archiveShard(ctx context.Context, snap *disco.ClusterSnapshot, index string, shard uint64, w io.Writer) (err error) {
+	for _, node := range snap.ShardNodes(index, shard) {
+		var rc io.ReadCloser
+		var e error
+		if node.ID == api.NodeID() {
+			rc, e = api.IndexShardSnapshot(ctx, index, shard)
+		} else {
+			rc, e = api.server.defaultClient.ShardReaderFromURI(ctx, index, shard, node.URI)
+		}
+		if e != nil {
+			if err == nil {
+				err = e // save first error, try next node
+			}
+			continue
+		}
+		// Part of the snapshot may have been written by the time copying
+		// fails, so there's no trying another node then.
+		defer rc.Close()
+		_, err = io.Copy(w, rc)
+		return err
+	}
+	if err == nil {
+		err = errors.New("no nodes available")
+	}
+	return err
+}
+
+// archivePartition writes a column key partition, from its primary.
+func (api *API) archivePartition(ctx context.Context, snap *disco.ClusterSnapshot, idx *Index, partition int, w io.Writer) error {
+	primary := snap.PrimaryPartitionNode(partition)
+	if primary == nil {
+		return errors.Errorf("no primary for partition %d", partition)
+	}
+	if primary.ID == api.NodeID() {
+		store := idx.TranslateStore(partition)
+		if store == nil {
+			return ErrTranslateStoreNotFound
+		}
+		_, err := store.WriteTo(w)
+		return err
+	}
+	rc, err := api.server.defaultClient.IndexTranslateDataReaderFromURI(ctx, idx.Name(), partition, primary.URI)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(w, rc)
+	return err
+}
+
+// archiveField writes a field's keys, from the primary, which holds the
+// authoritative copy.
+func (api *API) archiveField(ctx context.Context, field *Field, w io.Writer) error {
+	primary := api.cluster.primaryNode()
+	if primary == nil {
+		return errors.New("no primary node")
+	}
+	if primary.ID == api.NodeID() {
+		store := field.TranslateStore()
+		if store == nil {
+			return ErrTranslateStoreNotFound
+		}
+		_, err := store.WriteTo(w)
+		return err
+	}
+	rc, err := api.server.defaultClient.FieldTranslateDataReaderFromURI(ctx, field.Index(), field.Name(), primary.URI)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(w, rc)
+	return err
+}
+
+// ImportIndex restores an index from an archive written by ExportIndex,
+// returning the archive's manifest. The index must not already exist. The
+// archive is checked against its manifest before anything is restored.
+func (api *API) ImportIndex(ctx context.Context, r io.Reader) (*IndexArchiveManifest, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ImportIndex")
+	defer span.Finish()
+
+	if err := api.validate(apiApplySchema); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	dir, err := os.MkdirTemp("", "featurebase-archive-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	manifest, err := readIndexArchive(r, dir)
+	if err != nil {
+		return nil, err
+	}
+	file := func(name string) string { return filepath.Join(dir, filepath.FromSlash(name)) }
+
+	var info IndexInfo
+	if buf, err := os.ReadFile(file(indexArchiveSchema)); err != nil {
+		return nil, err
+	} else if err := json.Unmarshal(buf, &info); err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "decoding schema"))
+	}
+	if info.Name != manifest.Index {
+		return nil, NewBadRequestError(errors.Errorf("schema is for index %q, not %q", info.Name, manifest.Index))
+	}
+
+	idx, err := api.CreateIndex(ctx, info.Name, info.Options)
+	if err != nil {
+		return nil, err
+	}
+	for _, fi := range info.Fields {
+		if idx.Field(fi.Name) != nil {
+			continue
+		}
+		opts := fi.Options
+		if _, err := api.CreateField(ctx, info.Name, fi.Name, func(fo *FieldOptions) error {
+			*fo = opts
+			return nil
+		}); err != nil {
+			return nil, errors.Wrapf(err, "creating field %q", fi.Name)
+		}
+	}
+
+	snap := api.cluster.NewSnapshot()
+	nodes := api.cluster.Nodes()
+	for name := range manifest.Files {
+		if !strings.HasPrefix(name, "fields/") {
+			continue
+		}
+		field := path.Base(path.Dir(name))
+		for _, node := range nodes {
+			if err := api.restoreArchiveFile(ctx, node, file(name), func(rd io.Reader) error {
+				return api.TranslateFieldDB(ctx, info.Name, field, rd)
+			}, func(fn func() (io.Reader, error)) error {
+				return api.server.defaultClient.ImportFieldKeys(ctx, &node.URI, info.Name, field, false, fn)
+			}); err != nil {
+				return nil, errors.Wrapf(err, "restoring keys of field %q on node %s", field, node.ID)
+			}
+		}
+	}
+
+	if idx.Keys() {
+		partitions, err := api.archivePartitions(ctx, idx, snap, manifest, dir)
+		if err != nil {
+			return nil, errors.Wrap(err, "re-partitioning column keys")
+		}
+		for partition, filename := range partitions {
+			partition := partition
+			for _, node := range snap.PartitionNodes(partition) {
+				if err := api.restoreArchiveFile(ctx, node, filename, func(rd io.Reader) error {
+					return api.TranslateIndexDB(ctx, info.Name, partition, rd)
+				}, func(fn func() (io.Reader, error)) error {
+					return api.server.defaultClient.ImportIndexKeys(ctx, &node.URI, info.Name, partition, false, fn)
+				}); err != nil {
+					return nil, errors.Wrapf(err, "restoring column keys of partition %d on node %s", partition, node.ID)
+				}
+			}
+		}
+	}
+
+	for _, shard := range manifest.Shards {
+		shard := shard
+		for _, node := range snap.ShardNodes(info.Name, shard) {
+			if err := api.restoreArchiveFile(ctx, node, file(indexArchiveShard(shard)), func(rd io.Reader) error {
+				return api.RestoreShard(ctx, info.Name, shard, rd)
+			}, func(fn func() (io.Reader, error)) error {
+				return api.server.defaultClient.RestoreShardFromURI(ctx, node.URI, info.Name, shard, fn)
+			}); err != nil {
+				return nil, errors.Wrapf(err, "restoring shard %d on node %s", shard, node.ID)
+			}
+		}
+	}
+	return manifest, nil
+}
+
+// restoreArchiveFile restores a file from an archive on node, with local if
+// it's this node, or remote otherwise.
+func (api *API) restoreArchiveFile(ctx context.Context, node *disco.Node, filename string, local func(io.Reader) error, remote func(func() (io.Reader, error)) error) error {
+	if node.ID != api.NodeID() {
+		return remote(func() (io.Reader, error) {
+			return os.Open(filename) // closed by the http library as the request body
+		})
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return local(f)
+}
+
+// readIndexArchive extracts an archive into dir, verifying its contents
+// against its manifest.
+func readIndexArchive(r io.Reader, dir string) (*IndexArchiveManifest, error) {
+	sums := make(map[string]string)
+	var manifest *IndexArchiveManifest
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, NewBadRequestError(errors.Wrap(err, "reading archive"))
+		}
+		name := hdr.Name
+		if hdr.Typeflag != tar.TypeReg || name != path.Clean(name) || path.IsAbs(name) || strings.HasPrefix(name, "../") {
+			return nil, NewBadRequestError(errors.Errorf("unexpected archive entry %q", name))
+		}
+		if name == indexArchiveManifest {
+			manifest = &IndexArchiveManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, NewBadRequestError(errors.Wrap(err, "decoding manifest"))
+			}
+			continue
+		}
+
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+			return nil, err
+		}
+		f, err := os.Create(filename)
+		if err != nil {
+			return nil, err
+		}
+		h := sha256.New()
+		_, err = io.Copy(io.MultiWriter(f, h), tr)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "extracting %s", name)
+		}
+		sums[name] = hex.EncodeToString(h.Sum(nil))
+	}
+
+	if manifest == nil {
+		return nil, NewBadRequestError(errors.New("archive has no manifest"))
+	} else if manifest.ShardWidth != ShardWidth {
+		return nil, NewBadRequestError(errors.Errorf("archive has shard width %d, not %d", manifest.ShardWidth, ShardWidth))
+	} else if manifest.PartitionN <= 0 {
+		return nil, NewBadRequestError(errors.Errorf("archive has invalid partition count %d", manifest.PartitionN))
+	}
+	if _, ok := manifest.Files[indexArchiveSchema]; !ok {
+		return nil, NewBadRequestError(errors.New("archive has no schema"))
+	}
+	for _, shard := range manifest.Shards {
+		if _, ok := manifest.Files[indexArchiveShard(shard)]; !ok {
+			return nil, NewBadRequestError(errors.Errorf("archive has no data for shard %d", shard))
+		}
+	}
+	for name, sum := range manifest.Files {
+		if got, ok := sums[name]; !ok {
+			return nil, NewBadRequestError(errors.Errorf("archive is missing %s", name))
+		} else if got != sum {
+			return nil, NewBadRequestError(errors.Errorf("checksum mismatch for %s", name))
+		}
+		delete(sums, name)
+	}
+	for name := range sums {
+		return nil, NewBadRequestError(errors.Errorf("archive has unlisted file %s", name))
+	}
+	return manifest, nil
+}
+
+// archivePartitions returns the files holding the column keys of each of
+// this cluster's partitions. When the archive was taken with the same
+// number of partitions, they're the archive's own. Otherwise its keys are
+// re-partitioned: each is stored both in the partition of its key, for
+// looking up IDs by key, and the partition of its ID's shard, for looking
+// up keys by ID.
+func (api *API) archivePartitions(ctx context.Context, idx *Index, snap *disco.ClusterSnapshot, manifest *IndexArchiveManifest, dir string) (map[int]string, error) {
+	partitions := make(map[int]string)
+	if manifest.PartitionN == snap.PartitionN {
+		for name := range manifest.Files {
+			if !strings.HasPrefix(name, "translate/") {
+				continue
+			}
+			partition, err := strconv.Atoi(path.Base(name))
+			if err != nil || partition >= snap.PartitionN {
+				return nil, NewBadRequestError(errors.Errorf("unexpected archive entry %q", name))
+			}
+			partitions[partition] = filepath.Join(dir, filepath.FromSlash(name))
+		}
+		return partitions, nil
+	}
+
+	tmp := filepath.Join(dir, "repartition")
+	if err := os.MkdirAll(tmp, 0750); err != nil {
+		return nil, err
+	}
+	dsts := make(map[int]TranslateStore)
+	defer func() {
+		for _, store := range dsts {
+			store.Close()
+		}
+	}()
+	dst := func(partition int) (TranslateStore, error) {
+		if store := dsts[partition]; store != nil {
+			return store, nil
+		}
+		store, err := idx.OpenTranslateStore(filepath.Join(tmp, fmt.Sprintf("%04d.db", partition)), idx.Name(), "", partition, snap.PartitionN, false)
+		if err != nil {
+			return nil, err
+		}
+		dsts[partition] = store
+		return store, nil
+	}
+
+	names := make([]string, 0, len(manifest.Files))
+	for name := range manifest.Files {
+		if strings.HasPrefix(name, "translate/") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		src, err := idx.OpenTranslateStore(filepath.Join(tmp, fmt.Sprintf("src-%04d.db", i)), idx.Name(), "", i, manifest.PartitionN, false)
+		if err != nil {
+			return nil, err
+		}
+		err = func() error {
+			defer src.Close()
+			f, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if _, err := src.ReadFrom(f); err != nil {
+				return errors.Wrapf(err, "reading %s", name)
+			}
+			return forEachTranslateEntry(ctx, src, func(entry *TranslateEntry) error {
+				keyPartition := snap.KeyToKeyPartition(idx.Name(), entry.Key)
+				idPartition := snap.IDToShardPartition(idx.Name(), entry.ID)
+				for _, partition := range []int{keyPartition, idPartition} {
+					store, err := dst(partition)
+					if err != nil {
+						return err
+					} else if err := store.ForceSet(entry.ID, entry.Key); err != nil {
+						return err
+					}
+					if idPartition == keyPartition {
+						break
+					}
+				}
+				return nil
+			})
+		}()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for partition, store := range dsts {
+		filename := filepath.Join(tmp, fmt.Sprintf("%04d", partition))
+		f, err := os.Create(filename)
+		if err != nil {
+			return nil, err
+		}
+		_, err = store.WriteTo(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return nil, err
+		}
+		partitions[partition] = filename
+	}
+	return partitions, nil
+}
+
+// forEachTranslateEntry calls fn for each entry in store. Entry readers
+// wait for new entries once they reach the end, so reading stops at the
+// store's highest ID.
+func forEachTranslateEntry(ctx context.Context, store TranslateStore, fn func(*TranslateEntry) error) error {
+	maxID, err := store.MaxID()
+	if err != nil {
+		return err
+	} else if maxID == 0 {
+		return nil
+	}
+	r, err := store.EntryReader(ctx, 0)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	for {
+		var entry TranslateEntry
+		if err := r.ReadEntry(&entry); err != nil {
+			return err
+		} else if err := fn(&entry); err != nil {
+			return err
+		} else if entry.ID >= maxID {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,401 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/roaring"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// GET /index/{index}/stats reports how many columns an index has, how many
+// rows and keys each of its fields has, and the lowest and highest values
+// of its int, decimal, and timestamp fields, without running a query. Each
+// node counts the shards it would query, and keeps the counts of each field
+// in each shard until its write times show the field was written there
+// again, so only shards written since the last request are read. Key counts
+// are kept until the index is next written. Columns are counted from the
+// existence field, so aren't known for indexes which don't track existence.
+
+// IndexStats are the statistics of an index.
+type IndexStats struct {
+	Index string `json:"index"`
+
+	// Columns is the number of columns in the index, or nil if the index
+	// doesn't track existence.
+	Columns *uint64 `json:"columns,omitempty"`
+
+	// Keys is the number of column keys of a keyed index.
+	Keys   uint64       `json:"keys"`
+	Fields []FieldStats `json:"fields"`
+}
+
+// FieldStats are the statistics of a field. Rows is the number of rows with
+// any columns set, for fields with rows, and Keys the number of row keys of
+// a keyed field. Values is the number of columns with values in an int,
+// decimal, or timestamp field, and Min and Max the lowest and highest of
+// them, with the number of columns having them.
+type FieldStats struct {
+	Field  string    `json:"field"`
+	Type   string    `json:"type"`
+	Rows   uint64    `json:"rows"`
+	Keys   uint64    `json:"keys"`
+	Values uint64    `json:"values"`
+	Min    *ValCount `json:"min,omitempty"`
+	Max    *ValCount `json:"max,omitempty"`
+}
+
+// IndexStatsNodeRequest asks a node for the statistics of the shards and
+// column key partitions it counts for an index, and of the row keys of the
+// index's fields if FieldKeys is set.
+type IndexStatsNodeRequest struct {
+	Shards     []uint64 `json:"shards"`
+	Partitions []int    `json:"partitions"`
+	FieldKeys  bool     `json:"fieldKeys"`
+}
+
+// IndexStatsNode are the statistics a node counts for an index, to be
+// added up with those of the other nodes. Values are as stored, without
+// the base of their fields.
+type IndexStatsNode struct {
+	Keys   uint64                     `json:"keys"`
+	Fields map[string]*FieldStatsNode `json:"fields"`
+}
+
+// FieldStatsNode are the statistics a node counts for a field. For the
+// existence field, Values is the number of columns.
+type FieldStatsNode struct {
+	Rows     []uint64 `json:"rows,omitempty"`
+	Keys     uint64   `json:"keys,omitempty"`
+	Values   uint64   `json:"values,omitempty"`
+	Min      int64    `json:"min,omitempty"`
+	MinCount uint64   `json:"minCount,omitempty"`
+	Max      int64    `json:"max,omitempty"`
+	MaxCount uint64   `json:"maxCount,omitempty"`
+}
+
+// add adds the statistics in o to s.
+func (s *FieldStatsNode) add(o *FieldStatsNode) {
+	if len(o.Rows) > 0 {
+		rows := roaring.NewBitmap(s.Rows...)
+		rows.DirectAddN(o.Rows...)
+		s.Rows = rows.Slice()
+	}
+	s.Keys += o.Keys
+	if o.Values > 0 {
+		if s.Values == 0 || o.Min < s.Min {
+			s.Min, s.MinCount = o.Min, o.MinCount
+		} else if o.Min == s.Min {
+			s.MinCount += o.MinCount
+		}
+		if s.Values == 0 || o.Max > s.Max {
+			s.Max, s.MaxCount = o.Max, o.MaxCount
+		} else if o.Max == s.Max {
+			s.MaxCount += o.MaxCount
+		}
+	}
+	s.Values += o.Values
+}
+
+// IndexStats returns the statistics of an index across the cluster.
+func (api *API) IndexStats(ctx context.Context, indexName string) (*IndexStats, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.IndexStats")
+	defer span.Finish()
+
+	if err := api.validate(apiIndexStats); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	idx := api.holder.Index(indexName)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+
+	snap := api.cluster.NewSnapshot()
+	byNode, err := api.server.executor.shardsByNode(snap.Nodes, indexName, idx.AvailableShards(includeRemote).Slice())
+	if err != nil {
+		return nil, err
+	}
+	reqs := make([]*IndexStatsNodeRequest, len(snap.Nodes))
+	for i, node := range snap.Nodes {
+		reqs[i] = &IndexStatsNodeRequest{FieldKeys: snap.IsPrimaryFieldTranslationNode(node.ID)}
+		for n, shards := range byNode {
+			if n.ID == node.ID {
+				reqs[i].Shards = shards
+			}
+		}
+	}
+	if idx.Keys() {
+		for partition := 0; partition < snap.PartitionN; partition++ {
+			primary := snap.PrimaryPartitionNode(partition)
+			for i, node := range snap.Nodes {
+				if node.ID == primary.ID {
+					reqs[i].Partitions = append(reqs[i].Partitions, partition)
+				}
+			}
+		}
+	}
+
+	eg, _ := errgroup.WithContext(ctx)
+	myID := api.NodeID()
+	results := make([]*IndexStatsNode, len(snap.Nodes))
+	for i, node := range snap.Nodes {
+		i, node := i, node
+		if node.ID != myID {
+			eg.Go(func() (err error) {
+				results[i], err = api.server.defaultClient.IndexStatsNode(ctx, &node.URI, indexName, reqs[i])
+				return errors.Wrapf(err, "getting stats from node %s", node.ID)
+			})
+		} else {
+			eg.Go(func() (err error) {
+				results[i], err = api.indexStatsThisNode(idx, reqs[i])
+				return err
+			})
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	stats := &IndexStats{Index: indexName, Fields: []FieldStats{}}
+	sums := make(map[string]*FieldStatsNode)
+	for _, res := range results {
+		stats.Keys += res.Keys
+		for name, fs := range res.Fields {
+			if sums[name] == nil {
+				sums[name] = &FieldStatsNode{}
+			}
+			sums[name].add(fs)
+		}
+	}
+	for _, f := range idx.Fields() {
+		sum := sums[f.Name()]
+		if sum == nil {
+			sum = &FieldStatsNode{}
+		}
+		if f.Name() == existenceFieldName {
+			columns := sum.Values
+			stats.Columns = &columns
+			continue
+		}
+		fs := FieldStats{Field: f.Name(), Type: f.Type(), Rows: uint64(len(sum.Rows)), Keys: sum.Keys, Values: sum.Values}
+		if sum.Values > 0 {
+			min, err := f.valCountize(sum.Min, sum.MinCount, nil)
+			if err != nil {
+				return nil, errors.Wrapf(err, "field %s minimum", f.Name())
+			}
+			max, err := f.valCountize(sum.Max, sum.MaxCount, nil)
+			if err != nil {
+				return nil, errors.Wrapf(err, "field %s maximum", f.Name())
+			}
+			fs.Min, fs.Max = &min, &max
+		}
+		stats.Fields = append(stats.Fields, fs)
+	}
+	return stats, nil
+}
+
+// IndexStatsNode returns the statistics this node counts for an index.
+func (api *API) IndexStatsNode(ctx context.Context, indexName string, req *IndexStatsNodeRequest) (*IndexStatsNode, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.IndexStatsNode")
+	defer span.Finish()
+
+	if err := api.validate(apiIndexStats); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	idx := api.holder.Index(indexName)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	return api.indexStatsThisNode(idx, req)
+}
+
+func (api *API) indexStatsThisNode(idx *Index, req *IndexStatsNodeRequest) (*IndexStatsNode, error) {
+	out := &IndexStatsNode{Fields: make(map[string]*FieldStatsNode)}
+	for _, f := range idx.Fields() {
+		sum := &FieldStatsNode{}
+		for _, shard := range req.Shards {
+			fs, err := idx.fieldStats.shard(f, shard)
+			if err != nil {
+				return nil, errors.Wrapf(err, "counting field %s shard %d", f.Name(), shard)
+			}
+			sum.add(fs)
+		}
+		if req.FieldKeys && f.Keys() {
+			n, err := idx.fieldStats.keyCount(idx, f.TranslateStore())
+			if err != nil {
+				return nil, errors.Wrapf(err, "counting keys of field %s", f.Name())
+			}
+			sum.Keys = n
+		}
+		out.Fields[f.Name()] = sum
+	}
+	for _, partition := range req.Partitions {
+		n, err := idx.fieldStats.keyCount(idx, idx.TranslateStore(partition))
+		if err != nil {
+			return nil, errors.Wrapf(err, "counting keys of partition %d", partition)
+		}
+		out.Keys += n
+	}
+	return out, nil
+}
+
+// fieldStatsCache holds the statistics of the fields of an index in each shard,
+// and the key counts of its translation stores.
+type fieldStatsCache struct {
+	mu     sync.Mutex
+	shards map[string]map[uint64]*cachedStats
+	keys   map[TranslateStore]cachedKeyCount
+}
+
+type cachedStats struct {
+	stats    *FieldStatsNode
+	computed time.Time
+}
+
+type cachedKeyCount struct {
+	n         uint64
+	maxID     uint64
+	lastWrite time.Time
+}
+
+func newFieldStatsCache() *fieldStatsCache {
+	return &fieldStatsCache{
+		shards: make(map[string]map[uint64]*cachedStats),
+		keys:   make(map[TranslateStore]cachedKeyCount),
+	}
+}
+
+// shard returns the statistics of field f in shard, counting them
+// again if the field has been written there since they were last counted.
+func (c *fieldStatsCache) shard(f *Field, shard uint64) (*FieldStatsNode, error) {
+	last := f.idx.writeTimes.lastShardWrite(f.Name(), shard)
+	c.mu.Lock()
+	cached := c.shards[f.Name()][shard]
+	c.mu.Unlock()
+	if cached != nil && cached.computed.After(last) {
+		return cached.stats, nil
+	}
+
+	now := time.Now()
+	stats, err := f.statsForShard(shard)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.shards[f.Name()] == nil {
+		c.shards[f.Name()] = make(map[uint64]*cachedStats)
+	}
+	c.shards[f.Name()][shard] = &cachedStats{stats: stats, computed: now}
+	return stats, nil
+}
+
+// keyCount returns the number of keys in store, counting them again if more
+// keys have been created, or idx has been written, since they were last
+// counted. Keys are only deleted along with the data using them.
+func (c *fieldStatsCache) keyCount(idx *Index, store TranslateStore) (uint64, error) {
+	if store == nil {
+		return 0, nil
+	}
+	maxID, err := store.MaxID()
+	if err != nil {
+		return 0, errors.Wrap(err, "getting max ID")
+	}
+	lastWrite := idx.writeTimes.lastWrite()
+	c.mu.Lock()
+	cached, ok := c.keys[store]
+	c.mu.Unlock()
+	if ok && cached.maxID == maxID && cached.lastWrite.Equal(lastWrite) {
+		return cached.n, nil
+	}
+
+	ids, err := store.Match(func([]byte) bool { return true })
+	if err != nil {
+		return 0, errors.Wrap(err, "listing keys")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys[store] = cachedKeyCount{n: uint64(len(ids)), maxID: maxID, lastWrite: lastWrite}
+	return uint64(len(ids)), nil
+}
+
+// deleteField discards the statistics of a field which has been deleted.
+func (c *fieldStatsCache) deleteField(field string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.shards, field)
+}
+
+// statsForShard counts the statistics of f in a shard.
+func (f *Field) statsForShard(shard uint64) (*FieldStatsNode, error) {
+	tx := f.holder.txf.NewTx(Txo{Index: f.idx, Shard: shard})
+	defer tx.Rollback()
+
+	stats := &FieldStatsNode{}
+	switch f.Type() {
+	case FieldTypeInt, FieldTypeDecimal, FieldTypeTimestamp:
+		bsig := f.bsiGroup(f.name)
+		if bsig == nil {
+			return nil, ErrBSIGroupNotFound
+		}
+		view := f.view(viewBSIGroupPrefix + f.name)
+		if view == nil {
+			return stats, nil
+		}
+		frag := view.Fragment(shard)
+		if frag == nil {
+			return stats, nil
+		}
+		exists, err := frag.notNull(tx)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading existence")
+		}
+		if stats.Values = exists.Count(); stats.Values == 0 {
+			return stats, nil
+		}
+		if stats.Min, stats.MinCount, err = frag.min(tx, nil, bsig.BitDepth); err != nil {
+			return nil, errors.Wrap(err, "finding minimum")
+		}
+		if stats.Max, stats.MaxCount, err = frag.max(tx, nil, bsig.BitDepth); err != nil {
+			return nil, errors.Wrap(err, "finding maximum")
+		}
+	default:
+		if f.name == existenceFieldName {
+			view := f.view(viewStandard)
+			if view == nil {
+				return stats, nil
+			}
+			frag := view.Fragment(shard)
+			if frag == nil {
+				return stats, nil
+			}
+			row, err := frag.row(tx, 0)
+			if err != nil {
+				return nil, errors.Wrap(err, "reading existence")
+			}
+			stats.Values = row.Count()
+			return stats, nil
+		}
+		// Time views only hold rows also in the standard view, if it's
+		// kept, but a time field might not keep it.
+		rows := roaring.NewBitmap()
+		for _, view := range f.views() {
+			frag := view.Fragment(shard)
+			if frag == nil {
+				continue
+			}
+			ids, err := frag.rows(context.Background(), tx, 0)
+			if err != nil {
+				return nil, errors.Wrapf(err, "listing rows of view %s", view.name)
+			}
+			rows.DirectAddN(ids...)
+		}
+		stats.Rows = rows.Slice()
+	}
+	return stats, nil
+}
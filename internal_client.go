@@ -176,6 +176,11 @@ func retryWith400Policy(ctx context.Context, resp *http.Response, err error) (bo
 			}
 		}
 	}
+	if resp != nil && resp.StatusCode == http.StatusUpgradeRequired {
+		// The other node doesn't speak this one's protocol, and won't
+		// until one of them is upgraded.
+		return false, nil
+	}
 	if resp != nil && resp.StatusCode >= 400 {
 		return true, nil
 	}
@@ -543,6 +548,368 @@ func (c *InternalClient) MutexCheck(ctx context.Context, uri *pnet.URI, indexNam
 	return out, err
 }
 
+// FieldWrites returns the shards of a field on the node at uri written
+// after since, with the times they were last written.
+func (c *InternalClient) FieldWrites(ctx context.Context, uri *pnet.URI, indexName, fieldName string, since time.Time) ([]ShardWrite, error) {
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	u := uri.Path(fmt.Sprintf("/internal/index/%s/field/%s/writes?since=%s", indexName, fieldName, url.QueryEscape(since.Format(time.RFC3339Nano))))
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+	var out []ShardWrite
+	err = json.NewDecoder(resp.Body).Decode(&out)
+	return out, err
+}
+
+// CheckConsistency checks the consistency of an index's data on the node
+// at uri.
+func (c *InternalClient) CheckConsistency(ctx context.Context, uri *pnet.URI, indexName string) ([]ConsistencyProblem, error) {
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	u := uri.Path(fmt.Sprintf("/internal/index/%s/consistency", indexName))
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+	var out []ConsistencyProblem
+	err = json.NewDecoder(resp.Body).Decode(&out)
+	return out, err
+}
+
+// FieldKeyCollisions returns the keys of a field which more than one ID
+// translates to, as the cluster of the node at uri has them.
+func (c *InternalClient) FieldKeyCollisions(ctx context.Context, uri *pnet.URI, indexName, fieldName string) ([]KeyCollision, error) {
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	u := uri.Path(fmt.Sprintf("/index/%s/field/%s/key-collisions", indexName, fieldName))
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	var out []KeyCollision
+	err = json.NewDecoder(resp.Body).Decode(&out)
+	return out, err
+}
+
+// MergeDuplicateKeysNode merges the rows of the duplicate IDs of a field's
+// keys into their canonical IDs' rows on the node at uri only.
+func (c *InternalClient) MergeDuplicateKeysNode(ctx context.Context, uri *pnet.URI, indexName, fieldName string, collisions []KeyCollision) error {
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	buf, err := json.Marshal(collisions)
+	if err != nil {
+		return errors.Wrap(err, "encoding request")
+	}
+	u := uri.Path(fmt.Sprintf("/internal/index/%s/field/%s/merge-keys", indexName, fieldName))
+	req, err := http.NewRequest("POST", u, bytes.NewReader(buf))
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "executing request")
+	}
+	return resp.Body.Close()
+}
+
+// ImportColumnAttrsNode sets the attributes of columns of an index on the
+// node at uri only.
+func (c *InternalClient) ImportColumnAttrsNode(ctx context.Context, uri *pnet.URI, indexName string, attrs map[uint64]map[string]interface{}) error {
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	buf, err := json.Marshal(attrs)
+	if err != nil {
+		return errors.Wrap(err, "encoding request")
+	}
+	u := uri.Path(fmt.Sprintf("/internal/index/%s/column-attrs", indexName))
+	req, err := http.NewRequest("POST", u, bytes.NewReader(buf))
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "executing request")
+	}
+	return resp.Body.Close()
+}
+
+// ColumnAttrsNode returns the attributes the node at uri holds for columns
+// of an index.
+func (c *InternalClient) ColumnAttrsNode(ctx context.Context, uri *pnet.URI, indexName string, cols []uint64) (map[uint64]map[string]interface{}, error) {
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	buf, err := json.Marshal(cols)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding request")
+	}
+	u := uri.Path(fmt.Sprintf("/internal/index/%s/column-attrs/get", indexName))
+	req, err := http.NewRequest("POST", u, bytes.NewReader(buf))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	var out map[uint64]map[string]interface{}
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	err = dec.Decode(&out)
+	return out, err
+}
+
+// ExportQueryPart has the node at uri export a part of the results of a
+// query.
+func (c *InternalClient) ExportQueryPart(ctx context.Context, uri *pnet.URI, preq *ExportPartRequest) (ExportPart, error) {
+	buf, err := json.Marshal(preq)
+	if err != nil {
+		return ExportPart{}, errors.Wrap(err, "encoding request")
+	}
+	req, err := http.NewRequest("POST", uri.Path("/internal/export-part"), bytes.NewReader(buf))
+	if err != nil {
+		return ExportPart{}, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return ExportPart{}, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	var out ExportPart
+	err = json.NewDecoder(resp.Body).Decode(&out)
+	return out, err
+}
+
+// ReindexShard has the node at uri copy a shard of the source of a reindex.
+func (c *InternalClient) ReindexShard(ctx context.Context, uri *pnet.URI, sreq *ReindexShardRequest) (ReindexShardResult, error) {
+	buf, err := json.Marshal(sreq)
+	if err != nil {
+		return ReindexShardResult{}, errors.Wrap(err, "encoding request")
+	}
+	req, err := http.NewRequest("POST", uri.Path("/internal/reindex-shard"), bytes.NewReader(buf))
+	if err != nil {
+		return ReindexShardResult{}, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return ReindexShardResult{}, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	var out ReindexShardResult
+	err = json.NewDecoder(resp.Body).Decode(&out)
+	return out, err
+}
+
+// UsageReport reports the storage used by every index, field, and view on
+// the node at uri.
+func (c *InternalClient) UsageReport(ctx context.Context, uri *pnet.URI) (*UsageReport, error) {
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	req, err := http.NewRequest("GET", uri.Path("/internal/usage"), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code: %s", resp.Status)
+	}
+	var out UsageReport
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, errors.Wrap(err, "decoding usage report")
+	}
+	return &out, nil
+}
+
+// StorageReport gets a report of how the node at uri stores the data of an
+// index, or of every index if indexName is empty.
+func (c *InternalClient) StorageReport(ctx context.Context, uri *pnet.URI, indexName string) (*StorageReport, error) {
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	req, err := http.NewRequest("GET", uri.Path("/storage?index="+url.QueryEscape(indexName)), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	var out StorageReport
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, errors.Wrap(err, "decoding storage report")
+	}
+	return &out, nil
+}
+
+// OptimizeStorage optimizes the storage of the node at uri for an index, or
+// for every index if indexName is empty, calling fn with the progress of
+// each shard as it's reported.
+func (c *InternalClient) OptimizeStorage(ctx context.Context, uri *pnet.URI, indexName string, fn func(StorageOptimizeProgress) error) error {
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	req, err := http.NewRequest("POST", uri.Path("/storage/optimize?index="+url.QueryEscape(indexName)), nil)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var p StorageOptimizeProgress
+		if err := dec.Decode(&p); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "decoding progress")
+		} else if p.Error != "" {
+			return errors.New(p.Error)
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+}
+
+// NodeDiagnostics gets the diagnostics of the node at uri, with up to slowN
+// of its slowest recent queries.
+func (c *InternalClient) NodeDiagnostics(ctx context.Context, uri *pnet.URI, slowN int) (*NodeDiagnostics, error) {
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	req, err := http.NewRequest("GET", uri.Path(fmt.Sprintf("/diagnostics?slow=%d", slowN)), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	var out NodeDiagnostics
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, errors.Wrap(err, "decoding diagnostics")
+	}
+	return &out, nil
+}
+
+// IndexStatsNode gets the statistics the node at uri counts for an index.
+func (c *InternalClient) IndexStatsNode(ctx context.Context, uri *pnet.URI, indexName string, sreq *IndexStatsNodeRequest) (*IndexStatsNode, error) {
+	buf, err := json.Marshal(sreq)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding request")
+	}
+	req, err := http.NewRequest("POST", uri.Path(fmt.Sprintf("/internal/index/%s/stats", indexName)), bytes.NewReader(buf))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+	var out IndexStatsNode
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, errors.Wrap(err, "decoding index stats")
+	}
+	return &out, nil
+}
+
 func (c *InternalClient) PostSchema(ctx context.Context, uri *pnet.URI, s *Schema, remote bool) error {
 	u := uri.Path(fmt.Sprintf("/schema?remote=%v", remote))
 	buf, err := json.Marshal(s)
@@ -739,6 +1106,9 @@ func (c *InternalClient) QueryNode(ctx context.Context, uri *pnet.URI, index str
 	} else if qresp.Err != nil {
 		return nil, qresp.Err
 	}
+	if load, ok := parseNodeLoad(resp.Header.Get(headerNodeLoad)); ok {
+		qresp.load = &load
+	}
 
 	return qresp, nil
 }
@@ -795,6 +1165,12 @@ func (c *InternalClient) importNode(ctx context.Context, node *disco.Node, index
 	if opts.IgnoreKeyCheck {
 		vals.Set("ignoreKeyCheck", "true")
 	}
+	if opts.CreateParents {
+		vals.Set("createParents", "true")
+		if opts.ParentExistsField != "" {
+			vals.Set("parentExistsField", opts.ParentExistsField)
+		}
+	}
 	url := fmt.Sprintf("%s?%s", u.String(), vals.Encode())
 
 	req, err := http.NewRequest("POST", url, bytes.NewReader(buf))
@@ -1763,6 +2139,43 @@ func (c *InternalClient) MatchFieldKeysNode(ctx context.Context, uri *pnet.URI,
 	return matches, nil
 }
 
+// KeyPrefixShardsNode asks a node which shards of an index hold a column
+// whose key, in one of the given partitions, starts with prefix.
+func (c *InternalClient) KeyPrefixShardsNode(ctx context.Context, uri *pnet.URI, index, prefix string, partitions []int) (shards []uint64, err error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.KeyPrefixShardsNode")
+	defer span.Finish()
+
+	buf, err := json.Marshal(KeyPrefixShardsRequest{Prefix: prefix, Partitions: partitions})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling request")
+	}
+
+	// Create HTTP request.
+	u := uriPathToURL(uri, fmt.Sprintf("/internal/translate/index/%s/keys/prefix-shards", index))
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(buf))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	// Apply headers.
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &req.Header)
+
+	// Send the request.
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "executing request")
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(&shards); err != nil {
+		return nil, errors.Wrap(err, "json decoding")
+	}
+	return shards, nil
+}
+
 func (c *InternalClient) Transactions(ctx context.Context) (map[string]*Transaction, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.Transactions")
 	defer span.Finish()
@@ -1938,6 +2351,7 @@ func (c *InternalClient) executeRequest(req *http.Request, opts ...executeReques
 
 func (c *InternalClient) executeRetryableRequest(req *retryablehttp.Request, opts ...executeRequestOption) (*http.Response, error) {
 	tracing.GlobalTracer.InjectHTTPHeaders(req.Request)
+	setProtocolHeaders(req.Header)
 	req.Close = false
 	eo := &executeOpts{}
 	for _, opt := range opts {
@@ -1988,6 +2402,12 @@ func (c *InternalClient) handleResponse(req *http.Request, eo *executeOpts, resp
 		}
 		return nil, errors.Wrap(err, "getting response")
 	}
+	// Nodes which don't understand this one's protocol version refuse its
+	// requests, and this node refuses their responses.
+	if err := checkProtocol(resp.Header, req.URL.Host); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
 	if eo.giveRawResponse {
 		return resp, nil
 	}
@@ -2009,7 +2429,14 @@ func (c *InternalClient) handleResponse(req *http.Request, eo *executeOpts, resp
 		} else {
 			msg = string(buf)
 		}
-		return resp, errors.Errorf("against %s %s: '%s'", req.URL.String(), resp.Status, msg)
+		err = errors.Errorf("against %s %s: '%s'", req.URL.String(), resp.Status, msg)
+		// Errors keep the codes the other node gave them.
+		var coded CodedError
+		if errors.As(qr.Err, &coded) {
+			coded.Err = err
+			return resp, coded
+		}
+		return resp, err
 	}
 	return resp, nil
 }
@@ -2370,6 +2797,36 @@ func (c *InternalClient) ShardReader(ctx context.Context, index string, shard ui
 	return resp.Body, nil
 }
 
+// ShardReaderFromURI returns a reader that provides a snapshot of the current
+// shard RBF data on the specified node. Caller *must* close the returned
+// ReadCloser.
+func (c *InternalClient) ShardReaderFromURI(ctx context.Context, index string, shard uint64, uri pnet.URI) (io.ReadCloser, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.ShardReaderFromURI")
+	defer span.Finish()
+
+	node := &disco.Node{
+		URI: uri,
+	}
+	u := nodePathToURL(node, fmt.Sprintf("/internal/index/%s/shard/%d/snapshot", index, shard))
+
+	// Build request.
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	req.Header.Set("Accept", "application/octet-stream")
+	AddAuthToken(ctx, &req.Header)
+
+	// Execute request.
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
 // IDAllocDataReader returns a reader that provides a snapshot of ID allocation data.
 func (c *InternalClient) IDAllocDataReader(ctx context.Context) (io.ReadCloser, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.IDAllocDataReader")
@@ -2477,6 +2934,82 @@ func (c *InternalClient) FieldTranslateDataReader(ctx context.Context, index, fi
 	return resp.Body, nil
 }
 
+// IndexTranslateDataReaderFromURI returns a reader that provides a snapshot
+// of translation data for a partition in an index, from the specified node.
+func (c *InternalClient) IndexTranslateDataReaderFromURI(ctx context.Context, index string, partitionID int, uri pnet.URI) (io.ReadCloser, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.IndexTranslateDataReaderFromURI")
+	defer span.Finish()
+
+	u := nodePathToURL(&disco.Node{URI: uri}, "/internal/translate/data")
+	u.RawQuery = url.Values{"index": {index}, "partition": {strconv.Itoa(partitionID)}}.Encode()
+	return c.translateDataReader(ctx, u.String())
+}
+
+// LocalIndexTranslateDataReaderFromURI returns a reader that provides a
+// snapshot of the translation data the specified node has for a partition in
+// an index, whether or not it owns the partition.
+func (c *InternalClient) LocalIndexTranslateDataReaderFromURI(ctx context.Context, index string, partitionID int, uri pnet.URI) (io.ReadCloser, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.LocalIndexTranslateDataReaderFromURI")
+	defer span.Finish()
+
+	u := nodePathToURL(&disco.Node{URI: uri}, "/internal/translate/data")
+	u.RawQuery = url.Values{"index": {index}, "partition": {strconv.Itoa(partitionID)}, "local": {"true"}}.Encode()
+	return c.translateDataReader(ctx, u.String())
+}
+
+// FieldTranslateDataReaderFromURI returns a reader that provides a snapshot
+// of translation data for a field, from the specified node.
+func (c *InternalClient) FieldTranslateDataReaderFromURI(ctx context.Context, index, field string, uri pnet.URI) (io.ReadCloser, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.FieldTranslateDataReaderFromURI")
+	defer span.Finish()
+
+	u := nodePathToURL(&disco.Node{URI: uri}, "/internal/translate/data")
+	u.RawQuery = url.Values{"index": {index}, "field": {field}}.Encode()
+	return c.translateDataReader(ctx, u.String())
+}
+
+func (c *InternalClient) translateDataReader(ctx context.Context, u string) (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	req.Header.Set("User-Agent", "pilosa/"+Version)
+	req.Header.Set("Accept", "application/octet-stream")
+	AddAuthToken(ctx, &req.Header)
+
+	resp, err := c.executeRequest(req.WithContext(ctx), forwardAuthHeader(true))
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrTranslateStoreNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// RestoreShardFromURI replaces a shard's data on the specified node with an
+// RBF snapshot.
+func (c *InternalClient) RestoreShardFromURI(ctx context.Context, uri pnet.URI, index string, shard uint64, readerFunc func() (io.Reader, error)) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.RestoreShardFromURI")
+	defer span.Finish()
+
+	u := nodePathToURL(&disco.Node{URI: uri}, fmt.Sprintf("/internal/restore/%s/%d", index, shard))
+	httpReq, err := retryablehttp.NewRequest("POST", u.String(), readerFunc)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	httpReq.Header.Set("Content-Type", "application/octet-stream")
+	httpReq.Header.Set("User-Agent", "pilosa/"+Version)
+	AddAuthToken(ctx, &httpReq.Header)
+
+	resp, err := c.executeRetryableRequest(httpReq.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
 // Status returns pilosa cluster state as a string ("NORMAL", "DEGRADED", "DOWN", ...)
 func (c *InternalClient) Status(ctx context.Context) (string, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.Status")
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	gohttp "net/http"
 	"reflect"
@@ -1676,3 +1677,42 @@ func TestAddAuthToken(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_QueryNode_ErrorCode(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1)
+	defer cluster.Close()
+	ctx := context.Background()
+	cluster.CreateField(t, cluster.Idx(), pilosa.IndexOptions{}, "f")
+	m := cluster.GetNode(0)
+	if err := m.API.UpdateIndex(ctx, cluster.Idx(), pilosa.IndexUpdate{Option: "readOnly", Value: "true"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := m.API.UpdateIndex(ctx, cluster.Idx(), pilosa.IndexUpdate{Option: "readOnly", Value: "false"}); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// Errors from other nodes keep their codes.
+	c := MustNewClient(m.URL(), pilosa.GetHTTPClient(nil))
+	_, err := c.QueryNode(ctx, &m.API.Node().URI, cluster.Idx(), &pilosa.QueryRequest{Query: "Set(1, f=1)", Remote: true})
+	if err == nil {
+		t.Fatal("expected error writing to a read-only index")
+	}
+	if code, retryable := pilosa.ErrorCodeOf(err); code != pilosa.ErrorCodeIndexReadOnly || !retryable {
+		t.Fatalf("expected retryable %s, got %s (retryable %t): %v", pilosa.ErrorCodeIndexReadOnly, code, retryable, err)
+	}
+
+	// JSON responses carry them too.
+	resp := test.Do(t, "POST", fmt.Sprintf("%s/index/%s/query", m.URL(), cluster.Idx()), "Row(f=")
+	var body struct {
+		Error     string
+		Code      pilosa.ErrorCode
+		Retryable bool
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &body); err != nil {
+		t.Fatal(err)
+	} else if body.Code != pilosa.ErrorCodeBadRequest || body.Retryable || body.Error == "" {
+		t.Fatalf("expected a bad request error, got %+v", body)
+	}
+}
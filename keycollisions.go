@@ -0,0 +1,278 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+
+	"github.com/featurebasedb/featurebase/v3/roaring"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Restoring a keyed field's translation store badly, such as from a backup
+// taken while keys were being created, can leave a key with several IDs.
+// The store translates the key to one of them, the canonical ID, but the
+// others, its duplicates, still translate back to the key, and the rows of
+// both hold bits set with the key. FieldKeyCollisions finds such keys, and
+// MergeDuplicateKeys moves the bits of each duplicate's rows to the
+// canonical ID's and removes the duplicates from translation.
+
+// KeyCollision is a key of a field which more than one ID translates to.
+type KeyCollision struct {
+	Key string `json:"key"`
+	// ID is the key's canonical ID, which the key translates to.
+	ID uint64 `json:"id"`
+	// Duplicates are the other IDs which translate to the key.
+	Duplicates []uint64 `json:"duplicates"`
+}
+
+// FieldKeyCollisions returns the keys of a field which more than one ID
+// translates to, as the field's primary translation node has them.
+func (api *API) FieldKeyCollisions(ctx context.Context, indexName, fieldName string) ([]KeyCollision, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.FieldKeyCollisions")
+	defer span.Finish()
+
+	if err := api.validate(apiFieldKeyCollisions); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	field, err := api.keyedField(ctx, indexName, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replicas translate a key to whichever of its IDs they were sent last,
+	// so only the primary's canonical IDs are the same for every node.
+	snap := api.cluster.NewSnapshot()
+	if !snap.IsPrimaryFieldTranslationNode(api.NodeID()) {
+		primary := snap.PrimaryFieldTranslationNode()
+		return api.server.defaultClient.FieldKeyCollisions(ctx, &primary.URI, indexName, fieldName)
+	}
+	return fieldKeyCollisions(field.TranslateStore())
+}
+
+// MergeDuplicateKeys merges, on every node, the rows of the duplicate IDs of
+// a field's keys into their canonical IDs' rows, and removes the duplicate
+// IDs from translation. It returns the collisions it merged.
+func (api *API) MergeDuplicateKeys(ctx context.Context, indexName, fieldName string) ([]KeyCollision, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.MergeDuplicateKeys")
+	defer span.Finish()
+
+	if err := api.validate(apiMergeDuplicateKeys); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	collisions, err := api.FieldKeyCollisions(ctx, indexName, fieldName)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding key collisions")
+	}
+	if len(collisions) == 0 {
+		return collisions, nil
+	}
+
+	snap := api.cluster.NewSnapshot()
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, node := range snap.Nodes {
+		node := node
+		eg.Go(func() error {
+			var err error
+			if node.ID == api.NodeID() {
+				err = api.mergeDuplicateKeysThisNode(ctx, indexName, fieldName, collisions)
+			} else {
+				err = api.server.defaultClient.MergeDuplicateKeysNode(ctx, &node.URI, indexName, fieldName, collisions)
+			}
+			return errors.Wrapf(err, "merging on node %s", node.ID)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return collisions, nil
+}
+
+// MergeDuplicateKeysNode merges the given collisions of a field's keys on
+// this node only.
+func (api *API) MergeDuplicateKeysNode(ctx context.Context, indexName, fieldName string, collisions []KeyCollision) error {
+	if err := api.validate(apiMergeDuplicateKeys); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	if _, err := api.keyedField(ctx, indexName, fieldName); err != nil {
+		return err
+	}
+	return api.mergeDuplicateKeysThisNode(ctx, indexName, fieldName, collisions)
+}
+
+func (api *API) mergeDuplicateKeysThisNode(ctx context.Context, indexName, fieldName string, collisions []KeyCollision) error {
+	field := api.holder.Field(indexName, fieldName)
+	if field == nil {
+		return newNotFoundError(ErrFieldNotFound, fieldName)
+	}
+	qcx := api.Txf().NewQcx()
+	defer qcx.Abort()
+	if err := field.mergeDuplicateRows(ctx, qcx, collisions); err != nil {
+		return errors.Wrap(err, "merging rows")
+	}
+	if err := qcx.Finish(); err != nil {
+		return errors.Wrap(err, "committing")
+	}
+
+	// Move the translation last, so an interrupted merge can be run again.
+	store := field.TranslateStore()
+	dups := roaring.NewBitmap()
+	for _, c := range collisions {
+		dups.DirectAddN(c.Duplicates...)
+	}
+	commit, err := store.Delete(dups)
+	if err != nil {
+		return errors.Wrap(err, "deleting duplicate IDs")
+	} else if err := commit.Commit(); err != nil {
+		return errors.Wrap(err, "committing deletion of duplicate IDs")
+	}
+	// Deleting a duplicate ID may also have deleted its key's translation.
+	for _, c := range collisions {
+		if err := store.ForceSet(c.ID, c.Key); err != nil {
+			return errors.Wrapf(err, "translating key %q", c.Key)
+		}
+	}
+	return nil
+}
+
+// keyedField returns the named field, if it has keys.
+func (api *API) keyedField(ctx context.Context, indexName, fieldName string) (*Field, error) {
+	field, err := api.Field(ctx, indexName, fieldName)
+	if err != nil {
+		return nil, err
+	}
+	if !field.Keys() {
+		return nil, NewBadRequestError(errors.Errorf("field %s doesn't have keys", fieldName))
+	}
+	return field, nil
+}
+
+// keyCollisionBatchSize is how many IDs fieldKeyCollisions translates at a
+// time.
+const keyCollisionBatchSize = 1 << 16
+
+// fieldKeyCollisions returns the keys which more than one of the store's IDs
+// translate to, sorted by key. Each key's canonical ID is the one the store
+// translates the key to or, if that isn't one of them, the lowest. IDs
+// translating to the empty key can't be told apart from unused ones, so
+// they're ignored.
+func fieldKeyCollisions(store TranslateStore) ([]KeyCollision, error) {
+	maxID, err := store.MaxID()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading max ID")
+	}
+	idsByKey := make(map[string][]uint64)
+	ids := make([]uint64, 0, keyCollisionBatchSize)
+	for start := uint64(1); start <= maxID; start += keyCollisionBatchSize {
+		ids = ids[:0]
+		for id := start; id <= maxID && id < start+keyCollisionBatchSize; id++ {
+			ids = append(ids, id)
+		}
+		keys, err := store.TranslateIDs(ids)
+		if err != nil {
+			return nil, errors.Wrap(err, "translating IDs")
+		}
+		for i, key := range keys {
+			if key != "" {
+				idsByKey[key] = append(idsByKey[key], ids[i])
+			}
+		}
+	}
+
+	collisions := []KeyCollision{}
+	for key, keyIDs := range idsByKey {
+		if len(keyIDs) < 2 {
+			continue
+		}
+		found, err := store.FindKeys(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding key %q", key)
+		}
+		c := KeyCollision{Key: key, ID: keyIDs[0]}
+		if id, ok := found[key]; ok {
+			for _, keyID := range keyIDs {
+				if keyID == id {
+					c.ID = id
+					break
+				}
+			}
+		}
+		for _, keyID := range keyIDs {
+			if keyID != c.ID {
+				c.Duplicates = append(c.Duplicates, keyID)
+			}
+		}
+		collisions = append(collisions, c)
+	}
+	sort.Slice(collisions, func(a, b int) bool { return collisions[a].Key < collisions[b].Key })
+	return collisions, nil
+}
+
+// mergeDuplicateRows merges the rows of each collision's duplicate IDs into
+// the row of its canonical ID, in every view of the field on this node, and
+// clears them.
+func (f *Field) mergeDuplicateRows(ctx context.Context, qcx *Qcx, collisions []KeyCollision) error {
+	byShard := make(map[uint64][]*fragment)
+	for _, v := range f.views() {
+		for _, frag := range v.allFragments() {
+			byShard[frag.shard] = append(byShard[frag.shard], frag)
+		}
+	}
+	shards := make([]uint64, 0, len(byShard))
+	for shard := range byShard {
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(a, b int) bool { return shards[a] < shards[b] })
+
+	for _, shard := range shards {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := f.mergeShardDuplicateRows(qcx, shard, byShard[shard], collisions); err != nil {
+			return errors.Wrapf(err, "merging shard %d", shard)
+		}
+	}
+	return nil
+}
+
+func (f *Field) mergeShardDuplicateRows(qcx *Qcx, shard uint64, frags []*fragment, collisions []KeyCollision) (err0 error) {
+	tx, finisher, err := qcx.GetTx(Txo{Write: writable, Index: f.idx, Shard: shard})
+	if err != nil {
+		return err
+	}
+	defer finisher(&err0)
+
+	for _, frag := range frags {
+		for _, c := range collisions {
+			row, err := frag.row(tx, c.ID)
+			if err != nil {
+				return errors.Wrapf(err, "reading row %d of view %s", c.ID, frag.view())
+			}
+			merged := false
+			for _, dup := range c.Duplicates {
+				dupRow, err := frag.row(tx, dup)
+				if err != nil {
+					return errors.Wrapf(err, "reading row %d of view %s", dup, frag.view())
+				}
+				if !dupRow.Any() {
+					continue
+				}
+				row = row.Union(dupRow)
+				merged = true
+				if _, err := frag.clearRow(tx, dup); err != nil {
+					return errors.Wrapf(err, "clearing row %d of view %s", dup, frag.view())
+				}
+			}
+			if !merged {
+				continue
+			}
+			if _, err := frag.setRow(tx, row, c.ID); err != nil {
+				return errors.Wrapf(err, "setting row %d of view %s", c.ID, frag.view())
+			}
+		}
+	}
+	return nil
+}
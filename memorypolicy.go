@@ -0,0 +1,215 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/rbf"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// Field memory policies, which control how a field's data is kept in
+// memory. Fragment data lives in memory-mapped files, so it's normally up
+// to the operating system which fragments stay resident; large, rarely
+// queried fields can push out small, latency-critical ones.
+const (
+	// MemoryPolicyDefault leaves the field's data to the operating system.
+	MemoryPolicyDefault = ""
+
+	// MemoryPolicyPinned reads the field's data into memory, and keeps
+	// touching it so that it stays among the most recently used pages.
+	MemoryPolicyPinned = "pinned"
+
+	// MemoryPolicyCold drops the field's data from memory, and from the
+	// page cache, so that it doesn't displace other fields' data.
+	MemoryPolicyCold = "cold"
+)
+
+// memoryPolicyInterval is how often memory policies are applied.
+const memoryPolicyInterval = time.Minute
+
+// FieldMemoryPolicy controls how a field's data is kept in memory.
+type FieldMemoryPolicy struct {
+	// MemoryPolicy is one of the MemoryPolicy constants.
+	MemoryPolicy string `json:"memoryPolicy,omitempty"`
+
+	// MemoryPolicyViews are the views the policy applies to. It applies to
+	// all of the field's views if this is empty.
+	MemoryPolicyViews []string `json:"memoryPolicyViews,omitempty"`
+}
+
+func (p FieldMemoryPolicy) validate() error {
+	switch p.MemoryPolicy {
+	case MemoryPolicyDefault, MemoryPolicyPinned, MemoryPolicyCold:
+		return nil
+	}
+	return NewBadRequestError(errors.Errorf("invalid memory policy: '%s'", p.MemoryPolicy))
+}
+
+// policy returns the memory policy for a view of the field.
+func (p FieldMemoryPolicy) policy(view string) string {
+	if len(p.MemoryPolicyViews) == 0 {
+		return p.MemoryPolicy
+	}
+	for _, v := range p.MemoryPolicyViews {
+		if v == view {
+			return p.MemoryPolicy
+		}
+	}
+	return MemoryPolicyDefault
+}
+
+// parseFieldMemoryPolicy parses the value of a "memoryPolicy" field update,
+// which is either a policy for all of the field's views, or a JSON object
+// with the policy and the views it applies to.
+func parseFieldMemoryPolicy(value string) (FieldMemoryPolicy, error) {
+	var p FieldMemoryPolicy
+	if strings.HasPrefix(strings.TrimSpace(value), "{") {
+		if err := json.Unmarshal([]byte(value), &p); err != nil {
+			return p, NewBadRequestError(errors.Wrap(err, "parsing memory policy"))
+		}
+	} else {
+		p.MemoryPolicy = value
+	}
+	return p, p.validate()
+}
+
+// FragmentResidency describes how much of a fragment is in memory on this
+// node.
+type FragmentResidency struct {
+	Index  string `json:"index"`
+	Field  string `json:"field"`
+	View   string `json:"view"`
+	Shard  uint64 `json:"shard"`
+	Policy string `json:"policy,omitempty"`
+
+	// Bytes is the size of the fragment's storage, and ResidentBytes how
+	// much of it is in memory.
+	Bytes         int64 `json:"bytes"`
+	ResidentBytes int64 `json:"residentBytes"`
+}
+
+// adviseFragment applies advice to the storage of a fragment, and reports
+// how much of it was resident beforehand. Only RBF storage supports this.
+func (h *Holder) adviseFragment(idx *Index, field, view string, shard uint64, advice rbf.Advice) (FragmentResidency, error) {
+	res := FragmentResidency{Index: idx.Name(), Field: field, View: view, Shard: shard}
+	tx := h.txf.NewTx(Txo{Index: idx, Shard: shard})
+	defer tx.Rollback()
+	rtx, ok := tx.(*RBFTx)
+	if !ok {
+		return res, errors.Errorf("memory policies not available for %q storage", tx.Type())
+	}
+	r, err := rtx.tx.AdviseBitmaps(rbfName(idx.Name(), field, view, shard), advice)
+	if err != nil {
+		return res, err
+	}
+	res.Bytes = int64(r.PageN) * rbf.PageSize
+	res.ResidentBytes = int64(r.ResidentN) * rbf.PageSize
+	return res, nil
+}
+
+// applyMemoryPolicies applies the memory policies of every field to their
+// fragments on this node. Failing to apply them to one fragment is logged
+// and doesn't stop them being applied to the others.
+func (h *Holder) applyMemoryPolicies() {
+	for _, idx := range h.Indexes() {
+		for _, f := range idx.Fields() {
+			p := f.Options().FieldMemoryPolicy
+			if p.MemoryPolicy == MemoryPolicyDefault {
+				continue
+			}
+			for _, v := range f.views() {
+				var advice rbf.Advice
+				switch p.policy(v.name) {
+				case MemoryPolicyPinned:
+					advice = rbf.AdviceWillNeed
+				case MemoryPolicyCold:
+					advice = rbf.AdviceDontNeed
+				default:
+					continue
+				}
+				for _, frag := range v.fragmentList() {
+					select {
+					case <-h.closing:
+						return
+					default:
+					}
+					if _, err := h.adviseFragment(idx, f.Name(), v.name, frag.shard, advice); err != nil {
+						h.Logger.Errorf("applying memory policy to %s/%s/%s/%d: %v", idx.Name(), f.Name(), v.name, frag.shard, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// monitorMemoryPolicies periodically applies field memory policies, and
+// whenever they change. This is run in a goroutine.
+func (h *Holder) monitorMemoryPolicies() {
+	ticker := time.NewTicker(memoryPolicyInterval)
+	defer ticker.Stop()
+
+	for {
+		h.applyMemoryPolicies()
+		select {
+		case <-h.closing:
+			return
+		case <-ticker.C:
+		case <-h.memoryPolicyChanged:
+		}
+	}
+}
+
+// notifyMemoryPolicyChanged asks for memory policies to be applied soon.
+func (h *Holder) notifyMemoryPolicyChanged() {
+	select {
+	case h.memoryPolicyChanged <- struct{}{}:
+	default:
+	}
+}
+
+// FieldResidency reports how much of each fragment of a field is in memory
+// on this node.
+func (api *API) FieldResidency(ctx context.Context, indexName, fieldName string) ([]FragmentResidency, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.FieldResidency")
+	defer span.Finish()
+
+	if err := api.validate(apiFieldResidency); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	idx := api.holder.Index(indexName)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	f := idx.Field(fieldName)
+	if f == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
+	}
+
+	p := f.Options().FieldMemoryPolicy
+	res := []FragmentResidency{}
+	for _, v := range f.views() {
+		for _, frag := range v.fragmentList() {
+			r, err := api.holder.adviseFragment(idx, f.Name(), v.name, frag.shard, rbf.AdviceNormal)
+			if err != nil {
+				return nil, errors.Wrapf(err, "checking residency of view %s shard %d", v.name, frag.shard)
+			}
+			r.Policy = p.policy(v.name)
+			res = append(res, r)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].View != res[j].View {
+			return res[i].View < res[j].View
+		}
+		return res[i].Shard < res[j].Shard
+	})
+	return res, nil
+}
@@ -12,8 +12,11 @@ const (
 	MetricInvalidateCache                 = "invalidate_cache_total"
 	MetricInvalidateCacheSkipped          = "invalidate_cache_skipped_total"
 	MetricReadDirtyCache                  = "dirty_cache_total"
+	MetricMaintainCache                   = "maintain_cache_total"
 	MetricRankCacheLength                 = "rank_cache_length"
 	MetricCacheThresholdReached           = "cache_threshold_reached_total"
+	MetricCacheHits                       = "cache_hits_total"
+	MetricCacheMisses                     = "cache_misses_total"
 	MetricRow                             = "query_row_total"
 	MetricRowBSI                          = "query_row_bsi_total"
 	MetricSetBit                          = "set_bit_total"
@@ -24,6 +27,9 @@ const (
 	MetricClearedN                        = "cleared_total"
 	MetricSnapshotDurationSeconds         = "snapshot_duration_seconds"
 	MetricBlockRepair                     = "block_repair_total"
+	MetricCoalescedRead                   = "coalesced_read_total"
+	MetricSpillFiles                      = "spill_files"
+	MetricSpillBytes                      = "spill_bytes"
 	MetricSyncFieldDurationSeconds        = "sync_field_duration_seconds"
 	MetricSyncIndexDurationSeconds        = "sync_index_duration_seconds"
 	MetricHTTPRequest                     = "http_request_duration_seconds"
@@ -51,4 +57,13 @@ const (
 	MetricExclusiveTransactionBlocked     = "transaction_exclusive_blocked"
 	MetricPqlQueries                      = "pql_queries_total"
 	MetricSqlQueries                      = "sql_queries_total"
+	MetricQueryPriority                   = "query_priority_total"
+	MetricQueryAdmissionWaitSeconds       = "query_admission_wait_seconds"
+	MetricQueryQueueWaitSeconds           = "query_queue_wait_seconds"
+	MetricClientJobsQueued                = "client_jobs_queued"
+	MetricClientJobsRunning               = "client_jobs_running"
+	MetricLegacyCallsRewritten            = "legacy_calls_rewritten_total"
+	MetricDiskFreeBytes                   = "disk_free_bytes"
+	MetricReadOnly                        = "read_only"
+	MetricCorruptFragments                = "corrupt_fragments"
 )
@@ -0,0 +1,430 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"math"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// resolveQueryIndexes returns the indexes targeted by the index name of a
+// QueryRequest. The name may be a comma-separated list, and each element
+// may be an index, an alias, or a glob pattern such as "events_2024_*"
+// matching index names.
+func (api *API) resolveQueryIndexes(name string) ([]string, error) {
+	if !strings.ContainsAny(name, ",*?[") {
+		return []string{api.holder.aliases.Resolve(name)}, nil
+	}
+
+	var indexes []string
+	seen := make(map[string]struct{})
+	add := func(index string) {
+		if _, ok := seen[index]; !ok {
+			seen[index] = struct{}{}
+			indexes = append(indexes, index)
+		}
+	}
+	for _, elem := range strings.Split(name, ",") {
+		elem = strings.TrimSpace(elem)
+		if elem == "" {
+			return nil, NewBadRequestError(ErrIndexRequired)
+		}
+		if !strings.ContainsAny(elem, "*?[") {
+			add(api.holder.aliases.Resolve(elem))
+			continue
+		}
+		if _, err := path.Match(elem, ""); err != nil {
+			return nil, NewBadRequestError(errors.Wrapf(err, "index pattern %q", elem))
+		}
+		var matched bool
+		for _, idx := range api.holder.Indexes() {
+			if ok, _ := path.Match(elem, idx.Name()); ok {
+				add(idx.Name())
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, newNotFoundError(ErrIndexNotFound, elem)
+		}
+	}
+	return indexes, nil
+}
+
+// multiIndexCall reports whether a top-level call can be run against
+// several indexes with its results merged.
+func multiIndexCall(c *pql.Call) error {
+	if c.IsWrite() {
+		return errors.Errorf("%s cannot be used with multiple indexes", c.Name)
+	}
+	switch c.Name {
+	case "Count":
+		if len(c.Children) != 1 {
+			return errors.New("Count requires a single child call")
+		}
+	case "GroupBy":
+		// Sorting, offsets, and having-conditions can't be applied to
+		// each index separately and then merged.
+		for _, arg := range []string{"sort", "offset", "having"} {
+			if _, ok := c.Args[arg]; ok {
+				return errors.Errorf("GroupBy %s is not supported with multiple indexes", arg)
+			}
+		}
+		// Averages can't be added, nor histograms whose edges may differ,
+		// nor counts of distinct values, which may be in several indexes.
+		if aggregate, _, err := c.CallArg("aggregate"); err == nil && aggregate != nil {
+			switch aggregate.Name {
+			case "Avg", "WeightedAvg", "Histogram":
+				return errors.Errorf("GroupBy %s aggregate is not supported with multiple indexes", aggregate.Name)
+			case "Count":
+				if len(aggregate.Children) == 1 && aggregate.Children[0].Name == "Distinct" {
+					return errors.New("GroupBy Count(Distinct) aggregate is not supported with multiple indexes")
+				}
+			}
+		}
+	case "Sum", "Min", "Max":
+	case "Row", "Range", "All", "Not", "Union", "Intersect", "Difference", "Xor", "UnionRows", "IntersectRows", "ConstRow", "Limit", "Distinct":
+	default:
+		return errors.Errorf("%s is not supported with multiple indexes", c.Name)
+	}
+	// A limit or offset is applied to the results merged from every
+	// index, which is only done for the top-level call, or Count's child.
+	top := c
+	if c.Name == "Count" {
+		top = c.Children[0]
+	}
+	return checkNestedPaging(top.Children)
+}
+
+// checkNestedPaging returns an error if any of calls, or their children,
+// takes a limit or offset.
+func checkNestedPaging(calls []*pql.Call) error {
+	for _, c := range calls {
+		_, hasLimit := c.Args["limit"]
+		_, hasOffset := c.Args["offset"]
+		if c.Name == "Limit" || (c.Name == "Distinct" && (hasLimit || hasOffset)) {
+			return errors.Errorf("%s with a limit or offset is only supported at the top level with multiple indexes", c.Name)
+		}
+		if err := checkNestedPaging(c.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pagedIndexCall returns the call whose limit and offset, if it has any,
+// apply to the results of the top-level call c: c itself for Limit and
+// Distinct, or Count's child if it's one of those.
+func pagedIndexCall(c *pql.Call) *pql.Call {
+	if c.Name == "Count" && len(c.Children) == 1 {
+		c = c.Children[0]
+	}
+	switch c.Name {
+	case "Limit", "Distinct":
+		return c
+	}
+	return nil
+}
+
+// queryIndexes runs the query in req against each of indexes, merging the
+// results. Row results are unioned, and Count returns the number of
+// distinct records matching across all indexes, so a record which appears
+// in several indexes is only counted once. GroupBy counts and aggregates,
+// and Sum, are added, while Min and Max are taken over all indexes. The
+// limit and offset of Limit and Distinct are applied to the merged
+// results, in which records of keyed indexes are ordered by key. For a
+// union to be meaningful, the indexes must all be keyed or all unkeyed.
+func (api *API) queryIndexes(ctx context.Context, req *QueryRequest, indexes []string) (QueryResponse, error) {
+	q, err := pql.NewParser(strings.NewReader(req.Query)).Parse()
+	if err != nil {
+		return QueryResponse{}, NewBadRequestError(errors.Wrap(err, "parsing"))
+	}
+	for _, c := range q.Calls {
+		if err := multiIndexCall(c); err != nil {
+			return QueryResponse{}, NewBadRequestError(err)
+		}
+	}
+
+	var keys bool
+	for i, name := range indexes {
+		idx := api.holder.Index(name)
+		if idx == nil {
+			return QueryResponse{}, newNotFoundError(ErrIndexNotFound, name)
+		}
+		if i == 0 {
+			keys = idx.Keys()
+		} else if idx.Keys() != keys {
+			return QueryResponse{}, NewBadRequestError(errors.New("cannot query keyed and unkeyed indexes together"))
+		}
+	}
+
+	var results []interface{}
+	var existenceFallback string
+	var completeness *Completeness
+	for _, name := range indexes {
+		// Each execution translates the calls in place, so every index
+		// gets its own copy of the query.
+		q, err := pql.NewParser(strings.NewReader(req.Query)).Parse()
+		if err != nil {
+			return QueryResponse{}, errors.Wrap(err, "parsing")
+		}
+		api.rewriteLegacyCalls(name, q)
+		// Count is computed from the merged rows, since the same record
+		// may match in more than one index.
+		for i, c := range q.Calls {
+			if c.Name == "Count" {
+				q.Calls[i] = c.Children[0]
+			}
+		}
+		// So is the page of Limit and Distinct results.
+		for i, c := range q.Calls {
+			switch pc := pagedIndexCall(c); {
+			case pc == nil:
+			case pc.Name == "Limit":
+				if len(pc.Children) == 1 {
+					q.Calls[i] = pc.Children[0]
+				}
+			default:
+				delete(pc.Args, "limit")
+				delete(pc.Args, "offset")
+			}
+		}
+
+		r := *req
+		r.Index = name
+		resp, err := api.executeQuery(ctx, &r, q)
+		if err != nil {
+			return QueryResponse{}, errors.Wrapf(err, "querying index %s", name)
+		}
+		if resp.ExistenceFallback != "" {
+			existenceFallback = resp.ExistenceFallback
+		}
+		if resp.Completeness != nil {
+			if completeness == nil {
+				completeness = &Completeness{}
+			}
+			completeness.merge(resp.Completeness)
+		}
+		if results == nil {
+			results = resp.Results
+			continue
+		}
+		for i, c := range q.Calls {
+			if results[i], err = mergeIndexResults(c.Name, results[i], resp.Results[i]); err != nil {
+				return QueryResponse{}, errors.Wrapf(err, "merging %s results", c.Name)
+			}
+		}
+	}
+
+	for i, c := range q.Calls {
+		if pc := pagedIndexCall(c); pc != nil {
+			if results[i], err = pageIndexResult(pc, results[i]); err != nil {
+				return QueryResponse{}, err
+			}
+		}
+		switch c.Name {
+		case "Count":
+			switch r := results[i].(type) {
+			case *Row:
+				if r == nil {
+					results[i] = uint64(0)
+				} else if keys {
+					results[i] = uint64(len(r.Keys))
+				} else {
+					results[i] = r.Count()
+				}
+			case SignedRow:
+				results[i] = r.Neg.Count() + r.Pos.Count()
+			case DistinctTimestamp:
+				results[i] = uint64(len(r.Values))
+			default:
+				results[i] = uint64(0)
+			}
+		case "GroupBy":
+			limit, hasLimit, err := c.UintArg("limit")
+			if err != nil {
+				return QueryResponse{}, NewBadRequestError(errors.Wrap(err, "getting limit"))
+			}
+			if gc, ok := results[i].(*GroupCounts); ok && hasLimit && uint64(len(gc.groups)) > limit {
+				gc.groups = gc.groups[:limit]
+			}
+		}
+	}
+	return QueryResponse{Results: results, ExistenceFallback: existenceFallback, Completeness: completeness}, nil
+}
+
+// pageIndexResult returns the page of result, merged from several indexes,
+// given by the limit and offset of c, a Limit or Distinct call.
+func pageIndexResult(c *pql.Call, result interface{}) (interface{}, error) {
+	limit, hasLimit, err := c.UintArg("limit")
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "getting limit"))
+	}
+	offset, hasOffset, err := c.UintArg("offset")
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "getting offset"))
+	}
+	if !hasLimit && !hasOffset {
+		return result, nil
+	} else if !hasLimit {
+		limit = math.MaxUint64
+	}
+	switch r := result.(type) {
+	case *Row:
+		if r != nil && len(r.Keys) > 0 {
+			keys := r.Keys
+			if offset > uint64(len(keys)) {
+				offset = uint64(len(keys))
+			}
+			keys = keys[offset:]
+			if limit < uint64(len(keys)) {
+				keys = keys[:limit]
+			}
+			return &Row{Keys: keys}, nil
+		}
+	case DistinctTimestamp:
+		return nil, NewBadRequestError(errors.New("Distinct(): limit and offset aren't supported on timestamp fields"))
+	}
+	return pageDistinct(result, offset, limit), nil
+}
+
+// mergeIndexResults merges the results of a call run against two indexes.
+func mergeIndexResults(name string, a, b interface{}) (interface{}, error) {
+	switch a := a.(type) {
+	case *Row:
+		b, ok := b.(*Row)
+		if !ok {
+			break
+		}
+		return unionIndexRows(a, b), nil
+	case SignedRow:
+		b, ok := b.(SignedRow)
+		if !ok {
+			break
+		}
+		merged := a.union(b)
+		merged.field = a.field
+		return merged, nil
+	case DistinctTimestamp:
+		b, ok := b.(DistinctTimestamp)
+		if !ok {
+			break
+		}
+		return a.Union(b), nil
+	case *GroupCounts:
+		b, ok := b.(*GroupCounts)
+		if !ok {
+			break
+		}
+		return mergeIndexGroupCounts(a, b), nil
+	case ValCount:
+		b, ok := b.(ValCount)
+		if !ok {
+			break
+		}
+		switch name {
+		case "Sum":
+			sum := a.add(b)
+			if a.DecimalVal != nil && b.DecimalVal != nil {
+				val := pql.AddDecimal(*a.DecimalVal, *b.DecimalVal)
+				sum.DecimalVal = &val
+			} else if a.DecimalVal != nil || b.DecimalVal != nil {
+				return nil, errors.New("cannot add decimal and integer sums")
+			}
+			return sum, nil
+		case "Min":
+			return a.smaller(b), nil
+		case "Max":
+			return a.larger(b), nil
+		}
+	}
+	return nil, errors.Errorf("unexpected result types %T and %T", a, b)
+}
+
+// unionIndexRows returns the union of two translated rows from different
+// indexes. Rows from keyed indexes are merged by key, since the same key
+// may have a different ID in each index.
+func unionIndexRows(a, b *Row) *Row {
+	if len(a.Keys) == 0 && len(b.Keys) == 0 {
+		return a.Union(b)
+	}
+	seen := make(map[string]struct{}, len(a.Keys)+len(b.Keys))
+	keys := make([]string, 0, len(a.Keys)+len(b.Keys))
+	for _, key := range append(append([]string(nil), a.Keys...), b.Keys...) {
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return &Row{Keys: keys}
+}
+
+// indexGroupKey identifies a group independently of the index it came
+// from, using row keys rather than IDs for keyed fields.
+func indexGroupKey(g GroupCount) string {
+	var sb strings.Builder
+	for _, fr := range g.Group {
+		sb.WriteString(fr.Field)
+		sb.WriteByte(0)
+		if fr.RowKey != "" {
+			sb.WriteString("k")
+			sb.WriteString(fr.RowKey)
+		} else {
+			sb.WriteString(fr.String())
+		}
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+// mergeIndexGroupCounts merges GroupBy results from different indexes,
+// adding the counts and aggregates of matching groups.
+func mergeIndexGroupCounts(a, b *GroupCounts) *GroupCounts {
+	if a == nil {
+		return b
+	} else if b == nil {
+		return a
+	}
+	pos := make(map[string]int, len(a.groups))
+	groups := append([]GroupCount(nil), a.groups...)
+	for i, g := range groups {
+		pos[indexGroupKey(g)] = i
+	}
+	for _, g := range b.groups {
+		i, ok := pos[indexGroupKey(g)]
+		if !ok {
+			pos[indexGroupKey(g)] = len(groups)
+			groups = append(groups, g)
+			continue
+		}
+		groups[i].merge(g, a.aggregateType)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return compareIndexGroups(groups[i], groups[j]) < 0
+	})
+	return &GroupCounts{groups: groups, aggregateType: a.aggregateType}
+}
+
+// compareIndexGroups orders groups like GroupCount.Compare, but by key for
+// keyed fields.
+func compareIndexGroups(a, b GroupCount) int {
+	for i := range a.Group {
+		ka, kb := a.Group[i].RowKey, b.Group[i].RowKey
+		if ka == "" && kb == "" {
+			if c := (GroupCount{Group: a.Group[i : i+1]}).Compare(GroupCount{Group: b.Group[i : i+1]}); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := strings.Compare(ka, kb); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
@@ -0,0 +1,230 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// A mutex field with history keeps the values its columns held before they
+// were overwritten or cleared. Each one is recorded, as of the time it
+// changed, in history views named for the field's history time quantum,
+// which hold ordinary rows rather than mutex ones.
+//
+// Row(f=v, from=T1, to=T2) on such a field returns the columns whose value
+// changed from v between T1 and T2, and Rows(f, from=T1, to=T2) the values
+// which changed then. Row(f=v, at=T) returns the columns whose value was v
+// at time T, to the resolution of the quantum's finest unit: changes in the
+// same hour, day, month or year as T count as having come after it.
+
+// viewHistory is the prefix of the history views of a mutex field.
+const viewHistory = "history"
+
+// OptFieldHistory is a functional option on FieldOptions used to keep the
+// previous values of a mutex field, in views with time quantum q.
+func OptFieldHistory(q TimeQuantum) FieldOption {
+	return func(fo *FieldOptions) error {
+		if q.IsEmpty() || !q.Valid() {
+			return ErrInvalidTimeQuantum
+		}
+		fo.History = q
+		return nil
+	}
+}
+
+// isHistoryView reports whether a view holds a mutex field's history.
+func isHistoryView(name string) bool {
+	return strings.HasPrefix(name, viewHistory+"_")
+}
+
+// recordHistory records, as of t, the values in shard which setting (or
+// clearing) the bits at rowIDs and columnIDs is about to overwrite. It must
+// be called before they're written, with the transaction writing them.
+func (f *Field) recordHistory(tx Tx, shard uint64, rowIDs, columnIDs []uint64, clear bool, t time.Time) error {
+	if f.options.History == "" || len(columnIDs) == 0 {
+		return nil
+	}
+	v := f.view(viewStandard)
+	if v == nil {
+		return nil
+	}
+	frag := v.Fragment(shard)
+	if frag == nil {
+		return nil
+	}
+
+	// The last value set for a column is the one a mutex import keeps.
+	next := make(map[uint64]uint64, len(columnIDs))
+	for i, col := range columnIDs {
+		next[col] = rowIDs[i]
+	}
+	cols := make([]uint64, 0, len(next))
+	for col := range next {
+		cols = append(cols, col)
+	}
+	sort.Slice(cols, func(i, j int) bool { return cols[i] < cols[j] })
+	prevs, err := frag.mutexValues(tx, cols)
+	if err != nil {
+		return errors.Wrap(err, "getting previous values")
+	}
+
+	// A set overwrites any other value, and a clear only the one it names.
+	var prevRows, prevCols []uint64
+	if clear {
+		for i, col := range columnIDs {
+			if prev, ok := prevs[col]; ok && prev == rowIDs[i] {
+				prevRows, prevCols = append(prevRows, prev), append(prevCols, col)
+				delete(prevs, col)
+			}
+		}
+	} else {
+		for _, col := range cols {
+			if prev, ok := prevs[col]; ok && prev != next[col] {
+				prevRows, prevCols = append(prevRows, prev), append(prevCols, col)
+			}
+		}
+	}
+	if len(prevCols) == 0 {
+		return nil
+	}
+
+	q := f.options.History
+	for _, name := range append(viewsByTime(viewHistory, t, q), calendarViewsByTime(viewHistory, t, q, f.options.FiscalYearStart)...) {
+		view, err := f.createViewIfNotExists(name)
+		if err != nil {
+			return errors.Wrapf(err, "creating view %s", name)
+		}
+		hfrag, err := view.CreateFragmentIfNotExists(shard)
+		if err != nil {
+			return errors.Wrap(err, "creating fragment")
+		}
+		// bulkImport reuses the column slice, so give it a copy.
+		rows, cols := append([]uint64(nil), prevRows...), append([]uint64(nil), prevCols...)
+		if err := hfrag.bulkImport(tx, rows, cols, &ImportOptions{}); err != nil {
+			return errors.Wrapf(err, "recording history in view %s", name)
+		}
+	}
+	return nil
+}
+
+// mutexValues returns the values a mutex fragment holds for cols.
+func (f *fragment) mutexValues(tx Tx, cols []uint64) (map[uint64]uint64, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	vals := make(map[uint64]uint64, len(cols))
+	for _, col := range cols {
+		v, found, err := f.mutexVector.Get(tx, col)
+		if err != nil {
+			return nil, err
+		} else if found {
+			vals[col] = v
+		}
+	}
+	return vals, nil
+}
+
+// recordHistoryBit records the value setting or clearing one bit is about
+// to overwrite, in a transaction of its own.
+func (f *Field) recordHistoryBit(qcx *Qcx, rowID, colID uint64, clear bool, t time.Time) (err error) {
+	if f.options.History == "" {
+		return nil
+	}
+	shard := colID / ShardWidth
+	tx, finisher, err := qcx.GetTx(Txo{Write: true, Index: f.idx, Shard: shard})
+	if err != nil {
+		return err
+	}
+	defer finisher(&err)
+	return f.recordHistory(tx, shard, []uint64{rowID}, []uint64{colID}, clear, t)
+}
+
+// historyViewsAfter returns the field's history views of the finest unit of
+// its quantum which end after t, oldest first.
+func (f *Field) historyViewsAfter(t time.Time) ([]string, error) {
+	q := f.options.History.baseUnits()
+	if q == "" {
+		return nil, errors.Errorf("field %s doesn't keep history", f.name)
+	}
+	width := map[byte]int{'Y': 4, 'M': 6, 'D': 8, 'H': 10}[q[len(q)-1]]
+
+	var views []string
+	for _, v := range f.views() {
+		if !isHistoryView(v.name) || len(viewTimePart(v.name)) != width {
+			continue
+		}
+		end, err := timeOfView(v.name, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting time of view %s", v.name)
+		}
+		if end.After(t) {
+			views = append(views, v.name)
+		}
+	}
+	sort.Strings(views)
+	return views, nil
+}
+
+// executeRowAtShard returns the columns in shard whose value of a mutex field
+// with history was rowID at time at. For each column, that's the value the
+// first change after at changed from, or its current value if it hasn't
+// changed since.
+func (e *executor) executeRowAtShard(ctx context.Context, qcx *Qcx, idx *Index, f *Field, c *pql.Call, rowID uint64, shard uint64) (_ *Row, err0 error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeRowAtShard")
+	defer span.Finish()
+
+	loc, err := callLocation(c, f)
+	if err != nil {
+		return nil, err
+	}
+	at, err := parseTimeIn(c.Args["at"], loc)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing at time")
+	}
+	views, err := f.historyViewsAfter(at)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Shard: shard})
+	if err != nil {
+		return nil, err
+	}
+	defer finisher(&err0)
+
+	// changed holds the columns whose first change after at has been seen.
+	result, changed := NewRow(), NewRow()
+	for _, name := range append(views, viewStandard) {
+		frag := e.Holder.fragment(idx.Name(), f.Name(), name, shard)
+		if frag == nil {
+			continue
+		}
+		row, err := frag.row(tx, rowID)
+		if err != nil {
+			return nil, err
+		}
+		result = result.Union(row.Difference(changed))
+		if name == viewStandard {
+			break
+		}
+		rows, err := frag.rows(ctx, tx, 0)
+		if err != nil {
+			return nil, err
+		}
+		all, err := frag.unionRows(ctx, tx, rows)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed.Union(all)
+	}
+	if qcx.write {
+		result = result.Clone()
+	}
+	return result, nil
+}
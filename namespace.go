@@ -0,0 +1,246 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/featurebasedb/featurebase/v3/authn"
+	"github.com/featurebasedb/featurebase/v3/authz"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+// Indexes can be put in a namespace when they're created, so that tenants
+// sharing a cluster each get their own quotas, stat tags, and credentials.
+// Index names are still unique across the whole cluster, and key
+// translation is per index as always, so keys never cross namespaces.
+// Indexes without a namespace aren't subject to any quota.
+
+// NamespaceQuota limits the resources the indexes of a namespace can use.
+// Zero means unlimited.
+type NamespaceQuota struct {
+	// MaxShards limits the number of shards holding data, counted across
+	// all of the namespace's indexes.
+	MaxShards uint64 `json:"maxShards,omitempty"`
+
+	// MaxStorage limits the bytes of disk the namespace's indexes use on
+	// each node.
+	MaxStorage int64 `json:"maxStorageBytes,omitempty"`
+
+	// MaxQPS limits the queries per second each node coordinates against
+	// the namespace's indexes.
+	MaxQPS float64 `json:"maxQPS,omitempty"`
+}
+
+// LoadNamespaceQuotas reads the quotas of namespaces, by name, from the JSON
+// file at path.
+func LoadNamespaceQuotas(path string) (map[string]NamespaceQuota, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading namespace quotas")
+	}
+	var quotas map[string]NamespaceQuota
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&quotas); err != nil {
+		return nil, errors.Wrapf(err, "decoding namespace quotas from %s", path)
+	}
+	for name, q := range quotas {
+		if q.MaxStorage < 0 || q.MaxQPS < 0 {
+			return nil, errors.Errorf("namespace %s: quotas must not be negative", name)
+		}
+	}
+	return quotas, nil
+}
+
+// QuotaExceededError is returned when a request would take a namespace past
+// one of its quotas. Its cause is ErrNamespaceQuotaExceeded.
+type QuotaExceededError struct {
+	Namespace string
+	Kind      string // "shards", "bytes of storage", or "queries per second"
+	Limit     interface{}
+}
+
+func newQuotaExceededError(namespace, kind string, limit interface{}) QuotaExceededError {
+	return QuotaExceededError{Namespace: namespace, Kind: kind, Limit: limit}
+}
+
+func (e QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: namespace %q is limited to %v %s", ErrNamespaceQuotaExceeded, e.Namespace, e.Limit, e.Kind)
+}
+
+// Cause allows errors.Cause to return ErrNamespaceQuotaExceeded.
+func (e QuotaExceededError) Cause() error {
+	return ErrNamespaceQuotaExceeded
+}
+
+// Unwrap makes errors.Is(err, ErrNamespaceQuotaExceeded) true.
+func (e QuotaExceededError) Unwrap() error {
+	return ErrNamespaceQuotaExceeded
+}
+
+// namespaceQuotas enforces the quotas of namespaces.
+type namespaceQuotas struct {
+	quotas map[string]NamespaceQuota
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newNamespaceQuotas(quotas map[string]NamespaceQuota) *namespaceQuotas {
+	return &namespaceQuotas{
+		quotas:   quotas,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// quota returns the quota of a namespace.
+func (q *namespaceQuotas) quota(namespace string) NamespaceQuota {
+	return q.quotas[namespace]
+}
+
+// allowQuery reports whether a query can be run against a namespace without
+// going over its queries per second.
+func (q *namespaceQuotas) allowQuery(namespace string) bool {
+	max := q.quota(namespace).MaxQPS
+	if max <= 0 {
+		return true
+	}
+	q.mu.Lock()
+	l, ok := q.limiters[namespace]
+	if !ok {
+		burst := int(max)
+		if burst < 1 {
+			burst = 1
+		}
+		l = rate.NewLimiter(rate.Limit(max), burst)
+		q.limiters[namespace] = l
+	}
+	q.mu.Unlock()
+	return l.Allow()
+}
+
+// namespaceIndexes returns the indexes in a namespace.
+func (h *Holder) namespaceIndexes(namespace string) []*Index {
+	var indexes []*Index
+	for _, idx := range h.Indexes() {
+		if idx.Namespace() == namespace {
+			indexes = append(indexes, idx)
+		}
+	}
+	return indexes
+}
+
+// checkNamespaceShard returns an error if writing to a shard of idx would
+// take its namespace past its shard or storage quota.
+func (h *Holder) checkNamespaceShard(idx *Index, shard uint64) error {
+	namespace := idx.Namespace()
+	if namespace == "" {
+		return nil
+	}
+	quota := h.namespaces.quota(namespace)
+
+	if quota.MaxShards > 0 && !idx.AvailableShards(includeRemote).Contains(shard) {
+		var n uint64
+		for _, other := range h.namespaceIndexes(namespace) {
+			n += other.AvailableShards(includeRemote).Count()
+		}
+		if n >= quota.MaxShards {
+			return newQuotaExceededError(namespace, "shards", quota.MaxShards)
+		}
+	}
+
+	if quota.MaxStorage > 0 {
+		used, err := h.diskUsage.usage("namespace:"+namespace, func() (int64, error) {
+			var bytes int64
+			for _, other := range h.namespaceIndexes(namespace) {
+				usage, err := GetDiskUsage(other.Path())
+				if err != nil {
+					return 0, errors.Wrapf(err, "getting disk usage of index %s", other.Name())
+				}
+				bytes += usage.Usage
+			}
+			return bytes, nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "getting namespace storage")
+		}
+		if used >= quota.MaxStorage {
+			return newQuotaExceededError(namespace, "bytes of storage", quota.MaxStorage)
+		}
+	}
+	return nil
+}
+
+// checkNamespaceQuery returns an error if a query against indexes would take
+// any of their namespaces past its queries per second.
+func (h *Holder) checkNamespaceQuery(indexes []string) error {
+	seen := make(map[string]bool)
+	for _, name := range indexes {
+		idx := h.Index(name)
+		if idx == nil || idx.Namespace() == "" || seen[idx.Namespace()] {
+			continue
+		}
+		namespace := idx.Namespace()
+		seen[namespace] = true
+		if !h.namespaces.allowQuery(namespace) {
+			return newQuotaExceededError(namespace, "queries per second", h.namespaces.quota(namespace).MaxQPS)
+		}
+	}
+	return nil
+}
+
+// indexTags returns the stat tags of queries against an index.
+func (h *Holder) indexTags(name string) []string {
+	tags := []string{"index:" + name}
+	if idx := h.Index(name); idx != nil && idx.Namespace() != "" {
+		tags = append(tags, "namespace:"+idx.Namespace())
+	}
+	return tags
+}
+
+// namespaceSchema returns the indexes of schema in a namespace.
+func namespaceSchema(schema []*IndexInfo, namespace string) []*IndexInfo {
+	var filtered []*IndexInfo
+	for _, ii := range schema {
+		if ii.Options.Namespace == namespace {
+			filtered = append(filtered, ii)
+		}
+	}
+	return filtered
+}
+
+// IndexPermission returns a user's permission on an index, including any
+// permission on the index's namespace.
+func (api *API) IndexPermission(p *authz.GroupPermissions, user *authn.UserInfo, index string) (authz.Permission, error) {
+	var namespace string
+	if idx := api.holder.Index(index); idx != nil {
+		namespace = idx.Namespace()
+	}
+	return p.GetNamespacedPermissions(user, index, namespace)
+}
+
+// AuthorizedIndexes returns the indexes the groups have at least a
+// permission on, either directly or through their namespace.
+func (api *API) AuthorizedIndexes(p *authz.GroupPermissions, groups []authn.Group, perm authz.Permission) []string {
+	indexes := p.GetAuthorizedIndexList(groups, perm)
+	namespaces := p.GetAuthorizedNamespaceList(groups, perm)
+	if len(namespaces) == 0 {
+		return indexes
+	}
+	allowed := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		allowed[namespace] = true
+	}
+	for _, idx := range api.holder.Indexes() {
+		if allowed[idx.Namespace()] {
+			indexes = append(indexes, idx.Name())
+		}
+	}
+	return indexes
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadNamespaceQuotas(t *testing.T) {
+	load := func(body string) (map[string]NamespaceQuota, error) {
+		path := filepath.Join(t.TempDir(), "namespaces.json")
+		if err := os.WriteFile(path, []byte(body), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return LoadNamespaceQuotas(path)
+	}
+
+	quotas, err := load(`{"acme": {"maxShards": 100, "maxQPS": 2.5}, "other": {"maxStorageBytes": 1024}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]NamespaceQuota{
+		"acme":  {MaxShards: 100, MaxQPS: 2.5},
+		"other": {MaxStorage: 1024},
+	}
+	if !reflect.DeepEqual(quotas, exp) {
+		t.Fatalf("expected %+v, got %+v", exp, quotas)
+	}
+
+	for _, body := range []string{
+		`{"acme": {"maxShard": 100}}`,
+		`{"acme": {"maxQPS": -1}}`,
+		`[]`,
+	} {
+		if _, err := load(body); err == nil {
+			t.Fatalf("expected error loading %s", body)
+		}
+	}
+	if _, err := LoadNamespaceQuotas(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("expected error loading missing file")
+	}
+}
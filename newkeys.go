@@ -0,0 +1,130 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Writes by query create the keys they name which don't exist yet, so a
+// misspelled key from an upstream producer becomes a new row. A keyed field
+// holding a controlled vocabulary can reject new row keys instead, with
+// OptFieldRejectNewKeys, and a query can reject all new keys, of rows and
+// columns, with its RejectNewKeys option. A query writing keys which are
+// rejected fails with an UnknownKeysError naming them, before anything in it
+// is written.
+
+// OptFieldRejectNewKeys is a functional option on FieldOptions used to fail
+// writes by query which would create new row keys in a keyed field.
+func OptFieldRejectNewKeys() FieldOption {
+	return func(fo *FieldOptions) error {
+		fo.RejectNewKeys = true
+		return nil
+	}
+}
+
+// UnknownKeysError is returned for queries writing keys which don't exist,
+// and which are rejected rather than created. Its cause is ErrUnknownKeys.
+type UnknownKeysError struct {
+	Columns map[string][]string            `json:"columns,omitempty"` // map[index] -> column keys
+	Rows    map[string]map[string][]string `json:"rows,omitempty"`    // map[index]map[field] -> row keys
+}
+
+func (e UnknownKeysError) Error() string {
+	var parts []string
+	for index, keys := range e.Columns {
+		parts = append(parts, fmt.Sprintf("column keys %q of index %q", keys, index))
+	}
+	for index, fields := range e.Rows {
+		for field, keys := range fields {
+			parts = append(parts, fmt.Sprintf("row keys %q of field %q in index %q", keys, field, index))
+		}
+	}
+	sort.Strings(parts)
+	return fmt.Sprintf("%s: %s", ErrUnknownKeys, strings.Join(parts, "; "))
+}
+
+// Cause allows errors.Cause to return ErrUnknownKeys.
+func (e UnknownKeysError) Cause() error {
+	return ErrUnknownKeys
+}
+
+// Unwrap makes errors.Is(err, ErrUnknownKeys) true.
+func (e UnknownKeysError) Unwrap() error {
+	return ErrUnknownKeys
+}
+
+// rejectNewKeys looks up the keys dst would create, and returns an
+// UnknownKeysError if any of those which are rejected don't exist: all of
+// them if reject is set, or else those of fields which reject new keys.
+func (e *executor) rejectNewKeys(ctx context.Context, dst *keyCollector, reject bool) error {
+	var unknown UnknownKeysError
+	if reject {
+		for index, keys := range dst.createCols {
+			found, err := e.Cluster.findIndexKeys(ctx, index, keys...)
+			if err != nil {
+				return errors.Wrap(err, "finding query column keys")
+			}
+			if missing := missingKeys(keys, found); len(missing) > 0 {
+				if unknown.Columns == nil {
+					unknown.Columns = make(map[string][]string)
+				}
+				unknown.Columns[index] = missing
+			}
+		}
+	}
+	for index, fields := range dst.createRows {
+		idx := e.Holder.Index(index)
+		if idx == nil {
+			// Left for creating the keys to report.
+			continue
+		}
+		for field, keys := range fields {
+			f := idx.Field(field)
+			if f == nil || !(reject || f.RejectNewKeys()) {
+				continue
+			}
+			found, err := e.Cluster.findFieldKeys(ctx, f, keys...)
+			if err != nil {
+				return errors.Wrap(err, "finding query row keys")
+			}
+			if missing := missingKeys(keys, found); len(missing) > 0 {
+				if unknown.Rows == nil {
+					unknown.Rows = make(map[string]map[string][]string)
+				}
+				if unknown.Rows[index] == nil {
+					unknown.Rows[index] = make(map[string][]string)
+				}
+				unknown.Rows[index][field] = missing
+			}
+		}
+	}
+	if unknown.Columns == nil && unknown.Rows == nil {
+		return nil
+	}
+	return unknown
+}
+
+// missingKeys returns the keys which weren't found, sorted, without
+// duplicates.
+func missingKeys(keys []string, found map[string]uint64) []string {
+	var missing []string
+	seen := make(map[string]struct{})
+	for _, key := range keys {
+		if _, ok := found[key]; ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		missing = append(missing, key)
+	}
+	sort.Strings(missing)
+	return missing
+}
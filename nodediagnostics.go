@@ -0,0 +1,131 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// GET /diagnostics gathers what's needed to troubleshoot a node in
+// one request: its version, its view of the cluster's state, its health,
+// how it stores its data, which shards it holds compared to those it
+// should, and its slowest recent queries. The diag command asks every node
+// for its diagnostics at once, and merges them into a report of the
+// cluster.
+
+// DefaultSlowQueries is the number of slowest recent queries a node reports
+// in its diagnostics by default.
+const DefaultSlowQueries = 10
+
+// NodeDiagnostics describe a node, for troubleshooting.
+type NodeDiagnostics struct {
+	Node    string    `json:"node"`
+	URI     string    `json:"uri"`
+	Version string    `json:"version"`
+	State   string    `json:"state"`
+	Time    time.Time `json:"time"`
+
+	Health  Health         `json:"health"`
+	Storage *StorageReport `json:"storage"`
+	Shards  []ShardHolding `json:"shards"`
+
+	// SlowQueries are the slowest of the node's recent queries, slowest
+	// first.
+	SlowQueries []PastQueryStatus `json:"slowQueries"`
+}
+
+// ShardHolding compares the shards of an index with data which a node
+// should hold, by the cluster's placement, with those it has files for.
+// Missing shards are those it should hold but doesn't, and Unowned shards
+// those it holds but shouldn't.
+type ShardHolding struct {
+	Index   string   `json:"index"`
+	Owned   int      `json:"owned"`
+	Held    int      `json:"held"`
+	Missing []uint64 `json:"missing,omitempty"`
+	Unowned []uint64 `json:"unowned,omitempty"`
+}
+
+// NodeDiagnostics describes this node, with up to slowN of its slowest
+// recent queries.
+func (api *API) NodeDiagnostics(ctx context.Context, slowN int) (*NodeDiagnostics, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.NodeDiagnostics")
+	defer span.Finish()
+
+	if err := api.validate(apiNodeDiagnostics); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	d := &NodeDiagnostics{
+		Node:    api.NodeID(),
+		URI:     api.server.uri.String(),
+		Version: api.Version(),
+		Time:    time.Now().UTC(),
+		Health:  api.holder.health(),
+	}
+	state, err := api.cluster.State()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting cluster state")
+	}
+	d.State = string(state)
+
+	if d.Storage, err = api.StorageReport(ctx, ""); err != nil {
+		return nil, errors.Wrap(err, "getting storage report")
+	}
+	if d.Shards, err = api.shardHoldings(); err != nil {
+		return nil, err
+	}
+
+	d.SlowQueries = api.tracker.PastQueries()
+	sort.SliceStable(d.SlowQueries, func(i, j int) bool {
+		return d.SlowQueries[i].RuntimeNs > d.SlowQueries[j].RuntimeNs
+	})
+	if len(d.SlowQueries) > slowN {
+		d.SlowQueries = d.SlowQueries[:slowN]
+	}
+	return d, nil
+}
+
+// shardHoldings compares the shards this node should hold of each index with
+// those it holds.
+func (api *API) shardHoldings() ([]ShardHolding, error) {
+	snap := api.cluster.NewSnapshot()
+	node := disco.Nodes(snap.Nodes).NodeByID(api.NodeID())
+	holdings := []ShardHolding{}
+	for _, idx := range api.holder.Indexes() {
+		held, err := api.holder.localShards(idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing shards of %s", idx.Name())
+		}
+		available := idx.AvailableShards(includeRemote)
+		owned := make(map[uint64]bool)
+		if node != nil {
+			for _, shard := range snap.ContainsShards(idx.Name(), available, node) {
+				owned[shard] = true
+			}
+		}
+
+		sh := ShardHolding{Index: idx.Name(), Owned: len(owned), Held: len(held)}
+		have := make(map[uint64]bool, len(held))
+		for _, shard := range held {
+			have[shard] = true
+			if !owned[shard] && available.Contains(shard) {
+				sh.Unowned = append(sh.Unowned, shard)
+			}
+		}
+		for shard := range owned {
+			if !have[shard] {
+				sh.Missing = append(sh.Missing, shard)
+			}
+		}
+		sort.Slice(sh.Missing, func(i, j int) bool { return sh.Missing[i] < sh.Missing[j] })
+		holdings = append(holdings, sh)
+	}
+	return holdings, nil
+}
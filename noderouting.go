@@ -0,0 +1,290 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"runtime/metrics"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+// headerNodeLoad is the HTTP header in which a node reports its load along
+// with the results of a remote query.
+const headerNodeLoad = "X-Pilosa-Load"
+
+const (
+	// nodeLatencyDecay is the weight given to each new latency sample in a
+	// node's moving average.
+	nodeLatencyDecay = 0.2
+
+	// A node is considered degraded when its score is more than
+	// nodeDegradeRatio times the median score of the other nodes, and healthy
+	// again once it's back under nodeRecoverRatio times the median. The gap
+	// between the two keeps routing from flapping.
+	nodeDegradeRatio = 3.0
+	nodeRecoverRatio = 1.5
+
+	// nodeProbeInterval is how long queries avoid a degraded node before
+	// sending it work again, to find out whether it has recovered.
+	nodeProbeInterval = 5 * time.Second
+
+	// nodeLoadTTL is how long a node's reported load is trusted.
+	nodeLoadTTL = 10 * time.Second
+)
+
+// nodeLoad is the load reported by a node: the number of shard jobs queued
+// or running, and how much of its memory limit is in use, from 0 to 1.
+type nodeLoad struct {
+	Queue          int
+	MemoryPressure float64
+}
+
+func (l nodeLoad) String() string {
+	return fmt.Sprintf("queue=%d;mem=%.3f", l.Queue, l.MemoryPressure)
+}
+
+// parseNodeLoad parses a load reported in headerNodeLoad.
+func parseNodeLoad(s string) (nodeLoad, bool) {
+	var l nodeLoad
+	if _, err := fmt.Sscanf(s, "queue=%d;mem=%f", &l.Queue, &l.MemoryPressure); err != nil {
+		return nodeLoad{}, false
+	}
+	return l, true
+}
+
+// localLoad returns the load on this node.
+func (e *executor) localLoad() nodeLoad {
+	l := nodeLoad{Queue: e.work.len() + int(atomic.LoadUint64(&e.activeMappers))}
+
+	// Memory pressure is only meaningful when there's a memory limit.
+	if limit := debug.SetMemoryLimit(-1); limit > 0 && limit < math.MaxInt64 {
+		sample := []metrics.Sample{{Name: "/memory/classes/total:bytes"}}
+		metrics.Read(sample)
+		if sample[0].Value.Kind() == metrics.KindUint64 {
+			l.MemoryPressure = float64(sample[0].Value.Uint64()) / float64(limit)
+		}
+	}
+	return l
+}
+
+// nodeRouter tracks the health of the nodes in the cluster, as seen by the
+// queries this node coordinates, so that shards can be routed away from
+// nodes which are overloaded or degraded (by GC pauses or compactions, say)
+// to healthy replicas.
+//
+// Each node is scored by its recent latency per shard, scaled up by the
+// work queued on it and by memory pressure. A node scoring far above the
+// rest of the cluster is avoided for a while, then probed with work again;
+// it's only considered healthy once its score has come well back down.
+//
+// Only reads are routed this way, and only while the replicas of their
+// index are known to be in sync: calls like ClearRow() and Store() write
+// to a single replica of each shard, and the others only catch up when
+// anti-entropy syncs them, so until then reads go to the same replica as
+// the writes did, the first available owner of each shard. Reads are
+// therefore never routed to replicas while anti-entropy is disabled, as it
+// is by default. A node only knows of the writes it coordinated itself, so
+// a read may still reach a replica which hasn't caught up with a write run
+// on another node, until the next anti-entropy pass.
+type nodeRouter struct {
+	mu    sync.Mutex
+	nodes map[string]*nodeHealth
+
+	// When each index was last written by a call run on this node, and
+	// when the last anti-entropy pass to complete on this node began.
+	writes   map[string]time.Time
+	syncedAt time.Time
+
+	// now returns the current time; it can be replaced in tests.
+	now func() time.Time
+}
+
+// nodeHealth is the routing state of a single node.
+type nodeHealth struct {
+	// Moving average of the time taken per shard, in nanoseconds.
+	latency float64
+
+	// Shards of queries currently sent to the node by this node.
+	inflight int
+
+	// The load last reported by the node, and when.
+	load   nodeLoad
+	loadAt time.Time
+
+	degraded bool
+	probeAt  time.Time
+}
+
+func newNodeRouter() *nodeRouter {
+	return &nodeRouter{
+		nodes:  make(map[string]*nodeHealth),
+		writes: make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// health returns the state of a node, creating it if needed. The caller
+// must hold r.mu.
+func (r *nodeRouter) health(id string) *nodeHealth {
+	h := r.nodes[id]
+	if h == nil {
+		h = &nodeHealth{}
+		r.nodes[id] = h
+	}
+	return h
+}
+
+// score returns a node's score at now; higher is worse. Nodes which haven't
+// completed any work yet have no score.
+func (h *nodeHealth) score(now time.Time) float64 {
+	if h.latency == 0 {
+		return 0
+	}
+	queue := h.inflight
+	mem := 0.0
+	if now.Sub(h.loadAt) < nodeLoadTTL {
+		queue += h.load.Queue
+		mem = h.load.MemoryPressure
+	}
+	s := h.latency * float64(1+queue)
+	if mem > 0.75 {
+		s *= 1 + 10*(mem-0.75)
+	}
+	return s
+}
+
+// begin records that shards are being sent to a node.
+func (r *nodeRouter) begin(id string, shards int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.health(id).inflight += shards
+}
+
+// end records that a node finished work on shards begun with begin, taking
+// d. If ok is false, the work failed and its time isn't counted.
+func (r *nodeRouter) end(id string, shards int, d time.Duration, ok bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h := r.health(id)
+	h.inflight -= shards
+	if !ok || shards == 0 {
+		return
+	}
+	sample := float64(d) / float64(shards)
+	if h.latency == 0 {
+		h.latency = sample
+	} else {
+		h.latency += nodeLatencyDecay * (sample - h.latency)
+	}
+	r.update(h)
+}
+
+// report records the load reported by a node.
+func (r *nodeRouter) report(id string, load nodeLoad) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h := r.health(id)
+	h.load, h.loadAt = load, r.now()
+	r.update(h)
+}
+
+// update reconsiders whether h is degraded, compared to the median score of
+// the other nodes. The caller must hold r.mu.
+func (r *nodeRouter) update(h *nodeHealth) {
+	now := r.now()
+	scores := make([]float64, 0, len(r.nodes))
+	for _, other := range r.nodes {
+		if s := other.score(now); s > 0 && other != h {
+			scores = append(scores, s)
+		}
+	}
+	score := h.score(now)
+	if len(scores) == 0 || score == 0 {
+		return
+	}
+	sort.Float64s(scores)
+	median := scores[len(scores)/2]
+	if len(scores)%2 == 0 {
+		median = (median + scores[len(scores)/2-1]) / 2
+	}
+
+	switch {
+	case !h.degraded && score > nodeDegradeRatio*median:
+		h.degraded, h.probeAt = true, now.Add(nodeProbeInterval)
+	case h.degraded && score < nodeRecoverRatio*median:
+		h.degraded = false
+	case h.degraded && !now.Before(h.probeAt):
+		// Still not healthy; wait a while before probing it again.
+		h.probeAt = now.Add(nodeProbeInterval)
+	}
+}
+
+// avoid reports whether work should be routed away from a node, if there's
+// a healthy alternative.
+func (r *nodeRouter) avoid(id string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h := r.nodes[id]
+	return h != nil && h.degraded && r.now().Before(h.probeAt)
+}
+
+// preferReplicas reports whether the shards of c, a call against index, can
+// be routed to whichever of their replicas is healthiest. It records that
+// index is written if c is a write.
+func (r *nodeRouter) preferReplicas(index string, c *pql.Call) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c != nil && (c.IsWrite() || c.Name == "Delete") {
+		r.writes[index] = r.now()
+		return false
+	}
+	if r.syncedAt.IsZero() {
+		return false
+	}
+	written, ok := r.writes[index]
+	return !ok || written.Before(r.syncedAt)
+}
+
+// synced records that an anti-entropy pass which began at start has
+// completed, bringing the replicas of anything written before then back
+// into sync.
+func (r *nodeRouter) synced(start time.Time) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if start.After(r.syncedAt) {
+		r.syncedAt = start
+	}
+}
+
+// nodeLoad returns the load on this node, if it's running queries.
+func (api *API) nodeLoad() (nodeLoad, bool) {
+	if api.server == nil || api.server.executor == nil {
+		return nodeLoad{}, false
+	}
+	return api.server.executor.localLoad(), true
+}
@@ -0,0 +1,211 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+func TestNodeLoad_Parse(t *testing.T) {
+	l := nodeLoad{Queue: 12, MemoryPressure: 0.875}
+	if got, ok := parseNodeLoad(l.String()); !ok || got != l {
+		t.Fatalf("expected %v, got %v (%v)", l, got, ok)
+	}
+	if _, ok := parseNodeLoad(""); ok {
+		t.Fatal("expected empty load not to parse")
+	}
+}
+
+func TestNodeRouter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	r := newNodeRouter()
+	r.now = func() time.Time { return now }
+
+	run := func(id string, d time.Duration) {
+		r.begin(id, 10)
+		r.end(id, 10, d, true)
+	}
+	for i := 0; i < 5; i++ {
+		run("a", 10*time.Millisecond)
+		run("b", 10*time.Millisecond)
+		run("c", 10*time.Millisecond)
+	}
+	if r.avoid("a") || r.avoid("b") || r.avoid("c") {
+		t.Fatal("expected no nodes to be avoided")
+	}
+
+	// A node slowing down sharply is avoided.
+	for !r.avoid("c") {
+		run("c", 200*time.Millisecond)
+		if r.nodes["c"].latency > float64(200*time.Millisecond) {
+			t.Fatal("expected slow node to be avoided")
+		}
+	}
+
+	// Memory pressure and queued work make a node look worse.
+	r.report("b", nodeLoad{Queue: 2, MemoryPressure: 0.99})
+	if !r.avoid("b") {
+		t.Fatal("expected node under memory pressure to be avoided")
+	}
+	r.report("b", nodeLoad{})
+	if r.avoid("b") {
+		t.Fatal("expected node to recover once its load dropped")
+	}
+
+	// Once the probe interval passes, the node gets work again, and stays
+	// degraded until it has recovered well below the degrade threshold.
+	now = now.Add(nodeProbeInterval)
+	if r.avoid("c") {
+		t.Fatal("expected degraded node to be probed")
+	}
+	run("c", 25*time.Millisecond)
+	if !r.nodes["c"].degraded || !r.avoid("c") {
+		t.Fatal("expected node to stay degraded after one fast probe")
+	}
+	for i := 0; i < 20 && r.nodes["c"].degraded; i++ {
+		now = now.Add(nodeProbeInterval)
+		run("c", 10*time.Millisecond)
+	}
+	if r.nodes["c"].degraded || r.avoid("c") {
+		t.Fatal("expected node to recover")
+	}
+
+	// Work which failed doesn't count towards latency.
+	r.begin("a", 1)
+	r.end("a", 1, time.Hour, false)
+	if r.nodes["a"].inflight != 0 || r.avoid("a") {
+		t.Fatal("expected failed work to be ignored")
+	}
+}
+
+// newRoutingExecutor returns an executor for a cluster of three nodes,
+// each shard of which has two replicas.
+func newRoutingExecutor() (*executor, []*disco.Node) {
+	c := newCluster()
+	c.ReplicaN = 2
+	var nodes []*disco.Node
+	for i := 0; i < 3; i++ {
+		nodes = append(nodes, &disco.Node{
+			ID:    fmt.Sprintf("node%d", i),
+			URI:   NewTestURI("http", fmt.Sprintf("host%d", i), uint16(0)),
+			State: disco.NodeStateStarted,
+		})
+	}
+	c.noder = disco.NewLocalNoder(nodes)
+	return &executor{Cluster: c, router: newNodeRouter()}, nodes
+}
+
+func TestExecutor_AssignShards_AvoidsDegradedNodes(t *testing.T) {
+	e, nodes := newRoutingExecutor()
+
+	shards := []uint64{0, 1, 2, 3, 4, 5, 6, 7}
+	m, missing := e.assignShards(nodes, "i", shards, true)
+	if len(missing) > 0 {
+		t.Fatalf("expected every shard to be assigned, missing %v", missing)
+	}
+	var degraded *disco.Node
+	for node := range m {
+		degraded = node
+		break
+	}
+	e.router.nodes[degraded.ID] = &nodeHealth{degraded: true, probeAt: time.Now().Add(time.Hour)}
+
+	m, _ = e.assignShards(nodes, "i", shards, true)
+	var n int
+	for node, nodeShards := range m {
+		if node.ID == degraded.ID {
+			t.Fatalf("expected no shards on degraded node, got %v", nodeShards)
+		}
+		n += len(nodeShards)
+	}
+	if n != len(shards) {
+		t.Fatalf("expected %d shards, got %d", len(shards), n)
+	}
+
+	// Without replica preference, the degraded node keeps its shards.
+	m, _ = e.assignShards(nodes, "i", shards, false)
+	n = 0
+	for node, nodeShards := range m {
+		if node.ID == degraded.ID {
+			n += len(nodeShards)
+		}
+	}
+	if n == 0 {
+		t.Fatal("expected shards on the first owner without replica preference")
+	}
+
+	// With every replica degraded, the primaries are used anyway.
+	for _, node := range nodes {
+		e.router.nodes[node.ID] = &nodeHealth{degraded: true, probeAt: time.Now().Add(time.Hour)}
+	}
+	if m, missing = e.assignShards(nodes, "i", shards, true); len(missing) > 0 {
+		t.Fatalf("expected every shard to be assigned, missing %v", missing)
+	} else if len(m) == 0 {
+		t.Fatal("expected shards to be assigned")
+	}
+}
+
+func TestExecutor_AssignShards_ReadsFollowWrites(t *testing.T) {
+	e, nodes := newRoutingExecutor()
+	now := time.Unix(1000, 0)
+	e.router.now = func() time.Time { return now }
+
+	// Find the primary of a shard, and degrade it.
+	const shard = 3
+	snap := disco.NewClusterSnapshot(disco.NewLocalNoder(nodes), e.Cluster.Hasher, e.Cluster.partitionAssigner, e.Cluster.ReplicaN)
+	primary := snap.ShardNodes("i", shard)[0]
+	e.router.nodes[primary.ID] = &nodeHealth{degraded: true, probeAt: now.Add(time.Hour)}
+
+	owner := func(c *pql.Call) string {
+		m, missing := e.assignShards(nodes, "i", []uint64{shard}, e.router.preferReplicas("i", c))
+		if len(missing) > 0 {
+			t.Fatalf("expected shard to be assigned, missing %v", missing)
+		}
+		for node := range m {
+			return node.ID
+		}
+		return ""
+	}
+	read := &pql.Call{Name: "Row", Args: map[string]interface{}{"f": 1}}
+	write := &pql.Call{Name: "ClearRow", Args: map[string]interface{}{"f": 1}}
+
+	// Until anti-entropy has synced the replicas, reads use the primary.
+	if id := owner(read); id != primary.ID {
+		t.Fatalf("expected read before any sync on primary %s, got %s", primary.ID, id)
+	}
+	e.router.synced(now)
+	now = now.Add(time.Second)
+	if id := owner(read); id == primary.ID {
+		t.Fatalf("expected read of synced replicas to avoid degraded primary %s", id)
+	}
+
+	// Writes, and the reads after them, use the primary.
+	if id := owner(write); id != primary.ID {
+		t.Fatalf("expected write on primary %s, got %s", primary.ID, id)
+	}
+	if id := owner(read); id != primary.ID {
+		t.Fatalf("expected read after write on primary %s, got %s", primary.ID, id)
+	}
+
+	// A sync which began before the write doesn't cover it, but one which
+	// began after it does.
+	e.router.synced(now.Add(-time.Second / 2))
+	if id := owner(read); id != primary.ID {
+		t.Fatalf("expected read after write on primary %s, got %s", primary.ID, id)
+	}
+	now = now.Add(time.Second)
+	e.router.synced(now)
+	if id := owner(read); id == primary.ID {
+		t.Fatalf("expected read of synced replicas to avoid degraded primary %s", id)
+	}
+
+	// Other indexes aren't affected by writes.
+	if e.router.preferReplicas("j", write); !e.router.preferReplicas("i", read) {
+		t.Fatal("expected write to another index not to affect reads")
+	}
+}
@@ -0,0 +1,390 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// The HTTP API is described by an OpenAPI 3 document, served at
+// /openapi.json, which is generated from the handler's router: each named
+// route, other than the internal ones, is an operation named after the
+// route, with its path variables and the query arguments it accepts. The
+// bodies of the routes listed in openAPIRoutes are described by schemas
+// generated from the types they're encoded from, so they stay in step
+// with the handlers.
+
+// openAPIVersion is the version of the OpenAPI specification the document
+// follows.
+const openAPIVersion = "3.0.3"
+
+// openAPIRoute describes what a route takes and returns.
+type openAPIRoute struct {
+	summary string
+
+	// request is a value of the type of the route's JSON request body,
+	// and textRequest describes its plain text request body.
+	request     interface{}
+	textRequest string
+
+	// response is a value of the type of the route's JSON response, and
+	// errResponse of its JSON response to a request which failed.
+	response    interface{}
+	errResponse interface{}
+}
+
+// openAPIRoutes describes the routes whose bodies are known, by name.
+var openAPIRoutes = map[string]openAPIRoute{
+	"PostQuery": {
+		summary:     "Run a PQL query against an index.",
+		textRequest: "PQL query. Queries encoded as protobuf are sent as application/x-protobuf.",
+		response:    queryResponseJSON{},
+		errResponse: queryErrorJSON{},
+	},
+	"PostSQL":                 {summary: "Run a SQL statement.", textRequest: "SQL statement."},
+	"GetSchema":               {summary: "Get the schema of every index.", response: Schema{}},
+	"GetSchemaDetails":        {summary: "Get the schema of every index, with views.", response: Schema{}},
+	"PostSchema":              {summary: "Apply a schema.", request: Schema{}},
+	"GetIndex":                {summary: "Get the schema of an index.", response: IndexInfo{}},
+	"PostIndex":               {summary: "Create an index.", request: postIndexRequest{}, response: successResponse{}},
+	"DeleteIndex":             {summary: "Delete an index.", response: successResponse{}},
+	"PostField":               {summary: "Create a field.", request: postFieldRequest{}, response: successResponse{}},
+	"DeleteField":             {summary: "Delete a field.", response: successResponse{}},
+	"GetStatus":               {summary: "Get the state of the cluster.", response: getStatusResponse{}},
+	"GetInfo":                 {summary: "Get information about the node.", response: serverInfo{}},
+	"GetHealth":               {summary: "Check the health of the cluster.", response: Health{}},
+	"GetVersion":              {summary: "Get the server's version.", response: getVersionResponse{}},
+	"GetActiveQueries":        {summary: "List the queries running.", response: []ActiveQueryStatus{}},
+	"GetPastQueries":          {summary: "List the queries run recently.", response: []PastQueryStatus{}},
+	"GetClientSessions":       {summary: "List the client sessions open on the node.", response: []ClientSessionStatus{}},
+	"DeleteClientSession":     {summary: "Close a client session.", response: successResponse{}},
+	"GetUsageReport":          {summary: "Report the storage used by every index.", response: UsageReport{}},
+	"GetStorage":              {summary: "Report how the node stores its data.", response: StorageReport{}},
+	"GetNodeDiagnostics":      {summary: "Describe the node for troubleshooting.", response: NodeDiagnostics{}},
+	"PostStorageOptimize":     {summary: "Optimize the node's storage, streaming progress as a line of JSON per shard.", response: StorageOptimizeProgress{}},
+	"PostImportExistence":     {summary: "Make columns exist without setting any field's values.", request: ImportExistenceRequest{}, response: successResponse{}},
+	"PostImportSession":       {summary: "Start an import session.", request: ImportSessionRequest{}, response: ImportSessionStatus{}},
+	"GetImportSession":        {summary: "Get the progress of an import session.", response: ImportSessionStatus{}},
+	"DeleteImportSession":     {summary: "Abort or forget an import session. Keys created for its parts are kept.", response: successResponse{}},
+	"PutImportSessionPart":    {summary: "Stage a part of an import session.", request: ImportSessionPart{}, response: ImportSessionStatus{}},
+	"PostImportSessionCommit": {summary: "Commit an import session, a shard at a time; a failed commit can be resumed.", response: ImportSessionStatus{}},
+}
+
+// openAPISchemaNames names the schemas of unexported types.
+var openAPISchemaNames = map[reflect.Type]string{
+	reflect.TypeOf(queryResponseJSON{}):  "QueryResponse",
+	reflect.TypeOf(queryErrorJSON{}):     "QueryError",
+	reflect.TypeOf(postIndexRequest{}):   "IndexRequest",
+	reflect.TypeOf(postFieldRequest{}):   "FieldRequest",
+	reflect.TypeOf(fieldOptions{}):       "FieldRequestOptions",
+	reflect.TypeOf(successResponse{}):    "SuccessResponse",
+	reflect.TypeOf(getStatusResponse{}):  "Status",
+	reflect.TypeOf(serverInfo{}):         "ServerInfo",
+	reflect.TypeOf(getVersionResponse{}): "Version",
+}
+
+// OpenAPIDocument is an OpenAPI document describing the HTTP API.
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       OpenAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components OpenAPIComponents                       `json:"components"`
+}
+
+// OpenAPIInfo describes the API.
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIComponents holds the schemas operations refer to.
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+// OpenAPIOperation describes an operation: a method of a path.
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []OpenAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *OpenAPIBody                `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIParameter describes a path variable or query argument.
+type OpenAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIBody describes a request body.
+type OpenAPIBody struct {
+	Description string                       `json:"description,omitempty"`
+	Required    bool                         `json:"required,omitempty"`
+	Content     map[string]*OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse describes a response.
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType holds the schema of a body of a content type.
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPISchema describes a JSON value. An empty schema allows any value.
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+}
+
+// openAPIPathVar matches the variables of a route's path template, and
+// their patterns, if any.
+var openAPIPathVar = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// openAPIDocument describes the routes of router.
+func (h *Handler) openAPIDocument(router *mux.Router) (*OpenAPIDocument, error) {
+	// The document's version is required, though builds can lack one.
+	version := h.api.Version()
+	if version == "" {
+		version = "unknown"
+	}
+	doc := &OpenAPIDocument{
+		OpenAPI:    openAPIVersion,
+		Info:       OpenAPIInfo{Title: "FeatureBase", Version: version},
+		Paths:      make(map[string]map[string]*OpenAPIOperation),
+		Components: OpenAPIComponents{Schemas: make(map[string]*OpenAPISchema)},
+	}
+	ids := make(map[string]int)
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		name := route.GetName()
+		tpl, err := route.GetPathTemplate()
+		if name == "" || err != nil || strings.HasPrefix(tpl, "/internal/") {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		path := openAPIPathVar.ReplaceAllString(tpl, "{$1}")
+		var params []OpenAPIParameter
+		for _, m := range openAPIPathVar.FindAllStringSubmatch(tpl, -1) {
+			params = append(params, OpenAPIParameter{Name: m[1], In: "path", Required: true, Schema: &OpenAPISchema{Type: "string"}})
+		}
+		if spec, ok := h.validators[name]; ok {
+			params = append(params, spec.openAPIParameters()...)
+		}
+		var tags []string
+		if parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2); parts[0] != "" {
+			tags = []string{parts[0]}
+		}
+
+		for _, method := range methods {
+			id := name
+			if ids[name]++; ids[name] > 1 {
+				id += strconv.Itoa(ids[name])
+			}
+			op := &OpenAPIOperation{
+				OperationID: id,
+				Tags:        tags,
+				Parameters:  params,
+				Responses:   map[string]*OpenAPIResponse{"200": {Description: "Success."}},
+			}
+			if r, ok := openAPIRoutes[name]; ok {
+				doc.describe(op, r)
+			}
+			if doc.Paths[path] == nil {
+				doc.Paths[path] = make(map[string]*OpenAPIOperation)
+			}
+			doc.Paths[path][strings.ToLower(method)] = op
+		}
+		return nil
+	})
+	return doc, err
+}
+
+// describe describes the bodies of op.
+func (doc *OpenAPIDocument) describe(op *OpenAPIOperation, r openAPIRoute) {
+	op.Summary = r.summary
+	switch {
+	case r.request != nil:
+		op.RequestBody = &OpenAPIBody{
+			Required: true,
+			Content:  map[string]*OpenAPIMediaType{"application/json": {Schema: doc.schemaOf(reflect.TypeOf(r.request))}},
+		}
+	case r.textRequest != "":
+		op.RequestBody = &OpenAPIBody{
+			Description: r.textRequest,
+			Required:    true,
+			Content:     map[string]*OpenAPIMediaType{"text/plain": {Schema: &OpenAPISchema{Type: "string"}}},
+		}
+	}
+	if r.response != nil {
+		op.Responses["200"].Content = map[string]*OpenAPIMediaType{"application/json": {Schema: doc.schemaOf(reflect.TypeOf(r.response))}}
+	}
+	if r.errResponse != nil {
+		op.Responses["default"] = &OpenAPIResponse{
+			Description: "Failure.",
+			Content:     map[string]*OpenAPIMediaType{"application/json": {Schema: doc.schemaOf(reflect.TypeOf(r.errResponse))}},
+		}
+	}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+)
+
+// schemaOf returns the schema of the JSON encoding of values of t. Named
+// structs are added to the document's components, and referred to.
+func (doc *OpenAPIDocument) schemaOf(t reflect.Type) *OpenAPISchema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t, nullable = t.Elem(), true
+	}
+	switch t {
+	case timeType:
+		return &OpenAPISchema{Type: "string", Format: "date-time", Nullable: nullable}
+	case durationType:
+		return &OpenAPISchema{Type: "integer", Format: "int64", Description: "Nanoseconds."}
+	}
+	// Values which encode themselves can be anything, unless they're
+	// encoded as text.
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		if t.Kind() == reflect.String {
+			return &OpenAPISchema{Type: "string"}
+		}
+		return &OpenAPISchema{}
+	}
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return &OpenAPISchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer", Nullable: nullable}
+	case reflect.Int64, reflect.Uint64, reflect.Uintptr:
+		return &OpenAPISchema{Type: "integer", Format: "int64", Nullable: nullable}
+	case reflect.Float32, reflect.Float64:
+		return &OpenAPISchema{Type: "number", Nullable: nullable}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string", Nullable: nullable}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: doc.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: doc.schemaOf(t.Elem())}
+	case reflect.Struct:
+		name := openAPISchemaName(t)
+		if name == "" {
+			return doc.structSchema(t)
+		}
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			// Hold the name while the struct's fields, which can refer
+			// to it, are described.
+			doc.Components.Schemas[name] = &OpenAPISchema{}
+			doc.Components.Schemas[name] = doc.structSchema(t)
+		}
+		return &OpenAPISchema{Ref: "#/components/schemas/" + name}
+	}
+	return &OpenAPISchema{}
+}
+
+// structSchema returns the schema of a struct's fields, as encoding/json
+// encodes them.
+func (doc *OpenAPIDocument) structSchema(t reflect.Type) *OpenAPISchema {
+	s := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				// Fields of the outer struct take precedence.
+				for name, fs := range doc.structSchema(ft).Properties {
+					if _, ok := s.Properties[name]; !ok {
+						s.Properties[name] = fs
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties[name] = doc.schemaOf(f.Type)
+	}
+	return s
+}
+
+// openAPISchemaName returns the name of the schema of a struct type, or ""
+// for anonymous structs.
+func openAPISchemaName(t reflect.Type) string {
+	if name, ok := openAPISchemaNames[t]; ok {
+		return name
+	}
+	if t.Name() == "" {
+		return ""
+	}
+	if t.PkgPath() == reflect.TypeOf(Handler{}).PkgPath() {
+		return t.Name()
+	}
+	pkg := t.PkgPath()
+	return pkg[strings.LastIndex(pkg, "/")+1:] + "." + t.Name()
+}
+
+// openAPIParameters describes the query arguments s accepts.
+func (s *queryValidationSpec) openAPIParameters() []OpenAPIParameter {
+	required := make(map[string]bool, len(s.required))
+	for _, arg := range s.required {
+		required[arg] = true
+	}
+	args := make([]string, 0, len(s.args))
+	for arg := range s.args {
+		args = append(args, arg)
+	}
+	sort.Strings(args)
+	params := make([]OpenAPIParameter, len(args))
+	for i, arg := range args {
+		params[i] = OpenAPIParameter{Name: arg, In: "query", Required: required[arg], Schema: &OpenAPISchema{Type: "string"}}
+	}
+	return params
+}
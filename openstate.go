@@ -0,0 +1,147 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// OpenState reports how much of a node's data has been opened. Everything
+// is opened at startup unless the node opens fragments lazily, in which
+// case each fragment is opened the first time it's used.
+type OpenState struct {
+	Lazy bool `json:"lazy"`
+
+	// OpenSeconds is how long opening the indexes took at startup.
+	OpenSeconds float64 `json:"openSeconds"`
+
+	Fragments     int              `json:"fragments"`
+	OpenFragments int              `json:"openFragments"`
+	Indexes       []IndexOpenState `json:"indexes"`
+}
+
+// IndexOpenState reports how many of an index's fragments have been opened.
+type IndexOpenState struct {
+	Name          string `json:"name"`
+	Fields        int    `json:"fields"`
+	Fragments     int    `json:"fragments"`
+	OpenFragments int    `json:"openFragments"`
+}
+
+// openState returns how much of the holder's data has been opened.
+func (h *Holder) openState() OpenState {
+	state := OpenState{
+		Lazy:        h.cfg.LazyOpen,
+		OpenSeconds: h.openDuration.Seconds(),
+		Indexes:     []IndexOpenState{},
+	}
+	for _, idx := range h.Indexes() {
+		is := IndexOpenState{Name: idx.Name()}
+		for _, f := range idx.Fields() {
+			is.Fields++
+			for _, v := range f.views() {
+				for _, frag := range v.fragmentList() {
+					is.Fragments++
+					if frag.isOpen() {
+						is.OpenFragments++
+					}
+				}
+			}
+		}
+		state.Fragments += is.Fragments
+		state.OpenFragments += is.OpenFragments
+		state.Indexes = append(state.Indexes, is)
+	}
+	sort.Slice(state.Indexes, func(i, j int) bool {
+		return state.Indexes[i].Name < state.Indexes[j].Name
+	})
+	return state
+}
+
+// OpenState reports how much of this node's data has been opened.
+func (api *API) OpenState(ctx context.Context) (OpenState, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.OpenState")
+	defer span.Finish()
+
+	if err := api.validate(apiOpenState); err != nil {
+		return OpenState{}, errors.Wrap(err, "validating api method")
+	}
+	return api.holder.openState(), nil
+}
+
+// UnopenedFragment describes a fragment which couldn't be opened when it was
+// first used. Until the node is restarted, queries of its shard fail rather
+// than leaving it out of their results.
+type UnopenedFragment struct {
+	Index    string    `json:"index"`
+	Field    string    `json:"field"`
+	View     string    `json:"view"`
+	Shard    uint64    `json:"shard"`
+	Error    string    `json:"error"`
+	Detected time.Time `json:"detected"`
+}
+
+func (u UnopenedFragment) key() string {
+	return fmt.Sprintf("%s/%s/%s/%d", u.Index, u.Field, u.View, u.Shard)
+}
+
+// noteUnopened records that a fragment couldn't be opened.
+func (h *Holder) noteUnopened(f *fragment, err error) {
+	u := UnopenedFragment{
+		Index:    f.index(),
+		Field:    f.field(),
+		View:     f.view(),
+		Shard:    f.shard,
+		Error:    err.Error(),
+		Detected: time.Now().UTC(),
+	}
+	h.unopenedMu.Lock()
+	defer h.unopenedMu.Unlock()
+	if h.unopened == nil {
+		h.unopened = make(map[string]UnopenedFragment)
+	}
+	if _, ok := h.unopened[u.key()]; !ok {
+		h.Logger.Errorf("opening fragment %s: %v", u.key(), err)
+		h.unopened[u.key()] = u
+		atomic.StoreInt32(&h.unopenedN, int32(len(h.unopened)))
+	}
+}
+
+// unopenedFragments returns the fragments which couldn't be opened, in
+// order.
+func (h *Holder) unopenedFragments() []UnopenedFragment {
+	h.unopenedMu.Lock()
+	unopened := make([]UnopenedFragment, 0, len(h.unopened))
+	for _, u := range h.unopened {
+		unopened = append(unopened, u)
+	}
+	h.unopenedMu.Unlock()
+	sort.Slice(unopened, func(i, j int) bool {
+		return unopened[i].key() < unopened[j].key()
+	})
+	return unopened
+}
+
+// unopenedShardErr returns an error if a fragment of a shard of an index
+// couldn't be opened, and so would have been left out of the shard's
+// results.
+func (h *Holder) unopenedShardErr(index string, shard uint64) error {
+	if atomic.LoadInt32(&h.unopenedN) == 0 {
+		return nil
+	}
+	h.unopenedMu.Lock()
+	defer h.unopenedMu.Unlock()
+	for _, u := range h.unopened {
+		if u.Index == index && u.Shard == shard {
+			return errors.Wrapf(ErrFragmentUnopened, "%s: %s", u.key(), u.Error)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// A server can be given options for the indexes and fields created through
+// it which their requests don't give, and named templates of options which
+// requests can start from, as in
+//
+//	POST /index/i/field/f {"template": "timeseries", "options": {"keys": true}}
+//
+// so that conventions can be kept without every client spelling them out.
+// Options are given by the names they have in requests, and a request's own
+// options take precedence over its template's, which take precedence over
+// the server's defaults. Each option is taken whole, so a request giving
+// tags replaces the tags of its template, rather than adding to them.
+// Templates are read from a JSON file, as in
+//
+//	{
+//		"defaults": {"index": {"trackExistence": true}},
+//		"templates": {
+//			"timeseries": {
+//				"index": {"trackExistence": true},
+//				"field": {"type": "time", "timeQuantum": "D", "noStandardView": true}
+//			}
+//		}
+//	}
+
+// OptionTemplates are the options given to new indexes and fields by
+// default, and the named templates of options their requests can use.
+type OptionTemplates struct {
+	Defaults  OptionTemplate            `json:"defaults"`
+	Templates map[string]OptionTemplate `json:"templates"`
+}
+
+// OptionTemplate holds options of indexes and fields, by their names in the
+// options of requests to create them.
+type OptionTemplate struct {
+	Index map[string]json.RawMessage `json:"index,omitempty"`
+	Field map[string]json.RawMessage `json:"field,omitempty"`
+}
+
+// LoadOptionTemplates reads option templates from the JSON file at path.
+func LoadOptionTemplates(path string) (*OptionTemplates, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading option templates")
+	}
+	var t OptionTemplates
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&t); err != nil {
+		return nil, errors.Wrapf(err, "decoding option templates from %s", path)
+	}
+	return &t, nil
+}
+
+// validate returns an error if any of the templates gives an option indexes
+// or fields don't have.
+func (t *OptionTemplates) validate() error {
+	validIndexOptions := getValidOptions(IndexOptions{})
+	check := func(name string, tmpl OptionTemplate) error {
+		for opt := range tmpl.Index {
+			if !foundItem(validIndexOptions, opt) {
+				return errors.Errorf("option template %s: unknown index option %q", name, opt)
+			}
+		}
+		if len(tmpl.Field) == 0 {
+			return nil
+		}
+		buf, err := json.Marshal(tmpl.Field)
+		if err != nil {
+			return errors.Wrapf(err, "option template %s", name)
+		}
+		var fo fieldOptions
+		dec := json.NewDecoder(bytes.NewReader(buf))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&fo); err != nil {
+			return errors.Wrapf(err, "option template %s: field options", name)
+		}
+		return nil
+	}
+	if err := check("defaults", t.Defaults); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(t.Templates))
+	for name := range t.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := check(name, t.Templates[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply returns the body of a request to create an index or a field, with
+// the options part picks from the server's defaults, and from the template
+// the request names, if any, added to those the request doesn't give. The
+// body is returned as it is if there's nothing to add.
+func (t *OptionTemplates) apply(body []byte, part func(OptionTemplate) map[string]json.RawMessage) ([]byte, error) {
+	req := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, NewBadRequestError(errors.Wrap(err, "decoding request"))
+		}
+	}
+	var name string
+	raw, named := req["template"]
+	if named {
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return nil, NewBadRequestError(errors.New("template must be a string"))
+		}
+		delete(req, "template")
+	}
+
+	var layers []map[string]json.RawMessage
+	if t != nil {
+		layers = append(layers, part(t.Defaults))
+	}
+	if name != "" {
+		var tmpl OptionTemplate
+		var ok bool
+		if t != nil {
+			tmpl, ok = t.Templates[name]
+		}
+		if !ok {
+			return nil, NewBadRequestError(errors.Errorf("unknown option template %q", name))
+		}
+		layers = append(layers, part(tmpl))
+	}
+	if !named && (len(layers) == 0 || len(layers[0]) == 0) {
+		return body, nil
+	}
+
+	options := make(map[string]json.RawMessage)
+	for _, layer := range layers {
+		for opt, v := range layer {
+			options[opt] = v
+		}
+	}
+	if raw, ok := req["options"]; ok {
+		given := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(raw, &given); err != nil {
+			return nil, NewBadRequestError(errors.Wrap(err, "decoding options"))
+		}
+		for opt, v := range given {
+			options[opt] = v
+		}
+	}
+	buf, err := json.Marshal(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding options")
+	}
+	req["options"] = buf
+	return json.Marshal(req)
+}
+
+// indexOptionTemplate returns the index options of a template.
+func indexOptionTemplate(t OptionTemplate) map[string]json.RawMessage { return t.Index }
+
+// fieldOptionTemplate returns the field options of a template.
+func fieldOptionTemplate(t OptionTemplate) map[string]json.RawMessage { return t.Field }
@@ -0,0 +1,88 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOptionTemplates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "templates.json")
+	if err := os.WriteFile(path, []byte(`{
+		"defaults": {"field": {"cacheSize": 100}},
+		"templates": {
+			"timeseries": {
+				"index": {"trackExistence": true},
+				"field": {"type": "time", "timeQuantum": "D", "noStandardView": true}
+			}
+		}
+	}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := LoadOptionTemplates(path)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := tmpl.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	options := func(body []byte) map[string]interface{} {
+		var req struct {
+			Options map[string]interface{} `json:"options"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatal(err)
+		}
+		return req.Options
+	}
+	for _, tc := range []struct {
+		body string
+		part func(OptionTemplate) map[string]json.RawMessage
+		exp  map[string]interface{}
+	}{
+		{``, fieldOptionTemplate, map[string]interface{}{"cacheSize": 100.0}},
+		{`{"options": {"cacheSize": 5, "keys": true}}`, fieldOptionTemplate, map[string]interface{}{"cacheSize": 5.0, "keys": true}},
+		{`{"template": "timeseries", "options": {"timeQuantum": "YM"}}`, fieldOptionTemplate, map[string]interface{}{
+			"cacheSize": 100.0, "type": "time", "timeQuantum": "YM", "noStandardView": true,
+		}},
+		{`{"template": "timeseries"}`, indexOptionTemplate, map[string]interface{}{"trackExistence": true}},
+		{`{"template": "", "options": {"keys": true}}`, indexOptionTemplate, map[string]interface{}{"keys": true}},
+	} {
+		body, err := tmpl.apply([]byte(tc.body), tc.part)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.body, err)
+		} else if strings.Contains(string(body), "template") {
+			t.Fatalf("%s: template left in request %s", tc.body, body)
+		} else if got := options(body); !reflect.DeepEqual(got, tc.exp) {
+			t.Fatalf("%s: expected options %v, got %v", tc.body, tc.exp, got)
+		}
+	}
+
+	// Requests are left alone if there's nothing to add.
+	if body, err := tmpl.apply([]byte(`{"options": {"keys": true}}`), indexOptionTemplate); err != nil {
+		t.Fatal(err)
+	} else if string(body) != `{"options": {"keys": true}}` {
+		t.Fatalf("unexpected request %s", body)
+	}
+	var none *OptionTemplates
+	if _, err := none.apply([]byte(`{"template": "timeseries"}`), indexOptionTemplate); err == nil || !strings.Contains(err.Error(), "unknown option template") {
+		t.Fatalf("expected unknown template, got %v", err)
+	}
+	if _, err := tmpl.apply([]byte(`{"template": 7}`), indexOptionTemplate); err == nil {
+		t.Fatal("expected error for template which isn't a string")
+	}
+
+	for _, bad := range []OptionTemplate{
+		{Index: map[string]json.RawMessage{"timeQuantum": json.RawMessage(`"D"`)}},
+		{Field: map[string]json.RawMessage{"trackExistence": json.RawMessage(`true`)}},
+	} {
+		if err := (&OptionTemplates{Templates: map[string]OptionTemplate{"bad": bad}}).validate(); err == nil || !strings.Contains(err.Error(), "option template bad") {
+			t.Fatalf("expected invalid template %v, got %v", bad, err)
+		}
+	}
+}
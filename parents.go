@@ -0,0 +1,77 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// An import into a field with a foreign index can create the parent records
+// it references, with the CreateParents import option, so that parents
+// needn't be imported before their children. The parents' keys are created
+// as the import's values are translated, as they always are; CreateParents
+// also makes the parents exist in the foreign index, and, if the import
+// names a ParentExistsField, sets that bool field for them. Parents are
+// created before the import's own data is written, so a failure leaves
+// parents without children rather than children without parents.
+
+// importParents makes the records of field's foreign index which ids
+// reference exist.
+func (api *API) importParents(ctx context.Context, field *Field, ids []uint64, options *ImportOptions) error {
+	if field.ForeignIndex() == "" {
+		return NewBadRequestError(errors.Errorf("field %s has no foreign index to create parents in", field.Name()))
+	}
+	parent := api.holder.Index(field.ForeignIndex())
+	if parent == nil {
+		return errors.Wrapf(ErrForeignIndexNotFound, "%s", field.ForeignIndex())
+	}
+
+	fieldName, rowID := options.ParentExistsField, trueRowID
+	if fieldName == "" {
+		if parent.existenceField() == nil {
+			// There's nothing to record a parent's existence in.
+			return nil
+		}
+		fieldName, rowID = existenceFieldName, 0
+	} else if f := parent.Field(fieldName); f == nil {
+		return newNotFoundError(ErrFieldNotFound, fieldName)
+	} else if f.Type() != FieldTypeBool {
+		return NewBadRequestError(errors.Errorf("parent exists field %s is of type %q, not bool", fieldName, f.Type()))
+	}
+
+	cols := make([]uint64, len(ids))
+	copy(cols, ids)
+	sort.Slice(cols, func(i, j int) bool { return cols[i] < cols[j] })
+	n := 0
+	for i, col := range cols {
+		if i == 0 || col != cols[n-1] {
+			cols[n] = col
+			n++
+		}
+	}
+	cols = cols[:n]
+	if len(cols) == 0 {
+		return nil
+	}
+	rows := make([]uint64, len(cols))
+	for i := range rows {
+		rows[i] = rowID
+	}
+
+	qcx := api.Txf().NewQcx()
+	defer qcx.Abort()
+	err := api.Import(ctx, qcx, &ImportRequest{
+		Index:     parent.Name(),
+		Field:     fieldName,
+		Shard:     ^uint64(0),
+		RowIDs:    rows,
+		ColumnIDs: cols,
+	}, OptImportOptionsIgnoreKeyCheck(true))
+	if err != nil {
+		return errors.Wrapf(err, "importing into index %s", parent.Name())
+	}
+	return qcx.Finish()
+}
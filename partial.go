@@ -0,0 +1,134 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// A query fails if any of its shards can't be reached, because none of the
+// nodes holding it are up. Queries with QueryRequest.AllowPartial set
+// instead leave such shards out, and return results computed from the
+// shards which could be reached, along with a Completeness reporting the
+// shards left out, so that dashboards can show something during an
+// incident. Shards are left out only when the nodes holding them are down
+// or refuse connections; any other error still fails the query. Writes
+// can't be partial.
+
+// Completeness reports how much of its data a query allowing partial
+// results covered.
+type Completeness struct {
+	// Complete is true if every shard queried was reached.
+	Complete bool `json:"complete"`
+
+	// Shards is the number of shards queried.
+	Shards int `json:"shards"`
+
+	// MissingShards holds the shards of each index which couldn't be
+	// reached.
+	MissingShards map[string][]uint64 `json:"missingShards,omitempty"`
+
+	// Coverage is the estimated fraction of the data covered by the
+	// results, the fraction of the shards queried which were reached.
+	Coverage float64 `json:"coverage"`
+}
+
+// newCompleteness returns the Completeness of a query of shards of index,
+// of which missing couldn't be reached.
+func newCompleteness(index string, shards int, missing []uint64) *Completeness {
+	c := &Completeness{Shards: shards}
+	if len(missing) > 0 {
+		c.MissingShards = map[string][]uint64{index: missing}
+	}
+	c.update()
+	return c
+}
+
+// merge adds the shards queried, and those missing, of other to c.
+func (c *Completeness) merge(other *Completeness) {
+	c.Shards += other.Shards
+	for index, shards := range other.MissingShards {
+		if c.MissingShards == nil {
+			c.MissingShards = make(map[string][]uint64)
+		}
+		c.MissingShards[index] = append(c.MissingShards[index], shards...)
+	}
+	c.update()
+}
+
+// update sets Complete and Coverage from the shards queried and missing.
+func (c *Completeness) update() {
+	var missing int
+	for _, shards := range c.MissingShards {
+		missing += len(shards)
+	}
+	c.Complete = missing == 0
+	c.Coverage = 1
+	if c.Shards > 0 {
+		c.Coverage = float64(c.Shards-missing) / float64(c.Shards)
+	}
+}
+
+// partialResults records the shards a query allowing partial results
+// queried, and those it couldn't reach.
+type partialResults struct {
+	mu      sync.Mutex
+	queried map[uint64]struct{}
+	missing map[uint64]struct{}
+}
+
+func newPartialResults() *partialResults {
+	return &partialResults{
+		queried: make(map[uint64]struct{}),
+		missing: make(map[uint64]struct{}),
+	}
+}
+
+// query records that shards were queried.
+func (p *partialResults) query(shards []uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, shard := range shards {
+		p.queried[shard] = struct{}{}
+	}
+}
+
+// miss records that shards couldn't be reached.
+func (p *partialResults) miss(shards []uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, shard := range shards {
+		p.missing[shard] = struct{}{}
+	}
+}
+
+// completeness returns the Completeness of the query of index.
+func (p *partialResults) completeness(index string) *Completeness {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var missing []uint64
+	for shard := range p.missing {
+		missing = append(missing, shard)
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+	return newCompleteness(index, len(p.queried), missing)
+}
+
+type contextKeyPartialResultsType struct{}
+
+var contextKeyPartialResults = contextKeyPartialResultsType{}
+
+// withPartialResults returns a context carrying the record of a query
+// allowing partial results.
+func withPartialResults(ctx context.Context, p *partialResults) context.Context {
+	return context.WithValue(ctx, contextKeyPartialResults, p)
+}
+
+// partialResultsFromContext returns the record of the query running in
+// ctx, if it allows partial results.
+func partialResultsFromContext(ctx context.Context) *partialResults {
+	p, _ := ctx.Value(contextKeyPartialResults).(*partialResults)
+	return p
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	pnet "github.com/featurebasedb/featurebase/v3/net"
+	"github.com/pkg/errors"
+)
+
+// With the ring-hash partition assignment, a node joining or leaving the
+// cluster moves only some key partitions to other nodes. A node which comes
+// to own a key partition, as its primary or a replica, copies the partition's
+// keys from a node which owned it before, if it has none of them, before it
+// starts replicating the partition's keys from its primary.
+
+// keyPartitionMigrateTimeout bounds the copying of each key partition.
+const keyPartitionMigrateTimeout = 5 * time.Minute
+
+// migrateKeyPartitions copies the index key partitions which the local node
+// owns in snap, but didn't in prev, from the nodes which owned them in prev.
+// If prev is nil, as it is when the node has just started, the partitions
+// it owned before are taken to be those the other nodes would own without it.
+func (s *holderSyncer) migrateKeyPartitions(prev, snap *disco.ClusterSnapshot) {
+	if prev != nil && sameNodeIDs(prev.Nodes, snap.Nodes) {
+		return
+	}
+	nodeID := s.Node.ID
+	present := make(map[string]*disco.Node, len(snap.Nodes))
+	others := make([]*disco.Node, 0, len(snap.Nodes))
+	for _, node := range snap.Nodes {
+		present[node.ID] = node
+		if node.ID != nodeID {
+			others = append(others, node)
+		}
+	}
+	if prev == nil {
+		if len(others) == 0 {
+			return
+		}
+		prev = disco.NewClusterSnapshot(disco.NewLocalNoder(others), snap.Hasher, snap.PartitionAssignment, s.Cluster.ReplicaN)
+	}
+
+	var migrated int
+	for _, idx := range s.Holder.Indexes() {
+		if !idx.Keys() {
+			continue
+		}
+		for partitionID := 0; partitionID < snap.PartitionN; partitionID++ {
+			if s.IsClosing() {
+				return
+			}
+			if !snap.OwnsPartition(nodeID, partitionID) || prev.OwnsPartition(nodeID, partitionID) {
+				continue
+			}
+			store := idx.TranslateStore(partitionID)
+			if store == nil {
+				continue
+			}
+			if maxID, err := store.MaxID(); err != nil {
+				s.Holder.Logger.Errorf("reading key partition %d of index %s: %s", partitionID, idx.Name(), err)
+				continue
+			} else if maxID != 0 {
+				continue
+			}
+
+			// Keep keys from being created in the partition until it has
+			// those created before; the read-only flags of all stores are
+			// set once partitions are migrated.
+			store.SetReadOnly(true)
+			for _, node := range prev.PartitionNodes(partitionID) {
+				if node.ID == nodeID || present[node.ID] == nil {
+					continue
+				}
+				if err := s.copyKeyPartition(idx.Name(), partitionID, store, present[node.ID].URI); err != nil {
+					s.Holder.Logger.Errorf("copying key partition %d of index %s from node %s: %s", partitionID, idx.Name(), node.ID, err)
+					continue
+				}
+				migrated++
+				break
+			}
+		}
+	}
+	if migrated > 0 {
+		s.Holder.Logger.Infof("copied %d key partitions from their previous owners", migrated)
+	}
+}
+
+// copyKeyPartition replaces the contents of store with those of the key
+// partition held by the node at uri.
+func (s *holderSyncer) copyKeyPartition(index string, partitionID int, store TranslateStore, uri pnet.URI) error {
+	ctx, cancel := context.WithTimeout(context.Background(), keyPartitionMigrateTimeout)
+	defer cancel()
+	rd, err := s.Cluster.InternalClient.LocalIndexTranslateDataReaderFromURI(ctx, index, partitionID, uri)
+	if err != nil {
+		return errors.Wrap(err, "requesting partition")
+	}
+	defer rd.Close()
+	if _, err := store.ReadFrom(rd); err != nil {
+		return errors.Wrap(err, "reading partition")
+	}
+	return nil
+}
+
+// sameNodeIDs returns true if a and b are the same nodes, in the same order.
+func sameNodeIDs(a, b []*disco.Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	pnet "github.com/featurebasedb/featurebase/v3/net"
+)
+
+func TestHolderSyncer_MigrateKeyPartitions(t *testing.T) {
+	h := newTestHolder(t)
+	idx, err := h.CreateIndex("i", IndexOptions{Keys: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The other node has a key in each partition.
+	var mu sync.Mutex
+	var requested []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		partitionID, err := strconv.Atoi(q.Get("partition"))
+		if err != nil || q.Get("index") != "i" || q.Get("local") != "true" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		requested = append(requested, partitionID)
+		mu.Unlock()
+		store := NewInMemTranslateStore("i", "", partitionID, disco.DefaultPartitionN)
+		if _, err := store.CreateKeys(fmt.Sprintf("key%d", partitionID)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = store.WriteTo(w)
+	}))
+	defer srv.Close()
+	uri, err := pnet.NewURIFromAddress(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewInternalClient(srv.URL, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	local := &disco.Node{ID: "node0", URI: NewTestURIFromHostPort("localhost", 10101)}
+	c := newCluster()
+	c.noder = disco.NewLocalNoder([]*disco.Node{local, {ID: "node1", URI: *uri}})
+	c.partitionAssigner = disco.PartitionAssignmentRingHash
+	c.InternalClient = client
+	s := &holderSyncer{Holder: h, Node: local, Cluster: c}
+
+	// When the node starts, it copies the partitions it owns.
+	snap := c.NewSnapshot()
+	s.migrateKeyPartitions(nil, snap)
+	var owned int
+	for partitionID := 0; partitionID < snap.PartitionN; partitionID++ {
+		found, err := idx.TranslateStore(partitionID).FindKeys(fmt.Sprintf("key%d", partitionID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if snap.OwnsPartition(local.ID, partitionID) {
+			owned++
+			if len(found) != 1 {
+				t.Fatalf("partition %d is owned, but wasn't copied", partitionID)
+			}
+		} else if len(found) != 0 {
+			t.Fatalf("partition %d isn't owned, but was copied", partitionID)
+		}
+	}
+	if owned == 0 || owned == snap.PartitionN || len(requested) != owned {
+		t.Fatalf("owned %d partitions, requested %d", owned, len(requested))
+	}
+
+	// Partitions aren't copied again while the nodes are the same.
+	requested = nil
+	s.migrateKeyPartitions(snap, c.NewSnapshot())
+	if len(requested) != 0 {
+		t.Fatalf("requested %d partitions without a change of nodes", len(requested))
+	}
+}
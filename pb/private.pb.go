@@ -23,11 +23,21 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type IndexMeta struct {
-	Keys                 bool     `protobuf:"varint,3,opt,name=Keys,proto3" json:"Keys,omitempty"`
-	TrackExistence       bool     `protobuf:"varint,4,opt,name=TrackExistence,proto3" json:"TrackExistence,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Keys                 bool              `protobuf:"varint,3,opt,name=Keys,proto3" json:"Keys,omitempty"`
+	TrackExistence       bool              `protobuf:"varint,4,opt,name=TrackExistence,proto3" json:"TrackExistence,omitempty"`
+	MaxColumns           uint64            `protobuf:"varint,5,opt,name=MaxColumns,proto3" json:"MaxColumns,omitempty"`
+	MaxGroups            uint64            `protobuf:"varint,6,opt,name=MaxGroups,proto3" json:"MaxGroups,omitempty"`
+	MaxRows              uint64            `protobuf:"varint,7,opt,name=MaxRows,proto3" json:"MaxRows,omitempty"`
+	Description          string            `protobuf:"bytes,8,opt,name=Description,proto3" json:"Description,omitempty"`
+	Owner                string            `protobuf:"bytes,9,opt,name=Owner,proto3" json:"Owner,omitempty"`
+	Tags                 map[string]string `protobuf:"bytes,10,rep,name=Tags,proto3" json:"Tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Namespace            string            `protobuf:"bytes,11,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
+	MaxStorage           int64             `protobuf:"varint,12,opt,name=MaxStorage,proto3" json:"MaxStorage,omitempty"`
+	ReadOnly             bool              `protobuf:"varint,13,opt,name=ReadOnly,proto3" json:"ReadOnly,omitempty"`
+	Cubes                []*Cube           `protobuf:"bytes,14,rep,name=Cubes,proto3" json:"Cubes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *IndexMeta) Reset()         { *m = IndexMeta{} }
@@ -77,33 +87,179 @@ func (m *IndexMeta) GetTrackExistence() bool {
 	return false
 }
 
-type FieldOptions struct {
-	Type                 string   `protobuf:"bytes,8,opt,name=Type,proto3" json:"Type,omitempty"`
-	CacheType            string   `protobuf:"bytes,3,opt,name=CacheType,proto3" json:"CacheType,omitempty"`
-	CacheSize            uint32   `protobuf:"varint,4,opt,name=CacheSize,proto3" json:"CacheSize,omitempty"`
-	TimeQuantum          string   `protobuf:"bytes,5,opt,name=TimeQuantum,proto3" json:"TimeQuantum,omitempty"`
-	OldMin               int64    `protobuf:"varint,9,opt,name=OldMin,proto3" json:"OldMin,omitempty"`
-	OldMax               int64    `protobuf:"varint,10,opt,name=OldMax,proto3" json:"OldMax,omitempty"`
-	Keys                 bool     `protobuf:"varint,11,opt,name=Keys,proto3" json:"Keys,omitempty"`
-	NoStandardView       bool     `protobuf:"varint,12,opt,name=NoStandardView,proto3" json:"NoStandardView,omitempty"`
-	Base                 int64    `protobuf:"varint,13,opt,name=Base,proto3" json:"Base,omitempty"`
-	BitDepth             uint64   `protobuf:"varint,14,opt,name=BitDepth,proto3" json:"BitDepth,omitempty"`
-	Scale                int64    `protobuf:"varint,15,opt,name=Scale,proto3" json:"Scale,omitempty"`
-	ForeignIndex         string   `protobuf:"bytes,16,opt,name=ForeignIndex,proto3" json:"ForeignIndex,omitempty"`
-	Min                  *Decimal `protobuf:"bytes,17,opt,name=Min,proto3" json:"Min,omitempty"`
-	Max                  *Decimal `protobuf:"bytes,18,opt,name=Max,proto3" json:"Max,omitempty"`
-	TimeUnit             string   `protobuf:"bytes,19,opt,name=TimeUnit,proto3" json:"TimeUnit,omitempty"`
-	TTL                  string   `protobuf:"bytes,20,opt,name=TTL,proto3" json:"TTL,omitempty"`
+func (m *IndexMeta) GetMaxColumns() uint64 {
+	if m != nil {
+		return m.MaxColumns
+	}
+	return 0
+}
+
+func (m *IndexMeta) GetMaxGroups() uint64 {
+	if m != nil {
+		return m.MaxGroups
+	}
+	return 0
+}
+
+func (m *IndexMeta) GetMaxRows() uint64 {
+	if m != nil {
+		return m.MaxRows
+	}
+	return 0
+}
+
+func (m *IndexMeta) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *IndexMeta) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *IndexMeta) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *IndexMeta) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *IndexMeta) GetMaxStorage() int64 {
+	if m != nil {
+		return m.MaxStorage
+	}
+	return 0
+}
+
+func (m *IndexMeta) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *IndexMeta) GetCubes() []*Cube {
+	if m != nil {
+		return m.Cubes
+	}
+	return nil
+}
+
+type Cube struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Fields               []string `protobuf:"bytes,2,rep,name=Fields,proto3" json:"Fields,omitempty"`
+	Aggregate            string   `protobuf:"bytes,3,opt,name=Aggregate,proto3" json:"Aggregate,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *Cube) Reset()         { *m = Cube{} }
+func (m *Cube) String() string { return proto.CompactTextString(m) }
+func (*Cube) ProtoMessage()    {}
+func (*Cube) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{1}
+}
+func (m *Cube) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Cube) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Cube.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Cube) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cube.Merge(m, src)
+}
+func (m *Cube) XXX_Size() int {
+	return m.Size()
+}
+func (m *Cube) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cube.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cube proto.InternalMessageInfo
+
+func (m *Cube) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Cube) GetFields() []string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *Cube) GetAggregate() string {
+	if m != nil {
+		return m.Aggregate
+	}
+	return ""
+}
+
+type FieldOptions struct {
+	Type                 string            `protobuf:"bytes,8,opt,name=Type,proto3" json:"Type,omitempty"`
+	CacheType            string            `protobuf:"bytes,3,opt,name=CacheType,proto3" json:"CacheType,omitempty"`
+	CacheSize            uint32            `protobuf:"varint,4,opt,name=CacheSize,proto3" json:"CacheSize,omitempty"`
+	TimeQuantum          string            `protobuf:"bytes,5,opt,name=TimeQuantum,proto3" json:"TimeQuantum,omitempty"`
+	OldMin               int64             `protobuf:"varint,9,opt,name=OldMin,proto3" json:"OldMin,omitempty"`
+	OldMax               int64             `protobuf:"varint,10,opt,name=OldMax,proto3" json:"OldMax,omitempty"`
+	Keys                 bool              `protobuf:"varint,11,opt,name=Keys,proto3" json:"Keys,omitempty"`
+	NoStandardView       bool              `protobuf:"varint,12,opt,name=NoStandardView,proto3" json:"NoStandardView,omitempty"`
+	Base                 int64             `protobuf:"varint,13,opt,name=Base,proto3" json:"Base,omitempty"`
+	BitDepth             uint64            `protobuf:"varint,14,opt,name=BitDepth,proto3" json:"BitDepth,omitempty"`
+	Scale                int64             `protobuf:"varint,15,opt,name=Scale,proto3" json:"Scale,omitempty"`
+	ForeignIndex         string            `protobuf:"bytes,16,opt,name=ForeignIndex,proto3" json:"ForeignIndex,omitempty"`
+	Min                  *Decimal          `protobuf:"bytes,17,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max                  *Decimal          `protobuf:"bytes,18,opt,name=Max,proto3" json:"Max,omitempty"`
+	TimeUnit             string            `protobuf:"bytes,19,opt,name=TimeUnit,proto3" json:"TimeUnit,omitempty"`
+	TTL                  string            `protobuf:"bytes,20,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	Description          string            `protobuf:"bytes,21,opt,name=Description,proto3" json:"Description,omitempty"`
+	Owner                string            `protobuf:"bytes,22,opt,name=Owner,proto3" json:"Owner,omitempty"`
+	Tags                 map[string]string `protobuf:"bytes,23,rep,name=Tags,proto3" json:"Tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MemoryPolicy         string            `protobuf:"bytes,24,opt,name=MemoryPolicy,proto3" json:"MemoryPolicy,omitempty"`
+	MemoryPolicyViews    []string          `protobuf:"bytes,25,rep,name=MemoryPolicyViews,proto3" json:"MemoryPolicyViews,omitempty"`
+	CacheStaleness       string            `protobuf:"bytes,26,opt,name=CacheStaleness,proto3" json:"CacheStaleness,omitempty"`
+	Derive               string            `protobuf:"bytes,27,opt,name=Derive,proto3" json:"Derive,omitempty"`
+	History              string            `protobuf:"bytes,28,opt,name=History,proto3" json:"History,omitempty"`
+	Cascade              string            `protobuf:"bytes,29,opt,name=Cascade,proto3" json:"Cascade,omitempty"`
+	TrackDistinct        bool              `protobuf:"varint,30,opt,name=TrackDistinct,proto3" json:"TrackDistinct,omitempty"`
+	TimeZone             string            `protobuf:"bytes,31,opt,name=TimeZone,proto3" json:"TimeZone,omitempty"`
+	FiscalYearStart      uint32            `protobuf:"varint,32,opt,name=FiscalYearStart,proto3" json:"FiscalYearStart,omitempty"`
+	RejectNewKeys        bool              `protobuf:"varint,33,opt,name=RejectNewKeys,proto3" json:"RejectNewKeys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
 func (m *FieldOptions) Reset()         { *m = FieldOptions{} }
 func (m *FieldOptions) String() string { return proto.CompactTextString(m) }
 func (*FieldOptions) ProtoMessage()    {}
 func (*FieldOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{1}
+	return fileDescriptor_d2a91b51c7bdc125, []int{2}
 }
 func (m *FieldOptions) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -244,6 +400,97 @@ func (m *FieldOptions) GetTTL() string {
 	return ""
 }
 
+func (m *FieldOptions) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *FieldOptions) GetMemoryPolicy() string {
+	if m != nil {
+		return m.MemoryPolicy
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetMemoryPolicyViews() []string {
+	if m != nil {
+		return m.MemoryPolicyViews
+	}
+	return nil
+}
+
+func (m *FieldOptions) GetCacheStaleness() string {
+	if m != nil {
+		return m.CacheStaleness
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetDerive() string {
+	if m != nil {
+		return m.Derive
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetHistory() string {
+	if m != nil {
+		return m.History
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetCascade() string {
+	if m != nil {
+		return m.Cascade
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetTrackDistinct() bool {
+	if m != nil {
+		return m.TrackDistinct
+	}
+	return false
+}
+
+func (m *FieldOptions) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetFiscalYearStart() uint32 {
+	if m != nil {
+		return m.FiscalYearStart
+	}
+	return 0
+}
+
+func (m *FieldOptions) GetRejectNewKeys() bool {
+	if m != nil {
+		return m.RejectNewKeys
+	}
+	return false
+}
+
 type ImportResponse struct {
 	Err                  string   `protobuf:"bytes,1,opt,name=Err,proto3" json:"Err,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -255,7 +502,7 @@ func (m *ImportResponse) Reset()         { *m = ImportResponse{} }
 func (m *ImportResponse) String() string { return proto.CompactTextString(m) }
 func (*ImportResponse) ProtoMessage()    {}
 func (*ImportResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{2}
+	return fileDescriptor_d2a91b51c7bdc125, []int{3}
 }
 func (m *ImportResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -306,7 +553,7 @@ func (m *BlockDataRequest) Reset()         { *m = BlockDataRequest{} }
 func (m *BlockDataRequest) String() string { return proto.CompactTextString(m) }
 func (*BlockDataRequest) ProtoMessage()    {}
 func (*BlockDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{3}
+	return fileDescriptor_d2a91b51c7bdc125, []int{4}
 }
 func (m *BlockDataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -382,7 +629,7 @@ func (m *BlockDataResponse) Reset()         { *m = BlockDataResponse{} }
 func (m *BlockDataResponse) String() string { return proto.CompactTextString(m) }
 func (*BlockDataResponse) ProtoMessage()    {}
 func (*BlockDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{4}
+	return fileDescriptor_d2a91b51c7bdc125, []int{5}
 }
 func (m *BlockDataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -436,7 +683,7 @@ func (m *Cache) Reset()         { *m = Cache{} }
 func (m *Cache) String() string { return proto.CompactTextString(m) }
 func (*Cache) ProtoMessage()    {}
 func (*Cache) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{5}
+	return fileDescriptor_d2a91b51c7bdc125, []int{6}
 }
 func (m *Cache) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -483,7 +730,7 @@ func (m *MaxShards) Reset()         { *m = MaxShards{} }
 func (m *MaxShards) String() string { return proto.CompactTextString(m) }
 func (*MaxShards) ProtoMessage()    {}
 func (*MaxShards) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{6}
+	return fileDescriptor_d2a91b51c7bdc125, []int{7}
 }
 func (m *MaxShards) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -532,7 +779,7 @@ func (m *CreateShardMessage) Reset()         { *m = CreateShardMessage{} }
 func (m *CreateShardMessage) String() string { return proto.CompactTextString(m) }
 func (*CreateShardMessage) ProtoMessage()    {}
 func (*CreateShardMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{7}
+	return fileDescriptor_d2a91b51c7bdc125, []int{8}
 }
 func (m *CreateShardMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -593,7 +840,7 @@ func (m *DeleteIndexMessage) Reset()         { *m = DeleteIndexMessage{} }
 func (m *DeleteIndexMessage) String() string { return proto.CompactTextString(m) }
 func (*DeleteIndexMessage) ProtoMessage()    {}
 func (*DeleteIndexMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{8}
+	return fileDescriptor_d2a91b51c7bdc125, []int{9}
 }
 func (m *DeleteIndexMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -642,7 +889,7 @@ func (m *CreateIndexMessage) Reset()         { *m = CreateIndexMessage{} }
 func (m *CreateIndexMessage) String() string { return proto.CompactTextString(m) }
 func (*CreateIndexMessage) ProtoMessage()    {}
 func (*CreateIndexMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{9}
+	return fileDescriptor_d2a91b51c7bdc125, []int{10}
 }
 func (m *CreateIndexMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -706,7 +953,7 @@ func (m *CreateFieldMessage) Reset()         { *m = CreateFieldMessage{} }
 func (m *CreateFieldMessage) String() string { return proto.CompactTextString(m) }
 func (*CreateFieldMessage) ProtoMessage()    {}
 func (*CreateFieldMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{10}
+	return fileDescriptor_d2a91b51c7bdc125, []int{11}
 }
 func (m *CreateFieldMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -775,7 +1022,7 @@ func (m *UpdateFieldMessage) Reset()         { *m = UpdateFieldMessage{} }
 func (m *UpdateFieldMessage) String() string { return proto.CompactTextString(m) }
 func (*UpdateFieldMessage) ProtoMessage()    {}
 func (*UpdateFieldMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{11}
+	return fileDescriptor_d2a91b51c7bdc125, []int{12}
 }
 func (m *UpdateFieldMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -830,7 +1077,7 @@ func (m *FieldUpdate) Reset()         { *m = FieldUpdate{} }
 func (m *FieldUpdate) String() string { return proto.CompactTextString(m) }
 func (*FieldUpdate) ProtoMessage()    {}
 func (*FieldUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{12}
+	return fileDescriptor_d2a91b51c7bdc125, []int{13}
 }
 func (m *FieldUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -885,7 +1132,7 @@ func (m *DeleteFieldMessage) Reset()         { *m = DeleteFieldMessage{} }
 func (m *DeleteFieldMessage) String() string { return proto.CompactTextString(m) }
 func (*DeleteFieldMessage) ProtoMessage()    {}
 func (*DeleteFieldMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{13}
+	return fileDescriptor_d2a91b51c7bdc125, []int{14}
 }
 func (m *DeleteFieldMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -941,7 +1188,7 @@ func (m *DeleteAvailableShardMessage) Reset()         { *m = DeleteAvailableShar
 func (m *DeleteAvailableShardMessage) String() string { return proto.CompactTextString(m) }
 func (*DeleteAvailableShardMessage) ProtoMessage()    {}
 func (*DeleteAvailableShardMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{14}
+	return fileDescriptor_d2a91b51c7bdc125, []int{15}
 }
 func (m *DeleteAvailableShardMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -991,28 +1238,28 @@ func (m *DeleteAvailableShardMessage) GetShardID() uint64 {
 	return 0
 }
 
-type Field struct {
-	Name                 string        `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	Meta                 *FieldOptions `protobuf:"bytes,2,opt,name=Meta,proto3" json:"Meta,omitempty"`
-	Views                []string      `protobuf:"bytes,3,rep,name=Views,proto3" json:"Views,omitempty"`
-	CreatedAt            int64         `protobuf:"varint,4,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+type SetRowMetaMessage struct {
+	Index                string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Field                string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
+	Row                  string   `protobuf:"bytes,3,opt,name=Row,proto3" json:"Row,omitempty"`
+	Meta                 []byte   `protobuf:"bytes,4,opt,name=Meta,proto3" json:"Meta,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Field) Reset()         { *m = Field{} }
-func (m *Field) String() string { return proto.CompactTextString(m) }
-func (*Field) ProtoMessage()    {}
-func (*Field) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{15}
+func (m *SetRowMetaMessage) Reset()         { *m = SetRowMetaMessage{} }
+func (m *SetRowMetaMessage) String() string { return proto.CompactTextString(m) }
+func (*SetRowMetaMessage) ProtoMessage()    {}
+func (*SetRowMetaMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{16}
 }
-func (m *Field) XXX_Unmarshal(b []byte) error {
+func (m *SetRowMetaMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Field) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetRowMetaMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Field.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetRowMetaMessage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1022,65 +1269,66 @@ func (m *Field) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Field) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Field.Merge(m, src)
+func (m *SetRowMetaMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRowMetaMessage.Merge(m, src)
 }
-func (m *Field) XXX_Size() int {
+func (m *SetRowMetaMessage) XXX_Size() int {
 	return m.Size()
 }
-func (m *Field) XXX_DiscardUnknown() {
-	xxx_messageInfo_Field.DiscardUnknown(m)
+func (m *SetRowMetaMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRowMetaMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Field proto.InternalMessageInfo
+var xxx_messageInfo_SetRowMetaMessage proto.InternalMessageInfo
 
-func (m *Field) GetName() string {
+func (m *SetRowMetaMessage) GetIndex() string {
 	if m != nil {
-		return m.Name
+		return m.Index
 	}
 	return ""
 }
 
-func (m *Field) GetMeta() *FieldOptions {
+func (m *SetRowMetaMessage) GetField() string {
 	if m != nil {
-		return m.Meta
+		return m.Field
 	}
-	return nil
+	return ""
 }
 
-func (m *Field) GetViews() []string {
+func (m *SetRowMetaMessage) GetRow() string {
 	if m != nil {
-		return m.Views
+		return m.Row
 	}
-	return nil
+	return ""
 }
 
-func (m *Field) GetCreatedAt() int64 {
+func (m *SetRowMetaMessage) GetMeta() []byte {
 	if m != nil {
-		return m.CreatedAt
+		return m.Meta
 	}
-	return 0
+	return nil
 }
 
-type Schema struct {
-	Indexes              []*Index `protobuf:"bytes,1,rep,name=Indexes,proto3" json:"Indexes,omitempty"`
+type SetAliasMessage struct {
+	Alias                string   `protobuf:"bytes,1,opt,name=Alias,proto3" json:"Alias,omitempty"`
+	Index                string   `protobuf:"bytes,2,opt,name=Index,proto3" json:"Index,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Schema) Reset()         { *m = Schema{} }
-func (m *Schema) String() string { return proto.CompactTextString(m) }
-func (*Schema) ProtoMessage()    {}
-func (*Schema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{16}
+func (m *SetAliasMessage) Reset()         { *m = SetAliasMessage{} }
+func (m *SetAliasMessage) String() string { return proto.CompactTextString(m) }
+func (*SetAliasMessage) ProtoMessage()    {}
+func (*SetAliasMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{17}
 }
-func (m *Schema) XXX_Unmarshal(b []byte) error {
+func (m *SetAliasMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Schema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetAliasMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Schema.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetAliasMessage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1090,117 +1338,52 @@ func (m *Schema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Schema) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Schema.Merge(m, src)
+func (m *SetAliasMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAliasMessage.Merge(m, src)
 }
-func (m *Schema) XXX_Size() int {
+func (m *SetAliasMessage) XXX_Size() int {
 	return m.Size()
 }
-func (m *Schema) XXX_DiscardUnknown() {
-	xxx_messageInfo_Schema.DiscardUnknown(m)
+func (m *SetAliasMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAliasMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Schema proto.InternalMessageInfo
-
-func (m *Schema) GetIndexes() []*Index {
-	if m != nil {
-		return m.Indexes
-	}
-	return nil
-}
-
-type Index struct {
-	Name                 string     `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	CreatedAt            int64      `protobuf:"varint,2,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
-	Options              *IndexMeta `protobuf:"bytes,5,opt,name=Options,proto3" json:"Options,omitempty"`
-	Fields               []*Field   `protobuf:"bytes,4,rep,name=Fields,proto3" json:"Fields,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
-}
-
-func (m *Index) Reset()         { *m = Index{} }
-func (m *Index) String() string { return proto.CompactTextString(m) }
-func (*Index) ProtoMessage()    {}
-func (*Index) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{17}
-}
-func (m *Index) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *Index) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_Index.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
-}
-func (m *Index) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Index.Merge(m, src)
-}
-func (m *Index) XXX_Size() int {
-	return m.Size()
-}
-func (m *Index) XXX_DiscardUnknown() {
-	xxx_messageInfo_Index.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_Index proto.InternalMessageInfo
+var xxx_messageInfo_SetAliasMessage proto.InternalMessageInfo
 
-func (m *Index) GetName() string {
+func (m *SetAliasMessage) GetAlias() string {
 	if m != nil {
-		return m.Name
+		return m.Alias
 	}
 	return ""
 }
 
-func (m *Index) GetCreatedAt() int64 {
-	if m != nil {
-		return m.CreatedAt
-	}
-	return 0
-}
-
-func (m *Index) GetOptions() *IndexMeta {
-	if m != nil {
-		return m.Options
-	}
-	return nil
-}
-
-func (m *Index) GetFields() []*Field {
+func (m *SetAliasMessage) GetIndex() string {
 	if m != nil {
-		return m.Fields
+		return m.Index
 	}
-	return nil
+	return ""
 }
 
-type URI struct {
-	Scheme               string   `protobuf:"bytes,1,opt,name=Scheme,proto3" json:"Scheme,omitempty"`
-	Host                 string   `protobuf:"bytes,2,opt,name=Host,proto3" json:"Host,omitempty"`
-	Port                 uint32   `protobuf:"varint,3,opt,name=Port,proto3" json:"Port,omitempty"`
+type CloneIndexMessage struct {
+	Source               string   `protobuf:"bytes,1,opt,name=Source,proto3" json:"Source,omitempty"`
+	Target               string   `protobuf:"bytes,2,opt,name=Target,proto3" json:"Target,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *URI) Reset()         { *m = URI{} }
-func (m *URI) String() string { return proto.CompactTextString(m) }
-func (*URI) ProtoMessage()    {}
-func (*URI) Descriptor() ([]byte, []int) {
+func (m *CloneIndexMessage) Reset()         { *m = CloneIndexMessage{} }
+func (m *CloneIndexMessage) String() string { return proto.CompactTextString(m) }
+func (*CloneIndexMessage) ProtoMessage()    {}
+func (*CloneIndexMessage) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{18}
 }
-func (m *URI) XXX_Unmarshal(b []byte) error {
+func (m *CloneIndexMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *URI) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CloneIndexMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_URI.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CloneIndexMessage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1210,62 +1393,52 @@ func (m *URI) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *URI) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_URI.Merge(m, src)
+func (m *CloneIndexMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloneIndexMessage.Merge(m, src)
 }
-func (m *URI) XXX_Size() int {
+func (m *CloneIndexMessage) XXX_Size() int {
 	return m.Size()
 }
-func (m *URI) XXX_DiscardUnknown() {
-	xxx_messageInfo_URI.DiscardUnknown(m)
+func (m *CloneIndexMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloneIndexMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_URI proto.InternalMessageInfo
+var xxx_messageInfo_CloneIndexMessage proto.InternalMessageInfo
 
-func (m *URI) GetScheme() string {
+func (m *CloneIndexMessage) GetSource() string {
 	if m != nil {
-		return m.Scheme
+		return m.Source
 	}
 	return ""
 }
 
-func (m *URI) GetHost() string {
+func (m *CloneIndexMessage) GetTarget() string {
 	if m != nil {
-		return m.Host
+		return m.Target
 	}
 	return ""
 }
 
-func (m *URI) GetPort() uint32 {
-	if m != nil {
-		return m.Port
-	}
-	return 0
-}
-
-type Node struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	URI                  *URI     `protobuf:"bytes,2,opt,name=URI,proto3" json:"URI,omitempty"`
-	IsPrimary            bool     `protobuf:"varint,3,opt,name=IsPrimary,proto3" json:"IsPrimary,omitempty"`
-	State                string   `protobuf:"bytes,4,opt,name=State,proto3" json:"State,omitempty"`
-	GRPCURI              *URI     `protobuf:"bytes,5,opt,name=GRPCURI,proto3" json:"GRPCURI,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type UpdateIndexMessage struct {
+	CreateIndexMessage   *CreateIndexMessage `protobuf:"bytes,1,opt,name=CreateIndexMessage,proto3" json:"CreateIndexMessage,omitempty"`
+	Update               *IndexUpdate        `protobuf:"bytes,2,opt,name=Update,proto3" json:"Update,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
-func (m *Node) Reset()         { *m = Node{} }
-func (m *Node) String() string { return proto.CompactTextString(m) }
-func (*Node) ProtoMessage()    {}
-func (*Node) Descriptor() ([]byte, []int) {
+func (m *UpdateIndexMessage) Reset()         { *m = UpdateIndexMessage{} }
+func (m *UpdateIndexMessage) String() string { return proto.CompactTextString(m) }
+func (*UpdateIndexMessage) ProtoMessage()    {}
+func (*UpdateIndexMessage) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{19}
 }
-func (m *Node) XXX_Unmarshal(b []byte) error {
+func (m *UpdateIndexMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateIndexMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Node.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateIndexMessage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1275,73 +1448,52 @@ func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Node) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Node.Merge(m, src)
+func (m *UpdateIndexMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateIndexMessage.Merge(m, src)
 }
-func (m *Node) XXX_Size() int {
+func (m *UpdateIndexMessage) XXX_Size() int {
 	return m.Size()
 }
-func (m *Node) XXX_DiscardUnknown() {
-	xxx_messageInfo_Node.DiscardUnknown(m)
+func (m *UpdateIndexMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateIndexMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Node proto.InternalMessageInfo
-
-func (m *Node) GetID() string {
-	if m != nil {
-		return m.ID
-	}
-	return ""
-}
+var xxx_messageInfo_UpdateIndexMessage proto.InternalMessageInfo
 
-func (m *Node) GetURI() *URI {
+func (m *UpdateIndexMessage) GetCreateIndexMessage() *CreateIndexMessage {
 	if m != nil {
-		return m.URI
+		return m.CreateIndexMessage
 	}
 	return nil
 }
 
-func (m *Node) GetIsPrimary() bool {
-	if m != nil {
-		return m.IsPrimary
-	}
-	return false
-}
-
-func (m *Node) GetState() string {
-	if m != nil {
-		return m.State
-	}
-	return ""
-}
-
-func (m *Node) GetGRPCURI() *URI {
+func (m *UpdateIndexMessage) GetUpdate() *IndexUpdate {
 	if m != nil {
-		return m.GRPCURI
+		return m.Update
 	}
 	return nil
 }
 
-type NodeStateMessage struct {
-	NodeID               string   `protobuf:"bytes,1,opt,name=NodeID,proto3" json:"NodeID,omitempty"`
-	State                string   `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
+type IndexUpdate struct {
+	Option               string   `protobuf:"bytes,1,opt,name=Option,proto3" json:"Option,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NodeStateMessage) Reset()         { *m = NodeStateMessage{} }
-func (m *NodeStateMessage) String() string { return proto.CompactTextString(m) }
-func (*NodeStateMessage) ProtoMessage()    {}
-func (*NodeStateMessage) Descriptor() ([]byte, []int) {
+func (m *IndexUpdate) Reset()         { *m = IndexUpdate{} }
+func (m *IndexUpdate) String() string { return proto.CompactTextString(m) }
+func (*IndexUpdate) ProtoMessage()    {}
+func (*IndexUpdate) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{20}
 }
-func (m *NodeStateMessage) XXX_Unmarshal(b []byte) error {
+func (m *IndexUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *NodeStateMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *IndexUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_NodeStateMessage.Marshal(b, m, deterministic)
+		return xxx_messageInfo_IndexUpdate.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1351,52 +1503,51 @@ func (m *NodeStateMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *NodeStateMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NodeStateMessage.Merge(m, src)
+func (m *IndexUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexUpdate.Merge(m, src)
 }
-func (m *NodeStateMessage) XXX_Size() int {
+func (m *IndexUpdate) XXX_Size() int {
 	return m.Size()
 }
-func (m *NodeStateMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_NodeStateMessage.DiscardUnknown(m)
+func (m *IndexUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexUpdate.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NodeStateMessage proto.InternalMessageInfo
+var xxx_messageInfo_IndexUpdate proto.InternalMessageInfo
 
-func (m *NodeStateMessage) GetNodeID() string {
+func (m *IndexUpdate) GetOption() string {
 	if m != nil {
-		return m.NodeID
+		return m.Option
 	}
 	return ""
 }
 
-func (m *NodeStateMessage) GetState() string {
+func (m *IndexUpdate) GetValue() string {
 	if m != nil {
-		return m.State
+		return m.Value
 	}
 	return ""
 }
 
-type NodeEventMessage struct {
-	Event                uint32   `protobuf:"varint,1,opt,name=Event,proto3" json:"Event,omitempty"`
-	Node                 *Node    `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+type MaintenanceMessage struct {
+	Reason               string   `protobuf:"bytes,1,opt,name=Reason,proto3" json:"Reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NodeEventMessage) Reset()         { *m = NodeEventMessage{} }
-func (m *NodeEventMessage) String() string { return proto.CompactTextString(m) }
-func (*NodeEventMessage) ProtoMessage()    {}
-func (*NodeEventMessage) Descriptor() ([]byte, []int) {
+func (m *MaintenanceMessage) Reset()         { *m = MaintenanceMessage{} }
+func (m *MaintenanceMessage) String() string { return proto.CompactTextString(m) }
+func (*MaintenanceMessage) ProtoMessage()    {}
+func (*MaintenanceMessage) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{21}
 }
-func (m *NodeEventMessage) XXX_Unmarshal(b []byte) error {
+func (m *MaintenanceMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *NodeEventMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MaintenanceMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_NodeEventMessage.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MaintenanceMessage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1406,53 +1557,47 @@ func (m *NodeEventMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *NodeEventMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NodeEventMessage.Merge(m, src)
+func (m *MaintenanceMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaintenanceMessage.Merge(m, src)
 }
-func (m *NodeEventMessage) XXX_Size() int {
+func (m *MaintenanceMessage) XXX_Size() int {
 	return m.Size()
 }
-func (m *NodeEventMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_NodeEventMessage.DiscardUnknown(m)
+func (m *MaintenanceMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaintenanceMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NodeEventMessage proto.InternalMessageInfo
-
-func (m *NodeEventMessage) GetEvent() uint32 {
-	if m != nil {
-		return m.Event
-	}
-	return 0
-}
+var xxx_messageInfo_MaintenanceMessage proto.InternalMessageInfo
 
-func (m *NodeEventMessage) GetNode() *Node {
+func (m *MaintenanceMessage) GetReason() string {
 	if m != nil {
-		return m.Node
+		return m.Reason
 	}
-	return nil
+	return ""
 }
 
-type NodeStatus struct {
-	Node                 *Node          `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
-	Schema               *Schema        `protobuf:"bytes,3,opt,name=Schema,proto3" json:"Schema,omitempty"`
-	Indexes              []*IndexStatus `protobuf:"bytes,4,rep,name=Indexes,proto3" json:"Indexes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+type Field struct {
+	Name                 string        `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Meta                 *FieldOptions `protobuf:"bytes,2,opt,name=Meta,proto3" json:"Meta,omitempty"`
+	Views                []string      `protobuf:"bytes,3,rep,name=Views,proto3" json:"Views,omitempty"`
+	CreatedAt            int64         `protobuf:"varint,4,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *NodeStatus) Reset()         { *m = NodeStatus{} }
-func (m *NodeStatus) String() string { return proto.CompactTextString(m) }
-func (*NodeStatus) ProtoMessage()    {}
-func (*NodeStatus) Descriptor() ([]byte, []int) {
+func (m *Field) Reset()         { *m = Field{} }
+func (m *Field) String() string { return proto.CompactTextString(m) }
+func (*Field) ProtoMessage()    {}
+func (*Field) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{22}
 }
-func (m *NodeStatus) XXX_Unmarshal(b []byte) error {
+func (m *Field) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *NodeStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Field) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_NodeStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Field.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1462,60 +1607,65 @@ func (m *NodeStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *NodeStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NodeStatus.Merge(m, src)
+func (m *Field) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Field.Merge(m, src)
 }
-func (m *NodeStatus) XXX_Size() int {
+func (m *Field) XXX_Size() int {
 	return m.Size()
 }
-func (m *NodeStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_NodeStatus.DiscardUnknown(m)
+func (m *Field) XXX_DiscardUnknown() {
+	xxx_messageInfo_Field.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NodeStatus proto.InternalMessageInfo
+var xxx_messageInfo_Field proto.InternalMessageInfo
 
-func (m *NodeStatus) GetNode() *Node {
+func (m *Field) GetName() string {
 	if m != nil {
-		return m.Node
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-func (m *NodeStatus) GetSchema() *Schema {
+func (m *Field) GetMeta() *FieldOptions {
 	if m != nil {
-		return m.Schema
+		return m.Meta
 	}
 	return nil
 }
 
-func (m *NodeStatus) GetIndexes() []*IndexStatus {
+func (m *Field) GetViews() []string {
 	if m != nil {
-		return m.Indexes
+		return m.Views
 	}
 	return nil
 }
 
-type IndexStatus struct {
-	Name                 string         `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	Fields               []*FieldStatus `protobuf:"bytes,2,rep,name=Fields,proto3" json:"Fields,omitempty"`
-	CreatedAt            int64          `protobuf:"varint,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+func (m *Field) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
 }
 
-func (m *IndexStatus) Reset()         { *m = IndexStatus{} }
-func (m *IndexStatus) String() string { return proto.CompactTextString(m) }
-func (*IndexStatus) ProtoMessage()    {}
-func (*IndexStatus) Descriptor() ([]byte, []int) {
+type Schema struct {
+	Indexes              []*Index `protobuf:"bytes,1,rep,name=Indexes,proto3" json:"Indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Schema) Reset()         { *m = Schema{} }
+func (m *Schema) String() string { return proto.CompactTextString(m) }
+func (*Schema) ProtoMessage()    {}
+func (*Schema) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{23}
 }
-func (m *IndexStatus) XXX_Unmarshal(b []byte) error {
+func (m *Schema) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *IndexStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Schema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_IndexStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Schema.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1525,60 +1675,47 @@ func (m *IndexStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *IndexStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_IndexStatus.Merge(m, src)
+func (m *Schema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schema.Merge(m, src)
 }
-func (m *IndexStatus) XXX_Size() int {
+func (m *Schema) XXX_Size() int {
 	return m.Size()
 }
-func (m *IndexStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_IndexStatus.DiscardUnknown(m)
+func (m *Schema) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schema.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_IndexStatus proto.InternalMessageInfo
-
-func (m *IndexStatus) GetName() string {
-	if m != nil {
-		return m.Name
-	}
-	return ""
-}
+var xxx_messageInfo_Schema proto.InternalMessageInfo
 
-func (m *IndexStatus) GetFields() []*FieldStatus {
+func (m *Schema) GetIndexes() []*Index {
 	if m != nil {
-		return m.Fields
+		return m.Indexes
 	}
 	return nil
 }
 
-func (m *IndexStatus) GetCreatedAt() int64 {
-	if m != nil {
-		return m.CreatedAt
-	}
-	return 0
-}
-
-type FieldStatus struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	AvailableShards      []uint64 `protobuf:"varint,2,rep,packed,name=AvailableShards,proto3" json:"AvailableShards,omitempty"`
-	CreatedAt            int64    `protobuf:"varint,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type Index struct {
+	Name                 string     `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	CreatedAt            int64      `protobuf:"varint,2,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	Options              *IndexMeta `protobuf:"bytes,5,opt,name=Options,proto3" json:"Options,omitempty"`
+	Fields               []*Field   `protobuf:"bytes,4,rep,name=Fields,proto3" json:"Fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *FieldStatus) Reset()         { *m = FieldStatus{} }
-func (m *FieldStatus) String() string { return proto.CompactTextString(m) }
-func (*FieldStatus) ProtoMessage()    {}
-func (*FieldStatus) Descriptor() ([]byte, []int) {
+func (m *Index) Reset()         { *m = Index{} }
+func (m *Index) String() string { return proto.CompactTextString(m) }
+func (*Index) ProtoMessage()    {}
+func (*Index) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{24}
 }
-func (m *FieldStatus) XXX_Unmarshal(b []byte) error {
+func (m *Index) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FieldStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Index) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FieldStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Index.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1588,61 +1725,67 @@ func (m *FieldStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *FieldStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FieldStatus.Merge(m, src)
+func (m *Index) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Index.Merge(m, src)
 }
-func (m *FieldStatus) XXX_Size() int {
+func (m *Index) XXX_Size() int {
 	return m.Size()
 }
-func (m *FieldStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_FieldStatus.DiscardUnknown(m)
+func (m *Index) XXX_DiscardUnknown() {
+	xxx_messageInfo_Index.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FieldStatus proto.InternalMessageInfo
+var xxx_messageInfo_Index proto.InternalMessageInfo
 
-func (m *FieldStatus) GetName() string {
+func (m *Index) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-func (m *FieldStatus) GetAvailableShards() []uint64 {
+func (m *Index) GetCreatedAt() int64 {
 	if m != nil {
-		return m.AvailableShards
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Index) GetOptions() *IndexMeta {
+	if m != nil {
+		return m.Options
 	}
 	return nil
 }
 
-func (m *FieldStatus) GetCreatedAt() int64 {
+func (m *Index) GetFields() []*Field {
 	if m != nil {
-		return m.CreatedAt
+		return m.Fields
 	}
-	return 0
+	return nil
 }
 
-type ClusterStatus struct {
-	ClusterID            string   `protobuf:"bytes,1,opt,name=ClusterID,proto3" json:"ClusterID,omitempty"`
-	State                string   `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
-	Nodes                []*Node  `protobuf:"bytes,3,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
-	Schema               *Schema  `protobuf:"bytes,4,opt,name=Schema,proto3" json:"Schema,omitempty"`
+type URI struct {
+	Scheme               string   `protobuf:"bytes,1,opt,name=Scheme,proto3" json:"Scheme,omitempty"`
+	Host                 string   `protobuf:"bytes,2,opt,name=Host,proto3" json:"Host,omitempty"`
+	Port                 uint32   `protobuf:"varint,3,opt,name=Port,proto3" json:"Port,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ClusterStatus) Reset()         { *m = ClusterStatus{} }
-func (m *ClusterStatus) String() string { return proto.CompactTextString(m) }
-func (*ClusterStatus) ProtoMessage()    {}
-func (*ClusterStatus) Descriptor() ([]byte, []int) {
+func (m *URI) Reset()         { *m = URI{} }
+func (m *URI) String() string { return proto.CompactTextString(m) }
+func (*URI) ProtoMessage()    {}
+func (*URI) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{25}
 }
-func (m *ClusterStatus) XXX_Unmarshal(b []byte) error {
+func (m *URI) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ClusterStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *URI) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ClusterStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_URI.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1652,68 +1795,62 @@ func (m *ClusterStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *ClusterStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ClusterStatus.Merge(m, src)
+func (m *URI) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_URI.Merge(m, src)
 }
-func (m *ClusterStatus) XXX_Size() int {
+func (m *URI) XXX_Size() int {
 	return m.Size()
 }
-func (m *ClusterStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_ClusterStatus.DiscardUnknown(m)
+func (m *URI) XXX_DiscardUnknown() {
+	xxx_messageInfo_URI.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ClusterStatus proto.InternalMessageInfo
+var xxx_messageInfo_URI proto.InternalMessageInfo
 
-func (m *ClusterStatus) GetClusterID() string {
+func (m *URI) GetScheme() string {
 	if m != nil {
-		return m.ClusterID
+		return m.Scheme
 	}
 	return ""
 }
 
-func (m *ClusterStatus) GetState() string {
+func (m *URI) GetHost() string {
 	if m != nil {
-		return m.State
+		return m.Host
 	}
 	return ""
 }
 
-func (m *ClusterStatus) GetNodes() []*Node {
-	if m != nil {
-		return m.Nodes
-	}
-	return nil
-}
-
-func (m *ClusterStatus) GetSchema() *Schema {
+func (m *URI) GetPort() uint32 {
 	if m != nil {
-		return m.Schema
+		return m.Port
 	}
-	return nil
+	return 0
 }
 
-type BSIGroup struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	Type                 string   `protobuf:"bytes,2,opt,name=Type,proto3" json:"Type,omitempty"`
-	Min                  int64    `protobuf:"varint,3,opt,name=Min,proto3" json:"Min,omitempty"`
-	Max                  int64    `protobuf:"varint,4,opt,name=Max,proto3" json:"Max,omitempty"`
+type Node struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	URI                  *URI     `protobuf:"bytes,2,opt,name=URI,proto3" json:"URI,omitempty"`
+	IsPrimary            bool     `protobuf:"varint,3,opt,name=IsPrimary,proto3" json:"IsPrimary,omitempty"`
+	State                string   `protobuf:"bytes,4,opt,name=State,proto3" json:"State,omitempty"`
+	GRPCURI              *URI     `protobuf:"bytes,5,opt,name=GRPCURI,proto3" json:"GRPCURI,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *BSIGroup) Reset()         { *m = BSIGroup{} }
-func (m *BSIGroup) String() string { return proto.CompactTextString(m) }
-func (*BSIGroup) ProtoMessage()    {}
-func (*BSIGroup) Descriptor() ([]byte, []int) {
+func (m *Node) Reset()         { *m = Node{} }
+func (m *Node) String() string { return proto.CompactTextString(m) }
+func (*Node) ProtoMessage()    {}
+func (*Node) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{26}
 }
-func (m *BSIGroup) XXX_Unmarshal(b []byte) error {
+func (m *Node) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *BSIGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_BSIGroup.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Node.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1723,67 +1860,73 @@ func (m *BSIGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *BSIGroup) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BSIGroup.Merge(m, src)
+func (m *Node) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node.Merge(m, src)
 }
-func (m *BSIGroup) XXX_Size() int {
+func (m *Node) XXX_Size() int {
 	return m.Size()
 }
-func (m *BSIGroup) XXX_DiscardUnknown() {
-	xxx_messageInfo_BSIGroup.DiscardUnknown(m)
+func (m *Node) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BSIGroup proto.InternalMessageInfo
+var xxx_messageInfo_Node proto.InternalMessageInfo
 
-func (m *BSIGroup) GetName() string {
+func (m *Node) GetID() string {
 	if m != nil {
-		return m.Name
+		return m.ID
 	}
 	return ""
 }
 
-func (m *BSIGroup) GetType() string {
+func (m *Node) GetURI() *URI {
 	if m != nil {
-		return m.Type
+		return m.URI
 	}
-	return ""
+	return nil
 }
 
-func (m *BSIGroup) GetMin() int64 {
+func (m *Node) GetIsPrimary() bool {
 	if m != nil {
-		return m.Min
+		return m.IsPrimary
 	}
-	return 0
+	return false
 }
 
-func (m *BSIGroup) GetMax() int64 {
+func (m *Node) GetState() string {
 	if m != nil {
-		return m.Max
+		return m.State
 	}
-	return 0
+	return ""
 }
 
-type CreateViewMessage struct {
-	Index                string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
-	Field                string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
-	View                 string   `protobuf:"bytes,3,opt,name=View,proto3" json:"View,omitempty"`
+func (m *Node) GetGRPCURI() *URI {
+	if m != nil {
+		return m.GRPCURI
+	}
+	return nil
+}
+
+type NodeStateMessage struct {
+	NodeID               string   `protobuf:"bytes,1,opt,name=NodeID,proto3" json:"NodeID,omitempty"`
+	State                string   `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateViewMessage) Reset()         { *m = CreateViewMessage{} }
-func (m *CreateViewMessage) String() string { return proto.CompactTextString(m) }
-func (*CreateViewMessage) ProtoMessage()    {}
-func (*CreateViewMessage) Descriptor() ([]byte, []int) {
+func (m *NodeStateMessage) Reset()         { *m = NodeStateMessage{} }
+func (m *NodeStateMessage) String() string { return proto.CompactTextString(m) }
+func (*NodeStateMessage) ProtoMessage()    {}
+func (*NodeStateMessage) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{27}
 }
-func (m *CreateViewMessage) XXX_Unmarshal(b []byte) error {
+func (m *NodeStateMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CreateViewMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *NodeStateMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CreateViewMessage.Marshal(b, m, deterministic)
+		return xxx_messageInfo_NodeStateMessage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1793,60 +1936,52 @@ func (m *CreateViewMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *CreateViewMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateViewMessage.Merge(m, src)
+func (m *NodeStateMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeStateMessage.Merge(m, src)
 }
-func (m *CreateViewMessage) XXX_Size() int {
+func (m *NodeStateMessage) XXX_Size() int {
 	return m.Size()
 }
-func (m *CreateViewMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateViewMessage.DiscardUnknown(m)
+func (m *NodeStateMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeStateMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateViewMessage proto.InternalMessageInfo
-
-func (m *CreateViewMessage) GetIndex() string {
-	if m != nil {
-		return m.Index
-	}
-	return ""
-}
+var xxx_messageInfo_NodeStateMessage proto.InternalMessageInfo
 
-func (m *CreateViewMessage) GetField() string {
+func (m *NodeStateMessage) GetNodeID() string {
 	if m != nil {
-		return m.Field
+		return m.NodeID
 	}
 	return ""
 }
 
-func (m *CreateViewMessage) GetView() string {
+func (m *NodeStateMessage) GetState() string {
 	if m != nil {
-		return m.View
+		return m.State
 	}
 	return ""
 }
 
-type DeleteViewMessage struct {
-	Index                string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
-	Field                string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
-	View                 string   `protobuf:"bytes,3,opt,name=View,proto3" json:"View,omitempty"`
+type NodeEventMessage struct {
+	Event                uint32   `protobuf:"varint,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	Node                 *Node    `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteViewMessage) Reset()         { *m = DeleteViewMessage{} }
-func (m *DeleteViewMessage) String() string { return proto.CompactTextString(m) }
-func (*DeleteViewMessage) ProtoMessage()    {}
-func (*DeleteViewMessage) Descriptor() ([]byte, []int) {
+func (m *NodeEventMessage) Reset()         { *m = NodeEventMessage{} }
+func (m *NodeEventMessage) String() string { return proto.CompactTextString(m) }
+func (*NodeEventMessage) ProtoMessage()    {}
+func (*NodeEventMessage) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{28}
 }
-func (m *DeleteViewMessage) XXX_Unmarshal(b []byte) error {
+func (m *NodeEventMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteViewMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *NodeEventMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteViewMessage.Marshal(b, m, deterministic)
+		return xxx_messageInfo_NodeEventMessage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1856,64 +1991,53 @@ func (m *DeleteViewMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *DeleteViewMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteViewMessage.Merge(m, src)
+func (m *NodeEventMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeEventMessage.Merge(m, src)
 }
-func (m *DeleteViewMessage) XXX_Size() int {
+func (m *NodeEventMessage) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteViewMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteViewMessage.DiscardUnknown(m)
+func (m *NodeEventMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeEventMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteViewMessage proto.InternalMessageInfo
-
-func (m *DeleteViewMessage) GetIndex() string {
-	if m != nil {
-		return m.Index
-	}
-	return ""
-}
+var xxx_messageInfo_NodeEventMessage proto.InternalMessageInfo
 
-func (m *DeleteViewMessage) GetField() string {
+func (m *NodeEventMessage) GetEvent() uint32 {
 	if m != nil {
-		return m.Field
+		return m.Event
 	}
-	return ""
+	return 0
 }
 
-func (m *DeleteViewMessage) GetView() string {
+func (m *NodeEventMessage) GetNode() *Node {
 	if m != nil {
-		return m.View
+		return m.Node
 	}
-	return ""
+	return nil
 }
 
-type ResizeInstruction struct {
-	JobID                int64                      `protobuf:"varint,1,opt,name=JobID,proto3" json:"JobID,omitempty"`
-	Node                 *Node                      `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
-	Primary              *Node                      `protobuf:"bytes,3,opt,name=Primary,proto3" json:"Primary,omitempty"`
-	Sources              []*ResizeSource            `protobuf:"bytes,4,rep,name=Sources,proto3" json:"Sources,omitempty"`
-	TranslationSources   []*TranslationResizeSource `protobuf:"bytes,8,rep,name=TranslationSources,proto3" json:"TranslationSources,omitempty"`
-	NodeStatus           *NodeStatus                `protobuf:"bytes,7,opt,name=NodeStatus,proto3" json:"NodeStatus,omitempty"`
-	ClusterStatus        *ClusterStatus             `protobuf:"bytes,6,opt,name=ClusterStatus,proto3" json:"ClusterStatus,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+type NodeStatus struct {
+	Node                 *Node          `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
+	Schema               *Schema        `protobuf:"bytes,3,opt,name=Schema,proto3" json:"Schema,omitempty"`
+	Indexes              []*IndexStatus `protobuf:"bytes,4,rep,name=Indexes,proto3" json:"Indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *ResizeInstruction) Reset()         { *m = ResizeInstruction{} }
-func (m *ResizeInstruction) String() string { return proto.CompactTextString(m) }
-func (*ResizeInstruction) ProtoMessage()    {}
-func (*ResizeInstruction) Descriptor() ([]byte, []int) {
+func (m *NodeStatus) Reset()         { *m = NodeStatus{} }
+func (m *NodeStatus) String() string { return proto.CompactTextString(m) }
+func (*NodeStatus) ProtoMessage()    {}
+func (*NodeStatus) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{29}
 }
-func (m *ResizeInstruction) XXX_Unmarshal(b []byte) error {
+func (m *NodeStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ResizeInstruction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *NodeStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ResizeInstruction.Marshal(b, m, deterministic)
+		return xxx_messageInfo_NodeStatus.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1923,90 +2047,123 @@ func (m *ResizeInstruction) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *ResizeInstruction) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResizeInstruction.Merge(m, src)
+func (m *NodeStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeStatus.Merge(m, src)
 }
-func (m *ResizeInstruction) XXX_Size() int {
+func (m *NodeStatus) XXX_Size() int {
 	return m.Size()
 }
-func (m *ResizeInstruction) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResizeInstruction.DiscardUnknown(m)
+func (m *NodeStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResizeInstruction proto.InternalMessageInfo
+var xxx_messageInfo_NodeStatus proto.InternalMessageInfo
 
-func (m *ResizeInstruction) GetJobID() int64 {
+func (m *NodeStatus) GetNode() *Node {
 	if m != nil {
-		return m.JobID
+		return m.Node
 	}
-	return 0
+	return nil
 }
 
-func (m *ResizeInstruction) GetNode() *Node {
+func (m *NodeStatus) GetSchema() *Schema {
 	if m != nil {
-		return m.Node
+		return m.Schema
 	}
 	return nil
 }
 
-func (m *ResizeInstruction) GetPrimary() *Node {
+func (m *NodeStatus) GetIndexes() []*IndexStatus {
 	if m != nil {
-		return m.Primary
+		return m.Indexes
 	}
 	return nil
 }
 
-func (m *ResizeInstruction) GetSources() []*ResizeSource {
-	if m != nil {
-		return m.Sources
+type IndexStatus struct {
+	Name                 string         `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Fields               []*FieldStatus `protobuf:"bytes,2,rep,name=Fields,proto3" json:"Fields,omitempty"`
+	CreatedAt            int64          `protobuf:"varint,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *IndexStatus) Reset()         { *m = IndexStatus{} }
+func (m *IndexStatus) String() string { return proto.CompactTextString(m) }
+func (*IndexStatus) ProtoMessage()    {}
+func (*IndexStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{30}
+}
+func (m *IndexStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IndexStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IndexStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *IndexStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexStatus.Merge(m, src)
+}
+func (m *IndexStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *IndexStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexStatus.DiscardUnknown(m)
 }
 
-func (m *ResizeInstruction) GetTranslationSources() []*TranslationResizeSource {
+var xxx_messageInfo_IndexStatus proto.InternalMessageInfo
+
+func (m *IndexStatus) GetName() string {
 	if m != nil {
-		return m.TranslationSources
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-func (m *ResizeInstruction) GetNodeStatus() *NodeStatus {
+func (m *IndexStatus) GetFields() []*FieldStatus {
 	if m != nil {
-		return m.NodeStatus
+		return m.Fields
 	}
 	return nil
 }
 
-func (m *ResizeInstruction) GetClusterStatus() *ClusterStatus {
+func (m *IndexStatus) GetCreatedAt() int64 {
 	if m != nil {
-		return m.ClusterStatus
+		return m.CreatedAt
 	}
-	return nil
+	return 0
 }
 
-type ResizeSource struct {
-	Node                 *Node    `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
-	Index                string   `protobuf:"bytes,2,opt,name=Index,proto3" json:"Index,omitempty"`
-	Field                string   `protobuf:"bytes,3,opt,name=Field,proto3" json:"Field,omitempty"`
-	View                 string   `protobuf:"bytes,4,opt,name=View,proto3" json:"View,omitempty"`
-	Shard                uint64   `protobuf:"varint,5,opt,name=Shard,proto3" json:"Shard,omitempty"`
+type FieldStatus struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	AvailableShards      []uint64 `protobuf:"varint,2,rep,packed,name=AvailableShards,proto3" json:"AvailableShards,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ResizeSource) Reset()         { *m = ResizeSource{} }
-func (m *ResizeSource) String() string { return proto.CompactTextString(m) }
-func (*ResizeSource) ProtoMessage()    {}
-func (*ResizeSource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{30}
+func (m *FieldStatus) Reset()         { *m = FieldStatus{} }
+func (m *FieldStatus) String() string { return proto.CompactTextString(m) }
+func (*FieldStatus) ProtoMessage()    {}
+func (*FieldStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{31}
 }
-func (m *ResizeSource) XXX_Unmarshal(b []byte) error {
+func (m *FieldStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ResizeSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FieldStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ResizeSource.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FieldStatus.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2016,74 +2173,61 @@ func (m *ResizeSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *ResizeSource) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResizeSource.Merge(m, src)
+func (m *FieldStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldStatus.Merge(m, src)
 }
-func (m *ResizeSource) XXX_Size() int {
+func (m *FieldStatus) XXX_Size() int {
 	return m.Size()
 }
-func (m *ResizeSource) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResizeSource.DiscardUnknown(m)
+func (m *FieldStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResizeSource proto.InternalMessageInfo
+var xxx_messageInfo_FieldStatus proto.InternalMessageInfo
 
-func (m *ResizeSource) GetNode() *Node {
+func (m *FieldStatus) GetName() string {
 	if m != nil {
-		return m.Node
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-func (m *ResizeSource) GetIndex() string {
+func (m *FieldStatus) GetAvailableShards() []uint64 {
 	if m != nil {
-		return m.Index
+		return m.AvailableShards
 	}
-	return ""
-}
-
-func (m *ResizeSource) GetField() string {
-	if m != nil {
-		return m.Field
-	}
-	return ""
-}
-
-func (m *ResizeSource) GetView() string {
-	if m != nil {
-		return m.View
-	}
-	return ""
+	return nil
 }
 
-func (m *ResizeSource) GetShard() uint64 {
+func (m *FieldStatus) GetCreatedAt() int64 {
 	if m != nil {
-		return m.Shard
+		return m.CreatedAt
 	}
 	return 0
 }
 
-type TranslationResizeSource struct {
-	Node                 *Node    `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
-	Index                string   `protobuf:"bytes,2,opt,name=Index,proto3" json:"Index,omitempty"`
-	PartitionID          int32    `protobuf:"varint,3,opt,name=PartitionID,proto3" json:"PartitionID,omitempty"`
+type ClusterStatus struct {
+	ClusterID            string   `protobuf:"bytes,1,opt,name=ClusterID,proto3" json:"ClusterID,omitempty"`
+	State                string   `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
+	Nodes                []*Node  `protobuf:"bytes,3,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
+	Schema               *Schema  `protobuf:"bytes,4,opt,name=Schema,proto3" json:"Schema,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TranslationResizeSource) Reset()         { *m = TranslationResizeSource{} }
-func (m *TranslationResizeSource) String() string { return proto.CompactTextString(m) }
-func (*TranslationResizeSource) ProtoMessage()    {}
-func (*TranslationResizeSource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{31}
+func (m *ClusterStatus) Reset()         { *m = ClusterStatus{} }
+func (m *ClusterStatus) String() string { return proto.CompactTextString(m) }
+func (*ClusterStatus) ProtoMessage()    {}
+func (*ClusterStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{32}
 }
-func (m *TranslationResizeSource) XXX_Unmarshal(b []byte) error {
+func (m *ClusterStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TranslationResizeSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ClusterStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TranslationResizeSource.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ClusterStatus.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2093,60 +2237,68 @@ func (m *TranslationResizeSource) XXX_Marshal(b []byte, deterministic bool) ([]b
 		return b[:n], nil
 	}
 }
-func (m *TranslationResizeSource) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TranslationResizeSource.Merge(m, src)
+func (m *ClusterStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterStatus.Merge(m, src)
 }
-func (m *TranslationResizeSource) XXX_Size() int {
+func (m *ClusterStatus) XXX_Size() int {
 	return m.Size()
 }
-func (m *TranslationResizeSource) XXX_DiscardUnknown() {
-	xxx_messageInfo_TranslationResizeSource.DiscardUnknown(m)
+func (m *ClusterStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TranslationResizeSource proto.InternalMessageInfo
+var xxx_messageInfo_ClusterStatus proto.InternalMessageInfo
 
-func (m *TranslationResizeSource) GetNode() *Node {
+func (m *ClusterStatus) GetClusterID() string {
 	if m != nil {
-		return m.Node
+		return m.ClusterID
 	}
-	return nil
+	return ""
 }
 
-func (m *TranslationResizeSource) GetIndex() string {
+func (m *ClusterStatus) GetState() string {
 	if m != nil {
-		return m.Index
+		return m.State
 	}
 	return ""
 }
 
-func (m *TranslationResizeSource) GetPartitionID() int32 {
+func (m *ClusterStatus) GetNodes() []*Node {
 	if m != nil {
-		return m.PartitionID
+		return m.Nodes
 	}
-	return 0
+	return nil
 }
 
-type ResizeInstructionComplete struct {
-	JobID                int64    `protobuf:"varint,1,opt,name=JobID,proto3" json:"JobID,omitempty"`
-	Node                 *Node    `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
-	Error                string   `protobuf:"bytes,3,opt,name=Error,proto3" json:"Error,omitempty"`
+func (m *ClusterStatus) GetSchema() *Schema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+type BSIGroup struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=Type,proto3" json:"Type,omitempty"`
+	Min                  int64    `protobuf:"varint,3,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max                  int64    `protobuf:"varint,4,opt,name=Max,proto3" json:"Max,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ResizeInstructionComplete) Reset()         { *m = ResizeInstructionComplete{} }
-func (m *ResizeInstructionComplete) String() string { return proto.CompactTextString(m) }
-func (*ResizeInstructionComplete) ProtoMessage()    {}
-func (*ResizeInstructionComplete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{32}
+func (m *BSIGroup) Reset()         { *m = BSIGroup{} }
+func (m *BSIGroup) String() string { return proto.CompactTextString(m) }
+func (*BSIGroup) ProtoMessage()    {}
+func (*BSIGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{33}
 }
-func (m *ResizeInstructionComplete) XXX_Unmarshal(b []byte) error {
+func (m *BSIGroup) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ResizeInstructionComplete) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BSIGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ResizeInstructionComplete.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BSIGroup.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2156,59 +2308,67 @@ func (m *ResizeInstructionComplete) XXX_Marshal(b []byte, deterministic bool) ([
 		return b[:n], nil
 	}
 }
-func (m *ResizeInstructionComplete) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResizeInstructionComplete.Merge(m, src)
+func (m *BSIGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BSIGroup.Merge(m, src)
 }
-func (m *ResizeInstructionComplete) XXX_Size() int {
+func (m *BSIGroup) XXX_Size() int {
 	return m.Size()
 }
-func (m *ResizeInstructionComplete) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResizeInstructionComplete.DiscardUnknown(m)
+func (m *BSIGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_BSIGroup.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResizeInstructionComplete proto.InternalMessageInfo
+var xxx_messageInfo_BSIGroup proto.InternalMessageInfo
 
-func (m *ResizeInstructionComplete) GetJobID() int64 {
+func (m *BSIGroup) GetName() string {
 	if m != nil {
-		return m.JobID
+		return m.Name
 	}
-	return 0
+	return ""
 }
 
-func (m *ResizeInstructionComplete) GetNode() *Node {
+func (m *BSIGroup) GetType() string {
 	if m != nil {
-		return m.Node
+		return m.Type
 	}
-	return nil
+	return ""
 }
 
-func (m *ResizeInstructionComplete) GetError() string {
+func (m *BSIGroup) GetMin() int64 {
 	if m != nil {
-		return m.Error
+		return m.Min
 	}
-	return ""
+	return 0
 }
 
-type Topology struct {
-	ClusterID            string   `protobuf:"bytes,1,opt,name=ClusterID,proto3" json:"ClusterID,omitempty"`
-	NodeIDs              []string `protobuf:"bytes,2,rep,name=NodeIDs,proto3" json:"NodeIDs,omitempty"`
+func (m *BSIGroup) GetMax() int64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type CreateViewMessage struct {
+	Index                string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Field                string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
+	View                 string   `protobuf:"bytes,3,opt,name=View,proto3" json:"View,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Topology) Reset()         { *m = Topology{} }
-func (m *Topology) String() string { return proto.CompactTextString(m) }
-func (*Topology) ProtoMessage()    {}
-func (*Topology) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{33}
+func (m *CreateViewMessage) Reset()         { *m = CreateViewMessage{} }
+func (m *CreateViewMessage) String() string { return proto.CompactTextString(m) }
+func (*CreateViewMessage) ProtoMessage()    {}
+func (*CreateViewMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{34}
 }
-func (m *Topology) XXX_Unmarshal(b []byte) error {
+func (m *CreateViewMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Topology) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CreateViewMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Topology.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CreateViewMessage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2218,50 +2378,60 @@ func (m *Topology) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Topology) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Topology.Merge(m, src)
+func (m *CreateViewMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateViewMessage.Merge(m, src)
 }
-func (m *Topology) XXX_Size() int {
+func (m *CreateViewMessage) XXX_Size() int {
 	return m.Size()
 }
-func (m *Topology) XXX_DiscardUnknown() {
-	xxx_messageInfo_Topology.DiscardUnknown(m)
+func (m *CreateViewMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateViewMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Topology proto.InternalMessageInfo
+var xxx_messageInfo_CreateViewMessage proto.InternalMessageInfo
 
-func (m *Topology) GetClusterID() string {
+func (m *CreateViewMessage) GetIndex() string {
 	if m != nil {
-		return m.ClusterID
+		return m.Index
 	}
 	return ""
 }
 
-func (m *Topology) GetNodeIDs() []string {
+func (m *CreateViewMessage) GetField() string {
 	if m != nil {
-		return m.NodeIDs
+		return m.Field
 	}
-	return nil
+	return ""
 }
 
-type RecalculateCaches struct {
+func (m *CreateViewMessage) GetView() string {
+	if m != nil {
+		return m.View
+	}
+	return ""
+}
+
+type DeleteViewMessage struct {
+	Index                string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Field                string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
+	View                 string   `protobuf:"bytes,3,opt,name=View,proto3" json:"View,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RecalculateCaches) Reset()         { *m = RecalculateCaches{} }
-func (m *RecalculateCaches) String() string { return proto.CompactTextString(m) }
-func (*RecalculateCaches) ProtoMessage()    {}
-func (*RecalculateCaches) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{34}
+func (m *DeleteViewMessage) Reset()         { *m = DeleteViewMessage{} }
+func (m *DeleteViewMessage) String() string { return proto.CompactTextString(m) }
+func (*DeleteViewMessage) ProtoMessage()    {}
+func (*DeleteViewMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{35}
 }
-func (m *RecalculateCaches) XXX_Unmarshal(b []byte) error {
+func (m *DeleteViewMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RecalculateCaches) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeleteViewMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RecalculateCaches.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeleteViewMessage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2271,77 +2441,64 @@ func (m *RecalculateCaches) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *RecalculateCaches) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RecalculateCaches.Merge(m, src)
+func (m *DeleteViewMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteViewMessage.Merge(m, src)
 }
-func (m *RecalculateCaches) XXX_Size() int {
+func (m *DeleteViewMessage) XXX_Size() int {
 	return m.Size()
 }
-func (m *RecalculateCaches) XXX_DiscardUnknown() {
-	xxx_messageInfo_RecalculateCaches.DiscardUnknown(m)
+func (m *DeleteViewMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteViewMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RecalculateCaches proto.InternalMessageInfo
+var xxx_messageInfo_DeleteViewMessage proto.InternalMessageInfo
 
-type LoadSchemaMessage struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *DeleteViewMessage) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
 }
 
-func (m *LoadSchemaMessage) Reset()         { *m = LoadSchemaMessage{} }
-func (m *LoadSchemaMessage) String() string { return proto.CompactTextString(m) }
-func (*LoadSchemaMessage) ProtoMessage()    {}
-func (*LoadSchemaMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{35}
+func (m *DeleteViewMessage) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
 }
-func (m *LoadSchemaMessage) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+
+func (m *DeleteViewMessage) GetView() string {
+	if m != nil {
+		return m.View
+	}
+	return ""
 }
-func (m *LoadSchemaMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_LoadSchemaMessage.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
-}
-func (m *LoadSchemaMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LoadSchemaMessage.Merge(m, src)
-}
-func (m *LoadSchemaMessage) XXX_Size() int {
-	return m.Size()
-}
-func (m *LoadSchemaMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_LoadSchemaMessage.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_LoadSchemaMessage proto.InternalMessageInfo
 
-type TransactionMessage struct {
-	Action               string       `protobuf:"bytes,1,opt,name=Action,proto3" json:"Action,omitempty"`
-	Transaction          *Transaction `protobuf:"bytes,2,opt,name=Transaction,proto3" json:"Transaction,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+type ResizeInstruction struct {
+	JobID                int64                      `protobuf:"varint,1,opt,name=JobID,proto3" json:"JobID,omitempty"`
+	Node                 *Node                      `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+	Primary              *Node                      `protobuf:"bytes,3,opt,name=Primary,proto3" json:"Primary,omitempty"`
+	Sources              []*ResizeSource            `protobuf:"bytes,4,rep,name=Sources,proto3" json:"Sources,omitempty"`
+	TranslationSources   []*TranslationResizeSource `protobuf:"bytes,8,rep,name=TranslationSources,proto3" json:"TranslationSources,omitempty"`
+	NodeStatus           *NodeStatus                `protobuf:"bytes,7,opt,name=NodeStatus,proto3" json:"NodeStatus,omitempty"`
+	ClusterStatus        *ClusterStatus             `protobuf:"bytes,6,opt,name=ClusterStatus,proto3" json:"ClusterStatus,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
 }
 
-func (m *TransactionMessage) Reset()         { *m = TransactionMessage{} }
-func (m *TransactionMessage) String() string { return proto.CompactTextString(m) }
-func (*TransactionMessage) ProtoMessage()    {}
-func (*TransactionMessage) Descriptor() ([]byte, []int) {
+func (m *ResizeInstruction) Reset()         { *m = ResizeInstruction{} }
+func (m *ResizeInstruction) String() string { return proto.CompactTextString(m) }
+func (*ResizeInstruction) ProtoMessage()    {}
+func (*ResizeInstruction) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{36}
 }
-func (m *TransactionMessage) XXX_Unmarshal(b []byte) error {
+func (m *ResizeInstruction) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TransactionMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResizeInstruction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TransactionMessage.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResizeInstruction.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2351,56 +2508,90 @@ func (m *TransactionMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *TransactionMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TransactionMessage.Merge(m, src)
+func (m *ResizeInstruction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResizeInstruction.Merge(m, src)
 }
-func (m *TransactionMessage) XXX_Size() int {
+func (m *ResizeInstruction) XXX_Size() int {
 	return m.Size()
 }
-func (m *TransactionMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_TransactionMessage.DiscardUnknown(m)
+func (m *ResizeInstruction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResizeInstruction.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TransactionMessage proto.InternalMessageInfo
+var xxx_messageInfo_ResizeInstruction proto.InternalMessageInfo
 
-func (m *TransactionMessage) GetAction() string {
+func (m *ResizeInstruction) GetJobID() int64 {
 	if m != nil {
-		return m.Action
+		return m.JobID
 	}
-	return ""
+	return 0
 }
 
-func (m *TransactionMessage) GetTransaction() *Transaction {
+func (m *ResizeInstruction) GetNode() *Node {
 	if m != nil {
-		return m.Transaction
+		return m.Node
 	}
 	return nil
 }
 
-type Transaction struct {
-	ID                   string            `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Active               bool              `protobuf:"varint,2,opt,name=Active,proto3" json:"Active,omitempty"`
-	Exclusive            bool              `protobuf:"varint,3,opt,name=Exclusive,proto3" json:"Exclusive,omitempty"`
-	Timeout              int64             `protobuf:"varint,4,opt,name=Timeout,proto3" json:"Timeout,omitempty"`
-	Deadline             int64             `protobuf:"varint,5,opt,name=Deadline,proto3" json:"Deadline,omitempty"`
-	Stats                *TransactionStats `protobuf:"bytes,6,opt,name=Stats,proto3" json:"Stats,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *ResizeInstruction) GetPrimary() *Node {
+	if m != nil {
+		return m.Primary
+	}
+	return nil
 }
 
-func (m *Transaction) Reset()         { *m = Transaction{} }
-func (m *Transaction) String() string { return proto.CompactTextString(m) }
-func (*Transaction) ProtoMessage()    {}
-func (*Transaction) Descriptor() ([]byte, []int) {
+func (m *ResizeInstruction) GetSources() []*ResizeSource {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+func (m *ResizeInstruction) GetTranslationSources() []*TranslationResizeSource {
+	if m != nil {
+		return m.TranslationSources
+	}
+	return nil
+}
+
+func (m *ResizeInstruction) GetNodeStatus() *NodeStatus {
+	if m != nil {
+		return m.NodeStatus
+	}
+	return nil
+}
+
+func (m *ResizeInstruction) GetClusterStatus() *ClusterStatus {
+	if m != nil {
+		return m.ClusterStatus
+	}
+	return nil
+}
+
+type ResizeSource struct {
+	Node                 *Node    `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
+	Index                string   `protobuf:"bytes,2,opt,name=Index,proto3" json:"Index,omitempty"`
+	Field                string   `protobuf:"bytes,3,opt,name=Field,proto3" json:"Field,omitempty"`
+	View                 string   `protobuf:"bytes,4,opt,name=View,proto3" json:"View,omitempty"`
+	Shard                uint64   `protobuf:"varint,5,opt,name=Shard,proto3" json:"Shard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResizeSource) Reset()         { *m = ResizeSource{} }
+func (m *ResizeSource) String() string { return proto.CompactTextString(m) }
+func (*ResizeSource) ProtoMessage()    {}
+func (*ResizeSource) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{37}
 }
-func (m *Transaction) XXX_Unmarshal(b []byte) error {
+func (m *ResizeSource) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResizeSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Transaction.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResizeSource.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2410,78 +2601,74 @@ func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *Transaction) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Transaction.Merge(m, src)
+func (m *ResizeSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResizeSource.Merge(m, src)
 }
-func (m *Transaction) XXX_Size() int {
+func (m *ResizeSource) XXX_Size() int {
 	return m.Size()
 }
-func (m *Transaction) XXX_DiscardUnknown() {
-	xxx_messageInfo_Transaction.DiscardUnknown(m)
+func (m *ResizeSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResizeSource.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Transaction proto.InternalMessageInfo
+var xxx_messageInfo_ResizeSource proto.InternalMessageInfo
 
-func (m *Transaction) GetID() string {
+func (m *ResizeSource) GetNode() *Node {
 	if m != nil {
-		return m.ID
+		return m.Node
 	}
-	return ""
+	return nil
 }
 
-func (m *Transaction) GetActive() bool {
+func (m *ResizeSource) GetIndex() string {
 	if m != nil {
-		return m.Active
+		return m.Index
 	}
-	return false
+	return ""
 }
 
-func (m *Transaction) GetExclusive() bool {
+func (m *ResizeSource) GetField() string {
 	if m != nil {
-		return m.Exclusive
+		return m.Field
 	}
-	return false
+	return ""
 }
 
-func (m *Transaction) GetTimeout() int64 {
+func (m *ResizeSource) GetView() string {
 	if m != nil {
-		return m.Timeout
+		return m.View
 	}
-	return 0
+	return ""
 }
 
-func (m *Transaction) GetDeadline() int64 {
+func (m *ResizeSource) GetShard() uint64 {
 	if m != nil {
-		return m.Deadline
+		return m.Shard
 	}
 	return 0
 }
 
-func (m *Transaction) GetStats() *TransactionStats {
-	if m != nil {
-		return m.Stats
-	}
-	return nil
-}
-
-type TransactionStats struct {
+type TranslationResizeSource struct {
+	Node                 *Node    `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
+	Index                string   `protobuf:"bytes,2,opt,name=Index,proto3" json:"Index,omitempty"`
+	PartitionID          int32    `protobuf:"varint,3,opt,name=PartitionID,proto3" json:"PartitionID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TransactionStats) Reset()         { *m = TransactionStats{} }
-func (m *TransactionStats) String() string { return proto.CompactTextString(m) }
-func (*TransactionStats) ProtoMessage()    {}
-func (*TransactionStats) Descriptor() ([]byte, []int) {
+func (m *TranslationResizeSource) Reset()         { *m = TranslationResizeSource{} }
+func (m *TranslationResizeSource) String() string { return proto.CompactTextString(m) }
+func (*TranslationResizeSource) ProtoMessage()    {}
+func (*TranslationResizeSource) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{38}
 }
-func (m *TransactionStats) XXX_Unmarshal(b []byte) error {
+func (m *TranslationResizeSource) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TransactionStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TranslationResizeSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TransactionStats.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TranslationResizeSource.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2491,36 +2678,60 @@ func (m *TransactionStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *TransactionStats) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TransactionStats.Merge(m, src)
+func (m *TranslationResizeSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TranslationResizeSource.Merge(m, src)
 }
-func (m *TransactionStats) XXX_Size() int {
+func (m *TranslationResizeSource) XXX_Size() int {
 	return m.Size()
 }
-func (m *TransactionStats) XXX_DiscardUnknown() {
-	xxx_messageInfo_TransactionStats.DiscardUnknown(m)
+func (m *TranslationResizeSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_TranslationResizeSource.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TransactionStats proto.InternalMessageInfo
+var xxx_messageInfo_TranslationResizeSource proto.InternalMessageInfo
 
-type ResizeAbortMessage struct {
+func (m *TranslationResizeSource) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *TranslationResizeSource) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *TranslationResizeSource) GetPartitionID() int32 {
+	if m != nil {
+		return m.PartitionID
+	}
+	return 0
+}
+
+type ResizeInstructionComplete struct {
+	JobID                int64    `protobuf:"varint,1,opt,name=JobID,proto3" json:"JobID,omitempty"`
+	Node                 *Node    `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=Error,proto3" json:"Error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ResizeAbortMessage) Reset()         { *m = ResizeAbortMessage{} }
-func (m *ResizeAbortMessage) String() string { return proto.CompactTextString(m) }
-func (*ResizeAbortMessage) ProtoMessage()    {}
-func (*ResizeAbortMessage) Descriptor() ([]byte, []int) {
+func (m *ResizeInstructionComplete) Reset()         { *m = ResizeInstructionComplete{} }
+func (m *ResizeInstructionComplete) String() string { return proto.CompactTextString(m) }
+func (*ResizeInstructionComplete) ProtoMessage()    {}
+func (*ResizeInstructionComplete) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{39}
 }
-func (m *ResizeAbortMessage) XXX_Unmarshal(b []byte) error {
+func (m *ResizeInstructionComplete) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ResizeAbortMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResizeInstructionComplete) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ResizeAbortMessage.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResizeInstructionComplete.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2530,38 +2741,59 @@ func (m *ResizeAbortMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *ResizeAbortMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResizeAbortMessage.Merge(m, src)
+func (m *ResizeInstructionComplete) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResizeInstructionComplete.Merge(m, src)
 }
-func (m *ResizeAbortMessage) XXX_Size() int {
+func (m *ResizeInstructionComplete) XXX_Size() int {
 	return m.Size()
 }
-func (m *ResizeAbortMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResizeAbortMessage.DiscardUnknown(m)
+func (m *ResizeInstructionComplete) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResizeInstructionComplete.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResizeAbortMessage proto.InternalMessageInfo
+var xxx_messageInfo_ResizeInstructionComplete proto.InternalMessageInfo
 
-type ResizeNodeMessage struct {
-	NodeID               string   `protobuf:"bytes,1,opt,name=NodeID,proto3" json:"NodeID,omitempty"`
-	Action               string   `protobuf:"bytes,2,opt,name=Action,proto3" json:"Action,omitempty"`
+func (m *ResizeInstructionComplete) GetJobID() int64 {
+	if m != nil {
+		return m.JobID
+	}
+	return 0
+}
+
+func (m *ResizeInstructionComplete) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *ResizeInstructionComplete) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type Topology struct {
+	ClusterID            string   `protobuf:"bytes,1,opt,name=ClusterID,proto3" json:"ClusterID,omitempty"`
+	NodeIDs              []string `protobuf:"bytes,2,rep,name=NodeIDs,proto3" json:"NodeIDs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ResizeNodeMessage) Reset()         { *m = ResizeNodeMessage{} }
-func (m *ResizeNodeMessage) String() string { return proto.CompactTextString(m) }
-func (*ResizeNodeMessage) ProtoMessage()    {}
-func (*ResizeNodeMessage) Descriptor() ([]byte, []int) {
+func (m *Topology) Reset()         { *m = Topology{} }
+func (m *Topology) String() string { return proto.CompactTextString(m) }
+func (*Topology) ProtoMessage()    {}
+func (*Topology) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{40}
 }
-func (m *ResizeNodeMessage) XXX_Unmarshal(b []byte) error {
+func (m *Topology) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ResizeNodeMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Topology) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ResizeNodeMessage.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Topology.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2571,53 +2803,50 @@ func (m *ResizeNodeMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *ResizeNodeMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResizeNodeMessage.Merge(m, src)
+func (m *Topology) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Topology.Merge(m, src)
 }
-func (m *ResizeNodeMessage) XXX_Size() int {
+func (m *Topology) XXX_Size() int {
 	return m.Size()
 }
-func (m *ResizeNodeMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResizeNodeMessage.DiscardUnknown(m)
+func (m *Topology) XXX_DiscardUnknown() {
+	xxx_messageInfo_Topology.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResizeNodeMessage proto.InternalMessageInfo
+var xxx_messageInfo_Topology proto.InternalMessageInfo
 
-func (m *ResizeNodeMessage) GetNodeID() string {
+func (m *Topology) GetClusterID() string {
 	if m != nil {
-		return m.NodeID
+		return m.ClusterID
 	}
 	return ""
 }
 
-func (m *ResizeNodeMessage) GetAction() string {
+func (m *Topology) GetNodeIDs() []string {
 	if m != nil {
-		return m.Action
+		return m.NodeIDs
 	}
-	return ""
+	return nil
 }
 
-type FieldOperation struct {
-	RecordIDs            []uint64 `protobuf:"varint,1,rep,packed,name=RecordIDs,proto3" json:"RecordIDs,omitempty"`
-	Values               []uint64 `protobuf:"varint,2,rep,packed,name=Values,proto3" json:"Values,omitempty"`
-	Signed               []int64  `protobuf:"varint,3,rep,packed,name=Signed,proto3" json:"Signed,omitempty"`
+type RecalculateCaches struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FieldOperation) Reset()         { *m = FieldOperation{} }
-func (m *FieldOperation) String() string { return proto.CompactTextString(m) }
-func (*FieldOperation) ProtoMessage()    {}
-func (*FieldOperation) Descriptor() ([]byte, []int) {
+func (m *RecalculateCaches) Reset()         { *m = RecalculateCaches{} }
+func (m *RecalculateCaches) String() string { return proto.CompactTextString(m) }
+func (*RecalculateCaches) ProtoMessage()    {}
+func (*RecalculateCaches) Descriptor() ([]byte, []int) {
 	return fileDescriptor_d2a91b51c7bdc125, []int{41}
 }
-func (m *FieldOperation) XXX_Unmarshal(b []byte) error {
+func (m *RecalculateCaches) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FieldOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RecalculateCaches) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FieldOperation.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RecalculateCaches.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2627,61 +2856,77 @@ func (m *FieldOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *FieldOperation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FieldOperation.Merge(m, src)
+func (m *RecalculateCaches) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecalculateCaches.Merge(m, src)
 }
-func (m *FieldOperation) XXX_Size() int {
+func (m *RecalculateCaches) XXX_Size() int {
 	return m.Size()
 }
-func (m *FieldOperation) XXX_DiscardUnknown() {
-	xxx_messageInfo_FieldOperation.DiscardUnknown(m)
+func (m *RecalculateCaches) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecalculateCaches.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FieldOperation proto.InternalMessageInfo
+var xxx_messageInfo_RecalculateCaches proto.InternalMessageInfo
 
-func (m *FieldOperation) GetRecordIDs() []uint64 {
-	if m != nil {
-		return m.RecordIDs
-	}
-	return nil
+type LoadSchemaMessage struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FieldOperation) GetValues() []uint64 {
-	if m != nil {
-		return m.Values
-	}
-	return nil
+func (m *LoadSchemaMessage) Reset()         { *m = LoadSchemaMessage{} }
+func (m *LoadSchemaMessage) String() string { return proto.CompactTextString(m) }
+func (*LoadSchemaMessage) ProtoMessage()    {}
+func (*LoadSchemaMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{42}
 }
-
-func (m *FieldOperation) GetSigned() []int64 {
-	if m != nil {
-		return m.Signed
+func (m *LoadSchemaMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LoadSchemaMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LoadSchemaMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *LoadSchemaMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadSchemaMessage.Merge(m, src)
+}
+func (m *LoadSchemaMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *LoadSchemaMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadSchemaMessage.DiscardUnknown(m)
 }
 
-type ShardIngestOperation struct {
-	OpType               string                     `protobuf:"bytes,1,opt,name=OpType,proto3" json:"OpType,omitempty"`
-	ClearRecordIDs       []uint64                   `protobuf:"varint,2,rep,packed,name=ClearRecordIDs,proto3" json:"ClearRecordIDs,omitempty"`
-	ClearFields          []string                   `protobuf:"bytes,3,rep,name=ClearFields,proto3" json:"ClearFields,omitempty"`
-	FieldOps             map[string]*FieldOperation `protobuf:"bytes,4,rep,name=FieldOps,proto3" json:"FieldOps,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+var xxx_messageInfo_LoadSchemaMessage proto.InternalMessageInfo
+
+type TransactionMessage struct {
+	Action               string       `protobuf:"bytes,1,opt,name=Action,proto3" json:"Action,omitempty"`
+	Transaction          *Transaction `protobuf:"bytes,2,opt,name=Transaction,proto3" json:"Transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *ShardIngestOperation) Reset()         { *m = ShardIngestOperation{} }
-func (m *ShardIngestOperation) String() string { return proto.CompactTextString(m) }
-func (*ShardIngestOperation) ProtoMessage()    {}
-func (*ShardIngestOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{42}
+func (m *TransactionMessage) Reset()         { *m = TransactionMessage{} }
+func (m *TransactionMessage) String() string { return proto.CompactTextString(m) }
+func (*TransactionMessage) ProtoMessage()    {}
+func (*TransactionMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{43}
 }
-func (m *ShardIngestOperation) XXX_Unmarshal(b []byte) error {
+func (m *TransactionMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ShardIngestOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TransactionMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ShardIngestOperation.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TransactionMessage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2691,65 +2936,56 @@ func (m *ShardIngestOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *ShardIngestOperation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ShardIngestOperation.Merge(m, src)
+func (m *TransactionMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionMessage.Merge(m, src)
 }
-func (m *ShardIngestOperation) XXX_Size() int {
+func (m *TransactionMessage) XXX_Size() int {
 	return m.Size()
 }
-func (m *ShardIngestOperation) XXX_DiscardUnknown() {
-	xxx_messageInfo_ShardIngestOperation.DiscardUnknown(m)
+func (m *TransactionMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionMessage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ShardIngestOperation proto.InternalMessageInfo
+var xxx_messageInfo_TransactionMessage proto.InternalMessageInfo
 
-func (m *ShardIngestOperation) GetOpType() string {
+func (m *TransactionMessage) GetAction() string {
 	if m != nil {
-		return m.OpType
+		return m.Action
 	}
 	return ""
 }
 
-func (m *ShardIngestOperation) GetClearRecordIDs() []uint64 {
-	if m != nil {
-		return m.ClearRecordIDs
-	}
-	return nil
-}
-
-func (m *ShardIngestOperation) GetClearFields() []string {
-	if m != nil {
-		return m.ClearFields
-	}
-	return nil
-}
-
-func (m *ShardIngestOperation) GetFieldOps() map[string]*FieldOperation {
+func (m *TransactionMessage) GetTransaction() *Transaction {
 	if m != nil {
-		return m.FieldOps
+		return m.Transaction
 	}
 	return nil
 }
 
-type ShardIngestOperations struct {
-	Ops                  []*ShardIngestOperation `protobuf:"bytes,1,rep,name=Ops,proto3" json:"Ops,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+type Transaction struct {
+	ID                   string            `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Active               bool              `protobuf:"varint,2,opt,name=Active,proto3" json:"Active,omitempty"`
+	Exclusive            bool              `protobuf:"varint,3,opt,name=Exclusive,proto3" json:"Exclusive,omitempty"`
+	Timeout              int64             `protobuf:"varint,4,opt,name=Timeout,proto3" json:"Timeout,omitempty"`
+	Deadline             int64             `protobuf:"varint,5,opt,name=Deadline,proto3" json:"Deadline,omitempty"`
+	Stats                *TransactionStats `protobuf:"bytes,6,opt,name=Stats,proto3" json:"Stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *ShardIngestOperations) Reset()         { *m = ShardIngestOperations{} }
-func (m *ShardIngestOperations) String() string { return proto.CompactTextString(m) }
-func (*ShardIngestOperations) ProtoMessage()    {}
-func (*ShardIngestOperations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{43}
+func (m *Transaction) Reset()         { *m = Transaction{} }
+func (m *Transaction) String() string { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()    {}
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{44}
 }
-func (m *ShardIngestOperations) XXX_Unmarshal(b []byte) error {
+func (m *Transaction) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ShardIngestOperations) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ShardIngestOperations.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Transaction.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2759,44 +2995,78 @@ func (m *ShardIngestOperations) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *ShardIngestOperations) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ShardIngestOperations.Merge(m, src)
+func (m *Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transaction.Merge(m, src)
 }
-func (m *ShardIngestOperations) XXX_Size() int {
+func (m *Transaction) XXX_Size() int {
 	return m.Size()
 }
-func (m *ShardIngestOperations) XXX_DiscardUnknown() {
-	xxx_messageInfo_ShardIngestOperations.DiscardUnknown(m)
+func (m *Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transaction.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ShardIngestOperations proto.InternalMessageInfo
+var xxx_messageInfo_Transaction proto.InternalMessageInfo
 
-func (m *ShardIngestOperations) GetOps() []*ShardIngestOperation {
+func (m *Transaction) GetID() string {
 	if m != nil {
-		return m.Ops
+		return m.ID
+	}
+	return ""
+}
+
+func (m *Transaction) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *Transaction) GetExclusive() bool {
+	if m != nil {
+		return m.Exclusive
+	}
+	return false
+}
+
+func (m *Transaction) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *Transaction) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
+}
+
+func (m *Transaction) GetStats() *TransactionStats {
+	if m != nil {
+		return m.Stats
 	}
 	return nil
 }
 
-type ShardedIngestRequest struct {
-	Ops                  map[uint64]*ShardIngestOperations `protobuf:"bytes,1,rep,name=Ops,proto3" json:"Ops,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
-	XXX_unrecognized     []byte                            `json:"-"`
-	XXX_sizecache        int32                             `json:"-"`
+type TransactionStats struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ShardedIngestRequest) Reset()         { *m = ShardedIngestRequest{} }
-func (m *ShardedIngestRequest) String() string { return proto.CompactTextString(m) }
-func (*ShardedIngestRequest) ProtoMessage()    {}
-func (*ShardedIngestRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d2a91b51c7bdc125, []int{44}
+func (m *TransactionStats) Reset()         { *m = TransactionStats{} }
+func (m *TransactionStats) String() string { return proto.CompactTextString(m) }
+func (*TransactionStats) ProtoMessage()    {}
+func (*TransactionStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{45}
 }
-func (m *ShardedIngestRequest) XXX_Unmarshal(b []byte) error {
+func (m *TransactionStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ShardedIngestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TransactionStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ShardedIngestRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TransactionStats.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2806,680 +3076,647 @@ func (m *ShardedIngestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *ShardedIngestRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ShardedIngestRequest.Merge(m, src)
+func (m *TransactionStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionStats.Merge(m, src)
 }
-func (m *ShardedIngestRequest) XXX_Size() int {
+func (m *TransactionStats) XXX_Size() int {
 	return m.Size()
 }
-func (m *ShardedIngestRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ShardedIngestRequest.DiscardUnknown(m)
+func (m *TransactionStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionStats.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ShardedIngestRequest proto.InternalMessageInfo
+var xxx_messageInfo_TransactionStats proto.InternalMessageInfo
 
-func (m *ShardedIngestRequest) GetOps() map[uint64]*ShardIngestOperations {
-	if m != nil {
-		return m.Ops
-	}
-	return nil
+type ResizeAbortMessage struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterType((*IndexMeta)(nil), "pb.IndexMeta")
-	proto.RegisterType((*FieldOptions)(nil), "pb.FieldOptions")
-	proto.RegisterType((*ImportResponse)(nil), "pb.ImportResponse")
-	proto.RegisterType((*BlockDataRequest)(nil), "pb.BlockDataRequest")
-	proto.RegisterType((*BlockDataResponse)(nil), "pb.BlockDataResponse")
-	proto.RegisterType((*Cache)(nil), "pb.Cache")
-	proto.RegisterType((*MaxShards)(nil), "pb.MaxShards")
-	proto.RegisterMapType((map[string]uint64)(nil), "pb.MaxShards.StandardEntry")
-	proto.RegisterType((*CreateShardMessage)(nil), "pb.CreateShardMessage")
-	proto.RegisterType((*DeleteIndexMessage)(nil), "pb.DeleteIndexMessage")
-	proto.RegisterType((*CreateIndexMessage)(nil), "pb.CreateIndexMessage")
-	proto.RegisterType((*CreateFieldMessage)(nil), "pb.CreateFieldMessage")
-	proto.RegisterType((*UpdateFieldMessage)(nil), "pb.UpdateFieldMessage")
-	proto.RegisterType((*FieldUpdate)(nil), "pb.FieldUpdate")
-	proto.RegisterType((*DeleteFieldMessage)(nil), "pb.DeleteFieldMessage")
-	proto.RegisterType((*DeleteAvailableShardMessage)(nil), "pb.DeleteAvailableShardMessage")
-	proto.RegisterType((*Field)(nil), "pb.Field")
-	proto.RegisterType((*Schema)(nil), "pb.Schema")
-	proto.RegisterType((*Index)(nil), "pb.Index")
-	proto.RegisterType((*URI)(nil), "pb.URI")
-	proto.RegisterType((*Node)(nil), "pb.Node")
-	proto.RegisterType((*NodeStateMessage)(nil), "pb.NodeStateMessage")
-	proto.RegisterType((*NodeEventMessage)(nil), "pb.NodeEventMessage")
-	proto.RegisterType((*NodeStatus)(nil), "pb.NodeStatus")
-	proto.RegisterType((*IndexStatus)(nil), "pb.IndexStatus")
-	proto.RegisterType((*FieldStatus)(nil), "pb.FieldStatus")
-	proto.RegisterType((*ClusterStatus)(nil), "pb.ClusterStatus")
-	proto.RegisterType((*BSIGroup)(nil), "pb.BSIGroup")
-	proto.RegisterType((*CreateViewMessage)(nil), "pb.CreateViewMessage")
-	proto.RegisterType((*DeleteViewMessage)(nil), "pb.DeleteViewMessage")
-	proto.RegisterType((*ResizeInstruction)(nil), "pb.ResizeInstruction")
-	proto.RegisterType((*ResizeSource)(nil), "pb.ResizeSource")
-	proto.RegisterType((*TranslationResizeSource)(nil), "pb.TranslationResizeSource")
-	proto.RegisterType((*ResizeInstructionComplete)(nil), "pb.ResizeInstructionComplete")
-	proto.RegisterType((*Topology)(nil), "pb.Topology")
-	proto.RegisterType((*RecalculateCaches)(nil), "pb.RecalculateCaches")
-	proto.RegisterType((*LoadSchemaMessage)(nil), "pb.LoadSchemaMessage")
-	proto.RegisterType((*TransactionMessage)(nil), "pb.TransactionMessage")
-	proto.RegisterType((*Transaction)(nil), "pb.Transaction")
-	proto.RegisterType((*TransactionStats)(nil), "pb.TransactionStats")
-	proto.RegisterType((*ResizeAbortMessage)(nil), "pb.ResizeAbortMessage")
-	proto.RegisterType((*ResizeNodeMessage)(nil), "pb.ResizeNodeMessage")
-	proto.RegisterType((*FieldOperation)(nil), "pb.FieldOperation")
-	proto.RegisterType((*ShardIngestOperation)(nil), "pb.ShardIngestOperation")
-	proto.RegisterMapType((map[string]*FieldOperation)(nil), "pb.ShardIngestOperation.FieldOpsEntry")
-	proto.RegisterType((*ShardIngestOperations)(nil), "pb.ShardIngestOperations")
-	proto.RegisterType((*ShardedIngestRequest)(nil), "pb.ShardedIngestRequest")
-	proto.RegisterMapType((map[uint64]*ShardIngestOperations)(nil), "pb.ShardedIngestRequest.OpsEntry")
+func (m *ResizeAbortMessage) Reset()         { *m = ResizeAbortMessage{} }
+func (m *ResizeAbortMessage) String() string { return proto.CompactTextString(m) }
+func (*ResizeAbortMessage) ProtoMessage()    {}
+func (*ResizeAbortMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{46}
 }
-
-func init() { proto.RegisterFile("private.proto", fileDescriptor_d2a91b51c7bdc125) }
-
-var fileDescriptor_d2a91b51c7bdc125 = []byte{
-	// 1701 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x4b, 0x6f, 0x23, 0x4b,
-	0x15, 0xa6, 0x1f, 0xf1, 0xe3, 0x38, 0xce, 0x38, 0x75, 0xc3, 0xd0, 0x93, 0x3b, 0x44, 0x9e, 0x02,
-	0xcd, 0x98, 0x91, 0x08, 0x22, 0x77, 0x71, 0x11, 0x77, 0x73, 0x27, 0x76, 0x66, 0x30, 0xf7, 0xce,
-	0xe3, 0x56, 0x1e, 0x4b, 0x50, 0xa5, 0x5d, 0x4a, 0x5a, 0x69, 0x77, 0x9b, 0xee, 0x76, 0xc6, 0x9e,
-	0x05, 0x12, 0x08, 0x04, 0x1b, 0xf6, 0xac, 0xf8, 0x17, 0xfc, 0x01, 0x56, 0x6c, 0x90, 0xf8, 0x09,
-	0x68, 0xf8, 0x23, 0xa8, 0x4e, 0x55, 0x75, 0x97, 0x1d, 0x27, 0x86, 0x88, 0x5d, 0x9f, 0xef, 0x9c,
-	0x3a, 0xef, 0x3a, 0x75, 0x6c, 0x68, 0x4f, 0xb2, 0xe8, 0x9a, 0x17, 0x62, 0x7f, 0x92, 0xa5, 0x45,
-	0x4a, 0xdc, 0xc9, 0xf9, 0xee, 0xe6, 0x64, 0x7a, 0x1e, 0x47, 0xa1, 0x42, 0xe8, 0x2b, 0x68, 0x0e,
-	0x93, 0x91, 0x98, 0xbd, 0x16, 0x05, 0x27, 0x04, 0xfc, 0xaf, 0xc4, 0x3c, 0x0f, 0xbc, 0xae, 0xd3,
-	0x6b, 0x30, 0xfc, 0x26, 0x4f, 0x61, 0xeb, 0x24, 0xe3, 0xe1, 0xd5, 0xd1, 0x2c, 0xca, 0x0b, 0x91,
-	0x84, 0x22, 0xf0, 0x91, 0xbb, 0x84, 0xd2, 0xbf, 0x79, 0xb0, 0xf9, 0x32, 0x12, 0xf1, 0xe8, 0xed,
-	0xa4, 0x88, 0xd2, 0x24, 0x97, 0xca, 0x4e, 0xe6, 0x13, 0x11, 0x34, 0xba, 0x4e, 0xaf, 0xc9, 0xf0,
-	0x9b, 0x3c, 0x86, 0x66, 0x9f, 0x87, 0x97, 0x02, 0x19, 0x1e, 0x32, 0x2a, 0xa0, 0xe4, 0x1e, 0x47,
-	0x1f, 0x94, 0x95, 0x36, 0xab, 0x00, 0xd2, 0x85, 0xd6, 0x49, 0x34, 0x16, 0xdf, 0x4c, 0x79, 0x52,
-	0x4c, 0xc7, 0xc1, 0x06, 0x9e, 0xb6, 0x21, 0xf2, 0x10, 0x6a, 0x6f, 0xe3, 0xd1, 0xeb, 0x28, 0x09,
-	0x9a, 0x5d, 0xa7, 0xe7, 0x31, 0x4d, 0x19, 0x9c, 0xcf, 0x02, 0xa8, 0x70, 0x3e, 0x2b, 0xc3, 0x6d,
-	0x2d, 0x86, 0xfb, 0x26, 0x3d, 0x2e, 0x78, 0x32, 0xe2, 0xd9, 0xe8, 0x2c, 0x12, 0xef, 0x83, 0x4d,
-	0x15, 0xee, 0x22, 0x2a, 0xcf, 0x1e, 0xf2, 0x5c, 0x04, 0x6d, 0xd4, 0x88, 0xdf, 0x64, 0x17, 0x1a,
-	0x87, 0x51, 0x31, 0x10, 0x93, 0xe2, 0x32, 0xd8, 0xea, 0x3a, 0x3d, 0x9f, 0x95, 0x34, 0xd9, 0x81,
-	0x8d, 0xe3, 0x90, 0xc7, 0x22, 0x78, 0x80, 0x07, 0x14, 0x41, 0x28, 0x6c, 0xbe, 0x4c, 0x33, 0x11,
-	0x5d, 0x24, 0x58, 0x84, 0xa0, 0x83, 0x41, 0x2d, 0x60, 0xe4, 0xbb, 0xe0, 0xc9, 0x90, 0xb6, 0xbb,
-	0x4e, 0xaf, 0x75, 0xd0, 0xda, 0x9f, 0x9c, 0xef, 0x0f, 0x44, 0x18, 0x8d, 0x79, 0xcc, 0x24, 0x8e,
-	0x6c, 0x3e, 0x0b, 0xc8, 0x2a, 0x36, 0x9f, 0x49, 0x9f, 0x64, 0x8a, 0x4e, 0x93, 0xa8, 0x08, 0x3e,
-	0x41, 0xed, 0x25, 0x4d, 0x3a, 0xe0, 0x9d, 0x9c, 0x7c, 0x1d, 0xec, 0x20, 0x2c, 0x3f, 0x29, 0x85,
-	0xad, 0xe1, 0x78, 0x92, 0x66, 0x05, 0x13, 0xf9, 0x24, 0x4d, 0x72, 0x21, 0x65, 0x8e, 0xb2, 0x2c,
-	0x70, 0x94, 0xcc, 0x51, 0x96, 0xd1, 0x5f, 0x43, 0xe7, 0x30, 0x4e, 0xc3, 0xab, 0x01, 0x2f, 0x38,
-	0x13, 0xbf, 0x9a, 0x8a, 0xbc, 0x90, 0xd1, 0xa9, 0x00, 0x94, 0x9c, 0x22, 0x24, 0x8a, 0x1d, 0x11,
-	0xb8, 0x0a, 0x45, 0x42, 0x66, 0x0e, 0xf3, 0xaa, 0x0a, 0x88, 0xdf, 0x98, 0x9d, 0x4b, 0x9e, 0x8d,
-	0xb0, 0xea, 0x3e, 0x53, 0x84, 0x44, 0xd1, 0x12, 0x76, 0x8a, 0xcf, 0x14, 0x41, 0x87, 0xb0, 0x6d,
-	0xd9, 0xd7, 0x6e, 0x3e, 0x84, 0x1a, 0x4b, 0xdf, 0x0f, 0x07, 0x79, 0xe0, 0x74, 0xbd, 0x9e, 0xcf,
-	0x34, 0x85, 0x2d, 0x95, 0xc6, 0xd3, 0x71, 0x22, 0x59, 0x2e, 0xb2, 0x2a, 0x80, 0x3e, 0x82, 0x0d,
-	0xec, 0x2f, 0x19, 0x65, 0x75, 0x56, 0x7e, 0xd2, 0xdf, 0x38, 0xd0, 0x7c, 0xcd, 0x67, 0xe8, 0x48,
-	0x4e, 0x3e, 0x87, 0x86, 0xa9, 0x3e, 0x0a, 0xb5, 0x0e, 0x3e, 0x95, 0x99, 0x2e, 0x05, 0xf6, 0x0d,
-	0xf7, 0x28, 0x29, 0xb2, 0x39, 0x2b, 0x85, 0x77, 0xbf, 0x80, 0xf6, 0x02, 0x4b, 0x5a, 0xba, 0x12,
-	0x73, 0x93, 0xcf, 0x2b, 0x31, 0x97, 0x51, 0x5e, 0xf3, 0x78, 0x2a, 0x30, 0x4b, 0x3e, 0x53, 0xc4,
-	0x4f, 0xdd, 0x9f, 0x38, 0xf4, 0x0c, 0x48, 0x3f, 0x13, 0xbc, 0x10, 0x68, 0xe4, 0xb5, 0xc8, 0x73,
-	0x7e, 0x21, 0xd6, 0xe5, 0xda, 0xb3, 0x73, 0x5d, 0xe6, 0xd5, 0xb5, 0xf2, 0x4a, 0x9f, 0x03, 0x19,
-	0x88, 0x58, 0x14, 0x42, 0xdf, 0xfc, 0x3b, 0xf4, 0xd2, 0x2b, 0xe3, 0xc3, 0x7a, 0x59, 0xf2, 0x04,
-	0x7c, 0x39, 0x46, 0xd0, 0x58, 0xeb, 0xa0, 0x2d, 0x33, 0x54, 0xce, 0x16, 0x86, 0x2c, 0xac, 0x07,
-	0xaa, 0x1b, 0xbd, 0x28, 0xd0, 0x55, 0x8f, 0x55, 0x00, 0xfd, 0x9d, 0x63, 0xac, 0xa1, 0xfb, 0xff,
-	0x65, 0xc4, 0x0b, 0xdd, 0xf5, 0x7d, 0xed, 0x83, 0x87, 0x3e, 0x74, 0xa4, 0x0f, 0xf6, 0x54, 0x5a,
-	0xe5, 0x86, 0xbf, 0xec, 0xc6, 0xef, 0x1d, 0x20, 0xa7, 0x93, 0xd1, 0xb2, 0x1b, 0x2f, 0x57, 0x39,
-	0x87, 0x3e, 0xb5, 0x0e, 0x1e, 0x4a, 0x43, 0x37, 0xb9, 0x6c, 0x55, 0x38, 0xcf, 0xa0, 0xa6, 0xb4,
-	0xeb, 0x44, 0x3d, 0x28, 0x9d, 0x54, 0x30, 0xd3, 0x6c, 0xfa, 0x05, 0xb4, 0x2c, 0x18, 0xc7, 0x18,
-	0x46, 0xa1, 0xf3, 0xa0, 0x29, 0x99, 0x88, 0xb3, 0xb2, 0x81, 0x9a, 0x4c, 0x11, 0xf4, 0x4b, 0x53,
-	0xe4, 0xfb, 0xa6, 0x92, 0x86, 0xf0, 0xa9, 0xd2, 0xf0, 0xe2, 0x9a, 0x47, 0x31, 0x3f, 0x8f, 0xff,
-	0xa7, 0x3e, 0x5c, 0xa8, 0x4a, 0x00, 0x75, 0x3c, 0x3b, 0x1c, 0xe8, 0xbb, 0x6c, 0x48, 0x3a, 0x85,
-	0x6a, 0x2c, 0xbc, 0xe1, 0x63, 0xa1, 0xb5, 0xe1, 0x77, 0x59, 0x4c, 0xf7, 0xce, 0x62, 0xca, 0xf8,
-	0x23, 0xf1, 0x5e, 0x3e, 0x5b, 0x1e, 0xc6, 0x2f, 0x89, 0x35, 0x25, 0xfe, 0x21, 0xd4, 0x8e, 0xc3,
-	0x4b, 0x31, 0xe6, 0xe4, 0x7b, 0x50, 0x47, 0xcf, 0x45, 0xae, 0x6f, 0x76, 0xb3, 0xec, 0x5b, 0x66,
-	0x38, 0xb2, 0x23, 0x74, 0x7c, 0xab, 0xdc, 0x5c, 0x30, 0xe5, 0x2e, 0x99, 0x22, 0xcf, 0xa0, 0xae,
-	0xfd, 0xc5, 0x91, 0x77, 0xe3, 0x62, 0x18, 0x2e, 0x79, 0x02, 0x35, 0x8c, 0x2e, 0x0f, 0xfc, 0xca,
-	0x11, 0x44, 0x98, 0x66, 0xd0, 0x23, 0xf0, 0x4e, 0xd9, 0x50, 0x76, 0x02, 0x7a, 0x6f, 0xdc, 0xd0,
-	0x94, 0x74, 0xee, 0x67, 0x69, 0x5e, 0xe8, 0xdc, 0xe3, 0xb7, 0xc4, 0xde, 0xa5, 0x99, 0xba, 0x6c,
-	0x6d, 0x86, 0xdf, 0xf4, 0x8f, 0x0e, 0xf8, 0x6f, 0xd2, 0x91, 0x20, 0x5b, 0xe0, 0x0e, 0x07, 0x5a,
-	0x89, 0x3b, 0x1c, 0x90, 0x47, 0xa8, 0x5f, 0xe7, 0xbb, 0x2e, 0xed, 0x9f, 0xb2, 0x21, 0x43, 0x9b,
-	0x8f, 0xa1, 0x39, 0xcc, 0xdf, 0x65, 0xd1, 0x98, 0x67, 0x73, 0xbd, 0x20, 0x54, 0x00, 0x0e, 0x9a,
-	0x42, 0xb6, 0xb4, 0xaf, 0xca, 0x8e, 0x04, 0x79, 0x02, 0xf5, 0x57, 0xec, 0x5d, 0x5f, 0xaa, 0xdc,
-	0x58, 0x54, 0x69, 0x70, 0xfa, 0x25, 0x74, 0xa4, 0x27, 0x28, 0x6f, 0x3a, 0xeb, 0x21, 0xd4, 0x24,
-	0x56, 0x7a, 0xa6, 0xa9, 0xca, 0x88, 0x6b, 0x19, 0xa1, 0x2f, 0x95, 0x86, 0xa3, 0x6b, 0x91, 0x14,
-	0x56, 0x6f, 0x22, 0x8d, 0x0a, 0xda, 0x4c, 0x11, 0xe4, 0xb1, 0x8a, 0x5a, 0x87, 0xd7, 0x90, 0xbe,
-	0x48, 0x9a, 0x21, 0x4a, 0xe7, 0x00, 0xc6, 0x93, 0x69, 0x5e, 0xca, 0x3a, 0xab, 0x64, 0x09, 0x35,
-	0xed, 0xa3, 0xe7, 0x0c, 0x48, 0xbe, 0x42, 0x98, 0x69, 0xac, 0x1f, 0x54, 0x8d, 0xa5, 0xea, 0xf9,
-	0xa0, 0xac, 0xbb, 0xb2, 0x51, 0xb5, 0xd7, 0x25, 0xb4, 0x2c, 0x7c, 0x65, 0x8f, 0x3d, 0x2b, 0x9b,
-	0xc3, 0xad, 0x94, 0x21, 0xa2, 0x95, 0x69, 0xf6, 0x9a, 0x09, 0x1b, 0xe9, 0x91, 0x72, 0x87, 0xa5,
-	0x1e, 0x3c, 0x58, 0xbc, 0xf0, 0xe6, 0xe1, 0x5c, 0x86, 0xd7, 0x98, 0xfa, 0x83, 0x03, 0xed, 0x7e,
-	0x3c, 0xcd, 0x0b, 0x91, 0x95, 0x39, 0x6d, 0x6a, 0xa0, 0x2c, 0x6d, 0x05, 0xac, 0xae, 0x2e, 0xd9,
-	0x83, 0x0d, 0x99, 0x71, 0x75, 0xb9, 0xed, 0x42, 0x28, 0xd8, 0xaa, 0x84, 0x7f, 0x5b, 0x25, 0xe8,
-	0x19, 0x34, 0x0e, 0x8f, 0x87, 0xaf, 0xb2, 0x74, 0x3a, 0x59, 0x19, 0xb1, 0xd9, 0x54, 0x5d, 0x6b,
-	0x53, 0xed, 0xa8, 0xad, 0x4b, 0x45, 0x85, 0x8b, 0x56, 0x47, 0x2d, 0x5a, 0xbe, 0x46, 0xf8, 0x8c,
-	0x1e, 0xc3, 0xb6, 0x0a, 0x57, 0x4e, 0x9c, 0xfb, 0x8c, 0x45, 0xb3, 0x0a, 0x79, 0xd5, 0x2a, 0x24,
-	0x95, 0xaa, 0xa9, 0xfb, 0xff, 0x54, 0xfa, 0x0f, 0x17, 0xb6, 0x99, 0xc8, 0xa3, 0x0f, 0x62, 0x98,
-	0xe4, 0x45, 0x36, 0x0d, 0xcd, 0xc3, 0xf1, 0xf3, 0xf4, 0x5c, 0xd7, 0xc2, 0x63, 0x8a, 0xb8, 0xfb,
-	0x96, 0x10, 0x0a, 0x75, 0x7b, 0x08, 0xd8, 0x02, 0x86, 0x41, 0x9e, 0x43, 0xfd, 0x38, 0x9d, 0x66,
-	0x61, 0xd9, 0xf9, 0x38, 0xb9, 0x95, 0x7d, 0xc5, 0x60, 0x46, 0x80, 0x7c, 0x05, 0xe4, 0x24, 0xe3,
-	0x49, 0x1e, 0x73, 0xe9, 0x92, 0x39, 0xd6, 0xa8, 0x76, 0x2c, 0x8b, 0xbb, 0xa0, 0x61, 0xc5, 0x31,
-	0xb2, 0x6f, 0x5f, 0xe1, 0xa0, 0x8e, 0xfe, 0x6d, 0x19, 0xff, 0xf4, 0x3d, 0xb1, 0x2f, 0xf9, 0xe7,
-	0x4b, 0x1d, 0x1a, 0xd4, 0xf0, 0xc8, 0x36, 0x3e, 0xe6, 0x36, 0x83, 0x2d, 0xca, 0xd1, 0xdf, 0x3a,
-	0xb0, 0x69, 0x7b, 0xb3, 0x66, 0x5c, 0x94, 0xe5, 0x73, 0xd7, 0xaf, 0x6c, 0xa6, 0x7c, 0xfe, 0xaa,
-	0xf5, 0x78, 0xc3, 0x5e, 0xe3, 0x52, 0xf8, 0xce, 0x2d, 0xc9, 0xb9, 0x97, 0x3b, 0x5d, 0x68, 0xbd,
-	0xe3, 0x59, 0x11, 0x49, 0x65, 0xfa, 0x9d, 0xde, 0x60, 0x36, 0x44, 0x05, 0x3c, 0xba, 0xd1, 0x44,
-	0xfd, 0x74, 0x3c, 0x91, 0xdd, 0x7a, 0xaf, 0x66, 0x92, 0x63, 0x3a, 0xcb, 0xd2, 0xcc, 0x64, 0x00,
-	0x09, 0x7a, 0x08, 0x8d, 0x93, 0x74, 0x92, 0xc6, 0xe9, 0xc5, 0x7c, 0xcd, 0xc8, 0x08, 0xa0, 0xae,
-	0x9e, 0x06, 0x35, 0xa2, 0x9a, 0xcc, 0x90, 0xf4, 0x13, 0xd9, 0xef, 0x21, 0x8f, 0xc3, 0x69, 0xcc,
-	0x0b, 0x81, 0x4b, 0x3e, 0x82, 0x5f, 0xa7, 0x7c, 0xa4, 0xa6, 0x82, 0xbe, 0x5a, 0xf4, 0x97, 0xba,
-	0x01, 0x39, 0x86, 0x63, 0x3d, 0x41, 0x2f, 0x42, 0x7b, 0xd7, 0x52, 0x14, 0xf9, 0x31, 0xb4, 0x2c,
-	0x69, 0x7b, 0x81, 0xb3, 0x60, 0x66, 0xcb, 0xd0, 0xbf, 0x3a, 0x0b, 0x67, 0x6e, 0xbc, 0xb9, 0xda,
-	0xd4, 0xb5, 0x4a, 0x52, 0x83, 0x69, 0x4a, 0x86, 0x7e, 0x34, 0x0b, 0xe3, 0x69, 0x2e, 0x59, 0xfa,
-	0xc1, 0x2d, 0x01, 0x19, 0xba, 0xfc, 0x1d, 0x97, 0x4e, 0xcd, 0x72, 0x63, 0x48, 0xf9, 0x8b, 0x6f,
-	0x20, 0xf8, 0x28, 0x8e, 0x12, 0x81, 0xfd, 0xe2, 0xb1, 0x92, 0x26, 0xcf, 0xd5, 0x8c, 0x35, 0x8d,
-	0xbe, 0xb3, 0xe4, 0x38, 0xf2, 0xd4, 0xe4, 0xcd, 0x29, 0x81, 0xce, 0x32, 0x8b, 0xee, 0x00, 0x51,
-	0x1d, 0xf0, 0xe2, 0x3c, 0xcd, 0xcc, 0x6b, 0x4b, 0xfb, 0x66, 0xb8, 0xc8, 0xec, 0xaf, 0x7b, 0xc4,
-	0xab, 0xcc, 0xba, 0x76, 0x66, 0xe9, 0x2f, 0x60, 0x4b, 0xef, 0x76, 0x22, 0xc3, 0x86, 0x96, 0x09,
-	0x60, 0x22, 0x4c, 0xe5, 0x9a, 0x68, 0x7e, 0x9a, 0x55, 0x80, 0xd4, 0x83, 0x8b, 0xae, 0x79, 0x9d,
-	0x34, 0x85, 0xbb, 0x51, 0x74, 0x91, 0x88, 0x11, 0xbe, 0x18, 0x1e, 0xd3, 0x14, 0xfd, 0x93, 0x0b,
-	0x3b, 0x6a, 0xe9, 0x4c, 0x2e, 0x44, 0x5e, 0x54, 0x66, 0x70, 0xad, 0xc6, 0xf9, 0x5f, 0xae, 0xd5,
-	0xf8, 0x02, 0x3c, 0x85, 0xad, 0x7e, 0x2c, 0x78, 0x56, 0xf9, 0xa0, 0x0c, 0x2d, 0xa1, 0xf2, 0xde,
-	0x20, 0xa2, 0x9f, 0x67, 0xb5, 0x84, 0xda, 0x10, 0x39, 0x84, 0x86, 0x0e, 0xcd, 0x0c, 0xc4, 0xa7,
-	0xf8, 0x4a, 0xad, 0xf0, 0xc6, 0xec, 0xb7, 0xb9, 0xfe, 0x21, 0x69, 0xc8, 0xdd, 0xb7, 0xd0, 0x5e,
-	0x60, 0xad, 0xf8, 0x21, 0xd9, 0xb3, 0x7f, 0x48, 0xb6, 0x0e, 0x88, 0xb5, 0x2e, 0x6b, 0xed, 0xf6,
-	0x8f, 0xcb, 0x3e, 0x7c, 0x7b, 0x95, 0x03, 0x39, 0x79, 0x0e, 0x9e, 0x74, 0x54, 0x2d, 0xc3, 0xc1,
-	0x6d, 0x8e, 0x32, 0x29, 0x44, 0xff, 0xe2, 0xe8, 0xa4, 0x0a, 0xcd, 0x37, 0x7f, 0x08, 0x7c, 0x66,
-	0x2b, 0x79, 0x52, 0x2a, 0x59, 0x12, 0xdb, 0x2f, 0x03, 0x95, 0xd2, 0xbb, 0xdf, 0x40, 0x63, 0x55,
-	0x78, 0xbe, 0x0a, 0xef, 0x47, 0x8b, 0xe1, 0x3d, 0xba, 0xcd, 0xb3, 0xdc, 0x8a, 0xf2, 0xb0, 0xf3,
-	0xf7, 0x8f, 0x7b, 0xce, 0x3f, 0x3f, 0xee, 0x39, 0xff, 0xfa, 0xb8, 0xe7, 0xfc, 0xf9, 0xdf, 0x7b,
-	0xdf, 0x3a, 0xaf, 0xe1, 0xff, 0x5e, 0x9f, 0xfd, 0x27, 0x00, 0x00, 0xff, 0xff, 0x76, 0x11, 0x06,
-	0x2f, 0x1a, 0x13, 0x00, 0x00,
+func (m *ResizeAbortMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *IndexMeta) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ResizeAbortMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResizeAbortMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *ResizeAbortMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResizeAbortMessage.Merge(m, src)
+}
+func (m *ResizeAbortMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResizeAbortMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResizeAbortMessage.DiscardUnknown(m)
 }
 
-func (m *IndexMeta) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_ResizeAbortMessage proto.InternalMessageInfo
+
+type ResizeNodeMessage struct {
+	NodeID               string   `protobuf:"bytes,1,opt,name=NodeID,proto3" json:"NodeID,omitempty"`
+	Action               string   `protobuf:"bytes,2,opt,name=Action,proto3" json:"Action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *IndexMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.TrackExistence {
-		i--
-		if m.TrackExistence {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Keys {
-		i--
-		if m.Keys {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+func (m *ResizeNodeMessage) Reset()         { *m = ResizeNodeMessage{} }
+func (m *ResizeNodeMessage) String() string { return proto.CompactTextString(m) }
+func (*ResizeNodeMessage) ProtoMessage()    {}
+func (*ResizeNodeMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{47}
+}
+func (m *ResizeNodeMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResizeNodeMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResizeNodeMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x18
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ResizeNodeMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResizeNodeMessage.Merge(m, src)
+}
+func (m *ResizeNodeMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResizeNodeMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResizeNodeMessage.DiscardUnknown(m)
 }
 
-func (m *FieldOptions) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ResizeNodeMessage proto.InternalMessageInfo
+
+func (m *ResizeNodeMessage) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *FieldOptions) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ResizeNodeMessage) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
 }
 
-func (m *FieldOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.TTL) > 0 {
-		i -= len(m.TTL)
-		copy(dAtA[i:], m.TTL)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.TTL)))
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0xa2
-	}
-	if len(m.TimeUnit) > 0 {
-		i -= len(m.TimeUnit)
-		copy(dAtA[i:], m.TimeUnit)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.TimeUnit)))
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0x9a
-	}
-	if m.Max != nil {
-		{
-			size, err := m.Max.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0x92
-	}
-	if m.Min != nil {
-		{
-			size, err := m.Min.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0x8a
-	}
-	if len(m.ForeignIndex) > 0 {
-		i -= len(m.ForeignIndex)
-		copy(dAtA[i:], m.ForeignIndex)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.ForeignIndex)))
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0x82
-	}
-	if m.Scale != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Scale))
-		i--
-		dAtA[i] = 0x78
-	}
-	if m.BitDepth != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.BitDepth))
-		i--
-		dAtA[i] = 0x70
-	}
-	if m.Base != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Base))
-		i--
-		dAtA[i] = 0x68
-	}
-	if m.NoStandardView {
-		i--
-		if m.NoStandardView {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x60
-	}
-	if m.Keys {
-		i--
-		if m.Keys {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x58
-	}
-	if m.OldMax != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.OldMax))
-		i--
-		dAtA[i] = 0x50
-	}
-	if m.OldMin != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.OldMin))
-		i--
-		dAtA[i] = 0x48
-	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Type)))
-		i--
-		dAtA[i] = 0x42
-	}
-	if len(m.TimeQuantum) > 0 {
-		i -= len(m.TimeQuantum)
-		copy(dAtA[i:], m.TimeQuantum)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.TimeQuantum)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if m.CacheSize != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.CacheSize))
-		i--
-		dAtA[i] = 0x20
-	}
-	if len(m.CacheType) > 0 {
-		i -= len(m.CacheType)
-		copy(dAtA[i:], m.CacheType)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.CacheType)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	return len(dAtA) - i, nil
+type FieldOperation struct {
+	RecordIDs            []uint64 `protobuf:"varint,1,rep,packed,name=RecordIDs,proto3" json:"RecordIDs,omitempty"`
+	Values               []uint64 `protobuf:"varint,2,rep,packed,name=Values,proto3" json:"Values,omitempty"`
+	Signed               []int64  `protobuf:"varint,3,rep,packed,name=Signed,proto3" json:"Signed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ImportResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *FieldOperation) Reset()         { *m = FieldOperation{} }
+func (m *FieldOperation) String() string { return proto.CompactTextString(m) }
+func (*FieldOperation) ProtoMessage()    {}
+func (*FieldOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{48}
+}
+func (m *FieldOperation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FieldOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FieldOperation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *ImportResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *FieldOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldOperation.Merge(m, src)
+}
+func (m *FieldOperation) XXX_Size() int {
+	return m.Size()
+}
+func (m *FieldOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldOperation.DiscardUnknown(m)
 }
 
-func (m *ImportResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_FieldOperation proto.InternalMessageInfo
+
+func (m *FieldOperation) GetRecordIDs() []uint64 {
+	if m != nil {
+		return m.RecordIDs
 	}
-	if len(m.Err) > 0 {
-		i -= len(m.Err)
-		copy(dAtA[i:], m.Err)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Err)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+func (m *FieldOperation) GetValues() []uint64 {
+	if m != nil {
+		return m.Values
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *BlockDataRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *FieldOperation) GetSigned() []int64 {
+	if m != nil {
+		return m.Signed
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *BlockDataRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ShardIngestOperation struct {
+	OpType               string                     `protobuf:"bytes,1,opt,name=OpType,proto3" json:"OpType,omitempty"`
+	ClearRecordIDs       []uint64                   `protobuf:"varint,2,rep,packed,name=ClearRecordIDs,proto3" json:"ClearRecordIDs,omitempty"`
+	ClearFields          []string                   `protobuf:"bytes,3,rep,name=ClearFields,proto3" json:"ClearFields,omitempty"`
+	FieldOps             map[string]*FieldOperation `protobuf:"bytes,4,rep,name=FieldOps,proto3" json:"FieldOps,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
 }
 
-func (m *BlockDataRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.View) > 0 {
-		i -= len(m.View)
-		copy(dAtA[i:], m.View)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.View)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if m.Shard != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Shard))
-		i--
-		dAtA[i] = 0x20
+func (m *ShardIngestOperation) Reset()         { *m = ShardIngestOperation{} }
+func (m *ShardIngestOperation) String() string { return proto.CompactTextString(m) }
+func (*ShardIngestOperation) ProtoMessage()    {}
+func (*ShardIngestOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{49}
+}
+func (m *ShardIngestOperation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardIngestOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardIngestOperation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Block != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Block))
-		i--
-		dAtA[i] = 0x18
+}
+func (m *ShardIngestOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardIngestOperation.Merge(m, src)
+}
+func (m *ShardIngestOperation) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardIngestOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardIngestOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardIngestOperation proto.InternalMessageInfo
+
+func (m *ShardIngestOperation) GetOpType() string {
+	if m != nil {
+		return m.OpType
 	}
-	if len(m.Field) > 0 {
-		i -= len(m.Field)
-		copy(dAtA[i:], m.Field)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
-		i--
-		dAtA[i] = 0x12
+	return ""
+}
+
+func (m *ShardIngestOperation) GetClearRecordIDs() []uint64 {
+	if m != nil {
+		return m.ClearRecordIDs
 	}
-	if len(m.Index) > 0 {
-		i -= len(m.Index)
-		copy(dAtA[i:], m.Index)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+func (m *ShardIngestOperation) GetClearFields() []string {
+	if m != nil {
+		return m.ClearFields
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *BlockDataResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ShardIngestOperation) GetFieldOps() map[string]*FieldOperation {
+	if m != nil {
+		return m.FieldOps
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *BlockDataResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ShardIngestOperations struct {
+	Ops                  []*ShardIngestOperation `protobuf:"bytes,1,rep,name=Ops,proto3" json:"Ops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
-func (m *BlockDataResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ColumnIDs) > 0 {
-		dAtA4 := make([]byte, len(m.ColumnIDs)*10)
-		var j3 int
-		for _, num := range m.ColumnIDs {
-			for num >= 1<<7 {
-				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j3++
-			}
-			dAtA4[j3] = uint8(num)
-			j3++
-		}
-		i -= j3
-		copy(dAtA[i:], dAtA4[:j3])
-		i = encodeVarintPrivate(dAtA, i, uint64(j3))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.RowIDs) > 0 {
-		dAtA6 := make([]byte, len(m.RowIDs)*10)
-		var j5 int
-		for _, num := range m.RowIDs {
-			for num >= 1<<7 {
-				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j5++
-			}
-			dAtA6[j5] = uint8(num)
-			j5++
+func (m *ShardIngestOperations) Reset()         { *m = ShardIngestOperations{} }
+func (m *ShardIngestOperations) String() string { return proto.CompactTextString(m) }
+func (*ShardIngestOperations) ProtoMessage()    {}
+func (*ShardIngestOperations) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{50}
+}
+func (m *ShardIngestOperations) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardIngestOperations) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardIngestOperations.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i -= j5
-		copy(dAtA[i:], dAtA6[:j5])
-		i = encodeVarintPrivate(dAtA, i, uint64(j5))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ShardIngestOperations) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardIngestOperations.Merge(m, src)
+}
+func (m *ShardIngestOperations) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardIngestOperations) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardIngestOperations.DiscardUnknown(m)
 }
 
-func (m *Cache) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ShardIngestOperations proto.InternalMessageInfo
+
+func (m *ShardIngestOperations) GetOps() []*ShardIngestOperation {
+	if m != nil {
+		return m.Ops
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Cache) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ShardedIngestRequest struct {
+	Ops                  map[uint64]*ShardIngestOperations `protobuf:"bytes,1,rep,name=Ops,proto3" json:"Ops,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
 }
 
-func (m *Cache) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.IDs) > 0 {
-		dAtA8 := make([]byte, len(m.IDs)*10)
-		var j7 int
-		for _, num := range m.IDs {
-			for num >= 1<<7 {
-				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j7++
-			}
-			dAtA8[j7] = uint8(num)
-			j7++
+func (m *ShardedIngestRequest) Reset()         { *m = ShardedIngestRequest{} }
+func (m *ShardedIngestRequest) String() string { return proto.CompactTextString(m) }
+func (*ShardedIngestRequest) ProtoMessage()    {}
+func (*ShardedIngestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{51}
+}
+func (m *ShardedIngestRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardedIngestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardedIngestRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i -= j7
-		copy(dAtA[i:], dAtA8[:j7])
-		i = encodeVarintPrivate(dAtA, i, uint64(j7))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ShardedIngestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardedIngestRequest.Merge(m, src)
+}
+func (m *ShardedIngestRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardedIngestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardedIngestRequest.DiscardUnknown(m)
 }
 
-func (m *MaxShards) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ShardedIngestRequest proto.InternalMessageInfo
+
+func (m *ShardedIngestRequest) GetOps() map[uint64]*ShardIngestOperations {
+	if m != nil {
+		return m.Ops
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *MaxShards) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SettingsMessage struct {
+	QueryTimeout         int64    `protobuf:"varint,1,opt,name=QueryTimeout,proto3" json:"QueryTimeout,omitempty"`
+	LongQueryTime        int64    `protobuf:"varint,2,opt,name=LongQueryTime,proto3" json:"LongQueryTime,omitempty"`
+	WorkerPoolSize       int64    `protobuf:"varint,3,opt,name=WorkerPoolSize,proto3" json:"WorkerPoolSize,omitempty"`
+	MaxWritesPerRequest  int64    `protobuf:"varint,4,opt,name=MaxWritesPerRequest,proto3" json:"MaxWritesPerRequest,omitempty"`
+	MaxQueryMemory       int64    `protobuf:"varint,5,opt,name=MaxQueryMemory,proto3" json:"MaxQueryMemory,omitempty"`
+	MaxBatchQueries      int64    `protobuf:"varint,6,opt,name=MaxBatchQueries,proto3" json:"MaxBatchQueries,omitempty"`
+	MaxBackgroundQueries int64    `protobuf:"varint,7,opt,name=MaxBackgroundQueries,proto3" json:"MaxBackgroundQueries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MaxShards) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Standard) > 0 {
-		for k := range m.Standard {
-			v := m.Standard[k]
-			baseI := i
-			i = encodeVarintPrivate(dAtA, i, uint64(v))
-			i--
-			dAtA[i] = 0x10
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintPrivate(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintPrivate(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0xa
+func (m *SettingsMessage) Reset()         { *m = SettingsMessage{} }
+func (m *SettingsMessage) String() string { return proto.CompactTextString(m) }
+func (*SettingsMessage) ProtoMessage()    {}
+func (*SettingsMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d2a91b51c7bdc125, []int{52}
+}
+func (m *SettingsMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SettingsMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SettingsMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *SettingsMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SettingsMessage.Merge(m, src)
+}
+func (m *SettingsMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *SettingsMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_SettingsMessage.DiscardUnknown(m)
 }
 
-func (m *CreateShardMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SettingsMessage proto.InternalMessageInfo
+
+func (m *SettingsMessage) GetQueryTimeout() int64 {
+	if m != nil {
+		return m.QueryTimeout
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *CreateShardMessage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SettingsMessage) GetLongQueryTime() int64 {
+	if m != nil {
+		return m.LongQueryTime
+	}
+	return 0
 }
 
-func (m *CreateShardMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *SettingsMessage) GetWorkerPoolSize() int64 {
+	if m != nil {
+		return m.WorkerPoolSize
 	}
-	if len(m.Field) > 0 {
-		i -= len(m.Field)
-		copy(dAtA[i:], m.Field)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
-		i--
-		dAtA[i] = 0x1a
+	return 0
+}
+
+func (m *SettingsMessage) GetMaxWritesPerRequest() int64 {
+	if m != nil {
+		return m.MaxWritesPerRequest
 	}
-	if m.Shard != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Shard))
-		i--
-		dAtA[i] = 0x10
+	return 0
+}
+
+func (m *SettingsMessage) GetMaxQueryMemory() int64 {
+	if m != nil {
+		return m.MaxQueryMemory
 	}
-	if len(m.Index) > 0 {
-		i -= len(m.Index)
-		copy(dAtA[i:], m.Index)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
-		i--
-		dAtA[i] = 0xa
+	return 0
+}
+
+func (m *SettingsMessage) GetMaxBatchQueries() int64 {
+	if m != nil {
+		return m.MaxBatchQueries
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *DeleteIndexMessage) Marshal() (dAtA []byte, err error) {
+func (m *SettingsMessage) GetMaxBackgroundQueries() int64 {
+	if m != nil {
+		return m.MaxBackgroundQueries
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*IndexMeta)(nil), "pb.IndexMeta")
+	proto.RegisterMapType((map[string]string)(nil), "pb.IndexMeta.TagsEntry")
+	proto.RegisterType((*Cube)(nil), "pb.Cube")
+	proto.RegisterType((*FieldOptions)(nil), "pb.FieldOptions")
+	proto.RegisterMapType((map[string]string)(nil), "pb.FieldOptions.TagsEntry")
+	proto.RegisterType((*ImportResponse)(nil), "pb.ImportResponse")
+	proto.RegisterType((*BlockDataRequest)(nil), "pb.BlockDataRequest")
+	proto.RegisterType((*BlockDataResponse)(nil), "pb.BlockDataResponse")
+	proto.RegisterType((*Cache)(nil), "pb.Cache")
+	proto.RegisterType((*MaxShards)(nil), "pb.MaxShards")
+	proto.RegisterMapType((map[string]uint64)(nil), "pb.MaxShards.StandardEntry")
+	proto.RegisterType((*CreateShardMessage)(nil), "pb.CreateShardMessage")
+	proto.RegisterType((*DeleteIndexMessage)(nil), "pb.DeleteIndexMessage")
+	proto.RegisterType((*CreateIndexMessage)(nil), "pb.CreateIndexMessage")
+	proto.RegisterType((*CreateFieldMessage)(nil), "pb.CreateFieldMessage")
+	proto.RegisterType((*UpdateFieldMessage)(nil), "pb.UpdateFieldMessage")
+	proto.RegisterType((*FieldUpdate)(nil), "pb.FieldUpdate")
+	proto.RegisterType((*DeleteFieldMessage)(nil), "pb.DeleteFieldMessage")
+	proto.RegisterType((*DeleteAvailableShardMessage)(nil), "pb.DeleteAvailableShardMessage")
+	proto.RegisterType((*SetRowMetaMessage)(nil), "pb.SetRowMetaMessage")
+	proto.RegisterType((*SetAliasMessage)(nil), "pb.SetAliasMessage")
+	proto.RegisterType((*CloneIndexMessage)(nil), "pb.CloneIndexMessage")
+	proto.RegisterType((*UpdateIndexMessage)(nil), "pb.UpdateIndexMessage")
+	proto.RegisterType((*IndexUpdate)(nil), "pb.IndexUpdate")
+	proto.RegisterType((*MaintenanceMessage)(nil), "pb.MaintenanceMessage")
+	proto.RegisterType((*Field)(nil), "pb.Field")
+	proto.RegisterType((*Schema)(nil), "pb.Schema")
+	proto.RegisterType((*Index)(nil), "pb.Index")
+	proto.RegisterType((*URI)(nil), "pb.URI")
+	proto.RegisterType((*Node)(nil), "pb.Node")
+	proto.RegisterType((*NodeStateMessage)(nil), "pb.NodeStateMessage")
+	proto.RegisterType((*NodeEventMessage)(nil), "pb.NodeEventMessage")
+	proto.RegisterType((*NodeStatus)(nil), "pb.NodeStatus")
+	proto.RegisterType((*IndexStatus)(nil), "pb.IndexStatus")
+	proto.RegisterType((*FieldStatus)(nil), "pb.FieldStatus")
+	proto.RegisterType((*ClusterStatus)(nil), "pb.ClusterStatus")
+	proto.RegisterType((*BSIGroup)(nil), "pb.BSIGroup")
+	proto.RegisterType((*CreateViewMessage)(nil), "pb.CreateViewMessage")
+	proto.RegisterType((*DeleteViewMessage)(nil), "pb.DeleteViewMessage")
+	proto.RegisterType((*ResizeInstruction)(nil), "pb.ResizeInstruction")
+	proto.RegisterType((*ResizeSource)(nil), "pb.ResizeSource")
+	proto.RegisterType((*TranslationResizeSource)(nil), "pb.TranslationResizeSource")
+	proto.RegisterType((*ResizeInstructionComplete)(nil), "pb.ResizeInstructionComplete")
+	proto.RegisterType((*Topology)(nil), "pb.Topology")
+	proto.RegisterType((*RecalculateCaches)(nil), "pb.RecalculateCaches")
+	proto.RegisterType((*LoadSchemaMessage)(nil), "pb.LoadSchemaMessage")
+	proto.RegisterType((*TransactionMessage)(nil), "pb.TransactionMessage")
+	proto.RegisterType((*Transaction)(nil), "pb.Transaction")
+	proto.RegisterType((*TransactionStats)(nil), "pb.TransactionStats")
+	proto.RegisterType((*ResizeAbortMessage)(nil), "pb.ResizeAbortMessage")
+	proto.RegisterType((*ResizeNodeMessage)(nil), "pb.ResizeNodeMessage")
+	proto.RegisterType((*FieldOperation)(nil), "pb.FieldOperation")
+	proto.RegisterType((*ShardIngestOperation)(nil), "pb.ShardIngestOperation")
+	proto.RegisterMapType((map[string]*FieldOperation)(nil), "pb.ShardIngestOperation.FieldOpsEntry")
+	proto.RegisterType((*ShardIngestOperations)(nil), "pb.ShardIngestOperations")
+	proto.RegisterType((*ShardedIngestRequest)(nil), "pb.ShardedIngestRequest")
+	proto.RegisterMapType((map[uint64]*ShardIngestOperations)(nil), "pb.ShardedIngestRequest.OpsEntry")
+	proto.RegisterType((*SettingsMessage)(nil), "pb.SettingsMessage")
+}
+
+func init() { proto.RegisterFile("private.proto", fileDescriptor_d2a91b51c7bdc125) }
+
+var fileDescriptor_d2a91b51c7bdc125 = []byte{
+	// 2300 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xdd, 0x52, 0x24, 0x49,
+	0xf5, 0xff, 0xf7, 0x07, 0x74, 0x73, 0x1a, 0x18, 0xc8, 0x65, 0x99, 0x1a, 0x66, 0x96, 0x3f, 0x53,
+	0x4e, 0xec, 0xe0, 0xb8, 0xa2, 0xb2, 0x17, 0x63, 0xb8, 0x61, 0xc4, 0x02, 0x0d, 0xbb, 0xed, 0x0e,
+	0x03, 0x93, 0xcd, 0xcc, 0x86, 0x5e, 0x68, 0x24, 0xd5, 0x19, 0x4d, 0x49, 0x51, 0xd5, 0x66, 0x55,
+	0x43, 0xf7, 0x5e, 0x18, 0xa1, 0xa1, 0xa1, 0x37, 0xde, 0x7b, 0xe5, 0x5b, 0x18, 0x5e, 0xec, 0x0b,
+	0x78, 0x63, 0x84, 0x8f, 0x60, 0x8c, 0x2f, 0x62, 0x9c, 0x93, 0x99, 0x55, 0xd9, 0x4d, 0x31, 0xb8,
+	0x84, 0x77, 0x7d, 0x7e, 0x27, 0xeb, 0xe4, 0xf9, 0x3e, 0x99, 0xd9, 0xb0, 0x30, 0x50, 0xe1, 0xa5,
+	0xc8, 0xe4, 0xd6, 0x40, 0x25, 0x59, 0xc2, 0xaa, 0x83, 0xd3, 0xb5, 0xf9, 0xc1, 0xf0, 0x34, 0x0a,
+	0x03, 0x8d, 0xf8, 0x7f, 0xab, 0xc1, 0x5c, 0x27, 0xee, 0xc9, 0xd1, 0xa1, 0xcc, 0x04, 0x63, 0x50,
+	0xff, 0x42, 0x8e, 0x53, 0xaf, 0xb6, 0x51, 0xd9, 0x6c, 0x72, 0xfa, 0xcd, 0x3e, 0x84, 0xc5, 0x13,
+	0x25, 0x82, 0xf3, 0xfd, 0x51, 0x98, 0x66, 0x32, 0x0e, 0xa4, 0x57, 0x27, 0xee, 0x14, 0xca, 0xd6,
+	0x01, 0x0e, 0xc5, 0x68, 0x2f, 0x89, 0x86, 0x17, 0x71, 0xea, 0xcd, 0x6c, 0x54, 0x36, 0xeb, 0xdc,
+	0x41, 0xd8, 0x23, 0x98, 0x3b, 0x14, 0xa3, 0xcf, 0x54, 0x32, 0x1c, 0xa4, 0xde, 0x2c, 0xb1, 0x0b,
+	0x80, 0x79, 0xd0, 0x38, 0x14, 0x23, 0x9e, 0x5c, 0xa5, 0x5e, 0x83, 0x78, 0x96, 0x64, 0x1b, 0xd0,
+	0x6a, 0xcb, 0x34, 0x50, 0xe1, 0x20, 0x0b, 0x93, 0xd8, 0x6b, 0x6e, 0x54, 0x36, 0xe7, 0xb8, 0x0b,
+	0xb1, 0x15, 0x98, 0x39, 0xba, 0x8a, 0xa5, 0xf2, 0xe6, 0x88, 0xa7, 0x09, 0xf6, 0x1d, 0xa8, 0x9f,
+	0x88, 0x7e, 0xea, 0xc1, 0x46, 0x6d, 0xb3, 0xb5, 0x7d, 0x7f, 0x6b, 0x70, 0xba, 0x95, 0x1b, 0xba,
+	0x85, 0x9c, 0xfd, 0x38, 0x53, 0x63, 0x4e, 0x8b, 0x50, 0xb9, 0x97, 0xe2, 0x42, 0xa6, 0x03, 0x11,
+	0x48, 0xaf, 0x45, 0x62, 0x0a, 0xc0, 0x98, 0xd6, 0xcd, 0x12, 0x25, 0xfa, 0xd2, 0x9b, 0xdf, 0xa8,
+	0x6c, 0xd6, 0xb8, 0x83, 0xb0, 0x35, 0x68, 0x72, 0x29, 0x7a, 0x47, 0x71, 0x34, 0xf6, 0x16, 0xc8,
+	0x39, 0x39, 0xcd, 0xd6, 0x61, 0x66, 0x6f, 0x78, 0x2a, 0x53, 0x6f, 0x91, 0xf4, 0x68, 0xa2, 0x1e,
+	0x08, 0x70, 0x0d, 0xaf, 0x3d, 0x87, 0xb9, 0x5c, 0x19, 0xb6, 0x04, 0xb5, 0x73, 0x39, 0xf6, 0x2a,
+	0xa4, 0x00, 0xfe, 0x44, 0xdb, 0x2e, 0x45, 0x34, 0x94, 0x5e, 0x55, 0xdb, 0x46, 0xc4, 0x8f, 0xaa,
+	0x3f, 0xac, 0xf8, 0xc7, 0x50, 0x47, 0x09, 0x18, 0x33, 0xd4, 0xd4, 0x7c, 0x44, 0xbf, 0xd9, 0x2a,
+	0xcc, 0x1e, 0x84, 0x32, 0xea, 0xa5, 0x5e, 0x75, 0xa3, 0xb6, 0x39, 0xc7, 0x0d, 0x85, 0x66, 0xee,
+	0xf4, 0xfb, 0x4a, 0xf6, 0x45, 0x26, 0x29, 0xc8, 0x73, 0xbc, 0x00, 0xfc, 0xaf, 0x1b, 0x30, 0x4f,
+	0x0b, 0x8f, 0xc8, 0xaf, 0x29, 0x8a, 0x3e, 0x19, 0x0f, 0xa4, 0xf1, 0x39, 0xfd, 0x46, 0x11, 0x7b,
+	0x22, 0x38, 0x93, 0xc4, 0x30, 0x22, 0x72, 0x20, 0xe7, 0x76, 0xc3, 0xaf, 0x74, 0x9e, 0x2c, 0xf0,
+	0x02, 0xc0, 0x50, 0x9e, 0x84, 0x17, 0xf2, 0xd5, 0x50, 0xc4, 0xd9, 0xf0, 0x82, 0x72, 0x64, 0x8e,
+	0xbb, 0x10, 0x2a, 0x7e, 0x14, 0xf5, 0x0e, 0xc3, 0x98, 0x62, 0x59, 0xe3, 0x86, 0xb2, 0xb8, 0x18,
+	0x79, 0x50, 0xe0, 0x62, 0x94, 0x27, 0x6c, 0x6b, 0x32, 0x61, 0x5f, 0x26, 0xdd, 0x4c, 0xc4, 0x3d,
+	0xa1, 0x7a, 0x6f, 0x42, 0x79, 0x45, 0x11, 0x6b, 0xf2, 0x29, 0x14, 0xbf, 0xdd, 0x15, 0xa9, 0xa4,
+	0x88, 0xd5, 0x38, 0xfd, 0xc6, 0x48, 0xee, 0x86, 0x59, 0x5b, 0x0e, 0xb2, 0x33, 0x6f, 0x91, 0xf2,
+	0x30, 0xa7, 0x31, 0x14, 0xdd, 0x40, 0x44, 0xd2, 0xbb, 0x47, 0x1f, 0x68, 0x82, 0xf9, 0x30, 0x7f,
+	0x90, 0x28, 0x19, 0xf6, 0x63, 0xca, 0x2e, 0x6f, 0x89, 0x8c, 0x9a, 0xc0, 0xd8, 0x07, 0x50, 0x43,
+	0x93, 0x96, 0x37, 0x2a, 0x9b, 0xad, 0xed, 0x16, 0x66, 0x40, 0x5b, 0x06, 0xe1, 0x85, 0x88, 0x38,
+	0xe2, 0xc4, 0x16, 0x23, 0x8f, 0x95, 0xb1, 0xc5, 0x08, 0x75, 0x42, 0x17, 0xbd, 0x8e, 0xc3, 0xcc,
+	0x7b, 0x8f, 0xa4, 0xe7, 0x34, 0x26, 0xcc, 0xc9, 0xc9, 0x0b, 0x6f, 0x45, 0x27, 0xcc, 0xc9, 0xc9,
+	0x8b, 0xe9, 0x72, 0x79, 0xff, 0x1d, 0xe5, 0xb2, 0xea, 0x96, 0xcb, 0x96, 0x29, 0x97, 0xfb, 0x94,
+	0xa6, 0x6b, 0xa8, 0x85, 0x9b, 0x0b, 0xd7, 0x2a, 0xc6, 0x87, 0xf9, 0x43, 0x79, 0x91, 0xa8, 0xf1,
+	0x71, 0x12, 0x85, 0xc1, 0xd8, 0xf3, 0xb4, 0xdd, 0x2e, 0xc6, 0x3e, 0x82, 0x65, 0x97, 0x46, 0xaf,
+	0xa7, 0xde, 0x03, 0xca, 0xc8, 0xeb, 0x0c, 0x8c, 0x9b, 0x4e, 0x95, 0x4c, 0x44, 0x32, 0x96, 0x69,
+	0xea, 0xad, 0x91, 0xcc, 0x29, 0x14, 0x73, 0xa1, 0x2d, 0x55, 0x78, 0x29, 0xbd, 0x87, 0xc4, 0x37,
+	0x14, 0xb6, 0x90, 0xcf, 0xc3, 0x34, 0x4b, 0xd4, 0xd8, 0x7b, 0x44, 0x0c, 0x4b, 0x22, 0x67, 0x4f,
+	0xa4, 0x81, 0xe8, 0x49, 0xef, 0x03, 0xcd, 0x31, 0x24, 0x7b, 0x02, 0x0b, 0xd4, 0xc6, 0xda, 0x61,
+	0x9a, 0x85, 0x71, 0x90, 0x79, 0xeb, 0x94, 0x2a, 0x93, 0xa0, 0x8d, 0xc0, 0xcf, 0x92, 0x58, 0x7a,
+	0xff, 0x5f, 0x44, 0x00, 0x69, 0xb6, 0x09, 0xf7, 0x0e, 0xc2, 0x34, 0x10, 0xd1, 0x4f, 0xa5, 0x50,
+	0xdd, 0x4c, 0xa8, 0xcc, 0xdb, 0xa0, 0xbc, 0x9f, 0x86, 0x71, 0x2f, 0x2e, 0x7f, 0x29, 0x83, 0xec,
+	0xa5, 0xbc, 0xa2, 0xa4, 0x7d, 0xac, 0xf7, 0x9a, 0x00, 0xef, 0xde, 0x0f, 0x7c, 0x58, 0xec, 0x5c,
+	0x0c, 0x12, 0x95, 0x71, 0x99, 0x0e, 0x92, 0x38, 0x95, 0xf8, 0xf5, 0xbe, 0x52, 0xf6, 0xeb, 0x7d,
+	0xa5, 0xfc, 0x5f, 0xc3, 0xd2, 0x6e, 0x94, 0x04, 0xe7, 0x6d, 0x91, 0x09, 0x2e, 0x7f, 0x35, 0x94,
+	0x69, 0x86, 0x12, 0x75, 0xe6, 0xea, 0x75, 0x9a, 0x40, 0x94, 0xc2, 0x6f, 0xf7, 0x21, 0x02, 0x4b,
+	0x86, 0x0a, 0x4a, 0x57, 0x2e, 0xfd, 0xa6, 0xb2, 0x38, 0x13, 0xaa, 0x47, 0xe5, 0x5e, 0xe7, 0x9a,
+	0x40, 0x94, 0x76, 0xa2, 0x16, 0x51, 0xe7, 0x9a, 0xf0, 0x3b, 0xb0, 0xec, 0xec, 0x6f, 0xd4, 0x5c,
+	0x85, 0x59, 0x9e, 0x5c, 0x75, 0xda, 0xa9, 0x57, 0xd9, 0xa8, 0x6d, 0xd6, 0xb9, 0xa1, 0xa8, 0x97,
+	0xd0, 0xec, 0xe8, 0xb4, 0x75, 0x1f, 0xab, 0xf3, 0x02, 0xf0, 0x1f, 0xc0, 0x0c, 0xe5, 0x05, 0x5a,
+	0x59, 0x7c, 0x8b, 0x3f, 0xfd, 0xdf, 0x54, 0x68, 0xd4, 0x90, 0x22, 0x29, 0x7b, 0x0e, 0x4d, 0x5b,
+	0xf6, 0xb4, 0xa8, 0xb5, 0xfd, 0x10, 0x93, 0x3b, 0x5f, 0xb0, 0x65, 0xb9, 0x3a, 0xbb, 0xf3, 0xc5,
+	0x6b, 0x9f, 0xc0, 0xc2, 0x04, 0xeb, 0xb6, 0x68, 0xd4, 0xdd, 0x68, 0xbc, 0x01, 0xb6, 0xa7, 0xa4,
+	0xc8, 0x24, 0x6d, 0x72, 0x28, 0xd3, 0x14, 0x07, 0xc5, 0x2d, 0xbe, 0xae, 0xb9, 0xbe, 0xce, 0xfd,
+	0x5a, 0x75, 0xfc, 0xea, 0x3f, 0x03, 0xd6, 0x96, 0x91, 0xcc, 0xa4, 0x99, 0x65, 0xef, 0x90, 0xeb,
+	0x9f, 0x5b, 0x1d, 0x6e, 0x5f, 0xcb, 0x1e, 0x43, 0x1d, 0x07, 0x23, 0x6d, 0xd6, 0xda, 0x5e, 0x98,
+	0x98, 0x96, 0x9c, 0x58, 0x14, 0x0f, 0x12, 0xd7, 0xdb, 0xc9, 0x48, 0xd5, 0x1a, 0x2f, 0x00, 0xff,
+	0x77, 0x15, 0xbb, 0x1b, 0xa9, 0xff, 0x5f, 0x5a, 0x3c, 0x91, 0x5d, 0x4f, 0x8c, 0x0e, 0x35, 0xd2,
+	0x61, 0x69, 0xba, 0x05, 0x95, 0xa9, 0x51, 0x9f, 0x56, 0xe3, 0xf7, 0x15, 0x60, 0xaf, 0x07, 0xbd,
+	0x69, 0x35, 0x0e, 0xca, 0x94, 0x23, 0x9d, 0x5a, 0xdb, 0xab, 0x34, 0x92, 0xaf, 0x71, 0x79, 0x99,
+	0x39, 0x4f, 0x61, 0x56, 0x4b, 0x37, 0x8e, 0xba, 0x97, 0x2b, 0xa9, 0x61, 0x6e, 0xd8, 0xfe, 0x27,
+	0xd0, 0x72, 0x60, 0x9a, 0x5f, 0xba, 0x21, 0x6b, 0x3f, 0x18, 0x0a, 0x1d, 0xf1, 0xc6, 0x2d, 0x67,
+	0x22, 0xfc, 0x4f, 0x6d, 0x90, 0xef, 0xea, 0x4a, 0x3f, 0x80, 0x87, 0x5a, 0xc2, 0xce, 0xa5, 0x08,
+	0x23, 0x71, 0x1a, 0x7d, 0xa3, 0x3c, 0x9c, 0x88, 0x8a, 0x07, 0x0d, 0xfa, 0xb6, 0xd3, 0x36, 0xb5,
+	0x6c, 0x49, 0x5f, 0xc2, 0x72, 0x57, 0x66, 0x3c, 0xb9, 0xc2, 0xb8, 0xdc, 0x45, 0xf4, 0x12, 0xd4,
+	0x78, 0x72, 0x65, 0xd2, 0x1e, 0x7f, 0x62, 0x83, 0xa1, 0x14, 0xc0, 0xb8, 0xce, 0xeb, 0x80, 0xfb,
+	0x3f, 0x86, 0x7b, 0x5d, 0x99, 0xed, 0x44, 0xa1, 0x48, 0x9d, 0x4d, 0x88, 0xb6, 0x9b, 0x10, 0x51,
+	0x6c, 0x5d, 0x75, 0xab, 0x60, 0x0f, 0x96, 0xf7, 0xa2, 0x24, 0x9e, 0x2c, 0x82, 0x55, 0x98, 0xed,
+	0x26, 0x43, 0x15, 0xd8, 0x63, 0x93, 0xa1, 0x10, 0x3f, 0x11, 0xaa, 0x2f, 0x33, 0x23, 0xc3, 0x50,
+	0x4e, 0x5a, 0x4d, 0x88, 0x39, 0x28, 0xab, 0xb0, 0xeb, 0x69, 0xe5, 0x72, 0x79, 0x59, 0x4d, 0x96,
+	0xa6, 0x15, 0xad, 0xb8, 0x9e, 0x56, 0x0e, 0xfc, 0x0d, 0xd3, 0xea, 0x23, 0x60, 0x87, 0x22, 0x8c,
+	0x33, 0x19, 0x8b, 0x38, 0x90, 0x8e, 0x2b, 0xb8, 0x14, 0x69, 0x21, 0x43, 0x53, 0xfe, 0x10, 0x8a,
+	0xa6, 0x7f, 0xed, 0x80, 0xf9, 0x64, 0xa2, 0x5d, 0xdc, 0x54, 0xaa, 0xa8, 0x06, 0xcd, 0xfc, 0x1a,
+	0xcd, 0x7c, 0x4d, 0xdc, 0x52, 0xc0, 0xdf, 0x85, 0xd9, 0x6e, 0x70, 0x26, 0x2f, 0x04, 0xfb, 0x16,
+	0x34, 0xc8, 0x56, 0x99, 0x9a, 0xbe, 0x3d, 0x97, 0x7b, 0x85, 0x5b, 0x0e, 0x06, 0xc6, 0xa4, 0x58,
+	0x99, 0x9a, 0x13, 0x5b, 0x55, 0xa7, 0xb6, 0x62, 0x4f, 0xa1, 0x61, 0xf4, 0xf5, 0x66, 0xca, 0xda,
+	0x9e, 0xe5, 0xb2, 0xc7, 0xf9, 0x71, 0xba, 0x5e, 0x28, 0x42, 0x88, 0x3d, 0x59, 0xfb, 0xfb, 0x50,
+	0x7b, 0xcd, 0x3b, 0x6c, 0xd5, 0x68, 0x5f, 0xe4, 0x15, 0x51, 0xa8, 0xdc, 0xe7, 0x49, 0x6a, 0xb3,
+	0x8a, 0x7e, 0x23, 0x76, 0x9c, 0x28, 0xdd, 0x4a, 0x17, 0x38, 0xfd, 0xf6, 0xff, 0x58, 0x81, 0xfa,
+	0xcb, 0xa4, 0x27, 0xd9, 0x22, 0x54, 0x3b, 0x6d, 0x23, 0xa4, 0xda, 0x69, 0xb3, 0x07, 0x24, 0xdf,
+	0xf8, 0xbb, 0x81, 0xfb, 0xbf, 0xe6, 0x1d, 0x4e, 0x7b, 0x3e, 0x82, 0xb9, 0x4e, 0x7a, 0xac, 0xc2,
+	0x0b, 0xa1, 0xc6, 0xe6, 0xe6, 0x56, 0x00, 0x34, 0x46, 0x32, 0xcc, 0xac, 0xba, 0x4e, 0x05, 0x22,
+	0xd8, 0x63, 0x68, 0x7c, 0xc6, 0x8f, 0xf7, 0x50, 0xe4, 0xcc, 0xa4, 0x48, 0x8b, 0xfb, 0x9f, 0xc2,
+	0x12, 0x6a, 0x42, 0xeb, 0x9d, 0x5c, 0x41, 0x2c, 0xd7, 0xcc, 0x50, 0xc5, 0x26, 0x55, 0x67, 0x13,
+	0xff, 0x40, 0x4b, 0xd8, 0xbf, 0x94, 0x71, 0xe6, 0x54, 0x2e, 0xd1, 0x24, 0x60, 0x81, 0x6b, 0x82,
+	0x3d, 0xd2, 0x56, 0x1b, 0xf3, 0xe8, 0x8e, 0x84, 0x34, 0x27, 0xd4, 0x1f, 0x03, 0x58, 0x4d, 0x86,
+	0x69, 0xbe, 0xb6, 0x52, 0xb6, 0x96, 0xf9, 0x36, 0x7d, 0xcc, 0x14, 0x01, 0xe4, 0x6b, 0xc4, 0x04,
+	0x43, 0xb0, 0x6f, 0x17, 0x89, 0xa5, 0xe3, 0x59, 0x94, 0x9b, 0xde, 0xa3, 0x48, 0xaf, 0x33, 0x68,
+	0x39, 0x78, 0x69, 0x8e, 0x3d, 0x9d, 0xb8, 0x6b, 0xb9, 0x23, 0xc1, 0x08, 0x73, 0x2e, 0x5f, 0xef,
+	0x98, 0x9f, 0x21, 0xb4, 0x9c, 0x8f, 0x4a, 0x77, 0xda, 0x84, 0x7b, 0x93, 0xed, 0xdc, 0x1e, 0x8b,
+	0xa6, 0xe1, 0x5b, 0xb6, 0xfa, 0x43, 0x05, 0x16, 0xf6, 0xa2, 0x61, 0x9a, 0x49, 0x95, 0xfb, 0x74,
+	0xce, 0x00, 0x79, 0x68, 0x0b, 0xa0, 0x3c, 0xba, 0x78, 0xb1, 0x45, 0x8f, 0xeb, 0xe2, 0x76, 0x03,
+	0xa1, 0x61, 0x27, 0x12, 0xf5, 0x9b, 0x22, 0xe1, 0xbf, 0x81, 0xe6, 0x6e, 0xb7, 0x43, 0x4f, 0x00,
+	0xa5, 0x16, 0xdb, 0x0b, 0x68, 0xd5, 0xb9, 0x80, 0x2e, 0xe9, 0xcb, 0x94, 0xb6, 0x0a, 0x7f, 0x12,
+	0x22, 0x46, 0xa6, 0x95, 0xe0, 0x4f, 0xbf, 0x0b, 0xcb, 0xda, 0x5c, 0xec, 0x38, 0x77, 0x99, 0x4c,
+	0xf6, 0xa0, 0x5b, 0x2b, 0x0e, 0xba, 0x28, 0x54, 0xcf, 0xd4, 0xff, 0xa5, 0xd0, 0x7f, 0x54, 0x61,
+	0x99, 0xcb, 0x34, 0xfc, 0x4a, 0x76, 0xe2, 0x34, 0x53, 0xc3, 0xc0, 0xf6, 0xef, 0x9f, 0x24, 0xa7,
+	0x26, 0x16, 0x35, 0xae, 0x89, 0x77, 0x57, 0x09, 0xf3, 0xa1, 0xe1, 0x36, 0x01, 0x77, 0x81, 0x65,
+	0xb0, 0x67, 0xd0, 0xd0, 0x83, 0xce, 0x66, 0x3e, 0x75, 0x6e, 0xbd, 0xbf, 0x66, 0x70, 0xbb, 0x80,
+	0x7d, 0x01, 0xec, 0x44, 0x89, 0x38, 0x8d, 0x04, 0xaa, 0x64, 0x3f, 0x6b, 0x16, 0x27, 0x68, 0x87,
+	0x3b, 0x21, 0xa1, 0xe4, 0x33, 0xb6, 0xe5, 0x96, 0x30, 0xbd, 0xf0, 0xb4, 0xb6, 0x17, 0xad, 0x7e,
+	0x1a, 0xe5, 0x6e, 0x91, 0x3f, 0x9f, 0xca, 0x50, 0x7a, 0x30, 0x6a, 0x6d, 0x2f, 0xd3, 0x4c, 0x75,
+	0x19, 0x7c, 0x72, 0x9d, 0xff, 0xdb, 0x0a, 0xcc, 0xbb, 0xda, 0xdc, 0xd2, 0x2e, 0x4a, 0x8f, 0x0c,
+	0x37, 0x1c, 0xc8, 0x6d, 0xf8, 0xea, 0x65, 0x97, 0x9f, 0x19, 0xf7, 0x90, 0x9e, 0xc0, 0xfd, 0x1b,
+	0x9c, 0x73, 0x27, 0x75, 0x36, 0xa0, 0x75, 0x2c, 0x54, 0x16, 0xa2, 0x30, 0x73, 0x0a, 0x9b, 0xe1,
+	0x2e, 0xe4, 0x4b, 0x78, 0x70, 0x2d, 0x89, 0xf6, 0x92, 0x8b, 0x01, 0x66, 0xeb, 0x9d, 0x92, 0x09,
+	0xdb, 0xb4, 0x52, 0x89, 0xb2, 0x1e, 0x20, 0xc2, 0xdf, 0x85, 0xe6, 0x49, 0x32, 0x48, 0xa2, 0xa4,
+	0x3f, 0xbe, 0xa5, 0x65, 0x78, 0xd0, 0xd0, 0xa3, 0xc1, 0xbe, 0x40, 0x59, 0xd2, 0x7f, 0x0f, 0xf3,
+	0x3d, 0x10, 0x51, 0x30, 0x8c, 0x44, 0x26, 0xe9, 0x0a, 0x47, 0xe0, 0x8b, 0x44, 0xf4, 0x74, 0x57,
+	0x30, 0xa5, 0xe5, 0xff, 0xc2, 0x24, 0xa0, 0x20, 0x73, 0x9c, 0x11, 0xb4, 0x13, 0xb8, 0x47, 0x1e,
+	0x4d, 0xb1, 0x1f, 0x40, 0xcb, 0x59, 0xed, 0x9e, 0xa3, 0x1c, 0x98, 0xbb, 0x6b, 0xfc, 0xbf, 0x56,
+	0x26, 0xbe, 0xb9, 0x36, 0x73, 0xcd, 0x56, 0x97, 0xda, 0x49, 0x4d, 0x6e, 0x28, 0x34, 0x7d, 0x7f,
+	0x14, 0x44, 0xc3, 0x14, 0x59, 0x66, 0xe0, 0xe6, 0x00, 0x9a, 0x8e, 0x8f, 0x03, 0xc9, 0xd0, 0x1e,
+	0x6e, 0x2c, 0x89, 0xcf, 0x08, 0x6d, 0x29, 0x7a, 0x51, 0x18, 0x4b, 0xca, 0x97, 0x1a, 0xcf, 0x69,
+	0xf6, 0x4c, 0xf7, 0x58, 0x9b, 0xe8, 0x2b, 0x53, 0x8a, 0x13, 0x4f, 0x77, 0xde, 0xd4, 0x67, 0xb0,
+	0x34, 0xcd, 0xf2, 0x57, 0x80, 0xe9, 0x0c, 0xd8, 0x39, 0x4d, 0x94, 0x9d, 0xb6, 0x78, 0xf6, 0xd5,
+	0x28, 0x7a, 0xff, 0xb6, 0x21, 0x5e, 0x78, 0xb6, 0xea, 0x7a, 0xd6, 0xff, 0x39, 0x2c, 0x9a, 0xb3,
+	0x9d, 0x54, 0x94, 0xd0, 0xe8, 0x00, 0x2e, 0x83, 0x04, 0x2f, 0x01, 0xf6, 0xe2, 0x5d, 0x00, 0x28,
+	0x87, 0xce, 0x9b, 0x76, 0x3a, 0x19, 0x0a, 0xf1, 0x6e, 0xd8, 0x8f, 0x65, 0x8f, 0x26, 0x46, 0x8d,
+	0x1b, 0xca, 0xff, 0x53, 0x15, 0x56, 0xf4, 0x95, 0x22, 0xee, 0xcb, 0x34, 0x2b, 0xb6, 0xa1, 0xd3,
+	0x2d, 0xf5, 0xff, 0xfc, 0x74, 0x8b, 0x14, 0x3d, 0x14, 0x45, 0x52, 0xa8, 0x42, 0x07, 0xbd, 0xd1,
+	0x14, 0x8a, 0x75, 0x43, 0x88, 0x19, 0xcf, 0xfa, 0x10, 0xea, 0x42, 0x6c, 0x17, 0x9a, 0xc6, 0x34,
+	0xdb, 0x10, 0x3f, 0xa4, 0x29, 0x55, 0xa2, 0x8d, 0x3d, 0xdf, 0x9a, 0x47, 0xb0, 0xfc, 0xbb, 0xb5,
+	0x23, 0x58, 0x98, 0x60, 0x95, 0x3c, 0x13, 0x6c, 0xba, 0xcf, 0x04, 0xad, 0x6d, 0xe6, 0x1c, 0x97,
+	0x8d, 0x74, 0xf7, 0xe9, 0x60, 0x0f, 0xde, 0x2f, 0x53, 0x20, 0x65, 0xcf, 0xa0, 0x76, 0x34, 0xd0,
+	0x0e, 0x6f, 0x6d, 0x7b, 0x37, 0x29, 0xca, 0x71, 0x91, 0xff, 0x97, 0x8a, 0x71, 0xaa, 0x34, 0x7c,
+	0xfb, 0xdc, 0xf3, 0xb1, 0x2b, 0xe4, 0x71, 0x2e, 0x64, 0x6a, 0xd9, 0x56, 0x6e, 0x28, 0xae, 0x5e,
+	0x7b, 0x05, 0xcd, 0x32, 0xf3, 0xea, 0xda, 0xbc, 0xef, 0x4d, 0x9a, 0xf7, 0xe0, 0x26, 0xcd, 0x52,
+	0xd7, 0xca, 0xaf, 0xab, 0x74, 0xad, 0xcb, 0xc2, 0xb8, 0x9f, 0x5f, 0xeb, 0x7c, 0x98, 0x7f, 0x35,
+	0x94, 0x6a, 0x6c, 0xeb, 0x47, 0x37, 0xac, 0x09, 0x0c, 0x5f, 0xd1, 0x5e, 0x24, 0x71, 0x3f, 0xc7,
+	0xcc, 0xb1, 0x7e, 0x12, 0xc4, 0x14, 0xf9, 0x32, 0x51, 0xe7, 0x52, 0x1d, 0x27, 0x49, 0x44, 0x8f,
+	0xd1, 0xfa, 0xbc, 0x30, 0x85, 0xb2, 0xef, 0xc3, 0x7b, 0x87, 0x62, 0xf4, 0xa5, 0x0a, 0x33, 0x99,
+	0x1e, 0x4b, 0x65, 0xac, 0x37, 0x85, 0x5b, 0xc6, 0x42, 0xc9, 0x87, 0x62, 0x44, 0x3b, 0xe9, 0x27,
+	0x4c, 0x53, 0xca, 0x53, 0x28, 0x1e, 0xd6, 0x0e, 0xc5, 0x68, 0x57, 0x64, 0xc1, 0x19, 0xc2, 0xa1,
+	0xd4, 0xa5, 0x5d, 0xe3, 0xd3, 0x30, 0xdb, 0x86, 0x15, 0x82, 0x82, 0xf3, 0xbe, 0x4a, 0x86, 0x71,
+	0xcf, 0x2e, 0x6f, 0xd0, 0xf2, 0x52, 0xde, 0xee, 0xd2, 0xdf, 0xdf, 0xae, 0x57, 0xfe, 0xf9, 0x76,
+	0xbd, 0xf2, 0xaf, 0xb7, 0xeb, 0x95, 0x3f, 0xff, 0x7b, 0xfd, 0xff, 0x4e, 0x67, 0xe9, 0xff, 0x9c,
+	0x8f, 0xff, 0x33, 0x00, 0xaf, 0x88, 0x98, 0x3f, 0xf2, 0x19, 0x00, 0x00,
+}
+
+func (m *IndexMeta) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3489,12 +3726,12 @@ func (m *DeleteIndexMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteIndexMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *IndexMeta) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteIndexMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *IndexMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3503,17 +3740,114 @@ func (m *DeleteIndexMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Index) > 0 {
-		i -= len(m.Index)
-		copy(dAtA[i:], m.Index)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+	if len(m.Cubes) > 0 {
+		for iNdEx := len(m.Cubes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Cubes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPrivate(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x72
+		}
+	}
+	if m.ReadOnly {
 		i--
-		dAtA[i] = 0xa
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.MaxStorage != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.MaxStorage))
+		i--
+		dAtA[i] = 0x60
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.Tags) > 0 {
+		for k := range m.Tags {
+			v := m.Tags[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintPrivate(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintPrivate(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintPrivate(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.MaxRows != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.MaxRows))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.MaxGroups != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.MaxGroups))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.MaxColumns != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.MaxColumns))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.TrackExistence {
+		i--
+		if m.TrackExistence {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Keys {
+		i--
+		if m.Keys {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *CreateIndexMessage) Marshal() (dAtA []byte, err error) {
+func (m *Cube) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3523,12 +3857,12 @@ func (m *CreateIndexMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateIndexMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *Cube) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CreateIndexMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Cube) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3537,34 +3871,33 @@ func (m *CreateIndexMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CreatedAt != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
+	if len(m.Aggregate) > 0 {
+		i -= len(m.Aggregate)
+		copy(dAtA[i:], m.Aggregate)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Aggregate)))
 		i--
-		dAtA[i] = 0x18
+		dAtA[i] = 0x1a
 	}
-	if m.Meta != nil {
-		{
-			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
+	if len(m.Fields) > 0 {
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Fields[iNdEx])
+			copy(dAtA[i:], m.Fields[iNdEx])
+			i = encodeVarintPrivate(dAtA, i, uint64(len(m.Fields[iNdEx])))
+			i--
+			dAtA[i] = 0x12
 		}
-		i--
-		dAtA[i] = 0x12
 	}
-	if len(m.Index) > 0 {
-		i -= len(m.Index)
-		copy(dAtA[i:], m.Index)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *CreateFieldMessage) Marshal() (dAtA []byte, err error) {
+func (m *FieldOptions) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3574,12 +3907,12 @@ func (m *CreateFieldMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateFieldMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *FieldOptions) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CreateFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *FieldOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3588,67 +3921,162 @@ func (m *CreateFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CreatedAt != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
+	if m.RejectNewKeys {
 		i--
-		dAtA[i] = 0x20
+		if m.RejectNewKeys {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x88
 	}
-	if m.Meta != nil {
-		{
-			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
+	if m.FiscalYearStart != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.FiscalYearStart))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x80
+	}
+	if len(m.TimeZone) > 0 {
+		i -= len(m.TimeZone)
+		copy(dAtA[i:], m.TimeZone)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.TimeZone)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xfa
+	}
+	if m.TrackDistinct {
+		i--
+		if m.TrackDistinct {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf0
 	}
-	if len(m.Field) > 0 {
-		i -= len(m.Field)
-		copy(dAtA[i:], m.Field)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
+	if len(m.Cascade) > 0 {
+		i -= len(m.Cascade)
+		copy(dAtA[i:], m.Cascade)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Cascade)))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xea
 	}
-	if len(m.Index) > 0 {
-		i -= len(m.Index)
-		copy(dAtA[i:], m.Index)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+	if len(m.History) > 0 {
+		i -= len(m.History)
+		copy(dAtA[i:], m.History)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.History)))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe2
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *UpdateFieldMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if len(m.Derive) > 0 {
+		i -= len(m.Derive)
+		copy(dAtA[i:], m.Derive)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Derive)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xda
 	}
-	return dAtA[:n], nil
-}
-
-func (m *UpdateFieldMessage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *UpdateFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.CacheStaleness) > 0 {
+		i -= len(m.CacheStaleness)
+		copy(dAtA[i:], m.CacheStaleness)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.CacheStaleness)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd2
 	}
-	if m.Update != nil {
+	if len(m.MemoryPolicyViews) > 0 {
+		for iNdEx := len(m.MemoryPolicyViews) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.MemoryPolicyViews[iNdEx])
+			copy(dAtA[i:], m.MemoryPolicyViews[iNdEx])
+			i = encodeVarintPrivate(dAtA, i, uint64(len(m.MemoryPolicyViews[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xca
+		}
+	}
+	if len(m.MemoryPolicy) > 0 {
+		i -= len(m.MemoryPolicy)
+		copy(dAtA[i:], m.MemoryPolicy)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.MemoryPolicy)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc2
+	}
+	if len(m.Tags) > 0 {
+		for k := range m.Tags {
+			v := m.Tags[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintPrivate(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintPrivate(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintPrivate(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xba
+		}
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb2
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xaa
+	}
+	if len(m.TTL) > 0 {
+		i -= len(m.TTL)
+		copy(dAtA[i:], m.TTL)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.TTL)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	if len(m.TimeUnit) > 0 {
+		i -= len(m.TimeUnit)
+		copy(dAtA[i:], m.TimeUnit)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.TimeUnit)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if m.Max != nil {
 		{
-			size, err := m.Update.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Max.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3656,11 +4084,13 @@ func (m *UpdateFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintPrivate(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
 	}
-	if m.CreateFieldMessage != nil {
+	if m.Min != nil {
 		{
-			size, err := m.CreateFieldMessage.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Min.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3668,12 +4098,94 @@ func (m *UpdateFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintPrivate(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if len(m.ForeignIndex) > 0 {
+		i -= len(m.ForeignIndex)
+		copy(dAtA[i:], m.ForeignIndex)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.ForeignIndex)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.Scale != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Scale))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.BitDepth != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.BitDepth))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.Base != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Base))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.NoStandardView {
+		i--
+		if m.NoStandardView {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.Keys {
+		i--
+		if m.Keys {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.OldMax != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.OldMax))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.OldMin != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.OldMin))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.TimeQuantum) > 0 {
+		i -= len(m.TimeQuantum)
+		copy(dAtA[i:], m.TimeQuantum)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.TimeQuantum)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.CacheSize != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.CacheSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.CacheType) > 0 {
+		i -= len(m.CacheType)
+		copy(dAtA[i:], m.CacheType)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.CacheType)))
+		i--
+		dAtA[i] = 0x1a
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *FieldUpdate) Marshal() (dAtA []byte, err error) {
+func (m *ImportResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3683,12 +4195,12 @@ func (m *FieldUpdate) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *FieldUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *ImportResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *FieldUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ImportResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3697,24 +4209,17 @@ func (m *FieldUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Option) > 0 {
-		i -= len(m.Option)
-		copy(dAtA[i:], m.Option)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Option)))
+	if len(m.Err) > 0 {
+		i -= len(m.Err)
+		copy(dAtA[i:], m.Err)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Err)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DeleteFieldMessage) Marshal() (dAtA []byte, err error) {
+func (m *BlockDataRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3724,12 +4229,12 @@ func (m *DeleteFieldMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteFieldMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *BlockDataRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BlockDataRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3738,12 +4243,29 @@ func (m *DeleteFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Field) > 0 {
-		i -= len(m.Field)
-		copy(dAtA[i:], m.Field)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
+	if len(m.View) > 0 {
+		i -= len(m.View)
+		copy(dAtA[i:], m.View)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.View)))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x2a
+	}
+	if m.Shard != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Shard))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Block != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Block))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0x12
 	}
 	if len(m.Index) > 0 {
 		i -= len(m.Index)
@@ -3755,7 +4277,7 @@ func (m *DeleteFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *DeleteAvailableShardMessage) Marshal() (dAtA []byte, err error) {
+func (m *BlockDataResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3765,12 +4287,12 @@ func (m *DeleteAvailableShardMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteAvailableShardMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *BlockDataResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteAvailableShardMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BlockDataResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3779,29 +4301,46 @@ func (m *DeleteAvailableShardMessage) MarshalToSizedBuffer(dAtA []byte) (int, er
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ShardID != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.ShardID))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Field) > 0 {
-		i -= len(m.Field)
-		copy(dAtA[i:], m.Field)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
+	if len(m.ColumnIDs) > 0 {
+		dAtA4 := make([]byte, len(m.ColumnIDs)*10)
+		var j3 int
+		for _, num := range m.ColumnIDs {
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintPrivate(dAtA, i, uint64(j3))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Index) > 0 {
-		i -= len(m.Index)
-		copy(dAtA[i:], m.Index)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+	if len(m.RowIDs) > 0 {
+		dAtA6 := make([]byte, len(m.RowIDs)*10)
+		var j5 int
+		for _, num := range m.RowIDs {
+			for num >= 1<<7 {
+				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j5++
+			}
+			dAtA6[j5] = uint8(num)
+			j5++
+		}
+		i -= j5
+		copy(dAtA[i:], dAtA6[:j5])
+		i = encodeVarintPrivate(dAtA, i, uint64(j5))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Field) Marshal() (dAtA []byte, err error) {
+func (m *Cache) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3811,12 +4350,12 @@ func (m *Field) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Field) MarshalTo(dAtA []byte) (int, error) {
+func (m *Cache) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Field) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Cache) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3825,43 +4364,28 @@ func (m *Field) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CreatedAt != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
-		i--
-		dAtA[i] = 0x20
-	}
-	if len(m.Views) > 0 {
-		for iNdEx := len(m.Views) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Views[iNdEx])
-			copy(dAtA[i:], m.Views[iNdEx])
-			i = encodeVarintPrivate(dAtA, i, uint64(len(m.Views[iNdEx])))
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if m.Meta != nil {
-		{
-			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if len(m.IDs) > 0 {
+		dAtA8 := make([]byte, len(m.IDs)*10)
+		var j7 int
+		for _, num := range m.IDs {
+			for num >= 1<<7 {
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j7++
 			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
+			dAtA8[j7] = uint8(num)
+			j7++
 		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
+		i -= j7
+		copy(dAtA[i:], dAtA8[:j7])
+		i = encodeVarintPrivate(dAtA, i, uint64(j7))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Schema) Marshal() (dAtA []byte, err error) {
+func (m *MaxShards) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3871,12 +4395,12 @@ func (m *Schema) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Schema) MarshalTo(dAtA []byte) (int, error) {
+func (m *MaxShards) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Schema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *MaxShards) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3885,16 +4409,19 @@ func (m *Schema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Indexes) > 0 {
-		for iNdEx := len(m.Indexes) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Indexes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPrivate(dAtA, i, uint64(size))
-			}
+	if len(m.Standard) > 0 {
+		for k := range m.Standard {
+			v := m.Standard[k]
+			baseI := i
+			i = encodeVarintPrivate(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintPrivate(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintPrivate(dAtA, i, uint64(baseI-i))
 			i--
 			dAtA[i] = 0xa
 		}
@@ -3902,7 +4429,7 @@ func (m *Schema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Index) Marshal() (dAtA []byte, err error) {
+func (m *CreateShardMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3912,12 +4439,12 @@ func (m *Index) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Index) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateShardMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Index) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CreateShardMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3926,48 +4453,29 @@ func (m *Index) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Options != nil {
-		{
-			size, err := m.Options.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
 		i--
-		dAtA[i] = 0x2a
-	}
-	if len(m.Fields) > 0 {
-		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Fields[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPrivate(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
-		}
+		dAtA[i] = 0x1a
 	}
-	if m.CreatedAt != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
+	if m.Shard != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Shard))
 		i--
 		dAtA[i] = 0x10
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *URI) Marshal() (dAtA []byte, err error) {
+func (m *DeleteIndexMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3977,12 +4485,12 @@ func (m *URI) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *URI) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeleteIndexMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *URI) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DeleteIndexMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3991,29 +4499,17 @@ func (m *URI) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Port != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Port))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Host) > 0 {
-		i -= len(m.Host)
-		copy(dAtA[i:], m.Host)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Host)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Scheme) > 0 {
-		i -= len(m.Scheme)
-		copy(dAtA[i:], m.Scheme)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Scheme)))
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Node) Marshal() (dAtA []byte, err error) {
+func (m *CreateIndexMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4023,12 +4519,12 @@ func (m *Node) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Node) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateIndexMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CreateIndexMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4037,38 +4533,14 @@ func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.GRPCURI != nil {
-		{
-			size, err := m.GRPCURI.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
-	}
-	if len(m.State) > 0 {
-		i -= len(m.State)
-		copy(dAtA[i:], m.State)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.State)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.IsPrimary {
-		i--
-		if m.IsPrimary {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
+	if m.CreatedAt != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
 		i--
 		dAtA[i] = 0x18
 	}
-	if m.URI != nil {
+	if m.Meta != nil {
 		{
-			size, err := m.URI.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -4078,17 +4550,17 @@ func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.ID)))
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *NodeStateMessage) Marshal() (dAtA []byte, err error) {
+func (m *CreateFieldMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4098,12 +4570,12 @@ func (m *NodeStateMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NodeStateMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateFieldMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NodeStateMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CreateFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4112,24 +4584,41 @@ func (m *NodeStateMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.State) > 0 {
-		i -= len(m.State)
-		copy(dAtA[i:], m.State)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.State)))
+	if m.CreatedAt != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Meta != nil {
+		{
+			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.NodeID) > 0 {
-		i -= len(m.NodeID)
-		copy(dAtA[i:], m.NodeID)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.NodeID)))
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *NodeEventMessage) Marshal() (dAtA []byte, err error) {
+func (m *UpdateFieldMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4139,12 +4628,12 @@ func (m *NodeEventMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NodeEventMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateFieldMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NodeEventMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4153,9 +4642,9 @@ func (m *NodeEventMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Node != nil {
+	if m.Update != nil {
 		{
-			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Update.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -4165,15 +4654,22 @@ func (m *NodeEventMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	if m.Event != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Event))
+	if m.CreateFieldMessage != nil {
+		{
+			size, err := m.CreateFieldMessage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *NodeStatus) Marshal() (dAtA []byte, err error) {
+func (m *FieldUpdate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4183,12 +4679,12 @@ func (m *NodeStatus) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NodeStatus) MarshalTo(dAtA []byte) (int, error) {
+func (m *FieldUpdate) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NodeStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *FieldUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4197,48 +4693,24 @@ func (m *NodeStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Indexes) > 0 {
-		for iNdEx := len(m.Indexes) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Indexes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPrivate(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if m.Schema != nil {
-		{
-			size, err := m.Schema.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Value)))
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x12
 	}
-	if m.Node != nil {
-		{
-			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
+	if len(m.Option) > 0 {
+		i -= len(m.Option)
+		copy(dAtA[i:], m.Option)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Option)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *IndexStatus) Marshal() (dAtA []byte, err error) {
+func (m *DeleteFieldMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4248,12 +4720,12 @@ func (m *IndexStatus) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *IndexStatus) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeleteFieldMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *IndexStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DeleteFieldMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4262,36 +4734,24 @@ func (m *IndexStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CreatedAt != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
 		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Fields) > 0 {
-		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Fields[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPrivate(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
+		dAtA[i] = 0x12
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *FieldStatus) Marshal() (dAtA []byte, err error) {
+func (m *DeleteAvailableShardMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4301,12 +4761,12 @@ func (m *FieldStatus) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *FieldStatus) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeleteAvailableShardMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *FieldStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DeleteAvailableShardMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4315,40 +4775,29 @@ func (m *FieldStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CreatedAt != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
+	if m.ShardID != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.ShardID))
 		i--
 		dAtA[i] = 0x18
 	}
-	if len(m.AvailableShards) > 0 {
-		dAtA21 := make([]byte, len(m.AvailableShards)*10)
-		var j20 int
-		for _, num := range m.AvailableShards {
-			for num >= 1<<7 {
-				dAtA21[j20] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j20++
-			}
-			dAtA21[j20] = uint8(num)
-			j20++
-		}
-		i -= j20
-		copy(dAtA[i:], dAtA21[:j20])
-		i = encodeVarintPrivate(dAtA, i, uint64(j20))
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ClusterStatus) Marshal() (dAtA []byte, err error) {
+func (m *SetRowMetaMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4358,12 +4807,12 @@ func (m *ClusterStatus) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ClusterStatus) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetRowMetaMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ClusterStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetRowMetaMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4372,50 +4821,38 @@ func (m *ClusterStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Schema != nil {
-		{
-			size, err := m.Schema.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
+	if len(m.Meta) > 0 {
+		i -= len(m.Meta)
+		copy(dAtA[i:], m.Meta)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Meta)))
 		i--
 		dAtA[i] = 0x22
 	}
-	if len(m.Nodes) > 0 {
-		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Nodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPrivate(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+	if len(m.Row) > 0 {
+		i -= len(m.Row)
+		copy(dAtA[i:], m.Row)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Row)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if len(m.State) > 0 {
-		i -= len(m.State)
-		copy(dAtA[i:], m.State)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.State)))
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.ClusterID) > 0 {
-		i -= len(m.ClusterID)
-		copy(dAtA[i:], m.ClusterID)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.ClusterID)))
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *BSIGroup) Marshal() (dAtA []byte, err error) {
+func (m *SetAliasMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4425,12 +4862,12 @@ func (m *BSIGroup) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BSIGroup) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetAliasMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BSIGroup) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetAliasMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4439,34 +4876,24 @@ func (m *BSIGroup) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Max != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Max))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Min != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Min))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Type)))
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
+	if len(m.Alias) > 0 {
+		i -= len(m.Alias)
+		copy(dAtA[i:], m.Alias)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Alias)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *CreateViewMessage) Marshal() (dAtA []byte, err error) {
+func (m *CloneIndexMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4476,12 +4903,12 @@ func (m *CreateViewMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateViewMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *CloneIndexMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CreateViewMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CloneIndexMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4490,31 +4917,24 @@ func (m *CreateViewMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.View) > 0 {
-		i -= len(m.View)
-		copy(dAtA[i:], m.View)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.View)))
+	if len(m.Target) > 0 {
+		i -= len(m.Target)
+		copy(dAtA[i:], m.Target)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Target)))
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x12
 	}
-	if len(m.Field) > 0 {
-		i -= len(m.Field)
-		copy(dAtA[i:], m.Field)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Index) > 0 {
-		i -= len(m.Index)
-		copy(dAtA[i:], m.Index)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Source)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DeleteViewMessage) Marshal() (dAtA []byte, err error) {
+func (m *UpdateIndexMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4524,12 +4944,12 @@ func (m *DeleteViewMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteViewMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateIndexMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteViewMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateIndexMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4538,31 +4958,34 @@ func (m *DeleteViewMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.View) > 0 {
-		i -= len(m.View)
-		copy(dAtA[i:], m.View)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.View)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Field) > 0 {
-		i -= len(m.Field)
-		copy(dAtA[i:], m.Field)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
+	if m.Update != nil {
+		{
+			size, err := m.Update.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Index) > 0 {
-		i -= len(m.Index)
-		copy(dAtA[i:], m.Index)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+	if m.CreateIndexMessage != nil {
+		{
+			size, err := m.CreateIndexMessage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ResizeInstruction) Marshal() (dAtA []byte, err error) {
+func (m *IndexUpdate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4572,12 +4995,12 @@ func (m *ResizeInstruction) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResizeInstruction) MarshalTo(dAtA []byte) (int, error) {
+func (m *IndexUpdate) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResizeInstruction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *IndexUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4586,91 +5009,24 @@ func (m *ResizeInstruction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.TranslationSources) > 0 {
-		for iNdEx := len(m.TranslationSources) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.TranslationSources[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPrivate(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x42
-		}
-	}
-	if m.NodeStatus != nil {
-		{
-			size, err := m.NodeStatus.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x3a
-	}
-	if m.ClusterStatus != nil {
-		{
-			size, err := m.ClusterStatus.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
-	}
-	if len(m.Sources) > 0 {
-		for iNdEx := len(m.Sources) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Sources[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPrivate(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if m.Primary != nil {
-		{
-			size, err := m.Primary.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Node != nil {
-		{
-			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Value)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if m.JobID != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.JobID))
+	if len(m.Option) > 0 {
+		i -= len(m.Option)
+		copy(dAtA[i:], m.Option)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Option)))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ResizeSource) Marshal() (dAtA []byte, err error) {
+func (m *MaintenanceMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4680,12 +5036,12 @@ func (m *ResizeSource) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResizeSource) MarshalTo(dAtA []byte) (int, error) {
+func (m *MaintenanceMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResizeSource) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *MaintenanceMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4694,48 +5050,17 @@ func (m *ResizeSource) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Shard != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Shard))
-		i--
-		dAtA[i] = 0x28
-	}
-	if len(m.View) > 0 {
-		i -= len(m.View)
-		copy(dAtA[i:], m.View)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.View)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Field) > 0 {
-		i -= len(m.Field)
-		copy(dAtA[i:], m.Field)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Index) > 0 {
-		i -= len(m.Index)
-		copy(dAtA[i:], m.Index)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Node != nil {
-		{
-			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Reason)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *TranslationResizeSource) Marshal() (dAtA []byte, err error) {
+func (m *Field) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4745,12 +5070,12 @@ func (m *TranslationResizeSource) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TranslationResizeSource) MarshalTo(dAtA []byte) (int, error) {
+func (m *Field) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TranslationResizeSource) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Field) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4759,21 +5084,23 @@ func (m *TranslationResizeSource) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.PartitionID != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.PartitionID))
+	if m.CreatedAt != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
 		i--
-		dAtA[i] = 0x18
+		dAtA[i] = 0x20
 	}
-	if len(m.Index) > 0 {
-		i -= len(m.Index)
-		copy(dAtA[i:], m.Index)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
-		i--
-		dAtA[i] = 0x12
+	if len(m.Views) > 0 {
+		for iNdEx := len(m.Views) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Views[iNdEx])
+			copy(dAtA[i:], m.Views[iNdEx])
+			i = encodeVarintPrivate(dAtA, i, uint64(len(m.Views[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	if m.Node != nil {
+	if m.Meta != nil {
 		{
-			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -4781,12 +5108,19 @@ func (m *TranslationResizeSource) MarshalToSizedBuffer(dAtA []byte) (int, error)
 			i = encodeVarintPrivate(dAtA, i, uint64(size))
 		}
 		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
+		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ResizeInstructionComplete) Marshal() (dAtA []byte, err error) {
+func (m *Schema) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4796,12 +5130,12 @@ func (m *ResizeInstructionComplete) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResizeInstructionComplete) MarshalTo(dAtA []byte) (int, error) {
+func (m *Schema) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResizeInstructionComplete) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Schema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4810,34 +5144,24 @@ func (m *ResizeInstructionComplete) MarshalToSizedBuffer(dAtA []byte) (int, erro
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Error) > 0 {
-		i -= len(m.Error)
-		copy(dAtA[i:], m.Error)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Error)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Node != nil {
-		{
-			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if len(m.Indexes) > 0 {
+		for iNdEx := len(m.Indexes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Indexes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPrivate(dAtA, i, uint64(size))
 			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
+			i--
+			dAtA[i] = 0xa
 		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.JobID != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.JobID))
-		i--
-		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Topology) Marshal() (dAtA []byte, err error) {
+func (m *Index) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4847,12 +5171,12 @@ func (m *Topology) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Topology) MarshalTo(dAtA []byte) (int, error) {
+func (m *Index) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Topology) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Index) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4861,53 +5185,48 @@ func (m *Topology) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.NodeIDs) > 0 {
-		for iNdEx := len(m.NodeIDs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.NodeIDs[iNdEx])
-			copy(dAtA[i:], m.NodeIDs[iNdEx])
-			i = encodeVarintPrivate(dAtA, i, uint64(len(m.NodeIDs[iNdEx])))
+	if m.Options != nil {
+		{
+			size, err := m.Options.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Fields) > 0 {
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Fields[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPrivate(dAtA, i, uint64(size))
+			}
 			i--
-			dAtA[i] = 0x12
+			dAtA[i] = 0x22
 		}
 	}
-	if len(m.ClusterID) > 0 {
-		i -= len(m.ClusterID)
-		copy(dAtA[i:], m.ClusterID)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.ClusterID)))
+	if m.CreatedAt != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
 		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *RecalculateCaches) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+		dAtA[i] = 0x10
 	}
-	return dAtA[:n], nil
-}
-
-func (m *RecalculateCaches) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *RecalculateCaches) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *LoadSchemaMessage) Marshal() (dAtA []byte, err error) {
+func (m *URI) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4917,12 +5236,12 @@ func (m *LoadSchemaMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LoadSchemaMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *URI) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LoadSchemaMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *URI) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4931,56 +5250,29 @@ func (m *LoadSchemaMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *TransactionMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *TransactionMessage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *TransactionMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+	if m.Port != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Port))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.Transaction != nil {
-		{
-			size, err := m.Transaction.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPrivate(dAtA, i, uint64(size))
-		}
+	if len(m.Host) > 0 {
+		i -= len(m.Host)
+		copy(dAtA[i:], m.Host)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Host)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Action) > 0 {
-		i -= len(m.Action)
-		copy(dAtA[i:], m.Action)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Action)))
+	if len(m.Scheme) > 0 {
+		i -= len(m.Scheme)
+		copy(dAtA[i:], m.Scheme)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Scheme)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Transaction) Marshal() (dAtA []byte, err error) {
+func (m *Node) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4990,12 +5282,12 @@ func (m *Transaction) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Transaction) MarshalTo(dAtA []byte) (int, error) {
+func (m *Node) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Transaction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5004,9 +5296,9 @@ func (m *Transaction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Stats != nil {
+	if m.GRPCURI != nil {
 		{
-			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.GRPCURI.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5014,21 +5306,18 @@ func (m *Transaction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintPrivate(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x32
-	}
-	if m.Deadline != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Deadline))
-		i--
-		dAtA[i] = 0x28
+		dAtA[i] = 0x2a
 	}
-	if m.Timeout != 0 {
-		i = encodeVarintPrivate(dAtA, i, uint64(m.Timeout))
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.State)))
 		i--
-		dAtA[i] = 0x20
+		dAtA[i] = 0x22
 	}
-	if m.Exclusive {
+	if m.IsPrimary {
 		i--
-		if m.Exclusive {
+		if m.IsPrimary {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
@@ -5036,15 +5325,17 @@ func (m *Transaction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x18
 	}
-	if m.Active {
-		i--
-		if m.Active {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	if m.URI != nil {
+		{
+			size, err := m.URI.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x12
 	}
 	if len(m.ID) > 0 {
 		i -= len(m.ID)
@@ -5056,7 +5347,7 @@ func (m *Transaction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *TransactionStats) Marshal() (dAtA []byte, err error) {
+func (m *NodeStateMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5066,12 +5357,12 @@ func (m *TransactionStats) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TransactionStats) MarshalTo(dAtA []byte) (int, error) {
+func (m *NodeStateMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TransactionStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *NodeStateMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5080,37 +5371,24 @@ func (m *TransactionStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ResizeAbortMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ResizeAbortMessage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ResizeAbortMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.NodeID) > 0 {
+		i -= len(m.NodeID)
+		copy(dAtA[i:], m.NodeID)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.NodeID)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ResizeNodeMessage) Marshal() (dAtA []byte, err error) {
+func (m *NodeEventMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5120,12 +5398,12 @@ func (m *ResizeNodeMessage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResizeNodeMessage) MarshalTo(dAtA []byte) (int, error) {
+func (m *NodeEventMessage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResizeNodeMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *NodeEventMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5134,24 +5412,27 @@ func (m *ResizeNodeMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Action) > 0 {
-		i -= len(m.Action)
-		copy(dAtA[i:], m.Action)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Action)))
+	if m.Node != nil {
+		{
+			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.NodeID) > 0 {
-		i -= len(m.NodeID)
-		copy(dAtA[i:], m.NodeID)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.NodeID)))
+	if m.Event != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Event))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *FieldOperation) Marshal() (dAtA []byte, err error) {
+func (m *NodeStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5161,12 +5442,12 @@ func (m *FieldOperation) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *FieldOperation) MarshalTo(dAtA []byte) (int, error) {
+func (m *NodeStatus) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *FieldOperation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *NodeStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5175,65 +5456,48 @@ func (m *FieldOperation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Signed) > 0 {
-		dAtA33 := make([]byte, len(m.Signed)*10)
-		var j32 int
-		for _, num1 := range m.Signed {
-			num := uint64(num1)
-			for num >= 1<<7 {
-				dAtA33[j32] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j32++
-			}
-			dAtA33[j32] = uint8(num)
-			j32++
-		}
-		i -= j32
-		copy(dAtA[i:], dAtA33[:j32])
-		i = encodeVarintPrivate(dAtA, i, uint64(j32))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Values) > 0 {
-		dAtA35 := make([]byte, len(m.Values)*10)
-		var j34 int
-		for _, num := range m.Values {
-			for num >= 1<<7 {
-				dAtA35[j34] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j34++
+	if len(m.Indexes) > 0 {
+		for iNdEx := len(m.Indexes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Indexes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPrivate(dAtA, i, uint64(size))
 			}
-			dAtA35[j34] = uint8(num)
-			j34++
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Schema != nil {
+		{
+			size, err := m.Schema.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
 		}
-		i -= j34
-		copy(dAtA[i:], dAtA35[:j34])
-		i = encodeVarintPrivate(dAtA, i, uint64(j34))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x1a
 	}
-	if len(m.RecordIDs) > 0 {
-		dAtA37 := make([]byte, len(m.RecordIDs)*10)
-		var j36 int
-		for _, num := range m.RecordIDs {
-			for num >= 1<<7 {
-				dAtA37[j36] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j36++
+	if m.Node != nil {
+		{
+			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
-			dAtA37[j36] = uint8(num)
-			j36++
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
 		}
-		i -= j36
-		copy(dAtA[i:], dAtA37[:j36])
-		i = encodeVarintPrivate(dAtA, i, uint64(j36))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ShardIngestOperation) Marshal() (dAtA []byte, err error) {
+func (m *IndexStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5243,12 +5507,12 @@ func (m *ShardIngestOperation) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ShardIngestOperation) MarshalTo(dAtA []byte) (int, error) {
+func (m *IndexStatus) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ShardIngestOperation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *IndexStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5257,70 +5521,93 @@ func (m *ShardIngestOperation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.FieldOps) > 0 {
-		for k := range m.FieldOps {
-			v := m.FieldOps[k]
-			baseI := i
-			if v != nil {
-				{
-					size, err := v.MarshalToSizedBuffer(dAtA[:i])
-					if err != nil {
-						return 0, err
-					}
-					i -= size
-					i = encodeVarintPrivate(dAtA, i, uint64(size))
+	if m.CreatedAt != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Fields) > 0 {
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Fields[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
 				}
-				i--
-				dAtA[i] = 0x12
+				i -= size
+				i = encodeVarintPrivate(dAtA, i, uint64(size))
 			}
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintPrivate(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintPrivate(dAtA, i, uint64(baseI-i))
 			i--
-			dAtA[i] = 0x22
+			dAtA[i] = 0x12
 		}
 	}
-	if len(m.ClearFields) > 0 {
-		for iNdEx := len(m.ClearFields) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.ClearFields[iNdEx])
-			copy(dAtA[i:], m.ClearFields[iNdEx])
-			i = encodeVarintPrivate(dAtA, i, uint64(len(m.ClearFields[iNdEx])))
-			i--
-			dAtA[i] = 0x1a
-		}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
 	}
-	if len(m.ClearRecordIDs) > 0 {
-		dAtA40 := make([]byte, len(m.ClearRecordIDs)*10)
-		var j39 int
-		for _, num := range m.ClearRecordIDs {
+	return len(dAtA) - i, nil
+}
+
+func (m *FieldStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FieldStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FieldStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.CreatedAt != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.CreatedAt))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.AvailableShards) > 0 {
+		dAtA23 := make([]byte, len(m.AvailableShards)*10)
+		var j22 int
+		for _, num := range m.AvailableShards {
 			for num >= 1<<7 {
-				dAtA40[j39] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA23[j22] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j39++
+				j22++
 			}
-			dAtA40[j39] = uint8(num)
-			j39++
+			dAtA23[j22] = uint8(num)
+			j22++
 		}
-		i -= j39
-		copy(dAtA[i:], dAtA40[:j39])
-		i = encodeVarintPrivate(dAtA, i, uint64(j39))
+		i -= j22
+		copy(dAtA[i:], dAtA23[:j22])
+		i = encodeVarintPrivate(dAtA, i, uint64(j22))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.OpType) > 0 {
-		i -= len(m.OpType)
-		copy(dAtA[i:], m.OpType)
-		i = encodeVarintPrivate(dAtA, i, uint64(len(m.OpType)))
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ShardIngestOperations) Marshal() (dAtA []byte, err error) {
+func (m *ClusterStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5330,12 +5617,12 @@ func (m *ShardIngestOperations) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ShardIngestOperations) MarshalTo(dAtA []byte) (int, error) {
+func (m *ClusterStatus) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ShardIngestOperations) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ClusterStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5344,10 +5631,22 @@ func (m *ShardIngestOperations) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Ops) > 0 {
-		for iNdEx := len(m.Ops) - 1; iNdEx >= 0; iNdEx-- {
+	if m.Schema != nil {
+		{
+			size, err := m.Schema.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Ops[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Nodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -5355,13 +5654,27 @@ func (m *ShardIngestOperations) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintPrivate(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0xa
+			dAtA[i] = 0x1a
 		}
 	}
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ClusterID) > 0 {
+		i -= len(m.ClusterID)
+		copy(dAtA[i:], m.ClusterID)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.ClusterID)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ShardedIngestRequest) Marshal() (dAtA []byte, err error) {
+func (m *BSIGroup) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5371,12 +5684,12 @@ func (m *ShardedIngestRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ShardedIngestRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *BSIGroup) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ShardedIngestRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BSIGroup) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5385,830 +5698,1104 @@ func (m *ShardedIngestRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Ops) > 0 {
-		for k := range m.Ops {
-			v := m.Ops[k]
-			baseI := i
-			if v != nil {
-				{
-					size, err := v.MarshalToSizedBuffer(dAtA[:i])
-					if err != nil {
-						return 0, err
-					}
-					i -= size
-					i = encodeVarintPrivate(dAtA, i, uint64(size))
-				}
-				i--
-				dAtA[i] = 0x12
-			}
-			i = encodeVarintPrivate(dAtA, i, uint64(k))
-			i--
-			dAtA[i] = 0x8
-			i = encodeVarintPrivate(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0xa
-		}
+	if m.Max != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Max))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Min != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Min))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintPrivate(dAtA []byte, offset int, v uint64) int {
-	offset -= sovPrivate(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *CreateViewMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *IndexMeta) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Keys {
-		n += 2
-	}
-	if m.TrackExistence {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+
+func (m *CreateViewMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *FieldOptions) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *CreateViewMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.CacheType)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.CacheSize != 0 {
-		n += 1 + sovPrivate(uint64(m.CacheSize))
-	}
-	l = len(m.TimeQuantum)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.OldMin != 0 {
-		n += 1 + sovPrivate(uint64(m.OldMin))
-	}
-	if m.OldMax != 0 {
-		n += 1 + sovPrivate(uint64(m.OldMax))
-	}
-	if m.Keys {
-		n += 2
-	}
-	if m.NoStandardView {
-		n += 2
-	}
-	if m.Base != 0 {
-		n += 1 + sovPrivate(uint64(m.Base))
-	}
-	if m.BitDepth != 0 {
-		n += 1 + sovPrivate(uint64(m.BitDepth))
-	}
-	if m.Scale != 0 {
-		n += 1 + sovPrivate(uint64(m.Scale))
-	}
-	l = len(m.ForeignIndex)
-	if l > 0 {
-		n += 2 + l + sovPrivate(uint64(l))
-	}
-	if m.Min != nil {
-		l = m.Min.Size()
-		n += 2 + l + sovPrivate(uint64(l))
-	}
-	if m.Max != nil {
-		l = m.Max.Size()
-		n += 2 + l + sovPrivate(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.TimeUnit)
-	if l > 0 {
-		n += 2 + l + sovPrivate(uint64(l))
+	if len(m.View) > 0 {
+		i -= len(m.View)
+		copy(dAtA[i:], m.View)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.View)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	l = len(m.TTL)
-	if l > 0 {
-		n += 2 + l + sovPrivate(uint64(l))
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ImportResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Err)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *DeleteViewMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *BlockDataRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *DeleteViewMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteViewMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Index)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.Field)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.Block != 0 {
-		n += 1 + sovPrivate(uint64(m.Block))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Shard != 0 {
-		n += 1 + sovPrivate(uint64(m.Shard))
+	if len(m.View) > 0 {
+		i -= len(m.View)
+		copy(dAtA[i:], m.View)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.View)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	l = len(m.View)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BlockDataResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.RowIDs) > 0 {
-		l = 0
-		for _, e := range m.RowIDs {
-			l += sovPrivate(uint64(e))
-		}
-		n += 1 + sovPrivate(uint64(l)) + l
-	}
-	if len(m.ColumnIDs) > 0 {
-		l = 0
-		for _, e := range m.ColumnIDs {
-			l += sovPrivate(uint64(e))
-		}
-		n += 1 + sovPrivate(uint64(l)) + l
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ResizeInstruction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Cache) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.IDs) > 0 {
-		l = 0
-		for _, e := range m.IDs {
-			l += sovPrivate(uint64(e))
-		}
-		n += 1 + sovPrivate(uint64(l)) + l
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *ResizeInstruction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MaxShards) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ResizeInstruction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Standard) > 0 {
-		for k, v := range m.Standard {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovPrivate(uint64(len(k))) + 1 + sovPrivate(uint64(v))
-			n += mapEntrySize + 1 + sovPrivate(uint64(mapEntrySize))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *CreateShardMessage) Size() (n int) {
-	if m == nil {
-		return 0
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	var l int
-	_ = l
-	l = len(m.Index)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if len(m.TranslationSources) > 0 {
+		for iNdEx := len(m.TranslationSources) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TranslationSources[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPrivate(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
 	}
-	if m.Shard != 0 {
-		n += 1 + sovPrivate(uint64(m.Shard))
+	if m.NodeStatus != nil {
+		{
+			size, err := m.NodeStatus.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
 	}
-	l = len(m.Field)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.ClusterStatus != nil {
+		{
+			size, err := m.ClusterStatus.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Sources) > 0 {
+		for iNdEx := len(m.Sources) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Sources[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPrivate(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
 	}
-	return n
-}
-
-func (m *DeleteIndexMessage) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Primary != nil {
+		{
+			size, err := m.Primary.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	var l int
-	_ = l
-	l = len(m.Index)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.Node != nil {
+		{
+			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.JobID != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.JobID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *CreateIndexMessage) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ResizeSource) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ResizeSource) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResizeSource) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Index)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Meta != nil {
-		l = m.Meta.Size()
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.Shard != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Shard))
+		i--
+		dAtA[i] = 0x28
 	}
-	if m.CreatedAt != 0 {
-		n += 1 + sovPrivate(uint64(m.CreatedAt))
+	if len(m.View) > 0 {
+		i -= len(m.View)
+		copy(dAtA[i:], m.View)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.View)))
+		i--
+		dAtA[i] = 0x22
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	return n
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Node != nil {
+		{
+			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *CreateFieldMessage) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TranslationResizeSource) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *TranslationResizeSource) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TranslationResizeSource) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Index)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.Field)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Meta != nil {
-		l = m.Meta.Size()
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.PartitionID != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.PartitionID))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.CreatedAt != 0 {
-		n += 1 + sovPrivate(uint64(m.CreatedAt))
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Node != nil {
+		{
+			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *UpdateFieldMessage) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ResizeInstructionComplete) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ResizeInstructionComplete) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResizeInstructionComplete) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.CreateFieldMessage != nil {
-		l = m.CreateFieldMessage.Size()
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Update != nil {
-		l = m.Update.Size()
-		n += 1 + l + sovPrivate(uint64(l))
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Node != nil {
+		{
+			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if m.JobID != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.JobID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *FieldUpdate) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Topology) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Topology) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Topology) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Option)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if len(m.NodeIDs) > 0 {
+		for iNdEx := len(m.NodeIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.NodeIDs[iNdEx])
+			copy(dAtA[i:], m.NodeIDs[iNdEx])
+			i = encodeVarintPrivate(dAtA, i, uint64(len(m.NodeIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.ClusterID) > 0 {
+		i -= len(m.ClusterID)
+		copy(dAtA[i:], m.ClusterID)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.ClusterID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DeleteFieldMessage) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RecalculateCaches) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RecalculateCaches) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecalculateCaches) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Index)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.Field)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DeleteAvailableShardMessage) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LoadSchemaMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *LoadSchemaMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LoadSchemaMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Index)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.Field)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.ShardID != 0 {
-		n += 1 + sovPrivate(uint64(m.ShardID))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Field) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TransactionMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *TransactionMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransactionMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.Meta != nil {
-		l = m.Meta.Size()
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Views) > 0 {
-		for _, s := range m.Views {
-			l = len(s)
-			n += 1 + l + sovPrivate(uint64(l))
+	if m.Transaction != nil {
+		{
+			size, err := m.Transaction.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.CreatedAt != 0 {
-		n += 1 + sovPrivate(uint64(m.CreatedAt))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Action) > 0 {
+		i -= len(m.Action)
+		copy(dAtA[i:], m.Action)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Action)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Schema) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Indexes) > 0 {
-		for _, e := range m.Indexes {
-			l = e.Size()
-			n += 1 + l + sovPrivate(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *Transaction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Index) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *Transaction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Transaction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.CreatedAt != 0 {
-		n += 1 + sovPrivate(uint64(m.CreatedAt))
-	}
-	if len(m.Fields) > 0 {
-		for _, e := range m.Fields {
-			l = e.Size()
-			n += 1 + l + sovPrivate(uint64(l))
-		}
-	}
-	if m.Options != nil {
-		l = m.Options.Size()
-		n += 1 + l + sovPrivate(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-
-func (m *URI) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Stats != nil {
+		{
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPrivate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
 	}
-	var l int
-	_ = l
-	l = len(m.Scheme)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.Deadline != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Deadline))
+		i--
+		dAtA[i] = 0x28
 	}
-	l = len(m.Host)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.Timeout != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Timeout))
+		i--
+		dAtA[i] = 0x20
 	}
-	if m.Port != 0 {
-		n += 1 + sovPrivate(uint64(m.Port))
+	if m.Exclusive {
+		i--
+		if m.Exclusive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Active {
+		i--
+		if m.Active {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
 	}
-	return n
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *Node) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.URI != nil {
-		l = m.URI.Size()
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.IsPrimary {
-		n += 2
-	}
-	l = len(m.State)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.GRPCURI != nil {
-		l = m.GRPCURI.Size()
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *TransactionStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *NodeStateMessage) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.NodeID)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.State)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *TransactionStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NodeEventMessage) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *TransactionStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Event != 0 {
-		n += 1 + sovPrivate(uint64(m.Event))
-	}
-	if m.Node != nil {
-		l = m.Node.Size()
-		n += 1 + l + sovPrivate(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *NodeStatus) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ResizeAbortMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ResizeAbortMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResizeAbortMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Node != nil {
-		l = m.Node.Size()
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.Schema != nil {
-		l = m.Schema.Size()
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if len(m.Indexes) > 0 {
-		for _, e := range m.Indexes {
-			l = e.Size()
-			n += 1 + l + sovPrivate(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *IndexStatus) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ResizeNodeMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ResizeNodeMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResizeNodeMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if len(m.Fields) > 0 {
-		for _, e := range m.Fields {
-			l = e.Size()
-			n += 1 + l + sovPrivate(uint64(l))
-		}
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CreatedAt != 0 {
-		n += 1 + sovPrivate(uint64(m.CreatedAt))
+	if len(m.Action) > 0 {
+		i -= len(m.Action)
+		copy(dAtA[i:], m.Action)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Action)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.NodeID) > 0 {
+		i -= len(m.NodeID)
+		copy(dAtA[i:], m.NodeID)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.NodeID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *FieldStatus) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *FieldOperation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *FieldOperation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FieldOperation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.AvailableShards) > 0 {
-		l = 0
-		for _, e := range m.AvailableShards {
-			l += sovPrivate(uint64(e))
+	if len(m.Signed) > 0 {
+		dAtA35 := make([]byte, len(m.Signed)*10)
+		var j34 int
+		for _, num1 := range m.Signed {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA35[j34] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j34++
+			}
+			dAtA35[j34] = uint8(num)
+			j34++
 		}
-		n += 1 + sovPrivate(uint64(l)) + l
+		i -= j34
+		copy(dAtA[i:], dAtA35[:j34])
+		i = encodeVarintPrivate(dAtA, i, uint64(j34))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.CreatedAt != 0 {
-		n += 1 + sovPrivate(uint64(m.CreatedAt))
+	if len(m.Values) > 0 {
+		dAtA37 := make([]byte, len(m.Values)*10)
+		var j36 int
+		for _, num := range m.Values {
+			for num >= 1<<7 {
+				dAtA37[j36] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j36++
+			}
+			dAtA37[j36] = uint8(num)
+			j36++
+		}
+		i -= j36
+		copy(dAtA[i:], dAtA37[:j36])
+		i = encodeVarintPrivate(dAtA, i, uint64(j36))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.RecordIDs) > 0 {
+		dAtA39 := make([]byte, len(m.RecordIDs)*10)
+		var j38 int
+		for _, num := range m.RecordIDs {
+			for num >= 1<<7 {
+				dAtA39[j38] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j38++
+			}
+			dAtA39[j38] = uint8(num)
+			j38++
+		}
+		i -= j38
+		copy(dAtA[i:], dAtA39[:j38])
+		i = encodeVarintPrivate(dAtA, i, uint64(j38))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ClusterStatus) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ShardIngestOperation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardIngestOperation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ShardIngestOperation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ClusterID)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.State)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+	if len(m.FieldOps) > 0 {
+		for k := range m.FieldOps {
+			v := m.FieldOps[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintPrivate(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintPrivate(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintPrivate(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
 	}
-	if len(m.Nodes) > 0 {
-		for _, e := range m.Nodes {
-			l = e.Size()
-			n += 1 + l + sovPrivate(uint64(l))
+	if len(m.ClearFields) > 0 {
+		for iNdEx := len(m.ClearFields) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ClearFields[iNdEx])
+			copy(dAtA[i:], m.ClearFields[iNdEx])
+			i = encodeVarintPrivate(dAtA, i, uint64(len(m.ClearFields[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
 		}
 	}
-	if m.Schema != nil {
-		l = m.Schema.Size()
-		n += 1 + l + sovPrivate(uint64(l))
+	if len(m.ClearRecordIDs) > 0 {
+		dAtA42 := make([]byte, len(m.ClearRecordIDs)*10)
+		var j41 int
+		for _, num := range m.ClearRecordIDs {
+			for num >= 1<<7 {
+				dAtA42[j41] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j41++
+			}
+			dAtA42[j41] = uint8(num)
+			j41++
+		}
+		i -= j41
+		copy(dAtA[i:], dAtA42[:j41])
+		i = encodeVarintPrivate(dAtA, i, uint64(j41))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.OpType) > 0 {
+		i -= len(m.OpType)
+		copy(dAtA[i:], m.OpType)
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.OpType)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BSIGroup) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.Min != 0 {
-		n += 1 + sovPrivate(uint64(m.Min))
-	}
-	if m.Max != 0 {
-		n += 1 + sovPrivate(uint64(m.Max))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ShardIngestOperations) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *CreateViewMessage) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Index)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.Field)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.View)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *ShardIngestOperations) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteViewMessage) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ShardIngestOperations) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Index)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.Field)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	l = len(m.View)
-	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Ops) > 0 {
+		for iNdEx := len(m.Ops) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ops[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPrivate(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ResizeInstruction) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ShardedIngestRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardedIngestRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ShardedIngestRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.JobID != 0 {
-		n += 1 + sovPrivate(uint64(m.JobID))
-	}
-	if m.Node != nil {
-		l = m.Node.Size()
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Primary != nil {
-		l = m.Primary.Size()
-		n += 1 + l + sovPrivate(uint64(l))
-	}
-	if len(m.Sources) > 0 {
-		for _, e := range m.Sources {
-			l = e.Size()
-			n += 1 + l + sovPrivate(uint64(l))
+	if len(m.Ops) > 0 {
+		for k := range m.Ops {
+			v := m.Ops[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintPrivate(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintPrivate(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintPrivate(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	if m.ClusterStatus != nil {
-		l = m.ClusterStatus.Size()
-		n += 1 + l + sovPrivate(uint64(l))
+	return len(dAtA) - i, nil
+}
+
+func (m *SettingsMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	if m.NodeStatus != nil {
-		l = m.NodeStatus.Size()
-		n += 1 + l + sovPrivate(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *SettingsMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SettingsMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.TranslationSources) > 0 {
-		for _, e := range m.TranslationSources {
-			l = e.Size()
-			n += 1 + l + sovPrivate(uint64(l))
-		}
+	if m.MaxBackgroundQueries != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.MaxBackgroundQueries))
+		i--
+		dAtA[i] = 0x38
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.MaxBatchQueries != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.MaxBatchQueries))
+		i--
+		dAtA[i] = 0x30
 	}
-	return n
+	if m.MaxQueryMemory != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.MaxQueryMemory))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MaxWritesPerRequest != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.MaxWritesPerRequest))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.WorkerPoolSize != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.WorkerPoolSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.LongQueryTime != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.LongQueryTime))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.QueryTimeout != 0 {
+		i = encodeVarintPrivate(dAtA, i, uint64(m.QueryTimeout))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ResizeSource) Size() (n int) {
+func encodeVarintPrivate(dAtA []byte, offset int, v uint64) int {
+	offset -= sovPrivate(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *IndexMeta) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Node != nil {
-		l = m.Node.Size()
-		n += 1 + l + sovPrivate(uint64(l))
+	if m.Keys {
+		n += 2
 	}
-	l = len(m.Index)
+	if m.TrackExistence {
+		n += 2
+	}
+	if m.MaxColumns != 0 {
+		n += 1 + sovPrivate(uint64(m.MaxColumns))
+	}
+	if m.MaxGroups != 0 {
+		n += 1 + sovPrivate(uint64(m.MaxGroups))
+	}
+	if m.MaxRows != 0 {
+		n += 1 + sovPrivate(uint64(m.MaxRows))
+	}
+	l = len(m.Description)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	l = len(m.Field)
+	l = len(m.Owner)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	l = len(m.View)
+	if len(m.Tags) > 0 {
+		for k, v := range m.Tags {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPrivate(uint64(len(k))) + 1 + len(v) + sovPrivate(uint64(len(v)))
+			n += mapEntrySize + 1 + sovPrivate(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.Namespace)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if m.Shard != 0 {
-		n += 1 + sovPrivate(uint64(m.Shard))
+	if m.MaxStorage != 0 {
+		n += 1 + sovPrivate(uint64(m.MaxStorage))
+	}
+	if m.ReadOnly {
+		n += 2
+	}
+	if len(m.Cubes) > 0 {
+		for _, e := range m.Cubes {
+			l = e.Size()
+			n += 1 + l + sovPrivate(uint64(l))
+		}
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -6216,110 +6803,193 @@ func (m *ResizeSource) Size() (n int) {
 	return n
 }
 
-func (m *TranslationResizeSource) Size() (n int) {
+func (m *Cube) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Node != nil {
-		l = m.Node.Size()
+	l = len(m.Name)
+	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	l = len(m.Index)
+	if len(m.Fields) > 0 {
+		for _, s := range m.Fields {
+			l = len(s)
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	l = len(m.Aggregate)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if m.PartitionID != 0 {
-		n += 1 + sovPrivate(uint64(m.PartitionID))
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ResizeInstructionComplete) Size() (n int) {
+func (m *FieldOptions) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.JobID != 0 {
-		n += 1 + sovPrivate(uint64(m.JobID))
+	l = len(m.CacheType)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if m.Node != nil {
-		l = m.Node.Size()
+	if m.CacheSize != 0 {
+		n += 1 + sovPrivate(uint64(m.CacheSize))
+	}
+	l = len(m.TimeQuantum)
+	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	l = len(m.Error)
+	l = len(m.Type)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.OldMin != 0 {
+		n += 1 + sovPrivate(uint64(m.OldMin))
 	}
-	return n
-}
-
-func (m *Topology) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.OldMax != 0 {
+		n += 1 + sovPrivate(uint64(m.OldMax))
 	}
-	var l int
-	_ = l
-	l = len(m.ClusterID)
+	if m.Keys {
+		n += 2
+	}
+	if m.NoStandardView {
+		n += 2
+	}
+	if m.Base != 0 {
+		n += 1 + sovPrivate(uint64(m.Base))
+	}
+	if m.BitDepth != 0 {
+		n += 1 + sovPrivate(uint64(m.BitDepth))
+	}
+	if m.Scale != 0 {
+		n += 1 + sovPrivate(uint64(m.Scale))
+	}
+	l = len(m.ForeignIndex)
 	if l > 0 {
-		n += 1 + l + sovPrivate(uint64(l))
+		n += 2 + l + sovPrivate(uint64(l))
 	}
-	if len(m.NodeIDs) > 0 {
-		for _, s := range m.NodeIDs {
+	if m.Min != nil {
+		l = m.Min.Size()
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	if m.Max != nil {
+		l = m.Max.Size()
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.TimeUnit)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.TTL)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for k, v := range m.Tags {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPrivate(uint64(len(k))) + 1 + len(v) + sovPrivate(uint64(len(v)))
+			n += mapEntrySize + 2 + sovPrivate(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.MemoryPolicy)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	if len(m.MemoryPolicyViews) > 0 {
+		for _, s := range m.MemoryPolicyViews {
 			l = len(s)
-			n += 1 + l + sovPrivate(uint64(l))
+			n += 2 + l + sovPrivate(uint64(l))
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	l = len(m.CacheStaleness)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
 	}
-	return n
-}
-
-func (m *RecalculateCaches) Size() (n int) {
-	if m == nil {
-		return 0
+	l = len(m.Derive)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.History)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Cascade)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	if m.TrackDistinct {
+		n += 3
+	}
+	l = len(m.TimeZone)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	if m.FiscalYearStart != 0 {
+		n += 2 + sovPrivate(uint64(m.FiscalYearStart))
+	}
+	if m.RejectNewKeys {
+		n += 3
 	}
-	var l int
-	_ = l
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *LoadSchemaMessage) Size() (n int) {
+func (m *ImportResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = len(m.Err)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *TransactionMessage) Size() (n int) {
+func (m *BlockDataRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Action)
+	l = len(m.Index)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if m.Transaction != nil {
-		l = m.Transaction.Size()
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Block != 0 {
+		n += 1 + sovPrivate(uint64(m.Block))
+	}
+	if m.Shard != 0 {
+		n += 1 + sovPrivate(uint64(m.Shard))
+	}
+	l = len(m.View)
+	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -6328,30 +6998,102 @@ func (m *TransactionMessage) Size() (n int) {
 	return n
 }
 
-func (m *Transaction) Size() (n int) {
+func (m *BlockDataResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.ID)
+	if len(m.RowIDs) > 0 {
+		l = 0
+		for _, e := range m.RowIDs {
+			l += sovPrivate(uint64(e))
+		}
+		n += 1 + sovPrivate(uint64(l)) + l
+	}
+	if len(m.ColumnIDs) > 0 {
+		l = 0
+		for _, e := range m.ColumnIDs {
+			l += sovPrivate(uint64(e))
+		}
+		n += 1 + sovPrivate(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Cache) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.IDs) > 0 {
+		l = 0
+		for _, e := range m.IDs {
+			l += sovPrivate(uint64(e))
+		}
+		n += 1 + sovPrivate(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MaxShards) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Standard) > 0 {
+		for k, v := range m.Standard {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPrivate(uint64(len(k))) + 1 + sovPrivate(uint64(v))
+			n += mapEntrySize + 1 + sovPrivate(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateShardMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Index)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if m.Active {
-		n += 2
+	if m.Shard != 0 {
+		n += 1 + sovPrivate(uint64(m.Shard))
 	}
-	if m.Exclusive {
-		n += 2
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if m.Timeout != 0 {
-		n += 1 + sovPrivate(uint64(m.Timeout))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if m.Deadline != 0 {
-		n += 1 + sovPrivate(uint64(m.Deadline))
+	return n
+}
+
+func (m *DeleteIndexMessage) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	if m.Stats != nil {
-		l = m.Stats.Size()
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -6360,41 +7102,87 @@ func (m *Transaction) Size() (n int) {
 	return n
 }
 
-func (m *TransactionStats) Size() (n int) {
+func (m *CreateIndexMessage) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovPrivate(uint64(m.CreatedAt))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ResizeAbortMessage) Size() (n int) {
+func (m *CreateFieldMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovPrivate(uint64(m.CreatedAt))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateFieldMessage) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.CreateFieldMessage != nil {
+		l = m.CreateFieldMessage.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Update != nil {
+		l = m.Update.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ResizeNodeMessage) Size() (n int) {
+func (m *FieldUpdate) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.NodeID)
+	l = len(m.Option)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	l = len(m.Action)
+	l = len(m.Value)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
@@ -6404,32 +7192,42 @@ func (m *ResizeNodeMessage) Size() (n int) {
 	return n
 }
 
-func (m *FieldOperation) Size() (n int) {
+func (m *DeleteFieldMessage) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.RecordIDs) > 0 {
-		l = 0
-		for _, e := range m.RecordIDs {
-			l += sovPrivate(uint64(e))
-		}
-		n += 1 + sovPrivate(uint64(l)) + l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if len(m.Values) > 0 {
-		l = 0
-		for _, e := range m.Values {
-			l += sovPrivate(uint64(e))
-		}
-		n += 1 + sovPrivate(uint64(l)) + l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if len(m.Signed) > 0 {
-		l = 0
-		for _, e := range m.Signed {
-			l += sovPrivate(uint64(e))
-		}
-		n += 1 + sovPrivate(uint64(l)) + l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteAvailableShardMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.ShardID != 0 {
+		n += 1 + sovPrivate(uint64(m.ShardID))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -6437,98 +7235,2702 @@ func (m *FieldOperation) Size() (n int) {
 	return n
 }
 
-func (m *ShardIngestOperation) Size() (n int) {
+func (m *SetRowMetaMessage) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.OpType)
+	l = len(m.Index)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if len(m.ClearRecordIDs) > 0 {
-		l = 0
-		for _, e := range m.ClearRecordIDs {
-			l += sovPrivate(uint64(e))
-		}
-		n += 1 + sovPrivate(uint64(l)) + l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if len(m.ClearFields) > 0 {
-		for _, s := range m.ClearFields {
-			l = len(s)
-			n += 1 + l + sovPrivate(uint64(l))
-		}
+	l = len(m.Row)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if len(m.FieldOps) > 0 {
-		for k, v := range m.FieldOps {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovPrivate(uint64(l))
+	l = len(m.Meta)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetAliasMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Alias)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CloneIndexMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateIndexMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CreateIndexMessage != nil {
+		l = m.CreateIndexMessage.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Update != nil {
+		l = m.Update.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IndexUpdate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Option)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MaintenanceMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Field) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if len(m.Views) > 0 {
+		for _, s := range m.Views {
+			l = len(s)
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovPrivate(uint64(m.CreatedAt))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Schema) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Indexes) > 0 {
+		for _, e := range m.Indexes {
+			l = e.Size()
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Index) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovPrivate(uint64(m.CreatedAt))
+	}
+	if len(m.Fields) > 0 {
+		for _, e := range m.Fields {
+			l = e.Size()
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if m.Options != nil {
+		l = m.Options.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *URI) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Scheme)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Host)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovPrivate(uint64(m.Port))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Node) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.URI != nil {
+		l = m.URI.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.IsPrimary {
+		n += 2
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.GRPCURI != nil {
+		l = m.GRPCURI.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NodeStateMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NodeEventMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Event != 0 {
+		n += 1 + sovPrivate(uint64(m.Event))
+	}
+	if m.Node != nil {
+		l = m.Node.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NodeStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Node != nil {
+		l = m.Node.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Schema != nil {
+		l = m.Schema.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if len(m.Indexes) > 0 {
+		for _, e := range m.Indexes {
+			l = e.Size()
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IndexStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if len(m.Fields) > 0 {
+		for _, e := range m.Fields {
+			l = e.Size()
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovPrivate(uint64(m.CreatedAt))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FieldStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if len(m.AvailableShards) > 0 {
+		l = 0
+		for _, e := range m.AvailableShards {
+			l += sovPrivate(uint64(e))
+		}
+		n += 1 + sovPrivate(uint64(l)) + l
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovPrivate(uint64(m.CreatedAt))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ClusterStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClusterID)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if len(m.Nodes) > 0 {
+		for _, e := range m.Nodes {
+			l = e.Size()
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if m.Schema != nil {
+		l = m.Schema.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BSIGroup) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Min != 0 {
+		n += 1 + sovPrivate(uint64(m.Min))
+	}
+	if m.Max != 0 {
+		n += 1 + sovPrivate(uint64(m.Max))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateViewMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.View)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteViewMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.View)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResizeInstruction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.JobID != 0 {
+		n += 1 + sovPrivate(uint64(m.JobID))
+	}
+	if m.Node != nil {
+		l = m.Node.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Primary != nil {
+		l = m.Primary.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if len(m.Sources) > 0 {
+		for _, e := range m.Sources {
+			l = e.Size()
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if m.ClusterStatus != nil {
+		l = m.ClusterStatus.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.NodeStatus != nil {
+		l = m.NodeStatus.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if len(m.TranslationSources) > 0 {
+		for _, e := range m.TranslationSources {
+			l = e.Size()
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResizeSource) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Node != nil {
+		l = m.Node.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.View)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Shard != 0 {
+		n += 1 + sovPrivate(uint64(m.Shard))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TranslationResizeSource) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Node != nil {
+		l = m.Node.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.PartitionID != 0 {
+		n += 1 + sovPrivate(uint64(m.PartitionID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResizeInstructionComplete) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.JobID != 0 {
+		n += 1 + sovPrivate(uint64(m.JobID))
+	}
+	if m.Node != nil {
+		l = m.Node.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Topology) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClusterID)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if len(m.NodeIDs) > 0 {
+		for _, s := range m.NodeIDs {
+			l = len(s)
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RecalculateCaches) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LoadSchemaMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransactionMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Transaction != nil {
+		l = m.Transaction.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Transaction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Active {
+		n += 2
+	}
+	if m.Exclusive {
+		n += 2
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovPrivate(uint64(m.Timeout))
+	}
+	if m.Deadline != 0 {
+		n += 1 + sovPrivate(uint64(m.Deadline))
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransactionStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResizeAbortMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResizeNodeMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FieldOperation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.RecordIDs) > 0 {
+		l = 0
+		for _, e := range m.RecordIDs {
+			l += sovPrivate(uint64(e))
+		}
+		n += 1 + sovPrivate(uint64(l)) + l
+	}
+	if len(m.Values) > 0 {
+		l = 0
+		for _, e := range m.Values {
+			l += sovPrivate(uint64(e))
+		}
+		n += 1 + sovPrivate(uint64(l)) + l
+	}
+	if len(m.Signed) > 0 {
+		l = 0
+		for _, e := range m.Signed {
+			l += sovPrivate(uint64(e))
+		}
+		n += 1 + sovPrivate(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardIngestOperation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.OpType)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if len(m.ClearRecordIDs) > 0 {
+		l = 0
+		for _, e := range m.ClearRecordIDs {
+			l += sovPrivate(uint64(e))
+		}
+		n += 1 + sovPrivate(uint64(l)) + l
+	}
+	if len(m.ClearFields) > 0 {
+		for _, s := range m.ClearFields {
+			l = len(s)
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if len(m.FieldOps) > 0 {
+		for k, v := range m.FieldOps {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovPrivate(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovPrivate(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovPrivate(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardIngestOperations) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ops) > 0 {
+		for _, e := range m.Ops {
+			l = e.Size()
+			n += 1 + l + sovPrivate(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardedIngestRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ops) > 0 {
+		for k, v := range m.Ops {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovPrivate(uint64(l))
+			}
+			mapEntrySize := 1 + sovPrivate(uint64(k)) + l
+			n += mapEntrySize + 1 + sovPrivate(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SettingsMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.QueryTimeout != 0 {
+		n += 1 + sovPrivate(uint64(m.QueryTimeout))
+	}
+	if m.LongQueryTime != 0 {
+		n += 1 + sovPrivate(uint64(m.LongQueryTime))
+	}
+	if m.WorkerPoolSize != 0 {
+		n += 1 + sovPrivate(uint64(m.WorkerPoolSize))
+	}
+	if m.MaxWritesPerRequest != 0 {
+		n += 1 + sovPrivate(uint64(m.MaxWritesPerRequest))
+	}
+	if m.MaxQueryMemory != 0 {
+		n += 1 + sovPrivate(uint64(m.MaxQueryMemory))
+	}
+	if m.MaxBatchQueries != 0 {
+		n += 1 + sovPrivate(uint64(m.MaxBatchQueries))
+	}
+	if m.MaxBackgroundQueries != 0 {
+		n += 1 + sovPrivate(uint64(m.MaxBackgroundQueries))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovPrivate(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozPrivate(x uint64) (n int) {
+	return sovPrivate(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *IndexMeta) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IndexMeta: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IndexMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Keys = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrackExistence", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TrackExistence = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxColumns", wireType)
+			}
+			m.MaxColumns = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxColumns |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGroups", wireType)
+			}
+			m.MaxGroups = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGroups |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRows", wireType)
+			}
+			m.MaxRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxRows |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tags == nil {
+				m.Tags = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPrivate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPrivate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPrivate(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Tags[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxStorage", wireType)
+			}
+			m.MaxStorage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxStorage |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cubes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cubes = append(m.Cubes, &Cube{})
+			if err := m.Cubes[len(m.Cubes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Cube) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Cube: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Cube: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aggregate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Aggregate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FieldOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FieldOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FieldOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CacheType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CacheType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CacheSize", wireType)
+			}
+			m.CacheSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CacheSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeQuantum", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TimeQuantum = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldMin", wireType)
+			}
+			m.OldMin = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OldMin |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldMax", wireType)
+			}
+			m.OldMax = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OldMax |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Keys = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NoStandardView", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NoStandardView = bool(v != 0)
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Base", wireType)
+			}
+			m.Base = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Base |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BitDepth", wireType)
+			}
+			m.BitDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BitDepth |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scale", wireType)
+			}
+			m.Scale = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Scale |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForeignIndex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ForeignIndex = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Min == nil {
+				m.Min = &Decimal{}
+			}
+			if err := m.Min.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Max == nil {
+				m.Max = &Decimal{}
+			}
+			if err := m.Max.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeUnit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TimeUnit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TTL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tags == nil {
+				m.Tags = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPrivate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPrivate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPrivate(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Tags[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryPolicy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MemoryPolicy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryPolicyViews", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MemoryPolicyViews = append(m.MemoryPolicyViews, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CacheStaleness", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CacheStaleness = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 27:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Derive", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Derive = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field History", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.History = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 29:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cascade", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cascade = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 30:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrackDistinct", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TrackDistinct = bool(v != 0)
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeZone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TimeZone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 32:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FiscalYearStart", wireType)
+			}
+			m.FiscalYearStart = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FiscalYearStart |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 33:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectNewKeys", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RejectNewKeys = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Err = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BlockDataRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockDataRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockDataRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			mapEntrySize := 1 + len(k) + sovPrivate(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovPrivate(uint64(mapEntrySize))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *ShardIngestOperations) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Ops) > 0 {
-		for _, e := range m.Ops {
-			l = e.Size()
-			n += 1 + l + sovPrivate(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *ShardedIngestRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Ops) > 0 {
-		for k, v := range m.Ops {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovPrivate(uint64(l))
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
 			}
-			mapEntrySize := 1 + sovPrivate(uint64(k)) + l
-			n += mapEntrySize + 1 + sovPrivate(uint64(mapEntrySize))
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			m.Block = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Block |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			m.Shard = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shard |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.View = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func sovPrivate(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozPrivate(x uint64) (n int) {
-	return sovPrivate(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *IndexMeta) Unmarshal(dAtA []byte) error {
+func (m *BlockDataResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6551,52 +9953,164 @@ func (m *IndexMeta) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IndexMeta: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockDataResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IndexMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockDataResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RowIDs = append(m.RowIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPrivate
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPrivate
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RowIDs) == 0 {
+					m.RowIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPrivate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RowIDs = append(m.RowIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowIDs", wireType)
+			}
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+				m.ColumnIDs = append(m.ColumnIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				if packedLen < 0 {
+					return ErrInvalidLengthPrivate
 				}
-			}
-			m.Keys = bool(v != 0)
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TrackExistence", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPrivate
 				}
-				if iNdEx >= l {
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ColumnIDs) == 0 {
+					m.ColumnIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPrivate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ColumnIDs = append(m.ColumnIDs, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
 			}
-			m.TrackExistence = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -6619,7 +10133,7 @@ func (m *IndexMeta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FieldOptions) Unmarshal(dAtA []byte) error {
+func (m *Cache) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6642,248 +10156,144 @@ func (m *FieldOptions) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FieldOptions: wiretype end group for non-group")
+			return fmt.Errorf("proto: Cache: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FieldOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Cache: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CacheType", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.CacheType = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CacheSize", wireType)
-			}
-			m.CacheSize = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CacheSize |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TimeQuantum", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TimeQuantum = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Type = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OldMin", wireType)
-			}
-			m.OldMin = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+				m.IDs = append(m.IDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.OldMin |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				if packedLen < 0 {
+					return ErrInvalidLengthPrivate
 				}
-			}
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OldMax", wireType)
-			}
-			m.OldMax = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPrivate
 				}
-				if iNdEx >= l {
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.OldMax |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+				elementCount = count
+				if elementCount != 0 && len(m.IDs) == 0 {
+					m.IDs = make([]uint64, 0, elementCount)
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPrivate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.IDs = append(m.IDs, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
-			m.Keys = bool(v != 0)
-		case 12:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NoStandardView", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPrivate
 			}
-			m.NoStandardView = bool(v != 0)
-		case 13:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Base", wireType)
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Base = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Base |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MaxShards) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
 			}
-		case 14:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BitDepth", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.BitDepth = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.BitDepth |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 15:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Scale", wireType)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaxShards: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaxShards: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Standard", wireType)
 			}
-			m.Scale = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -6893,84 +10303,161 @@ func (m *FieldOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Scale |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 16:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ForeignIndex", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Standard == nil {
+				m.Standard = make(map[string]uint64)
+			}
+			var mapkey string
+			var mapvalue uint64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPrivate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPrivate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPrivate(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthPrivate
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPrivate
+			m.Standard[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ForeignIndex = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 17:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPrivate
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateShardMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Min == nil {
-				m.Min = &Decimal{}
-			}
-			if err := m.Min.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 18:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateShardMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateShardMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -6980,33 +10467,29 @@ func (m *FieldOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPrivate
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Max == nil {
-				m.Max = &Decimal{}
-			}
-			if err := m.Max.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 19:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TimeUnit", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
 			}
-			var stringLen uint64
+			m.Shard = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -7016,27 +10499,14 @@ func (m *FieldOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Shard |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TimeUnit = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 20:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7064,7 +10534,7 @@ func (m *FieldOptions) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TTL = string(dAtA[iNdEx:postIndex])
+			m.Field = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -7088,7 +10558,7 @@ func (m *FieldOptions) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImportResponse) Unmarshal(dAtA []byte) error {
+func (m *DeleteIndexMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7111,15 +10581,15 @@ func (m *ImportResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImportResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteIndexMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteIndexMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7147,7 +10617,7 @@ func (m *ImportResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Err = string(dAtA[iNdEx:postIndex])
+			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -7171,7 +10641,7 @@ func (m *ImportResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlockDataRequest) Unmarshal(dAtA []byte) error {
+func (m *CreateIndexMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7194,10 +10664,10 @@ func (m *BlockDataRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlockDataRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateIndexMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockDataRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateIndexMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7234,9 +10704,9 @@ func (m *BlockDataRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -7246,94 +10716,47 @@ func (m *BlockDataRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPrivate
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Field = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			if m.Meta == nil {
+				m.Meta = &IndexMeta{}
 			}
-			m.Block = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Block |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		case 4:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
-			}
-			m.Shard = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Shard |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
 			}
-			var stringLen uint64
+			m.CreatedAt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.View = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -7356,7 +10779,7 @@ func (m *BlockDataRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlockDataResponse) Unmarshal(dAtA []byte) error {
+func (m *CreateFieldMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7379,163 +10802,130 @@ func (m *BlockDataResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlockDataResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateFieldMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockDataResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateFieldMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPrivate
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.RowIDs = append(m.RowIDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPrivate
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPrivate
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPrivate
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.RowIDs) == 0 {
-					m.RowIDs = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPrivate
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.RowIDs = append(m.RowIDs, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field RowIDs", wireType)
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPrivate
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
 				}
-				m.ColumnIDs = append(m.ColumnIDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPrivate
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPrivate
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPrivate
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				elementCount = count
-				if elementCount != 0 && len(m.ColumnIDs) == 0 {
-					m.ColumnIDs = make([]uint64, 0, elementCount)
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Meta == nil {
+				m.Meta = &FieldOptions{}
+			}
+			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
 				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPrivate
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.ColumnIDs = append(m.ColumnIDs, v)
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
 			}
 		default:
 			iNdEx = preIndex
@@ -7559,7 +10949,7 @@ func (m *BlockDataResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Cache) Unmarshal(dAtA []byte) error {
+func (m *UpdateFieldMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7582,88 +10972,84 @@ func (m *Cache) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Cache: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateFieldMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Cache: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateFieldMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPrivate
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateFieldMessage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
 				}
-				m.IDs = append(m.IDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPrivate
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPrivate
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPrivate
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CreateFieldMessage == nil {
+				m.CreateFieldMessage = &CreateFieldMessage{}
+			}
+			if err := m.CreateFieldMessage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.IDs) == 0 {
-					m.IDs = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPrivate
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.IDs = append(m.IDs, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Update == nil {
+				m.Update = &FieldUpdate{}
+			}
+			if err := m.Update.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -7686,7 +11072,7 @@ func (m *Cache) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaxShards) Unmarshal(dAtA []byte) error {
+func (m *FieldUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7709,17 +11095,17 @@ func (m *MaxShards) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaxShards: wiretype end group for non-group")
+			return fmt.Errorf("proto: FieldUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaxShards: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FieldUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Standard", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Option", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -7729,104 +11115,55 @@ func (m *MaxShards) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPrivate
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Standard == nil {
-				m.Standard = make(map[string]uint64)
+			m.Option = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var mapkey string
-			var mapvalue uint64
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPrivate
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPrivate
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthPrivate
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthPrivate
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPrivate
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipPrivate(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthPrivate
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Standard[mapkey] = mapvalue
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -7850,7 +11187,7 @@ func (m *MaxShards) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateShardMessage) Unmarshal(dAtA []byte) error {
+func (m *DeleteFieldMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7873,10 +11210,10 @@ func (m *CreateShardMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateShardMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteFieldMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateShardMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteFieldMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7912,25 +11249,6 @@ func (m *CreateShardMessage) Unmarshal(dAtA []byte) error {
 			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
-			}
-			m.Shard = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Shard |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
 			}
@@ -7984,7 +11302,7 @@ func (m *CreateShardMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteIndexMessage) Unmarshal(dAtA []byte) error {
+func (m *DeleteAvailableShardMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8007,10 +11325,10 @@ func (m *DeleteIndexMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteIndexMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteAvailableShardMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteIndexMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteAvailableShardMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8045,6 +11363,57 @@ func (m *DeleteIndexMessage) Unmarshal(dAtA []byte) error {
 			}
 			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -8067,7 +11436,7 @@ func (m *DeleteIndexMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateIndexMessage) Unmarshal(dAtA []byte) error {
+func (m *SetRowMetaMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8090,10 +11459,10 @@ func (m *CreateIndexMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateIndexMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetRowMetaMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateIndexMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetRowMetaMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8130,9 +11499,9 @@ func (m *CreateIndexMessage) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -8142,33 +11511,61 @@ func (m *CreateIndexMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPrivate
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Meta == nil {
-				m.Meta = &IndexMeta{}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
 			}
-			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Row = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
 			}
-			m.CreatedAt = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -8178,11 +11575,26 @@ func (m *CreateIndexMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CreatedAt |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Meta = append(m.Meta[:0], dAtA[iNdEx:postIndex]...)
+			if m.Meta == nil {
+				m.Meta = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -8205,7 +11617,7 @@ func (m *CreateIndexMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateFieldMessage) Unmarshal(dAtA []byte) error {
+func (m *SetAliasMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8228,15 +11640,15 @@ func (m *CreateFieldMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateFieldMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetAliasMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateFieldMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetAliasMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Alias", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8264,11 +11676,11 @@ func (m *CreateFieldMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Index = string(dAtA[iNdEx:postIndex])
+			m.Alias = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8296,63 +11708,8 @@ func (m *CreateFieldMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Field = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Meta == nil {
-				m.Meta = &FieldOptions{}
-			}
-			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
-			}
-			m.CreatedAt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CreatedAt |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -8375,7 +11732,7 @@ func (m *CreateFieldMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateFieldMessage) Unmarshal(dAtA []byte) error {
+func (m *CloneIndexMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8398,17 +11755,17 @@ func (m *UpdateFieldMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateFieldMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: CloneIndexMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateFieldMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CloneIndexMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateFieldMessage", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -8418,33 +11775,29 @@ func (m *UpdateFieldMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPrivate
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.CreateFieldMessage == nil {
-				m.CreateFieldMessage = &CreateFieldMessage{}
-			}
-			if err := m.CreateFieldMessage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Source = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -8454,27 +11807,23 @@ func (m *UpdateFieldMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPrivate
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Update == nil {
-				m.Update = &FieldUpdate{}
-			}
-			if err := m.Update.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Target = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8498,7 +11847,7 @@ func (m *UpdateFieldMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FieldUpdate) Unmarshal(dAtA []byte) error {
+func (m *UpdateIndexMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8521,17 +11870,17 @@ func (m *FieldUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FieldUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateIndexMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FieldUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateIndexMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Option", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateIndexMessage", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -8541,29 +11890,33 @@ func (m *FieldUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPrivate
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Option = string(dAtA[iNdEx:postIndex])
+			if m.CreateIndexMessage == nil {
+				m.CreateIndexMessage = &CreateIndexMessage{}
+			}
+			if err := m.CreateIndexMessage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -8573,23 +11926,27 @@ func (m *FieldUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPrivate
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = string(dAtA[iNdEx:postIndex])
+			if m.Update == nil {
+				m.Update = &IndexUpdate{}
+			}
+			if err := m.Update.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8613,7 +11970,7 @@ func (m *FieldUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteFieldMessage) Unmarshal(dAtA []byte) error {
+func (m *IndexUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8636,15 +11993,15 @@ func (m *DeleteFieldMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteFieldMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: IndexUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteFieldMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IndexUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Option", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8672,11 +12029,11 @@ func (m *DeleteFieldMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Index = string(dAtA[iNdEx:postIndex])
+			m.Option = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8704,7 +12061,7 @@ func (m *DeleteFieldMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Field = string(dAtA[iNdEx:postIndex])
+			m.Value = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8728,7 +12085,7 @@ func (m *DeleteFieldMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteAvailableShardMessage) Unmarshal(dAtA []byte) error {
+func (m *MaintenanceMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8751,47 +12108,15 @@ func (m *DeleteAvailableShardMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteAvailableShardMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: MaintenanceMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteAvailableShardMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MaintenanceMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Index = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8819,27 +12144,8 @@ func (m *DeleteAvailableShardMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Field = string(dAtA[iNdEx:postIndex])
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
-			}
-			m.ShardID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPrivate
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -13314,6 +16620,190 @@ func (m *ShardedIngestRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SettingsMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SettingsMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SettingsMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueryTimeout", wireType)
+			}
+			m.QueryTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.QueryTimeout |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LongQueryTime", wireType)
+			}
+			m.LongQueryTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LongQueryTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkerPoolSize", wireType)
+			}
+			m.WorkerPoolSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WorkerPoolSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxWritesPerRequest", wireType)
+			}
+			m.MaxWritesPerRequest = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxWritesPerRequest |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxQueryMemory", wireType)
+			}
+			m.MaxQueryMemory = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxQueryMemory |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBatchQueries", wireType)
+			}
+			m.MaxBatchQueries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBatchQueries |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBackgroundQueries", wireType)
+			}
+			m.MaxBackgroundQueries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBackgroundQueries |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipPrivate(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -609,6 +609,7 @@ type ExtractedTableColumn struct {
 	//	*ExtractedTableColumn_ID
 	KeyOrID              isExtractedTableColumn_KeyOrID `protobuf_oneof:"KeyOrID"`
 	Values               []*ExtractedTableValue         `protobuf:"bytes,3,rep,name=Values,proto3" json:"Values,omitempty"`
+	Attrs                []byte                         `protobuf:"bytes,4,opt,name=Attrs,proto3" json:"Attrs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
 	XXX_unrecognized     []byte                         `json:"-"`
 	XXX_sizecache        int32                          `json:"-"`
@@ -691,6 +692,13 @@ func (m *ExtractedTableColumn) GetValues() []*ExtractedTableValue {
 	return nil
 }
 
+func (m *ExtractedTableColumn) GetAttrs() []byte {
+	if m != nil {
+		return m.Attrs
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*ExtractedTableColumn) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -930,6 +938,7 @@ func (m *PairField) GetField() string {
 type PairsField struct {
 	Pairs                []*Pair  `protobuf:"bytes,1,rep,name=Pairs,proto3" json:"Pairs,omitempty"`
 	Field                string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
+	Stale                bool     `protobuf:"varint,3,opt,name=Stale,proto3" json:"Stale,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -982,6 +991,13 @@ func (m *PairsField) GetField() string {
 	return ""
 }
 
+func (m *PairsField) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
 type Int64 struct {
 	Value                int64    `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1034,6 +1050,9 @@ type FieldRow struct {
 	RowID                uint64   `protobuf:"varint,2,opt,name=RowID,proto3" json:"RowID,omitempty"`
 	RowKey               string   `protobuf:"bytes,3,opt,name=RowKey,proto3" json:"RowKey,omitempty"`
 	Value                *Int64   `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	Period               string   `protobuf:"bytes,5,opt,name=Period,proto3" json:"Period,omitempty"`
+	Index                string   `protobuf:"bytes,6,opt,name=Index,proto3" json:"Index,omitempty"`
+	ValueTo              *Int64   `protobuf:"bytes,7,opt,name=ValueTo,proto3" json:"ValueTo,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1100,10 +1119,34 @@ func (m *FieldRow) GetValue() *Int64 {
 	return nil
 }
 
+func (m *FieldRow) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *FieldRow) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *FieldRow) GetValueTo() *Int64 {
+	if m != nil {
+		return m.ValueTo
+	}
+	return nil
+}
+
 type GroupCount struct {
 	Group                []*FieldRow `protobuf:"bytes,1,rep,name=Group,proto3" json:"Group,omitempty"`
 	Count                uint64      `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
 	Agg                  int64       `protobuf:"varint,3,opt,name=Agg,proto3" json:"Agg,omitempty"`
+	AggWeight            int64       `protobuf:"varint,4,opt,name=AggWeight,proto3" json:"AggWeight,omitempty"`
+	TimestampAgg         string      `protobuf:"bytes,5,opt,name=TimestampAgg,proto3" json:"TimestampAgg,omitempty"`
+	Histogram            *Histogram  `protobuf:"bytes,6,opt,name=Histogram,proto3" json:"Histogram,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
@@ -1163,12 +1206,36 @@ func (m *GroupCount) GetAgg() int64 {
 	return 0
 }
 
+func (m *GroupCount) GetAggWeight() int64 {
+	if m != nil {
+		return m.AggWeight
+	}
+	return 0
+}
+
+func (m *GroupCount) GetTimestampAgg() string {
+	if m != nil {
+		return m.TimestampAgg
+	}
+	return ""
+}
+
+func (m *GroupCount) GetHistogram() *Histogram {
+	if m != nil {
+		return m.Histogram
+	}
+	return nil
+}
+
 type ValCount struct {
 	Val                  int64    `protobuf:"varint,1,opt,name=Val,proto3" json:"Val,omitempty"`
 	Count                int64    `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
 	FloatVal             float64  `protobuf:"fixed64,3,opt,name=FloatVal,proto3" json:"FloatVal,omitempty"`
 	DecimalVal           *Decimal `protobuf:"bytes,4,opt,name=DecimalVal,proto3" json:"DecimalVal,omitempty"`
 	TimestampVal         string   `protobuf:"bytes,5,opt,name=TimestampVal,proto3" json:"TimestampVal,omitempty"`
+	BigVal               string   `protobuf:"bytes,6,opt,name=BigVal,proto3" json:"BigVal,omitempty"`
+	Precision            int64    `protobuf:"varint,7,opt,name=Precision,proto3" json:"Precision,omitempty"`
+	WeightSum            string   `protobuf:"bytes,8,opt,name=WeightSum,proto3" json:"WeightSum,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1242,6 +1309,27 @@ func (m *ValCount) GetTimestampVal() string {
 	return ""
 }
 
+func (m *ValCount) GetBigVal() string {
+	if m != nil {
+		return m.BigVal
+	}
+	return ""
+}
+
+func (m *ValCount) GetPrecision() int64 {
+	if m != nil {
+		return m.Precision
+	}
+	return 0
+}
+
+func (m *ValCount) GetWeightSum() string {
+	if m != nil {
+		return m.WeightSum
+	}
+	return ""
+}
+
 type Decimal struct {
 	Value                int64    `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
 	Scale                int64    `protobuf:"varint,2,opt,name=Scale,proto3" json:"Scale,omitempty"`
@@ -1383,6 +1471,13 @@ type QueryRequest struct {
 	EmbeddedData         []*Row   `protobuf:"bytes,8,rep,name=EmbeddedData,proto3" json:"EmbeddedData,omitempty"`
 	PreTranslated        bool     `protobuf:"varint,9,opt,name=PreTranslated,proto3" json:"PreTranslated,omitempty"`
 	MaxMemory            int64    `protobuf:"varint,10,opt,name=MaxMemory,proto3" json:"MaxMemory,omitempty"`
+	ExistenceFallback    string   `protobuf:"bytes,11,opt,name=ExistenceFallback,proto3" json:"ExistenceFallback,omitempty"`
+	Priority             string   `protobuf:"bytes,12,opt,name=Priority,proto3" json:"Priority,omitempty"`
+	AllowPartial         bool     `protobuf:"varint,13,opt,name=AllowPartial,proto3" json:"AllowPartial,omitempty"`
+	ExecPath             string   `protobuf:"bytes,14,opt,name=ExecPath,proto3" json:"ExecPath,omitempty"`
+	Client               string   `protobuf:"bytes,15,opt,name=Client,proto3" json:"Client,omitempty"`
+	Hints                []string `protobuf:"bytes,16,rep,name=Hints,proto3" json:"Hints,omitempty"`
+	IgnoreResultLimits   bool     `protobuf:"varint,17,opt,name=IgnoreResultLimits,proto3" json:"IgnoreResultLimits,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1463,9 +1558,61 @@ func (m *QueryRequest) GetMaxMemory() int64 {
 	return 0
 }
 
+func (m *QueryRequest) GetExistenceFallback() string {
+	if m != nil {
+		return m.ExistenceFallback
+	}
+	return ""
+}
+
+func (m *QueryRequest) GetPriority() string {
+	if m != nil {
+		return m.Priority
+	}
+	return ""
+}
+
+func (m *QueryRequest) GetAllowPartial() bool {
+	if m != nil {
+		return m.AllowPartial
+	}
+	return false
+}
+
+func (m *QueryRequest) GetExecPath() string {
+	if m != nil {
+		return m.ExecPath
+	}
+	return ""
+}
+
+func (m *QueryRequest) GetClient() string {
+	if m != nil {
+		return m.Client
+	}
+	return ""
+}
+
+func (m *QueryRequest) GetHints() []string {
+	if m != nil {
+		return m.Hints
+	}
+	return nil
+}
+
+func (m *QueryRequest) GetIgnoreResultLimits() bool {
+	if m != nil {
+		return m.IgnoreResultLimits
+	}
+	return false
+}
+
 type QueryResponse struct {
 	Err                  string         `protobuf:"bytes,1,opt,name=Err,proto3" json:"Err,omitempty"`
 	Results              []*QueryResult `protobuf:"bytes,2,rep,name=Results,proto3" json:"Results,omitempty"`
+	Completeness         *Completeness  `protobuf:"bytes,3,opt,name=Completeness,proto3" json:"Completeness,omitempty"`
+	ErrCode              string         `protobuf:"bytes,4,opt,name=ErrCode,proto3" json:"ErrCode,omitempty"`
+	ErrRetryable         bool           `protobuf:"varint,5,opt,name=ErrRetryable,proto3" json:"ErrRetryable,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -1518,6 +1665,153 @@ func (m *QueryResponse) GetResults() []*QueryResult {
 	return nil
 }
 
+func (m *QueryResponse) GetCompleteness() *Completeness {
+	if m != nil {
+		return m.Completeness
+	}
+	return nil
+}
+
+func (m *QueryResponse) GetErrCode() string {
+	if m != nil {
+		return m.ErrCode
+	}
+	return ""
+}
+
+func (m *QueryResponse) GetErrRetryable() bool {
+	if m != nil {
+		return m.ErrRetryable
+	}
+	return false
+}
+
+type Completeness struct {
+	Complete             bool           `protobuf:"varint,1,opt,name=Complete,proto3" json:"Complete,omitempty"`
+	Shards               int64          `protobuf:"varint,2,opt,name=Shards,proto3" json:"Shards,omitempty"`
+	MissingShards        []*IndexShards `protobuf:"bytes,3,rep,name=MissingShards,proto3" json:"MissingShards,omitempty"`
+	Coverage             float64        `protobuf:"fixed64,4,opt,name=Coverage,proto3" json:"Coverage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Completeness) Reset()         { *m = Completeness{} }
+func (m *Completeness) String() string { return proto.CompactTextString(m) }
+func (*Completeness) ProtoMessage()    {}
+func (*Completeness) Descriptor() ([]byte, []int) {
+	return fileDescriptor_413a91106d7bcce8, []int{23}
+}
+func (m *Completeness) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Completeness) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Completeness.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Completeness) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Completeness.Merge(m, src)
+}
+func (m *Completeness) XXX_Size() int {
+	return m.Size()
+}
+func (m *Completeness) XXX_DiscardUnknown() {
+	xxx_messageInfo_Completeness.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Completeness proto.InternalMessageInfo
+
+func (m *Completeness) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+func (m *Completeness) GetShards() int64 {
+	if m != nil {
+		return m.Shards
+	}
+	return 0
+}
+
+func (m *Completeness) GetMissingShards() []*IndexShards {
+	if m != nil {
+		return m.MissingShards
+	}
+	return nil
+}
+
+func (m *Completeness) GetCoverage() float64 {
+	if m != nil {
+		return m.Coverage
+	}
+	return 0
+}
+
+type IndexShards struct {
+	Index                string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Shards               []uint64 `protobuf:"varint,2,rep,packed,name=Shards,proto3" json:"Shards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IndexShards) Reset()         { *m = IndexShards{} }
+func (m *IndexShards) String() string { return proto.CompactTextString(m) }
+func (*IndexShards) ProtoMessage()    {}
+func (*IndexShards) Descriptor() ([]byte, []int) {
+	return fileDescriptor_413a91106d7bcce8, []int{24}
+}
+func (m *IndexShards) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IndexShards) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IndexShards.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *IndexShards) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexShards.Merge(m, src)
+}
+func (m *IndexShards) XXX_Size() int {
+	return m.Size()
+}
+func (m *IndexShards) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexShards.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IndexShards proto.InternalMessageInfo
+
+func (m *IndexShards) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *IndexShards) GetShards() []uint64 {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
 type QueryResult struct {
 	Type     uint32    `protobuf:"varint,6,opt,name=Type,proto3" json:"Type,omitempty"`
 	Row      *Row      `protobuf:"bytes,1,opt,name=Row,proto3" json:"Row,omitempty"`
@@ -1532,26 +1826,29 @@ type QueryResult struct {
 	// format can get a best-effort treatment rather than causing panics.
 	// Later this can almost certainly go away, but leave a comment warning
 	// people that 8 is Spoken For if you do that, please.
-	OldGroupCounts       []*GroupCount      `protobuf:"bytes,8,rep,name=OldGroupCounts,proto3" json:"OldGroupCounts,omitempty"`
-	RowIdentifiers       *RowIdentifiers    `protobuf:"bytes,9,opt,name=RowIdentifiers,proto3" json:"RowIdentifiers,omitempty"`
-	SignedRow            *SignedRow         `protobuf:"bytes,10,opt,name=SignedRow,proto3" json:"SignedRow,omitempty"`
-	PairsField           *PairsField        `protobuf:"bytes,11,opt,name=PairsField,proto3" json:"PairsField,omitempty"`
-	PairField            *PairField         `protobuf:"bytes,12,opt,name=PairField,proto3" json:"PairField,omitempty"`
-	ExtractedIDMatrix    *ExtractedIDMatrix `protobuf:"bytes,13,opt,name=ExtractedIDMatrix,proto3" json:"ExtractedIDMatrix,omitempty"`
-	ExtractedTable       *ExtractedTable    `protobuf:"bytes,14,opt,name=ExtractedTable,proto3" json:"ExtractedTable,omitempty"`
-	RowMatrix            *RowMatrix         `protobuf:"bytes,15,opt,name=RowMatrix,proto3" json:"RowMatrix,omitempty"`
-	GroupCounts          *GroupCounts       `protobuf:"bytes,16,opt,name=GroupCounts,proto3" json:"GroupCounts,omitempty"`
-	DistinctTimestamp    *DistinctTimestamp `protobuf:"bytes,17,opt,name=DistinctTimestamp,proto3" json:"DistinctTimestamp,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	OldGroupCounts          []*GroupCount            `protobuf:"bytes,8,rep,name=OldGroupCounts,proto3" json:"OldGroupCounts,omitempty"`
+	RowIdentifiers          *RowIdentifiers          `protobuf:"bytes,9,opt,name=RowIdentifiers,proto3" json:"RowIdentifiers,omitempty"`
+	SignedRow               *SignedRow               `protobuf:"bytes,10,opt,name=SignedRow,proto3" json:"SignedRow,omitempty"`
+	PairsField              *PairsField              `protobuf:"bytes,11,opt,name=PairsField,proto3" json:"PairsField,omitempty"`
+	PairField               *PairField               `protobuf:"bytes,12,opt,name=PairField,proto3" json:"PairField,omitempty"`
+	ExtractedIDMatrix       *ExtractedIDMatrix       `protobuf:"bytes,13,opt,name=ExtractedIDMatrix,proto3" json:"ExtractedIDMatrix,omitempty"`
+	ExtractedTable          *ExtractedTable          `protobuf:"bytes,14,opt,name=ExtractedTable,proto3" json:"ExtractedTable,omitempty"`
+	RowMatrix               *RowMatrix               `protobuf:"bytes,15,opt,name=RowMatrix,proto3" json:"RowMatrix,omitempty"`
+	GroupCounts             *GroupCounts             `protobuf:"bytes,16,opt,name=GroupCounts,proto3" json:"GroupCounts,omitempty"`
+	DistinctTimestamp       *DistinctTimestamp       `protobuf:"bytes,17,opt,name=DistinctTimestamp,proto3" json:"DistinctTimestamp,omitempty"`
+	SortedRow               *SortedRow               `protobuf:"bytes,18,opt,name=SortedRow,proto3" json:"SortedRow,omitempty"`
+	ExtractedIDMatrixSorted *ExtractedIDMatrixSorted `protobuf:"bytes,19,opt,name=ExtractedIDMatrixSorted,proto3" json:"ExtractedIDMatrixSorted,omitempty"`
+	Histogram               *Histogram               `protobuf:"bytes,20,opt,name=Histogram,proto3" json:"Histogram,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}                 `json:"-"`
+	XXX_unrecognized        []byte                   `json:"-"`
+	XXX_sizecache           int32                    `json:"-"`
 }
 
 func (m *QueryResult) Reset()         { *m = QueryResult{} }
 func (m *QueryResult) String() string { return proto.CompactTextString(m) }
 func (*QueryResult) ProtoMessage()    {}
 func (*QueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{23}
+	return fileDescriptor_413a91106d7bcce8, []int{25}
 }
 func (m *QueryResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1699,6 +1996,27 @@ func (m *QueryResult) GetDistinctTimestamp() *DistinctTimestamp {
 	return nil
 }
 
+func (m *QueryResult) GetSortedRow() *SortedRow {
+	if m != nil {
+		return m.SortedRow
+	}
+	return nil
+}
+
+func (m *QueryResult) GetExtractedIDMatrixSorted() *ExtractedIDMatrixSorted {
+	if m != nil {
+		return m.ExtractedIDMatrixSorted
+	}
+	return nil
+}
+
+func (m *QueryResult) GetHistogram() *Histogram {
+	if m != nil {
+		return m.Histogram
+	}
+	return nil
+}
+
 type ImportRequest struct {
 	Index                string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
 	Field                string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
@@ -1720,7 +2038,7 @@ func (m *ImportRequest) Reset()         { *m = ImportRequest{} }
 func (m *ImportRequest) String() string { return proto.CompactTextString(m) }
 func (*ImportRequest) ProtoMessage()    {}
 func (*ImportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{24}
+	return fileDescriptor_413a91106d7bcce8, []int{26}
 }
 func (m *ImportRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1838,6 +2156,8 @@ type ImportValueRequest struct {
 	IndexCreatedAt       int64     `protobuf:"varint,10,opt,name=IndexCreatedAt,proto3" json:"IndexCreatedAt,omitempty"`
 	FieldCreatedAt       int64     `protobuf:"varint,11,opt,name=FieldCreatedAt,proto3" json:"FieldCreatedAt,omitempty"`
 	Clear                bool      `protobuf:"varint,12,opt,name=Clear,proto3" json:"Clear,omitempty"`
+	DecimalValues        []string  `protobuf:"bytes,13,rep,name=DecimalValues,proto3" json:"DecimalValues,omitempty"`
+	Nulls                []bool    `protobuf:"varint,14,rep,packed,name=Nulls,proto3" json:"Nulls,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -1847,7 +2167,7 @@ func (m *ImportValueRequest) Reset()         { *m = ImportValueRequest{} }
 func (m *ImportValueRequest) String() string { return proto.CompactTextString(m) }
 func (*ImportValueRequest) ProtoMessage()    {}
 func (*ImportValueRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{25}
+	return fileDescriptor_413a91106d7bcce8, []int{27}
 }
 func (m *ImportValueRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1953,6 +2273,20 @@ func (m *ImportValueRequest) GetClear() bool {
 	return false
 }
 
+func (m *ImportValueRequest) GetDecimalValues() []string {
+	if m != nil {
+		return m.DecimalValues
+	}
+	return nil
+}
+
+func (m *ImportValueRequest) GetNulls() []bool {
+	if m != nil {
+		return m.Nulls
+	}
+	return nil
+}
+
 type AtomicRecord struct {
 	Index                string                `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
 	Shard                uint64                `protobuf:"varint,2,opt,name=Shard,proto3" json:"Shard,omitempty"`
@@ -1967,7 +2301,7 @@ func (m *AtomicRecord) Reset()         { *m = AtomicRecord{} }
 func (m *AtomicRecord) String() string { return proto.CompactTextString(m) }
 func (*AtomicRecord) ProtoMessage()    {}
 func (*AtomicRecord) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{26}
+	return fileDescriptor_413a91106d7bcce8, []int{28}
 }
 func (m *AtomicRecord) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2035,7 +2369,7 @@ func (m *AtomicImportResponse) Reset()         { *m = AtomicImportResponse{} }
 func (m *AtomicImportResponse) String() string { return proto.CompactTextString(m) }
 func (*AtomicImportResponse) ProtoMessage()    {}
 func (*AtomicImportResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{27}
+	return fileDescriptor_413a91106d7bcce8, []int{29}
 }
 func (m *AtomicImportResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2085,7 +2419,7 @@ func (m *TranslateKeysRequest) Reset()         { *m = TranslateKeysRequest{} }
 func (m *TranslateKeysRequest) String() string { return proto.CompactTextString(m) }
 func (*TranslateKeysRequest) ProtoMessage()    {}
 func (*TranslateKeysRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{28}
+	return fileDescriptor_413a91106d7bcce8, []int{30}
 }
 func (m *TranslateKeysRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2153,7 +2487,7 @@ func (m *TranslateKeysResponse) Reset()         { *m = TranslateKeysResponse{} }
 func (m *TranslateKeysResponse) String() string { return proto.CompactTextString(m) }
 func (*TranslateKeysResponse) ProtoMessage()    {}
 func (*TranslateKeysResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{29}
+	return fileDescriptor_413a91106d7bcce8, []int{31}
 }
 func (m *TranslateKeysResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2202,7 +2536,7 @@ func (m *TranslateIDsRequest) Reset()         { *m = TranslateIDsRequest{} }
 func (m *TranslateIDsRequest) String() string { return proto.CompactTextString(m) }
 func (*TranslateIDsRequest) ProtoMessage()    {}
 func (*TranslateIDsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{30}
+	return fileDescriptor_413a91106d7bcce8, []int{32}
 }
 func (m *TranslateIDsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2263,7 +2597,7 @@ func (m *TranslateIDsResponse) Reset()         { *m = TranslateIDsResponse{} }
 func (m *TranslateIDsResponse) String() string { return proto.CompactTextString(m) }
 func (*TranslateIDsResponse) ProtoMessage()    {}
 func (*TranslateIDsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{31}
+	return fileDescriptor_413a91106d7bcce8, []int{33}
 }
 func (m *TranslateIDsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2311,7 +2645,7 @@ func (m *ImportRoaringRequestView) Reset()         { *m = ImportRoaringRequestVi
 func (m *ImportRoaringRequestView) String() string { return proto.CompactTextString(m) }
 func (*ImportRoaringRequestView) ProtoMessage()    {}
 func (*ImportRoaringRequestView) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{32}
+	return fileDescriptor_413a91106d7bcce8, []int{34}
 }
 func (m *ImportRoaringRequestView) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2371,7 +2705,7 @@ func (m *ImportRoaringRequest) Reset()         { *m = ImportRoaringRequest{} }
 func (m *ImportRoaringRequest) String() string { return proto.CompactTextString(m) }
 func (*ImportRoaringRequest) ProtoMessage()    {}
 func (*ImportRoaringRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{33}
+	return fileDescriptor_413a91106d7bcce8, []int{35}
 }
 func (m *ImportRoaringRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2464,7 +2798,7 @@ func (m *RoaringUpdate) Reset()         { *m = RoaringUpdate{} }
 func (m *RoaringUpdate) String() string { return proto.CompactTextString(m) }
 func (*RoaringUpdate) ProtoMessage()    {}
 func (*RoaringUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{34}
+	return fileDescriptor_413a91106d7bcce8, []int{36}
 }
 func (m *RoaringUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2540,7 +2874,7 @@ func (m *ImportRoaringShardRequest) Reset()         { *m = ImportRoaringShardReq
 func (m *ImportRoaringShardRequest) String() string { return proto.CompactTextString(m) }
 func (*ImportRoaringShardRequest) ProtoMessage()    {}
 func (*ImportRoaringShardRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{35}
+	return fileDescriptor_413a91106d7bcce8, []int{37}
 }
 func (m *ImportRoaringShardRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2595,7 +2929,7 @@ func (m *GroupCounts) Reset()         { *m = GroupCounts{} }
 func (m *GroupCounts) String() string { return proto.CompactTextString(m) }
 func (*GroupCounts) ProtoMessage()    {}
 func (*GroupCounts) Descriptor() ([]byte, []int) {
-	return fileDescriptor_413a91106d7bcce8, []int{36}
+	return fileDescriptor_413a91106d7bcce8, []int{38}
 }
 func (m *GroupCounts) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2638,17 +2972,419 @@ func (m *GroupCounts) GetGroups() []*GroupCount {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Row)(nil), "pb.Row")
-	proto.RegisterType((*RowMatrix)(nil), "pb.RowMatrix")
-	proto.RegisterType((*SignedRow)(nil), "pb.SignedRow")
-	proto.RegisterType((*RowIdentifiers)(nil), "pb.RowIdentifiers")
-	proto.RegisterType((*IDList)(nil), "pb.IDList")
-	proto.RegisterType((*ExtractedIDColumn)(nil), "pb.ExtractedIDColumn")
-	proto.RegisterType((*ExtractedIDMatrix)(nil), "pb.ExtractedIDMatrix")
-	proto.RegisterType((*KeyList)(nil), "pb.KeyList")
-	proto.RegisterType((*ExtractedTableValue)(nil), "pb.ExtractedTableValue")
-	proto.RegisterType((*ExtractedTableColumn)(nil), "pb.ExtractedTableColumn")
+type SortValue struct {
+	// Types that are valid to be assigned to Value:
+	//	*SortValue_String_
+	//	*SortValue_Bool
+	//	*SortValue_Int64
+	//	*SortValue_Uint64
+	//	*SortValue_Values
+	Value                isSortValue_Value `protobuf_oneof:"Value"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SortValue) Reset()         { *m = SortValue{} }
+func (m *SortValue) String() string { return proto.CompactTextString(m) }
+func (*SortValue) ProtoMessage()    {}
+func (*SortValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_413a91106d7bcce8, []int{39}
+}
+func (m *SortValue) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SortValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SortValue.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SortValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SortValue.Merge(m, src)
+}
+func (m *SortValue) XXX_Size() int {
+	return m.Size()
+}
+func (m *SortValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_SortValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SortValue proto.InternalMessageInfo
+
+type isSortValue_Value interface {
+	isSortValue_Value()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type SortValue_String_ struct {
+	String_ string `protobuf:"bytes,1,opt,name=String,proto3,oneof" json:"String,omitempty"`
+}
+type SortValue_Bool struct {
+	Bool bool `protobuf:"varint,2,opt,name=Bool,proto3,oneof" json:"Bool,omitempty"`
+}
+type SortValue_Int64 struct {
+	Int64 int64 `protobuf:"varint,3,opt,name=Int64,proto3,oneof" json:"Int64,omitempty"`
+}
+type SortValue_Uint64 struct {
+	Uint64 uint64 `protobuf:"varint,4,opt,name=Uint64,proto3,oneof" json:"Uint64,omitempty"`
+}
+type SortValue_Values struct {
+	Values *SortValues `protobuf:"bytes,5,opt,name=Values,proto3,oneof" json:"Values,omitempty"`
+}
+
+func (*SortValue_String_) isSortValue_Value() {}
+func (*SortValue_Bool) isSortValue_Value()    {}
+func (*SortValue_Int64) isSortValue_Value()   {}
+func (*SortValue_Uint64) isSortValue_Value()  {}
+func (*SortValue_Values) isSortValue_Value()  {}
+
+func (m *SortValue) GetValue() isSortValue_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *SortValue) GetString_() string {
+	if x, ok := m.GetValue().(*SortValue_String_); ok {
+		return x.String_
+	}
+	return ""
+}
+
+func (m *SortValue) GetBool() bool {
+	if x, ok := m.GetValue().(*SortValue_Bool); ok {
+		return x.Bool
+	}
+	return false
+}
+
+func (m *SortValue) GetInt64() int64 {
+	if x, ok := m.GetValue().(*SortValue_Int64); ok {
+		return x.Int64
+	}
+	return 0
+}
+
+func (m *SortValue) GetUint64() uint64 {
+	if x, ok := m.GetValue().(*SortValue_Uint64); ok {
+		return x.Uint64
+	}
+	return 0
+}
+
+func (m *SortValue) GetValues() *SortValues {
+	if x, ok := m.GetValue().(*SortValue_Values); ok {
+		return x.Values
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SortValue) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SortValue_String_)(nil),
+		(*SortValue_Bool)(nil),
+		(*SortValue_Int64)(nil),
+		(*SortValue_Uint64)(nil),
+		(*SortValue_Values)(nil),
+	}
+}
+
+type SortValues struct {
+	Values               []*SortValue `protobuf:"bytes,1,rep,name=Values,proto3" json:"Values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SortValues) Reset()         { *m = SortValues{} }
+func (m *SortValues) String() string { return proto.CompactTextString(m) }
+func (*SortValues) ProtoMessage()    {}
+func (*SortValues) Descriptor() ([]byte, []int) {
+	return fileDescriptor_413a91106d7bcce8, []int{40}
+}
+func (m *SortValues) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SortValues) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SortValues.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SortValues) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SortValues.Merge(m, src)
+}
+func (m *SortValues) XXX_Size() int {
+	return m.Size()
+}
+func (m *SortValues) XXX_DiscardUnknown() {
+	xxx_messageInfo_SortValues.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SortValues proto.InternalMessageInfo
+
+func (m *SortValues) GetValues() []*SortValue {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type SortedColumn struct {
+	ID                   uint64     `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Value                *SortValue `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SortedColumn) Reset()         { *m = SortedColumn{} }
+func (m *SortedColumn) String() string { return proto.CompactTextString(m) }
+func (*SortedColumn) ProtoMessage()    {}
+func (*SortedColumn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_413a91106d7bcce8, []int{41}
+}
+func (m *SortedColumn) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SortedColumn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SortedColumn.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SortedColumn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SortedColumn.Merge(m, src)
+}
+func (m *SortedColumn) XXX_Size() int {
+	return m.Size()
+}
+func (m *SortedColumn) XXX_DiscardUnknown() {
+	xxx_messageInfo_SortedColumn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SortedColumn proto.InternalMessageInfo
+
+func (m *SortedColumn) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *SortedColumn) GetValue() *SortValue {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type SortedRow struct {
+	Row                  *Row            `protobuf:"bytes,1,opt,name=Row,proto3" json:"Row,omitempty"`
+	Columns              []*SortedColumn `protobuf:"bytes,2,rep,name=Columns,proto3" json:"Columns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SortedRow) Reset()         { *m = SortedRow{} }
+func (m *SortedRow) String() string { return proto.CompactTextString(m) }
+func (*SortedRow) ProtoMessage()    {}
+func (*SortedRow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_413a91106d7bcce8, []int{42}
+}
+func (m *SortedRow) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SortedRow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SortedRow.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SortedRow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SortedRow.Merge(m, src)
+}
+func (m *SortedRow) XXX_Size() int {
+	return m.Size()
+}
+func (m *SortedRow) XXX_DiscardUnknown() {
+	xxx_messageInfo_SortedRow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SortedRow proto.InternalMessageInfo
+
+func (m *SortedRow) GetRow() *Row {
+	if m != nil {
+		return m.Row
+	}
+	return nil
+}
+
+func (m *SortedRow) GetColumns() []*SortedColumn {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+type ExtractedIDMatrixSorted struct {
+	ExtractedIDMatrix    *ExtractedIDMatrix `protobuf:"bytes,1,opt,name=ExtractedIDMatrix,proto3" json:"ExtractedIDMatrix,omitempty"`
+	Columns              []*SortedColumn    `protobuf:"bytes,2,rep,name=Columns,proto3" json:"Columns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ExtractedIDMatrixSorted) Reset()         { *m = ExtractedIDMatrixSorted{} }
+func (m *ExtractedIDMatrixSorted) String() string { return proto.CompactTextString(m) }
+func (*ExtractedIDMatrixSorted) ProtoMessage()    {}
+func (*ExtractedIDMatrixSorted) Descriptor() ([]byte, []int) {
+	return fileDescriptor_413a91106d7bcce8, []int{43}
+}
+func (m *ExtractedIDMatrixSorted) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExtractedIDMatrixSorted) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExtractedIDMatrixSorted.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExtractedIDMatrixSorted) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtractedIDMatrixSorted.Merge(m, src)
+}
+func (m *ExtractedIDMatrixSorted) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExtractedIDMatrixSorted) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtractedIDMatrixSorted.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtractedIDMatrixSorted proto.InternalMessageInfo
+
+func (m *ExtractedIDMatrixSorted) GetExtractedIDMatrix() *ExtractedIDMatrix {
+	if m != nil {
+		return m.ExtractedIDMatrix
+	}
+	return nil
+}
+
+func (m *ExtractedIDMatrixSorted) GetColumns() []*SortedColumn {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+type Histogram struct {
+	Field                string   `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
+	Edges                []int64  `protobuf:"varint,2,rep,packed,name=Edges,proto3" json:"Edges,omitempty"`
+	Counts               []uint64 `protobuf:"varint,3,rep,packed,name=Counts,proto3" json:"Counts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Histogram) Reset()         { *m = Histogram{} }
+func (m *Histogram) String() string { return proto.CompactTextString(m) }
+func (*Histogram) ProtoMessage()    {}
+func (*Histogram) Descriptor() ([]byte, []int) {
+	return fileDescriptor_413a91106d7bcce8, []int{44}
+}
+func (m *Histogram) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Histogram) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Histogram.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Histogram) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Histogram.Merge(m, src)
+}
+func (m *Histogram) XXX_Size() int {
+	return m.Size()
+}
+func (m *Histogram) XXX_DiscardUnknown() {
+	xxx_messageInfo_Histogram.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Histogram proto.InternalMessageInfo
+
+func (m *Histogram) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *Histogram) GetEdges() []int64 {
+	if m != nil {
+		return m.Edges
+	}
+	return nil
+}
+
+func (m *Histogram) GetCounts() []uint64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Row)(nil), "pb.Row")
+	proto.RegisterType((*RowMatrix)(nil), "pb.RowMatrix")
+	proto.RegisterType((*SignedRow)(nil), "pb.SignedRow")
+	proto.RegisterType((*RowIdentifiers)(nil), "pb.RowIdentifiers")
+	proto.RegisterType((*IDList)(nil), "pb.IDList")
+	proto.RegisterType((*ExtractedIDColumn)(nil), "pb.ExtractedIDColumn")
+	proto.RegisterType((*ExtractedIDMatrix)(nil), "pb.ExtractedIDMatrix")
+	proto.RegisterType((*KeyList)(nil), "pb.KeyList")
+	proto.RegisterType((*ExtractedTableValue)(nil), "pb.ExtractedTableValue")
+	proto.RegisterType((*ExtractedTableColumn)(nil), "pb.ExtractedTableColumn")
 	proto.RegisterType((*ExtractedTableField)(nil), "pb.ExtractedTableField")
 	proto.RegisterType((*ExtractedTable)(nil), "pb.ExtractedTable")
 	proto.RegisterType((*Pair)(nil), "pb.Pair")
@@ -2662,6 +3398,8 @@ func init() {
 	proto.RegisterType((*DistinctTimestamp)(nil), "pb.DistinctTimestamp")
 	proto.RegisterType((*QueryRequest)(nil), "pb.QueryRequest")
 	proto.RegisterType((*QueryResponse)(nil), "pb.QueryResponse")
+	proto.RegisterType((*Completeness)(nil), "pb.Completeness")
+	proto.RegisterType((*IndexShards)(nil), "pb.IndexShards")
 	proto.RegisterType((*QueryResult)(nil), "pb.QueryResult")
 	proto.RegisterType((*ImportRequest)(nil), "pb.ImportRequest")
 	proto.RegisterType((*ImportValueRequest)(nil), "pb.ImportValueRequest")
@@ -2676,120 +3414,161 @@ func init() {
 	proto.RegisterType((*RoaringUpdate)(nil), "pb.RoaringUpdate")
 	proto.RegisterType((*ImportRoaringShardRequest)(nil), "pb.ImportRoaringShardRequest")
 	proto.RegisterType((*GroupCounts)(nil), "pb.GroupCounts")
+	proto.RegisterType((*SortValue)(nil), "pb.SortValue")
+	proto.RegisterType((*SortValues)(nil), "pb.SortValues")
+	proto.RegisterType((*SortedColumn)(nil), "pb.SortedColumn")
+	proto.RegisterType((*SortedRow)(nil), "pb.SortedRow")
+	proto.RegisterType((*ExtractedIDMatrixSorted)(nil), "pb.ExtractedIDMatrixSorted")
+	proto.RegisterType((*Histogram)(nil), "pb.Histogram")
 }
 
 func init() { proto.RegisterFile("public.proto", fileDescriptor_413a91106d7bcce8) }
 
 var fileDescriptor_413a91106d7bcce8 = []byte{
-	// 1726 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcd, 0x8e, 0x2b, 0x47,
-	0x15, 0x9e, 0xfe, 0xf1, 0xdf, 0xb1, 0xc7, 0x77, 0xa6, 0x32, 0x09, 0x9d, 0xcb, 0xc4, 0x38, 0x2d,
-	0x14, 0x1c, 0x06, 0xdd, 0x08, 0x83, 0x22, 0x14, 0x09, 0xa2, 0xf1, 0x78, 0xc2, 0xb5, 0x6e, 0xee,
-	0xe4, 0x52, 0x1e, 0x0c, 0x8b, 0x6c, 0x7a, 0xec, 0xc2, 0x69, 0xd1, 0x76, 0x9b, 0xee, 0x76, 0x3c,
-	0xf3, 0x00, 0x08, 0x1e, 0x81, 0x1d, 0x4f, 0x83, 0x60, 0x07, 0x4b, 0x96, 0xe8, 0xb2, 0xe3, 0x29,
-	0xd0, 0x39, 0x55, 0xd5, 0x5d, 0xdd, 0xf6, 0x44, 0x51, 0x94, 0x5d, 0x9f, 0x9f, 0x3a, 0x75, 0xce,
-	0x57, 0xe7, 0xcf, 0x86, 0xce, 0x66, 0x7b, 0x17, 0x85, 0xf3, 0x67, 0x9b, 0x24, 0xce, 0x62, 0x66,
-	0x6f, 0xee, 0xfc, 0x07, 0x70, 0x78, 0xbc, 0x63, 0x1e, 0x34, 0xae, 0xe2, 0x68, 0xbb, 0x5a, 0xa7,
-	0x9e, 0xd5, 0x77, 0x06, 0x2e, 0xd7, 0x24, 0x63, 0xe0, 0xbe, 0x10, 0x0f, 0xa9, 0xe7, 0xf4, 0x9d,
-	0x41, 0x8b, 0xd3, 0x37, 0x6a, 0xf3, 0x38, 0x48, 0xc2, 0xf5, 0xd2, 0x73, 0xfb, 0xd6, 0xa0, 0xc3,
-	0x35, 0xc9, 0xce, 0xa0, 0x36, 0x59, 0x2f, 0xc4, 0xbd, 0x57, 0xeb, 0x5b, 0x83, 0x16, 0x97, 0x04,
-	0x72, 0x3f, 0x09, 0x45, 0xb4, 0xf0, 0xea, 0x92, 0x4b, 0x84, 0x3f, 0x80, 0x16, 0x8f, 0x77, 0x2f,
-	0x83, 0x2c, 0x09, 0xef, 0xd9, 0x77, 0xc1, 0xe5, 0xf1, 0x4e, 0xde, 0xde, 0x1e, 0x36, 0x9e, 0x6d,
-	0xee, 0x9e, 0xf1, 0x78, 0xc7, 0x89, 0xe9, 0x5f, 0x42, 0x6b, 0x1a, 0x2e, 0xd7, 0x62, 0x81, 0xae,
-	0xbe, 0x0d, 0xce, 0xab, 0x18, 0x15, 0x2d, 0x53, 0x11, 0x79, 0x28, 0xba, 0x11, 0x4b, 0xcf, 0xae,
-	0x88, 0x6e, 0xc4, 0xd2, 0xff, 0x19, 0x74, 0x79, 0xbc, 0x9b, 0x2c, 0xc4, 0x3a, 0x0b, 0x7f, 0x17,
-	0x8a, 0x84, 0x02, 0xcb, 0x6f, 0x74, 0xe5, 0x45, 0x79, 0xb0, 0x76, 0x11, 0xac, 0xff, 0x14, 0xea,
-	0x93, 0xf1, 0xa7, 0x61, 0x9a, 0xb1, 0x13, 0x70, 0x26, 0x63, 0x7d, 0x00, 0x3f, 0xfd, 0x2b, 0x38,
-	0xbd, 0xbe, 0xcf, 0x92, 0x60, 0x9e, 0x89, 0xc5, 0x64, 0x2c, 0x21, 0x63, 0x5d, 0xb0, 0x27, 0x63,
-	0xf2, 0xcf, 0xe5, 0xf6, 0x64, 0xcc, 0x7a, 0xe0, 0xce, 0x82, 0x48, 0x1a, 0x6d, 0x0f, 0x01, 0xdd,
-	0x92, 0x06, 0x39, 0xf1, 0xfd, 0xcf, 0x4b, 0x46, 0x14, 0x1e, 0x6f, 0x41, 0x9d, 0x50, 0x92, 0xd7,
-	0xb5, 0xb8, 0xa2, 0xd8, 0x07, 0xc5, 0x43, 0x49, 0x7b, 0x6f, 0xa2, 0xbd, 0x3d, 0x27, 0xf2, 0xf7,
-	0xf3, 0xdf, 0x81, 0xc6, 0x0b, 0xf1, 0x40, 0xfe, 0xeb, 0xe8, 0x2c, 0x23, 0xba, 0x7f, 0x5a, 0xf0,
-	0x46, 0x7e, 0xfa, 0x36, 0xb8, 0x8b, 0xc4, 0x2c, 0x88, 0xb6, 0x82, 0xf5, 0x74, 0xac, 0x56, 0xd9,
-	0xe7, 0xe7, 0x47, 0x14, 0x39, 0x7b, 0x37, 0x47, 0x0a, 0x15, 0xda, 0xa8, 0xa0, 0xae, 0x79, 0x7e,
-	0xa4, 0xb2, 0xe4, 0x1c, 0x9a, 0xa3, 0xe9, 0x84, 0xcc, 0x79, 0x4e, 0xdf, 0x1a, 0x38, 0xcf, 0x8f,
-	0x78, 0xce, 0x61, 0x4f, 0xa1, 0xf1, 0x72, 0x9b, 0x89, 0xfb, 0xc9, 0x98, 0x72, 0xc8, 0x7d, 0x7e,
-	0xc4, 0x35, 0x03, 0x4f, 0xd2, 0xe7, 0x0b, 0xf1, 0x20, 0x13, 0x09, 0x4f, 0x6a, 0x0e, 0x3b, 0x03,
-	0x77, 0x14, 0xc7, 0x11, 0x25, 0x53, 0x13, 0x6f, 0x43, 0x6a, 0xd4, 0x80, 0x1a, 0x19, 0xf6, 0xef,
-	0xe1, 0xac, 0x1c, 0x90, 0x7a, 0x16, 0x06, 0x0e, 0xda, 0xb3, 0x94, 0x3d, 0x24, 0xd8, 0x09, 0x3d,
-	0x95, 0xad, 0xee, 0xc7, 0xc7, 0xfa, 0x00, 0xea, 0x64, 0x46, 0x26, 0x7c, 0x7b, 0xf8, 0x9d, 0x12,
-	0xbc, 0x05, 0x40, 0x5c, 0xa9, 0x8d, 0x5a, 0x84, 0xef, 0x67, 0xc9, 0x64, 0xec, 0xff, 0xbc, 0x0a,
-	0x25, 0xbd, 0x19, 0xc2, 0x7e, 0x13, 0xac, 0x84, 0xbc, 0x99, 0xd3, 0x37, 0xf2, 0x6e, 0x1f, 0x36,
-	0x82, 0xae, 0x6e, 0x71, 0xfa, 0xf6, 0xb7, 0xd0, 0x2d, 0x1f, 0x47, 0x67, 0x8c, 0x24, 0x38, 0xe8,
-	0x0c, 0xc9, 0xf3, 0xec, 0x18, 0x56, 0xb3, 0xc3, 0xdb, 0x3f, 0x51, 0x4d, 0x90, 0x5f, 0x80, 0xfb,
-	0x2a, 0x08, 0x93, 0xbd, 0xb4, 0x3d, 0x91, 0x78, 0x39, 0xe4, 0xa1, 0x23, 0x81, 0xaf, 0x5d, 0xc5,
-	0xdb, 0x75, 0x26, 0x01, 0xe3, 0x92, 0xf0, 0x3f, 0x86, 0x16, 0x9e, 0x97, 0xb1, 0x9e, 0x4b, 0x63,
-	0x2a, 0x6f, 0x9a, 0x78, 0x3b, 0xd2, 0x5c, 0x5e, 0x91, 0xf7, 0x01, 0xdb, 0xec, 0x03, 0x23, 0x00,
-	0x94, 0xa6, 0xd2, 0x42, 0x0f, 0x6a, 0x44, 0xa9, 0x90, 0x0b, 0x13, 0x92, 0xfd, 0x88, 0x8d, 0x77,
-	0xb0, 0xef, 0x64, 0x1f, 0xfe, 0x14, 0xc5, 0x32, 0xe3, 0xd0, 0x03, 0x87, 0xab, 0x9c, 0x88, 0xa1,
-	0x29, 0x81, 0x8a, 0x77, 0x85, 0x01, 0xcb, 0x30, 0x80, 0x5c, 0xec, 0x0f, 0x63, 0x1d, 0x1b, 0x11,
-	0x58, 0x85, 0x3c, 0xde, 0x15, 0x30, 0x28, 0x8a, 0x7d, 0x4f, 0xdf, 0xe2, 0x52, 0x9c, 0x2d, 0xaa,
-	0x0f, 0xbc, 0x5f, 0x5f, 0xf8, 0x5b, 0x80, 0x5f, 0x26, 0xf1, 0x76, 0x43, 0x10, 0x31, 0x1f, 0x6a,
-	0x44, 0xa9, 0x98, 0x3a, 0xa8, 0xae, 0xfd, 0xe1, 0x52, 0x74, 0x18, 0x5c, 0x7c, 0x84, 0xcb, 0xe5,
-	0x52, 0x96, 0x0f, 0xc7, 0x4f, 0xff, 0xaf, 0x16, 0x34, 0x67, 0x41, 0x94, 0x8b, 0x67, 0x41, 0xa4,
-	0x62, 0xc5, 0xcf, 0xb2, 0x19, 0x47, 0x9b, 0x79, 0x0a, 0xcd, 0x4f, 0xa2, 0x38, 0xc8, 0x50, 0x19,
-	0x6d, 0x59, 0x3c, 0xa7, 0xd9, 0x05, 0xc0, 0x58, 0xcc, 0xc3, 0x55, 0x10, 0xa1, 0xd4, 0x2d, 0xea,
-	0x59, 0x71, 0xb9, 0x21, 0x66, 0x3e, 0x74, 0x6e, 0xc3, 0x95, 0x48, 0xb3, 0x60, 0xb5, 0x41, 0x75,
-	0xd9, 0xe6, 0x4b, 0x3c, 0xff, 0x8f, 0x16, 0x34, 0xd4, 0x91, 0xc3, 0xcf, 0x81, 0xdc, 0xe9, 0x3c,
-	0x88, 0x84, 0x76, 0x92, 0x08, 0xd6, 0x03, 0xb8, 0x11, 0xbb, 0x99, 0x48, 0xd2, 0x30, 0x5e, 0x93,
-	0x9b, 0x4d, 0x6e, 0x70, 0xf0, 0x31, 0x66, 0x41, 0x74, 0x79, 0x97, 0xaa, 0xa1, 0xa3, 0x28, 0xc5,
-	0xc7, 0xc6, 0x5f, 0xa3, 0x33, 0x8a, 0xf2, 0x3f, 0x86, 0xd3, 0x71, 0x98, 0x66, 0xe1, 0x7a, 0x9e,
-	0xe5, 0xfe, 0x29, 0x65, 0xac, 0x6f, 0xd5, 0x57, 0x25, 0x95, 0x17, 0xa9, 0x5d, 0x14, 0xa9, 0xff,
-	0x37, 0x0b, 0x3a, 0xbf, 0xda, 0x8a, 0xe4, 0x81, 0x8b, 0x3f, 0x6c, 0x45, 0x9a, 0xa1, 0xdf, 0x44,
-	0xeb, 0xd4, 0x21, 0x02, 0x4d, 0x4e, 0xbf, 0x08, 0x92, 0x85, 0xac, 0x39, 0x97, 0x2b, 0x8a, 0x92,
-	0x47, 0xac, 0xe2, 0x4c, 0x68, 0xbf, 0x24, 0xc5, 0x2e, 0xa0, 0x73, 0xbd, 0xba, 0x13, 0x8b, 0x85,
-	0x58, 0x8c, 0x83, 0x2c, 0xf0, 0x9a, 0xe5, 0x91, 0x57, 0x12, 0xb2, 0xef, 0xc3, 0xf1, 0xab, 0x44,
-	0xdc, 0x26, 0xc1, 0x3a, 0x8d, 0x82, 0x4c, 0x2c, 0xbc, 0x16, 0xd9, 0x2a, 0x33, 0xd9, 0x39, 0xb4,
-	0x5e, 0x06, 0xf7, 0x2f, 0xc5, 0x2a, 0x4e, 0x1e, 0x3c, 0x20, 0x50, 0x0b, 0x86, 0xff, 0x29, 0x1c,
-	0xab, 0x30, 0xd2, 0x4d, 0xbc, 0x4e, 0x05, 0xa6, 0xcd, 0x75, 0x92, 0xa8, 0x28, 0xf0, 0x93, 0xbd,
-	0x0f, 0x0d, 0x2e, 0xd2, 0x6d, 0x94, 0xe9, 0xc6, 0xf1, 0x04, 0xdd, 0xd1, 0xa7, 0xb6, 0x51, 0xc6,
-	0xb5, 0xdc, 0xff, 0x5f, 0x0d, 0xda, 0x86, 0x20, 0x6f, 0x65, 0xd8, 0x8e, 0x8f, 0x65, 0x2b, 0xc3,
-	0x41, 0xcc, 0xe3, 0xdd, 0xde, 0x8c, 0xc6, 0xf2, 0xeb, 0x80, 0x75, 0xa3, 0x72, 0xdc, 0xba, 0x29,
-	0xaa, 0xdd, 0x39, 0x5c, 0xed, 0xb8, 0x97, 0x7c, 0x11, 0xac, 0x97, 0x62, 0x41, 0x8f, 0xde, 0xe4,
-	0x9a, 0x64, 0x83, 0xa2, 0x0c, 0x08, 0x5f, 0x55, 0x56, 0x9a, 0xc7, 0x8b, 0x22, 0x91, 0x45, 0x8c,
-	0xd3, 0xac, 0x21, 0xdf, 0x47, 0x52, 0xec, 0x43, 0xe8, 0x7e, 0x16, 0x2d, 0x8a, 0x32, 0x4d, 0xd5,
-	0x4b, 0x74, 0xd1, 0x4e, 0xc1, 0xe6, 0x15, 0x2d, 0xf6, 0x51, 0x75, 0x95, 0xa0, 0x37, 0x69, 0x0f,
-	0x99, 0x8a, 0xd3, 0x90, 0xf0, 0xea, 0xd2, 0x71, 0x61, 0x6c, 0x32, 0xf4, 0x50, 0xed, 0xe1, 0x31,
-	0x1e, 0xcb, 0x99, 0xdc, 0xd8, 0x74, 0x9e, 0x99, 0x8d, 0xd1, 0x6b, 0x93, 0x76, 0x57, 0x23, 0x24,
-	0xb9, 0xdc, 0x6c, 0x9d, 0x17, 0x46, 0x27, 0xf6, 0x3a, 0x85, 0xf1, 0x9c, 0xc9, 0x8d, 0x4e, 0x7d,
-	0x75, 0x60, 0xeb, 0xf0, 0x8e, 0xe9, 0x50, 0x75, 0xa5, 0x90, 0x42, 0x7e, 0x60, 0x4b, 0xf9, 0xa8,
-	0x3a, 0xb2, 0xbc, 0x6e, 0x01, 0x45, 0x59, 0xc2, 0xab, 0xc3, 0xed, 0xc2, 0x58, 0xff, 0xbc, 0x27,
-	0x85, 0xb7, 0x39, 0x93, 0x1b, 0xeb, 0xe1, 0x8f, 0xa1, 0x6d, 0x3e, 0xd4, 0x09, 0xa9, 0x3f, 0x29,
-	0x3f, 0x54, 0xca, 0x4d, 0x1d, 0x0c, 0x70, 0xaf, 0xfc, 0xbd, 0xd3, 0x22, 0xc0, 0x3d, 0x21, 0xdf,
-	0xd7, 0xf7, 0xff, 0x6e, 0xc3, 0xf1, 0x64, 0xb5, 0x89, 0x93, 0xcc, 0xe8, 0x01, 0x72, 0xc3, 0xb5,
-	0x0e, 0x6e, 0xb8, 0x76, 0x65, 0xa8, 0x50, 0x2f, 0xa0, 0x66, 0xe6, 0x72, 0x49, 0x18, 0xf9, 0xe8,
-	0x96, 0xf2, 0xf1, 0x1c, 0x5a, 0x72, 0x26, 0xa3, 0xa8, 0x46, 0xa2, 0x82, 0x21, 0x77, 0xee, 0x1d,
-	0xed, 0x5c, 0x0d, 0xea, 0x5c, 0x9a, 0xc4, 0xbe, 0x29, 0xd5, 0x48, 0xd8, 0x24, 0xa1, 0xc1, 0x41,
-	0x79, 0x1e, 0x50, 0xea, 0xd5, 0xfb, 0xce, 0xc0, 0xe1, 0x06, 0x87, 0xbd, 0x07, 0x5d, 0x0a, 0xe2,
-	0x2a, 0x11, 0xd8, 0x4c, 0x2e, 0x33, 0xca, 0x67, 0x87, 0x57, 0xb8, 0xa8, 0x47, 0x61, 0x15, 0x7a,
-	0xb2, 0xd3, 0x54, 0xb8, 0x34, 0x82, 0x22, 0x11, 0x24, 0x94, 0xb1, 0x4d, 0x2e, 0x09, 0xff, 0xdf,
-	0x36, 0x30, 0x89, 0xa4, 0xdc, 0x9f, 0xbe, 0x35, 0x38, 0xbf, 0x1a, 0xb6, 0x32, 0x38, 0x8d, 0x3d,
-	0x70, 0x8a, 0x79, 0x20, 0x81, 0xd1, 0xf3, 0xa0, 0x0f, 0x6d, 0x3d, 0x21, 0x51, 0x88, 0xa8, 0x5a,
-	0xdc, 0x64, 0xe1, 0x28, 0x9c, 0x66, 0xf8, 0xa3, 0x47, 0xa9, 0xb4, 0xc8, 0x76, 0x89, 0x77, 0x00,
-	0x5a, 0xf8, 0x9a, 0xd0, 0xb6, 0xbf, 0x1a, 0xda, 0x8e, 0x09, 0xed, 0x9f, 0x2c, 0xe8, 0x5c, 0x66,
-	0xf1, 0x2a, 0x9c, 0x73, 0x31, 0x8f, 0x93, 0xc5, 0xe3, 0xa0, 0x4a, 0xf8, 0x6c, 0x13, 0xbe, 0x01,
-	0x38, 0x93, 0x2f, 0x13, 0xd5, 0x7f, 0xdf, 0xa2, 0x45, 0x66, 0xef, 0x95, 0x38, 0xaa, 0xb0, 0x77,
-	0xc1, 0x9e, 0x24, 0x94, 0xb3, 0xed, 0xe1, 0x69, 0xa1, 0xa8, 0x75, 0xec, 0x49, 0xe2, 0xff, 0x08,
-	0xce, 0xa4, 0x23, 0x5a, 0xa4, 0x06, 0xce, 0x19, 0xd4, 0xae, 0x93, 0x24, 0xd6, 0x23, 0x47, 0x12,
-	0xb8, 0xa9, 0xe7, 0x33, 0x0c, 0x1f, 0xe3, 0x9b, 0xe4, 0xc4, 0xa1, 0x9f, 0xa7, 0x7d, 0x68, 0xdf,
-	0xc4, 0xd9, 0x6f, 0x92, 0x30, 0xa3, 0x96, 0x24, 0x07, 0x87, 0xc9, 0xf2, 0xdf, 0x87, 0x37, 0x2b,
-	0x37, 0x17, 0x93, 0x11, 0xd3, 0xc8, 0x29, 0x7e, 0xe2, 0x4d, 0xe1, 0x8d, 0x5c, 0x75, 0x32, 0xfe,
-	0x46, 0x3e, 0xee, 0x1b, 0xfd, 0xa1, 0x11, 0x39, 0x19, 0x55, 0xd7, 0x1f, 0x88, 0xc6, 0x1f, 0x81,
-	0xa7, 0xd0, 0x94, 0xbf, 0xb1, 0x95, 0x07, 0xb3, 0x50, 0xec, 0x1e, 0xfb, 0x69, 0x41, 0x6b, 0x85,
-	0x4d, 0x4b, 0x12, 0x7d, 0xfb, 0x7f, 0xb6, 0xe1, 0xec, 0x90, 0x91, 0x22, 0xa1, 0x2c, 0x23, 0xa1,
-	0xd8, 0x10, 0x6a, 0x5f, 0x86, 0x62, 0xa7, 0x77, 0x81, 0x73, 0xe3, 0xb1, 0xf7, 0x7c, 0xe0, 0x52,
-	0x15, 0x0b, 0xe9, 0x72, 0x9e, 0xe9, 0xcd, 0xad, 0xc5, 0x15, 0x85, 0x37, 0x8c, 0xa2, 0x78, 0xfe,
-	0x7b, 0xf9, 0x2b, 0x8f, 0x4b, 0xe2, 0x40, 0x61, 0xd4, 0xbe, 0x66, 0x61, 0xd4, 0x0f, 0x16, 0xc6,
-	0x00, 0x9e, 0xfc, 0x7a, 0xb3, 0x08, 0x32, 0x71, 0x7d, 0x1f, 0xa6, 0x99, 0x58, 0xcf, 0x85, 0xd7,
-	0xa0, 0x88, 0xaa, 0x6c, 0xdc, 0x4e, 0x8f, 0x55, 0x14, 0x52, 0xf4, 0xc8, 0x0f, 0x02, 0x06, 0x2e,
-	0x86, 0xa7, 0x17, 0x42, 0x82, 0x3b, 0x47, 0xcb, 0x21, 0x6c, 0x15, 0x5a, 0x27, 0xe0, 0x4c, 0x45,
-	0xa6, 0x96, 0x52, 0xfc, 0xc4, 0xd6, 0x40, 0x22, 0x59, 0x8e, 0xa9, 0xda, 0xff, 0x4a, 0x3c, 0xff,
-	0x73, 0x78, 0xbb, 0x04, 0x29, 0x55, 0xa3, 0x7e, 0x96, 0x62, 0x75, 0xb4, 0x4a, 0xab, 0xe3, 0x0f,
-	0xa0, 0x36, 0x33, 0x1e, 0xe6, 0x54, 0xce, 0x4b, 0x23, 0x18, 0x2e, 0xe5, 0xfe, 0xb4, 0x34, 0x2f,
-	0xb1, 0x47, 0x5e, 0x2e, 0x97, 0x89, 0x58, 0x06, 0x99, 0x4e, 0x96, 0x82, 0xc1, 0xde, 0x83, 0x3a,
-	0x29, 0x6b, 0xb3, 0xd5, 0x05, 0x48, 0x49, 0x47, 0x27, 0xff, 0x78, 0xdd, 0xb3, 0xfe, 0xf5, 0xba,
-	0x67, 0xfd, 0xe7, 0x75, 0xcf, 0xfa, 0xcb, 0x7f, 0x7b, 0x47, 0x77, 0x75, 0xfa, 0x23, 0xe9, 0x27,
-	0xff, 0x0f, 0x00, 0x00, 0xff, 0xff, 0x42, 0x09, 0xf4, 0x7d, 0x58, 0x12, 0x00, 0x00,
+	// 2274 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x5f, 0x73, 0x23, 0x47,
+	0x11, 0xbf, 0xd5, 0xea, 0x6f, 0x4b, 0xf6, 0xd9, 0x13, 0xe7, 0xb2, 0xb9, 0x5c, 0x8c, 0xb3, 0x81,
+	0xa0, 0xe4, 0x52, 0x97, 0xc2, 0x09, 0x29, 0x0a, 0x0a, 0x52, 0xb6, 0xe4, 0xc3, 0xaa, 0xcb, 0xf9,
+	0xcc, 0xd8, 0xe7, 0xf0, 0x90, 0x97, 0xb5, 0x34, 0xe8, 0xb6, 0xb2, 0xd2, 0x8a, 0xdd, 0xd1, 0xc9,
+	0xfe, 0x00, 0x14, 0x14, 0x0f, 0x3c, 0x41, 0x15, 0x55, 0xbc, 0xf0, 0x05, 0xf8, 0x0e, 0x14, 0x2f,
+	0xe1, 0x0d, 0x3e, 0x02, 0x75, 0x7c, 0x04, 0xbe, 0x00, 0xd5, 0xdd, 0xb3, 0x3b, 0xbb, 0x92, 0x7c,
+	0x24, 0x29, 0xde, 0xf6, 0xd7, 0xdd, 0xd3, 0xd3, 0xff, 0x66, 0xa6, 0x67, 0x16, 0x3a, 0xb3, 0xf9,
+	0x65, 0x14, 0x0e, 0x1f, 0xcc, 0x92, 0x58, 0xc7, 0xa2, 0x32, 0xbb, 0xf4, 0xaf, 0xc1, 0x95, 0xf1,
+	0x42, 0x78, 0xd0, 0xe8, 0xc5, 0xd1, 0x7c, 0x32, 0x4d, 0x3d, 0x67, 0xcf, 0xed, 0x56, 0x65, 0x06,
+	0x85, 0x80, 0xea, 0x23, 0x75, 0x9d, 0x7a, 0xee, 0x9e, 0xdb, 0x6d, 0x49, 0xfa, 0x46, 0x69, 0x19,
+	0x07, 0x49, 0x38, 0x1d, 0x7b, 0xd5, 0x3d, 0xa7, 0xdb, 0x91, 0x19, 0x14, 0x3b, 0x50, 0x1b, 0x4c,
+	0x47, 0xea, 0xca, 0xab, 0xed, 0x39, 0xdd, 0x96, 0x64, 0x80, 0xd4, 0x87, 0xa1, 0x8a, 0x46, 0x5e,
+	0x9d, 0xa9, 0x04, 0xfc, 0x2e, 0xb4, 0x64, 0xbc, 0x78, 0x1c, 0xe8, 0x24, 0xbc, 0x12, 0x6f, 0x40,
+	0x55, 0xc6, 0x0b, 0x9e, 0xbd, 0xbd, 0xdf, 0x78, 0x30, 0xbb, 0x7c, 0x20, 0xe3, 0x85, 0x24, 0xa2,
+	0x7f, 0x00, 0xad, 0xb3, 0x70, 0x3c, 0x55, 0x23, 0x34, 0xf5, 0x75, 0x70, 0x4f, 0x63, 0x14, 0x74,
+	0x8a, 0x82, 0x48, 0x43, 0xd6, 0x89, 0x1a, 0x7b, 0x95, 0x25, 0xd6, 0x89, 0x1a, 0xfb, 0x3f, 0x80,
+	0x4d, 0x19, 0x2f, 0x06, 0x23, 0x35, 0xd5, 0xe1, 0x2f, 0x42, 0x95, 0x90, 0x63, 0xf9, 0x8c, 0x55,
+	0x9e, 0x28, 0x77, 0xb6, 0x62, 0x9d, 0xf5, 0xef, 0x42, 0x7d, 0xd0, 0xff, 0x34, 0x4c, 0xb5, 0xd8,
+	0x02, 0x77, 0xd0, 0xcf, 0x06, 0xe0, 0xa7, 0xdf, 0x83, 0xed, 0xa3, 0x2b, 0x9d, 0x04, 0x43, 0xad,
+	0x46, 0x83, 0x3e, 0x87, 0x4c, 0x6c, 0x42, 0x65, 0xd0, 0x27, 0xfb, 0xaa, 0xb2, 0x32, 0xe8, 0x8b,
+	0x5d, 0xa8, 0x5e, 0x04, 0x11, 0x2b, 0x6d, 0xef, 0x03, 0x9a, 0xc5, 0x0a, 0x25, 0xd1, 0xfd, 0xcf,
+	0x4b, 0x4a, 0x4c, 0x3c, 0xee, 0x40, 0x9d, 0xa2, 0xc4, 0xd3, 0xb5, 0xa4, 0x41, 0xe2, 0x03, 0x9b,
+	0x28, 0xd6, 0xf7, 0x2a, 0xea, 0x5b, 0x31, 0x22, 0xcf, 0x9f, 0xff, 0x26, 0x34, 0x1e, 0xa9, 0x6b,
+	0xb2, 0x3f, 0xf3, 0xce, 0x29, 0x78, 0xf7, 0x0f, 0x07, 0x5e, 0xc9, 0x47, 0x9f, 0x07, 0x97, 0x91,
+	0xba, 0x08, 0xa2, 0xb9, 0x12, 0xbb, 0x99, 0xaf, 0x4e, 0xd9, 0xe6, 0xe3, 0x5b, 0xe4, 0xb9, 0x78,
+	0x2b, 0x8f, 0x14, 0x0a, 0xb4, 0x51, 0xc0, 0x4c, 0x73, 0x7c, 0xcb, 0x54, 0xc9, 0x3d, 0x68, 0x1e,
+	0x9e, 0x0d, 0x48, 0x9d, 0xe7, 0xee, 0x39, 0x5d, 0xf7, 0xf8, 0x96, 0xcc, 0x29, 0xe2, 0x2e, 0x34,
+	0x1e, 0xcf, 0xb5, 0xba, 0x1a, 0xf4, 0xa9, 0x86, 0xaa, 0xc7, 0xb7, 0x64, 0x46, 0xc0, 0x91, 0xf4,
+	0xf9, 0x48, 0x5d, 0x73, 0x21, 0xe1, 0xc8, 0x8c, 0x22, 0x76, 0xa0, 0x7a, 0x18, 0xc7, 0x11, 0x15,
+	0x53, 0x13, 0x67, 0x43, 0x74, 0xd8, 0x80, 0x1a, 0x29, 0xf6, 0x7f, 0xe7, 0xc0, 0x4e, 0xd9, 0x23,
+	0x93, 0x17, 0x01, 0x2e, 0x2a, 0x74, 0x8c, 0x42, 0x04, 0x62, 0x8b, 0x72, 0x55, 0x31, 0x06, 0x60,
+	0xb6, 0x3e, 0x80, 0x3a, 0xe9, 0xe1, 0x8a, 0x6f, 0xef, 0xbf, 0x56, 0x8a, 0xaf, 0x8d, 0x90, 0x34,
+	0x62, 0x58, 0xdc, 0x07, 0x5a, 0x27, 0xa9, 0x59, 0x0a, 0x0c, 0x0e, 0x5b, 0x14, 0xf6, 0x27, 0xc9,
+	0xa0, 0xef, 0xff, 0x78, 0x39, 0xc2, 0x94, 0x4a, 0xcc, 0xc6, 0x49, 0x30, 0x51, 0x6c, 0x8f, 0xa4,
+	0x6f, 0xa4, 0x9d, 0x5f, 0xcf, 0x14, 0x19, 0xd4, 0x92, 0xf4, 0xed, 0xcf, 0x61, 0xb3, 0x3c, 0x1c,
+	0x4d, 0x2c, 0xd4, 0xc6, 0x5a, 0x13, 0x89, 0x9f, 0x17, 0xcd, 0xfe, 0x72, 0xd1, 0x78, 0xab, 0x23,
+	0x96, 0xeb, 0xe6, 0x27, 0x50, 0x3d, 0x0d, 0xc2, 0x64, 0xa5, 0x9a, 0xb7, 0x38, 0x8a, 0x2e, 0x59,
+	0xe8, 0x72, 0x3e, 0x6a, 0xbd, 0x78, 0x3e, 0xd5, 0x1c, 0x46, 0xc9, 0xc0, 0xff, 0x04, 0x5a, 0x38,
+	0x9e, 0x7d, 0xbd, 0xc7, 0xca, 0x4c, 0x39, 0x35, 0x71, 0x76, 0xc4, 0x92, 0xa7, 0xc8, 0xb7, 0x87,
+	0x4a, 0x71, 0x7b, 0xf8, 0x39, 0x00, 0x72, 0x53, 0xd6, 0xb0, 0x0b, 0x35, 0x42, 0xc6, 0x65, 0xab,
+	0x82, 0xc9, 0xeb, 0x75, 0x20, 0xf5, 0x4c, 0x07, 0x11, 0xd7, 0x5f, 0x53, 0x32, 0xf0, 0xdf, 0xc4,
+	0x4d, 0x4a, 0x7f, 0xfc, 0x11, 0xb2, 0xb9, 0x3c, 0xd1, 0x2e, 0x57, 0x9a, 0x02, 0xfa, 0x9b, 0x03,
+	0x4d, 0x8e, 0x5f, 0xbc, 0xb0, 0x7a, 0x9d, 0x25, 0xbd, 0xb8, 0x9b, 0xf4, 0x33, 0x97, 0x09, 0xe0,
+	0x9a, 0x95, 0xf1, 0xc2, 0x46, 0xc7, 0x20, 0xf1, 0xad, 0x6c, 0x9a, 0x2a, 0xb9, 0xdf, 0xa2, 0xd5,
+	0x84, 0x06, 0x98, 0x19, 0x71, 0xe0, 0xa9, 0x4a, 0xc2, 0x78, 0x64, 0xb6, 0x4d, 0x83, 0xec, 0x6e,
+	0x5a, 0x2f, 0xee, 0xa6, 0x6f, 0x43, 0x83, 0x86, 0x9d, 0xc7, 0x5e, 0x63, 0x59, 0x61, 0xc6, 0xf1,
+	0xbf, 0x74, 0x00, 0x7e, 0x9a, 0xc4, 0xf3, 0x19, 0x65, 0x43, 0xf8, 0x50, 0x23, 0x64, 0xc2, 0xd7,
+	0xc1, 0x11, 0x99, 0x8f, 0x92, 0x59, 0xeb, 0xf3, 0x88, 0xf9, 0x3e, 0x18, 0x8f, 0x79, 0x01, 0x4b,
+	0xfc, 0x14, 0xf7, 0xa0, 0x75, 0x30, 0x1e, 0x7f, 0xa6, 0xc2, 0xf1, 0x33, 0x4d, 0x2e, 0xb9, 0xd2,
+	0x12, 0x84, 0x0f, 0x9d, 0xf3, 0x70, 0xa2, 0x52, 0x1d, 0x4c, 0x66, 0x38, 0x90, 0x3d, 0x2a, 0xd1,
+	0xc4, 0x7d, 0x68, 0x1d, 0x87, 0xa9, 0x8e, 0xc7, 0x49, 0x30, 0x21, 0xdf, 0xda, 0xfb, 0x1b, 0x68,
+	0x51, 0x4e, 0x94, 0x96, 0xef, 0xff, 0xc7, 0x81, 0xe6, 0x45, 0x10, 0xe5, 0xd6, 0x5c, 0x04, 0x91,
+	0xc9, 0x17, 0x7e, 0x96, 0xad, 0x76, 0x33, 0xab, 0xef, 0x42, 0xf3, 0x61, 0x14, 0x07, 0x1a, 0x85,
+	0xd1, 0x74, 0x47, 0xe6, 0x58, 0xdc, 0x07, 0xe8, 0xab, 0x61, 0x38, 0x09, 0x22, 0xe4, 0x56, 0xed,
+	0x06, 0x66, 0xa8, 0xb2, 0xc0, 0x2e, 0xb9, 0x83, 0xe2, 0xcb, 0xee, 0xa0, 0xcc, 0x1d, 0xa8, 0x1f,
+	0x86, 0x63, 0xe4, 0x72, 0x9e, 0x0c, 0xc2, 0x40, 0x9d, 0x26, 0x6a, 0x18, 0xa6, 0x61, 0x3c, 0xa5,
+	0x54, 0xb9, 0xd2, 0x12, 0x90, 0xcb, 0x21, 0x3b, 0x9b, 0x4f, 0xbc, 0x26, 0x0d, 0xb4, 0x04, 0xff,
+	0x57, 0x0e, 0x34, 0x8c, 0x19, 0xeb, 0xcb, 0x94, 0x6a, 0x7b, 0x88, 0xb5, 0x6d, 0x1c, 0x27, 0x20,
+	0x76, 0x01, 0x4e, 0xd4, 0xe2, 0x42, 0x25, 0x34, 0x29, 0x97, 0x7d, 0x81, 0x82, 0xb6, 0x5e, 0x04,
+	0xd1, 0xc1, 0x65, 0xb6, 0x5d, 0x19, 0x64, 0xe8, 0x78, 0x7a, 0xd6, 0x68, 0x8c, 0x41, 0xfe, 0x27,
+	0xb0, 0xdd, 0x0f, 0x53, 0x1d, 0x4e, 0x87, 0x3a, 0xf7, 0x59, 0xdc, 0xc9, 0xf7, 0x48, 0x73, 0x38,
+	0x31, 0xca, 0xb7, 0xb4, 0x8a, 0xdd, 0xd2, 0xfc, 0xbf, 0xb8, 0xd0, 0xf9, 0xd9, 0x5c, 0x25, 0xd7,
+	0x52, 0xfd, 0x72, 0xae, 0x52, 0x8d, 0x76, 0x13, 0xce, 0x56, 0x14, 0x01, 0x54, 0x79, 0xf6, 0x2c,
+	0x48, 0x46, 0xbc, 0x43, 0x55, 0xa5, 0x41, 0x48, 0x97, 0x6a, 0x12, 0x6b, 0x95, 0xd9, 0xc5, 0x48,
+	0xdc, 0x87, 0xce, 0xd1, 0xe4, 0x52, 0x8d, 0x46, 0x6a, 0xd4, 0x0f, 0x74, 0xe0, 0x35, 0xcb, 0x7d,
+	0x43, 0x89, 0x29, 0xbe, 0x0d, 0x1b, 0xa7, 0x89, 0x3a, 0x4f, 0x82, 0x69, 0x1a, 0x05, 0x5a, 0x8d,
+	0xbc, 0x16, 0xe9, 0x2a, 0x13, 0x31, 0x21, 0x8f, 0x83, 0xab, 0xc7, 0x6a, 0x12, 0x27, 0xd7, 0x1e,
+	0x70, 0xba, 0x72, 0x82, 0x78, 0x1f, 0x4f, 0xe9, 0x30, 0xd5, 0x6a, 0x3a, 0x54, 0x0f, 0x83, 0x28,
+	0xba, 0x0c, 0x86, 0x5f, 0x78, 0x6d, 0x72, 0x61, 0x95, 0x81, 0xf5, 0x77, 0x9a, 0x84, 0x71, 0x12,
+	0xea, 0x6b, 0xaf, 0x43, 0x42, 0x39, 0xc6, 0x92, 0x3a, 0x88, 0xa2, 0x78, 0x71, 0x1a, 0x24, 0x3a,
+	0x0c, 0x22, 0x6f, 0x83, 0x8c, 0x29, 0xd1, 0x70, 0xfc, 0xd1, 0x95, 0x1a, 0x9e, 0x06, 0xfa, 0x99,
+	0xb7, 0xc9, 0xe3, 0x33, 0x8c, 0x21, 0xe9, 0x45, 0xa1, 0x9a, 0x6a, 0xef, 0x36, 0x97, 0x1b, 0x23,
+	0x0c, 0xec, 0x71, 0x38, 0xd5, 0xa9, 0xb7, 0x45, 0x49, 0x61, 0x20, 0x1e, 0x80, 0x18, 0x8c, 0xa7,
+	0x71, 0xa2, 0xa4, 0x4a, 0xe7, 0x91, 0xfe, 0x34, 0x9c, 0x84, 0x3a, 0xf5, 0xb6, 0x69, 0xce, 0x35,
+	0x1c, 0xff, 0xaf, 0x0e, 0x6c, 0x98, 0x7c, 0xa5, 0xb3, 0x78, 0x9a, 0x2a, 0x5c, 0x73, 0x47, 0x49,
+	0x62, 0xd2, 0x85, 0x9f, 0xe2, 0x5d, 0x68, 0xf0, 0x98, 0xec, 0x3c, 0xb9, 0x8d, 0x71, 0xcf, 0x46,
+	0xcd, 0x23, 0x2d, 0x33, 0xbe, 0xf8, 0x08, 0x3a, 0xbd, 0x78, 0x32, 0x8b, 0x94, 0x56, 0x53, 0x95,
+	0xa6, 0x54, 0x91, 0xed, 0xfd, 0x2d, 0x94, 0x2f, 0xd2, 0x65, 0x49, 0x0a, 0x1b, 0xcc, 0xa3, 0x24,
+	0xe9, 0xc5, 0x23, 0xde, 0x33, 0x5b, 0x32, 0x83, 0x18, 0xbc, 0xa3, 0x24, 0x91, 0x4a, 0x27, 0xd7,
+	0x78, 0x6a, 0x99, 0xaa, 0x28, 0xd1, 0xfc, 0x3f, 0x38, 0xe5, 0x49, 0x31, 0x9a, 0x19, 0x26, 0x37,
+	0x9a, 0x32, 0xc7, 0xa5, 0xc2, 0xc3, 0x94, 0x1b, 0x24, 0xbe, 0x0f, 0x1b, 0x8f, 0xc3, 0x34, 0x0d,
+	0xa7, 0x63, 0xc3, 0x76, 0xad, 0xa7, 0xb4, 0x0f, 0x33, 0x59, 0x96, 0xa5, 0x78, 0xaa, 0xe7, 0x2a,
+	0x09, 0xc6, 0x6c, 0xba, 0x23, 0x73, 0xec, 0xff, 0x08, 0xda, 0x85, 0x91, 0x76, 0x77, 0x77, 0x8a,
+	0xbb, 0xfb, 0x0d, 0x0b, 0xc1, 0xff, 0x53, 0x03, 0xda, 0x85, 0x08, 0xe7, 0xad, 0x02, 0x6e, 0x39,
+	0x1b, 0xdc, 0x2a, 0x60, 0xff, 0x2b, 0xe3, 0xc5, 0x4a, 0x6b, 0x8c, 0xe7, 0x58, 0x07, 0x9c, 0x13,
+	0xb3, 0xb1, 0x3b, 0x27, 0xf6, 0x34, 0x75, 0xd7, 0x9f, 0xa6, 0x78, 0x1d, 0x78, 0x16, 0x4c, 0xc7,
+	0x6a, 0x44, 0x4e, 0x34, 0x65, 0x06, 0x45, 0xd7, 0x6e, 0xc6, 0x14, 0x7b, 0x73, 0x96, 0x64, 0x34,
+	0x99, 0x73, 0xcd, 0x69, 0x88, 0x4d, 0x64, 0x83, 0x1d, 0x61, 0x24, 0x3e, 0x86, 0xcd, 0x27, 0xd1,
+	0xc8, 0x9e, 0x4d, 0xa9, 0x59, 0xbb, 0x9b, 0xa8, 0xc7, 0x92, 0xe5, 0x92, 0x94, 0xf8, 0xe1, 0x72,
+	0x07, 0x4f, 0xab, 0xb8, 0xbd, 0x2f, 0x8c, 0x9f, 0x05, 0x8e, 0x5c, 0x92, 0x14, 0xf7, 0x0b, 0x17,
+	0x08, 0x0f, 0xec, 0x81, 0x93, 0x13, 0xa5, 0xe5, 0x8b, 0x07, 0xc5, 0xc6, 0x83, 0x96, 0xb8, 0x31,
+	0xce, 0x52, 0x65, 0x41, 0x02, 0x95, 0xe7, 0x9d, 0x8e, 0xd7, 0xb1, 0xca, 0x73, 0xa2, 0xb4, 0x7c,
+	0xd1, 0x5b, 0xd3, 0xec, 0xd3, 0x0e, 0xb0, 0xda, 0xc9, 0x33, 0x53, 0xae, 0xca, 0x63, 0x28, 0xca,
+	0xcd, 0x9b, 0xb7, 0x69, 0x43, 0x51, 0xe6, 0xc8, 0x25, 0x49, 0x71, 0xbf, 0x70, 0xeb, 0xf2, 0x6e,
+	0x5b, 0x6b, 0x73, 0xa2, 0xb4, 0x7c, 0xf1, 0x3d, 0x68, 0x17, 0x13, 0xb5, 0xb5, 0xe7, 0x64, 0x4b,
+	0xa0, 0x40, 0x96, 0x45, 0x19, 0xd1, 0x5b, 0x73, 0x60, 0x78, 0xdb, 0xd6, 0xc1, 0x15, 0xa6, 0x5c,
+	0x95, 0xa7, 0x7c, 0xc5, 0x89, 0xe6, 0x7c, 0x89, 0x42, 0xbe, 0x32, 0xa2, 0xb4, 0x7c, 0xf1, 0x14,
+	0x5e, 0x5b, 0x09, 0x11, 0x73, 0xbd, 0x57, 0x68, 0xe8, 0x1b, 0x6b, 0x03, 0x6b, 0x14, 0xdc, 0x34,
+	0xb6, 0xdc, 0xa4, 0xec, 0xfc, 0x8f, 0x26, 0xe5, 0xcb, 0x0a, 0x6c, 0x0c, 0x26, 0xb3, 0x38, 0xd1,
+	0x85, 0x63, 0x6e, 0xcd, 0xea, 0xbe, 0xb9, 0x4d, 0xc5, 0x55, 0x4e, 0xbb, 0x63, 0x55, 0x32, 0x28,
+	0x2c, 0xa0, 0x6a, 0x69, 0x01, 0xdd, 0x83, 0x16, 0x37, 0xe9, 0xc8, 0xaa, 0x11, 0xcb, 0x12, 0xf8,
+	0x6e, 0xbe, 0xa0, 0xbb, 0x59, 0x83, 0xce, 0x81, 0x0c, 0x62, 0x6b, 0xc0, 0x62, 0xc4, 0x6c, 0x12,
+	0xb3, 0x40, 0x41, 0x7e, 0x9e, 0x81, 0xd4, 0xab, 0xef, 0xb9, 0x5d, 0x57, 0x16, 0x28, 0xe2, 0x1d,
+	0xd8, 0x24, 0x27, 0x7a, 0x89, 0xc2, 0xf3, 0xf2, 0x40, 0xd3, 0x02, 0x74, 0xe5, 0x12, 0x15, 0xe5,
+	0xc8, 0x2d, 0x2b, 0xc7, 0x87, 0xe9, 0x12, 0x95, 0x3a, 0xb7, 0x48, 0x05, 0x09, 0x2d, 0xb1, 0xa6,
+	0x64, 0xe0, 0xff, 0xde, 0x05, 0xc1, 0x91, 0xe4, 0x6b, 0xd6, 0xff, 0x2d, 0x9c, 0x2f, 0x0f, 0x5b,
+	0x39, 0x38, 0x8d, 0x95, 0xe0, 0xd8, 0x96, 0x87, 0x03, 0x63, 0x90, 0xd8, 0x83, 0x76, 0xd6, 0x58,
+	0xce, 0x15, 0x47, 0xd5, 0x91, 0x45, 0x12, 0x9e, 0x58, 0x67, 0x1a, 0x1f, 0x47, 0x8c, 0x48, 0x8b,
+	0x74, 0x97, 0x68, 0x6b, 0x42, 0x0b, 0x5f, 0x31, 0xb4, 0xed, 0x97, 0x87, 0xb6, 0x53, 0x08, 0x2d,
+	0xb6, 0x41, 0xb6, 0xb3, 0x45, 0x53, 0x36, 0xc8, 0x94, 0x32, 0x11, 0xc7, 0x9e, 0xcc, 0xa3, 0x28,
+	0xf5, 0x36, 0xf7, 0x5c, 0x1c, 0x4b, 0xc0, 0xff, 0xb5, 0x03, 0x9d, 0x03, 0x1d, 0x4f, 0xc2, 0xa1,
+	0x54, 0xc3, 0x38, 0x19, 0xdd, 0x9c, 0x10, 0x0e, 0x7d, 0xa5, 0x18, 0xfa, 0x2e, 0xb8, 0x83, 0xe7,
+	0x89, 0x39, 0x6c, 0xee, 0xd0, 0x09, 0xba, 0x92, 0x61, 0x89, 0x22, 0xe2, 0x2d, 0xa8, 0x0c, 0x12,
+	0xaa, 0xf7, 0xf6, 0xfe, 0xb6, 0x15, 0xcc, 0x64, 0x2a, 0x83, 0xc4, 0x7f, 0x1f, 0x76, 0xd8, 0x90,
+	0x8c, 0x65, 0xda, 0x94, 0x1d, 0xa8, 0x1d, 0x25, 0x49, 0x9c, 0x35, 0x2a, 0x0c, 0xfc, 0x2b, 0xd8,
+	0xc9, 0x5b, 0x3c, 0x4c, 0xe4, 0x37, 0xa9, 0xa7, 0x75, 0x4f, 0x60, 0x7b, 0xd0, 0x3e, 0x89, 0xf5,
+	0x67, 0x49, 0xa8, 0x69, 0xff, 0xe5, 0x53, 0xb2, 0x48, 0xf2, 0xdf, 0x85, 0x57, 0x97, 0x66, 0xb6,
+	0xfd, 0xd4, 0xa0, 0xcf, 0xda, 0xcc, 0x33, 0xd2, 0x19, 0xbc, 0x92, 0x8b, 0x0e, 0xfa, 0xdf, 0xc8,
+	0xc6, 0x55, 0xa5, 0xef, 0xc1, 0x4e, 0x59, 0xa9, 0x99, 0x7e, 0x8d, 0x37, 0xfe, 0x21, 0x78, 0x26,
+	0x9a, 0xfc, 0x8e, 0x67, 0x2c, 0xb8, 0x08, 0xd5, 0xe2, 0xa6, 0x77, 0x0a, 0xea, 0xba, 0x2b, 0x74,
+	0x87, 0xa0, 0x6f, 0xff, 0x37, 0x15, 0xd8, 0x59, 0xa7, 0xc4, 0x16, 0xa3, 0x53, 0x2c, 0xc6, 0x7d,
+	0xa8, 0x3d, 0x0f, 0xd5, 0x22, 0xeb, 0x20, 0xef, 0x15, 0x92, 0xbd, 0x62, 0x83, 0x64, 0x51, 0x5c,
+	0x84, 0x07, 0x43, 0x9d, 0x5d, 0x6c, 0x5a, 0xd2, 0x20, 0x9c, 0xe1, 0x30, 0x8a, 0x87, 0x5f, 0xf0,
+	0x4b, 0x92, 0x64, 0xb0, 0x66, 0x51, 0xd5, 0xbe, 0xe2, 0xa2, 0xaa, 0xaf, 0x5d, 0x54, 0x5d, 0xb8,
+	0xfd, 0x74, 0x36, 0x0a, 0xb4, 0xca, 0xdb, 0x7d, 0xba, 0xd4, 0x35, 0xe5, 0x32, 0x19, 0x2f, 0x6f,
+	0x1b, 0xc6, 0x0b, 0x66, 0xdd, 0xf0, 0x8c, 0x20, 0xa0, 0x8a, 0xee, 0x65, 0xf7, 0x25, 0xfc, 0xb6,
+	0xd1, 0x72, 0xf9, 0x39, 0x89, 0x00, 0xa6, 0xf7, 0x4c, 0x69, 0x73, 0x67, 0xc3, 0x4f, 0xdc, 0x56,
+	0x88, 0xc5, 0xcb, 0x31, 0xcd, 0x1a, 0xe1, 0x22, 0xcd, 0xff, 0x1c, 0x5e, 0x2f, 0x85, 0x94, 0x56,
+	0x63, 0x96, 0x16, 0x7b, 0xb3, 0x72, 0x4a, 0x37, 0xab, 0xef, 0x42, 0xed, 0xa2, 0x90, 0x98, 0x6d,
+	0x6e, 0x0e, 0x0a, 0xce, 0x48, 0xe6, 0xfb, 0x67, 0xa5, 0xe6, 0xc0, 0x3c, 0x0b, 0x24, 0x6a, 0x1c,
+	0xe8, 0xac, 0x58, 0x2c, 0x41, 0xbc, 0x03, 0x75, 0x12, 0xce, 0xd4, 0x2e, 0x77, 0x7b, 0x86, 0xeb,
+	0xff, 0xd9, 0xe1, 0xa3, 0x9f, 0xef, 0xb8, 0x1e, 0xd4, 0x79, 0x9f, 0xcc, 0x5f, 0xed, 0x0c, 0xce,
+	0x1f, 0x01, 0x2b, 0xc5, 0x47, 0x40, 0x71, 0xc7, 0xbc, 0xec, 0xe4, 0xef, 0x8d, 0x0c, 0x51, 0xcf,
+	0xd3, 0x90, 0x18, 0xd9, 0x5b, 0xa3, 0xc1, 0xa2, 0x9b, 0xef, 0xeb, 0x35, 0xdb, 0xe8, 0xe5, 0x06,
+	0xa4, 0x28, 0xc9, 0x5f, 0xf6, 0x81, 0xf1, 0x43, 0x00, 0x2b, 0x20, 0xbe, 0x53, 0xba, 0x0b, 0x17,
+	0xfa, 0x94, 0xd2, 0x2b, 0xa1, 0xdf, 0x83, 0x0e, 0xf7, 0x15, 0x37, 0x3c, 0x12, 0xbf, 0x6d, 0xb4,
+	0x9b, 0x07, 0xd5, 0x25, 0x2d, 0x66, 0x66, 0x59, 0x68, 0x8b, 0x5e, 0xd6, 0xec, 0xbf, 0xb7, 0xfc,
+	0xde, 0xb7, 0x65, 0x9b, 0xa7, 0xe5, 0x77, 0xbe, 0xdf, 0x3a, 0x37, 0xb6, 0x4f, 0xeb, 0x9b, 0x55,
+	0xe7, 0x6b, 0x36, 0xab, 0x5f, 0xc7, 0x98, 0x27, 0x85, 0x9e, 0xeb, 0xe6, 0xa7, 0xb7, 0xa3, 0xd1,
+	0x58, 0xb1, 0x32, 0x57, 0x32, 0xa0, 0x3b, 0x31, 0xf7, 0xa8, 0xbc, 0x03, 0x1a, 0x74, 0xb8, 0xf5,
+	0xf7, 0x17, 0xbb, 0xce, 0x3f, 0x5f, 0xec, 0x3a, 0xff, 0x7a, 0xb1, 0xeb, 0xfc, 0xf1, 0xdf, 0xbb,
+	0xb7, 0x2e, 0xeb, 0xf4, 0xef, 0xe3, 0xc3, 0xff, 0x0e, 0x00, 0x50, 0xeb, 0xb9, 0x43, 0x0b, 0x19,
+	0x00, 0x00,
 }
 
 func (m *Row) Marshal() (dAtA []byte, err error) {
@@ -3347,6 +4126,13 @@ func (m *ExtractedTableColumn) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Attrs) > 0 {
+		i -= len(m.Attrs)
+		copy(dAtA[i:], m.Attrs)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Attrs)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.Values) > 0 {
 		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -3609,6 +4395,16 @@ func (m *PairsField) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Stale {
+		i--
+		if m.Stale {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.Field) > 0 {
 		i -= len(m.Field)
 		copy(dAtA[i:], m.Field)
@@ -3689,9 +4485,35 @@ func (m *FieldRow) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Value != nil {
+	if m.ValueTo != nil {
 		{
-			size, err := m.Value.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.ValueTo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublic(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Index)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Period) > 0 {
+		i -= len(m.Period)
+		copy(dAtA[i:], m.Period)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Period)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Value != nil {
+		{
+			size, err := m.Value.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3747,6 +4569,30 @@ func (m *GroupCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Histogram != nil {
+		{
+			size, err := m.Histogram.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublic(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.TimestampAgg) > 0 {
+		i -= len(m.TimestampAgg)
+		copy(dAtA[i:], m.TimestampAgg)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.TimestampAgg)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.AggWeight != 0 {
+		i = encodeVarintPublic(dAtA, i, uint64(m.AggWeight))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.Agg != 0 {
 		i = encodeVarintPublic(dAtA, i, uint64(m.Agg))
 		i--
@@ -3798,6 +4644,25 @@ func (m *ValCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.WeightSum) > 0 {
+		i -= len(m.WeightSum)
+		copy(dAtA[i:], m.WeightSum)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.WeightSum)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Precision != 0 {
+		i = encodeVarintPublic(dAtA, i, uint64(m.Precision))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.BigVal) > 0 {
+		i -= len(m.BigVal)
+		copy(dAtA[i:], m.BigVal)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.BigVal)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if len(m.TimestampVal) > 0 {
 		i -= len(m.TimestampVal)
 		copy(dAtA[i:], m.TimestampVal)
@@ -3967,6 +4832,67 @@ func (m *QueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.IgnoreResultLimits {
+		i--
+		if m.IgnoreResultLimits {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if len(m.Hints) > 0 {
+		for iNdEx := len(m.Hints) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Hints[iNdEx])
+			copy(dAtA[i:], m.Hints[iNdEx])
+			i = encodeVarintPublic(dAtA, i, uint64(len(m.Hints[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if len(m.Client) > 0 {
+		i -= len(m.Client)
+		copy(dAtA[i:], m.Client)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Client)))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if len(m.ExecPath) > 0 {
+		i -= len(m.ExecPath)
+		copy(dAtA[i:], m.ExecPath)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.ExecPath)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.AllowPartial {
+		i--
+		if m.AllowPartial {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if len(m.Priority) > 0 {
+		i -= len(m.Priority)
+		copy(dAtA[i:], m.Priority)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Priority)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.ExistenceFallback) > 0 {
+		i -= len(m.ExistenceFallback)
+		copy(dAtA[i:], m.ExistenceFallback)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.ExistenceFallback)))
+		i--
+		dAtA[i] = 0x5a
+	}
 	if m.MaxMemory != 0 {
 		i = encodeVarintPublic(dAtA, i, uint64(m.MaxMemory))
 		i--
@@ -4058,6 +4984,35 @@ func (m *QueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.ErrRetryable {
+		i--
+		if m.ErrRetryable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.ErrCode) > 0 {
+		i -= len(m.ErrCode)
+		copy(dAtA[i:], m.ErrCode)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.ErrCode)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Completeness != nil {
+		{
+			size, err := m.Completeness.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublic(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.Results) > 0 {
 		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -4082,6 +5037,120 @@ func (m *QueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Completeness) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Completeness) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Completeness) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Coverage != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Coverage))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if len(m.MissingShards) > 0 {
+		for iNdEx := len(m.MissingShards) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MissingShards[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPublic(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Shards != 0 {
+		i = encodeVarintPublic(dAtA, i, uint64(m.Shards))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Complete {
+		i--
+		if m.Complete {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IndexShards) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexShards) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IndexShards) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Shards) > 0 {
+		dAtA18 := make([]byte, len(m.Shards)*10)
+		var j17 int
+		for _, num := range m.Shards {
+			for num >= 1<<7 {
+				dAtA18[j17] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j17++
+			}
+			dAtA18[j17] = uint8(num)
+			j17++
+		}
+		i -= j17
+		copy(dAtA[i:], dAtA18[:j17])
+		i = encodeVarintPublic(dAtA, i, uint64(j17))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Index) > 0 {
+		i -= len(m.Index)
+		copy(dAtA[i:], m.Index)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Index)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *QueryResult) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4106,6 +5175,48 @@ func (m *QueryResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Histogram != nil {
+		{
+			size, err := m.Histogram.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublic(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	if m.ExtractedIDMatrixSorted != nil {
+		{
+			size, err := m.ExtractedIDMatrixSorted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublic(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if m.SortedRow != nil {
+		{
+			size, err := m.SortedRow.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublic(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
 	if m.DistinctTimestamp != nil {
 		{
 			size, err := m.DistinctTimestamp.MarshalToSizedBuffer(dAtA[:i])
@@ -4233,20 +5344,20 @@ func (m *QueryResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.RowIDs) > 0 {
-		dAtA26 := make([]byte, len(m.RowIDs)*10)
-		var j25 int
+		dAtA31 := make([]byte, len(m.RowIDs)*10)
+		var j30 int
 		for _, num := range m.RowIDs {
 			for num >= 1<<7 {
-				dAtA26[j25] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA31[j30] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j25++
+				j30++
 			}
-			dAtA26[j25] = uint8(num)
-			j25++
+			dAtA31[j30] = uint8(num)
+			j30++
 		}
-		i -= j25
-		copy(dAtA[i:], dAtA26[:j25])
-		i = encodeVarintPublic(dAtA, i, uint64(j25))
+		i -= j30
+		copy(dAtA[i:], dAtA31[:j30])
+		i = encodeVarintPublic(dAtA, i, uint64(j30))
 		i--
 		dAtA[i] = 0x3a
 	}
@@ -4374,57 +5485,57 @@ func (m *ImportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.Timestamps) > 0 {
-		dAtA30 := make([]byte, len(m.Timestamps)*10)
-		var j29 int
+		dAtA35 := make([]byte, len(m.Timestamps)*10)
+		var j34 int
 		for _, num1 := range m.Timestamps {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA30[j29] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA35[j34] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j29++
+				j34++
 			}
-			dAtA30[j29] = uint8(num)
-			j29++
+			dAtA35[j34] = uint8(num)
+			j34++
 		}
-		i -= j29
-		copy(dAtA[i:], dAtA30[:j29])
-		i = encodeVarintPublic(dAtA, i, uint64(j29))
+		i -= j34
+		copy(dAtA[i:], dAtA35[:j34])
+		i = encodeVarintPublic(dAtA, i, uint64(j34))
 		i--
 		dAtA[i] = 0x32
 	}
 	if len(m.ColumnIDs) > 0 {
-		dAtA32 := make([]byte, len(m.ColumnIDs)*10)
-		var j31 int
+		dAtA37 := make([]byte, len(m.ColumnIDs)*10)
+		var j36 int
 		for _, num := range m.ColumnIDs {
 			for num >= 1<<7 {
-				dAtA32[j31] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA37[j36] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j31++
+				j36++
 			}
-			dAtA32[j31] = uint8(num)
-			j31++
+			dAtA37[j36] = uint8(num)
+			j36++
 		}
-		i -= j31
-		copy(dAtA[i:], dAtA32[:j31])
-		i = encodeVarintPublic(dAtA, i, uint64(j31))
+		i -= j36
+		copy(dAtA[i:], dAtA37[:j36])
+		i = encodeVarintPublic(dAtA, i, uint64(j36))
 		i--
 		dAtA[i] = 0x2a
 	}
 	if len(m.RowIDs) > 0 {
-		dAtA34 := make([]byte, len(m.RowIDs)*10)
-		var j33 int
+		dAtA39 := make([]byte, len(m.RowIDs)*10)
+		var j38 int
 		for _, num := range m.RowIDs {
 			for num >= 1<<7 {
-				dAtA34[j33] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA39[j38] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j33++
+				j38++
 			}
-			dAtA34[j33] = uint8(num)
-			j33++
+			dAtA39[j38] = uint8(num)
+			j38++
 		}
-		i -= j33
-		copy(dAtA[i:], dAtA34[:j33])
-		i = encodeVarintPublic(dAtA, i, uint64(j33))
+		i -= j38
+		copy(dAtA[i:], dAtA39[:j38])
+		i = encodeVarintPublic(dAtA, i, uint64(j38))
 		i--
 		dAtA[i] = 0x22
 	}
@@ -4474,6 +5585,28 @@ func (m *ImportValueRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Nulls) > 0 {
+		for iNdEx := len(m.Nulls) - 1; iNdEx >= 0; iNdEx-- {
+			i--
+			if m.Nulls[iNdEx] {
+				dAtA[i] = 1
+			} else {
+				dAtA[i] = 0
+			}
+		}
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Nulls)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if len(m.DecimalValues) > 0 {
+		for iNdEx := len(m.DecimalValues) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DecimalValues[iNdEx])
+			copy(dAtA[i:], m.DecimalValues[iNdEx])
+			i = encodeVarintPublic(dAtA, i, uint64(len(m.DecimalValues[iNdEx])))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
 	if m.Clear {
 		i--
 		if m.Clear {
@@ -4505,9 +5638,9 @@ func (m *ImportValueRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	if len(m.FloatValues) > 0 {
 		for iNdEx := len(m.FloatValues) - 1; iNdEx >= 0; iNdEx-- {
-			f35 := math.Float64bits(float64(m.FloatValues[iNdEx]))
+			f40 := math.Float64bits(float64(m.FloatValues[iNdEx]))
 			i -= 8
-			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f35))
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f40))
 		}
 		i = encodeVarintPublic(dAtA, i, uint64(len(m.FloatValues)*8))
 		i--
@@ -4523,39 +5656,39 @@ func (m *ImportValueRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.Values) > 0 {
-		dAtA37 := make([]byte, len(m.Values)*10)
-		var j36 int
+		dAtA42 := make([]byte, len(m.Values)*10)
+		var j41 int
 		for _, num1 := range m.Values {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA37[j36] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA42[j41] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j36++
+				j41++
 			}
-			dAtA37[j36] = uint8(num)
-			j36++
+			dAtA42[j41] = uint8(num)
+			j41++
 		}
-		i -= j36
-		copy(dAtA[i:], dAtA37[:j36])
-		i = encodeVarintPublic(dAtA, i, uint64(j36))
+		i -= j41
+		copy(dAtA[i:], dAtA42[:j41])
+		i = encodeVarintPublic(dAtA, i, uint64(j41))
 		i--
 		dAtA[i] = 0x32
 	}
 	if len(m.ColumnIDs) > 0 {
-		dAtA39 := make([]byte, len(m.ColumnIDs)*10)
-		var j38 int
+		dAtA44 := make([]byte, len(m.ColumnIDs)*10)
+		var j43 int
 		for _, num := range m.ColumnIDs {
 			for num >= 1<<7 {
-				dAtA39[j38] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA44[j43] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j38++
+				j43++
 			}
-			dAtA39[j38] = uint8(num)
-			j38++
+			dAtA44[j43] = uint8(num)
+			j43++
 		}
-		i -= j38
-		copy(dAtA[i:], dAtA39[:j38])
-		i = encodeVarintPublic(dAtA, i, uint64(j38))
+		i -= j43
+		copy(dAtA[i:], dAtA44[:j43])
+		i = encodeVarintPublic(dAtA, i, uint64(j43))
 		i--
 		dAtA[i] = 0x2a
 	}
@@ -4767,20 +5900,20 @@ func (m *TranslateKeysResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	if len(m.IDs) > 0 {
-		dAtA41 := make([]byte, len(m.IDs)*10)
-		var j40 int
+		dAtA46 := make([]byte, len(m.IDs)*10)
+		var j45 int
 		for _, num := range m.IDs {
 			for num >= 1<<7 {
-				dAtA41[j40] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA46[j45] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j40++
+				j45++
 			}
-			dAtA41[j40] = uint8(num)
-			j40++
+			dAtA46[j45] = uint8(num)
+			j45++
 		}
-		i -= j40
-		copy(dAtA[i:], dAtA41[:j40])
-		i = encodeVarintPublic(dAtA, i, uint64(j40))
+		i -= j45
+		copy(dAtA[i:], dAtA46[:j45])
+		i = encodeVarintPublic(dAtA, i, uint64(j45))
 		i--
 		dAtA[i] = 0x1a
 	}
@@ -4812,20 +5945,20 @@ func (m *TranslateIDsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	if len(m.IDs) > 0 {
-		dAtA43 := make([]byte, len(m.IDs)*10)
-		var j42 int
+		dAtA48 := make([]byte, len(m.IDs)*10)
+		var j47 int
 		for _, num := range m.IDs {
 			for num >= 1<<7 {
-				dAtA43[j42] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA48[j47] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j42++
+				j47++
 			}
-			dAtA43[j42] = uint8(num)
-			j42++
+			dAtA48[j47] = uint8(num)
+			j47++
 		}
-		i -= j42
-		copy(dAtA[i:], dAtA43[:j42])
-		i = encodeVarintPublic(dAtA, i, uint64(j42))
+		i -= j47
+		copy(dAtA[i:], dAtA48[:j47])
+		i = encodeVarintPublic(dAtA, i, uint64(j47))
 		i--
 		dAtA[i] = 0x1a
 	}
@@ -5170,207 +6303,439 @@ func (m *GroupCounts) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintPublic(dAtA []byte, offset int, v uint64) int {
-	offset -= sovPublic(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *SortValue) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Row) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *SortValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SortValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Columns) > 0 {
-		l = 0
-		for _, e := range m.Columns {
-			l += sovPublic(uint64(e))
-		}
-		n += 1 + sovPublic(uint64(l)) + l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Keys) > 0 {
-		for _, s := range m.Keys {
-			l = len(s)
-			n += 1 + l + sovPublic(uint64(l))
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
 		}
 	}
-	l = len(m.Roaring)
-	if l > 0 {
-		n += 1 + l + sovPublic(uint64(l))
-	}
-	l = len(m.Index)
-	if l > 0 {
-		n += 1 + l + sovPublic(uint64(l))
-	}
-	l = len(m.Field)
-	if l > 0 {
-		n += 1 + l + sovPublic(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *RowMatrix) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SortValue_String_) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SortValue_String_) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.String_)
+	copy(dAtA[i:], m.String_)
+	i = encodeVarintPublic(dAtA, i, uint64(len(m.String_)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+func (m *SortValue_Bool) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SortValue_Bool) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i--
+	if m.Bool {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
 	}
-	var l int
-	_ = l
-	if len(m.Rows) > 0 {
-		for _, e := range m.Rows {
-			l = e.Size()
-			n += 1 + l + sovPublic(uint64(l))
+	i--
+	dAtA[i] = 0x10
+	return len(dAtA) - i, nil
+}
+func (m *SortValue_Int64) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SortValue_Int64) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintPublic(dAtA, i, uint64(m.Int64))
+	i--
+	dAtA[i] = 0x18
+	return len(dAtA) - i, nil
+}
+func (m *SortValue_Uint64) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SortValue_Uint64) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintPublic(dAtA, i, uint64(m.Uint64))
+	i--
+	dAtA[i] = 0x20
+	return len(dAtA) - i, nil
+}
+func (m *SortValue_Values) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SortValue_Values) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Values != nil {
+		{
+			size, err := m.Values.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublic(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return len(dAtA) - i, nil
+}
+func (m *SortValues) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *SignedRow) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *SortValues) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SortValues) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Pos != nil {
-		l = m.Pos.Size()
-		n += 1 + l + sovPublic(uint64(l))
-	}
-	if m.Neg != nil {
-		l = m.Neg.Size()
-		n += 1 + l + sovPublic(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Values[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPublic(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *RowIdentifiers) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SortedColumn) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SortedColumn) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SortedColumn) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Rows) > 0 {
-		l = 0
-		for _, e := range m.Rows {
-			l += sovPublic(uint64(e))
-		}
-		n += 1 + sovPublic(uint64(l)) + l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Keys) > 0 {
-		for _, s := range m.Keys {
-			l = len(s)
-			n += 1 + l + sovPublic(uint64(l))
+	if m.Value != nil {
+		{
+			size, err := m.Value.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublic(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.ID != 0 {
+		i = encodeVarintPublic(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *IDList) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SortedRow) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SortedRow) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SortedRow) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.IDs) > 0 {
-		l = 0
-		for _, e := range m.IDs {
-			l += sovPublic(uint64(e))
-		}
-		n += 1 + sovPublic(uint64(l)) + l
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Columns) > 0 {
+		for iNdEx := len(m.Columns) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Columns[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPublic(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Row != nil {
+		{
+			size, err := m.Row.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublic(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ExtractedIDColumn) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ExtractedIDMatrixSorted) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ExtractedIDMatrixSorted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExtractedIDMatrixSorted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovPublic(uint64(m.ID))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Vals) > 0 {
-		for _, e := range m.Vals {
-			l = e.Size()
-			n += 1 + l + sovPublic(uint64(l))
+	if len(m.Columns) > 0 {
+		for iNdEx := len(m.Columns) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Columns[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPublic(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.ExtractedIDMatrix != nil {
+		{
+			size, err := m.ExtractedIDMatrix.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublic(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ExtractedIDMatrix) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Histogram) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Histogram) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Histogram) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Fields) > 0 {
-		for _, s := range m.Fields {
-			l = len(s)
-			n += 1 + l + sovPublic(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Counts) > 0 {
+		dAtA2 := make([]byte, len(m.Counts)*10)
+		var j1 int
+		for _, num := range m.Counts {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
 		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintPublic(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if len(m.Columns) > 0 {
-		for _, e := range m.Columns {
-			l = e.Size()
-			n += 1 + l + sovPublic(uint64(l))
+	if len(m.Edges) > 0 {
+		dAtA4 := make([]byte, len(m.Edges)*10)
+		var j3 int
+		for _, num1 := range m.Edges {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
 		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintPublic(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *KeyList) Size() (n int) {
+func encodeVarintPublic(dAtA []byte, offset int, v uint64) int {
+	offset -= sovPublic(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Row) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if len(m.Columns) > 0 {
+		l = 0
+		for _, e := range m.Columns {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
 	if len(m.Keys) > 0 {
 		for _, s := range m.Keys {
 			l = len(s)
 			n += 1 + l + sovPublic(uint64(l))
 		}
 	}
+	l = len(m.Roaring)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ExtractedTableValue) Size() (n int) {
+func (m *RowMatrix) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Value != nil {
-		n += m.Value.Size()
+	if len(m.Rows) > 0 {
+		for _, e := range m.Rows {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -5378,60 +6743,202 @@ func (m *ExtractedTableValue) Size() (n int) {
 	return n
 }
 
-func (m *ExtractedTableValue_IDs) Size() (n int) {
+func (m *SignedRow) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.IDs != nil {
-		l = m.IDs.Size()
+	if m.Pos != nil {
+		l = m.Pos.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Neg != nil {
+		l = m.Neg.Size()
 		n += 1 + l + sovPublic(uint64(l))
 	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
 	return n
 }
-func (m *ExtractedTableValue_Keys) Size() (n int) {
+
+func (m *RowIdentifiers) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Keys != nil {
-		l = m.Keys.Size()
-		n += 1 + l + sovPublic(uint64(l))
+	if len(m.Rows) > 0 {
+		l = 0
+		for _, e := range m.Rows {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	if len(m.Keys) > 0 {
+		for _, s := range m.Keys {
+			l = len(s)
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
-func (m *ExtractedTableValue_BSIValue) Size() (n int) {
+
+func (m *IDList) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	n += 1 + sovPublic(uint64(m.BSIValue))
+	if len(m.IDs) > 0 {
+		l = 0
+		for _, e := range m.IDs {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
 	return n
 }
-func (m *ExtractedTableValue_MutexID) Size() (n int) {
+
+func (m *ExtractedIDColumn) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	n += 1 + sovPublic(uint64(m.MutexID))
+	if m.ID != 0 {
+		n += 1 + sovPublic(uint64(m.ID))
+	}
+	if len(m.Vals) > 0 {
+		for _, e := range m.Vals {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
 	return n
 }
-func (m *ExtractedTableValue_MutexKey) Size() (n int) {
+
+func (m *ExtractedIDMatrix) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.MutexKey)
-	n += 1 + l + sovPublic(uint64(l))
-	return n
-}
-func (m *ExtractedTableValue_Bool) Size() (n int) {
-	if m == nil {
+	if len(m.Fields) > 0 {
+		for _, s := range m.Fields {
+			l = len(s)
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if len(m.Columns) > 0 {
+		for _, e := range m.Columns {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KeyList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for _, s := range m.Keys {
+			l = len(s)
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExtractedTableValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExtractedTableValue_IDs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IDs != nil {
+		l = m.IDs.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	return n
+}
+func (m *ExtractedTableValue_Keys) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Keys != nil {
+		l = m.Keys.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	return n
+}
+func (m *ExtractedTableValue_BSIValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovPublic(uint64(m.BSIValue))
+	return n
+}
+func (m *ExtractedTableValue_MutexID) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovPublic(uint64(m.MutexID))
+	return n
+}
+func (m *ExtractedTableValue_MutexKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.MutexKey)
+	n += 1 + l + sovPublic(uint64(l))
+	return n
+}
+func (m *ExtractedTableValue_Bool) Size() (n int) {
+	if m == nil {
 		return 0
 	}
 	var l int
@@ -5454,6 +6961,10 @@ func (m *ExtractedTableColumn) Size() (n int) {
 			n += 1 + l + sovPublic(uint64(l))
 		}
 	}
+	l = len(m.Attrs)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5581,6 +7092,9 @@ func (m *PairsField) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPublic(uint64(l))
 	}
+	if m.Stale {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5623,6 +7137,18 @@ func (m *FieldRow) Size() (n int) {
 		l = m.Value.Size()
 		n += 1 + l + sovPublic(uint64(l))
 	}
+	l = len(m.Period)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.ValueTo != nil {
+		l = m.ValueTo.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5647,6 +7173,17 @@ func (m *GroupCount) Size() (n int) {
 	if m.Agg != 0 {
 		n += 1 + sovPublic(uint64(m.Agg))
 	}
+	if m.AggWeight != 0 {
+		n += 1 + sovPublic(uint64(m.AggWeight))
+	}
+	l = len(m.TimestampAgg)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Histogram != nil {
+		l = m.Histogram.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5676,6 +7213,17 @@ func (m *ValCount) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPublic(uint64(l))
 	}
+	l = len(m.BigVal)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Precision != 0 {
+		n += 1 + sovPublic(uint64(m.Precision))
+	}
+	l = len(m.WeightSum)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5764,6 +7312,34 @@ func (m *QueryRequest) Size() (n int) {
 	if m.MaxMemory != 0 {
 		n += 1 + sovPublic(uint64(m.MaxMemory))
 	}
+	l = len(m.ExistenceFallback)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	l = len(m.Priority)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.AllowPartial {
+		n += 2
+	}
+	l = len(m.ExecPath)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	l = len(m.Client)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if len(m.Hints) > 0 {
+		for _, s := range m.Hints {
+			l = len(s)
+			n += 2 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.IgnoreResultLimits {
+		n += 3
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5786,6 +7362,67 @@ func (m *QueryResponse) Size() (n int) {
 			n += 1 + l + sovPublic(uint64(l))
 		}
 	}
+	if m.Completeness != nil {
+		l = m.Completeness.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	l = len(m.ErrCode)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.ErrRetryable {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Completeness) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Complete {
+		n += 2
+	}
+	if m.Shards != 0 {
+		n += 1 + sovPublic(uint64(m.Shards))
+	}
+	if len(m.MissingShards) > 0 {
+		for _, e := range m.MissingShards {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.Coverage != 0 {
+		n += 9
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IndexShards) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if len(m.Shards) > 0 {
+		l = 0
+		for _, e := range m.Shards {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5870,6 +7507,18 @@ func (m *QueryResult) Size() (n int) {
 		l = m.DistinctTimestamp.Size()
 		n += 2 + l + sovPublic(uint64(l))
 	}
+	if m.SortedRow != nil {
+		l = m.SortedRow.Size()
+		n += 2 + l + sovPublic(uint64(l))
+	}
+	if m.ExtractedIDMatrixSorted != nil {
+		l = m.ExtractedIDMatrixSorted.Size()
+		n += 2 + l + sovPublic(uint64(l))
+	}
+	if m.Histogram != nil {
+		l = m.Histogram.Size()
+		n += 2 + l + sovPublic(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5996,6 +7645,15 @@ func (m *ImportValueRequest) Size() (n int) {
 	if m.Clear {
 		n += 2
 	}
+	if len(m.DecimalValues) > 0 {
+		for _, s := range m.DecimalValues {
+			l = len(s)
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if len(m.Nulls) > 0 {
+		n += 1 + sovPublic(uint64(len(m.Nulls))) + len(m.Nulls)
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -6273,34 +7931,209 @@ func (m *GroupCounts) Size() (n int) {
 	return n
 }
 
-func sovPublic(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *SortValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func sozPublic(x uint64) (n int) {
-	return sovPublic(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *SortValue_String_) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.String_)
+	n += 1 + l + sovPublic(uint64(l))
+	return n
 }
-func (m *Row) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPublic
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
+func (m *SortValue_Bool) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 2
+	return n
+}
+func (m *SortValue_Int64) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovPublic(uint64(m.Int64))
+	return n
+}
+func (m *SortValue_Uint64) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovPublic(uint64(m.Uint64))
+	return n
+}
+func (m *SortValue_Values) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Values != nil {
+		l = m.Values.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	return n
+}
+func (m *SortValues) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, e := range m.Values {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SortedColumn) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovPublic(uint64(m.ID))
+	}
+	if m.Value != nil {
+		l = m.Value.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SortedRow) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Row != nil {
+		l = m.Row.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if len(m.Columns) > 0 {
+		for _, e := range m.Columns {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExtractedIDMatrixSorted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ExtractedIDMatrix != nil {
+		l = m.ExtractedIDMatrix.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if len(m.Columns) > 0 {
+		for _, e := range m.Columns {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Histogram) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if len(m.Edges) > 0 {
+		l = 0
+		for _, e := range m.Edges {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	if len(m.Counts) > 0 {
+		l = 0
+		for _, e := range m.Counts {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovPublic(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozPublic(x uint64) (n int) {
+	return sovPublic(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Row) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
 		if wireType == 4 {
 			return fmt.Errorf("proto: Row: wiretype end group for non-group")
 		}
@@ -7663,6 +9496,40 @@ func (m *ExtractedTableColumn) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attrs = append(m.Attrs[:0], dAtA[iNdEx:postIndex]...)
+			if m.Attrs == nil {
+				m.Attrs = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -8254,6 +10121,26 @@ func (m *PairsField) Unmarshal(dAtA []byte) error {
 			}
 			m.Field = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stale", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Stale = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -8494,34 +10381,134 @@ func (m *FieldRow) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPublic(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Period", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GroupCount) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
+			m.Period = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueTo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValueTo == nil {
+				m.ValueTo = &Int64{}
+			}
+			if err := m.ValueTo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GroupCount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
 		for shift := uint(0); ; shift += 7 {
 			if shift >= 64 {
 				return ErrIntOverflowPublic
@@ -8617,6 +10604,93 @@ func (m *GroupCount) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggWeight", wireType)
+			}
+			m.AggWeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AggWeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimestampAgg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TimestampAgg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Histogram", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Histogram == nil {
+				m.Histogram = &Histogram{}
+			}
+			if err := m.Histogram.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -8785,6 +10859,89 @@ func (m *ValCount) Unmarshal(dAtA []byte) error {
 			}
 			m.TimestampVal = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BigVal", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BigVal = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Precision", wireType)
+			}
+			m.Precision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Precision |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WeightSum", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WeightSum = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -9315,60 +11472,93 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPublic(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExistenceFallback", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPublic
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *QueryResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPublic
+			m.ExistenceFallback = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
 			}
-			if iNdEx >= l {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Priority = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowPartial", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllowPartial = bool(v != 0)
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecPath", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9396,13 +11586,13 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Err = string(dAtA[iNdEx:postIndex])
+			m.ExecPath = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Client", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -9412,26 +11602,76 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPublic
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Results = append(m.Results, &QueryResult{})
-			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Client = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hints", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Hints = append(m.Hints, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreResultLimits", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IgnoreResultLimits = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -9454,7 +11694,7 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryResult) Unmarshal(dAtA []byte) error {
+func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9477,17 +11717,17 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -9497,33 +11737,29 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPublic
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Row == nil {
-				m.Row = &Row{}
-			}
-			if err := m.Row.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Err = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field N", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
 			}
-			m.N = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -9533,14 +11769,29 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.N |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Results = append(m.Results, &QueryResult{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pairs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Completeness", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9567,16 +11818,18 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Pairs = append(m.Pairs, &Pair{})
-			if err := m.Pairs[len(m.Pairs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Completeness == nil {
+				m.Completeness = &Completeness{}
+			}
+			if err := m.Completeness.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Changed", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrCode", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -9586,17 +11839,29 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Changed = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValCount", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrRetryable", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -9606,12 +11871,122 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			m.ErrRetryable = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Completeness) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Completeness: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Completeness: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Complete", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Complete = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+			}
+			m.Shards = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shards |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MissingShards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthPublic
 			}
 			postIndex := iNdEx + msglen
@@ -9621,18 +11996,78 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ValCount == nil {
-				m.ValCount = &ValCount{}
-			}
-			if err := m.ValCount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.MissingShards = append(m.MissingShards, &IndexShards{})
+			if err := m.MissingShards[len(m.MissingShards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Coverage", wireType)
 			}
-			m.Type = 0
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Coverage = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IndexShards) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IndexShards: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IndexShards: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -9642,12 +12077,25 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType == 0 {
 				var v uint64
 				for shift := uint(0); ; shift += 7 {
@@ -9664,7 +12112,7 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 						break
 					}
 				}
-				m.RowIDs = append(m.RowIDs, v)
+				m.Shards = append(m.Shards, v)
 			} else if wireType == 2 {
 				var packedLen int
 				for shift := uint(0); ; shift += 7 {
@@ -9699,8 +12147,8 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 					}
 				}
 				elementCount = count
-				if elementCount != 0 && len(m.RowIDs) == 0 {
-					m.RowIDs = make([]uint64, 0, elementCount)
+				if elementCount != 0 && len(m.Shards) == 0 {
+					m.Shards = make([]uint64, 0, elementCount)
 				}
 				for iNdEx < postIndex {
 					var v uint64
@@ -9718,14 +12166,65 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 							break
 						}
 					}
-					m.RowIDs = append(m.RowIDs, v)
+					m.Shards = append(m.Shards, v)
 				}
 			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field RowIDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
 			}
-		case 8:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OldGroupCounts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9752,15 +12251,255 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OldGroupCounts = append(m.OldGroupCounts, &GroupCount{})
-			if err := m.OldGroupCounts[len(m.OldGroupCounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Row == nil {
+				m.Row = &Row{}
+			}
+			if err := m.Row.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RowIdentifiers", wireType)
-			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field N", wireType)
+			}
+			m.N = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.N |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pairs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pairs = append(m.Pairs, &Pair{})
+			if err := m.Pairs[len(m.Pairs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Changed = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValCount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValCount == nil {
+				m.ValCount = &ValCount{}
+			}
+			if err := m.ValCount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RowIDs = append(m.RowIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPublic
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RowIDs) == 0 {
+					m.RowIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RowIDs = append(m.RowIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowIDs", wireType)
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldGroupCounts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldGroupCounts = append(m.OldGroupCounts, &GroupCount{})
+			if err := m.OldGroupCounts[len(m.OldGroupCounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowIdentifiers", wireType)
+			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
@@ -10049,7 +12788,596 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field DistinctTimestamp", wireType)
 			}
-			var msglen int
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DistinctTimestamp == nil {
+				m.DistinctTimestamp = &DistinctTimestamp{}
+			}
+			if err := m.DistinctTimestamp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SortedRow", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SortedRow == nil {
+				m.SortedRow = &SortedRow{}
+			}
+			if err := m.SortedRow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtractedIDMatrixSorted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExtractedIDMatrixSorted == nil {
+				m.ExtractedIDMatrixSorted = &ExtractedIDMatrixSorted{}
+			}
+			if err := m.ExtractedIDMatrixSorted.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Histogram", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Histogram == nil {
+				m.Histogram = &Histogram{}
+			}
+			if err := m.Histogram.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			m.Shard = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shard |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RowIDs = append(m.RowIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPublic
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RowIDs) == 0 {
+					m.RowIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RowIDs = append(m.RowIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowIDs", wireType)
+			}
+		case 5:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ColumnIDs = append(m.ColumnIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPublic
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ColumnIDs) == 0 {
+					m.ColumnIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ColumnIDs = append(m.ColumnIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
+			}
+		case 6:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Timestamps = append(m.Timestamps, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPublic
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Timestamps) == 0 {
+					m.Timestamps = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Timestamps = append(m.Timestamps, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamps", wireType)
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowKeys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RowKeys = append(m.RowKeys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnKeys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ColumnKeys = append(m.ColumnKeys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexCreatedAt", wireType)
+			}
+			m.IndexCreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IndexCreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FieldCreatedAt", wireType)
+			}
+			m.FieldCreatedAt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -10059,28 +13387,31 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.FieldCreatedAt |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.DistinctTimestamp == nil {
-				m.DistinctTimestamp = &DistinctTimestamp{}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Clear", wireType)
 			}
-			if err := m.DistinctTimestamp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.Clear = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -10103,7 +13434,7 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImportRequest) Unmarshal(dAtA []byte) error {
+func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10126,10 +13457,10 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImportRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImportValueRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportValueRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10215,7 +13546,7 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 4:
+		case 5:
 			if wireType == 0 {
 				var v uint64
 				for shift := uint(0); ; shift += 7 {
@@ -10232,7 +13563,7 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 						break
 					}
 				}
-				m.RowIDs = append(m.RowIDs, v)
+				m.ColumnIDs = append(m.ColumnIDs, v)
 			} else if wireType == 2 {
 				var packedLen int
 				for shift := uint(0); ; shift += 7 {
@@ -10267,8 +13598,8 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 					}
 				}
 				elementCount = count
-				if elementCount != 0 && len(m.RowIDs) == 0 {
-					m.RowIDs = make([]uint64, 0, elementCount)
+				if elementCount != 0 && len(m.ColumnIDs) == 0 {
+					m.ColumnIDs = make([]uint64, 0, elementCount)
 				}
 				for iNdEx < postIndex {
 					var v uint64
@@ -10286,14 +13617,14 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 							break
 						}
 					}
-					m.RowIDs = append(m.RowIDs, v)
+					m.ColumnIDs = append(m.ColumnIDs, v)
 				}
 			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field RowIDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
 			}
-		case 5:
+		case 6:
 			if wireType == 0 {
-				var v uint64
+				var v int64
 				for shift := uint(0); ; shift += 7 {
 					if shift >= 64 {
 						return ErrIntOverflowPublic
@@ -10303,12 +13634,12 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 					}
 					b := dAtA[iNdEx]
 					iNdEx++
-					v |= uint64(b&0x7F) << shift
+					v |= int64(b&0x7F) << shift
 					if b < 0x80 {
 						break
 					}
 				}
-				m.ColumnIDs = append(m.ColumnIDs, v)
+				m.Values = append(m.Values, v)
 			} else if wireType == 2 {
 				var packedLen int
 				for shift := uint(0); ; shift += 7 {
@@ -10343,11 +13674,11 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 					}
 				}
 				elementCount = count
-				if elementCount != 0 && len(m.ColumnIDs) == 0 {
-					m.ColumnIDs = make([]uint64, 0, elementCount)
+				if elementCount != 0 && len(m.Values) == 0 {
+					m.Values = make([]int64, 0, elementCount)
 				}
 				for iNdEx < postIndex {
-					var v uint64
+					var v int64
 					for shift := uint(0); ; shift += 7 {
 						if shift >= 64 {
 							return ErrIntOverflowPublic
@@ -10357,19 +13688,227 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						v |= uint64(b&0x7F) << shift
+						v |= int64(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
 					}
-					m.ColumnIDs = append(m.ColumnIDs, v)
+					m.Values = append(m.Values, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnKeys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ColumnKeys = append(m.ColumnKeys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.FloatValues = append(m.FloatValues, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPublic
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 8
+				if elementCount != 0 && len(m.FloatValues) == 0 {
+					m.FloatValues = make([]float64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.FloatValues = append(m.FloatValues, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field FloatValues", wireType)
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StringValues", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StringValues = append(m.StringValues, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexCreatedAt", wireType)
+			}
+			m.IndexCreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IndexCreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FieldCreatedAt", wireType)
+			}
+			m.FieldCreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FieldCreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Clear", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Clear = bool(v != 0)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DecimalValues", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
 			}
-		case 6:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DecimalValues = append(m.DecimalValues, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 14:
 			if wireType == 0 {
-				var v int64
+				var v int
 				for shift := uint(0); ; shift += 7 {
 					if shift >= 64 {
 						return ErrIntOverflowPublic
@@ -10379,12 +13918,12 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 					}
 					b := dAtA[iNdEx]
 					iNdEx++
-					v |= int64(b&0x7F) << shift
+					v |= int(b&0x7F) << shift
 					if b < 0x80 {
 						break
 					}
 				}
-				m.Timestamps = append(m.Timestamps, v)
+				m.Nulls = append(m.Nulls, bool(v != 0))
 			} else if wireType == 2 {
 				var packedLen int
 				for shift := uint(0); ; shift += 7 {
@@ -10412,18 +13951,12 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 					return io.ErrUnexpectedEOF
 				}
 				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Timestamps) == 0 {
-					m.Timestamps = make([]int64, 0, elementCount)
+				elementCount = packedLen
+				if elementCount != 0 && len(m.Nulls) == 0 {
+					m.Nulls = make([]bool, 0, elementCount)
 				}
 				for iNdEx < postIndex {
-					var v int64
+					var v int
 					for shift := uint(0); ; shift += 7 {
 						if shift >= 64 {
 							return ErrIntOverflowPublic
@@ -10433,19 +13966,70 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						v |= int64(b&0x7F) << shift
+						v |= int(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
 					}
-					m.Timestamps = append(m.Timestamps, v)
+					m.Nulls = append(m.Nulls, bool(v != 0))
 				}
 			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamps", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Nulls", wireType)
 			}
-		case 7:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AtomicRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AtomicRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AtomicRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RowKeys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10473,13 +14057,13 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RowKeys = append(m.RowKeys, string(dAtA[iNdEx:postIndex]))
+			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnKeys", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
 			}
-			var stringLen uint64
+			m.Shard = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -10489,29 +14073,16 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Shard |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ColumnKeys = append(m.ColumnKeys, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IndexCreatedAt", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ivr", wireType)
 			}
-			m.IndexCreatedAt = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -10521,16 +14092,31 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.IndexCreatedAt |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FieldCreatedAt", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
 			}
-			m.FieldCreatedAt = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ivr = append(m.Ivr, &ImportValueRequest{})
+			if err := m.Ivr[len(m.Ivr)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ir", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -10540,16 +14126,82 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FieldCreatedAt |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Clear", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
 			}
-			var v int
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ir = append(m.Ir, &ImportRequest{})
+			if err := m.Ir[len(m.Ir)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AtomicImportResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AtomicImportResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AtomicImportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -10559,12 +14211,24 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Clear = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -10587,7 +14251,7 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
+func (m *TranslateKeysRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10610,10 +14274,10 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImportValueRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TranslateKeysRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImportValueRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TranslateKeysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10681,10 +14345,42 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 			m.Field = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keys = append(m.Keys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NotWritable", wireType)
 			}
-			m.Shard = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -10694,12 +14390,64 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Shard |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			m.NotWritable = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TranslateKeysResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TranslateKeysResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TranslateKeysResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 3:
 			if wireType == 0 {
 				var v uint64
 				for shift := uint(0); ; shift += 7 {
@@ -10716,7 +14464,7 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 						break
 					}
 				}
-				m.ColumnIDs = append(m.ColumnIDs, v)
+				m.IDs = append(m.IDs, v)
 			} else if wireType == 2 {
 				var packedLen int
 				for shift := uint(0); ; shift += 7 {
@@ -10751,8 +14499,8 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 					}
 				}
 				elementCount = count
-				if elementCount != 0 && len(m.ColumnIDs) == 0 {
-					m.ColumnIDs = make([]uint64, 0, elementCount)
+				if elementCount != 0 && len(m.IDs) == 0 {
+					m.IDs = make([]uint64, 0, elementCount)
 				}
 				for iNdEx < postIndex {
 					var v uint64
@@ -10770,14 +14518,129 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 							break
 						}
 					}
-					m.ColumnIDs = append(m.ColumnIDs, v)
+					m.IDs = append(m.IDs, v)
 				}
 			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
-		case 6:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TranslateIDsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TranslateIDsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TranslateIDsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType == 0 {
-				var v int64
+				var v uint64
 				for shift := uint(0); ; shift += 7 {
 					if shift >= 64 {
 						return ErrIntOverflowPublic
@@ -10787,12 +14650,12 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 					}
 					b := dAtA[iNdEx]
 					iNdEx++
-					v |= int64(b&0x7F) << shift
+					v |= uint64(b&0x7F) << shift
 					if b < 0x80 {
 						break
 					}
 				}
-				m.Values = append(m.Values, v)
+				m.IDs = append(m.IDs, v)
 			} else if wireType == 2 {
 				var packedLen int
 				for shift := uint(0); ; shift += 7 {
@@ -10827,11 +14690,11 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 					}
 				}
 				elementCount = count
-				if elementCount != 0 && len(m.Values) == 0 {
-					m.Values = make([]int64, 0, elementCount)
+				if elementCount != 0 && len(m.IDs) == 0 {
+					m.IDs = make([]uint64, 0, elementCount)
 				}
 				for iNdEx < postIndex {
-					var v int64
+					var v uint64
 					for shift := uint(0); ; shift += 7 {
 						if shift >= 64 {
 							return ErrIntOverflowPublic
@@ -10841,19 +14704,70 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						v |= int64(b&0x7F) << shift
+						v |= uint64(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
 					}
-					m.Values = append(m.Values, v)
+					m.IDs = append(m.IDs, v)
 				}
 			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TranslateIDsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
 			}
-		case 7:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TranslateIDsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TranslateIDsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnKeys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10881,65 +14795,62 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ColumnKeys = append(m.ColumnKeys, string(dAtA[iNdEx:postIndex]))
+			m.Keys = append(m.Keys, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 8:
-			if wireType == 1 {
-				var v uint64
-				if (iNdEx + 8) > l {
-					return io.ErrUnexpectedEOF
-				}
-				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-				iNdEx += 8
-				v2 := float64(math.Float64frombits(v))
-				m.FloatValues = append(m.FloatValues, v2)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPublic
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPublic
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPublic
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				elementCount = packedLen / 8
-				if elementCount != 0 && len(m.FloatValues) == 0 {
-					m.FloatValues = make([]float64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					if (iNdEx + 8) > l {
-						return io.ErrUnexpectedEOF
-					}
-					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-					iNdEx += 8
-					v2 := float64(math.Float64frombits(v))
-					m.FloatValues = append(m.FloatValues, v2)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field FloatValues", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-		case 9:
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportRoaringRequestView) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportRoaringRequestView: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportRoaringRequestView: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StringValues", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10967,13 +14878,13 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.StringValues = append(m.StringValues, string(dAtA[iNdEx:postIndex]))
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IndexCreatedAt", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			m.IndexCreatedAt = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -10983,50 +14894,26 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.IndexCreatedAt |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FieldCreatedAt", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthPublic
 			}
-			m.FieldCreatedAt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.FieldCreatedAt |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
 			}
-		case 12:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Clear", wireType)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
-			m.Clear = bool(v != 0)
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -11049,7 +14936,7 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AtomicRecord) Unmarshal(dAtA []byte) error {
+func (m *ImportRoaringRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11072,15 +14959,69 @@ func (m *AtomicRecord) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AtomicRecord: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImportRoaringRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AtomicRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportRoaringRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Clear", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Clear = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Views", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Views = append(m.Views, &ImportRoaringRequestView{})
+			if err := m.Views[len(m.Views)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11108,13 +15049,13 @@ func (m *AtomicRecord) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Index = string(dAtA[iNdEx:postIndex])
+			m.Action = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
 			}
-			m.Shard = 0
+			m.Block = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -11124,16 +15065,16 @@ func (m *AtomicRecord) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Shard |= uint64(b&0x7F) << shift
+				m.Block |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ivr", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexCreatedAt", wireType)
 			}
-			var msglen int
+			m.IndexCreatedAt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -11143,31 +15084,16 @@ func (m *AtomicRecord) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.IndexCreatedAt |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Ivr = append(m.Ivr, &ImportValueRequest{})
-			if err := m.Ivr[len(m.Ivr)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ir", wireType)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FieldCreatedAt", wireType)
 			}
-			var msglen int
+			m.FieldCreatedAt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -11177,26 +15103,31 @@ func (m *AtomicRecord) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.FieldCreatedAt |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateExistence", wireType)
 			}
-			m.Ir = append(m.Ir, &ImportRequest{})
-			if err := m.Ir[len(m.Ir)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.UpdateExistence = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -11219,7 +15150,7 @@ func (m *AtomicRecord) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AtomicImportResponse) Unmarshal(dAtA []byte) error {
+func (m *RoaringUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11242,15 +15173,15 @@ func (m *AtomicImportResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AtomicImportResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: RoaringUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AtomicImportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RoaringUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11278,62 +15209,11 @@ func (m *AtomicImportResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Error = string(dAtA[iNdEx:postIndex])
+			m.Field = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPublic(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *TranslateKeysRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPublic
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: TranslateKeysRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TranslateKeysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11361,13 +15241,13 @@ func (m *TranslateKeysRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Index = string(dAtA[iNdEx:postIndex])
+			m.View = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Clear", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -11377,29 +15257,31 @@ func (m *TranslateKeysRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPublic
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Field = string(dAtA[iNdEx:postIndex])
+			m.Clear = append(m.Clear[:0], dAtA[iNdEx:postIndex]...)
+			if m.Clear == nil {
+				m.Clear = []byte{}
+			}
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Set", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -11409,27 +15291,29 @@ func (m *TranslateKeysRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPublic
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Keys = append(m.Keys, string(dAtA[iNdEx:postIndex]))
+			m.Set = append(m.Set[:0], dAtA[iNdEx:postIndex]...)
+			if m.Set == nil {
+				m.Set = []byte{}
+			}
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotWritable", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClearRecords", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -11446,7 +15330,7 @@ func (m *TranslateKeysRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.NotWritable = bool(v != 0)
+			m.ClearRecords = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -11469,7 +15353,7 @@ func (m *TranslateKeysRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TranslateKeysResponse) Unmarshal(dAtA []byte) error {
+func (m *ImportRoaringShardRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11492,88 +15376,66 @@ func (m *TranslateKeysResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TranslateKeysResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImportRoaringShardRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TranslateKeysResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportRoaringShardRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 3:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPublic
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.IDs = append(m.IDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPublic
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPublic
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPublic
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remote", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Remote = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Views", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
 				}
-				elementCount = count
-				if elementCount != 0 && len(m.IDs) == 0 {
-					m.IDs = make([]uint64, 0, elementCount)
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPublic
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.IDs = append(m.IDs, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Views = append(m.Views, &RoaringUpdate{})
+			if err := m.Views[len(m.Views)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -11596,7 +15458,7 @@ func (m *TranslateKeysResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TranslateIDsRequest) Unmarshal(dAtA []byte) error {
+func (m *GroupCounts) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11619,15 +15481,15 @@ func (m *TranslateIDsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TranslateIDsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GroupCounts: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TranslateIDsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GroupCounts: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Aggregate", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11655,13 +15517,13 @@ func (m *TranslateIDsRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Index = string(dAtA[iNdEx:postIndex])
+			m.Aggregate = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -11671,100 +15533,26 @@ func (m *TranslateIDsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPublic
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Field = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPublic
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.IDs = append(m.IDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPublic
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPublic
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPublic
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.IDs) == 0 {
-					m.IDs = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPublic
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.IDs = append(m.IDs, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
+			m.Groups = append(m.Groups, &GroupCount{})
+			if err := m.Groups[len(m.Groups)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -11787,7 +15575,7 @@ func (m *TranslateIDsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TranslateIDsResponse) Unmarshal(dAtA []byte) error {
+func (m *SortValue) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11810,15 +15598,15 @@ func (m *TranslateIDsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TranslateIDsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SortValue: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TranslateIDsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SortValue: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 3:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field String_", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11830,23 +15618,119 @@ func (m *TranslateIDsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = &SortValue_String_{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bool", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Value = &SortValue_Bool{b}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Int64", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &SortValue_Int64{v}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uint64", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &SortValue_Uint64{v}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPublic
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Keys = append(m.Keys, string(dAtA[iNdEx:postIndex]))
+			v := &SortValues{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &SortValue_Values{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11870,7 +15754,7 @@ func (m *TranslateIDsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImportRoaringRequestView) Unmarshal(dAtA []byte) error {
+func (m *SortValues) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11893,49 +15777,17 @@ func (m *ImportRoaringRequestView) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImportRoaringRequestView: wiretype end group for non-group")
+			return fmt.Errorf("proto: SortValues: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImportRoaringRequestView: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SortValues: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -11945,24 +15797,24 @@ func (m *ImportRoaringRequestView) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPublic
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Values = append(m.Values, &SortValue{})
+			if err := m.Values[len(m.Values)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -11987,7 +15839,7 @@ func (m *ImportRoaringRequestView) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImportRoaringRequest) Unmarshal(dAtA []byte) error {
+func (m *SortedColumn) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12010,17 +15862,17 @@ func (m *ImportRoaringRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImportRoaringRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SortedColumn: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImportRoaringRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SortedColumn: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Clear", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var v int
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -12030,15 +15882,14 @@ func (m *ImportRoaringRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Clear = bool(v != 0)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Views", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12065,120 +15916,13 @@ func (m *ImportRoaringRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Views = append(m.Views, &ImportRoaringRequestView{})
-			if err := m.Views[len(m.Views)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPublic
+			if m.Value == nil {
+				m.Value = &SortValue{}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Action = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
-			}
-			m.Block = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Block |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IndexCreatedAt", wireType)
-			}
-			m.IndexCreatedAt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.IndexCreatedAt |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FieldCreatedAt", wireType)
-			}
-			m.FieldCreatedAt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.FieldCreatedAt |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdateExistence", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.UpdateExistence = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -12201,7 +15945,7 @@ func (m *ImportRoaringRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RoaringUpdate) Unmarshal(dAtA []byte) error {
+func (m *SortedRow) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12224,81 +15968,17 @@ func (m *RoaringUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RoaringUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: SortedRow: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RoaringUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SortedRow: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Field = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.View = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Clear", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -12308,31 +15988,33 @@ func (m *RoaringUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPublic
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Clear = append(m.Clear[:0], dAtA[iNdEx:postIndex]...)
-			if m.Clear == nil {
-				m.Clear = []byte{}
+			if m.Row == nil {
+				m.Row = &Row{}
+			}
+			if err := m.Row.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Set", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Columns", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -12342,46 +16024,26 @@ func (m *RoaringUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPublic
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Set = append(m.Set[:0], dAtA[iNdEx:postIndex]...)
-			if m.Set == nil {
-				m.Set = []byte{}
+			m.Columns = append(m.Columns, &SortedColumn{})
+			if err := m.Columns[len(m.Columns)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClearRecords", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.ClearRecords = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -12404,7 +16066,7 @@ func (m *RoaringUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImportRoaringShardRequest) Unmarshal(dAtA []byte) error {
+func (m *ExtractedIDMatrixSorted) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12427,17 +16089,17 @@ func (m *ImportRoaringShardRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImportRoaringShardRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExtractedIDMatrixSorted: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImportRoaringShardRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExtractedIDMatrixSorted: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Remote", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtractedIDMatrix", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -12447,15 +16109,31 @@ func (m *ImportRoaringShardRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Remote = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExtractedIDMatrix == nil {
+				m.ExtractedIDMatrix = &ExtractedIDMatrix{}
+			}
+			if err := m.ExtractedIDMatrix.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Views", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Columns", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12482,8 +16160,8 @@ func (m *ImportRoaringShardRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Views = append(m.Views, &RoaringUpdate{})
-			if err := m.Views[len(m.Views)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Columns = append(m.Columns, &SortedColumn{})
+			if err := m.Columns[len(m.Columns)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -12509,7 +16187,7 @@ func (m *ImportRoaringShardRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GroupCounts) Unmarshal(dAtA []byte) error {
+func (m *Histogram) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12532,15 +16210,15 @@ func (m *GroupCounts) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GroupCounts: wiretype end group for non-group")
+			return fmt.Errorf("proto: Histogram: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GroupCounts: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Histogram: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Aggregate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12568,42 +16246,160 @@ func (m *GroupCounts) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Aggregate = string(dAtA[iNdEx:postIndex])
+			m.Field = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.Edges = append(m.Edges, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPublic
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
 				}
+				elementCount = count
+				if elementCount != 0 && len(m.Edges) == 0 {
+					m.Edges = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Edges = append(m.Edges, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Edges", wireType)
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Groups = append(m.Groups, &GroupCount{})
-			if err := m.Groups[len(m.Groups)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Counts = append(m.Counts, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPublic
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Counts) == 0 {
+					m.Counts = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Counts = append(m.Counts, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counts", wireType)
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -3,6 +3,7 @@
 package pilosa
 
 import (
+	"fmt"
 	"regexp"
 	"time"
 
@@ -18,6 +19,10 @@ var (
 	ErrIndexRequired = errors.New("index required")
 	ErrIndexExists   = disco.ErrIndexExists
 	ErrIndexNotFound = errors.New("index not found")
+	ErrIndexAliased  = errors.New("index is the target of an alias")
+
+	ErrAliasExists   = errors.New("alias already exists")
+	ErrAliasNotFound = errors.New("alias not found")
 
 	ErrInvalidAddress = errors.New("invalid address")
 	ErrInvalidSchema  = errors.New("invalid schema")
@@ -51,11 +56,59 @@ var (
 
 	// ErrFragmentNotFound is returned when a fragment does not exist.
 	ErrFragmentNotFound = errors.New("fragment not found")
+
+	// ErrFragmentUnopened is returned when a query reads a shard with a
+	// fragment which couldn't be opened.
+	ErrFragmentUnopened = errors.New("fragment couldn't be opened")
 	ErrQueryRequired    = errors.New("query required")
 	ErrQueryCancelled   = errors.New("query cancelled")
 	ErrQueryTimeout     = errors.New("query timeout")
 	ErrTooManyWrites    = errors.New("too many write commands")
 
+	// ErrResultLimitExceeded is returned when a query result exceeds one of
+	// the result limits configured on the index.
+	ErrResultLimitExceeded = errors.New("result limit exceeded")
+
+	// ErrNamespaceQuotaExceeded is returned when a request would take the
+	// namespace of an index past one of its quotas.
+	ErrNamespaceQuotaExceeded = errors.New("namespace quota exceeded")
+
+	// ErrStorageQuotaExceeded is returned when a write would take an index,
+	// or the node, past its storage quota.
+	ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+	// ErrReadOnly is returned by writes while the node is read-only because
+	// its disk is low on space.
+	ErrReadOnly = errors.New("node is read-only")
+
+	// ErrIncompatibleProtocol is returned by requests between nodes which
+	// speak no protocol version in common.
+	ErrIncompatibleProtocol = errors.New("incompatible protocol version")
+
+	// ErrIndexReadOnly is returned by writes to an index which has been made
+	// read-only.
+	ErrIndexReadOnly = errors.New("index is read-only")
+
+	// ErrMaintenance is returned by writes while the node is in maintenance
+	// mode.
+	ErrMaintenance = errors.New("node is in maintenance mode")
+
+	// ErrQueryDenied is returned for queries containing a call which a
+	// query rule denies.
+	ErrQueryDenied = errors.New("query denied")
+
+	// ErrUnknownKeys is returned for writes which would create new keys,
+	// by queries or into fields which reject them.
+	ErrUnknownKeys = errors.New("unknown keys")
+
+	// ErrSumOverflow is returned for sums which don't fit in the precision
+	// they're computed to.
+	ErrSumOverflow = errors.New("sum overflows")
+
+	// ErrSignedInput is returned when negative values, such as those
+	// Distinct() finds in an int field, are used as columns.
+	ErrSignedInput = errors.New("negative values can't be used as columns")
+
 	// TODO(2.0) poorly named - used when a *node* doesn't own a shard. Probably
 	// we won't need this error at all by 2.0 though.
 	ErrClusterDoesNotOwnShard = errors.New("node does not own shard")
@@ -134,6 +187,58 @@ func newPreconditionFailedError(err error) PreconditionFailedError {
 	return PreconditionFailedError{err}
 }
 
+// ResultLimitError is returned when a call would produce more results than
+// the index allows. Its cause is ErrResultLimitExceeded.
+type ResultLimitError struct {
+	Index string
+	Call  string
+	Kind  string // "columns", "groups", or "rows"
+	Limit uint64
+}
+
+// newResultLimitError returns a ResultLimitError for the given call.
+func newResultLimitError(index, call, kind string, limit uint64) ResultLimitError {
+	return ResultLimitError{Index: index, Call: call, Kind: kind, Limit: limit}
+}
+
+func (e ResultLimitError) Error() string {
+	return fmt.Sprintf("%s: %s() on index %q exceeds limit of %d %s", ErrResultLimitExceeded, e.Call, e.Index, e.Limit, e.Kind)
+}
+
+// Cause allows errors.Cause to return ErrResultLimitExceeded.
+func (e ResultLimitError) Cause() error {
+	return ErrResultLimitExceeded
+}
+
+// Unwrap makes errors.Is(err, ErrResultLimitExceeded) true.
+func (e ResultLimitError) Unwrap() error {
+	return ErrResultLimitExceeded
+}
+
+// SignedInputError is returned when the result of a call with negative
+// values is used as a set of columns, which can only be non-negative, as by
+// Intersect(), Union(), or the filter of GroupBy(). Its cause is
+// ErrSignedInput.
+type SignedInputError struct {
+	Call      string
+	Field     string
+	Negatives uint64
+}
+
+func (e SignedInputError) Error() string {
+	return fmt.Sprintf("%s: %s(field=%s) has %d negative values; filter them out first, as in %[2]s(Row(%[3]s >= 0), field=%[3]s)", ErrSignedInput, e.Call, e.Field, e.Negatives)
+}
+
+// Cause allows errors.Cause to return ErrSignedInput.
+func (e SignedInputError) Cause() error {
+	return ErrSignedInput
+}
+
+// Unwrap makes errors.Is(err, ErrSignedInput) true.
+func (e SignedInputError) Unwrap() error {
+	return ErrSignedInput
+}
+
 // Regular expression to validate index and field names.
 var nameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,229}$`)
 
@@ -0,0 +1,219 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+// The planner estimates how many columns each input of an Intersect
+// returns in a shard, from the shard's metadata (see shardStats), and
+// computes the inputs from the smallest estimate up, so that each
+// intersection, and each comparison of a BSI field limited to the columns
+// left, does as little work as it can. Estimates assume values of BSI
+// fields are spread evenly between their minimum and maximum in the shard;
+// calls the planner doesn't understand are estimated to match every column,
+// which leaves them in the order they were given. When the query is
+// profiled, the Intersect's profile lists each input's estimate alongside
+// the number of columns it actually produced.
+
+// intersectStep is an input of an Intersect, as planned for a shard.
+type intersectStep struct {
+	Call      string `json:"call"`
+	Estimated uint64 `json:"estimated"`
+	Actual    uint64 `json:"actual"`
+
+	call *pql.Call
+}
+
+// planIntersect returns the inputs of an Intersect in the order they should
+// be computed in shard.
+func (e *executor) planIntersect(ctx context.Context, idx *Index, inputs []*pql.Call, shard uint64) ([]*intersectStep, error) {
+	steps := make([]*intersectStep, len(inputs))
+	for i, input := range inputs {
+		est, err := e.estimateShardCount(ctx, idx, input, shard)
+		if err != nil {
+			return nil, err
+		}
+		steps[i] = &intersectStep{Call: input.String(), Estimated: est, call: input}
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].Estimated < steps[j].Estimated })
+	return steps, nil
+}
+
+// estimateShardCount estimates the number of columns the bitmap call c
+// returns in shard. It errs on the side of more, returning ShardWidth for
+// calls it can't estimate.
+func (e *executor) estimateShardCount(ctx context.Context, idx *Index, c *pql.Call, shard uint64) (uint64, error) {
+	switch c.Name {
+	case "Row", "Range":
+		return e.estimateRowCount(ctx, idx, c, shard)
+	case "Intersect":
+		est := uint64(ShardWidth)
+		for _, child := range c.Children {
+			n, err := e.estimateShardCount(ctx, idx, child, shard)
+			if err != nil {
+				return 0, err
+			}
+			if n < est {
+				est = n
+			}
+		}
+		return est, nil
+	case "Union":
+		var est uint64
+		for _, child := range c.Children {
+			n, err := e.estimateShardCount(ctx, idx, child, shard)
+			if err != nil {
+				return 0, err
+			}
+			if est += n; est >= ShardWidth {
+				return ShardWidth, nil
+			}
+		}
+		return est, nil
+	case "Difference":
+		if len(c.Children) > 0 {
+			return e.estimateShardCount(ctx, idx, c.Children[0], shard)
+		}
+	}
+	return ShardWidth, nil
+}
+
+// estimateRowCount estimates the number of columns a Row call returns in
+// shard.
+func (e *executor) estimateRowCount(ctx context.Context, idx *Index, c *pql.Call, shard uint64) (uint64, error) {
+	if c.HasConditionArg() {
+		if len(c.Args) != 1 {
+			return ShardWidth, nil
+		}
+		for fieldName, v := range c.Args {
+			cond, ok := v.(*pql.Condition)
+			if !ok {
+				return ShardWidth, nil
+			}
+			f := idx.Field(fieldName)
+			if f == nil {
+				return ShardWidth, nil
+			}
+			fs, err := idx.fieldShardStats(ctx, f, shard)
+			if err != nil || fs == nil || fs.rows != nil {
+				return ShardWidth, err
+			}
+			return bsiEstimate(f, cond, fs), nil
+		}
+	}
+
+	if _, ok := c.Args["from"]; ok {
+		return ShardWidth, nil
+	} else if _, ok := c.Args["to"]; ok {
+		return ShardWidth, nil
+	}
+	fieldName, err := c.FieldArg()
+	if err != nil {
+		return ShardWidth, nil
+	}
+	f := idx.Field(fieldName)
+	if f == nil {
+		return ShardWidth, nil
+	}
+	rowID, ok, err := c.UintArg(fieldName)
+	if err != nil || !ok {
+		return ShardWidth, nil
+	}
+	fs, err := idx.fieldShardStats(ctx, f, shard)
+	if err != nil || fs == nil || fs.rows == nil {
+		return ShardWidth, err
+	}
+	if fs.rowCounts != nil {
+		return fs.rowCounts[rowID], nil
+	} else if !fs.rows.mayContain(rowID) {
+		return 0, nil
+	}
+	return ShardWidth, nil
+}
+
+// bsiEstimate estimates the number of columns whose values of a BSI field
+// satisfy cond, given the field's metadata for a shard.
+func bsiEstimate(f *Field, cond *pql.Condition, fs *fieldShardStats) uint64 {
+	bsig := f.bsiGroup(f.Name())
+	if bsig == nil {
+		return ShardWidth
+	}
+	if cond.Value == nil {
+		if cond.Op == pql.NEQ {
+			return fs.count
+		}
+		// "== null" depends on existence, rather than on the field.
+		return ShardWidth
+	}
+	if !bsiMayMatch(f, cond, fs) {
+		return 0
+	}
+	min, max := fs.min+bsig.Base, fs.max+bsig.Base
+
+	// share returns the estimate for the values in [lo, hi].
+	share := func(lo, hi int64) uint64 {
+		if lo < min {
+			lo = min
+		}
+		if hi > max {
+			hi = max
+		}
+		if lo > hi {
+			return 0
+		}
+		n := float64(fs.count) * (float64(hi-lo) + 1) / (float64(max-min) + 1)
+		if n < 1 {
+			return 1
+		}
+		return uint64(n)
+	}
+
+	switch cond.Op {
+	case pql.BETWEEN, pql.BTWN_LT_LT, pql.BTWN_LTE_LT, pql.BTWN_LT_LTE:
+		predicates, err := getCondIntSlice(f, cond)
+		if err != nil || len(predicates) != 2 {
+			return fs.count
+		}
+		return share(predicates[0], predicates[1])
+	case pql.IN:
+		values, ok := cond.Value.([]interface{})
+		if !ok {
+			return fs.count
+		}
+		var est uint64
+		for _, v := range values {
+			value, err := getScaledInt(f, v)
+			if err != nil {
+				return fs.count
+			}
+			est += share(value, value)
+		}
+		if est > fs.count {
+			return fs.count
+		}
+		return est
+	}
+
+	value, err := getScaledInt(f, cond.Value)
+	if err != nil {
+		return fs.count
+	}
+	switch cond.Op {
+	case pql.EQ:
+		return share(value, value)
+	case pql.LT:
+		return share(min, value-1)
+	case pql.LTE:
+		return share(min, value)
+	case pql.GT:
+		return share(value+1, max)
+	case pql.GTE:
+		return share(value, max)
+	}
+	return fs.count
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+func TestExecutor_EstimateShardCount(t *testing.T) {
+	holder := newTestHolder(t)
+	idx, err := holder.CreateIndex("i", IndexOptions{TrackExistence: true})
+	if err != nil {
+		t.Fatalf("creating index: %v", err)
+	}
+	f, err := idx.CreateField("f")
+	if err != nil {
+		t.Fatalf("creating field: %v", err)
+	}
+	v, err := idx.CreateField("v", OptFieldTypeInt(0, 1000))
+	if err != nil {
+		t.Fatalf("creating field: %v", err)
+	}
+
+	// Row 1 has 100 columns, row 2 has 10; v has the values 0-99.
+	qcx := holder.Txf().NewWritableQcx()
+	for col := uint64(0); col < 100; col++ {
+		if _, err := f.SetBit(qcx, 1, col, nil); err != nil {
+			t.Fatalf("setting bit: %v", err)
+		}
+		if col%10 == 0 {
+			if _, err := f.SetBit(qcx, 2, col, nil); err != nil {
+				t.Fatalf("setting bit: %v", err)
+			}
+		}
+		if _, err := v.SetValue(qcx, col, int64(col)); err != nil {
+			t.Fatalf("setting value: %v", err)
+		}
+	}
+	if err := qcx.Finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	e := &executor{Holder: holder}
+	parse := func(query string) *pql.Call {
+		t.Helper()
+		q, err := pql.NewParser(strings.NewReader(query)).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return q.Calls[0]
+	}
+	for query, exp := range map[string]uint64{
+		`Row(f=1)`:                       100,
+		`Row(f=2)`:                       10,
+		`Row(f=3)`:                       0,
+		`Row(v == 5)`:                    1,
+		`Row(v < 50)`:                    50,
+		`Row(v >= 90)`:                   10,
+		`Row(v > 500)`:                   0,
+		`Row(10 <= v <= 29)`:             20,
+		`Row(v != null)`:                 100,
+		`Row(v == null)`:                 ShardWidth,
+		`Intersect(Row(f=1), Row(f=2))`:  10,
+		`Union(Row(f=1), Row(f=2))`:      110,
+		`Difference(Row(f=2), Row(f=1))`: 10,
+		`All()`:                          ShardWidth,
+	} {
+		if got, err := e.estimateShardCount(context.Background(), idx, parse(query), 0); err != nil {
+			t.Fatal(err)
+		} else if got != exp {
+			t.Errorf("%s: expected %d, got %d", query, exp, got)
+		}
+	}
+
+	steps, err := e.planIntersect(context.Background(), idx, parse(`Intersect(All(), Row(f=1), Row(v < 50), Row(f=2))`).Children, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, step := range steps {
+		order = append(order, step.Call)
+	}
+	if exp := []string{"Row(f=2)", "Row(v<50)", "Row(f=1)", "All()"}; !reflect.DeepEqual(order, exp) {
+		t.Fatalf("expected order %v, got %v", exp, order)
+	}
+}
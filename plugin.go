@@ -0,0 +1,423 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/featurebasedb/featurebase/v3/logger"
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// Plugins are WebAssembly modules implementing user-defined functions,
+// which run against each shard, on the node holding it, like the built in
+// calls. Every .wasm file in the plugins directory is loaded when the
+// server starts, as a function named after the file; each node needs the
+// same plugins. A function is called from PQL as
+//
+//	UDF(Row(f=1), Row(g=2), name="score", values="v")
+//
+// where the calls are the function's inputs, and values optionally names
+// an int, decimal or timestamp field whose values are passed too.
+//
+// A module exports its memory as "memory", a function
+// "alloc(size i32) i32" returning the address of size bytes of that
+// memory, and either "row(ptr, len i32) i64", for functions returning a
+// set of columns, or "scalar(ptr, len i32) i64", for functions returning
+// a number. For each shard, the module is instantiated, its input is
+// copied to memory from alloc, and row or scalar is called with the
+// input's address and length. All integers are little-endian; the input
+// is:
+//
+//	u64 shard
+//	u32 number of inputs, then for each input:
+//	    u32 number of columns, then each column as a u32
+//	u32 number of values, then for each value:
+//	    u32 column, i64 value
+//
+// Columns are offsets within the shard, in ascending order. Values are the
+// integers stored by the values field, so decimals are scaled by the
+// field's scale and timestamps are in the field's time unit. If there are
+// inputs, values are only passed for the columns of the first.
+//
+// row returns the address of its result in its upper 32 bits and the
+// number of columns in its lower 32; the result is that many u32 column
+// offsets. A function returning a row can be used anywhere a row can.
+// scalar returns a number, which is added up over all shards into the
+// value of the result; the count of the result is the number of columns
+// the function was given: those of its first input, or those with values
+// if there are no inputs.
+
+// pluginExt is the file extension of plugins.
+const pluginExt = ".wasm"
+
+// pluginMemoryLimitPages limits the memory of each instance of a plugin to
+// 256MiB (in 64KiB pages).
+const pluginMemoryLimitPages = 4096
+
+// Kinds of plugins, by their result.
+const (
+	pluginKindRow    = "row"
+	pluginKindScalar = "scalar"
+)
+
+// plugin is a compiled user-defined function.
+type plugin struct {
+	name     string
+	kind     string
+	compiled wazero.CompiledModule
+}
+
+// pluginRegistry holds the plugins loaded from a directory.
+type pluginRegistry struct {
+	runtime wazero.Runtime
+	plugins map[string]*plugin
+}
+
+// loadPlugins compiles the plugins in dir. An empty dir means there are no
+// plugins.
+func loadPlugins(dir string, log logger.Logger) (*pluginRegistry, error) {
+	r := &pluginRegistry{plugins: make(map[string]*plugin)}
+	if dir == "" {
+		return r, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading plugins directory")
+	}
+
+	ctx := context.Background()
+	r.runtime = wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(pluginMemoryLimitPages))
+	// Modules built for WASI are common, so its imports are provided, but
+	// without access to the file system, clock or environment.
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, r.runtime); err != nil {
+		r.close()
+		return nil, errors.Wrap(err, "instantiating WASI")
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != pluginExt {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), pluginExt)
+		p, err := r.compile(ctx, name, filepath.Join(dir, entry.Name()))
+		if err != nil {
+			r.close()
+			return nil, errors.Wrapf(err, "loading plugin %q", name)
+		}
+		r.plugins[name] = p
+		log.Infof("loaded %s plugin %q", p.kind, name)
+	}
+	return r, nil
+}
+
+// compile compiles the plugin in path, checking it has the exports it
+// needs.
+func (r *pluginRegistry) compile(ctx context.Context, name, path string) (*plugin, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading plugin")
+	}
+	compiled, err := r.runtime.CompileModule(ctx, buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "compiling plugin")
+	}
+	p := &plugin{name: name, compiled: compiled}
+
+	if _, ok := compiled.ExportedMemories()["memory"]; !ok {
+		return nil, errors.New("plugin doesn't export memory")
+	}
+	funcs := compiled.ExportedFunctions()
+	if !hasSignature(funcs["alloc"], []api.ValueType{api.ValueTypeI32}, []api.ValueType{api.ValueTypeI32}) {
+		return nil, errors.New("plugin doesn't export alloc(i32) i32")
+	}
+	for _, kind := range []string{pluginKindRow, pluginKindScalar} {
+		if !hasSignature(funcs[kind], []api.ValueType{api.ValueTypeI32, api.ValueTypeI32}, []api.ValueType{api.ValueTypeI64}) {
+			continue
+		} else if p.kind != "" {
+			return nil, errors.New("plugin exports both row and scalar")
+		}
+		p.kind = kind
+	}
+	if p.kind == "" {
+		return nil, errors.New("plugin doesn't export row(i32, i32) i64 or scalar(i32, i32) i64")
+	}
+	return p, nil
+}
+
+// hasSignature reports whether the function fn exists and has the given
+// parameters and results.
+func hasSignature(fn api.FunctionDefinition, params, results []api.ValueType) bool {
+	if fn == nil {
+		return false
+	}
+	return string(fn.ParamTypes()) == string(params) && string(fn.ResultTypes()) == string(results)
+}
+
+// plugin returns the plugin named name.
+func (r *pluginRegistry) plugin(name string) (*plugin, error) {
+	if r != nil {
+		if p, ok := r.plugins[name]; ok {
+			return p, nil
+		}
+	}
+	return nil, errors.Errorf("plugin %q not found", name)
+}
+
+// close releases the plugins.
+func (r *pluginRegistry) close() error {
+	if r == nil || r.runtime == nil {
+		return nil
+	}
+	return r.runtime.Close(context.Background())
+}
+
+// call runs the plugin with the input in, returning the result of its row
+// or scalar function.
+func (p *plugin) call(ctx context.Context, runtime wazero.Runtime, in []byte) (_ uint64, _ api.Module, err error) {
+	mod, err := runtime.InstantiateModule(ctx, p.compiled, wazero.NewModuleConfig().WithName(""))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "instantiating plugin")
+	}
+	defer func() {
+		if err != nil {
+			mod.Close(ctx)
+		}
+	}()
+
+	res, err := mod.ExportedFunction("alloc").Call(ctx, uint64(len(in)))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "allocating plugin input")
+	}
+	ptr := uint32(res[0])
+	if !mod.Memory().Write(ptr, in) {
+		return 0, nil, errors.Errorf("plugin input at %d, length %d, is out of range", ptr, len(in))
+	}
+	res, err = mod.ExportedFunction(p.kind).Call(ctx, uint64(ptr), uint64(len(in)))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "calling plugin")
+	}
+	return res[0], mod, nil
+}
+
+// udfInput encodes the input of a plugin for shard.
+func udfInput(shard uint64, inputs []*Row, values map[uint64]int64) []byte {
+	n := 16 + 12*len(values)
+	cols := make([][]uint64, len(inputs))
+	for i, row := range inputs {
+		cols[i] = row.Columns()
+		n += 4 + 4*len(cols[i])
+	}
+	base := shard * ShardWidth
+
+	buf := make([]byte, 0, n)
+	buf = binary.LittleEndian.AppendUint64(buf, shard)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(inputs)))
+	for _, c := range cols {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(c)))
+		for _, col := range c {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(col-base))
+		}
+	}
+
+	valueCols := make([]uint64, 0, len(values))
+	for col := range values {
+		valueCols = append(valueCols, col)
+	}
+	sort.Slice(valueCols, func(i, j int) bool { return valueCols[i] < valueCols[j] })
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(valueCols)))
+	for _, col := range valueCols {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(col-base))
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(values[col]))
+	}
+	return buf
+}
+
+// udfPlugin returns the plugin a UDF call calls.
+func (e *executor) udfPlugin(c *pql.Call) (*plugin, error) {
+	name, ok, err := c.StringArg("name")
+	if err != nil {
+		return nil, errors.Wrap(err, "UDF(): name")
+	} else if !ok {
+		return nil, errors.New("UDF(): name required")
+	}
+	return e.plugins.plugin(name)
+}
+
+// executeUDF executes a UDF call whose plugin returns a scalar.
+func (e *executor) executeUDF(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shards []uint64, opt *ExecOptions) (interface{}, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeUDF")
+	defer span.Finish()
+
+	p, err := e.udfPlugin(c)
+	if err != nil {
+		return nil, err
+	}
+	if p.kind == pluginKindRow {
+		return e.executeBitmapCall(ctx, qcx, index, c, shards, opt)
+	}
+
+	mapFn := func(ctx context.Context, shard uint64, mopt *mapOptions) (_ interface{}, err error) {
+		return e.executeUDFScalarShard(ctx, qcx, index, c, p, shard)
+	}
+	reduceFn := func(ctx context.Context, prev, v interface{}) interface{} {
+		other, _ := prev.(ValCount)
+		return other.add(v.(ValCount))
+	}
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	other, _ := result.(ValCount)
+	return other, nil
+}
+
+// executeUDFShard executes a UDF call whose plugin returns a row for a
+// shard.
+func (e *executor) executeUDFShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (*Row, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeUDFShard")
+	defer span.Finish()
+
+	p, err := e.udfPlugin(c)
+	if err != nil {
+		return nil, err
+	} else if p.kind != pluginKindRow {
+		return nil, errors.Errorf("plugin %q doesn't return a row", p.name)
+	}
+	in, _, err := e.udfShardInput(ctx, qcx, index, c, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	res, mod, err := p.call(ctx, e.plugins.runtime, in)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %q", p.name)
+	}
+	defer mod.Close(ctx)
+
+	ptr, n := uint32(res>>32), uint32(res)
+	out, ok := mod.Memory().Read(ptr, n*4)
+	if !ok {
+		return nil, errors.Errorf("plugin %q: result at %d, length %d, is out of range", p.name, ptr, n)
+	}
+	cols := make([]uint64, n)
+	for i := range cols {
+		col := binary.LittleEndian.Uint32(out[i*4:])
+		if col >= ShardWidth {
+			return nil, errors.Errorf("plugin %q: column %d is outside the shard", p.name, col)
+		}
+		cols[i] = shard*ShardWidth + uint64(col)
+	}
+	sort.Slice(cols, func(i, j int) bool { return cols[i] < cols[j] })
+	return NewRow(cols...), nil
+}
+
+// executeUDFScalarShard executes a UDF call whose plugin returns a scalar
+// for a shard.
+func (e *executor) executeUDFScalarShard(ctx context.Context, qcx *Qcx, index string, c *pql.Call, p *plugin, shard uint64) (ValCount, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.executeUDFScalarShard")
+	defer span.Finish()
+
+	in, count, err := e.udfShardInput(ctx, qcx, index, c, shard)
+	if err != nil {
+		return ValCount{}, err
+	}
+	res, mod, err := p.call(ctx, e.plugins.runtime, in)
+	if err != nil {
+		return ValCount{}, errors.Wrapf(err, "plugin %q", p.name)
+	}
+	mod.Close(ctx)
+	return ValCount{Val: int64(res), Count: int64(count)}, nil
+}
+
+// udfShardInput computes the inputs of a UDF call in shard, returning the
+// plugin's encoded input and the number of columns it was given.
+func (e *executor) udfShardInput(ctx context.Context, qcx *Qcx, index string, c *pql.Call, shard uint64) (_ []byte, count uint64, err0 error) {
+	inputs := make([]*Row, len(c.Children))
+	for i, child := range c.Children {
+		row, err := e.executeBitmapCallShard(ctx, qcx, index, child, shard)
+		if err != nil {
+			return nil, 0, err
+		}
+		inputs[i] = row
+	}
+
+	fieldName, ok, err := c.StringArg("values")
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "UDF(): values")
+	} else if !ok {
+		if len(inputs) > 0 {
+			count = inputs[0].Count()
+		}
+		return udfInput(shard, inputs, nil), count, nil
+	}
+
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return nil, 0, newNotFoundError(ErrIndexNotFound, index)
+	}
+	field := idx.Field(fieldName)
+	if field == nil {
+		return nil, 0, newNotFoundError(ErrFieldNotFound, fieldName)
+	}
+	bsig := field.bsiGroup(fieldName)
+	if bsig == nil {
+		return nil, 0, errors.Errorf("UDF(): values field %q isn't an int, decimal or timestamp field", fieldName)
+	}
+
+	values := make(map[uint64]int64)
+	frag := e.Holder.fragment(index, fieldName, viewBSIGroupPrefix+fieldName, shard)
+	if frag != nil {
+		tx, finisher, err := qcx.GetTx(Txo{Write: !writable, Index: idx, Fragment: frag, Shard: shard})
+		if err != nil {
+			return nil, 0, err
+		}
+		defer finisher(&err0)
+
+		exists, err := frag.row(tx, bsiExistsBit)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "loading BSI exists bit from fragment")
+		}
+		if len(inputs) > 0 {
+			exists = exists.Intersect(inputs[0])
+		}
+
+		// Rotate the BSI matrix, as Extract does.
+		data := make(map[uint64]uint64)
+		mergeBits(exists, 0, data)
+		sign, err := frag.row(tx, bsiSignBit)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "loading BSI sign bit from fragment")
+		}
+		mergeBits(sign.Intersect(exists), 1<<63, data)
+		for i := uint64(0); i < bsig.BitDepth; i++ {
+			bits, err := frag.row(tx, bsiOffsetBit+i)
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "loading BSI significand bit from fragment")
+			}
+			mergeBits(bits.Intersect(exists), 1<<i, data)
+		}
+		for col, val := range data {
+			values[col] = (2*(int64(val)>>63)+1)*int64(val&^(1<<63)) + bsig.Base
+		}
+	}
+
+	if len(inputs) > 0 {
+		count = inputs[0].Count()
+	} else {
+		count = uint64(len(values))
+	}
+	return udfInput(shard, inputs, values), count, nil
+}
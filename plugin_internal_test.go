@@ -0,0 +1,192 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/featurebasedb/featurebase/v3/logger"
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+// wasmFunc is a function of a test plugin: its export name, whether it
+// takes (i32) rather than (i32, i32), its result type, local declarations
+// and code.
+type wasmFunc struct {
+	name   string
+	unary  bool
+	result byte
+	locals []byte
+	code   []byte
+}
+
+// wasmModule assembles a module with a memory of one page and funcs.
+func wasmModule(funcs ...wasmFunc) []byte {
+	section := func(id byte, entries ...[]byte) []byte {
+		body := []byte{byte(len(entries))}
+		for _, e := range entries {
+			body = append(body, e...)
+		}
+		return append([]byte{id, byte(len(body))}, body...)
+	}
+	name := func(s string) []byte { return append([]byte{byte(len(s))}, s...) }
+
+	var types, indexes, exports, codes [][]byte
+	exports = append(exports, append(name("memory"), 0x02, 0x00))
+	for i, fn := range funcs {
+		if fn.unary {
+			types = append(types, []byte{0x60, 0x01, 0x7f, 0x01, fn.result})
+		} else {
+			types = append(types, []byte{0x60, 0x02, 0x7f, 0x7f, 0x01, fn.result})
+		}
+		indexes = append(indexes, []byte{byte(i)})
+		exports = append(exports, append(name(fn.name), 0x00, byte(i)))
+		body := append(append([]byte{}, fn.locals...), fn.code...)
+		body = append(body, 0x0b)
+		codes = append(codes, append([]byte{byte(len(body))}, body...))
+	}
+
+	mod := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	mod = append(mod, section(0x01, types...)...)
+	mod = append(mod, section(0x03, indexes...)...)
+	mod = append(mod, section(0x05, []byte{0x00, 0x01})...)
+	mod = append(mod, section(0x07, exports...)...)
+	mod = append(mod, section(0x0a, codes...)...)
+	return mod
+}
+
+var (
+	// alloc grows memory by enough pages for size bytes, returning the
+	// address of the first new page.
+	wasmAlloc = wasmFunc{name: "alloc", unary: true, result: 0x7f, locals: []byte{0x00}, code: []byte{
+		0x20, 0x00, 0x41, 0x10, 0x76, 0x41, 0x01, 0x6a, // size>>16 + 1
+		0x40, 0x00, // memory.grow
+		0x41, 0x10, 0x74, // <<16
+	}}
+	// identity returns its first input, in place.
+	wasmIdentity = wasmFunc{name: "row", result: 0x7e, locals: []byte{0x00}, code: []byte{
+		0x20, 0x00, 0x41, 0x10, 0x6a, 0xad, 0x42, 0x20, 0x86, // (ptr+16)<<32
+		0x20, 0x00, 0x28, 0x02, 0x0c, 0xad, 0x84, // | count of first input
+	}}
+	// sum returns the sum of its values, when it has no inputs.
+	wasmSum = wasmFunc{name: "scalar", result: 0x7e, locals: []byte{0x02, 0x02, 0x7f, 0x01, 0x7e}, code: []byte{
+		0x20, 0x00, 0x41, 0x10, 0x6a, 0x21, 0x02, // i = ptr+16
+		0x20, 0x02, 0x20, 0x00, 0x28, 0x02, 0x0c, 0x41, 0x0c, 0x6c, 0x6a, 0x21, 0x03, // end = i + 12*values
+		0x02, 0x40, 0x03, 0x40, // block, loop
+		0x20, 0x02, 0x20, 0x03, 0x4f, 0x0d, 0x01, // break if i >= end
+		0x20, 0x04, 0x20, 0x02, 0x29, 0x00, 0x04, 0x7c, 0x21, 0x04, // acc += value at i+4
+		0x20, 0x02, 0x41, 0x0c, 0x6a, 0x21, 0x02, // i += 12
+		0x0c, 0x00, 0x0b, 0x0b, // continue, end loop, end block
+		0x20, 0x04,
+	}}
+)
+
+func TestExecutor_UDF(t *testing.T) {
+	dir := t.TempDir()
+	for name, mod := range map[string][]byte{
+		"identity": wasmModule(wasmAlloc, wasmIdentity),
+		"sum":      wasmModule(wasmAlloc, wasmSum),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name+pluginExt), mod, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plugins, err := loadPlugins(dir, logger.NopLogger)
+	if err != nil {
+		t.Fatalf("loading plugins: %v", err)
+	}
+	defer plugins.close()
+	if p, err := plugins.plugin("identity"); err != nil || p.kind != pluginKindRow {
+		t.Fatalf("expected row plugin, got %+v, %v", p, err)
+	} else if p, err := plugins.plugin("sum"); err != nil || p.kind != pluginKindScalar {
+		t.Fatalf("expected scalar plugin, got %+v, %v", p, err)
+	}
+
+	holder := newTestHolder(t)
+	idx, err := holder.CreateIndex("i", IndexOptions{})
+	if err != nil {
+		t.Fatalf("creating index: %v", err)
+	}
+	f, err := idx.CreateField("f")
+	if err != nil {
+		t.Fatalf("creating field: %v", err)
+	}
+	v, err := idx.CreateField("v", OptFieldTypeInt(-100, 100))
+	if err != nil {
+		t.Fatalf("creating field: %v", err)
+	}
+	qcx := holder.Txf().NewWritableQcx()
+	for _, col := range []uint64{1, 5, ShardWidth + 2} {
+		if _, err := f.SetBit(qcx, 1, col, nil); err != nil {
+			t.Fatalf("setting bit: %v", err)
+		}
+	}
+	for col, val := range map[uint64]int64{1: 10, 2: -3, 5: 7} {
+		if _, err := v.SetValue(qcx, col, val); err != nil {
+			t.Fatalf("setting value: %v", err)
+		}
+	}
+	if err := qcx.Finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	e := &executor{Holder: holder, plugins: plugins}
+	parse := func(query string) *pql.Call {
+		t.Helper()
+		q, err := pql.NewParser(strings.NewReader(query)).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return q.Calls[0]
+	}
+	ctx := context.Background()
+	qcx = holder.Txf().NewQcx()
+	defer qcx.Abort()
+
+	for shard, exp := range [][]uint64{{1, 5}, {ShardWidth + 2}} {
+		row, err := e.executeUDFShard(ctx, qcx, "i", parse(`UDF(Row(f=1), name="identity")`), uint64(shard))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := row.Columns(); !reflect.DeepEqual(got, exp) {
+			t.Fatalf("shard %d: expected %v, got %v", shard, exp, got)
+		}
+	}
+
+	p, _ := plugins.plugin("sum")
+	vc, err := e.executeUDFScalarShard(ctx, qcx, "i", parse(`UDF(name="sum", values="v")`), p, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (ValCount{Val: 14, Count: 3}); vc != exp {
+		t.Fatalf("expected %+v, got %+v", exp, vc)
+	}
+
+	if _, err := e.executeUDFShard(ctx, qcx, "i", parse(`UDF(Row(f=1), name="missing")`), 0); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, err := e.executeUDFShard(ctx, qcx, "i", parse(`UDF(name="sum")`), 0); err == nil || !strings.Contains(err.Error(), "doesn't return a row") {
+		t.Fatalf("expected wrong kind error, got %v", err)
+	}
+}
+
+func TestLoadPlugins_Invalid(t *testing.T) {
+	for name, mod := range map[string][]byte{
+		"garbage":  []byte("not wasm"),
+		"noalloc":  wasmModule(wasmIdentity),
+		"noresult": wasmModule(wasmAlloc),
+	} {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, name+pluginExt), mod, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadPlugins(dir, logger.NopLogger); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package pql
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 	"strconv"
@@ -18,8 +19,38 @@ import (
 type Query struct {
 	Calls []*Call
 
+	// Bindings are the calls whose results are bound to names, in the
+	// order they're bound, which the calls can refer to.
+	Bindings []*Binding
+
 	callStack   []*callStackElem
 	conditional []string
+	binding     *Binding
+}
+
+// Binding binds the result of a call to a name, so that it's computed once
+// for a query and can be referred to, as a Ref call, by the calls after
+// it, as in `x = Row(f=1); Count(Intersect(x, Row(g=2)))`.
+type Binding struct {
+	Name string
+	Call *Call
+
+	// Pos is the number of calls before the binding, which is computed
+	// after them.
+	Pos int
+}
+
+// RefCallName is the name of the calls referring to bound results.
+const RefCallName = "Ref"
+
+// Binding returns the binding named name, or nil if there isn't one.
+func (q *Query) Binding(name string) *Binding {
+	for _, b := range q.Bindings {
+		if b.Name == name {
+			return b
+		}
+	}
+	return nil
 }
 
 // ExpandVars recursively replaces variables in the query with their values.
@@ -43,6 +74,11 @@ func (q *Query) ExpandVars(vars map[string]interface{}) (*Query, error) {
 
 // HasCall returns true if q contains the given call name.
 func (q *Query) HasCall(name string) bool {
+	for _, b := range q.Bindings {
+		if b.Call.HasCall(name) {
+			return true
+		}
+	}
 	for _, c := range q.Calls {
 		if c.HasCall(name) {
 			return true
@@ -60,7 +96,11 @@ func (q *Query) startCall(name string) {
 	q.callStack = append(q.callStack, &callStackElem{call: newCall})
 
 	if len(q.callStack) == 1 {
-		q.Calls = append(q.Calls, newCall)
+		if q.binding != nil {
+			q.binding.Call = newCall
+		} else {
+			q.Calls = append(q.Calls, newCall)
+		}
 	} else if prevElem := q.callStack[len(q.callStack)-2]; prevElem.lastField == "" {
 		prevElem.call.Children = append(prevElem.call.Children, newCall)
 	}
@@ -74,6 +114,36 @@ func (q *Query) endCall() *Call {
 	return elem.call
 }
 
+// startBinding starts binding the result of the next call to name.
+func (q *Query) startBinding(name string) {
+	if q.Binding(name) != nil {
+		panic(fmt.Sprintf("%s: %s", duplicateBindingErrorMessage, name))
+	}
+	q.binding = &Binding{Name: name, Pos: len(q.Calls)}
+}
+
+// endBinding ends a binding, once its call has been parsed.
+func (q *Query) endBinding() {
+	if q.binding.Call.IsWrite() {
+		panic(fmt.Sprintf("%s: %s", writeBindingErrorMessage, q.binding.Name))
+	}
+	q.Bindings = append(q.Bindings, q.binding)
+	q.binding = nil
+}
+
+// addRef adds a reference to the result bound to name as a child of the
+// current call.
+func (q *Query) addRef(name string) {
+	if q.Binding(name) == nil {
+		panic(fmt.Sprintf("%s: %s", undefinedBindingErrorMessage, name))
+	}
+	elem := q.lastCallStackElem()
+	elem.call.Children = append(elem.call.Children, &Call{
+		Name: RefCallName,
+		Args: map[string]interface{}{"name": name},
+	})
+}
+
 func (q *Query) lastCallStackElem() *callStackElem {
 	if len(q.callStack) == 0 {
 		return nil
@@ -175,8 +245,13 @@ func (q *Query) addVal(val interface{}) {
 		panic(fmt.Sprintf("addVal called with '%s' when lastField is empty", val))
 	}
 	if elem.inList {
-		list := elem.call.Args[elem.lastField].([]interface{})
-		elem.call.Args[elem.lastField] = append(list, val)
+		if elem.lastCond != ILLEGAL {
+			cond := elem.call.Args[elem.lastField].(*Condition)
+			cond.Value = append(cond.Value.([]interface{}), val)
+		} else {
+			list := elem.call.Args[elem.lastField].([]interface{})
+			elem.call.Args[elem.lastField] = append(list, val)
+		}
 		return
 	}
 	if elem.lastCond != ILLEGAL {
@@ -299,13 +374,29 @@ func (q *Query) addNEQ() {
 func (q *Query) addBTWN() {
 	q.lastCallStackElem().lastCond = BETWEEN
 }
+func (q *Query) addIN() {
+	q.lastCallStackElem().lastCond = IN
+}
+
+// startBetween starts the list of the bounds of a Between call's field.
+func (q *Query) startBetween() {
+	q.addBTWN()
+	q.startList()
+}
+
+// endBetween ends a Between call, which is a Row call with a BETWEEN
+// condition, so Between(f, 10, 20) is Row(10 <= f <= 20).
+func (q *Query) endBetween() {
+	q.endList()
+	q.endCall().Name = "Row"
+}
 
 // WriteCallN returns the number of mutating calls.
 func (q *Query) WriteCallN() int {
 	var n int
 	for _, call := range q.Calls {
 		switch call.Name {
-		case "Set", "Clear", "ClearRow", "Store", "SetBit":
+		case "Set", "Clear", "ClearRow", "Store", "SetBit", "ClearBit":
 			n++
 		}
 	}
@@ -314,9 +405,16 @@ func (q *Query) WriteCallN() int {
 
 // String returns a string representation of the query.
 func (q *Query) String() string {
-	a := make([]string, len(q.Calls))
-	for i, call := range q.Calls {
-		a[i] = call.String()
+	a := make([]string, 0, len(q.Bindings)+len(q.Calls))
+	bindings := q.Bindings
+	for i := 0; i <= len(q.Calls); i++ {
+		for len(bindings) > 0 && bindings[0].Pos <= i {
+			a = append(a, bindings[0].Name+" = "+bindings[0].Call.String())
+			bindings = bindings[1:]
+		}
+		if i < len(q.Calls) {
+			a = append(a, q.Calls[i].String())
+		}
 	}
 	return strings.Join(a, "\n")
 }
@@ -385,7 +483,7 @@ func (c *Call) IsWrite() bool {
 		return false
 	}
 	switch c.Name {
-	case "Set", "Clear", "ClearRow", "Store", "SetBit":
+	case "Set", "Clear", "ClearRow", "Store", "SetBit", "ClearBit":
 		return true
 	}
 	return false
@@ -445,10 +543,37 @@ var callInfoByFunc = map[string]callInfo{
 	"Distinct":  {allowUnknown: true, callType: PrecallGlobal},
 	"Condition": {allowUnknown: true},
 
+	// field=condition, or field=f as a child of Extract
+	"CardinalityOf": {allowUnknown: true},
+
 	// allow only "field=X" cases with string field names
 	"Max": allowField,
 	"Min": allowField,
-	"Sum": allowField,
+	"Avg": allowField,
+	"Histogram": {
+		allowUnknown: false,
+		prototypes: map[string]interface{}{
+			"_field":      stringOrVariable,
+			"field":       stringOrVariable,
+			"bucketWidth": int64(0),
+			"edges":       nil,
+		},
+	},
+	"Sum": {
+		allowUnknown: false,
+		prototypes: map[string]interface{}{
+			"_field":    stringOrVariable,
+			"field":     stringOrVariable,
+			"precision": int64(0),
+		},
+	},
+	"WeightedAvg": {
+		allowUnknown: false,
+		prototypes: map[string]interface{}{
+			"value":  "",
+			"weight": "",
+		},
+	},
 
 	// only take other calls, should never have "args"
 	"Difference": {allowUnknown: false},
@@ -466,25 +591,37 @@ var callInfoByFunc = map[string]callInfo{
 		prototypes: map[string]interface{}{
 			"limit":  int64(0),
 			"offset": int64(0),
+			"order":  "",
 		},
 	},
 	"ClearRow": {allowUnknown: true},
 	"Store":    {allowUnknown: true},
 	"MinRow":   allowField,
 	"MaxRow":   allowField,
+
+	// bool field counts, of an optional bitmap call
+	"CountTrue":  allowField,
+	"CountFalse": allowField,
+	"CountNull":  allowField,
 	"Rows": {
 		allowUnknown: false,
 		prototypes: map[string]interface{}{
 			"_field":   stringOrVariable,
 			"field":    stringOrVariable,
 			"limit":    int64(0),
+			"via":      stringOrVariable,
 			"column":   nil,
 			"previous": nil,
 			"from":     nil,
 			"to":       nil,
+			"tz":       "",
 			"like":     "",
 			"valueidx": int64(0),
 			"in":       nil,
+			// groups by period when GroupBy's child
+			"period": "",
+			// groups an int field by ranges when GroupBy's child
+			"buckets": nil,
 		},
 	},
 	"InnerUnionRows": {
@@ -494,6 +631,18 @@ var callInfoByFunc = map[string]callInfo{
 			"field":  stringOrVariable,
 			"from":   nil,
 			"to":     nil,
+			"tz":     "",
+			"rows":   nil,
+		},
+	},
+	"InnerIntersectRows": {
+		allowUnknown: false,
+		prototypes: map[string]interface{}{
+			"_field": stringOrVariable,
+			"field":  stringOrVariable,
+			"from":   nil,
+			"to":     nil,
+			"tz":     "",
 			"rows":   nil,
 		},
 	},
@@ -502,9 +651,19 @@ var callInfoByFunc = map[string]callInfo{
 			"n": int64(0),
 		},
 	},
-	"Union":     {allowUnknown: false},
-	"UnionRows": {allowUnknown: false, callType: PrecallGlobal},
-	"Extract":   {allowUnknown: false},
+	"Union":         {allowUnknown: false},
+	"UnionRows":     {allowUnknown: false, callType: PrecallGlobal},
+	"IntersectRows": {allowUnknown: false, callType: PrecallGlobal},
+	"Extract": {
+		allowUnknown: false,
+		prototypes: map[string]interface{}{
+			"limit":        int64(0),
+			"offset":       int64(0),
+			"order":        "",
+			"where":        "",
+			"includeAttrs": true,
+		},
+	},
 	"ExternalLookup": {
 		allowUnknown: false,
 		prototypes: map[string]interface{}{
@@ -522,6 +681,15 @@ var callInfoByFunc = map[string]callInfo{
 	},
 	"Xor": {allowUnknown: false},
 
+	// a user-defined function, implemented by a plugin
+	"UDF": {
+		allowUnknown: false,
+		prototypes: map[string]interface{}{
+			"name":   "",
+			"values": "",
+		},
+	},
+
 	"ConstRow": {
 		allowUnknown: false,
 		prototypes: map[string]interface{}{
@@ -539,6 +707,7 @@ var callInfoByFunc = map[string]callInfo{
 			"filter": nil,
 			"from":   nil,
 			"to":     nil,
+			"tz":     "",
 		},
 	},
 
@@ -575,12 +744,18 @@ var callInfoByFunc = map[string]callInfo{
 			"aggregate": nil,
 			"having":    nil,
 			"sort":      "",
+			// set by the coordinating node to prune groups on each shard
+			"shardthreshold": int64(0),
 		},
 	},
 	"Options": {
 		allowUnknown: false,
 		prototypes: map[string]interface{}{
-			"shards": nil,
+			"shards":      nil,
+			"columnRange": nil,
+			"keyPrefix":   "",
+			"timeUnit":    "",
+			"hint":        nil,
 		},
 	},
 	"Set": {
@@ -593,12 +768,24 @@ var callInfoByFunc = map[string]callInfo{
 	"Precomputed": {
 		allowUnknown: true,
 	},
+	RefCallName: {
+		allowUnknown: false,
+		prototypes: map[string]interface{}{
+			"name": "",
+		},
+	},
 	"SetBit": {
 		allowUnknown: true,
 		prototypes: map[string]interface{}{
 			"_col": stringOrInt64,
 		},
 	},
+	"ClearBit": {
+		allowUnknown: true,
+		prototypes: map[string]interface{}{
+			"_col": stringOrInt64,
+		},
+	},
 	"IncludesColumn": {
 		allowUnknown: false,
 		prototypes: map[string]interface{}{
@@ -608,6 +795,8 @@ var callInfoByFunc = map[string]callInfo{
 	"Sort": {
 		allowUnknown: true,
 		prototypes: map[string]interface{}{
+			"after":     stringOrInt64,
+			"by":        "",
 			"_field":    stringOrVariable,
 			"field":     stringOrVariable,
 			"limit":     int64(0),
@@ -719,7 +908,7 @@ func IsReservedArg(name string) bool {
 		return true
 	}
 	switch name {
-	case "from", "to", "index":
+	case "from", "to", "tz", "at", "index", "timeUnit":
 		return true
 	default:
 		return false
@@ -845,6 +1034,36 @@ func (c *Call) UintSliceArg(key string) ([]uint64, bool, error) {
 	}
 }
 
+// IntSliceArg reads the value at key from call.Args as a slice of int64. If
+// the key is not in Call.Args, the value of the returned bool will be false,
+// and the error will be nil. Values which are uint64 are converted to int64,
+// if they fit.
+func (c *Call) IntSliceArg(key string) ([]int64, bool, error) {
+	val, ok := c.Args[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	switch tval := val.(type) {
+	case []int64:
+		return tval, true, nil
+	case []interface{}:
+		ret := make([]int64, len(tval))
+		for i, v := range tval {
+			if iv, ok := v.(int64); ok {
+				ret[i] = iv
+			} else if uv, ok := v.(uint64); ok && uv <= math.MaxInt64 {
+				ret[i] = int64(uv)
+			} else {
+				return nil, true, errors.Errorf("'%v' at position %d is %[1]T, but need integer", v, i)
+			}
+		}
+		return ret, true, nil
+	default:
+		return nil, true, fmt.Errorf("unexpected type %T in IntSliceArg, val %v", tval, tval)
+	}
+}
+
 func (c *Call) StringArg(key string) (string, bool, error) {
 	val, ok := c.Args[key]
 	if !ok {
@@ -927,6 +1146,13 @@ func (c *Call) Clone() *Call {
 func (c *Call) String() string {
 	var buf bytes.Buffer
 
+	// A reference is written as the name it refers to.
+	if c.Name == RefCallName {
+		if name, ok := c.Args["name"].(string); ok {
+			return name
+		}
+	}
+
 	// Write name.
 	if c.Name != "" {
 		buf.WriteString(c.Name)
@@ -1178,6 +1404,8 @@ func (cond *Condition) StringWithSubj(subj string) string {
 		} else if cond.Op == BTWN_LT_LT {
 			return fmt.Sprintf("%s<%s<%s", val[0], subj, val[1])
 		}
+	case IN:
+		return fmt.Sprintf("%s in %s", subj, formatValue(cond.Value))
 	}
 	return ""
 }
@@ -1309,6 +1537,8 @@ func formatValue(v interface{}) string {
 		return joinInterfaceSlice(v)
 	case []uint64:
 		return joinUint64Slice(v)
+	case []int64:
+		return joinInt64Slice(v)
 	case time.Time:
 		return fmt.Sprintf("\"%s\"", v.Format(time.RFC3339Nano))
 	case *Condition:
@@ -1364,6 +1594,14 @@ func joinUint64Slice(a []uint64) string {
 	return "[" + strings.Join(other, ",") + "]"
 }
 
+func joinInt64Slice(a []int64) string {
+	other := make([]string, len(a))
+	for i := range a {
+		other[i] = strconv.FormatInt(a[i], 10)
+	}
+	return "[" + strings.Join(other, ",") + "]"
+}
+
 func parseNum(val string) interface{} {
 	var ival interface{}
 	var err error
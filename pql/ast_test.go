@@ -269,3 +269,35 @@ func TestQuery_ExpandVars(t *testing.T) {
 		})
 	}
 }
+
+func TestQuery_RewriteLegacy(t *testing.T) {
+	for _, tt := range []struct {
+		input  string
+		output string
+		n      int
+	}{
+		{`SetBit(frame="f", row=1, col=2)`, `Set(_col=2, f=1)`, 1},
+		{`SetBit(field=f, rowID=1, columnID=2, timestamp="2010-01-01T00:00")`, `Set(_col=2, _timestamp="2010-01-01T00:00", f=1)`, 1},
+		{`ClearBit(f=3, col="a")`, `Clear(_col="a", f=3)`, 1},
+		{`Count(Union(Bitmap(frame=f, row=1), Bitmap(g=2)))`, `Count(Union(Row(f=1), Row(g=2)))`, 2},
+		{`Range(f=1, 2010-01-01T00:00, 2011-01-01T00:00)`, `Row(f=1, from="2010-01-01T00:00", to="2011-01-01T00:00")`, 1},
+		{`Range(frame=f, row=1, start="2010-01-01T00:00", end="2011-01-01T00:00")`, `Row(f=1, from="2010-01-01T00:00", to="2011-01-01T00:00")`, 1},
+		{`Range(v > 5)`, `Row(v>5)`, 1},
+		{`x = Bitmap(f=1); Count(x)`, "x = Row(f=1)\nCount(x)", 1},
+		// Calls which can't be rewritten are left alone.
+		{`SetBit(f=1, g=2, col=3)`, `SetBit(col=3, f=1, g=2)`, 0},
+		{`Row(f=1)`, `Row(f=1)`, 0},
+	} {
+		q, err := pql.ParseString(tt.input)
+		if err != nil {
+			t.Fatalf("parsing %s: %v", tt.input, err)
+		}
+		n := 0
+		for _, count := range q.RewriteLegacy() {
+			n += count
+		}
+		if s := q.String(); s != tt.output || n != tt.n {
+			t.Errorf("%s: expected %s with %d rewritten, got %s with %d", tt.input, tt.output, tt.n, s, n)
+		}
+	}
+}
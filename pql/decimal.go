@@ -232,6 +232,10 @@ func (d Decimal) ToInt64(scale int64) int64 {
 // results are undefined.
 func (d Decimal) Float64() float64 {
 	var ret float64
+	if !d.value.IsInt64() {
+		ret, _ = new(big.Float).SetInt(&d.value).Float64()
+		return ret / math.Pow10(int(d.Scale))
+	}
 	if d.Scale == 0 {
 		ret = float64(d.value.Int64())
 	} else {
@@ -0,0 +1,142 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pql
+
+// Queries saved by older clients, such as dashboards, use calls which have
+// since been replaced: SetBit and ClearBit, which took the field, row and
+// column as named arguments, Bitmap, which became Row, and Range, which
+// became Row with from and to, or with a condition. RewriteLegacy rewrites
+// them to the calls which replaced them, so they keep working.
+
+// legacyCalls maps each legacy call to the call it's rewritten to.
+var legacyCalls = map[string]string{
+	"SetBit":   "Set",
+	"ClearBit": "Clear",
+	"Bitmap":   "Row",
+	"Range":    "Row",
+}
+
+// legacyArgs are the arguments of legacy calls which aren't fields.
+var legacyArgs = map[string]bool{
+	"col": true, "columnID": true, "columnKey": true,
+	"timestamp": true, "start": true, "end": true,
+}
+
+// LegacyReplacement returns the call a legacy call is rewritten to, or ""
+// if name isn't a legacy call.
+func LegacyReplacement(name string) string {
+	return legacyCalls[name]
+}
+
+// RewriteLegacy rewrites the legacy calls in q to the calls which replaced
+// them, and returns how many of each legacy call were rewritten. Legacy
+// calls with arguments which can't be rewritten are left as they are.
+func (q *Query) RewriteLegacy() map[string]int {
+	counts := make(map[string]int)
+	for i, c := range q.Calls {
+		q.Calls[i] = c.rewriteLegacy(counts)
+	}
+	for _, b := range q.Bindings {
+		b.Call = b.Call.rewriteLegacy(counts)
+	}
+	return counts
+}
+
+// rewriteLegacy returns c, or what it's rewritten to if it's a legacy call,
+// with the legacy calls among its children and arguments rewritten.
+func (c *Call) rewriteLegacy(counts map[string]int) *Call {
+	for i, child := range c.Children {
+		c.Children[i] = child.rewriteLegacy(counts)
+	}
+	for key, arg := range c.Args {
+		if child, ok := arg.(*Call); ok {
+			c.Args[key] = child.rewriteLegacy(counts)
+		}
+	}
+
+	var rewritten *Call
+	switch c.Name {
+	case "SetBit", "ClearBit":
+		rewritten = c.rewriteLegacyBit()
+	case "Bitmap", "Range":
+		rewritten = c.rewriteLegacyRow()
+	}
+	if rewritten == nil {
+		return c
+	}
+	counts[c.Name]++
+	return rewritten
+}
+
+// legacyArg returns the first of the arguments of c named by keys.
+func (c *Call) legacyArg(keys ...string) (interface{}, bool) {
+	for _, key := range keys {
+		if v, ok := c.Args[key]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// legacyField returns the field named by a legacy call, which is either in
+// a frame or field argument, with the row in a row argument, or is the
+// call's only field argument.
+func (c *Call) legacyField() (field string, row interface{}, ok bool) {
+	if v, ok := c.legacyArg("frame", "field", "_field"); ok {
+		field, _ = v.(string)
+		row, ok = c.legacyArg("row", "rowID", "rowKey")
+		return field, row, field != "" && ok
+	}
+	for key, v := range c.Args {
+		if IsReservedArg(key) || legacyArgs[key] {
+			continue
+		} else if field != "" {
+			return "", nil, false
+		}
+		field, row = key, v
+	}
+	return field, row, field != ""
+}
+
+// rewriteLegacyBit rewrites SetBit or ClearBit to Set or Clear, returning
+// nil if it can't.
+func (c *Call) rewriteLegacyBit() *Call {
+	col, ok := c.legacyArg("col", "columnID", "columnKey", "_col")
+	if !ok {
+		return nil
+	}
+	field, row, ok := c.legacyField()
+	if !ok {
+		return nil
+	}
+	args := map[string]interface{}{"_col": col, field: row}
+	if ts, ok := c.legacyArg("timestamp", "_timestamp"); ok {
+		if c.Name == "ClearBit" {
+			return nil
+		}
+		args["_timestamp"] = ts
+	}
+	return &Call{Name: legacyCalls[c.Name], Args: args}
+}
+
+// rewriteLegacyRow rewrites Bitmap or Range to Row, returning nil if it
+// can't.
+func (c *Call) rewriteLegacyRow() *Call {
+	if len(c.Children) > 0 {
+		return nil
+	}
+	if c.HasConditionArg() {
+		return &Call{Name: "Row", Args: c.Args}
+	}
+	field, row, ok := c.legacyField()
+	if !ok {
+		return nil
+	}
+	args := map[string]interface{}{field: row}
+	for _, pair := range [][2]string{{"from", "start"}, {"to", "end"}} {
+		if v, ok := c.legacyArg(pair[0], pair[1]); ok {
+			args[pair[0]] = v
+		}
+	}
+	return &Call{Name: "Row", Args: args}
+}
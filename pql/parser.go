@@ -16,6 +16,9 @@ import (
 const duplicateArgErrorMessage = "duplicate argument provided"
 const intOutOfRangeError = "integer is not in signed 64-bit range"
 const invalidTimestampError = "string is not a valid timestamp"
+const duplicateBindingErrorMessage = "name bound more than once"
+const writeBindingErrorMessage = "cannot bind result of write call"
+const undefinedBindingErrorMessage = "name not bound"
 
 // parser represents a parser for the PQL language.
 type parser struct {
@@ -68,10 +71,19 @@ func (p *parser) Parse() (*Query, error) {
 		}
 		if strings.HasPrefix(errorMessage, duplicateArgErrorMessage) || strings.HasPrefix(errorMessage, intOutOfRangeError) || strings.HasPrefix(errorMessage, invalidTimestampError) {
 			return nil, fmt.Errorf("%s", v)
+		} else if strings.HasPrefix(errorMessage, duplicateBindingErrorMessage) || strings.HasPrefix(errorMessage, writeBindingErrorMessage) || strings.HasPrefix(errorMessage, undefinedBindingErrorMessage) {
+			// These are errors in the structure of the query, like syntax
+			// errors.
+			return nil, errors.Wrap(errors.New(errorMessage), "parsing")
 		} else {
 			panic(v)
 		}
 	}
+	for _, b := range p.Query.Bindings {
+		if err := b.Call.CheckCallInfo(); err != nil {
+			return nil, err
+		}
+	}
 	for _, call := range p.Query.Calls {
 		if call == nil {
 			return nil, fmt.Errorf("unexpected nil Call in query's call list")
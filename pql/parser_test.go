@@ -183,6 +183,43 @@ func TestParser_Parse(t *testing.T) {
 		}
 	})
 
+	// Parse with in-list conditions, including keys.
+	t.Run("WithIn", func(t *testing.T) {
+		q, err := pql.ParseString(`Row(x in [1, -5, 9], y in ["a", 'b'])`)
+		if err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(q.Calls[0],
+			&pql.Call{
+				Name: "Row",
+				Args: map[string]interface{}{
+					"x": &pql.Condition{Op: pql.IN, Value: []interface{}{int64(1), int64(-5), int64(9)}},
+					"y": &pql.Condition{Op: pql.IN, Value: []interface{}{"a", "b"}},
+				},
+			},
+		) {
+			t.Fatalf("unexpected call: %#v", q.Calls[0])
+		} else if s := q.String(); s != `Row(x in [1,-5,9], y in ["a","b"])` {
+			t.Fatalf("unexpected string: %s", s)
+		}
+	})
+
+	// Between is a Row call with a BETWEEN condition.
+	t.Run("Between", func(t *testing.T) {
+		q, err := pql.ParseString(`Count(between(x, 10, 20.5))`)
+		if err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(q.Calls[0].Children[0],
+			&pql.Call{
+				Name: "Row",
+				Args: map[string]interface{}{
+					"x": &pql.Condition{Op: pql.BETWEEN, Value: []interface{}{int64(10), pql.NewDecimal(205, 1)}},
+				},
+			},
+		) {
+			t.Fatalf("unexpected call: %#v", q.Calls[0].Children[0])
+		}
+	})
+
 	t.Run("MixedCase", func(t *testing.T) {
 		q, err := pql.ParseString(`roW(x=3)`)
 		if err != nil {
@@ -240,6 +277,44 @@ func TestParser_Parse(t *testing.T) {
 		}
 	})
 
+	t.Run("Bindings", func(t *testing.T) {
+		q, err := pql.ParseString(`Set(1, f=1); x = Row(f=1); y = Union(x, Row(g=2)) Count(Intersect(x, y))`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ref := func(name string) *pql.Call {
+			return &pql.Call{Name: pql.RefCallName, Args: map[string]interface{}{"name": name}}
+		}
+		if exp := []*pql.Binding{
+			{Name: "x", Pos: 1, Call: &pql.Call{Name: "Row", Args: map[string]interface{}{"f": int64(1)}}},
+			{Name: "y", Pos: 1, Call: &pql.Call{Name: "Union", Children: []*pql.Call{
+				ref("x"),
+				{Name: "Row", Args: map[string]interface{}{"g": int64(2)}},
+			}}},
+		}; !reflect.DeepEqual(q.Bindings, exp) {
+			t.Fatalf("unexpected bindings: %v", q.Bindings)
+		}
+		if len(q.Calls) != 2 || !reflect.DeepEqual(q.Calls[1], &pql.Call{
+			Name:     "Count",
+			Children: []*pql.Call{{Name: "Intersect", Children: []*pql.Call{ref("x"), ref("y")}}},
+		}) {
+			t.Fatalf("unexpected calls: %v", q.Calls)
+		}
+		if exp := "Set(_col=1, f=1)\nx = Row(f=1)\ny = Union(x, Row(g=2))\nCount(Intersect(x, y))"; q.String() != exp {
+			t.Fatalf("expected %q, got %q", exp, q.String())
+		}
+
+		for query, msg := range map[string]string{
+			`Count(x)`:                  "name not bound: x",
+			`Count(x); x = Row(f=1)`:    "name not bound: x",
+			`x = Row(f=1) x = Row(f=2)`: "name bound more than once: x",
+			`x = Set(1, f=1)`:           "cannot bind result of write call: x",
+		} {
+			if _, err := pql.ParseString(query); err == nil || !strings.Contains(err.Error(), msg) {
+				t.Errorf("%s: expected error %q, got %v", query, msg, err)
+			}
+		}
+	})
 }
 
 func TestUnquote(t *testing.T) {
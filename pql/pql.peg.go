@@ -19,6 +19,7 @@ type pegRule uint8
 const (
 	ruleUnknown pegRule = iota
 	ruleCalls
+	ruleBinding
 	ruleCall
 	ruleallargs
 	ruleargs
@@ -58,6 +59,7 @@ const (
 	ruletimebasicfmt
 	ruletimefmt
 	ruletime
+	rulePegText
 	ruleAction0
 	ruleAction1
 	ruleAction2
@@ -86,7 +88,6 @@ const (
 	ruleAction25
 	ruleAction26
 	ruleAction27
-	rulePegText
 	ruleAction28
 	ruleAction29
 	ruleAction30
@@ -121,11 +122,19 @@ const (
 	ruleAction59
 	ruleAction60
 	ruleAction61
+	ruleAction62
+	ruleAction63
+	ruleAction64
+	ruleAction65
+	ruleAction66
+	ruleAction67
+	ruleAction68
 )
 
 var rul3s = [...]string{
 	"Unknown",
 	"Calls",
+	"Binding",
 	"Call",
 	"allargs",
 	"args",
@@ -165,6 +174,7 @@ var rul3s = [...]string{
 	"timebasicfmt",
 	"timefmt",
 	"time",
+	"PegText",
 	"Action0",
 	"Action1",
 	"Action2",
@@ -193,7 +203,6 @@ var rul3s = [...]string{
 	"Action25",
 	"Action26",
 	"Action27",
-	"PegText",
 	"Action28",
 	"Action29",
 	"Action30",
@@ -228,6 +237,13 @@ var rul3s = [...]string{
 	"Action59",
 	"Action60",
 	"Action61",
+	"Action62",
+	"Action63",
+	"Action64",
+	"Action65",
+	"Action66",
+	"Action67",
+	"Action68",
 }
 
 type token32 struct {
@@ -344,7 +360,7 @@ type PQL struct {
 
 	Buffer string
 	buffer []rune
-	rules  [104]func() bool
+	rules  [112]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -447,128 +463,142 @@ func (p *PQL) Execute() {
 			text = string(_buffer[begin:end])
 
 		case ruleAction0:
-			p.startCall("Set")
+			p.startBinding(text)
 		case ruleAction1:
-			p.endCall()
+			p.endBinding()
 		case ruleAction2:
-			p.startCall("Clear")
+			p.startCall("Set")
 		case ruleAction3:
 			p.endCall()
 		case ruleAction4:
-			p.startCall("ClearRow")
+			p.startCall("Clear")
 		case ruleAction5:
 			p.endCall()
 		case ruleAction6:
-			p.startCall("Store")
+			p.startCall("ClearRow")
 		case ruleAction7:
 			p.endCall()
 		case ruleAction8:
-			p.startCall("TopN")
+			p.startCall("Store")
 		case ruleAction9:
 			p.endCall()
 		case ruleAction10:
-			p.startCall("TopK")
+			p.startCall("TopN")
 		case ruleAction11:
 			p.endCall()
 		case ruleAction12:
-			p.startCall("Percentile")
+			p.startCall("TopK")
 		case ruleAction13:
 			p.endCall()
 		case ruleAction14:
-			p.startCall("Rows")
+			p.startCall("Percentile")
 		case ruleAction15:
 			p.endCall()
 		case ruleAction16:
-			p.startCall("Min")
+			p.startCall("Rows")
 		case ruleAction17:
 			p.endCall()
 		case ruleAction18:
-			p.startCall("Max")
+			p.startCall("Min")
 		case ruleAction19:
 			p.endCall()
 		case ruleAction20:
-			p.startCall("Sum")
+			p.startCall("Max")
 		case ruleAction21:
 			p.endCall()
 		case ruleAction22:
-			p.startCall("Range")
+			p.startCall("Sum")
 		case ruleAction23:
-			p.addField("from")
+			p.endCall()
 		case ruleAction24:
-			p.addVal(text)
+			p.startCall("Range")
 		case ruleAction25:
-			p.addField("to")
+			p.addField("from")
 		case ruleAction26:
 			p.addVal(text)
 		case ruleAction27:
-			p.endCall()
+			p.addField("to")
 		case ruleAction28:
-			p.startCall(text)
+			p.addVal(text)
 		case ruleAction29:
 			p.endCall()
 		case ruleAction30:
-			p.addBTWN()
+			p.startCall("Between")
 		case ruleAction31:
-			p.addLTE()
+			p.startBetween()
 		case ruleAction32:
-			p.addGTE()
+			p.endBetween()
 		case ruleAction33:
-			p.addEQ()
+			p.startCall(text)
 		case ruleAction34:
-			p.addNEQ()
+			p.endCall()
 		case ruleAction35:
-			p.addLT()
+			p.addRef(text)
 		case ruleAction36:
-			p.addGT()
+			p.addBTWN()
 		case ruleAction37:
-			p.startConditional()
+			p.addLTE()
 		case ruleAction38:
-			p.endConditional()
+			p.addGTE()
 		case ruleAction39:
-			p.condAdd(text)
+			p.addEQ()
 		case ruleAction40:
-			p.condAdd(text)
+			p.addNEQ()
 		case ruleAction41:
-			p.condAdd(text)
+			p.addLT()
 		case ruleAction42:
-			p.startList()
+			p.addGT()
 		case ruleAction43:
-			p.endList()
+			p.addIN()
 		case ruleAction44:
-			p.addVal(nil)
+			p.startConditional()
 		case ruleAction45:
-			p.addVal(true)
+			p.endConditional()
 		case ruleAction46:
-			p.addVal(false)
+			p.condAdd(text)
 		case ruleAction47:
-			p.addVal(NewVariable(text))
+			p.condAdd(text)
 		case ruleAction48:
-			p.addVal(text)
+			p.condAdd(text)
 		case ruleAction49:
-			p.addTimestampVal(text)
+			p.startList()
 		case ruleAction50:
-			p.addNumVal(text)
+			p.endList()
 		case ruleAction51:
-			p.startCall(text)
+			p.addVal(nil)
 		case ruleAction52:
-			p.addVal(p.endCall())
+			p.addVal(true)
 		case ruleAction53:
-			p.addVal(text)
+			p.addVal(false)
 		case ruleAction54:
-			p.addVal(text)
+			p.addVal(NewVariable(text))
 		case ruleAction55:
 			p.addVal(text)
 		case ruleAction56:
-			p.addField(text)
+			p.addTimestampVal(text)
 		case ruleAction57:
-			p.addPosStr("_field", text)
+			p.addNumVal(text)
 		case ruleAction58:
-			p.addPosNum("_col", text)
+			p.startCall(text)
 		case ruleAction59:
-			p.addPosStr("_col", text)
+			p.addVal(p.endCall())
 		case ruleAction60:
-			p.addPosStr("_col", text)
+			p.addVal(text)
 		case ruleAction61:
+			p.addVal(text)
+		case ruleAction62:
+			p.addVal(text)
+		case ruleAction63:
+			p.addField(text)
+		case ruleAction64:
+			p.addPosStr("_field", text)
+		case ruleAction65:
+			p.addPosNum("_col", text)
+		case ruleAction66:
+			p.addPosStr("_col", text)
+		case ruleAction67:
+			p.addPosStr("_col", text)
+		case ruleAction68:
 			p.addPosStr("_timestamp", text)
 
 		}
@@ -663,7 +693,7 @@ func (p *PQL) Init(options ...func(*PQL) error) error {
 
 	_rules = [...]func() bool{
 		nil,
-		/* 0 Calls <- <(sp (Call sp)* !.)> */
+		/* 0 Calls <- <(sp ((Binding / Call) sp (';' sp)?)* !.)> */
 		func() bool {
 			position0, tokenIndex0 := position, tokenIndex
 			{
@@ -674,24 +704,75 @@ func (p *PQL) Init(options ...func(*PQL) error) error {
 			l2:
 				{
 					position3, tokenIndex3 := position, tokenIndex
-					if !_rules[ruleCall]() {
-						goto l3
+					{
+						position4, tokenIndex4 := position, tokenIndex
+						{
+							position6 := position
+							{
+								position7 := position
+								if !_rules[ruleIDENT]() {
+									goto l5
+								}
+								add(rulePegText, position7)
+							}
+							if !_rules[rulesp]() {
+								goto l5
+							}
+							if buffer[position] != rune('=') {
+								goto l5
+							}
+							position++
+							if !_rules[rulesp]() {
+								goto l5
+							}
+							{
+								add(ruleAction0, position)
+							}
+							if !_rules[ruleCall]() {
+								goto l5
+							}
+							{
+								add(ruleAction1, position)
+							}
+							add(ruleBinding, position6)
+						}
+						goto l4
+					l5:
+						position, tokenIndex = position4, tokenIndex4
+						if !_rules[ruleCall]() {
+							goto l3
+						}
 					}
+				l4:
 					if !_rules[rulesp]() {
 						goto l3
 					}
+					{
+						position10, tokenIndex10 := position, tokenIndex
+						if buffer[position] != rune(';') {
+							goto l10
+						}
+						position++
+						if !_rules[rulesp]() {
+							goto l10
+						}
+						goto l11
+					l10:
+						position, tokenIndex = position10, tokenIndex10
+					}
+				l11:
 					goto l2
 				l3:
 					position, tokenIndex = position3, tokenIndex3
 				}
 				{
-					position4, tokenIndex4 := position, tokenIndex
+					position12, tokenIndex12 := position, tokenIndex
 					if !matchDot() {
-						goto l4
+						goto l12
 					}
 					goto l0
-				l4:
-					position, tokenIndex = position4, tokenIndex4
+				l12:
+					position, tokenIndex = position12, tokenIndex12
 				}
 				add(ruleCalls, position1)
 			}
@@ -700,3678 +781,3876 @@ func (p *PQL) Init(options ...func(*PQL) error) error {
 			position, tokenIndex = position0, tokenIndex0
 			return false
 		},
-		/* 1 Call <- <((('s' / 'S') ('e' / 'E') ('t' / 'T') Action0 open col comma args (comma time)? close Action1) / (('c' / 'C') ('l' / 'L') ('e' / 'E') ('a' / 'A') ('r' / 'R') Action2 open col comma args close Action3) / (('c' / 'C') ('l' / 'L') ('e' / 'E') ('a' / 'A') ('r' / 'R') ('r' / 'R') ('o' / 'O') ('w' / 'W') Action4 open arg close Action5) / (('s' / 'S') ('t' / 'T') ('o' / 'O') ('r' / 'R') ('e' / 'E') Action6 open Call comma arg close Action7) / (('t' / 'T') ('o' / 'O') ('p' / 'P') ('n' / 'N') Action8 open posfield (comma allargs)? close Action9) / (('t' / 'T') ('o' / 'O') ('p' / 'P') ('k' / 'K') Action10 open posfield (comma allargs)? close Action11) / (('p' / 'P') ('e' / 'E') ('r' / 'R') ('c' / 'C') ('e' / 'E') ('n' / 'N') ('t' / 'T') ('i' / 'I') ('l' / 'L') ('e' / 'E') Action12 open posfield (comma allargs)? close Action13) / (('r' / 'R') ('o' / 'O') ('w' / 'W') ('s' / 'S') Action14 open posfield (comma allargs)? close Action15) / (('m' / 'M') ('i' / 'I') ('n' / 'N') Action16 open posfield (comma allargs)? close Action17) / (('m' / 'M') ('a' / 'A') ('x' / 'X') Action18 open posfield (comma allargs)? close Action19) / (('s' / 'S') ('u' / 'U') ('m' / 'M') Action20 open posfield (comma allargs)? close Action21) / (('r' / 'R') ('a' / 'A') ('n' / 'N') ('g' / 'G') ('e' / 'E') Action22 open field eq value comma ('f' 'r' 'o' 'm' '=')? Action23 timefmt Action24 comma ('t' 'o' '=')? sp Action25 timefmt Action26 close Action27) / (<IDENT> Action28 open allargs comma? close Action29))> */
+		/* 1 Binding <- <(<IDENT> sp '=' sp Action0 Call Action1)> */
+		nil,
+		/* 2 Call <- <((('s' / 'S') ('e' / 'E') ('t' / 'T') Action2 open col comma args (comma time)? close Action3) / (('c' / 'C') ('l' / 'L') ('e' / 'E') ('a' / 'A') ('r' / 'R') Action4 open col comma args close Action5) / (('c' / 'C') ('l' / 'L') ('e' / 'E') ('a' / 'A') ('r' / 'R') ('r' / 'R') ('o' / 'O') ('w' / 'W') Action6 open arg close Action7) / (('s' / 'S') ('t' / 'T') ('o' / 'O') ('r' / 'R') ('e' / 'E') Action8 open Call comma arg close Action9) / (('t' / 'T') ('o' / 'O') ('p' / 'P') ('n' / 'N') Action10 open posfield (comma allargs)? close Action11) / (('t' / 'T') ('o' / 'O') ('p' / 'P') ('k' / 'K') Action12 open posfield (comma allargs)? close Action13) / (('p' / 'P') ('e' / 'E') ('r' / 'R') ('c' / 'C') ('e' / 'E') ('n' / 'N') ('t' / 'T') ('i' / 'I') ('l' / 'L') ('e' / 'E') Action14 open posfield (comma allargs)? close Action15) / (('r' / 'R') ('o' / 'O') ('w' / 'W') ('s' / 'S') Action16 open posfield (comma allargs)? close Action17) / (('m' / 'M') ('i' / 'I') ('n' / 'N') Action18 open posfield (comma allargs)? close Action19) / (('m' / 'M') ('a' / 'A') ('x' / 'X') Action20 open posfield (comma allargs)? close Action21) / (('s' / 'S') ('u' / 'U') ('m' / 'M') Action22 open posfield (comma allargs)? close Action23) / (('r' / 'R') ('a' / 'A') ('n' / 'N') ('g' / 'G') ('e' / 'E') Action24 open field eq value comma ('f' 'r' 'o' 'm' '=')? Action25 timefmt Action26 comma ('t' 'o' '=')? sp Action27 timefmt Action28 close Action29) / (('b' / 'B') ('e' / 'E') ('t' / 'T') ('w' / 'W') ('e' / 'E') ('e' / 'E') ('n' / 'N') Action30 open field comma Action31 item comma item close Action32) / (<IDENT> Action33 open allargs comma? close Action34) / (<IDENT> &(comma / close) Action35))> */
 		func() bool {
-			position5, tokenIndex5 := position, tokenIndex
+			position14, tokenIndex14 := position, tokenIndex
 			{
-				position6 := position
+				position15 := position
 				{
-					position7, tokenIndex7 := position, tokenIndex
+					position16, tokenIndex16 := position, tokenIndex
 					{
-						position9, tokenIndex9 := position, tokenIndex
+						position18, tokenIndex18 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l10
+							goto l19
 						}
 						position++
-						goto l9
-					l10:
-						position, tokenIndex = position9, tokenIndex9
+						goto l18
+					l19:
+						position, tokenIndex = position18, tokenIndex18
 						if buffer[position] != rune('S') {
-							goto l8
+							goto l17
 						}
 						position++
 					}
-				l9:
+				l18:
 					{
-						position11, tokenIndex11 := position, tokenIndex
+						position20, tokenIndex20 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l12
+							goto l21
 						}
 						position++
-						goto l11
-					l12:
-						position, tokenIndex = position11, tokenIndex11
+						goto l20
+					l21:
+						position, tokenIndex = position20, tokenIndex20
 						if buffer[position] != rune('E') {
-							goto l8
+							goto l17
 						}
 						position++
 					}
-				l11:
+				l20:
 					{
-						position13, tokenIndex13 := position, tokenIndex
+						position22, tokenIndex22 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l14
+							goto l23
 						}
 						position++
-						goto l13
-					l14:
-						position, tokenIndex = position13, tokenIndex13
+						goto l22
+					l23:
+						position, tokenIndex = position22, tokenIndex22
 						if buffer[position] != rune('T') {
-							goto l8
+							goto l17
 						}
 						position++
 					}
-				l13:
+				l22:
 					{
-						add(ruleAction0, position)
+						add(ruleAction2, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l8
+						goto l17
 					}
 					if !_rules[rulecol]() {
-						goto l8
+						goto l17
 					}
 					if !_rules[rulecomma]() {
-						goto l8
+						goto l17
 					}
 					if !_rules[ruleargs]() {
-						goto l8
+						goto l17
 					}
 					{
-						position16, tokenIndex16 := position, tokenIndex
+						position25, tokenIndex25 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l16
+							goto l25
 						}
 						{
-							position18 := position
+							position27 := position
 							{
-								position19 := position
+								position28 := position
 								if !_rules[ruletimefmt]() {
-									goto l16
+									goto l25
 								}
-								add(rulePegText, position19)
+								add(rulePegText, position28)
 							}
 							{
-								add(ruleAction61, position)
+								add(ruleAction68, position)
 							}
-							add(ruletime, position18)
+							add(ruletime, position27)
 						}
-						goto l17
-					l16:
-						position, tokenIndex = position16, tokenIndex16
+						goto l26
+					l25:
+						position, tokenIndex = position25, tokenIndex25
 					}
-				l17:
+				l26:
 					if !_rules[ruleclose]() {
-						goto l8
+						goto l17
 					}
 					{
-						add(ruleAction1, position)
+						add(ruleAction3, position)
 					}
-					goto l7
-				l8:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l17:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position23, tokenIndex23 := position, tokenIndex
+						position32, tokenIndex32 := position, tokenIndex
 						if buffer[position] != rune('c') {
-							goto l24
+							goto l33
 						}
 						position++
-						goto l23
-					l24:
-						position, tokenIndex = position23, tokenIndex23
+						goto l32
+					l33:
+						position, tokenIndex = position32, tokenIndex32
 						if buffer[position] != rune('C') {
-							goto l22
+							goto l31
 						}
 						position++
 					}
-				l23:
+				l32:
 					{
-						position25, tokenIndex25 := position, tokenIndex
+						position34, tokenIndex34 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l26
+							goto l35
 						}
 						position++
-						goto l25
-					l26:
-						position, tokenIndex = position25, tokenIndex25
+						goto l34
+					l35:
+						position, tokenIndex = position34, tokenIndex34
 						if buffer[position] != rune('L') {
-							goto l22
+							goto l31
 						}
 						position++
 					}
-				l25:
+				l34:
 					{
-						position27, tokenIndex27 := position, tokenIndex
+						position36, tokenIndex36 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l28
+							goto l37
 						}
 						position++
-						goto l27
-					l28:
-						position, tokenIndex = position27, tokenIndex27
+						goto l36
+					l37:
+						position, tokenIndex = position36, tokenIndex36
 						if buffer[position] != rune('E') {
-							goto l22
+							goto l31
 						}
 						position++
 					}
-				l27:
+				l36:
 					{
-						position29, tokenIndex29 := position, tokenIndex
+						position38, tokenIndex38 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l30
+							goto l39
 						}
 						position++
-						goto l29
-					l30:
-						position, tokenIndex = position29, tokenIndex29
+						goto l38
+					l39:
+						position, tokenIndex = position38, tokenIndex38
 						if buffer[position] != rune('A') {
-							goto l22
+							goto l31
 						}
 						position++
 					}
-				l29:
+				l38:
 					{
-						position31, tokenIndex31 := position, tokenIndex
+						position40, tokenIndex40 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l32
+							goto l41
 						}
 						position++
-						goto l31
-					l32:
-						position, tokenIndex = position31, tokenIndex31
+						goto l40
+					l41:
+						position, tokenIndex = position40, tokenIndex40
 						if buffer[position] != rune('R') {
-							goto l22
+							goto l31
 						}
 						position++
 					}
-				l31:
+				l40:
 					{
-						add(ruleAction2, position)
+						add(ruleAction4, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l22
+						goto l31
 					}
 					if !_rules[rulecol]() {
-						goto l22
+						goto l31
 					}
 					if !_rules[rulecomma]() {
-						goto l22
+						goto l31
 					}
 					if !_rules[ruleargs]() {
-						goto l22
+						goto l31
 					}
 					if !_rules[ruleclose]() {
-						goto l22
+						goto l31
 					}
 					{
-						add(ruleAction3, position)
+						add(ruleAction5, position)
 					}
-					goto l7
-				l22:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l31:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position36, tokenIndex36 := position, tokenIndex
+						position45, tokenIndex45 := position, tokenIndex
 						if buffer[position] != rune('c') {
-							goto l37
+							goto l46
 						}
 						position++
-						goto l36
-					l37:
-						position, tokenIndex = position36, tokenIndex36
+						goto l45
+					l46:
+						position, tokenIndex = position45, tokenIndex45
 						if buffer[position] != rune('C') {
-							goto l35
+							goto l44
 						}
 						position++
 					}
-				l36:
+				l45:
 					{
-						position38, tokenIndex38 := position, tokenIndex
+						position47, tokenIndex47 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l39
+							goto l48
 						}
 						position++
-						goto l38
-					l39:
-						position, tokenIndex = position38, tokenIndex38
+						goto l47
+					l48:
+						position, tokenIndex = position47, tokenIndex47
 						if buffer[position] != rune('L') {
-							goto l35
+							goto l44
 						}
 						position++
 					}
-				l38:
+				l47:
 					{
-						position40, tokenIndex40 := position, tokenIndex
+						position49, tokenIndex49 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l41
+							goto l50
 						}
 						position++
-						goto l40
-					l41:
-						position, tokenIndex = position40, tokenIndex40
+						goto l49
+					l50:
+						position, tokenIndex = position49, tokenIndex49
 						if buffer[position] != rune('E') {
-							goto l35
+							goto l44
 						}
 						position++
 					}
-				l40:
+				l49:
 					{
-						position42, tokenIndex42 := position, tokenIndex
+						position51, tokenIndex51 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l43
+							goto l52
 						}
 						position++
-						goto l42
-					l43:
-						position, tokenIndex = position42, tokenIndex42
+						goto l51
+					l52:
+						position, tokenIndex = position51, tokenIndex51
 						if buffer[position] != rune('A') {
-							goto l35
+							goto l44
 						}
 						position++
 					}
-				l42:
+				l51:
 					{
-						position44, tokenIndex44 := position, tokenIndex
+						position53, tokenIndex53 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l45
+							goto l54
 						}
 						position++
-						goto l44
-					l45:
-						position, tokenIndex = position44, tokenIndex44
+						goto l53
+					l54:
+						position, tokenIndex = position53, tokenIndex53
 						if buffer[position] != rune('R') {
-							goto l35
+							goto l44
 						}
 						position++
 					}
-				l44:
+				l53:
 					{
-						position46, tokenIndex46 := position, tokenIndex
+						position55, tokenIndex55 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l47
+							goto l56
 						}
 						position++
-						goto l46
-					l47:
-						position, tokenIndex = position46, tokenIndex46
+						goto l55
+					l56:
+						position, tokenIndex = position55, tokenIndex55
 						if buffer[position] != rune('R') {
-							goto l35
+							goto l44
 						}
 						position++
 					}
-				l46:
+				l55:
 					{
-						position48, tokenIndex48 := position, tokenIndex
+						position57, tokenIndex57 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l49
+							goto l58
 						}
 						position++
-						goto l48
-					l49:
-						position, tokenIndex = position48, tokenIndex48
+						goto l57
+					l58:
+						position, tokenIndex = position57, tokenIndex57
 						if buffer[position] != rune('O') {
-							goto l35
+							goto l44
 						}
 						position++
 					}
-				l48:
+				l57:
 					{
-						position50, tokenIndex50 := position, tokenIndex
+						position59, tokenIndex59 := position, tokenIndex
 						if buffer[position] != rune('w') {
-							goto l51
+							goto l60
 						}
 						position++
-						goto l50
-					l51:
-						position, tokenIndex = position50, tokenIndex50
+						goto l59
+					l60:
+						position, tokenIndex = position59, tokenIndex59
 						if buffer[position] != rune('W') {
-							goto l35
+							goto l44
 						}
 						position++
 					}
-				l50:
+				l59:
 					{
-						add(ruleAction4, position)
+						add(ruleAction6, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l35
+						goto l44
 					}
 					if !_rules[rulearg]() {
-						goto l35
+						goto l44
 					}
 					if !_rules[ruleclose]() {
-						goto l35
+						goto l44
 					}
 					{
-						add(ruleAction5, position)
+						add(ruleAction7, position)
 					}
-					goto l7
-				l35:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l44:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position55, tokenIndex55 := position, tokenIndex
+						position64, tokenIndex64 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l56
+							goto l65
 						}
 						position++
-						goto l55
-					l56:
-						position, tokenIndex = position55, tokenIndex55
+						goto l64
+					l65:
+						position, tokenIndex = position64, tokenIndex64
 						if buffer[position] != rune('S') {
-							goto l54
+							goto l63
 						}
 						position++
 					}
-				l55:
+				l64:
 					{
-						position57, tokenIndex57 := position, tokenIndex
+						position66, tokenIndex66 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l58
+							goto l67
 						}
 						position++
-						goto l57
-					l58:
-						position, tokenIndex = position57, tokenIndex57
+						goto l66
+					l67:
+						position, tokenIndex = position66, tokenIndex66
 						if buffer[position] != rune('T') {
-							goto l54
+							goto l63
 						}
 						position++
 					}
-				l57:
+				l66:
 					{
-						position59, tokenIndex59 := position, tokenIndex
+						position68, tokenIndex68 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l60
+							goto l69
 						}
 						position++
-						goto l59
-					l60:
-						position, tokenIndex = position59, tokenIndex59
+						goto l68
+					l69:
+						position, tokenIndex = position68, tokenIndex68
 						if buffer[position] != rune('O') {
-							goto l54
+							goto l63
 						}
 						position++
 					}
-				l59:
+				l68:
 					{
-						position61, tokenIndex61 := position, tokenIndex
+						position70, tokenIndex70 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l62
+							goto l71
 						}
 						position++
-						goto l61
-					l62:
-						position, tokenIndex = position61, tokenIndex61
+						goto l70
+					l71:
+						position, tokenIndex = position70, tokenIndex70
 						if buffer[position] != rune('R') {
-							goto l54
+							goto l63
 						}
 						position++
 					}
-				l61:
+				l70:
 					{
-						position63, tokenIndex63 := position, tokenIndex
+						position72, tokenIndex72 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l64
+							goto l73
 						}
 						position++
-						goto l63
-					l64:
-						position, tokenIndex = position63, tokenIndex63
+						goto l72
+					l73:
+						position, tokenIndex = position72, tokenIndex72
 						if buffer[position] != rune('E') {
-							goto l54
+							goto l63
 						}
 						position++
 					}
-				l63:
+				l72:
 					{
-						add(ruleAction6, position)
+						add(ruleAction8, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l54
+						goto l63
 					}
 					if !_rules[ruleCall]() {
-						goto l54
+						goto l63
 					}
 					if !_rules[rulecomma]() {
-						goto l54
+						goto l63
 					}
 					if !_rules[rulearg]() {
-						goto l54
+						goto l63
 					}
 					if !_rules[ruleclose]() {
-						goto l54
+						goto l63
 					}
 					{
-						add(ruleAction7, position)
+						add(ruleAction9, position)
 					}
-					goto l7
-				l54:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l63:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position68, tokenIndex68 := position, tokenIndex
+						position77, tokenIndex77 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l69
+							goto l78
 						}
 						position++
-						goto l68
-					l69:
-						position, tokenIndex = position68, tokenIndex68
+						goto l77
+					l78:
+						position, tokenIndex = position77, tokenIndex77
 						if buffer[position] != rune('T') {
-							goto l67
+							goto l76
 						}
 						position++
 					}
-				l68:
+				l77:
 					{
-						position70, tokenIndex70 := position, tokenIndex
+						position79, tokenIndex79 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l71
+							goto l80
 						}
 						position++
-						goto l70
-					l71:
-						position, tokenIndex = position70, tokenIndex70
+						goto l79
+					l80:
+						position, tokenIndex = position79, tokenIndex79
 						if buffer[position] != rune('O') {
-							goto l67
+							goto l76
 						}
 						position++
 					}
-				l70:
+				l79:
 					{
-						position72, tokenIndex72 := position, tokenIndex
+						position81, tokenIndex81 := position, tokenIndex
 						if buffer[position] != rune('p') {
-							goto l73
+							goto l82
 						}
 						position++
-						goto l72
-					l73:
-						position, tokenIndex = position72, tokenIndex72
+						goto l81
+					l82:
+						position, tokenIndex = position81, tokenIndex81
 						if buffer[position] != rune('P') {
-							goto l67
+							goto l76
 						}
 						position++
 					}
-				l72:
+				l81:
 					{
-						position74, tokenIndex74 := position, tokenIndex
+						position83, tokenIndex83 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l75
+							goto l84
 						}
 						position++
-						goto l74
-					l75:
-						position, tokenIndex = position74, tokenIndex74
+						goto l83
+					l84:
+						position, tokenIndex = position83, tokenIndex83
 						if buffer[position] != rune('N') {
-							goto l67
+							goto l76
 						}
 						position++
 					}
-				l74:
+				l83:
 					{
-						add(ruleAction8, position)
+						add(ruleAction10, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l67
+						goto l76
 					}
 					if !_rules[ruleposfield]() {
-						goto l67
+						goto l76
 					}
 					{
-						position77, tokenIndex77 := position, tokenIndex
+						position86, tokenIndex86 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l77
+							goto l86
 						}
 						if !_rules[ruleallargs]() {
-							goto l77
+							goto l86
 						}
-						goto l78
-					l77:
-						position, tokenIndex = position77, tokenIndex77
+						goto l87
+					l86:
+						position, tokenIndex = position86, tokenIndex86
 					}
-				l78:
+				l87:
 					if !_rules[ruleclose]() {
-						goto l67
+						goto l76
 					}
 					{
-						add(ruleAction9, position)
+						add(ruleAction11, position)
 					}
-					goto l7
-				l67:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l76:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position81, tokenIndex81 := position, tokenIndex
+						position90, tokenIndex90 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l82
+							goto l91
 						}
 						position++
-						goto l81
-					l82:
-						position, tokenIndex = position81, tokenIndex81
+						goto l90
+					l91:
+						position, tokenIndex = position90, tokenIndex90
 						if buffer[position] != rune('T') {
-							goto l80
+							goto l89
 						}
 						position++
 					}
-				l81:
+				l90:
 					{
-						position83, tokenIndex83 := position, tokenIndex
+						position92, tokenIndex92 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l84
+							goto l93
 						}
 						position++
-						goto l83
-					l84:
-						position, tokenIndex = position83, tokenIndex83
+						goto l92
+					l93:
+						position, tokenIndex = position92, tokenIndex92
 						if buffer[position] != rune('O') {
-							goto l80
+							goto l89
 						}
 						position++
 					}
-				l83:
+				l92:
 					{
-						position85, tokenIndex85 := position, tokenIndex
+						position94, tokenIndex94 := position, tokenIndex
 						if buffer[position] != rune('p') {
-							goto l86
+							goto l95
 						}
 						position++
-						goto l85
-					l86:
-						position, tokenIndex = position85, tokenIndex85
+						goto l94
+					l95:
+						position, tokenIndex = position94, tokenIndex94
 						if buffer[position] != rune('P') {
-							goto l80
+							goto l89
 						}
 						position++
 					}
-				l85:
+				l94:
 					{
-						position87, tokenIndex87 := position, tokenIndex
+						position96, tokenIndex96 := position, tokenIndex
 						if buffer[position] != rune('k') {
-							goto l88
+							goto l97
 						}
 						position++
-						goto l87
-					l88:
-						position, tokenIndex = position87, tokenIndex87
+						goto l96
+					l97:
+						position, tokenIndex = position96, tokenIndex96
 						if buffer[position] != rune('K') {
-							goto l80
+							goto l89
 						}
 						position++
 					}
-				l87:
+				l96:
 					{
-						add(ruleAction10, position)
+						add(ruleAction12, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l80
+						goto l89
 					}
 					if !_rules[ruleposfield]() {
-						goto l80
+						goto l89
 					}
 					{
-						position90, tokenIndex90 := position, tokenIndex
+						position99, tokenIndex99 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l90
+							goto l99
 						}
 						if !_rules[ruleallargs]() {
-							goto l90
+							goto l99
 						}
-						goto l91
-					l90:
-						position, tokenIndex = position90, tokenIndex90
+						goto l100
+					l99:
+						position, tokenIndex = position99, tokenIndex99
 					}
-				l91:
+				l100:
 					if !_rules[ruleclose]() {
-						goto l80
+						goto l89
 					}
 					{
-						add(ruleAction11, position)
+						add(ruleAction13, position)
 					}
-					goto l7
-				l80:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l89:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position94, tokenIndex94 := position, tokenIndex
+						position103, tokenIndex103 := position, tokenIndex
 						if buffer[position] != rune('p') {
-							goto l95
+							goto l104
 						}
 						position++
-						goto l94
-					l95:
-						position, tokenIndex = position94, tokenIndex94
+						goto l103
+					l104:
+						position, tokenIndex = position103, tokenIndex103
 						if buffer[position] != rune('P') {
-							goto l93
+							goto l102
 						}
 						position++
 					}
-				l94:
+				l103:
 					{
-						position96, tokenIndex96 := position, tokenIndex
+						position105, tokenIndex105 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l97
+							goto l106
 						}
 						position++
-						goto l96
-					l97:
-						position, tokenIndex = position96, tokenIndex96
+						goto l105
+					l106:
+						position, tokenIndex = position105, tokenIndex105
 						if buffer[position] != rune('E') {
-							goto l93
+							goto l102
 						}
 						position++
 					}
-				l96:
+				l105:
 					{
-						position98, tokenIndex98 := position, tokenIndex
+						position107, tokenIndex107 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l99
+							goto l108
 						}
 						position++
-						goto l98
-					l99:
-						position, tokenIndex = position98, tokenIndex98
+						goto l107
+					l108:
+						position, tokenIndex = position107, tokenIndex107
 						if buffer[position] != rune('R') {
-							goto l93
+							goto l102
 						}
 						position++
 					}
-				l98:
+				l107:
 					{
-						position100, tokenIndex100 := position, tokenIndex
+						position109, tokenIndex109 := position, tokenIndex
 						if buffer[position] != rune('c') {
-							goto l101
+							goto l110
 						}
 						position++
-						goto l100
-					l101:
-						position, tokenIndex = position100, tokenIndex100
+						goto l109
+					l110:
+						position, tokenIndex = position109, tokenIndex109
 						if buffer[position] != rune('C') {
-							goto l93
+							goto l102
 						}
 						position++
 					}
-				l100:
+				l109:
 					{
-						position102, tokenIndex102 := position, tokenIndex
+						position111, tokenIndex111 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l103
+							goto l112
 						}
 						position++
-						goto l102
-					l103:
-						position, tokenIndex = position102, tokenIndex102
+						goto l111
+					l112:
+						position, tokenIndex = position111, tokenIndex111
 						if buffer[position] != rune('E') {
-							goto l93
+							goto l102
 						}
 						position++
 					}
-				l102:
+				l111:
 					{
-						position104, tokenIndex104 := position, tokenIndex
+						position113, tokenIndex113 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l105
+							goto l114
 						}
 						position++
-						goto l104
-					l105:
-						position, tokenIndex = position104, tokenIndex104
+						goto l113
+					l114:
+						position, tokenIndex = position113, tokenIndex113
 						if buffer[position] != rune('N') {
-							goto l93
+							goto l102
 						}
 						position++
 					}
-				l104:
+				l113:
 					{
-						position106, tokenIndex106 := position, tokenIndex
+						position115, tokenIndex115 := position, tokenIndex
 						if buffer[position] != rune('t') {
-							goto l107
+							goto l116
 						}
 						position++
-						goto l106
-					l107:
-						position, tokenIndex = position106, tokenIndex106
+						goto l115
+					l116:
+						position, tokenIndex = position115, tokenIndex115
 						if buffer[position] != rune('T') {
-							goto l93
+							goto l102
 						}
 						position++
 					}
-				l106:
+				l115:
 					{
-						position108, tokenIndex108 := position, tokenIndex
+						position117, tokenIndex117 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l109
+							goto l118
 						}
 						position++
-						goto l108
-					l109:
-						position, tokenIndex = position108, tokenIndex108
+						goto l117
+					l118:
+						position, tokenIndex = position117, tokenIndex117
 						if buffer[position] != rune('I') {
-							goto l93
+							goto l102
 						}
 						position++
 					}
-				l108:
+				l117:
 					{
-						position110, tokenIndex110 := position, tokenIndex
+						position119, tokenIndex119 := position, tokenIndex
 						if buffer[position] != rune('l') {
-							goto l111
+							goto l120
 						}
 						position++
-						goto l110
-					l111:
-						position, tokenIndex = position110, tokenIndex110
+						goto l119
+					l120:
+						position, tokenIndex = position119, tokenIndex119
 						if buffer[position] != rune('L') {
-							goto l93
+							goto l102
 						}
 						position++
 					}
-				l110:
+				l119:
 					{
-						position112, tokenIndex112 := position, tokenIndex
+						position121, tokenIndex121 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l113
+							goto l122
 						}
 						position++
-						goto l112
-					l113:
-						position, tokenIndex = position112, tokenIndex112
+						goto l121
+					l122:
+						position, tokenIndex = position121, tokenIndex121
 						if buffer[position] != rune('E') {
-							goto l93
+							goto l102
 						}
 						position++
 					}
-				l112:
+				l121:
 					{
-						add(ruleAction12, position)
+						add(ruleAction14, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l93
+						goto l102
 					}
 					if !_rules[ruleposfield]() {
-						goto l93
+						goto l102
 					}
 					{
-						position115, tokenIndex115 := position, tokenIndex
+						position124, tokenIndex124 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l115
+							goto l124
 						}
 						if !_rules[ruleallargs]() {
-							goto l115
+							goto l124
 						}
-						goto l116
-					l115:
-						position, tokenIndex = position115, tokenIndex115
+						goto l125
+					l124:
+						position, tokenIndex = position124, tokenIndex124
 					}
-				l116:
+				l125:
 					if !_rules[ruleclose]() {
-						goto l93
+						goto l102
 					}
 					{
-						add(ruleAction13, position)
+						add(ruleAction15, position)
 					}
-					goto l7
-				l93:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l102:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position119, tokenIndex119 := position, tokenIndex
+						position128, tokenIndex128 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l120
+							goto l129
 						}
 						position++
-						goto l119
-					l120:
-						position, tokenIndex = position119, tokenIndex119
+						goto l128
+					l129:
+						position, tokenIndex = position128, tokenIndex128
 						if buffer[position] != rune('R') {
-							goto l118
+							goto l127
 						}
 						position++
 					}
-				l119:
+				l128:
 					{
-						position121, tokenIndex121 := position, tokenIndex
+						position130, tokenIndex130 := position, tokenIndex
 						if buffer[position] != rune('o') {
-							goto l122
+							goto l131
 						}
 						position++
-						goto l121
-					l122:
-						position, tokenIndex = position121, tokenIndex121
+						goto l130
+					l131:
+						position, tokenIndex = position130, tokenIndex130
 						if buffer[position] != rune('O') {
-							goto l118
+							goto l127
 						}
 						position++
 					}
-				l121:
+				l130:
 					{
-						position123, tokenIndex123 := position, tokenIndex
+						position132, tokenIndex132 := position, tokenIndex
 						if buffer[position] != rune('w') {
-							goto l124
+							goto l133
 						}
 						position++
-						goto l123
-					l124:
-						position, tokenIndex = position123, tokenIndex123
+						goto l132
+					l133:
+						position, tokenIndex = position132, tokenIndex132
 						if buffer[position] != rune('W') {
-							goto l118
+							goto l127
 						}
 						position++
 					}
-				l123:
+				l132:
 					{
-						position125, tokenIndex125 := position, tokenIndex
+						position134, tokenIndex134 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l126
+							goto l135
 						}
 						position++
-						goto l125
-					l126:
-						position, tokenIndex = position125, tokenIndex125
+						goto l134
+					l135:
+						position, tokenIndex = position134, tokenIndex134
 						if buffer[position] != rune('S') {
-							goto l118
+							goto l127
 						}
 						position++
 					}
-				l125:
+				l134:
 					{
-						add(ruleAction14, position)
+						add(ruleAction16, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l118
+						goto l127
 					}
 					if !_rules[ruleposfield]() {
-						goto l118
+						goto l127
 					}
 					{
-						position128, tokenIndex128 := position, tokenIndex
+						position137, tokenIndex137 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l128
+							goto l137
 						}
 						if !_rules[ruleallargs]() {
-							goto l128
+							goto l137
 						}
-						goto l129
-					l128:
-						position, tokenIndex = position128, tokenIndex128
+						goto l138
+					l137:
+						position, tokenIndex = position137, tokenIndex137
 					}
-				l129:
+				l138:
 					if !_rules[ruleclose]() {
-						goto l118
+						goto l127
 					}
 					{
-						add(ruleAction15, position)
+						add(ruleAction17, position)
 					}
-					goto l7
-				l118:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l127:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position132, tokenIndex132 := position, tokenIndex
+						position141, tokenIndex141 := position, tokenIndex
 						if buffer[position] != rune('m') {
-							goto l133
+							goto l142
 						}
 						position++
-						goto l132
-					l133:
-						position, tokenIndex = position132, tokenIndex132
+						goto l141
+					l142:
+						position, tokenIndex = position141, tokenIndex141
 						if buffer[position] != rune('M') {
-							goto l131
+							goto l140
 						}
 						position++
 					}
-				l132:
+				l141:
 					{
-						position134, tokenIndex134 := position, tokenIndex
+						position143, tokenIndex143 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l135
+							goto l144
 						}
 						position++
-						goto l134
-					l135:
-						position, tokenIndex = position134, tokenIndex134
+						goto l143
+					l144:
+						position, tokenIndex = position143, tokenIndex143
 						if buffer[position] != rune('I') {
-							goto l131
+							goto l140
 						}
 						position++
 					}
-				l134:
+				l143:
 					{
-						position136, tokenIndex136 := position, tokenIndex
+						position145, tokenIndex145 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l137
+							goto l146
 						}
 						position++
-						goto l136
-					l137:
-						position, tokenIndex = position136, tokenIndex136
+						goto l145
+					l146:
+						position, tokenIndex = position145, tokenIndex145
 						if buffer[position] != rune('N') {
-							goto l131
+							goto l140
 						}
 						position++
 					}
-				l136:
+				l145:
 					{
-						add(ruleAction16, position)
+						add(ruleAction18, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l131
+						goto l140
 					}
 					if !_rules[ruleposfield]() {
-						goto l131
+						goto l140
 					}
 					{
-						position139, tokenIndex139 := position, tokenIndex
+						position148, tokenIndex148 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l139
+							goto l148
 						}
 						if !_rules[ruleallargs]() {
-							goto l139
+							goto l148
 						}
-						goto l140
-					l139:
-						position, tokenIndex = position139, tokenIndex139
+						goto l149
+					l148:
+						position, tokenIndex = position148, tokenIndex148
 					}
-				l140:
+				l149:
 					if !_rules[ruleclose]() {
-						goto l131
+						goto l140
 					}
 					{
-						add(ruleAction17, position)
+						add(ruleAction19, position)
 					}
-					goto l7
-				l131:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l140:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position143, tokenIndex143 := position, tokenIndex
+						position152, tokenIndex152 := position, tokenIndex
 						if buffer[position] != rune('m') {
-							goto l144
+							goto l153
 						}
 						position++
-						goto l143
-					l144:
-						position, tokenIndex = position143, tokenIndex143
+						goto l152
+					l153:
+						position, tokenIndex = position152, tokenIndex152
 						if buffer[position] != rune('M') {
-							goto l142
+							goto l151
 						}
 						position++
 					}
-				l143:
+				l152:
 					{
-						position145, tokenIndex145 := position, tokenIndex
+						position154, tokenIndex154 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l146
+							goto l155
 						}
 						position++
-						goto l145
-					l146:
-						position, tokenIndex = position145, tokenIndex145
+						goto l154
+					l155:
+						position, tokenIndex = position154, tokenIndex154
 						if buffer[position] != rune('A') {
-							goto l142
+							goto l151
 						}
 						position++
 					}
-				l145:
+				l154:
 					{
-						position147, tokenIndex147 := position, tokenIndex
+						position156, tokenIndex156 := position, tokenIndex
 						if buffer[position] != rune('x') {
-							goto l148
+							goto l157
 						}
 						position++
-						goto l147
-					l148:
-						position, tokenIndex = position147, tokenIndex147
+						goto l156
+					l157:
+						position, tokenIndex = position156, tokenIndex156
 						if buffer[position] != rune('X') {
-							goto l142
+							goto l151
 						}
 						position++
 					}
-				l147:
+				l156:
 					{
-						add(ruleAction18, position)
+						add(ruleAction20, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l142
+						goto l151
 					}
 					if !_rules[ruleposfield]() {
-						goto l142
+						goto l151
 					}
 					{
-						position150, tokenIndex150 := position, tokenIndex
+						position159, tokenIndex159 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l150
+							goto l159
 						}
 						if !_rules[ruleallargs]() {
-							goto l150
+							goto l159
 						}
-						goto l151
-					l150:
-						position, tokenIndex = position150, tokenIndex150
+						goto l160
+					l159:
+						position, tokenIndex = position159, tokenIndex159
 					}
-				l151:
+				l160:
 					if !_rules[ruleclose]() {
-						goto l142
+						goto l151
 					}
 					{
-						add(ruleAction19, position)
+						add(ruleAction21, position)
 					}
-					goto l7
-				l142:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l151:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position154, tokenIndex154 := position, tokenIndex
+						position163, tokenIndex163 := position, tokenIndex
 						if buffer[position] != rune('s') {
-							goto l155
+							goto l164
 						}
 						position++
-						goto l154
-					l155:
-						position, tokenIndex = position154, tokenIndex154
+						goto l163
+					l164:
+						position, tokenIndex = position163, tokenIndex163
 						if buffer[position] != rune('S') {
-							goto l153
+							goto l162
 						}
 						position++
 					}
-				l154:
+				l163:
 					{
-						position156, tokenIndex156 := position, tokenIndex
+						position165, tokenIndex165 := position, tokenIndex
 						if buffer[position] != rune('u') {
-							goto l157
+							goto l166
 						}
 						position++
-						goto l156
-					l157:
-						position, tokenIndex = position156, tokenIndex156
+						goto l165
+					l166:
+						position, tokenIndex = position165, tokenIndex165
 						if buffer[position] != rune('U') {
-							goto l153
+							goto l162
 						}
 						position++
 					}
-				l156:
+				l165:
 					{
-						position158, tokenIndex158 := position, tokenIndex
+						position167, tokenIndex167 := position, tokenIndex
 						if buffer[position] != rune('m') {
-							goto l159
+							goto l168
 						}
 						position++
-						goto l158
-					l159:
-						position, tokenIndex = position158, tokenIndex158
+						goto l167
+					l168:
+						position, tokenIndex = position167, tokenIndex167
 						if buffer[position] != rune('M') {
-							goto l153
+							goto l162
 						}
 						position++
 					}
-				l158:
+				l167:
 					{
-						add(ruleAction20, position)
+						add(ruleAction22, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l153
+						goto l162
 					}
 					if !_rules[ruleposfield]() {
-						goto l153
+						goto l162
 					}
 					{
-						position161, tokenIndex161 := position, tokenIndex
+						position170, tokenIndex170 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l161
+							goto l170
 						}
 						if !_rules[ruleallargs]() {
-							goto l161
+							goto l170
 						}
-						goto l162
-					l161:
-						position, tokenIndex = position161, tokenIndex161
+						goto l171
+					l170:
+						position, tokenIndex = position170, tokenIndex170
 					}
-				l162:
+				l171:
 					if !_rules[ruleclose]() {
-						goto l153
+						goto l162
 					}
 					{
-						add(ruleAction21, position)
+						add(ruleAction23, position)
 					}
-					goto l7
-				l153:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l162:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position165, tokenIndex165 := position, tokenIndex
+						position174, tokenIndex174 := position, tokenIndex
 						if buffer[position] != rune('r') {
-							goto l166
+							goto l175
 						}
 						position++
-						goto l165
-					l166:
-						position, tokenIndex = position165, tokenIndex165
+						goto l174
+					l175:
+						position, tokenIndex = position174, tokenIndex174
 						if buffer[position] != rune('R') {
-							goto l164
+							goto l173
 						}
 						position++
 					}
-				l165:
+				l174:
 					{
-						position167, tokenIndex167 := position, tokenIndex
+						position176, tokenIndex176 := position, tokenIndex
 						if buffer[position] != rune('a') {
-							goto l168
+							goto l177
 						}
 						position++
-						goto l167
-					l168:
-						position, tokenIndex = position167, tokenIndex167
+						goto l176
+					l177:
+						position, tokenIndex = position176, tokenIndex176
 						if buffer[position] != rune('A') {
-							goto l164
+							goto l173
 						}
 						position++
 					}
-				l167:
+				l176:
 					{
-						position169, tokenIndex169 := position, tokenIndex
+						position178, tokenIndex178 := position, tokenIndex
 						if buffer[position] != rune('n') {
-							goto l170
+							goto l179
 						}
 						position++
-						goto l169
-					l170:
-						position, tokenIndex = position169, tokenIndex169
+						goto l178
+					l179:
+						position, tokenIndex = position178, tokenIndex178
 						if buffer[position] != rune('N') {
-							goto l164
+							goto l173
 						}
 						position++
 					}
-				l169:
+				l178:
 					{
-						position171, tokenIndex171 := position, tokenIndex
+						position180, tokenIndex180 := position, tokenIndex
 						if buffer[position] != rune('g') {
-							goto l172
+							goto l181
 						}
 						position++
-						goto l171
-					l172:
-						position, tokenIndex = position171, tokenIndex171
+						goto l180
+					l181:
+						position, tokenIndex = position180, tokenIndex180
 						if buffer[position] != rune('G') {
-							goto l164
+							goto l173
 						}
 						position++
 					}
-				l171:
+				l180:
 					{
-						position173, tokenIndex173 := position, tokenIndex
+						position182, tokenIndex182 := position, tokenIndex
 						if buffer[position] != rune('e') {
-							goto l174
+							goto l183
 						}
 						position++
-						goto l173
-					l174:
-						position, tokenIndex = position173, tokenIndex173
+						goto l182
+					l183:
+						position, tokenIndex = position182, tokenIndex182
 						if buffer[position] != rune('E') {
-							goto l164
+							goto l173
 						}
 						position++
 					}
-				l173:
+				l182:
 					{
-						add(ruleAction22, position)
+						add(ruleAction24, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l164
+						goto l173
 					}
 					if !_rules[rulefield]() {
-						goto l164
+						goto l173
 					}
 					if !_rules[ruleeq]() {
-						goto l164
+						goto l173
 					}
 					if !_rules[rulevalue]() {
-						goto l164
+						goto l173
 					}
 					if !_rules[rulecomma]() {
-						goto l164
+						goto l173
 					}
 					{
-						position176, tokenIndex176 := position, tokenIndex
+						position185, tokenIndex185 := position, tokenIndex
 						if buffer[position] != rune('f') {
-							goto l176
+							goto l185
+						}
+						position++
+						if buffer[position] != rune('r') {
+							goto l185
+						}
+						position++
+						if buffer[position] != rune('o') {
+							goto l185
+						}
+						position++
+						if buffer[position] != rune('m') {
+							goto l185
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l185
+						}
+						position++
+						goto l186
+					l185:
+						position, tokenIndex = position185, tokenIndex185
+					}
+				l186:
+					{
+						add(ruleAction25, position)
+					}
+					if !_rules[ruletimefmt]() {
+						goto l173
+					}
+					{
+						add(ruleAction26, position)
+					}
+					if !_rules[rulecomma]() {
+						goto l173
+					}
+					{
+						position189, tokenIndex189 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l189
+						}
+						position++
+						if buffer[position] != rune('o') {
+							goto l189
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l189
+						}
+						position++
+						goto l190
+					l189:
+						position, tokenIndex = position189, tokenIndex189
+					}
+				l190:
+					if !_rules[rulesp]() {
+						goto l173
+					}
+					{
+						add(ruleAction27, position)
+					}
+					if !_rules[ruletimefmt]() {
+						goto l173
+					}
+					{
+						add(ruleAction28, position)
+					}
+					if !_rules[ruleclose]() {
+						goto l173
+					}
+					{
+						add(ruleAction29, position)
+					}
+					goto l16
+				l173:
+					position, tokenIndex = position16, tokenIndex16
+					{
+						position195, tokenIndex195 := position, tokenIndex
+						if buffer[position] != rune('b') {
+							goto l196
+						}
+						position++
+						goto l195
+					l196:
+						position, tokenIndex = position195, tokenIndex195
+						if buffer[position] != rune('B') {
+							goto l194
+						}
+						position++
+					}
+				l195:
+					{
+						position197, tokenIndex197 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l198
+						}
+						position++
+						goto l197
+					l198:
+						position, tokenIndex = position197, tokenIndex197
+						if buffer[position] != rune('E') {
+							goto l194
+						}
+						position++
+					}
+				l197:
+					{
+						position199, tokenIndex199 := position, tokenIndex
+						if buffer[position] != rune('t') {
+							goto l200
+						}
+						position++
+						goto l199
+					l200:
+						position, tokenIndex = position199, tokenIndex199
+						if buffer[position] != rune('T') {
+							goto l194
+						}
+						position++
+					}
+				l199:
+					{
+						position201, tokenIndex201 := position, tokenIndex
+						if buffer[position] != rune('w') {
+							goto l202
+						}
+						position++
+						goto l201
+					l202:
+						position, tokenIndex = position201, tokenIndex201
+						if buffer[position] != rune('W') {
+							goto l194
+						}
+						position++
+					}
+				l201:
+					{
+						position203, tokenIndex203 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l204
 						}
 						position++
-						if buffer[position] != rune('r') {
-							goto l176
+						goto l203
+					l204:
+						position, tokenIndex = position203, tokenIndex203
+						if buffer[position] != rune('E') {
+							goto l194
 						}
 						position++
-						if buffer[position] != rune('o') {
-							goto l176
+					}
+				l203:
+					{
+						position205, tokenIndex205 := position, tokenIndex
+						if buffer[position] != rune('e') {
+							goto l206
 						}
 						position++
-						if buffer[position] != rune('m') {
-							goto l176
+						goto l205
+					l206:
+						position, tokenIndex = position205, tokenIndex205
+						if buffer[position] != rune('E') {
+							goto l194
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l176
+					}
+				l205:
+					{
+						position207, tokenIndex207 := position, tokenIndex
+						if buffer[position] != rune('n') {
+							goto l208
+						}
+						position++
+						goto l207
+					l208:
+						position, tokenIndex = position207, tokenIndex207
+						if buffer[position] != rune('N') {
+							goto l194
 						}
 						position++
-						goto l177
-					l176:
-						position, tokenIndex = position176, tokenIndex176
 					}
-				l177:
+				l207:
 					{
-						add(ruleAction23, position)
+						add(ruleAction30, position)
 					}
-					if !_rules[ruletimefmt]() {
-						goto l164
+					if !_rules[ruleopen]() {
+						goto l194
 					}
-					{
-						add(ruleAction24, position)
+					if !_rules[rulefield]() {
+						goto l194
 					}
 					if !_rules[rulecomma]() {
-						goto l164
+						goto l194
 					}
 					{
-						position180, tokenIndex180 := position, tokenIndex
-						if buffer[position] != rune('t') {
-							goto l180
-						}
-						position++
-						if buffer[position] != rune('o') {
-							goto l180
-						}
-						position++
-						if buffer[position] != rune('=') {
-							goto l180
-						}
-						position++
-						goto l181
-					l180:
-						position, tokenIndex = position180, tokenIndex180
-					}
-				l181:
-					if !_rules[rulesp]() {
-						goto l164
+						add(ruleAction31, position)
 					}
-					{
-						add(ruleAction25, position)
+					if !_rules[ruleitem]() {
+						goto l194
 					}
-					if !_rules[ruletimefmt]() {
-						goto l164
+					if !_rules[rulecomma]() {
+						goto l194
 					}
-					{
-						add(ruleAction26, position)
+					if !_rules[ruleitem]() {
+						goto l194
 					}
 					if !_rules[ruleclose]() {
-						goto l164
+						goto l194
 					}
 					{
-						add(ruleAction27, position)
+						add(ruleAction32, position)
 					}
-					goto l7
-				l164:
-					position, tokenIndex = position7, tokenIndex7
+					goto l16
+				l194:
+					position, tokenIndex = position16, tokenIndex16
 					{
-						position185 := position
+						position213 := position
 						if !_rules[ruleIDENT]() {
-							goto l5
+							goto l212
 						}
-						add(rulePegText, position185)
+						add(rulePegText, position213)
 					}
 					{
-						add(ruleAction28, position)
+						add(ruleAction33, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l5
+						goto l212
 					}
 					if !_rules[ruleallargs]() {
-						goto l5
+						goto l212
 					}
 					{
-						position187, tokenIndex187 := position, tokenIndex
+						position215, tokenIndex215 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l187
+							goto l215
 						}
-						goto l188
-					l187:
-						position, tokenIndex = position187, tokenIndex187
+						goto l216
+					l215:
+						position, tokenIndex = position215, tokenIndex215
 					}
-				l188:
+				l216:
 					if !_rules[ruleclose]() {
-						goto l5
+						goto l212
 					}
 					{
-						add(ruleAction29, position)
+						add(ruleAction34, position)
+					}
+					goto l16
+				l212:
+					position, tokenIndex = position16, tokenIndex16
+					{
+						position218 := position
+						if !_rules[ruleIDENT]() {
+							goto l14
+						}
+						add(rulePegText, position218)
+					}
+					{
+						position219, tokenIndex219 := position, tokenIndex
+						{
+							position220, tokenIndex220 := position, tokenIndex
+							if !_rules[rulecomma]() {
+								goto l221
+							}
+							goto l220
+						l221:
+							position, tokenIndex = position220, tokenIndex220
+							if !_rules[ruleclose]() {
+								goto l14
+							}
+						}
+					l220:
+						position, tokenIndex = position219, tokenIndex219
+					}
+					{
+						add(ruleAction35, position)
 					}
 				}
-			l7:
-				add(ruleCall, position6)
+			l16:
+				add(ruleCall, position15)
 			}
 			return true
-		l5:
-			position, tokenIndex = position5, tokenIndex5
+		l14:
+			position, tokenIndex = position14, tokenIndex14
 			return false
 		},
-		/* 2 allargs <- <((Call (comma Call)* (comma args)?) / args / sp)> */
+		/* 3 allargs <- <((Call (comma Call)* (comma args)?) / args / sp)> */
 		func() bool {
-			position190, tokenIndex190 := position, tokenIndex
+			position223, tokenIndex223 := position, tokenIndex
 			{
-				position191 := position
+				position224 := position
 				{
-					position192, tokenIndex192 := position, tokenIndex
+					position225, tokenIndex225 := position, tokenIndex
 					if !_rules[ruleCall]() {
-						goto l193
+						goto l226
 					}
-				l194:
+				l227:
 					{
-						position195, tokenIndex195 := position, tokenIndex
+						position228, tokenIndex228 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l195
+							goto l228
 						}
 						if !_rules[ruleCall]() {
-							goto l195
+							goto l228
 						}
-						goto l194
-					l195:
-						position, tokenIndex = position195, tokenIndex195
+						goto l227
+					l228:
+						position, tokenIndex = position228, tokenIndex228
 					}
 					{
-						position196, tokenIndex196 := position, tokenIndex
+						position229, tokenIndex229 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l196
+							goto l229
 						}
 						if !_rules[ruleargs]() {
-							goto l196
+							goto l229
 						}
-						goto l197
-					l196:
-						position, tokenIndex = position196, tokenIndex196
+						goto l230
+					l229:
+						position, tokenIndex = position229, tokenIndex229
 					}
-				l197:
-					goto l192
-				l193:
-					position, tokenIndex = position192, tokenIndex192
+				l230:
+					goto l225
+				l226:
+					position, tokenIndex = position225, tokenIndex225
 					if !_rules[ruleargs]() {
-						goto l198
+						goto l231
 					}
-					goto l192
-				l198:
-					position, tokenIndex = position192, tokenIndex192
+					goto l225
+				l231:
+					position, tokenIndex = position225, tokenIndex225
 					if !_rules[rulesp]() {
-						goto l190
+						goto l223
 					}
 				}
-			l192:
-				add(ruleallargs, position191)
+			l225:
+				add(ruleallargs, position224)
 			}
 			return true
-		l190:
-			position, tokenIndex = position190, tokenIndex190
+		l223:
+			position, tokenIndex = position223, tokenIndex223
 			return false
 		},
-		/* 3 args <- <(arg (comma args)? sp)> */
+		/* 4 args <- <(arg (comma args)? sp)> */
 		func() bool {
-			position199, tokenIndex199 := position, tokenIndex
+			position232, tokenIndex232 := position, tokenIndex
 			{
-				position200 := position
+				position233 := position
 				if !_rules[rulearg]() {
-					goto l199
+					goto l232
 				}
 				{
-					position201, tokenIndex201 := position, tokenIndex
+					position234, tokenIndex234 := position, tokenIndex
 					if !_rules[rulecomma]() {
-						goto l201
+						goto l234
 					}
 					if !_rules[ruleargs]() {
-						goto l201
+						goto l234
 					}
-					goto l202
-				l201:
-					position, tokenIndex = position201, tokenIndex201
+					goto l235
+				l234:
+					position, tokenIndex = position234, tokenIndex234
 				}
-			l202:
+			l235:
 				if !_rules[rulesp]() {
-					goto l199
+					goto l232
 				}
-				add(ruleargs, position200)
+				add(ruleargs, position233)
 			}
 			return true
-		l199:
-			position, tokenIndex = position199, tokenIndex199
+		l232:
+			position, tokenIndex = position232, tokenIndex232
 			return false
 		},
-		/* 4 arg <- <((field eq value) / (field sp COND sp value) / conditional)> */
+		/* 5 arg <- <((field eq value) / (field sp COND sp value) / conditional)> */
 		func() bool {
-			position203, tokenIndex203 := position, tokenIndex
+			position236, tokenIndex236 := position, tokenIndex
 			{
-				position204 := position
+				position237 := position
 				{
-					position205, tokenIndex205 := position, tokenIndex
+					position238, tokenIndex238 := position, tokenIndex
 					if !_rules[rulefield]() {
-						goto l206
+						goto l239
 					}
 					if !_rules[ruleeq]() {
-						goto l206
+						goto l239
 					}
 					if !_rules[rulevalue]() {
-						goto l206
+						goto l239
 					}
-					goto l205
-				l206:
-					position, tokenIndex = position205, tokenIndex205
+					goto l238
+				l239:
+					position, tokenIndex = position238, tokenIndex238
 					if !_rules[rulefield]() {
-						goto l207
+						goto l240
 					}
 					if !_rules[rulesp]() {
-						goto l207
+						goto l240
 					}
 					{
-						position208 := position
+						position241 := position
 						{
-							position209, tokenIndex209 := position, tokenIndex
+							position242, tokenIndex242 := position, tokenIndex
 							if buffer[position] != rune('>') {
-								goto l210
+								goto l243
 							}
 							position++
 							if buffer[position] != rune('<') {
-								goto l210
+								goto l243
 							}
 							position++
 							{
-								add(ruleAction30, position)
+								add(ruleAction36, position)
 							}
-							goto l209
-						l210:
-							position, tokenIndex = position209, tokenIndex209
+							goto l242
+						l243:
+							position, tokenIndex = position242, tokenIndex242
 							if buffer[position] != rune('<') {
-								goto l212
+								goto l245
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l212
+								goto l245
 							}
 							position++
 							{
-								add(ruleAction31, position)
+								add(ruleAction37, position)
 							}
-							goto l209
-						l212:
-							position, tokenIndex = position209, tokenIndex209
+							goto l242
+						l245:
+							position, tokenIndex = position242, tokenIndex242
 							if buffer[position] != rune('>') {
-								goto l214
+								goto l247
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l214
+								goto l247
 							}
 							position++
 							{
-								add(ruleAction32, position)
+								add(ruleAction38, position)
 							}
-							goto l209
-						l214:
-							position, tokenIndex = position209, tokenIndex209
+							goto l242
+						l247:
+							position, tokenIndex = position242, tokenIndex242
 							if buffer[position] != rune('=') {
-								goto l216
+								goto l249
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l216
+								goto l249
 							}
 							position++
 							{
-								add(ruleAction33, position)
+								add(ruleAction39, position)
 							}
-							goto l209
-						l216:
-							position, tokenIndex = position209, tokenIndex209
+							goto l242
+						l249:
+							position, tokenIndex = position242, tokenIndex242
 							if buffer[position] != rune('!') {
-								goto l218
+								goto l251
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l218
+								goto l251
 							}
 							position++
 							{
-								add(ruleAction34, position)
+								add(ruleAction40, position)
 							}
-							goto l209
-						l218:
-							position, tokenIndex = position209, tokenIndex209
+							goto l242
+						l251:
+							position, tokenIndex = position242, tokenIndex242
 							if buffer[position] != rune('<') {
-								goto l220
+								goto l253
 							}
 							position++
 							{
-								add(ruleAction35, position)
+								add(ruleAction41, position)
 							}
-							goto l209
-						l220:
-							position, tokenIndex = position209, tokenIndex209
+							goto l242
+						l253:
+							position, tokenIndex = position242, tokenIndex242
 							if buffer[position] != rune('>') {
-								goto l207
+								goto l255
 							}
 							position++
 							{
-								add(ruleAction36, position)
+								add(ruleAction42, position)
+							}
+							goto l242
+						l255:
+							position, tokenIndex = position242, tokenIndex242
+							if buffer[position] != rune('i') {
+								goto l240
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l240
+							}
+							position++
+							{
+								add(ruleAction43, position)
 							}
 						}
-					l209:
-						add(ruleCOND, position208)
+					l242:
+						add(ruleCOND, position241)
 					}
 					if !_rules[rulesp]() {
-						goto l207
+						goto l240
 					}
 					if !_rules[rulevalue]() {
-						goto l207
+						goto l240
 					}
-					goto l205
-				l207:
-					position, tokenIndex = position205, tokenIndex205
+					goto l238
+				l240:
+					position, tokenIndex = position238, tokenIndex238
 					{
-						position223 := position
+						position258 := position
 						{
-							add(ruleAction37, position)
+							add(ruleAction44, position)
 						}
 						if !_rules[rulecondint]() {
-							goto l203
+							goto l236
 						}
 						if !_rules[rulecondLT]() {
-							goto l203
+							goto l236
 						}
 						{
-							position225 := position
+							position260 := position
 							{
-								position226 := position
+								position261 := position
 								if !_rules[rulefieldExpr]() {
-									goto l203
+									goto l236
 								}
-								add(rulePegText, position226)
+								add(rulePegText, position261)
 							}
 							if !_rules[rulesp]() {
-								goto l203
+								goto l236
 							}
 							{
-								add(ruleAction41, position)
+								add(ruleAction48, position)
 							}
-							add(rulecondfield, position225)
+							add(rulecondfield, position260)
 						}
 						if !_rules[rulecondLT]() {
-							goto l203
+							goto l236
 						}
 						if !_rules[rulecondint]() {
-							goto l203
+							goto l236
 						}
 						{
-							add(ruleAction38, position)
+							add(ruleAction45, position)
 						}
-						add(ruleconditional, position223)
+						add(ruleconditional, position258)
 					}
 				}
-			l205:
-				add(rulearg, position204)
+			l238:
+				add(rulearg, position237)
 			}
 			return true
-		l203:
-			position, tokenIndex = position203, tokenIndex203
+		l236:
+			position, tokenIndex = position236, tokenIndex236
 			return false
 		},
-		/* 5 COND <- <(('>' '<' Action30) / ('<' '=' Action31) / ('>' '=' Action32) / ('=' '=' Action33) / ('!' '=' Action34) / ('<' Action35) / ('>' Action36))> */
+		/* 6 COND <- <(('>' '<' Action36) / ('<' '=' Action37) / ('>' '=' Action38) / ('=' '=' Action39) / ('!' '=' Action40) / ('<' Action41) / ('>' Action42) / ('i' 'n' Action43))> */
 		nil,
-		/* 6 conditional <- <(Action37 condint condLT condfield condLT condint Action38)> */
+		/* 7 conditional <- <(Action44 condint condLT condfield condLT condint Action45)> */
 		nil,
-		/* 7 condint <- <(<decimal> sp Action39)> */
+		/* 8 condint <- <(<decimal> sp Action46)> */
 		func() bool {
-			position231, tokenIndex231 := position, tokenIndex
+			position266, tokenIndex266 := position, tokenIndex
 			{
-				position232 := position
+				position267 := position
 				{
-					position233 := position
+					position268 := position
 					if !_rules[ruledecimal]() {
-						goto l231
+						goto l266
 					}
-					add(rulePegText, position233)
+					add(rulePegText, position268)
 				}
 				if !_rules[rulesp]() {
-					goto l231
+					goto l266
 				}
 				{
-					add(ruleAction39, position)
+					add(ruleAction46, position)
 				}
-				add(rulecondint, position232)
+				add(rulecondint, position267)
 			}
 			return true
-		l231:
-			position, tokenIndex = position231, tokenIndex231
+		l266:
+			position, tokenIndex = position266, tokenIndex266
 			return false
 		},
-		/* 8 condLT <- <(<(('<' '=') / '<')> sp Action40)> */
+		/* 9 condLT <- <(<(('<' '=') / '<')> sp Action47)> */
 		func() bool {
-			position235, tokenIndex235 := position, tokenIndex
+			position270, tokenIndex270 := position, tokenIndex
 			{
-				position236 := position
+				position271 := position
 				{
-					position237 := position
+					position272 := position
 					{
-						position238, tokenIndex238 := position, tokenIndex
+						position273, tokenIndex273 := position, tokenIndex
 						if buffer[position] != rune('<') {
-							goto l239
+							goto l274
 						}
 						position++
 						if buffer[position] != rune('=') {
-							goto l239
+							goto l274
 						}
 						position++
-						goto l238
-					l239:
-						position, tokenIndex = position238, tokenIndex238
+						goto l273
+					l274:
+						position, tokenIndex = position273, tokenIndex273
 						if buffer[position] != rune('<') {
-							goto l235
+							goto l270
 						}
 						position++
 					}
-				l238:
-					add(rulePegText, position237)
+				l273:
+					add(rulePegText, position272)
 				}
 				if !_rules[rulesp]() {
-					goto l235
+					goto l270
 				}
 				{
-					add(ruleAction40, position)
+					add(ruleAction47, position)
 				}
-				add(rulecondLT, position236)
+				add(rulecondLT, position271)
 			}
 			return true
-		l235:
-			position, tokenIndex = position235, tokenIndex235
+		l270:
+			position, tokenIndex = position270, tokenIndex270
 			return false
 		},
-		/* 9 condfield <- <(<fieldExpr> sp Action41)> */
+		/* 10 condfield <- <(<fieldExpr> sp Action48)> */
 		nil,
-		/* 10 value <- <(item / (lbrack Action42 items rbrack Action43))> */
+		/* 11 value <- <(item / (lbrack Action49 items rbrack Action50))> */
 		func() bool {
-			position242, tokenIndex242 := position, tokenIndex
+			position277, tokenIndex277 := position, tokenIndex
 			{
-				position243 := position
+				position278 := position
 				{
-					position244, tokenIndex244 := position, tokenIndex
+					position279, tokenIndex279 := position, tokenIndex
 					if !_rules[ruleitem]() {
-						goto l245
+						goto l280
 					}
-					goto l244
-				l245:
-					position, tokenIndex = position244, tokenIndex244
+					goto l279
+				l280:
+					position, tokenIndex = position279, tokenIndex279
 					{
-						position246 := position
+						position281 := position
 						if buffer[position] != rune('[') {
-							goto l242
+							goto l277
 						}
 						position++
 						if !_rules[rulesp]() {
-							goto l242
+							goto l277
 						}
-						add(rulelbrack, position246)
+						add(rulelbrack, position281)
 					}
 					{
-						add(ruleAction42, position)
+						add(ruleAction49, position)
 					}
 					if !_rules[ruleitems]() {
-						goto l242
+						goto l277
 					}
 					{
-						position248 := position
+						position283 := position
 						if !_rules[rulesp]() {
-							goto l242
+							goto l277
 						}
 						if buffer[position] != rune(']') {
-							goto l242
+							goto l277
 						}
 						position++
 						if !_rules[rulesp]() {
-							goto l242
+							goto l277
 						}
-						add(rulerbrack, position248)
+						add(rulerbrack, position283)
 					}
 					{
-						add(ruleAction43, position)
+						add(ruleAction50, position)
 					}
 				}
-			l244:
-				add(rulevalue, position243)
+			l279:
+				add(rulevalue, position278)
 			}
 			return true
-		l242:
-			position, tokenIndex = position242, tokenIndex242
+		l277:
+			position, tokenIndex = position277, tokenIndex277
 			return false
 		},
-		/* 11 items <- <(item (comma items)?)> */
+		/* 12 items <- <(item (comma items)?)> */
 		func() bool {
-			position250, tokenIndex250 := position, tokenIndex
+			position285, tokenIndex285 := position, tokenIndex
 			{
-				position251 := position
+				position286 := position
 				if !_rules[ruleitem]() {
-					goto l250
+					goto l285
 				}
 				{
-					position252, tokenIndex252 := position, tokenIndex
+					position287, tokenIndex287 := position, tokenIndex
 					if !_rules[rulecomma]() {
-						goto l252
+						goto l287
 					}
 					if !_rules[ruleitems]() {
-						goto l252
+						goto l287
 					}
-					goto l253
-				l252:
-					position, tokenIndex = position252, tokenIndex252
+					goto l288
+				l287:
+					position, tokenIndex = position287, tokenIndex287
 				}
-			l253:
-				add(ruleitems, position251)
+			l288:
+				add(ruleitems, position286)
 			}
 			return true
-		l250:
-			position, tokenIndex = position250, tokenIndex250
+		l285:
+			position, tokenIndex = position285, tokenIndex285
 			return false
 		},
-		/* 12 item <- <(('n' 'u' 'l' 'l' &(comma / close) Action44) / ('t' 'r' 'u' 'e' &(comma / close) Action45) / ('f' 'a' 'l' 's' 'e' &(comma / close) Action46) / ('$' <variable> Action47) / (timefmt Action48) / (timestampfmt Action49) / (<decimal> Action50) / (<IDENT> Action51 open allargs comma? close Action52) / (<([a-z] / [A-Z] / [0-9] / '-' / '_' / ':')+> Action53) / (<('"' doublequotedstring '"')> Action54) / (<('\'' singlequotedstring '\'')> Action55))> */
+		/* 13 item <- <(('n' 'u' 'l' 'l' &(comma / close) Action51) / ('t' 'r' 'u' 'e' &(comma / close) Action52) / ('f' 'a' 'l' 's' 'e' &(comma / close) Action53) / ('$' <variable> Action54) / (timefmt Action55) / (timestampfmt Action56) / (<decimal> Action57) / (<IDENT> Action58 open allargs comma? close Action59) / (<([a-z] / [A-Z] / [0-9] / '-' / '_' / ':')+> Action60) / (<('"' doublequotedstring '"')> Action61) / (<('\'' singlequotedstring '\'')> Action62))> */
 		func() bool {
-			position254, tokenIndex254 := position, tokenIndex
+			position289, tokenIndex289 := position, tokenIndex
 			{
-				position255 := position
+				position290 := position
 				{
-					position256, tokenIndex256 := position, tokenIndex
+					position291, tokenIndex291 := position, tokenIndex
 					if buffer[position] != rune('n') {
-						goto l257
+						goto l292
 					}
 					position++
 					if buffer[position] != rune('u') {
-						goto l257
+						goto l292
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l257
+						goto l292
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l257
+						goto l292
 					}
 					position++
 					{
-						position258, tokenIndex258 := position, tokenIndex
+						position293, tokenIndex293 := position, tokenIndex
 						{
-							position259, tokenIndex259 := position, tokenIndex
+							position294, tokenIndex294 := position, tokenIndex
 							if !_rules[rulecomma]() {
-								goto l260
+								goto l295
 							}
-							goto l259
-						l260:
-							position, tokenIndex = position259, tokenIndex259
+							goto l294
+						l295:
+							position, tokenIndex = position294, tokenIndex294
 							if !_rules[ruleclose]() {
-								goto l257
+								goto l292
 							}
 						}
-					l259:
-						position, tokenIndex = position258, tokenIndex258
+					l294:
+						position, tokenIndex = position293, tokenIndex293
 					}
 					{
-						add(ruleAction44, position)
+						add(ruleAction51, position)
 					}
-					goto l256
-				l257:
-					position, tokenIndex = position256, tokenIndex256
+					goto l291
+				l292:
+					position, tokenIndex = position291, tokenIndex291
 					if buffer[position] != rune('t') {
-						goto l262
+						goto l297
 					}
 					position++
 					if buffer[position] != rune('r') {
-						goto l262
+						goto l297
 					}
 					position++
 					if buffer[position] != rune('u') {
-						goto l262
+						goto l297
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l262
+						goto l297
 					}
 					position++
 					{
-						position263, tokenIndex263 := position, tokenIndex
+						position298, tokenIndex298 := position, tokenIndex
 						{
-							position264, tokenIndex264 := position, tokenIndex
+							position299, tokenIndex299 := position, tokenIndex
 							if !_rules[rulecomma]() {
-								goto l265
+								goto l300
 							}
-							goto l264
-						l265:
-							position, tokenIndex = position264, tokenIndex264
+							goto l299
+						l300:
+							position, tokenIndex = position299, tokenIndex299
 							if !_rules[ruleclose]() {
-								goto l262
+								goto l297
 							}
 						}
-					l264:
-						position, tokenIndex = position263, tokenIndex263
+					l299:
+						position, tokenIndex = position298, tokenIndex298
 					}
 					{
-						add(ruleAction45, position)
+						add(ruleAction52, position)
 					}
-					goto l256
-				l262:
-					position, tokenIndex = position256, tokenIndex256
+					goto l291
+				l297:
+					position, tokenIndex = position291, tokenIndex291
 					if buffer[position] != rune('f') {
-						goto l267
+						goto l302
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l267
+						goto l302
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l267
+						goto l302
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l267
+						goto l302
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l267
+						goto l302
 					}
 					position++
 					{
-						position268, tokenIndex268 := position, tokenIndex
+						position303, tokenIndex303 := position, tokenIndex
 						{
-							position269, tokenIndex269 := position, tokenIndex
+							position304, tokenIndex304 := position, tokenIndex
 							if !_rules[rulecomma]() {
-								goto l270
+								goto l305
 							}
-							goto l269
-						l270:
-							position, tokenIndex = position269, tokenIndex269
+							goto l304
+						l305:
+							position, tokenIndex = position304, tokenIndex304
 							if !_rules[ruleclose]() {
-								goto l267
+								goto l302
 							}
 						}
-					l269:
-						position, tokenIndex = position268, tokenIndex268
+					l304:
+						position, tokenIndex = position303, tokenIndex303
 					}
 					{
-						add(ruleAction46, position)
+						add(ruleAction53, position)
 					}
-					goto l256
-				l267:
-					position, tokenIndex = position256, tokenIndex256
+					goto l291
+				l302:
+					position, tokenIndex = position291, tokenIndex291
 					if buffer[position] != rune('$') {
-						goto l272
+						goto l307
 					}
 					position++
 					{
-						position273 := position
+						position308 := position
 						{
-							position274 := position
+							position309 := position
 							{
-								position275, tokenIndex275 := position, tokenIndex
+								position310, tokenIndex310 := position, tokenIndex
 								if c := buffer[position]; c < rune('a') || c > rune('z') {
-									goto l276
+									goto l311
 								}
 								position++
-								goto l275
-							l276:
-								position, tokenIndex = position275, tokenIndex275
+								goto l310
+							l311:
+								position, tokenIndex = position310, tokenIndex310
 								if c := buffer[position]; c < rune('A') || c > rune('Z') {
-									goto l277
+									goto l312
 								}
 								position++
-								goto l275
-							l277:
-								position, tokenIndex = position275, tokenIndex275
+								goto l310
+							l312:
+								position, tokenIndex = position310, tokenIndex310
 								if buffer[position] != rune('_') {
-									goto l272
+									goto l307
 								}
 								position++
 							}
-						l275:
-						l278:
+						l310:
+						l313:
 							{
-								position279, tokenIndex279 := position, tokenIndex
+								position314, tokenIndex314 := position, tokenIndex
 								{
-									position280, tokenIndex280 := position, tokenIndex
+									position315, tokenIndex315 := position, tokenIndex
 									if c := buffer[position]; c < rune('a') || c > rune('z') {
-										goto l281
+										goto l316
 									}
 									position++
-									goto l280
-								l281:
-									position, tokenIndex = position280, tokenIndex280
+									goto l315
+								l316:
+									position, tokenIndex = position315, tokenIndex315
 									if c := buffer[position]; c < rune('A') || c > rune('Z') {
-										goto l282
+										goto l317
 									}
 									position++
-									goto l280
-								l282:
-									position, tokenIndex = position280, tokenIndex280
+									goto l315
+								l317:
+									position, tokenIndex = position315, tokenIndex315
 									if c := buffer[position]; c < rune('0') || c > rune('9') {
-										goto l283
+										goto l318
 									}
 									position++
-									goto l280
-								l283:
-									position, tokenIndex = position280, tokenIndex280
+									goto l315
+								l318:
+									position, tokenIndex = position315, tokenIndex315
 									if buffer[position] != rune('_') {
-										goto l284
+										goto l319
 									}
 									position++
-									goto l280
-								l284:
-									position, tokenIndex = position280, tokenIndex280
+									goto l315
+								l319:
+									position, tokenIndex = position315, tokenIndex315
 									if buffer[position] != rune('-') {
-										goto l279
+										goto l314
 									}
 									position++
 								}
-							l280:
-								goto l278
-							l279:
-								position, tokenIndex = position279, tokenIndex279
+							l315:
+								goto l313
+							l314:
+								position, tokenIndex = position314, tokenIndex314
 							}
-							add(rulevariable, position274)
+							add(rulevariable, position309)
 						}
-						add(rulePegText, position273)
+						add(rulePegText, position308)
 					}
 					{
-						add(ruleAction47, position)
+						add(ruleAction54, position)
 					}
-					goto l256
-				l272:
-					position, tokenIndex = position256, tokenIndex256
+					goto l291
+				l307:
+					position, tokenIndex = position291, tokenIndex291
 					if !_rules[ruletimefmt]() {
-						goto l286
+						goto l321
 					}
 					{
-						add(ruleAction48, position)
+						add(ruleAction55, position)
 					}
-					goto l256
-				l286:
-					position, tokenIndex = position256, tokenIndex256
+					goto l291
+				l321:
+					position, tokenIndex = position291, tokenIndex291
 					{
-						position289 := position
+						position324 := position
 						{
-							position290, tokenIndex290 := position, tokenIndex
+							position325, tokenIndex325 := position, tokenIndex
 							if buffer[position] != rune('"') {
-								goto l291
+								goto l326
 							}
 							position++
 							{
-								position292 := position
+								position327 := position
 								if !_rules[ruletimestampbasicfmt]() {
-									goto l291
+									goto l326
 								}
-								add(rulePegText, position292)
+								add(rulePegText, position327)
 							}
 							if buffer[position] != rune('"') {
-								goto l291
+								goto l326
 							}
 							position++
-							goto l290
-						l291:
-							position, tokenIndex = position290, tokenIndex290
+							goto l325
+						l326:
+							position, tokenIndex = position325, tokenIndex325
 							if buffer[position] != rune('\'') {
-								goto l293
+								goto l328
 							}
 							position++
 							{
-								position294 := position
+								position329 := position
 								if !_rules[ruletimestampbasicfmt]() {
-									goto l293
+									goto l328
 								}
-								add(rulePegText, position294)
+								add(rulePegText, position329)
 							}
 							if buffer[position] != rune('\'') {
-								goto l293
+								goto l328
 							}
 							position++
-							goto l290
-						l293:
-							position, tokenIndex = position290, tokenIndex290
+							goto l325
+						l328:
+							position, tokenIndex = position325, tokenIndex325
 							{
-								position295 := position
+								position330 := position
 								if !_rules[ruletimestampbasicfmt]() {
-									goto l288
+									goto l323
 								}
-								add(rulePegText, position295)
+								add(rulePegText, position330)
 							}
 						}
-					l290:
-						add(ruletimestampfmt, position289)
+					l325:
+						add(ruletimestampfmt, position324)
 					}
 					{
-						add(ruleAction49, position)
+						add(ruleAction56, position)
 					}
-					goto l256
-				l288:
-					position, tokenIndex = position256, tokenIndex256
+					goto l291
+				l323:
+					position, tokenIndex = position291, tokenIndex291
 					{
-						position298 := position
+						position333 := position
 						if !_rules[ruledecimal]() {
-							goto l297
+							goto l332
 						}
-						add(rulePegText, position298)
+						add(rulePegText, position333)
 					}
 					{
-						add(ruleAction50, position)
+						add(ruleAction57, position)
 					}
-					goto l256
-				l297:
-					position, tokenIndex = position256, tokenIndex256
+					goto l291
+				l332:
+					position, tokenIndex = position291, tokenIndex291
 					{
-						position301 := position
+						position336 := position
 						if !_rules[ruleIDENT]() {
-							goto l300
+							goto l335
 						}
-						add(rulePegText, position301)
+						add(rulePegText, position336)
 					}
 					{
-						add(ruleAction51, position)
+						add(ruleAction58, position)
 					}
 					if !_rules[ruleopen]() {
-						goto l300
+						goto l335
 					}
 					if !_rules[ruleallargs]() {
-						goto l300
+						goto l335
 					}
 					{
-						position303, tokenIndex303 := position, tokenIndex
+						position338, tokenIndex338 := position, tokenIndex
 						if !_rules[rulecomma]() {
-							goto l303
+							goto l338
 						}
-						goto l304
-					l303:
-						position, tokenIndex = position303, tokenIndex303
+						goto l339
+					l338:
+						position, tokenIndex = position338, tokenIndex338
 					}
-				l304:
+				l339:
 					if !_rules[ruleclose]() {
-						goto l300
+						goto l335
 					}
 					{
-						add(ruleAction52, position)
+						add(ruleAction59, position)
 					}
-					goto l256
-				l300:
-					position, tokenIndex = position256, tokenIndex256
+					goto l291
+				l335:
+					position, tokenIndex = position291, tokenIndex291
 					{
-						position307 := position
+						position342 := position
 						{
-							position310, tokenIndex310 := position, tokenIndex
+							position345, tokenIndex345 := position, tokenIndex
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l311
+								goto l346
 							}
 							position++
-							goto l310
-						l311:
-							position, tokenIndex = position310, tokenIndex310
+							goto l345
+						l346:
+							position, tokenIndex = position345, tokenIndex345
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l312
+								goto l347
 							}
 							position++
-							goto l310
-						l312:
-							position, tokenIndex = position310, tokenIndex310
+							goto l345
+						l347:
+							position, tokenIndex = position345, tokenIndex345
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l313
+								goto l348
 							}
 							position++
-							goto l310
-						l313:
-							position, tokenIndex = position310, tokenIndex310
+							goto l345
+						l348:
+							position, tokenIndex = position345, tokenIndex345
 							if buffer[position] != rune('-') {
-								goto l314
+								goto l349
 							}
 							position++
-							goto l310
-						l314:
-							position, tokenIndex = position310, tokenIndex310
+							goto l345
+						l349:
+							position, tokenIndex = position345, tokenIndex345
 							if buffer[position] != rune('_') {
-								goto l315
+								goto l350
 							}
 							position++
-							goto l310
-						l315:
-							position, tokenIndex = position310, tokenIndex310
+							goto l345
+						l350:
+							position, tokenIndex = position345, tokenIndex345
 							if buffer[position] != rune(':') {
-								goto l306
+								goto l341
 							}
 							position++
 						}
-					l310:
-					l308:
+					l345:
+					l343:
 						{
-							position309, tokenIndex309 := position, tokenIndex
+							position344, tokenIndex344 := position, tokenIndex
 							{
-								position316, tokenIndex316 := position, tokenIndex
+								position351, tokenIndex351 := position, tokenIndex
 								if c := buffer[position]; c < rune('a') || c > rune('z') {
-									goto l317
+									goto l352
 								}
 								position++
-								goto l316
-							l317:
-								position, tokenIndex = position316, tokenIndex316
+								goto l351
+							l352:
+								position, tokenIndex = position351, tokenIndex351
 								if c := buffer[position]; c < rune('A') || c > rune('Z') {
-									goto l318
+									goto l353
 								}
 								position++
-								goto l316
-							l318:
-								position, tokenIndex = position316, tokenIndex316
+								goto l351
+							l353:
+								position, tokenIndex = position351, tokenIndex351
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l319
+									goto l354
 								}
 								position++
-								goto l316
-							l319:
-								position, tokenIndex = position316, tokenIndex316
+								goto l351
+							l354:
+								position, tokenIndex = position351, tokenIndex351
 								if buffer[position] != rune('-') {
-									goto l320
+									goto l355
 								}
 								position++
-								goto l316
-							l320:
-								position, tokenIndex = position316, tokenIndex316
+								goto l351
+							l355:
+								position, tokenIndex = position351, tokenIndex351
 								if buffer[position] != rune('_') {
-									goto l321
+									goto l356
 								}
 								position++
-								goto l316
-							l321:
-								position, tokenIndex = position316, tokenIndex316
+								goto l351
+							l356:
+								position, tokenIndex = position351, tokenIndex351
 								if buffer[position] != rune(':') {
-									goto l309
+									goto l344
 								}
 								position++
 							}
-						l316:
-							goto l308
-						l309:
-							position, tokenIndex = position309, tokenIndex309
+						l351:
+							goto l343
+						l344:
+							position, tokenIndex = position344, tokenIndex344
 						}
-						add(rulePegText, position307)
+						add(rulePegText, position342)
 					}
 					{
-						add(ruleAction53, position)
+						add(ruleAction60, position)
 					}
-					goto l256
-				l306:
-					position, tokenIndex = position256, tokenIndex256
+					goto l291
+				l341:
+					position, tokenIndex = position291, tokenIndex291
 					{
-						position324 := position
+						position359 := position
 						if buffer[position] != rune('"') {
-							goto l323
+							goto l358
 						}
 						position++
 						if !_rules[ruledoublequotedstring]() {
-							goto l323
+							goto l358
 						}
 						if buffer[position] != rune('"') {
-							goto l323
+							goto l358
 						}
 						position++
-						add(rulePegText, position324)
+						add(rulePegText, position359)
 					}
 					{
-						add(ruleAction54, position)
+						add(ruleAction61, position)
 					}
-					goto l256
-				l323:
-					position, tokenIndex = position256, tokenIndex256
+					goto l291
+				l358:
+					position, tokenIndex = position291, tokenIndex291
 					{
-						position326 := position
+						position361 := position
 						if buffer[position] != rune('\'') {
-							goto l254
+							goto l289
 						}
 						position++
 						if !_rules[rulesinglequotedstring]() {
-							goto l254
+							goto l289
 						}
 						if buffer[position] != rune('\'') {
-							goto l254
+							goto l289
 						}
 						position++
-						add(rulePegText, position326)
+						add(rulePegText, position361)
 					}
 					{
-						add(ruleAction55, position)
+						add(ruleAction62, position)
 					}
 				}
-			l256:
-				add(ruleitem, position255)
+			l291:
+				add(ruleitem, position290)
 			}
 			return true
-		l254:
-			position, tokenIndex = position254, tokenIndex254
+		l289:
+			position, tokenIndex = position289, tokenIndex289
 			return false
 		},
-		/* 13 doublequotedstring <- <(('\\' '"') / ('\\' '\\') / ('\\' 'n') / ('\\' 't') / (!('"' / '\\') .))*> */
+		/* 14 doublequotedstring <- <(('\\' '"') / ('\\' '\\') / ('\\' 'n') / ('\\' 't') / (!('"' / '\\') .))*> */
 		func() bool {
 			{
-				position329 := position
-			l330:
+				position364 := position
+			l365:
 				{
-					position331, tokenIndex331 := position, tokenIndex
+					position366, tokenIndex366 := position, tokenIndex
 					{
-						position332, tokenIndex332 := position, tokenIndex
+						position367, tokenIndex367 := position, tokenIndex
 						if buffer[position] != rune('\\') {
-							goto l333
+							goto l368
 						}
 						position++
 						if buffer[position] != rune('"') {
-							goto l333
+							goto l368
 						}
 						position++
-						goto l332
-					l333:
-						position, tokenIndex = position332, tokenIndex332
+						goto l367
+					l368:
+						position, tokenIndex = position367, tokenIndex367
 						if buffer[position] != rune('\\') {
-							goto l334
+							goto l369
 						}
 						position++
 						if buffer[position] != rune('\\') {
-							goto l334
+							goto l369
 						}
 						position++
-						goto l332
-					l334:
-						position, tokenIndex = position332, tokenIndex332
+						goto l367
+					l369:
+						position, tokenIndex = position367, tokenIndex367
 						if buffer[position] != rune('\\') {
-							goto l335
+							goto l370
 						}
 						position++
 						if buffer[position] != rune('n') {
-							goto l335
+							goto l370
 						}
 						position++
-						goto l332
-					l335:
-						position, tokenIndex = position332, tokenIndex332
+						goto l367
+					l370:
+						position, tokenIndex = position367, tokenIndex367
 						if buffer[position] != rune('\\') {
-							goto l336
+							goto l371
 						}
 						position++
 						if buffer[position] != rune('t') {
-							goto l336
+							goto l371
 						}
 						position++
-						goto l332
-					l336:
-						position, tokenIndex = position332, tokenIndex332
+						goto l367
+					l371:
+						position, tokenIndex = position367, tokenIndex367
 						{
-							position337, tokenIndex337 := position, tokenIndex
+							position372, tokenIndex372 := position, tokenIndex
 							{
-								position338, tokenIndex338 := position, tokenIndex
+								position373, tokenIndex373 := position, tokenIndex
 								if buffer[position] != rune('"') {
-									goto l339
+									goto l374
 								}
 								position++
-								goto l338
-							l339:
-								position, tokenIndex = position338, tokenIndex338
+								goto l373
+							l374:
+								position, tokenIndex = position373, tokenIndex373
 								if buffer[position] != rune('\\') {
-									goto l337
+									goto l372
 								}
 								position++
 							}
-						l338:
-							goto l331
-						l337:
-							position, tokenIndex = position337, tokenIndex337
+						l373:
+							goto l366
+						l372:
+							position, tokenIndex = position372, tokenIndex372
 						}
 						if !matchDot() {
-							goto l331
+							goto l366
 						}
 					}
-				l332:
-					goto l330
-				l331:
-					position, tokenIndex = position331, tokenIndex331
+				l367:
+					goto l365
+				l366:
+					position, tokenIndex = position366, tokenIndex366
 				}
-				add(ruledoublequotedstring, position329)
+				add(ruledoublequotedstring, position364)
 			}
 			return true
 		},
-		/* 14 singlequotedstring <- <(('\\' '\'') / ('\\' '\\') / ('\\' 'n') / ('\\' 't') / (!('\'' / '\\') .))*> */
+		/* 15 singlequotedstring <- <(('\\' '\'') / ('\\' '\\') / ('\\' 'n') / ('\\' 't') / (!('\'' / '\\') .))*> */
 		func() bool {
 			{
-				position341 := position
-			l342:
+				position376 := position
+			l377:
 				{
-					position343, tokenIndex343 := position, tokenIndex
+					position378, tokenIndex378 := position, tokenIndex
 					{
-						position344, tokenIndex344 := position, tokenIndex
+						position379, tokenIndex379 := position, tokenIndex
 						if buffer[position] != rune('\\') {
-							goto l345
+							goto l380
 						}
 						position++
 						if buffer[position] != rune('\'') {
-							goto l345
+							goto l380
 						}
 						position++
-						goto l344
-					l345:
-						position, tokenIndex = position344, tokenIndex344
+						goto l379
+					l380:
+						position, tokenIndex = position379, tokenIndex379
 						if buffer[position] != rune('\\') {
-							goto l346
+							goto l381
 						}
 						position++
 						if buffer[position] != rune('\\') {
-							goto l346
+							goto l381
 						}
 						position++
-						goto l344
-					l346:
-						position, tokenIndex = position344, tokenIndex344
+						goto l379
+					l381:
+						position, tokenIndex = position379, tokenIndex379
 						if buffer[position] != rune('\\') {
-							goto l347
+							goto l382
 						}
 						position++
 						if buffer[position] != rune('n') {
-							goto l347
+							goto l382
 						}
 						position++
-						goto l344
-					l347:
-						position, tokenIndex = position344, tokenIndex344
+						goto l379
+					l382:
+						position, tokenIndex = position379, tokenIndex379
 						if buffer[position] != rune('\\') {
-							goto l348
+							goto l383
 						}
 						position++
 						if buffer[position] != rune('t') {
-							goto l348
+							goto l383
 						}
 						position++
-						goto l344
-					l348:
-						position, tokenIndex = position344, tokenIndex344
+						goto l379
+					l383:
+						position, tokenIndex = position379, tokenIndex379
 						{
-							position349, tokenIndex349 := position, tokenIndex
+							position384, tokenIndex384 := position, tokenIndex
 							{
-								position350, tokenIndex350 := position, tokenIndex
+								position385, tokenIndex385 := position, tokenIndex
 								if buffer[position] != rune('\'') {
-									goto l351
+									goto l386
 								}
 								position++
-								goto l350
-							l351:
-								position, tokenIndex = position350, tokenIndex350
+								goto l385
+							l386:
+								position, tokenIndex = position385, tokenIndex385
 								if buffer[position] != rune('\\') {
-									goto l349
+									goto l384
 								}
 								position++
 							}
-						l350:
-							goto l343
-						l349:
-							position, tokenIndex = position349, tokenIndex349
+						l385:
+							goto l378
+						l384:
+							position, tokenIndex = position384, tokenIndex384
 						}
 						if !matchDot() {
-							goto l343
+							goto l378
 						}
 					}
-				l344:
-					goto l342
-				l343:
-					position, tokenIndex = position343, tokenIndex343
+				l379:
+					goto l377
+				l378:
+					position, tokenIndex = position378, tokenIndex378
 				}
-				add(rulesinglequotedstring, position341)
+				add(rulesinglequotedstring, position376)
 			}
 			return true
 		},
-		/* 15 variable <- <(([a-z] / [A-Z] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*)> */
+		/* 16 variable <- <(([a-z] / [A-Z] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*)> */
 		nil,
-		/* 16 fieldExpr <- <(([a-z] / [A-Z] / '_' / '$') ([a-z] / [A-Z] / [0-9] / '_' / '-')*)> */
+		/* 17 fieldExpr <- <(([a-z] / [A-Z] / '_' / '$') ([a-z] / [A-Z] / [0-9] / '_' / '-')*)> */
 		func() bool {
-			position353, tokenIndex353 := position, tokenIndex
+			position388, tokenIndex388 := position, tokenIndex
 			{
-				position354 := position
+				position389 := position
 				{
-					position355, tokenIndex355 := position, tokenIndex
+					position390, tokenIndex390 := position, tokenIndex
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l356
+						goto l391
 					}
 					position++
-					goto l355
-				l356:
-					position, tokenIndex = position355, tokenIndex355
+					goto l390
+				l391:
+					position, tokenIndex = position390, tokenIndex390
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l357
+						goto l392
 					}
 					position++
-					goto l355
-				l357:
-					position, tokenIndex = position355, tokenIndex355
+					goto l390
+				l392:
+					position, tokenIndex = position390, tokenIndex390
 					if buffer[position] != rune('_') {
-						goto l358
+						goto l393
 					}
 					position++
-					goto l355
-				l358:
-					position, tokenIndex = position355, tokenIndex355
+					goto l390
+				l393:
+					position, tokenIndex = position390, tokenIndex390
 					if buffer[position] != rune('$') {
-						goto l353
+						goto l388
 					}
 					position++
 				}
-			l355:
-			l359:
+			l390:
+			l394:
 				{
-					position360, tokenIndex360 := position, tokenIndex
+					position395, tokenIndex395 := position, tokenIndex
 					{
-						position361, tokenIndex361 := position, tokenIndex
+						position396, tokenIndex396 := position, tokenIndex
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l362
+							goto l397
 						}
 						position++
-						goto l361
-					l362:
-						position, tokenIndex = position361, tokenIndex361
+						goto l396
+					l397:
+						position, tokenIndex = position396, tokenIndex396
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l363
+							goto l398
 						}
 						position++
-						goto l361
-					l363:
-						position, tokenIndex = position361, tokenIndex361
+						goto l396
+					l398:
+						position, tokenIndex = position396, tokenIndex396
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l364
+							goto l399
 						}
 						position++
-						goto l361
-					l364:
-						position, tokenIndex = position361, tokenIndex361
+						goto l396
+					l399:
+						position, tokenIndex = position396, tokenIndex396
 						if buffer[position] != rune('_') {
-							goto l365
+							goto l400
 						}
 						position++
-						goto l361
-					l365:
-						position, tokenIndex = position361, tokenIndex361
+						goto l396
+					l400:
+						position, tokenIndex = position396, tokenIndex396
 						if buffer[position] != rune('-') {
-							goto l360
+							goto l395
 						}
 						position++
 					}
-				l361:
-					goto l359
-				l360:
-					position, tokenIndex = position360, tokenIndex360
+				l396:
+					goto l394
+				l395:
+					position, tokenIndex = position395, tokenIndex395
 				}
-				add(rulefieldExpr, position354)
+				add(rulefieldExpr, position389)
 			}
 			return true
-		l353:
-			position, tokenIndex = position353, tokenIndex353
+		l388:
+			position, tokenIndex = position388, tokenIndex388
 			return false
 		},
-		/* 17 field <- <(<(fieldExpr / reserved)> Action56)> */
+		/* 18 field <- <(<(fieldExpr / reserved)> Action63)> */
 		func() bool {
-			position366, tokenIndex366 := position, tokenIndex
+			position401, tokenIndex401 := position, tokenIndex
 			{
-				position367 := position
+				position402 := position
 				{
-					position368 := position
+					position403 := position
 					{
-						position369, tokenIndex369 := position, tokenIndex
+						position404, tokenIndex404 := position, tokenIndex
 						if !_rules[rulefieldExpr]() {
-							goto l370
+							goto l405
 						}
-						goto l369
-					l370:
-						position, tokenIndex = position369, tokenIndex369
+						goto l404
+					l405:
+						position, tokenIndex = position404, tokenIndex404
 						{
-							position371 := position
+							position406 := position
 							{
-								position372, tokenIndex372 := position, tokenIndex
+								position407, tokenIndex407 := position, tokenIndex
 								if buffer[position] != rune('_') {
-									goto l373
+									goto l408
 								}
 								position++
 								if buffer[position] != rune('r') {
-									goto l373
+									goto l408
 								}
 								position++
 								if buffer[position] != rune('o') {
-									goto l373
+									goto l408
 								}
 								position++
 								if buffer[position] != rune('w') {
-									goto l373
+									goto l408
 								}
 								position++
-								goto l372
-							l373:
-								position, tokenIndex = position372, tokenIndex372
+								goto l407
+							l408:
+								position, tokenIndex = position407, tokenIndex407
 								if buffer[position] != rune('_') {
-									goto l374
+									goto l409
 								}
 								position++
 								if buffer[position] != rune('c') {
-									goto l374
+									goto l409
 								}
 								position++
 								if buffer[position] != rune('o') {
-									goto l374
+									goto l409
 								}
 								position++
 								if buffer[position] != rune('l') {
-									goto l374
+									goto l409
 								}
 								position++
-								goto l372
-							l374:
-								position, tokenIndex = position372, tokenIndex372
+								goto l407
+							l409:
+								position, tokenIndex = position407, tokenIndex407
 								if buffer[position] != rune('_') {
-									goto l375
+									goto l410
 								}
 								position++
 								if buffer[position] != rune('s') {
-									goto l375
+									goto l410
 								}
 								position++
 								if buffer[position] != rune('t') {
-									goto l375
+									goto l410
 								}
 								position++
 								if buffer[position] != rune('a') {
-									goto l375
+									goto l410
 								}
 								position++
 								if buffer[position] != rune('r') {
-									goto l375
+									goto l410
 								}
 								position++
 								if buffer[position] != rune('t') {
-									goto l375
+									goto l410
 								}
 								position++
-								goto l372
-							l375:
-								position, tokenIndex = position372, tokenIndex372
+								goto l407
+							l410:
+								position, tokenIndex = position407, tokenIndex407
 								if buffer[position] != rune('_') {
-									goto l376
+									goto l411
 								}
 								position++
 								if buffer[position] != rune('e') {
-									goto l376
+									goto l411
 								}
 								position++
 								if buffer[position] != rune('n') {
-									goto l376
+									goto l411
 								}
 								position++
 								if buffer[position] != rune('d') {
-									goto l376
+									goto l411
 								}
 								position++
-								goto l372
-							l376:
-								position, tokenIndex = position372, tokenIndex372
+								goto l407
+							l411:
+								position, tokenIndex = position407, tokenIndex407
 								if buffer[position] != rune('_') {
-									goto l377
+									goto l412
 								}
 								position++
 								if buffer[position] != rune('t') {
-									goto l377
+									goto l412
 								}
 								position++
 								if buffer[position] != rune('i') {
-									goto l377
+									goto l412
 								}
 								position++
 								if buffer[position] != rune('m') {
-									goto l377
+									goto l412
 								}
 								position++
 								if buffer[position] != rune('e') {
-									goto l377
+									goto l412
 								}
 								position++
 								if buffer[position] != rune('s') {
-									goto l377
+									goto l412
 								}
 								position++
 								if buffer[position] != rune('t') {
-									goto l377
+									goto l412
 								}
 								position++
 								if buffer[position] != rune('a') {
-									goto l377
+									goto l412
 								}
 								position++
 								if buffer[position] != rune('m') {
-									goto l377
+									goto l412
 								}
 								position++
 								if buffer[position] != rune('p') {
-									goto l377
+									goto l412
 								}
 								position++
-								goto l372
-							l377:
-								position, tokenIndex = position372, tokenIndex372
+								goto l407
+							l412:
+								position, tokenIndex = position407, tokenIndex407
 								if buffer[position] != rune('_') {
-									goto l366
+									goto l401
 								}
 								position++
 								if buffer[position] != rune('f') {
-									goto l366
+									goto l401
 								}
 								position++
 								if buffer[position] != rune('i') {
-									goto l366
+									goto l401
 								}
 								position++
 								if buffer[position] != rune('e') {
-									goto l366
+									goto l401
 								}
 								position++
 								if buffer[position] != rune('l') {
-									goto l366
+									goto l401
 								}
 								position++
 								if buffer[position] != rune('d') {
-									goto l366
+									goto l401
 								}
 								position++
 							}
-						l372:
-							add(rulereserved, position371)
+						l407:
+							add(rulereserved, position406)
 						}
 					}
-				l369:
-					add(rulePegText, position368)
+				l404:
+					add(rulePegText, position403)
 				}
 				{
-					add(ruleAction56, position)
+					add(ruleAction63, position)
 				}
-				add(rulefield, position367)
+				add(rulefield, position402)
 			}
 			return true
-		l366:
-			position, tokenIndex = position366, tokenIndex366
+		l401:
+			position, tokenIndex = position401, tokenIndex401
 			return false
 		},
-		/* 18 reserved <- <(('_' 'r' 'o' 'w') / ('_' 'c' 'o' 'l') / ('_' 's' 't' 'a' 'r' 't') / ('_' 'e' 'n' 'd') / ('_' 't' 'i' 'm' 'e' 's' 't' 'a' 'm' 'p') / ('_' 'f' 'i' 'e' 'l' 'd'))> */
+		/* 19 reserved <- <(('_' 'r' 'o' 'w') / ('_' 'c' 'o' 'l') / ('_' 's' 't' 'a' 'r' 't') / ('_' 'e' 'n' 'd') / ('_' 't' 'i' 'm' 'e' 's' 't' 'a' 'm' 'p') / ('_' 'f' 'i' 'e' 'l' 'd'))> */
 		nil,
-		/* 19 posfield <- <(('f' 'i' 'e' 'l' 'd' '=')? <fieldExpr> Action57)> */
+		/* 20 posfield <- <(('f' 'i' 'e' 'l' 'd' '=')? <fieldExpr> Action64)> */
 		func() bool {
-			position380, tokenIndex380 := position, tokenIndex
+			position415, tokenIndex415 := position, tokenIndex
 			{
-				position381 := position
+				position416 := position
 				{
-					position382, tokenIndex382 := position, tokenIndex
+					position417, tokenIndex417 := position, tokenIndex
 					if buffer[position] != rune('f') {
-						goto l382
+						goto l417
 					}
 					position++
 					if buffer[position] != rune('i') {
-						goto l382
+						goto l417
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l382
+						goto l417
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l382
+						goto l417
 					}
 					position++
 					if buffer[position] != rune('d') {
-						goto l382
+						goto l417
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l382
+						goto l417
 					}
 					position++
-					goto l383
-				l382:
-					position, tokenIndex = position382, tokenIndex382
+					goto l418
+				l417:
+					position, tokenIndex = position417, tokenIndex417
 				}
-			l383:
+			l418:
 				{
-					position384 := position
+					position419 := position
 					if !_rules[rulefieldExpr]() {
-						goto l380
+						goto l415
 					}
-					add(rulePegText, position384)
+					add(rulePegText, position419)
 				}
 				{
-					add(ruleAction57, position)
+					add(ruleAction64, position)
 				}
-				add(ruleposfield, position381)
+				add(ruleposfield, position416)
 			}
 			return true
-		l380:
-			position, tokenIndex = position380, tokenIndex380
+		l415:
+			position, tokenIndex = position415, tokenIndex415
 			return false
 		},
-		/* 20 col <- <((<digits> Action58) / (<('\'' singlequotedstring '\'')> Action59) / (<('"' doublequotedstring '"')> Action60))> */
+		/* 21 col <- <((<digits> Action65) / (<('\'' singlequotedstring '\'')> Action66) / (<('"' doublequotedstring '"')> Action67))> */
 		func() bool {
-			position386, tokenIndex386 := position, tokenIndex
+			position421, tokenIndex421 := position, tokenIndex
 			{
-				position387 := position
+				position422 := position
 				{
-					position388, tokenIndex388 := position, tokenIndex
+					position423, tokenIndex423 := position, tokenIndex
 					{
-						position390 := position
+						position425 := position
 						if !_rules[ruledigits]() {
-							goto l389
+							goto l424
 						}
-						add(rulePegText, position390)
+						add(rulePegText, position425)
 					}
 					{
-						add(ruleAction58, position)
+						add(ruleAction65, position)
 					}
-					goto l388
-				l389:
-					position, tokenIndex = position388, tokenIndex388
+					goto l423
+				l424:
+					position, tokenIndex = position423, tokenIndex423
 					{
-						position393 := position
+						position428 := position
 						if buffer[position] != rune('\'') {
-							goto l392
+							goto l427
 						}
 						position++
 						if !_rules[rulesinglequotedstring]() {
-							goto l392
+							goto l427
 						}
 						if buffer[position] != rune('\'') {
-							goto l392
+							goto l427
 						}
 						position++
-						add(rulePegText, position393)
+						add(rulePegText, position428)
 					}
 					{
-						add(ruleAction59, position)
+						add(ruleAction66, position)
 					}
-					goto l388
-				l392:
-					position, tokenIndex = position388, tokenIndex388
+					goto l423
+				l427:
+					position, tokenIndex = position423, tokenIndex423
 					{
-						position395 := position
+						position430 := position
 						if buffer[position] != rune('"') {
-							goto l386
+							goto l421
 						}
 						position++
 						if !_rules[ruledoublequotedstring]() {
-							goto l386
+							goto l421
 						}
 						if buffer[position] != rune('"') {
-							goto l386
+							goto l421
 						}
 						position++
-						add(rulePegText, position395)
+						add(rulePegText, position430)
 					}
 					{
-						add(ruleAction60, position)
+						add(ruleAction67, position)
 					}
 				}
-			l388:
-				add(rulecol, position387)
+			l423:
+				add(rulecol, position422)
 			}
 			return true
-		l386:
-			position, tokenIndex = position386, tokenIndex386
+		l421:
+			position, tokenIndex = position421, tokenIndex421
 			return false
 		},
-		/* 21 open <- <('(' sp)> */
+		/* 22 open <- <('(' sp)> */
 		func() bool {
-			position397, tokenIndex397 := position, tokenIndex
+			position432, tokenIndex432 := position, tokenIndex
 			{
-				position398 := position
+				position433 := position
 				if buffer[position] != rune('(') {
-					goto l397
+					goto l432
 				}
 				position++
 				if !_rules[rulesp]() {
-					goto l397
+					goto l432
 				}
-				add(ruleopen, position398)
+				add(ruleopen, position433)
 			}
 			return true
-		l397:
-			position, tokenIndex = position397, tokenIndex397
+		l432:
+			position, tokenIndex = position432, tokenIndex432
 			return false
 		},
-		/* 22 close <- <(sp ')' sp)> */
+		/* 23 close <- <(sp ')' sp)> */
 		func() bool {
-			position399, tokenIndex399 := position, tokenIndex
+			position434, tokenIndex434 := position, tokenIndex
 			{
-				position400 := position
+				position435 := position
 				if !_rules[rulesp]() {
-					goto l399
+					goto l434
 				}
 				if buffer[position] != rune(')') {
-					goto l399
+					goto l434
 				}
 				position++
 				if !_rules[rulesp]() {
-					goto l399
+					goto l434
 				}
-				add(ruleclose, position400)
+				add(ruleclose, position435)
 			}
 			return true
-		l399:
-			position, tokenIndex = position399, tokenIndex399
+		l434:
+			position, tokenIndex = position434, tokenIndex434
 			return false
 		},
-		/* 23 sp <- <(' ' / '\t' / '\n')*> */
+		/* 24 sp <- <(' ' / '\t' / '\n')*> */
 		func() bool {
 			{
-				position402 := position
-			l403:
+				position437 := position
+			l438:
 				{
-					position404, tokenIndex404 := position, tokenIndex
+					position439, tokenIndex439 := position, tokenIndex
 					{
-						position405, tokenIndex405 := position, tokenIndex
+						position440, tokenIndex440 := position, tokenIndex
 						if buffer[position] != rune(' ') {
-							goto l406
+							goto l441
 						}
 						position++
-						goto l405
-					l406:
-						position, tokenIndex = position405, tokenIndex405
+						goto l440
+					l441:
+						position, tokenIndex = position440, tokenIndex440
 						if buffer[position] != rune('\t') {
-							goto l407
+							goto l442
 						}
 						position++
-						goto l405
-					l407:
-						position, tokenIndex = position405, tokenIndex405
+						goto l440
+					l442:
+						position, tokenIndex = position440, tokenIndex440
 						if buffer[position] != rune('\n') {
-							goto l404
+							goto l439
 						}
 						position++
 					}
-				l405:
-					goto l403
-				l404:
-					position, tokenIndex = position404, tokenIndex404
+				l440:
+					goto l438
+				l439:
+					position, tokenIndex = position439, tokenIndex439
 				}
-				add(rulesp, position402)
+				add(rulesp, position437)
 			}
 			return true
 		},
-		/* 24 eq <- <(sp '=' sp)> */
+		/* 25 eq <- <(sp '=' sp)> */
 		func() bool {
-			position408, tokenIndex408 := position, tokenIndex
+			position443, tokenIndex443 := position, tokenIndex
 			{
-				position409 := position
+				position444 := position
 				if !_rules[rulesp]() {
-					goto l408
+					goto l443
 				}
 				if buffer[position] != rune('=') {
-					goto l408
+					goto l443
 				}
 				position++
 				if !_rules[rulesp]() {
-					goto l408
+					goto l443
 				}
-				add(ruleeq, position409)
+				add(ruleeq, position444)
 			}
 			return true
-		l408:
-			position, tokenIndex = position408, tokenIndex408
+		l443:
+			position, tokenIndex = position443, tokenIndex443
 			return false
 		},
-		/* 25 comma <- <(sp ',' sp)> */
+		/* 26 comma <- <(sp ',' sp)> */
 		func() bool {
-			position410, tokenIndex410 := position, tokenIndex
+			position445, tokenIndex445 := position, tokenIndex
 			{
-				position411 := position
+				position446 := position
 				if !_rules[rulesp]() {
-					goto l410
+					goto l445
 				}
 				if buffer[position] != rune(',') {
-					goto l410
+					goto l445
 				}
 				position++
 				if !_rules[rulesp]() {
-					goto l410
+					goto l445
 				}
-				add(rulecomma, position411)
+				add(rulecomma, position446)
 			}
 			return true
-		l410:
-			position, tokenIndex = position410, tokenIndex410
+		l445:
+			position, tokenIndex = position445, tokenIndex445
 			return false
 		},
-		/* 26 lbrack <- <('[' sp)> */
+		/* 27 lbrack <- <('[' sp)> */
 		nil,
-		/* 27 rbrack <- <(sp ']' sp)> */
+		/* 28 rbrack <- <(sp ']' sp)> */
 		nil,
-		/* 28 IDENT <- <(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9])*)> */
+		/* 29 IDENT <- <(([a-z] / [A-Z]) ([a-z] / [A-Z] / [0-9])*)> */
 		func() bool {
-			position414, tokenIndex414 := position, tokenIndex
+			position449, tokenIndex449 := position, tokenIndex
 			{
-				position415 := position
+				position450 := position
 				{
-					position416, tokenIndex416 := position, tokenIndex
+					position451, tokenIndex451 := position, tokenIndex
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l417
+						goto l452
 					}
 					position++
-					goto l416
-				l417:
-					position, tokenIndex = position416, tokenIndex416
+					goto l451
+				l452:
+					position, tokenIndex = position451, tokenIndex451
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l414
+						goto l449
 					}
 					position++
 				}
-			l416:
-			l418:
+			l451:
+			l453:
 				{
-					position419, tokenIndex419 := position, tokenIndex
+					position454, tokenIndex454 := position, tokenIndex
 					{
-						position420, tokenIndex420 := position, tokenIndex
+						position455, tokenIndex455 := position, tokenIndex
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l421
+							goto l456
 						}
 						position++
-						goto l420
-					l421:
-						position, tokenIndex = position420, tokenIndex420
+						goto l455
+					l456:
+						position, tokenIndex = position455, tokenIndex455
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l422
+							goto l457
 						}
 						position++
-						goto l420
-					l422:
-						position, tokenIndex = position420, tokenIndex420
+						goto l455
+					l457:
+						position, tokenIndex = position455, tokenIndex455
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l419
+							goto l454
 						}
 						position++
 					}
-				l420:
-					goto l418
-				l419:
-					position, tokenIndex = position419, tokenIndex419
+				l455:
+					goto l453
+				l454:
+					position, tokenIndex = position454, tokenIndex454
 				}
-				add(ruleIDENT, position415)
+				add(ruleIDENT, position450)
 			}
 			return true
-		l414:
-			position, tokenIndex = position414, tokenIndex414
+		l449:
+			position, tokenIndex = position449, tokenIndex449
 			return false
 		},
-		/* 29 digits <- <[0-9]+> */
+		/* 30 digits <- <[0-9]+> */
 		func() bool {
-			position423, tokenIndex423 := position, tokenIndex
+			position458, tokenIndex458 := position, tokenIndex
 			{
-				position424 := position
+				position459 := position
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l423
+					goto l458
 				}
 				position++
-			l425:
+			l460:
 				{
-					position426, tokenIndex426 := position, tokenIndex
+					position461, tokenIndex461 := position, tokenIndex
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l426
+						goto l461
 					}
 					position++
-					goto l425
-				l426:
-					position, tokenIndex = position426, tokenIndex426
+					goto l460
+				l461:
+					position, tokenIndex = position461, tokenIndex461
 				}
-				add(ruledigits, position424)
+				add(ruledigits, position459)
 			}
 			return true
-		l423:
-			position, tokenIndex = position423, tokenIndex423
+		l458:
+			position, tokenIndex = position458, tokenIndex458
 			return false
 		},
-		/* 30 signedDigits <- <('-'? digits)> */
+		/* 31 signedDigits <- <('-'? digits)> */
 		nil,
-		/* 31 decimal <- <((signedDigits ('.' digits?)?) / ('-'? '.' digits))> */
+		/* 32 decimal <- <((signedDigits ('.' digits?)?) / ('-'? '.' digits))> */
 		func() bool {
-			position428, tokenIndex428 := position, tokenIndex
+			position463, tokenIndex463 := position, tokenIndex
 			{
-				position429 := position
+				position464 := position
 				{
-					position430, tokenIndex430 := position, tokenIndex
+					position465, tokenIndex465 := position, tokenIndex
 					{
-						position432 := position
+						position467 := position
 						{
-							position433, tokenIndex433 := position, tokenIndex
+							position468, tokenIndex468 := position, tokenIndex
 							if buffer[position] != rune('-') {
-								goto l433
+								goto l468
 							}
 							position++
-							goto l434
-						l433:
-							position, tokenIndex = position433, tokenIndex433
+							goto l469
+						l468:
+							position, tokenIndex = position468, tokenIndex468
 						}
-					l434:
+					l469:
 						if !_rules[ruledigits]() {
-							goto l431
+							goto l466
 						}
-						add(rulesignedDigits, position432)
+						add(rulesignedDigits, position467)
 					}
 					{
-						position435, tokenIndex435 := position, tokenIndex
+						position470, tokenIndex470 := position, tokenIndex
 						if buffer[position] != rune('.') {
-							goto l435
+							goto l470
 						}
 						position++
 						{
-							position437, tokenIndex437 := position, tokenIndex
+							position472, tokenIndex472 := position, tokenIndex
 							if !_rules[ruledigits]() {
-								goto l437
+								goto l472
 							}
-							goto l438
-						l437:
-							position, tokenIndex = position437, tokenIndex437
+							goto l473
+						l472:
+							position, tokenIndex = position472, tokenIndex472
 						}
-					l438:
-						goto l436
-					l435:
-						position, tokenIndex = position435, tokenIndex435
+					l473:
+						goto l471
+					l470:
+						position, tokenIndex = position470, tokenIndex470
 					}
-				l436:
-					goto l430
-				l431:
-					position, tokenIndex = position430, tokenIndex430
+				l471:
+					goto l465
+				l466:
+					position, tokenIndex = position465, tokenIndex465
 					{
-						position439, tokenIndex439 := position, tokenIndex
+						position474, tokenIndex474 := position, tokenIndex
 						if buffer[position] != rune('-') {
-							goto l439
+							goto l474
 						}
 						position++
-						goto l440
-					l439:
-						position, tokenIndex = position439, tokenIndex439
+						goto l475
+					l474:
+						position, tokenIndex = position474, tokenIndex474
 					}
-				l440:
+				l475:
 					if buffer[position] != rune('.') {
-						goto l428
+						goto l463
 					}
 					position++
 					if !_rules[ruledigits]() {
-						goto l428
+						goto l463
 					}
 				}
-			l430:
-				add(ruledecimal, position429)
+			l465:
+				add(ruledecimal, position464)
 			}
 			return true
-		l428:
-			position, tokenIndex = position428, tokenIndex428
+		l463:
+			position, tokenIndex = position463, tokenIndex463
 			return false
 		},
-		/* 32 tz <- <('Z' / ('-' [0-9] [0-9] ':' [0-9] [0-9]) / ('+' [0-9] [0-9] ':' [0-9] [0-9]))> */
+		/* 33 tz <- <('Z' / ('-' [0-9] [0-9] ':' [0-9] [0-9]) / ('+' [0-9] [0-9] ':' [0-9] [0-9]))> */
 		func() bool {
-			position441, tokenIndex441 := position, tokenIndex
+			position476, tokenIndex476 := position, tokenIndex
 			{
-				position442 := position
+				position477 := position
 				{
-					position443, tokenIndex443 := position, tokenIndex
+					position478, tokenIndex478 := position, tokenIndex
 					if buffer[position] != rune('Z') {
-						goto l444
+						goto l479
 					}
 					position++
-					goto l443
-				l444:
-					position, tokenIndex = position443, tokenIndex443
+					goto l478
+				l479:
+					position, tokenIndex = position478, tokenIndex478
 					if buffer[position] != rune('-') {
-						goto l445
+						goto l480
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l445
+						goto l480
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l445
+						goto l480
 					}
 					position++
 					if buffer[position] != rune(':') {
-						goto l445
+						goto l480
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l445
+						goto l480
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l445
+						goto l480
 					}
 					position++
-					goto l443
-				l445:
-					position, tokenIndex = position443, tokenIndex443
+					goto l478
+				l480:
+					position, tokenIndex = position478, tokenIndex478
 					if buffer[position] != rune('+') {
-						goto l441
+						goto l476
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l441
+						goto l476
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l441
+						goto l476
 					}
 					position++
 					if buffer[position] != rune(':') {
-						goto l441
+						goto l476
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l441
+						goto l476
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l441
+						goto l476
 					}
 					position++
 				}
-			l443:
-				add(ruletz, position442)
+			l478:
+				add(ruletz, position477)
 			}
 			return true
-		l441:
-			position, tokenIndex = position441, tokenIndex441
+		l476:
+			position, tokenIndex = position476, tokenIndex476
 			return false
 		},
-		/* 33 iso8601 <- <([0-9] [0-9] [0-9] [0-9] '-' ('0' / '1') [0-9] '-' [0-3] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9] ':' [0-9] [0-9] <tz>)> */
+		/* 34 iso8601 <- <([0-9] [0-9] [0-9] [0-9] '-' ('0' / '1') [0-9] '-' [0-3] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9] ':' [0-9] [0-9] <tz>)> */
 		nil,
-		/* 34 iso8601nano <- <([0-9] [0-9] [0-9] [0-9] '-' ('0' / '1') [0-9] '-' [0-3] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9] ':' [0-9] [0-9] '.' [0-9]+ <tz>)> */
+		/* 35 iso8601nano <- <([0-9] [0-9] [0-9] [0-9] '-' ('0' / '1') [0-9] '-' [0-3] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9] ':' [0-9] [0-9] '.' [0-9]+ <tz>)> */
 		nil,
-		/* 35 timestampbasicfmt <- <(iso8601nano / iso8601)> */
+		/* 36 timestampbasicfmt <- <(iso8601nano / iso8601)> */
 		func() bool {
-			position448, tokenIndex448 := position, tokenIndex
+			position483, tokenIndex483 := position, tokenIndex
 			{
-				position449 := position
+				position484 := position
 				{
-					position450, tokenIndex450 := position, tokenIndex
+					position485, tokenIndex485 := position, tokenIndex
 					{
-						position452 := position
+						position487 := position
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if buffer[position] != rune('-') {
-							goto l451
+							goto l486
 						}
 						position++
 						{
-							position453, tokenIndex453 := position, tokenIndex
+							position488, tokenIndex488 := position, tokenIndex
 							if buffer[position] != rune('0') {
-								goto l454
+								goto l489
 							}
 							position++
-							goto l453
-						l454:
-							position, tokenIndex = position453, tokenIndex453
+							goto l488
+						l489:
+							position, tokenIndex = position488, tokenIndex488
 							if buffer[position] != rune('1') {
-								goto l451
+								goto l486
 							}
 							position++
 						}
-					l453:
+					l488:
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if buffer[position] != rune('-') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('3') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if buffer[position] != rune('T') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if buffer[position] != rune(':') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if buffer[position] != rune(':') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
 						if buffer[position] != rune('.') {
-							goto l451
+							goto l486
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l451
+							goto l486
 						}
 						position++
-					l455:
+					l490:
 						{
-							position456, tokenIndex456 := position, tokenIndex
+							position491, tokenIndex491 := position, tokenIndex
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l456
+								goto l491
 							}
 							position++
-							goto l455
-						l456:
-							position, tokenIndex = position456, tokenIndex456
+							goto l490
+						l491:
+							position, tokenIndex = position491, tokenIndex491
 						}
 						{
-							position457 := position
+							position492 := position
 							if !_rules[ruletz]() {
-								goto l451
+								goto l486
 							}
-							add(rulePegText, position457)
+							add(rulePegText, position492)
 						}
-						add(ruleiso8601nano, position452)
+						add(ruleiso8601nano, position487)
 					}
-					goto l450
-				l451:
-					position, tokenIndex = position450, tokenIndex450
+					goto l485
+				l486:
+					position, tokenIndex = position485, tokenIndex485
 					{
-						position458 := position
+						position493 := position
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if buffer[position] != rune('-') {
-							goto l448
+							goto l483
 						}
 						position++
 						{
-							position459, tokenIndex459 := position, tokenIndex
+							position494, tokenIndex494 := position, tokenIndex
 							if buffer[position] != rune('0') {
-								goto l460
+								goto l495
 							}
 							position++
-							goto l459
-						l460:
-							position, tokenIndex = position459, tokenIndex459
+							goto l494
+						l495:
+							position, tokenIndex = position494, tokenIndex494
 							if buffer[position] != rune('1') {
-								goto l448
+								goto l483
 							}
 							position++
 						}
-					l459:
+					l494:
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if buffer[position] != rune('-') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('3') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if buffer[position] != rune('T') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if buffer[position] != rune(':') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if buffer[position] != rune(':') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l448
+							goto l483
 						}
 						position++
 						{
-							position461 := position
+							position496 := position
 							if !_rules[ruletz]() {
-								goto l448
+								goto l483
 							}
-							add(rulePegText, position461)
+							add(rulePegText, position496)
 						}
-						add(ruleiso8601, position458)
+						add(ruleiso8601, position493)
 					}
 				}
-			l450:
-				add(ruletimestampbasicfmt, position449)
+			l485:
+				add(ruletimestampbasicfmt, position484)
 			}
 			return true
-		l448:
-			position, tokenIndex = position448, tokenIndex448
+		l483:
+			position, tokenIndex = position483, tokenIndex483
 			return false
 		},
-		/* 36 timestampfmt <- <(('"' <timestampbasicfmt> '"') / ('\'' <timestampbasicfmt> '\'') / <timestampbasicfmt>)> */
+		/* 37 timestampfmt <- <(('"' <timestampbasicfmt> '"') / ('\'' <timestampbasicfmt> '\'') / <timestampbasicfmt>)> */
 		nil,
-		/* 37 timebasicfmt <- <([0-9] [0-9] [0-9] [0-9] '-' ('0' / '1') [0-9] '-' [0-3] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9])> */
+		/* 38 timebasicfmt <- <([0-9] [0-9] [0-9] [0-9] '-' ('0' / '1') [0-9] '-' [0-3] [0-9] 'T' [0-9] [0-9] ':' [0-9] [0-9])> */
 		func() bool {
-			position463, tokenIndex463 := position, tokenIndex
+			position498, tokenIndex498 := position, tokenIndex
 			{
-				position464 := position
+				position499 := position
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l463
+					goto l498
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l463
+					goto l498
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l463
+					goto l498
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l463
+					goto l498
 				}
 				position++
 				if buffer[position] != rune('-') {
-					goto l463
+					goto l498
 				}
 				position++
 				{
-					position465, tokenIndex465 := position, tokenIndex
+					position500, tokenIndex500 := position, tokenIndex
 					if buffer[position] != rune('0') {
-						goto l466
+						goto l501
 					}
 					position++
-					goto l465
-				l466:
-					position, tokenIndex = position465, tokenIndex465
+					goto l500
+				l501:
+					position, tokenIndex = position500, tokenIndex500
 					if buffer[position] != rune('1') {
-						goto l463
+						goto l498
 					}
 					position++
 				}
-			l465:
+			l500:
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l463
+					goto l498
 				}
 				position++
 				if buffer[position] != rune('-') {
-					goto l463
+					goto l498
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('3') {
-					goto l463
+					goto l498
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l463
+					goto l498
 				}
 				position++
 				if buffer[position] != rune('T') {
-					goto l463
+					goto l498
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l463
+					goto l498
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l463
+					goto l498
 				}
 				position++
 				if buffer[position] != rune(':') {
-					goto l463
+					goto l498
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l463
+					goto l498
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l463
+					goto l498
 				}
 				position++
-				add(ruletimebasicfmt, position464)
+				add(ruletimebasicfmt, position499)
 			}
 			return true
-		l463:
-			position, tokenIndex = position463, tokenIndex463
+		l498:
+			position, tokenIndex = position498, tokenIndex498
 			return false
 		},
-		/* 38 timefmt <- <(('"' <timebasicfmt> '"') / ('\'' <timebasicfmt> '\'') / <timebasicfmt>)> */
+		/* 39 timefmt <- <(('"' <timebasicfmt> '"') / ('\'' <timebasicfmt> '\'') / <timebasicfmt>)> */
 		func() bool {
-			position467, tokenIndex467 := position, tokenIndex
+			position502, tokenIndex502 := position, tokenIndex
 			{
-				position468 := position
+				position503 := position
 				{
-					position469, tokenIndex469 := position, tokenIndex
+					position504, tokenIndex504 := position, tokenIndex
 					if buffer[position] != rune('"') {
-						goto l470
+						goto l505
 					}
 					position++
 					{
-						position471 := position
+						position506 := position
 						if !_rules[ruletimebasicfmt]() {
-							goto l470
+							goto l505
 						}
-						add(rulePegText, position471)
+						add(rulePegText, position506)
 					}
 					if buffer[position] != rune('"') {
-						goto l470
+						goto l505
 					}
 					position++
-					goto l469
-				l470:
-					position, tokenIndex = position469, tokenIndex469
+					goto l504
+				l505:
+					position, tokenIndex = position504, tokenIndex504
 					if buffer[position] != rune('\'') {
-						goto l472
+						goto l507
 					}
 					position++
 					{
-						position473 := position
+						position508 := position
 						if !_rules[ruletimebasicfmt]() {
-							goto l472
+							goto l507
 						}
-						add(rulePegText, position473)
+						add(rulePegText, position508)
 					}
 					if buffer[position] != rune('\'') {
-						goto l472
+						goto l507
 					}
 					position++
-					goto l469
-				l472:
-					position, tokenIndex = position469, tokenIndex469
+					goto l504
+				l507:
+					position, tokenIndex = position504, tokenIndex504
 					{
-						position474 := position
+						position509 := position
 						if !_rules[ruletimebasicfmt]() {
-							goto l467
+							goto l502
 						}
-						add(rulePegText, position474)
+						add(rulePegText, position509)
 					}
 				}
-			l469:
-				add(ruletimefmt, position468)
+			l504:
+				add(ruletimefmt, position503)
 			}
 			return true
-		l467:
-			position, tokenIndex = position467, tokenIndex467
+		l502:
+			position, tokenIndex = position502, tokenIndex502
 			return false
 		},
-		/* 39 time <- <(<timefmt> Action61)> */
+		/* 40 time <- <(<timefmt> Action68)> */
+		nil,
+		nil,
+		/* 43 Action0 <- <{ p.startBinding(text) }> */
+		nil,
+		/* 44 Action1 <- <{ p.endBinding() }> */
+		nil,
+		/* 45 Action2 <- <{p.startCall("Set")}> */
+		nil,
+		/* 46 Action3 <- <{p.endCall()}> */
+		nil,
+		/* 47 Action4 <- <{p.startCall("Clear")}> */
+		nil,
+		/* 48 Action5 <- <{p.endCall()}> */
 		nil,
-		/* 41 Action0 <- <{p.startCall("Set")}> */
+		/* 49 Action6 <- <{p.startCall("ClearRow")}> */
 		nil,
-		/* 42 Action1 <- <{p.endCall()}> */
+		/* 50 Action7 <- <{p.endCall()}> */
 		nil,
-		/* 43 Action2 <- <{p.startCall("Clear")}> */
+		/* 51 Action8 <- <{p.startCall("Store")}> */
 		nil,
-		/* 44 Action3 <- <{p.endCall()}> */
+		/* 52 Action9 <- <{p.endCall()}> */
 		nil,
-		/* 45 Action4 <- <{p.startCall("ClearRow")}> */
+		/* 53 Action10 <- <{p.startCall("TopN")}> */
 		nil,
-		/* 46 Action5 <- <{p.endCall()}> */
+		/* 54 Action11 <- <{p.endCall()}> */
 		nil,
-		/* 47 Action6 <- <{p.startCall("Store")}> */
+		/* 55 Action12 <- <{p.startCall("TopK")}> */
 		nil,
-		/* 48 Action7 <- <{p.endCall()}> */
+		/* 56 Action13 <- <{p.endCall()}> */
 		nil,
-		/* 49 Action8 <- <{p.startCall("TopN")}> */
+		/* 57 Action14 <- <{p.startCall("Percentile")}> */
 		nil,
-		/* 50 Action9 <- <{p.endCall()}> */
+		/* 58 Action15 <- <{p.endCall()}> */
 		nil,
-		/* 51 Action10 <- <{p.startCall("TopK")}> */
+		/* 59 Action16 <- <{p.startCall("Rows")}> */
 		nil,
-		/* 52 Action11 <- <{p.endCall()}> */
+		/* 60 Action17 <- <{p.endCall()}> */
 		nil,
-		/* 53 Action12 <- <{p.startCall("Percentile")}> */
+		/* 61 Action18 <- <{p.startCall("Min")}> */
 		nil,
-		/* 54 Action13 <- <{p.endCall()}> */
+		/* 62 Action19 <- <{p.endCall()}> */
 		nil,
-		/* 55 Action14 <- <{p.startCall("Rows")}> */
+		/* 63 Action20 <- <{p.startCall("Max")}> */
 		nil,
-		/* 56 Action15 <- <{p.endCall()}> */
+		/* 64 Action21 <- <{p.endCall()}> */
 		nil,
-		/* 57 Action16 <- <{p.startCall("Min")}> */
+		/* 65 Action22 <- <{p.startCall("Sum")}> */
 		nil,
-		/* 58 Action17 <- <{p.endCall()}> */
+		/* 66 Action23 <- <{p.endCall()}> */
 		nil,
-		/* 59 Action18 <- <{p.startCall("Max")}> */
+		/* 67 Action24 <- <{p.startCall("Range")}> */
 		nil,
-		/* 60 Action19 <- <{p.endCall()}> */
+		/* 68 Action25 <- <{p.addField("from")}> */
 		nil,
-		/* 61 Action20 <- <{p.startCall("Sum")}> */
+		/* 69 Action26 <- <{p.addVal(text)}> */
 		nil,
-		/* 62 Action21 <- <{p.endCall()}> */
+		/* 70 Action27 <- <{p.addField("to")}> */
 		nil,
-		/* 63 Action22 <- <{p.startCall("Range")}> */
+		/* 71 Action28 <- <{p.addVal(text)}> */
 		nil,
-		/* 64 Action23 <- <{p.addField("from")}> */
+		/* 72 Action29 <- <{p.endCall()}> */
 		nil,
-		/* 65 Action24 <- <{p.addVal(text)}> */
+		/* 73 Action30 <- <{p.startCall("Between")}> */
 		nil,
-		/* 66 Action25 <- <{p.addField("to")}> */
+		/* 74 Action31 <- <{p.startBetween()}> */
 		nil,
-		/* 67 Action26 <- <{p.addVal(text)}> */
+		/* 75 Action32 <- <{p.endBetween()}> */
 		nil,
-		/* 68 Action27 <- <{p.endCall()}> */
+		/* 76 Action33 <- <{ p.startCall(text) }> */
 		nil,
+		/* 77 Action34 <- <{ p.endCall() }> */
 		nil,
-		/* 70 Action28 <- <{ p.startCall(text) }> */
+		/* 78 Action35 <- <{ p.addRef(text) }> */
 		nil,
-		/* 71 Action29 <- <{ p.endCall() }> */
+		/* 79 Action36 <- <{ p.addBTWN() }> */
 		nil,
-		/* 72 Action30 <- <{ p.addBTWN() }> */
+		/* 80 Action37 <- <{ p.addLTE() }> */
 		nil,
-		/* 73 Action31 <- <{ p.addLTE() }> */
+		/* 81 Action38 <- <{ p.addGTE() }> */
 		nil,
-		/* 74 Action32 <- <{ p.addGTE() }> */
+		/* 82 Action39 <- <{ p.addEQ() }> */
 		nil,
-		/* 75 Action33 <- <{ p.addEQ() }> */
+		/* 83 Action40 <- <{ p.addNEQ() }> */
 		nil,
-		/* 76 Action34 <- <{ p.addNEQ() }> */
+		/* 84 Action41 <- <{ p.addLT() }> */
 		nil,
-		/* 77 Action35 <- <{ p.addLT() }> */
+		/* 85 Action42 <- <{ p.addGT() }> */
 		nil,
-		/* 78 Action36 <- <{ p.addGT() }> */
+		/* 86 Action43 <- <{ p.addIN() }> */
 		nil,
-		/* 79 Action37 <- <{p.startConditional()}> */
+		/* 87 Action44 <- <{p.startConditional()}> */
 		nil,
-		/* 80 Action38 <- <{p.endConditional()}> */
+		/* 88 Action45 <- <{p.endConditional()}> */
 		nil,
-		/* 81 Action39 <- <{p.condAdd(text)}> */
+		/* 89 Action46 <- <{p.condAdd(text)}> */
 		nil,
-		/* 82 Action40 <- <{p.condAdd(text)}> */
+		/* 90 Action47 <- <{p.condAdd(text)}> */
 		nil,
-		/* 83 Action41 <- <{p.condAdd(text)}> */
+		/* 91 Action48 <- <{p.condAdd(text)}> */
 		nil,
-		/* 84 Action42 <- <{ p.startList() }> */
+		/* 92 Action49 <- <{ p.startList() }> */
 		nil,
-		/* 85 Action43 <- <{ p.endList() }> */
+		/* 93 Action50 <- <{ p.endList() }> */
 		nil,
-		/* 86 Action44 <- <{ p.addVal(nil) }> */
+		/* 94 Action51 <- <{ p.addVal(nil) }> */
 		nil,
-		/* 87 Action45 <- <{ p.addVal(true) }> */
+		/* 95 Action52 <- <{ p.addVal(true) }> */
 		nil,
-		/* 88 Action46 <- <{ p.addVal(false) }> */
+		/* 96 Action53 <- <{ p.addVal(false) }> */
 		nil,
-		/* 89 Action47 <- <{ p.addVal(NewVariable(text)) }> */
+		/* 97 Action54 <- <{ p.addVal(NewVariable(text)) }> */
 		nil,
-		/* 90 Action48 <- <{ p.addVal(text) }> */
+		/* 98 Action55 <- <{ p.addVal(text) }> */
 		nil,
-		/* 91 Action49 <- <{ p.addTimestampVal(text) }> */
+		/* 99 Action56 <- <{ p.addTimestampVal(text) }> */
 		nil,
-		/* 92 Action50 <- <{ p.addNumVal(text) }> */
+		/* 100 Action57 <- <{ p.addNumVal(text) }> */
 		nil,
-		/* 93 Action51 <- <{ p.startCall(text) }> */
+		/* 101 Action58 <- <{ p.startCall(text) }> */
 		nil,
-		/* 94 Action52 <- <{ p.addVal(p.endCall()) }> */
+		/* 102 Action59 <- <{ p.addVal(p.endCall()) }> */
 		nil,
-		/* 95 Action53 <- <{ p.addVal(text) }> */
+		/* 103 Action60 <- <{ p.addVal(text) }> */
 		nil,
-		/* 96 Action54 <- <{ p.addVal(text) }> */
+		/* 104 Action61 <- <{ p.addVal(text) }> */
 		nil,
-		/* 97 Action55 <- <{ p.addVal(text) }> */
+		/* 105 Action62 <- <{ p.addVal(text) }> */
 		nil,
-		/* 98 Action56 <- <{ p.addField(text) }> */
+		/* 106 Action63 <- <{ p.addField(text) }> */
 		nil,
-		/* 99 Action57 <- <{ p.addPosStr("_field", text) }> */
+		/* 107 Action64 <- <{ p.addPosStr("_field", text) }> */
 		nil,
-		/* 100 Action58 <- <{p.addPosNum("_col", text)}> */
+		/* 108 Action65 <- <{p.addPosNum("_col", text)}> */
 		nil,
-		/* 101 Action59 <- <{p.addPosStr("_col", text)}> */
+		/* 109 Action66 <- <{p.addPosStr("_col", text)}> */
 		nil,
-		/* 102 Action60 <- <{p.addPosStr("_col", text)}> */
+		/* 110 Action67 <- <{p.addPosStr("_col", text)}> */
 		nil,
-		/* 103 Action61 <- <{p.addPosStr("_timestamp", text)}> */
+		/* 111 Action68 <- <{p.addPosStr("_timestamp", text)}> */
 		nil,
 	}
 	p.rules = _rules
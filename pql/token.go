@@ -27,6 +27,8 @@ const (
 	BTWN_LT_LTE // a < x <= b
 	BTWN_LTE_LT // a <= x < b
 	BTWN_LT_LT  // a < x < b
+
+	IN // in  (x is one of a list of values)
 )
 
 var tokens = [...]string{
@@ -40,6 +42,7 @@ var tokens = [...]string{
 	GT:      ">",
 	GTE:     ">=",
 	BETWEEN: "><",
+	IN:      "in",
 }
 
 // String returns the string representation of the token.
@@ -0,0 +1,354 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Priority classes of queries, given by a query request's Priority. Queries
+// of a lower class are admitted and have their shards worked on only after
+// those of higher classes, so large batch exports can't hold up interactive
+// queries. Queries without a priority are interactive.
+const (
+	QueryPriorityInteractive = "interactive"
+	QueryPriorityBatch       = "batch"
+	QueryPriorityBackground  = "background"
+)
+
+// Priority classes, from highest to lowest.
+const (
+	priorityInteractive = iota
+	priorityBatch
+	priorityBackground
+	numQueryPriorities
+)
+
+// queryPriorities maps priority classes to their names.
+var queryPriorities = [numQueryPriorities]string{
+	priorityInteractive: QueryPriorityInteractive,
+	priorityBatch:       QueryPriorityBatch,
+	priorityBackground:  QueryPriorityBackground,
+}
+
+// defaultMaxPrioritySkips is how many times a priority class with work
+// waiting can be passed over for higher classes before it's served anyway.
+const defaultMaxPrioritySkips = 16
+
+// queryPriorityClass returns the class of a query priority.
+func queryPriorityClass(priority string) (int, error) {
+	if priority == "" {
+		return priorityInteractive, nil
+	}
+	for class, p := range queryPriorities {
+		if p == priority {
+			return class, nil
+		}
+	}
+	return 0, NewBadRequestError(errors.Errorf("invalid query priority %q, expected %q, %q or %q", priority, QueryPriorityInteractive, QueryPriorityBatch, QueryPriorityBackground))
+}
+
+type contextKeyQueryPriorityType struct{}
+
+var contextKeyQueryPriority = contextKeyQueryPriorityType{}
+
+// withQueryPriority returns a context carrying the priority class of a
+// query, for its shards to be queued by.
+func withQueryPriority(ctx context.Context, class int) context.Context {
+	return context.WithValue(ctx, contextKeyQueryPriority, class)
+}
+
+// queryPriorityFromContext returns the priority class of the query running
+// in ctx.
+func queryPriorityFromContext(ctx context.Context) int {
+	class, _ := ctx.Value(contextKeyQueryPriority).(int)
+	return class
+}
+
+type contextKeyQueryClientType struct{}
+
+var contextKeyQueryClient = contextKeyQueryClientType{}
+
+// withQueryClient returns a context carrying the client which made a
+// query, for its shards to be queued by.
+func withQueryClient(ctx context.Context, client string) context.Context {
+	return context.WithValue(ctx, contextKeyQueryClient, client)
+}
+
+// queryClientFromContext returns the client which made the query running
+// in ctx.
+func queryClientFromContext(ctx context.Context) string {
+	client, _ := ctx.Value(contextKeyQueryClient).(string)
+	return client
+}
+
+// strideScale is the stride of a client with a weight of one.
+const strideScale = 1 << 20
+
+// jobQueue holds the shard jobs of local queries waiting for a worker, in a
+// queue per priority class. Workers take the highest priority job waiting,
+// except that a class which has been passed over maxSkips times while it
+// had jobs waiting is served next, so lower priority queries always make
+// progress.
+//
+// Within a class, jobs are queued per client, and the clients with jobs
+// waiting are served in proportion to their weights, so one client
+// flooding the queue with the shards of huge queries only gets its share
+// of the workers. Each client has a pass which goes up by its stride, the
+// inverse of its weight, whenever one of its jobs is taken, and the client
+// with the lowest pass goes next. A client joins at the pass of the last
+// job taken, so it can't make up for the time it had nothing queued.
+type jobQueue struct {
+	// A slot must be taken in the room of a class before pushing a job
+	// to it, so each class holds at most as many jobs as its room.
+	room [numQueryPriorities]chan struct{}
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	classes  [numQueryPriorities]classQueue
+	skipped  [numQueryPriorities]int
+	maxSkips int
+	weights  map[string]int
+	loads    map[string]*ClientLoad
+	closed   bool
+}
+
+// classQueue holds the jobs of a priority class, by client.
+type classQueue struct {
+	clients map[string]*clientQueue
+	pass    uint64
+	n       int
+}
+
+// clientQueue holds a client's jobs of a priority class.
+type clientQueue struct {
+	jobs []job
+	pass uint64
+}
+
+// ClientLoad is how many shard jobs of a client's queries are queued and
+// running on a node.
+type ClientLoad struct {
+	Queued  int `json:"queued"`
+	Running int `json:"running"`
+}
+
+// newJobQueue returns a jobQueue with room for size jobs of each class,
+// sharing workers between clients by weights. Clients without a weight
+// have a weight of one.
+func newJobQueue(size, maxSkips int, weights map[string]int) *jobQueue {
+	q := &jobQueue{
+		maxSkips: maxSkips,
+		weights:  weights,
+		loads:    make(map[string]*ClientLoad),
+	}
+	q.cond = sync.NewCond(&q.mu)
+	for i := range q.room {
+		q.room[i] = make(chan struct{}, size)
+		q.classes[i].clients = make(map[string]*clientQueue)
+	}
+	return q
+}
+
+// reserve returns the room of a priority class, in which a slot must be
+// taken before a job is pushed.
+func (q *jobQueue) reserve(class int) chan<- struct{} {
+	return q.room[class]
+}
+
+// push queues a job of a priority class, for which a slot has been taken.
+func (q *jobQueue) push(class int, j job) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	c := &q.classes[class]
+	cq := c.clients[j.client]
+	if cq == nil {
+		cq = &clientQueue{pass: c.pass}
+		c.clients[j.client] = cq
+	}
+	cq.jobs = append(cq.jobs, j)
+	c.n++
+	q.load(j.client).Queued++
+	q.cond.Signal()
+}
+
+// load returns the load of a client. The caller must hold q.mu.
+func (q *jobQueue) load(client string) *ClientLoad {
+	l := q.loads[client]
+	if l == nil {
+		l = &ClientLoad{}
+		q.loads[client] = l
+	}
+	return l
+}
+
+// stride returns how far a client's pass goes up for each of its jobs.
+func (q *jobQueue) stride(client string) uint64 {
+	if w := q.weights[client]; w > 0 {
+		return strideScale / uint64(w)
+	}
+	return strideScale
+}
+
+// len returns the number of jobs queued.
+func (q *jobQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := 0
+	for _, c := range q.classes {
+		n += c.n
+	}
+	return n
+}
+
+// close closes the queue, after which next returns false once the jobs
+// queued have been taken.
+func (q *jobQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// next returns the next job a worker should run, waiting for one if none
+// are queued. ok is false once the queue has been closed. The worker must
+// call finished once it's run the job.
+func (q *jobQueue) next() (j job, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if j, found := q.take(); found {
+			return j, true
+		} else if q.closed {
+			return job{}, false
+		}
+		q.cond.Wait()
+	}
+}
+
+// take returns the next job a worker should run without waiting. found is
+// false if no job was queued. The caller must hold q.mu.
+func (q *jobQueue) take() (j job, found bool) {
+	// Starved classes go first, then the rest in order of priority.
+	for _, starved := range []bool{true, false} {
+		for class := range q.classes {
+			if (q.skipped[class] >= q.maxSkips) != starved || q.classes[class].n == 0 {
+				continue
+			}
+			j = q.classes[class].take(q)
+			<-q.room[class]
+			q.skipped[class] = 0
+			for lower := class + 1; lower < numQueryPriorities; lower++ {
+				if q.classes[lower].n > 0 {
+					q.skipped[lower]++
+				}
+			}
+			l := q.load(j.client)
+			l.Queued--
+			l.Running++
+			return j, true
+		}
+	}
+	return j, false
+}
+
+// take removes the next job of the client with the lowest pass, which must
+// exist, breaking ties by name.
+func (c *classQueue) take(q *jobQueue) job {
+	var client string
+	var next *clientQueue
+	for name, cq := range c.clients {
+		if next == nil || cq.pass < next.pass || (cq.pass == next.pass && name < client) {
+			client, next = name, cq
+		}
+	}
+	j := next.jobs[0]
+	next.jobs[0] = job{}
+	next.jobs = next.jobs[1:]
+	c.pass = next.pass
+	next.pass += q.stride(client)
+	if len(next.jobs) == 0 {
+		delete(c.clients, client)
+	}
+	c.n--
+	return j
+}
+
+// finished records that a job taken by next has been run.
+func (q *jobQueue) finished(j job) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	l := q.load(j.client)
+	if l.Running--; l.Running == 0 && l.Queued == 0 {
+		delete(q.loads, j.client)
+	}
+}
+
+// clientLoads returns the loads of the clients with jobs queued or
+// running.
+func (q *jobQueue) clientLoads() map[string]ClientLoad {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	loads := make(map[string]ClientLoad, len(q.loads))
+	for client, l := range q.loads {
+		loads[client] = *l
+	}
+	return loads
+}
+
+// queryAdmission limits how many queries of each priority class can run on
+// a coordinating node at once. A query beyond the limit of its class waits
+// for another of its class to finish. Interactive queries are never held
+// back.
+type queryAdmission struct {
+	slots [numQueryPriorities]chan struct{}
+}
+
+// newQueryAdmission returns a queryAdmission admitting up to maxBatch batch
+// queries and maxBackground background queries at once. A limit of zero
+// admits any number.
+func newQueryAdmission(maxBatch, maxBackground int) *queryAdmission {
+	a := &queryAdmission{}
+	if maxBatch > 0 {
+		a.slots[priorityBatch] = make(chan struct{}, maxBatch)
+	}
+	if maxBackground > 0 {
+		a.slots[priorityBackground] = make(chan struct{}, maxBackground)
+	}
+	return a
+}
+
+// admit waits for a query of a priority class to be admitted, returning a
+// function to call once it has finished.
+func (a *queryAdmission) admit(ctx context.Context, class int) (release func(), err error) {
+	slots := a.slots[class]
+	if slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "waiting for admission")
+	}
+}
+
+// admitQuery admits a query of a priority class on the coordinating node,
+// recording how long it waited.
+func (e *executor) admitQuery(ctx context.Context, class int) (release func(), err error) {
+	start := time.Now()
+	release, err = e.queryAdmission().admit(ctx, class)
+	if err != nil {
+		return nil, err
+	}
+	if e.Holder != nil {
+		stats := e.Holder.Stats.WithTags("priority:" + queryPriorities[class])
+		stats.Count(MetricQueryPriority, 1, 1.0)
+		stats.Timing(MetricQueryAdmissionWaitSeconds, time.Since(start), 1.0)
+	}
+	return release, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestQueryPriorityClass(t *testing.T) {
+	for priority, exp := range map[string]int{
+		"":                       priorityInteractive,
+		QueryPriorityInteractive: priorityInteractive,
+		QueryPriorityBatch:       priorityBatch,
+		QueryPriorityBackground:  priorityBackground,
+	} {
+		if class, err := queryPriorityClass(priority); err != nil || class != exp {
+			t.Errorf("priority %q: expected class %d, got %d (%v)", priority, exp, class, err)
+		}
+	}
+	if _, err := queryPriorityClass("urgent"); err == nil {
+		t.Error("expected error for unknown priority")
+	}
+}
+
+func TestJobQueue(t *testing.T) {
+	q := newJobQueue(10, 2, nil)
+	push := func(class int, shard uint64) {
+		q.reserve(class) <- struct{}{}
+		q.push(class, job{shard: shard})
+	}
+	for i := uint64(0); i < 5; i++ {
+		push(priorityInteractive, i)
+	}
+	push(priorityBatch, 100)
+	push(priorityBackground, 200)
+
+	// Lower classes are served once they've been passed over too often,
+	// and otherwise jobs are taken in order of priority.
+	var got []uint64
+	for q.len() > 0 {
+		j, ok := q.next()
+		if !ok {
+			t.Fatal("unexpected closed queue")
+		}
+		got = append(got, j.shard)
+	}
+	exp := []uint64{0, 1, 100, 200, 2, 3, 4}
+	if len(got) != len(exp) {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+	for i := range exp {
+		if got[i] != exp[i] {
+			t.Fatalf("expected %v, got %v", exp, got)
+		}
+	}
+
+	q.close()
+	if _, ok := q.next(); ok {
+		t.Fatal("expected closed queue")
+	}
+}
+
+func TestJobQueue_Clients(t *testing.T) {
+	q := newJobQueue(10, 2, map[string]int{"b": 2})
+	push := func(client string, shard uint64) {
+		q.reserve(priorityInteractive) <- struct{}{}
+		q.push(priorityInteractive, job{shard: shard, client: client})
+	}
+	for i := uint64(0); i < 6; i++ {
+		push("a", i)
+	}
+	for i := uint64(10); i < 14; i++ {
+		push("b", i)
+	}
+
+	j, _ := q.next()
+	if loads := q.clientLoads(); loads["a"] != (ClientLoad{Queued: 5, Running: 1}) || loads["b"] != (ClientLoad{Queued: 4}) {
+		t.Fatalf("unexpected loads: %v", loads)
+	}
+	q.finished(j)
+	if loads := q.clientLoads(); loads["a"] != (ClientLoad{Queued: 5}) {
+		t.Fatalf("unexpected loads: %v", loads)
+	}
+
+	// b has twice the weight of a, so it gets two jobs taken for each of
+	// a's while both have jobs queued.
+	got := []uint64{j.shard}
+	for q.len() > 0 {
+		j, _ := q.next()
+		q.finished(j)
+		got = append(got, j.shard)
+	}
+	exp := []uint64{0, 10, 11, 1, 12, 13, 2, 3, 4, 5}
+	if len(got) != len(exp) {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+	for i := range exp {
+		if got[i] != exp[i] {
+			t.Fatalf("expected %v, got %v", exp, got)
+		}
+	}
+	if loads := q.clientLoads(); len(loads) != 0 {
+		t.Fatalf("expected no loads, got %v", loads)
+	}
+}
+
+func TestQueryAdmission(t *testing.T) {
+	a := newQueryAdmission(1, 0)
+
+	// Interactive and background queries aren't limited here.
+	for _, class := range []int{priorityInteractive, priorityBackground} {
+		for i := 0; i < 3; i++ {
+			if _, err := a.admit(context.Background(), class); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	release, err := a.admit(context.Background(), priorityBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := a.admit(ctx, priorityBatch); err == nil {
+		t.Fatal("expected second batch query to wait")
+	}
+	release()
+	if _, err := a.admit(context.Background(), priorityBatch); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -325,6 +325,12 @@ func (m *TableResponse) GetDuration() int64 {
 type ColumnInfo struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Datatype             string   `protobuf:"bytes,2,opt,name=datatype,proto3" json:"datatype,omitempty"`
+	Nullable             bool     `protobuf:"varint,3,opt,name=nullable,proto3" json:"nullable,omitempty"`
+	Scale                int64    `protobuf:"varint,4,opt,name=scale,proto3" json:"scale,omitempty"`
+	Epoch                string   `protobuf:"bytes,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	TimeUnit             string   `protobuf:"bytes,6,opt,name=timeUnit,proto3" json:"timeUnit,omitempty"`
+	Index                string   `protobuf:"bytes,7,opt,name=index,proto3" json:"index,omitempty"`
+	Field                string   `protobuf:"bytes,8,opt,name=field,proto3" json:"field,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -369,6 +375,48 @@ func (m *ColumnInfo) GetDatatype() string {
 	return ""
 }
 
+func (m *ColumnInfo) GetNullable() bool {
+	if m != nil {
+		return m.Nullable
+	}
+	return false
+}
+
+func (m *ColumnInfo) GetScale() int64 {
+	if m != nil {
+		return m.Scale
+	}
+	return 0
+}
+
+func (m *ColumnInfo) GetEpoch() string {
+	if m != nil {
+		return m.Epoch
+	}
+	return ""
+}
+
+func (m *ColumnInfo) GetTimeUnit() string {
+	if m != nil {
+		return m.TimeUnit
+	}
+	return ""
+}
+
+func (m *ColumnInfo) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *ColumnInfo) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
 type ColumnResponse struct {
 	// Types that are valid to be assigned to ColumnVal:
 	//	*ColumnResponse_StringVal
@@ -1218,66 +1266,70 @@ func init() {
 }
 
 var fileDescriptor_ef0691a44d1e275c = []byte{
-	// 939 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x5b, 0x73, 0x1b, 0x35,
-	0x14, 0xf6, 0x76, 0x37, 0xbe, 0x9c, 0xcd, 0xad, 0x0a, 0x0d, 0x8b, 0xcb, 0x80, 0xab, 0xc0, 0xd4,
-	0x0c, 0x4c, 0x5a, 0x0c, 0xa5, 0x03, 0xa4, 0xc3, 0x34, 0x69, 0xc1, 0x19, 0x60, 0x70, 0x55, 0xda,
-	0x07, 0xde, 0x64, 0x5b, 0x4e, 0x77, 0x58, 0xaf, 0x9c, 0x95, 0x9c, 0xe0, 0xff, 0xc4, 0x0b, 0xcf,
-	0xbc, 0xf0, 0x5b, 0xf8, 0x25, 0x8c, 0x6e, 0x7b, 0xb1, 0x1d, 0x1a, 0x98, 0xe9, 0x93, 0x75, 0xce,
-	0xf7, 0x9d, 0xa3, 0xf3, 0x9d, 0x23, 0xc9, 0x0b, 0x9b, 0xb3, 0x38, 0xe1, 0x82, 0x1e, 0xce, 0x32,
-	0x2e, 0x39, 0xaa, 0x1b, 0x0b, 0x7f, 0x09, 0x3b, 0xcf, 0xe6, 0x2c, 0x5b, 0x0c, 0x9e, 0xfd, 0x40,
-	0xd8, 0xf9, 0x9c, 0x09, 0x89, 0xde, 0x82, 0x8d, 0x38, 0x1d, 0xb3, 0xdf, 0x22, 0xaf, 0xe3, 0x75,
-	0x5b, 0xc4, 0x18, 0x68, 0x17, 0xfc, 0xd9, 0x79, 0x12, 0xdd, 0xd0, 0x3e, 0xb5, 0xc4, 0x07, 0x36,
-	0xf4, 0x79, 0x11, 0xba, 0x0b, 0xbe, 0x38, 0x4f, 0x6c, 0xa0, 0x5a, 0xe2, 0xaf, 0x21, 0x7c, 0x2e,
-	0xa9, 0x9c, 0x8b, 0xa7, 0x59, 0xc6, 0x33, 0x84, 0x20, 0x38, 0xe1, 0x63, 0xa6, 0x19, 0x5b, 0x44,
-	0xaf, 0x51, 0x04, 0x8d, 0x1f, 0x99, 0x10, 0xf4, 0x8c, 0xd9, 0xec, 0xce, 0xc4, 0x7f, 0x79, 0x10,
-	0x12, 0x7e, 0x49, 0x98, 0x98, 0xf1, 0x54, 0x30, 0xf4, 0x09, 0x34, 0x5e, 0x31, 0x3a, 0x66, 0x99,
-	0x88, 0xbc, 0x8e, 0xdf, 0x0d, 0x7b, 0xe8, 0xd0, 0x8a, 0x3a, 0xe1, 0xc9, 0x7c, 0x9a, 0x9e, 0xa6,
-	0x13, 0x4e, 0x1c, 0x05, 0xdd, 0x87, 0xc6, 0x48, 0xbb, 0x45, 0x74, 0x43, 0xb3, 0xf7, 0xab, 0x6c,
-	0x97, 0x96, 0x38, 0x1a, 0x7a, 0x50, 0x29, 0x36, 0xf2, 0x3b, 0x5e, 0x37, 0xec, 0xed, 0xb9, 0xa8,
-	0x12, 0x44, 0x2a, 0xa2, 0xda, 0xd0, 0x1c, 0xcf, 0x33, 0x2a, 0x63, 0x9e, 0x46, 0x41, 0xc7, 0xeb,
-	0xfa, 0x24, 0xb7, 0xf1, 0x43, 0xf0, 0x09, 0xbf, 0x2c, 0xd7, 0xe2, 0x5d, 0xab, 0x16, 0xfc, 0x87,
-	0x07, 0x5b, 0x3f, 0xd3, 0x61, 0xc2, 0xfe, 0xa7, 0xfa, 0xf7, 0x21, 0xc8, 0xf8, 0xa5, 0x93, 0x1e,
-	0x3a, 0xaa, 0x6a, 0xa7, 0x06, 0xde, 0x84, 0xd8, 0x23, 0x80, 0xa2, 0x14, 0x35, 0xeb, 0x94, 0x4e,
-	0x99, 0x3d, 0x0d, 0x7a, 0xad, 0xa3, 0xa9, 0xa4, 0x72, 0x31, 0x73, 0xc3, 0xce, 0x6d, 0xfc, 0xbb,
-	0x0f, 0xdb, 0xd5, 0x6e, 0xa0, 0xf7, 0xa0, 0x25, 0x64, 0x16, 0xa7, 0x67, 0x2f, 0xa9, 0x3d, 0x55,
-	0xfd, 0x1a, 0x29, 0x5c, 0x0a, 0x9f, 0xc7, 0xa9, 0xfc, 0xe2, 0x73, 0x85, 0xab, 0x7c, 0x81, 0xc2,
-	0x73, 0x17, 0x7a, 0x17, 0x9a, 0x39, 0xac, 0x04, 0xfa, 0xfd, 0x1a, 0xc9, 0x3d, 0xa8, 0x0d, 0x8d,
-	0x21, 0xe7, 0x89, 0x02, 0x95, 0x92, 0x66, 0xbf, 0x46, 0x9c, 0x43, 0x63, 0x09, 0x1f, 0x2a, 0x6c,
-	0xa3, 0xe3, 0x75, 0x37, 0x35, 0x66, 0x1c, 0xe8, 0x11, 0x6c, 0x9b, 0x2d, 0x1e, 0x67, 0x19, 0x5d,
-	0x28, 0x4a, 0xbd, 0xda, 0xbc, 0x17, 0x05, 0xda, 0xaf, 0x91, 0x25, 0xb2, 0x0a, 0x37, 0x0a, 0xf2,
-	0xf0, 0xc6, 0x72, 0xef, 0x73, 0x54, 0x85, 0x57, 0xc9, 0xa8, 0x03, 0x30, 0x49, 0x38, 0xb5, 0xaa,
-	0x9a, 0x1d, 0xaf, 0xeb, 0xf5, 0x6b, 0xa4, 0xe4, 0x43, 0x9f, 0x02, 0x8c, 0xd9, 0x28, 0x9e, 0x52,
-	0x2d, 0xad, 0xa5, 0x93, 0xef, 0xb8, 0xe4, 0x4f, 0x0c, 0xa2, 0x42, 0x0a, 0x12, 0xfa, 0x00, 0x36,
-	0x65, 0x3c, 0x65, 0x42, 0xd2, 0xe9, 0x4c, 0x05, 0x81, 0xed, 0x75, 0xc5, 0x7b, 0x1c, 0x42, 0xcb,
-	0x1c, 0xcf, 0x97, 0x34, 0xc1, 0x0f, 0xa0, 0x61, 0x73, 0xa9, 0x17, 0xe3, 0x82, 0x26, 0x73, 0x33,
-	0x6a, 0x9f, 0x18, 0x43, 0x79, 0xc5, 0x88, 0x26, 0x66, 0xd0, 0x3e, 0x31, 0x06, 0xfe, 0xd3, 0x83,
-	0xed, 0xd3, 0x54, 0xcc, 0xd8, 0x48, 0xfe, 0xfb, 0x83, 0xf3, 0x71, 0xf9, 0xfa, 0x2a, 0x09, 0x37,
-	0x9d, 0x84, 0xd3, 0xb1, 0xf8, 0x29, 0xfb, 0x9e, 0x2d, 0x44, 0x71, 0x73, 0x31, 0x6c, 0x4e, 0xe2,
-	0x44, 0xb2, 0xec, 0xdb, 0x98, 0x25, 0x63, 0x11, 0xf9, 0x1d, 0xbf, 0xdb, 0x22, 0x15, 0x9f, 0xda,
-	0x26, 0x89, 0xa7, 0xb1, 0xd4, 0xc3, 0x0e, 0x88, 0x31, 0xd0, 0x3e, 0xd4, 0xf9, 0x64, 0x22, 0x98,
-	0xd4, 0x73, 0x0e, 0x88, 0xb5, 0x14, 0xfb, 0x5c, 0xbd, 0x6e, 0x7a, 0xb6, 0x2d, 0x62, 0x0c, 0x7c,
-	0x07, 0xc2, 0xd2, 0x70, 0xd5, 0x11, 0xbf, 0xa0, 0x89, 0xb9, 0x8f, 0x01, 0xd1, 0x6b, 0x45, 0x29,
-	0x0d, 0xb0, 0x42, 0x69, 0x59, 0xca, 0x19, 0xb4, 0x72, 0x0d, 0xe8, 0x2e, 0xf8, 0xf1, 0x58, 0x68,
-	0xed, 0x57, 0x1e, 0x21, 0xc5, 0x40, 0x1f, 0x41, 0xf0, 0x2b, 0x5b, 0xb8, 0x6e, 0x5c, 0x71, 0x5a,
-	0x34, 0xe5, 0xb8, 0x0e, 0x81, 0xbe, 0x52, 0xb7, 0x61, 0xe3, 0x54, 0x37, 0x73, 0xcd, 0x5d, 0xc4,
-	0x47, 0x80, 0x4e, 0x32, 0x46, 0x25, 0xd3, 0x14, 0x37, 0x8c, 0x75, 0xb7, 0x16, 0x95, 0x76, 0x6e,
-	0x9a, 0x2d, 0xf0, 0x2d, 0xd8, 0xab, 0x44, 0x9b, 0x1b, 0x8b, 0x3f, 0x84, 0x9d, 0xef, 0x98, 0x7c,
-	0x5d, 0x46, 0xfc, 0x10, 0x76, 0x0b, 0x9a, 0xbd, 0xec, 0x07, 0xe5, 0x63, 0x10, 0xf6, 0xb6, 0xf2,
-	0x71, 0x6b, 0x96, 0xc1, 0xf0, 0x1e, 0xdc, 0x74, 0x81, 0x4c, 0xd8, 0x1d, 0xf0, 0x23, 0x40, 0x65,
-	0xa7, 0xcd, 0x77, 0x17, 0x1a, 0xb1, 0x71, 0xd9, 0xf7, 0x72, 0x29, 0xa3, 0x43, 0x71, 0x17, 0xd0,
-	0x13, 0x96, 0xb0, 0xd7, 0x37, 0x42, 0x89, 0xae, 0x30, 0xcd, 0x4e, 0xbd, 0xbf, 0x03, 0xa8, 0x0f,
-	0x74, 0x6a, 0xd4, 0x87, 0xb0, 0xd4, 0x16, 0xd4, 0xce, 0x9f, 0xe8, 0x95, 0x4e, 0xb7, 0x6f, 0xaf,
-	0xc5, 0x6c, 0x1f, 0x6b, 0xe8, 0x29, 0x40, 0x21, 0x0a, 0xbd, 0xe3, 0xc8, 0x2b, 0xea, 0xdb, 0xed,
-	0x75, 0x50, 0x9e, 0xe6, 0x1b, 0x68, 0x3a, 0x3f, 0x7a, 0x7b, 0x99, 0xe9, 0x52, 0x44, 0xab, 0x40,
-	0x9e, 0xa0, 0x0f, 0x61, 0x49, 0x73, 0xa1, 0x68, 0xb5, 0x65, 0x85, 0xa2, 0x35, 0x4d, 0xc2, 0x35,
-	0x74, 0x04, 0x4d, 0xf7, 0xc1, 0x50, 0x94, 0xb2, 0xf4, 0x09, 0xd1, 0xde, 0x2b, 0xff, 0x53, 0xe5,
-	0xb1, 0xf7, 0x3d, 0xf4, 0x18, 0xb6, 0x1c, 0xf7, 0x45, 0x4a, 0xb3, 0xc5, 0xd5, 0x29, 0x6e, 0x39,
-	0xa0, 0xf2, 0xff, 0x59, 0x2a, 0x60, 0xb0, 0x52, 0xc0, 0xe0, 0x3f, 0x14, 0x30, 0x58, 0x5f, 0xc0,
-	0xe0, 0x1a, 0x05, 0x7c, 0x05, 0x0d, 0xfb, 0xf6, 0xa1, 0xfd, 0xe2, 0x30, 0x96, 0x1f, 0xc3, 0x2b,
-	0xb7, 0x3f, 0x3e, 0xf8, 0xe5, 0xce, 0x59, 0x2c, 0x5f, 0xcd, 0x87, 0x87, 0x23, 0x3e, 0xbd, 0x67,
-	0x48, 0xee, 0xe7, 0xa2, 0x77, 0x4f, 0x7f, 0xd6, 0x0d, 0xeb, 0xfa, 0xe7, 0xb3, 0x7f, 0x02, 0x00,
-	0x00, 0xff, 0xff, 0x74, 0xe6, 0x59, 0x2d, 0xed, 0x09, 0x00, 0x00,
+	// 993 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdb, 0x72, 0x1b, 0x45,
+	0x13, 0xd6, 0x7a, 0xd7, 0x3a, 0xf4, 0xfa, 0x94, 0x71, 0xe2, 0x7f, 0x7f, 0x99, 0x02, 0x65, 0x0c,
+	0x15, 0x51, 0x50, 0x4e, 0x30, 0x84, 0x14, 0x90, 0x14, 0x15, 0x3b, 0x01, 0xb9, 0x80, 0x42, 0x99,
+	0xe0, 0x5c, 0x70, 0x37, 0x92, 0x46, 0xf6, 0x16, 0xab, 0x1d, 0x79, 0x67, 0x64, 0xa3, 0x77, 0xe2,
+	0x86, 0x6b, 0x6e, 0x78, 0x00, 0x9e, 0x82, 0x27, 0xa1, 0xe6, 0xb4, 0x07, 0x49, 0x26, 0x81, 0x2a,
+	0xae, 0xb4, 0xdd, 0xdf, 0xd7, 0x3d, 0xfd, 0x75, 0xf7, 0x8e, 0x16, 0x36, 0xa6, 0x71, 0xc2, 0x05,
+	0x3d, 0x9c, 0x66, 0x5c, 0x72, 0x54, 0x37, 0x16, 0xfe, 0x0c, 0xb6, 0x5f, 0xcc, 0x58, 0x36, 0xef,
+	0xbf, 0xf8, 0x96, 0xb0, 0xcb, 0x19, 0x13, 0x12, 0xdd, 0x86, 0xf5, 0x38, 0x1d, 0xb1, 0x9f, 0x23,
+	0xaf, 0xe3, 0x75, 0x5b, 0xc4, 0x18, 0x68, 0x07, 0xfc, 0xe9, 0x65, 0x12, 0xad, 0x69, 0x9f, 0x7a,
+	0xc4, 0x07, 0x36, 0xf4, 0x65, 0x11, 0xba, 0x03, 0xbe, 0xb8, 0x4c, 0x6c, 0xa0, 0x7a, 0xc4, 0x5f,
+	0x40, 0xf8, 0x52, 0x52, 0x39, 0x13, 0xcf, 0xb3, 0x8c, 0x67, 0x08, 0x41, 0x70, 0xc2, 0x47, 0x4c,
+	0x33, 0x36, 0x89, 0x7e, 0x46, 0x11, 0x34, 0xbe, 0x63, 0x42, 0xd0, 0x73, 0x66, 0xb3, 0x3b, 0x13,
+	0xff, 0xee, 0x41, 0x48, 0xf8, 0x35, 0x61, 0x62, 0xca, 0x53, 0xc1, 0xd0, 0x87, 0xd0, 0xb8, 0x60,
+	0x74, 0xc4, 0x32, 0x11, 0x79, 0x1d, 0xbf, 0x1b, 0x1e, 0xa1, 0x43, 0x2b, 0xea, 0x84, 0x27, 0xb3,
+	0x49, 0x7a, 0x9a, 0x8e, 0x39, 0x71, 0x14, 0xf4, 0x00, 0x1a, 0x43, 0xed, 0x16, 0xd1, 0x9a, 0x66,
+	0xef, 0x55, 0xd9, 0x2e, 0x2d, 0x71, 0x34, 0xf4, 0xb0, 0x52, 0x6c, 0xe4, 0x77, 0xbc, 0x6e, 0x78,
+	0xb4, 0xeb, 0xa2, 0x4a, 0x10, 0xa9, 0x88, 0x6a, 0x43, 0x73, 0x34, 0xcb, 0xa8, 0x8c, 0x79, 0x1a,
+	0x05, 0x1d, 0xaf, 0xeb, 0x93, 0xdc, 0xc6, 0x8f, 0xc0, 0x27, 0xfc, 0xba, 0x5c, 0x8b, 0xf7, 0x46,
+	0xb5, 0xe0, 0x5f, 0x3d, 0xd8, 0xfc, 0x81, 0x0e, 0x12, 0xf6, 0x2f, 0xd5, 0xbf, 0x03, 0x41, 0xc6,
+	0xaf, 0x9d, 0xf4, 0xd0, 0x51, 0x55, 0x3b, 0x35, 0xf0, 0x5f, 0x88, 0xfd, 0xc3, 0x03, 0x28, 0x6a,
+	0x51, 0xc3, 0x4e, 0xe9, 0x84, 0xd9, 0x75, 0xd0, 0xcf, 0x3a, 0x9c, 0x4a, 0x2a, 0xe7, 0x53, 0x37,
+	0xed, 0xdc, 0x56, 0x58, 0x3a, 0x4b, 0x12, 0x25, 0x5a, 0x97, 0xd3, 0x24, 0xb9, 0xad, 0x96, 0x52,
+	0x0c, 0x69, 0xc2, 0xec, 0x99, 0xc6, 0x50, 0x5e, 0x36, 0xe5, 0xc3, 0x8b, 0x68, 0xdd, 0xac, 0xaa,
+	0x36, 0x54, 0x1e, 0x19, 0x4f, 0xd8, 0x59, 0x1a, 0xcb, 0xa8, 0x6e, 0xce, 0x70, 0x76, 0xb1, 0xdc,
+	0x8d, 0xf2, 0x72, 0xdf, 0x86, 0xf5, 0x71, 0xcc, 0x92, 0x51, 0xd4, 0x34, 0x5e, 0x6d, 0xe0, 0x5f,
+	0x7c, 0xd8, 0xaa, 0x8e, 0x07, 0xbd, 0x0d, 0x2d, 0x21, 0xb3, 0x38, 0x3d, 0x7f, 0x45, 0xed, 0x9a,
+	0xf7, 0x6a, 0xa4, 0x70, 0x29, 0x7c, 0x16, 0xa7, 0xf2, 0xd3, 0x4f, 0x14, 0xae, 0xf4, 0x05, 0x0a,
+	0xcf, 0x5d, 0xe8, 0x2d, 0x68, 0xe6, 0xb0, 0x92, 0xe8, 0xf7, 0x6a, 0x24, 0xf7, 0xa0, 0x36, 0x34,
+	0x06, 0x9c, 0x27, 0x0a, 0x54, 0x32, 0x9b, 0xbd, 0x1a, 0x71, 0x0e, 0x8d, 0x25, 0x7c, 0xa0, 0x30,
+	0x25, 0x76, 0x43, 0x63, 0xc6, 0x81, 0x9e, 0xc0, 0x96, 0x39, 0xe2, 0x69, 0x96, 0xd1, 0xb9, 0xa2,
+	0xd4, 0xab, 0xd3, 0x3c, 0x2b, 0xd0, 0x5e, 0x8d, 0x2c, 0x90, 0x55, 0xb8, 0x51, 0x90, 0x87, 0x37,
+	0x16, 0x97, 0x21, 0x47, 0x55, 0x78, 0x95, 0x8c, 0x3a, 0x00, 0xe3, 0x84, 0x53, 0xab, 0x4a, 0x75,
+	0xd0, 0xeb, 0xd5, 0x48, 0xc9, 0x87, 0x3e, 0x02, 0x18, 0xb1, 0x61, 0x3c, 0xa1, 0x5a, 0x5a, 0x4b,
+	0x27, 0xdf, 0x76, 0xc9, 0x9f, 0x19, 0x44, 0x85, 0x14, 0x24, 0xf4, 0x2e, 0x6c, 0xa8, 0x99, 0x09,
+	0x49, 0x27, 0x53, 0x15, 0x04, 0xb6, 0xd7, 0x15, 0xef, 0x71, 0x08, 0x2d, 0xf3, 0xbe, 0xbc, 0xa2,
+	0x09, 0x7e, 0x08, 0x0d, 0x9b, 0x4b, 0xcd, 0xf3, 0x8a, 0x26, 0x33, 0xb3, 0x7a, 0x3e, 0x31, 0x46,
+	0xb1, 0x43, 0x6b, 0xa5, 0x1d, 0xc2, 0xbf, 0x79, 0xb0, 0x75, 0x9a, 0x8a, 0x29, 0x1b, 0xca, 0xbf,
+	0xbf, 0x01, 0x3f, 0x28, 0xdf, 0x27, 0x4a, 0xc2, 0x2d, 0x27, 0xe1, 0x74, 0x24, 0xbe, 0xcf, 0xbe,
+	0x61, 0x73, 0x51, 0x5c, 0x25, 0x18, 0x36, 0xc6, 0x71, 0x22, 0x59, 0xf6, 0x95, 0x5a, 0x25, 0x11,
+	0xf9, 0x1d, 0xbf, 0xdb, 0x22, 0x15, 0x9f, 0x3a, 0x26, 0x89, 0x27, 0xb1, 0xd4, 0xc3, 0x0e, 0x88,
+	0x31, 0xd0, 0x1e, 0xd4, 0xf9, 0x78, 0x2c, 0x98, 0xd4, 0x73, 0x0e, 0x88, 0xb5, 0x14, 0xfb, 0x52,
+	0x5d, 0xb7, 0x76, 0xa5, 0x8d, 0x81, 0xef, 0x42, 0x58, 0x1a, 0xae, 0x7a, 0xe5, 0xae, 0x68, 0x62,
+	0x2e, 0x88, 0x80, 0xe8, 0x67, 0x45, 0x29, 0x0d, 0xb0, 0x42, 0x69, 0x59, 0xca, 0x39, 0xb4, 0x72,
+	0x0d, 0xe8, 0x1e, 0xf8, 0xf1, 0x48, 0x44, 0x5e, 0x75, 0x07, 0xaa, 0x2b, 0xa4, 0x18, 0xe8, 0x7d,
+	0x08, 0x7e, 0x62, 0x73, 0xd7, 0x8d, 0x1b, 0xb6, 0x45, 0x53, 0x8e, 0xeb, 0x10, 0xa8, 0x57, 0x1c,
+	0xef, 0xc3, 0xfa, 0xa9, 0x6e, 0xe6, 0x8a, 0xbb, 0x01, 0x3f, 0x06, 0x74, 0x92, 0x31, 0x2a, 0x99,
+	0xa6, 0xb8, 0x61, 0xac, 0x60, 0x2a, 0x5f, 0x7e, 0x72, 0xd3, 0x1c, 0x81, 0xef, 0xc0, 0x6e, 0x25,
+	0xda, 0xbc, 0xb1, 0xf8, 0x3d, 0xd8, 0xfe, 0x9a, 0xc9, 0xd7, 0x65, 0xc4, 0x8f, 0x60, 0xa7, 0xa0,
+	0xd9, 0x97, 0xfd, 0xa0, 0xbc, 0x06, 0xe1, 0xd1, 0x66, 0x3e, 0x6e, 0xcd, 0x32, 0x18, 0xde, 0x85,
+	0x5b, 0x2e, 0x90, 0x09, 0x7b, 0x02, 0x7e, 0x02, 0xa8, 0xec, 0xb4, 0xf9, 0xee, 0x41, 0x23, 0x36,
+	0x2e, 0x7b, 0x81, 0x2f, 0x64, 0x74, 0x28, 0xee, 0x02, 0x7a, 0xc6, 0x12, 0xf6, 0xfa, 0x46, 0x28,
+	0xd1, 0x15, 0xa6, 0x39, 0xe9, 0xe8, 0xcf, 0x00, 0xea, 0x7d, 0x9d, 0x1a, 0xf5, 0x20, 0x2c, 0xb5,
+	0x05, 0xb5, 0xf3, 0xff, 0x8c, 0xa5, 0x4e, 0xb7, 0xf7, 0x57, 0x62, 0xb6, 0x8f, 0x35, 0xf4, 0x1c,
+	0xa0, 0x10, 0x85, 0xfe, 0xef, 0xc8, 0x4b, 0xea, 0xdb, 0xed, 0x55, 0x50, 0x9e, 0xe6, 0x4b, 0x68,
+	0x3a, 0x3f, 0xfa, 0xdf, 0x22, 0xd3, 0xa5, 0x88, 0x96, 0x81, 0x3c, 0x41, 0x0f, 0xc2, 0x92, 0xe6,
+	0x42, 0xd1, 0x72, 0xcb, 0xda, 0xfb, 0x2b, 0xb1, 0x3c, 0xd3, 0x63, 0x68, 0xba, 0x2f, 0x98, 0xa2,
+	0x94, 0x85, 0x6f, 0x9a, 0xf6, 0x6e, 0xf9, 0xaf, 0x33, 0x8f, 0x7d, 0xe0, 0xa1, 0xa7, 0xb0, 0xe9,
+	0xb8, 0x67, 0x29, 0xcd, 0xe6, 0x37, 0xa7, 0xb8, 0xe3, 0x80, 0xca, 0x1f, 0x7a, 0xa9, 0x80, 0xfe,
+	0x52, 0x01, 0xfd, 0x7f, 0x50, 0x40, 0x7f, 0x75, 0x01, 0xfd, 0x37, 0x28, 0xe0, 0x73, 0x68, 0xd8,
+	0xbb, 0x0f, 0xed, 0x15, 0xcb, 0x58, 0xbe, 0x0c, 0x6f, 0x3c, 0xfe, 0xf8, 0xe0, 0xc7, 0xbb, 0xe7,
+	0xb1, 0xbc, 0x98, 0x0d, 0x0e, 0x87, 0x7c, 0x72, 0xdf, 0x90, 0xdc, 0xcf, 0xd5, 0xd1, 0x7d, 0xfd,
+	0x9d, 0x39, 0xa8, 0xeb, 0x9f, 0x8f, 0xff, 0x1a, 0x00, 0xae, 0x16, 0xe0, 0x42, 0x7e, 0x0a, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,122 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// The nodes of a cluster talk to each other, fanning out queries, forwarding
+// imports and broadcasting messages, over a protocol with a version. It's
+// bumped whenever a node couldn't correctly understand a request or message
+// from a node of the previous version. Each node also speaks the versions
+// back to MinProtocolVersion, so a cluster can be upgraded a node at a time.
+// Internal requests and their responses carry the versions their sender
+// speaks, and are refused if the two nodes speak no version in common,
+// rather than being misread. Nodes from before versions were exchanged
+// speak version 0.
+const (
+	ProtocolVersion    = 1
+	MinProtocolVersion = 0
+)
+
+// Headers in which internal requests and responses carry the newest and
+// oldest protocol versions their sender speaks.
+const (
+	headerProtocolVersion    = "X-Pilosa-Protocol-Version"
+	headerMinProtocolVersion = "X-Pilosa-Min-Protocol-Version"
+)
+
+// ProtocolError is returned when a request is between nodes which speak no
+// protocol version in common. Its cause is ErrIncompatibleProtocol.
+type ProtocolError struct {
+	Peer        string // the other node
+	PeerVersion int
+	PeerMin     int
+}
+
+func (e ProtocolError) Error() string {
+	return fmt.Sprintf("%s: this node speaks protocol versions %d to %d, but %s speaks %d to %d", ErrIncompatibleProtocol, MinProtocolVersion, ProtocolVersion, e.Peer, e.PeerMin, e.PeerVersion)
+}
+
+// Cause allows errors.Cause to return ErrIncompatibleProtocol.
+func (e ProtocolError) Cause() error {
+	return ErrIncompatibleProtocol
+}
+
+// Unwrap makes errors.Is(err, ErrIncompatibleProtocol) true.
+func (e ProtocolError) Unwrap() error {
+	return ErrIncompatibleProtocol
+}
+
+// setProtocolHeaders sets headers giving the protocol versions this node
+// speaks.
+func setProtocolHeaders(h http.Header) {
+	h.Set(headerProtocolVersion, strconv.Itoa(ProtocolVersion))
+	h.Set(headerMinProtocolVersion, strconv.Itoa(MinProtocolVersion))
+}
+
+// protocolVersions returns the newest and oldest protocol versions headers
+// say their sender speaks. Senders which don't say speak version 0.
+func protocolVersions(h http.Header) (version, min int, err error) {
+	v := h.Get(headerProtocolVersion)
+	if v == "" {
+		return 0, 0, nil
+	}
+	if version, err = strconv.Atoi(v); err != nil {
+		return 0, 0, errors.Wrapf(err, "parsing %s", headerProtocolVersion)
+	}
+	min = version
+	if v := h.Get(headerMinProtocolVersion); v != "" {
+		if min, err = strconv.Atoi(v); err != nil {
+			return 0, 0, errors.Wrapf(err, "parsing %s", headerMinProtocolVersion)
+		}
+	}
+	return version, min, nil
+}
+
+// compatibleProtocol reports whether this node speaks a protocol version
+// in common with a node speaking versions min to version. Nodes only check
+// this, rather than agreeing on a version to speak, since so far every
+// version can be read by the nodes of the next.
+func compatibleProtocol(version, min int) bool {
+	newest := version
+	if ProtocolVersion < newest {
+		newest = ProtocolVersion
+	}
+	return newest >= min && newest >= MinProtocolVersion
+}
+
+// checkProtocol returns an error if headers from peer show it speaks no
+// protocol version in common with this node.
+func checkProtocol(h http.Header, peer string) error {
+	version, min, err := protocolVersions(h)
+	if err != nil {
+		return err
+	}
+	if !compatibleProtocol(version, min) {
+		return ProtocolError{Peer: peer, PeerVersion: version, PeerMin: min}
+	}
+	return nil
+}
+
+// checkProtocol is middleware which refuses requests from nodes speaking no
+// protocol version in common with this one. Every response says which
+// versions this node speaks.
+func (h *Handler) checkProtocol(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setProtocolHeaders(w.Header())
+		if err := checkProtocol(r.Header, r.RemoteAddr); errors.Is(err, ErrIncompatibleProtocol) {
+			http.Error(w, err.Error(), http.StatusUpgradeRequired)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestCompatibleProtocol(t *testing.T) {
+	for _, tt := range []struct {
+		version, min int
+		ok           bool
+	}{
+		{version: 0, min: 0, ok: true},
+		{version: ProtocolVersion, min: MinProtocolVersion, ok: true},
+		{version: ProtocolVersion + 1, min: ProtocolVersion, ok: true},
+		{version: ProtocolVersion + 2, min: ProtocolVersion + 1, ok: false},
+		{version: MinProtocolVersion - 1, min: MinProtocolVersion - 1, ok: false},
+	} {
+		if ok := compatibleProtocol(tt.version, tt.min); ok != tt.ok {
+			t.Errorf("versions %d to %d: expected %v, got %v", tt.min, tt.version, tt.ok, ok)
+		}
+	}
+}
+
+// setPeerProtocolHeaders sets headers as a node speaking versions min to
+// version would.
+func setPeerProtocolHeaders(h http.Header, version, min int) {
+	h.Set(headerProtocolVersion, strconv.Itoa(version))
+	h.Set(headerMinProtocolVersion, strconv.Itoa(min))
+}
+
+func TestHandler_CheckProtocol(t *testing.T) {
+	h := (&Handler{}).checkProtocol(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, tt := range []struct {
+		name    string
+		headers func(http.Header)
+		status  int
+	}{
+		{name: "Client", headers: func(http.Header) {}, status: http.StatusOK},
+		{name: "Same", headers: setProtocolHeaders, status: http.StatusOK},
+		{name: "Newer", headers: func(h http.Header) {
+			setPeerProtocolHeaders(h, ProtocolVersion+1, ProtocolVersion)
+		}, status: http.StatusOK},
+		{name: "Incompatible", headers: func(h http.Header) {
+			setPeerProtocolHeaders(h, ProtocolVersion+2, ProtocolVersion+1)
+		}, status: http.StatusUpgradeRequired},
+		{name: "Invalid", headers: func(h http.Header) {
+			h.Set(headerProtocolVersion, "x")
+		}, status: http.StatusBadRequest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/status", nil)
+			tt.headers(r.Header)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, w.Code, w.Body)
+			}
+			if v := w.Header().Get(headerProtocolVersion); v != strconv.Itoa(ProtocolVersion) {
+				t.Fatalf("expected response protocol version %d, got %q", ProtocolVersion, v)
+			}
+		})
+	}
+}
+
+func TestInternalClient_Protocol(t *testing.T) {
+	peerVersion, peerMin := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.Header.Get(headerProtocolVersion); v != strconv.Itoa(ProtocolVersion) {
+			t.Errorf("expected request protocol version %d, got %q", ProtocolVersion, v)
+		}
+		// A peer of version 0 predates version checks, so sends no headers.
+		if peerVersion > 0 {
+			setPeerProtocolHeaders(w.Header(), peerVersion, peerMin)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"state":"NORMAL"}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewInternalClient(srv.URL, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Status(context.Background()); err != nil {
+		t.Fatalf("old peer: %v", err)
+	}
+
+	peerVersion, peerMin = ProtocolVersion+1, ProtocolVersion
+	if _, err := c.Status(context.Background()); err != nil {
+		t.Fatalf("peer one version newer: %v", err)
+	}
+
+	peerVersion, peerMin = ProtocolVersion+2, ProtocolVersion+1
+	_, err = c.Status(context.Background())
+	if !errors.Is(err, ErrIncompatibleProtocol) {
+		t.Fatalf("expected incompatible protocol, got %v", err)
+	}
+	var perr ProtocolError
+	if !errors.As(err, &perr) || perr.PeerVersion != peerVersion {
+		t.Fatalf("expected protocol error with peer version %d, got %#v", peerVersion, err)
+	}
+}
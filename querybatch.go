@@ -0,0 +1,60 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// A batch holds independent queries, such as those behind the panels of a
+// dashboard, which are run concurrently and answered together, so that a
+// client pays for one request rather than one per query. Each query is run
+// as if it were sent on its own, and gets its own response; one failing
+// doesn't fail the others, its response holds the error instead.
+
+// MaxQueryBatchSize is the most queries a batch may hold.
+const MaxQueryBatchSize = 100
+
+// QueryBatch runs each of reqs concurrently, and returns their responses
+// in the same order. The error of a query which fails is returned in its
+// response's Err; an error is only returned if the batch itself is
+// invalid.
+func (api *API) QueryBatch(ctx context.Context, reqs []*QueryRequest) ([]QueryResponse, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.QueryBatch")
+	defer span.Finish()
+
+	if err := api.validate(apiQuery); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	if len(reqs) == 0 {
+		return nil, NewBadRequestError(ErrQueryRequired)
+	} else if len(reqs) > MaxQueryBatchSize {
+		return nil, NewBadRequestError(fmt.Errorf("batch of %d queries exceeds the limit of %d", len(reqs), MaxQueryBatchSize))
+	}
+	for i, req := range reqs {
+		if req.Remote {
+			return nil, NewBadRequestError(fmt.Errorf("query %d: remote queries can't be batched", i))
+		}
+	}
+
+	resps := make([]QueryResponse, len(reqs))
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func(i int, req *QueryRequest) {
+			defer wg.Done()
+			resp, err := api.Query(ctx, req)
+			if err != nil {
+				resp = QueryResponse{Err: err}
+			}
+			resps[i] = resp
+		}(i, req)
+	}
+	wg.Wait()
+	return resps, nil
+}
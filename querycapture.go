@@ -0,0 +1,148 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/logger"
+	"github.com/pkg/errors"
+)
+
+// Query capture records the queries a node coordinates to a file, one JSON
+// object per line, so that they can be replayed against another cluster or
+// version with "featurebase replay". A sampled fraction of queries is
+// recorded, along with every query slower than the long query time, the
+// queries the slow log reports.
+
+// CapturedQuery is a query recorded by query capture.
+type CapturedQuery struct {
+	Time     time.Time     `json:"time"`
+	Index    string        `json:"index"`
+	Query    string        `json:"query"`
+	Shards   []uint64      `json:"shards,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Slow     bool          `json:"slow,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// queryCapture writes captured queries to a file. A nil queryCapture
+// records nothing.
+type queryCapture struct {
+	mu   sync.Mutex
+	file *os.File
+	w    *bufio.Writer
+	rate float64
+	rand *rand.Rand
+
+	logger logger.Logger
+}
+
+// newQueryCapture returns a queryCapture appending to the file at path,
+// sampling queries at rate, or nil if path is empty.
+func newQueryCapture(path string, rate float64, logger logger.Logger) (*queryCapture, error) {
+	if path == "" {
+		return nil, nil
+	} else if rate < 0 || rate > 1 {
+		return nil, errors.Errorf("query capture sample rate must be between 0 and 1, not %v", rate)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening query capture file")
+	}
+	return &queryCapture{
+		file:   f,
+		w:      bufio.NewWriter(f),
+		rate:   rate,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		logger: logger,
+	}, nil
+}
+
+// record writes a query to the capture file, if it's slow or sampled.
+func (c *queryCapture) record(q CapturedQuery) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == nil || (!q.Slow && c.rand.Float64() >= c.rate) {
+		return
+	}
+	buf, err := json.Marshal(q)
+	if err == nil {
+		buf = append(buf, '\n')
+		_, err = c.w.Write(buf)
+	}
+	if err == nil {
+		// Flush each query, so the file can be replayed while the
+		// server is running.
+		err = c.w.Flush()
+	}
+	if err != nil {
+		c.logger.Errorf("capturing query: %v", err)
+	}
+}
+
+// Close flushes and closes the capture file.
+func (c *queryCapture) Close() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == nil {
+		return nil
+	}
+	err := c.w.Flush()
+	if cerr := c.file.Close(); err == nil {
+		err = cerr
+	}
+	c.file = nil
+	return err
+}
+
+// captureQuery records a query this node coordinated against index, if
+// query capture is on.
+func (api *API) captureQuery(index string, req *QueryRequest, start time.Time, err error) {
+	if api.server.queryCapture == nil || req.Query == "" {
+		return
+	}
+	q := CapturedQuery{
+		Time:     start.UTC(),
+		Index:    index,
+		Query:    req.Query,
+		Shards:   req.Shards,
+		Duration: time.Since(start),
+	}
+	if long := api.LongQueryTime(); long > 0 && q.Duration > long {
+		q.Slow = true
+	}
+	if err != nil {
+		q.Error = err.Error()
+	}
+	api.server.queryCapture.record(q)
+}
+
+// ReadCapturedQueries reads the queries recorded by query capture from r,
+// calling fn with each, in order, until it returns an error.
+func ReadCapturedQueries(r io.Reader, fn func(CapturedQuery) error) error {
+	dec := json.NewDecoder(r)
+	for {
+		var q CapturedQuery
+		if err := dec.Decode(&q); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "decoding captured query")
+		}
+		if err := fn(q); err != nil {
+			return err
+		}
+	}
+}
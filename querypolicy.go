@@ -0,0 +1,244 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/featurebasedb/featurebase/v3/authn"
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// Query rules let operators forbid calls which are too expensive, or which
+// write, in ad-hoc queries. Each call in a query, including nested calls, is
+// checked against the rules in order after the query is parsed and before
+// it's executed; the first rule matching the call decides whether it's
+// allowed, and calls matching no rule are allowed. A rule can be limited to
+// some indexes, or to users in some groups, so for example an allow rule
+// for an admin group ahead of the deny rules exempts the group from them.
+// Rules are checked only by the node coordinating the query.
+
+// Actions of query rules.
+const (
+	QueryRuleDeny  = "deny"
+	QueryRuleAllow = "allow"
+)
+
+// QueryRule matches calls in queries, which it allows or denies. A rule
+// with neither Without nor Above matches every call it applies to.
+type QueryRule struct {
+	// Action is QueryRuleDeny, the default, or QueryRuleAllow.
+	Action string `json:"action,omitempty"`
+
+	// Calls, Indexes and Groups limit the rule to calls of those names, in
+	// queries against those indexes, by users in those groups. Empty means
+	// any.
+	Calls   []string `json:"calls,omitempty"`
+	Indexes []string `json:"indexes,omitempty"`
+	Groups  []string `json:"groups,omitempty"`
+
+	// Without matches calls missing any of these arguments, and Above
+	// matches calls with any of these arguments greater than the value
+	// given, as in TopN's n.
+	Without []string         `json:"without,omitempty"`
+	Above   map[string]int64 `json:"above,omitempty"`
+}
+
+// LoadQueryRules reads query rules from the JSON file at path, as in
+//
+//	[{"action": "allow", "groups": ["ops"]}, {"calls": ["Store"]}]
+func LoadQueryRules(path string) ([]QueryRule, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading query rules")
+	}
+	var rules []QueryRule
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rules); err != nil {
+		return nil, errors.Wrapf(err, "decoding query rules from %s", path)
+	}
+	if err := validateQueryRules(rules); err != nil {
+		return nil, errors.Wrapf(err, "query rules in %s", path)
+	}
+	return rules, nil
+}
+
+// validateQueryRules returns an error if any of rules is invalid.
+func validateQueryRules(rules []QueryRule) error {
+	for i, rule := range rules {
+		switch rule.Action {
+		case "", QueryRuleDeny, QueryRuleAllow:
+		default:
+			return errors.Errorf("query rule %d: invalid action %q, expected %q or %q", i, rule.Action, QueryRuleDeny, QueryRuleAllow)
+		}
+	}
+	return nil
+}
+
+// QueryDeniedError is returned for queries containing a call a query rule
+// denies. Its cause is ErrQueryDenied.
+type QueryDeniedError struct {
+	Call   string
+	Rule   int // the index of the rule in the configured rules
+	Reason string
+}
+
+func (e QueryDeniedError) Error() string {
+	return fmt.Sprintf("%s: %s denied by rule %d: %s", ErrQueryDenied, e.Call, e.Rule, e.Reason)
+}
+
+// Cause allows errors.Cause to return ErrQueryDenied.
+func (e QueryDeniedError) Cause() error {
+	return ErrQueryDenied
+}
+
+// Unwrap makes errors.Is(err, ErrQueryDenied) true.
+func (e QueryDeniedError) Unwrap() error {
+	return ErrQueryDenied
+}
+
+// applies returns whether the rule applies to queries by a user in groups
+// against indexes.
+func (r QueryRule) applies(indexes, groups []string) bool {
+	return matchesAny(r.Indexes, indexes) && matchesAny(r.Groups, groups)
+}
+
+// match returns whether the rule matches c and, if so, why.
+func (r QueryRule) match(c *pql.Call) (reason string, ok bool) {
+	if len(r.Calls) > 0 && !matchesAny(r.Calls, []string{c.Name}) {
+		return "", false
+	}
+	if len(r.Without) == 0 && len(r.Above) == 0 {
+		return "call not allowed", true
+	}
+	for _, arg := range r.Without {
+		if _, ok := c.Args[arg]; !ok {
+			return fmt.Sprintf("%s is required", arg), true
+		}
+	}
+	for arg, max := range r.Above {
+		var above bool
+		switch v := c.Args[arg].(type) {
+		case int64:
+			above = v > max
+		case uint64:
+			above = max < 0 || v > uint64(max)
+		case float64:
+			above = v > float64(max)
+		}
+		if above {
+			return fmt.Sprintf("%s may be at most %d", arg, max), true
+		}
+	}
+	return "", false
+}
+
+// matchesAny returns whether any of values is in set, or set is empty.
+func matchesAny(set, values []string) bool {
+	if len(set) == 0 {
+		return true
+	}
+	for _, s := range set {
+		for _, v := range values {
+			if s == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkQueryRules returns an error if a query rule denies any call in q,
+// made by a user in groups against indexes.
+func (h *Holder) checkQueryRules(q *pql.Query, indexes, groups []string) error {
+	var rules []int
+	for i, rule := range h.cfg.QueryRules {
+		if rule.applies(indexes, groups) {
+			rules = append(rules, i)
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	for _, c := range q.Calls {
+		if err := h.checkCallRules(c, rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCallRules checks c, and the calls nested in it, against the query
+// rules at the indexes given.
+func (h *Holder) checkCallRules(c *pql.Call, rules []int) error {
+	for _, i := range rules {
+		rule := h.cfg.QueryRules[i]
+		reason, ok := rule.match(c)
+		if !ok {
+			continue
+		}
+		if rule.Action == QueryRuleAllow {
+			break
+		}
+		return QueryDeniedError{Call: c.Name, Rule: i, Reason: reason}
+	}
+	for _, child := range c.Children {
+		if err := h.checkCallRules(child, rules); err != nil {
+			return err
+		}
+	}
+	for _, arg := range c.Args {
+		if child, ok := arg.(*pql.Call); ok {
+			if err := h.checkCallRules(child, rules); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkQueryPolicy returns an error if the query rules deny any call in
+// query, made by the user making the request in ctx against indexes.
+func (api *API) checkQueryPolicy(ctx context.Context, query string, indexes []string) error {
+	if len(api.holder.cfg.QueryRules) == 0 {
+		return nil
+	}
+	q, err := pql.NewParser(strings.NewReader(query)).Parse()
+	if err != nil {
+		return errors.Wrap(err, "parsing")
+	}
+	// Rules apply to the calls legacy calls are executed as.
+	if api.server.rewriteLegacyCalls {
+		q.RewriteLegacy()
+	}
+	return api.holder.checkQueryRules(q, indexes, requestGroups(ctx))
+}
+
+// requestGroups returns the IDs of the groups of the user making the
+// request in ctx, if it's known.
+func requestGroups(ctx context.Context) []string {
+	var groups []authn.Group
+	switch g := ctx.Value(contextKeyGroupMembership).(type) {
+	case []string:
+		return g
+	case []authn.Group:
+		groups = g
+	default:
+		// gRPC requests carry the whole user.
+		if uinfo, ok := ctx.Value("userinfo").(*authn.UserInfo); ok {
+			groups = uinfo.Groups
+		}
+	}
+	ids := make([]string, len(groups))
+	for i, g := range groups {
+		ids[i] = g.GroupID
+	}
+	return ids
+}
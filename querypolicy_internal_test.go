@@ -0,0 +1,110 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/featurebasedb/featurebase/v3/authn"
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+func TestHolder_CheckQueryRules(t *testing.T) {
+	h := &Holder{cfg: TestHolderConfig()}
+	h.cfg.QueryRules = []QueryRule{
+		{Action: QueryRuleAllow, Groups: []string{"ops"}},
+		{Calls: []string{"Store"}},
+		{Calls: []string{"Extract"}, Without: []string{"limit"}},
+		{Calls: []string{"TopN"}, Above: map[string]int64{"n": 100}},
+		{Calls: []string{"ClearRow"}, Indexes: []string{"logs"}},
+	}
+	for _, tt := range []struct {
+		query  string
+		index  string
+		groups []string
+		rule   int // -1 if allowed
+	}{
+		{query: "Row(f=1)", rule: -1},
+		{query: "Store(Row(f=1), g=2)", rule: 1},
+		{query: "Store(Row(f=1), g=2)", groups: []string{"ops"}, rule: -1},
+		{query: "Extract(All(), Rows(f))", rule: 2},
+		{query: "Extract(All(), Rows(f), limit=10)", rule: -1},
+		{query: "TopN(f, n=100)", rule: -1},
+		{query: "TopN(f, n=1000)", rule: 3},
+		{query: "Count(Union(Row(f=1), Store(Row(f=1), g=2)))", rule: 1},
+		{query: "GroupBy(Rows(f), filter=Extract(All(), Rows(f)))", rule: 2},
+		{query: "ClearRow(f=1)", index: "users", rule: -1},
+		{query: "ClearRow(f=1)", index: "logs", rule: 4},
+	} {
+		q, err := pql.ParseString(tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		index := tt.index
+		if index == "" {
+			index = "i"
+		}
+		err = h.checkQueryRules(q, []string{index}, tt.groups)
+		var derr QueryDeniedError
+		if tt.rule < 0 && err != nil {
+			t.Errorf("%s on %s by %v: unexpected error %v", tt.query, index, tt.groups, err)
+		} else if tt.rule >= 0 && (!errors.As(err, &derr) || derr.Rule != tt.rule) {
+			t.Errorf("%s on %s by %v: expected denial by rule %d, got %v", tt.query, index, tt.groups, tt.rule, err)
+		}
+	}
+
+	if err := validateQueryRules([]QueryRule{{Action: "block"}}); err == nil {
+		t.Fatal("expected invalid action error")
+	}
+}
+
+func TestRequestGroups(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKeyGroupMembership, []authn.Group{{GroupID: "a"}, {GroupID: "b"}})
+	if groups := requestGroups(ctx); len(groups) != 2 || groups[0] != "a" || groups[1] != "b" {
+		t.Fatalf("unexpected groups %v", groups)
+	}
+	ctx = context.WithValue(context.Background(), "userinfo", &authn.UserInfo{Groups: []authn.Group{{GroupID: "c"}}})
+	if groups := requestGroups(ctx); len(groups) != 1 || groups[0] != "c" {
+		t.Fatalf("unexpected groups %v", groups)
+	}
+	if groups := requestGroups(context.Background()); len(groups) != 0 {
+		t.Fatalf("expected no groups, got %v", groups)
+	}
+}
+
+func TestLoadQueryRules(t *testing.T) {
+	load := func(body string) ([]QueryRule, error) {
+		path := filepath.Join(t.TempDir(), "rules.json")
+		if err := os.WriteFile(path, []byte(body), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return LoadQueryRules(path)
+	}
+
+	rules, err := load(`[{"action": "allow", "groups": ["ops"]}, {"calls": ["TopN"], "above": {"n": 100}}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []QueryRule{
+		{Action: QueryRuleAllow, Groups: []string{"ops"}},
+		{Calls: []string{"TopN"}, Above: map[string]int64{"n": 100}},
+	}
+	if !reflect.DeepEqual(rules, exp) {
+		t.Fatalf("expected %+v, got %+v", exp, rules)
+	}
+
+	for _, body := range []string{
+		`[{"call": ["Store"]}]`,
+		`[{"action": "block", "calls": ["Store"]}]`,
+		`{"calls": ["Store"]}`,
+	} {
+		if _, err := load(body); err == nil {
+			t.Fatalf("expected error loading %s", body)
+		}
+	}
+}
@@ -171,7 +171,11 @@ type RBFTx struct {
 	Db           *RbfDBWrapper
 
 	done bool
-	mu   sync.Mutex // protect done as it changes state
+	mu   sync.Mutex // protect done and written as they change state
+
+	// written holds the fields and shards written, whose write times are
+	// recorded when the transaction commits.
+	written map[writeKey]struct{}
 }
 
 func (tx *RBFTx) DBPath() string {
@@ -182,14 +186,34 @@ func (tx *RBFTx) Type() string {
 	return RBFTxn
 }
 
+// snapshot returns the WAL ID identifying the data a read-only transaction
+// sees, and false for a writable one, which sees its own writes.
+func (tx *RBFTx) snapshot() (int64, bool) {
+	if tx.tx.Writable() {
+		return 0, false
+	}
+	return tx.tx.WALID(), true
+}
+
 func (tx *RBFTx) Rollback() {
 	tx.tx.Rollback()
 	tx.Db.CleanupTx(tx)
 }
 
 func (tx *RBFTx) Commit() (err error) {
+	if tx.o.Write && tx.o.Index != nil {
+		tx.o.Index.cubes.beginWrite(tx.o.Shard)
+	}
 	err = tx.tx.Commit()
 	tx.Db.CleanupTx(tx)
+	if tx.o.Write && tx.o.Index != nil {
+		// Metadata about the shard may no longer be accurate.
+		tx.o.Index.shardStats.invalidate(tx.o.Shard)
+		tx.o.Index.cubes.endWrite(tx.o.Index, tx.o.Shard)
+	}
+	if err == nil {
+		tx.recordWrites()
+	}
 	return err
 }
 
@@ -202,21 +226,31 @@ func (tx *RBFTx) Container(index, field, view string, shard uint64, key uint64)
 }
 
 func (tx *RBFTx) PutContainer(index, field, view string, shard uint64, key uint64, c *roaring.Container) error {
+	tx.noteWrite(index, field, shard)
 	return tx.tx.PutContainer(rbfName(index, field, view, shard), key, c)
 }
 
 func (tx *RBFTx) RemoveContainer(index, field, view string, shard uint64, key uint64) error {
+	tx.noteWrite(index, field, shard)
 	return tx.tx.RemoveContainer(rbfName(index, field, view, shard), key)
 }
 
 // Add sets all the a bits hot in the specified fragment.
 func (tx *RBFTx) Add(index, field, view string, shard uint64, a ...uint64) (changeCount int, err error) {
-	return tx.addOrRemove(index, field, view, shard, false, a...)
+	changeCount, err = tx.addOrRemove(index, field, view, shard, false, a...)
+	if changeCount > 0 {
+		tx.noteWrite(index, field, shard)
+	}
+	return changeCount, err
 }
 
 // Remove clears all the specified a bits in the chosen fragment.
 func (tx *RBFTx) Remove(index, field, view string, shard uint64, a ...uint64) (changeCount int, err error) {
-	return tx.addOrRemove(index, field, view, shard, true, a...)
+	changeCount, err = tx.addOrRemove(index, field, view, shard, true, a...)
+	if changeCount > 0 {
+		tx.noteWrite(index, field, shard)
+	}
+	return changeCount, err
 }
 
 // sortedParanoia is a flag to enable a check for unsorted inputs to addOrRemove,
@@ -393,7 +427,11 @@ func (tx *RBFTx) OffsetRange(index, field, view string, shard uint64, offset, st
 }
 
 func (tx *RBFTx) ImportRoaringBits(index, field, view string, shard uint64, rit roaring.RoaringIterator, clear bool, log bool, rowSize uint64) (changed int, rowSet map[uint64]int, err error) {
-	return tx.tx.ImportRoaringBits(rbfName(index, field, view, shard), rit, clear, log, rowSize)
+	changed, rowSet, err = tx.tx.ImportRoaringBits(rbfName(index, field, view, shard), rit, clear, log, rowSize)
+	if changed > 0 {
+		tx.noteWrite(index, field, shard)
+	}
+	return changed, rowSet, err
 }
 
 func (tx *RBFTx) NewTxIterator(index, field, view string, shard uint64) *roaring.Iterator {
@@ -407,6 +445,7 @@ func (tx *RBFTx) ApplyFilter(index, field, view string, shard uint64, ckey uint6
 }
 
 func (tx *RBFTx) ApplyRewriter(index, field, view string, shard uint64, ckey uint64, filter roaring.BitmapRewriter) (err error) {
+	tx.noteWrite(index, field, shard)
 	return tx.tx.ApplyRewriter(rbfName(index, field, view, shard), ckey, filter)
 }
 
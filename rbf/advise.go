@@ -0,0 +1,116 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package rbf
+
+import (
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+// Advice describes how the pages of some bitmaps are expected to be used,
+// for Tx.AdviseBitmaps.
+type Advice int
+
+const (
+	// AdviceNormal leaves the pages alone, and only reports their residency.
+	AdviceNormal Advice = iota
+
+	// AdviceWillNeed reads the pages into memory, so that they're resident
+	// and recently used, and less likely to be evicted than other pages.
+	AdviceWillNeed
+
+	// AdviceDontNeed drops the pages from memory, and from the page cache
+	// where the operating system allows it.
+	AdviceDontNeed
+)
+
+// BitmapResidency describes how much of some bitmaps is in memory.
+type BitmapResidency struct {
+	// Number of pages used by the bitmaps.
+	PageN int
+
+	// Number of those pages which were resident in memory, before any
+	// advice was applied.
+	ResidentN int
+}
+
+// AdviseBitmaps applies advice to the pages of the bitmaps whose names start
+// with prefix, and reports how many of them were resident in memory. Pages
+// written by this transaction are always resident, and are left alone.
+func (tx *Tx) AdviseBitmaps(prefix string, advice Advice) (r BitmapResidency, err error) {
+	records, err := tx.RootRecords()
+	if err != nil {
+		return r, err
+	}
+
+	itr := records.Iterator()
+	itr.Seek(prefix)
+	for !itr.Done() {
+		name, pgno := itr.Next()
+		if !strings.HasPrefix(name.(string), prefix) {
+			break
+		}
+
+		if err := tx.walkTree(pgno.(uint32), 0, func(pgno, parent, typ uint32, err error) error {
+			if err != nil {
+				return err
+			}
+			page, isHeap, err := tx.readPage(pgno)
+			if err != nil {
+				return err
+			}
+			r.PageN++
+			if isHeap {
+				r.ResidentN++
+				return nil
+			}
+			if pageResident(page) {
+				r.ResidentN++
+			}
+			switch advice {
+			case AdviceWillNeed:
+				willNeedPage(page)
+			case AdviceDontNeed:
+				tx.db.dontNeedPage(page)
+			}
+			return nil
+		}); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+// dontNeedPage drops a page, mapped from the data or WAL file, from memory.
+func (db *DB) dontNeedPage(page []byte) {
+	if off, ok := mmapOffset(db.data, page); ok && db.file != nil {
+		dontNeedPage(page, db.file, off)
+	} else if off, ok := mmapOffset(db.wal, page); ok && db.walFile != nil {
+		dontNeedPage(page, db.walFile, off)
+	}
+}
+
+// mmapOffset returns the offset of page within the mapping m, if it's part
+// of it.
+func mmapOffset(m, page []byte) (int64, bool) {
+	if len(m) == 0 || len(page) == 0 {
+		return 0, false
+	}
+	start := uintptr(unsafe.Pointer(&m[0]))
+	p := uintptr(unsafe.Pointer(&page[0]))
+	if p < start || p >= start+uintptr(len(m)) {
+		return 0, false
+	}
+	return int64(p - start), true
+}
+
+// touchPage reads a byte from each operating system page of a mapped page,
+// faulting it in and marking it recently used.
+func touchPage(page []byte) {
+	var b byte
+	for i := 0; i < len(page); i += 4096 {
+		b ^= page[i]
+	}
+	runtime.KeepAlive(b)
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+//go:build linux && (amd64 || arm64)
+// +build linux
+// +build amd64 arm64
+
+package rbf
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// posixFadvDontNeed is POSIX_FADV_DONTNEED, which asks the kernel to drop
+// a file range from the page cache.
+const posixFadvDontNeed = 4
+
+// pageResident reports whether the mapped page is resident in memory.
+func pageResident(page []byte) bool {
+	// One byte of output per operating system page.
+	var vec [PageSize / 4096]byte
+	_, _, errno := syscall.Syscall(syscall.SYS_MINCORE, uintptr(unsafe.Pointer(&page[0])), uintptr(len(page)), uintptr(unsafe.Pointer(&vec[0])))
+	if errno != 0 {
+		return false
+	}
+	for _, v := range vec[:(len(page)+4095)/4096] {
+		if v&1 == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// willNeedPage reads the mapped page into memory.
+func willNeedPage(page []byte) {
+	_ = syscall.Madvise(page, syscall.MADV_WILLNEED)
+	touchPage(page)
+}
+
+// dontNeedPage drops the mapped page, which is at offset off in f, from
+// this process's mapping and from the page cache.
+func dontNeedPage(page []byte, f *os.File, off int64) {
+	_ = syscall.Madvise(page, syscall.MADV_DONTNEED)
+	_, _, _ = syscall.Syscall6(syscall.SYS_FADVISE64, f.Fd(), uintptr(off), uintptr(len(page)), posixFadvDontNeed, 0, 0)
+}
@@ -0,0 +1,19 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+//go:build !linux || !(amd64 || arm64)
+// +build !linux !amd64,!arm64
+
+package rbf
+
+import "os"
+
+// pageResident reports whether the mapped page is resident in memory. It
+// can't be determined on this platform, so pages are assumed resident.
+func pageResident(page []byte) bool { return true }
+
+// willNeedPage reads the mapped page into memory.
+func willNeedPage(page []byte) { touchPage(page) }
+
+// dontNeedPage would drop the mapped page from memory; it isn't supported
+// on this platform.
+func dontNeedPage(page []byte, f *os.File, off int64) {}
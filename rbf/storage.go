@@ -0,0 +1,129 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package rbf
+
+import (
+	"github.com/featurebasedb/featurebase/v3/roaring"
+)
+
+// StorageStats describes how the data of a database is stored.
+type StorageStats struct {
+	// Number of pages in the database, and how many of them are free.
+	PageN     int
+	FreePageN int
+
+	// Number of bitmaps, and the number of their containers stored as
+	// arrays, runs, and bitmaps.
+	BitmapN          int
+	ArrayContainerN  int
+	RunContainerN    int
+	BitmapContainerN int
+}
+
+// StorageStats counts the pages of the database, and the containers of its
+// bitmaps by how they're stored.
+func (tx *Tx) StorageStats() (s StorageStats, err error) {
+	tx.mu.RLock()
+	defer tx.mu.RUnlock()
+
+	if tx.db == nil {
+		return s, ErrTxClosed
+	}
+	records, err := tx.RootRecords()
+	if err != nil {
+		return s, err
+	}
+	free, err := tx.freePageSet()
+	if err != nil {
+		return s, err
+	}
+	s.PageN = tx.PageN()
+	s.FreePageN = len(free)
+
+	for itr := records.Iterator(); !itr.Done(); {
+		_, pgno := itr.Next()
+		s.BitmapN++
+
+		if err := tx.walkTree(pgno.(uint32), 0, func(pgno, parent, typ uint32, err error) error {
+			if err != nil || typ != PageTypeLeaf {
+				return err
+			}
+			page, _, err := tx.readPage(pgno)
+			if err != nil {
+				return err
+			}
+			for i, n := 0, readCellN(page); i < n; i++ {
+				switch readLeafCell(page, i).Type {
+				case ContainerTypeArray:
+					s.ArrayContainerN++
+				case ContainerTypeRLE:
+					s.RunContainerN++
+				case ContainerTypeBitmap, ContainerTypeBitmapPtr:
+					s.BitmapContainerN++
+				}
+			}
+			return nil
+		}); err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+// OptimizeBitmap rewrites each container of a bitmap which would be stored
+// in less space as another type, and returns how many were rewritten.
+func (tx *Tx) OptimizeBitmap(name string) (n int, err error) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.db == nil {
+		return 0, ErrTxClosed
+	} else if !tx.writable {
+		return 0, ErrTxNotWritable
+	}
+
+	pgno, err := tx.root(name)
+	if err == ErrBitmapNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	// Find the containers to rewrite before writing any, as writes move
+	// cells between pages.
+	var keys []uint64
+	var containers []*roaring.Container
+	if err := tx.walkTree(pgno, 0, func(pgno, parent, typ uint32, err error) error {
+		if err != nil || typ != PageTypeLeaf {
+			return err
+		}
+		page, _, err := tx.readPage(pgno)
+		if err != nil {
+			return err
+		}
+		for i, n := 0, readCellN(page); i < n; i++ {
+			cell := readLeafCell(page, i)
+			stored := cell.Type
+			if stored == ContainerTypeBitmapPtr {
+				stored = ContainerTypeBitmap
+			}
+			c := roaring.Optimize(toContainer(cell, tx).Clone())
+			if c == nil || ConvertToLeafArgs(cell.Key, c.Clone()).Type == stored {
+				continue
+			}
+			keys = append(keys, cell.Key)
+			containers = append(containers, c)
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	for i, key := range keys {
+		if err := tx.putContainer(name, key, containers[i]); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
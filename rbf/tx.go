@@ -86,6 +86,13 @@ func (tx *Tx) Writable() bool {
 	return tx.writable
 }
 
+// WALID returns the highest WAL ID written when the transaction started. A
+// read-only transaction sees the same data as any other which started with
+// the same WAL ID on the same database.
+func (tx *Tx) WALID() int64 {
+	return tx.walID
+}
+
 // dirty returns true if any pages have been updated in this tx.
 func (tx *Tx) dirty() bool {
 	return tx.dirtyN() != 0
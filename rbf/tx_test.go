@@ -1333,3 +1333,123 @@ func mustWritePage(tb testing.TB, path string, pgno uint32, buf []byte) {
 		tb.Fatal(err)
 	}
 }
+
+func TestTx_AdviseBitmaps(t *testing.T) {
+	db := MustOpenDB(t)
+	defer MustCloseDB(t, db)
+
+	// Fill a few containers of "x\x00a" with bitmap containers, and one of
+	// "y" with an array container.
+	tx := MustBegin(t, db, true)
+	for _, name := range []string{"x\x00a", "x\x00b", "y"} {
+		if err := tx.CreateBitmap(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for key := uint64(0); key < 4; key++ {
+		var a []uint64
+		for i := uint64(0); i < 10000; i++ {
+			a = append(a, key<<16|i*2)
+		}
+		if _, err := tx.Add("x\x00a", a...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := tx.Add("x\x00b", 1, 2, 3); err != nil {
+		t.Fatal(err)
+	} else if _, err := tx.Add("y", 1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pages written by the transaction are resident.
+	if r, err := tx.AdviseBitmaps("x\x00", rbf.AdviceNormal); err != nil {
+		t.Fatal(err)
+	} else if r.PageN < 6 || r.ResidentN != r.PageN {
+		t.Fatalf("unexpected residency: %+v", r)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx = MustBegin(t, db, false)
+	defer tx.Rollback()
+	all, err := tx.AdviseBitmaps("x\x00", rbf.AdviceWillNeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, err := tx.AdviseBitmaps("x\x00", rbf.AdviceNormal); err != nil {
+		t.Fatal(err)
+	} else if r.PageN != all.PageN || r.ResidentN != r.PageN {
+		t.Fatalf("expected %d resident pages after advising, got %+v", all.PageN, r)
+	}
+	if r, err := tx.AdviseBitmaps("x\x00b", rbf.AdviceDontNeed); err != nil {
+		t.Fatal(err)
+	} else if r.PageN != 1 {
+		t.Fatalf("expected a single leaf page, got %+v", r)
+	}
+	if r, err := tx.AdviseBitmaps("z", rbf.AdviceNormal); err != nil {
+		t.Fatal(err)
+	} else if r.PageN != 0 {
+		t.Fatalf("expected no pages, got %+v", r)
+	}
+
+	// Data must survive having its pages dropped.
+	if ok, err := tx.Contains("x\x00b", 2); err != nil || !ok {
+		t.Fatalf("expected value after dropping pages: %v %v", ok, err)
+	}
+}
+
+func TestTx_OptimizeBitmap(t *testing.T) {
+	db := MustOpenDB(t)
+	defer MustCloseDB(t, db)
+
+	// A range big enough to be added as a bitmap, and a smaller one added
+	// as an array, are both smallest as runs. Scattered values aren't.
+	tx := MustBegin(t, db, true)
+	var a []uint64
+	for i := uint64(0); i < 10000; i++ {
+		a = append(a, i)
+	}
+	for i := uint64(0); i < 100; i++ {
+		a = append(a, 1<<16|i)
+	}
+	a = append(a, 2<<16|1, 2<<16|3, 2<<16|5)
+	if _, err := tx.Add("x", a...); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := tx.StorageStats(); err != nil {
+		t.Fatal(err)
+	} else if s.BitmapN != 1 || s.BitmapContainerN != 1 || s.ArrayContainerN != 2 || s.RunContainerN != 0 {
+		t.Fatalf("unexpected stats before optimizing: %+v", s)
+	}
+	if n, err := tx.OptimizeBitmap("x"); err != nil {
+		t.Fatal(err)
+	} else if n != 2 {
+		t.Fatalf("expected 2 containers rewritten, got %d", n)
+	}
+	if n, err := tx.OptimizeBitmap("x"); err != nil {
+		t.Fatal(err)
+	} else if n != 0 {
+		t.Fatalf("expected optimized bitmap to be left alone, got %d rewritten", n)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	tx = MustBegin(t, db, false)
+	defer tx.Rollback()
+	if s, err := tx.StorageStats(); err != nil {
+		t.Fatal(err)
+	} else if s.BitmapContainerN != 0 || s.ArrayContainerN != 1 || s.RunContainerN != 2 {
+		t.Fatalf("unexpected stats after optimizing: %+v", s)
+	}
+	if n, err := tx.Count("x"); err != nil {
+		t.Fatal(err)
+	} else if n != uint64(len(a)) {
+		t.Fatalf("expected %d values, got %d", len(a), n)
+	}
+	if ok, err := tx.Contains("x", 2<<16|3); err != nil || !ok {
+		t.Fatalf("expected value after optimizing: %v %v", ok, err)
+	}
+}
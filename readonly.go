@@ -0,0 +1,237 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// Writes can be paused, for backups, migrations or incidents, without
+// stopping reads. An index can be made read-only, which every node
+// enforces, and a node can be put in maintenance mode, in which it refuses
+// all writes. Both are refused with errors which are worth retrying once
+// the pause is over. Unlike a node which is read-only because its disk is
+// full, clears are refused too.
+
+// IndexUpdate represents a change to an index option. Only readOnly, and
+// cubes, whose value is a JSON array of CubeOptions, can be changed.
+type IndexUpdate struct {
+	Option string `json:"option"`
+	Value  string `json:"value"`
+}
+
+// UpdateIndexMessage is an internal message changing an option of an
+// existing index.
+type UpdateIndexMessage struct {
+	CreateIndexMessage CreateIndexMessage
+	Update             IndexUpdate
+}
+
+// MaintenanceMessage is an internal message putting every node in
+// maintenance mode for Reason, or taking them out of it if Reason is "".
+type MaintenanceMessage struct {
+	Reason string
+}
+
+// IndexReadOnlyError is returned by writes to a read-only index. Its cause
+// is ErrIndexReadOnly.
+type IndexReadOnlyError struct {
+	Index string
+}
+
+func (e IndexReadOnlyError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrIndexReadOnly, e.Index)
+}
+
+// Cause allows errors.Cause to return ErrIndexReadOnly.
+func (e IndexReadOnlyError) Cause() error {
+	return ErrIndexReadOnly
+}
+
+// Unwrap makes errors.Is(err, ErrIndexReadOnly) true.
+func (e IndexReadOnlyError) Unwrap() error {
+	return ErrIndexReadOnly
+}
+
+// MaintenanceError is returned by writes while the node is in maintenance
+// mode. Its cause is ErrMaintenance.
+type MaintenanceError struct {
+	Reason string
+}
+
+func (e MaintenanceError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMaintenance, e.Reason)
+}
+
+// Cause allows errors.Cause to return ErrMaintenance.
+func (e MaintenanceError) Cause() error {
+	return ErrMaintenance
+}
+
+// Unwrap makes errors.Is(err, ErrMaintenance) true.
+func (e MaintenanceError) Unwrap() error {
+	return ErrMaintenance
+}
+
+// Maintenance reports whether a node is in maintenance mode.
+type Maintenance struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// setMaintenance puts the node in maintenance mode for reason, or takes it
+// out of maintenance mode if reason is "".
+func (h *Holder) setMaintenance(reason string) {
+	h.readOnlyMu.Lock()
+	defer h.readOnlyMu.Unlock()
+	if reason != h.maintenance {
+		if reason != "" {
+			h.Logger.Infof("node is in maintenance mode: %s", reason)
+		} else {
+			h.Logger.Infof("node is out of maintenance mode")
+		}
+	}
+	h.maintenance = reason
+}
+
+// maintenanceMode returns whether the node is in maintenance mode.
+func (h *Holder) maintenanceMode() Maintenance {
+	h.readOnlyMu.RLock()
+	defer h.readOnlyMu.RUnlock()
+	return Maintenance{Enabled: h.maintenance != "", Reason: h.maintenance}
+}
+
+// checkIndexWritable returns an error if writes to idx, including clears,
+// should be refused, because the node is read-only or in maintenance mode,
+// or because idx is read-only.
+func (h *Holder) checkIndexWritable(idx *Index) error {
+	if err := h.checkWritable(); err != nil {
+		return err
+	}
+	h.readOnlyMu.RLock()
+	maintenance := h.maintenance
+	h.readOnlyMu.RUnlock()
+	if maintenance != "" {
+		return MaintenanceError{Reason: maintenance}
+	}
+	if idx.ReadOnly() {
+		return IndexReadOnlyError{Index: idx.Name()}
+	}
+	return nil
+}
+
+// UpdateIndex changes an option of an existing index, throughout the
+// cluster.
+func (api *API) UpdateIndex(ctx context.Context, indexName string, update IndexUpdate) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.UpdateIndex")
+	defer span.Finish()
+
+	if err := api.validate(apiUpdateIndex); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	}
+
+	cim, err := index.updateOptions(ctx, update)
+	if err != nil {
+		return errors.Wrap(err, "updating index")
+	}
+	index.updateOptionsLocal(cim)
+
+	err = api.holder.sendOrSpool(&UpdateIndexMessage{
+		CreateIndexMessage: *cim,
+		Update:             update,
+	})
+	return errors.Wrap(err, "sending UpdateIndex message")
+}
+
+// updateOptions applies update to the index's options as stored in etcd,
+// returning the updated CreateIndexMessage.
+func (i *Index) updateOptions(ctx context.Context, update IndexUpdate) (*CreateIndexMessage, error) {
+	buf, err := i.holder.Schemator.Index(ctx, i.name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting index '%s' from etcd", i.name)
+	}
+	cim, err := decodeCreateIndexMessage(i.holder.serializer, buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding CreateIndexMessage")
+	}
+	cim.Index = i.name
+
+	switch update.Option {
+	case "readOnly":
+		readOnly, err := strconv.ParseBool(update.Value)
+		if err != nil {
+			return nil, NewBadRequestError(errors.Errorf("invalid value for readOnly: '%s'", update.Value))
+		}
+		cim.Meta.ReadOnly = readOnly
+	case "cubes":
+		var cubes []CubeOptions
+		if err := json.Unmarshal([]byte(update.Value), &cubes); err != nil {
+			return nil, NewBadRequestError(errors.Wrap(err, "invalid value for cubes"))
+		} else if err := validateCubes(i, cubes); err != nil {
+			return nil, NewBadRequestError(err)
+		}
+		cim.Meta.Cubes = cubes
+	default:
+		return nil, NewBadRequestError(errors.Errorf("updates for option '%s' are not supported", update.Option))
+	}
+
+	if b, err := i.serializer.Marshal(cim); err != nil {
+		return nil, errors.Wrap(err, "marshaling")
+	} else if err := i.holder.Schemator.UpdateIndex(ctx, i.name, b); errors.Cause(err) == disco.ErrIndexDoesNotExist {
+		return nil, newNotFoundError(ErrIndexNotFound, i.name)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "writing index to disco: %s", i.name)
+	}
+	return cim, nil
+}
+
+// updateOptionsLocal applies the options which can be updated from cim to
+// the index.
+func (i *Index) updateOptionsLocal(cim *CreateIndexMessage) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.readOnly = cim.Meta.ReadOnly
+	i.cubes.define(cim.Meta.Cubes)
+}
+
+// Maintenance reports whether this node is in maintenance mode.
+func (api *API) Maintenance(ctx context.Context) (Maintenance, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.Maintenance")
+	defer span.Finish()
+
+	if err := api.validate(apiMaintenance); err != nil {
+		return Maintenance{}, errors.Wrap(err, "validating api method")
+	}
+	return api.holder.maintenanceMode(), nil
+}
+
+// SetMaintenance puts this node, or every node if cluster is set, in
+// maintenance mode for reason, or takes it out of maintenance mode if
+// reason is "". Nodes are taken out of maintenance mode when they restart.
+func (api *API) SetMaintenance(ctx context.Context, reason string, cluster bool) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.SetMaintenance")
+	defer span.Finish()
+
+	if err := api.validate(apiMaintenance); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	api.holder.setMaintenance(reason)
+	if !cluster {
+		return nil
+	}
+	err := api.server.SendSync(&MaintenanceMessage{Reason: reason})
+	return errors.Wrap(err, "sending Maintenance message")
+}
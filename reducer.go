@@ -0,0 +1,180 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sync"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+// commutativeReduceCalls are the calls whose reduce functions give the
+// same result whatever order, and however grouped, the shard results are
+// folded in. Their results can be reduced concurrently.
+var commutativeReduceCalls = map[string]struct{}{
+	"Row":         {},
+	"Union":       {},
+	"Intersect":   {},
+	"Difference":  {},
+	"Xor":         {},
+	"Count":       {},
+	"Sum":         {},
+	"Avg":         {},
+	"WeightedAvg": {},
+	"Histogram":   {},
+	"GroupBy":     {},
+}
+
+// reducer folds the results of a map phase together with a reduce
+// function. For most calls the results are folded one at a time, as they
+// arrive. For commutative calls with enough results, they're handed to a
+// pool of workers, each of which folds those it receives into a partial
+// result of its own; the partial results are then combined pairwise, in a
+// tree, when the reducer finishes. This spreads the cost of reducing a
+// query over many shards across cores, rather than leaving it to the one
+// goroutine receiving results.
+//
+// Like the serial fold, every result passed to add is only ever used as the
+// second argument to the reduce function, so results which share storage
+// with fragments are never modified.
+type reducer struct {
+	ctx      context.Context
+	reduceFn reduceFunc
+
+	// result is the result so far, for a serial reducer, or the final
+	// result once a concurrent reducer has finished.
+	result   interface{}
+	finished bool
+
+	// For a concurrent reducer, the channel feeding the workers and the
+	// partial result of each worker.
+	ch       chan interface{}
+	wg       sync.WaitGroup
+	partials []interface{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// newReducer returns a reducer for n results of the call c.
+func (e *executor) newReducer(ctx context.Context, c *pql.Call, reduceFn reduceFunc, n int) *reducer {
+	r := &reducer{ctx: ctx, reduceFn: reduceFn}
+
+	// Each worker should fold at least a couple of results.
+	workers := e.reduceWorkers
+	if workers > n/2 {
+		workers = n / 2
+	}
+	if _, ok := commutativeReduceCalls[c.Name]; !ok || workers < 2 || hasQueryHint(ctx, HintSerialReduce) {
+		return r
+	}
+
+	r.ch = make(chan interface{}, workers)
+	r.partials = make([]interface{}, workers)
+	r.wg.Add(workers)
+	for i := range r.partials {
+		go r.work(i)
+	}
+	return r
+}
+
+// work folds results from the channel into the partial result i, until the
+// channel is closed.
+func (r *reducer) work(i int) {
+	defer r.wg.Done()
+	var acc interface{}
+	for v := range r.ch {
+		// Keep draining the channel after a failure so add doesn't block.
+		if r.failed() != nil {
+			continue
+		}
+		acc = r.reduceFn(r.ctx, acc, v)
+		if err, ok := acc.(error); ok {
+			r.fail(err)
+			acc = nil
+		}
+	}
+	r.partials[i] = acc
+}
+
+func (r *reducer) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *reducer) failed() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// add folds v into the result. It returns an error if the reduction has
+// failed, in which case the caller should stop and call finish.
+func (r *reducer) add(v interface{}) error {
+	if r.ch == nil {
+		r.result = r.reduceFn(r.ctx, r.result, v)
+		if err, ok := r.result.(error); ok {
+			r.result = nil
+			r.fail(err)
+		}
+		return r.failed()
+	}
+	if err := r.failed(); err != nil {
+		return err
+	}
+	r.ch <- v
+	return nil
+}
+
+// finish waits for any workers to exit, and returns the result. It must be
+// called at least once, even if the caller gives up on the result, and may
+// be called again.
+func (r *reducer) finish() (interface{}, error) {
+	if r.ch == nil || r.finished {
+		return r.result, r.failed()
+	}
+	r.finished = true
+	close(r.ch)
+	r.wg.Wait()
+	if err := r.failed(); err != nil {
+		return nil, err
+	}
+
+	// Workers which received no results have nothing to contribute.
+	parts := r.partials[:0]
+	for _, part := range r.partials {
+		if part != nil {
+			parts = append(parts, part)
+		}
+	}
+	for len(parts) > 1 {
+		next := make([]interface{}, (len(parts)+1)/2)
+		var wg sync.WaitGroup
+		for i := 0; i+1 < len(parts); i += 2 {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				next[i/2] = r.reduceFn(r.ctx, parts[i], parts[i+1])
+			}(i)
+		}
+		if len(parts)%2 == 1 {
+			next[len(next)-1] = parts[len(parts)-1]
+		}
+		wg.Wait()
+		for _, v := range next {
+			if err, ok := v.(error); ok {
+				r.fail(err)
+				return nil, err
+			}
+		}
+		parts = next
+	}
+	if len(parts) > 0 {
+		r.result = parts[0]
+	}
+	return r.result, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+func TestReducer(t *testing.T) {
+	e := &executor{reduceWorkers: 4}
+	ctx := context.Background()
+
+	sumFn := func(ctx context.Context, prev, v interface{}) interface{} {
+		other, _ := prev.(uint64)
+		return other + v.(uint64)
+	}
+	rowFn := func(ctx context.Context, prev, v interface{}) interface{} {
+		other, _ := prev.(*Row)
+		if other == nil {
+			other = NewRow()
+		}
+		other.Merge(v.(*Row))
+		return other
+	}
+
+	t.Run("Count", func(t *testing.T) {
+		for _, n := range []int{0, 1, 3, 1000} {
+			r := e.newReducer(ctx, &pql.Call{Name: "Count"}, sumFn, n)
+			if concurrent := r.ch != nil; concurrent != (n >= 8) {
+				t.Fatalf("n=%d: expected concurrent=%v", n, n >= 8)
+			}
+			var exp uint64
+			for i := 0; i < n; i++ {
+				exp += uint64(i)
+				if err := r.add(uint64(i)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			result, err := r.finish()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := result.(uint64); got != exp {
+				t.Fatalf("n=%d: expected %d, got %d", n, exp, got)
+			}
+		}
+	})
+
+	t.Run("Union", func(t *testing.T) {
+		r := e.newReducer(ctx, &pql.Call{Name: "Union"}, rowFn, 100)
+		var exp []uint64
+		for shard := uint64(0); shard < 100; shard++ {
+			cols := []uint64{shard * ShardWidth, shard*ShardWidth + 7}
+			exp = append(exp, cols...)
+			if err := r.add(NewRow(cols...)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		result, err := r.finish()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := result.(*Row).Columns(); !reflect.DeepEqual(got, exp) {
+			t.Fatalf("expected %d columns, got %d", len(exp), len(got))
+		}
+	})
+
+	t.Run("Serial", func(t *testing.T) {
+		if r := e.newReducer(ctx, &pql.Call{Name: "TopN"}, sumFn, 1000); r.ch != nil {
+			t.Fatal("expected non-commutative call to be reduced serially")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		failFn := func(ctx context.Context, prev, v interface{}) interface{} {
+			if v.(uint64) == 50 {
+				return errBoom
+			}
+			return sumFn(ctx, prev, v)
+		}
+		r := e.newReducer(ctx, &pql.Call{Name: "Count"}, failFn, 100)
+		for i := 0; i < 100; i++ {
+			if err := r.add(uint64(i)); err != nil {
+				break
+			}
+		}
+		if _, err := r.finish(); err != errBoom {
+			t.Fatalf("expected error %v, got %v", errBoom, err)
+		}
+		if _, err := r.finish(); err != errBoom {
+			t.Fatalf("expected error %v again, got %v", errBoom, err)
+		}
+	})
+}
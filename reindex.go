@@ -0,0 +1,570 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Reindex copies the columns of a source index which match a filter into a
+// target index, with their values in a chosen set of fields, which may be
+// renamed on the way. Each shard of the source is copied by a node holding
+// it: the node extracts the matching columns from the shard, and imports
+// them into the target like any other data. Shards are copied one at a
+// time on each node, and every node records the shards it's finished, by
+// the ID of the reindex, so a reindex which was interrupted can be resumed
+// by running it again with the same ID; the shards already copied are
+// skipped.
+//
+// The target index, and any target fields which don't exist, are created
+// with the options of their sources. Values are converted to the types of
+// target fields which already exist: row IDs and keys, integers, decimals
+// and timestamps are converted between each other where the conversion is
+// meaningful, and a reindex fails on a value which can't be converted. The
+// time views of time fields aren't copied, only their standard rows. Source
+// and target must either both have keys or both not.
+
+// reindexDir is the directory, within the data directory, in which the
+// progress of reindexes is recorded.
+const reindexDir = "reindex"
+
+// ReindexRequest is a request to copy data from one index into another.
+type ReindexRequest struct {
+	// ID names the reindex, so that it can be resumed. It defaults to
+	// the names of the source and target.
+	ID     string `json:"id,omitempty"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+
+	// Filter is a row call selecting the columns to copy. All columns are
+	// copied if it's empty.
+	Filter string `json:"filter,omitempty"`
+
+	// Fields are the fields to copy. If there are none, every field of
+	// the source is copied into the field of the same name.
+	Fields []ReindexField `json:"fields,omitempty"`
+
+	// Restart copies every shard, even those an earlier reindex of the
+	// same ID finished.
+	Restart bool `json:"restart,omitempty"`
+}
+
+// ReindexField maps a field of the source of a reindex to a field of its
+// target.
+type ReindexField struct {
+	Source string `json:"source"`
+	// Target defaults to Source.
+	Target string `json:"target,omitempty"`
+}
+
+// ReindexStatus reports the progress of a reindex.
+type ReindexStatus struct {
+	ID      string `json:"id"`
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	Shards  int    `json:"shards"`
+	Copied  int    `json:"copied"`
+	Resumed int    `json:"resumed"` // shards finished by an earlier run
+	Columns uint64 `json:"columns"`
+	Done    bool   `json:"done"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ReindexShardRequest is a request to a node to copy a shard of the
+// source of a reindex.
+type ReindexShardRequest struct {
+	ReindexRequest
+	Shard uint64 `json:"shard"`
+}
+
+// ReindexShardResult is the result of copying a shard.
+type ReindexShardResult struct {
+	Columns uint64 `json:"columns"`
+	Resumed bool   `json:"resumed"`
+}
+
+// reindexes holds the statuses of the reindexes coordinated by a node, and
+// serializes the recording of their progress.
+type reindexes struct {
+	mu       sync.Mutex
+	statuses map[string]*ReindexStatus
+}
+
+// Reindex copies data from one index into another, returning its status
+// once it's finished.
+func (api *API) Reindex(ctx context.Context, req *ReindexRequest) (*ReindexStatus, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.Reindex")
+	defer span.Finish()
+
+	if err := api.validate(apiReindex); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	if req.ID == "" {
+		req.ID = req.Source + "-" + req.Target
+	}
+	if err := ValidateName(req.ID); err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "reindex id"))
+	}
+	src, err := api.Index(ctx, req.Source)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.createReindexTarget(ctx, src, req); err != nil {
+		return nil, err
+	}
+	if _, err := reindexQuery(req); err != nil {
+		return nil, err
+	}
+
+	shards := src.AvailableShards(includeRemote).Slice()
+	byNode, err := api.server.executor.shardsByNode(api.cluster.Nodes(), req.Source, shards)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*disco.Node, 0, len(byNode))
+	for node := range byNode {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+
+	status := &ReindexStatus{ID: req.ID, Source: req.Source, Target: req.Target, Shards: len(shards)}
+	api.reindexes.mu.Lock()
+	if old := api.reindexes.statuses[req.ID]; old != nil && !old.Done {
+		api.reindexes.mu.Unlock()
+		return nil, newConflictError(errors.Errorf("reindex %s is already running", req.ID))
+	}
+	if api.reindexes.statuses == nil {
+		api.reindexes.statuses = make(map[string]*ReindexStatus)
+	}
+	api.reindexes.statuses[req.ID] = status
+	api.reindexes.mu.Unlock()
+
+	g, gctx := errgroup.WithContext(ctx)
+	for _, node := range nodes {
+		node := node
+		g.Go(func() error {
+			for _, shard := range byNode[node] {
+				sreq := &ReindexShardRequest{ReindexRequest: *req, Shard: shard}
+				var res ReindexShardResult
+				var err error
+				if node.ID == api.NodeID() {
+					res, err = api.ReindexShard(gctx, sreq)
+				} else {
+					res, err = api.server.defaultClient.ReindexShard(gctx, &node.URI, sreq)
+				}
+				if err != nil {
+					return errors.Wrapf(err, "copying shard %d on node %s", shard, node.ID)
+				}
+				api.reindexes.mu.Lock()
+				if res.Resumed {
+					status.Resumed++
+				} else {
+					status.Copied++
+				}
+				status.Columns += res.Columns
+				api.reindexes.mu.Unlock()
+			}
+			return nil
+		})
+	}
+	err = g.Wait()
+
+	api.reindexes.mu.Lock()
+	defer api.reindexes.mu.Unlock()
+	status.Done = true
+	if err != nil {
+		status.Error = err.Error()
+	}
+	out := *status
+	return &out, err
+}
+
+// ReindexStatus returns the status of a reindex coordinated by this node.
+func (api *API) ReindexStatus(ctx context.Context, id string) (*ReindexStatus, error) {
+	if err := api.validate(apiReindex); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	api.reindexes.mu.Lock()
+	defer api.reindexes.mu.Unlock()
+	status, ok := api.reindexes.statuses[id]
+	if !ok {
+		return nil, newNotFoundError(errors.New("reindex not found"), id)
+	}
+	out := *status
+	return &out, nil
+}
+
+// ReindexShard copies a shard of the source of a reindex into its target,
+// unless a reindex of the same ID already has.
+func (api *API) ReindexShard(ctx context.Context, req *ReindexShardRequest) (ReindexShardResult, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ReindexShard")
+	defer span.Finish()
+
+	if err := api.validate(apiReindex); err != nil {
+		return ReindexShardResult{}, errors.Wrap(err, "validating api method")
+	}
+	if err := ValidateName(req.ID); err != nil {
+		return ReindexShardResult{}, NewBadRequestError(errors.Wrap(err, "reindex id"))
+	}
+	path := filepath.Join(api.holder.path, reindexDir, req.ID)
+	if !req.Restart {
+		done, err := readReindexProgress(path)
+		if err != nil {
+			return ReindexShardResult{}, errors.Wrap(err, "reading progress")
+		}
+		if _, ok := done[req.Shard]; ok {
+			return ReindexShardResult{Resumed: true}, nil
+		}
+	}
+
+	query, err := reindexQuery(&req.ReindexRequest)
+	if err != nil {
+		return ReindexShardResult{}, err
+	}
+	dst := api.holder.Index(req.Target)
+	if dst == nil {
+		return ReindexShardResult{}, newNotFoundError(ErrIndexNotFound, req.Target)
+	}
+	resp, err := api.Query(ctx, &QueryRequest{Index: req.Source, Query: query, Shards: []uint64{req.Shard}})
+	if err != nil {
+		return ReindexShardResult{}, errors.Wrap(err, "extracting shard")
+	}
+	table, ok := resp.Results[0].(ExtractedTable)
+	if !ok {
+		return ReindexShardResult{}, errors.Errorf("unexpected extract result %T", resp.Results[0])
+	}
+
+	fields := reindexFields(req.Fields)
+	for i, f := range table.Fields {
+		field := dst.Field(fields[i].Target)
+		if field == nil {
+			return ReindexShardResult{}, newNotFoundError(ErrFieldNotFound, fields[i].Target)
+		}
+		if err := api.reindexImport(ctx, field, table.Columns, i); err != nil {
+			return ReindexShardResult{}, errors.Wrapf(err, "copying field %s", f.Name)
+		}
+	}
+
+	api.reindexes.mu.Lock()
+	defer api.reindexes.mu.Unlock()
+	if err := appendReindexProgress(path, req.Shard); err != nil {
+		return ReindexShardResult{}, errors.Wrap(err, "recording progress")
+	}
+	return ReindexShardResult{Columns: uint64(len(table.Columns))}, nil
+}
+
+// reindexFields returns the target of each field of a reindex.
+func reindexFields(fields []ReindexField) []ReindexField {
+	out := make([]ReindexField, len(fields))
+	for i, f := range fields {
+		out[i] = f
+		if out[i].Target == "" {
+			out[i].Target = f.Source
+		}
+	}
+	return out
+}
+
+// reindexQuery returns the Extract query which reads the columns and
+// values a reindex copies.
+func reindexQuery(req *ReindexRequest) (string, error) {
+	filter := req.Filter
+	if filter == "" {
+		filter = "All()"
+	}
+	q, err := pql.NewParser(strings.NewReader(filter)).Parse()
+	if err != nil {
+		return "", NewBadRequestError(errors.Wrap(err, "parsing filter"))
+	} else if len(q.Calls) != 1 {
+		return "", NewBadRequestError(errors.New("reindex filter must be a single call"))
+	}
+	if len(req.Fields) == 0 {
+		return "", NewBadRequestError(errors.New("reindex has no fields"))
+	}
+	extract := &pql.Call{Name: "Extract", Children: []*pql.Call{q.Calls[0]}}
+	for _, f := range req.Fields {
+		extract.Children = append(extract.Children, &pql.Call{Name: "Rows", Args: map[string]interface{}{"field": f.Source}})
+	}
+	return extract.String(), nil
+}
+
+// createReindexTarget fills in the fields of a reindex if it has none, and
+// creates its target index and target fields which don't exist yet.
+func (api *API) createReindexTarget(ctx context.Context, src *Index, req *ReindexRequest) error {
+	if req.Source == req.Target {
+		return NewBadRequestError(errors.New("reindex source and target must differ"))
+	}
+	if len(req.Fields) == 0 {
+		for _, f := range src.Fields() {
+			if f.Name() != existenceFieldName {
+				req.Fields = append(req.Fields, ReindexField{Source: f.Name()})
+			}
+		}
+	}
+	req.Fields = reindexFields(req.Fields)
+
+	dst := api.holder.Index(req.Target)
+	if dst == nil {
+		var err error
+		if dst, err = api.CreateIndex(ctx, req.Target, src.Options()); err != nil {
+			return errors.Wrap(err, "creating target")
+		}
+	} else if dst.Keys() != src.Keys() {
+		return NewBadRequestError(errors.New("reindex source and target must both have keys or both not"))
+	}
+	for _, f := range req.Fields {
+		field := src.Field(f.Source)
+		if field == nil {
+			return newNotFoundError(ErrFieldNotFound, f.Source)
+		}
+		if dst.Field(f.Target) != nil {
+			continue
+		}
+		opts := field.Options()
+		if _, err := api.CreateField(ctx, req.Target, f.Target, func(fo *FieldOptions) error {
+			*fo = opts
+			return nil
+		}); err != nil {
+			return errors.Wrapf(err, "creating field %s", f.Target)
+		}
+	}
+	return nil
+}
+
+// reindexImport imports the values in column i of the rows of an extracted
+// table into field, converting them to the field's type.
+func (api *API) reindexImport(ctx context.Context, field *Field, cols []ExtractedTableColumn, i int) error {
+	qcx := api.Txf().NewQcx()
+	defer qcx.Abort()
+
+	var colIDs []uint64
+	var colKeys []string
+	addColumn := func(col KeyOrID) {
+		if col.Keyed {
+			colKeys = append(colKeys, col.Key)
+		} else {
+			colIDs = append(colIDs, col.ID)
+		}
+	}
+
+	switch field.Type() {
+	case FieldTypeInt, FieldTypeDecimal, FieldTypeTimestamp:
+		req := &ImportValueRequest{Index: field.Index(), Field: field.Name(), Shard: ^uint64(0)}
+		for _, col := range cols {
+			v := col.Rows[i]
+			if v == nil {
+				continue
+			}
+			if field.Type() == FieldTypeTimestamp {
+				t, err := reindexTimestamp(field, v)
+				if err != nil {
+					return err
+				}
+				req.TimestampValues = append(req.TimestampValues, t)
+			} else {
+				n, err := reindexValue(field, v)
+				if err != nil {
+					return err
+				}
+				req.Values = append(req.Values, n)
+			}
+			addColumn(col.Column)
+		}
+		if len(colIDs)+len(colKeys) == 0 {
+			return nil
+		}
+		req.ColumnIDs, req.ColumnKeys = colIDs, colKeys
+		if err := api.ImportValue(ctx, qcx, req); err != nil {
+			return err
+		}
+	default:
+		req := &ImportRequest{Index: field.Index(), Field: field.Name(), Shard: ^uint64(0)}
+		for _, col := range cols {
+			ids, keys, err := reindexRows(field, col.Rows[i])
+			if err != nil {
+				return err
+			}
+			for range ids {
+				addColumn(col.Column)
+			}
+			for range keys {
+				addColumn(col.Column)
+			}
+			req.RowIDs = append(req.RowIDs, ids...)
+			req.RowKeys = append(req.RowKeys, keys...)
+		}
+		if len(colIDs)+len(colKeys) == 0 {
+			return nil
+		}
+		req.ColumnIDs, req.ColumnKeys = colIDs, colKeys
+		if err := api.Import(ctx, qcx, req); err != nil {
+			return err
+		}
+	}
+	return qcx.Finish()
+}
+
+// reindexRows converts an extracted value to the row IDs, or row keys, of
+// field.
+func reindexRows(field *Field, v interface{}) (ids []uint64, keys []string, err error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil, nil
+	case bool:
+		if v {
+			ids = []uint64{1}
+		} else {
+			ids = []uint64{0}
+		}
+	case uint64:
+		ids = []uint64{v}
+	case int64:
+		if v < 0 {
+			return nil, nil, NewBadRequestError(errors.Errorf("can't convert %d to a row of field %s", v, field.Name()))
+		}
+		ids = []uint64{uint64(v)}
+	case []uint64:
+		ids = v
+	case string:
+		keys = []string{v}
+	case []string:
+		keys = v
+	default:
+		return nil, nil, NewBadRequestError(errors.Errorf("can't convert %v of type %[1]T to a row of field %s", v, field.Name()))
+	}
+
+	if field.Keys() {
+		for _, id := range ids {
+			keys = append(keys, strconv.FormatUint(id, 10))
+		}
+		return nil, keys, nil
+	}
+	for _, key := range keys {
+		id, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, nil, NewBadRequestError(errors.Errorf("can't convert key %q to a row of field %s", key, field.Name()))
+		}
+		ids = append(ids, id)
+	}
+	if field.Type() == FieldTypeBool {
+		for _, id := range ids {
+			if id > 1 {
+				return nil, nil, NewBadRequestError(errors.Errorf("can't convert %d to a value of bool field %s", id, field.Name()))
+			}
+		}
+	}
+	return ids, nil, nil
+}
+
+// reindexValue converts an extracted value to a value of an int or decimal
+// field, as it's imported.
+func reindexValue(field *Field, v interface{}) (int64, error) {
+	scale := field.Options().Scale
+	var dec pql.Decimal
+	switch v := v.(type) {
+	case int64:
+		dec = pql.NewDecimal(v, 0)
+	case uint64:
+		dec = pql.NewDecimal(int64(v), 0)
+	case bool:
+		if v {
+			dec = pql.NewDecimal(1, 0)
+		}
+	case pql.Decimal:
+		if field.Type() == FieldTypeInt && v.Scale > 0 {
+			return 0, NewBadRequestError(errors.Errorf("can't convert %s to a value of int field %s", v, field.Name()))
+		}
+		dec = v
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, NewBadRequestError(errors.Errorf("can't convert %q to a value of field %s", v, field.Name()))
+		}
+		dec = pql.NewDecimal(n, 0)
+	default:
+		return 0, NewBadRequestError(errors.Errorf("can't convert %v of type %[1]T to a value of field %s", v, field.Name()))
+	}
+	if !dec.SupportedByScale(scale) {
+		return 0, NewBadRequestError(errors.Errorf("%s is out of range for field %s", dec, field.Name()))
+	}
+	return dec.ToInt64(scale), nil
+}
+
+// reindexTimestamp converts an extracted value to a value of a timestamp
+// field. Integers count the field's time unit since the Unix epoch.
+func reindexTimestamp(field *Field, v interface{}) (time.Time, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, nil
+	case int64:
+		return ValToTimestamp(field.Options().TimeUnit, v)
+	case uint64:
+		return ValToTimestamp(field.Options().TimeUnit, int64(v))
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, NewBadRequestError(errors.Errorf("can't convert %q to a value of field %s", v, field.Name()))
+		}
+		return t, nil
+	}
+	return time.Time{}, NewBadRequestError(errors.Errorf("can't convert %v of type %[1]T to a value of field %s", v, field.Name()))
+}
+
+// readReindexProgress returns the shards recorded as finished in the
+// progress file at path.
+func readReindexProgress(path string) (map[uint64]struct{}, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	done := make(map[uint64]struct{})
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// A line cut short by a crash is ignored, and its shard copied
+		// again.
+		if shard, err := strconv.ParseUint(scanner.Text(), 10, 64); err == nil {
+			done[shard] = struct{}{}
+		}
+	}
+	return done, scanner.Err()
+}
+
+// appendReindexProgress records shard as finished in the progress file at
+// path.
+func appendReindexProgress(path string, shard uint64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "%d\n", shard); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
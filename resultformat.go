@@ -0,0 +1,284 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// Query results are encoded as a single JSON object by default, with each
+// Extract column and GroupBy group an object of its own. Big results can
+// be asked for in one of two other formats instead, with the format
+// argument of the query, or for NDJSON, an Accept header of
+// application/x-ndjson.
+//
+// NDJSON streams each result as lines of JSON, so neither the server nor
+// the client holds the whole encoded response at once. Results other than
+// Extracts and GroupBys are a line with the result's value; Extracts are a
+// line with the fields, then a line for each column; and GroupBys are a
+// line for each group. Every line has the number of its result, counting
+// from zero, and the last line is marked done, with anything else the
+// response reports, such as the existence fallback used. An error is a
+// line of its own.
+//
+// Columnar JSON is the default JSON, except that Extracts and GroupBys are
+// arrays of values for each field, rather than objects for each column or
+// group.
+const (
+	QueryFormatJSON     = "json"
+	QueryFormatNDJSON   = "ndjson"
+	QueryFormatColumnar = "columnar"
+)
+
+// contentTypeNDJSON is the content type of NDJSON responses.
+const contentTypeNDJSON = "application/x-ndjson"
+
+// queryFormat returns the format r asks for its results to be encoded in.
+func queryFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "":
+		if validHeaderAcceptType(r.Header, "application", "x-ndjson") && !validHeaderAcceptJSON(r.Header) {
+			return QueryFormatNDJSON, nil
+		}
+		return QueryFormatJSON, nil
+	case QueryFormatJSON, QueryFormatNDJSON, QueryFormatColumnar:
+		return format, nil
+	default:
+		return QueryFormatJSON, NewBadRequestError(errors.Errorf("unknown format %q", format))
+	}
+}
+
+// ndjsonFlushSize is how much of an NDJSON response is buffered before
+// it's flushed to the client.
+const ndjsonFlushSize = 64 << 10
+
+// writeNDJSONQueryResponse writes resp to w as NDJSON.
+func writeNDJSONQueryResponse(w io.Writer, resp *QueryResponse) error {
+	bw := bufio.NewWriterSize(w, ndjsonFlushSize)
+	enc := json.NewEncoder(bw)
+	flush := func() error {
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+
+	if resp.Err != nil {
+		if err := enc.Encode(resp); err != nil {
+			return errors.Wrap(err, "encoding error")
+		}
+		return flush()
+	}
+
+	for i, result := range resp.Results {
+		if err := writeNDJSONResult(enc, i, result); err != nil {
+			return errors.Wrapf(err, "encoding result %d", i)
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+
+	done := struct {
+		Done              bool              `json:"done"`
+		CacheStale        bool              `json:"cacheStale,omitempty"`
+		ExistenceFallback string            `json:"existenceFallback,omitempty"`
+		Completeness      *Completeness     `json:"completeness,omitempty"`
+		Statements        []StatementStatus `json:"statements,omitempty"`
+		Profile           *tracing.Profile  `json:"profile,omitempty"`
+	}{
+		Done:              true,
+		CacheStale:        resp.cacheStale(),
+		ExistenceFallback: resp.ExistenceFallback,
+		Completeness:      resp.Completeness,
+		Statements:        resp.Statements,
+		Profile:           resp.Profile,
+	}
+	if err := enc.Encode(done); err != nil {
+		return errors.Wrap(err, "encoding end of results")
+	}
+	return flush()
+}
+
+// writeNDJSONResult encodes the lines of result i.
+func writeNDJSONResult(enc *json.Encoder, i int, result interface{}) error {
+	switch result := result.(type) {
+	case ExtractedTable:
+		if err := enc.Encode(struct {
+			Result int                   `json:"result"`
+			Fields []ExtractedTableField `json:"fields"`
+		}{i, result.Fields}); err != nil {
+			return err
+		}
+		for _, col := range result.Columns {
+			if err := enc.Encode(struct {
+				Result int `json:"result"`
+				ExtractedTableColumn
+			}{i, col}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *GroupCounts:
+		agg := result.AggregateColumn()
+		for _, gc := range result.Groups() {
+			line := struct {
+				Result    int         `json:"result"`
+				Group     []FieldRow  `json:"group"`
+				Count     uint64      `json:"count"`
+				Aggregate string      `json:"aggregate,omitempty"`
+				Value     interface{} `json:"value,omitempty"`
+			}{Result: i, Group: gc.Group, Count: gc.Count}
+			if agg != "" {
+				line.Aggregate, line.Value = agg, result.aggregateValue(gc)
+			}
+			if err := enc.Encode(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return enc.Encode(struct {
+			Result int         `json:"result"`
+			Value  interface{} `json:"value"`
+		}{i, result})
+	}
+}
+
+// aggregateValue returns the value of the aggregate of gc, one of g's
+// groups.
+func (g *GroupCounts) aggregateValue(gc GroupCount) interface{} {
+	switch g.aggregateType {
+	case sumAggregate, distinctAggregate:
+		return gc.Agg
+	case decimalSumAggregate, averageAggregate:
+		return gc.DecimalAgg
+	case minAggregate, maxAggregate:
+		if !gc.TimestampAgg.IsZero() {
+			return gc.TimestampAgg
+		}
+		return gc.Agg
+	case histogramAggregate:
+		return gc.Histogram
+	}
+	return nil
+}
+
+// columnarResponse returns resp with its Extracts and GroupBys made
+// columnar.
+func columnarResponse(resp *QueryResponse) *QueryResponse {
+	if resp.Err != nil {
+		return resp
+	}
+	out := *resp
+	out.Results = make([]interface{}, len(resp.Results))
+	for i, result := range resp.Results {
+		switch result := result.(type) {
+		case ExtractedTable:
+			out.Results[i] = columnarExtract(result)
+		case *GroupCounts:
+			out.Results[i] = columnarGroupBy(result)
+		default:
+			out.Results[i] = result
+		}
+	}
+	return &out
+}
+
+// ColumnarExtract is an Extract result with the values of each field in an
+// array, in the order of the columns.
+type ColumnarExtract struct {
+	Fields  []ExtractedTableField    `json:"fields"`
+	Columns []KeyOrID                `json:"columns"`
+	Values  [][]interface{}          `json:"values"`
+	Attrs   []map[string]interface{} `json:"attrs,omitempty"`
+}
+
+func columnarExtract(t ExtractedTable) *ColumnarExtract {
+	out := &ColumnarExtract{
+		Fields:  t.Fields,
+		Columns: make([]KeyOrID, len(t.Columns)),
+		Values:  make([][]interface{}, len(t.Fields)),
+	}
+	for i := range out.Values {
+		out.Values[i] = make([]interface{}, len(t.Columns))
+	}
+	for j, col := range t.Columns {
+		out.Columns[j] = col.Column
+		for i, v := range col.Rows {
+			out.Values[i][j] = v
+		}
+		if col.Attrs != nil {
+			if out.Attrs == nil {
+				out.Attrs = make([]map[string]interface{}, len(t.Columns))
+			}
+			out.Attrs[j] = col.Attrs
+		}
+	}
+	return out
+}
+
+// ColumnarGroupBy is a GroupBy result with the rows of each field in an
+// array, in the order of the groups. A row is its key, or value for an int
+// field, or ID. Periods has the periods of fields grouped by period.
+type ColumnarGroupBy struct {
+	Fields     []string        `json:"fields"`
+	Groups     [][]interface{} `json:"groups"`
+	Periods    [][]string      `json:"periods,omitempty"`
+	Counts     []uint64        `json:"counts"`
+	Aggregate  string          `json:"aggregate,omitempty"`
+	Aggregates []interface{}   `json:"aggregates,omitempty"`
+}
+
+func columnarGroupBy(g *GroupCounts) *ColumnarGroupBy {
+	groups := g.Groups()
+	out := &ColumnarGroupBy{
+		Fields: []string{},
+		Groups: [][]interface{}{},
+		Counts: make([]uint64, len(groups)),
+	}
+	if len(groups) > 0 {
+		for _, fr := range groups[0].Group {
+			out.Fields = append(out.Fields, fr.Field)
+			out.Groups = append(out.Groups, make([]interface{}, len(groups)))
+		}
+	}
+	if out.Aggregate = g.AggregateColumn(); out.Aggregate != "" {
+		out.Aggregates = make([]interface{}, len(groups))
+	}
+	for j, gc := range groups {
+		for i, fr := range gc.Group {
+			switch {
+			case fr.RowKey != "":
+				out.Groups[i][j] = fr.RowKey
+			case fr.Value != nil:
+				out.Groups[i][j] = *fr.Value
+			default:
+				out.Groups[i][j] = fr.RowID
+			}
+			if fr.Period != "" {
+				if out.Periods == nil {
+					out.Periods = make([][]string, len(out.Fields))
+				}
+				if out.Periods[i] == nil {
+					out.Periods[i] = make([]string, len(groups))
+				}
+				out.Periods[i][j] = fr.Period
+			}
+		}
+		out.Counts[j] = gc.Count
+		if out.Aggregates != nil {
+			out.Aggregates[j] = g.aggregateValue(gc)
+		}
+	}
+	return out
+}
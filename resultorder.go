@@ -0,0 +1,163 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// Orders in which Extract() and All() can return columns, given by their
+// "order" argument. Columns are ordered by ID unless asked otherwise, which
+// for keyed indexes is the order of their translation partitions. When an
+// Extract() ordered by key is limited, each shard, and each merge of shards,
+// only keeps the columns with the lowest keys needed to fill the limit; an
+// All() ordered by key translates every column it matches.
+const (
+	OrderID  = "id"
+	OrderKey = "key"
+)
+
+// columnOrder holds the "order", "limit" and "offset" arguments of a call
+// returning columns. The limit and offset apply after ordering.
+type columnOrder struct {
+	order    string
+	limit    uint64
+	hasLimit bool
+	offset   uint64
+}
+
+// parseColumnOrder parses the ordering arguments of c.
+func parseColumnOrder(c *pql.Call) (columnOrder, error) {
+	o := columnOrder{order: OrderID}
+	if order, ok, err := c.StringArg("order"); err != nil {
+		return o, NewBadRequestError(errors.Wrap(err, "getting order"))
+	} else if ok {
+		switch order {
+		case OrderID, OrderKey:
+			o.order = order
+		default:
+			return o, NewBadRequestError(errors.Errorf("invalid order %q, expected %q or %q", order, OrderID, OrderKey))
+		}
+	}
+	var err error
+	if o.limit, o.hasLimit, err = c.UintArg("limit"); err != nil {
+		return o, NewBadRequestError(errors.Wrap(err, "getting limit"))
+	}
+	if o.offset, _, err = c.UintArg("offset"); err != nil {
+		return o, NewBadRequestError(errors.Wrap(err, "getting offset"))
+	}
+	return o, nil
+}
+
+// byKey reports whether columns of idx must be ordered by key, which can
+// only be done once they've been translated. Columns of an index without
+// keys are ordered by ID either way.
+func (o columnOrder) byKey(idx *Index) bool {
+	return o.order == OrderKey && idx != nil && idx.Keys()
+}
+
+// bounds returns the range of n ordered columns selected by the limit and
+// offset.
+func (o columnOrder) bounds(n int) (start, end int) {
+	start, end = n, n
+	if o.offset < uint64(n) {
+		start = int(o.offset)
+	}
+	if o.hasLimit && o.limit < uint64(n-start) {
+		end = start + int(o.limit)
+	}
+	return start, end
+}
+
+// keep returns how many of the lowest column IDs each shard needs to return
+// for the columns selected when ordering by ID, or -1 if every column is
+// needed.
+func (o columnOrder) keep() int {
+	if !o.hasLimit {
+		return -1
+	}
+	return int(o.offset + o.limit)
+}
+
+// orderMatrixByKey orders the columns of m by key and applies the limit and
+// offset, given the keys of its column IDs.
+func orderMatrixByKey(m ExtractedIDMatrix, o columnOrder, keys map[uint64]string) ExtractedIDMatrix {
+	sort.SliceStable(m.Columns, func(i, j int) bool {
+		return keys[m.Columns[i].ColumnID] < keys[m.Columns[j].ColumnID]
+	})
+	start, end := o.bounds(len(m.Columns))
+	m.Columns = m.Columns[start:end]
+	return m
+}
+
+// orderKeys orders keys and applies the limit and offset.
+func orderKeys(keys []string, o columnOrder) []string {
+	sort.Strings(keys)
+	start, end := o.bounds(len(keys))
+	return keys[start:end]
+}
+
+// keyTrimmer trims the columns of an Extract() ordered by key to the keep
+// columns with the lowest keys, translating their IDs as it goes. It
+// remembers the keys of the columns it keeps, so merged results don't
+// need translating again.
+type keyTrimmer struct {
+	keep      int
+	translate func(ctx context.Context, ids map[uint64]struct{}) (map[uint64]string, error)
+
+	mu   sync.Mutex
+	keys map[uint64]string
+}
+
+func newKeyTrimmer(keep int, translate func(ctx context.Context, ids map[uint64]struct{}) (map[uint64]string, error)) *keyTrimmer {
+	return &keyTrimmer{keep: keep, translate: translate, keys: make(map[uint64]string)}
+}
+
+// trim returns m with only the columns with the lowest keys. A nil
+// keyTrimmer leaves m as is.
+func (t *keyTrimmer) trim(ctx context.Context, m ExtractedIDMatrix) (ExtractedIDMatrix, error) {
+	if t == nil || len(m.Columns) <= t.keep {
+		return m, nil
+	}
+
+	ids := make(map[uint64]struct{})
+	t.mu.Lock()
+	for _, col := range m.Columns {
+		if _, ok := t.keys[col.ColumnID]; !ok {
+			ids[col.ColumnID] = struct{}{}
+		}
+	}
+	t.mu.Unlock()
+	var keys map[uint64]string
+	if len(ids) > 0 {
+		var err error
+		if keys, err = t.translate(ctx, ids); err != nil {
+			return m, errors.Wrap(err, "translating columns")
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id := range ids {
+		t.keys[id] = keys[id]
+	}
+	sort.Slice(m.Columns, func(i, j int) bool {
+		ki, kj := t.keys[m.Columns[i].ColumnID], t.keys[m.Columns[j].ColumnID]
+		if ki != kj {
+			return ki < kj
+		}
+		return m.Columns[i].ColumnID < m.Columns[j].ColumnID
+	})
+	// Columns which don't make the cut can't make it later, so their keys
+	// are forgotten.
+	for _, col := range m.Columns[t.keep:] {
+		delete(t.keys, col.ColumnID)
+	}
+	m.Columns = m.Columns[:t.keep]
+	return m, nil
+}
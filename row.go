@@ -485,6 +485,53 @@ func (r *Row) Columns() []uint64 {
 	return a
 }
 
+// sliceColumns returns a row with up to limit of the columns of r, skipping
+// the offset smallest. If desc is true, columns are taken from the largest
+// down instead. Whole segments are shared with r, rather than copied.
+func (r *Row) sliceColumns(offset, limit uint64, desc bool) *Row {
+	if r == nil {
+		return nil
+	}
+	out := &Row{}
+	n := len(r.segments)
+	for k := 0; k < n && limit > 0; k++ {
+		i := k
+		if desc {
+			i = n - 1 - k
+		}
+		seg := r.segments[i]
+		count := seg.Count()
+		if count <= offset {
+			offset -= count
+			continue
+		}
+		if offset == 0 && count <= limit {
+			out.segments = append(out.segments, seg)
+			limit -= count
+			continue
+		}
+
+		take := count - offset
+		if take > limit {
+			take = limit
+		}
+		cols := seg.Columns()
+		if desc {
+			cols = cols[count-offset-take : count-offset]
+		} else {
+			cols = cols[offset : offset+take]
+		}
+		out.segments = append(out.segments, NewRow(cols...).segments...)
+		offset, limit = 0, limit-take
+	}
+	if desc {
+		for i, j := 0, len(out.segments)-1; i < j; i, j = i+1, j-1 {
+			out.segments[i], out.segments[j] = out.segments[j], out.segments[i]
+		}
+	}
+	return out
+}
+
 // Includes returns true if the row contains the given column.
 func (r *Row) Includes(col uint64) bool {
 	shard := col / ShardWidth
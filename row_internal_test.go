@@ -0,0 +1,53 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRow_sliceColumns(t *testing.T) {
+	cols := []uint64{1, 2, 3, ShardWidth, ShardWidth + 5, 3 * ShardWidth, 3*ShardWidth + 1, 3*ShardWidth + 2}
+	r := NewRow(cols...)
+	reversed := make([]uint64, len(cols))
+	for i, col := range cols {
+		reversed[len(cols)-1-i] = col
+	}
+
+	for _, tc := range []struct{ offset, limit uint64 }{
+		{0, 0}, {0, 2}, {0, 3}, {1, 3}, {2, 4}, {3, 2}, {4, 100}, {7, 1}, {8, 1}, {20, 5}, {0, 100},
+	} {
+		end := func(from uint64) uint64 {
+			if from+tc.limit > uint64(len(cols)) {
+				return uint64(len(cols))
+			}
+			return from + tc.limit
+		}
+		from := tc.offset
+		if from > uint64(len(cols)) {
+			from = uint64(len(cols))
+		}
+
+		exp := cols[from:end(from)]
+		if got := r.sliceColumns(tc.offset, tc.limit, false).Columns(); !reflect.DeepEqual(got, exp) && len(got)+len(exp) > 0 {
+			t.Errorf("offset %d, limit %d: expected %v, got %v", tc.offset, tc.limit, exp, got)
+		}
+
+		// Descending, the columns are taken from the end, but the row is
+		// still in order.
+		desc := append([]uint64{}, reversed[from:end(from)]...)
+		for i, j := 0, len(desc)-1; i < j; i, j = i+1, j-1 {
+			desc[i], desc[j] = desc[j], desc[i]
+		}
+		if got := r.sliceColumns(tc.offset, tc.limit, true).Columns(); !reflect.DeepEqual(got, desc) && len(got)+len(desc) > 0 {
+			t.Errorf("offset %d, limit %d, desc: expected %v, got %v", tc.offset, tc.limit, desc, got)
+		}
+	}
+
+	r.sliceColumns(1, 4, false)
+	r.sliceColumns(1, 4, true)
+	if got := r.Columns(); !reflect.DeepEqual(got, cols) {
+		t.Fatalf("slicing changed the row: %v", got)
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// MaxRowMetaSize is the largest metadata blob which may be attached to a row.
+const MaxRowMetaSize = 16 * 1024
+
+// ErrRowMetaTooLarge is returned when row metadata exceeds MaxRowMetaSize.
+var ErrRowMetaTooLarge = errors.New("row metadata too large")
+
+// rowMetaStore holds small JSON metadata blobs attached to the rows of
+// fields. Metadata is stored in a bolt database under the holder's path,
+// with one bucket per index containing one bucket per field. Rows are
+// identified by their key for keyed fields, and by their decimal ID
+// otherwise. The database is opened lazily so that nodes which never
+// use row metadata don't create it.
+type rowMetaStore struct {
+	mu           sync.Mutex
+	path         string
+	fsyncEnabled bool
+	db           *bolt.DB
+}
+
+func newRowMetaStore(path string, fsyncEnabled bool) *rowMetaStore {
+	return &rowMetaStore{path: path, fsyncEnabled: fsyncEnabled}
+}
+
+// open returns the underlying database, opening it if necessary. If create
+// is false and the database doesn't exist yet, it returns nil.
+func (s *rowMetaStore) open(create bool) (*bolt.DB, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db != nil {
+		return s.db, nil
+	}
+	if !create {
+		if _, err := os.Stat(s.path); os.IsNotExist(err) {
+			return nil, nil
+		}
+	}
+	db, err := bolt.Open(s.path, 0600, &bolt.Options{Timeout: 1 * time.Second, NoSync: !s.fsyncEnabled})
+	if err != nil {
+		return nil, errors.Wrap(err, "opening row metadata store")
+	}
+	s.db = db
+	return db, nil
+}
+
+// Close closes the underlying database, if it was opened.
+func (s *rowMetaStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db = nil
+	return err
+}
+
+// Set stores meta for the given row. An empty meta removes any existing
+// metadata for the row.
+func (s *rowMetaStore) Set(index, field, row string, meta json.RawMessage) error {
+	db, err := s.open(len(meta) > 0)
+	if err != nil || db == nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		if len(meta) == 0 {
+			ib := tx.Bucket([]byte(index))
+			if ib == nil {
+				return nil
+			}
+			fb := ib.Bucket([]byte(field))
+			if fb == nil {
+				return nil
+			}
+			return fb.Delete([]byte(row))
+		}
+		ib, err := tx.CreateBucketIfNotExists([]byte(index))
+		if err != nil {
+			return errors.Wrap(err, "creating index bucket")
+		}
+		fb, err := ib.CreateBucketIfNotExists([]byte(field))
+		if err != nil {
+			return errors.Wrap(err, "creating field bucket")
+		}
+		return fb.Put([]byte(row), meta)
+	})
+}
+
+// Get returns the metadata for each of rows, in order. Rows without
+// metadata get a nil entry. If none of the rows have metadata, a nil
+// slice is returned.
+func (s *rowMetaStore) Get(index, field string, rows []string) ([]json.RawMessage, error) {
+	db, err := s.open(false)
+	if err != nil || db == nil {
+		return nil, err
+	}
+	var metas []json.RawMessage
+	err = db.View(func(tx *bolt.Tx) error {
+		ib := tx.Bucket([]byte(index))
+		if ib == nil {
+			return nil
+		}
+		fb := ib.Bucket([]byte(field))
+		if fb == nil {
+			return nil
+		}
+		for i, row := range rows {
+			v := fb.Get([]byte(row))
+			if v == nil {
+				continue
+			}
+			if metas == nil {
+				metas = make([]json.RawMessage, len(rows))
+			}
+			// Values returned by bolt are only valid during the transaction.
+			metas[i] = append(json.RawMessage(nil), v...)
+		}
+		return nil
+	})
+	return metas, err
+}
+
+// DeleteIndex removes all metadata for the fields of index.
+func (s *rowMetaStore) DeleteIndex(index string) error {
+	db, err := s.open(false)
+	if err != nil || db == nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket([]byte(index)) == nil {
+			return nil
+		}
+		return tx.DeleteBucket([]byte(index))
+	})
+}
+
+// DeleteField removes all metadata for the rows of field.
+func (s *rowMetaStore) DeleteField(index, field string) error {
+	db, err := s.open(false)
+	if err != nil || db == nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		ib := tx.Bucket([]byte(index))
+		if ib == nil || ib.Bucket([]byte(field)) == nil {
+			return nil
+		}
+		return ib.DeleteBucket([]byte(field))
+	})
+}
+
+// rowMetaName returns the name under which metadata for a row is stored.
+func rowMetaName(id uint64, key string, keys bool) string {
+	if keys {
+		return key
+	}
+	return strconv.FormatUint(id, 10)
+}
+
+// SetRowMetaMessage is an internal message setting (or, with a nil Meta,
+// clearing) the metadata attached to a row of a field.
+type SetRowMetaMessage struct {
+	Index string
+	Field string
+	Row   string
+	Meta  json.RawMessage
+}
+
+// fieldHasRowMeta reports whether rows of f may carry metadata.
+func fieldHasRowMeta(f *Field) bool {
+	switch f.Type() {
+	case FieldTypeSet, FieldTypeMutex, FieldTypeTime, FieldTypeBool:
+		return true
+	}
+	return false
+}
+
+// attachRowMeta attaches row metadata to a translated TopN, Rows, or
+// GroupBy result, returning the updated result. Other results are returned
+// unchanged.
+func (e *executor) attachRowMeta(idx *Index, result interface{}) (interface{}, error) {
+	store := e.Holder.rowMeta
+	switch result := result.(type) {
+	case *PairsField:
+		field := idx.Field(result.Field)
+		if field == nil || len(result.Pairs) == 0 {
+			return result, nil
+		}
+		rows := make([]string, len(result.Pairs))
+		for i, p := range result.Pairs {
+			rows[i] = rowMetaName(p.ID, p.Key, field.Keys())
+		}
+		metas, err := store.Get(idx.Name(), field.Name(), rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Meta = metas
+	case RowIdentifiers:
+		field := idx.Field(result.field)
+		if field == nil {
+			return result, nil
+		}
+		rows := result.Keys
+		if !field.Keys() {
+			rows = make([]string, len(result.Rows))
+			for i, id := range result.Rows {
+				rows[i] = rowMetaName(id, "", false)
+			}
+		}
+		if len(rows) == 0 {
+			return result, nil
+		}
+		metas, err := store.Get(idx.Name(), field.Name(), rows)
+		if err != nil {
+			return nil, err
+		}
+		result.Meta = metas
+		return result, nil
+	case *GroupCounts:
+		groups := result.Groups()
+		if len(groups) == 0 {
+			return result, nil
+		}
+		// Every group has the same fields in the same order.
+		for j := range groups[0].Group {
+			field := idx.Field(groups[0].Group[j].Field)
+			if field == nil || !fieldHasRowMeta(field) {
+				continue
+			}
+			rows := make([]string, len(groups))
+			for i := range groups {
+				fr := groups[i].Group[j]
+				rows[i] = rowMetaName(fr.RowID, fr.RowKey, field.Keys())
+			}
+			metas, err := store.Get(idx.Name(), field.Name(), rows)
+			if err != nil {
+				return nil, err
+			}
+			for i := range metas {
+				groups[i].Group[j].Meta = metas[i]
+			}
+		}
+	}
+	return result, nil
+}
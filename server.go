@@ -79,6 +79,20 @@ type Server struct { // nolint: maligned
 	confirmDownRetries   int
 	syncer               holderSyncer
 	maxQueryMemory       int64
+	existenceFallback    string
+	maxBatchQueries      int
+	maxBackgroundQueries int
+	clientWeights        map[string]int
+	rewriteLegacyCalls   bool
+	eventWebhooks        []string
+	pluginsDir           string
+	events               *eventNotifier
+	queryCapturePath     string
+	queryCaptureRate     float64
+	queryCapture         *queryCapture
+	canaryPath           string
+	canaryRate           float64
+	canary               *canary
 
 	translationSyncer      TranslationSyncer
 	resetTranslationSyncCh chan struct{}
@@ -88,10 +102,19 @@ type Server struct { // nolint: maligned
 	defaultClient *InternalClient
 	dataDir       string
 
+	// Temporary directory for results spilled to disk, and its quota.
+	tempDir        string
+	tempDirQuota   int64
+	spillThreshold int64
+
 	// Threshold for logging long-running queries
 	longQueryTime      time.Duration
 	queryHistoryLength int
 
+	// Settings which can be changed while the server is running.
+	settingsMu sync.RWMutex
+	settings   Settings
+
 	executionPlannerFn ExecutionPlannerFn
 }
 
@@ -160,6 +183,27 @@ func OptServerDataDir(dir string) ServerOption {
 	}
 }
 
+// OptServerTempDir sets the directory which results too large to assemble
+// in memory are spilled to, and the number of bytes it can hold, or 0 for
+// no limit. It defaults to the data directory's tmp.
+func OptServerTempDir(dir string, quota int64) ServerOption {
+	return func(s *Server) error {
+		s.tempDir = dir
+		s.tempDirQuota = quota
+		return nil
+	}
+}
+
+// OptServerSpillThreshold sets how many bytes the columns of an Extract
+// can take before they're spilled to the temporary directory. Zero never
+// spills them.
+func OptServerSpillThreshold(n int64) ServerOption {
+	return func(s *Server) error {
+		s.spillThreshold = n
+		return nil
+	}
+}
+
 // OptServerAntiEntropyInterval is a functional option on Server
 // used to set the anti-entropy interval.
 func OptServerAntiEntropyInterval(interval time.Duration) ServerOption {
@@ -398,6 +442,165 @@ func OptServerMaxQueryMemory(v int64) ServerOption {
 	}
 }
 
+// OptServerExistenceFallback sets the default existence fallback for
+// queries against indexes which don't track existence.
+func OptServerExistenceFallback(fallback string) ServerOption {
+	return func(s *Server) error {
+		s.existenceFallback = fallback
+		return nil
+	}
+}
+
+// OptServerQueryAdmission limits how many batch and background priority
+// queries the server coordinates at once. Zero means no limit.
+func OptServerQueryAdmission(maxBatch, maxBackground int) ServerOption {
+	return func(s *Server) error {
+		s.maxBatchQueries = maxBatch
+		s.maxBackgroundQueries = maxBackground
+		return nil
+	}
+}
+
+// OptServerClientWeights sets the shares of the shard workers the clients
+// queueing queries at once get, by client. Clients without a weight have a
+// weight of one.
+func OptServerClientWeights(weights map[string]int) ServerOption {
+	return func(s *Server) error {
+		s.clientWeights = weights
+		return nil
+	}
+}
+
+// OptServerNamespaceQuotas sets the quotas of namespaces, by name.
+func OptServerNamespaceQuotas(quotas map[string]NamespaceQuota) ServerOption {
+	return func(s *Server) error {
+		s.holderConfig.NamespaceQuotas = quotas
+		return nil
+	}
+}
+
+// OptServerQueryRules sets the rules which allow or deny calls in queries
+// the server coordinates.
+func OptServerQueryRules(rules []QueryRule) ServerOption {
+	return func(s *Server) error {
+		if err := validateQueryRules(rules); err != nil {
+			return err
+		}
+		s.holderConfig.QueryRules = rules
+		return nil
+	}
+}
+
+// OptServerOptionTemplates sets the options given to indexes and fields
+// created through the server by default, and the templates of options their
+// requests can name.
+func OptServerOptionTemplates(t *OptionTemplates) ServerOption {
+	return func(s *Server) error {
+		if t != nil {
+			if err := t.validate(); err != nil {
+				return err
+			}
+		}
+		s.holderConfig.OptionTemplates = t
+		return nil
+	}
+}
+
+// OptServerDiskLimits limits the disk used by the server's data, and sets
+// how much of the disk must be left free for it to accept writes.
+func OptServerDiskLimits(limits DiskLimits) ServerOption {
+	return func(s *Server) error {
+		s.holderConfig.DiskLimits = limits
+		return nil
+	}
+}
+
+// OptServerChecksums makes the server save a checksum of each fragment's
+// data, verifying it when the fragment is opened and, if scrubInterval is
+// set, that often while it's open.
+func OptServerChecksums(enabled bool, scrubInterval time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.holderConfig.Checksums = enabled
+		s.holderConfig.ScrubInterval = scrubInterval
+		return nil
+	}
+}
+
+// OptServerStaleTxAge sets how long a transaction context can be open
+// before /health reports it as stale.
+func OptServerStaleTxAge(age time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.holderConfig.StaleTxAge = age
+		return nil
+	}
+}
+
+// OptServerReadCoalesceWindow sets how long a row read from a fragment is
+// shared with other queries reading the same data.
+func OptServerReadCoalesceWindow(window time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.holderConfig.ReadCoalesceWindow = window
+		return nil
+	}
+}
+
+// OptServerRewriteLegacyCalls sets whether legacy PQL calls are rewritten
+// to the calls which replaced them.
+func OptServerRewriteLegacyCalls(rewrite bool) ServerOption {
+	return func(s *Server) error {
+		s.rewriteLegacyCalls = rewrite
+		return nil
+	}
+}
+
+// OptServerLazyOpen makes the server open fragments when they're first
+// used rather than all at startup.
+func OptServerLazyOpen(lazy bool) ServerOption {
+	return func(s *Server) error {
+		s.holderConfig.LazyOpen = lazy
+		return nil
+	}
+}
+
+// OptServerEventWebhooks sets the URLs events about changes to the schema
+// and cluster are posted to.
+func OptServerEventWebhooks(urls []string) ServerOption {
+	return func(s *Server) error {
+		s.eventWebhooks = urls
+		return nil
+	}
+}
+
+// OptServerQueryCapture sets the file queries are captured to, and the
+// fraction of them sampled. Queries slower than the long query time are
+// always captured.
+func OptServerQueryCapture(path string, rate float64) ServerOption {
+	return func(s *Server) error {
+		s.queryCapturePath = path
+		s.queryCaptureRate = rate
+		return nil
+	}
+}
+
+// OptServerCanary sets the execution path a sample of read-only queries,
+// rate, are executed on a second time, to compare their results.
+func OptServerCanary(path string, rate float64) ServerOption {
+	return func(s *Server) error {
+		s.canaryPath = path
+		s.canaryRate = rate
+		return nil
+	}
+}
+
+// OptServerPluginsDir sets the directory user-defined functions are loaded
+// from.
+func OptServerPluginsDir(dir string) ServerOption {
+	return func(s *Server) error {
+		s.pluginsDir = dir
+		return nil
+	}
+}
+
 // OptServerDisCo is a functional option on Server
 // used to set the Distributed Consensus implementation.
 func OptServerDisCo(disCo disco.DisCo,
@@ -462,6 +665,8 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 		confirmDownRetries: defaultConfirmDownRetries,
 		confirmDownSleep:   defaultConfirmDownSleep,
 
+		rewriteLegacyCalls: true,
+
 		resetTranslationSyncCh: make(chan struct{}, 1),
 
 		logger: logger.NopLogger,
@@ -499,20 +704,44 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 		maxQueryMemory = int64(float64(memTotal) * .20)
 	}
 
+	plugins, err := loadPlugins(s.pluginsDir, s.logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading plugins")
+	}
+
+	path, err := expandDirName(s.dataDir)
+	if err != nil {
+		return nil, err
+	}
+	// Without a data directory or temporary directory, nothing is spilled.
+	var spill *spillDir
+	tempDir := s.tempDir
+	if tempDir == "" && path != "" {
+		tempDir = filepath.Join(path, "tmp")
+	} else if tempDir, err = expandDirName(tempDir); err != nil {
+		return nil, err
+	}
+	if tempDir != "" {
+		if spill, err = openSpillDir(tempDir, s.tempDirQuota); err != nil {
+			return nil, errors.Wrap(err, "opening temporary directory")
+		}
+	}
+
 	// set up executor after server opts have been processed
 	executorOpts := []executorOption{
 		optExecutorInternalQueryClient(s.defaultClient),
 		optExecutorMaxMemory(maxQueryMemory),
+		optExecutorExistenceFallback(s.existenceFallback),
+		optExecutorQueryAdmission(s.maxBatchQueries, s.maxBackgroundQueries),
+		optExecutorClientWeights(s.clientWeights),
+		optExecutorPlugins(plugins),
+		optExecutorSpill(spill, s.spillThreshold),
 	}
 	if s.executorPoolSize > 0 {
 		executorOpts = append(executorOpts, optExecutorWorkerPoolSize(s.executorPoolSize))
 	}
 	s.executor = newExecutor(executorOpts...)
 
-	path, err := expandDirName(s.dataDir)
-	if err != nil {
-		return nil, err
-	}
 	s.holder = NewHolder(path, s.holderConfig)
 	s.holder.Stats.SetLogger(s.logger)
 	cwd, err := os.Getwd()
@@ -536,6 +765,14 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 	s.holder.executor = s.executor
 	s.executor.Cluster = s.cluster
 	s.executor.MaxWritesPerRequest = s.maxWritesPerRequest
+	s.settings = Settings{
+		LongQueryTime:        s.longQueryTime,
+		WorkerPoolSize:       s.executor.workerPoolSize,
+		MaxWritesPerRequest:  s.maxWritesPerRequest,
+		MaxQueryMemory:       maxQueryMemory,
+		MaxBatchQueries:      s.maxBatchQueries,
+		MaxBackgroundQueries: s.maxBackgroundQueries,
+	}
 	s.cluster.broadcaster = s
 	s.cluster.maxWritesPerRequest = s.maxWritesPerRequest
 	s.cluster.confirmDownRetries = s.confirmDownRetries
@@ -544,6 +781,14 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 	s.holder.sharder = s.sharder
 	s.holder.serializer = s.serializer
 
+	s.events = newEventNotifier(s.eventWebhooks, s.cluster.Name, s.logger)
+	if s.queryCapture, err = newQueryCapture(s.queryCapturePath, s.queryCaptureRate, s.logger); err != nil {
+		return nil, errors.Wrap(err, "starting query capture")
+	}
+	if s.canary, err = newCanary(s.canaryPath, s.canaryRate, s.logger); err != nil {
+		return nil, errors.Wrap(err, "starting canary")
+	}
+
 	// Initial stats must be invoked after the executor obtains reference to the holder.
 	s.executor.InitStats()
 
@@ -625,6 +870,7 @@ func (s *Server) Open() error {
 	s.syncer.Cluster = s.cluster
 	s.syncer.Closing = s.closing
 	s.syncer.Stats = s.holder.Stats.WithTags("component:HolderSyncer")
+	s.syncer.synced = s.executor.router.synced
 
 	// Start background process listening for translation
 	// sync resets.
@@ -645,6 +891,9 @@ func (s *Server) Open() error {
 	if err := s.holder.Open(); err != nil {
 		return errors.Wrap(err, "opening Holder")
 	}
+	if err := s.loadSettings(); err != nil {
+		return errors.Wrap(err, "loading settings")
+	}
 	// bring up the background tasks for the holder.
 	s.holder.Activate()
 
@@ -652,13 +901,16 @@ func (s *Server) Open() error {
 		return errors.Wrap(err, "setting nodeState")
 	}
 
-	if ok := s.addToWaitGroup(4); !ok {
+	if ok := s.addToWaitGroup(7); !ok {
 		return fmt.Errorf("closing server while opening server is NOT allowed")
 	}
 	go func() { defer s.wg.Done(); s.monitorAntiEntropy() }()
 	go func() { defer s.wg.Done(); s.monitorRuntime() }()
 	go func() { defer s.wg.Done(); s.monitorDiagnostics() }()
 	go func() { defer s.wg.Done(); s.monitorViewsRemoval() }()
+	go func() { defer s.wg.Done(); s.monitorEvents() }()
+	go func() { defer s.wg.Done(); s.monitorDiskPressure() }()
+	go func() { defer s.wg.Done(); s.monitorChecksums() }()
 
 	toSend := func() []Message {
 		s.holder.startMsgsMu.Lock()
@@ -764,6 +1016,7 @@ func (s *Server) Close() error {
 		// Notify goroutines to stop.
 		close(s.closing)
 		s.wg.Wait()
+		s.canary.Close()
 
 		errE := s.executor.Close()
 
@@ -781,6 +1034,9 @@ func (s *Server) Close() error {
 		if s.holder != nil {
 			errh = s.holder.Close()
 		}
+		if err := s.queryCapture.Close(); err != nil {
+			s.logger.Errorf("closing query capture: %v", err)
+		}
 
 		// prefer to return holder error over cluster
 		// error. This order is somewhat arbitrary. It would be better if we had
@@ -873,7 +1129,7 @@ func (s *Server) ViewsRemoval(ctx context.Context) {
 						if len(viewName) == 2 {
 							// when getting the view time, we want to grab the end date
 							// because start date will aways be older
-							viewTime, err := timeOfView(view.name, true)
+							viewTime, err := field.timeOfView(view.name, true)
 							if err != nil {
 								s.logger.Printf("view: %s; err: %s", viewName, err)
 								continue
@@ -921,8 +1177,13 @@ func (s *Server) monitorAntiEntropy() {
 	// %% begin sonarcloud ignore %%
 	// This code isn't really used anymore because of problems with the design,
 	// but we haven't taken it out yet. But there's no code coverage of it.
-	if s.antiEntropyInterval == 0 || s.cluster.ReplicaN <= 1 {
+	if s.cluster.ReplicaN <= 1 {
 		return // anti entropy disabled
+	} else if s.antiEntropyInterval == 0 {
+		// Reads are only routed to replicas once anti-entropy has synced
+		// them; see nodeRouter.
+		s.logger.Warnf("anti-entropy is disabled, so reads won't be routed from degraded nodes to their replicas")
+		return
 	}
 	s.cluster.initializeAntiEntropy()
 
@@ -1022,6 +1283,36 @@ func (s *Server) receiveMessage(m Message) error {
 			return err
 		}
 
+	case *SetRowMetaMessage:
+		if err := s.holder.rowMeta.Set(obj.Index, obj.Field, obj.Row, obj.Meta); err != nil {
+			return errors.Wrap(err, "setting row metadata")
+		}
+
+	case *SetAliasMessage:
+		if err := s.holder.aliases.Set(obj.Alias, obj.Index); err != nil {
+			return errors.Wrap(err, "setting alias")
+		}
+
+	case *CloneIndexMessage:
+		if err := s.cloneIndexData(context.Background(), obj.Source, obj.Target); err != nil {
+			return errors.Wrap(err, "cloning index")
+		}
+
+	case *UpdateIndexMessage:
+		idx := s.holder.Index(obj.CreateIndexMessage.Index)
+		if idx == nil {
+			return newNotFoundError(ErrIndexNotFound, obj.CreateIndexMessage.Index)
+		}
+		idx.updateOptionsLocal(&obj.CreateIndexMessage)
+
+	case *MaintenanceMessage:
+		s.holder.setMaintenance(obj.Reason)
+
+	case *SettingsMessage:
+		if err := s.updateSettings(obj.Settings); err != nil {
+			return errors.Wrap(err, "updating settings")
+		}
+
 	case *DeleteAvailableShardMessage:
 		f := s.holder.Field(obj.Index, obj.Field)
 		if err := f.RemoveAvailableShard(obj.ShardID); err != nil {
@@ -1271,6 +1562,10 @@ func (s *Server) monitorRuntime() {
 
 	s.logger.Infof("runtime stats initializing (%s interval)", s.metricInterval)
 
+	// Clients whose shard jobs were last reported, so their gauges can be
+	// zeroed once they have none.
+	clients := make(map[string]struct{})
+
 	for {
 		// Wait for tick or a close.
 		select {
@@ -1298,6 +1593,23 @@ func (s *Server) monitorRuntime() {
 		s.holder.Stats.Gauge(MetricStackInuse, float64(m.StackInuse), 1.0)
 		s.holder.Stats.Gauge(MetricMallocs, float64(m.Mallocs), 1.0)
 		s.holder.Stats.Gauge(MetricFrees, float64(m.Frees), 1.0)
+
+		// Shard jobs queued and running for each client.
+		loads := s.executor.work.clientLoads()
+		for client := range clients {
+			if _, ok := loads[client]; !ok {
+				loads[client] = ClientLoad{}
+				delete(clients, client)
+			}
+		}
+		for client, l := range loads {
+			stats := s.holder.Stats.WithTags("client:" + client)
+			stats.Gauge(MetricClientJobsQueued, float64(l.Queued), 1.0)
+			stats.Gauge(MetricClientJobsRunning, float64(l.Running), 1.0)
+			if l != (ClientLoad{}) {
+				clients[client] = struct{}{}
+			}
+		}
 	}
 }
 
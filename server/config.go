@@ -18,6 +18,7 @@ import (
 
 	"github.com/featurebasedb/featurebase/v3/authz"
 	petcd "github.com/featurebasedb/featurebase/v3/etcd"
+	"github.com/featurebasedb/featurebase/v3/etcdkeys"
 	rbfcfg "github.com/featurebasedb/featurebase/v3/rbf/cfg"
 	"github.com/featurebasedb/featurebase/v3/storage"
 	"github.com/featurebasedb/featurebase/v3/toml"
@@ -92,6 +93,19 @@ type Config struct {
 	Handler struct {
 		// CORS Allowed Origins
 		AllowedOrigins []string `toml:"allowed-origins"`
+		// Headers, beyond Content-Type, cross-origin requests can send.
+		AllowedHeaders []string `toml:"allowed-headers"`
+		// Methods cross-origin requests can use; GET, HEAD and POST if
+		// none are given.
+		AllowedMethods []string `toml:"allowed-methods"`
+		// Response headers cross-origin requests can read.
+		ExposedHeaders []string `toml:"exposed-headers"`
+		// AllowCredentials lets cross-origin requests send cookies and
+		// authorization headers.
+		AllowCredentials bool `toml:"allow-credentials"`
+		// CORSMaxAge is how long browsers can cache the responses to
+		// preflight requests.
+		CORSMaxAge toml.Duration `toml:"cors-max-age"`
 	} `toml:"handler"`
 
 	// MaxMapCount puts an in-process limit on the number of mmaps. After this
@@ -126,6 +140,115 @@ type Config struct {
 	// Limits the total amount of memory to be used by Extract() & SELECT queries.
 	MaxQueryMemory int64 `toml:"max-query-memory"`
 
+	// TempDir is the directory which results too large to assemble in
+	// memory are spilled to. It defaults to the data directory's tmp.
+	// Files left in it by a previous run are removed on startup.
+	TempDir string `toml:"temp-dir"`
+
+	// TempDirQuota is the number of bytes the files in TempDir can hold.
+	// Zero means no limit.
+	TempDirQuota int64 `toml:"temp-dir-quota"`
+
+	// SpillThreshold is the number of bytes the columns of an Extract can
+	// take before they're spilled to TempDir. Zero never spills them.
+	SpillThreshold int64 `toml:"spill-threshold"`
+
+	// ExistenceFallback is used by Not(), All(), and "== null" queries
+	// against indexes which don't track existence: "*" to treat columns
+	// with a value in any field as existing, or the name of a field.
+	ExistenceFallback string `toml:"existence-fallback"`
+
+	// QueryPriority limits how many queries of the batch and background
+	// priority classes a node coordinates at once, so they can't crowd out
+	// interactive queries. Zero means no limit.
+	//
+	// ClientWeights sets the shares of the shard workers the clients get
+	// when they're queueing queries at the same time, by client: the name
+	// of the user when authentication is on, otherwise the client name
+	// passed with the query or the client's address. Clients without a
+	// weight have a weight of one.
+	QueryPriority struct {
+		MaxBatch      int            `toml:"max-batch"`
+		MaxBackground int            `toml:"max-background"`
+		ClientWeights map[string]int `toml:"client-weights"`
+	} `toml:"query-priority"`
+
+	// Events configures where events about changes to the schema and
+	// cluster, such as indexes being created or nodes leaving, are posted.
+	Events struct {
+		Webhooks []string `toml:"webhooks"`
+	} `toml:"events"`
+
+	// QueryCapture configures recording queries to a file, for replay with
+	// "featurebase replay". A sample of queries is recorded, along with
+	// every query slower than the long query time.
+	QueryCapture struct {
+		Path       string  `toml:"path"`
+		SampleRate float64 `toml:"sample-rate"`
+	} `toml:"query-capture"`
+
+	// Canary configures executing a sample of read-only queries a second
+	// time, on another execution path, and logging those whose results
+	// differ.
+	Canary struct {
+		Path       string  `toml:"path"`
+		SampleRate float64 `toml:"sample-rate"`
+	} `toml:"canary"`
+
+	// LazyOpen opens fragments when they're first used rather than all at
+	// startup, so nodes with many fields and views restart quickly.
+	LazyOpen bool `toml:"lazy-open"`
+
+	// Checksums saves a checksum of each fragment's data with it, verified
+	// when the fragment is opened, and every scrub-interval if that's set,
+	// so data damaged on disk is reported through /health.
+	Checksums struct {
+		Enabled       bool          `toml:"enabled"`
+		ScrubInterval toml.Duration `toml:"scrub-interval"`
+	} `toml:"checksums"`
+
+	// StaleTransactionAge is how long a transaction can be open before
+	// it's reported through /health as stale, with the stack which opened
+	// it. Zero means transactions are never reported.
+	StaleTransactionAge toml.Duration `toml:"stale-transaction-age"`
+
+	// ReadCoalesceWindow is how long a row read from a fragment is shared
+	// with other queries reading the same data. Zero only shares reads
+	// between queries reading at the same time, and a negative window
+	// disables sharing.
+	ReadCoalesceWindow toml.Duration `toml:"read-coalesce-window"`
+
+	// RewriteLegacyCalls rewrites legacy PQL calls, such as SetBit and
+	// Range, to the calls which replaced them, rather than failing them.
+	RewriteLegacyCalls bool `toml:"rewrite-legacy-calls"`
+
+	// Disk limits the disk used by the node's data, and sets how much of
+	// the disk must be left free, below which the node is read-only until
+	// space is freed. Zero means no limit.
+	Disk struct {
+		MaxStorage     int64         `toml:"max-storage-bytes"`
+		MinFree        uint64        `toml:"min-free-bytes"`
+		MinFreePercent float64       `toml:"min-free-percent"`
+		CheckInterval  toml.Duration `toml:"check-interval"`
+	} `toml:"disk"`
+
+	// Namespaces is a JSON file of the quotas of namespaces, by name, as
+	// in {"acme": {"maxShards": 100}}.
+	Namespaces string `toml:"namespaces"`
+
+	// QueryRules is a JSON file of rules which allow or deny calls in
+	// queries, checked in order, as in [{"calls": ["Store"]}].
+	QueryRules string `toml:"query-rules"`
+
+	// PluginsDir is the directory user-defined functions, as WebAssembly
+	// modules, are loaded from.
+	PluginsDir string `toml:"plugins-dir"`
+
+	// OptionTemplates is a JSON file of the options given to indexes and
+	// fields created through the server by default, and of templates of
+	// options their requests can name.
+	OptionTemplates string `toml:"option-templates"`
+
 	Cluster struct {
 		ReplicaN int    `toml:"replicas"`
 		Name     string `toml:"name"`
@@ -143,9 +266,22 @@ type Config struct {
 		MapSize int `toml:"map-size"`
 		// DEPRECATED: Translation config supports translation store replication.
 		PrimaryURL string `toml:"primary-url"`
+
+		// Backend is where key translation stores are kept: "boltdb", in
+		// files in the data directory, or "etcd", in an etcd cluster
+		// shared by every node.
+		Backend string `toml:"backend"`
+		// EtcdHosts is the comma-separated etcd endpoints of the etcd
+		// backend. It defaults to etcd.etcd-hosts, or else the embedded
+		// etcd's listen client URL.
+		EtcdHosts string `toml:"etcd-hosts"`
+		// EtcdPrefix is the prefix of the etcd backend's etcd keys.
+		EtcdPrefix string `toml:"etcd-prefix"`
 	} `toml:"translation"`
 
 	AntiEntropy struct {
+		// Interval is how often replicas are synced. Reads are only
+		// routed from degraded nodes to replicas when it's set.
 		Interval toml.Duration `toml:"interval"`
 	} `toml:"anti-entropy"`
 
@@ -312,6 +448,14 @@ func (c *Config) validate() error {
 const (
 	PartitionToNodeJmp     string = "jmp-hash"
 	PartitionToNodeModulus string = "modulus"
+	// PartitionToNodeRing assigns partitions with a consistent hash ring,
+	// so adding or removing a node moves only about 1/N of them.
+	PartitionToNodeRing string = "ring-hash"
+)
+
+const (
+	TranslationBackendBoltDB string = "boltdb"
+	TranslationBackendEtcd   string = "etcd"
 )
 
 // NewConfig returns an instance of Config with default options.
@@ -342,6 +486,14 @@ func NewConfig() *Config {
 		QueryHistoryLength: 100,
 
 		LongQueryTime: toml.Duration(-time.Minute),
+
+		StaleTransactionAge: toml.Duration(10 * time.Minute),
+
+		ReadCoalesceWindow: toml.Duration(10 * time.Millisecond),
+
+		SpillThreshold: 256 << 20,
+
+		RewriteLegacyCalls: true,
 	}
 
 	// Cluster config.
@@ -367,6 +519,9 @@ func NewConfig() *Config {
 
 	c.SQL.EndpointEnabled = false
 
+	c.Translation.Backend = TranslationBackendBoltDB
+	c.Translation.EtcdPrefix = etcdkeys.DefaultPrefix
+
 	c.Etcd.AClientURL = ""
 	c.Etcd.LClientURL = "http://localhost:10301"
 	c.Etcd.APeerURL = ""
@@ -23,6 +23,7 @@ import (
 	vdsm_pb "github.com/featurebasedb/featurebase/v3/proto/vdsm"
 	"github.com/featurebasedb/featurebase/v3/sql"
 	"github.com/featurebasedb/featurebase/v3/stats"
+	"github.com/golang/protobuf/proto"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -97,7 +98,8 @@ func errToStatusError(err error) error {
 		pilosa.ErrQueryRequired,
 		pilosa.ErrFieldsArgumentRequired,
 		pilosa.ErrIntFieldWithKeys,
-		pilosa.ErrDecimalFieldWithKeys:
+		pilosa.ErrDecimalFieldWithKeys,
+		pilosa.ErrIncompatibleProtocol:
 		return status.Error(codes.FailedPrecondition, err.Error())
 
 	case pilosa.ErrInvalidView,
@@ -110,9 +112,21 @@ func errToStatusError(err error) error {
 		pilosa.ErrBSIGroupValueTooLow,
 		pilosa.ErrBSIGroupValueTooHigh,
 		pilosa.ErrInvalidRangeOperation,
-		pilosa.ErrInvalidBetweenValue:
+		pilosa.ErrInvalidBetweenValue,
+		pilosa.ErrSumOverflow:
 		return status.Error(codes.OutOfRange, err.Error())
 
+	case pilosa.ErrStorageQuotaExceeded,
+		pilosa.ErrReadOnly:
+		return status.Error(codes.ResourceExhausted, err.Error())
+
+	case pilosa.ErrIndexReadOnly,
+		pilosa.ErrMaintenance:
+		return status.Error(codes.Unavailable, err.Error())
+
+	case pilosa.ErrQueryDenied:
+		return status.Error(codes.PermissionDenied, err.Error())
+
 	case pilosa.ErrQueryTimeout:
 		return status.Error(codes.DeadlineExceeded, err.Error())
 
@@ -181,7 +195,7 @@ func (h *GRPCHandler) QuerySQL(req *pb.QuerySQLRequest, stream pb.Pilosa_QuerySQ
 			perm = authz.Admin
 		}
 
-		allowed := h.perms.GetAuthorizedIndexList(uinfo.Groups, perm)
+		allowed := h.api.AuthorizedIndexes(h.perms, uinfo.Groups, perm)
 		if !h.perms.IsAdmin(uinfo.Groups) {
 			if !isAllowed(parsed.Tables, allowed) {
 				return status.Error(codes.PermissionDenied, "insufficient permissions to access requested tables")
@@ -245,7 +259,7 @@ func (h *GRPCHandler) QuerySQLUnary(ctx context.Context, req *pb.QuerySQLRequest
 			perm = authz.Admin
 		}
 
-		allowed := h.perms.GetAuthorizedIndexList(uinfo.(*authn.UserInfo).Groups, perm)
+		allowed := h.api.AuthorizedIndexes(h.perms, uinfo.(*authn.UserInfo).Groups, perm)
 		if !h.perms.IsAdmin(uinfo.(*authn.UserInfo).Groups) {
 			if !isAllowed(parsed.Tables, allowed) {
 				return nil, status.Error(codes.PermissionDenied, "insufficient permissions to access requested tables")
@@ -300,7 +314,7 @@ func (h *GRPCHandler) QueryPQL(req *pb.QueryPQLRequest, stream pb.Pilosa_QueryPQ
 			lperm = authz.Write
 		}
 		if !h.perms.IsAdmin(uinfo.(*authn.UserInfo).Groups) {
-			if !isAllowed([]string{req.Index}, h.perms.GetAuthorizedIndexList(uinfo.(*authn.UserInfo).Groups, lperm)) {
+			if !isAllowed([]string{req.Index}, h.api.AuthorizedIndexes(h.perms, uinfo.(*authn.UserInfo).Groups, lperm)) {
 				return status.Error(codes.PermissionDenied, "insufficient permissions to access requested indexes")
 			}
 		}
@@ -325,7 +339,7 @@ func (h *GRPCHandler) QueryPQL(req *pb.QueryPQLRequest, stream pb.Pilosa_QueryPQ
 		h.logger.Infof("GRPC QueryPQL %v %s", durQuery, query.Query)
 	}
 
-	rslt := resp.Results[0]
+	rslt := h.describeColumns(ctx, req.Index, resp.Results[0])
 	toRowser, err := ToRowserWrapper(rslt)
 	if err != nil {
 		return errors.Wrap(err, "wrapping as type ToRowser")
@@ -369,7 +383,7 @@ func (h *GRPCHandler) QueryPQLUnary(ctx context.Context, req *pb.QueryPQLRequest
 			lperm = authz.Write
 		}
 		if !h.perms.IsAdmin(uinfo.(*authn.UserInfo).Groups) {
-			if !isAllowed([]string{req.Index}, h.perms.GetAuthorizedIndexList(uinfo.(*authn.UserInfo).Groups, lperm)) {
+			if !isAllowed([]string{req.Index}, h.api.AuthorizedIndexes(h.perms, uinfo.(*authn.UserInfo).Groups, lperm)) {
 				return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("insufficient permissions for %v", req.Index))
 			}
 		}
@@ -388,7 +402,7 @@ func (h *GRPCHandler) QueryPQLUnary(ctx context.Context, req *pb.QueryPQLRequest
 		h.logger.Infof("GRPC QueryPQLUnary %v %s", durQuery, query.Query)
 	}
 
-	rslt := resp.Results[0]
+	rslt := h.describeColumns(ctx, req.Index, resp.Results[0])
 	toTabler, err := ToTablerWrapper(rslt)
 	if err != nil {
 		return nil, errors.Wrap(err, "wrapping as type ToTabler")
@@ -445,7 +459,7 @@ func (h *GRPCHandler) GetIndex(ctx context.Context, req *pb.GetIndexRequest) (*p
 		if !ok {
 			return nil, status.Error(codes.InvalidArgument, "malformed auth header")
 		}
-		p, err := h.perms.GetPermissions(pp, req.Name)
+		p, err := h.api.IndexPermission(h.perms, pp, req.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -488,7 +502,7 @@ func (h *GRPCHandler) GetIndexes(ctx context.Context, req *pb.GetIndexesRequest)
 	indexes := make([]*pb.Index, 0)
 	for _, index := range schema {
 		if userInfo != nil {
-			if p, err := h.perms.GetPermissions(userInfo, index.Name); err == nil && p.Satisfies(authz.Read) {
+			if p, err := h.api.IndexPermission(h.perms, userInfo, index.Name); err == nil && p.Satisfies(authz.Read) {
 				indexes = append(indexes, &pb.Index{Name: index.Name})
 			}
 		} else {
@@ -708,6 +722,45 @@ func ToRowserWrapper(result interface{}) (pb.ToRowser, error) {
 	return toRowser, nil
 }
 
+// describeColumns wraps an Extract result of a query of the index so that
+// the headers of its tables describe its columns.
+func (h *GRPCHandler) describeColumns(ctx context.Context, indexName string, result interface{}) interface{} {
+	table, ok := result.(pilosa.ExtractedTable)
+	if !ok {
+		return result
+	}
+	index, err := h.api.Index(ctx, indexName)
+	if err != nil {
+		return result
+	}
+	return describedTable{ExtractedTable: table, index: index}
+}
+
+// describedTable is an Extract result whose headers describe its columns,
+// with the metadata of the fields of its index.
+type describedTable struct {
+	pilosa.ExtractedTable
+	index *pilosa.Index
+}
+
+// ToRows implements the ToRowser interface.
+func (t describedTable) ToRows(callback func(*pb.RowResponse) error) error {
+	// Every row has the same headers.
+	described := false
+	return t.ExtractedTable.ToRows(func(rr *pb.RowResponse) error {
+		if !described {
+			t.index.AnnotateColumns(rr.Headers)
+			described = true
+		}
+		return callback(rr)
+	})
+}
+
+// ToTable implements the ToTabler interface.
+func (t describedTable) ToTable() (*pb.TableResponse, error) {
+	return pb.RowsToTable(t, len(t.Columns))
+}
+
 // durationRowser is a wrapper for pb.ToRowser that can be used to inject a
 // duration value into the first record in a stream
 type durationRowser struct {
@@ -1647,6 +1700,10 @@ func NewGRPCServer(opts ...grpcServerOption) (*grpcServer, error) {
 		})
 	}
 
+	if server.api != nil {
+		streamInterceptors = append(streamInterceptors, server.sessionStreamInterceptor)
+	}
+
 	if monitor.IsOn() {
 		unaryInterceptors = append(unaryInterceptors, monitorUnaryInterceptor)
 	}
@@ -1714,6 +1771,60 @@ func (w *wrappedStream) SendMsg(m interface{}) error {
 	return w.ServerStream.SendMsg(m)
 }
 
+// sessionStreamInterceptor tracks each stream as a client session, which
+// is closed by canceling the stream's context.
+func (s *grpcServer) sessionStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+	var remote string
+	if p, ok := peer.FromContext(ctx); ok {
+		remote = p.Addr.String()
+	}
+	cs := s.api.StartClientSession(pilosa.ClientSessionGRPCStream, remote, info.FullMethod, cancel)
+	defer cs.End()
+	return handler(srv, &sessionStream{ServerStream: ss, ctx: pilosa.WithClientSession(ctx, cs), session: cs})
+}
+
+// sessionStream records what's sent and received in a stream in its client
+// session, and stops sending once the session is closed.
+type sessionStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	session *pilosa.ClientSession
+}
+
+func (w *sessionStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *sessionStream) RecvMsg(m interface{}) error {
+	if err := w.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	switch r := m.(type) {
+	case *pb.QueryPQLRequest:
+		w.session.SetQuery(r.Index, r.Pql)
+	case *pb.QuerySQLRequest:
+		w.session.SetQuery("", r.Sql)
+	default:
+		w.session.Touch()
+	}
+	return nil
+}
+
+func (w *sessionStream) SendMsg(m interface{}) error {
+	if err := w.ctx.Err(); err != nil {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if err := w.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		w.session.Sent(proto.Size(msg))
+	}
+	return nil
+}
+
 func Valid(ctx context.Context, auth *authn.Auth) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
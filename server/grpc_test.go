@@ -477,6 +477,58 @@ func TestQueryPQL(t *testing.T) {
 	}
 }
 
+func TestQueryPQLUnary_ColumnInfo(t *testing.T) {
+	m := test.RunCommand(t)
+	defer m.Close()
+
+	i := m.MustCreateIndex(t, "i", pilosa.IndexOptions{TrackExistence: true})
+	m.MustCreateField(t, i.Name(), "s")
+	m.MustCreateField(t, i.Name(), "d", pilosa.OptFieldTypeDecimal(2))
+	m.MustCreateField(t, i.Name(), "ts", pilosa.OptFieldTypeTimestamp(pilosa.DefaultEpoch, pilosa.TimeUnitMilliseconds))
+	gh := server.NewGRPCHandler(m.API)
+
+	stream := &MockServerTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+	for _, q := range []string{`Set(1, s=2)`, `Set(1, d=1.25)`, `Set(1, ts="2022-01-02T03:04:05Z")`} {
+		if _, err := gh.QueryPQLUnary(ctx, &pb.QueryPQLRequest{Index: i.Name(), Pql: q}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := gh.QueryPQLUnary(ctx, &pb.QueryPQLRequest{
+		Index: i.Name(),
+		Pql:   `Extract(All(), Rows(s), Rows(d), Rows(ts), CardinalityOf(field=s))`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	epoch := pilosa.DefaultEpoch.UTC().Format(time.RFC3339Nano)
+	exp := []*pb.ColumnInfo{
+		{Name: "_id", Datatype: "uint64", Index: "i"},
+		{Name: "s", Datatype: "[]uint64", Nullable: true, Index: "i", Field: "s"},
+		{Name: "d", Datatype: "decimal", Nullable: true, Scale: 2, Index: "i", Field: "d"},
+		{Name: "ts", Datatype: "timestamp", Nullable: true, Epoch: epoch, TimeUnit: pilosa.TimeUnitMilliseconds, Index: "i", Field: "ts"},
+		{Name: "CardinalityOf(s)", Datatype: "int64", Index: "i", Field: "s"},
+	}
+	if !reflect.DeepEqual(resp.Headers, exp) {
+		t.Fatalf("expected headers:\n%v\ngot:\n%v", exp, resp.Headers)
+	}
+
+	// Streamed rows have the same headers.
+	mock := &mockPilosa_QuerySQLServer{ctx: context.Background()}
+	if err := gh.QueryPQL(&pb.QueryPQLRequest{
+		Index: i.Name(),
+		Pql:   `Extract(All(), Rows(s), Rows(d), Rows(ts), CardinalityOf(field=s))`,
+	}, mock); err != nil {
+		t.Fatal(err)
+	} else if len(mock.Results) != 1 {
+		t.Fatalf("expected one result, got %d", len(mock.Results))
+	} else if !reflect.DeepEqual(mock.Results[0].Headers, exp) {
+		t.Fatalf("expected headers:\n%v\ngot:\n%v", exp, mock.Results[0].Headers)
+	}
+}
+
 type (
 	tableResponse struct {
 		headers []columnInfo
@@ -582,7 +582,7 @@ func TestHandler_Endpoints(t *testing.T) {
 		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query?shards=a,b", strings.NewReader("Count(Row(f0=30))")))
 		if w.Code != http.StatusBadRequest {
 			t.Fatalf("unexpected status code: %d", w.Code)
-		} else if body := w.Body.String(); body != `{"error":"invalid shard argument"}`+"\n" {
+		} else if body := w.Body.String(); body != `{"error":"invalid shard argument","code":"BAD_REQUEST","retryable":false}`+"\n" {
 			t.Fatalf("unexpected body: %q", body)
 		}
 	})
@@ -675,7 +675,7 @@ func TestHandler_Endpoints(t *testing.T) {
 		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader(`Row(row=30)`)))
 		if w.Code != http.StatusBadRequest {
 			t.Fatalf("unexpected status code: %d", w.Code)
-		} else if body := w.Body.String(); body != `{"error":"executing: translating call: validating value for field \"row\": field not found"}`+"\n" {
+		} else if body := w.Body.String(); body != `{"error":"executing: translating call: validating value for field \"row\": field not found","code":"NOT_FOUND","retryable":false}`+"\n" {
 			t.Fatalf("unexpected body: %q", body)
 		}
 	})
@@ -929,7 +929,7 @@ func TestHandler_Endpoints(t *testing.T) {
 		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/idx0/query?shards=0,1", strings.NewReader("bad_fn(")))
 		if w.Code != http.StatusBadRequest {
 			t.Fatalf("unexpected status code: %d", w.Code)
-		} else if body := w.Body.String(); body != `{"error":"parsing: parsing: \nparse error near IDENT (line 1 symbol 1 - line 1 symbol 4):\n\"bad\"\n"}`+"\n" {
+		} else if body := w.Body.String(); body != `{"error":"parsing: parsing: \nparse error near IDENT (line 1 symbol 1 - line 1 symbol 4):\n\"bad\"\n","code":"BAD_REQUEST","retryable":false}`+"\n" {
 			t.Fatalf("unexpected body: %s", body)
 		}
 	})
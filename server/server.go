@@ -34,6 +34,7 @@ import (
 	"github.com/featurebasedb/featurebase/v3/boltdb"
 	"github.com/featurebasedb/featurebase/v3/encoding/proto"
 	petcd "github.com/featurebasedb/featurebase/v3/etcd"
+	"github.com/featurebasedb/featurebase/v3/etcdkeys"
 	"github.com/featurebasedb/featurebase/v3/gcnotify"
 	"github.com/featurebasedb/featurebase/v3/gopsutil"
 	"github.com/featurebasedb/featurebase/v3/logger"
@@ -86,6 +87,10 @@ type Command struct {
 
 	serverOptions []pilosa.ServerOption
 	auth          *authn.Auth
+
+	// translateClient is the client of the etcd translation store
+	// backend, if it's used.
+	translateClient io.Closer
 }
 
 type CommandOption func(c *Command) error
@@ -439,6 +444,32 @@ func (m *Command) SetupServer() error {
 	m.Config.Etcd.Id = m.Config.Name // TODO(twg) rethink this
 	e := petcd.NewEtcd(m.Config.Etcd, m.logger, m.Config.Cluster.ReplicaN, version)
 
+	openTranslateStore, err := m.openTranslateStoreFunc()
+	if err != nil {
+		return errors.Wrap(err, "setting up translation stores")
+	}
+
+	var namespaceQuotas map[string]pilosa.NamespaceQuota
+	if m.Config.Namespaces != "" {
+		if namespaceQuotas, err = pilosa.LoadNamespaceQuotas(m.Config.Namespaces); err != nil {
+			return errors.Wrap(err, "loading namespace quotas")
+		}
+	}
+
+	var queryRules []pilosa.QueryRule
+	if m.Config.QueryRules != "" {
+		if queryRules, err = pilosa.LoadQueryRules(m.Config.QueryRules); err != nil {
+			return errors.Wrap(err, "loading query rules")
+		}
+	}
+
+	var optionTemplates *pilosa.OptionTemplates
+	if m.Config.OptionTemplates != "" {
+		if optionTemplates, err = pilosa.LoadOptionTemplates(m.Config.OptionTemplates); err != nil {
+			return errors.Wrap(err, "loading option templates")
+		}
+	}
+
 	executionPlannerFn := func(e pilosa.Executor, a *pilosa.API, s string) sql3.CompilePlanner {
 		fapi := &pilosa.FeatureBaseSchemaAPI{API: a}
 		return planner.NewExecutionPlanner(e, fapi, a, s)
@@ -453,7 +484,7 @@ func (m *Command) SetupServer() error {
 		pilosa.OptServerMetricInterval(time.Duration(m.Config.Metric.PollInterval)),
 		pilosa.OptServerDiagnosticsInterval(diagnosticsInterval),
 		pilosa.OptServerExecutorPoolSize(m.Config.WorkerPoolSize),
-		pilosa.OptServerOpenTranslateStore(boltdb.OpenTranslateStore),
+		pilosa.OptServerOpenTranslateStore(openTranslateStore),
 		pilosa.OptServerOpenTranslateReader(pilosa.GetOpenTranslateReaderWithLockerFunc(c, &sync.Mutex{})),
 		pilosa.OptServerOpenIDAllocator(pilosa.OpenIDAllocator),
 		pilosa.OptServerLogger(m.logger),
@@ -468,6 +499,29 @@ func (m *Command) SetupServer() error {
 		pilosa.OptServerStorageConfig(m.Config.Storage),
 		pilosa.OptServerRBFConfig(m.Config.RBFConfig),
 		pilosa.OptServerMaxQueryMemory(m.Config.MaxQueryMemory),
+		pilosa.OptServerTempDir(m.Config.TempDir, m.Config.TempDirQuota),
+		pilosa.OptServerSpillThreshold(m.Config.SpillThreshold),
+		pilosa.OptServerExistenceFallback(m.Config.ExistenceFallback),
+		pilosa.OptServerQueryAdmission(m.Config.QueryPriority.MaxBatch, m.Config.QueryPriority.MaxBackground),
+		pilosa.OptServerClientWeights(m.Config.QueryPriority.ClientWeights),
+		pilosa.OptServerEventWebhooks(m.Config.Events.Webhooks),
+		pilosa.OptServerQueryCapture(m.Config.QueryCapture.Path, m.Config.QueryCapture.SampleRate),
+		pilosa.OptServerCanary(m.Config.Canary.Path, m.Config.Canary.SampleRate),
+		pilosa.OptServerPluginsDir(m.Config.PluginsDir),
+		pilosa.OptServerNamespaceQuotas(namespaceQuotas),
+		pilosa.OptServerQueryRules(queryRules),
+		pilosa.OptServerOptionTemplates(optionTemplates),
+		pilosa.OptServerLazyOpen(m.Config.LazyOpen),
+		pilosa.OptServerChecksums(m.Config.Checksums.Enabled, time.Duration(m.Config.Checksums.ScrubInterval)),
+		pilosa.OptServerStaleTxAge(time.Duration(m.Config.StaleTransactionAge)),
+		pilosa.OptServerReadCoalesceWindow(time.Duration(m.Config.ReadCoalesceWindow)),
+		pilosa.OptServerRewriteLegacyCalls(m.Config.RewriteLegacyCalls),
+		pilosa.OptServerDiskLimits(pilosa.DiskLimits{
+			MaxStorage:     m.Config.Disk.MaxStorage,
+			MinFree:        m.Config.Disk.MinFree,
+			MinFreePercent: m.Config.Disk.MinFreePercent,
+			CheckInterval:  time.Duration(m.Config.Disk.CheckInterval),
+		}),
 		pilosa.OptServerQueryHistoryLength(m.Config.QueryHistoryLength),
 		pilosa.OptServerPartitionAssigner(m.Config.Cluster.PartitionToNodeAssignment),
 		pilosa.OptServerDisCo(e, e, e, e),
@@ -555,7 +609,14 @@ func (m *Command) SetupServer() error {
 	}
 
 	m.Handler, err = pilosa.NewHandler(
-		pilosa.OptHandlerAllowedOrigins(m.Config.Handler.AllowedOrigins),
+		pilosa.OptHandlerCORS(pilosa.CORSOptions{
+			AllowedOrigins:   m.Config.Handler.AllowedOrigins,
+			AllowedHeaders:   m.Config.Handler.AllowedHeaders,
+			AllowedMethods:   m.Config.Handler.AllowedMethods,
+			ExposedHeaders:   m.Config.Handler.ExposedHeaders,
+			AllowCredentials: m.Config.Handler.AllowCredentials,
+			MaxAge:           int(time.Duration(m.Config.Handler.CORSMaxAge) / time.Second),
+		}),
 		pilosa.OptHandlerAPI(m.API),
 		pilosa.OptHandlerLogger(m.logger),
 		pilosa.OptHandlerQueryLogger(m.queryLogger),
@@ -655,6 +716,9 @@ func (m *Command) Close() error {
 		eg.Go(m.Handler.Close)
 		eg.Go(m.Server.Close)
 		eg.Go(m.API.Close)
+		if m.translateClient != nil {
+			eg.Go(m.translateClient.Close)
+		}
 		if closer, ok := m.logOutput.(io.Closer); ok {
 			// If closer is os.Stdout or os.Stderr, don't close it.
 			if closer != os.Stdout && closer != os.Stderr {
@@ -670,6 +734,32 @@ func (m *Command) Close() error {
 	}
 }
 
+// openTranslateStoreFunc returns the function opening the translation
+// stores of the configured backend.
+func (m *Command) openTranslateStoreFunc() (pilosa.OpenTranslateStoreFunc, error) {
+	switch m.Config.Translation.Backend {
+	case TranslationBackendBoltDB, "":
+		return boltdb.OpenTranslateStore, nil
+	case TranslationBackendEtcd:
+		hosts := m.Config.Translation.EtcdHosts
+		if hosts == "" {
+			hosts = m.Config.Etcd.EtcdHosts
+		}
+		if hosts == "" {
+			hosts = m.Config.Etcd.LClientURL
+		}
+		cli, err := etcdkeys.NewClient(hosts)
+		if err != nil {
+			return nil, err
+		}
+		m.translateClient = cli
+		m.logger.Infof("keeping translation stores in etcd %s under %q", hosts, m.Config.Translation.EtcdPrefix)
+		return etcdkeys.OpenTranslateStore(cli, m.Config.Translation.EtcdPrefix), nil
+	default:
+		return nil, errors.Errorf("unknown translation backend %q", m.Config.Translation.Backend)
+	}
+}
+
 // newStatsClient creates a stats client from the config
 func newStatsClient(name string, host string, namespace string) (stats.StatsClient, error) {
 	switch name {
@@ -0,0 +1,183 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// settingsFile is the file in the data directory holding the settings
+// changed while the server was running.
+const settingsFile = "settings.json"
+
+// Settings are the server settings which can be changed while it's
+// running. They start out as configured, and changes are applied to every
+// node and kept in each node's data directory, where they override the
+// configuration when the node restarts. Any other setting, such as cache
+// sizes, which are options of each field, still needs a restart to
+// change.
+//
+// A QueryTimeout of zero lets queries run for as long as they take, and a
+// zero limit on writes, query memory, or concurrent queries means there's
+// no limit.
+type Settings struct {
+	QueryTimeout         time.Duration `json:"queryTimeout"`
+	LongQueryTime        time.Duration `json:"longQueryTime"`
+	WorkerPoolSize       int           `json:"workerPoolSize"`
+	MaxWritesPerRequest  int           `json:"maxWritesPerRequest"`
+	MaxQueryMemory       int64         `json:"maxQueryMemory"`
+	MaxBatchQueries      int           `json:"maxBatchQueries"`
+	MaxBackgroundQueries int           `json:"maxBackgroundQueries"`
+}
+
+// SettingsMessage is an internal message changing the settings of every
+// node.
+type SettingsMessage struct {
+	Settings Settings
+}
+
+// validate returns an error if st can't be applied.
+func (st Settings) validate() error {
+	switch {
+	case st.QueryTimeout < 0:
+		return errors.New("queryTimeout can't be negative")
+	case st.WorkerPoolSize < 1:
+		return errors.New("workerPoolSize must be at least 1")
+	case st.MaxWritesPerRequest < 0:
+		return errors.New("maxWritesPerRequest can't be negative")
+	case st.MaxQueryMemory < 0:
+		return errors.New("maxQueryMemory can't be negative")
+	case st.MaxBatchQueries < 0:
+		return errors.New("maxBatchQueries can't be negative")
+	case st.MaxBackgroundQueries < 0:
+		return errors.New("maxBackgroundQueries can't be negative")
+	}
+	return nil
+}
+
+// currentSettings returns the settings the server is running with.
+func (s *Server) currentSettings() Settings {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+	return s.settings
+}
+
+// applySettings makes the server run with st.
+func (s *Server) applySettings(st Settings) {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	if st != s.settings {
+		s.logger.Infof("applying settings: %+v", st)
+	}
+	s.settings = st
+	s.executor.setLimits(st)
+}
+
+// updateSettings makes the server run with st, and keeps them in the data
+// directory for when it restarts.
+func (s *Server) updateSettings(st Settings) error {
+	if err := st.validate(); err != nil {
+		return NewBadRequestError(err)
+	}
+	s.applySettings(st)
+
+	buf, err := json.Marshal(st)
+	if err != nil {
+		return errors.Wrap(err, "marshaling settings")
+	}
+	path := filepath.Join(s.holder.path, settingsFile)
+	if err := os.WriteFile(path+".tmp", buf, 0600); err != nil {
+		return errors.Wrap(err, "writing settings")
+	}
+	return errors.Wrap(os.Rename(path+".tmp", path), "renaming settings")
+}
+
+// loadSettings applies the settings kept in the data directory, if there
+// are any.
+func (s *Server) loadSettings() error {
+	buf, err := os.ReadFile(filepath.Join(s.holder.path, settingsFile))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading settings")
+	}
+	st := s.currentSettings()
+	if err := json.Unmarshal(buf, &st); err != nil {
+		return errors.Wrap(err, "decoding settings")
+	} else if err := st.validate(); err != nil {
+		return errors.Wrap(err, "validating settings")
+	}
+	s.applySettings(st)
+	return nil
+}
+
+// setLimits changes the limits on queries to those of st. Queries which
+// have already been admitted keep their slots in the old admission limits.
+func (e *executor) setLimits(st Settings) {
+	e.limitsMu.Lock()
+	defer e.limitsMu.Unlock()
+	e.MaxWritesPerRequest = st.MaxWritesPerRequest
+	e.maxMemory = st.MaxQueryMemory
+	if st.MaxBatchQueries != e.maxBatchQueries || st.MaxBackgroundQueries != e.maxBackgroundQueries {
+		e.maxBatchQueries, e.maxBackgroundQueries = st.MaxBatchQueries, st.MaxBackgroundQueries
+		e.admission = newQueryAdmission(e.maxBatchQueries, e.maxBackgroundQueries)
+	}
+	if st.WorkerPoolSize != e.workerPoolSize {
+		e.workerPoolSize = st.WorkerPoolSize
+		e.workers.Resize(e.workerPoolSize)
+	}
+}
+
+// maxWritesPerRequest returns the most writes a request may make.
+func (e *executor) maxWritesPerRequest() int {
+	e.limitsMu.RLock()
+	defer e.limitsMu.RUnlock()
+	return e.MaxWritesPerRequest
+}
+
+// maxQueryMemory returns the most memory an Extract may use.
+func (e *executor) maxQueryMemory() int64 {
+	e.limitsMu.RLock()
+	defer e.limitsMu.RUnlock()
+	return e.maxMemory
+}
+
+// queryAdmission returns the limits on concurrent queries.
+func (e *executor) queryAdmission() *queryAdmission {
+	e.limitsMu.RLock()
+	defer e.limitsMu.RUnlock()
+	return e.admission
+}
+
+// Settings returns the settings this node is running with.
+func (api *API) Settings(ctx context.Context) (Settings, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.Settings")
+	defer span.Finish()
+
+	if err := api.validate(apiSettings); err != nil {
+		return Settings{}, errors.Wrap(err, "validating api method")
+	}
+	return api.server.currentSettings(), nil
+}
+
+// UpdateSettings changes the settings of every node to st.
+func (api *API) UpdateSettings(ctx context.Context, st Settings) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.UpdateSettings")
+	defer span.Finish()
+
+	if err := api.validate(apiSettings); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	if err := api.server.updateSettings(st); err != nil {
+		return err
+	}
+	err := api.server.SendSync(&SettingsMessage{Settings: st})
+	return errors.Wrap(err, "sending Settings message")
+}
@@ -0,0 +1,363 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"math/bits"
+	"sync"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+// shardStats holds lightweight per-shard metadata about the fields of an
+// index, which the executor uses to skip shards that can't match a
+// predicate without reading their fragments. BSI fields record the range
+// of values present in the shard, and set-like fields record a bloom
+// filter of the rows present in the shard, and, when there aren't too
+// many, the number of columns in each. The planner also uses these to
+// estimate the sizes of the inputs of a query.
+//
+// Metadata is computed lazily, the first time a shard is queried, and is
+// discarded whenever a write to the shard commits. Each shard has a
+// generation which is incremented by writes; metadata computed during a
+// write is only kept if the generation didn't change in the meantime.
+type shardStats struct {
+	mu     sync.Mutex
+	gens   map[uint64]uint64
+	fields map[shardStatsKey]*fieldShardStats
+}
+
+type shardStatsKey struct {
+	field *Field
+	shard uint64
+}
+
+// fieldShardStats is the metadata for a single field in a single shard.
+type fieldShardStats struct {
+	gen uint64
+
+	// For BSI fields, the number of columns with values, and the range
+	// of those values relative to the field's base.
+	count    uint64
+	min, max int64
+
+	// For set-like fields, the rows present in the standard view, and
+	// the number of columns in each if there are at most
+	// maxShardStatsRowCounts rows.
+	rows      *bloomFilter
+	rowCounts map[uint64]uint64
+}
+
+// maxShardStatsRowCounts is the most rows of a field in a shard for which
+// the number of columns in each row is kept.
+const maxShardStatsRowCounts = 1024
+
+func newShardStats() *shardStats {
+	return &shardStats{
+		gens:   make(map[uint64]uint64),
+		fields: make(map[shardStatsKey]*fieldShardStats),
+	}
+}
+
+// invalidate discards metadata for shard, after a write to it.
+func (s *shardStats) invalidate(shard uint64) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gens[shard]++
+}
+
+// deleteField discards metadata for a field which has been deleted.
+func (s *shardStats) deleteField(f *Field) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.fields {
+		if key.field == f {
+			delete(s.fields, key)
+		}
+	}
+}
+
+// get returns the current metadata for a field in a shard, if any, and
+// the shard's generation.
+func (s *shardStats) get(f *Field, shard uint64) (*fieldShardStats, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	gen := s.gens[shard]
+	if fs := s.fields[shardStatsKey{field: f, shard: shard}]; fs != nil && fs.gen == gen {
+		return fs, gen
+	}
+	return nil, gen
+}
+
+// put stores metadata computed as of generation fs.gen, unless the shard
+// has been written to since.
+func (s *shardStats) put(f *Field, shard uint64, fs *fieldShardStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.gens[shard] == fs.gen {
+		s.fields[shardStatsKey{field: f, shard: shard}] = fs
+	}
+}
+
+// fieldShardStats returns the metadata for f in shard, computing it if
+// necessary. It returns nil if the field doesn't support pruning.
+func (i *Index) fieldShardStats(ctx context.Context, f *Field, shard uint64) (*fieldShardStats, error) {
+	if i.shardStats == nil {
+		return nil, nil
+	}
+	fs, gen := i.shardStats.get(f, shard)
+	if fs != nil {
+		return fs, nil
+	}
+
+	// Use a transaction of our own, begun after reading the generation,
+	// so that a write committing meanwhile is noticed.
+	tx := i.holder.txf.NewTx(Txo{Index: i, Shard: shard})
+	defer tx.Rollback()
+
+	fs = &fieldShardStats{gen: gen}
+	switch f.Type() {
+	case FieldTypeInt, FieldTypeDecimal, FieldTypeTimestamp:
+		bsig := f.bsiGroup(f.Name())
+		if bsig == nil {
+			return nil, nil
+		}
+		if frag := i.holder.fragment(i.name, f.Name(), viewBSIGroupPrefix+f.Name(), shard); frag != nil {
+			var err error
+			if fs.min, _, err = frag.min(tx, nil, bsig.BitDepth); err != nil {
+				return nil, err
+			}
+			if fs.max, _, err = frag.max(tx, nil, bsig.BitDepth); err != nil {
+				return nil, err
+			}
+			exists, err := frag.notNull(tx)
+			if err != nil {
+				return nil, err
+			}
+			fs.count = exists.Count()
+		}
+	case FieldTypeSet, FieldTypeMutex, FieldTypeBool, FieldTypeTime:
+		if f.options.NoStandardView {
+			return nil, nil
+		}
+		var rows []uint64
+		if frag := i.holder.fragment(i.name, f.Name(), viewStandard, shard); frag != nil {
+			var err error
+			if rows, err = frag.rows(ctx, tx, 0); err != nil {
+				return nil, err
+			}
+		}
+		fs.rows = newBloomFilter(len(rows))
+		for _, row := range rows {
+			fs.rows.add(row)
+		}
+		if len(rows) <= maxShardStatsRowCounts {
+			fs.rowCounts = make(map[uint64]uint64, len(rows))
+			for _, row := range rows {
+				n, err := tx.CountRange(i.name, f.Name(), viewStandard, shard, row*ShardWidth, (row+1)*ShardWidth)
+				if err != nil {
+					return nil, err
+				}
+				fs.rowCounts[row] = n
+			}
+		}
+	default:
+		return nil, nil
+	}
+	i.shardStats.put(f, shard, fs)
+	return fs, nil
+}
+
+// shardMayMatch reports whether the bitmap call c could return any columns
+// in shard. It errs on the side of true: calls it doesn't understand, and
+// arguments it can't interpret, are assumed to match so that the executor
+// handles them as usual.
+func (e *executor) shardMayMatch(ctx context.Context, idx *Index, c *pql.Call, shard uint64) (bool, error) {
+	switch c.Name {
+	case "Row":
+		return e.rowMayMatch(ctx, idx, c, shard)
+	case "Intersect":
+		for _, child := range c.Children {
+			if ok, err := e.shardMayMatch(ctx, idx, child, shard); err != nil || !ok {
+				return ok, err
+			}
+		}
+		return true, nil
+	case "Union":
+		for _, child := range c.Children {
+			if ok, err := e.shardMayMatch(ctx, idx, child, shard); err != nil || ok {
+				return ok, err
+			}
+		}
+		return len(c.Children) == 0, nil
+	case "Difference":
+		if len(c.Children) == 0 {
+			return true, nil
+		}
+		return e.shardMayMatch(ctx, idx, c.Children[0], shard)
+	}
+	return true, nil
+}
+
+// rowMayMatch reports whether a Row call could return any columns in shard.
+func (e *executor) rowMayMatch(ctx context.Context, idx *Index, c *pql.Call, shard uint64) (bool, error) {
+	if c.HasConditionArg() {
+		if len(c.Args) != 1 {
+			return true, nil
+		}
+		for fieldName, v := range c.Args {
+			cond, ok := v.(*pql.Condition)
+			if !ok {
+				return true, nil
+			}
+			f := idx.Field(fieldName)
+			if f == nil {
+				return true, nil
+			}
+			fs, err := idx.fieldShardStats(ctx, f, shard)
+			if err != nil || fs == nil || fs.rows != nil {
+				return true, err
+			}
+			return bsiMayMatch(f, cond, fs), nil
+		}
+	}
+
+	// Only plain row lookups in the standard view are considered.
+	if _, ok := c.Args["from"]; ok {
+		return true, nil
+	} else if _, ok := c.Args["to"]; ok {
+		return true, nil
+	}
+	fieldName, err := c.FieldArg()
+	if err != nil {
+		return true, nil
+	}
+	f := idx.Field(fieldName)
+	if f == nil {
+		return true, nil
+	}
+	rowID, ok, err := c.UintArg(fieldName)
+	if err != nil || !ok {
+		return true, nil
+	}
+	fs, err := idx.fieldShardStats(ctx, f, shard)
+	if err != nil || fs == nil || fs.rows == nil {
+		return true, err
+	}
+	return fs.rows.mayContain(rowID), nil
+}
+
+// bsiMayMatch reports whether a condition on a BSI field could match any
+// of the values described by fs.
+func bsiMayMatch(f *Field, cond *pql.Condition, fs *fieldShardStats) bool {
+	bsig := f.bsiGroup(f.Name())
+	if bsig == nil {
+		return true
+	}
+	if cond.Value == nil {
+		// "== null" depends on existence, rather than on the field.
+		return cond.Op != pql.NEQ || fs.count > 0
+	}
+	if fs.count == 0 {
+		return false
+	}
+	min, max := fs.min+bsig.Base, fs.max+bsig.Base
+
+	switch cond.Op {
+	case pql.BETWEEN, pql.BTWN_LT_LT, pql.BTWN_LTE_LT, pql.BTWN_LT_LTE:
+		predicates, err := getCondIntSlice(f, cond)
+		if err != nil || len(predicates) != 2 {
+			return true
+		}
+		return predicates[0] <= max && predicates[1] >= min
+	case pql.IN:
+		values, ok := cond.Value.([]interface{})
+		if !ok {
+			return true
+		}
+		for _, v := range values {
+			value, err := getScaledInt(f, v)
+			if err != nil || (value >= min && value <= max) {
+				return true
+			}
+		}
+		return false
+	}
+
+	value, err := getScaledInt(f, cond.Value)
+	if err != nil {
+		return true
+	}
+	switch cond.Op {
+	case pql.EQ:
+		return value >= min && value <= max
+	case pql.NEQ:
+		return min != max || min != value
+	case pql.LT:
+		return min < value
+	case pql.LTE:
+		return min <= value
+	case pql.GT:
+		return max > value
+	case pql.GTE:
+		return max >= value
+	}
+	return true
+}
+
+// bloomFilter is a fixed-size bloom filter of uint64 values, using about
+// ten bits per value for a false positive rate of around 1%.
+type bloomFilter struct {
+	bits []uint64
+}
+
+// bloomHashes is the number of hash functions used by bloomFilter.
+const bloomHashes = 7
+
+func newBloomFilter(n int) *bloomFilter {
+	words := (n*10 + 63) / 64
+	if words == 0 {
+		words = 1
+	}
+	return &bloomFilter{bits: make([]uint64, words)}
+}
+
+// hashes returns two independent hashes of v, from which the filter's
+// bit positions are derived.
+func (b *bloomFilter) hashes(v uint64) (uint64, uint64) {
+	// splitmix64 finalizer.
+	v += 0x9e3779b97f4a7c15
+	v = (v ^ (v >> 30)) * 0xbf58476d1ce4e5b9
+	v = (v ^ (v >> 27)) * 0x94d049bb133111eb
+	v ^= v >> 31
+	return v, bits.RotateLeft64(v, 32) | 1
+}
+
+func (b *bloomFilter) add(v uint64) {
+	n := uint64(len(b.bits)) * 64
+	h1, h2 := b.hashes(v)
+	for i := uint64(0); i < bloomHashes; i++ {
+		pos := (h1 + i*h2) % n
+		b.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// mayContain reports whether v may have been added to the filter.
+func (b *bloomFilter) mayContain(v uint64) bool {
+	n := uint64(len(b.bits)) * 64
+	h1, h2 := b.hashes(v)
+	for i := uint64(0); i < bloomHashes; i++ {
+		pos := (h1 + i*h2) % n
+		if b.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+func TestBloomFilter(t *testing.T) {
+	b := newBloomFilter(1000)
+	for i := uint64(0); i < 1000; i++ {
+		b.add(i * 7)
+	}
+	for i := uint64(0); i < 1000; i++ {
+		if !b.mayContain(i * 7) {
+			t.Fatalf("expected filter to contain %d", i*7)
+		}
+	}
+	var falsePositives int
+	for i := uint64(0); i < 10000; i++ {
+		if b.mayContain(i*7 + 1) {
+			falsePositives++
+		}
+	}
+	if falsePositives > 500 {
+		t.Fatalf("too many false positives: %d/10000", falsePositives)
+	}
+
+	if newBloomFilter(0).mayContain(1) {
+		t.Fatal("expected empty filter to contain nothing")
+	}
+}
+
+func TestExecutor_ShardMayMatch(t *testing.T) {
+	holder := newTestHolder(t)
+	idx, err := holder.CreateIndex("i", IndexOptions{TrackExistence: true})
+	if err != nil {
+		t.Fatalf("creating index: %v", err)
+	}
+	f, err := idx.CreateField("f")
+	if err != nil {
+		t.Fatalf("creating field: %v", err)
+	}
+	v, err := idx.CreateField("v", OptFieldTypeInt(-100, 1000))
+	if err != nil {
+		t.Fatalf("creating field: %v", err)
+	}
+
+	// Shard 0 has rows 1 and 2 and values 10-20; shard 1 has row 3 and
+	// values -5 and 500; shard 2 has nothing.
+	qcx := holder.Txf().NewWritableQcx()
+	for _, bit := range [][2]uint64{{1, 1}, {2, 2}, {3, ShardWidth + 1}} {
+		if _, err := f.SetBit(qcx, bit[0], bit[1], nil); err != nil {
+			t.Fatalf("setting bit: %v", err)
+		}
+	}
+	for col, val := range map[uint64]int64{1: 10, 2: 20, ShardWidth + 1: -5, ShardWidth + 2: 500} {
+		if _, err := v.SetValue(qcx, col, val); err != nil {
+			t.Fatalf("setting value: %v", err)
+		}
+	}
+	if err := qcx.Finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	e := &executor{Holder: holder}
+	check := func(query string, expected ...bool) {
+		t.Helper()
+		q, err := pql.NewParser(strings.NewReader(query)).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for shard, exp := range expected {
+			ok, err := e.shardMayMatch(context.Background(), idx, q.Calls[0], uint64(shard))
+			if err != nil {
+				t.Fatal(err)
+			} else if ok != exp {
+				t.Errorf("%s: shard %d: expected %v, got %v", query, shard, exp, ok)
+			}
+		}
+	}
+
+	check(`Row(f=1)`, true, false, false)
+	check(`Row(f=3)`, false, true, false)
+	check(`Row(v > 100)`, false, true, false)
+	check(`Row(v < 0)`, false, true, false)
+	check(`Row(v == 15)`, true, true, false)
+	check(`Row(v == 600)`, false, false, false)
+	check(`Row(v != 10)`, true, true, false)
+	check(`Row(v != null)`, true, true, false)
+	check(`Row(v == null)`, true, true, true)
+	check(`Row(0 <= v <= 9)`, false, true, false)
+	check(`Row(21 <= v <= 30)`, false, true, false)
+	check(`Row(20 <= v < 600)`, true, true, false)
+	check(`Intersect(Row(f=1), Row(v > 100))`, false, false, false)
+	check(`Union(Row(f=1), Row(v > 100))`, true, true, false)
+	check(`Difference(Row(f=3), Row(f=1))`, false, true, false)
+	check(`All()`, true, true, true)
+
+	// Writes to a shard discard its metadata.
+	qcx = holder.Txf().NewWritableQcx()
+	if _, err := f.SetBit(qcx, 1, 2*ShardWidth, nil); err != nil {
+		t.Fatalf("setting bit: %v", err)
+	}
+	if _, err := v.SetValue(qcx, 3, 200); err != nil {
+		t.Fatalf("setting value: %v", err)
+	}
+	if err := qcx.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	check(`Row(f=1)`, true, false, true)
+	check(`Row(v > 100)`, true, true, false)
+}
@@ -0,0 +1,202 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/featurebasedb/featurebase/v3/disco"
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Options(columnRange=[min, max]) and, on keyed indexes,
+// Options(keyPrefix="...") restrict a query to the shards which could hold
+// the columns it's about, rather than making callers work out shard numbers
+// themselves. A column range covers the shards from the one holding min to
+// the one holding max. A key prefix covers the shards holding a column whose
+// key starts with it; as keys are spread across partitions by a hash of the
+// whole key, finding them means asking the primary of every partition.
+// Either narrows the shards the query would otherwise run on, so it can be
+// combined with shards. Shards are the unit of targeting: columns outside
+// the range, or whose keys don't start with the prefix, are still included
+// if they're in a covered shard.
+
+// targetShards narrows shards to those covered by the columnRange and
+// keyPrefix arguments of an Options call, if it has them.
+func (e *executor) targetShards(ctx context.Context, index string, c *pql.Call, shards []uint64) ([]uint64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "executor.targetShards")
+	defer span.Finish()
+
+	if bounds, ok, err := c.UintSliceArg("columnRange"); err != nil || (ok && (len(bounds) != 2 || bounds[0] > bounds[1])) {
+		return nil, NewBadRequestError(errors.New("Options(): columnRange must be a list of two unsigned integers, [min, max], with min <= max"))
+	} else if ok {
+		min, max := bounds[0]/ShardWidth, bounds[1]/ShardWidth
+		shards = filterShards(shards, func(shard uint64) bool {
+			return shard >= min && shard <= max
+		})
+	}
+
+	prefix, ok, err := c.StringArg("keyPrefix")
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "Options(): reading keyPrefix"))
+	} else if !ok {
+		return shards, nil
+	}
+	if idx := e.Holder.Index(index); idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, index)
+	} else if !idx.Keys() {
+		return nil, NewBadRequestError(errors.Errorf("Options(): keyPrefix requires an index with keys, and %q has none", index))
+	}
+	covered, err := e.Cluster.keyPrefixShards(ctx, index, prefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding shards of key prefix")
+	}
+	in := make(map[uint64]struct{}, len(covered))
+	for _, shard := range covered {
+		in[shard] = struct{}{}
+	}
+	return filterShards(shards, func(shard uint64) bool {
+		_, ok := in[shard]
+		return ok
+	}), nil
+}
+
+// filterShards returns the shards for which keep returns true, in a new
+// slice, so that the caller's shards are left as they were.
+func filterShards(shards []uint64, keep func(uint64) bool) []uint64 {
+	kept := []uint64{}
+	for _, shard := range shards {
+		if keep(shard) {
+			kept = append(kept, shard)
+		}
+	}
+	return kept
+}
+
+// keyPrefixShards returns the shards of an index holding a column whose key
+// starts with prefix, in order. Each partition's keys are matched on its
+// primary node.
+func (c *cluster) keyPrefixShards(ctx context.Context, indexName, prefix string) ([]uint64, error) {
+	idx := c.holder.Index(indexName)
+	if idx == nil {
+		return nil, ErrIndexNotFound
+	}
+
+	// Group partitions by their primary node.
+	snap := c.NewSnapshot()
+	partitionsByNode := make(map[*disco.Node][]int)
+	var local []int
+	for partitionID := 0; partitionID < snap.PartitionN; partitionID++ {
+		primary := snap.PrimaryPartitionNode(partitionID)
+		if primary == nil {
+			return nil, errors.Errorf("matching index(%s) key prefix on partition(%d) - cannot find primary node", indexName, partitionID)
+		}
+		if c.Node.ID == primary.ID {
+			local = append(local, partitionID)
+			continue
+		}
+		partitionsByNode[primary] = append(partitionsByNode[primary], partitionID)
+	}
+
+	remoteResults := make(chan []uint64, len(partitionsByNode))
+	var g errgroup.Group
+	defer g.Wait() //nolint:errcheck
+	for node, partitions := range partitionsByNode {
+		node, partitions := node, partitions
+		g.Go(func() error {
+			shards, err := c.InternalClient.KeyPrefixShardsNode(ctx, &node.URI, indexName, prefix, partitions)
+			if err != nil {
+				return errors.Wrapf(err, "matching index(%s) key prefix on node %s", indexName, node.ID)
+			}
+			remoteResults <- shards
+			return nil
+		})
+	}
+
+	shards, err := idx.keyPrefixShards(prefix, local)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	close(remoteResults)
+
+	seen := make(map[uint64]struct{})
+	for _, shard := range shards {
+		seen[shard] = struct{}{}
+	}
+	for remote := range remoteResults {
+		for _, shard := range remote {
+			seen[shard] = struct{}{}
+		}
+	}
+	all := make([]uint64, 0, len(seen))
+	for shard := range seen {
+		all = append(all, shard)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	return all, nil
+}
+
+// keyPrefixShards returns the shards holding a column whose key, in one of
+// the given partitions of the index's translate stores, starts with prefix.
+func (i *Index) keyPrefixShards(prefix string, partitions []int) ([]uint64, error) {
+	p := []byte(prefix)
+	seen := make(map[uint64]struct{})
+	shards := []uint64{}
+	for _, partitionID := range partitions {
+		store := i.TranslateStore(partitionID)
+		if store == nil {
+			continue
+		}
+		ids, err := store.Match(func(key []byte) bool {
+			return bytes.HasPrefix(key, p)
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "matching keys of index(%s) on partition(%d)", i.Name(), partitionID)
+		}
+		for _, id := range ids {
+			shard := id / ShardWidth
+			if _, ok := seen[shard]; !ok {
+				seen[shard] = struct{}{}
+				shards = append(shards, shard)
+			}
+		}
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+	return shards, nil
+}
+
+// KeyPrefixShardsRequest asks a node which shards hold a column whose key,
+// in one of its partitions, starts with Prefix.
+type KeyPrefixShardsRequest struct {
+	Prefix     string `json:"prefix"`
+	Partitions []int  `json:"partitions"`
+}
+
+// KeyPrefixShards returns the shards holding a column of an index whose key,
+// in one of the given partitions, starts with prefix, as held by this node.
+func (api *API) KeyPrefixShards(ctx context.Context, indexName, prefix string, partitions []int) ([]uint64, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.KeyPrefixShards")
+	defer span.Finish()
+
+	if err := api.validate(apiKeyPrefixShards); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	idx := api.holder.Index(indexName)
+	if idx == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	for _, p := range partitions {
+		if p < 0 || p >= api.cluster.partitionN {
+			return nil, NewBadRequestError(errors.Errorf("invalid partition %d", p))
+		}
+	}
+	return idx.keyPrefixShards(prefix, partitions)
+}
@@ -0,0 +1,352 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// sortKey is one of the fields Sort() orders columns by, in the order
+// given by its "by" argument, e.g. `by="region asc, revenue desc"`.
+type sortKey struct {
+	field string
+	desc  bool
+}
+
+// parseSortKeys returns the keys a Sort() call orders columns by. Columns
+// are ordered by their value in the first field, then by their value in
+// the next field among those with equal values, and so on. Without "by",
+// columns are ordered by the value of "field", ascending unless "sort-desc"
+// is set.
+func parseSortKeys(c *pql.Call) ([]sortKey, error) {
+	by, ok, err := c.StringArg("by")
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "getting by"))
+	} else if !ok {
+		fieldName, err := c.FirstStringArg("field", "_field")
+		if err != nil {
+			return nil, errors.Wrap(err, "getting field")
+		}
+		desc, _, err := c.BoolArg("sort-desc")
+		if err != nil {
+			return nil, errors.Wrap(err, "getting sort-desc")
+		}
+		return []sortKey{{field: fieldName, desc: desc}}, nil
+	}
+
+	for _, arg := range []string{"field", "_field", "sort-desc"} {
+		if _, ok := c.Args[arg]; ok {
+			return nil, NewBadRequestError(errors.Errorf("Sort with by does not support %q", arg))
+		}
+	}
+	var keys []sortKey
+	for _, spec := range strings.Split(by, ",") {
+		spec = strings.TrimSpace(spec)
+		fieldDir := strings.Fields(spec)
+		if len(fieldDir) == 0 || len(fieldDir) > 2 {
+			return nil, NewBadRequestError(errors.Errorf("invalid sorting directive: '%s'", spec))
+		}
+		key := sortKey{field: fieldDir[0]}
+		if len(fieldDir) == 2 {
+			switch fieldDir[1] {
+			case "asc":
+			case "desc":
+				key.desc = true
+			default:
+				return nil, NewBadRequestError(errors.Errorf("unknown sort direction '%s'", fieldDir[1]))
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sortDirections returns whether each of keys is descending.
+func sortDirections(keys []sortKey) []bool {
+	desc := make([]bool, len(keys))
+	for i, k := range keys {
+		desc[i] = k.desc
+	}
+	return desc
+}
+
+// compareSortValues compares two values of the same sort key, returning
+// -1, 0 or 1. Missing values compare greater than any other. ok is false if
+// the values can't be compared.
+func compareSortValues(a, b interface{}) (cmp int, ok bool) {
+	switch {
+	case a == nil && b == nil:
+		return 0, true
+	case a == nil:
+		return 1, true
+	case b == nil:
+		return -1, true
+	}
+	switch a := a.(type) {
+	case string:
+		b, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(a, b), true
+	case bool:
+		b, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case a == b:
+			return 0, true
+		case b:
+			return -1, true
+		default:
+			return 1, true
+		}
+	case int64:
+		b, ok := b.(int64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case a < b:
+			return -1, true
+		case a > b:
+			return 1, true
+		default:
+			return 0, true
+		}
+	case uint64:
+		b, ok := b.(uint64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case a < b:
+			return -1, true
+		case a > b:
+			return 1, true
+		default:
+			return 0, true
+		}
+	default:
+		return 0, false
+	}
+}
+
+// lessRowKV reports whether a sorts before b, given the direction of each
+// sort key. Values of a composite sort are a []interface{} holding the
+// value of each key, with missing values sorted last whatever the
+// direction. Columns with equal values are ordered by ID, so results merged
+// from several shards are always in the same order.
+func lessRowKV(a, b RowKV, desc []bool) (bool, error) {
+	aVals, aOK := a.Value.([]interface{})
+	bVals, bOK := b.Value.([]interface{})
+	if !aOK || !bOK {
+		aVals, bVals = []interface{}{a.Value}, []interface{}{b.Value}
+	}
+	if len(aVals) != len(bVals) || len(aVals) > len(desc) {
+		return false, errors.Errorf("couldn't compare %v and %v", a, b)
+	}
+	for i := range aVals {
+		cmp, ok := compareSortValues(aVals[i], bVals[i])
+		if !ok {
+			return false, errors.Errorf("couldn't compare %v and %v", a, b)
+		}
+		if cmp == 0 {
+			continue
+		}
+		if desc[i] && aVals[i] != nil && bVals[i] != nil {
+			cmp = -cmp
+		}
+		return cmp < 0, nil
+	}
+	return a.RowID < b.RowID, nil
+}
+
+// executeSortKeysShard orders the columns of filter in a shard by several
+// sort keys. Only columns with a value in the first key's field are
+// returned.
+func (e *executor) executeSortKeysShard(ctx context.Context, tx Tx, idx *Index, keys []sortKey, filter *Row, shard uint64) (*SortedRow, error) {
+	fields := make([]*Field, len(keys))
+	for i, k := range keys {
+		if fields[i] = idx.Field(k.field); fields[i] == nil {
+			return nil, newNotFoundError(ErrFieldNotFound, k.field)
+		}
+	}
+
+	primary, err := e.sortShardField(ctx, tx, idx, fields[0], filter, shard, keys[0].desc)
+	if err != nil {
+		return nil, err
+	}
+	cols := NewRow(primary.Columns()...)
+
+	// Secondary values only need to be looked up, not ordered.
+	values := make([]map[uint64]interface{}, len(keys)-1)
+	for i, f := range fields[1:] {
+		res, err := e.sortShardField(ctx, tx, idx, f, cols, shard, false)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = make(map[uint64]interface{}, len(res.RowKVs))
+		for _, kv := range res.RowKVs {
+			values[i][kv.RowID] = kv.Value
+		}
+	}
+
+	rowKVs := make([]RowKV, len(primary.RowKVs))
+	for i, kv := range primary.RowKVs {
+		vals := make([]interface{}, len(keys))
+		vals[0] = kv.Value
+		for j := range values {
+			vals[j+1] = values[j][kv.RowID]
+		}
+		rowKVs[i] = RowKV{RowID: kv.RowID, Value: vals}
+	}
+
+	desc := sortDirections(keys)
+	var sortErr error
+	sort.SliceStable(rowKVs, func(i, j int) bool {
+		less, err := lessRowKV(rowKVs[i], rowKVs[j], desc)
+		if err != nil {
+			sortErr = err
+		}
+		return less
+	})
+	if sortErr != nil {
+		return nil, errors.Wrap(sortErr, "sorting columns")
+	}
+	return &SortedRow{
+		Row:    cols,
+		RowKVs: rowKVs,
+	}, nil
+}
+
+// sortAfterArg returns the name of the internal argument holding the value
+// of the i-th sort key of the column a Sort() call resumes after.
+func sortAfterArg(i int) string {
+	return fmt.Sprintf("after-value-%d", i)
+}
+
+// resolveSortAfter looks up the sort values of the column a Sort() call's
+// "after" argument resumes after, and adds them to the call, so each shard
+// can skip straight past it rather than every node sorting and returning
+// the columns of all earlier pages.
+func (e *executor) resolveSortAfter(ctx context.Context, qcx *Qcx, index string, c *pql.Call, opt *ExecOptions) error {
+	if _, ok := c.Args["after"]; !ok {
+		return nil
+	} else if _, ok := c.Args[sortAfterArg(0)]; ok {
+		return nil
+	}
+	col, _, err := c.UintArg("after")
+	if err != nil {
+		return NewBadRequestError(errors.Wrap(err, "getting after"))
+	}
+	keys, err := parseSortKeys(c)
+	if err != nil {
+		return err
+	}
+
+	// Sort the column on its own, on whichever node holds it.
+	probe := &pql.Call{
+		Name: "Sort",
+		Args: map[string]interface{}{},
+		Children: []*pql.Call{{
+			Name: "ConstRow",
+			Args: map[string]interface{}{"columns": []uint64{col}},
+			Type: pql.PrecallGlobal,
+		}},
+	}
+	for _, arg := range []string{"by", "field", "_field", "sort-desc"} {
+		if v, ok := c.Args[arg]; ok {
+			probe.Args[arg] = v
+		}
+	}
+	popt := *opt
+	popt.EmbeddedData = nil
+	shards := []uint64{col / ShardWidth}
+	if err := e.handlePreCalls(ctx, qcx, index, probe, shards, &popt); err != nil {
+		return errors.Wrap(err, "finding column to sort after")
+	}
+	res, err := e.executeSort(ctx, qcx, index, probe, shards, &popt)
+	if err != nil {
+		return errors.Wrap(err, "finding column to sort after")
+	}
+	if len(res.RowKVs) == 0 {
+		return NewBadRequestError(errors.Errorf("column to sort after has no value in field %s", keys[0].field))
+	}
+
+	vals, ok := res.RowKVs[0].Value.([]interface{})
+	if !ok {
+		vals = []interface{}{res.RowKVs[0].Value}
+	}
+	c.Args["after"] = col
+	for i, v := range vals {
+		if v != nil {
+			c.Args[sortAfterArg(i)] = v
+		}
+	}
+	return nil
+}
+
+// parseSortAfter returns the column a Sort() call resumes after, along with
+// its sort values, or nil if the call starts from the first column.
+func parseSortAfter(c *pql.Call, idx *Index, keys []sortKey) (*RowKV, error) {
+	if _, ok := c.Args[sortAfterArg(0)]; !ok {
+		return nil, nil
+	}
+	col, _, err := c.UintArg("after")
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "getting after"))
+	}
+	vals := make([]interface{}, len(keys))
+	for i, k := range keys {
+		v := c.Args[sortAfterArg(i)]
+		// Row IDs of mutex fields without keys are parsed as signed.
+		if n, ok := v.(int64); ok {
+			if f := idx.Field(k.field); f != nil && f.Type() == FieldTypeMutex {
+				v = uint64(n)
+			}
+		}
+		vals[i] = v
+	}
+	if len(keys) == 1 {
+		return &RowKV{RowID: col, Value: vals[0]}, nil
+	}
+	return &RowKV{RowID: col, Value: vals}, nil
+}
+
+// seekSortedRow drops the columns of a shard's sorted columns up to and
+// including after, if given, and then keeps the first keep of those left,
+// unless keep is negative.
+func seekSortedRow(res *SortedRow, after *RowKV, desc []bool, keep int) (*SortedRow, error) {
+	rowKVs := res.RowKVs
+	if after != nil {
+		var searchErr error
+		i := sort.Search(len(rowKVs), func(i int) bool {
+			less, err := lessRowKV(*after, rowKVs[i], desc)
+			if err != nil {
+				searchErr = err
+			}
+			return less
+		})
+		if searchErr != nil {
+			return nil, errors.Wrap(searchErr, "seeking sorted columns")
+		}
+		rowKVs = rowKVs[i:]
+	}
+	if keep >= 0 && len(rowKVs) > keep {
+		rowKVs = rowKVs[:keep]
+	}
+	if len(rowKVs) == len(res.RowKVs) {
+		return res, nil
+	}
+	res = &SortedRow{RowKVs: rowKVs}
+	res.Row = NewRow(res.Columns()...)
+	return res, nil
+}
@@ -0,0 +1,310 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// Results too large to assemble in memory can be spilled to a node's
+// temporary directory, the data directory's tmp by default, or the
+// temp-dir setting. The files in it are counted against its quota, if it
+// has one, and are removed when they're no longer needed, or when the
+// node next starts, if it stopped before removing them.
+//
+// An Extract's columns are spilled once the columns its node holds take
+// more memory than the spill threshold. Each batch of them is written as
+// a run of columns in ID order, and the runs are merged in order once all
+// the columns are in, skipping those before the offset and stopping at the
+// limit.
+
+// spillFilePrefix starts the names of the files in a temporary directory,
+// so only they're removed on startup.
+const spillFilePrefix = "spill-"
+
+// ErrSpillQuotaExceeded is returned when spilling results would exceed the
+// quota of the temporary directory.
+var ErrSpillQuotaExceeded = errors.New("temporary directory quota exceeded")
+
+// spillDir is a directory for the files of spilled results.
+type spillDir struct {
+	path string
+
+	// quota is the number of bytes the files in the directory can hold,
+	// or 0 for no limit.
+	quota int64
+
+	// used is the number of bytes the files hold. It's updated
+	// atomically.
+	used int64
+
+	// files is the number of files open. It's updated atomically.
+	files int64
+}
+
+// openSpillDir creates the directory at path, if need be, and removes the
+// files spilled to it before.
+func openSpillDir(path string, quota int64) (*spillDir, error) {
+	if err := os.MkdirAll(path, 0750); err != nil {
+		return nil, errors.Wrap(err, "creating temporary directory")
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading temporary directory")
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), spillFilePrefix) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(path, entry.Name())); err != nil {
+			return nil, errors.Wrap(err, "removing old temporary file")
+		}
+	}
+	return &spillDir{path: path, quota: quota}, nil
+}
+
+// Used returns the number of bytes the directory's files hold.
+func (d *spillDir) Used() int64 {
+	return atomic.LoadInt64(&d.used)
+}
+
+// Files returns the number of files open in the directory.
+func (d *spillDir) Files() int64 {
+	return atomic.LoadInt64(&d.files)
+}
+
+// create returns a new file in the directory.
+func (d *spillDir) create() (*spillFile, error) {
+	f, err := os.CreateTemp(d.path, spillFilePrefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temporary file")
+	}
+	atomic.AddInt64(&d.files, 1)
+	sf := &spillFile{dir: d, f: f}
+	sf.w = bufio.NewWriter(writerFunc(sf.write))
+	return sf, nil
+}
+
+// writerFunc is an io.Writer calling a function.
+type writerFunc func(p []byte) (int, error)
+
+func (fn writerFunc) Write(p []byte) (int, error) { return fn(p) }
+
+// spillFile is a file in a spillDir, which is written and then read back.
+type spillFile struct {
+	dir  *spillDir
+	f    *os.File
+	w    *bufio.Writer
+	size int64
+}
+
+// write writes p to the file, if the directory's quota allows it.
+func (sf *spillFile) write(p []byte) (int, error) {
+	n := int64(len(p))
+	if used := atomic.AddInt64(&sf.dir.used, n); sf.dir.quota > 0 && used > sf.dir.quota {
+		atomic.AddInt64(&sf.dir.used, -n)
+		return 0, ErrSpillQuotaExceeded
+	}
+	sf.size += n
+	return sf.f.Write(p)
+}
+
+// reader returns a reader of the file from the start.
+func (sf *spillFile) reader() (io.ByteReader, error) {
+	if _, err := sf.f.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "seeking temporary file")
+	}
+	return bufio.NewReader(sf.f), nil
+}
+
+// Close closes and removes the file.
+func (sf *spillFile) Close() error {
+	atomic.AddInt64(&sf.dir.used, -sf.size)
+	atomic.AddInt64(&sf.dir.files, -1)
+	sf.size = 0
+	err := sf.f.Close()
+	if rerr := os.Remove(sf.f.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// extractSpill holds the columns of an Extract which have been spilled.
+type extractSpill struct {
+	dir  *spillDir
+	runs []*spillFile
+
+	// n is the number of columns spilled.
+	n int
+}
+
+// add writes cols, which it sorts, as a run.
+func (s *extractSpill) add(cols []ExtractedIDColumn) error {
+	if len(cols) == 0 {
+		return nil
+	}
+	sort.Slice(cols, func(i, j int) bool { return cols[i].ColumnID < cols[j].ColumnID })
+	sf, err := s.dir.create()
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, sf)
+	var buf []byte
+	for _, col := range cols {
+		buf = appendSpilledColumn(buf[:0], col)
+		if _, err := sf.w.Write(buf); err != nil {
+			return errors.Wrap(err, "spilling column")
+		}
+	}
+	if err := sf.w.Flush(); err != nil {
+		return errors.Wrap(err, "spilling columns")
+	}
+	s.n += len(cols)
+	return nil
+}
+
+// appendSpilledColumn appends col to buf, as its ID and number of rows,
+// and each row's length plus one, or zero for a nil row, and values, all
+// as uvarints.
+func appendSpilledColumn(buf []byte, col ExtractedIDColumn) []byte {
+	buf = binary.AppendUvarint(buf, col.ColumnID)
+	buf = binary.AppendUvarint(buf, uint64(len(col.Rows)))
+	for _, row := range col.Rows {
+		if row == nil {
+			buf = binary.AppendUvarint(buf, 0)
+			continue
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(row))+1)
+		for _, v := range row {
+			buf = binary.AppendUvarint(buf, v)
+		}
+	}
+	return buf
+}
+
+// readSpilledColumn reads a column written by appendSpilledColumn.
+func readSpilledColumn(r io.ByteReader) (col ExtractedIDColumn, err error) {
+	if col.ColumnID, err = binary.ReadUvarint(r); err != nil {
+		return col, err
+	}
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return col, io.ErrUnexpectedEOF
+	}
+	col.Rows = make([][]uint64, n)
+	for i := range col.Rows {
+		l, err := binary.ReadUvarint(r)
+		if err != nil {
+			return col, io.ErrUnexpectedEOF
+		} else if l == 0 {
+			continue
+		}
+		row := make([]uint64, l-1)
+		for j := range row {
+			if row[j], err = binary.ReadUvarint(r); err != nil {
+				return col, io.ErrUnexpectedEOF
+			}
+		}
+		col.Rows[i] = row
+	}
+	return col, nil
+}
+
+// merge returns the spilled columns in ID order, from the start-th until
+// before the end-th.
+func (s *extractSpill) merge(start, end int) ([]ExtractedIDColumn, error) {
+	h := make(spillRunHeap, 0, len(s.runs))
+	for _, sf := range s.runs {
+		r, err := sf.reader()
+		if err != nil {
+			return nil, err
+		}
+		run := &spillRun{r: r}
+		if ok, err := run.next(); err != nil {
+			return nil, err
+		} else if ok {
+			h = append(h, run)
+		}
+	}
+	heap.Init(&h)
+
+	n := end
+	if n > s.n {
+		n = s.n
+	}
+	if n < start {
+		n = start
+	}
+	cols := make([]ExtractedIDColumn, 0, n-start)
+	for i := 0; i < end && len(h) > 0; i++ {
+		run := h[0]
+		if i >= start {
+			cols = append(cols, run.col)
+		}
+		if ok, err := run.next(); err != nil {
+			return nil, err
+		} else if ok {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+	return cols, nil
+}
+
+// Close removes the runs.
+func (s *extractSpill) Close() error {
+	var err error
+	for _, sf := range s.runs {
+		if cerr := sf.Close(); err == nil {
+			err = cerr
+		}
+	}
+	s.runs = nil
+	return err
+}
+
+// spillRun reads the columns of a run in order.
+type spillRun struct {
+	r   io.ByteReader
+	col ExtractedIDColumn
+}
+
+// next reads the run's next column, returning false at its end.
+func (run *spillRun) next() (bool, error) {
+	col, err := readSpilledColumn(run.r)
+	if err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrap(err, "reading spilled column")
+	}
+	run.col = col
+	return true, nil
+}
+
+// spillRunHeap orders runs by the ID of their next column.
+type spillRunHeap []*spillRun
+
+func (h spillRunHeap) Len() int           { return len(h) }
+func (h spillRunHeap) Less(i, j int) bool { return h[i].col.ColumnID < h[j].col.ColumnID }
+func (h spillRunHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *spillRunHeap) Push(x interface{}) { *h = append(*h, x.(*spillRun)) }
+
+func (h *spillRunHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	run := old[n-1]
+	*h = old[:n-1]
+	return run
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestSpillDir_Open(t *testing.T) {
+	path := t.TempDir()
+	for _, name := range []string{spillFilePrefix + "1", "other"} {
+		if err := os.WriteFile(filepath.Join(path, name), []byte("x"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := openSpillDir(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(entries) != 1 || entries[0].Name() != "other" {
+		t.Fatalf("expected only the file which wasn't spilled to remain, got %v", entries)
+	}
+}
+
+func TestExtractSpill(t *testing.T) {
+	dir, err := openSpillDir(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spill := &extractSpill{dir: dir}
+	for _, run := range [][]ExtractedIDColumn{
+		{{ColumnID: 9, Rows: [][]uint64{{1}, nil}}, {ColumnID: 2, Rows: [][]uint64{{}, {3, 4}}}},
+		{{ColumnID: 5, Rows: [][]uint64{{1 << 40}, {}}}},
+		{{ColumnID: 1, Rows: [][]uint64{nil, nil}}, {ColumnID: 7, Rows: [][]uint64{{2}, {2}}}},
+	} {
+		if err := spill.add(run); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if dir.Files() != 3 || dir.Used() == 0 {
+		t.Fatalf("expected 3 files holding data, got %d holding %d bytes", dir.Files(), dir.Used())
+	}
+
+	cols, err := spill.merge(0, spill.n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []ExtractedIDColumn{
+		{ColumnID: 1, Rows: [][]uint64{nil, nil}},
+		{ColumnID: 2, Rows: [][]uint64{{}, {3, 4}}},
+		{ColumnID: 5, Rows: [][]uint64{{1 << 40}, {}}},
+		{ColumnID: 7, Rows: [][]uint64{{2}, {2}}},
+		{ColumnID: 9, Rows: [][]uint64{{1}, nil}},
+	}
+	if !reflect.DeepEqual(cols, exp) {
+		t.Fatalf("expected %v, got %v", exp, cols)
+	}
+	if cols, err := spill.merge(1, 3); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(cols, exp[1:3]) {
+		t.Fatalf("expected %v, got %v", exp[1:3], cols)
+	}
+
+	if err := spill.Close(); err != nil {
+		t.Fatal(err)
+	} else if dir.Files() != 0 || dir.Used() != 0 {
+		t.Fatalf("expected no files left, got %d holding %d bytes", dir.Files(), dir.Used())
+	}
+	if entries, err := os.ReadDir(dir.path); err != nil {
+		t.Fatal(err)
+	} else if len(entries) != 0 {
+		t.Fatalf("expected spilled files to be removed, got %v", entries)
+	}
+}
+
+func TestExtractSpill_Quota(t *testing.T) {
+	dir, err := openSpillDir(t.TempDir(), 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spill := &extractSpill{dir: dir}
+	defer spill.Close()
+
+	cols := make([]ExtractedIDColumn, 100)
+	for i := range cols {
+		cols[i] = ExtractedIDColumn{ColumnID: uint64(i), Rows: [][]uint64{{uint64(i)}}}
+	}
+	if err := spill.add(cols); errors.Cause(err) != ErrSpillQuotaExceeded {
+		t.Fatalf("expected quota to be exceeded, got %v", err)
+	}
+}
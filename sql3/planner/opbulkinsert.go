@@ -347,6 +347,7 @@ func (i *bulkInsertCSVRowIter) processBatch(ctx context.Context) error {
 
 	log.Printf("BULK INSERT: inserting columns...")
 	qcx := i.planner.computeAPI.Txf().NewQcx()
+	defer qcx.Abort()
 
 	//nil out colids if the table is keyed
 	for colIdx, mc := range i.options.columnMap {
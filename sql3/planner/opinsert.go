@@ -100,6 +100,7 @@ var _ types.RowIterator = (*insertRowIter)(nil)
 
 func (i *insertRowIter) Next(ctx context.Context) (types.Row, error) {
 	qcx := i.planner.computeAPI.Txf().NewQcx()
+	defer qcx.Abort()
 
 	colIDs := make([]uint64, 0)
 	colKeys := make([]string, 0)
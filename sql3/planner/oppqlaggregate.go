@@ -5,6 +5,7 @@ package planner
 import (
 	"context"
 	"fmt"
+	"math/big"
 
 	pilosa "github.com/featurebasedb/featurebase/v3"
 	"github.com/featurebasedb/featurebase/v3/pql"
@@ -175,9 +176,10 @@ func (i *pqlAggregateRowIter) Next(ctx context.Context) (types.Row, error) {
 				}
 			}
 
+			// The sum may not fit in an int64 even when the average does.
 			call = &pql.Call{
 				Name:     "Sum",
-				Args:     map[string]interface{}{"field": expr.columnName},
+				Args:     map[string]interface{}{"field": expr.columnName, "precision": int64(128)},
 				Children: []*pql.Call{cond},
 			}
 
@@ -263,7 +265,11 @@ func (i *pqlAggregateRowIter) Next(ctx context.Context) (types.Row, error) {
 		case pilosa.ValCount:
 			if actualResult.DecimalVal == nil {
 				if i.aggregate.AggType() == types.AGGREGATE_AVG {
-					average := float64(actualResult.Val) / float64(actualResult.Count)
+					sum := float64(actualResult.Val)
+					if actualResult.BigVal != nil {
+						sum, _ = new(big.Float).SetInt(actualResult.BigVal).Float64()
+					}
+					average := sum / float64(actualResult.Count)
 					i.resultValue = pql.NewDecimal(int64(average*10000), 4)
 				} else {
 					i.resultValue = int64(actualResult.Val)
@@ -0,0 +1,270 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/featurebasedb/featurebase/v3/rbf"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+)
+
+// GET /storage reports how a node stores its data: for each index, how many
+// shard files it has, how big they and their write-ahead logs are, how many
+// of their pages are free, and how many containers of their bitmaps are
+// stored as arrays, runs, and bitmaps. POST /storage/optimize rewrites each
+// container which would take less space as another type, a shard at a
+// time, and then checkpoints the shard's write-ahead log into its file, so
+// free pages at the end of the file are dropped. Each shard is locked for
+// writes while it's optimized, and its progress is streamed back as a line
+// of JSON.
+
+// StorageCounts describe how some data is stored.
+type StorageCounts struct {
+	Files            int   `json:"files"`
+	Bytes            int64 `json:"bytes"`
+	WALBytes         int64 `json:"walBytes"`
+	Pages            int   `json:"pages"`
+	FreePages        int   `json:"freePages"`
+	Bitmaps          int   `json:"bitmaps"`
+	ArrayContainers  int   `json:"arrayContainers"`
+	RunContainers    int   `json:"runContainers"`
+	BitmapContainers int   `json:"bitmapContainers"`
+}
+
+func (c *StorageCounts) add(o StorageCounts) {
+	c.Files += o.Files
+	c.Bytes += o.Bytes
+	c.WALBytes += o.WALBytes
+	c.Pages += o.Pages
+	c.FreePages += o.FreePages
+	c.Bitmaps += o.Bitmaps
+	c.ArrayContainers += o.ArrayContainers
+	c.RunContainers += o.RunContainers
+	c.BitmapContainers += o.BitmapContainers
+}
+
+// StorageReport describes how a node stores its data, in total, and for
+// each index.
+type StorageReport struct {
+	Node string `json:"node"`
+	StorageCounts
+	Indexes []IndexStorage `json:"indexes"`
+}
+
+// IndexStorage describes how a node stores the data of an index.
+type IndexStorage struct {
+	Index string `json:"index"`
+	StorageCounts
+}
+
+// StorageOptimizeProgress reports the optimization of a shard. Done is the
+// number of shards optimized so far, out of Total. Containers is the number
+// of containers rewritten in the shard, and Bytes and WALBytes the size of
+// its file and write-ahead log before and after. A progress with Error set
+// reports that optimizing failed.
+type StorageOptimizeProgress struct {
+	Error          string `json:"error,omitempty"`
+	Index          string `json:"index,omitempty"`
+	Shard          uint64 `json:"shard"`
+	Done           int    `json:"done"`
+	Total          int    `json:"total"`
+	Containers     int    `json:"containers"`
+	BytesBefore    int64  `json:"bytesBefore"`
+	WALBytesBefore int64  `json:"walBytesBefore"`
+	Bytes          int64  `json:"bytes"`
+	WALBytes       int64  `json:"walBytes"`
+}
+
+// StorageReport reports how this node stores the data of an index, or of
+// every index if indexName is empty.
+func (api *API) StorageReport(ctx context.Context, indexName string) (*StorageReport, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.StorageReport")
+	defer span.Finish()
+
+	if err := api.validate(apiStorage); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	indexes, err := api.storageIndexes(indexName)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &StorageReport{Node: api.NodeID(), Indexes: []IndexStorage{}}
+	for _, idx := range indexes {
+		shards, err := api.holder.localShards(idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing shards of %s", idx.Name())
+		}
+		is := IndexStorage{Index: idx.Name()}
+		for _, shard := range shards {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			c, err := api.holder.shardStorage(idx, shard)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading shard %d of %s", shard, idx.Name())
+			}
+			is.add(c)
+		}
+		report.add(is.StorageCounts)
+		report.Indexes = append(report.Indexes, is)
+	}
+	return report, nil
+}
+
+// OptimizeStorage optimizes the storage of the shards this node holds of an
+// index, or of every index if indexName is empty, calling fn after each
+// shard. Only one optimization can run on a node at a time.
+func (api *API) OptimizeStorage(ctx context.Context, indexName string, fn func(StorageOptimizeProgress) error) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.OptimizeStorage")
+	defer span.Finish()
+
+	if err := api.validate(apiStorage); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	if !api.optimizing.TryLock() {
+		return newConflictError(errors.New("storage is already being optimized"))
+	}
+	defer api.optimizing.Unlock()
+
+	indexes, err := api.storageIndexes(indexName)
+	if err != nil {
+		return err
+	}
+	type indexShard struct {
+		idx   *Index
+		shard uint64
+	}
+	var work []indexShard
+	for _, idx := range indexes {
+		shards, err := api.holder.localShards(idx)
+		if err != nil {
+			return errors.Wrapf(err, "listing shards of %s", idx.Name())
+		}
+		for _, shard := range shards {
+			work = append(work, indexShard{idx: idx, shard: shard})
+		}
+	}
+
+	for i, w := range work {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		p, err := api.holder.optimizeShard(w.idx, w.shard)
+		if err != nil {
+			return errors.Wrapf(err, "optimizing shard %d of %s", w.shard, w.idx.Name())
+		}
+		p.Done, p.Total = i+1, len(work)
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storageIndexes returns the named index, or every index if name is empty,
+// in order.
+func (api *API) storageIndexes(name string) ([]*Index, error) {
+	if name != "" {
+		idx := api.holder.Index(name)
+		if idx == nil {
+			return nil, newNotFoundError(ErrIndexNotFound, name)
+		}
+		return []*Index{idx}, nil
+	}
+	return api.holder.Indexes(), nil
+}
+
+// localShards returns the shards of idx which have files on this node, in
+// order.
+func (h *Holder) localShards(idx *Index) ([]uint64, error) {
+	m, err := h.txf.GetShardsForIndex(idx, "", false)
+	if err != nil {
+		return nil, err
+	}
+	shards := make([]uint64, 0, len(m))
+	for shard := range m {
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+	return shards, nil
+}
+
+// shardStorage describes how a shard of idx is stored. Only RBF storage
+// supports this.
+func (h *Holder) shardStorage(idx *Index, shard uint64) (StorageCounts, error) {
+	var c StorageCounts
+	tx := h.txf.NewTx(Txo{Index: idx, Shard: shard})
+	defer tx.Rollback()
+	rtx, ok := tx.(*RBFTx)
+	if !ok {
+		return c, fmt.Errorf("storage reports not available for %q storage", tx.Type())
+	}
+	s, err := rtx.tx.StorageStats()
+	if err != nil {
+		return c, err
+	}
+	c.Files = 1
+	if c.Bytes, c.WALBytes, err = rbfSizes(rtx.Db.db); err != nil {
+		return c, err
+	}
+	c.Pages = s.PageN
+	c.FreePages = s.FreePageN
+	c.Bitmaps = s.BitmapN
+	c.ArrayContainers = s.ArrayContainerN
+	c.RunContainers = s.RunContainerN
+	c.BitmapContainers = s.BitmapContainerN
+	return c, nil
+}
+
+// optimizeShard rewrites the containers of a shard of idx which would take
+// less space as another type, and checkpoints its database.
+func (h *Holder) optimizeShard(idx *Index, shard uint64) (StorageOptimizeProgress, error) {
+	p := StorageOptimizeProgress{Index: idx.Name(), Shard: shard}
+	tx := h.txf.NewTx(Txo{Write: true, Index: idx, Shard: shard})
+	defer tx.Rollback()
+	rtx, ok := tx.(*RBFTx)
+	if !ok {
+		return p, fmt.Errorf("storage optimization not available for %q storage", tx.Type())
+	}
+	db := rtx.Db.db
+
+	var err error
+	if p.BytesBefore, p.WALBytesBefore, err = rbfSizes(db); err != nil {
+		return p, err
+	}
+	names, err := rtx.tx.BitmapNames()
+	if err != nil {
+		return p, err
+	}
+	for _, name := range names {
+		n, err := rtx.tx.OptimizeBitmap(name)
+		if err != nil {
+			return p, errors.Wrapf(err, "optimizing %s", name)
+		}
+		p.Containers += n
+	}
+	if err := tx.Commit(); err != nil {
+		return p, errors.Wrap(err, "committing")
+	}
+
+	if err := db.Checkpoint(); err != nil {
+		return p, errors.Wrap(err, "checkpointing")
+	}
+	p.Bytes, p.WALBytes, err = rbfSizes(db)
+	return p, err
+}
+
+// rbfSizes returns the sizes of the data file and WAL of db.
+func rbfSizes(db *rbf.DB) (data, wal int64, err error) {
+	fi, err := os.Stat(db.DataPath())
+	if err != nil {
+		return 0, 0, err
+	}
+	return fi.Size(), db.WALSize(), nil
+}
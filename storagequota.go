@@ -0,0 +1,271 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Writes are refused up front with clear errors, rather than failing part
+// way through once the disk is full. Each index, and the node as a whole,
+// can be limited in the disk its data uses, beyond which imports and Set()
+// are refused. Clearing data is still allowed, so space can be freed.
+// Separately, a node becomes read-only while the free space on its disk is
+// below a minimum, or after a write fails because the disk is full, and
+// accepts writes again once space has been freed.
+
+// DiskLimits limits the disk used by a node's data, and sets how much of
+// the disk must be left free for the node to accept writes. Zero means no
+// limit.
+type DiskLimits struct {
+	// MaxStorage limits the bytes of disk all of the node's data can use.
+	MaxStorage int64
+
+	// MinFree and MinFreePercent are the bytes, and the percentage of the
+	// disk, which must be left free. Below either, the node is read-only.
+	MinFree        uint64
+	MinFreePercent float64
+
+	// CheckInterval is how often the free space on the disk is checked.
+	CheckInterval time.Duration
+}
+
+const (
+	// defaultDiskCheckInterval is how often the free space on the disk is
+	// checked by default.
+	defaultDiskCheckInterval = 10 * time.Second
+
+	// diskUsageTTL is how long the disk used by data is cached for, since
+	// it's found by walking its files.
+	diskUsageTTL = 10 * time.Second
+)
+
+// StorageQuotaError is returned when a write would take an index, or the
+// node, past its storage quota. Its cause is ErrStorageQuotaExceeded.
+type StorageQuotaError struct {
+	Index string // empty for the node's quota
+	Limit int64
+}
+
+func (e StorageQuotaError) Error() string {
+	if e.Index == "" {
+		return fmt.Sprintf("%s: node is limited to %d bytes of storage", ErrStorageQuotaExceeded, e.Limit)
+	}
+	return fmt.Sprintf("%s: index %q is limited to %d bytes of storage", ErrStorageQuotaExceeded, e.Index, e.Limit)
+}
+
+// Cause allows errors.Cause to return ErrStorageQuotaExceeded.
+func (e StorageQuotaError) Cause() error {
+	return ErrStorageQuotaExceeded
+}
+
+// Unwrap makes errors.Is(err, ErrStorageQuotaExceeded) true.
+func (e StorageQuotaError) Unwrap() error {
+	return ErrStorageQuotaExceeded
+}
+
+// ReadOnlyError is returned by writes while the node is read-only because
+// its disk is low on space. Its cause is ErrReadOnly.
+type ReadOnlyError struct {
+	Reason string
+}
+
+func (e ReadOnlyError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrReadOnly, e.Reason)
+}
+
+// Cause allows errors.Cause to return ErrReadOnly.
+func (e ReadOnlyError) Cause() error {
+	return ErrReadOnly
+}
+
+// Unwrap makes errors.Is(err, ErrReadOnly) true.
+func (e ReadOnlyError) Unwrap() error {
+	return ErrReadOnly
+}
+
+// diskPressure returns why a node should be read-only, given the free and
+// total bytes of its disk, or "" if it shouldn't be.
+func diskPressure(free, total uint64, limits DiskLimits) string {
+	switch {
+	case free == 0:
+		return "disk is full"
+	case free < limits.MinFree:
+		return fmt.Sprintf("%d bytes free on disk, below the minimum of %d", free, limits.MinFree)
+	case limits.MinFreePercent > 0 && float64(free)*100 < limits.MinFreePercent*float64(total):
+		return fmt.Sprintf("%.1f%% of disk free, below the minimum of %.1f%%", float64(free)*100/float64(total), limits.MinFreePercent)
+	}
+	return ""
+}
+
+// diskUsageCache caches the bytes of disk used by data, by key.
+type diskUsageCache struct {
+	mu      sync.Mutex
+	entries map[string]diskUsageEntry
+}
+
+type diskUsageEntry struct {
+	bytes int64
+	at    time.Time
+}
+
+func newDiskUsageCache() *diskUsageCache {
+	return &diskUsageCache{entries: make(map[string]diskUsageEntry)}
+}
+
+// usage returns the bytes of disk used by the data under key, as found by
+// fn, which is only called if the last result is out of date.
+func (c *diskUsageCache) usage(key string, fn func() (int64, error)) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok && time.Since(e.at) < diskUsageTTL {
+		return e.bytes, nil
+	}
+	bytes, err := fn()
+	if err != nil {
+		return 0, err
+	}
+	c.entries[key] = diskUsageEntry{bytes: bytes, at: time.Now()}
+	return bytes, nil
+}
+
+// pathUsage returns the bytes of disk used under path.
+func (c *diskUsageCache) pathUsage(path string) (int64, error) {
+	return c.usage(path, func() (int64, error) {
+		usage, err := GetDiskUsage(path)
+		return usage.Usage, err
+	})
+}
+
+// setReadOnly makes the node read-only for reason, or accept writes again
+// if reason is "". It reports whether that changed anything.
+func (h *Holder) setReadOnly(reason string) bool {
+	h.readOnlyMu.Lock()
+	defer h.readOnlyMu.Unlock()
+	changed := (reason == "") != (h.readOnly == "")
+	h.readOnly = reason
+	return changed
+}
+
+// checkWritable returns an error if the node is read-only.
+func (h *Holder) checkWritable() error {
+	h.readOnlyMu.RLock()
+	defer h.readOnlyMu.RUnlock()
+	if h.readOnly != "" {
+		return ReadOnlyError{Reason: h.readOnly}
+	}
+	return nil
+}
+
+// checkWrite returns an error if a write to a shard of idx should be
+// refused, because the node or index is read-only, or the node is in
+// maintenance mode, or because it could take the index, the node, or the
+// index's namespace past a quota. Clears are only refused by the former,
+// since they never add data.
+func (h *Holder) checkWrite(idx *Index, shard uint64, clear bool) error {
+	if err := h.checkIndexWritable(idx); err != nil {
+		return err
+	}
+	if clear {
+		return nil
+	}
+	if err := h.checkStorageQuotas(idx); err != nil {
+		return err
+	}
+	return h.checkNamespaceShard(idx, shard)
+}
+
+// checkStorageQuotas returns an error if the disk used by idx, or by all
+// of the node's data, has reached its quota.
+func (h *Holder) checkStorageQuotas(idx *Index) error {
+	if max := idx.MaxStorage(); max > 0 {
+		used, err := h.diskUsage.pathUsage(idx.Path())
+		if err != nil {
+			return errors.Wrapf(err, "getting disk usage of index %s", idx.Name())
+		}
+		if used >= max {
+			return StorageQuotaError{Index: idx.Name(), Limit: max}
+		}
+	}
+	if max := h.diskLimits.MaxStorage; max > 0 {
+		used, err := h.diskUsage.pathUsage(h.path)
+		if err != nil {
+			return errors.Wrap(err, "getting disk usage of node")
+		}
+		if used >= max {
+			return StorageQuotaError{Limit: max}
+		}
+	}
+	return nil
+}
+
+// noteWriteErr makes the node read-only if err shows the disk is full, so
+// later writes are refused before they start rather than failing part way
+// through. The node accepts writes again once a check of the disk finds
+// enough free space.
+func (h *Holder) noteWriteErr(err error) {
+	if h == nil || !errors.Is(err, syscall.ENOSPC) {
+		return
+	}
+	if h.setReadOnly("disk is full") {
+		h.Logger.Errorf("write failed with full disk, node is read-only: %v", err)
+	}
+}
+
+// monitorDiskPressure periodically checks the free space on the disk
+// holding the node's data, making the node read-only while it's too low.
+func (s *Server) monitorDiskPressure() {
+	interval := s.holder.diskLimits.CheckInterval
+	if interval <= 0 {
+		interval = defaultDiskCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.checkDiskPressure()
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkDiskPressure checks the free space on the disk holding the node's
+// data once, making the node read-only or accept writes again as needed.
+// Nothing is changed if the size of the disk isn't known.
+func (s *Server) checkDiskPressure() {
+	path := s.holder.Path()
+	total, err := s.systemInfo.DiskCapacity(path)
+	if err != nil {
+		s.logger.Printf("failed to check disk capacity: %v", err)
+		return
+	} else if total == 0 {
+		return
+	}
+	free, err := s.systemInfo.DiskFree(path)
+	if err != nil {
+		s.logger.Printf("failed to check free disk space: %v", err)
+		return
+	}
+	s.holder.Stats.Gauge(MetricDiskFreeBytes, float64(free), 1.0)
+
+	reason := diskPressure(free, total, s.holder.diskLimits)
+	if s.holder.setReadOnly(reason) {
+		if reason != "" {
+			s.logger.Errorf("node is read-only: %s", reason)
+		} else {
+			s.logger.Infof("disk has enough free space, node accepts writes again")
+		}
+	}
+	readOnly := 0.0
+	if reason != "" {
+		readOnly = 1.0
+	}
+	s.holder.Stats.Gauge(MetricReadOnly, readOnly, 1.0)
+}
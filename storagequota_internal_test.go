@@ -0,0 +1,60 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/featurebasedb/featurebase/v3/logger"
+	"github.com/pkg/errors"
+)
+
+func TestDiskPressure(t *testing.T) {
+	limits := DiskLimits{MinFree: 100, MinFreePercent: 10}
+	for _, tt := range []struct {
+		free, total uint64
+		limits      DiskLimits
+		readOnly    bool
+	}{
+		{free: 0, total: 1000, readOnly: true},
+		{free: 1, total: 1000},
+		{free: 500, total: 1000, limits: limits},
+		{free: 99, total: 1000, limits: limits, readOnly: true},
+		{free: 150, total: 2000, limits: limits, readOnly: true},
+		{free: 200, total: 2000, limits: limits},
+	} {
+		if reason := diskPressure(tt.free, tt.total, tt.limits); (reason != "") != tt.readOnly {
+			t.Errorf("free %d of %d: expected read-only %v, got %q", tt.free, tt.total, tt.readOnly, reason)
+		}
+	}
+}
+
+func TestHolder_ReadOnly(t *testing.T) {
+	h := &Holder{Logger: logger.NopLogger}
+	if err := h.checkWritable(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Errors other than a full disk don't change anything.
+	h.noteWriteErr(errors.New("oops"))
+	if err := h.checkWritable(); err != nil {
+		t.Fatal(err)
+	}
+
+	h.noteWriteErr(errors.Wrap(&os.PathError{Op: "write", Path: "wal", Err: syscall.ENOSPC}, "committing"))
+	err := h.checkWritable()
+	if !errors.Is(err, ErrReadOnly) || errors.Cause(err) != ErrReadOnly {
+		t.Fatalf("expected read-only, got %v", err)
+	}
+
+	if !h.setReadOnly("") {
+		t.Fatal("expected change leaving read-only")
+	} else if h.setReadOnly("") {
+		t.Fatal("expected no change")
+	}
+	if err := h.checkWritable(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"sync"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+// A subtree of bitmap calls can appear more than once in a call, as in
+// Intersect(Union(Row(a=1), Row(b=2)), Not(Union(Row(a=1), Row(b=2)))).
+// Before a call is executed, the executor finds the subtrees which are the
+// same as another, by the text of their normalized calls, and computes each
+// of them once per shard, reusing its row for the others. The same is done
+// for global pre-calls, such as Distinct, which are computed once per call
+// instead of once for each time they appear in it. Results are only reused
+// within a top-level call, so writes made by earlier calls of a query are
+// always seen. The unplanned execution path doesn't reuse them, so canary
+// executions check the reuse.
+
+// subexprCacheable reports whether the result of a bitmap call can be
+// reused for another call with the same text in a shard.
+func subexprCacheable(c *pql.Call) bool {
+	switch c.Name {
+	case "Row", "Range", "Difference", "Intersect", "Union", "InnerUnionRows", "InnerIntersectRows", "Xor", "Not", "Shift", "All", "CardinalityOf":
+		return true
+	}
+	// Precomputed calls are already computed, and UDFs needn't give the
+	// same results twice.
+	return false
+}
+
+// subexprCache holds the rows computed in each shard for the subtrees of a
+// call which appear more than once in it.
+type subexprCache struct {
+	// keys holds the normalized text of each repeated subtree.
+	keys map[*pql.Call]string
+
+	mu   sync.Mutex
+	rows map[subexprKey]*subexprEntry
+}
+
+type subexprKey struct {
+	key   string
+	shard uint64
+}
+
+// subexprEntry is the row of a subtree in a shard, which is ready once
+// the first caller to compute it is done.
+type subexprEntry struct {
+	ready chan struct{}
+	row   *Row
+	err   error
+}
+
+// newSubexprCache returns a cache for the repeated subtrees of c, or nil if
+// it doesn't have any.
+func newSubexprCache(c *pql.Call) *subexprCache {
+	calls := make(map[string][]*pql.Call)
+	var walk func(c *pql.Call)
+	walk = func(c *pql.Call) {
+		if c == nil {
+			return
+		}
+		if subexprCacheable(c) && !hasUDF(c) {
+			key := c.String()
+			calls[key] = append(calls[key], c)
+		}
+		for _, child := range c.Children {
+			walk(child)
+		}
+		for _, arg := range c.Args {
+			if call, ok := arg.(*pql.Call); ok {
+				walk(call)
+			}
+		}
+	}
+	walk(c)
+
+	var cache *subexprCache
+	for key, repeated := range calls {
+		if len(repeated) < 2 {
+			continue
+		}
+		if cache == nil {
+			cache = &subexprCache{
+				keys: make(map[*pql.Call]string),
+				rows: make(map[subexprKey]*subexprEntry),
+			}
+		}
+		for _, call := range repeated {
+			cache.keys[call] = key
+		}
+	}
+	return cache
+}
+
+// row returns the row of c in shard, computing it with fn unless it has
+// been computed already. Calls which aren't repeated are always computed.
+func (sc *subexprCache) row(c *pql.Call, shard uint64, fn func() (*Row, error)) (*Row, error) {
+	if sc == nil {
+		return fn()
+	}
+	key, ok := sc.keys[c]
+	if !ok {
+		return fn()
+	}
+
+	k := subexprKey{key: key, shard: shard}
+	sc.mu.Lock()
+	entry, ok := sc.rows[k]
+	if ok {
+		sc.mu.Unlock()
+		<-entry.ready
+		return entry.row, entry.err
+	}
+	entry = &subexprEntry{ready: make(chan struct{})}
+	sc.rows[k] = entry
+	sc.mu.Unlock()
+
+	entry.row, entry.err = fn()
+	close(entry.ready)
+	return entry.row, entry.err
+}
+
+type contextKeySubexprCacheType struct{}
+
+var contextKeySubexprCache = contextKeySubexprCacheType{}
+
+// withSubexprCache returns a context for executing call, which reuses the
+// rows of its repeated subtrees.
+func withSubexprCache(ctx context.Context, call *pql.Call) context.Context {
+	if execPathFromContext(ctx) == ExecPathUnplanned {
+		return ctx
+	}
+	cache := newSubexprCache(call)
+	if cache == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKeySubexprCache, cache)
+}
+
+// subexprCacheFromContext returns the cache of the call executing in ctx,
+// if it has one.
+func subexprCacheFromContext(ctx context.Context) *subexprCache {
+	cache, _ := ctx.Value(contextKeySubexprCache).(*subexprCache)
+	return cache
+}
+
+// precallKey returns the key under which the result of a global pre-call
+// of c in index can be reused, or "" if it can't be.
+func precallKey(ctx context.Context, index string, c *pql.Call) string {
+	if execPathFromContext(ctx) == ExecPathUnplanned || hasUDF(c) {
+		return ""
+	}
+	return index + "\x00" + c.String()
+}
+
+// hasUDF reports whether c or any call within it is a UDF.
+func hasUDF(c *pql.Call) bool {
+	if c.Name == "UDF" {
+		return true
+	}
+	for _, child := range c.Children {
+		if hasUDF(child) {
+			return true
+		}
+	}
+	for _, arg := range c.Args {
+		if call, ok := arg.(*pql.Call); ok && hasUDF(call) {
+			return true
+		}
+	}
+	return false
+}
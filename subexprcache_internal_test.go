@@ -0,0 +1,60 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"testing"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+func TestSubexprCache(t *testing.T) {
+	q, err := pql.ParseString(`Intersect(Union(Row(a=1), Row(b=2)), Not(Union(Row(a=1), Row(b=2))), Row(c=3))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := q.Calls[0]
+	cache := newSubexprCache(call)
+	if cache == nil {
+		t.Fatal("expected a cache for repeated subtrees")
+	}
+	first, second := call.Children[0], call.Children[1].Children[0]
+	// Each Union, and the Rows within them, are repeated.
+	if len(cache.keys) != 6 {
+		t.Fatalf("expected 6 repeated calls, got %d", len(cache.keys))
+	} else if cache.keys[first] != cache.keys[second] {
+		t.Fatalf("expected %s and %s to share a key", first, second)
+	} else if _, ok := cache.keys[call.Children[2]]; ok {
+		t.Fatalf("didn't expect %s to be cached", call.Children[2])
+	}
+
+	computed := 0
+	fn := func() (*Row, error) {
+		computed++
+		return NewRow(uint64(computed)), nil
+	}
+	for _, c := range []*pql.Call{first, second} {
+		if row, err := cache.row(c, 0, fn); err != nil {
+			t.Fatal(err)
+		} else if cols := row.Columns(); len(cols) != 1 || cols[0] != 1 {
+			t.Fatalf("expected the first row, got %v", cols)
+		}
+	}
+	if _, err := cache.row(second, 1, fn); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.row(call.Children[2], 0, fn); err != nil {
+		t.Fatal(err)
+	}
+	if computed != 3 {
+		t.Fatalf("expected 3 computations, got %d", computed)
+	}
+
+	q, err = pql.ParseString(`Union(Row(a=1), Row(a=2))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cache := newSubexprCache(q.Calls[0]); cache != nil {
+		t.Fatalf("expected no cache without repeated subtrees, got %v", cache.keys)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"math/big"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// Sums of int and decimal fields are accumulated in 128 bits within each
+// shard and combined exactly across shards, so they can't silently wrap
+// around as they could in an int64. A sum is returned to the precision the
+// Sum call asks for, 64 bits by default, in which case ValCount.Val (or
+// DecimalVal) holds it; with precision=128, as in Sum(field=f,
+// precision=128), sums which don't fit in 64 bits are returned in
+// ValCount.BigVal instead. A sum which doesn't fit in the precision asked
+// for is an error wrapping ErrSumOverflow. ValCount.Precision reports the
+// precision used.
+
+// Precisions of sums, in bits.
+const (
+	sumPrecisionDefault = 64
+	sumPrecisionWide    = 128
+)
+
+// sumPrecision returns the precision a Sum call asks for.
+func sumPrecision(c *pql.Call) (int64, error) {
+	precision, ok, err := c.IntArg("precision")
+	if err != nil {
+		return 0, errors.Wrap(err, "Sum(): precision")
+	} else if !ok {
+		return sumPrecisionDefault, nil
+	}
+	switch precision {
+	case sumPrecisionDefault, sumPrecisionWide:
+		return precision, nil
+	}
+	return 0, NewBadRequestError(errors.Errorf("Sum(): invalid precision %d, expected %d or %d", precision, sumPrecisionDefault, sumPrecisionWide))
+}
+
+// setSum sets the value of vc to sum, using Val if it fits and BigVal
+// otherwise.
+func (vc *ValCount) setSum(sum *big.Int) {
+	if sum.IsInt64() {
+		vc.Val, vc.BigVal = sum.Int64(), nil
+	} else {
+		vc.Val, vc.BigVal = 0, sum
+	}
+}
+
+// sum returns the value of vc, from Val or BigVal.
+func (vc *ValCount) sum() *big.Int {
+	if vc.BigVal != nil {
+		return new(big.Int).Set(vc.BigVal)
+	}
+	return big.NewInt(vc.Val)
+}
+
+// checkSumPrecision returns an error if vc's sum, of field, doesn't fit in
+// precision bits, and otherwise records the precision in vc.
+func (vc *ValCount) checkSumPrecision(field string, precision int64) error {
+	if vc.BigVal != nil && (precision < sumPrecisionWide || vc.BigVal.BitLen() >= sumPrecisionWide) {
+		return errors.Wrapf(ErrSumOverflow, "sum of %s doesn't fit in %d bits", field, precision)
+	}
+	vc.Precision = precision
+	return nil
+}
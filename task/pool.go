@@ -74,6 +74,18 @@ func (p *Pool) Shutdown() {
 	atomic.StoreInt32(&p.targetN, 0)
 }
 
+// Resize changes the number of goroutines the pool aims to keep active.
+// New workers are spawned immediately, while excess workers exit once
+// they finish their current step.
+func (p *Pool) Resize(targetN int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	atomic.StoreInt32(&p.targetN, int32(targetN))
+	for atomic.LoadInt32(&p.unblocked) < int32(targetN) {
+		p.addWorker()
+	}
+}
+
 // Stats reports on the pool's current state -- total live workers it
 // has, how many it thinks are unblocked, and what its target is.
 // These numbers are sampled individually, and there's no locking, so they
@@ -42,7 +42,22 @@ func (q TimeQuantum) Granularity() rune {
 
 // Valid returns true if q is a valid time quantum value.
 func (q TimeQuantum) Valid() bool {
-	switch q {
+	if q.hasCalendarUnits() {
+		// Week and quarter units go in order among the others, which
+		// mustn't be left out.
+		if q.baseUnits() == "" {
+			return false
+		}
+		units := calendarUnits
+		for _, unit := range q {
+			i := strings.IndexRune(units, unit)
+			if i < 0 {
+				return false
+			}
+			units = units[i+1:]
+		}
+	}
+	switch q.baseUnits() {
 	case "Y", "YM", "YMD", "YMDH",
 		"M", "MD", "MDH",
 		"D", "DH",
@@ -272,16 +287,26 @@ func nextDayGTE(t time.Time, end time.Time) bool {
 
 // parseTime parses a string or int64 into a time.Time value.
 func parseTime(t interface{}) (time.Time, error) {
+	return parseTimeIn(t, time.UTC)
+}
+
+// parseTimeIn parses a string or int64 into a time.Time value in UTC,
+// reading strings as times in loc.
+func parseTimeIn(t interface{}, loc *time.Location) (time.Time, error) {
 	var err error
 	var calcTime time.Time
 	switch v := t.(type) {
 	case string:
-		if calcTime, err = time.Parse(TimeFormat, v); err != nil {
+		if calcTime, err = time.ParseInLocation(TimeFormat, v, loc); err != nil {
 			// if the default parsing fails, check if user tried to
 			// supply partial time eg year and month
-			calcTime, err = parsePartialTime(v)
-			return calcTime, err
+			if calcTime, err = parsePartialTime(v); err != nil {
+				return calcTime, err
+			}
+			y, m, d := calcTime.Date()
+			calcTime = time.Date(y, m, d, calcTime.Hour(), calcTime.Minute(), 0, 0, loc)
 		}
+		calcTime = calcTime.UTC()
 	case int64:
 		calcTime = time.Unix(v, 0).UTC()
 	default:
@@ -0,0 +1,193 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"strings"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// ClearRow and Store can be limited to a time range of a time field, with
+// from and to arguments, as in ClearRow(f=1, from="2022-01-05T00:00",
+// to="2022-01-06T00:00"), so a row's bits for a day can be corrected
+// without clearing all of its history. The range must start and end on
+// boundaries of the field's finest time unit. The row is cleared in the
+// views of that unit for periods in the range. In the views of longer
+// periods overlapping it, and in the standard view, the row keeps only
+// those of the cleared bits which it still has at another time in their
+// periods. Store then sets the bits of its source row at the range's
+// start.
+
+// rowTimeRange returns the range given by the from and to arguments of a
+// ClearRow or Store call on field, if it has them.
+func rowTimeRange(c *pql.Call, field *Field) (from, to time.Time, ok bool, err error) {
+	fromArg, hasFrom := c.Args["from"]
+	toArg, hasTo := c.Args["to"]
+	if !hasFrom && !hasTo {
+		return from, to, false, nil
+	} else if !hasFrom || !hasTo {
+		return from, to, false, NewBadRequestError(errors.Errorf("%s() needs both from and to for a time range", c.Name))
+	}
+	if field.Type() != FieldTypeTime || field.TimeQuantum() == "" {
+		return from, to, false, NewBadRequestError(errors.Errorf("%s() with a time range requires a time field, but %s is a %s field", c.Name, field.Name(), field.Type()))
+	}
+
+	loc, err := callLocation(c, field)
+	if err != nil {
+		return from, to, false, err
+	}
+	if from, err = parseTimeIn(fromArg, loc); err != nil {
+		return from, to, false, NewBadRequestError(errors.Wrap(err, "parsing from time"))
+	}
+	if to, err = parseTimeIn(toArg, loc); err != nil {
+		return from, to, false, NewBadRequestError(errors.Wrap(err, "parsing to time"))
+	}
+	if !from.Before(to) {
+		return from, to, false, NewBadRequestError(errors.Errorf("%s() time range is empty, from %s isn't before to %s", c.Name, from.Format(TimeFormat), to.Format(TimeFormat)))
+	}
+	unit, fy := field.finestTimeUnit(), field.options.FiscalYearStart
+	if !periodStart(from, unit, fy).Equal(from) || !periodStart(to, unit, fy).Equal(to) {
+		return from, to, false, NewBadRequestError(errors.Errorf("%s() time range must start and end on a boundary of the field's finest time unit, %c", c.Name, unit))
+	}
+	return from, to, true, nil
+}
+
+// finestTimeUnit returns the shortest unit of the field's time quantum.
+func (f *Field) finestTimeUnit() rune {
+	q := f.TimeQuantum()
+	var unit rune
+	for _, u := range calendarUnits {
+		if strings.ContainsRune(string(q), u) {
+			unit = u
+		}
+	}
+	return unit
+}
+
+// clearRowTimeRange clears a row of a time field in a shard for the periods
+// from from until to, and returns whether it had any bits in them.
+func (e *executor) clearRowTimeRange(tx Tx, field *Field, shard, rowID uint64, from, to time.Time) (bool, error) {
+	finest, fy := field.finestTimeUnit(), field.options.FiscalYearStart
+	cleared := NewRow()
+	for t := from; t.Before(to); t = periodEnd(t, finest) {
+		name := viewByCalendarUnit(viewStandard, t, finest, fy)
+		frag := e.Holder.fragment(field.index, field.name, name, shard)
+		if frag == nil {
+			continue
+		}
+		row, err := frag.row(tx, rowID)
+		if err != nil {
+			return false, errors.Wrapf(err, "reading row %d on view %s", rowID, name)
+		}
+		if !row.Any() {
+			continue
+		}
+		cleared = cleared.Union(row)
+		if _, err := frag.clearRow(tx, rowID); err != nil {
+			return false, errors.Wrapf(err, "clearing row %d on view %s", rowID, name)
+		}
+	}
+	if !cleared.Any() {
+		return false, nil
+	}
+
+	// The views of longer periods overlapping the range keep the cleared
+	// bits which the row still has in the shortest periods overlapping
+	// theirs. Weeks and months don't nest, so that may keep some it
+	// doesn't have.
+	for _, unit := range string(field.TimeQuantum()) {
+		if unit == finest {
+			continue
+		}
+		for t := periodStart(from, unit, fy); t.Before(to); t = periodEnd(t, unit) {
+			end := periodEnd(t, unit)
+			var within []string
+			for s := periodStart(t, finest, fy); s.Before(end); s = periodEnd(s, finest) {
+				within = append(within, viewByCalendarUnit(viewStandard, s, finest, fy))
+			}
+			if err := e.removeClearedBits(tx, field, shard, rowID, viewByCalendarUnit(viewStandard, t, unit, fy), within, cleared); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if !field.options.NoStandardView {
+		var all []string
+		for _, v := range field.views() {
+			if strings.HasPrefix(v.name, viewStandard+"_") {
+				all = append(all, v.name)
+			}
+		}
+		if err := e.removeClearedBits(tx, field, shard, rowID, viewStandard, all, cleared); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// removeClearedBits removes the bits of cleared from a row of a field's
+// view in a shard, except for those the row has in any of the views within.
+func (e *executor) removeClearedBits(tx Tx, field *Field, shard, rowID uint64, view string, within []string, cleared *Row) error {
+	frag := e.Holder.fragment(field.index, field.name, view, shard)
+	if frag == nil {
+		return nil
+	}
+	remaining := NewRow()
+	for _, name := range within {
+		f := e.Holder.fragment(field.index, field.name, name, shard)
+		if f == nil {
+			continue
+		}
+		row, err := f.row(tx, rowID)
+		if err != nil {
+			return errors.Wrapf(err, "reading row %d on view %s", rowID, name)
+		}
+		remaining = remaining.Union(row)
+	}
+	row, err := frag.row(tx, rowID)
+	if err != nil {
+		return errors.Wrapf(err, "reading row %d on view %s", rowID, view)
+	}
+	removed := row.Intersect(cleared.Difference(remaining))
+	if !removed.Any() {
+		return nil
+	}
+	if _, err := frag.setRow(tx, row.Difference(removed), rowID); err != nil {
+		return errors.Wrapf(err, "storing row %d on view %s", rowID, view)
+	}
+	return nil
+}
+
+// setRowAtTime sets the bits of src in a row of a time field in a shard,
+// in the views for time t and the standard view.
+func (e *executor) setRowAtTime(tx Tx, field *Field, shard, rowID uint64, src *Row, t time.Time) (bool, error) {
+	if !src.Any() {
+		return false, nil
+	}
+	q, fy := field.TimeQuantum(), field.options.FiscalYearStart
+	names := append(viewsByTime(viewStandard, t, q), calendarViewsByTime(viewStandard, t, q, fy)...)
+	if !field.options.NoStandardView {
+		names = append(names, viewStandard)
+	}
+	for _, name := range names {
+		view, err := field.createViewIfNotExists(name)
+		if err != nil {
+			return false, errors.Wrapf(err, "creating view %s", name)
+		}
+		frag, err := view.CreateFragmentIfNotExists(shard)
+		if err != nil {
+			return false, errors.Wrapf(err, "creating fragment %d of view %s", shard, name)
+		}
+		row, err := frag.row(tx, rowID)
+		if err != nil {
+			return false, errors.Wrapf(err, "reading row %d on view %s", rowID, name)
+		}
+		if _, err := frag.setRow(tx, row.Union(src), rowID); err != nil {
+			return false, errors.Wrapf(err, "storing row %d on view %s", rowID, name)
+		}
+	}
+	return true, nil
+}
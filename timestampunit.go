@@ -0,0 +1,110 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// Row calls comparing timestamp fields accept times as timestamps, as
+// strings in RFC3339 or the formats of from and to, or as integers counting
+// a timeUnit since the Unix epoch, as in Row(ts > 1640995200000,
+// timeUnit="ms"). Integers are counts of the field's own unit if no
+// timeUnit is given. Values are converted to times before the query is
+// executed, so the field's unit and epoch apply to all of them alike.
+//
+// Options(..., timeUnit="ms") returns timestamps from the call it wraps as
+// integers counting that unit since the Unix epoch, rather than as times.
+
+// timestampCondition converts the values a Row call compares a timestamp
+// field with to times.
+func timestampCondition(f *Field, c *pql.Call, arg interface{}) (interface{}, error) {
+	unit, _, err := c.StringArg("timeUnit")
+	if err != nil {
+		return nil, errors.Wrap(err, "reading timeUnit")
+	}
+	if unit == "" {
+		unit = f.Options().TimeUnit
+	} else if !IsValidTimeUnit(unit) {
+		return nil, NewBadRequestError(errors.Errorf("invalid time unit %q", unit))
+	}
+
+	cond, ok := arg.(*pql.Condition)
+	if !ok {
+		return toTimestamp(arg, unit)
+	}
+	value, err := toTimestamp(cond.Value, unit)
+	if err != nil {
+		return nil, err
+	}
+	return &pql.Condition{Op: cond.Op, Value: value}, nil
+}
+
+// toTimestamp converts a value, or a list of them, to a time, reading
+// integers as counts of unit.
+func toTimestamp(v interface{}, unit string) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, time.Time:
+		return v, nil
+	case int64:
+		return ValToTimestamp(unit, v)
+	case uint64:
+		return ValToTimestamp(unit, int64(v))
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t.UTC(), nil
+		}
+		t, err := parseTime(v)
+		if err != nil {
+			return nil, NewBadRequestError(errors.Errorf("invalid timestamp %q", v))
+		}
+		return t, nil
+	case []interface{}:
+		ts := make([]interface{}, len(v))
+		for i := range v {
+			t, err := toTimestamp(v[i], unit)
+			if err != nil {
+				return nil, err
+			}
+			ts[i] = t
+		}
+		return ts, nil
+	default:
+		return nil, errors.Errorf("invalid timestamp value %v of %[1]T", v)
+	}
+}
+
+// timestampsToUnit returns a result with its timestamps converted to
+// integers counting unit since the Unix epoch.
+func timestampsToUnit(result interface{}, unit string) interface{} {
+	switch r := result.(type) {
+	case ValCount:
+		if !r.TimestampVal.IsZero() {
+			r.Val, r.TimestampVal = TimestampToVal(unit, r.TimestampVal), time.Time{}
+		}
+		return r
+	case *ValCount:
+		if r != nil {
+			v := timestampsToUnit(*r, unit).(ValCount)
+			return &v
+		}
+	case *GroupCounts:
+		for i := range r.Groups() {
+			if g := &r.groups[i]; !g.TimestampAgg.IsZero() {
+				g.Agg, g.TimestampAgg = TimestampToVal(unit, g.TimestampAgg), time.Time{}
+			}
+		}
+	case ExtractedTable:
+		for _, col := range r.Columns {
+			for i, v := range col.Rows {
+				if t, ok := v.(time.Time); ok {
+					col.Rows[i] = TimestampToVal(unit, t)
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"sync"
+	"time"
+	_ "time/tzdata" // so time zones load wherever the server runs
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+	"github.com/pkg/errors"
+)
+
+// Time views are named for UTC times. The from and to times of queries of
+// time fields (and of mutex fields' history), when they don't give a zone,
+// are read in the zone named by the call's tz argument, or else the field's
+// time zone, or else UTC, and converted to UTC to choose the views they
+// cover. So with a quantum including hours, Row(f=1, from='2022-03-13',
+// to='2022-03-14', tz="America/Chicago") covers the 23 hours of that
+// Chicago day. With coarser quantums, ranges are rounded to the UTC views.
+
+// OptFieldTimeZone is a functional option on FieldOptions used to set the
+// time zone in which a time field's queries read from and to times.
+func OptFieldTimeZone(name string) FieldOption {
+	return func(fo *FieldOptions) error {
+		if _, err := loadLocation(name); err != nil {
+			return err
+		}
+		fo.TimeZone = name
+		return nil
+	}
+}
+
+// locations caches loaded time zones by name.
+var locations sync.Map
+
+// loadLocation returns the named time zone, or UTC if name is empty.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrapf(err, "invalid time zone %q", name))
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// callLocation returns the time zone in which a call on f reads times.
+func callLocation(c *pql.Call, f *Field) (*time.Location, error) {
+	name, _, err := c.StringArg("tz")
+	if err != nil {
+		return nil, errors.Wrap(err, "reading tz")
+	}
+	if name == "" && f != nil {
+		name = f.options.TimeZone
+	}
+	return loadLocation(name)
+}
@@ -90,6 +90,26 @@ type TranslateStore interface { // TODO: refactor this interface; readonly shoul
 	Delete(records *roaring.Bitmap) (Commitor, error)
 }
 
+// TranslateStoreDropper is implemented by translation stores which aren't
+// kept in their index's or field's directory, such as those kept in an
+// external KV store, and so delete what's in them themselves, with Drop,
+// when their index or field is deleted.
+type TranslateStoreDropper interface {
+	Drop() error
+}
+
+// dropTranslateStores drops those of stores which drop themselves.
+func dropTranslateStores(stores ...TranslateStore) error {
+	for _, store := range stores {
+		if d, ok := store.(TranslateStoreDropper); ok {
+			if err := d.Drop(); err != nil {
+				return errors.Wrap(err, "dropping translation store")
+			}
+		}
+	}
+	return nil
+}
+
 // This implements ingest's key translator interface, which differs
 // slightly because we want to be able to do fast lookups on arbitrary
 // IDs which are not necessarily contiguous small values, so the []string
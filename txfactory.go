@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/featurebasedb/featurebase/v3/task"
 	"github.com/featurebasedb/featurebase/v3/testhook"
@@ -106,6 +107,10 @@ type Qcx struct {
 
 	// don't allow automatic reuse now. Must manually call Reset, or NewQcx().
 	done bool
+
+	// tracked is what's known about the Qcx while it's open, guarded by
+	// Txf.qcxs.mu.
+	tracked *trackedQcx
 }
 
 // Finish commits/rollsback all stored Tx. It no longer resets the
@@ -117,6 +122,7 @@ func (q *Qcx) Finish() (err error) {
 	if q.RequiredForAtomicWriteTx != nil {
 		if q.RequiredTxo.Write {
 			err = (*q.RequiredForAtomicWriteTx).Commit() // PanicOn here on 2nd. is this a double commit?
+			q.Txf.holder.noteWriteErr(err)
 		} else {
 			(*q.RequiredForAtomicWriteTx).Rollback()
 		}
@@ -126,6 +132,7 @@ func (q *Qcx) Finish() (err error) {
 	q.Grp = q.Txf.NewTxGroup()
 	if !q.done {
 		_ = testhook.Closed(q.Txf.holder.Auditor, q, nil)
+		q.Txf.qcxs.remove(q)
 	}
 	q.done = true
 
@@ -148,6 +155,7 @@ func (q *Qcx) Abort() {
 	q.Grp = q.Txf.NewTxGroup()
 	if !q.done {
 		_ = testhook.Closed(q.Txf.holder.Auditor, q, nil)
+		q.Txf.qcxs.remove(q)
 	}
 	q.done = true
 }
@@ -164,6 +172,9 @@ func (q *Qcx) unprotected_reset() {
 	q.RequiredForAtomicWriteTx = nil
 	q.RequiredTxo = nil
 	q.Grp = q.Txf.NewTxGroup()
+	if q.done {
+		q.Txf.qcxs.add(q, 2)
+	}
 	q.done = false
 }
 
@@ -182,6 +193,7 @@ func (f *TxFactory) NewQcx() (qcx *Qcx) {
 		qcx.isRoaring = true
 	}
 	_ = testhook.Opened(f.holder.Auditor, qcx, nil)
+	f.qcxs.add(qcx, 1)
 	return
 }
 
@@ -200,6 +212,7 @@ func (f *TxFactory) NewWritableQcx() (qcx *Qcx) {
 	}
 	_ = testhook.Opened(f.holder.Auditor, qcx, nil)
 	qcx.write = true
+	f.qcxs.add(qcx, 1)
 	return
 }
 
@@ -322,7 +335,9 @@ func (qcx *Qcx) GetTx(o Txo) (tx Tx, finisher func(perr *error), err error) {
 			// so defer finisher(nil) means always Commit writes, ignoring
 			// the enclosing functions return status.
 			if perr == nil || *perr == nil {
-				vprint.PanicOn(tx.Commit())
+				err := tx.Commit()
+				qcx.Txf.holder.noteWriteErr(err)
+				vprint.PanicOn(err)
 			} else {
 				tx.Rollback()
 			}
@@ -389,6 +404,9 @@ type TxFactory struct {
 	dbPerShard *DBPerShard
 
 	holder *Holder
+
+	// The Qcxs which are open.
+	qcxs qcxTracker
 }
 
 // integer types for fast switch{}
@@ -480,7 +498,16 @@ func (f *TxFactory) DeleteFieldFromStore(index, field, fieldPath string) (err er
 func (f *TxFactory) DeleteFragmentFromStore(
 	index, field, view string, shard uint64, frag *fragment,
 ) (err error) {
-	return f.dbPerShard.DeleteFragment(index, field, view, shard, frag)
+	if err := f.dbPerShard.DeleteFragment(index, field, view, shard, frag); err != nil {
+		return err
+	}
+	if f.holder == nil {
+		return nil
+	}
+	if idx := f.holder.Index(index); idx != nil {
+		idx.writeTimes.record(field, shard, time.Now())
+	}
+	return nil
 }
 
 // CloseIndex is a no-op. This seems to be in place for debugging purposes.
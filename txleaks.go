@@ -0,0 +1,177 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/pql"
+)
+
+// A Qcx which is never finished or aborted, or which belongs to a query
+// that never completes, holds its shards' transactions open, which stalls
+// writes and keeps storage from being reclaimed. Every open Qcx is tracked
+// along with the stack which opened it, the query it belongs to, if any,
+// and how many shard jobs the executor is running for that query. Any open
+// for longer than the holder's StaleTxAge are reported by /health. Only the
+// stack which opened a Qcx is kept; the stacks of the shard jobs running for
+// its query aren't captured, but can be found among the goroutines listed
+// by /debug/pprof/goroutine.
+
+// maxStaleCalls is how many of a query's calls are named for a stale
+// transaction.
+const maxStaleCalls = 10
+
+// StaleTransaction describes a Qcx which has been open for longer than the
+// stale transaction age, and may have leaked. Calls names the calls of
+// its query, Jobs is how many of the query's shard jobs are running, and
+// Stack is where it was opened, rather than where those jobs are.
+type StaleTransaction struct {
+	Index  string    `json:"index,omitempty"`
+	Calls  string    `json:"calls,omitempty"`
+	Write  bool      `json:"write"`
+	Opened time.Time `json:"opened"`
+	Age    string    `json:"age"`
+	Jobs   int64     `json:"jobs"`
+	Stack  string    `json:"stack"`
+}
+
+// trackedQcx is what's known about an open Qcx.
+type trackedQcx struct {
+	opened time.Time
+	write  bool
+	stack  []uintptr
+
+	// Set by describe, for a Qcx executing a query.
+	index string
+	calls string
+
+	jobs int64 // atomic
+}
+
+// qcxTracker tracks the Qcxs opened by a TxFactory which are still open.
+type qcxTracker struct {
+	mu   sync.Mutex
+	open map[*Qcx]*trackedQcx
+}
+
+// add starts tracking q, skipping skip frames of the stack which opened it.
+func (t *qcxTracker) add(q *Qcx, skip int) {
+	var pcs [32]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	tq := &trackedQcx{
+		opened: time.Now(),
+		write:  q.write,
+		stack:  append([]uintptr(nil), pcs[:n]...),
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.open == nil {
+		t.open = make(map[*Qcx]*trackedQcx)
+	}
+	t.open[q] = tq
+	q.tracked = tq
+}
+
+// remove stops tracking q.
+func (t *qcxTracker) remove(q *Qcx) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.open, q)
+}
+
+// report returns how many Qcxs are open for each index, with those which
+// aren't executing a query counted under "", and those which have been
+// open for longer than age, oldest first. Nothing is stale if age isn't
+// positive.
+func (t *qcxTracker) report(age time.Duration) (map[string]int, []StaleTransaction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	counts := make(map[string]int)
+	var stale []StaleTransaction
+	now := time.Now()
+	for _, tq := range t.open {
+		counts[tq.index]++
+		if age <= 0 || now.Sub(tq.opened) <= age {
+			continue
+		}
+		st := StaleTransaction{
+			Index:  tq.index,
+			Calls:  tq.calls,
+			Write:  tq.write,
+			Opened: tq.opened.UTC(),
+			Age:    now.Sub(tq.opened).Round(time.Second).String(),
+			Jobs:   atomic.LoadInt64(&tq.jobs),
+			Stack:  formatStack(tq.stack),
+		}
+		stale = append(stale, st)
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].Opened.Before(stale[j].Opened) })
+	return counts, stale
+}
+
+// formatStack formats a stack like a goroutine's in a panic.
+func formatStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// describe records the query q is executing against index, for reporting
+// if q is left open.
+func (q *Qcx) describe(index string, query *pql.Query) {
+	names := make([]string, 0, maxStaleCalls+1)
+	for i, c := range query.Calls {
+		if i == maxStaleCalls {
+			names = append(names, "...")
+			break
+		}
+		names = append(names, c.Name)
+	}
+	calls := strings.Join(names, ", ")
+
+	q.Txf.qcxs.mu.Lock()
+	defer q.Txf.qcxs.mu.Unlock()
+	if q.tracked != nil {
+		q.tracked.index, q.tracked.calls = index, calls
+	}
+}
+
+type trackedQcxKey struct{}
+
+// withTrackedQcx returns a context in which the executor's shard jobs are
+// counted against q.
+func withTrackedQcx(ctx context.Context, q *Qcx) context.Context {
+	q.Txf.qcxs.mu.Lock()
+	tq := q.tracked
+	q.Txf.qcxs.mu.Unlock()
+	if tq == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, trackedQcxKey{}, tq)
+}
+
+// startJob counts a shard job as running for the Qcx tracked in ctx, if
+// there is one, returning a function to call once the job has finished.
+func startJob(ctx context.Context) func() {
+	tq, _ := ctx.Value(trackedQcxKey{}).(*trackedQcx)
+	if tq == nil {
+		return func() {}
+	}
+	atomic.AddInt64(&tq.jobs, 1)
+	return func() { atomic.AddInt64(&tq.jobs, -1) }
+}
@@ -0,0 +1,282 @@
+// Copyright 2022 Molecula Corp. (DBA FeatureBase).
+// SPDX-License-Identifier: Apache-2.0
+package pilosa
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/featurebasedb/featurebase/v3/rbf"
+	"github.com/featurebasedb/featurebase/v3/tracing"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// UsageReport breaks down the storage used by the cluster by index, field,
+// and view. Bytes are the size of the fragments' storage, summed over all
+// replicas, plus the size of the key translation stores; ResidentBytes are
+// how many of the fragments' bytes are in memory. OtherBytes are the rest
+// of the bytes in the nodes' data directories, such as write-ahead logs and
+// free pages, which don't belong to any one field.
+//
+// The deltas are the change in bytes since a prior report passed to
+// UsageReport, and are left out when there isn't one.
+type UsageReport struct {
+	Time          time.Time    `json:"time"`
+	Since         *time.Time   `json:"since,omitempty"`
+	Bytes         int64        `json:"bytes"`
+	ResidentBytes int64        `json:"residentBytes"`
+	OtherBytes    int64        `json:"otherBytes"`
+	BytesDelta    *int64       `json:"bytesDelta,omitempty"`
+	Indexes       []IndexUsage `json:"indexes"`
+}
+
+// IndexUsage is the storage used by an index. KeyBytes are the size of its
+// column key translation stores, and are included in Bytes.
+type IndexUsage struct {
+	Index         string       `json:"index"`
+	Bytes         int64        `json:"bytes"`
+	ResidentBytes int64        `json:"residentBytes"`
+	KeyBytes      int64        `json:"keyBytes"`
+	BytesDelta    *int64       `json:"bytesDelta,omitempty"`
+	Fields        []FieldUsage `json:"fields"`
+}
+
+// FieldUsage is the storage used by a field. KeyBytes are the size of its
+// row key translation store, and are included in Bytes.
+type FieldUsage struct {
+	Field         string      `json:"field"`
+	Bytes         int64       `json:"bytes"`
+	ResidentBytes int64       `json:"residentBytes"`
+	KeyBytes      int64       `json:"keyBytes"`
+	BytesDelta    *int64      `json:"bytesDelta,omitempty"`
+	Views         []ViewUsage `json:"views"`
+}
+
+// ViewUsage is the storage used by the fragments of a view.
+type ViewUsage struct {
+	View          string `json:"view"`
+	Bytes         int64  `json:"bytes"`
+	ResidentBytes int64  `json:"residentBytes"`
+	BytesDelta    *int64 `json:"bytesDelta,omitempty"`
+}
+
+// UsageReport reports the storage used by every index, field, and view
+// across the cluster. If prior is a report returned earlier, the new
+// report has the changes in bytes since then as well.
+func (api *API) UsageReport(ctx context.Context, prior *UsageReport) (*UsageReport, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.UsageReport")
+	defer span.Finish()
+
+	if err := api.validate(apiUsageReport); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	snap := api.cluster.NewSnapshot()
+	eg, _ := errgroup.WithContext(ctx)
+	myID := api.NodeID()
+	results := make([]*UsageReport, len(snap.Nodes))
+	for i, node := range snap.Nodes {
+		i, node := i, node
+		if node.ID != myID {
+			eg.Go(func() (err error) {
+				results[i], err = api.server.defaultClient.UsageReport(ctx, &node.URI)
+				return errors.Wrapf(err, "getting usage of node %s", node.ID)
+			})
+		} else {
+			eg.Go(func() (err error) {
+				results[i], err = api.usageThisNode()
+				return err
+			})
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	report := &UsageReport{Time: time.Now().UTC(), Indexes: []IndexUsage{}}
+	for _, r := range results {
+		report.add(r)
+	}
+	if prior != nil {
+		report.setDeltas(prior)
+	}
+	return report, nil
+}
+
+// UsageReportNode reports the storage used by every index, field, and view
+// on this node.
+func (api *API) UsageReportNode(ctx context.Context) (*UsageReport, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.UsageReportNode")
+	defer span.Finish()
+
+	if err := api.validate(apiUsageReport); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.usageThisNode()
+}
+
+func (api *API) usageThisNode() (*UsageReport, error) {
+	report := &UsageReport{Time: time.Now().UTC(), Indexes: []IndexUsage{}}
+	for _, idx := range api.holder.Indexes() {
+		iu := IndexUsage{Index: idx.Name(), Fields: []FieldUsage{}}
+		keyBytes, err := pathBytes(filepath.Join(idx.path, translateStoreDir))
+		if err != nil {
+			return nil, errors.Wrapf(err, "sizing keys of index %s", idx.Name())
+		}
+		iu.KeyBytes, iu.Bytes = keyBytes, keyBytes
+
+		for _, f := range idx.Fields() {
+			fu := FieldUsage{Field: f.Name(), Views: []ViewUsage{}}
+			if fu.KeyBytes, err = pathBytes(f.TranslateStorePath()); err != nil {
+				return nil, errors.Wrapf(err, "sizing keys of field %s", f.Name())
+			}
+			fu.Bytes = fu.KeyBytes
+
+			for _, v := range f.views() {
+				vu := ViewUsage{View: v.name}
+				for _, frag := range v.fragmentList() {
+					r, err := api.holder.adviseFragment(idx, f.Name(), v.name, frag.shard, rbf.AdviceNormal)
+					if err != nil {
+						return nil, errors.Wrapf(err, "sizing field %s view %s shard %d", f.Name(), v.name, frag.shard)
+					}
+					vu.Bytes += r.Bytes
+					vu.ResidentBytes += r.ResidentBytes
+				}
+				fu.Bytes += vu.Bytes
+				fu.ResidentBytes += vu.ResidentBytes
+				fu.Views = append(fu.Views, vu)
+			}
+			iu.Bytes += fu.Bytes
+			iu.ResidentBytes += fu.ResidentBytes
+			iu.Fields = append(iu.Fields, fu)
+		}
+		report.Bytes += iu.Bytes
+		report.ResidentBytes += iu.ResidentBytes
+		report.Indexes = append(report.Indexes, iu)
+	}
+
+	total, err := GetDiskUsage(api.holder.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "sizing data directory")
+	}
+	if total.Usage > report.Bytes {
+		report.OtherBytes = total.Usage - report.Bytes
+	}
+	report.sort()
+	return report, nil
+}
+
+// pathBytes returns the size of the file or directory at path, or zero if
+// there's nothing there.
+func pathBytes(path string) (int64, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return 0, nil
+	}
+	u, err := GetDiskUsage(path)
+	return u.Usage, err
+}
+
+// add adds the usage in o to r. The indexes, fields, and views of both
+// must be sorted by name, and stay sorted.
+func (r *UsageReport) add(o *UsageReport) {
+	r.Bytes += o.Bytes
+	r.ResidentBytes += o.ResidentBytes
+	r.OtherBytes += o.OtherBytes
+
+	for _, oi := range o.Indexes {
+		i := sort.Search(len(r.Indexes), func(i int) bool { return r.Indexes[i].Index >= oi.Index })
+		if i == len(r.Indexes) || r.Indexes[i].Index != oi.Index {
+			r.Indexes = append(r.Indexes, IndexUsage{})
+			copy(r.Indexes[i+1:], r.Indexes[i:])
+			r.Indexes[i] = IndexUsage{Index: oi.Index, Fields: []FieldUsage{}}
+		}
+		r.Indexes[i].add(oi)
+	}
+}
+
+func (u *IndexUsage) add(o IndexUsage) {
+	u.Bytes += o.Bytes
+	u.ResidentBytes += o.ResidentBytes
+	u.KeyBytes += o.KeyBytes
+	for _, of := range o.Fields {
+		i := sort.Search(len(u.Fields), func(i int) bool { return u.Fields[i].Field >= of.Field })
+		if i == len(u.Fields) || u.Fields[i].Field != of.Field {
+			u.Fields = append(u.Fields, FieldUsage{})
+			copy(u.Fields[i+1:], u.Fields[i:])
+			u.Fields[i] = FieldUsage{Field: of.Field, Views: []ViewUsage{}}
+		}
+		u.Fields[i].add(of)
+	}
+}
+
+func (u *FieldUsage) add(o FieldUsage) {
+	u.Bytes += o.Bytes
+	u.ResidentBytes += o.ResidentBytes
+	u.KeyBytes += o.KeyBytes
+	for _, ov := range o.Views {
+		i := sort.Search(len(u.Views), func(i int) bool { return u.Views[i].View >= ov.View })
+		if i == len(u.Views) || u.Views[i].View != ov.View {
+			u.Views = append(u.Views, ViewUsage{})
+			copy(u.Views[i+1:], u.Views[i:])
+			u.Views[i] = ViewUsage{View: ov.View}
+		}
+		u.Views[i].Bytes += ov.Bytes
+		u.Views[i].ResidentBytes += ov.ResidentBytes
+	}
+}
+
+// sort sorts the indexes, fields, and views of r by name.
+func (r *UsageReport) sort() {
+	sort.Slice(r.Indexes, func(i, j int) bool { return r.Indexes[i].Index < r.Indexes[j].Index })
+	for _, iu := range r.Indexes {
+		sort.Slice(iu.Fields, func(i, j int) bool { return iu.Fields[i].Field < iu.Fields[j].Field })
+		for _, fu := range iu.Fields {
+			sort.Slice(fu.Views, func(i, j int) bool { return fu.Views[i].View < fu.Views[j].View })
+		}
+	}
+}
+
+// setDeltas sets the deltas of r to the changes in bytes since prior.
+// Anything missing from prior is counted from zero.
+func (r *UsageReport) setDeltas(prior *UsageReport) {
+	since := prior.Time
+	r.Since = &since
+	r.BytesDelta = usageDelta(r.Bytes, prior.Bytes)
+
+	priorIndexes := make(map[string]IndexUsage, len(prior.Indexes))
+	for _, pi := range prior.Indexes {
+		priorIndexes[pi.Index] = pi
+	}
+	for i := range r.Indexes {
+		iu := &r.Indexes[i]
+		pi := priorIndexes[iu.Index]
+		iu.BytesDelta = usageDelta(iu.Bytes, pi.Bytes)
+
+		priorFields := make(map[string]FieldUsage, len(pi.Fields))
+		for _, pf := range pi.Fields {
+			priorFields[pf.Field] = pf
+		}
+		for j := range iu.Fields {
+			fu := &iu.Fields[j]
+			pf := priorFields[fu.Field]
+			fu.BytesDelta = usageDelta(fu.Bytes, pf.Bytes)
+
+			priorViews := make(map[string]int64, len(pf.Views))
+			for _, pv := range pf.Views {
+				priorViews[pv.View] = pv.Bytes
+			}
+			for k := range fu.Views {
+				fu.Views[k].BytesDelta = usageDelta(fu.Views[k].Bytes, priorViews[fu.Views[k].View])
+			}
+		}
+	}
+}
+
+func usageDelta(now, then int64) *int64 {
+	d := now - then
+	return &d
+}
@@ -143,6 +143,10 @@ func (v *view) openWithShardSet(ss *shardSet) error {
 		frags = append(frags, frag)
 		v.fragments[frag.shard] = frag
 	}
+	// Lazily opened fragments are opened on first use instead.
+	if v.holder.cfg.LazyOpen {
+		frags = nil
+	}
 
 	nGoro := runtime.NumCPU()
 	if v.idx.holder.txf.TxType() != "roaring" {
@@ -268,7 +272,7 @@ fragLoop:
 func (v *view) flushCaches() {
 	// we don't have a lock/cache of the closing mutex here, because
 	// individual view objects never get reopened, just discarded and recreated.
-	for _, f := range v.allFragments() {
+	for _, f := range v.openFragments() {
 		select {
 		case <-v.closing:
 			return
@@ -293,15 +297,56 @@ func (v *view) availableShards() *roaring.Bitmap {
 	return v.knownShards
 }
 
-// Fragment returns a fragment in the view by shard.
+// Fragment returns a fragment in the view by shard, opening it if it hasn't
+// been yet. It returns nil if there's no such fragment, or if it couldn't
+// be opened, in which case the holder records it, so that queries of its
+// shard fail and the node reports itself unhealthy.
 func (v *view) Fragment(shard uint64) *fragment {
 	v.mu.RLock()
-	defer v.mu.RUnlock()
-	return v.fragments[shard]
+	frag := v.fragments[shard]
+	v.mu.RUnlock()
+	if frag == nil {
+		return nil
+	}
+	if err := frag.ensureOpen(); err != nil {
+		v.holder.noteUnopened(frag, err)
+		return nil
+	}
+	return frag
 }
 
-// allFragments returns a list of all fragments in the view.
+// allFragments returns a list of all fragments in the view, opening any
+// which haven't been yet. Fragments which couldn't be opened are left out,
+// and recorded with the holder as Fragment does.
 func (v *view) allFragments() []*fragment {
+	frags := v.fragmentList()
+	other := frags[:0]
+	for _, frag := range frags {
+		if err := frag.ensureOpen(); err != nil {
+			v.holder.noteUnopened(frag, err)
+			continue
+		}
+		other = append(other, frag)
+	}
+	return other
+}
+
+// openFragments returns a list of the fragments in the view which have
+// been opened, for upkeep which lazily opened fragments don't need yet.
+func (v *view) openFragments() []*fragment {
+	frags := v.fragmentList()
+	other := frags[:0]
+	for _, frag := range frags {
+		if frag.isOpen() {
+			other = append(other, frag)
+		}
+	}
+	return other
+}
+
+// fragmentList returns a list of all fragments in the view, whether or
+// not they've been opened.
+func (v *view) fragmentList() []*fragment {
 	v.mu.RLock()
 	defer v.mu.RUnlock()
 
@@ -312,9 +357,10 @@ func (v *view) allFragments() []*fragment {
 	return other
 }
 
-// recalculateCaches recalculates the cache on every fragment in the view.
+// recalculateCaches recalculates the cache on every open fragment in the
+// view. Lazily opened fragments calculate their caches when opened.
 func (v *view) recalculateCaches() {
-	for _, fragment := range v.allFragments() {
+	for _, fragment := range v.openFragments() {
 		fragment.RecalculateCache()
 	}
 }
@@ -343,6 +389,9 @@ func (v *view) CreateFragmentIfNotExists(shard uint64) (*fragment, error) {
 
 	// Find fragment in cache first.
 	if frag := v.fragments[shard]; frag != nil {
+		if err := frag.ensureOpen(); err != nil {
+			return nil, errors.Wrap(err, "opening fragment")
+		}
 		return frag, nil
 	}
 
@@ -397,7 +446,7 @@ func (v *view) newFragment(shard uint64) *fragment {
 	frag.CacheType = v.cacheType
 	frag.CacheSize = v.cacheSize
 	frag.stats = v.stats
-	if v.fieldType == FieldTypeMutex {
+	if v.fieldType == FieldTypeMutex && !isHistoryView(v.name) {
 		frag.mutexVector = newRowsVector(frag)
 	} else if v.fieldType == FieldTypeBool {
 		frag.mutexVector = newBoolVector(frag)
@@ -421,6 +470,10 @@ func (v *view) deleteFragment(shard uint64) error {
 	if err := idx.holder.txf.DeleteFragmentFromStore(f.index(), f.field(), f.view(), f.shard, f); err != nil {
 		return errors.Wrap(err, "DeleteFragment")
 	}
+	if err := os.Remove(f.checksumPath()); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing checksum")
+	}
+	v.holder.forgetCorrupt(f)
 	delete(v.fragments, shard)
 	v.removeKnownShard(shard)
 
@@ -580,7 +633,7 @@ func (v *view) rangeOp(qcx *Qcx, op pql.Token, bitDepth uint64, predicate int64)
 		}
 		defer finisher(&err0)
 
-		other, err := frag.rangeOp(tx, op, bitDepth, predicate)
+		other, err := frag.rangeOp(tx, nil, op, bitDepth, predicate)
 		if err != nil {
 			return nil, err
 		}